@@ -0,0 +1,40 @@
+// Copyright (c) Mainflux
+// SPDX-License-Identifier: Apache-2.0
+
+package api
+
+import (
+	"context"
+
+	"github.com/go-kit/kit/endpoint"
+	"github.com/mainflux/mainflux/audit"
+)
+
+func listEventsEndpoint(svc audit.Service) endpoint.Endpoint {
+	return func(ctx context.Context, request interface{}) (interface{}, error) {
+		req := request.(listEventsReq)
+
+		if err := req.validate(); err != nil {
+			return nil, err
+		}
+
+		page, err := svc.ListEvents(ctx, req.token, req.filter, req.offset, req.limit)
+		if err != nil {
+			return nil, err
+		}
+
+		res := eventsPageRes{
+			pageRes: pageRes{
+				Total:  page.Total,
+				Offset: page.Offset,
+				Limit:  page.Limit,
+			},
+			Events: []eventRes{},
+		}
+		for _, e := range page.Events {
+			res.Events = append(res.Events, toEventRes(e))
+		}
+
+		return res, nil
+	}
+}
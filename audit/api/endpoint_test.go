@@ -0,0 +1,201 @@
+// Copyright (c) Mainflux
+// SPDX-License-Identifier: Apache-2.0
+
+package api_test
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/mainflux/mainflux/audit"
+	"github.com/mainflux/mainflux/audit/api"
+	"github.com/mainflux/mainflux/audit/mocks"
+	"github.com/mainflux/mainflux/pkg/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const (
+	token      = "token"
+	adminToken = "admin-token"
+	wrongValue = "wrong-value"
+	email      = "user@example.com"
+	otherEmail = "other@example.com"
+	adminEmail = "admin@example.com"
+)
+
+type testRequest struct {
+	client *http.Client
+	method string
+	url    string
+	token  string
+}
+
+func (tr testRequest) make() (*http.Response, error) {
+	req, err := http.NewRequest(tr.method, tr.url, nil)
+	if err != nil {
+		return nil, err
+	}
+	if tr.token != "" {
+		req.Header.Set("Authorization", tr.token)
+	}
+	return tr.client.Do(req)
+}
+
+func newService() audit.Service {
+	users := map[string]string{
+		token:      email,
+		adminToken: adminEmail,
+	}
+	auth := mocks.NewAuthNServiceClient(users, adminEmail)
+
+	return audit.New(auth, mocks.NewEventRepository(), uuid.NewMock())
+}
+
+func newServer(svc audit.Service) *httptest.Server {
+	return httptest.NewServer(api.MakeHandler(svc))
+}
+
+func TestListEvents(t *testing.T) {
+	svc := newService()
+	ts := newServer(svc)
+	defer ts.Close()
+
+	start := time.Unix(time.Now().Unix(), 0).UTC()
+	events := []audit.Event{
+		{Service: "things", Operation: "create_things", Actor: email, Entity: "1"},
+		{Service: "things", Operation: "remove_thing", Actor: email, Entity: "1"},
+		{Service: "users", Operation: "update_user", Actor: email, Error: "malformed entity specification"},
+		{Service: "things", Operation: "create_things", Actor: otherEmail, Entity: "2"},
+	}
+	for i, e := range events {
+		e.Created = start.Add(time.Duration(i) * time.Second)
+		err := svc.Record(context.Background(), e)
+		require.Nil(t, err, fmt.Sprintf("unexpected error: %s", err))
+	}
+
+	cases := []struct {
+		desc   string
+		url    string
+		token  string
+		status int
+		size   int
+	}{
+		{
+			desc:   "list own events",
+			url:    fmt.Sprintf("%s/events", ts.URL),
+			token:  token,
+			status: http.StatusOK,
+			size:   3,
+		},
+		{
+			desc:   "list own events of service",
+			url:    fmt.Sprintf("%s/events?service=things", ts.URL),
+			token:  token,
+			status: http.StatusOK,
+			size:   2,
+		},
+		{
+			desc:   "list own events of entity",
+			url:    fmt.Sprintf("%s/events?entity=1&operation=remove_thing", ts.URL),
+			token:  token,
+			status: http.StatusOK,
+			size:   1,
+		},
+		{
+			desc:   "list own events in time range",
+			url:    fmt.Sprintf("%s/events?from=%d&to=%d", ts.URL, start.Unix()+1, start.Unix()+3),
+			token:  token,
+			status: http.StatusOK,
+			size:   2,
+		},
+		{
+			desc:   "list all events as admin",
+			url:    fmt.Sprintf("%s/events", ts.URL),
+			token:  adminToken,
+			status: http.StatusOK,
+			size:   len(events),
+		},
+		{
+			desc:   "list events of actor as admin",
+			url:    fmt.Sprintf("%s/events?actor=%s", ts.URL, otherEmail),
+			token:  adminToken,
+			status: http.StatusOK,
+			size:   1,
+		},
+		{
+			desc:   "list events with limit",
+			url:    fmt.Sprintf("%s/events?offset=1&limit=2", ts.URL),
+			token:  adminToken,
+			status: http.StatusOK,
+			size:   2,
+		},
+		{
+			desc:   "list events with invalid limit",
+			url:    fmt.Sprintf("%s/events?limit=0", ts.URL),
+			token:  token,
+			status: http.StatusBadRequest,
+		},
+		{
+			desc:   "list events with invalid offset",
+			url:    fmt.Sprintf("%s/events?offset=%s", ts.URL, wrongValue),
+			token:  token,
+			status: http.StatusBadRequest,
+		},
+		{
+			desc:   "list events with invalid time",
+			url:    fmt.Sprintf("%s/events?from=%s", ts.URL, wrongValue),
+			token:  token,
+			status: http.StatusBadRequest,
+		},
+		{
+			desc:   "list events with inverted time range",
+			url:    fmt.Sprintf("%s/events?from=%d&to=%d", ts.URL, start.Unix()+3, start.Unix()+1),
+			token:  token,
+			status: http.StatusBadRequest,
+		},
+		{
+			desc:   "list events with invalid token",
+			url:    fmt.Sprintf("%s/events", ts.URL),
+			token:  wrongValue,
+			status: http.StatusForbidden,
+		},
+		{
+			desc:   "list events without token",
+			url:    fmt.Sprintf("%s/events", ts.URL),
+			token:  "",
+			status: http.StatusForbidden,
+		},
+	}
+
+	for _, tc := range cases {
+		req := testRequest{
+			client: ts.Client(),
+			method: http.MethodGet,
+			url:    tc.url,
+			token:  tc.token,
+		}
+		res, err := req.make()
+		assert.Nil(t, err, fmt.Sprintf("%s: unexpected error %s", tc.desc, err))
+		assert.Equal(t, tc.status, res.StatusCode, fmt.Sprintf("%s: expected status code %d got %d", tc.desc, tc.status, res.StatusCode))
+		if tc.status != http.StatusOK {
+			continue
+		}
+
+		var page struct {
+			Total  uint64 `json:"total"`
+			Events []struct {
+				Service   string `json:"service"`
+				Operation string `json:"operation"`
+			} `json:"events"`
+		}
+		err = json.NewDecoder(res.Body).Decode(&page)
+		assert.Nil(t, err, fmt.Sprintf("%s: unexpected error %s", tc.desc, err))
+		assert.Equal(t, tc.size, len(page.Events), fmt.Sprintf("%s: expected %d events got %d", tc.desc, tc.size, len(page.Events)))
+	}
+}
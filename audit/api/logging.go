@@ -0,0 +1,53 @@
+// Copyright (c) Mainflux
+// SPDX-License-Identifier: Apache-2.0
+
+// +build !test
+
+package api
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/mainflux/mainflux/audit"
+	log "github.com/mainflux/mainflux/logger"
+)
+
+var _ audit.Service = (*loggingMiddleware)(nil)
+
+type loggingMiddleware struct {
+	logger log.Logger
+	svc    audit.Service
+}
+
+// LoggingMiddleware adds logging facilities to the core service.
+func LoggingMiddleware(svc audit.Service, logger log.Logger) audit.Service {
+	return &loggingMiddleware{logger, svc}
+}
+
+func (lm *loggingMiddleware) Record(ctx context.Context, e audit.Event) (err error) {
+	defer func(begin time.Time) {
+		message := fmt.Sprintf("Method record for service %s and operation %s took %s to complete", e.Service, e.Operation, time.Since(begin))
+		if err != nil {
+			lm.logger.Warn(fmt.Sprintf("%s with error: %s.", message, err))
+			return
+		}
+		lm.logger.Info(fmt.Sprintf("%s without errors.", message))
+	}(time.Now())
+
+	return lm.svc.Record(ctx, e)
+}
+
+func (lm *loggingMiddleware) ListEvents(ctx context.Context, token string, filter audit.EventFilter, offset, limit uint64) (page audit.EventsPage, err error) {
+	defer func(begin time.Time) {
+		message := fmt.Sprintf("Method list_events for token %s took %s to complete", token, time.Since(begin))
+		if err != nil {
+			lm.logger.Warn(fmt.Sprintf("%s with error: %s.", message, err))
+			return
+		}
+		lm.logger.Info(fmt.Sprintf("%s without errors.", message))
+	}(time.Now())
+
+	return lm.svc.ListEvents(ctx, token, filter, offset, limit)
+}
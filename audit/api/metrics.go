@@ -0,0 +1,50 @@
+// Copyright (c) Mainflux
+// SPDX-License-Identifier: Apache-2.0
+
+// +build !test
+
+package api
+
+import (
+	"context"
+	"time"
+
+	"github.com/go-kit/kit/metrics"
+	"github.com/mainflux/mainflux/audit"
+)
+
+var _ audit.Service = (*metricsMiddleware)(nil)
+
+type metricsMiddleware struct {
+	counter metrics.Counter
+	latency metrics.Histogram
+	svc     audit.Service
+}
+
+// MetricsMiddleware instruments core service by tracking request count and
+// latency.
+func MetricsMiddleware(svc audit.Service, counter metrics.Counter, latency metrics.Histogram) audit.Service {
+	return &metricsMiddleware{
+		counter: counter,
+		latency: latency,
+		svc:     svc,
+	}
+}
+
+func (ms *metricsMiddleware) Record(ctx context.Context, e audit.Event) error {
+	defer func(begin time.Time) {
+		ms.counter.With("method", "record").Add(1)
+		ms.latency.With("method", "record").Observe(time.Since(begin).Seconds())
+	}(time.Now())
+
+	return ms.svc.Record(ctx, e)
+}
+
+func (ms *metricsMiddleware) ListEvents(ctx context.Context, token string, filter audit.EventFilter, offset, limit uint64) (audit.EventsPage, error) {
+	defer func(begin time.Time) {
+		ms.counter.With("method", "list_events").Add(1)
+		ms.latency.With("method", "list_events").Observe(time.Since(begin).Seconds())
+	}(time.Now())
+
+	return ms.svc.ListEvents(ctx, token, filter, offset, limit)
+}
@@ -0,0 +1,35 @@
+// Copyright (c) Mainflux
+// SPDX-License-Identifier: Apache-2.0
+
+package api
+
+import (
+	"github.com/mainflux/mainflux/audit"
+)
+
+type apiReq interface {
+	validate() error
+}
+
+type listEventsReq struct {
+	token  string
+	filter audit.EventFilter
+	offset uint64
+	limit  uint64
+}
+
+func (req listEventsReq) validate() error {
+	if req.token == "" {
+		return audit.ErrUnauthorizedAccess
+	}
+
+	if req.limit == 0 || req.limit > maxLimit {
+		return audit.ErrMalformedEntity
+	}
+
+	if !req.filter.From.IsZero() && !req.filter.To.IsZero() && !req.filter.From.Before(req.filter.To) {
+		return audit.ErrMalformedEntity
+	}
+
+	return nil
+}
@@ -0,0 +1,69 @@
+// Copyright (c) Mainflux
+// SPDX-License-Identifier: Apache-2.0
+
+package api
+
+import (
+	"net/http"
+	"time"
+
+	"github.com/mainflux/mainflux"
+	"github.com/mainflux/mainflux/audit"
+)
+
+var _ mainflux.Response = (*eventsPageRes)(nil)
+
+type pageRes struct {
+	Total  uint64 `json:"total"`
+	Offset uint64 `json:"offset"`
+	Limit  uint64 `json:"limit"`
+}
+
+type eventRes struct {
+	ID           string    `json:"id"`
+	Service      string    `json:"service"`
+	Operation    string    `json:"operation"`
+	Actor        string    `json:"actor,omitempty"`
+	Impersonator string    `json:"impersonator,omitempty"`
+	KeyID        string    `json:"key_id,omitempty"`
+	IP           string    `json:"ip,omitempty"`
+	Entity       string    `json:"entity,omitempty"`
+	Error        string    `json:"error,omitempty"`
+	Created      time.Time `json:"created"`
+}
+
+type eventsPageRes struct {
+	pageRes
+	Events []eventRes `json:"events"`
+}
+
+func (res eventsPageRes) Code() int {
+	return http.StatusOK
+}
+
+func (res eventsPageRes) Headers() map[string]string {
+	return map[string]string{}
+}
+
+func (res eventsPageRes) Empty() bool {
+	return false
+}
+
+type errorRes struct {
+	Err string `json:"error"`
+}
+
+func toEventRes(e audit.Event) eventRes {
+	return eventRes{
+		ID:           e.ID,
+		Service:      e.Service,
+		Operation:    e.Operation,
+		Actor:        e.Actor,
+		Impersonator: e.Impersonator,
+		KeyID:        e.KeyID,
+		IP:           e.IP,
+		Entity:       e.Entity,
+		Error:        e.Error,
+		Created:      e.Created,
+	}
+}
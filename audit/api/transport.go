@@ -0,0 +1,180 @@
+// Copyright (c) Mainflux
+// SPDX-License-Identifier: Apache-2.0
+
+package api
+
+import (
+	"context"
+	"encoding/json"
+	"math"
+	"net/http"
+	"strconv"
+	"time"
+
+	kithttp "github.com/go-kit/kit/transport/http"
+	"github.com/go-zoo/bone"
+	"github.com/mainflux/mainflux"
+	"github.com/mainflux/mainflux/audit"
+	"github.com/mainflux/mainflux/pkg/errors"
+	"github.com/prometheus/client_golang/prometheus/promhttp"
+)
+
+const (
+	contentType  = "application/json"
+	offsetKey    = "offset"
+	limitKey     = "limit"
+	serviceKey   = "service"
+	operationKey = "operation"
+	actorKey     = "actor"
+	entityKey    = "entity"
+	fromKey      = "from"
+	toKey        = "to"
+	maxLimit     = 100
+	defaultLimit = 10
+)
+
+var errInvalidQueryParams = errors.New("invalid query params")
+
+// MakeHandler returns a HTTP handler for API endpoints.
+func MakeHandler(svc audit.Service) http.Handler {
+	opts := []kithttp.ServerOption{
+		kithttp.ServerErrorEncoder(encodeError),
+	}
+
+	r := bone.New()
+
+	r.Get("/events", kithttp.NewServer(
+		listEventsEndpoint(svc),
+		decodeListEventsRequest,
+		encodeResponse,
+		opts...,
+	))
+
+	r.GetFunc("/version", mainflux.Version("audit"))
+	r.Handle("/metrics", promhttp.Handler())
+
+	return r
+}
+
+func decodeListEventsRequest(_ context.Context, r *http.Request) (interface{}, error) {
+	offset, err := readUintQuery(r, offsetKey, 0)
+	if err != nil {
+		return nil, err
+	}
+
+	limit, err := readUintQuery(r, limitKey, defaultLimit)
+	if err != nil {
+		return nil, err
+	}
+
+	from, err := readTimeQuery(r, fromKey)
+	if err != nil {
+		return nil, err
+	}
+
+	to, err := readTimeQuery(r, toKey)
+	if err != nil {
+		return nil, err
+	}
+
+	q := r.URL.Query()
+	req := listEventsReq{
+		token: r.Header.Get("Authorization"),
+		filter: audit.EventFilter{
+			Service:   q.Get(serviceKey),
+			Operation: q.Get(operationKey),
+			Actor:     q.Get(actorKey),
+			Entity:    q.Get(entityKey),
+			From:      from,
+			To:        to,
+		},
+		offset: offset,
+		limit:  limit,
+	}
+
+	return req, nil
+}
+
+func readUintQuery(r *http.Request, key string, def uint64) (uint64, error) {
+	vals := bone.GetQuery(r, key)
+	if len(vals) > 1 {
+		return 0, errInvalidQueryParams
+	}
+
+	if len(vals) == 0 {
+		return def, nil
+	}
+
+	val, err := strconv.ParseUint(vals[0], 10, 64)
+	if err != nil {
+		return 0, errors.Wrap(errInvalidQueryParams, err)
+	}
+
+	return val, nil
+}
+
+// readTimeQuery reads the time given in seconds since the Unix epoch, the
+// same way the readers do. Missing time is returned as the zero time.
+func readTimeQuery(r *http.Request, key string) (time.Time, error) {
+	vals := bone.GetQuery(r, key)
+	if len(vals) > 1 {
+		return time.Time{}, errInvalidQueryParams
+	}
+
+	if len(vals) == 0 {
+		return time.Time{}, nil
+	}
+
+	val, err := strconv.ParseFloat(vals[0], 64)
+	if err != nil {
+		return time.Time{}, errors.Wrap(errInvalidQueryParams, err)
+	}
+	if val < 0 || math.IsInf(val, 0) || math.IsNaN(val) {
+		return time.Time{}, errInvalidQueryParams
+	}
+	sec, frac := math.Modf(val)
+
+	return time.Unix(int64(sec), int64(frac*1e9)).UTC(), nil
+}
+
+func encodeResponse(_ context.Context, w http.ResponseWriter, response interface{}) error {
+	w.Header().Set("Content-Type", contentType)
+
+	if ar, ok := response.(mainflux.Response); ok {
+		for k, v := range ar.Headers() {
+			w.Header().Set(k, v)
+		}
+
+		w.WriteHeader(ar.Code())
+
+		if ar.Empty() {
+			return nil
+		}
+	}
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+func encodeError(_ context.Context, err error, w http.ResponseWriter) {
+	switch errorVal := err.(type) {
+	case errors.Error:
+		w.Header().Set("Content-Type", contentType)
+		switch {
+		case errors.Contains(errorVal, errInvalidQueryParams):
+			w.WriteHeader(http.StatusBadRequest)
+		case errors.Contains(errorVal, audit.ErrMalformedEntity):
+			w.WriteHeader(http.StatusBadRequest)
+		case errors.Contains(errorVal, audit.ErrUnauthorizedAccess):
+			w.WriteHeader(http.StatusForbidden)
+		default:
+			w.WriteHeader(http.StatusInternalServerError)
+		}
+		if errorVal.Msg() != "" {
+			if err := json.NewEncoder(w).Encode(errorRes{Err: errorVal.Msg()}); err != nil {
+				w.WriteHeader(http.StatusInternalServerError)
+			}
+		}
+	default:
+		w.WriteHeader(http.StatusInternalServerError)
+	}
+}
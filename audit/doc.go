@@ -0,0 +1,9 @@
+// Copyright (c) Mainflux
+// SPDX-License-Identifier: Apache-2.0
+
+// Package audit contains the domain concept definitions needed to support
+// Mainflux audit service functionality. Audit event records a management
+// operation performed by the user on one of the services: who performed
+// it, when, from which address and using which key, and whether it
+// succeeded.
+package audit
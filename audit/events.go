@@ -0,0 +1,55 @@
+// Copyright (c) Mainflux
+// SPDX-License-Identifier: Apache-2.0
+
+package audit
+
+import (
+	"context"
+	"time"
+)
+
+// Event represents the management operation performed by the user.
+// Impersonator is set if the operation was performed by the admin on
+// behalf of the actor. Error is set if the operation failed.
+type Event struct {
+	ID           string
+	Service      string
+	Operation    string
+	Actor        string
+	Impersonator string
+	KeyID        string
+	IP           string
+	Entity       string
+	Error        string
+	Created      time.Time
+}
+
+// EventsPage contains page related metadata as well as a list of events
+// that belong to this page.
+type EventsPage struct {
+	Total  uint64
+	Offset uint64
+	Limit  uint64
+	Events []Event
+}
+
+// EventFilter is used to narrow down retrieved events. Empty fields are not
+// used for filtering.
+type EventFilter struct {
+	Service   string
+	Operation string
+	Actor     string
+	Entity    string
+	From      time.Time
+	To        time.Time
+}
+
+// EventRepository specifies an event persistence API.
+type EventRepository interface {
+	// Save persists the event.
+	Save(ctx context.Context, e Event) (string, error)
+
+	// RetrieveAll retrieves the subset of events matching the filter.
+	// Events are sorted from the newest to the oldest.
+	RetrieveAll(ctx context.Context, filter EventFilter, offset, limit uint64) (EventsPage, error)
+}
@@ -0,0 +1,76 @@
+// Copyright (c) Mainflux
+// SPDX-License-Identifier: Apache-2.0
+
+package mocks
+
+import (
+	"context"
+	"sort"
+	"sync"
+
+	"github.com/mainflux/mainflux/audit"
+)
+
+var _ audit.EventRepository = (*eventRepositoryMock)(nil)
+
+type eventRepositoryMock struct {
+	mu     sync.Mutex
+	events []audit.Event
+}
+
+// NewEventRepository creates in-memory event repository.
+func NewEventRepository() audit.EventRepository {
+	return &eventRepositoryMock{}
+}
+
+func (erm *eventRepositoryMock) Save(_ context.Context, e audit.Event) (string, error) {
+	erm.mu.Lock()
+	defer erm.mu.Unlock()
+
+	erm.events = append(erm.events, e)
+	return e.ID, nil
+}
+
+func (erm *eventRepositoryMock) RetrieveAll(_ context.Context, filter audit.EventFilter, offset, limit uint64) (audit.EventsPage, error) {
+	erm.mu.Lock()
+	defer erm.mu.Unlock()
+
+	items := []audit.Event{}
+	for _, e := range erm.events {
+		if matches(e, filter) {
+			items = append(items, e)
+		}
+	}
+	sort.SliceStable(items, func(i, j int) bool {
+		return items[i].Created.After(items[j].Created)
+	})
+
+	total := uint64(len(items))
+	return audit.EventsPage{
+		Total:  total,
+		Offset: offset,
+		Limit:  limit,
+		Events: items[min(offset, total):min(offset+limit, total)],
+	}, nil
+}
+
+func matches(e audit.Event, filter audit.EventFilter) bool {
+	switch {
+	case filter.Service != "" && e.Service != filter.Service,
+		filter.Operation != "" && e.Operation != filter.Operation,
+		filter.Actor != "" && e.Actor != filter.Actor,
+		filter.Entity != "" && e.Entity != filter.Entity,
+		!filter.From.IsZero() && e.Created.Before(filter.From),
+		!filter.To.IsZero() && !e.Created.Before(filter.To):
+		return false
+	}
+
+	return true
+}
+
+func min(a, b uint64) uint64 {
+	if a < b {
+		return a
+	}
+	return b
+}
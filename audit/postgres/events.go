@@ -0,0 +1,155 @@
+// Copyright (c) Mainflux
+// SPDX-License-Identifier: Apache-2.0
+
+package postgres
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/jmoiron/sqlx"
+	"github.com/mainflux/mainflux/audit"
+	"github.com/mainflux/mainflux/pkg/errors"
+)
+
+var (
+	errSaveEvent     = errors.New("failed to save event to database")
+	errRetrieveEvent = errors.New("failed to retrieve events from database")
+)
+
+var _ audit.EventRepository = (*eventRepository)(nil)
+
+type eventRepository struct {
+	db *sqlx.DB
+}
+
+// NewEventRepository instantiates a PostgreSQL implementation of event
+// repository.
+func NewEventRepository(db *sqlx.DB) audit.EventRepository {
+	return &eventRepository{db: db}
+}
+
+func (er eventRepository) Save(ctx context.Context, e audit.Event) (string, error) {
+	q := `INSERT INTO events (id, service, operation, actor, impersonator, key_id, ip, entity, error, created)
+		  VALUES (:id, :service, :operation, :actor, :impersonator, :key_id, :ip, :entity, :error, :created)`
+
+	if _, err := er.db.NamedExecContext(ctx, q, toDBEvent(e)); err != nil {
+		return "", errors.Wrap(errSaveEvent, err)
+	}
+
+	return e.ID, nil
+}
+
+func (er eventRepository) RetrieveAll(ctx context.Context, filter audit.EventFilter, offset, limit uint64) (audit.EventsPage, error) {
+	conds := []string{"TRUE"}
+	if filter.Service != "" {
+		conds = append(conds, "service = :service")
+	}
+	if filter.Operation != "" {
+		conds = append(conds, "operation = :operation")
+	}
+	if filter.Actor != "" {
+		conds = append(conds, "actor = :actor")
+	}
+	if filter.Entity != "" {
+		conds = append(conds, "entity = :entity")
+	}
+	if !filter.From.IsZero() {
+		conds = append(conds, "created >= :from")
+	}
+	if !filter.To.IsZero() {
+		conds = append(conds, "created < :to")
+	}
+	cond := strings.Join(conds, " AND ")
+
+	params := map[string]interface{}{
+		"service":   filter.Service,
+		"operation": filter.Operation,
+		"actor":     filter.Actor,
+		"entity":    filter.Entity,
+		"from":      filter.From,
+		"to":        filter.To,
+		"limit":     limit,
+		"offset":    offset,
+	}
+
+	q := fmt.Sprintf(`SELECT id, service, operation, actor, impersonator, key_id, ip, entity, error, created
+		  FROM events WHERE %s ORDER BY created DESC LIMIT :limit OFFSET :offset`, cond)
+	rows, err := er.db.NamedQueryContext(ctx, q, params)
+	if err != nil {
+		return audit.EventsPage{}, errors.Wrap(errRetrieveEvent, err)
+	}
+	defer rows.Close()
+
+	items := []audit.Event{}
+	for rows.Next() {
+		dbe := dbEvent{}
+		if err := rows.StructScan(&dbe); err != nil {
+			return audit.EventsPage{}, errors.Wrap(errRetrieveEvent, err)
+		}
+		items = append(items, toEvent(dbe))
+	}
+
+	stmt, err := er.db.PrepareNamedContext(ctx, fmt.Sprintf(`SELECT COUNT(*) FROM events WHERE %s`, cond))
+	if err != nil {
+		return audit.EventsPage{}, errors.Wrap(errRetrieveEvent, err)
+	}
+	defer stmt.Close()
+
+	var total uint64
+	if err := stmt.GetContext(ctx, &total, params); err != nil {
+		return audit.EventsPage{}, errors.Wrap(errRetrieveEvent, err)
+	}
+
+	return audit.EventsPage{
+		Total:  total,
+		Offset: offset,
+		Limit:  limit,
+		Events: items,
+	}, nil
+}
+
+type dbEvent struct {
+	ID           string    `db:"id"`
+	Service      string    `db:"service"`
+	Operation    string    `db:"operation"`
+	Actor        string    `db:"actor"`
+	Impersonator string    `db:"impersonator"`
+	KeyID        string    `db:"key_id"`
+	IP           string    `db:"ip"`
+	Entity       string    `db:"entity"`
+	Error        string    `db:"error"`
+	Created      time.Time `db:"created"`
+}
+
+func toDBEvent(e audit.Event) dbEvent {
+	return dbEvent{
+		ID:           e.ID,
+		Service:      e.Service,
+		Operation:    e.Operation,
+		Actor:        e.Actor,
+		Impersonator: e.Impersonator,
+		KeyID:        e.KeyID,
+		IP:           e.IP,
+		Entity:       e.Entity,
+		Error:        e.Error,
+		Created:      e.Created,
+	}
+}
+
+func toEvent(dbe dbEvent) audit.Event {
+	return audit.Event{
+		ID:           dbe.ID,
+		Service:      dbe.Service,
+		Operation:    dbe.Operation,
+		Actor:        dbe.Actor,
+		Impersonator: dbe.Impersonator,
+		KeyID:        dbe.KeyID,
+		IP:           dbe.IP,
+		Entity:       dbe.Entity,
+		Error:        dbe.Error,
+		Created:      dbe.Created,
+	}
+}
@@ -0,0 +1,128 @@
+// Copyright (c) Mainflux
+// SPDX-License-Identifier: Apache-2.0
+
+package postgres_test
+
+import (
+	"context"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/mainflux/mainflux/audit"
+	"github.com/mainflux/mainflux/audit/postgres"
+	uuidProvider "github.com/mainflux/mainflux/pkg/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestEventSaveRetrieveAll(t *testing.T) {
+	repo := postgres.NewEventRepository(db)
+
+	actors := []string{"user@example.com", "other@example.com"}
+	n := uint64(10)
+	start := time.Now().UTC().Truncate(time.Millisecond)
+	for i := uint64(0); i < n; i++ {
+		id, err := uuidProvider.New().ID()
+		require.Nil(t, err, fmt.Sprintf("got unexpected error: %s", err))
+
+		e := audit.Event{
+			ID:        id,
+			Service:   "things",
+			Operation: "create_things",
+			Actor:     actors[i%2],
+			KeyID:     "1",
+			IP:        "127.0.0.1",
+			Entity:    fmt.Sprintf("%d", i),
+			Created:   start.Add(time.Duration(i) * time.Second),
+		}
+		if i%5 == 0 {
+			e.Service = "users"
+			e.Operation = "update_user"
+			e.Error = "missing or invalid credentials provided"
+		}
+		_, err = repo.Save(context.Background(), e)
+		require.Nil(t, err, fmt.Sprintf("got unexpected error: %s", err))
+	}
+
+	cases := map[string]struct {
+		filter audit.EventFilter
+		offset uint64
+		limit  uint64
+		size   uint64
+		total  uint64
+	}{
+		"retrieve all events": {
+			offset: 0,
+			limit:  n,
+			size:   n,
+			total:  n,
+		},
+		"retrieve subset of events": {
+			offset: 8,
+			limit:  n,
+			size:   2,
+			total:  n,
+		},
+		"retrieve events of service": {
+			filter: audit.EventFilter{Service: "users"},
+			offset: 0,
+			limit:  n,
+			size:   2,
+			total:  2,
+		},
+		"retrieve events of operation": {
+			filter: audit.EventFilter{Operation: "create_things"},
+			offset: 0,
+			limit:  n,
+			size:   n - 2,
+			total:  n - 2,
+		},
+		"retrieve events of actor": {
+			filter: audit.EventFilter{Actor: actors[0]},
+			offset: 0,
+			limit:  n,
+			size:   n / 2,
+			total:  n / 2,
+		},
+		"retrieve events of entity": {
+			filter: audit.EventFilter{Entity: "3"},
+			offset: 0,
+			limit:  n,
+			size:   1,
+			total:  1,
+		},
+		"retrieve events in time range": {
+			filter: audit.EventFilter{From: start.Add(2 * time.Second), To: start.Add(5 * time.Second)},
+			offset: 0,
+			limit:  n,
+			size:   3,
+			total:  3,
+		},
+		"retrieve events of actor and service": {
+			filter: audit.EventFilter{Actor: actors[1], Service: "users"},
+			offset: 0,
+			limit:  n,
+			size:   1,
+			total:  1,
+		},
+		"retrieve events of non-existing actor": {
+			filter: audit.EventFilter{Actor: "wrong"},
+			offset: 0,
+			limit:  n,
+			size:   0,
+			total:  0,
+		},
+	}
+
+	for desc, tc := range cases {
+		page, err := repo.RetrieveAll(context.Background(), tc.filter, tc.offset, tc.limit)
+		require.Nil(t, err, fmt.Sprintf("%s: got unexpected error: %s", desc, err))
+		size := uint64(len(page.Events))
+		assert.Equal(t, tc.size, size, fmt.Sprintf("%s: expected size %d got %d\n", desc, tc.size, size))
+		assert.Equal(t, tc.total, page.Total, fmt.Sprintf("%s: expected total %d got %d\n", desc, tc.total, page.Total))
+		for i := 1; i < len(page.Events); i++ {
+			assert.False(t, page.Events[i].Created.After(page.Events[i-1].Created), fmt.Sprintf("%s: expected events sorted from newest", desc))
+		}
+	}
+}
@@ -0,0 +1,77 @@
+// Copyright (c) Mainflux
+// SPDX-License-Identifier: Apache-2.0
+
+package postgres
+
+import (
+	"fmt"
+
+	"github.com/jmoiron/sqlx"
+	_ "github.com/lib/pq" // required for SQL access
+	migrate "github.com/rubenv/sql-migrate"
+)
+
+// Config defines the options that are used when connecting to a PostgreSQL instance
+type Config struct {
+	Host        string
+	Port        string
+	User        string
+	Pass        string
+	Name        string
+	SSLMode     string
+	SSLCert     string
+	SSLKey      string
+	SSLRootCert string
+}
+
+// Connect creates a connection to the PostgreSQL instance and applies any
+// unapplied database migrations. A non-nil error is returned to indicate
+// failure.
+func Connect(cfg Config) (*sqlx.DB, error) {
+	url := fmt.Sprintf("host=%s port=%s user=%s dbname=%s password=%s sslmode=%s sslcert=%s sslkey=%s sslrootcert=%s", cfg.Host, cfg.Port, cfg.User, cfg.Name, cfg.Pass, cfg.SSLMode, cfg.SSLCert, cfg.SSLKey, cfg.SSLRootCert)
+
+	db, err := sqlx.Open("postgres", url)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := migrateDB(db); err != nil {
+		return nil, err
+	}
+
+	return db, nil
+}
+
+func migrateDB(db *sqlx.DB) error {
+	migrations := &migrate.MemoryMigrationSource{
+		Migrations: []*migrate.Migration{
+			{
+				Id: "audit_1",
+				Up: []string{
+					`CREATE TABLE IF NOT EXISTS events (
+						id           UUID,
+						service      VARCHAR(254) NOT NULL,
+						operation    VARCHAR(254) NOT NULL,
+						actor        VARCHAR(254),
+						impersonator VARCHAR(254),
+						key_id       VARCHAR(254),
+						ip           VARCHAR(254),
+						entity       TEXT,
+						error        TEXT,
+						created      TIMESTAMPTZ NOT NULL,
+						PRIMARY KEY (id)
+					)`,
+					`CREATE INDEX IF NOT EXISTS events_created_idx ON events (created DESC)`,
+					`CREATE INDEX IF NOT EXISTS events_actor_created_idx ON events (actor, created DESC)`,
+				},
+				Down: []string{
+					"DROP TABLE events",
+				},
+			},
+		},
+	}
+
+	_, err := migrate.Exec(db.DB, "postgres", migrations, migrate.Up)
+
+	return err
+}
@@ -0,0 +1,6 @@
+// Copyright (c) Mainflux
+// SPDX-License-Identifier: Apache-2.0
+
+// Package redis contains the consumer of the audit events published by the
+// other services to the Redis stream.
+package redis
@@ -0,0 +1,98 @@
+// Copyright (c) Mainflux
+// SPDX-License-Identifier: Apache-2.0
+
+package redis
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+	"time"
+
+	"github.com/go-redis/redis"
+	"github.com/mainflux/mainflux/audit"
+	"github.com/mainflux/mainflux/logger"
+	pkgaudit "github.com/mainflux/mainflux/pkg/audit"
+)
+
+const (
+	group  = "mainflux.audit"
+	exists = "BUSYGROUP Consumer Group name already exists"
+)
+
+// Subscriber represents the source of the audit events.
+type Subscriber interface {
+	// Subscribe receives the audit events and records them.
+	Subscribe() error
+}
+
+type eventStore struct {
+	svc      audit.Service
+	client   *redis.Client
+	consumer string
+	logger   logger.Logger
+}
+
+// NewEventStore returns new event store instance.
+func NewEventStore(svc audit.Service, client *redis.Client, consumer string, log logger.Logger) Subscriber {
+	return eventStore{
+		svc:      svc,
+		client:   client,
+		consumer: consumer,
+		logger:   log,
+	}
+}
+
+func (es eventStore) Subscribe() error {
+	err := es.client.XGroupCreateMkStream(pkgaudit.Stream, group, "$").Err()
+	if err != nil && err.Error() != exists {
+		return err
+	}
+
+	for {
+		streams, err := es.client.XReadGroup(&redis.XReadGroupArgs{
+			Group:    group,
+			Consumer: es.consumer,
+			Streams:  []string{pkgaudit.Stream, ">"},
+			Count:    100,
+		}).Result()
+		if err != nil || len(streams) == 0 {
+			continue
+		}
+
+		for _, msg := range streams[0].Messages {
+			if err := es.svc.Record(context.Background(), decode(msg.Values)); err != nil {
+				es.logger.Warn(fmt.Sprintf("Failed to record audit event: %s", err.Error()))
+				continue
+			}
+			es.client.XAck(pkgaudit.Stream, group, msg.ID)
+		}
+	}
+}
+
+func decode(event map[string]interface{}) audit.Event {
+	e := audit.Event{
+		Service:      read(event, "service", ""),
+		Operation:    read(event, "operation", ""),
+		Actor:        read(event, "actor", ""),
+		Impersonator: read(event, "impersonator", ""),
+		KeyID:        read(event, "key_id", ""),
+		IP:           read(event, "ip", ""),
+		Entity:       read(event, "entity", ""),
+		Error:        read(event, "error", ""),
+	}
+	if created, err := strconv.ParseInt(read(event, "created", ""), 10, 64); err == nil {
+		e.Created = time.Unix(0, created).UTC()
+	}
+
+	return e
+}
+
+func read(event map[string]interface{}, key, def string) string {
+	val, ok := event[key].(string)
+	if !ok {
+		return def
+	}
+
+	return val
+}
@@ -0,0 +1,80 @@
+// Copyright (c) Mainflux
+// SPDX-License-Identifier: Apache-2.0
+
+package audit
+
+import (
+	"context"
+	"time"
+
+	"github.com/mainflux/mainflux"
+	"github.com/mainflux/mainflux/pkg/errors"
+)
+
+var (
+	// ErrMalformedEntity indicates malformed entity specification.
+	ErrMalformedEntity = errors.New("malformed entity specification")
+
+	// ErrUnauthorizedAccess indicates missing or invalid credentials provided
+	// when accessing a protected resource.
+	ErrUnauthorizedAccess = errors.New("missing or invalid credentials provided")
+)
+
+var _ Service = (*auditService)(nil)
+
+// Service specifies an API that must be fulfilled by the domain service
+// implementation, and all of its decorators (e.g. logging & metrics).
+type Service interface {
+	// Record persists the event published by one of the services.
+	Record(ctx context.Context, e Event) error
+
+	// ListEvents retrieves a subset of events matching the filter. The
+	// platform admin retrieves the events of all the users, while the other
+	// users retrieve only the operations they performed.
+	ListEvents(ctx context.Context, token string, filter EventFilter, offset, limit uint64) (EventsPage, error)
+}
+
+type auditService struct {
+	auth   mainflux.AuthNServiceClient
+	events EventRepository
+	idp    mainflux.UUIDProvider
+}
+
+// New instantiates the audit service implementation.
+func New(auth mainflux.AuthNServiceClient, events EventRepository, idp mainflux.UUIDProvider) Service {
+	return &auditService{
+		auth:   auth,
+		events: events,
+		idp:    idp,
+	}
+}
+
+func (as *auditService) Record(ctx context.Context, e Event) error {
+	if e.Service == "" || e.Operation == "" {
+		return ErrMalformedEntity
+	}
+
+	id, err := as.idp.ID()
+	if err != nil {
+		return err
+	}
+	e.ID = id
+	if e.Created.IsZero() {
+		e.Created = time.Now().UTC()
+	}
+
+	_, err = as.events.Save(ctx, e)
+	return err
+}
+
+func (as *auditService) ListEvents(ctx context.Context, token string, filter EventFilter, offset, limit uint64) (EventsPage, error) {
+	res, err := as.auth.Identify(ctx, &mainflux.Token{Value: token})
+	if err != nil {
+		return EventsPage{}, errors.Wrap(ErrUnauthorizedAccess, err)
+	}
+	if !res.GetAdmin() {
+		filter.Actor = res.GetEmail()
+	}
+
+	return as.events.RetrieveAll(ctx, filter, offset, limit)
+}
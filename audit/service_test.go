@@ -0,0 +1,172 @@
+// Copyright (c) Mainflux
+// SPDX-License-Identifier: Apache-2.0
+
+package audit_test
+
+import (
+	"context"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/mainflux/mainflux/audit"
+	"github.com/mainflux/mainflux/audit/mocks"
+	"github.com/mainflux/mainflux/pkg/errors"
+	"github.com/mainflux/mainflux/pkg/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const (
+	token      = "token"
+	otherToken = "other-token"
+	adminToken = "admin-token"
+	wrongValue = "wrong-value"
+	email      = "user@example.com"
+	otherEmail = "other@example.com"
+	adminEmail = "admin@example.com"
+)
+
+func newService() audit.Service {
+	users := map[string]string{
+		token:      email,
+		otherToken: otherEmail,
+		adminToken: adminEmail,
+	}
+	auth := mocks.NewAuthNServiceClient(users, adminEmail)
+
+	return audit.New(auth, mocks.NewEventRepository(), uuid.NewMock())
+}
+
+func newEvent(actor, service, operation string) audit.Event {
+	return audit.Event{
+		Service:   service,
+		Operation: operation,
+		Actor:     actor,
+		KeyID:     "1",
+		IP:        "127.0.0.1",
+		Entity:    "1",
+	}
+}
+
+func TestRecord(t *testing.T) {
+	svc := newService()
+
+	cases := []struct {
+		desc  string
+		event audit.Event
+		err   error
+	}{
+		{
+			desc:  "record event",
+			event: newEvent(email, "things", "create_things"),
+			err:   nil,
+		},
+		{
+			desc:  "record event of failed operation",
+			event: audit.Event{Service: "things", Operation: "remove_thing", Actor: email, Error: "non-existent entity"},
+			err:   nil,
+		},
+		{
+			desc:  "record event without service",
+			event: newEvent(email, "", "create_things"),
+			err:   audit.ErrMalformedEntity,
+		},
+		{
+			desc:  "record event without operation",
+			event: newEvent(email, "things", ""),
+			err:   audit.ErrMalformedEntity,
+		},
+	}
+
+	for _, tc := range cases {
+		err := svc.Record(context.Background(), tc.event)
+		assert.True(t, errors.Contains(err, tc.err), fmt.Sprintf("%s: expected %s got %s\n", tc.desc, tc.err, err))
+	}
+}
+
+func TestListEvents(t *testing.T) {
+	svc := newService()
+
+	start := time.Now().UTC()
+	events := []audit.Event{
+		newEvent(email, "things", "create_things"),
+		newEvent(email, "things", "remove_thing"),
+		newEvent(email, "users", "update_user"),
+		newEvent(otherEmail, "things", "create_things"),
+	}
+	for i, e := range events {
+		e.Created = start.Add(time.Duration(i) * time.Second)
+		err := svc.Record(context.Background(), e)
+		require.Nil(t, err, fmt.Sprintf("unexpected error: %s", err))
+	}
+
+	cases := []struct {
+		desc   string
+		token  string
+		filter audit.EventFilter
+		size   uint64
+		err    error
+	}{
+		{
+			desc:  "list own events",
+			token: token,
+			size:  3,
+			err:   nil,
+		},
+		{
+			desc:   "list own events of service",
+			token:  token,
+			filter: audit.EventFilter{Service: "things"},
+			size:   2,
+			err:    nil,
+		},
+		{
+			desc:   "list own events of operation",
+			token:  token,
+			filter: audit.EventFilter{Operation: "create_things"},
+			size:   1,
+			err:    nil,
+		},
+		{
+			desc:   "list own events in time range",
+			token:  token,
+			filter: audit.EventFilter{From: start.Add(time.Second), To: start.Add(3 * time.Second)},
+			size:   2,
+			err:    nil,
+		},
+		{
+			desc:   "list events of another user",
+			token:  token,
+			filter: audit.EventFilter{Actor: otherEmail},
+			size:   3,
+			err:    nil,
+		},
+		{
+			desc:  "list all events as admin",
+			token: adminToken,
+			size:  uint64(len(events)),
+			err:   nil,
+		},
+		{
+			desc:   "list events of another user as admin",
+			token:  adminToken,
+			filter: audit.EventFilter{Actor: otherEmail},
+			size:   1,
+			err:    nil,
+		},
+		{
+			desc:  "list events with invalid token",
+			token: wrongValue,
+			size:  0,
+			err:   audit.ErrUnauthorizedAccess,
+		},
+	}
+
+	for _, tc := range cases {
+		page, err := svc.ListEvents(context.Background(), tc.token, tc.filter, 0, 10)
+		size := uint64(len(page.Events))
+		assert.Equal(t, tc.size, size, fmt.Sprintf("%s: expected size %d got %d\n", tc.desc, tc.size, size))
+		assert.True(t, errors.Contains(err, tc.err), fmt.Sprintf("%s: expected %s got %s\n", tc.desc, tc.err, err))
+	}
+}
@@ -0,0 +1,110 @@
+// Copyright (c) Mainflux
+// SPDX-License-Identifier: Apache-2.0
+
+package api
+
+import (
+	"context"
+
+	"github.com/mainflux/mainflux/authn"
+	"github.com/mainflux/mainflux/pkg/audit"
+)
+
+var _ authn.Service = (*auditMiddleware)(nil)
+
+type auditMiddleware struct {
+	rec audit.Recorder
+	svc authn.Service
+}
+
+// AuditMiddleware records the operations on the API keys. The operations on
+// the login and session keys are recorded by the users service, which
+// performs them on behalf of the user.
+func AuditMiddleware(svc authn.Service, rec audit.Recorder) authn.Service {
+	return &auditMiddleware{rec, svc}
+}
+
+// NewIdentifier returns the function identifying the users by the service
+// itself, since the service can't call itself over gRPC.
+func NewIdentifier(svc authn.Service) audit.IdentifyFunc {
+	return func(ctx context.Context, token string) (audit.Identity, error) {
+		id, err := svc.Identify(ctx, token)
+		if err != nil {
+			return audit.Identity{}, err
+		}
+
+		return audit.Identity{
+			Email:        id.Email,
+			Impersonator: id.Impersonator,
+			KeyID:        id.KeyID,
+		}, nil
+	}
+}
+
+func (am *auditMiddleware) Issue(ctx context.Context, token string, newKey authn.Key) (key authn.Key, secret string, err error) {
+	if newKey.Type != authn.APIKey {
+		return am.svc.Issue(ctx, token, newKey)
+	}
+
+	e := am.rec.Event(ctx, token, "issue", "")
+	defer func() {
+		e.Entity = key.ID
+		am.rec.Record(ctx, e, err)
+	}()
+
+	return am.svc.Issue(ctx, token, newKey)
+}
+
+func (am *auditMiddleware) Revoke(ctx context.Context, token, id string) (err error) {
+	e := am.rec.Event(ctx, token, "revoke", id)
+	defer func() { am.rec.Record(ctx, e, err) }()
+
+	return am.svc.Revoke(ctx, token, id)
+}
+
+func (am *auditMiddleware) Retrieve(ctx context.Context, token, id string) (authn.Key, error) {
+	return am.svc.Retrieve(ctx, token, id)
+}
+
+func (am *auditMiddleware) ListKeys(ctx context.Context, token string, pm authn.PageMetadata) (authn.KeyPage, error) {
+	return am.svc.ListKeys(ctx, token, pm)
+}
+
+func (am *auditMiddleware) RevokeAll(ctx context.Context, token, subject string) (err error) {
+	e := am.rec.Event(ctx, token, "revoke_all", subject)
+	defer func() { am.rec.Record(ctx, e, err) }()
+
+	return am.svc.RevokeAll(ctx, token, subject)
+}
+
+func (am *auditMiddleware) Identify(ctx context.Context, key string) (authn.Identity, error) {
+	return am.svc.Identify(ctx, key)
+}
+
+func (am *auditMiddleware) RevokeToken(ctx context.Context, token string) error {
+	return am.svc.RevokeToken(ctx, token)
+}
+
+func (am *auditMiddleware) RevokeSessions(ctx context.Context, token string) error {
+	return am.svc.RevokeSessions(ctx, token)
+}
+
+func (am *auditMiddleware) Refresh(ctx context.Context, token string) (string, string, error) {
+	return am.svc.Refresh(ctx, token)
+}
+
+func (am *auditMiddleware) VerifyChallenge(ctx context.Context, token string) (authn.Identity, error) {
+	return am.svc.VerifyChallenge(ctx, token)
+}
+
+func (am *auditMiddleware) PublicKeys(ctx context.Context) ([]authn.PublicKey, error) {
+	return am.svc.PublicKeys(ctx)
+}
+
+func (am *auditMiddleware) Impersonate(ctx context.Context, token, id, email string) (string, error) {
+	return am.svc.Impersonate(ctx, token, id, email)
+}
+
+func (am *auditMiddleware) SetDisabled(ctx context.Context, token, id string, disabled bool) error {
+	return am.svc.SetDisabled(ctx, token, id, disabled)
+}
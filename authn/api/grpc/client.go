@@ -10,6 +10,7 @@ import (
 	"github.com/go-kit/kit/endpoint"
 	kitot "github.com/go-kit/kit/tracing/opentracing"
 	kitgrpc "github.com/go-kit/kit/transport/grpc"
+	"github.com/golang/protobuf/ptypes/empty"
 	"github.com/mainflux/mainflux"
 	opentracing "github.com/opentracing/opentracing-go"
 	"google.golang.org/grpc"
@@ -18,9 +19,15 @@ import (
 var _ mainflux.AuthNServiceClient = (*grpcClient)(nil)
 
 type grpcClient struct {
-	issue    endpoint.Endpoint
-	identify endpoint.Endpoint
-	timeout  time.Duration
+	issue           endpoint.Endpoint
+	identify        endpoint.Endpoint
+	revokeToken     endpoint.Endpoint
+	revokeSessions  endpoint.Endpoint
+	refresh         endpoint.Endpoint
+	verifyChallenge endpoint.Endpoint
+	impersonate     endpoint.Endpoint
+	setDisabled     endpoint.Endpoint
+	timeout         time.Duration
 }
 
 // NewClient returns new gRPC client instance.
@@ -42,6 +49,54 @@ func NewClient(tracer opentracing.Tracer, conn *grpc.ClientConn, timeout time.Du
 			decodeIdentifyResponse,
 			mainflux.UserIdentity{},
 		).Endpoint()),
+		revokeToken: kitot.TraceClient(tracer, "revoke_token")(kitgrpc.NewClient(
+			conn,
+			"mainflux.AuthNService",
+			"RevokeToken",
+			encodeIdentifyRequest,
+			decodeEmptyResponse,
+			empty.Empty{},
+		).Endpoint()),
+		revokeSessions: kitot.TraceClient(tracer, "revoke_sessions")(kitgrpc.NewClient(
+			conn,
+			"mainflux.AuthNService",
+			"RevokeSessions",
+			encodeIdentifyRequest,
+			decodeEmptyResponse,
+			empty.Empty{},
+		).Endpoint()),
+		refresh: kitot.TraceClient(tracer, "refresh")(kitgrpc.NewClient(
+			conn,
+			"mainflux.AuthNService",
+			"Refresh",
+			encodeIdentifyRequest,
+			decodeTokensResponse,
+			mainflux.Tokens{},
+		).Endpoint()),
+		verifyChallenge: kitot.TraceClient(tracer, "verify_challenge")(kitgrpc.NewClient(
+			conn,
+			"mainflux.AuthNService",
+			"VerifyChallenge",
+			encodeIdentifyRequest,
+			decodeIdentifyResponse,
+			mainflux.UserIdentity{},
+		).Endpoint()),
+		impersonate: kitot.TraceClient(tracer, "impersonate")(kitgrpc.NewClient(
+			conn,
+			"mainflux.AuthNService",
+			"Impersonate",
+			encodeImpersonateRequest,
+			decodeTokenResponse,
+			mainflux.Token{},
+		).Endpoint()),
+		setDisabled: kitot.TraceClient(tracer, "set_disabled")(kitgrpc.NewClient(
+			conn,
+			"mainflux.AuthNService",
+			"SetDisabled",
+			encodeDisableRequest,
+			decodeEmptyResponse,
+			empty.Empty{},
+		).Endpoint()),
 		timeout: timeout,
 	}
 }
@@ -79,7 +134,15 @@ func (client grpcClient) Identify(ctx context.Context, token *mainflux.Token, _
 	}
 
 	ir := res.(identityRes)
-	return &mainflux.UserIdentity{Id: ir.id, Email: ir.email}, ir.err
+	id := &mainflux.UserIdentity{
+		Id:           ir.id,
+		Email:        ir.email,
+		Scopes:       ir.scopes,
+		Admin:        ir.admin,
+		Impersonator: ir.impersonator,
+		KeyId:        ir.keyID,
+	}
+	return id, ir.err
 }
 
 func encodeIdentifyRequest(_ context.Context, grpcReq interface{}) (interface{}, error) {
@@ -89,5 +152,115 @@ func encodeIdentifyRequest(_ context.Context, grpcReq interface{}) (interface{},
 
 func decodeIdentifyResponse(_ context.Context, grpcRes interface{}) (interface{}, error) {
 	res := grpcRes.(*mainflux.UserIdentity)
-	return identityRes{id: res.GetId(), email: res.GetEmail(), err: nil}, nil
+	ir := identityRes{
+		id:           res.GetId(),
+		email:        res.GetEmail(),
+		scopes:       res.GetScopes(),
+		admin:        res.GetAdmin(),
+		impersonator: res.GetImpersonator(),
+		keyID:        res.GetKeyId(),
+	}
+	return ir, nil
+}
+
+func (client grpcClient) RevokeToken(ctx context.Context, token *mainflux.Token, _ ...grpc.CallOption) (*empty.Empty, error) {
+	ctx, close := context.WithTimeout(ctx, client.timeout)
+	defer close()
+
+	res, err := client.revokeToken(ctx, identityReq{token: token.GetValue()})
+	if err != nil {
+		return nil, err
+	}
+
+	er := res.(emptyRes)
+	return &empty.Empty{}, er.err
+}
+
+func (client grpcClient) RevokeSessions(ctx context.Context, token *mainflux.Token, _ ...grpc.CallOption) (*empty.Empty, error) {
+	ctx, close := context.WithTimeout(ctx, client.timeout)
+	defer close()
+
+	res, err := client.revokeSessions(ctx, identityReq{token: token.GetValue()})
+	if err != nil {
+		return nil, err
+	}
+
+	er := res.(emptyRes)
+	return &empty.Empty{}, er.err
+}
+
+func (client grpcClient) Refresh(ctx context.Context, token *mainflux.Token, _ ...grpc.CallOption) (*mainflux.Tokens, error) {
+	ctx, close := context.WithTimeout(ctx, client.timeout)
+	defer close()
+
+	res, err := client.refresh(ctx, identityReq{token: token.GetValue()})
+	if err != nil {
+		return nil, err
+	}
+
+	tr := res.(tokensRes)
+	return &mainflux.Tokens{Access: tr.access, Refresh: tr.refresh}, tr.err
+}
+
+func (client grpcClient) VerifyChallenge(ctx context.Context, token *mainflux.Token, _ ...grpc.CallOption) (*mainflux.UserIdentity, error) {
+	ctx, close := context.WithTimeout(ctx, client.timeout)
+	defer close()
+
+	res, err := client.verifyChallenge(ctx, identityReq{token: token.GetValue()})
+	if err != nil {
+		return nil, err
+	}
+
+	ir := res.(identityRes)
+	return &mainflux.UserIdentity{Id: ir.id, Email: ir.email}, ir.err
+}
+
+func (client grpcClient) Impersonate(ctx context.Context, req *mainflux.ImpersonateReq, _ ...grpc.CallOption) (*mainflux.Token, error) {
+	ctx, close := context.WithTimeout(ctx, client.timeout)
+	defer close()
+
+	res, err := client.impersonate(ctx, impersonateReq{token: req.GetToken(), id: req.GetId(), email: req.GetEmail()})
+	if err != nil {
+		return nil, err
+	}
+
+	ir := res.(issueRes)
+	return &mainflux.Token{Value: ir.value}, ir.err
+}
+
+func encodeImpersonateRequest(_ context.Context, grpcReq interface{}) (interface{}, error) {
+	req := grpcReq.(impersonateReq)
+	return &mainflux.ImpersonateReq{Token: req.token, Id: req.id, Email: req.email}, nil
+}
+
+func decodeTokenResponse(_ context.Context, grpcRes interface{}) (interface{}, error) {
+	res := grpcRes.(*mainflux.Token)
+	return issueRes{value: res.GetValue(), err: nil}, nil
+}
+
+func (client grpcClient) SetDisabled(ctx context.Context, req *mainflux.DisableReq, _ ...grpc.CallOption) (*empty.Empty, error) {
+	ctx, close := context.WithTimeout(ctx, client.timeout)
+	defer close()
+
+	res, err := client.setDisabled(ctx, disableReq{token: req.GetToken(), id: req.GetId(), disabled: req.GetDisabled()})
+	if err != nil {
+		return nil, err
+	}
+
+	er := res.(emptyRes)
+	return &empty.Empty{}, er.err
+}
+
+func encodeDisableRequest(_ context.Context, grpcReq interface{}) (interface{}, error) {
+	req := grpcReq.(disableReq)
+	return &mainflux.DisableReq{Token: req.token, Id: req.id, Disabled: req.disabled}, nil
+}
+
+func decodeTokensResponse(_ context.Context, grpcRes interface{}) (interface{}, error) {
+	res := grpcRes.(*mainflux.Tokens)
+	return tokensRes{access: res.GetAccess(), refresh: res.GetRefresh(), err: nil}, nil
+}
+
+func decodeEmptyResponse(_ context.Context, _ interface{}) (interface{}, error) {
+	return emptyRes{}, nil
 }
@@ -8,6 +8,7 @@ import (
 	"time"
 
 	"github.com/go-kit/kit/endpoint"
+	"github.com/mainflux/mainflux"
 	"github.com/mainflux/mainflux/authn"
 )
 
@@ -48,10 +49,120 @@ func identifyEndpoint(svc authn.Service) endpoint.Endpoint {
 		}
 
 		ret := identityRes{
-			id:    id.ID,
-			email: id.Email,
-			err:   nil,
+			id:           id.ID,
+			email:        id.Email,
+			scopes:       toProtoScopes(id.Scopes),
+			admin:        id.Admin,
+			impersonator: id.Impersonator,
+			keyID:        id.KeyID,
+			err:          nil,
 		}
 		return ret, nil
 	}
 }
+
+func revokeTokenEndpoint(svc authn.Service) endpoint.Endpoint {
+	return func(ctx context.Context, request interface{}) (interface{}, error) {
+		req := request.(identityReq)
+		if err := req.validate(); err != nil {
+			return nil, err
+		}
+
+		if err := svc.RevokeToken(ctx, req.token); err != nil {
+			return nil, err
+		}
+
+		return emptyRes{}, nil
+	}
+}
+
+func revokeSessionsEndpoint(svc authn.Service) endpoint.Endpoint {
+	return func(ctx context.Context, request interface{}) (interface{}, error) {
+		req := request.(identityReq)
+		if err := req.validate(); err != nil {
+			return nil, err
+		}
+
+		if err := svc.RevokeSessions(ctx, req.token); err != nil {
+			return nil, err
+		}
+
+		return emptyRes{}, nil
+	}
+}
+
+func toProtoScopes(scopes []authn.Scope) []*mainflux.Scope {
+	var ret []*mainflux.Scope
+	for _, s := range scopes {
+		ret = append(ret, &mainflux.Scope{
+			Resource: s.Resource,
+			Action:   s.Action,
+			Ids:      s.IDs,
+		})
+	}
+
+	return ret
+}
+
+func refreshEndpoint(svc authn.Service) endpoint.Endpoint {
+	return func(ctx context.Context, request interface{}) (interface{}, error) {
+		req := request.(identityReq)
+		if err := req.validate(); err != nil {
+			return nil, err
+		}
+
+		access, refresh, err := svc.Refresh(ctx, req.token)
+		if err != nil {
+			return nil, err
+		}
+
+		return tokensRes{access: access, refresh: refresh}, nil
+	}
+}
+
+func verifyChallengeEndpoint(svc authn.Service) endpoint.Endpoint {
+	return func(ctx context.Context, request interface{}) (interface{}, error) {
+		req := request.(identityReq)
+		if err := req.validate(); err != nil {
+			return nil, err
+		}
+
+		id, err := svc.VerifyChallenge(ctx, req.token)
+		if err != nil {
+			return nil, err
+		}
+
+		return identityRes{id: id.ID, email: id.Email}, nil
+	}
+}
+
+func impersonateEndpoint(svc authn.Service) endpoint.Endpoint {
+	return func(ctx context.Context, request interface{}) (interface{}, error) {
+		req := request.(impersonateReq)
+		if err := req.validate(); err != nil {
+			return nil, err
+		}
+
+		secret, err := svc.Impersonate(ctx, req.token, req.id, req.email)
+		if err != nil {
+			return nil, err
+		}
+
+		return issueRes{secret, nil}, nil
+	}
+}
+
+func setDisabledEndpoint(svc authn.Service) endpoint.Endpoint {
+	return func(ctx context.Context, request interface{}) (interface{}, error) {
+		req := request.(disableReq)
+		if err := req.validate(); err != nil {
+			return nil, err
+		}
+
+		if err := svc.SetDisabled(ctx, req.token, req.id, req.disabled); err != nil {
+			return nil, err
+		}
+
+		return emptyRes{}, nil
+	}
+}
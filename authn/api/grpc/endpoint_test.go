@@ -37,7 +37,7 @@ func newService() authn.Service {
 	uuidProvider := uuid.NewMock()
 	t := jwt.New(secret)
 
-	return authn.New(repo, uuidProvider, t)
+	return authn.New(repo, mocks.NewRevocationRepository(), mocks.NewRefreshRepository(), uuidProvider, t, "")
 }
 
 func startGRPCServer(svc authn.Service, port int) {
@@ -110,15 +110,19 @@ func TestIssue(t *testing.T) {
 }
 
 func TestIdentify(t *testing.T) {
-	_, loginSecret, err := svc.Issue(context.Background(), "", authn.Key{Type: authn.UserKey, IssuedAt: time.Now(), IssuerID: id, Subject: email})
+	loginKey, loginSecret, err := svc.Issue(context.Background(), "", authn.Key{Type: authn.UserKey, IssuedAt: time.Now(), IssuerID: id, Subject: email})
 	assert.Nil(t, err, fmt.Sprintf("Issuing user key expected to succeed: %s", err))
 
-	_, recoverySecret, err := svc.Issue(context.Background(), "", authn.Key{Type: authn.RecoveryKey, IssuedAt: time.Now(), IssuerID: id, Subject: email})
+	recoveryKey, recoverySecret, err := svc.Issue(context.Background(), "", authn.Key{Type: authn.RecoveryKey, IssuedAt: time.Now(), IssuerID: id, Subject: email})
 	assert.Nil(t, err, fmt.Sprintf("Issuing recovery key expected to succeed: %s", err))
 
-	_, apiSecret, err := svc.Issue(context.Background(), loginSecret, authn.Key{Type: authn.APIKey, IssuedAt: time.Now(), ExpiresAt: time.Now().Add(time.Minute), IssuerID: id, Subject: email})
+	apiKey, apiSecret, err := svc.Issue(context.Background(), loginSecret, authn.Key{Type: authn.APIKey, IssuedAt: time.Now(), ExpiresAt: time.Now().Add(time.Minute), IssuerID: id, Subject: email})
 	assert.Nil(t, err, fmt.Sprintf("Issuing API key expected to succeed: %s", err))
 
+	scopes := []authn.Scope{{Resource: "messages", Action: "read", IDs: []string{"1"}}}
+	scopedKey, scopedSecret, err := svc.Issue(context.Background(), loginSecret, authn.Key{Type: authn.APIKey, IssuedAt: time.Now(), IssuerID: id, Subject: email, Scopes: scopes})
+	assert.Nil(t, err, fmt.Sprintf("Issuing scoped API key expected to succeed: %s", err))
+
 	authAddr := fmt.Sprintf("localhost:%d", port)
 	conn, _ := grpc.Dial(authAddr, grpc.WithInsecure())
 	client := grpcapi.NewClient(mocktracer.New(), conn, time.Second)
@@ -133,21 +137,28 @@ func TestIdentify(t *testing.T) {
 		{
 			desc:  "identify user with user token",
 			token: loginSecret,
-			idt:   mainflux.UserIdentity{Email: email, Id: id},
+			idt:   mainflux.UserIdentity{Email: email, Id: id, KeyId: loginKey.ID},
 			err:   nil,
 			code:  codes.OK,
 		},
 		{
 			desc:  "identify user with recovery token",
 			token: recoverySecret,
-			idt:   mainflux.UserIdentity{Email: email, Id: id},
+			idt:   mainflux.UserIdentity{Email: email, Id: id, KeyId: recoveryKey.ID},
 			err:   nil,
 			code:  codes.OK,
 		},
 		{
 			desc:  "identify user with API token",
 			token: apiSecret,
-			idt:   mainflux.UserIdentity{Email: email, Id: id},
+			idt:   mainflux.UserIdentity{Email: email, Id: id, KeyId: apiKey.ID},
+			err:   nil,
+			code:  codes.OK,
+		},
+		{
+			desc:  "identify user with scoped API token",
+			token: scopedSecret,
+			idt:   mainflux.UserIdentity{Email: email, Id: id, Scopes: []*mainflux.Scope{{Resource: "messages", Action: "read", Ids: []string{"1"}}}, KeyId: scopedKey.ID},
 			err:   nil,
 			code:  codes.OK,
 		},
@@ -177,3 +188,184 @@ func TestIdentify(t *testing.T) {
 		assert.Equal(t, tc.code, e.Code(), fmt.Sprintf("%s: expected %s got %s", tc.desc, tc.code, e.Code()))
 	}
 }
+
+func TestRevokeToken(t *testing.T) {
+	_, loginSecret, err := svc.Issue(context.Background(), "", authn.Key{Type: authn.UserKey, IssuedAt: time.Now(), IssuerID: id, Subject: email})
+	assert.Nil(t, err, fmt.Sprintf("Issuing user key expected to succeed: %s", err))
+
+	authAddr := fmt.Sprintf("localhost:%d", port)
+	conn, _ := grpc.Dial(authAddr, grpc.WithInsecure())
+	client := grpcapi.NewClient(mocktracer.New(), conn, time.Second)
+
+	cases := []struct {
+		desc  string
+		token string
+		code  codes.Code
+	}{
+		{
+			desc:  "revoke user token",
+			token: loginSecret,
+			code:  codes.OK,
+		},
+		{
+			desc:  "revoke revoked user token",
+			token: loginSecret,
+			code:  codes.Unauthenticated,
+		},
+		{
+			desc:  "revoke invalid token",
+			token: "invalid",
+			code:  codes.Unauthenticated,
+		},
+		{
+			desc:  "revoke empty token",
+			token: "",
+			code:  codes.InvalidArgument,
+		},
+	}
+
+	for _, tc := range cases {
+		_, err := client.RevokeToken(context.Background(), &mainflux.Token{Value: tc.token})
+		e, ok := status.FromError(err)
+		assert.True(t, ok, "gRPC status can't be extracted from the error")
+		assert.Equal(t, tc.code, e.Code(), fmt.Sprintf("%s: expected %s got %s", tc.desc, tc.code, e.Code()))
+	}
+}
+
+func TestRevokeSessions(t *testing.T) {
+	_, loginSecret, err := svc.Issue(context.Background(), "", authn.Key{Type: authn.UserKey, IssuedAt: time.Now(), IssuerID: "sessionsID", Subject: "sessions@example.com"})
+	assert.Nil(t, err, fmt.Sprintf("Issuing user key expected to succeed: %s", err))
+
+	authAddr := fmt.Sprintf("localhost:%d", port)
+	conn, _ := grpc.Dial(authAddr, grpc.WithInsecure())
+	client := grpcapi.NewClient(mocktracer.New(), conn, time.Second)
+
+	cases := []struct {
+		desc  string
+		token string
+		code  codes.Code
+	}{
+		{
+			desc:  "revoke sessions",
+			token: loginSecret,
+			code:  codes.OK,
+		},
+		{
+			desc:  "revoke sessions with revoked token",
+			token: loginSecret,
+			code:  codes.Unauthenticated,
+		},
+		{
+			desc:  "revoke sessions with invalid token",
+			token: "invalid",
+			code:  codes.Unauthenticated,
+		},
+	}
+
+	for _, tc := range cases {
+		_, err := client.RevokeSessions(context.Background(), &mainflux.Token{Value: tc.token})
+		e, ok := status.FromError(err)
+		assert.True(t, ok, "gRPC status can't be extracted from the error")
+		assert.Equal(t, tc.code, e.Code(), fmt.Sprintf("%s: expected %s got %s", tc.desc, tc.code, e.Code()))
+	}
+}
+
+func TestRefresh(t *testing.T) {
+	_, refreshSecret, err := svc.Issue(context.Background(), "", authn.Key{Type: authn.RefreshKey, IssuedAt: time.Now(), IssuerID: "refreshID", Subject: "refresh@example.com"})
+	assert.Nil(t, err, fmt.Sprintf("Issuing refresh key expected to succeed: %s", err))
+	_, loginSecret, err := svc.Issue(context.Background(), "", authn.Key{Type: authn.UserKey, IssuedAt: time.Now(), IssuerID: "refreshID", Subject: "refresh@example.com"})
+	assert.Nil(t, err, fmt.Sprintf("Issuing user key expected to succeed: %s", err))
+
+	authAddr := fmt.Sprintf("localhost:%d", port)
+	conn, _ := grpc.Dial(authAddr, grpc.WithInsecure())
+	client := grpcapi.NewClient(mocktracer.New(), conn, time.Second)
+
+	cases := []struct {
+		desc  string
+		token string
+		code  codes.Code
+	}{
+		{
+			desc:  "refresh tokens",
+			token: refreshSecret,
+			code:  codes.OK,
+		},
+		{
+			desc:  "refresh tokens with used refresh token",
+			token: refreshSecret,
+			code:  codes.Unauthenticated,
+		},
+		{
+			desc:  "refresh tokens with user token",
+			token: loginSecret,
+			code:  codes.Unauthenticated,
+		},
+		{
+			desc:  "refresh tokens with empty token",
+			token: "",
+			code:  codes.InvalidArgument,
+		},
+	}
+
+	for _, tc := range cases {
+		tokens, err := client.Refresh(context.Background(), &mainflux.Token{Value: tc.token})
+		e, ok := status.FromError(err)
+		assert.True(t, ok, "gRPC status can't be extracted from the error")
+		assert.Equal(t, tc.code, e.Code(), fmt.Sprintf("%s: expected %s got %s", tc.desc, tc.code, e.Code()))
+		if tc.code == codes.OK {
+			assert.NotEmpty(t, tokens.GetAccess(), fmt.Sprintf("%s: expected access token", tc.desc))
+			assert.NotEmpty(t, tokens.GetRefresh(), fmt.Sprintf("%s: expected refresh token", tc.desc))
+		}
+	}
+}
+
+func TestVerifyChallenge(t *testing.T) {
+	_, challengeSecret, err := svc.Issue(context.Background(), "", authn.Key{Type: authn.ChallengeKey, IssuedAt: time.Now(), IssuerID: "challengeID", Subject: "challenge@example.com"})
+	assert.Nil(t, err, fmt.Sprintf("Issuing challenge key expected to succeed: %s", err))
+	_, loginSecret, err := svc.Issue(context.Background(), "", authn.Key{Type: authn.UserKey, IssuedAt: time.Now(), IssuerID: "challengeID", Subject: "challenge@example.com"})
+	assert.Nil(t, err, fmt.Sprintf("Issuing user key expected to succeed: %s", err))
+
+	authAddr := fmt.Sprintf("localhost:%d", port)
+	conn, _ := grpc.Dial(authAddr, grpc.WithInsecure())
+	client := grpcapi.NewClient(mocktracer.New(), conn, time.Second)
+
+	cases := []struct {
+		desc  string
+		token string
+		id    string
+		email string
+		code  codes.Code
+	}{
+		{
+			desc:  "verify challenge token",
+			token: challengeSecret,
+			id:    "challengeID",
+			email: "challenge@example.com",
+			code:  codes.OK,
+		},
+		{
+			desc:  "verify used challenge token",
+			token: challengeSecret,
+			code:  codes.Unauthenticated,
+		},
+		{
+			desc:  "verify user token",
+			token: loginSecret,
+			code:  codes.Unauthenticated,
+		},
+		{
+			desc:  "verify empty token",
+			token: "",
+			code:  codes.InvalidArgument,
+		},
+	}
+
+	for _, tc := range cases {
+		id, err := client.VerifyChallenge(context.Background(), &mainflux.Token{Value: tc.token})
+		e, ok := status.FromError(err)
+		assert.True(t, ok, "gRPC status can't be extracted from the error")
+		assert.Equal(t, tc.code, e.Code(), fmt.Sprintf("%s: expected %s got %s", tc.desc, tc.code, e.Code()))
+		assert.Equal(t, tc.id, id.GetId(), fmt.Sprintf("%s: expected %s got %s", tc.desc, tc.id, id.GetId()))
+		assert.Equal(t, tc.email, id.GetEmail(), fmt.Sprintf("%s: expected %s got %s", tc.desc, tc.email, id.GetEmail()))
+	}
+}
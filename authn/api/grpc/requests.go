@@ -35,7 +35,43 @@ func (req issueReq) validate() error {
 	}
 	if req.keyType != authn.UserKey &&
 		req.keyType != authn.APIKey &&
-		req.keyType != authn.RecoveryKey {
+		req.keyType != authn.RecoveryKey &&
+		req.keyType != authn.RefreshKey &&
+		req.keyType != authn.ChallengeKey {
+		return authn.ErrMalformedEntity
+	}
+
+	return nil
+}
+
+type impersonateReq struct {
+	token string
+	id    string
+	email string
+}
+
+func (req impersonateReq) validate() error {
+	if req.token == "" {
+		return authn.ErrUnauthorizedAccess
+	}
+	if req.id == "" || req.email == "" {
+		return authn.ErrMalformedEntity
+	}
+
+	return nil
+}
+
+type disableReq struct {
+	token    string
+	id       string
+	disabled bool
+}
+
+func (req disableReq) validate() error {
+	if req.token == "" {
+		return authn.ErrUnauthorizedAccess
+	}
+	if req.id == "" {
 		return authn.ErrMalformedEntity
 	}
 
@@ -3,10 +3,26 @@
 
 package grpc
 
+import "github.com/mainflux/mainflux"
+
 type identityRes struct {
-	id    string
-	email string
-	err   error
+	id           string
+	email        string
+	scopes       []*mainflux.Scope
+	admin        bool
+	impersonator string
+	keyID        string
+	err          error
+}
+
+type emptyRes struct {
+	err error
+}
+
+type tokensRes struct {
+	access  string
+	refresh string
+	err     error
 }
 
 type issueRes struct {
@@ -8,6 +8,7 @@ import (
 
 	kitot "github.com/go-kit/kit/tracing/opentracing"
 	kitgrpc "github.com/go-kit/kit/transport/grpc"
+	"github.com/golang/protobuf/ptypes/empty"
 	mainflux "github.com/mainflux/mainflux"
 	"github.com/mainflux/mainflux/authn"
 	"github.com/mainflux/mainflux/pkg/errors"
@@ -19,8 +20,14 @@ import (
 var _ mainflux.AuthNServiceServer = (*grpcServer)(nil)
 
 type grpcServer struct {
-	issue    kitgrpc.Handler
-	identify kitgrpc.Handler
+	issue           kitgrpc.Handler
+	identify        kitgrpc.Handler
+	revokeToken     kitgrpc.Handler
+	revokeSessions  kitgrpc.Handler
+	refresh         kitgrpc.Handler
+	verifyChallenge kitgrpc.Handler
+	impersonate     kitgrpc.Handler
+	setDisabled     kitgrpc.Handler
 }
 
 // NewServer returns new AuthnServiceServer instance.
@@ -36,6 +43,36 @@ func NewServer(tracer opentracing.Tracer, svc authn.Service) mainflux.AuthNServi
 			decodeIdentifyRequest,
 			encodeIdentifyResponse,
 		),
+		revokeToken: kitgrpc.NewServer(
+			kitot.TraceServer(tracer, "revoke_token")(revokeTokenEndpoint(svc)),
+			decodeIdentifyRequest,
+			encodeEmptyResponse,
+		),
+		revokeSessions: kitgrpc.NewServer(
+			kitot.TraceServer(tracer, "revoke_sessions")(revokeSessionsEndpoint(svc)),
+			decodeIdentifyRequest,
+			encodeEmptyResponse,
+		),
+		refresh: kitgrpc.NewServer(
+			kitot.TraceServer(tracer, "refresh")(refreshEndpoint(svc)),
+			decodeIdentifyRequest,
+			encodeTokensResponse,
+		),
+		verifyChallenge: kitgrpc.NewServer(
+			kitot.TraceServer(tracer, "verify_challenge")(verifyChallengeEndpoint(svc)),
+			decodeIdentifyRequest,
+			encodeIdentifyResponse,
+		),
+		impersonate: kitgrpc.NewServer(
+			kitot.TraceServer(tracer, "impersonate")(impersonateEndpoint(svc)),
+			decodeImpersonateRequest,
+			encodeIssueResponse,
+		),
+		setDisabled: kitgrpc.NewServer(
+			kitot.TraceServer(tracer, "set_disabled")(setDisabledEndpoint(svc)),
+			decodeDisableRequest,
+			encodeEmptyResponse,
+		),
 	}
 }
 
@@ -55,6 +92,54 @@ func (s *grpcServer) Identify(ctx context.Context, token *mainflux.Token) (*main
 	return res.(*mainflux.UserIdentity), nil
 }
 
+func (s *grpcServer) RevokeToken(ctx context.Context, token *mainflux.Token) (*empty.Empty, error) {
+	_, res, err := s.revokeToken.ServeGRPC(ctx, token)
+	if err != nil {
+		return nil, encodeError(err)
+	}
+	return res.(*empty.Empty), nil
+}
+
+func (s *grpcServer) RevokeSessions(ctx context.Context, token *mainflux.Token) (*empty.Empty, error) {
+	_, res, err := s.revokeSessions.ServeGRPC(ctx, token)
+	if err != nil {
+		return nil, encodeError(err)
+	}
+	return res.(*empty.Empty), nil
+}
+
+func (s *grpcServer) Refresh(ctx context.Context, token *mainflux.Token) (*mainflux.Tokens, error) {
+	_, res, err := s.refresh.ServeGRPC(ctx, token)
+	if err != nil {
+		return nil, encodeError(err)
+	}
+	return res.(*mainflux.Tokens), nil
+}
+
+func (s *grpcServer) VerifyChallenge(ctx context.Context, token *mainflux.Token) (*mainflux.UserIdentity, error) {
+	_, res, err := s.verifyChallenge.ServeGRPC(ctx, token)
+	if err != nil {
+		return nil, encodeError(err)
+	}
+	return res.(*mainflux.UserIdentity), nil
+}
+
+func (s *grpcServer) Impersonate(ctx context.Context, req *mainflux.ImpersonateReq) (*mainflux.Token, error) {
+	_, res, err := s.impersonate.ServeGRPC(ctx, req)
+	if err != nil {
+		return nil, encodeError(err)
+	}
+	return res.(*mainflux.Token), nil
+}
+
+func (s *grpcServer) SetDisabled(ctx context.Context, req *mainflux.DisableReq) (*empty.Empty, error) {
+	_, res, err := s.setDisabled.ServeGRPC(ctx, req)
+	if err != nil {
+		return nil, encodeError(err)
+	}
+	return res.(*empty.Empty), nil
+}
+
 func decodeIssueRequest(_ context.Context, grpcReq interface{}) (interface{}, error) {
 	req := grpcReq.(*mainflux.IssueReq)
 	return issueReq{id: req.GetId(), email: req.GetEmail(), keyType: req.GetType()}, nil
@@ -72,7 +157,35 @@ func decodeIdentifyRequest(_ context.Context, grpcReq interface{}) (interface{},
 
 func encodeIdentifyResponse(_ context.Context, grpcRes interface{}) (interface{}, error) {
 	res := grpcRes.(identityRes)
-	return &mainflux.UserIdentity{Id: res.id, Email: res.email}, encodeError(res.err)
+	id := &mainflux.UserIdentity{
+		Id:           res.id,
+		Email:        res.email,
+		Scopes:       res.scopes,
+		Admin:        res.admin,
+		Impersonator: res.impersonator,
+		KeyId:        res.keyID,
+	}
+	return id, encodeError(res.err)
+}
+
+func decodeImpersonateRequest(_ context.Context, grpcReq interface{}) (interface{}, error) {
+	req := grpcReq.(*mainflux.ImpersonateReq)
+	return impersonateReq{token: req.GetToken(), id: req.GetId(), email: req.GetEmail()}, nil
+}
+
+func decodeDisableRequest(_ context.Context, grpcReq interface{}) (interface{}, error) {
+	req := grpcReq.(*mainflux.DisableReq)
+	return disableReq{token: req.GetToken(), id: req.GetId(), disabled: req.GetDisabled()}, nil
+}
+
+func encodeEmptyResponse(_ context.Context, grpcRes interface{}) (interface{}, error) {
+	res := grpcRes.(emptyRes)
+	return &empty.Empty{}, encodeError(res.err)
+}
+
+func encodeTokensResponse(_ context.Context, grpcRes interface{}) (interface{}, error) {
+	res := grpcRes.(tokensRes)
+	return &mainflux.Tokens{Access: res.access, Refresh: res.refresh}, encodeError(res.err)
 }
 
 func encodeError(err error) error {
@@ -85,6 +198,12 @@ func encodeError(err error) error {
 		return status.Error(codes.Unauthenticated, err.Error())
 	case errors.Contains(err, authn.ErrKeyExpired):
 		return status.Error(codes.Unauthenticated, err.Error())
+	case errors.Contains(err, authn.ErrKeyRevoked):
+		return status.Error(codes.Unauthenticated, err.Error())
+	case errors.Contains(err, authn.ErrKeyReused):
+		return status.Error(codes.Unauthenticated, err.Error())
+	case errors.Contains(err, authn.ErrDisabled):
+		return status.Error(codes.Unauthenticated, err.Error())
 	default:
 		return status.Error(codes.Internal, "internal server error")
 	}
@@ -5,6 +5,10 @@ package http
 
 import (
 	"context"
+	"crypto/ecdsa"
+	"crypto/rsa"
+	"encoding/base64"
+	"math/big"
 	"time"
 
 	"github.com/go-kit/kit/endpoint"
@@ -22,6 +26,7 @@ func issueEndpoint(svc authn.Service) endpoint.Endpoint {
 		newKey := authn.Key{
 			IssuedAt: now,
 			Type:     req.Type,
+			Scopes:   req.Scopes,
 		}
 
 		duration := time.Duration(req.Duration * time.Second)
@@ -76,17 +81,131 @@ func retrieveEndpoint(svc authn.Service) endpoint.Endpoint {
 		if err != nil {
 			return nil, err
 		}
-		ret := retrieveKeyRes{
-			ID:       key.ID,
-			IssuerID: key.IssuerID,
-			Subject:  key.Subject,
-			Type:     key.Type,
-			IssuedAt: key.IssuedAt,
+
+		return toKeyRes(key), nil
+	}
+}
+
+func listKeysEndpoint(svc authn.Service) endpoint.Endpoint {
+	return func(ctx context.Context, request interface{}) (interface{}, error) {
+		req := request.(listKeysReq)
+
+		if err := req.validate(); err != nil {
+			return nil, err
 		}
-		if !key.ExpiresAt.IsZero() {
-			ret.ExpiresAt = &key.ExpiresAt
+
+		pm := authn.PageMetadata{
+			Offset: req.offset,
+			Limit:  req.limit,
+			Type:   req.keyType,
+			Status: req.status,
+		}
+		page, err := svc.ListKeys(ctx, req.token, pm)
+		if err != nil {
+			return nil, err
 		}
 
-		return ret, nil
+		res := keysPageRes{
+			Total:  page.Total,
+			Offset: page.Offset,
+			Limit:  page.Limit,
+			Keys:   []retrieveKeyRes{},
+		}
+		for _, key := range page.Keys {
+			res.Keys = append(res.Keys, toKeyRes(key))
+		}
+
+		return res, nil
 	}
 }
+
+func revokeAllEndpoint(svc authn.Service) endpoint.Endpoint {
+	return func(ctx context.Context, request interface{}) (interface{}, error) {
+		req := request.(revokeAllReq)
+
+		if err := req.validate(); err != nil {
+			return nil, err
+		}
+
+		if err := svc.RevokeAll(ctx, req.token, req.subject); err != nil {
+			return nil, err
+		}
+
+		return revokeKeyRes{}, nil
+	}
+}
+
+func toKeyRes(key authn.Key) retrieveKeyRes {
+	ret := retrieveKeyRes{
+		ID:       key.ID,
+		IssuerID: key.IssuerID,
+		Subject:  key.Subject,
+		Type:     key.Type,
+		IssuedAt: key.IssuedAt,
+		Scopes:   key.Scopes,
+	}
+	if !key.ExpiresAt.IsZero() {
+		ret.ExpiresAt = &key.ExpiresAt
+	}
+	if !key.LastUsedAt.IsZero() {
+		ret.LastUsedAt = &key.LastUsedAt
+	}
+
+	return ret
+}
+
+func jwksEndpoint(svc authn.Service) endpoint.Endpoint {
+	return func(ctx context.Context, request interface{}) (interface{}, error) {
+		keys, err := svc.PublicKeys(ctx)
+		if err != nil {
+			return nil, err
+		}
+
+		res := jwksRes{Keys: []jwk{}}
+		for _, k := range keys {
+			if key, ok := toJWK(k); ok {
+				res.Keys = append(res.Keys, key)
+			}
+		}
+
+		return res, nil
+	}
+}
+
+// toJWK converts the public key to its JSON Web Key representation as
+// specified by RFC 7518.
+func toJWK(pk authn.PublicKey) (jwk, bool) {
+	key := jwk{
+		ID:        pk.ID,
+		Use:       "sig",
+		Algorithm: pk.Algorithm,
+	}
+
+	switch k := pk.Key.(type) {
+	case *rsa.PublicKey:
+		key.KeyType = "RSA"
+		key.N = encodeJWKInt(k.N, 0)
+		key.E = encodeJWKInt(big.NewInt(int64(k.E)), 0)
+	case *ecdsa.PublicKey:
+		size := (k.Curve.Params().BitSize + 7) / 8
+		key.KeyType = "EC"
+		key.Curve = k.Curve.Params().Name
+		key.X = encodeJWKInt(k.X, size)
+		key.Y = encodeJWKInt(k.Y, size)
+	default:
+		return jwk{}, false
+	}
+
+	return key, true
+}
+
+// encodeJWKInt encodes the integer as base64url of its big-endian bytes,
+// left padded with zeros to the size, if provided.
+func encodeJWKInt(i *big.Int, size int) string {
+	b := i.Bytes()
+	if len(b) < size {
+		b = append(make([]byte, size-len(b)), b...)
+	}
+
+	return base64.RawURLEncoding.EncodeToString(b)
+}
@@ -5,6 +5,11 @@ package http_test
 
 import (
 	"context"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/rsa"
+	"encoding/base64"
 	"encoding/json"
 	"fmt"
 	"io"
@@ -21,6 +26,7 @@ import (
 	"github.com/mainflux/mainflux/pkg/uuid"
 	"github.com/opentracing/opentracing-go/mocktracer"
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
 )
 
 const (
@@ -35,6 +41,7 @@ const (
 type issueRequest struct {
 	Duration time.Duration `json:"duration,omitempty"`
 	Type     uint32        `json:"type,omitempty"`
+	Scopes   []authn.Scope `json:"scopes,omitempty"`
 }
 
 type testRequest struct {
@@ -66,7 +73,7 @@ func newService() authn.Service {
 	repo := mocks.NewKeyRepository()
 	uuidProvider := uuid.NewMock()
 	t := jwt.New(secret)
-	return authn.New(repo, uuidProvider, t)
+	return authn.New(repo, mocks.NewRevocationRepository(), mocks.NewRefreshRepository(), uuidProvider, t, "")
 }
 
 func newServer(svc authn.Service) *httptest.Server {
@@ -91,6 +98,9 @@ func TestIssue(t *testing.T) {
 	uk := issueRequest{Type: authn.UserKey}
 	ak := issueRequest{Type: authn.APIKey, Duration: time.Hour}
 	rk := issueRequest{Type: authn.RecoveryKey}
+	sk := issueRequest{Type: authn.APIKey, Scopes: []authn.Scope{{Resource: "messages", Action: "read", IDs: []string{"1"}}}}
+	isk := issueRequest{Type: authn.APIKey, Scopes: []authn.Scope{{Resource: "messages", Action: "delete"}}}
+	suk := issueRequest{Type: authn.UserKey, Scopes: []authn.Scope{{Resource: "things", Action: "read"}}}
 
 	cases := []struct {
 		desc   string
@@ -113,6 +123,27 @@ func TestIssue(t *testing.T) {
 			token:  loginSecret,
 			status: http.StatusCreated,
 		},
+		{
+			desc:   "issue scoped API key",
+			req:    toJSON(sk),
+			ct:     contentType,
+			token:  loginSecret,
+			status: http.StatusCreated,
+		},
+		{
+			desc:   "issue API key with invalid scope",
+			req:    toJSON(isk),
+			ct:     contentType,
+			token:  loginSecret,
+			status: http.StatusBadRequest,
+		},
+		{
+			desc:   "issue scoped user key",
+			req:    toJSON(suk),
+			ct:     contentType,
+			token:  "",
+			status: http.StatusBadRequest,
+		},
 		{
 			desc:   "issue recovery key",
 			req:    toJSON(rk),
@@ -288,3 +319,264 @@ func TestRevoke(t *testing.T) {
 		assert.Equal(t, tc.status, res.StatusCode, fmt.Sprintf("%s: expected status code %d got %d", tc.desc, tc.status, res.StatusCode))
 	}
 }
+
+type keysPageRes struct {
+	Total  uint64 `json:"total"`
+	Offset uint64 `json:"offset"`
+	Limit  uint64 `json:"limit"`
+	Keys   []struct {
+		ID         string     `json:"id"`
+		Subject    string     `json:"subject"`
+		LastUsedAt *time.Time `json:"last_used_at"`
+	} `json:"keys"`
+}
+
+func TestListKeys(t *testing.T) {
+	svc := newService()
+	_, loginSecret, err := svc.Issue(context.Background(), "", authn.Key{Type: authn.UserKey, IssuedAt: time.Now(), IssuerID: id, Subject: email})
+	assert.Nil(t, err, fmt.Sprintf("Issuing user key expected to succeed: %s", err))
+
+	n := 3
+	for i := 0; i < n; i++ {
+		key := authn.Key{Type: authn.APIKey, IssuedAt: time.Now(), IssuerID: id, Subject: email}
+		_, _, err := svc.Issue(context.Background(), loginSecret, key)
+		require.Nil(t, err, fmt.Sprintf("Issuing user key expected to succeed: %s", err))
+	}
+
+	ts := newServer(svc)
+	defer ts.Close()
+	client := ts.Client()
+
+	cases := []struct {
+		desc   string
+		query  string
+		token  string
+		status int
+		size   int
+	}{
+		{
+			desc:   "list keys",
+			query:  "",
+			token:  loginSecret,
+			status: http.StatusOK,
+			size:   n,
+		},
+		{
+			desc:   "list keys with offset and limit",
+			query:  "?offset=1&limit=1",
+			token:  loginSecret,
+			status: http.StatusOK,
+			size:   1,
+		},
+		{
+			desc:   "list active API keys",
+			query:  fmt.Sprintf("?type=%d&status=%s", authn.APIKey, authn.ActiveKeys),
+			token:  loginSecret,
+			status: http.StatusOK,
+			size:   n,
+		},
+		{
+			desc:   "list expired keys",
+			query:  fmt.Sprintf("?status=%s", authn.ExpiredKeys),
+			token:  loginSecret,
+			status: http.StatusOK,
+			size:   0,
+		},
+		{
+			desc:   "list keys with invalid status",
+			query:  "?status=revoked",
+			token:  loginSecret,
+			status: http.StatusBadRequest,
+		},
+		{
+			desc:   "list keys with invalid type",
+			query:  "?type=5",
+			token:  loginSecret,
+			status: http.StatusBadRequest,
+		},
+		{
+			desc:   "list keys with invalid limit",
+			query:  "?limit=1000",
+			token:  loginSecret,
+			status: http.StatusBadRequest,
+		},
+		{
+			desc:   "list keys with malformed offset",
+			query:  "?offset=first",
+			token:  loginSecret,
+			status: http.StatusBadRequest,
+		},
+		{
+			desc:   "list keys unauthorized",
+			query:  "",
+			token:  "wrong",
+			status: http.StatusForbidden,
+		},
+	}
+
+	for _, tc := range cases {
+		req := testRequest{
+			client: client,
+			method: http.MethodGet,
+			url:    fmt.Sprintf("%s/keys%s", ts.URL, tc.query),
+			token:  tc.token,
+		}
+		res, err := req.make()
+		assert.Nil(t, err, fmt.Sprintf("%s: unexpected error %s", tc.desc, err))
+		assert.Equal(t, tc.status, res.StatusCode, fmt.Sprintf("%s: expected status code %d got %d", tc.desc, tc.status, res.StatusCode))
+		if tc.status != http.StatusOK {
+			continue
+		}
+		var page keysPageRes
+		err = json.NewDecoder(res.Body).Decode(&page)
+		assert.Nil(t, err, fmt.Sprintf("%s: unexpected error %s", tc.desc, err))
+		assert.Equal(t, tc.size, len(page.Keys), fmt.Sprintf("%s: expected %d keys got %d", tc.desc, tc.size, len(page.Keys)))
+	}
+}
+
+func TestRevokeAll(t *testing.T) {
+	svc := newService()
+	_, loginSecret, err := svc.Issue(context.Background(), "", authn.Key{Type: authn.UserKey, IssuedAt: time.Now(), IssuerID: id, Subject: email})
+	assert.Nil(t, err, fmt.Sprintf("Issuing user key expected to succeed: %s", err))
+
+	for _, sub := range []string{email, "ci@example.com"} {
+		key := authn.Key{Type: authn.APIKey, IssuedAt: time.Now(), IssuerID: id, Subject: sub}
+		_, _, err := svc.Issue(context.Background(), loginSecret, key)
+		require.Nil(t, err, fmt.Sprintf("Issuing user key expected to succeed: %s", err))
+	}
+
+	ts := newServer(svc)
+	defer ts.Close()
+	client := ts.Client()
+
+	cases := []struct {
+		desc   string
+		query  string
+		token  string
+		status int
+		total  uint64
+	}{
+		{
+			desc:   "revoke all keys unauthorized",
+			query:  "",
+			token:  "wrong",
+			status: http.StatusForbidden,
+			total:  2,
+		},
+		{
+			desc:   "revoke all keys of the subject",
+			query:  "?subject=ci@example.com",
+			token:  loginSecret,
+			status: http.StatusNoContent,
+			total:  1,
+		},
+		{
+			desc:   "revoke all keys",
+			query:  "",
+			token:  loginSecret,
+			status: http.StatusNoContent,
+			total:  0,
+		},
+	}
+
+	for _, tc := range cases {
+		req := testRequest{
+			client: client,
+			method: http.MethodDelete,
+			url:    fmt.Sprintf("%s/keys%s", ts.URL, tc.query),
+			token:  tc.token,
+		}
+		res, err := req.make()
+		assert.Nil(t, err, fmt.Sprintf("%s: unexpected error %s", tc.desc, err))
+		assert.Equal(t, tc.status, res.StatusCode, fmt.Sprintf("%s: expected status code %d got %d", tc.desc, tc.status, res.StatusCode))
+		page, err := svc.ListKeys(context.Background(), loginSecret, authn.PageMetadata{Limit: 10})
+		require.Nil(t, err, fmt.Sprintf("%s: unexpected error %s", tc.desc, err))
+		assert.Equal(t, tc.total, page.Total, fmt.Sprintf("%s: expected %d keys got %d", tc.desc, tc.total, page.Total))
+	}
+}
+
+type jwk struct {
+	KeyType   string `json:"kty"`
+	ID        string `json:"kid"`
+	Use       string `json:"use"`
+	Algorithm string `json:"alg"`
+	N         string `json:"n"`
+	E         string `json:"e"`
+	Curve     string `json:"crv"`
+	X         string `json:"x"`
+	Y         string `json:"y"`
+}
+
+type jwks struct {
+	Keys []jwk `json:"keys"`
+}
+
+func TestJWKS(t *testing.T) {
+	rsaKey, err := rsa.GenerateKey(rand.Reader, 2048)
+	require.Nil(t, err, fmt.Sprintf("generating RSA key expected to succeed: %s", err))
+	ecKey, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	require.Nil(t, err, fmt.Sprintf("generating EC key expected to succeed: %s", err))
+	tokenizer, err := jwt.NewAsymmetric([]jwt.SigningKey{{ID: "rsa", Key: rsaKey}, {ID: "ec", Key: ecKey}}, "rsa", secret)
+	require.Nil(t, err, fmt.Sprintf("creating tokenizer expected to succeed: %s", err))
+
+	enc := base64.RawURLEncoding
+	cases := []struct {
+		desc string
+		svc  authn.Service
+		keys []jwk
+	}{
+		{
+			desc: "retrieve JWKS of asymmetric tokenizer",
+			svc:  authn.New(mocks.NewKeyRepository(), mocks.NewRevocationRepository(), mocks.NewRefreshRepository(), uuid.NewMock(), tokenizer, ""),
+			keys: []jwk{
+				{
+					KeyType:   "EC",
+					ID:        "ec",
+					Use:       "sig",
+					Algorithm: "ES256",
+					Curve:     "P-256",
+					X:         enc.EncodeToString(padTo(ecKey.X.Bytes(), 32)),
+					Y:         enc.EncodeToString(padTo(ecKey.Y.Bytes(), 32)),
+				},
+				{
+					KeyType:   "RSA",
+					ID:        "rsa",
+					Use:       "sig",
+					Algorithm: "RS256",
+					N:         enc.EncodeToString(rsaKey.N.Bytes()),
+					E:         "AQAB",
+				},
+			},
+		},
+		{
+			desc: "retrieve JWKS of HMAC tokenizer",
+			svc:  newService(),
+			keys: []jwk{},
+		},
+	}
+
+	for _, tc := range cases {
+		ts := newServer(tc.svc)
+		req := testRequest{
+			client: ts.Client(),
+			method: http.MethodGet,
+			url:    fmt.Sprintf("%s/.well-known/jwks.json", ts.URL),
+		}
+		res, err := req.make()
+		assert.Nil(t, err, fmt.Sprintf("%s: unexpected error %s", tc.desc, err))
+		assert.Equal(t, http.StatusOK, res.StatusCode, fmt.Sprintf("%s: expected status code %d got %d", tc.desc, http.StatusOK, res.StatusCode))
+
+		var body jwks
+		err = json.NewDecoder(res.Body).Decode(&body)
+		assert.Nil(t, err, fmt.Sprintf("%s: unexpected error %s", tc.desc, err))
+		assert.Equal(t, tc.keys, body.Keys, fmt.Sprintf("%s: expected keys %v got %v", tc.desc, tc.keys, body.Keys))
+		ts.Close()
+	}
+}
+
+func padTo(b []byte, size int) []byte {
+	if len(b) >= size {
+		return b
+	}
+	return append(make([]byte, size-len(b)), b...)
+}
@@ -9,20 +9,31 @@ import (
 	"github.com/mainflux/mainflux/authn"
 )
 
+const maxLimitSize = 100
+
 type issueKeyReq struct {
 	token    string
 	Type     uint32        `json:"type,omitempty"`
 	Duration time.Duration `json:"duration,omitempty"`
+	Scopes   []authn.Scope `json:"scopes,omitempty"`
 }
 
 // It is not possible to issue Reset key using HTTP API.
 func (req issueKeyReq) validate() error {
 	if req.Type == authn.UserKey {
+		if len(req.Scopes) > 0 {
+			return authn.ErrMalformedEntity
+		}
 		return nil
 	}
 	if req.token == "" || (req.Type != authn.APIKey) {
 		return authn.ErrMalformedEntity
 	}
+	for _, s := range req.Scopes {
+		if err := s.Validate(); err != nil {
+			return err
+		}
+	}
 	return nil
 }
 
@@ -37,3 +48,39 @@ func (req keyReq) validate() error {
 	}
 	return nil
 }
+
+type listKeysReq struct {
+	token   string
+	offset  uint64
+	limit   uint64
+	keyType *uint32
+	status  string
+}
+
+func (req listKeysReq) validate() error {
+	if req.token == "" {
+		return authn.ErrMalformedEntity
+	}
+	if req.limit == 0 || req.limit > maxLimitSize {
+		return authn.ErrMalformedEntity
+	}
+	if req.keyType != nil && *req.keyType > authn.APIKey {
+		return authn.ErrMalformedEntity
+	}
+	if req.status != "" && req.status != authn.ActiveKeys && req.status != authn.ExpiredKeys {
+		return authn.ErrMalformedEntity
+	}
+	return nil
+}
+
+type revokeAllReq struct {
+	token   string
+	subject string
+}
+
+func (req revokeAllReq) validate() error {
+	if req.token == "" {
+		return authn.ErrMalformedEntity
+	}
+	return nil
+}
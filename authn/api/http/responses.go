@@ -8,11 +8,14 @@ import (
 	"time"
 
 	"github.com/mainflux/mainflux"
+	"github.com/mainflux/mainflux/authn"
 )
 
 var (
 	_ mainflux.Response = (*issueKeyRes)(nil)
 	_ mainflux.Response = (*revokeKeyRes)(nil)
+	_ mainflux.Response = (*keysPageRes)(nil)
+	_ mainflux.Response = (*jwksRes)(nil)
 )
 
 type issueKeyRes struct {
@@ -35,12 +38,14 @@ func (res issueKeyRes) Empty() bool {
 }
 
 type retrieveKeyRes struct {
-	ID        string     `json:"id,omitempty"`
-	IssuerID  string     `json:"issuer_id,omitempty"`
-	Subject   string     `json:"subject,omitempty"`
-	Type      uint32     `json:"type,omitempty"`
-	IssuedAt  time.Time  `json:"issued_at,omitempty"`
-	ExpiresAt *time.Time `json:"expires_at,omitempty"`
+	ID         string        `json:"id,omitempty"`
+	IssuerID   string        `json:"issuer_id,omitempty"`
+	Subject    string        `json:"subject,omitempty"`
+	Type       uint32        `json:"type,omitempty"`
+	IssuedAt   time.Time     `json:"issued_at,omitempty"`
+	ExpiresAt  *time.Time    `json:"expires_at,omitempty"`
+	LastUsedAt *time.Time    `json:"last_used_at,omitempty"`
+	Scopes     []authn.Scope `json:"scopes,omitempty"`
 }
 
 func (res retrieveKeyRes) Code() int {
@@ -55,6 +60,25 @@ func (res retrieveKeyRes) Empty() bool {
 	return false
 }
 
+type keysPageRes struct {
+	Total  uint64           `json:"total"`
+	Offset uint64           `json:"offset"`
+	Limit  uint64           `json:"limit"`
+	Keys   []retrieveKeyRes `json:"keys"`
+}
+
+func (res keysPageRes) Code() int {
+	return http.StatusOK
+}
+
+func (res keysPageRes) Headers() map[string]string {
+	return map[string]string{}
+}
+
+func (res keysPageRes) Empty() bool {
+	return false
+}
+
 type revokeKeyRes struct {
 }
 
@@ -70,6 +94,34 @@ func (res revokeKeyRes) Empty() bool {
 	return true
 }
 
+type jwk struct {
+	KeyType   string `json:"kty"`
+	ID        string `json:"kid"`
+	Use       string `json:"use"`
+	Algorithm string `json:"alg"`
+	N         string `json:"n,omitempty"`
+	E         string `json:"e,omitempty"`
+	Curve     string `json:"crv,omitempty"`
+	X         string `json:"x,omitempty"`
+	Y         string `json:"y,omitempty"`
+}
+
+type jwksRes struct {
+	Keys []jwk `json:"keys"`
+}
+
+func (res jwksRes) Code() int {
+	return http.StatusOK
+}
+
+func (res jwksRes) Headers() map[string]string {
+	return map[string]string{}
+}
+
+func (res jwksRes) Empty() bool {
+	return false
+}
+
 type errorRes struct {
 	Err string `json:"error"`
 }
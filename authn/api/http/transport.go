@@ -8,6 +8,7 @@ import (
 	"encoding/json"
 	"io"
 	"net/http"
+	"strconv"
 	"strings"
 
 	kitot "github.com/go-kit/kit/tracing/opentracing"
@@ -15,19 +16,35 @@ import (
 	"github.com/go-zoo/bone"
 	"github.com/mainflux/mainflux"
 	"github.com/mainflux/mainflux/authn"
+	"github.com/mainflux/mainflux/pkg/audit"
 	"github.com/mainflux/mainflux/pkg/errors"
 	"github.com/opentracing/opentracing-go"
 	"github.com/prometheus/client_golang/prometheus/promhttp"
 )
 
-const contentType = "application/json"
+const (
+	contentType = "application/json"
 
-var errUnsupportedContentType = errors.New("unsupported content type")
+	offsetKey  = "offset"
+	limitKey   = "limit"
+	typeKey    = "type"
+	statusKey  = "status"
+	subjectKey = "subject"
+
+	defOffset = 0
+	defLimit  = 10
+)
+
+var (
+	errUnsupportedContentType = errors.New("unsupported content type")
+	errInvalidQueryParams     = errors.New("invalid query params")
+)
 
 // MakeHandler returns a HTTP handler for API endpoints.
 func MakeHandler(svc authn.Service, tracer opentracing.Tracer) http.Handler {
 	opts := []kithttp.ServerOption{
 		kithttp.ServerErrorEncoder(encodeError),
+		kithttp.ServerBefore(audit.PopulateIP),
 	}
 
 	mux := bone.New()
@@ -39,6 +56,20 @@ func MakeHandler(svc authn.Service, tracer opentracing.Tracer) http.Handler {
 		opts...,
 	))
 
+	mux.Get("/keys", kithttp.NewServer(
+		kitot.TraceServer(tracer, "list_keys")(listKeysEndpoint(svc)),
+		decodeListKeys,
+		encodeResponse,
+		opts...,
+	))
+
+	mux.Delete("/keys", kithttp.NewServer(
+		kitot.TraceServer(tracer, "revoke_all")(revokeAllEndpoint(svc)),
+		decodeRevokeAll,
+		encodeResponse,
+		opts...,
+	))
+
 	mux.Get("/keys/:id", kithttp.NewServer(
 		kitot.TraceServer(tracer, "retrieve")(retrieveEndpoint(svc)),
 		decodeKeyReq,
@@ -53,6 +84,13 @@ func MakeHandler(svc authn.Service, tracer opentracing.Tracer) http.Handler {
 		opts...,
 	))
 
+	mux.Get("/.well-known/jwks.json", kithttp.NewServer(
+		kitot.TraceServer(tracer, "jwks")(jwksEndpoint(svc)),
+		decodeJWKS,
+		encodeResponse,
+		opts...,
+	))
+
 	mux.GetFunc("/version", mainflux.Version("auth"))
 	mux.Handle("/metrics", promhttp.Handler())
 
@@ -73,6 +111,10 @@ func decodeIssue(_ context.Context, r *http.Request) (interface{}, error) {
 	return req, nil
 }
 
+func decodeJWKS(_ context.Context, r *http.Request) (interface{}, error) {
+	return nil, nil
+}
+
 func decodeKeyReq(_ context.Context, r *http.Request) (interface{}, error) {
 	req := keyReq{
 		token: r.Header.Get("Authorization"),
@@ -81,6 +123,57 @@ func decodeKeyReq(_ context.Context, r *http.Request) (interface{}, error) {
 	return req, nil
 }
 
+func decodeListKeys(_ context.Context, r *http.Request) (interface{}, error) {
+	o, err := readUintQuery(r, offsetKey, defOffset)
+	if err != nil {
+		return nil, err
+	}
+
+	l, err := readUintQuery(r, limitKey, defLimit)
+	if err != nil {
+		return nil, err
+	}
+
+	s, err := readStringQuery(r, statusKey)
+	if err != nil {
+		return nil, err
+	}
+
+	req := listKeysReq{
+		token:  r.Header.Get("Authorization"),
+		offset: o,
+		limit:  l,
+		status: s,
+	}
+
+	if len(bone.GetQuery(r, typeKey)) > 0 {
+		t, err := readUintQuery(r, typeKey, 0)
+		if err != nil {
+			return nil, err
+		}
+		keyType := uint32(t)
+		if uint64(keyType) != t {
+			return nil, errInvalidQueryParams
+		}
+		req.keyType = &keyType
+	}
+
+	return req, nil
+}
+
+func decodeRevokeAll(_ context.Context, r *http.Request) (interface{}, error) {
+	s, err := readStringQuery(r, subjectKey)
+	if err != nil {
+		return nil, err
+	}
+
+	req := revokeAllReq{
+		token:   r.Header.Get("Authorization"),
+		subject: s,
+	}
+	return req, nil
+}
+
 func encodeResponse(_ context.Context, w http.ResponseWriter, response interface{}) error {
 	w.Header().Set("Content-Type", contentType)
 
@@ -103,7 +196,9 @@ func encodeError(_ context.Context, err error, w http.ResponseWriter) {
 	switch {
 	case errors.Contains(err, authn.ErrMalformedEntity):
 		w.WriteHeader(http.StatusBadRequest)
-	case errors.Contains(err, authn.ErrUnauthorizedAccess):
+	case errors.Contains(err, authn.ErrUnauthorizedAccess),
+		errors.Contains(err, authn.ErrKeyRevoked),
+		errors.Contains(err, authn.ErrDisabled):
 		w.WriteHeader(http.StatusForbidden)
 	case errors.Contains(err, authn.ErrNotFound):
 		w.WriteHeader(http.StatusNotFound)
@@ -113,6 +208,8 @@ func encodeError(_ context.Context, err error, w http.ResponseWriter) {
 		w.WriteHeader(http.StatusBadRequest)
 	case errors.Contains(err, io.ErrUnexpectedEOF):
 		w.WriteHeader(http.StatusBadRequest)
+	case errors.Contains(err, errInvalidQueryParams):
+		w.WriteHeader(http.StatusBadRequest)
 	case errors.Contains(err, errUnsupportedContentType):
 		w.WriteHeader(http.StatusUnsupportedMediaType)
 	default:
@@ -126,3 +223,34 @@ func encodeError(_ context.Context, err error, w http.ResponseWriter) {
 		}
 	}
 }
+
+func readUintQuery(r *http.Request, key string, def uint64) (uint64, error) {
+	vals := bone.GetQuery(r, key)
+	if len(vals) > 1 {
+		return 0, errInvalidQueryParams
+	}
+
+	if len(vals) == 0 {
+		return def, nil
+	}
+
+	val, err := strconv.ParseUint(vals[0], 10, 64)
+	if err != nil {
+		return 0, errInvalidQueryParams
+	}
+
+	return val, nil
+}
+
+func readStringQuery(r *http.Request, key string) (string, error) {
+	vals := bone.GetQuery(r, key)
+	if len(vals) > 1 {
+		return "", errInvalidQueryParams
+	}
+
+	if len(vals) == 0 {
+		return "", nil
+	}
+
+	return vals[0], nil
+}
@@ -69,6 +69,32 @@ func (lm *loggingMiddleware) Retrieve(ctx context.Context, token, id string) (ke
 	return lm.svc.Retrieve(ctx, token, id)
 }
 
+func (lm *loggingMiddleware) ListKeys(ctx context.Context, token string, pm authn.PageMetadata) (page authn.KeyPage, err error) {
+	defer func(begin time.Time) {
+		message := fmt.Sprintf("Method list_keys took %s to complete", time.Since(begin))
+		if err != nil {
+			lm.logger.Warn(fmt.Sprintf("%s with error: %s.", message, err))
+			return
+		}
+		lm.logger.Info(fmt.Sprintf("%s without errors.", message))
+	}(time.Now())
+
+	return lm.svc.ListKeys(ctx, token, pm)
+}
+
+func (lm *loggingMiddleware) RevokeAll(ctx context.Context, token, subject string) (err error) {
+	defer func(begin time.Time) {
+		message := fmt.Sprintf("Method revoke_all for subject %s took %s to complete", subject, time.Since(begin))
+		if err != nil {
+			lm.logger.Warn(fmt.Sprintf("%s with error: %s.", message, err))
+			return
+		}
+		lm.logger.Info(fmt.Sprintf("%s without errors.", message))
+	}(time.Now())
+
+	return lm.svc.RevokeAll(ctx, token, subject)
+}
+
 func (lm *loggingMiddleware) Identify(ctx context.Context, key string) (id authn.Identity, err error) {
 	defer func(begin time.Time) {
 		message := fmt.Sprintf("Method identify took %s to complete", time.Since(begin))
@@ -81,3 +107,94 @@ func (lm *loggingMiddleware) Identify(ctx context.Context, key string) (id authn
 
 	return lm.svc.Identify(ctx, key)
 }
+
+func (lm *loggingMiddleware) RevokeToken(ctx context.Context, token string) (err error) {
+	defer func(begin time.Time) {
+		message := fmt.Sprintf("Method revoke_token took %s to complete", time.Since(begin))
+		if err != nil {
+			lm.logger.Warn(fmt.Sprintf("%s with error: %s.", message, err))
+			return
+		}
+		lm.logger.Info(fmt.Sprintf("%s without errors.", message))
+	}(time.Now())
+
+	return lm.svc.RevokeToken(ctx, token)
+}
+
+func (lm *loggingMiddleware) RevokeSessions(ctx context.Context, token string) (err error) {
+	defer func(begin time.Time) {
+		message := fmt.Sprintf("Method revoke_sessions took %s to complete", time.Since(begin))
+		if err != nil {
+			lm.logger.Warn(fmt.Sprintf("%s with error: %s.", message, err))
+			return
+		}
+		lm.logger.Info(fmt.Sprintf("%s without errors.", message))
+	}(time.Now())
+
+	return lm.svc.RevokeSessions(ctx, token)
+}
+
+func (lm *loggingMiddleware) Refresh(ctx context.Context, token string) (access, refresh string, err error) {
+	defer func(begin time.Time) {
+		message := fmt.Sprintf("Method refresh took %s to complete", time.Since(begin))
+		if err != nil {
+			lm.logger.Warn(fmt.Sprintf("%s with error: %s.", message, err))
+			return
+		}
+		lm.logger.Info(fmt.Sprintf("%s without errors.", message))
+	}(time.Now())
+
+	return lm.svc.Refresh(ctx, token)
+}
+
+func (lm *loggingMiddleware) VerifyChallenge(ctx context.Context, token string) (id authn.Identity, err error) {
+	defer func(begin time.Time) {
+		message := fmt.Sprintf("Method verify_challenge took %s to complete", time.Since(begin))
+		if err != nil {
+			lm.logger.Warn(fmt.Sprintf("%s with error: %s.", message, err))
+			return
+		}
+		lm.logger.Info(fmt.Sprintf("%s without errors.", message))
+	}(time.Now())
+
+	return lm.svc.VerifyChallenge(ctx, token)
+}
+
+func (lm *loggingMiddleware) PublicKeys(ctx context.Context) (keys []authn.PublicKey, err error) {
+	defer func(begin time.Time) {
+		message := fmt.Sprintf("Method public_keys took %s to complete", time.Since(begin))
+		if err != nil {
+			lm.logger.Warn(fmt.Sprintf("%s with error: %s.", message, err))
+			return
+		}
+		lm.logger.Info(fmt.Sprintf("%s without errors.", message))
+	}(time.Now())
+
+	return lm.svc.PublicKeys(ctx)
+}
+
+func (lm *loggingMiddleware) Impersonate(ctx context.Context, token, id, email string) (key string, err error) {
+	defer func(begin time.Time) {
+		message := fmt.Sprintf("Method impersonate for user %s took %s to complete", id, time.Since(begin))
+		if err != nil {
+			lm.logger.Warn(fmt.Sprintf("%s with error: %s.", message, err))
+			return
+		}
+		lm.logger.Info(fmt.Sprintf("%s without errors.", message))
+	}(time.Now())
+
+	return lm.svc.Impersonate(ctx, token, id, email)
+}
+
+func (lm *loggingMiddleware) SetDisabled(ctx context.Context, token, id string, disabled bool) (err error) {
+	defer func(begin time.Time) {
+		message := fmt.Sprintf("Method set_disabled for user %s to %t took %s to complete", id, disabled, time.Since(begin))
+		if err != nil {
+			lm.logger.Warn(fmt.Sprintf("%s with error: %s.", message, err))
+			return
+		}
+		lm.logger.Info(fmt.Sprintf("%s without errors.", message))
+	}(time.Now())
+
+	return lm.svc.SetDisabled(ctx, token, id, disabled)
+}
@@ -56,6 +56,24 @@ func (ms *metricsMiddleware) Retrieve(ctx context.Context, token, id string) (au
 	return ms.svc.Retrieve(ctx, token, id)
 }
 
+func (ms *metricsMiddleware) ListKeys(ctx context.Context, token string, pm authn.PageMetadata) (authn.KeyPage, error) {
+	defer func(begin time.Time) {
+		ms.counter.With("method", "list_keys").Add(1)
+		ms.latency.With("method", "list_keys").Observe(time.Since(begin).Seconds())
+	}(time.Now())
+
+	return ms.svc.ListKeys(ctx, token, pm)
+}
+
+func (ms *metricsMiddleware) RevokeAll(ctx context.Context, token, subject string) error {
+	defer func(begin time.Time) {
+		ms.counter.With("method", "revoke_all").Add(1)
+		ms.latency.With("method", "revoke_all").Observe(time.Since(begin).Seconds())
+	}(time.Now())
+
+	return ms.svc.RevokeAll(ctx, token, subject)
+}
+
 func (ms *metricsMiddleware) Identify(ctx context.Context, token string) (authn.Identity, error) {
 	defer func(begin time.Time) {
 		ms.counter.With("method", "identify").Add(1)
@@ -64,3 +82,66 @@ func (ms *metricsMiddleware) Identify(ctx context.Context, token string) (authn.
 
 	return ms.svc.Identify(ctx, token)
 }
+
+func (ms *metricsMiddleware) RevokeToken(ctx context.Context, token string) error {
+	defer func(begin time.Time) {
+		ms.counter.With("method", "revoke_token").Add(1)
+		ms.latency.With("method", "revoke_token").Observe(time.Since(begin).Seconds())
+	}(time.Now())
+
+	return ms.svc.RevokeToken(ctx, token)
+}
+
+func (ms *metricsMiddleware) RevokeSessions(ctx context.Context, token string) error {
+	defer func(begin time.Time) {
+		ms.counter.With("method", "revoke_sessions").Add(1)
+		ms.latency.With("method", "revoke_sessions").Observe(time.Since(begin).Seconds())
+	}(time.Now())
+
+	return ms.svc.RevokeSessions(ctx, token)
+}
+
+func (ms *metricsMiddleware) Refresh(ctx context.Context, token string) (string, string, error) {
+	defer func(begin time.Time) {
+		ms.counter.With("method", "refresh").Add(1)
+		ms.latency.With("method", "refresh").Observe(time.Since(begin).Seconds())
+	}(time.Now())
+
+	return ms.svc.Refresh(ctx, token)
+}
+
+func (ms *metricsMiddleware) VerifyChallenge(ctx context.Context, token string) (authn.Identity, error) {
+	defer func(begin time.Time) {
+		ms.counter.With("method", "verify_challenge").Add(1)
+		ms.latency.With("method", "verify_challenge").Observe(time.Since(begin).Seconds())
+	}(time.Now())
+
+	return ms.svc.VerifyChallenge(ctx, token)
+}
+
+func (ms *metricsMiddleware) PublicKeys(ctx context.Context) ([]authn.PublicKey, error) {
+	defer func(begin time.Time) {
+		ms.counter.With("method", "public_keys").Add(1)
+		ms.latency.With("method", "public_keys").Observe(time.Since(begin).Seconds())
+	}(time.Now())
+
+	return ms.svc.PublicKeys(ctx)
+}
+
+func (ms *metricsMiddleware) Impersonate(ctx context.Context, token, id, email string) (string, error) {
+	defer func(begin time.Time) {
+		ms.counter.With("method", "impersonate").Add(1)
+		ms.latency.With("method", "impersonate").Observe(time.Since(begin).Seconds())
+	}(time.Now())
+
+	return ms.svc.Impersonate(ctx, token, id, email)
+}
+
+func (ms *metricsMiddleware) SetDisabled(ctx context.Context, token, id string, disabled bool) error {
+	defer func(begin time.Time) {
+		ms.counter.With("method", "set_disabled").Add(1)
+		ms.latency.With("method", "set_disabled").Observe(time.Since(begin).Seconds())
+	}(time.Now())
+
+	return ms.svc.SetDisabled(ctx, token, id, disabled)
+}
@@ -4,7 +4,16 @@
 package jwt_test
 
 import (
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/x509"
+	"encoding/pem"
 	"fmt"
+	"io/ioutil"
+	"os"
+	"path/filepath"
 	"testing"
 	"time"
 
@@ -67,6 +76,20 @@ func TestParse(t *testing.T) {
 	expToken, err := tokenizer.Issue(expKey)
 	require.Nil(t, err, fmt.Sprintf("issuing expired key expected to succeed: %s", err))
 
+	scopedKey := key()
+	scopedKey.Type = authn.APIKey
+	scopedKey.Scopes = []authn.Scope{
+		{Resource: "things", Action: "read"},
+		{Resource: "messages", Action: "read", IDs: []string{"1", "2"}},
+	}
+	scopedToken, err := tokenizer.Issue(scopedKey)
+	require.Nil(t, err, fmt.Sprintf("issuing scoped key expected to succeed: %s", err))
+
+	refreshKey := key()
+	refreshKey.Type = authn.RefreshKey
+	refreshToken, err := tokenizer.Issue(refreshKey)
+	require.Nil(t, err, fmt.Sprintf("issuing refresh key expected to succeed: %s", err))
+
 	cases := []struct {
 		desc  string
 		key   authn.Key
@@ -98,6 +121,18 @@ func TestParse(t *testing.T) {
 			token: userToken,
 			err:   nil,
 		},
+		{
+			desc:  "parse scoped key",
+			key:   scopedKey,
+			token: scopedToken,
+			err:   nil,
+		},
+		{
+			desc:  "parse refresh key",
+			key:   refreshKey,
+			token: refreshToken,
+			err:   nil,
+		},
 	}
 
 	for _, tc := range cases {
@@ -106,3 +141,187 @@ func TestParse(t *testing.T) {
 		assert.Equal(t, tc.key, key, fmt.Sprintf("%s expected %v, got %v", tc.desc, tc.key, key))
 	}
 }
+
+func TestAsymmetric(t *testing.T) {
+	rsaKey, err := rsa.GenerateKey(rand.Reader, 2048)
+	require.Nil(t, err, fmt.Sprintf("generating RSA key expected to succeed: %s", err))
+	ecKey, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	require.Nil(t, err, fmt.Sprintf("generating EC key expected to succeed: %s", err))
+	keys := []jwt.SigningKey{
+		{ID: "rsa", Key: rsaKey},
+		{ID: "ec", Key: ecKey},
+	}
+
+	rsaTokenizer, err := jwt.NewAsymmetric(keys, "rsa", "")
+	require.Nil(t, err, fmt.Sprintf("creating tokenizer expected to succeed: %s", err))
+	rsaToken, err := rsaTokenizer.Issue(key())
+	require.Nil(t, err, fmt.Sprintf("issuing key expected to succeed: %s", err))
+
+	ecTokenizer, err := jwt.NewAsymmetric(keys, "ec", secret)
+	require.Nil(t, err, fmt.Sprintf("creating tokenizer expected to succeed: %s", err))
+	ecToken, err := ecTokenizer.Issue(key())
+	require.Nil(t, err, fmt.Sprintf("issuing key expected to succeed: %s", err))
+
+	hmacToken, err := jwt.New(secret).Issue(key())
+	require.Nil(t, err, fmt.Sprintf("issuing key expected to succeed: %s", err))
+
+	otherKey, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	require.Nil(t, err, fmt.Sprintf("generating EC key expected to succeed: %s", err))
+	otherTokenizer, err := jwt.NewAsymmetric([]jwt.SigningKey{{ID: "rsa", Key: otherKey}}, "rsa", "")
+	require.Nil(t, err, fmt.Sprintf("creating tokenizer expected to succeed: %s", err))
+	otherToken, err := otherTokenizer.Issue(key())
+	require.Nil(t, err, fmt.Sprintf("issuing key expected to succeed: %s", err))
+
+	cases := []struct {
+		desc      string
+		tokenizer authn.Tokenizer
+		token     string
+		key       authn.Key
+		err       error
+	}{
+		{
+			desc:      "parse RS256 token",
+			tokenizer: rsaTokenizer,
+			token:     rsaToken,
+			key:       key(),
+			err:       nil,
+		},
+		{
+			desc:      "parse ES256 token",
+			tokenizer: rsaTokenizer,
+			token:     ecToken,
+			key:       key(),
+			err:       nil,
+		},
+		{
+			desc:      "parse token signed by the previous signing key",
+			tokenizer: ecTokenizer,
+			token:     rsaToken,
+			key:       key(),
+			err:       nil,
+		},
+		{
+			desc:      "parse HMAC token with secret",
+			tokenizer: ecTokenizer,
+			token:     hmacToken,
+			key:       key(),
+			err:       nil,
+		},
+		{
+			desc:      "parse HMAC token without secret",
+			tokenizer: rsaTokenizer,
+			token:     hmacToken,
+			key:       authn.Key{},
+			err:       authn.ErrUnauthorizedAccess,
+		},
+		{
+			desc:      "parse token signed by unknown key",
+			tokenizer: rsaTokenizer,
+			token:     otherToken,
+			key:       authn.Key{},
+			err:       authn.ErrUnauthorizedAccess,
+		},
+		{
+			desc:      "parse asymmetric token using HMAC tokenizer",
+			tokenizer: jwt.New(secret),
+			token:     rsaToken,
+			key:       authn.Key{},
+			err:       authn.ErrUnauthorizedAccess,
+		},
+	}
+
+	for _, tc := range cases {
+		key, err := tc.tokenizer.Parse(tc.token)
+		assert.True(t, errors.Contains(err, tc.err), fmt.Sprintf("%s expected %s, got %s", tc.desc, tc.err, err))
+		assert.Equal(t, tc.key, key, fmt.Sprintf("%s expected %v, got %v", tc.desc, tc.key, key))
+	}
+
+	pks := ecTokenizer.PublicKeys()
+	require.Len(t, pks, len(keys), fmt.Sprintf("expected %d public keys got %d", len(keys), len(pks)))
+	assert.Equal(t, authn.PublicKey{ID: "ec", Algorithm: "ES256", Key: &ecKey.PublicKey}, pks[0], "expected EC public key")
+	assert.Equal(t, authn.PublicKey{ID: "rsa", Algorithm: "RS256", Key: &rsaKey.PublicKey}, pks[1], "expected RSA public key")
+	assert.Empty(t, jwt.New(secret).PublicKeys(), "expected no public keys for HMAC tokenizer")
+}
+
+func TestNewAsymmetric(t *testing.T) {
+	ecKey, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	require.Nil(t, err, fmt.Sprintf("generating EC key expected to succeed: %s", err))
+	p384Key, err := ecdsa.GenerateKey(elliptic.P384(), rand.Reader)
+	require.Nil(t, err, fmt.Sprintf("generating EC key expected to succeed: %s", err))
+
+	cases := []struct {
+		desc string
+		keys []jwt.SigningKey
+		id   string
+		err  error
+	}{
+		{
+			desc: "create tokenizer",
+			keys: []jwt.SigningKey{{ID: "ec", Key: ecKey}},
+			id:   "ec",
+			err:  nil,
+		},
+		{
+			desc: "create tokenizer with unknown signing key",
+			keys: []jwt.SigningKey{{ID: "ec", Key: ecKey}},
+			id:   "rsa",
+			err:  jwt.ErrSigningKeyNotFound,
+		},
+		{
+			desc: "create tokenizer with unsupported curve",
+			keys: []jwt.SigningKey{{ID: "ec", Key: p384Key}},
+			id:   "ec",
+			err:  jwt.ErrUnsupportedKey,
+		},
+		{
+			desc: "create tokenizer with unsupported key type",
+			keys: []jwt.SigningKey{{ID: "hmac", Key: []byte(secret)}},
+			id:   "hmac",
+			err:  jwt.ErrUnsupportedKey,
+		},
+	}
+
+	for _, tc := range cases {
+		_, err := jwt.NewAsymmetric(tc.keys, tc.id, "")
+		assert.True(t, errors.Contains(err, tc.err), fmt.Sprintf("%s expected %s, got %s", tc.desc, tc.err, err))
+	}
+}
+
+func TestLoadKeys(t *testing.T) {
+	dir, err := ioutil.TempDir("", "authn")
+	require.Nil(t, err, fmt.Sprintf("unexpected error: %s", err))
+	defer os.RemoveAll(dir)
+
+	rsaKey, err := rsa.GenerateKey(rand.Reader, 2048)
+	require.Nil(t, err, fmt.Sprintf("generating RSA key expected to succeed: %s", err))
+	ecKey, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	require.Nil(t, err, fmt.Sprintf("generating EC key expected to succeed: %s", err))
+	ecDER, err := x509.MarshalECPrivateKey(ecKey)
+	require.Nil(t, err, fmt.Sprintf("encoding EC key expected to succeed: %s", err))
+
+	files := map[string][]byte{
+		"2021-01.pem": pem.EncodeToMemory(&pem.Block{Type: "RSA PRIVATE KEY", Bytes: x509.MarshalPKCS1PrivateKey(rsaKey)}),
+		"2021-02.pem": pem.EncodeToMemory(&pem.Block{Type: "EC PRIVATE KEY", Bytes: ecDER}),
+		"README.md":   []byte("not a key"),
+	}
+	for name, data := range files {
+		err := ioutil.WriteFile(filepath.Join(dir, name), data, 0600)
+		require.Nil(t, err, fmt.Sprintf("unexpected error: %s", err))
+	}
+
+	keys, err := jwt.LoadKeys(dir)
+	require.Nil(t, err, fmt.Sprintf("loading keys expected to succeed: %s", err))
+	expected := []jwt.SigningKey{
+		{ID: "2021-01", Key: rsaKey},
+		{ID: "2021-02", Key: ecKey},
+	}
+	assert.Equal(t, len(expected), len(keys), fmt.Sprintf("expected %d keys got %d", len(expected), len(keys)))
+	for i, k := range keys {
+		assert.Equal(t, expected[i].ID, k.ID, fmt.Sprintf("expected key ID %s got %s", expected[i].ID, k.ID))
+	}
+
+	err = ioutil.WriteFile(filepath.Join(dir, "invalid.pem"), []byte("not a key"), 0600)
+	require.Nil(t, err, fmt.Sprintf("unexpected error: %s", err))
+	_, err = jwt.LoadKeys(dir)
+	assert.True(t, errors.Contains(err, jwt.ErrUnsupportedKey), fmt.Sprintf("expected %s, got %s", jwt.ErrUnsupportedKey, err))
+}
@@ -4,6 +4,14 @@
 package jwt
 
 import (
+	"crypto"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rsa"
+	"io/ioutil"
+	"path/filepath"
+	"sort"
+	"strings"
 	"time"
 
 	"github.com/dgrijalva/jwt-go"
@@ -11,31 +19,142 @@ import (
 	"github.com/mainflux/mainflux/pkg/errors"
 )
 
-const issuerName = "mainflux.authn"
+const (
+	issuerName  = "mainflux.authn"
+	keyIDHeader = "kid"
+	keyFileExt  = ".pem"
+)
+
+var (
+	// ErrUnsupportedKey indicates that the signing key is neither RSA
+	// nor ECDSA P-256 private key.
+	ErrUnsupportedKey = errors.New("unsupported signing key")
+
+	// ErrSigningKeyNotFound indicates that there is no signing key with
+	// the ID that is supposed to be used for signing.
+	ErrSigningKeyNotFound = errors.New("signing key not found")
+
+	errLoadKeys = errors.New("failed to load signing keys")
+)
+
+// SigningKey represents the private key used to sign tokens. Key ID is set
+// in the "kid" header of the signed tokens, so the key used for verification
+// can be looked up after the signing key is rotated.
+type SigningKey struct {
+	ID  string
+	Key crypto.PrivateKey
+}
 
 type claims struct {
 	jwt.StandardClaims
-	IssuerID string  `json:"issuer_id,omitempty"`
-	Type     *uint32 `json:"type,omitempty"`
+	IssuerID     string        `json:"issuer_id,omitempty"`
+	Type         *uint32       `json:"type,omitempty"`
+	Scopes       []authn.Scope `json:"scopes,omitempty"`
+	Impersonator string        `json:"impersonator,omitempty"`
 }
 
 func (c claims) Valid() error {
-	if c.Type == nil || *c.Type > authn.APIKey || c.Issuer != issuerName {
+	if c.Type == nil || *c.Type > authn.ChallengeKey || c.Issuer != issuerName {
 		return authn.ErrMalformedEntity
 	}
 
 	return c.StandardClaims.Valid()
 }
 
+type signingKey struct {
+	id      string
+	method  jwt.SigningMethod
+	private crypto.PrivateKey
+	public  crypto.PublicKey
+}
+
 type tokenizer struct {
-	secret string
+	secret  string
+	signing *signingKey
+	keys    map[string]signingKey
 }
 
-// New returns new JWT Tokenizer.
+// New returns new JWT Tokenizer which signs tokens using HMAC with the
+// provided secret.
 func New(secret string) authn.Tokenizer {
 	return tokenizer{secret: secret}
 }
 
+// NewAsymmetric returns new JWT Tokenizer which signs tokens using the RSA
+// (RS256) or ECDSA (ES256) key identified by the provided signing key ID.
+// Tokens signed by any of the provided keys are valid, so the signing key
+// can be rotated without invalidating the tokens signed by the previous one.
+// If secret is not empty, tokens signed using HMAC with that secret are
+// valid as well, which allows migration from the HMAC tokenizer.
+func NewAsymmetric(keys []SigningKey, signingKeyID, secret string) (authn.Tokenizer, error) {
+	t := tokenizer{
+		secret: secret,
+		keys:   make(map[string]signingKey),
+	}
+
+	for _, k := range keys {
+		sk := signingKey{
+			id:      k.ID,
+			private: k.Key,
+		}
+		switch key := k.Key.(type) {
+		case *rsa.PrivateKey:
+			sk.method = jwt.SigningMethodRS256
+			sk.public = &key.PublicKey
+		case *ecdsa.PrivateKey:
+			if key.Curve != elliptic.P256() {
+				return nil, ErrUnsupportedKey
+			}
+			sk.method = jwt.SigningMethodES256
+			sk.public = &key.PublicKey
+		default:
+			return nil, ErrUnsupportedKey
+		}
+		t.keys[k.ID] = sk
+	}
+
+	sk, ok := t.keys[signingKeyID]
+	if !ok {
+		return nil, ErrSigningKeyNotFound
+	}
+	t.signing = &sk
+
+	return t, nil
+}
+
+// LoadKeys loads PEM encoded RSA and ECDSA private keys from the files with
+// .pem extension in the provided directory. File name without extension is
+// used as the key ID.
+func LoadKeys(dir string) ([]SigningKey, error) {
+	paths, err := filepath.Glob(filepath.Join(dir, "*"+keyFileExt))
+	if err != nil {
+		return nil, errors.Wrap(errLoadKeys, err)
+	}
+
+	var keys []SigningKey
+	for _, path := range paths {
+		data, err := ioutil.ReadFile(path)
+		if err != nil {
+			return nil, errors.Wrap(errLoadKeys, err)
+		}
+
+		var key crypto.PrivateKey
+		key, err = jwt.ParseRSAPrivateKeyFromPEM(data)
+		if err != nil {
+			if key, err = jwt.ParseECPrivateKeyFromPEM(data); err != nil {
+				return nil, errors.Wrap(ErrUnsupportedKey, err)
+			}
+		}
+
+		keys = append(keys, SigningKey{
+			ID:  strings.TrimSuffix(filepath.Base(path), keyFileExt),
+			Key: key,
+		})
+	}
+
+	return keys, nil
+}
+
 func (svc tokenizer) Issue(key authn.Key) (string, error) {
 	claims := claims{
 		StandardClaims: jwt.StandardClaims{
@@ -43,8 +162,10 @@ func (svc tokenizer) Issue(key authn.Key) (string, error) {
 			Subject:  key.Subject,
 			IssuedAt: key.IssuedAt.UTC().Unix(),
 		},
-		IssuerID: key.IssuerID,
-		Type:     &key.Type,
+		IssuerID:     key.IssuerID,
+		Type:         &key.Type,
+		Scopes:       key.Scopes,
+		Impersonator: key.Impersonator,
 	}
 
 	if !key.ExpiresAt.IsZero() {
@@ -54,18 +175,19 @@ func (svc tokenizer) Issue(key authn.Key) (string, error) {
 		claims.Id = key.ID
 	}
 
-	token := jwt.NewWithClaims(jwt.SigningMethodHS256, claims)
-	return token.SignedString([]byte(svc.secret))
+	if svc.signing == nil {
+		token := jwt.NewWithClaims(jwt.SigningMethodHS256, claims)
+		return token.SignedString([]byte(svc.secret))
+	}
+
+	token := jwt.NewWithClaims(svc.signing.method, claims)
+	token.Header[keyIDHeader] = svc.signing.id
+	return token.SignedString(svc.signing.private)
 }
 
 func (svc tokenizer) Parse(token string) (authn.Key, error) {
 	c := claims{}
-	_, err := jwt.ParseWithClaims(token, &c, func(token *jwt.Token) (interface{}, error) {
-		if _, ok := token.Method.(*jwt.SigningMethodHMAC); !ok {
-			return nil, authn.ErrUnauthorizedAccess
-		}
-		return []byte(svc.secret), nil
-	})
+	_, err := jwt.ParseWithClaims(token, &c, svc.verificationKey)
 
 	if err != nil {
 		if e, ok := err.(*jwt.ValidationError); ok && e.Errors == jwt.ValidationErrorExpired {
@@ -81,12 +203,51 @@ func (svc tokenizer) Parse(token string) (authn.Key, error) {
 	return c.toKey(), nil
 }
 
+func (svc tokenizer) PublicKeys() []authn.PublicKey {
+	keys := []authn.PublicKey{}
+	for _, k := range svc.keys {
+		keys = append(keys, authn.PublicKey{
+			ID:        k.id,
+			Algorithm: k.method.Alg(),
+			Key:       k.public,
+		})
+	}
+	sort.Slice(keys, func(i, j int) bool { return keys[i].ID < keys[j].ID })
+
+	return keys
+}
+
+// verificationKey looks up the key used to verify the token. HMAC signed
+// tokens are verified using the secret, if any. Asymmetric signed tokens
+// are verified using the public key identified by the "kid" header, which
+// must match the algorithm the token is signed with.
+func (svc tokenizer) verificationKey(token *jwt.Token) (interface{}, error) {
+	switch token.Method.(type) {
+	case *jwt.SigningMethodHMAC:
+		if svc.secret == "" {
+			return nil, authn.ErrUnauthorizedAccess
+		}
+		return []byte(svc.secret), nil
+	case *jwt.SigningMethodRSA, *jwt.SigningMethodECDSA:
+		kid, _ := token.Header[keyIDHeader].(string)
+		k, ok := svc.keys[kid]
+		if !ok || k.method.Alg() != token.Method.Alg() {
+			return nil, authn.ErrUnauthorizedAccess
+		}
+		return k.public, nil
+	default:
+		return nil, authn.ErrUnauthorizedAccess
+	}
+}
+
 func (c claims) toKey() authn.Key {
 	key := authn.Key{
-		ID:       c.Id,
-		IssuerID: c.IssuerID,
-		Subject:  c.Subject,
-		IssuedAt: time.Unix(c.IssuedAt, 0).UTC(),
+		ID:           c.Id,
+		IssuerID:     c.IssuerID,
+		Subject:      c.Subject,
+		IssuedAt:     time.Unix(c.IssuedAt, 0).UTC(),
+		Scopes:       c.Scopes,
+		Impersonator: c.Impersonator,
 	}
 	if c.ExpiresAt != 0 {
 		key.ExpiresAt = time.Unix(c.ExpiresAt, 0).UTC()
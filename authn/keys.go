@@ -7,6 +7,8 @@ import (
 	"context"
 	"errors"
 	"time"
+
+	"github.com/mainflux/mainflux"
 )
 
 var (
@@ -24,22 +26,104 @@ const (
 	RecoveryKey
 	// APIKey enables the one to act on behalf of the user.
 	APIKey
+	// RefreshKey is a long-lived, single-use key received on successful
+	// login alongside the user key, used to obtain a new pair of keys.
+	RefreshKey
+	// ChallengeKey is a short-lived, single-use key received on login of
+	// the user with two-factor authentication enabled. It is exchanged for
+	// the user key once the second factor is verified.
+	ChallengeKey
+)
+
+const (
+	// ActiveKeys filters the keys that are not expired.
+	ActiveKeys = "active"
+	// ExpiredKeys filters the expired keys.
+	ExpiredKeys = "expired"
 )
 
 // Key represents API key.
 type Key struct {
-	ID        string
-	Type      uint32
-	IssuerID  string
-	Subject   string
-	IssuedAt  time.Time
-	ExpiresAt time.Time
+	ID         string
+	Type       uint32
+	IssuerID   string
+	Subject    string
+	IssuedAt   time.Time
+	ExpiresAt  time.Time
+	LastUsedAt time.Time
+	Scopes     []Scope
+	// Impersonator is the email of the admin the user key is issued to
+	// on behalf of the subject. It's empty for the regular keys.
+	Impersonator string
+}
+
+// PageMetadata contains the parameters used for listing the keys. Type
+// and status (active or expired) filter the keys only if set.
+type PageMetadata struct {
+	Offset uint64
+	Limit  uint64
+	Type   *uint32
+	Status string
 }
 
-// Identity contains ID and Email.
+// KeyPage contains a page of keys.
+type KeyPage struct {
+	Total  uint64
+	Offset uint64
+	Limit  uint64
+	Keys   []Key
+}
+
+// Scope restricts the actions the API key can be used for to the action
+// on the resources of the given type. If IDs are provided, only resources
+// with the listed IDs are covered by the scope. Key without scopes can be
+// used for any action its issuer is allowed to perform.
+type Scope struct {
+	Resource string   `json:"resource"`
+	Action   string   `json:"action"`
+	IDs      []string `json:"ids,omitempty"`
+}
+
+// Identity contains ID, Email and scopes of the key used for
+// identification, as well as the ID of the key itself. Admin is set only
+// for the user key of the platform admin, and Impersonator for the user key
+// issued to the admin on behalf of another user.
 type Identity struct {
-	ID    string
-	Email string
+	ID           string
+	Email        string
+	Scopes       []Scope
+	Admin        bool
+	Impersonator string
+	KeyID        string
+}
+
+var (
+	resources = map[string]bool{
+		mainflux.ThingsResource:   true,
+		mainflux.ChannelsResource: true,
+		mainflux.GroupsResource:   true,
+		mainflux.MessagesResource: true,
+		mainflux.TwinsResource:    true,
+		mainflux.ConfigsResource:  true,
+	}
+	actions = map[string]bool{
+		mainflux.ReadAction:  true,
+		mainflux.WriteAction: true,
+	}
+)
+
+// Validate checks if the scope refers to the known resource and action.
+func (s Scope) Validate() error {
+	if !resources[s.Resource] || !actions[s.Action] {
+		return ErrMalformedEntity
+	}
+	for _, id := range s.IDs {
+		if id == "" {
+			return ErrMalformedEntity
+		}
+	}
+
+	return nil
 }
 
 // Expired verifies if the key is expired.
@@ -61,4 +145,16 @@ type KeyRepository interface {
 
 	// Remove removes Key with provided ID.
 	Remove(context.Context, string, string) error
+
+	// RetrieveAll retrieves a subset of the keys issued by the issuer with
+	// the provided ID.
+	RetrieveAll(ctx context.Context, issuerID string, pm PageMetadata) (KeyPage, error)
+
+	// RemoveAll removes all of the keys issued by the issuer with the
+	// provided ID. If subject is not empty, only the keys issued for the
+	// subject are removed.
+	RemoveAll(ctx context.Context, issuerID, subject string) error
+
+	// UpdateLastUsed sets the time the Key with provided ID was last used.
+	UpdateLastUsed(ctx context.Context, issuerID, id string, t time.Time) error
 }
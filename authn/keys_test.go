@@ -8,6 +8,7 @@ import (
 	"testing"
 	"time"
 
+	"github.com/mainflux/mainflux"
 	"github.com/mainflux/mainflux/authn"
 	"github.com/stretchr/testify/assert"
 )
@@ -58,3 +59,42 @@ func TestExpired(t *testing.T) {
 		assert.Equal(t, tc.expired, res, fmt.Sprintf("%s: expected %t got %t\n", tc.desc, tc.expired, res))
 	}
 }
+
+func TestScopeValidate(t *testing.T) {
+	cases := []struct {
+		desc  string
+		scope authn.Scope
+		err   error
+	}{
+		{
+			desc:  "validate scope without IDs",
+			scope: authn.Scope{Resource: mainflux.ThingsResource, Action: mainflux.ReadAction},
+			err:   nil,
+		},
+		{
+			desc:  "validate scope with IDs",
+			scope: authn.Scope{Resource: mainflux.MessagesResource, Action: mainflux.ReadAction, IDs: []string{"1", "2"}},
+			err:   nil,
+		},
+		{
+			desc:  "validate scope with unknown resource",
+			scope: authn.Scope{Resource: "users", Action: mainflux.ReadAction},
+			err:   authn.ErrMalformedEntity,
+		},
+		{
+			desc:  "validate scope with unknown action",
+			scope: authn.Scope{Resource: mainflux.ChannelsResource, Action: "delete"},
+			err:   authn.ErrMalformedEntity,
+		},
+		{
+			desc:  "validate scope with empty ID",
+			scope: authn.Scope{Resource: mainflux.ChannelsResource, Action: mainflux.WriteAction, IDs: []string{""}},
+			err:   authn.ErrMalformedEntity,
+		},
+	}
+
+	for _, tc := range cases {
+		err := tc.scope.Validate()
+		assert.Equal(t, tc.err, err, fmt.Sprintf("%s: expected %s got %s\n", tc.desc, tc.err, err))
+	}
+}
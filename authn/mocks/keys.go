@@ -5,7 +5,9 @@ package mocks
 
 import (
 	"context"
+	"sort"
 	"sync"
+	"time"
 
 	"github.com/mainflux/mainflux/authn"
 )
@@ -53,3 +55,67 @@ func (krm *keyRepositoryMock) Remove(ctx context.Context, issuerID, id string) e
 	}
 	return nil
 }
+
+func (krm *keyRepositoryMock) RetrieveAll(ctx context.Context, issuerID string, pm authn.PageMetadata) (authn.KeyPage, error) {
+	krm.mu.Lock()
+	defer krm.mu.Unlock()
+
+	keys := []authn.Key{}
+	for _, key := range krm.keys {
+		if key.IssuerID != issuerID {
+			continue
+		}
+		if pm.Type != nil && key.Type != *pm.Type {
+			continue
+		}
+		if (pm.Status == authn.ActiveKeys && key.Expired()) || (pm.Status == authn.ExpiredKeys && !key.Expired()) {
+			continue
+		}
+		keys = append(keys, key)
+	}
+	sort.SliceStable(keys, func(i, j int) bool {
+		return keys[i].ID < keys[j].ID
+	})
+
+	page := authn.KeyPage{
+		Total:  uint64(len(keys)),
+		Offset: pm.Offset,
+		Limit:  pm.Limit,
+		Keys:   []authn.Key{},
+	}
+	if pm.Offset >= uint64(len(keys)) {
+		return page, nil
+	}
+	end := pm.Offset + pm.Limit
+	if end > uint64(len(keys)) {
+		end = uint64(len(keys))
+	}
+	page.Keys = keys[pm.Offset:end]
+
+	return page, nil
+}
+
+func (krm *keyRepositoryMock) RemoveAll(ctx context.Context, issuerID, subject string) error {
+	krm.mu.Lock()
+	defer krm.mu.Unlock()
+
+	for id, key := range krm.keys {
+		if key.IssuerID == issuerID && (subject == "" || key.Subject == subject) {
+			delete(krm.keys, id)
+		}
+	}
+	return nil
+}
+
+func (krm *keyRepositoryMock) UpdateLastUsed(ctx context.Context, issuerID, id string, t time.Time) error {
+	krm.mu.Lock()
+	defer krm.mu.Unlock()
+
+	key, ok := krm.keys[id]
+	if !ok || key.IssuerID != issuerID {
+		return authn.ErrNotFound
+	}
+	key.LastUsedAt = t
+	krm.keys[id] = key
+	return nil
+}
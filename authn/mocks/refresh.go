@@ -0,0 +1,73 @@
+// Copyright (c) Mainflux
+// SPDX-License-Identifier: Apache-2.0
+
+package mocks
+
+import (
+	"context"
+	"sync"
+
+	"github.com/mainflux/mainflux/authn"
+)
+
+var _ authn.RefreshRepository = (*refreshRepositoryMock)(nil)
+
+type refreshRepositoryMock struct {
+	mu     sync.Mutex
+	tokens map[string]authn.RefreshToken
+	used   map[string]bool
+}
+
+// NewRefreshRepository creates in-memory refresh repository.
+func NewRefreshRepository() authn.RefreshRepository {
+	return &refreshRepositoryMock{
+		tokens: make(map[string]authn.RefreshToken),
+		used:   make(map[string]bool),
+	}
+}
+
+func (rrm *refreshRepositoryMock) Save(_ context.Context, token authn.RefreshToken) error {
+	rrm.mu.Lock()
+	defer rrm.mu.Unlock()
+
+	if _, ok := rrm.tokens[token.ID]; ok {
+		return authn.ErrConflict
+	}
+
+	rrm.tokens[token.ID] = token
+	return nil
+}
+
+func (rrm *refreshRepositoryMock) Use(_ context.Context, id string) (authn.RefreshToken, error) {
+	rrm.mu.Lock()
+	defer rrm.mu.Unlock()
+
+	token, ok := rrm.tokens[id]
+	if !ok {
+		return authn.RefreshToken{}, authn.ErrNotFound
+	}
+	if rrm.used[id] {
+		return token, authn.ErrKeyReused
+	}
+
+	rrm.used[id] = true
+	return token, nil
+}
+
+func (rrm *refreshRepositoryMock) RemoveFamily(_ context.Context, id string) error {
+	rrm.mu.Lock()
+	defer rrm.mu.Unlock()
+
+	token, ok := rrm.tokens[id]
+	if !ok {
+		return authn.ErrNotFound
+	}
+
+	for k, t := range rrm.tokens {
+		if t.FamilyID == token.FamilyID {
+			delete(rrm.tokens, k)
+			delete(rrm.used, k)
+		}
+	}
+	return nil
+}
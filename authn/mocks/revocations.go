@@ -0,0 +1,80 @@
+// Copyright (c) Mainflux
+// SPDX-License-Identifier: Apache-2.0
+
+package mocks
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	"github.com/mainflux/mainflux/authn"
+)
+
+var _ authn.RevocationRepository = (*revocationRepositoryMock)(nil)
+
+type revocationRepositoryMock struct {
+	mu       sync.Mutex
+	revoked  map[string]time.Time
+	cutoffs  map[string]time.Time
+	disabled map[string]bool
+}
+
+// NewRevocationRepository creates in-memory revocation repository.
+func NewRevocationRepository() authn.RevocationRepository {
+	return &revocationRepositoryMock{
+		revoked:  make(map[string]time.Time),
+		cutoffs:  make(map[string]time.Time),
+		disabled: make(map[string]bool),
+	}
+}
+
+func (rrm *revocationRepositoryMock) Save(_ context.Context, id string, expiresAt time.Time) error {
+	rrm.mu.Lock()
+	defer rrm.mu.Unlock()
+
+	rrm.revoked[id] = expiresAt
+	return nil
+}
+
+func (rrm *revocationRepositoryMock) Contains(_ context.Context, id string) (bool, error) {
+	rrm.mu.Lock()
+	defer rrm.mu.Unlock()
+
+	_, ok := rrm.revoked[id]
+	return ok, nil
+}
+
+func (rrm *revocationRepositoryMock) SaveCutoff(_ context.Context, issuerID string, t time.Time) error {
+	rrm.mu.Lock()
+	defer rrm.mu.Unlock()
+
+	rrm.cutoffs[issuerID] = t
+	return nil
+}
+
+func (rrm *revocationRepositoryMock) RetrieveCutoff(_ context.Context, issuerID string) (time.Time, error) {
+	rrm.mu.Lock()
+	defer rrm.mu.Unlock()
+
+	return rrm.cutoffs[issuerID], nil
+}
+
+func (rrm *revocationRepositoryMock) SaveDisabled(_ context.Context, issuerID string, disabled bool) error {
+	rrm.mu.Lock()
+	defer rrm.mu.Unlock()
+
+	if !disabled {
+		delete(rrm.disabled, issuerID)
+		return nil
+	}
+	rrm.disabled[issuerID] = true
+	return nil
+}
+
+func (rrm *revocationRepositoryMock) Disabled(_ context.Context, issuerID string) (bool, error) {
+	rrm.mu.Lock()
+	defer rrm.mu.Unlock()
+
+	return rrm.disabled[issuerID], nil
+}
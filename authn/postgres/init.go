@@ -72,6 +72,71 @@ func migrateDB(db *sqlx.DB) error {
 					`ALTER TABLE IF EXISTS keys DROP COLUMN issuer_id`,
 				},
 			},
+			{
+				Id: "authn_3",
+				Up: []string{
+					`ALTER TABLE IF EXISTS keys ADD COLUMN IF NOT EXISTS scopes JSONB`,
+				},
+				Down: []string{
+					`ALTER TABLE IF EXISTS keys DROP COLUMN scopes`,
+				},
+			},
+			{
+				Id: "authn_4",
+				Up: []string{
+					`ALTER TABLE IF EXISTS keys ADD COLUMN IF NOT EXISTS last_used_at TIMESTAMP`,
+				},
+				Down: []string{
+					`ALTER TABLE IF EXISTS keys DROP COLUMN last_used_at`,
+				},
+			},
+			{
+				Id: "authn_5",
+				Up: []string{
+					`CREATE TABLE IF NOT EXISTS revoked_keys (
+						id         VARCHAR(254) PRIMARY KEY,
+						expires_at TIMESTAMP NOT NULL
+					)`,
+					`CREATE TABLE IF NOT EXISTS session_cutoffs (
+						issuer_id  UUID PRIMARY KEY,
+						revoked_at TIMESTAMP NOT NULL
+					)`,
+				},
+				Down: []string{
+					`DROP TABLE IF EXISTS revoked_keys`,
+					`DROP TABLE IF EXISTS session_cutoffs`,
+				},
+			},
+			{
+				Id: "authn_6",
+				Up: []string{
+					`CREATE TABLE IF NOT EXISTS refresh_tokens (
+						id         UUID PRIMARY KEY,
+						family_id  UUID NOT NULL,
+						issuer_id  UUID NOT NULL,
+						subject    VARCHAR(254) NOT NULL,
+						issued_at  TIMESTAMP NOT NULL,
+						expires_at TIMESTAMP NOT NULL,
+						used       BOOLEAN NOT NULL DEFAULT FALSE
+					)`,
+					`CREATE INDEX IF NOT EXISTS refresh_tokens_family_id ON refresh_tokens (family_id)`,
+				},
+				Down: []string{
+					`DROP TABLE IF EXISTS refresh_tokens`,
+				},
+			},
+			{
+				Id: "authn_7",
+				Up: []string{
+					`CREATE TABLE IF NOT EXISTS disabled_issuers (
+						issuer_id   UUID PRIMARY KEY,
+						disabled_at TIMESTAMP NOT NULL
+					)`,
+				},
+				Down: []string{
+					`DROP TABLE IF EXISTS disabled_issuers`,
+				},
+			},
 		},
 	}
 
@@ -3,6 +3,8 @@ package postgres
 import (
 	"context"
 	"database/sql"
+	"encoding/json"
+	"strings"
 	"time"
 
 	"github.com/lib/pq"
@@ -14,6 +16,7 @@ var (
 	errSave     = errors.New("failed to save key in database")
 	errRetrieve = errors.New("failed to retrieve key from database")
 	errDelete   = errors.New("failed to delete key from database")
+	errUpdate   = errors.New("failed to update key in database")
 )
 var _ authn.KeyRepository = (*repo)(nil)
 
@@ -34,10 +37,13 @@ func New(db Database) authn.KeyRepository {
 }
 
 func (kr repo) Save(ctx context.Context, key authn.Key) (string, error) {
-	q := `INSERT INTO keys (id, type, issuer_id, subject, issued_at, expires_at)
-	      VALUES (:id, :type, :issuer_id, :subject, :issued_at, :expires_at)`
+	q := `INSERT INTO keys (id, type, issuer_id, subject, issued_at, expires_at, scopes)
+	      VALUES (:id, :type, :issuer_id, :subject, :issued_at, :expires_at, :scopes)`
 
-	dbKey := toDBKey(key)
+	dbKey, err := toDBKey(key)
+	if err != nil {
+		return "", errors.Wrap(errSave, err)
+	}
 	if _, err := kr.db.NamedExecContext(ctx, q, dbKey); err != nil {
 
 		pqErr, ok := err.(*pq.Error)
@@ -54,7 +60,7 @@ func (kr repo) Save(ctx context.Context, key authn.Key) (string, error) {
 }
 
 func (kr repo) Retrieve(ctx context.Context, issuerID, id string) (authn.Key, error) {
-	q := `SELECT id, type, issuer_id, subject, issued_at, expires_at FROM keys WHERE issuer_id = $1 AND id = $2`
+	q := `SELECT id, type, issuer_id, subject, issued_at, expires_at, last_used_at, scopes FROM keys WHERE issuer_id = $1 AND id = $2`
 	key := dbKey{}
 	if err := kr.db.QueryRowxContext(ctx, q, issuerID, id).StructScan(&key); err != nil {
 		pqErr, ok := err.(*pq.Error)
@@ -65,7 +71,12 @@ func (kr repo) Retrieve(ctx context.Context, issuerID, id string) (authn.Key, er
 		return authn.Key{}, errors.Wrap(errRetrieve, err)
 	}
 
-	return toKey(key), nil
+	k, err := toKey(key)
+	if err != nil {
+		return authn.Key{}, errors.Wrap(errRetrieve, err)
+	}
+
+	return k, nil
 }
 
 func (kr repo) Remove(ctx context.Context, issuerID, id string) error {
@@ -81,17 +92,128 @@ func (kr repo) Remove(ctx context.Context, issuerID, id string) error {
 	return nil
 }
 
+func (kr repo) RetrieveAll(ctx context.Context, issuerID string, pm authn.PageMetadata) (authn.KeyPage, error) {
+	conds := []string{"issuer_id = :issuer_id"}
+	params := map[string]interface{}{
+		"issuer_id": issuerID,
+		"now":       time.Now().UTC(),
+		"limit":     pm.Limit,
+		"offset":    pm.Offset,
+	}
+	if pm.Type != nil {
+		conds = append(conds, "type = :type")
+		params["type"] = *pm.Type
+	}
+	switch pm.Status {
+	case authn.ActiveKeys:
+		conds = append(conds, "(expires_at IS NULL OR expires_at > :now)")
+	case authn.ExpiredKeys:
+		conds = append(conds, "expires_at <= :now")
+	}
+	where := strings.Join(conds, " AND ")
+
+	q := `SELECT id, type, issuer_id, subject, issued_at, expires_at, last_used_at, scopes FROM keys
+	      WHERE ` + where + ` ORDER BY issued_at, id LIMIT :limit OFFSET :offset`
+	rows, err := kr.db.NamedQueryContext(ctx, q, params)
+	if err != nil {
+		return authn.KeyPage{}, errors.Wrap(errRetrieve, err)
+	}
+	defer rows.Close()
+
+	keys := []authn.Key{}
+	for rows.Next() {
+		dbk := dbKey{}
+		if err := rows.StructScan(&dbk); err != nil {
+			return authn.KeyPage{}, errors.Wrap(errRetrieve, err)
+		}
+		k, err := toKey(dbk)
+		if err != nil {
+			return authn.KeyPage{}, errors.Wrap(errRetrieve, err)
+		}
+		keys = append(keys, k)
+	}
+
+	total, err := kr.total(ctx, `SELECT COUNT(*) FROM keys WHERE `+where, params)
+	if err != nil {
+		return authn.KeyPage{}, errors.Wrap(errRetrieve, err)
+	}
+
+	return authn.KeyPage{
+		Total:  total,
+		Offset: pm.Offset,
+		Limit:  pm.Limit,
+		Keys:   keys,
+	}, nil
+}
+
+func (kr repo) RemoveAll(ctx context.Context, issuerID, subject string) error {
+	q := `DELETE FROM keys WHERE issuer_id = :issuer_id`
+	if subject != "" {
+		q = `DELETE FROM keys WHERE issuer_id = :issuer_id AND subject = :subject`
+	}
+	key := dbKey{
+		IssuerID: issuerID,
+		Subject:  subject,
+	}
+	if _, err := kr.db.NamedExecContext(ctx, q, key); err != nil {
+		return errors.Wrap(errDelete, err)
+	}
+
+	return nil
+}
+
+func (kr repo) UpdateLastUsed(ctx context.Context, issuerID, id string, t time.Time) error {
+	q := `UPDATE keys SET last_used_at = :last_used_at WHERE issuer_id = :issuer_id AND id = :id`
+	key := dbKey{
+		ID:         id,
+		IssuerID:   issuerID,
+		LastUsedAt: sql.NullTime{Time: t, Valid: true},
+	}
+	res, err := kr.db.NamedExecContext(ctx, q, key)
+	if err != nil {
+		return errors.Wrap(errUpdate, err)
+	}
+	cnt, err := res.RowsAffected()
+	if err != nil {
+		return errors.Wrap(errUpdate, err)
+	}
+	if cnt == 0 {
+		return authn.ErrNotFound
+	}
+
+	return nil
+}
+
+func (kr repo) total(ctx context.Context, query string, params interface{}) (uint64, error) {
+	rows, err := kr.db.NamedQueryContext(ctx, query, params)
+	if err != nil {
+		return 0, err
+	}
+	defer rows.Close()
+
+	var total uint64
+	if rows.Next() {
+		if err := rows.Scan(&total); err != nil {
+			return 0, err
+		}
+	}
+
+	return total, nil
+}
+
 type dbKey struct {
-	ID        string       `db:"id"`
-	Type      uint32       `db:"type"`
-	IssuerID  string       `db:"issuer_id"`
-	Subject   string       `db:"subject"`
-	Revoked   bool         `db:"revoked"`
-	IssuedAt  time.Time    `db:"issued_at"`
-	ExpiresAt sql.NullTime `db:"expires_at"`
+	ID         string       `db:"id"`
+	Type       uint32       `db:"type"`
+	IssuerID   string       `db:"issuer_id"`
+	Subject    string       `db:"subject"`
+	Revoked    bool         `db:"revoked"`
+	IssuedAt   time.Time    `db:"issued_at"`
+	ExpiresAt  sql.NullTime `db:"expires_at"`
+	LastUsedAt sql.NullTime `db:"last_used_at"`
+	Scopes     []byte       `db:"scopes"`
 }
 
-func toDBKey(key authn.Key) dbKey {
+func toDBKey(key authn.Key) (dbKey, error) {
 	ret := dbKey{
 		ID:       key.ID,
 		Type:     key.Type,
@@ -102,11 +224,18 @@ func toDBKey(key authn.Key) dbKey {
 	if !key.ExpiresAt.IsZero() {
 		ret.ExpiresAt = sql.NullTime{Time: key.ExpiresAt, Valid: true}
 	}
+	if len(key.Scopes) > 0 {
+		scopes, err := json.Marshal(key.Scopes)
+		if err != nil {
+			return dbKey{}, err
+		}
+		ret.Scopes = scopes
+	}
 
-	return ret
+	return ret, nil
 }
 
-func toKey(key dbKey) authn.Key {
+func toKey(key dbKey) (authn.Key, error) {
 	ret := authn.Key{
 		ID:       key.ID,
 		Type:     key.Type,
@@ -117,6 +246,14 @@ func toKey(key dbKey) authn.Key {
 	if key.ExpiresAt.Valid {
 		ret.ExpiresAt = key.ExpiresAt.Time
 	}
+	if key.LastUsedAt.Valid {
+		ret.LastUsedAt = key.LastUsedAt.Time
+	}
+	if len(key.Scopes) > 0 {
+		if err := json.Unmarshal(key.Scopes, &ret.Scopes); err != nil {
+			return authn.Key{}, err
+		}
+	}
 
-	return ret
+	return ret, nil
 }
@@ -24,6 +24,7 @@ func TestKeySave(t *testing.T) {
 	email := "user-save@example.com"
 	expTime := time.Now().Add(5 * time.Minute)
 	id, _ := uuidProvider.New().ID()
+	scopedID, _ := uuidProvider.New().ID()
 	cases := []struct {
 		desc string
 		key  authn.Key
@@ -51,6 +52,17 @@ func TestKeySave(t *testing.T) {
 			},
 			err: authn.ErrConflict,
 		},
+		{
+			desc: "save a new scoped key",
+			key: authn.Key{
+				Subject:  email,
+				IssuedAt: time.Now(),
+				ID:       scopedID,
+				IssuerID: id,
+				Scopes:   []authn.Scope{{Resource: "messages", Action: "read", IDs: []string{id}}},
+			},
+			err: nil,
+		},
 	}
 
 	for _, tc := range cases {
@@ -148,3 +160,187 @@ func TestKeyRemove(t *testing.T) {
 		assert.True(t, errors.Contains(err, tc.err), fmt.Sprintf("%s: expected %s got %s\n", tc.desc, tc.err, err))
 	}
 }
+
+func TestKeyRetrieveAll(t *testing.T) {
+	dbMiddleware := postgres.NewDatabase(db)
+	repo := postgres.New(dbMiddleware)
+
+	email := "user-list@example.com"
+	issuerID, _ := uuidProvider.New().ID()
+	n := uint64(10)
+	expired := uint64(3)
+	for i := uint64(0); i < n; i++ {
+		id, _ := uuidProvider.New().ID()
+		key := authn.Key{
+			ID:       id,
+			Type:     authn.APIKey,
+			Subject:  email,
+			IssuerID: issuerID,
+			IssuedAt: time.Now(),
+		}
+		if i < expired {
+			key.ExpiresAt = time.Now().Add(-time.Minute)
+		}
+		_, err := repo.Save(context.Background(), key)
+		assert.Nil(t, err, fmt.Sprintf("Storing Key expected to succeed: %s", err))
+	}
+
+	otherID, _ := uuidProvider.New().ID()
+	apiKey := authn.APIKey
+	userKey := authn.UserKey
+	cases := []struct {
+		desc  string
+		owner string
+		pm    authn.PageMetadata
+		size  uint64
+		total uint64
+	}{
+		{
+			desc:  "retrieve all keys",
+			owner: issuerID,
+			pm:    authn.PageMetadata{Offset: 0, Limit: n},
+			size:  n,
+			total: n,
+		},
+		{
+			desc:  "retrieve subset of keys",
+			owner: issuerID,
+			pm:    authn.PageMetadata{Offset: n - 2, Limit: n},
+			size:  2,
+			total: n,
+		},
+		{
+			desc:  "retrieve active keys",
+			owner: issuerID,
+			pm:    authn.PageMetadata{Offset: 0, Limit: n, Status: authn.ActiveKeys},
+			size:  n - expired,
+			total: n - expired,
+		},
+		{
+			desc:  "retrieve expired keys",
+			owner: issuerID,
+			pm:    authn.PageMetadata{Offset: 0, Limit: n, Status: authn.ExpiredKeys},
+			size:  expired,
+			total: expired,
+		},
+		{
+			desc:  "retrieve API keys",
+			owner: issuerID,
+			pm:    authn.PageMetadata{Offset: 0, Limit: n, Type: &apiKey},
+			size:  n,
+			total: n,
+		},
+		{
+			desc:  "retrieve user keys",
+			owner: issuerID,
+			pm:    authn.PageMetadata{Offset: 0, Limit: n, Type: &userKey},
+			size:  0,
+			total: 0,
+		},
+		{
+			desc:  "retrieve keys of other issuer",
+			owner: otherID,
+			pm:    authn.PageMetadata{Offset: 0, Limit: n},
+			size:  0,
+			total: 0,
+		},
+	}
+
+	for _, tc := range cases {
+		page, err := repo.RetrieveAll(context.Background(), tc.owner, tc.pm)
+		assert.Nil(t, err, fmt.Sprintf("%s: unexpected error: %s\n", tc.desc, err))
+		assert.Equal(t, tc.size, uint64(len(page.Keys)), fmt.Sprintf("%s: expected %d got %d\n", tc.desc, tc.size, len(page.Keys)))
+		assert.Equal(t, tc.total, page.Total, fmt.Sprintf("%s: expected total %d got %d\n", tc.desc, tc.total, page.Total))
+	}
+}
+
+func TestKeyRemoveAll(t *testing.T) {
+	dbMiddleware := postgres.NewDatabase(db)
+	repo := postgres.New(dbMiddleware)
+
+	email := "user-remove-all@example.com"
+	other := "ci@example.com"
+	issuerID, _ := uuidProvider.New().ID()
+	for _, sub := range []string{email, email, other} {
+		id, _ := uuidProvider.New().ID()
+		key := authn.Key{
+			ID:       id,
+			Type:     authn.APIKey,
+			Subject:  sub,
+			IssuerID: issuerID,
+			IssuedAt: time.Now(),
+		}
+		_, err := repo.Save(context.Background(), key)
+		assert.Nil(t, err, fmt.Sprintf("Storing Key expected to succeed: %s", err))
+	}
+
+	cases := []struct {
+		desc    string
+		subject string
+		total   uint64
+	}{
+		{
+			desc:    "remove keys of the subject",
+			subject: other,
+			total:   2,
+		},
+		{
+			desc:    "remove all keys",
+			subject: "",
+			total:   0,
+		},
+	}
+
+	for _, tc := range cases {
+		err := repo.RemoveAll(context.Background(), issuerID, tc.subject)
+		assert.Nil(t, err, fmt.Sprintf("%s: unexpected error: %s\n", tc.desc, err))
+		page, err := repo.RetrieveAll(context.Background(), issuerID, authn.PageMetadata{Limit: 10})
+		assert.Nil(t, err, fmt.Sprintf("%s: unexpected error: %s\n", tc.desc, err))
+		assert.Equal(t, tc.total, page.Total, fmt.Sprintf("%s: expected total %d got %d\n", tc.desc, tc.total, page.Total))
+	}
+}
+
+func TestKeyUpdateLastUsed(t *testing.T) {
+	dbMiddleware := postgres.NewDatabase(db)
+	repo := postgres.New(dbMiddleware)
+
+	id, _ := uuidProvider.New().ID()
+	key := authn.Key{
+		ID:       id,
+		Type:     authn.APIKey,
+		Subject:  "user-last-used@example.com",
+		IssuerID: id,
+		IssuedAt: time.Now(),
+	}
+	_, err := repo.Save(context.Background(), key)
+	assert.Nil(t, err, fmt.Sprintf("Storing Key expected to succeed: %s", err))
+
+	wrongID, _ := uuidProvider.New().ID()
+	cases := []struct {
+		desc string
+		id   string
+		err  error
+	}{
+		{
+			desc: "update last used time of existing key",
+			id:   key.ID,
+			err:  nil,
+		},
+		{
+			desc: "update last used time of non-existing key",
+			id:   wrongID,
+			err:  authn.ErrNotFound,
+		},
+	}
+
+	for _, tc := range cases {
+		now := time.Now().UTC().Round(time.Second)
+		err := repo.UpdateLastUsed(context.Background(), key.IssuerID, tc.id, now)
+		assert.True(t, errors.Contains(err, tc.err), fmt.Sprintf("%s: expected %s got %s\n", tc.desc, tc.err, err))
+		if err == nil {
+			k, err := repo.Retrieve(context.Background(), key.IssuerID, tc.id)
+			assert.Nil(t, err, fmt.Sprintf("%s: unexpected error: %s\n", tc.desc, err))
+			assert.True(t, now.Equal(k.LastUsedAt), fmt.Sprintf("%s: expected last used time %s got %s\n", tc.desc, now, k.LastUsedAt))
+		}
+	}
+}
@@ -0,0 +1,142 @@
+// Copyright (c) Mainflux
+// SPDX-License-Identifier: Apache-2.0
+
+package postgres
+
+import (
+	"context"
+	"database/sql"
+	"time"
+
+	"github.com/lib/pq"
+	"github.com/mainflux/mainflux/authn"
+	"github.com/mainflux/mainflux/pkg/errors"
+)
+
+var (
+	errSaveRefresh     = errors.New("failed to save refresh token in database")
+	errRetrieveRefresh = errors.New("failed to retrieve refresh token from database")
+	errDeleteRefresh   = errors.New("failed to delete refresh token from database")
+)
+
+var _ authn.RefreshRepository = (*refreshRepository)(nil)
+
+type refreshRepository struct {
+	db Database
+}
+
+// NewRefreshRepository instantiates a PostgreSQL implementation of refresh
+// repository.
+func NewRefreshRepository(db Database) authn.RefreshRepository {
+	return &refreshRepository{
+		db: db,
+	}
+}
+
+func (rr refreshRepository) Save(ctx context.Context, token authn.RefreshToken) error {
+	q := `INSERT INTO refresh_tokens (id, family_id, issuer_id, subject, issued_at, expires_at)
+	      VALUES (:id, :family_id, :issuer_id, :subject, :issued_at, :expires_at)`
+
+	dbt := toDBRefresh(token)
+	if _, err := rr.db.NamedExecContext(ctx, q, dbt); err != nil {
+		pqErr, ok := err.(*pq.Error)
+		if ok {
+			switch pqErr.Code.Name() {
+			case errDuplicate:
+				return errors.Wrap(authn.ErrConflict, err)
+			case errInvalid:
+				return errors.Wrap(authn.ErrMalformedEntity, err)
+			}
+		}
+		return errors.Wrap(errSaveRefresh, err)
+	}
+
+	// Expired refresh tokens can't be used, so there is no need to keep them.
+	dq := `DELETE FROM refresh_tokens WHERE expires_at < :now`
+	if _, err := rr.db.NamedExecContext(ctx, dq, map[string]interface{}{"now": time.Now().UTC()}); err != nil {
+		return errors.Wrap(errSaveRefresh, err)
+	}
+
+	return nil
+}
+
+func (rr refreshRepository) Use(ctx context.Context, id string) (authn.RefreshToken, error) {
+	// The previous value of the used flag is returned alongside the token,
+	// while the row lock guarantees that only one of the concurrent
+	// requests sees the token as unused.
+	q := `UPDATE refresh_tokens r SET used = TRUE
+	      FROM (SELECT id, used FROM refresh_tokens WHERE id = $1 FOR UPDATE) prev
+	      WHERE r.id = prev.id
+	      RETURNING r.id, r.family_id, r.issuer_id, r.subject, r.issued_at, r.expires_at, prev.used`
+
+	dbt := dbRefresh{}
+	if err := rr.db.QueryRowxContext(ctx, q, id).StructScan(&dbt); err != nil {
+		pqErr, ok := err.(*pq.Error)
+		if err == sql.ErrNoRows || ok && errInvalid == pqErr.Code.Name() {
+			return authn.RefreshToken{}, errors.Wrap(authn.ErrNotFound, err)
+		}
+		return authn.RefreshToken{}, errors.Wrap(errRetrieveRefresh, err)
+	}
+
+	token := toRefresh(dbt)
+	if dbt.Used {
+		return token, authn.ErrKeyReused
+	}
+
+	return token, nil
+}
+
+func (rr refreshRepository) RemoveFamily(ctx context.Context, id string) error {
+	q := `DELETE FROM refresh_tokens WHERE family_id = (SELECT family_id FROM refresh_tokens WHERE id = :id)`
+
+	res, err := rr.db.NamedExecContext(ctx, q, map[string]interface{}{"id": id})
+	if err != nil {
+		pqErr, ok := err.(*pq.Error)
+		if ok && errInvalid == pqErr.Code.Name() {
+			return errors.Wrap(authn.ErrNotFound, err)
+		}
+		return errors.Wrap(errDeleteRefresh, err)
+	}
+
+	cnt, err := res.RowsAffected()
+	if err != nil {
+		return errors.Wrap(errDeleteRefresh, err)
+	}
+	if cnt == 0 {
+		return authn.ErrNotFound
+	}
+
+	return nil
+}
+
+type dbRefresh struct {
+	ID        string    `db:"id"`
+	FamilyID  string    `db:"family_id"`
+	IssuerID  string    `db:"issuer_id"`
+	Subject   string    `db:"subject"`
+	IssuedAt  time.Time `db:"issued_at"`
+	ExpiresAt time.Time `db:"expires_at"`
+	Used      bool      `db:"used"`
+}
+
+func toDBRefresh(token authn.RefreshToken) dbRefresh {
+	return dbRefresh{
+		ID:        token.ID,
+		FamilyID:  token.FamilyID,
+		IssuerID:  token.IssuerID,
+		Subject:   token.Subject,
+		IssuedAt:  token.IssuedAt.UTC(),
+		ExpiresAt: token.ExpiresAt.UTC(),
+	}
+}
+
+func toRefresh(dbt dbRefresh) authn.RefreshToken {
+	return authn.RefreshToken{
+		ID:        dbt.ID,
+		FamilyID:  dbt.FamilyID,
+		IssuerID:  dbt.IssuerID,
+		Subject:   dbt.Subject,
+		IssuedAt:  dbt.IssuedAt,
+		ExpiresAt: dbt.ExpiresAt,
+	}
+}
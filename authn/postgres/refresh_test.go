@@ -0,0 +1,124 @@
+// Copyright (c) Mainflux
+// SPDX-License-Identifier: Apache-2.0
+
+package postgres_test
+
+import (
+	"context"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/mainflux/mainflux/authn"
+	"github.com/mainflux/mainflux/authn/postgres"
+	"github.com/mainflux/mainflux/pkg/errors"
+	uuidProvider "github.com/mainflux/mainflux/pkg/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func newRefreshToken(t *testing.T, familyID string) authn.RefreshToken {
+	id, err := uuidProvider.New().ID()
+	require.Nil(t, err, fmt.Sprintf("unexpected error: %s", err))
+	issuerID, err := uuidProvider.New().ID()
+	require.Nil(t, err, fmt.Sprintf("unexpected error: %s", err))
+	if familyID == "" {
+		familyID = id
+	}
+
+	return authn.RefreshToken{
+		ID:        id,
+		FamilyID:  familyID,
+		IssuerID:  issuerID,
+		Subject:   "user-refresh@example.com",
+		IssuedAt:  time.Now(),
+		ExpiresAt: time.Now().Add(time.Hour),
+	}
+}
+
+func TestRefreshUse(t *testing.T) {
+	dbMiddleware := postgres.NewDatabase(db)
+	repo := postgres.NewRefreshRepository(dbMiddleware)
+
+	token := newRefreshToken(t, "")
+	err := repo.Save(context.Background(), token)
+	require.Nil(t, err, fmt.Sprintf("unexpected error: %s", err))
+	err = repo.Save(context.Background(), token)
+	assert.True(t, errors.Contains(err, authn.ErrConflict), fmt.Sprintf("save duplicate token: expected %s got %s", authn.ErrConflict, err))
+
+	cases := []struct {
+		desc string
+		id   string
+		err  error
+	}{
+		{
+			desc: "use refresh token",
+			id:   token.ID,
+			err:  nil,
+		},
+		{
+			desc: "use already used refresh token",
+			id:   token.ID,
+			err:  authn.ErrKeyReused,
+		},
+		{
+			desc: "use non-existing refresh token",
+			id:   wrong,
+			err:  authn.ErrNotFound,
+		},
+	}
+
+	for _, tc := range cases {
+		rt, err := repo.Use(context.Background(), tc.id)
+		assert.True(t, errors.Contains(err, tc.err), fmt.Sprintf("%s: expected %s got %s\n", tc.desc, tc.err, err))
+		if tc.err != authn.ErrNotFound {
+			assert.Equal(t, token.FamilyID, rt.FamilyID, fmt.Sprintf("%s: expected family %s got %s\n", tc.desc, token.FamilyID, rt.FamilyID))
+		}
+	}
+}
+
+func TestRefreshRemoveFamily(t *testing.T) {
+	dbMiddleware := postgres.NewDatabase(db)
+	repo := postgres.NewRefreshRepository(dbMiddleware)
+
+	first := newRefreshToken(t, "")
+	second := newRefreshToken(t, first.FamilyID)
+	other := newRefreshToken(t, "")
+	for _, rt := range []authn.RefreshToken{first, second, other} {
+		err := repo.Save(context.Background(), rt)
+		require.Nil(t, err, fmt.Sprintf("unexpected error: %s", err))
+	}
+
+	err := repo.RemoveFamily(context.Background(), second.ID)
+	require.Nil(t, err, fmt.Sprintf("unexpected error: %s", err))
+
+	cases := []struct {
+		desc string
+		id   string
+		err  error
+	}{
+		{
+			desc: "use token from removed family",
+			id:   first.ID,
+			err:  authn.ErrNotFound,
+		},
+		{
+			desc: "use token used to remove family",
+			id:   second.ID,
+			err:  authn.ErrNotFound,
+		},
+		{
+			desc: "use token from other family",
+			id:   other.ID,
+			err:  nil,
+		},
+	}
+
+	for _, tc := range cases {
+		_, err := repo.Use(context.Background(), tc.id)
+		assert.True(t, errors.Contains(err, tc.err), fmt.Sprintf("%s: expected %s got %s\n", tc.desc, tc.err, err))
+	}
+
+	err = repo.RemoveFamily(context.Background(), wrong)
+	assert.True(t, errors.Contains(err, authn.ErrNotFound), fmt.Sprintf("remove non-existing family: expected %s got %s", authn.ErrNotFound, err))
+}
@@ -0,0 +1,155 @@
+// Copyright (c) Mainflux
+// SPDX-License-Identifier: Apache-2.0
+
+package postgres
+
+import (
+	"context"
+	"database/sql"
+	"time"
+
+	"github.com/lib/pq"
+	"github.com/mainflux/mainflux/authn"
+	"github.com/mainflux/mainflux/pkg/errors"
+)
+
+var (
+	errSaveRevocation     = errors.New("failed to save revocation in database")
+	errRetrieveRevocation = errors.New("failed to retrieve revocation from database")
+)
+
+var _ authn.RevocationRepository = (*revocationRepository)(nil)
+
+type revocationRepository struct {
+	db Database
+}
+
+// NewRevocationRepository instantiates a PostgreSQL implementation of
+// revocation repository.
+func NewRevocationRepository(db Database) authn.RevocationRepository {
+	return &revocationRepository{
+		db: db,
+	}
+}
+
+func (rr revocationRepository) Save(ctx context.Context, id string, expiresAt time.Time) error {
+	q := `INSERT INTO revoked_keys (id, expires_at) VALUES (:id, :expires_at)
+	      ON CONFLICT (id) DO UPDATE SET expires_at = :expires_at`
+
+	rev := dbRevocation{
+		ID:        id,
+		ExpiresAt: expiresAt.UTC(),
+	}
+	if _, err := rr.db.NamedExecContext(ctx, q, rev); err != nil {
+		return errors.Wrap(errSaveRevocation, err)
+	}
+
+	// Revocations of the expired keys are no longer needed, since such
+	// keys are rejected anyway.
+	dq := `DELETE FROM revoked_keys WHERE expires_at < :now`
+	if _, err := rr.db.NamedExecContext(ctx, dq, map[string]interface{}{"now": time.Now().UTC()}); err != nil {
+		return errors.Wrap(errSaveRevocation, err)
+	}
+
+	return nil
+}
+
+func (rr revocationRepository) Contains(ctx context.Context, id string) (bool, error) {
+	q := `SELECT id, expires_at FROM revoked_keys WHERE id = $1`
+
+	rev := dbRevocation{}
+	if err := rr.db.QueryRowxContext(ctx, q, id).StructScan(&rev); err != nil {
+		if err == sql.ErrNoRows {
+			return false, nil
+		}
+		return false, errors.Wrap(errRetrieveRevocation, err)
+	}
+
+	return true, nil
+}
+
+func (rr revocationRepository) SaveCutoff(ctx context.Context, issuerID string, t time.Time) error {
+	q := `INSERT INTO session_cutoffs (issuer_id, revoked_at) VALUES (:issuer_id, :revoked_at)
+	      ON CONFLICT (issuer_id) DO UPDATE SET revoked_at = :revoked_at`
+
+	cutoff := dbCutoff{
+		IssuerID:  issuerID,
+		RevokedAt: t.UTC(),
+	}
+	if _, err := rr.db.NamedExecContext(ctx, q, cutoff); err != nil {
+		pqErr, ok := err.(*pq.Error)
+		if ok && errInvalid == pqErr.Code.Name() {
+			return errors.Wrap(authn.ErrMalformedEntity, err)
+		}
+		return errors.Wrap(errSaveRevocation, err)
+	}
+
+	return nil
+}
+
+func (rr revocationRepository) RetrieveCutoff(ctx context.Context, issuerID string) (time.Time, error) {
+	q := `SELECT issuer_id, revoked_at FROM session_cutoffs WHERE issuer_id = $1`
+
+	cutoff := dbCutoff{}
+	if err := rr.db.QueryRowxContext(ctx, q, issuerID).StructScan(&cutoff); err != nil {
+		pqErr, ok := err.(*pq.Error)
+		if err == sql.ErrNoRows || ok && errInvalid == pqErr.Code.Name() {
+			return time.Time{}, nil
+		}
+		return time.Time{}, errors.Wrap(errRetrieveRevocation, err)
+	}
+
+	return cutoff.RevokedAt, nil
+}
+
+func (rr revocationRepository) SaveDisabled(ctx context.Context, issuerID string, disabled bool) error {
+	q := `DELETE FROM disabled_issuers WHERE issuer_id = :issuer_id`
+	if disabled {
+		q = `INSERT INTO disabled_issuers (issuer_id, disabled_at) VALUES (:issuer_id, :disabled_at)
+		     ON CONFLICT (issuer_id) DO NOTHING`
+	}
+
+	dis := dbDisabled{
+		IssuerID:   issuerID,
+		DisabledAt: time.Now().UTC(),
+	}
+	if _, err := rr.db.NamedExecContext(ctx, q, dis); err != nil {
+		pqErr, ok := err.(*pq.Error)
+		if ok && errInvalid == pqErr.Code.Name() {
+			return errors.Wrap(authn.ErrMalformedEntity, err)
+		}
+		return errors.Wrap(errSaveRevocation, err)
+	}
+
+	return nil
+}
+
+func (rr revocationRepository) Disabled(ctx context.Context, issuerID string) (bool, error) {
+	q := `SELECT issuer_id, disabled_at FROM disabled_issuers WHERE issuer_id = $1`
+
+	dis := dbDisabled{}
+	if err := rr.db.QueryRowxContext(ctx, q, issuerID).StructScan(&dis); err != nil {
+		pqErr, ok := err.(*pq.Error)
+		if err == sql.ErrNoRows || ok && errInvalid == pqErr.Code.Name() {
+			return false, nil
+		}
+		return false, errors.Wrap(errRetrieveRevocation, err)
+	}
+
+	return true, nil
+}
+
+type dbRevocation struct {
+	ID        string    `db:"id"`
+	ExpiresAt time.Time `db:"expires_at"`
+}
+
+type dbCutoff struct {
+	IssuerID  string    `db:"issuer_id"`
+	RevokedAt time.Time `db:"revoked_at"`
+}
+
+type dbDisabled struct {
+	IssuerID   string    `db:"issuer_id"`
+	DisabledAt time.Time `db:"disabled_at"`
+}
@@ -0,0 +1,155 @@
+// Copyright (c) Mainflux
+// SPDX-License-Identifier: Apache-2.0
+
+package postgres_test
+
+import (
+	"context"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/mainflux/mainflux/authn"
+	"github.com/mainflux/mainflux/authn/postgres"
+	"github.com/mainflux/mainflux/pkg/errors"
+	uuidProvider "github.com/mainflux/mainflux/pkg/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestRevocationContains(t *testing.T) {
+	dbMiddleware := postgres.NewDatabase(db)
+	repo := postgres.NewRevocationRepository(dbMiddleware)
+
+	id, _ := uuidProvider.New().ID()
+	expiredID, _ := uuidProvider.New().ID()
+	err := repo.Save(context.Background(), expiredID, time.Now().Add(-time.Minute))
+	require.Nil(t, err, fmt.Sprintf("unexpected error: %s", err))
+	err = repo.Save(context.Background(), id, time.Now().Add(time.Hour))
+	require.Nil(t, err, fmt.Sprintf("unexpected error: %s", err))
+	err = repo.Save(context.Background(), id, time.Now().Add(time.Hour))
+	require.Nil(t, err, fmt.Sprintf("unexpected error: %s", err))
+
+	cases := []struct {
+		desc     string
+		id       string
+		contains bool
+	}{
+		{
+			desc:     "check revoked key",
+			id:       id,
+			contains: true,
+		},
+		{
+			desc:     "check revoked expired key",
+			id:       expiredID,
+			contains: false,
+		},
+		{
+			desc:     "check non-revoked key",
+			id:       wrong,
+			contains: false,
+		},
+	}
+
+	for _, tc := range cases {
+		contains, err := repo.Contains(context.Background(), tc.id)
+		assert.Nil(t, err, fmt.Sprintf("%s: unexpected error: %s", tc.desc, err))
+		assert.Equal(t, tc.contains, contains, fmt.Sprintf("%s: expected %t got %t\n", tc.desc, tc.contains, contains))
+	}
+}
+
+func TestRevocationCutoff(t *testing.T) {
+	dbMiddleware := postgres.NewDatabase(db)
+	repo := postgres.NewRevocationRepository(dbMiddleware)
+
+	issuerID, _ := uuidProvider.New().ID()
+	otherID, _ := uuidProvider.New().ID()
+	first := time.Now().Add(-time.Hour).UTC().Round(time.Second)
+	last := time.Now().UTC().Round(time.Second)
+	err := repo.SaveCutoff(context.Background(), issuerID, first)
+	require.Nil(t, err, fmt.Sprintf("unexpected error: %s", err))
+	err = repo.SaveCutoff(context.Background(), issuerID, last)
+	require.Nil(t, err, fmt.Sprintf("unexpected error: %s", err))
+
+	cases := []struct {
+		desc     string
+		issuerID string
+		cutoff   time.Time
+	}{
+		{
+			desc:     "retrieve the latest cutoff",
+			issuerID: issuerID,
+			cutoff:   last,
+		},
+		{
+			desc:     "retrieve cutoff of issuer without revoked sessions",
+			issuerID: otherID,
+			cutoff:   time.Time{},
+		},
+		{
+			desc:     "retrieve cutoff with malformed issuer ID",
+			issuerID: wrong,
+			cutoff:   time.Time{},
+		},
+	}
+
+	for _, tc := range cases {
+		cutoff, err := repo.RetrieveCutoff(context.Background(), tc.issuerID)
+		assert.Nil(t, err, fmt.Sprintf("%s: unexpected error: %s", tc.desc, err))
+		assert.True(t, tc.cutoff.Equal(cutoff), fmt.Sprintf("%s: expected %s got %s\n", tc.desc, tc.cutoff, cutoff))
+	}
+}
+
+func TestRevocationDisabled(t *testing.T) {
+	dbMiddleware := postgres.NewDatabase(db)
+	repo := postgres.NewRevocationRepository(dbMiddleware)
+
+	disabledID, _ := uuidProvider.New().ID()
+	enabledID, _ := uuidProvider.New().ID()
+	otherID, _ := uuidProvider.New().ID()
+	for _, id := range []string{disabledID, enabledID} {
+		err := repo.SaveDisabled(context.Background(), id, true)
+		require.Nil(t, err, fmt.Sprintf("unexpected error: %s", err))
+	}
+	err := repo.SaveDisabled(context.Background(), disabledID, true)
+	require.Nil(t, err, fmt.Sprintf("disable disabled issuer: unexpected error: %s", err))
+	err = repo.SaveDisabled(context.Background(), enabledID, false)
+	require.Nil(t, err, fmt.Sprintf("unexpected error: %s", err))
+
+	err = repo.SaveDisabled(context.Background(), wrong, true)
+	assert.True(t, errors.Contains(err, authn.ErrMalformedEntity), fmt.Sprintf("disable issuer with malformed ID: expected %s got %s\n", authn.ErrMalformedEntity, err))
+
+	cases := []struct {
+		desc     string
+		issuerID string
+		disabled bool
+	}{
+		{
+			desc:     "check disabled issuer",
+			issuerID: disabledID,
+			disabled: true,
+		},
+		{
+			desc:     "check re-enabled issuer",
+			issuerID: enabledID,
+			disabled: false,
+		},
+		{
+			desc:     "check issuer that has never been disabled",
+			issuerID: otherID,
+			disabled: false,
+		},
+		{
+			desc:     "check issuer with malformed ID",
+			issuerID: wrong,
+			disabled: false,
+		},
+	}
+
+	for _, tc := range cases {
+		disabled, err := repo.Disabled(context.Background(), tc.issuerID)
+		assert.Nil(t, err, fmt.Sprintf("%s: unexpected error: %s", tc.desc, err))
+		assert.Equal(t, tc.disabled, disabled, fmt.Sprintf("%s: expected %t got %t\n", tc.desc, tc.disabled, disabled))
+	}
+}
@@ -0,0 +1,178 @@
+// Copyright (c) Mainflux
+// SPDX-License-Identifier: Apache-2.0
+
+package redis
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+	"time"
+
+	"github.com/go-redis/redis"
+	"github.com/mainflux/mainflux/authn"
+	"github.com/mainflux/mainflux/pkg/errors"
+)
+
+const (
+	revokedPrefix  = "revoked_key"
+	cutoffPrefix   = "session_cutoff"
+	disabledPrefix = "disabled_issuer"
+
+	// ttl limits how long the entries that may be changed by another
+	// writer (non-revoked keys, session cutoffs and disabled issuers) are
+	// cached.
+	ttl = 10 * time.Minute
+
+	revoked    = "1"
+	notRevoked = "0"
+)
+
+var errCache = errors.New("failed to update revocation cache")
+
+var _ authn.RevocationRepository = (*revocationCache)(nil)
+
+type revocationCache struct {
+	client *redis.Client
+	repo   authn.RevocationRepository
+}
+
+// NewRevocationRepository returns revocation repository that caches the
+// revocations stored in the wrapped repository using Redis. Revocations
+// are written through the cache, so the cached entries stay valid as long
+// as all of the writers share the cache.
+func NewRevocationRepository(client *redis.Client, repo authn.RevocationRepository) authn.RevocationRepository {
+	return &revocationCache{
+		client: client,
+		repo:   repo,
+	}
+}
+
+func (rc *revocationCache) Save(ctx context.Context, id string, expiresAt time.Time) error {
+	if err := rc.repo.Save(ctx, id, expiresAt); err != nil {
+		return err
+	}
+
+	exp := time.Until(expiresAt)
+	if exp <= 0 {
+		return rc.del(revokedKey(id))
+	}
+	if err := rc.client.Set(revokedKey(id), revoked, exp).Err(); err != nil {
+		return errors.Wrap(errCache, err)
+	}
+
+	return nil
+}
+
+func (rc *revocationCache) Contains(ctx context.Context, id string) (bool, error) {
+	val, err := rc.client.Get(revokedKey(id)).Result()
+	if err == nil {
+		return val == revoked, nil
+	}
+
+	contains, err := rc.repo.Contains(ctx, id)
+	if err != nil {
+		return false, err
+	}
+	if !contains {
+		// Failure to cache the value doesn't affect the result.
+		rc.client.Set(revokedKey(id), notRevoked, ttl)
+	}
+
+	return contains, nil
+}
+
+func (rc *revocationCache) SaveCutoff(ctx context.Context, issuerID string, t time.Time) error {
+	if err := rc.repo.SaveCutoff(ctx, issuerID, t); err != nil {
+		return err
+	}
+
+	val := strconv.FormatInt(t.UnixNano(), 10)
+	if err := rc.client.Set(cutoffKey(issuerID), val, ttl).Err(); err != nil {
+		return errors.Wrap(errCache, err)
+	}
+
+	return nil
+}
+
+func (rc *revocationCache) RetrieveCutoff(ctx context.Context, issuerID string) (time.Time, error) {
+	val, err := rc.client.Get(cutoffKey(issuerID)).Result()
+	if err == nil {
+		if nanos, err := strconv.ParseInt(val, 10, 64); err == nil {
+			if nanos == 0 {
+				return time.Time{}, nil
+			}
+			return time.Unix(0, nanos).UTC(), nil
+		}
+	}
+
+	t, err := rc.repo.RetrieveCutoff(ctx, issuerID)
+	if err != nil {
+		return time.Time{}, err
+	}
+
+	val = strconv.FormatInt(0, 10)
+	if !t.IsZero() {
+		val = strconv.FormatInt(t.UnixNano(), 10)
+	}
+	// Failure to cache the value doesn't affect the result.
+	rc.client.Set(cutoffKey(issuerID), val, ttl)
+
+	return t, nil
+}
+
+func (rc *revocationCache) SaveDisabled(ctx context.Context, issuerID string, disabled bool) error {
+	if err := rc.repo.SaveDisabled(ctx, issuerID, disabled); err != nil {
+		return err
+	}
+
+	val := notRevoked
+	if disabled {
+		val = revoked
+	}
+	if err := rc.client.Set(disabledKey(issuerID), val, ttl).Err(); err != nil {
+		return errors.Wrap(errCache, err)
+	}
+
+	return nil
+}
+
+func (rc *revocationCache) Disabled(ctx context.Context, issuerID string) (bool, error) {
+	val, err := rc.client.Get(disabledKey(issuerID)).Result()
+	if err == nil {
+		return val == revoked, nil
+	}
+
+	disabled, err := rc.repo.Disabled(ctx, issuerID)
+	if err != nil {
+		return false, err
+	}
+
+	val = notRevoked
+	if disabled {
+		val = revoked
+	}
+	// Failure to cache the value doesn't affect the result.
+	rc.client.Set(disabledKey(issuerID), val, ttl)
+
+	return disabled, nil
+}
+
+func (rc *revocationCache) del(key string) error {
+	if err := rc.client.Del(key).Err(); err != nil {
+		return errors.Wrap(errCache, err)
+	}
+	return nil
+}
+
+func revokedKey(id string) string {
+	return fmt.Sprintf("%s:%s", revokedPrefix, id)
+}
+
+func cutoffKey(issuerID string) string {
+	return fmt.Sprintf("%s:%s", cutoffPrefix, issuerID)
+}
+
+func disabledKey(issuerID string) string {
+	return fmt.Sprintf("%s:%s", disabledPrefix, issuerID)
+}
@@ -0,0 +1,140 @@
+// Copyright (c) Mainflux
+// SPDX-License-Identifier: Apache-2.0
+
+package redis_test
+
+import (
+	"context"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/mainflux/mainflux/authn/mocks"
+	"github.com/mainflux/mainflux/authn/redis"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestRevocationContains(t *testing.T) {
+	repo := mocks.NewRevocationRepository()
+	cache := redis.NewRevocationRepository(redisClient, repo)
+
+	// Cache the negative result before the key is revoked.
+	contains, err := cache.Contains(context.Background(), "revoked")
+	require.Nil(t, err, fmt.Sprintf("unexpected error: %s", err))
+	require.False(t, contains, "key should not be revoked before saving revocation")
+
+	err = cache.Save(context.Background(), "revoked", time.Now().Add(time.Hour))
+	require.Nil(t, err, fmt.Sprintf("unexpected error: %s", err))
+	// Revocations that are not cached are read from the repository.
+	err = repo.Save(context.Background(), "bypassed", time.Now().Add(time.Hour))
+	require.Nil(t, err, fmt.Sprintf("unexpected error: %s", err))
+
+	cases := []struct {
+		desc     string
+		id       string
+		contains bool
+	}{
+		{
+			desc:     "check revoked key",
+			id:       "revoked",
+			contains: true,
+		},
+		{
+			desc:     "check key revoked in repository",
+			id:       "bypassed",
+			contains: true,
+		},
+		{
+			desc:     "check non-revoked key",
+			id:       "active",
+			contains: false,
+		},
+	}
+
+	for _, tc := range cases {
+		contains, err := cache.Contains(context.Background(), tc.id)
+		assert.Nil(t, err, fmt.Sprintf("%s: unexpected error: %s", tc.desc, err))
+		assert.Equal(t, tc.contains, contains, fmt.Sprintf("%s: expected %t got %t\n", tc.desc, tc.contains, contains))
+	}
+}
+
+func TestRevocationCutoff(t *testing.T) {
+	repo := mocks.NewRevocationRepository()
+	cache := redis.NewRevocationRepository(redisClient, repo)
+
+	cutoff, err := cache.RetrieveCutoff(context.Background(), "issuer")
+	require.Nil(t, err, fmt.Sprintf("unexpected error: %s", err))
+	require.True(t, cutoff.IsZero(), "cutoff should be zero before revoking sessions")
+
+	now := time.Now().UTC()
+	err = cache.SaveCutoff(context.Background(), "issuer", now)
+	require.Nil(t, err, fmt.Sprintf("unexpected error: %s", err))
+
+	cases := []struct {
+		desc     string
+		issuerID string
+		cutoff   time.Time
+	}{
+		{
+			desc:     "retrieve saved cutoff",
+			issuerID: "issuer",
+			cutoff:   now,
+		},
+		{
+			desc:     "retrieve cutoff of issuer without revoked sessions",
+			issuerID: "other",
+			cutoff:   time.Time{},
+		},
+	}
+
+	for _, tc := range cases {
+		cutoff, err := cache.RetrieveCutoff(context.Background(), tc.issuerID)
+		assert.Nil(t, err, fmt.Sprintf("%s: unexpected error: %s", tc.desc, err))
+		assert.True(t, tc.cutoff.Equal(cutoff), fmt.Sprintf("%s: expected %s got %s\n", tc.desc, tc.cutoff, cutoff))
+	}
+}
+
+func TestRevocationDisabled(t *testing.T) {
+	repo := mocks.NewRevocationRepository()
+	cache := redis.NewRevocationRepository(redisClient, repo)
+
+	disabled, err := cache.Disabled(context.Background(), "disabled")
+	require.Nil(t, err, fmt.Sprintf("unexpected error: %s", err))
+	require.False(t, disabled, "issuer should be enabled before disabling it")
+
+	err = cache.SaveDisabled(context.Background(), "disabled", true)
+	require.Nil(t, err, fmt.Sprintf("unexpected error: %s", err))
+	err = cache.SaveDisabled(context.Background(), "enabled", true)
+	require.Nil(t, err, fmt.Sprintf("unexpected error: %s", err))
+	err = cache.SaveDisabled(context.Background(), "enabled", false)
+	require.Nil(t, err, fmt.Sprintf("unexpected error: %s", err))
+
+	cases := []struct {
+		desc     string
+		issuerID string
+		disabled bool
+	}{
+		{
+			desc:     "check disabled issuer",
+			issuerID: "disabled",
+			disabled: true,
+		},
+		{
+			desc:     "check re-enabled issuer",
+			issuerID: "enabled",
+			disabled: false,
+		},
+		{
+			desc:     "check issuer that has never been disabled",
+			issuerID: "other",
+			disabled: false,
+		},
+	}
+
+	for _, tc := range cases {
+		disabled, err := cache.Disabled(context.Background(), tc.issuerID)
+		assert.Nil(t, err, fmt.Sprintf("%s: unexpected error: %s", tc.desc, err))
+		assert.Equal(t, tc.disabled, disabled, fmt.Sprintf("%s: expected %t got %t\n", tc.desc, tc.disabled, disabled))
+	}
+}
@@ -0,0 +1,40 @@
+// Copyright (c) Mainflux
+// SPDX-License-Identifier: Apache-2.0
+
+package authn
+
+import (
+	"context"
+	"errors"
+	"time"
+)
+
+// ErrKeyReused indicates that the single-use refresh Key is used again.
+var ErrKeyReused = errors.New("reuse of refresh key")
+
+// RefreshToken represents the stored state of the issued refresh key.
+// Refresh keys issued by exchanging each other belong to the same family,
+// which is started on login.
+type RefreshToken struct {
+	ID        string
+	FamilyID  string
+	IssuerID  string
+	Subject   string
+	IssuedAt  time.Time
+	ExpiresAt time.Time
+}
+
+// RefreshRepository specifies refresh keys persistence API.
+type RefreshRepository interface {
+	// Save persists the refresh token.
+	Save(ctx context.Context, token RefreshToken) error
+
+	// Use marks the refresh token with provided ID as used and retrieves
+	// it. If the token has already been used, the token is returned
+	// alongside ErrKeyReused.
+	Use(ctx context.Context, id string) (RefreshToken, error)
+
+	// RemoveFamily removes all of the refresh tokens that belong to the
+	// family of the token with provided ID.
+	RemoveFamily(ctx context.Context, id string) error
+}
@@ -0,0 +1,46 @@
+// Copyright (c) Mainflux
+// SPDX-License-Identifier: Apache-2.0
+
+package authn
+
+import (
+	"context"
+	"errors"
+	"time"
+)
+
+var (
+	// ErrKeyRevoked indicates that the Key is revoked.
+	ErrKeyRevoked = errors.New("use of revoked key")
+
+	// ErrDisabled indicates that the issuer of the Key is disabled.
+	ErrDisabled = errors.New("use of key issued to disabled user")
+)
+
+// RevocationRepository specifies the persistence API of revoked user and
+// recovery keys. These keys are not stored once issued, so their
+// revocation is tracked separately until they expire. Issuers disabled
+// by the admin are tracked as well.
+type RevocationRepository interface {
+	// Save marks the Key with provided ID as revoked until it expires.
+	Save(ctx context.Context, id string, expiresAt time.Time) error
+
+	// Contains checks whether the Key with provided ID is revoked.
+	Contains(ctx context.Context, id string) (bool, error)
+
+	// SaveCutoff revokes all of the keys issued to the issuer with the
+	// provided ID before the given time.
+	SaveCutoff(ctx context.Context, issuerID string, t time.Time) error
+
+	// RetrieveCutoff retrieves the time before which all of the keys
+	// issued to the issuer with the provided ID are revoked. Zero time is
+	// returned if the keys of the issuer have never been revoked.
+	RetrieveCutoff(ctx context.Context, issuerID string) (time.Time, error)
+
+	// SaveDisabled disables the issuer with the provided ID, or enables it
+	// again. None of the keys issued to the disabled issuer are valid.
+	SaveDisabled(ctx context.Context, issuerID string, disabled bool) error
+
+	// Disabled checks whether the issuer with the provided ID is disabled.
+	Disabled(ctx context.Context, issuerID string) (bool, error)
+}
@@ -12,8 +12,12 @@ import (
 )
 
 const (
-	loginDuration    = 10 * time.Hour
-	recoveryDuration = 5 * time.Minute
+	loginDuration     = 10 * time.Hour
+	recoveryDuration  = 5 * time.Minute
+	refreshDuration   = 7 * 24 * time.Hour
+	challengeDuration = 5 * time.Minute
+
+	impersonationDuration = time.Hour
 )
 
 var (
@@ -30,11 +34,17 @@ var (
 	// ErrConflict indicates that entity already exists.
 	ErrConflict = errors.New("entity already exists")
 
-	errIssueUser = errors.New("failed to issue new user key")
-	errIssueTmp  = errors.New("failed to issue new temporary key")
-	errRevoke    = errors.New("failed to remove key")
-	errRetrieve  = errors.New("failed to retrieve key data")
-	errIdentify  = errors.New("failed to validate token")
+	errIssueUser   = errors.New("failed to issue new user key")
+	errIssueTmp    = errors.New("failed to issue new temporary key")
+	errRevoke      = errors.New("failed to remove key")
+	errRetrieve    = errors.New("failed to retrieve key data")
+	errList        = errors.New("failed to list keys")
+	errIdentify    = errors.New("failed to validate token")
+	errRevokeTmp   = errors.New("failed to revoke key")
+	errRefresh     = errors.New("failed to refresh key")
+	errChallenge   = errors.New("failed to verify challenge key")
+	errImpersonate = errors.New("failed to issue impersonation key")
+	errDisable     = errors.New("failed to update disabled user")
 )
 
 // Service specifies an API that must be fullfiled by the domain service
@@ -53,26 +63,76 @@ type Service interface {
 	// ID, that is issued by the user identified by the provided key.
 	Retrieve(ctx context.Context, token, id string) (Key, error)
 
+	// ListKeys retrieves a subset of the keys issued by the user
+	// identified by the provided key.
+	ListKeys(ctx context.Context, token string, pm PageMetadata) (KeyPage, error)
+
+	// RevokeAll removes all of the keys issued by the user identified by
+	// the provided key. If subject is not empty, only the keys issued for
+	// the subject are removed.
+	RevokeAll(ctx context.Context, token, subject string) error
+
 	// Identify validates token token. If token is valid, content
 	// is returned. If token is invalid, or invocation failed for some
 	// other reason, non-nil error value is returned in response.
 	Identify(ctx context.Context, token string) (Identity, error)
+
+	// RevokeToken revokes the provided key. Unlike the API keys, user
+	// and recovery keys are not stored, so they remain revoked until they
+	// expire.
+	RevokeToken(ctx context.Context, token string) error
+
+	// RevokeSessions revokes all of the user and recovery keys issued to
+	// the user identified by the provided key, including the provided key.
+	RevokeSessions(ctx context.Context, token string) error
+
+	// Refresh exchanges the refresh key for a new pair of user and refresh
+	// keys. Refresh key can be used only once. Any further use of it
+	// revokes all of the refresh keys obtained using the same login.
+	Refresh(ctx context.Context, token string) (string, string, error)
+
+	// VerifyChallenge validates the challenge key and returns the identity
+	// of the user it is issued to. Challenge key can be used only once.
+	VerifyChallenge(ctx context.Context, token string) (Identity, error)
+
+	// PublicKeys retrieves the public keys that can be used to verify
+	// the issued tokens without calling the service.
+	PublicKeys(ctx context.Context) ([]PublicKey, error)
+
+	// Impersonate issues the user key of the user with the provided ID
+	// and email to the admin identified by the provided key. The key
+	// carries the admin email as its impersonator, expires in an hour,
+	// and can't be used to manage the API keys.
+	Impersonate(ctx context.Context, token, id, email string) (string, error)
+
+	// SetDisabled disables the user with the provided ID, or enables it
+	// again. None of the keys issued to the disabled user are valid until
+	// it's enabled. Only the admin is allowed to disable the users.
+	SetDisabled(ctx context.Context, token, id string, disabled bool) error
 }
 
 var _ Service = (*service)(nil)
 
 type service struct {
 	keys         KeyRepository
+	revocations  RevocationRepository
+	refresh      RefreshRepository
 	uuidProvider mainflux.UUIDProvider
 	tokenizer    Tokenizer
+	admin        string
 }
 
-// New instantiates the auth service implementation.
-func New(keys KeyRepository, up mainflux.UUIDProvider, tokenizer Tokenizer) Service {
+// New instantiates the auth service implementation. Admin is the email of
+// the platform admin, whose user keys are identified as the admin ones.
+// Empty admin email disables the admin.
+func New(keys KeyRepository, revocations RevocationRepository, refresh RefreshRepository, up mainflux.UUIDProvider, tokenizer Tokenizer, admin string) Service {
 	return &service{
 		tokenizer:    tokenizer,
 		keys:         keys,
+		revocations:  revocations,
+		refresh:      refresh,
 		uuidProvider: up,
+		admin:        admin,
 	}
 }
 
@@ -80,18 +140,25 @@ func (svc service) Issue(ctx context.Context, token string, key Key) (Key, strin
 	if key.IssuedAt.IsZero() {
 		return Key{}, "", ErrInvalidKeyIssuedAt
 	}
+	if err := validateScopes(key); err != nil {
+		return Key{}, "", err
+	}
 	switch key.Type {
 	case APIKey:
 		return svc.userKey(ctx, token, key)
 	case RecoveryKey:
 		return svc.tmpKey(recoveryDuration, key)
+	case RefreshKey:
+		return svc.refreshKey(ctx, key, "")
+	case ChallengeKey:
+		return svc.tmpKey(challengeDuration, key)
 	default:
 		return svc.tmpKey(loginDuration, key)
 	}
 }
 
 func (svc service) Revoke(ctx context.Context, token, id string) error {
-	issuerID, _, err := svc.login(token)
+	issuerID, _, err := svc.login(ctx, token)
 	if err != nil {
 		return errors.Wrap(errRevoke, err)
 	}
@@ -102,7 +169,7 @@ func (svc service) Revoke(ctx context.Context, token, id string) error {
 }
 
 func (svc service) Retrieve(ctx context.Context, token, id string) (Key, error) {
-	issuerID, _, err := svc.login(token)
+	issuerID, _, err := svc.login(ctx, token)
 	if err != nil {
 		return Key{}, errors.Wrap(errRetrieve, err)
 	}
@@ -110,11 +177,34 @@ func (svc service) Retrieve(ctx context.Context, token, id string) (Key, error)
 	return svc.keys.Retrieve(ctx, issuerID, id)
 }
 
+func (svc service) ListKeys(ctx context.Context, token string, pm PageMetadata) (KeyPage, error) {
+	issuerID, _, err := svc.login(ctx, token)
+	if err != nil {
+		return KeyPage{}, errors.Wrap(errList, err)
+	}
+
+	return svc.keys.RetrieveAll(ctx, issuerID, pm)
+}
+
+func (svc service) RevokeAll(ctx context.Context, token, subject string) error {
+	issuerID, _, err := svc.login(ctx, token)
+	if err != nil {
+		return errors.Wrap(errRevoke, err)
+	}
+	if err := svc.keys.RemoveAll(ctx, issuerID, subject); err != nil {
+		return errors.Wrap(errRevoke, err)
+	}
+	return nil
+}
+
 func (svc service) Identify(ctx context.Context, token string) (Identity, error) {
 	key, err := svc.tokenizer.Parse(token)
 	if err != nil {
 		return Identity{}, errors.Wrap(errIdentify, err)
 	}
+	if err := svc.checkDisabled(ctx, key.IssuerID); err != nil {
+		return Identity{}, err
+	}
 
 	switch key.Type {
 	case APIKey:
@@ -127,15 +217,213 @@ func (svc service) Identify(ctx context.Context, token string) (Identity, error)
 			svc.keys.Remove(ctx, key.IssuerID, key.ID)
 			return Identity{}, ErrKeyExpired
 		}
-		return Identity{ID: k.IssuerID, Email: k.Subject}, nil
+		// Failure to track the key usage doesn't prevent identification.
+		svc.keys.UpdateLastUsed(ctx, key.IssuerID, key.ID, time.Now().UTC())
+		return Identity{ID: k.IssuerID, Email: k.Subject, Scopes: k.Scopes, KeyID: k.ID}, nil
 	case RecoveryKey, UserKey:
-		return Identity{ID: key.IssuerID, Email: key.Subject}, nil
+		if err := svc.checkRevoked(ctx, key); err != nil {
+			return Identity{}, err
+		}
+		id := Identity{
+			ID:           key.IssuerID,
+			Email:        key.Subject,
+			Admin:        svc.isAdmin(key),
+			Impersonator: key.Impersonator,
+			KeyID:        key.ID,
+		}
+		return id, nil
 	default:
 		return Identity{}, ErrUnauthorizedAccess
 	}
 }
 
+func (svc service) RevokeToken(ctx context.Context, token string) error {
+	key, err := svc.tokenizer.Parse(token)
+	if err != nil {
+		return errors.Wrap(errRevokeTmp, err)
+	}
+
+	if key.Type == RefreshKey {
+		if key.ID == "" {
+			return errors.Wrap(errRevokeTmp, ErrMalformedEntity)
+		}
+		if err := svc.refresh.RemoveFamily(ctx, key.ID); err != nil {
+			return errors.Wrap(errRevokeTmp, err)
+		}
+		return nil
+	}
+
+	if _, err := svc.Identify(ctx, token); err != nil {
+		return errors.Wrap(errRevokeTmp, err)
+	}
+
+	if key.Type == APIKey {
+		if err := svc.keys.Remove(ctx, key.IssuerID, key.ID); err != nil {
+			return errors.Wrap(errRevokeTmp, err)
+		}
+		return nil
+	}
+
+	// Keys issued before the revocation list was introduced can't be
+	// revoked one by one.
+	if key.ID == "" {
+		return errors.Wrap(errRevokeTmp, ErrMalformedEntity)
+	}
+	if err := svc.revocations.Save(ctx, key.ID, key.ExpiresAt); err != nil {
+		return errors.Wrap(errRevokeTmp, err)
+	}
+
+	return nil
+}
+
+func (svc service) RevokeSessions(ctx context.Context, token string) error {
+	id, err := svc.Identify(ctx, token)
+	if err != nil {
+		return errors.Wrap(errRevokeTmp, err)
+	}
+
+	if err := svc.revocations.SaveCutoff(ctx, id.ID, time.Now().UTC()); err != nil {
+		return errors.Wrap(errRevokeTmp, err)
+	}
+
+	// The cutoff doesn't cover the keys issued within its second, so the
+	// key used for the revocation is revoked explicitly.
+	key, err := svc.tokenizer.Parse(token)
+	if err != nil {
+		return errors.Wrap(errRevokeTmp, err)
+	}
+	if key.Type != APIKey && key.ID != "" {
+		if err := svc.revocations.Save(ctx, key.ID, key.ExpiresAt); err != nil {
+			return errors.Wrap(errRevokeTmp, err)
+		}
+	}
+
+	return nil
+}
+
+func (svc service) Refresh(ctx context.Context, token string) (string, string, error) {
+	key, err := svc.tokenizer.Parse(token)
+	if err != nil {
+		return "", "", errors.Wrap(errRefresh, err)
+	}
+	if key.Type != RefreshKey || key.ID == "" {
+		return "", "", ErrUnauthorizedAccess
+	}
+	if err := svc.checkRevoked(ctx, key); err != nil {
+		return "", "", err
+	}
+	if err := svc.checkDisabled(ctx, key.IssuerID); err != nil {
+		return "", "", err
+	}
+
+	rt, err := svc.refresh.Use(ctx, key.ID)
+	switch {
+	case errors.Contains(err, ErrKeyReused):
+		// Reuse of the key means that it has leaked, so the whole family
+		// is revoked to log out both the user and the attacker.
+		if err := svc.refresh.RemoveFamily(ctx, key.ID); err != nil {
+			return "", "", errors.Wrap(errRefresh, err)
+		}
+		return "", "", ErrKeyReused
+	case errors.Contains(err, ErrNotFound):
+		return "", "", errors.Wrap(ErrUnauthorizedAccess, err)
+	case err != nil:
+		return "", "", errors.Wrap(errRefresh, err)
+	}
+
+	now := time.Now().UTC()
+	_, access, err := svc.tmpKey(loginDuration, Key{Type: UserKey, IssuerID: rt.IssuerID, Subject: rt.Subject, IssuedAt: now})
+	if err != nil {
+		return "", "", errors.Wrap(errRefresh, err)
+	}
+	_, refresh, err := svc.refreshKey(ctx, Key{Type: RefreshKey, IssuerID: rt.IssuerID, Subject: rt.Subject, IssuedAt: now}, rt.FamilyID)
+	if err != nil {
+		return "", "", errors.Wrap(errRefresh, err)
+	}
+
+	return access, refresh, nil
+}
+
+func (svc service) VerifyChallenge(ctx context.Context, token string) (Identity, error) {
+	key, err := svc.tokenizer.Parse(token)
+	if err != nil {
+		return Identity{}, errors.Wrap(errChallenge, err)
+	}
+	if key.Type != ChallengeKey || key.ID == "" {
+		return Identity{}, ErrUnauthorizedAccess
+	}
+	if err := svc.checkRevoked(ctx, key); err != nil {
+		return Identity{}, err
+	}
+	if err := svc.checkDisabled(ctx, key.IssuerID); err != nil {
+		return Identity{}, err
+	}
+
+	// The key is revoked right away, so a failed verification of the
+	// second factor requires the user to log in again.
+	if err := svc.revocations.Save(ctx, key.ID, key.ExpiresAt); err != nil {
+		return Identity{}, errors.Wrap(errChallenge, err)
+	}
+
+	return Identity{ID: key.IssuerID, Email: key.Subject}, nil
+}
+
+func (svc service) PublicKeys(ctx context.Context) ([]PublicKey, error) {
+	return svc.tokenizer.PublicKeys(), nil
+}
+
+func (svc service) Impersonate(ctx context.Context, token, id, email string) (string, error) {
+	admin, err := svc.authorizeAdmin(ctx, token)
+	if err != nil {
+		return "", errors.Wrap(errImpersonate, err)
+	}
+	// Impersonating the admin itself would hide the admin actions
+	// behind the impersonation key.
+	if id == "" || email == "" || id == admin.ID || email == admin.Email {
+		return "", ErrMalformedEntity
+	}
+	if err := svc.checkDisabled(ctx, id); err != nil {
+		return "", errors.Wrap(errImpersonate, err)
+	}
+
+	key := Key{
+		Type:         UserKey,
+		IssuerID:     id,
+		Subject:      email,
+		IssuedAt:     time.Now().UTC(),
+		Impersonator: admin.Email,
+	}
+	_, secret, err := svc.tmpKey(impersonationDuration, key)
+	if err != nil {
+		return "", errors.Wrap(errImpersonate, err)
+	}
+
+	return secret, nil
+}
+
+func (svc service) SetDisabled(ctx context.Context, token, id string, disabled bool) error {
+	admin, err := svc.authorizeAdmin(ctx, token)
+	if err != nil {
+		return errors.Wrap(errDisable, err)
+	}
+	// The admin can't lock itself out.
+	if id == "" || id == admin.ID {
+		return ErrMalformedEntity
+	}
+	if err := svc.revocations.SaveDisabled(ctx, id, disabled); err != nil {
+		return errors.Wrap(errDisable, err)
+	}
+
+	return nil
+}
+
 func (svc service) tmpKey(duration time.Duration, key Key) (Key, string, error) {
+	// The key ID is used only to revoke the key, since the key is not stored.
+	id, err := svc.uuidProvider.ID()
+	if err != nil {
+		return Key{}, "", errors.Wrap(errIssueTmp, err)
+	}
+	key.ID = id
 	key.ExpiresAt = key.IssuedAt.Add(duration)
 	secret, err := svc.tokenizer.Issue(key)
 	if err != nil {
@@ -145,8 +433,41 @@ func (svc service) tmpKey(duration time.Duration, key Key) (Key, string, error)
 	return key, secret, nil
 }
 
+// refreshKey issues a new refresh key that belongs to the provided family.
+// Empty family ID starts a new family.
+func (svc service) refreshKey(ctx context.Context, key Key, familyID string) (Key, string, error) {
+	id, err := svc.uuidProvider.ID()
+	if err != nil {
+		return Key{}, "", errors.Wrap(errIssueTmp, err)
+	}
+	if familyID == "" {
+		familyID = id
+	}
+	key.ID = id
+	key.ExpiresAt = key.IssuedAt.Add(refreshDuration)
+
+	rt := RefreshToken{
+		ID:        key.ID,
+		FamilyID:  familyID,
+		IssuerID:  key.IssuerID,
+		Subject:   key.Subject,
+		IssuedAt:  key.IssuedAt,
+		ExpiresAt: key.ExpiresAt,
+	}
+	if err := svc.refresh.Save(ctx, rt); err != nil {
+		return Key{}, "", errors.Wrap(errIssueTmp, err)
+	}
+
+	secret, err := svc.tokenizer.Issue(key)
+	if err != nil {
+		return Key{}, "", errors.Wrap(errIssueTmp, err)
+	}
+
+	return key, secret, nil
+}
+
 func (svc service) userKey(ctx context.Context, token string, key Key) (Key, string, error) {
-	id, sub, err := svc.login(token)
+	id, sub, err := svc.login(ctx, token)
 	if err != nil {
 		return Key{}, "", errors.Wrap(errIssueUser, err)
 	}
@@ -174,15 +495,99 @@ func (svc service) userKey(ctx context.Context, token string, key Key) (Key, str
 	return key, secret, nil
 }
 
-func (svc service) login(token string) (string, string, error) {
+// validateScopes checks the key scopes. Only API keys can be scoped.
+func validateScopes(key Key) error {
+	if len(key.Scopes) == 0 {
+		return nil
+	}
+	if key.Type != APIKey {
+		return ErrMalformedEntity
+	}
+	for _, s := range key.Scopes {
+		if err := s.Validate(); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// checkRevoked verifies that the user or recovery key is neither revoked
+// on its own nor issued before the sessions of its issuer were revoked.
+func (svc service) checkRevoked(ctx context.Context, key Key) error {
+	if key.ID != "" {
+		revoked, err := svc.revocations.Contains(ctx, key.ID)
+		if err != nil {
+			return errors.Wrap(errIdentify, err)
+		}
+		if revoked {
+			return ErrKeyRevoked
+		}
+	}
+
+	cutoff, err := svc.revocations.RetrieveCutoff(ctx, key.IssuerID)
+	if err != nil {
+		return errors.Wrap(errIdentify, err)
+	}
+	// Token issue time is stored with the precision of a second, so the
+	// keys issued within the same second as the cutoff are left valid to
+	// let the user log in right after the revocation.
+	if key.IssuedAt.Before(cutoff.Truncate(time.Second)) {
+		return ErrKeyRevoked
+	}
+
+	return nil
+}
+
+// checkDisabled verifies that the issuer with the provided ID is not
+// disabled.
+func (svc service) checkDisabled(ctx context.Context, issuerID string) error {
+	disabled, err := svc.revocations.Disabled(ctx, issuerID)
+	if err != nil {
+		return errors.Wrap(errIdentify, err)
+	}
+	if disabled {
+		return ErrDisabled
+	}
+
+	return nil
+}
+
+// isAdmin checks whether the key is the user key of the admin. Keys issued
+// on behalf of the admin to anyone else are not the admin keys.
+func (svc service) isAdmin(key Key) bool {
+	return svc.admin != "" && key.Type == UserKey && key.Impersonator == "" && key.Subject == svc.admin
+}
+
+// authorizeAdmin identifies the admin by the provided token.
+func (svc service) authorizeAdmin(ctx context.Context, token string) (Identity, error) {
+	id, err := svc.Identify(ctx, token)
+	if err != nil {
+		return Identity{}, err
+	}
+	if !id.Admin {
+		return Identity{}, ErrUnauthorizedAccess
+	}
+
+	return id, nil
+}
+
+func (svc service) login(ctx context.Context, token string) (string, string, error) {
 	key, err := svc.tokenizer.Parse(token)
 	if err != nil {
 		return "", "", err
 	}
-	// Only user key token is valid for login.
-	if key.Type != UserKey || key.IssuerID == "" {
+	// Only user key token is valid for login. Keys used to impersonate
+	// the users can't be used to manage their keys.
+	if key.Type != UserKey || key.IssuerID == "" || key.Impersonator != "" {
 		return "", "", ErrUnauthorizedAccess
 	}
+	if err := svc.checkRevoked(ctx, key); err != nil {
+		return "", "", err
+	}
+	if err := svc.checkDisabled(ctx, key.IssuerID); err != nil {
+		return "", "", err
+	}
 
 	return key.IssuerID, key.Subject, nil
 }
@@ -5,6 +5,9 @@ package authn_test
 
 import (
 	"context"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
 	"fmt"
 	"testing"
 	"time"
@@ -15,19 +18,22 @@ import (
 	"github.com/mainflux/mainflux/pkg/errors"
 	"github.com/mainflux/mainflux/pkg/uuid"
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
 )
 
 const (
-	secret = "secret"
-	email  = "test@example.com"
-	id     = "testID"
+	secret     = "secret"
+	email      = "test@example.com"
+	id         = "testID"
+	adminEmail = "admin@example.com"
+	adminID    = "adminID"
 )
 
 func newService() authn.Service {
 	repo := mocks.NewKeyRepository()
 	uuidProvider := uuid.NewMock()
 	t := jwt.New(secret)
-	return authn.New(repo, uuidProvider, t)
+	return authn.New(repo, mocks.NewRevocationRepository(), mocks.NewRefreshRepository(), uuidProvider, t, adminEmail)
 }
 
 func TestIssue(t *testing.T) {
@@ -84,6 +90,36 @@ func TestIssue(t *testing.T) {
 			token: secret,
 			err:   authn.ErrInvalidKeyIssuedAt,
 		},
+		{
+			desc: "issue scoped API key",
+			key: authn.Key{
+				Type:     authn.APIKey,
+				IssuedAt: time.Now(),
+				Scopes:   []authn.Scope{{Resource: "messages", Action: "read", IDs: []string{"1"}}},
+			},
+			token: secret,
+			err:   nil,
+		},
+		{
+			desc: "issue API key with invalid scope",
+			key: authn.Key{
+				Type:     authn.APIKey,
+				IssuedAt: time.Now(),
+				Scopes:   []authn.Scope{{Resource: "users", Action: "read"}},
+			},
+			token: secret,
+			err:   authn.ErrMalformedEntity,
+		},
+		{
+			desc: "issue scoped user key",
+			key: authn.Key{
+				Type:     authn.UserKey,
+				IssuedAt: time.Now(),
+				Scopes:   []authn.Scope{{Resource: "things", Action: "read"}},
+			},
+			token: secret,
+			err:   authn.ErrMalformedEntity,
+		},
 		{
 			desc: "issue recovery key",
 			key: authn.Key{
@@ -219,16 +255,442 @@ func TestRetrieve(t *testing.T) {
 	}
 }
 
-func TestIdentify(t *testing.T) {
+func TestListKeys(t *testing.T) {
+	svc := newService()
+	_, userToken, err := svc.Issue(context.Background(), "", authn.Key{Type: authn.UserKey, IssuedAt: time.Now(), IssuerID: id, Subject: email})
+	assert.Nil(t, err, fmt.Sprintf("Issuing user key expected to succeed: %s", err))
+
+	n := uint64(5)
+	var apiToken string
+	for i := uint64(0); i < n; i++ {
+		key := authn.Key{Type: authn.APIKey, IssuedAt: time.Now()}
+		if i == 0 {
+			key.ExpiresAt = time.Now().Add(time.Millisecond)
+		}
+		_, token, err := svc.Issue(context.Background(), userToken, key)
+		require.Nil(t, err, fmt.Sprintf("Issuing user's key expected to succeed: %s", err))
+		apiToken = token
+	}
+	time.Sleep(10 * time.Millisecond)
+
+	_, err = svc.Identify(context.Background(), apiToken)
+	require.Nil(t, err, fmt.Sprintf("Identifying API key expected to succeed: %s", err))
+
+	apiKey := authn.APIKey
+	userKey := authn.UserKey
+	cases := []struct {
+		desc  string
+		token string
+		pm    authn.PageMetadata
+		size  uint64
+		total uint64
+		err   error
+	}{
+		{
+			desc:  "list all keys",
+			token: userToken,
+			pm:    authn.PageMetadata{Offset: 0, Limit: n},
+			size:  n,
+			total: n,
+			err:   nil,
+		},
+		{
+			desc:  "list subset of keys",
+			token: userToken,
+			pm:    authn.PageMetadata{Offset: n - 1, Limit: n},
+			size:  1,
+			total: n,
+			err:   nil,
+		},
+		{
+			desc:  "list active keys",
+			token: userToken,
+			pm:    authn.PageMetadata{Offset: 0, Limit: n, Status: authn.ActiveKeys},
+			size:  n - 1,
+			total: n - 1,
+			err:   nil,
+		},
+		{
+			desc:  "list expired keys",
+			token: userToken,
+			pm:    authn.PageMetadata{Offset: 0, Limit: n, Status: authn.ExpiredKeys},
+			size:  1,
+			total: 1,
+			err:   nil,
+		},
+		{
+			desc:  "list API keys",
+			token: userToken,
+			pm:    authn.PageMetadata{Offset: 0, Limit: n, Type: &apiKey},
+			size:  n,
+			total: n,
+			err:   nil,
+		},
+		{
+			desc:  "list user keys",
+			token: userToken,
+			pm:    authn.PageMetadata{Offset: 0, Limit: n, Type: &userKey},
+			size:  0,
+			total: 0,
+			err:   nil,
+		},
+		{
+			desc:  "list keys with API token",
+			token: apiToken,
+			pm:    authn.PageMetadata{Offset: 0, Limit: n},
+			err:   authn.ErrUnauthorizedAccess,
+		},
+		{
+			desc:  "list keys unauthorized",
+			token: "wrong",
+			pm:    authn.PageMetadata{Offset: 0, Limit: n},
+			err:   authn.ErrUnauthorizedAccess,
+		},
+	}
+
+	for _, tc := range cases {
+		page, err := svc.ListKeys(context.Background(), tc.token, tc.pm)
+		assert.True(t, errors.Contains(err, tc.err), fmt.Sprintf("%s: expected %s got %s\n", tc.desc, tc.err, err))
+		assert.Equal(t, tc.size, uint64(len(page.Keys)), fmt.Sprintf("%s: expected %d got %d\n", tc.desc, tc.size, len(page.Keys)))
+		assert.Equal(t, tc.total, page.Total, fmt.Sprintf("%s: expected total %d got %d\n", tc.desc, tc.total, page.Total))
+	}
+
+	page, err := svc.ListKeys(context.Background(), userToken, authn.PageMetadata{Offset: n - 1, Limit: 1})
+	require.Nil(t, err, fmt.Sprintf("Listing keys expected to succeed: %s", err))
+	assert.False(t, page.Keys[0].LastUsedAt.IsZero(), "Last used time of the identified key expected to be set")
+}
+
+func TestRevokeAll(t *testing.T) {
 	svc := newService()
+	_, userToken, err := svc.Issue(context.Background(), "", authn.Key{Type: authn.UserKey, IssuedAt: time.Now(), IssuerID: id, Subject: email})
+	assert.Nil(t, err, fmt.Sprintf("Issuing user key expected to succeed: %s", err))
+
+	other := "ci@example.com"
+	var apiToken string
+	for _, sub := range []string{email, email, other} {
+		_, token, err := svc.Issue(context.Background(), userToken, authn.Key{Type: authn.APIKey, IssuedAt: time.Now(), Subject: sub})
+		require.Nil(t, err, fmt.Sprintf("Issuing user's key expected to succeed: %s", err))
+		apiToken = token
+	}
 
+	cases := []struct {
+		desc    string
+		token   string
+		subject string
+		total   uint64
+		err     error
+	}{
+		{
+			desc:    "revoke all keys with API token",
+			token:   apiToken,
+			subject: "",
+			total:   3,
+			err:     authn.ErrUnauthorizedAccess,
+		},
+		{
+			desc:    "revoke all keys of the subject",
+			token:   userToken,
+			subject: other,
+			total:   2,
+			err:     nil,
+		},
+		{
+			desc:    "revoke all keys",
+			token:   userToken,
+			subject: "",
+			total:   0,
+			err:     nil,
+		},
+	}
+
+	for _, tc := range cases {
+		err := svc.RevokeAll(context.Background(), tc.token, tc.subject)
+		assert.True(t, errors.Contains(err, tc.err), fmt.Sprintf("%s: expected %s got %s\n", tc.desc, tc.err, err))
+		page, err := svc.ListKeys(context.Background(), userToken, authn.PageMetadata{Limit: 10})
+		require.Nil(t, err, fmt.Sprintf("Listing keys expected to succeed: %s", err))
+		assert.Equal(t, tc.total, page.Total, fmt.Sprintf("%s: expected total %d got %d\n", tc.desc, tc.total, page.Total))
+	}
+}
+
+func TestRevokeToken(t *testing.T) {
+	svc := newService()
 	_, loginSecret, err := svc.Issue(context.Background(), "", authn.Key{Type: authn.UserKey, IssuedAt: time.Now(), IssuerID: id, Subject: email})
 	assert.Nil(t, err, fmt.Sprintf("Issuing login key expected to succeed: %s", err))
-
+	_, otherSecret, err := svc.Issue(context.Background(), "", authn.Key{Type: authn.UserKey, IssuedAt: time.Now(), IssuerID: id, Subject: email})
+	assert.Nil(t, err, fmt.Sprintf("Issuing login key expected to succeed: %s", err))
 	_, recoverySecret, err := svc.Issue(context.Background(), "", authn.Key{Type: authn.RecoveryKey, IssuedAt: time.Now(), IssuerID: id, Subject: email})
+	assert.Nil(t, err, fmt.Sprintf("Issuing recovery key expected to succeed: %s", err))
+	_, apiSecret, err := svc.Issue(context.Background(), otherSecret, authn.Key{Type: authn.APIKey, IssuedAt: time.Now()})
+	assert.Nil(t, err, fmt.Sprintf("Issuing API key expected to succeed: %s", err))
+
+	cases := []struct {
+		desc  string
+		token string
+		err   error
+	}{
+		{
+			desc:  "revoke login key",
+			token: loginSecret,
+			err:   nil,
+		},
+		{
+			desc:  "revoke already revoked login key",
+			token: loginSecret,
+			err:   authn.ErrKeyRevoked,
+		},
+		{
+			desc:  "revoke recovery key",
+			token: recoverySecret,
+			err:   nil,
+		},
+		{
+			desc:  "revoke API key",
+			token: apiSecret,
+			err:   nil,
+		},
+		{
+			desc:  "revoke already revoked API key",
+			token: apiSecret,
+			err:   authn.ErrNotFound,
+		},
+		{
+			desc:  "revoke invalid key",
+			token: "invalid",
+			err:   authn.ErrUnauthorizedAccess,
+		},
+	}
+
+	for _, tc := range cases {
+		err := svc.RevokeToken(context.Background(), tc.token)
+		assert.True(t, errors.Contains(err, tc.err), fmt.Sprintf("%s expected %s got %s\n", tc.desc, tc.err, err))
+	}
+
+	_, err = svc.Identify(context.Background(), otherSecret)
+	assert.Nil(t, err, fmt.Sprintf("identifying login key that is not revoked expected to succeed: %s", err))
+
+	_, _, err = svc.Issue(context.Background(), loginSecret, authn.Key{Type: authn.APIKey, IssuedAt: time.Now()})
+	assert.True(t, errors.Contains(err, authn.ErrKeyRevoked), fmt.Sprintf("issuing API key using revoked login key expected %s got %s\n", authn.ErrKeyRevoked, err))
+	_, err = svc.ListKeys(context.Background(), loginSecret, authn.PageMetadata{Limit: 10})
+	assert.True(t, errors.Contains(err, authn.ErrKeyRevoked), fmt.Sprintf("listing keys using revoked login key expected %s got %s\n", authn.ErrKeyRevoked, err))
+}
+
+func TestRevokeSessions(t *testing.T) {
+	svc := newService()
+	_, loginSecret, err := svc.Issue(context.Background(), "", authn.Key{Type: authn.UserKey, IssuedAt: time.Now(), IssuerID: id, Subject: email})
+	assert.Nil(t, err, fmt.Sprintf("Issuing login key expected to succeed: %s", err))
+	// Keys issued within the second of revocation are not revoked.
+	before := time.Now().Add(-time.Second)
+	_, oldSecret, err := svc.Issue(context.Background(), "", authn.Key{Type: authn.UserKey, IssuedAt: before, IssuerID: id, Subject: email})
+	assert.Nil(t, err, fmt.Sprintf("Issuing login key expected to succeed: %s", err))
+	_, recoverySecret, err := svc.Issue(context.Background(), "", authn.Key{Type: authn.RecoveryKey, IssuedAt: before, IssuerID: id, Subject: email})
+	assert.Nil(t, err, fmt.Sprintf("Issuing recovery key expected to succeed: %s", err))
+	_, apiSecret, err := svc.Issue(context.Background(), loginSecret, authn.Key{Type: authn.APIKey, IssuedAt: before})
+	assert.Nil(t, err, fmt.Sprintf("Issuing API key expected to succeed: %s", err))
+	_, otherSecret, err := svc.Issue(context.Background(), "", authn.Key{Type: authn.UserKey, IssuedAt: time.Now(), IssuerID: "otherID", Subject: "other@example.com"})
+	assert.Nil(t, err, fmt.Sprintf("Issuing login key expected to succeed: %s", err))
+
+	err = svc.RevokeSessions(context.Background(), "invalid")
+	assert.True(t, errors.Contains(err, authn.ErrUnauthorizedAccess), fmt.Sprintf("revoking sessions with invalid key expected to fail with %s got %s", authn.ErrUnauthorizedAccess, err))
+
+	err = svc.RevokeSessions(context.Background(), loginSecret)
+	require.Nil(t, err, fmt.Sprintf("revoking sessions expected to succeed: %s", err))
+
+	_, newSecret, err := svc.Issue(context.Background(), "", authn.Key{Type: authn.UserKey, IssuedAt: time.Now(), IssuerID: id, Subject: email})
+	assert.Nil(t, err, fmt.Sprintf("Issuing login key expected to succeed: %s", err))
+
+	cases := []struct {
+		desc  string
+		token string
+		err   error
+	}{
+		{
+			desc:  "identify login key used for revocation",
+			token: loginSecret,
+			err:   authn.ErrKeyRevoked,
+		},
+		{
+			desc:  "identify login key issued before revocation",
+			token: oldSecret,
+			err:   authn.ErrKeyRevoked,
+		},
+		{
+			desc:  "identify recovery key issued before revocation",
+			token: recoverySecret,
+			err:   authn.ErrKeyRevoked,
+		},
+		{
+			desc:  "identify API key issued before revocation",
+			token: apiSecret,
+			err:   nil,
+		},
+		{
+			desc:  "identify login key of other user",
+			token: otherSecret,
+			err:   nil,
+		},
+		{
+			desc:  "identify login key issued after revocation",
+			token: newSecret,
+			err:   nil,
+		},
+	}
+
+	for _, tc := range cases {
+		_, err := svc.Identify(context.Background(), tc.token)
+		assert.True(t, errors.Contains(err, tc.err), fmt.Sprintf("%s expected %s got %s\n", tc.desc, tc.err, err))
+	}
+}
+
+func TestRefresh(t *testing.T) {
+	svc := newService()
+	_, refreshSecret, err := svc.Issue(context.Background(), "", authn.Key{Type: authn.RefreshKey, IssuedAt: time.Now(), IssuerID: id, Subject: email})
+	require.Nil(t, err, fmt.Sprintf("Issuing refresh key expected to succeed: %s", err))
+	_, loginSecret, err := svc.Issue(context.Background(), "", authn.Key{Type: authn.UserKey, IssuedAt: time.Now(), IssuerID: id, Subject: email})
+	require.Nil(t, err, fmt.Sprintf("Issuing login key expected to succeed: %s", err))
+
+	access, newRefreshSecret, err := svc.Refresh(context.Background(), refreshSecret)
+	require.Nil(t, err, fmt.Sprintf("refreshing key expected to succeed: %s", err))
+	identity, err := svc.Identify(context.Background(), access)
+	assert.Nil(t, err, fmt.Sprintf("identifying refreshed login key expected to succeed: %s", err))
+	assert.Equal(t, authn.Identity{ID: id, Email: email, KeyID: identity.KeyID}, identity, fmt.Sprintf("identifying refreshed login key expected %s got %s", email, identity.Email))
+	assert.NotEmpty(t, identity.KeyID, "identifying refreshed login key expected key ID")
+
+	cases := []struct {
+		desc  string
+		token string
+		err   error
+	}{
+		{
+			desc:  "refresh with login key",
+			token: loginSecret,
+			err:   authn.ErrUnauthorizedAccess,
+		},
+		{
+			desc:  "refresh with invalid key",
+			token: "invalid",
+			err:   authn.ErrUnauthorizedAccess,
+		},
+		{
+			desc:  "refresh with already used refresh key",
+			token: refreshSecret,
+			err:   authn.ErrKeyReused,
+		},
+		{
+			desc:  "refresh with refresh key of revoked family",
+			token: newRefreshSecret,
+			err:   authn.ErrUnauthorizedAccess,
+		},
+	}
+
+	for _, tc := range cases {
+		_, _, err := svc.Refresh(context.Background(), tc.token)
+		assert.True(t, errors.Contains(err, tc.err), fmt.Sprintf("%s expected %s got %s\n", tc.desc, tc.err, err))
+	}
+
+	_, err = svc.Identify(context.Background(), newRefreshSecret)
+	assert.True(t, errors.Contains(err, authn.ErrUnauthorizedAccess), fmt.Sprintf("identifying refresh key expected to fail with %s got %s", authn.ErrUnauthorizedAccess, err))
+}
+
+func TestRefreshRevoked(t *testing.T) {
+	svc := newService()
+	_, loginSecret, err := svc.Issue(context.Background(), "", authn.Key{Type: authn.UserKey, IssuedAt: time.Now(), IssuerID: id, Subject: email})
+	require.Nil(t, err, fmt.Sprintf("Issuing login key expected to succeed: %s", err))
+	_, refreshSecret, err := svc.Issue(context.Background(), "", authn.Key{Type: authn.RefreshKey, IssuedAt: time.Now(), IssuerID: id, Subject: email})
+	require.Nil(t, err, fmt.Sprintf("Issuing refresh key expected to succeed: %s", err))
+	// Keys issued within the second of revocation are not revoked.
+	_, oldRefreshSecret, err := svc.Issue(context.Background(), "", authn.Key{Type: authn.RefreshKey, IssuedAt: time.Now().Add(-time.Second), IssuerID: id, Subject: email})
+	require.Nil(t, err, fmt.Sprintf("Issuing refresh key expected to succeed: %s", err))
+
+	err = svc.RevokeToken(context.Background(), refreshSecret)
+	require.Nil(t, err, fmt.Sprintf("revoking refresh key expected to succeed: %s", err))
+	err = svc.RevokeToken(context.Background(), refreshSecret)
+	assert.True(t, errors.Contains(err, authn.ErrNotFound), fmt.Sprintf("revoking already revoked refresh key expected to fail with %s got %s", authn.ErrNotFound, err))
+
+	err = svc.RevokeSessions(context.Background(), loginSecret)
+	require.Nil(t, err, fmt.Sprintf("revoking sessions expected to succeed: %s", err))
+
+	cases := []struct {
+		desc  string
+		token string
+		err   error
+	}{
+		{
+			desc:  "refresh with revoked refresh key",
+			token: refreshSecret,
+			err:   authn.ErrUnauthorizedAccess,
+		},
+		{
+			desc:  "refresh with refresh key issued before sessions revocation",
+			token: oldRefreshSecret,
+			err:   authn.ErrKeyRevoked,
+		},
+	}
+
+	for _, tc := range cases {
+		_, _, err := svc.Refresh(context.Background(), tc.token)
+		assert.True(t, errors.Contains(err, tc.err), fmt.Sprintf("%s expected %s got %s\n", tc.desc, tc.err, err))
+	}
+}
+
+func TestVerifyChallenge(t *testing.T) {
+	svc := newService()
+	_, challengeSecret, err := svc.Issue(context.Background(), "", authn.Key{Type: authn.ChallengeKey, IssuedAt: time.Now(), IssuerID: id, Subject: email})
+	require.Nil(t, err, fmt.Sprintf("Issuing challenge key expected to succeed: %s", err))
+	_, loginSecret, err := svc.Issue(context.Background(), "", authn.Key{Type: authn.UserKey, IssuedAt: time.Now(), IssuerID: id, Subject: email})
+	require.Nil(t, err, fmt.Sprintf("Issuing login key expected to succeed: %s", err))
+
+	_, err = svc.Identify(context.Background(), challengeSecret)
+	assert.True(t, errors.Contains(err, authn.ErrUnauthorizedAccess), fmt.Sprintf("identifying with challenge key expected to fail with %s got %s", authn.ErrUnauthorizedAccess, err))
+
+	cases := []struct {
+		desc string
+		key  string
+		id   authn.Identity
+		err  error
+	}{
+		{
+			desc: "verify challenge key",
+			key:  challengeSecret,
+			id:   authn.Identity{ID: id, Email: email},
+			err:  nil,
+		},
+		{
+			desc: "verify used challenge key",
+			key:  challengeSecret,
+			id:   authn.Identity{},
+			err:  authn.ErrKeyRevoked,
+		},
+		{
+			desc: "verify user key",
+			key:  loginSecret,
+			id:   authn.Identity{},
+			err:  authn.ErrUnauthorizedAccess,
+		},
+		{
+			desc: "verify invalid key",
+			key:  "invalid",
+			id:   authn.Identity{},
+			err:  authn.ErrUnauthorizedAccess,
+		},
+	}
+
+	for _, tc := range cases {
+		id, err := svc.VerifyChallenge(context.Background(), tc.key)
+		assert.True(t, errors.Contains(err, tc.err), fmt.Sprintf("%s expected %s got %s\n", tc.desc, tc.err, err))
+		assert.Equal(t, tc.id, id, fmt.Sprintf("%s expected %v got %v\n", tc.desc, tc.id, id))
+	}
+}
+
+func TestIdentify(t *testing.T) {
+	svc := newService()
+
+	loginKey, loginSecret, err := svc.Issue(context.Background(), "", authn.Key{Type: authn.UserKey, IssuedAt: time.Now(), IssuerID: id, Subject: email})
+	assert.Nil(t, err, fmt.Sprintf("Issuing login key expected to succeed: %s", err))
+
+	recoveryKey, recoverySecret, err := svc.Issue(context.Background(), "", authn.Key{Type: authn.RecoveryKey, IssuedAt: time.Now(), IssuerID: id, Subject: email})
 	assert.Nil(t, err, fmt.Sprintf("Issuing reset key expected to succeed: %s", err))
 
-	_, apiSecret, err := svc.Issue(context.Background(), loginSecret, authn.Key{Type: authn.APIKey, IssuerID: id, Subject: email, IssuedAt: time.Now(), ExpiresAt: time.Now().Add(time.Minute)})
+	apiKey, apiSecret, err := svc.Issue(context.Background(), loginSecret, authn.Key{Type: authn.APIKey, IssuerID: id, Subject: email, IssuedAt: time.Now(), ExpiresAt: time.Now().Add(time.Minute)})
 	assert.Nil(t, err, fmt.Sprintf("Issuing user key expected to succeed: %s", err))
 
 	exp1 := time.Now().Add(-2 * time.Second)
@@ -238,6 +700,13 @@ func TestIdentify(t *testing.T) {
 	_, invalidSecret, err := svc.Issue(context.Background(), loginSecret, authn.Key{Type: 22, IssuedAt: time.Now()})
 	assert.Nil(t, err, fmt.Sprintf("Issuing user key expected to succeed: %s", err))
 
+	scopes := []authn.Scope{
+		{Resource: "things", Action: "read"},
+		{Resource: "messages", Action: "read", IDs: []string{"1"}},
+	}
+	scopedKey, scopedSecret, err := svc.Issue(context.Background(), loginSecret, authn.Key{Type: authn.APIKey, IssuedAt: time.Now(), Scopes: scopes})
+	assert.Nil(t, err, fmt.Sprintf("Issuing scoped key expected to succeed: %s", err))
+
 	cases := []struct {
 		desc string
 		key  string
@@ -247,19 +716,25 @@ func TestIdentify(t *testing.T) {
 		{
 			desc: "identify login key",
 			key:  loginSecret,
-			idt:  authn.Identity{id, email},
+			idt:  authn.Identity{ID: id, Email: email, KeyID: loginKey.ID},
 			err:  nil,
 		},
 		{
 			desc: "identify recovery key",
 			key:  recoverySecret,
-			idt:  authn.Identity{id, email},
+			idt:  authn.Identity{ID: id, Email: email, KeyID: recoveryKey.ID},
 			err:  nil,
 		},
 		{
 			desc: "identify API key",
 			key:  apiSecret,
-			idt:  authn.Identity{id, email},
+			idt:  authn.Identity{ID: id, Email: email, KeyID: apiKey.ID},
+			err:  nil,
+		},
+		{
+			desc: "identify scoped API key",
+			key:  scopedSecret,
+			idt:  authn.Identity{ID: id, Email: email, Scopes: scopes, KeyID: scopedKey.ID},
 			err:  nil,
 		},
 		{
@@ -285,6 +760,239 @@ func TestIdentify(t *testing.T) {
 	for _, tc := range cases {
 		idt, err := svc.Identify(context.Background(), tc.key)
 		assert.True(t, errors.Contains(err, tc.err), fmt.Sprintf("%s expected %s got %s\n", tc.desc, tc.err, err))
-		assert.Equal(t, tc.idt, idt, fmt.Sprintf("%s expected %s got %s\n", tc.desc, tc.idt, idt))
+		assert.Equal(t, tc.idt, idt, fmt.Sprintf("%s expected %v got %v\n", tc.desc, tc.idt, idt))
 	}
 }
+
+func TestIdentifyMigration(t *testing.T) {
+	repo := mocks.NewKeyRepository()
+	up := uuid.NewMock()
+	hmacSvc := authn.New(repo, mocks.NewRevocationRepository(), mocks.NewRefreshRepository(), up, jwt.New(secret), "")
+
+	_, hmacLoginSecret, err := hmacSvc.Issue(context.Background(), "", authn.Key{Type: authn.UserKey, IssuedAt: time.Now(), IssuerID: id, Subject: email})
+	assert.Nil(t, err, fmt.Sprintf("Issuing login key expected to succeed: %s", err))
+	_, hmacAPISecret, err := hmacSvc.Issue(context.Background(), hmacLoginSecret, authn.Key{Type: authn.APIKey, IssuedAt: time.Now(), ExpiresAt: time.Now().Add(time.Minute)})
+	assert.Nil(t, err, fmt.Sprintf("Issuing API key expected to succeed: %s", err))
+
+	ecKey, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	require.Nil(t, err, fmt.Sprintf("generating EC key expected to succeed: %s", err))
+	tokenizer, err := jwt.NewAsymmetric([]jwt.SigningKey{{ID: "ec", Key: ecKey}}, "ec", secret)
+	require.Nil(t, err, fmt.Sprintf("creating tokenizer expected to succeed: %s", err))
+	svc := authn.New(repo, mocks.NewRevocationRepository(), mocks.NewRefreshRepository(), up, tokenizer, "")
+
+	_, loginSecret, err := svc.Issue(context.Background(), "", authn.Key{Type: authn.UserKey, IssuedAt: time.Now(), IssuerID: id, Subject: email})
+	assert.Nil(t, err, fmt.Sprintf("Issuing login key expected to succeed: %s", err))
+	_, apiSecret, err := svc.Issue(context.Background(), hmacLoginSecret, authn.Key{Type: authn.APIKey, IssuedAt: time.Now(), ExpiresAt: time.Now().Add(time.Minute)})
+	assert.Nil(t, err, fmt.Sprintf("Issuing API key using HMAC login key expected to succeed: %s", err))
+
+	cases := []struct {
+		desc string
+		key  string
+		idt  authn.Identity
+		err  error
+	}{
+		{
+			desc: "identify HMAC login key",
+			key:  hmacLoginSecret,
+			idt:  authn.Identity{ID: id, Email: email},
+			err:  nil,
+		},
+		{
+			desc: "identify HMAC API key",
+			key:  hmacAPISecret,
+			idt:  authn.Identity{ID: id, Email: email},
+			err:  nil,
+		},
+		{
+			desc: "identify ES256 login key",
+			key:  loginSecret,
+			idt:  authn.Identity{ID: id, Email: email},
+			err:  nil,
+		},
+		{
+			desc: "identify ES256 API key",
+			key:  apiSecret,
+			idt:  authn.Identity{ID: id, Email: email},
+			err:  nil,
+		},
+	}
+
+	for _, tc := range cases {
+		idt, err := svc.Identify(context.Background(), tc.key)
+		assert.True(t, errors.Contains(err, tc.err), fmt.Sprintf("%s expected %s got %s\n", tc.desc, tc.err, err))
+		assert.NotEmpty(t, idt.KeyID, fmt.Sprintf("%s expected key ID", tc.desc))
+		tc.idt.KeyID = idt.KeyID
+		assert.Equal(t, tc.idt, idt, fmt.Sprintf("%s expected %v got %v\n", tc.desc, tc.idt, idt))
+	}
+}
+
+func TestIdentifyAdmin(t *testing.T) {
+	svc := newService()
+
+	_, adminSecret, err := svc.Issue(context.Background(), "", authn.Key{Type: authn.UserKey, IssuedAt: time.Now(), IssuerID: adminID, Subject: adminEmail})
+	require.Nil(t, err, fmt.Sprintf("Issuing admin login key expected to succeed: %s", err))
+	_, apiSecret, err := svc.Issue(context.Background(), adminSecret, authn.Key{Type: authn.APIKey, IssuedAt: time.Now()})
+	require.Nil(t, err, fmt.Sprintf("Issuing admin API key expected to succeed: %s", err))
+	_, recoverySecret, err := svc.Issue(context.Background(), "", authn.Key{Type: authn.RecoveryKey, IssuedAt: time.Now(), IssuerID: adminID, Subject: adminEmail})
+	require.Nil(t, err, fmt.Sprintf("Issuing admin recovery key expected to succeed: %s", err))
+
+	cases := []struct {
+		desc string
+		key  string
+		idt  authn.Identity
+	}{
+		{
+			desc: "identify admin login key",
+			key:  adminSecret,
+			idt:  authn.Identity{ID: adminID, Email: adminEmail, Admin: true},
+		},
+		{
+			desc: "identify admin API key",
+			key:  apiSecret,
+			idt:  authn.Identity{ID: adminID, Email: adminEmail},
+		},
+		{
+			desc: "identify admin recovery key",
+			key:  recoverySecret,
+			idt:  authn.Identity{ID: adminID, Email: adminEmail},
+		},
+	}
+
+	for _, tc := range cases {
+		idt, err := svc.Identify(context.Background(), tc.key)
+		assert.Nil(t, err, fmt.Sprintf("%s unexpected error: %s\n", tc.desc, err))
+		assert.NotEmpty(t, idt.KeyID, fmt.Sprintf("%s expected key ID", tc.desc))
+		tc.idt.KeyID = idt.KeyID
+		assert.Equal(t, tc.idt, idt, fmt.Sprintf("%s expected %v got %v\n", tc.desc, tc.idt, idt))
+	}
+}
+
+func TestImpersonate(t *testing.T) {
+	svc := newService()
+
+	_, adminSecret, err := svc.Issue(context.Background(), "", authn.Key{Type: authn.UserKey, IssuedAt: time.Now(), IssuerID: adminID, Subject: adminEmail})
+	require.Nil(t, err, fmt.Sprintf("Issuing admin login key expected to succeed: %s", err))
+	_, loginSecret, err := svc.Issue(context.Background(), "", authn.Key{Type: authn.UserKey, IssuedAt: time.Now(), IssuerID: id, Subject: email})
+	require.Nil(t, err, fmt.Sprintf("Issuing login key expected to succeed: %s", err))
+	err = svc.SetDisabled(context.Background(), adminSecret, "disabledID", true)
+	require.Nil(t, err, fmt.Sprintf("Disabling user expected to succeed: %s", err))
+
+	cases := []struct {
+		desc  string
+		token string
+		id    string
+		email string
+		err   error
+	}{
+		{
+			desc:  "impersonate user",
+			token: adminSecret,
+			id:    id,
+			email: email,
+			err:   nil,
+		},
+		{
+			desc:  "impersonate user as non-admin",
+			token: loginSecret,
+			id:    "otherID",
+			email: "other@example.com",
+			err:   authn.ErrUnauthorizedAccess,
+		},
+		{
+			desc:  "impersonate admin",
+			token: adminSecret,
+			id:    adminID,
+			email: adminEmail,
+			err:   authn.ErrMalformedEntity,
+		},
+		{
+			desc:  "impersonate disabled user",
+			token: adminSecret,
+			id:    "disabledID",
+			email: "disabled@example.com",
+			err:   authn.ErrDisabled,
+		},
+		{
+			desc:  "impersonate user with invalid token",
+			token: "invalid",
+			id:    id,
+			email: email,
+			err:   authn.ErrUnauthorizedAccess,
+		},
+	}
+
+	for _, tc := range cases {
+		token, err := svc.Impersonate(context.Background(), tc.token, tc.id, tc.email)
+		assert.True(t, errors.Contains(err, tc.err), fmt.Sprintf("%s expected %s got %s\n", tc.desc, tc.err, err))
+		if err != nil {
+			continue
+		}
+
+		idt, err := svc.Identify(context.Background(), token)
+		assert.Nil(t, err, fmt.Sprintf("%s: identifying impersonation key unexpected error: %s\n", tc.desc, err))
+		assert.NotEmpty(t, idt.KeyID, fmt.Sprintf("%s expected key ID", tc.desc))
+		expected := authn.Identity{ID: tc.id, Email: tc.email, Impersonator: adminEmail, KeyID: idt.KeyID}
+		assert.Equal(t, expected, idt, fmt.Sprintf("%s expected %v got %v\n", tc.desc, expected, idt))
+
+		_, _, err = svc.Issue(context.Background(), token, authn.Key{Type: authn.APIKey, IssuedAt: time.Now()})
+		assert.True(t, errors.Contains(err, authn.ErrUnauthorizedAccess), fmt.Sprintf("%s: issuing API key using impersonation key expected %s got %s\n", tc.desc, authn.ErrUnauthorizedAccess, err))
+	}
+}
+
+func TestSetDisabled(t *testing.T) {
+	svc := newService()
+
+	_, adminSecret, err := svc.Issue(context.Background(), "", authn.Key{Type: authn.UserKey, IssuedAt: time.Now(), IssuerID: adminID, Subject: adminEmail})
+	require.Nil(t, err, fmt.Sprintf("Issuing admin login key expected to succeed: %s", err))
+	_, loginSecret, err := svc.Issue(context.Background(), "", authn.Key{Type: authn.UserKey, IssuedAt: time.Now(), IssuerID: id, Subject: email})
+	require.Nil(t, err, fmt.Sprintf("Issuing login key expected to succeed: %s", err))
+	_, apiSecret, err := svc.Issue(context.Background(), loginSecret, authn.Key{Type: authn.APIKey, IssuedAt: time.Now()})
+	require.Nil(t, err, fmt.Sprintf("Issuing API key expected to succeed: %s", err))
+	_, refreshSecret, err := svc.Issue(context.Background(), "", authn.Key{Type: authn.RefreshKey, IssuedAt: time.Now(), IssuerID: id, Subject: email})
+	require.Nil(t, err, fmt.Sprintf("Issuing refresh key expected to succeed: %s", err))
+
+	cases := []struct {
+		desc  string
+		token string
+		id    string
+		err   error
+	}{
+		{
+			desc:  "disable user as non-admin",
+			token: loginSecret,
+			id:    "otherID",
+			err:   authn.ErrUnauthorizedAccess,
+		},
+		{
+			desc:  "disable admin",
+			token: adminSecret,
+			id:    adminID,
+			err:   authn.ErrMalformedEntity,
+		},
+		{
+			desc:  "disable user",
+			token: adminSecret,
+			id:    id,
+			err:   nil,
+		},
+	}
+
+	for _, tc := range cases {
+		err := svc.SetDisabled(context.Background(), tc.token, tc.id, true)
+		assert.True(t, errors.Contains(err, tc.err), fmt.Sprintf("%s expected %s got %s\n", tc.desc, tc.err, err))
+	}
+
+	for desc, key := range map[string]string{"login key": loginSecret, "API key": apiSecret} {
+		_, err := svc.Identify(context.Background(), key)
+		assert.True(t, errors.Contains(err, authn.ErrDisabled), fmt.Sprintf("identifying %s of disabled user expected %s got %s\n", desc, authn.ErrDisabled, err))
+	}
+	_, _, err = svc.Refresh(context.Background(), refreshSecret)
+	assert.True(t, errors.Contains(err, authn.ErrDisabled), fmt.Sprintf("refreshing key of disabled user expected %s got %s\n", authn.ErrDisabled, err))
+	_, _, err = svc.Issue(context.Background(), loginSecret, authn.Key{Type: authn.APIKey, IssuedAt: time.Now()})
+	assert.True(t, errors.Contains(err, authn.ErrDisabled), fmt.Sprintf("issuing API key by disabled user expected %s got %s\n", authn.ErrDisabled, err))
+
+	err = svc.SetDisabled(context.Background(), adminSecret, id, false)
+	require.Nil(t, err, fmt.Sprintf("Enabling user expected to succeed: %s", err))
+	_, err = svc.Identify(context.Background(), loginSecret)
+	assert.Nil(t, err, fmt.Sprintf("identifying login key of enabled user unexpected error: %s", err))
+}
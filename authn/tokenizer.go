@@ -3,6 +3,21 @@
 
 package authn
 
+import "crypto"
+
+// PublicKey represents the public part of the key used to sign tokens. It
+// can be used by other services to verify tokens without calling AuthN.
+type PublicKey struct {
+	// ID is the key ID set in the "kid" header of the signed tokens.
+	ID string
+
+	// Algorithm is the JWS algorithm of the key (e.g. RS256 or ES256).
+	Algorithm string
+
+	// Key is the *rsa.PublicKey or *ecdsa.PublicKey value.
+	Key crypto.PublicKey
+}
+
 // Tokenizer specifies API for encoding and decoding between string and Key.
 type Tokenizer interface {
 	// Issue converts API Key to its string representation.
@@ -10,4 +25,8 @@ type Tokenizer interface {
 
 	// Parse extracts API Key data from string token.
 	Parse(string) (Key, error)
+
+	// PublicKeys returns the public keys that can be used to verify
+	// issued tokens. Tokenizer that uses shared secret returns no keys.
+	PublicKeys() []PublicKey
 }
@@ -7,15 +7,19 @@ package tracing
 
 import (
 	"context"
+	"time"
 
 	"github.com/mainflux/mainflux/authn"
 	opentracing "github.com/opentracing/opentracing-go"
 )
 
 const (
-	saveOp     = "save"
-	retrieveOp = "retrieve_by_id"
-	revokeOp   = "remove"
+	saveOp           = "save"
+	retrieveOp       = "retrieve_by_id"
+	revokeOp         = "remove"
+	retrieveAllOp    = "retrieve_all"
+	revokeAllOp      = "remove_all"
+	updateLastUsedOp = "update_last_used"
 )
 
 var _ authn.KeyRepository = (*keyRepositoryMiddleware)(nil)
@@ -60,6 +64,30 @@ func (krm keyRepositoryMiddleware) Remove(ctx context.Context, owner, id string)
 	return krm.repo.Remove(ctx, owner, id)
 }
 
+func (krm keyRepositoryMiddleware) RetrieveAll(ctx context.Context, owner string, pm authn.PageMetadata) (authn.KeyPage, error) {
+	span := createSpan(ctx, krm.tracer, retrieveAllOp)
+	defer span.Finish()
+	ctx = opentracing.ContextWithSpan(ctx, span)
+
+	return krm.repo.RetrieveAll(ctx, owner, pm)
+}
+
+func (krm keyRepositoryMiddleware) RemoveAll(ctx context.Context, owner, subject string) error {
+	span := createSpan(ctx, krm.tracer, revokeAllOp)
+	defer span.Finish()
+	ctx = opentracing.ContextWithSpan(ctx, span)
+
+	return krm.repo.RemoveAll(ctx, owner, subject)
+}
+
+func (krm keyRepositoryMiddleware) UpdateLastUsed(ctx context.Context, owner, id string, t time.Time) error {
+	span := createSpan(ctx, krm.tracer, updateLastUsedOp)
+	defer span.Finish()
+	ctx = opentracing.ContextWithSpan(ctx, span)
+
+	return krm.repo.UpdateLastUsed(ctx, owner, id, t)
+}
+
 func createSpan(ctx context.Context, tracer opentracing.Tracer, opName string) opentracing.Span {
 	if parentSpan := opentracing.SpanFromContext(ctx); parentSpan != nil {
 		return tracer.StartSpan(
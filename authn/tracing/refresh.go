@@ -0,0 +1,57 @@
+// Copyright (c) Mainflux
+// SPDX-License-Identifier: Apache-2.0
+
+package tracing
+
+import (
+	"context"
+
+	"github.com/mainflux/mainflux/authn"
+	opentracing "github.com/opentracing/opentracing-go"
+)
+
+const (
+	saveRefreshOp  = "save_refresh"
+	useRefreshOp   = "use_refresh"
+	removeFamilyOp = "remove_family"
+)
+
+var _ authn.RefreshRepository = (*refreshRepositoryMiddleware)(nil)
+
+type refreshRepositoryMiddleware struct {
+	tracer opentracing.Tracer
+	repo   authn.RefreshRepository
+}
+
+// NewRefreshRepository tracks request and their latency, and adds spans
+// to context.
+func NewRefreshRepository(repo authn.RefreshRepository, tracer opentracing.Tracer) authn.RefreshRepository {
+	return refreshRepositoryMiddleware{
+		tracer: tracer,
+		repo:   repo,
+	}
+}
+
+func (rrm refreshRepositoryMiddleware) Save(ctx context.Context, token authn.RefreshToken) error {
+	span := createSpan(ctx, rrm.tracer, saveRefreshOp)
+	defer span.Finish()
+	ctx = opentracing.ContextWithSpan(ctx, span)
+
+	return rrm.repo.Save(ctx, token)
+}
+
+func (rrm refreshRepositoryMiddleware) Use(ctx context.Context, id string) (authn.RefreshToken, error) {
+	span := createSpan(ctx, rrm.tracer, useRefreshOp)
+	defer span.Finish()
+	ctx = opentracing.ContextWithSpan(ctx, span)
+
+	return rrm.repo.Use(ctx, id)
+}
+
+func (rrm refreshRepositoryMiddleware) RemoveFamily(ctx context.Context, id string) error {
+	span := createSpan(ctx, rrm.tracer, removeFamilyOp)
+	defer span.Finish()
+	ctx = opentracing.ContextWithSpan(ctx, span)
+
+	return rrm.repo.RemoveFamily(ctx, id)
+}
@@ -0,0 +1,85 @@
+// Copyright (c) Mainflux
+// SPDX-License-Identifier: Apache-2.0
+
+package tracing
+
+import (
+	"context"
+	"time"
+
+	"github.com/mainflux/mainflux/authn"
+	opentracing "github.com/opentracing/opentracing-go"
+)
+
+const (
+	saveRevocationOp     = "save_revocation"
+	containsRevocationOp = "contains_revocation"
+	saveCutoffOp         = "save_cutoff"
+	retrieveCutoffOp     = "retrieve_cutoff"
+	saveDisabledOp       = "save_disabled"
+	disabledOp           = "disabled"
+)
+
+var _ authn.RevocationRepository = (*revocationRepositoryMiddleware)(nil)
+
+type revocationRepositoryMiddleware struct {
+	tracer opentracing.Tracer
+	repo   authn.RevocationRepository
+}
+
+// NewRevocationRepository tracks request and their latency, and adds spans
+// to context.
+func NewRevocationRepository(repo authn.RevocationRepository, tracer opentracing.Tracer) authn.RevocationRepository {
+	return revocationRepositoryMiddleware{
+		tracer: tracer,
+		repo:   repo,
+	}
+}
+
+func (rrm revocationRepositoryMiddleware) Save(ctx context.Context, id string, expiresAt time.Time) error {
+	span := createSpan(ctx, rrm.tracer, saveRevocationOp)
+	defer span.Finish()
+	ctx = opentracing.ContextWithSpan(ctx, span)
+
+	return rrm.repo.Save(ctx, id, expiresAt)
+}
+
+func (rrm revocationRepositoryMiddleware) Contains(ctx context.Context, id string) (bool, error) {
+	span := createSpan(ctx, rrm.tracer, containsRevocationOp)
+	defer span.Finish()
+	ctx = opentracing.ContextWithSpan(ctx, span)
+
+	return rrm.repo.Contains(ctx, id)
+}
+
+func (rrm revocationRepositoryMiddleware) SaveCutoff(ctx context.Context, issuerID string, t time.Time) error {
+	span := createSpan(ctx, rrm.tracer, saveCutoffOp)
+	defer span.Finish()
+	ctx = opentracing.ContextWithSpan(ctx, span)
+
+	return rrm.repo.SaveCutoff(ctx, issuerID, t)
+}
+
+func (rrm revocationRepositoryMiddleware) RetrieveCutoff(ctx context.Context, issuerID string) (time.Time, error) {
+	span := createSpan(ctx, rrm.tracer, retrieveCutoffOp)
+	defer span.Finish()
+	ctx = opentracing.ContextWithSpan(ctx, span)
+
+	return rrm.repo.RetrieveCutoff(ctx, issuerID)
+}
+
+func (rrm revocationRepositoryMiddleware) SaveDisabled(ctx context.Context, issuerID string, disabled bool) error {
+	span := createSpan(ctx, rrm.tracer, saveDisabledOp)
+	defer span.Finish()
+	ctx = opentracing.ContextWithSpan(ctx, span)
+
+	return rrm.repo.SaveDisabled(ctx, issuerID, disabled)
+}
+
+func (rrm revocationRepositoryMiddleware) Disabled(ctx context.Context, issuerID string) (bool, error) {
+	span := createSpan(ctx, rrm.tracer, disabledOp)
+	defer span.Finish()
+	ctx = opentracing.ContextWithSpan(ctx, span)
+
+	return rrm.repo.Disabled(ctx, issuerID)
+}
@@ -0,0 +1,105 @@
+// Copyright (c) Mainflux
+// SPDX-License-Identifier: Apache-2.0
+
+package api
+
+import (
+	"context"
+
+	"github.com/mainflux/mainflux/bootstrap"
+	"github.com/mainflux/mainflux/pkg/audit"
+)
+
+var _ bootstrap.Service = (*auditMiddleware)(nil)
+
+type auditMiddleware struct {
+	rec audit.Recorder
+	svc bootstrap.Service
+}
+
+// AuditMiddleware records the operations changing the bootstrap configs.
+// Read-only operations, bootstrapping of the things and the handlers of the
+// things service events are not recorded. Since the service API doesn't
+// carry the request context, the client address is not recorded.
+func AuditMiddleware(svc bootstrap.Service, rec audit.Recorder) bootstrap.Service {
+	return &auditMiddleware{rec, svc}
+}
+
+func (am *auditMiddleware) Add(token string, cfg bootstrap.Config) (saved bootstrap.Config, err error) {
+	ctx := context.Background()
+	e := am.rec.Event(ctx, token, "add", "")
+	defer func() {
+		e.Entity = saved.MFThing
+		am.rec.Record(ctx, e, err)
+	}()
+
+	return am.svc.Add(token, cfg)
+}
+
+func (am *auditMiddleware) View(token, id string) (bootstrap.Config, error) {
+	return am.svc.View(token, id)
+}
+
+func (am *auditMiddleware) Update(token string, cfg bootstrap.Config) (err error) {
+	ctx := context.Background()
+	e := am.rec.Event(ctx, token, "update", cfg.MFThing)
+	defer func() { am.rec.Record(ctx, e, err) }()
+
+	return am.svc.Update(token, cfg)
+}
+
+func (am *auditMiddleware) UpdateCert(token, thingID, clientCert, clientKey, caCert string) (err error) {
+	ctx := context.Background()
+	e := am.rec.Event(ctx, token, "update_cert", thingID)
+	defer func() { am.rec.Record(ctx, e, err) }()
+
+	return am.svc.UpdateCert(token, thingID, clientCert, clientKey, caCert)
+}
+
+func (am *auditMiddleware) UpdateConnections(token, id string, connections []string) (err error) {
+	ctx := context.Background()
+	e := am.rec.Event(ctx, token, "update_connections", id)
+	defer func() { am.rec.Record(ctx, e, err) }()
+
+	return am.svc.UpdateConnections(token, id, connections)
+}
+
+func (am *auditMiddleware) List(token string, filter bootstrap.Filter, offset, limit uint64) (bootstrap.ConfigsPage, error) {
+	return am.svc.List(token, filter, offset, limit)
+}
+
+func (am *auditMiddleware) Remove(token, id string) (err error) {
+	ctx := context.Background()
+	e := am.rec.Event(ctx, token, "remove", id)
+	defer func() { am.rec.Record(ctx, e, err) }()
+
+	return am.svc.Remove(token, id)
+}
+
+func (am *auditMiddleware) Bootstrap(externalKey, externalID string, secure bool) (bootstrap.Config, error) {
+	return am.svc.Bootstrap(externalKey, externalID, secure)
+}
+
+func (am *auditMiddleware) ChangeState(token, id string, state bootstrap.State) (err error) {
+	ctx := context.Background()
+	e := am.rec.Event(ctx, token, "change_state", id)
+	defer func() { am.rec.Record(ctx, e, err) }()
+
+	return am.svc.ChangeState(token, id, state)
+}
+
+func (am *auditMiddleware) UpdateChannelHandler(channel bootstrap.Channel) error {
+	return am.svc.UpdateChannelHandler(channel)
+}
+
+func (am *auditMiddleware) RemoveConfigHandler(id string) error {
+	return am.svc.RemoveConfigHandler(id)
+}
+
+func (am *auditMiddleware) RemoveChannelHandler(id string) error {
+	return am.svc.RemoveChannelHandler(id)
+}
+
+func (am *auditMiddleware) DisconnectThingHandler(channelID, thingID string) error {
+	return am.svc.DisconnectThingHandler(channelID, thingID)
+}
@@ -28,6 +28,7 @@ func addEndpoint(svc bootstrap.Service) endpoint.Endpoint {
 			ExternalKey: req.ExternalKey,
 			MFChannels:  channels,
 			Name:        req.Name,
+			UserGroup:   req.UserGroup,
 			ClientCert:  req.ClientCert,
 			ClientKey:   req.ClientKey,
 			CACert:      req.CACert,
@@ -94,6 +95,7 @@ func viewEndpoint(svc bootstrap.Service) endpoint.Endpoint {
 			ExternalID:  config.ExternalID,
 			ExternalKey: config.ExternalKey,
 			Name:        config.Name,
+			UserGroup:   config.UserGroup,
 			Content:     config.Content,
 			State:       config.State,
 		}
@@ -111,9 +113,10 @@ func updateEndpoint(svc bootstrap.Service) endpoint.Endpoint {
 		}
 
 		config := bootstrap.Config{
-			MFThing: req.id,
-			Name:    req.Name,
-			Content: req.Content,
+			MFThing:   req.id,
+			Name:      req.Name,
+			UserGroup: req.UserGroup,
+			Content:   req.Content,
 		}
 
 		if err := svc.Update(req.key, config); err != nil {
@@ -186,6 +189,7 @@ func listEndpoint(svc bootstrap.Service) endpoint.Endpoint {
 				ExternalID:  cfg.ExternalID,
 				ExternalKey: cfg.ExternalKey,
 				Name:        cfg.Name,
+				UserGroup:   cfg.UserGroup,
 				Content:     cfg.Content,
 				State:       cfg.State,
 			}
@@ -163,7 +163,7 @@ func newService(authn mainflux.AuthNServiceClient, url string) bootstrap.Service
 	}
 
 	sdk := mfsdk.NewSDK(config)
-	return bootstrap.New(authn, things, sdk, encKey)
+	return bootstrap.New(authn, mocks.NewGroupsService(nil), things, sdk, encKey)
 }
 
 func generateChannels() map[string]things.Channel {
@@ -16,6 +16,7 @@ type addReq struct {
 	ExternalKey string   `json:"external_key"`
 	Channels    []string `json:"channels"`
 	Name        string   `json:"name"`
+	UserGroup   string   `json:"user_group"`
 	Content     string   `json:"content"`
 	ClientCert  string   `json:"client_cert"`
 	ClientKey   string   `json:"client_key"`
@@ -52,10 +53,11 @@ func (req entityReq) validate() error {
 }
 
 type updateReq struct {
-	key     string
-	id      string
-	Name    string `json:"name"`
-	Content string `json:"content"`
+	key       string
+	id        string
+	Name      string `json:"name"`
+	UserGroup string `json:"user_group"`
+	Content   string `json:"content"`
 }
 
 func (req updateReq) validate() error {
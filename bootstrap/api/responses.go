@@ -74,6 +74,7 @@ type viewRes struct {
 	ExternalKey string          `json:"external_key,omitempty"`
 	Content     string          `json:"content,omitempty"`
 	Name        string          `json:"name,omitempty"`
+	UserGroup   string          `json:"user_group,omitempty"`
 	State       bootstrap.State `json:"state"`
 }
 
@@ -8,9 +8,11 @@ package bootstrap
 // MFThing represents corresponding Mainflux Thing ID.
 // MFKey is key of corresponding Mainflux Thing.
 // MFChannels is a list of Mainflux Channels corresponding Mainflux Thing connects to.
+// UserGroup is the users group the Config is shared with.
 type Config struct {
 	MFThing     string
 	Owner       string
+	UserGroup   string
 	Name        string
 	ClientCert  string
 	ClientKey   string
@@ -30,6 +32,38 @@ type Channel struct {
 	Metadata map[string]interface{}
 }
 
+// Users group roles required to read and to write the Configs shared with
+// the group.
+const (
+	viewerRole = "viewer"
+	editorRole = "editor"
+)
+
+// Access specifies the Configs the user is allowed to access. These are the
+// ones owned by the user, together with the ones shared with the listed
+// users groups.
+type Access struct {
+	Owner      string
+	UserGroups []string
+}
+
+// Shares checks whether the users group is one of the groups the access is
+// shared with.
+func (acc Access) Shares(group string) bool {
+	for _, g := range acc.UserGroups {
+		if g == group {
+			return true
+		}
+	}
+
+	return false
+}
+
+// Allows checks whether the Config is accessible.
+func (acc Access) Allows(cfg Config) bool {
+	return cfg.Owner == acc.Owner || acc.Shares(cfg.UserGroup)
+}
+
 // Filter is used for the search filters.
 type Filter struct {
 	FullMatch    map[string]string
@@ -51,19 +85,19 @@ type ConfigRepository interface {
 	// error response.
 	Save(cfg Config, chsConnIDs []string) (string, error)
 
-	// RetrieveByID retrieves the Config having the provided identifier, that is owned
-	// by the specified user.
-	RetrieveByID(owner, id string) (Config, error)
+	// RetrieveByID retrieves the Config having the provided identifier, that is
+	// accessible using the provided access.
+	RetrieveByID(acc Access, id string) (Config, error)
 
-	// RetrieveAll retrieves a subset of Configs that are owned
-	// by the specific user, with given filter parameters.
-	RetrieveAll(owner string, filter Filter, offset, limit uint64) ConfigsPage
+	// RetrieveAll retrieves a subset of Configs that are accessible using
+	// the provided access, with given filter parameters.
+	RetrieveAll(acc Access, filter Filter, offset, limit uint64) ConfigsPage
 
 	// RetrieveByExternalID returns Config for given external ID.
 	RetrieveByExternalID(externalID string) (Config, error)
 
-	// Update updates an existing Config. A non-nil error is returned
-	// to indicate operation failure.
+	// Update updates an existing Config, including the users group it is
+	// shared with. A non-nil error is returned to indicate operation failure.
 	Update(cfg Config) error
 
 	// UpdateCerts updates an existing Config certificate and owner.
@@ -63,7 +63,7 @@ func (crm *configRepositoryMock) Save(config bootstrap.Config, connections []str
 	return config.MFThing, nil
 }
 
-func (crm *configRepositoryMock) RetrieveByID(token, id string) (bootstrap.Config, error) {
+func (crm *configRepositoryMock) RetrieveByID(acc bootstrap.Access, id string) (bootstrap.Config, error) {
 	crm.mu.Lock()
 	defer crm.mu.Unlock()
 
@@ -71,7 +71,7 @@ func (crm *configRepositoryMock) RetrieveByID(token, id string) (bootstrap.Confi
 	if !ok {
 		return bootstrap.Config{}, bootstrap.ErrNotFound
 	}
-	if c.Owner != token {
+	if !acc.Allows(c) {
 		return bootstrap.Config{}, bootstrap.ErrUnauthorizedAccess
 	}
 
@@ -79,7 +79,7 @@ func (crm *configRepositoryMock) RetrieveByID(token, id string) (bootstrap.Confi
 
 }
 
-func (crm *configRepositoryMock) RetrieveAll(token string, filter bootstrap.Filter, offset, limit uint64) bootstrap.ConfigsPage {
+func (crm *configRepositoryMock) RetrieveAll(acc bootstrap.Access, filter bootstrap.Filter, offset, limit uint64) bootstrap.ConfigsPage {
 	crm.mu.Lock()
 	defer crm.mu.Unlock()
 
@@ -107,7 +107,7 @@ func (crm *configRepositoryMock) RetrieveAll(token string, filter bootstrap.Filt
 		id, _ := strconv.ParseUint(v.MFThing, 10, 64)
 		if (state == emptyState || v.State == state) &&
 			(name == "" || strings.Index(strings.ToLower(v.Name), name) != notFoundIdx) &&
-			v.Owner == token {
+			acc.Allows(v) {
 			if id >= first && id < last {
 				configs = append(configs, v)
 			}
@@ -151,6 +151,7 @@ func (crm *configRepositoryMock) Update(config bootstrap.Config) error {
 
 	cfg.Name = config.Name
 	cfg.Content = config.Content
+	cfg.UserGroup = config.UserGroup
 	crm.configs[config.MFThing] = cfg
 
 	return nil
@@ -0,0 +1,49 @@
+// Copyright (c) Mainflux
+// SPDX-License-Identifier: Apache-2.0
+
+package mocks
+
+import (
+	"context"
+	"sort"
+
+	"github.com/mainflux/mainflux"
+	"github.com/mainflux/mainflux/users"
+	"google.golang.org/grpc"
+)
+
+var _ mainflux.UsersServiceClient = (*groupsServiceMock)(nil)
+
+var roleRanks = map[string]int{
+	users.ViewerRole: 1,
+	users.EditorRole: 2,
+	users.AdminRole:  3,
+}
+
+type groupsServiceMock struct {
+	roles map[string]map[string]string
+}
+
+// NewGroupsService creates mock of users service authorizing the members of
+// the users groups. Roles map the user email to the roles the user has in the
+// users groups, keyed by the group ID.
+func NewGroupsService(roles map[string]map[string]string) mainflux.UsersServiceClient {
+	return &groupsServiceMock{roles}
+}
+
+func (svc groupsServiceMock) Authorize(ctx context.Context, in *mainflux.AuthorizeReq, opts ...grpc.CallOption) (*mainflux.Authorization, error) {
+	rank, ok := roleRanks[in.GetRole()]
+	if !ok {
+		return nil, users.ErrMalformedEntity
+	}
+
+	groups := []string{}
+	for group, role := range svc.roles[in.GetEmail()] {
+		if roleRanks[role] >= rank {
+			groups = append(groups, group)
+		}
+	}
+	sort.Strings(groups)
+
+	return &mainflux.Authorization{Groups: groups}, nil
+}
@@ -53,6 +53,10 @@ func (svc *mainfluxThings) CreateThings(_ context.Context, owner string, ths ...
 	return ths, nil
 }
 
+func (svc *mainfluxThings) ShareThing(context.Context, string, string, string) error {
+	panic("not implemented")
+}
+
 func (svc *mainfluxThings) ViewThing(_ context.Context, owner, id string) (things.Thing, error) {
 	svc.mu.Lock()
 	defer svc.mu.Unlock()
@@ -70,7 +74,7 @@ func (svc *mainfluxThings) ViewThing(_ context.Context, owner, id string) (thing
 	return things.Thing{}, things.ErrNotFound
 }
 
-func (svc *mainfluxThings) Connect(_ context.Context, owner string, chIDs, thIDs []string) error {
+func (svc *mainfluxThings) Connect(_ context.Context, owner string, chIDs, thIDs, _ []string) error {
 	svc.mu.Lock()
 	defer svc.mu.Unlock()
 
@@ -152,6 +156,10 @@ func (svc *mainfluxThings) RemoveThing(_ context.Context, owner, id string) erro
 	return nil
 }
 
+func (svc *mainfluxThings) ShareChannel(context.Context, string, string, string) error {
+	panic("not implemented")
+}
+
 func (svc *mainfluxThings) ViewChannel(_ context.Context, owner, id string) (things.Channel, error) {
 	if c, ok := svc.channels[id]; ok {
 		return c, nil
@@ -167,7 +175,7 @@ func (svc *mainfluxThings) UpdateKey(context.Context, string, string, string) er
 	panic("not implemented")
 }
 
-func (svc *mainfluxThings) ListThings(context.Context, string, uint64, uint64, string, things.Metadata) (things.Page, error) {
+func (svc *mainfluxThings) ListThings(context.Context, string, uint64, uint64, string, string, things.Metadata) (things.Page, error) {
 	panic("not implemented")
 }
 
@@ -201,7 +209,7 @@ func (svc *mainfluxThings) UpdateChannel(context.Context, string, things.Channel
 	panic("not implemented")
 }
 
-func (svc *mainfluxThings) ListChannels(context.Context, string, uint64, uint64, string, things.Metadata) (things.ChannelsPage, error) {
+func (svc *mainfluxThings) ListChannels(context.Context, string, uint64, uint64, string, string, things.Metadata) (things.ChannelsPage, error) {
 	panic("not implemented")
 }
 
@@ -209,11 +217,19 @@ func (svc *mainfluxThings) RemoveChannel(context.Context, string, string) error
 	panic("not implemented")
 }
 
-func (svc *mainfluxThings) CanAccessByKey(context.Context, string, string) (string, error) {
+func (svc *mainfluxThings) CanAccessByKey(context.Context, string, string, string) (string, error) {
 	panic("not implemented")
 }
 
-func (svc *mainfluxThings) CanAccessByID(context.Context, string, string) error {
+func (svc *mainfluxThings) CanAccessByID(context.Context, string, string, string) error {
+	panic("not implemented")
+}
+
+func (svc *mainfluxThings) IsChannelOwner(context.Context, string, string) error {
+	panic("not implemented")
+}
+
+func (svc *mainfluxThings) ChannelSchema(context.Context, string) ([]byte, error) {
 	panic("not implemented")
 }
 
@@ -221,6 +237,58 @@ func (svc *mainfluxThings) Identify(context.Context, string) (string, error) {
 	panic("not implemented")
 }
 
+func (svc *mainfluxThings) CreateGroup(context.Context, string, things.Group) (things.Group, error) {
+	panic("not implemented")
+}
+
+func (svc *mainfluxThings) UpdateGroup(context.Context, string, things.Group) error {
+	panic("not implemented")
+}
+
+func (svc *mainfluxThings) ViewGroup(context.Context, string, string) (things.Group, error) {
+	panic("not implemented")
+}
+
+func (svc *mainfluxThings) ListGroups(context.Context, string, string, uint64, uint64, things.Metadata) (things.GroupsPage, error) {
+	panic("not implemented")
+}
+
+func (svc *mainfluxThings) ListThingsByGroup(context.Context, string, string, uint64, uint64) (things.Page, error) {
+	panic("not implemented")
+}
+
+func (svc *mainfluxThings) ListChannelsByGroup(context.Context, string, string, uint64, uint64) (things.ChannelsPage, error) {
+	panic("not implemented")
+}
+
+func (svc *mainfluxThings) RemoveGroup(context.Context, string, string) error {
+	panic("not implemented")
+}
+
+func (svc *mainfluxThings) AssignThings(context.Context, string, string, ...string) error {
+	panic("not implemented")
+}
+
+func (svc *mainfluxThings) UnassignThings(context.Context, string, string, ...string) error {
+	panic("not implemented")
+}
+
+func (svc *mainfluxThings) AssignChannels(context.Context, string, string, ...string) error {
+	panic("not implemented")
+}
+
+func (svc *mainfluxThings) UnassignChannels(context.Context, string, string, ...string) error {
+	panic("not implemented")
+}
+
+func (svc *mainfluxThings) ConnectGroup(context.Context, string, string, []string, []string) error {
+	panic("not implemented")
+}
+
+func (svc *mainfluxThings) DisconnectGroup(context.Context, string, string, string) error {
+	panic("not implemented")
+}
+
 func findIndex(list []string, val string) int {
 	for i, v := range list {
 		if v == val {
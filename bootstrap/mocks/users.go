@@ -6,6 +6,7 @@ package mocks
 import (
 	"context"
 
+	"github.com/golang/protobuf/ptypes/empty"
 	"github.com/mainflux/mainflux"
 	"github.com/mainflux/mainflux/users"
 	"google.golang.org/grpc"
@@ -14,17 +15,24 @@ import (
 var _ mainflux.AuthNServiceClient = (*serviceMock)(nil)
 
 type serviceMock struct {
-	users map[string]string
+	users  map[string]string
+	scopes map[string][]*mainflux.Scope
 }
 
 // NewUsersService creates mock of users service.
 func NewUsersService(users map[string]string) mainflux.AuthNServiceClient {
-	return &serviceMock{users}
+	return NewScopedUsersService(users, map[string][]*mainflux.Scope{})
+}
+
+// NewScopedUsersService creates mock of users service which identifies the
+// tokens present in the scopes map as API keys restricted to the scopes.
+func NewScopedUsersService(users map[string]string, scopes map[string][]*mainflux.Scope) mainflux.AuthNServiceClient {
+	return &serviceMock{users, scopes}
 }
 
 func (svc serviceMock) Identify(ctx context.Context, in *mainflux.Token, opts ...grpc.CallOption) (*mainflux.UserIdentity, error) {
 	if id, ok := svc.users[in.Value]; ok {
-		return &mainflux.UserIdentity{Email: id, Id: id}, nil
+		return &mainflux.UserIdentity{Email: id, Id: id, Scopes: svc.scopes[in.Value]}, nil
 	}
 	return nil, users.ErrUnauthorizedAccess
 }
@@ -38,3 +46,27 @@ func (svc serviceMock) Issue(ctx context.Context, in *mainflux.IssueReq, opts ..
 	}
 	return nil, users.ErrUnauthorizedAccess
 }
+
+func (svc serviceMock) RevokeToken(ctx context.Context, in *mainflux.Token, opts ...grpc.CallOption) (*empty.Empty, error) {
+	panic("not implemented")
+}
+
+func (svc serviceMock) RevokeSessions(ctx context.Context, in *mainflux.Token, opts ...grpc.CallOption) (*empty.Empty, error) {
+	panic("not implemented")
+}
+
+func (svc serviceMock) Refresh(ctx context.Context, in *mainflux.Token, opts ...grpc.CallOption) (*mainflux.Tokens, error) {
+	panic("not implemented")
+}
+
+func (svc serviceMock) VerifyChallenge(ctx context.Context, in *mainflux.Token, opts ...grpc.CallOption) (*mainflux.UserIdentity, error) {
+	panic("not implemented")
+}
+
+func (svc serviceMock) Impersonate(ctx context.Context, in *mainflux.ImpersonateReq, opts ...grpc.CallOption) (*mainflux.Token, error) {
+	panic("not implemented")
+}
+
+func (svc serviceMock) SetDisabled(ctx context.Context, in *mainflux.DisableReq, opts ...grpc.CallOption) (*empty.Empty, error) {
+	panic("not implemented")
+}
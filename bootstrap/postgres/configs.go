@@ -56,8 +56,8 @@ func NewConfigRepository(db *sqlx.DB, log logger.Logger) bootstrap.ConfigReposit
 }
 
 func (cr configRepository) Save(cfg bootstrap.Config, chsConnIDs []string) (string, error) {
-	q := `INSERT INTO configs (mainflux_thing, owner, name, client_cert, client_key, ca_cert, mainflux_key, external_id, external_key, content, state)
-		  VALUES (:mainflux_thing, :owner, :name, :client_cert, :client_key, :ca_cert, :mainflux_key, :external_id, :external_key, :content, :state)`
+	q := `INSERT INTO configs (mainflux_thing, owner, user_group, name, client_cert, client_key, ca_cert, mainflux_key, external_id, external_key, content, state)
+		  VALUES (:mainflux_thing, :owner, :user_group, :name, :client_cert, :client_key, :ca_cert, :mainflux_key, :external_id, :external_key, :content, :state)`
 
 	tx, err := cr.db.Beginx()
 	if err != nil {
@@ -96,17 +96,16 @@ func (cr configRepository) Save(cfg bootstrap.Config, chsConnIDs []string) (stri
 	return cfg.MFThing, nil
 }
 
-func (cr configRepository) RetrieveByID(owner, id string) (bootstrap.Config, error) {
-	q := `SELECT mainflux_thing, mainflux_key, external_id, external_key, name, content, state
+func (cr configRepository) RetrieveByID(acc bootstrap.Access, id string) (bootstrap.Config, error) {
+	q := `SELECT mainflux_thing, owner, user_group, mainflux_key, external_id, external_key, name, content, state
 		  FROM configs
-		  WHERE mainflux_thing = $1 AND owner = $2`
+		  WHERE mainflux_thing = $1 AND (owner = $2 OR user_group = ANY($3))`
 
 	dbcfg := dbConfig{
 		MFThing: id,
-		Owner:   owner,
 	}
 
-	if err := cr.db.QueryRowx(q, id, owner).StructScan(&dbcfg); err != nil {
+	if err := cr.db.QueryRowx(q, id, acc.Owner, userGroups(acc)).StructScan(&dbcfg); err != nil {
 		empty := bootstrap.Config{}
 		if err == sql.ErrNoRows {
 			return empty, errors.Wrap(bootstrap.ErrNotFound, err)
@@ -149,11 +148,11 @@ func (cr configRepository) RetrieveByID(owner, id string) (bootstrap.Config, err
 	return cfg, nil
 }
 
-func (cr configRepository) RetrieveAll(owner string, filter bootstrap.Filter, offset, limit uint64) bootstrap.ConfigsPage {
-	search, params := cr.retrieveAll(owner, filter)
+func (cr configRepository) RetrieveAll(acc bootstrap.Access, filter bootstrap.Filter, offset, limit uint64) bootstrap.ConfigsPage {
+	search, params := cr.retrieveAll(acc, filter)
 	n := len(params)
 
-	q := `SELECT mainflux_thing, mainflux_key, external_id, external_key, name, content, state
+	q := `SELECT mainflux_thing, owner, user_group, mainflux_key, external_id, external_key, name, content, state
 	      FROM configs %s ORDER BY mainflux_thing LIMIT $%d OFFSET $%d`
 	q = fmt.Sprintf(q, search, n+1, n+2)
 
@@ -168,8 +167,8 @@ func (cr configRepository) RetrieveAll(owner string, filter bootstrap.Filter, of
 	configs := []bootstrap.Config{}
 
 	for rows.Next() {
-		c := bootstrap.Config{Owner: owner}
-		if err := rows.Scan(&c.MFThing, &c.MFKey, &c.ExternalID, &c.ExternalKey, &name, &content, &c.State); err != nil {
+		c := bootstrap.Config{}
+		if err := rows.Scan(&c.MFThing, &c.Owner, &c.UserGroup, &c.MFKey, &c.ExternalID, &c.ExternalKey, &name, &content, &c.State); err != nil {
 			cr.log.Error(fmt.Sprintf("Failed to read retrieved config due to %s", err))
 			return bootstrap.ConfigsPage{}
 		}
@@ -196,7 +195,7 @@ func (cr configRepository) RetrieveAll(owner string, filter bootstrap.Filter, of
 }
 
 func (cr configRepository) RetrieveByExternalID(externalID string) (bootstrap.Config, error) {
-	q := `SELECT mainflux_thing, mainflux_key, external_key, owner, name, client_cert, client_key, ca_cert, content, state
+	q := `SELECT mainflux_thing, mainflux_key, external_key, owner, user_group, name, client_cert, client_key, ca_cert, content, state
 		  FROM configs
 		  WHERE external_id = $1`
 	dbcfg := dbConfig{
@@ -247,12 +246,12 @@ func (cr configRepository) RetrieveByExternalID(externalID string) (bootstrap.Co
 }
 
 func (cr configRepository) Update(cfg bootstrap.Config) error {
-	q := `UPDATE configs SET name = $1, content = $2 WHERE mainflux_thing = $3 AND owner = $4`
+	q := `UPDATE configs SET name = $1, content = $2, user_group = $3 WHERE mainflux_thing = $4 AND owner = $5`
 
 	content := nullString(cfg.Content)
 	name := nullString(cfg.Name)
 
-	res, err := cr.db.Exec(q, name, content, cfg.MFThing, cfg.Owner)
+	res, err := cr.db.Exec(q, name, content, cfg.UserGroup, cfg.MFThing, cfg.Owner)
 	if err != nil {
 		return errors.Wrap(errUpdate, err)
 	}
@@ -426,13 +425,13 @@ func (cr configRepository) DisconnectThing(channelID, thingID string) error {
 	return nil
 }
 
-func (cr configRepository) retrieveAll(owner string, filter bootstrap.Filter) (string, []interface{}) {
-	template := `WHERE owner = $1 %s`
-	params := []interface{}{owner}
+func (cr configRepository) retrieveAll(acc bootstrap.Access, filter bootstrap.Filter) (string, []interface{}) {
+	template := `WHERE (owner = $1 OR user_group = ANY($2)) %s`
+	params := []interface{}{acc.Owner, userGroups(acc)}
 	// One empty string so that strings Join works if only one filter is applied.
 	queries := []string{""}
-	// Since owner is the first param, start from 2.
-	counter := 2
+	// Since owner and users groups are the first params, start from 3.
+	counter := 3
 	for k, v := range filter.FullMatch {
 		queries = append(queries, fmt.Sprintf("%s = $%d", k, counter))
 		params = append(params, v)
@@ -552,6 +551,16 @@ func updateConnections(owner, id string, connections []string, tx *sqlx.Tx) erro
 	return err
 }
 
+// userGroups returns the users groups the access is shared with as the query
+// parameter.
+func userGroups(acc bootstrap.Access) pq.StringArray {
+	if acc.UserGroups == nil {
+		return pq.StringArray{}
+	}
+
+	return pq.StringArray(acc.UserGroups)
+}
+
 func nullString(s string) sql.NullString {
 	if s == "" {
 		return sql.NullString{}
@@ -566,6 +575,7 @@ func nullString(s string) sql.NullString {
 type dbConfig struct {
 	MFThing     string          `db:"mainflux_thing"`
 	Owner       string          `db:"owner"`
+	UserGroup   string          `db:"user_group"`
 	Name        sql.NullString  `db:"name"`
 	ClientCert  sql.NullString  `db:"client_cert"`
 	ClientKey   sql.NullString  `db:"client_key"`
@@ -581,6 +591,7 @@ func toDBConfig(cfg bootstrap.Config) dbConfig {
 	return dbConfig{
 		MFThing:     cfg.MFThing,
 		Owner:       cfg.Owner,
+		UserGroup:   cfg.UserGroup,
 		Name:        nullString(cfg.Name),
 		ClientCert:  nullString(cfg.ClientCert),
 		ClientKey:   nullString(cfg.ClientKey),
@@ -597,6 +608,7 @@ func toConfig(dbcfg dbConfig) bootstrap.Config {
 	cfg := bootstrap.Config{
 		MFThing:     dbcfg.MFThing,
 		Owner:       dbcfg.Owner,
+		UserGroup:   dbcfg.UserGroup,
 		MFKey:       dbcfg.MFKey,
 		ExternalID:  dbcfg.ExternalID,
 		ExternalKey: dbcfg.ExternalKey,
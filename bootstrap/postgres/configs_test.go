@@ -16,7 +16,10 @@ import (
 	"github.com/stretchr/testify/require"
 )
 
-const numConfigs = 10
+const (
+	numConfigs = 10
+	userGroup  = "user-group"
+)
 
 var (
 	config = bootstrap.Config{
@@ -108,6 +111,7 @@ func TestRetrieveByID(t *testing.T) {
 	c.MFThing = uid.String()
 	c.ExternalID = uid.String()
 	c.ExternalKey = uid.String()
+	c.UserGroup = userGroup
 	id, err := repo.Save(c, channels)
 	require.Nil(t, err, fmt.Sprintf("Saving config expected to succeed: %s.\n", err))
 
@@ -115,38 +119,50 @@ func TestRetrieveByID(t *testing.T) {
 	require.Nil(t, err, fmt.Sprintf("Got unexpected error: %s.\n", err))
 
 	cases := []struct {
-		desc  string
-		owner string
-		id    string
-		err   error
+		desc string
+		acc  bootstrap.Access
+		id   string
+		err  error
 	}{
 		{
-			desc:  "retrieve config",
-			owner: c.Owner,
-			id:    id,
-			err:   nil,
+			desc: "retrieve config",
+			acc:  bootstrap.Access{Owner: c.Owner},
+			id:   id,
+			err:  nil,
 		},
 		{
-			desc:  "retrieve config with wrong owner",
-			owner: "2",
-			id:    id,
-			err:   bootstrap.ErrNotFound,
+			desc: "retrieve config with wrong owner",
+			acc:  bootstrap.Access{Owner: "2"},
+			id:   id,
+			err:  bootstrap.ErrNotFound,
 		},
 		{
-			desc:  "retrieve a non-existing config",
-			owner: c.Owner,
-			id:    nonexistentConfID.String(),
-			err:   bootstrap.ErrNotFound,
+			desc: "retrieve config shared with users group",
+			acc:  bootstrap.Access{Owner: "2", UserGroups: []string{userGroup}},
+			id:   id,
+			err:  nil,
 		},
 		{
-			desc:  "retrieve a config with invalid ID",
-			owner: c.Owner,
-			id:    "invalid",
-			err:   bootstrap.ErrNotFound,
+			desc: "retrieve config shared with other users group",
+			acc:  bootstrap.Access{Owner: "2", UserGroups: []string{"other-group"}},
+			id:   id,
+			err:  bootstrap.ErrNotFound,
+		},
+		{
+			desc: "retrieve a non-existing config",
+			acc:  bootstrap.Access{Owner: c.Owner},
+			id:   nonexistentConfID.String(),
+			err:  bootstrap.ErrNotFound,
+		},
+		{
+			desc: "retrieve a config with invalid ID",
+			acc:  bootstrap.Access{Owner: c.Owner},
+			id:   "invalid",
+			err:  bootstrap.ErrNotFound,
 		},
 	}
 	for _, tc := range cases {
-		_, err := repo.RetrieveByID(tc.owner, tc.id)
+		_, err := repo.RetrieveByID(tc.acc, tc.id)
 		assert.True(t, errors.Contains(err, tc.err), fmt.Sprintf("%s: expected %s got %s\n", tc.desc, tc.err, err))
 	}
 }
@@ -169,6 +185,8 @@ func TestRetrieveAll(t *testing.T) {
 
 		if i%2 == 0 {
 			c.State = bootstrap.Active
+		} else {
+			c.UserGroup = userGroup
 		}
 
 		if i > 0 {
@@ -181,7 +199,7 @@ func TestRetrieveAll(t *testing.T) {
 
 	cases := []struct {
 		desc   string
-		owner  string
+		acc    bootstrap.Access
 		offset uint64
 		limit  uint64
 		filter bootstrap.Filter
@@ -189,28 +207,35 @@ func TestRetrieveAll(t *testing.T) {
 	}{
 		{
 			desc:   "retrieve all",
-			owner:  config.Owner,
+			acc:    bootstrap.Access{Owner: config.Owner},
 			offset: 0,
 			limit:  uint64(numConfigs),
 			size:   numConfigs,
 		},
 		{
 			desc:   "retrieve subset",
-			owner:  config.Owner,
+			acc:    bootstrap.Access{Owner: config.Owner},
 			offset: 5,
 			limit:  uint64(numConfigs - 5),
 			size:   numConfigs - 5,
 		},
 		{
 			desc:   "retrieve wrong owner",
-			owner:  "2",
+			acc:    bootstrap.Access{Owner: "2"},
 			offset: 0,
 			limit:  uint64(numConfigs),
 			size:   0,
 		},
+		{
+			desc:   "retrieve shared with users group",
+			acc:    bootstrap.Access{Owner: "2", UserGroups: []string{userGroup}},
+			offset: 0,
+			limit:  uint64(numConfigs),
+			size:   numConfigs / 2,
+		},
 		{
 			desc:   "retrieve all active",
-			owner:  config.Owner,
+			acc:    bootstrap.Access{Owner: config.Owner},
 			offset: 0,
 			limit:  uint64(numConfigs),
 			filter: bootstrap.Filter{FullMatch: map[string]string{"state": bootstrap.Active.String()}},
@@ -218,7 +243,7 @@ func TestRetrieveAll(t *testing.T) {
 		},
 		{
 			desc:   "retrieve search by name",
-			owner:  config.Owner,
+			acc:    bootstrap.Access{Owner: config.Owner},
 			offset: 0,
 			limit:  uint64(numConfigs),
 			filter: bootstrap.Filter{PartialMatch: map[string]string{"name": "1"}},
@@ -226,7 +251,7 @@ func TestRetrieveAll(t *testing.T) {
 		},
 	}
 	for _, tc := range cases {
-		ret := repo.RetrieveAll(tc.owner, tc.filter, tc.offset, tc.limit)
+		ret := repo.RetrieveAll(tc.acc, tc.filter, tc.offset, tc.limit)
 		size := len(ret.Configs)
 		assert.Equal(t, tc.size, size, fmt.Sprintf("%s: expected %d got %d\n", tc.desc, tc.size, size))
 	}
@@ -466,7 +491,7 @@ func TestRemove(t *testing.T) {
 		err := repo.Remove(c.Owner, id)
 		require.Nil(t, err, fmt.Sprintf("%d: failed to remove config due to: %s", i, err))
 
-		_, err = repo.RetrieveByID(c.Owner, id)
+		_, err = repo.RetrieveByID(bootstrap.Access{Owner: c.Owner}, id)
 		require.True(t, errors.Contains(err, bootstrap.ErrNotFound), fmt.Sprintf("%d: expected %s got %s", i, bootstrap.ErrNotFound, err))
 	}
 }
@@ -626,7 +651,7 @@ func TestUpdateChannel(t *testing.T) {
 	err = repo.UpdateChannel(update)
 	assert.Nil(t, err, fmt.Sprintf("updating config expected to succeed: %s.\n", err))
 
-	cfg, err := repo.RetrieveByID(c.Owner, c.MFThing)
+	cfg, err := repo.RetrieveByID(bootstrap.Access{Owner: c.Owner}, c.MFThing)
 	require.Nil(t, err, fmt.Sprintf("Retrieving config expected to succeed: %s.\n", err))
 	var retreved bootstrap.Channel
 	for _, c := range cfg.MFChannels {
@@ -657,7 +682,7 @@ func TestRemoveChannel(t *testing.T) {
 	err = repo.RemoveChannel(c.MFChannels[0].ID)
 	require.Nil(t, err, fmt.Sprintf("Retrieving config expected to succeed: %s.\n", err))
 
-	cfg, err := repo.RetrieveByID(c.Owner, c.MFThing)
+	cfg, err := repo.RetrieveByID(bootstrap.Access{Owner: c.Owner}, c.MFThing)
 	require.Nil(t, err, fmt.Sprintf("Retrieving config expected to succeed: %s.\n", err))
 	assert.NotContains(t, cfg.MFChannels, c.MFChannels[0], fmt.Sprintf("expected to remove channel %s from %s", c.MFChannels[0], cfg.MFChannels))
 }
@@ -681,7 +706,7 @@ func TestDisconnectThing(t *testing.T) {
 	err = repo.DisconnectThing(c.MFChannels[0].ID, saved)
 	require.Nil(t, err, fmt.Sprintf("Retrieving config expected to succeed: %s.\n", err))
 
-	cfg, err := repo.RetrieveByID(c.Owner, c.MFThing)
+	cfg, err := repo.RetrieveByID(bootstrap.Access{Owner: c.Owner}, c.MFThing)
 	require.Nil(t, err, fmt.Sprintf("Retrieving config expected to succeed: %s.\n", err))
 	assert.Equal(t, cfg.State, bootstrap.Inactive, fmt.Sprintf("expected ti be inactive when a connection is removed from %s", cfg))
 }
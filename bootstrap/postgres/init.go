@@ -100,6 +100,18 @@ func migrateDB(db *sqlx.DB) error {
 					"CREATE TABLE IF NOT EXISTS unknown_configs",
 				},
 			},
+			{
+				Id: "configs_3",
+				Up: []string{
+					`ALTER TABLE IF EXISTS configs ADD COLUMN IF NOT EXISTS
+					 user_group VARCHAR(36) NOT NULL DEFAULT ''`,
+					`CREATE INDEX IF NOT EXISTS configs_user_group_idx ON configs (user_group)`,
+				},
+				Down: []string{
+					"DROP INDEX configs_user_group_idx",
+					"ALTER TABLE configs DROP COLUMN user_group",
+				},
+			},
 		},
 	}
 
@@ -68,7 +68,7 @@ func newService(auth mainflux.AuthNServiceClient, url string) bootstrap.Service
 	}
 
 	sdk := mfsdk.NewSDK(config)
-	return bootstrap.New(auth, configs, sdk, encKey)
+	return bootstrap.New(auth, mocks.NewGroupsService(nil), configs, sdk, encKey)
 }
 
 func newThingsService(auth mainflux.AuthNServiceClient) things.Service {
@@ -63,9 +63,12 @@ var _ Service = (*bootstrapService)(nil)
 // implementation, and all of its decorators (e.g. logging & metrics).
 type Service interface {
 	// Add adds new Thing Config to the user identified by the provided token.
+	// The Config can only be shared with the users groups in which the user
+	// is at least an editor.
 	Add(token string, cfg Config) (Config, error)
 
-	// View returns Thing Config with given ID belonging to the user identified by the given token.
+	// View returns Thing Config with given ID belonging to the user identified by the given token,
+	// or shared with the users groups in which the user is at least a viewer.
 	View(token, id string) (Config, error)
 
 	// Update updates editable fields of the provided Config.
@@ -79,7 +82,8 @@ type Service interface {
 	UpdateConnections(token, id string, connections []string) error
 
 	// List returns subset of Configs with given search params that belong to the
-	// user identified by the given token.
+	// user identified by the given token, or are shared with the users groups
+	// of the user.
 	List(token string, filter Filter, offset, limit uint64) (ConfigsPage, error)
 
 	// Remove removes Config with specified token that belongs to the user identified by the given token.
@@ -117,6 +121,7 @@ type ConfigReader interface {
 
 type bootstrapService struct {
 	auth    mainflux.AuthNServiceClient
+	users   mainflux.UsersServiceClient
 	configs ConfigRepository
 	sdk     mfsdk.SDK
 	encKey  []byte
@@ -124,21 +129,32 @@ type bootstrapService struct {
 }
 
 // New returns new Bootstrap service.
-func New(auth mainflux.AuthNServiceClient, configs ConfigRepository, sdk mfsdk.SDK, encKey []byte) Service {
+func New(auth mainflux.AuthNServiceClient, users mainflux.UsersServiceClient, configs ConfigRepository, sdk mfsdk.SDK, encKey []byte) Service {
 	return &bootstrapService{
 		configs: configs,
 		sdk:     sdk,
 		auth:    auth,
+		users:   users,
 		encKey:  encKey,
 	}
 }
 
 func (bs bootstrapService) Add(token string, cfg Config) (Config, error) {
-	owner, err := bs.identify(token)
+	owner, err := bs.identify(token, mainflux.WriteAction, cfg.MFThing)
 	if err != nil {
 		return Config{}, err
 	}
 
+	if cfg.UserGroup != "" {
+		acc, err := bs.access(owner, mainflux.WriteAction)
+		if err != nil {
+			return Config{}, err
+		}
+		if !acc.Shares(cfg.UserGroup) {
+			return Config{}, ErrUnauthorizedAccess
+		}
+	}
+
 	toConnect := bs.toIDList(cfg.MFChannels)
 
 	// Check if channels exist. This is the way to prevent fetching channels that already exist.
@@ -181,30 +197,54 @@ func (bs bootstrapService) Add(token string, cfg Config) (Config, error) {
 }
 
 func (bs bootstrapService) View(token, id string) (Config, error) {
-	owner, err := bs.identify(token)
+	owner, err := bs.identify(token, mainflux.ReadAction, id)
+	if err != nil {
+		return Config{}, err
+	}
+
+	acc, err := bs.access(owner, mainflux.ReadAction)
 	if err != nil {
 		return Config{}, err
 	}
 
-	return bs.configs.RetrieveByID(owner, id)
+	return bs.configs.RetrieveByID(acc, id)
 }
 
 func (bs bootstrapService) Update(token string, cfg Config) error {
-	owner, err := bs.identify(token)
+	owner, err := bs.identify(token, mainflux.WriteAction, cfg.MFThing)
 	if err != nil {
 		return err
 	}
 
-	cfg.Owner = owner
+	acc, err := bs.access(owner, mainflux.WriteAction)
+	if err != nil {
+		return err
+	}
+
+	saved := bs.accessible(acc, cfg.MFThing)
+	cfg.Owner = saved.Owner
+	switch {
+	case cfg.UserGroup == "":
+		cfg.UserGroup = saved.UserGroup
+	case cfg.UserGroup != saved.UserGroup && !acc.Shares(cfg.UserGroup):
+		return ErrUnauthorizedAccess
+	}
 
 	return bs.configs.Update(cfg)
 }
 
 func (bs bootstrapService) UpdateCert(token, thingID, clientCert, clientKey, caCert string) error {
-	owner, err := bs.identify(token)
+	owner, err := bs.identify(token, mainflux.WriteAction, thingID)
 	if err != nil {
 		return err
 	}
+
+	acc, err := bs.access(owner, mainflux.WriteAction)
+	if err != nil {
+		return err
+	}
+
+	owner = bs.accessible(acc, thingID).Owner
 	if err := bs.configs.UpdateCert(owner, thingID, clientCert, clientKey, caCert); err != nil {
 		return errors.Wrap(errUpdateCert, err)
 	}
@@ -212,12 +252,17 @@ func (bs bootstrapService) UpdateCert(token, thingID, clientCert, clientKey, caC
 }
 
 func (bs bootstrapService) UpdateConnections(token, id string, connections []string) error {
-	owner, err := bs.identify(token)
+	owner, err := bs.identify(token, mainflux.WriteAction, id)
 	if err != nil {
 		return err
 	}
 
-	cfg, err := bs.configs.RetrieveByID(owner, id)
+	acc, err := bs.access(owner, mainflux.WriteAction)
+	if err != nil {
+		return err
+	}
+
+	cfg, err := bs.configs.RetrieveByID(acc, id)
 	if err != nil {
 		return errors.Wrap(errUpdateConnections, err)
 	}
@@ -225,7 +270,7 @@ func (bs bootstrapService) UpdateConnections(token, id string, connections []str
 	add, remove := bs.updateList(cfg, connections)
 
 	// Check if channels exist. This is the way to prevent fetching channels that already exist.
-	existing, err := bs.configs.ListExisting(owner, connections)
+	existing, err := bs.configs.ListExisting(cfg.Owner, connections)
 	if err != nil {
 		return errors.Wrap(errUpdateConnections, err)
 	}
@@ -265,23 +310,35 @@ func (bs bootstrapService) UpdateConnections(token, id string, connections []str
 		}
 	}
 
-	return bs.configs.UpdateConnections(owner, id, channels, connections)
+	return bs.configs.UpdateConnections(cfg.Owner, id, channels, connections)
 }
 
 func (bs bootstrapService) List(token string, filter Filter, offset, limit uint64) (ConfigsPage, error) {
-	owner, err := bs.identify(token)
+	owner, err := bs.identify(token, mainflux.ReadAction, "")
+	if err != nil {
+		return ConfigsPage{}, err
+	}
+
+	acc, err := bs.access(owner, mainflux.ReadAction)
 	if err != nil {
 		return ConfigsPage{}, err
 	}
 
-	return bs.configs.RetrieveAll(owner, filter, offset, limit), nil
+	return bs.configs.RetrieveAll(acc, filter, offset, limit), nil
 }
 
 func (bs bootstrapService) Remove(token, id string) error {
-	owner, err := bs.identify(token)
+	owner, err := bs.identify(token, mainflux.WriteAction, id)
 	if err != nil {
 		return err
 	}
+
+	acc, err := bs.access(owner, mainflux.WriteAction)
+	if err != nil {
+		return err
+	}
+
+	owner = bs.accessible(acc, id).Owner
 	if err := bs.configs.Remove(owner, id); err != nil {
 		return errors.Wrap(errRemoveBootstrap, err)
 	}
@@ -310,12 +367,17 @@ func (bs bootstrapService) Bootstrap(externalKey, externalID string, secure bool
 }
 
 func (bs bootstrapService) ChangeState(token, id string, state State) error {
-	owner, err := bs.identify(token)
+	owner, err := bs.identify(token, mainflux.WriteAction, id)
+	if err != nil {
+		return err
+	}
+
+	acc, err := bs.access(owner, mainflux.WriteAction)
 	if err != nil {
 		return err
 	}
 
-	cfg, err := bs.configs.RetrieveByID(owner, id)
+	cfg, err := bs.configs.RetrieveByID(acc, id)
 	if err != nil {
 		return errors.Wrap(errChangeState, err)
 	}
@@ -345,7 +407,7 @@ func (bs bootstrapService) ChangeState(token, id string, state State) error {
 			}
 		}
 	}
-	if err := bs.configs.ChangeState(owner, id, state); err != nil {
+	if err := bs.configs.ChangeState(cfg.Owner, id, state); err != nil {
 		return errors.Wrap(errChangeState, err)
 	}
 	return nil
@@ -379,7 +441,10 @@ func (bs bootstrapService) DisconnectThingHandler(channelID, thingID string) err
 	return nil
 }
 
-func (bs bootstrapService) identify(token string) (string, error) {
+// identify identifies the user by the provided token and checks whether the
+// identity is allowed to perform the action on the config of the thing with
+// the provided ID.
+func (bs bootstrapService) identify(token, action, id string) (string, error) {
 	ctx, cancel := context.WithTimeout(context.Background(), time.Second)
 	defer cancel()
 
@@ -387,10 +452,46 @@ func (bs bootstrapService) identify(token string) (string, error) {
 	if err != nil {
 		return "", ErrUnauthorizedAccess
 	}
+	if !res.Allows(mainflux.ConfigsResource, action, id) {
+		return "", ErrUnauthorizedAccess
+	}
 
 	return res.GetEmail(), nil
 }
 
+// access returns the Configs the user with the provided email can access in
+// order to perform the action. Besides the owned ones, these are the ones
+// shared with the users groups in which the user is a viewer for reading, or
+// an editor for writing.
+func (bs bootstrapService) access(email, action string) (Access, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), time.Second)
+	defer cancel()
+
+	role := viewerRole
+	if action == mainflux.WriteAction {
+		role = editorRole
+	}
+
+	res, err := bs.users.Authorize(ctx, &mainflux.AuthorizeReq{Email: email, Role: role})
+	if err != nil {
+		return Access{}, errors.Wrap(ErrUnauthorizedAccess, err)
+	}
+
+	return Access{Owner: email, UserGroups: res.GetGroups()}, nil
+}
+
+// accessible returns the Config with the provided ID if it is accessible.
+// Otherwise, the Config is reported as owned by the user, leaving it to the
+// repository to reject the operation on it.
+func (bs bootstrapService) accessible(acc Access, id string) Config {
+	cfg, err := bs.configs.RetrieveByID(acc, id)
+	if err != nil {
+		return Config{MFThing: id, Owner: acc.Owner}
+	}
+
+	return cfg
+}
+
 // Method thing retrieves Mainflux Thing creating one if an empty ID is passed.
 func (bs bootstrapService) thing(token, id string) (mfsdk.Thing, error) {
 	thingID := id
@@ -31,6 +31,7 @@ import (
 const (
 	validToken   = "validToken"
 	invalidToken = "invalidToken"
+	scopedToken  = "scopedToken"
 	email        = "test@example.com"
 	unknown      = "unknown"
 	channelsNum  = 3
@@ -54,13 +55,17 @@ var (
 )
 
 func newService(auth mainflux.AuthNServiceClient, url string) bootstrap.Service {
+	return newSharedService(auth, nil, url)
+}
+
+func newSharedService(auth mainflux.AuthNServiceClient, roles map[string]map[string]string, url string) bootstrap.Service {
 	things := mocks.NewConfigsRepository()
 	config := mfsdk.Config{
 		BaseURL: url,
 	}
 
 	sdk := mfsdk.NewSDK(config)
-	return bootstrap.New(auth, things, sdk, encKey)
+	return bootstrap.New(auth, mocks.NewGroupsService(roles), things, sdk, encKey)
 }
 
 func newThingsService(auth mainflux.AuthNServiceClient) things.Service {
@@ -150,13 +155,21 @@ func TestAdd(t *testing.T) {
 }
 
 func TestView(t *testing.T) {
-	users := mocks.NewUsersService(map[string]string{validToken: email})
+	scopes := map[string][]*mainflux.Scope{}
+	users := mocks.NewScopedUsersService(map[string]string{validToken: email, scopedToken: email}, scopes)
 
 	server := newThingsServer(newThingsService(users))
 	svc := newService(users, server.URL)
 
 	saved, err := svc.Add(validToken, config)
 	require.Nil(t, err, fmt.Sprintf("Saving config expected to succeed: %s.\n", err))
+	c := config
+	c.ExternalID = "other_external_id"
+	other, err := svc.Add(validToken, c)
+	require.Nil(t, err, fmt.Sprintf("Saving config expected to succeed: %s.\n", err))
+	scopes[scopedToken] = []*mainflux.Scope{
+		{Resource: mainflux.ConfigsResource, Action: mainflux.ReadAction, Ids: []string{saved.MFThing}},
+	}
 
 	cases := []struct {
 		desc  string
@@ -182,6 +195,18 @@ func TestView(t *testing.T) {
 			token: invalidToken,
 			err:   bootstrap.ErrUnauthorizedAccess,
 		},
+		{
+			desc:  "view a config with scoped key",
+			id:    saved.MFThing,
+			token: scopedToken,
+			err:   nil,
+		},
+		{
+			desc:  "view a config out of key scope",
+			id:    other.MFThing,
+			token: scopedToken,
+			err:   bootstrap.ErrUnauthorizedAccess,
+		},
 	}
 
 	for _, tc := range cases {
@@ -529,6 +554,135 @@ func TestRemove(t *testing.T) {
 	}
 }
 
+func TestSharedAccess(t *testing.T) {
+	const (
+		userGroup     = "user-group"
+		otherGroup    = "other-group"
+		viewerToken   = "viewerToken"
+		editorToken   = "editorToken"
+		outsiderToken = "outsiderToken"
+		viewerEmail   = "viewer@example.com"
+		editorEmail   = "editor@example.com"
+		outsiderEmail = "outsider@example.com"
+	)
+	users := mocks.NewUsersService(map[string]string{
+		validToken:    email,
+		viewerToken:   viewerEmail,
+		editorToken:   editorEmail,
+		outsiderToken: outsiderEmail,
+	})
+	roles := map[string]map[string]string{
+		email:       {userGroup: "admin"},
+		viewerEmail: {userGroup: "viewer"},
+		editorEmail: {userGroup: "editor", otherGroup: "viewer"},
+	}
+
+	server := newThingsServer(newThingsService(users))
+	svc := newSharedService(users, roles, server.URL)
+
+	c := config
+	c.UserGroup = userGroup
+	saved, err := svc.Add(validToken, c)
+	require.Nil(t, err, fmt.Sprintf("Saving config expected to succeed: %s.\n", err))
+
+	cases := []struct {
+		desc string
+		op   func() error
+		err  error
+	}{
+		{
+			desc: "view shared config as viewer",
+			op: func() error {
+				_, err := svc.View(viewerToken, saved.MFThing)
+				return err
+			},
+			err: nil,
+		},
+		{
+			desc: "list shared configs as viewer",
+			op: func() error {
+				page, err := svc.List(viewerToken, bootstrap.Filter{}, 0, 10)
+				if err == nil && len(page.Configs) != 1 {
+					return fmt.Errorf("expected 1 config got %d", len(page.Configs))
+				}
+				return err
+			},
+			err: nil,
+		},
+		{
+			desc: "view shared config as outsider",
+			op: func() error {
+				_, err := svc.View(outsiderToken, saved.MFThing)
+				return err
+			},
+			err: bootstrap.ErrUnauthorizedAccess,
+		},
+		{
+			desc: "update shared config as viewer",
+			op: func() error {
+				return svc.Update(viewerToken, bootstrap.Config{MFThing: saved.MFThing, Name: "viewer"})
+			},
+			err: bootstrap.ErrNotFound,
+		},
+		{
+			desc: "update shared config as editor",
+			op: func() error {
+				return svc.Update(editorToken, bootstrap.Config{MFThing: saved.MFThing, Name: "editor"})
+			},
+			err: nil,
+		},
+		{
+			desc: "update cert of shared config as editor",
+			op: func() error {
+				return svc.UpdateCert(editorToken, saved.MFThing, "cert", "key", "ca")
+			},
+			err: nil,
+		},
+		{
+			desc: "share config with group the user can only view",
+			op: func() error {
+				return svc.Update(editorToken, bootstrap.Config{MFThing: saved.MFThing, UserGroup: otherGroup})
+			},
+			err: bootstrap.ErrUnauthorizedAccess,
+		},
+		{
+			desc: "add config shared with group the user is not member of",
+			op: func() error {
+				_, err := svc.Add(outsiderToken, c)
+				return err
+			},
+			err: bootstrap.ErrUnauthorizedAccess,
+		},
+		{
+			desc: "remove shared config as viewer",
+			op: func() error {
+				if err := svc.Remove(viewerToken, saved.MFThing); err != nil {
+					return err
+				}
+				_, err := svc.View(validToken, saved.MFThing)
+				return err
+			},
+			err: nil,
+		},
+		{
+			desc: "remove shared config as editor",
+			op: func() error {
+				if err := svc.Remove(editorToken, saved.MFThing); err != nil {
+					return err
+				}
+				_, err := svc.View(validToken, saved.MFThing)
+				return err
+			},
+			err: bootstrap.ErrNotFound,
+		},
+	}
+
+	for _, tc := range cases {
+		err := tc.op()
+		assert.True(t, errors.Contains(err, tc.err), fmt.Sprintf("%s: expected %s got %s\n", tc.desc, tc.err, err))
+	}
+}
+
 func TestBootstrap(t *testing.T) {
 	users := mocks.NewUsersService(map[string]string{validToken: email})
 
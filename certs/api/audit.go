@@ -0,0 +1,41 @@
+// Copyright (c) Mainflux
+// SPDX-License-Identifier: Apache-2.0
+
+package api
+
+import (
+	"context"
+
+	"github.com/mainflux/mainflux/certs"
+	"github.com/mainflux/mainflux/pkg/audit"
+)
+
+var _ certs.Service = (*auditMiddleware)(nil)
+
+type auditMiddleware struct {
+	rec audit.Recorder
+	svc certs.Service
+}
+
+// AuditMiddleware records issuing and revoking of the things certificates.
+func AuditMiddleware(svc certs.Service, rec audit.Recorder) certs.Service {
+	return &auditMiddleware{rec, svc}
+}
+
+func (am *auditMiddleware) IssueCert(ctx context.Context, token, thingID, daysValid string, keyBits int, keyType string) (c certs.Cert, err error) {
+	e := am.rec.Event(ctx, token, "issue_cert", thingID)
+	defer func() { am.rec.Record(ctx, e, err) }()
+
+	return am.svc.IssueCert(ctx, token, thingID, daysValid, keyBits, keyType)
+}
+
+func (am *auditMiddleware) ListCerts(ctx context.Context, token string, offset, limit uint64) (certs.Page, error) {
+	return am.svc.ListCerts(ctx, token, offset, limit)
+}
+
+func (am *auditMiddleware) RevokeCert(ctx context.Context, token, thingID string) (c certs.Revoke, err error) {
+	e := am.rec.Event(ctx, token, "revoke_cert", thingID)
+	defer func() { am.rec.Record(ctx, e, err) }()
+
+	return am.svc.RevokeCert(ctx, token, thingID)
+}
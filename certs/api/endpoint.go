@@ -48,8 +48,9 @@ func listCerts(svc certs.Service) endpoint.Endpoint {
 
 		for _, cert := range page.Certs {
 			view := certsResponse{
-				Serial:  cert.Serial,
-				ThingID: cert.ThingID,
+				Serial:    cert.Serial,
+				ThingID:   cert.ThingID,
+				UserGroup: cert.UserGroup,
 			}
 			res.Certs = append(res.Certs, view)
 		}
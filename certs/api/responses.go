@@ -24,6 +24,7 @@ type certsResponse struct {
 	ClientKey  map[string]string `json:"client_key"`
 	Serial     string            `json:"serial"`
 	ThingID    string            `json:"thing_id"`
+	UserGroup  string            `json:"user_group,omitempty"`
 	CACert     string            `json:"ca_cert"`
 	Error      string            `json:"error"`
 }
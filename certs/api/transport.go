@@ -11,6 +11,7 @@ import (
 	"strconv"
 
 	"github.com/mainflux/mainflux/certs"
+	"github.com/mainflux/mainflux/pkg/audit"
 	"github.com/mainflux/mainflux/pkg/errors"
 
 	kithttp "github.com/go-kit/kit/transport/http"
@@ -40,6 +41,7 @@ var (
 func MakeHandler(svc certs.Service) http.Handler {
 	opts := []kithttp.ServerOption{
 		kithttp.ServerErrorEncoder(encodeError),
+		kithttp.ServerBefore(audit.PopulateIP),
 	}
 
 	r := bone.New()
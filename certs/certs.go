@@ -13,13 +13,22 @@ type Page struct {
 	Certs  []Cert
 }
 
+// Access specifies the certificates the user is allowed to access. These
+// are the ones owned by the user, together with the ones issued for the
+// things shared with the listed users groups.
+type Access struct {
+	Owner      string
+	UserGroups []string
+}
+
 // Repository specifies a Config persistence API.
 type Repository interface {
 	// Save  saves cert for thing into database
 	Save(ctx context.Context, cert Cert) (string, error)
 
-	// RetrieveAll retrieve all issued certificates for given owner
-	RetrieveAll(ctx context.Context, ownerID string, offset, limit uint64) (Page, error)
+	// RetrieveAll retrieve all issued certificates accessible using the
+	// provided access
+	RetrieveAll(ctx context.Context, acc Access, offset, limit uint64) (Page, error)
 
 	// Remove certificate from DB for given thing
 	Remove(ctx context.Context, thingID string) error
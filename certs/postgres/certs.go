@@ -45,9 +45,15 @@ func NewRepository(db *sqlx.DB, log logger.Logger) certs.Repository {
 	return &certsRepository{db: db, log: log}
 }
 
-func (cr certsRepository) RetrieveAll(ctx context.Context, ownerID string, offset, limit uint64) (certs.Page, error) {
-	q := `SELECT thing_id, owner_id, serial, expire FROM certs WHERE owner_id = $1 ORDER BY expire LIMIT $2 OFFSET $3;`
-	rows, err := cr.db.Query(q, ownerID, limit, offset)
+func (cr certsRepository) RetrieveAll(ctx context.Context, acc certs.Access, offset, limit uint64) (certs.Page, error) {
+	groups := pq.StringArray(acc.UserGroups)
+	if groups == nil {
+		groups = pq.StringArray{}
+	}
+
+	q := `SELECT thing_id, owner_id, user_group, serial, expire FROM certs
+	      WHERE owner_id = $1 OR user_group = ANY($2) ORDER BY expire LIMIT $3 OFFSET $4;`
+	rows, err := cr.db.Query(q, acc.Owner, groups, limit, offset)
 	if err != nil {
 		cr.log.Error(fmt.Sprintf("Failed to retrieve configs due to %s", err))
 		return certs.Page{}, err
@@ -58,7 +64,7 @@ func (cr certsRepository) RetrieveAll(ctx context.Context, ownerID string, offse
 
 	for rows.Next() {
 		c := certs.Cert{}
-		if err := rows.Scan(&c.ThingID, &c.OwnerID, &c.Serial, &c.Expire); err != nil {
+		if err := rows.Scan(&c.ThingID, &c.OwnerID, &c.UserGroup, &c.Serial, &c.Expire); err != nil {
 			cr.log.Error(fmt.Sprintf("Failed to read retrieved config due to %s", err))
 			return certs.Page{}, err
 
@@ -66,9 +72,9 @@ func (cr certsRepository) RetrieveAll(ctx context.Context, ownerID string, offse
 		certificates = append(certificates, c)
 	}
 
-	q = `SELECT COUNT(*) FROM certs WHERE owner_id = $1`
+	q = `SELECT COUNT(*) FROM certs WHERE owner_id = $1 OR user_group = ANY($2)`
 	var total uint64
-	if err := cr.db.QueryRow(q, ownerID).Scan(&total); err != nil {
+	if err := cr.db.QueryRow(q, acc.Owner, groups).Scan(&total); err != nil {
 		cr.log.Error(fmt.Sprintf("Failed to count certs due to %s", err))
 		return certs.Page{}, err
 	}
@@ -82,7 +88,7 @@ func (cr certsRepository) RetrieveAll(ctx context.Context, ownerID string, offse
 }
 
 func (cr certsRepository) Save(ctx context.Context, cert certs.Cert) (string, error) {
-	q := `INSERT INTO certs (thing_id, owner_id, serial, expire) VALUES (:thing_id, :owner_id, :serial, :expire)`
+	q := `INSERT INTO certs (thing_id, owner_id, user_group, serial, expire) VALUES (:thing_id, :owner_id, :user_group, :serial, :expire)`
 
 	tx, err := cr.db.Beginx()
 	if err != nil {
@@ -124,7 +130,7 @@ func (cr certsRepository) Remove(ctx context.Context, serial string) error {
 }
 
 func (cr certsRepository) RetrieveByThing(ctx context.Context, thingID string) (certs.Cert, error) {
-	q := `SELECT thing_id, owner_id, serial, expire FROM certs WHERE thing_id = $1`
+	q := `SELECT thing_id, owner_id, user_group, serial, expire FROM certs WHERE thing_id = $1`
 	var dbcrt dbCert
 	var c certs.Cert
 
@@ -143,7 +149,7 @@ func (cr certsRepository) RetrieveByThing(ctx context.Context, thingID string) (
 }
 
 func (cr certsRepository) retrieveBySerial(ctx context.Context, serial string) (certs.Cert, error) {
-	q := `SELECT thing_id, owner_id, serial, expire FROM certs WHERE serial = $1`
+	q := `SELECT thing_id, owner_id, user_group, serial, expire FROM certs WHERE serial = $1`
 	var dbcrt dbCert
 	var c certs.Cert
 
@@ -170,24 +176,27 @@ func (cr certsRepository) rollback(content string, tx *sqlx.Tx, err error) {
 }
 
 type dbCert struct {
-	ThingID string    `db:"thing_id"`
-	Serial  string    `db:"serial"`
-	Expire  time.Time `db:"expire"`
-	OwnerID string    `db:"owner_id"`
+	ThingID   string    `db:"thing_id"`
+	Serial    string    `db:"serial"`
+	Expire    time.Time `db:"expire"`
+	OwnerID   string    `db:"owner_id"`
+	UserGroup string    `db:"user_group"`
 }
 
 func toDBCert(c certs.Cert) dbCert {
 	return dbCert{
-		ThingID: c.ThingID,
-		OwnerID: c.OwnerID,
-		Serial:  c.Serial,
-		Expire:  c.Expire,
+		ThingID:   c.ThingID,
+		OwnerID:   c.OwnerID,
+		UserGroup: c.UserGroup,
+		Serial:    c.Serial,
+		Expire:    c.Expire,
 	}
 }
 
 func toCert(cdb dbCert) certs.Cert {
 	var c certs.Cert
 	c.OwnerID = cdb.OwnerID
+	c.UserGroup = cdb.UserGroup
 	c.ThingID = cdb.ThingID
 	c.Serial = cdb.Serial
 	c.Expire = cdb.Expire
@@ -71,6 +71,18 @@ func migrateDB(db *sqlx.DB) error {
 					"DROP TABLE IF EXISTS certs;",
 				},
 			},
+			{
+				Id: "certs_2",
+				Up: []string{
+					`ALTER TABLE IF EXISTS certs ADD COLUMN IF NOT EXISTS
+					 user_group VARCHAR(36) NOT NULL DEFAULT ''`,
+					`CREATE INDEX IF NOT EXISTS certs_user_group_idx ON certs (user_group)`,
+				},
+				Down: []string{
+					"DROP INDEX certs_user_group_idx",
+					"ALTER TABLE certs DROP COLUMN user_group",
+				},
+			},
 		},
 	}
 
@@ -48,6 +48,10 @@ var (
 	errFailedCertRevocation      = errors.New("failed to revoke certificate")
 )
 
+// viewerRole is the users group role required to list the certificates
+// issued for the things shared with the group.
+const viewerRole = "viewer"
+
 var _ Service = (*certsService)(nil)
 
 // Service specifies an API that must be fulfilled by the domain service
@@ -56,7 +60,9 @@ type Service interface {
 	// IssueCert issues certificate for given thing id if access is granted with token
 	IssueCert(ctx context.Context, token, thingID, daysValid string, keyBits int, keyType string) (Cert, error)
 
-	// ListCerts lists all certificates issued for given owner
+	// ListCerts lists all certificates issued for given owner, together
+	// with the ones issued for the things shared with the users groups in
+	// which the owner is at least a viewer
 	ListCerts(ctx context.Context, token string, offset, limit uint64) (Page, error)
 
 	// RevokeCert revokes certificate for given thing
@@ -87,6 +93,7 @@ type Config struct {
 
 type certsService struct {
 	auth      mainflux.AuthNServiceClient
+	users     mainflux.UsersServiceClient
 	certsRepo Repository
 	sdk       mfsdk.SDK
 	conf      Config
@@ -94,11 +101,12 @@ type certsService struct {
 }
 
 // New returns new Certs service.
-func New(auth mainflux.AuthNServiceClient, certs Repository, sdk mfsdk.SDK, config Config, pki pki.Agent) Service {
+func New(auth mainflux.AuthNServiceClient, users mainflux.UsersServiceClient, certs Repository, sdk mfsdk.SDK, config Config, pki pki.Agent) Service {
 	return &certsService{
 		certsRepo: certs,
 		sdk:       sdk,
 		auth:      auth,
+		users:     users,
 		conf:      config,
 		pki:       pki,
 	}
@@ -110,6 +118,7 @@ type Revoke struct {
 
 type Cert struct {
 	OwnerID        string    `json:"owner_id" mapstructure:"owner_id"`
+	UserGroup      string    `json:"user_group,omitempty" mapstructure:"-"`
 	ThingID        string    `json:"thing_id" mapstructure:"thing_id"`
 	ClientCert     string    `json:"client_cert" mapstructure:"certificate"`
 	IssuingCA      string    `json:"issuing_ca" mapstructure:"issuing_ca"`
@@ -148,6 +157,7 @@ func (cs *certsService) IssueCert(ctx context.Context, token, thingID string, da
 
 	c.ThingID = thingID
 	c.OwnerID = owner.GetEmail()
+	c.UserGroup = thing.UserGroup
 	c.ClientCert = cert.ClientCert
 	c.IssuingCA = cert.IssuingCA
 	c.CAChain = cert.CAChain
@@ -193,7 +203,16 @@ func (cs *certsService) ListCerts(ctx context.Context, token string, offset, lim
 		return Page{}, errors.Wrap(ErrUnauthorizedAccess, err)
 	}
 
-	return cs.certsRepo.RetrieveAll(ctx, u.GetEmail(), offset, limit)
+	res, err := cs.users.Authorize(ctx, &mainflux.AuthorizeReq{Email: u.GetEmail(), Role: viewerRole})
+	if err != nil {
+		return Page{}, errors.Wrap(ErrUnauthorizedAccess, err)
+	}
+	acc := Access{
+		Owner:      u.GetEmail(),
+		UserGroups: res.GetGroups(),
+	}
+
+	return cs.certsRepo.RetrieveAll(ctx, acc, offset, limit)
 }
 
 func (cs *certsService) certs(thingKey, daysValid string, keyBits int) (string, string, error) {
@@ -0,0 +1,57 @@
+// Copyright (c) Mainflux
+// SPDX-License-Identifier: Apache-2.0
+
+package cli
+
+import (
+	mfxsdk "github.com/mainflux/mainflux/pkg/sdk/go"
+	"github.com/spf13/cobra"
+)
+
+// NewAuditCmd returns audit command.
+func NewAuditCmd() *cobra.Command {
+	var query mfxsdk.EventsQuery
+
+	getCmd := cobra.Command{
+		Use:   "get",
+		Short: "get <user_auth_token> [--service=things] [--operation=remove_thing] [--actor=<email>] [--entity=<id>] [--from=<seconds>] [--to=<seconds>]",
+		Long:  `Get the list of audit events, from the newest to the oldest`,
+		Run: func(cmd *cobra.Command, args []string) {
+			if len(args) != 1 {
+				logUsage(cmd.Short)
+				return
+			}
+
+			query.Offset = uint64(Offset)
+			query.Limit = uint64(Limit)
+
+			l, err := sdk.Events(args[0], query)
+			if err != nil {
+				logError(err)
+				return
+			}
+
+			logJSON(l)
+		},
+	}
+
+	getCmd.Flags().StringVar(&query.Service, "service", "", "service the operation was performed on")
+	getCmd.Flags().StringVar(&query.Operation, "operation", "", "performed operation")
+	getCmd.Flags().StringVar(&query.Actor, "actor", "", "user that performed the operation")
+	getCmd.Flags().StringVar(&query.Entity, "entity", "", "entity the operation was performed on")
+	getCmd.Flags().Float64Var(&query.From, "from", 0, "start of the time range in seconds")
+	getCmd.Flags().Float64Var(&query.To, "to", 0, "end of the time range in seconds")
+
+	cmd := cobra.Command{
+		Use:   "audit",
+		Short: "Audit log",
+		Long:  `Audit log: list the recorded management operations`,
+		Run: func(cmd *cobra.Command, args []string) {
+			logUsage("audit [get]")
+		},
+	}
+
+	cmd.AddCommand(&getCmd)
+
+	return &cmd
+}
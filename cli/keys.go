@@ -0,0 +1,116 @@
+// Copyright (c) Mainflux
+// SPDX-License-Identifier: Apache-2.0
+
+package cli
+
+import (
+	mfxsdk "github.com/mainflux/mainflux/pkg/sdk/go"
+	"github.com/spf13/cobra"
+)
+
+// NewKeysCmd returns keys command.
+func NewKeysCmd() *cobra.Command {
+	var status string
+	var keyType int
+
+	getCmd := cobra.Command{
+		Use:   "get",
+		Short: "get <user_auth_token> [--status=active|expired] [--type=2]",
+		Long:  `Get the list of API keys issued by the user`,
+		Run: func(cmd *cobra.Command, args []string) {
+			if len(args) != 1 {
+				logUsage(cmd.Short)
+				return
+			}
+
+			query := mfxsdk.KeysQuery{
+				Offset: uint64(Offset),
+				Limit:  uint64(Limit),
+				Status: status,
+			}
+			if keyType >= 0 {
+				t := uint32(keyType)
+				query.Type = &t
+			}
+
+			l, err := sdk.Keys(args[0], query)
+			if err != nil {
+				logError(err)
+				return
+			}
+
+			logJSON(l)
+		},
+	}
+
+	getCmd.Flags().StringVar(&status, "status", "", "key status: active or expired")
+	getCmd.Flags().IntVar(&keyType, "type", -1, "key type")
+
+	revokeCmd := cobra.Command{
+		Use:   "revoke",
+		Short: "revoke [all | <key_id>] <user_auth_token>",
+		Long:  `Revoke API key by id or all of the API keys issued by the user`,
+		Run: func(cmd *cobra.Command, args []string) {
+			if len(args) != 2 {
+				logUsage(cmd.Short)
+				return
+			}
+
+			if args[0] == "all" {
+				if err := sdk.RevokeKeys("", args[1]); err != nil {
+					logError(err)
+					return
+				}
+				logOK()
+				return
+			}
+
+			if err := sdk.RevokeKey(args[0], args[1]); err != nil {
+				logError(err)
+				return
+			}
+
+			logOK()
+		},
+	}
+
+	revokeSubjectCmd := cobra.Command{
+		Use:   "revoke-subject",
+		Short: "revoke-subject <subject> <user_auth_token>",
+		Long:  `Revoke all of the API keys issued by the user for the subject`,
+		Run: func(cmd *cobra.Command, args []string) {
+			if len(args) != 2 {
+				logUsage(cmd.Short)
+				return
+			}
+
+			if err := sdk.RevokeKeys(args[0], args[1]); err != nil {
+				logError(err)
+				return
+			}
+
+			logOK()
+		},
+	}
+
+	cmd := cobra.Command{
+		Use:   "keys",
+		Short: "API keys management",
+		Long:  `API keys management: list and revoke API keys`,
+		Run: func(cmd *cobra.Command, args []string) {
+			logUsage("keys [get | revoke | revoke-subject]")
+		},
+	}
+
+	cmdKeys := []cobra.Command{
+		getCmd,
+		revokeCmd,
+		revokeSubjectCmd,
+	}
+
+	for i := range cmdKeys {
+		cmd.AddCommand(&cmdKeys[i])
+	}
+
+	return &cmd
+}
@@ -3,7 +3,10 @@
 
 package cli
 
-import "github.com/spf13/cobra"
+import (
+	mfxsdk "github.com/mainflux/mainflux/pkg/sdk/go"
+	"github.com/spf13/cobra"
+)
 
 const contentTypeSenml = "application/senml+json"
 
@@ -36,7 +39,11 @@ var cmdMessages = []cobra.Command{
 				return
 			}
 
-			m, err := sdk.ReadMessages(args[0], args[1])
+			query := mfxsdk.MessagesQuery{
+				Offset: uint64(Offset),
+				Limit:  uint64(Limit),
+			}
+			m, err := sdk.ReadMessages(args[0], args[1], query)
 			if err != nil {
 				logError(err)
 				return
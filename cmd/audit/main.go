@@ -0,0 +1,294 @@
+// Copyright (c) Mainflux
+// SPDX-License-Identifier: Apache-2.0
+
+package main
+
+import (
+	"fmt"
+	"io"
+	"io/ioutil"
+	"log"
+	"net/http"
+	"os"
+	"os/signal"
+	"strconv"
+	"syscall"
+	"time"
+
+	kitprometheus "github.com/go-kit/kit/metrics/prometheus"
+	"github.com/go-redis/redis"
+	"github.com/jmoiron/sqlx"
+	"github.com/mainflux/mainflux"
+	"github.com/mainflux/mainflux/audit"
+	"github.com/mainflux/mainflux/audit/api"
+	"github.com/mainflux/mainflux/audit/postgres"
+	auditredis "github.com/mainflux/mainflux/audit/redis"
+	authapi "github.com/mainflux/mainflux/authn/api/grpc"
+	"github.com/mainflux/mainflux/logger"
+	uuidProvider "github.com/mainflux/mainflux/pkg/uuid"
+	opentracing "github.com/opentracing/opentracing-go"
+	stdprometheus "github.com/prometheus/client_golang/prometheus"
+	jconfig "github.com/uber/jaeger-client-go/config"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/credentials"
+)
+
+const (
+	defLogLevel       = "error"
+	defHTTPPort       = "8180"
+	defJaegerURL      = ""
+	defServerCert     = ""
+	defServerKey      = ""
+	defDBHost         = "localhost"
+	defDBPort         = "5432"
+	defDBUser         = "mainflux"
+	defDBPass         = "mainflux"
+	defDB             = "audit"
+	defDBSSLMode      = "disable"
+	defDBSSLCert      = ""
+	defDBSSLKey       = ""
+	defDBSSLRootCert  = ""
+	defClientTLS      = "false"
+	defCACerts        = ""
+	defESURL          = "localhost:6379"
+	defESPass         = ""
+	defESDB           = "0"
+	defESConsumerName = "audit"
+	defAuthnURL       = "localhost:8181"
+	defAuthnTimeout   = "1s"
+
+	envLogLevel       = "MF_AUDIT_LOG_LEVEL"
+	envHTTPPort       = "MF_AUDIT_HTTP_PORT"
+	envJaegerURL      = "MF_JAEGER_URL"
+	envServerCert     = "MF_AUDIT_SERVER_CERT"
+	envServerKey      = "MF_AUDIT_SERVER_KEY"
+	envDBHost         = "MF_AUDIT_DB_HOST"
+	envDBPort         = "MF_AUDIT_DB_PORT"
+	envDBUser         = "MF_AUDIT_DB_USER"
+	envDBPass         = "MF_AUDIT_DB_PASS"
+	envDB             = "MF_AUDIT_DB"
+	envDBSSLMode      = "MF_AUDIT_DB_SSL_MODE"
+	envDBSSLCert      = "MF_AUDIT_DB_SSL_CERT"
+	envDBSSLKey       = "MF_AUDIT_DB_SSL_KEY"
+	envDBSSLRootCert  = "MF_AUDIT_DB_SSL_ROOT_CERT"
+	envClientTLS      = "MF_AUDIT_CLIENT_TLS"
+	envCACerts        = "MF_AUDIT_CA_CERTS"
+	envESURL          = "MF_AUDIT_ES_URL"
+	envESPass         = "MF_AUDIT_ES_PASS"
+	envESDB           = "MF_AUDIT_ES_DB"
+	envESConsumerName = "MF_AUDIT_EVENT_CONSUMER"
+	envAuthnURL       = "MF_AUTHN_GRPC_URL"
+	envAuthnTimeout   = "MF_AUTHN_GRPC_TIMEOUT"
+)
+
+type config struct {
+	logLevel       string
+	httpPort       string
+	jaegerURL      string
+	serverCert     string
+	serverKey      string
+	dbConfig       postgres.Config
+	clientTLS      bool
+	caCerts        string
+	esURL          string
+	esPass         string
+	esDB           string
+	esConsumerName string
+	authnURL       string
+	authnTimeout   time.Duration
+}
+
+func main() {
+	cfg := loadConfig()
+
+	logger, err := logger.New(os.Stdout, cfg.logLevel)
+	if err != nil {
+		log.Fatalf(err.Error())
+	}
+
+	db := connectToDB(cfg.dbConfig, logger)
+	defer db.Close()
+
+	esClient := connectToRedis(cfg.esURL, cfg.esPass, cfg.esDB, logger)
+	defer esClient.Close()
+
+	authTracer, authCloser := initJaeger("auth", cfg.jaegerURL, logger)
+	defer authCloser.Close()
+
+	authConn := connectToAuth(cfg, logger)
+	defer authConn.Close()
+
+	auth := authapi.NewClient(authTracer, authConn, cfg.authnTimeout)
+
+	svc := newService(auth, db, logger)
+
+	errs := make(chan error, 2)
+	go subscribeToAuditES(svc, esClient, cfg.esConsumerName, logger)
+	go startHTTPServer(api.MakeHandler(svc), cfg, logger, errs)
+
+	go func() {
+		c := make(chan os.Signal, 1)
+		signal.Notify(c, syscall.SIGINT)
+		errs <- fmt.Errorf("%s", <-c)
+	}()
+
+	err = <-errs
+	logger.Error(fmt.Sprintf("Audit service terminated: %s", err))
+}
+
+func loadConfig() config {
+	tls, err := strconv.ParseBool(mainflux.Env(envClientTLS, defClientTLS))
+	if err != nil {
+		log.Fatalf("Invalid value passed for %s\n", envClientTLS)
+	}
+
+	authnTimeout, err := time.ParseDuration(mainflux.Env(envAuthnTimeout, defAuthnTimeout))
+	if err != nil {
+		log.Fatalf("Invalid %s value: %s", envAuthnTimeout, err.Error())
+	}
+
+	dbConfig := postgres.Config{
+		Host:        mainflux.Env(envDBHost, defDBHost),
+		Port:        mainflux.Env(envDBPort, defDBPort),
+		User:        mainflux.Env(envDBUser, defDBUser),
+		Pass:        mainflux.Env(envDBPass, defDBPass),
+		Name:        mainflux.Env(envDB, defDB),
+		SSLMode:     mainflux.Env(envDBSSLMode, defDBSSLMode),
+		SSLCert:     mainflux.Env(envDBSSLCert, defDBSSLCert),
+		SSLKey:      mainflux.Env(envDBSSLKey, defDBSSLKey),
+		SSLRootCert: mainflux.Env(envDBSSLRootCert, defDBSSLRootCert),
+	}
+
+	return config{
+		logLevel:       mainflux.Env(envLogLevel, defLogLevel),
+		httpPort:       mainflux.Env(envHTTPPort, defHTTPPort),
+		jaegerURL:      mainflux.Env(envJaegerURL, defJaegerURL),
+		serverCert:     mainflux.Env(envServerCert, defServerCert),
+		serverKey:      mainflux.Env(envServerKey, defServerKey),
+		dbConfig:       dbConfig,
+		clientTLS:      tls,
+		caCerts:        mainflux.Env(envCACerts, defCACerts),
+		esURL:          mainflux.Env(envESURL, defESURL),
+		esPass:         mainflux.Env(envESPass, defESPass),
+		esDB:           mainflux.Env(envESDB, defESDB),
+		esConsumerName: mainflux.Env(envESConsumerName, defESConsumerName),
+		authnURL:       mainflux.Env(envAuthnURL, defAuthnURL),
+		authnTimeout:   authnTimeout,
+	}
+}
+
+func connectToDB(cfg postgres.Config, logger logger.Logger) *sqlx.DB {
+	db, err := postgres.Connect(cfg)
+	if err != nil {
+		logger.Error(fmt.Sprintf("Failed to connect to postgres: %s", err))
+		os.Exit(1)
+	}
+	return db
+}
+
+func connectToRedis(redisURL, redisPass, redisDB string, logger logger.Logger) *redis.Client {
+	db, err := strconv.Atoi(redisDB)
+	if err != nil {
+		logger.Error(fmt.Sprintf("Failed to connect to redis: %s", err))
+		os.Exit(1)
+	}
+
+	return redis.NewClient(&redis.Options{
+		Addr:     redisURL,
+		Password: redisPass,
+		DB:       db,
+	})
+}
+
+func initJaeger(svcName, url string, logger logger.Logger) (opentracing.Tracer, io.Closer) {
+	if url == "" {
+		return opentracing.NoopTracer{}, ioutil.NopCloser(nil)
+	}
+
+	tracer, closer, err := jconfig.Configuration{
+		ServiceName: svcName,
+		Sampler: &jconfig.SamplerConfig{
+			Type:  "const",
+			Param: 1,
+		},
+		Reporter: &jconfig.ReporterConfig{
+			LocalAgentHostPort: url,
+			LogSpans:           true,
+		},
+	}.NewTracer()
+	if err != nil {
+		logger.Error(fmt.Sprintf("Failed to init Jaeger client: %s", err))
+		os.Exit(1)
+	}
+
+	return tracer, closer
+}
+
+func connectToAuth(cfg config, logger logger.Logger) *grpc.ClientConn {
+	var opts []grpc.DialOption
+	if cfg.clientTLS {
+		if cfg.caCerts != "" {
+			tpc, err := credentials.NewClientTLSFromFile(cfg.caCerts, "")
+			if err != nil {
+				logger.Error(fmt.Sprintf("Failed to create tls credentials: %s", err))
+				os.Exit(1)
+			}
+			opts = append(opts, grpc.WithTransportCredentials(tpc))
+		}
+	} else {
+		opts = append(opts, grpc.WithInsecure())
+		logger.Info("gRPC communication is not encrypted")
+	}
+
+	conn, err := grpc.Dial(cfg.authnURL, opts...)
+	if err != nil {
+		logger.Error(fmt.Sprintf("Failed to connect to authn service: %s", err))
+		os.Exit(1)
+	}
+
+	return conn
+}
+
+func newService(auth mainflux.AuthNServiceClient, db *sqlx.DB, logger logger.Logger) audit.Service {
+	eventRepo := postgres.NewEventRepository(db)
+
+	svc := audit.New(auth, eventRepo, uuidProvider.New())
+	svc = api.LoggingMiddleware(svc, logger)
+	svc = api.MetricsMiddleware(
+		svc,
+		kitprometheus.NewCounterFrom(stdprometheus.CounterOpts{
+			Namespace: "audit",
+			Subsystem: "api",
+			Name:      "request_count",
+			Help:      "Number of requests received.",
+		}, []string{"method"}),
+		kitprometheus.NewSummaryFrom(stdprometheus.SummaryOpts{
+			Namespace: "audit",
+			Subsystem: "api",
+			Name:      "request_latency_microseconds",
+			Help:      "Total duration of requests in microseconds.",
+		}, []string{"method"}),
+	)
+
+	return svc
+}
+
+func subscribeToAuditES(svc audit.Service, client *redis.Client, consumer string, logger logger.Logger) {
+	eventStore := auditredis.NewEventStore(svc, client, consumer, logger)
+	logger.Info("Subscribed to Redis Event Store")
+	if err := eventStore.Subscribe(); err != nil {
+		logger.Warn(fmt.Sprintf("Audit service failed to subscribe to event sourcing: %s", err))
+	}
+}
+
+func startHTTPServer(handler http.Handler, cfg config, logger logger.Logger, errs chan error) {
+	p := fmt.Sprintf(":%s", cfg.httpPort)
+	if cfg.serverCert != "" || cfg.serverKey != "" {
+		logger.Info(fmt.Sprintf("Audit service started using https on port %s with cert %s key %s",
+			cfg.httpPort, cfg.serverCert, cfg.serverKey))
+		errs <- http.ListenAndServeTLS(p, cfg.serverCert, cfg.serverKey, handler)
+		return
+	}
+	logger.Info(fmt.Sprintf("Audit service started using http on port %s", cfg.httpPort))
+	errs <- http.ListenAndServe(p, handler)
+}
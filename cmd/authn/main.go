@@ -9,9 +9,11 @@ import (
 	"net/http"
 	"os"
 	"os/signal"
+	"strconv"
 	"syscall"
 
 	kitprometheus "github.com/go-kit/kit/metrics/prometheus"
+	"github.com/go-redis/redis"
 	"github.com/jmoiron/sqlx"
 	"github.com/mainflux/mainflux"
 	"github.com/mainflux/mainflux/authn"
@@ -20,8 +22,10 @@ import (
 	httpapi "github.com/mainflux/mainflux/authn/api/http"
 	"github.com/mainflux/mainflux/authn/jwt"
 	"github.com/mainflux/mainflux/authn/postgres"
+	rediscache "github.com/mainflux/mainflux/authn/redis"
 	"github.com/mainflux/mainflux/authn/tracing"
 	"github.com/mainflux/mainflux/logger"
+	"github.com/mainflux/mainflux/pkg/audit"
 	uuidProvider "github.com/mainflux/mainflux/pkg/uuid"
 	"github.com/opentracing/opentracing-go"
 	stdprometheus "github.com/prometheus/client_golang/prometheus"
@@ -47,6 +51,16 @@ const (
 	defServerCert    = ""
 	defServerKey     = ""
 	defJaegerURL     = ""
+	defJWTKeysDir    = ""
+	defJWTKeyID      = ""
+	defJWTAcceptHMAC = "true"
+	defCacheURL      = ""
+	defCachePass     = ""
+	defCacheDB       = "0"
+	defAuditURL      = ""
+	defAuditPass     = ""
+	defAuditDB       = "0"
+	defAdminEmail    = ""
 
 	envLogLevel      = "MF_AUTHN_LOG_LEVEL"
 	envDBHost        = "MF_AUTHN_DB_HOST"
@@ -64,6 +78,16 @@ const (
 	envServerCert    = "MF_AUTHN_SERVER_CERT"
 	envServerKey     = "MF_AUTHN_SERVER_KEY"
 	envJaegerURL     = "MF_JAEGER_URL"
+	envJWTKeysDir    = "MF_AUTHN_JWT_KEYS_DIR"
+	envJWTKeyID      = "MF_AUTHN_JWT_KEY_ID"
+	envJWTAcceptHMAC = "MF_AUTHN_JWT_ACCEPT_HMAC"
+	envCacheURL      = "MF_AUTHN_CACHE_URL"
+	envCachePass     = "MF_AUTHN_CACHE_PASS"
+	envCacheDB       = "MF_AUTHN_CACHE_DB"
+	envAuditURL      = "MF_AUTHN_AUDIT_URL"
+	envAuditPass     = "MF_AUTHN_AUDIT_PASS"
+	envAuditDB       = "MF_AUTHN_AUDIT_DB"
+	envAdminEmail    = "MF_AUTHN_ADMIN_EMAIL"
 )
 
 type config struct {
@@ -76,6 +100,16 @@ type config struct {
 	serverKey  string
 	jaegerURL  string
 	resetURL   string
+	keysDir    string
+	keyID      string
+	acceptHMAC bool
+	cacheURL   string
+	cachePass  string
+	cacheDB    string
+	auditURL   string
+	auditPass  string
+	auditDB    string
+	adminEmail string
 }
 
 type tokenConfig struct {
@@ -91,6 +125,11 @@ func main() {
 		log.Fatalf(err.Error())
 	}
 
+	if err := audit.LoadTrustedProxies(); err != nil {
+		logger.Error(fmt.Sprintf("Failed to load trusted proxies: %s", err))
+		os.Exit(1)
+	}
+
 	db := connectToDB(cfg.dbConfig, logger)
 	defer db.Close()
 
@@ -100,7 +139,11 @@ func main() {
 	dbTracer, dbCloser := initJaeger("authn_db", cfg.jaegerURL, logger)
 	defer dbCloser.Close()
 
-	svc := newService(db, dbTracer, cfg.secret, logger)
+	cacheClient := connectToRedis(cfg.cacheURL, cfg.cachePass, cfg.cacheDB, logger)
+	auditClient := connectToRedis(cfg.auditURL, cfg.auditPass, cfg.auditDB, logger)
+
+	t := newTokenizer(cfg, logger)
+	svc := newService(db, cacheClient, auditClient, dbTracer, t, cfg.adminEmail, logger)
 	errs := make(chan error, 2)
 
 	go startHTTPServer(tracer, svc, cfg.httpPort, cfg.serverCert, cfg.serverKey, logger, errs)
@@ -117,6 +160,11 @@ func main() {
 }
 
 func loadConfig() config {
+	acceptHMAC, err := strconv.ParseBool(mainflux.Env(envJWTAcceptHMAC, defJWTAcceptHMAC))
+	if err != nil {
+		log.Fatalf("Invalid value passed for %s\n", envJWTAcceptHMAC)
+	}
+
 	dbConfig := postgres.Config{
 		Host:        mainflux.Env(envDBHost, defDBHost),
 		Port:        mainflux.Env(envDBPort, defDBPort),
@@ -138,6 +186,16 @@ func loadConfig() config {
 		serverCert: mainflux.Env(envServerCert, defServerCert),
 		serverKey:  mainflux.Env(envServerKey, defServerKey),
 		jaegerURL:  mainflux.Env(envJaegerURL, defJaegerURL),
+		keysDir:    mainflux.Env(envJWTKeysDir, defJWTKeysDir),
+		keyID:      mainflux.Env(envJWTKeyID, defJWTKeyID),
+		acceptHMAC: acceptHMAC,
+		cacheURL:   mainflux.Env(envCacheURL, defCacheURL),
+		cachePass:  mainflux.Env(envCachePass, defCachePass),
+		cacheDB:    mainflux.Env(envCacheDB, defCacheDB),
+		auditURL:   mainflux.Env(envAuditURL, defAuditURL),
+		auditPass:  mainflux.Env(envAuditPass, defAuditPass),
+		auditDB:    mainflux.Env(envAuditDB, defAuditDB),
+		adminEmail: mainflux.Env(envAdminEmail, defAdminEmail),
 	}
 
 }
@@ -175,13 +233,70 @@ func connectToDB(dbConfig postgres.Config, logger logger.Logger) *sqlx.DB {
 	return db
 }
 
-func newService(db *sqlx.DB, tracer opentracing.Tracer, secret string, logger logger.Logger) authn.Service {
+// connectToRedis connects to the revocation cache or to the audit events
+// stream. Empty URL disables the Redis, in which case nil client is
+// returned.
+func connectToRedis(redisURL, redisPass, redisDB string, logger logger.Logger) *redis.Client {
+	if redisURL == "" {
+		return nil
+	}
+
+	db, err := strconv.Atoi(redisDB)
+	if err != nil {
+		logger.Error(fmt.Sprintf("Failed to connect to redis: %s", err))
+		os.Exit(1)
+	}
+
+	return redis.NewClient(&redis.Options{
+		Addr:     redisURL,
+		Password: redisPass,
+		DB:       db,
+	})
+}
+
+func newTokenizer(cfg config, logger logger.Logger) authn.Tokenizer {
+	if cfg.keysDir == "" {
+		return jwt.New(cfg.secret)
+	}
+
+	keys, err := jwt.LoadKeys(cfg.keysDir)
+	if err != nil {
+		logger.Error(fmt.Sprintf("Failed to load JWT signing keys: %s", err))
+		os.Exit(1)
+	}
+
+	// Empty secret disables verification of HMAC signed tokens.
+	secret := ""
+	if cfg.acceptHMAC {
+		secret = cfg.secret
+	}
+
+	t, err := jwt.NewAsymmetric(keys, cfg.keyID, secret)
+	if err != nil {
+		logger.Error(fmt.Sprintf("Failed to create JWT tokenizer: %s", err))
+		os.Exit(1)
+	}
+
+	return t
+}
+
+func newService(db *sqlx.DB, cacheClient, auditClient *redis.Client, tracer opentracing.Tracer, t authn.Tokenizer, admin string, logger logger.Logger) authn.Service {
 	database := postgres.NewDatabase(db)
 	repo := tracing.New(postgres.New(database), tracer)
 
+	revocations := postgres.NewRevocationRepository(database)
+	if cacheClient != nil {
+		revocations = rediscache.NewRevocationRepository(cacheClient, revocations)
+	}
+	revocations = tracing.NewRevocationRepository(revocations, tracer)
+	refresh := tracing.NewRefreshRepository(postgres.NewRefreshRepository(database), tracer)
+
 	up := uuidProvider.New()
-	t := jwt.New(secret)
-	svc := authn.New(repo, up, t)
+	svc := authn.New(repo, revocations, refresh, up, t, admin)
+	if auditClient != nil {
+		rec := audit.NewRecorder("authn", api.NewIdentifier(svc), audit.NewPublisher(auditClient), logger)
+		svc = api.AuditMiddleware(svc, rec)
+	}
 	svc = api.LoggingMiddleware(svc, logger)
 	svc = api.MetricsMiddleware(
 		svc,
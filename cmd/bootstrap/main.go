@@ -30,7 +30,9 @@ import (
 	api "github.com/mainflux/mainflux/bootstrap/api"
 	"github.com/mainflux/mainflux/bootstrap/postgres"
 	mflog "github.com/mainflux/mainflux/logger"
+	"github.com/mainflux/mainflux/pkg/audit"
 	mfsdk "github.com/mainflux/mainflux/pkg/sdk/go"
+	usersapi "github.com/mainflux/mainflux/users/api/grpc"
 	stdprometheus "github.com/prometheus/client_golang/prometheus"
 	jconfig "github.com/uber/jaeger-client-go/config"
 	"google.golang.org/grpc"
@@ -66,6 +68,11 @@ const (
 	defJaegerURL      = ""
 	defAuthnURL       = "localhost:8181"
 	defAuthnTimeout   = "1s"
+	defUsersURL       = "localhost:8181"
+	defUsersTimeout   = "1s"
+	defAuditURL       = ""
+	defAuditPass      = ""
+	defAuditDB        = "0"
 
 	envLogLevel       = "MF_BOOTSTRAP_LOG_LEVEL"
 	envDBHost         = "MF_BOOTSTRAP_DB_HOST"
@@ -95,6 +102,11 @@ const (
 	envJaegerURL      = "MF_JAEGER_URL"
 	envAuthnURL       = "MF_AUTHN_GRPC_URL"
 	envAuthnTimeout   = "MF_AUTHN_GRPC_TIMEOUT"
+	envUsersURL       = "MF_USERS_GRPC_URL"
+	envUsersTimeout   = "MF_USERS_GRPC_TIMEOUT"
+	envAuditURL       = "MF_BOOTSTRAP_AUDIT_URL"
+	envAuditPass      = "MF_BOOTSTRAP_AUDIT_PASS"
+	envAuditDB        = "MF_BOOTSTRAP_AUDIT_DB"
 )
 
 type config struct {
@@ -118,6 +130,11 @@ type config struct {
 	jaegerURL      string
 	authnURL       string
 	authnTimeout   time.Duration
+	usersURL       string
+	usersTimeout   time.Duration
+	auditURL       string
+	auditPass      string
+	auditDB        string
 }
 
 func main() {
@@ -140,12 +157,27 @@ func main() {
 	authTracer, authCloser := initJaeger("auth", cfg.jaegerURL, logger)
 	defer authCloser.Close()
 
-	authConn := connectToAuth(cfg, logger)
+	authConn := connectToGRPC(cfg, "authn", cfg.authnURL, logger)
 	defer authConn.Close()
 
 	auth := authapi.NewClient(authTracer, authConn, cfg.authnTimeout)
 
-	svc := newService(auth, db, logger, esClient, cfg)
+	usersTracer, usersCloser := initJaeger("users", cfg.jaegerURL, logger)
+	defer usersCloser.Close()
+
+	usersConn := connectToGRPC(cfg, "users", cfg.usersURL, logger)
+	defer usersConn.Close()
+
+	users := usersapi.NewClient(usersConn, usersTracer, cfg.usersTimeout)
+
+	// Auditing is disabled unless the audit events stream is configured.
+	var auditClient *r.Client
+	if cfg.auditURL != "" {
+		auditClient = connectToRedis(cfg.auditURL, cfg.auditPass, cfg.auditDB, logger)
+		defer auditClient.Close()
+	}
+
+	svc := newService(auth, users, db, logger, esClient, auditClient, cfg)
 	errs := make(chan error, 2)
 
 	go startHTTPServer(svc, cfg, logger, errs)
@@ -182,6 +214,10 @@ func loadConfig() config {
 	if err != nil {
 		log.Fatalf("Invalid %s value: %s", envAuthnTimeout, err.Error())
 	}
+	usersTimeout, err := time.ParseDuration(mainflux.Env(envUsersTimeout, defUsersTimeout))
+	if err != nil {
+		log.Fatalf("Invalid %s value: %s", envUsersTimeout, err.Error())
+	}
 	encKey, err := hex.DecodeString(mainflux.Env(envEncryptKey, defEncryptKey))
 	if err != nil {
 		log.Fatalf("Invalid %s value: %s", envEncryptKey, err.Error())
@@ -214,6 +250,11 @@ func loadConfig() config {
 		jaegerURL:      mainflux.Env(envJaegerURL, defJaegerURL),
 		authnURL:       mainflux.Env(envAuthnURL, defAuthnURL),
 		authnTimeout:   authnTimeout,
+		usersURL:       mainflux.Env(envUsersURL, defUsersURL),
+		usersTimeout:   usersTimeout,
+		auditURL:       mainflux.Env(envAuditURL, defAuditURL),
+		auditPass:      mainflux.Env(envAuditPass, defAuditPass),
+		auditDB:        mainflux.Env(envAuditDB, defAuditDB),
 	}
 }
 
@@ -264,7 +305,7 @@ func initJaeger(svcName, url string, logger logger.Logger) (opentracing.Tracer,
 	return tracer, closer
 }
 
-func newService(auth mainflux.AuthNServiceClient, db *sqlx.DB, logger mflog.Logger, esClient *r.Client, cfg config) bootstrap.Service {
+func newService(auth mainflux.AuthNServiceClient, users mainflux.UsersServiceClient, db *sqlx.DB, logger mflog.Logger, esClient, auditClient *r.Client, cfg config) bootstrap.Service {
 	thingsRepo := postgres.NewConfigRepository(db, logger)
 
 	config := mfsdk.Config{
@@ -274,8 +315,12 @@ func newService(auth mainflux.AuthNServiceClient, db *sqlx.DB, logger mflog.Logg
 
 	sdk := mfsdk.NewSDK(config)
 
-	svc := bootstrap.New(auth, thingsRepo, sdk, cfg.encKey)
+	svc := bootstrap.New(auth, users, thingsRepo, sdk, cfg.encKey)
 	svc = redisprod.NewEventStoreMiddleware(svc, esClient)
+	if auditClient != nil {
+		rec := audit.NewRecorder("bootstrap", audit.NewIdentifier(auth), audit.NewPublisher(auditClient), logger)
+		svc = api.AuditMiddleware(svc, rec)
+	}
 	svc = api.NewLoggingMiddleware(svc, logger)
 	svc = api.MetricsMiddleware(
 		svc,
@@ -295,7 +340,7 @@ func newService(auth mainflux.AuthNServiceClient, db *sqlx.DB, logger mflog.Logg
 	return svc
 }
 
-func connectToAuth(cfg config, logger logger.Logger) *grpc.ClientConn {
+func connectToGRPC(cfg config, svcName, url string, logger logger.Logger) *grpc.ClientConn {
 	var opts []grpc.DialOption
 	if cfg.clientTLS {
 		if cfg.caCerts != "" {
@@ -311,9 +356,9 @@ func connectToAuth(cfg config, logger logger.Logger) *grpc.ClientConn {
 		logger.Info("gRPC communication is not encrypted")
 	}
 
-	conn, err := grpc.Dial(cfg.authnURL, opts...)
+	conn, err := grpc.Dial(url, opts...)
 	if err != nil {
-		logger.Error(fmt.Sprintf("Failed to connect to authn service: %s", err))
+		logger.Error(fmt.Sprintf("Failed to connect to %s service: %s", svcName, err))
 		os.Exit(1)
 	}
 
@@ -19,7 +19,9 @@ import (
 	kitprometheus "github.com/go-kit/kit/metrics/prometheus"
 	"github.com/gocql/gocql"
 	"github.com/mainflux/mainflux"
+	authapi "github.com/mainflux/mainflux/authn/api/grpc"
 	"github.com/mainflux/mainflux/logger"
+	"github.com/mainflux/mainflux/pkg/encryption"
 	"github.com/mainflux/mainflux/readers"
 	"github.com/mainflux/mainflux/readers/api"
 	"github.com/mainflux/mainflux/readers/cassandra"
@@ -48,6 +50,8 @@ const (
 	defJaegerURL         = ""
 	defThingsAuthURL     = "localhost:8181"
 	defThingsAuthTimeout = "1s"
+	defAuthnURL          = ""
+	defAuthnTimeout      = "1s"
 
 	envLogLevel          = "MF_CASSANDRA_READER_LOG_LEVEL"
 	envPort              = "MF_CASSANDRA_READER_PORT"
@@ -63,6 +67,8 @@ const (
 	envJaegerURL         = "MF_JAEGER_URL"
 	envThingsAuthURL     = "MF_THINGS_AUTH_GRPC_URL"
 	envThingsAuthTimeout = "MF_THINGS_AUTH_GRPC_TIMEOUT"
+	envAuthnURL          = "MF_AUTHN_GRPC_URL"
+	envAuthnTimeout      = "MF_AUTHN_GRPC_TIMEOUT"
 )
 
 type config struct {
@@ -76,6 +82,9 @@ type config struct {
 	jaegerURL         string
 	thingsAuthURL     string
 	thingsAuthTimeout time.Duration
+	authnURL          string
+	authnTimeout      time.Duration
+	encryption        encryption.Config
 }
 
 func main() {
@@ -89,18 +98,38 @@ func main() {
 	session := connectToCassandra(cfg.dbCfg, logger)
 	defer session.Close()
 
-	conn := connectToThings(cfg, logger)
+	conn := connectToGRPC(cfg.thingsAuthURL, cfg, logger)
 	defer conn.Close()
 
 	thingsTracer, thingsCloser := initJaeger("things", cfg.jaegerURL, logger)
 	defer thingsCloser.Close()
 
 	tc := thingsapi.NewClient(conn, thingsTracer, cfg.thingsAuthTimeout)
+
+	// User and API keys are accepted only if authn service is configured.
+	var ac mainflux.AuthNServiceClient
+	if cfg.authnURL != "" {
+		authnConn := connectToGRPC(cfg.authnURL, cfg, logger)
+		defer authnConn.Close()
+
+		authnTracer, authnCloser := initJaeger("authn", cfg.jaegerURL, logger)
+		defer authnCloser.Close()
+
+		ac = authapi.NewClient(authnTracer, authnConn, cfg.authnTimeout)
+	}
 	repo := newService(session, logger)
+	if cfg.encryption.Enabled() {
+		cipher, err := encryption.NewCipher(cfg.encryption)
+		if err != nil {
+			logger.Error(fmt.Sprintf("Failed to create message cipher: %s", err))
+			os.Exit(1)
+		}
+		repo = readers.DecryptionMiddleware(repo, cipher)
+	}
 
 	errs := make(chan error, 2)
 
-	go startHTTPServer(repo, tc, cfg, errs, logger)
+	go startHTTPServer(repo, tc, ac, cfg, errs, logger)
 
 	go func() {
 		c := make(chan os.Signal)
@@ -136,6 +165,16 @@ func loadConfig() config {
 		log.Fatalf("Invalid %s value: %s", envThingsAuthTimeout, err.Error())
 	}
 
+	authnTimeout, err := time.ParseDuration(mainflux.Env(envAuthnTimeout, defAuthnTimeout))
+	if err != nil {
+		log.Fatalf("Invalid %s value: %s", envAuthnTimeout, err.Error())
+	}
+
+	encCfg, err := encryption.LoadConfig()
+	if err != nil {
+		log.Fatalf(err.Error())
+	}
+
 	return config{
 		logLevel:          mainflux.Env(envLogLevel, defLogLevel),
 		port:              mainflux.Env(envPort, defPort),
@@ -147,6 +186,9 @@ func loadConfig() config {
 		jaegerURL:         mainflux.Env(envJaegerURL, defJaegerURL),
 		thingsAuthURL:     mainflux.Env(envThingsAuthURL, defThingsAuthURL),
 		thingsAuthTimeout: authTimeout,
+		authnURL:          mainflux.Env(envAuthnURL, defAuthnURL),
+		authnTimeout:      authnTimeout,
+		encryption:        encCfg,
 	}
 }
 
@@ -160,7 +202,7 @@ func connectToCassandra(dbCfg cassandra.DBConfig, logger logger.Logger) *gocql.S
 	return session
 }
 
-func connectToThings(cfg config, logger logger.Logger) *grpc.ClientConn {
+func connectToGRPC(url string, cfg config, logger logger.Logger) *grpc.ClientConn {
 	var opts []grpc.DialOption
 	if cfg.clientTLS {
 		if cfg.caCerts != "" {
@@ -176,9 +218,9 @@ func connectToThings(cfg config, logger logger.Logger) *grpc.ClientConn {
 		opts = append(opts, grpc.WithInsecure())
 	}
 
-	conn, err := grpc.Dial(cfg.thingsAuthURL, opts...)
+	conn, err := grpc.Dial(url, opts...)
 	if err != nil {
-		logger.Error(fmt.Sprintf("Failed to connect to things service: %s", err))
+		logger.Error(fmt.Sprintf("Failed to connect to %s: %s", url, err))
 		os.Exit(1)
 	}
 	return conn
@@ -230,14 +272,14 @@ func newService(session *gocql.Session, logger logger.Logger) readers.MessageRep
 	return repo
 }
 
-func startHTTPServer(repo readers.MessageRepository, tc mainflux.ThingsServiceClient, cfg config, errs chan error, logger logger.Logger) {
+func startHTTPServer(repo readers.MessageRepository, tc mainflux.ThingsServiceClient, ac mainflux.AuthNServiceClient, cfg config, errs chan error, logger logger.Logger) {
 	p := fmt.Sprintf(":%s", cfg.port)
 	if cfg.serverCert != "" || cfg.serverKey != "" {
 		logger.Info(fmt.Sprintf("Cassandra reader service started using https on port %s with cert %s key %s",
 			cfg.port, cfg.serverCert, cfg.serverKey))
-		errs <- http.ListenAndServeTLS(p, cfg.serverCert, cfg.serverKey, api.MakeHandler(repo, tc, "cassandra-reader"))
+		errs <- http.ListenAndServeTLS(p, cfg.serverCert, cfg.serverKey, api.MakeHandler(repo, tc, ac, "cassandra-reader"))
 		return
 	}
 	logger.Info(fmt.Sprintf("Cassandra reader service started, exposed port %s", cfg.port))
-	errs <- http.ListenAndServe(p, api.MakeHandler(repo, tc, "cassandra-reader"))
+	errs <- http.ListenAndServe(p, api.MakeHandler(repo, tc, ac, "cassandra-reader"))
 }
@@ -17,7 +17,8 @@ import (
 	"github.com/gocql/gocql"
 	"github.com/mainflux/mainflux"
 	"github.com/mainflux/mainflux/logger"
-	"github.com/mainflux/mainflux/pkg/messaging/nats"
+	"github.com/mainflux/mainflux/pkg/encryption"
+	"github.com/mainflux/mainflux/pkg/messaging/jetstream"
 	"github.com/mainflux/mainflux/pkg/transformers/senml"
 	"github.com/mainflux/mainflux/writers"
 	"github.com/mainflux/mainflux/writers/api"
@@ -54,11 +55,15 @@ const (
 
 type config struct {
 	natsURL         string
+	jetStream       bool
+	jsConfig        jetstream.Config
 	logLevel        string
 	port            string
 	subjectsCfgPath string
 	contentType     string
 	dbCfg           cassandra.DBConfig
+	encryption      encryption.Config
+	writer          writers.Config
 }
 
 func main() {
@@ -69,9 +74,9 @@ func main() {
 		log.Fatalf(err.Error())
 	}
 
-	pubSub, err := nats.NewPubSub(cfg.natsURL, "", logger)
+	pubSub, err := jetstream.Connect(cfg.natsURL, svcName, cfg.jetStream, cfg.jsConfig, logger)
 	if err != nil {
-		logger.Error(fmt.Sprintf("Failed to connect to NATS: %s", err))
+		logger.Error(err.Error())
 		os.Exit(1)
 	}
 	defer pubSub.Close()
@@ -80,8 +85,16 @@ func main() {
 	defer session.Close()
 
 	repo := newService(session, logger)
+	if cfg.encryption.Enabled() {
+		cipher, err := encryption.NewCipher(cfg.encryption)
+		if err != nil {
+			logger.Error(fmt.Sprintf("Failed to create message cipher: %s", err))
+			os.Exit(1)
+		}
+		repo = writers.EncryptionMiddleware(repo, cipher)
+	}
 	st := senml.New(cfg.contentType)
-	if err := writers.Start(pubSub, repo, st, svcName, cfg.subjectsCfgPath, logger); err != nil {
+	if err := writers.Start(pubSub, repo, st, svcName, cfg.subjectsCfgPath, cfg.writer, logger); err != nil {
 		logger.Error(fmt.Sprintf("Failed to create Cassandra writer: %s", err))
 	}
 
@@ -113,13 +126,32 @@ func loadConfig() config {
 		Port:     dbPort,
 	}
 
+	jetStream, jsConfig, err := jetstream.LoadConfig()
+	if err != nil {
+		log.Fatalf(err.Error())
+	}
+
+	wcfg, err := writers.LoadConfig("MF_CASSANDRA_WRITER", "cassandra", jsConfig.AckWait)
+	if err != nil {
+		log.Fatalf(err.Error())
+	}
+
+	encCfg, err := encryption.LoadConfig()
+	if err != nil {
+		log.Fatalf(err.Error())
+	}
+
 	return config{
 		natsURL:         mainflux.Env(envNatsURL, defNatsURL),
+		jetStream:       jetStream,
+		jsConfig:        jsConfig,
 		logLevel:        mainflux.Env(envLogLevel, defLogLevel),
 		port:            mainflux.Env(envPort, defPort),
 		subjectsCfgPath: mainflux.Env(envSubjectsCfgPath, defSubjectsCfgPath),
 		contentType:     mainflux.Env(envContentType, defContentType),
 		dbCfg:           dbCfg,
+		encryption:      encCfg,
+		writer:          wcfg,
 	}
 }
 
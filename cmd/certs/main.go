@@ -25,6 +25,7 @@ import (
 	vault "github.com/mainflux/mainflux/certs/pki"
 	"github.com/mainflux/mainflux/certs/postgres"
 	"github.com/mainflux/mainflux/logger"
+	usersapi "github.com/mainflux/mainflux/users/api/grpc"
 	"github.com/opentracing/opentracing-go"
 	stdprometheus "github.com/prometheus/client_golang/prometheus"
 	"google.golang.org/grpc"
@@ -32,6 +33,7 @@ import (
 
 	"github.com/jmoiron/sqlx"
 	mflog "github.com/mainflux/mainflux/logger"
+	"github.com/mainflux/mainflux/pkg/audit"
 	"github.com/mainflux/mainflux/pkg/errors"
 	mfsdk "github.com/mainflux/mainflux/pkg/sdk/go"
 	jconfig "github.com/uber/jaeger-client-go/config"
@@ -58,6 +60,11 @@ const (
 	defJaegerURL     = ""
 	defAuthnURL      = "localhost:8181"
 	defAuthnTimeout  = "1s"
+	defUsersURL      = "localhost:8181"
+	defUsersTimeout  = "1s"
+	defAuditURL      = ""
+	defAuditPass     = ""
+	defAuditDB       = "0"
 
 	defSignCAPath     = "ca.crt"
 	defSignCAKeyPath  = "ca.key"
@@ -90,6 +97,11 @@ const (
 	envJaegerURL     = "MF_JAEGER_URL"
 	envAuthnURL      = "MF_AUTHN_GRPC_URL"
 	envAuthnTimeout  = "MF_AUTHN_GRPC_TIMEOUT"
+	envUsersURL      = "MF_USERS_GRPC_URL"
+	envUsersTimeout  = "MF_USERS_GRPC_TIMEOUT"
+	envAuditURL      = "MF_CERTS_AUDIT_URL"
+	envAuditPass     = "MF_CERTS_AUDIT_PASS"
+	envAuditDB       = "MF_CERTS_AUDIT_DB"
 
 	envSignCAPath     = "MF_CERTS_SIGN_CA_PATH"
 	envSignCAKey      = "MF_CERTS_SIGN_CA_KEY_PATH"
@@ -127,6 +139,11 @@ type config struct {
 	jaegerURL    string
 	authnURL     string
 	authnTimeout time.Duration
+	usersURL     string
+	usersTimeout time.Duration
+	auditURL     string
+	auditPass    string
+	auditDB      string
 	// Sign and issue certificates
 	// without 3rd party PKI
 	signCAPath     string
@@ -148,6 +165,11 @@ func main() {
 		log.Fatalf(err.Error())
 	}
 
+	if err := audit.LoadTrustedProxies(); err != nil {
+		logger.Error(fmt.Sprintf("Failed to load trusted proxies: %s", err))
+		os.Exit(1)
+	}
+
 	tlsCert, caCert, err := loadCertificates(cfg)
 	if err != nil {
 		logger.Error("Failed to load CA certificates for issuing client certs")
@@ -164,12 +186,27 @@ func main() {
 	authTracer, authCloser := initJaeger("auth", cfg.jaegerURL, logger)
 	defer authCloser.Close()
 
-	authConn := connectToAuth(cfg, logger)
+	authConn := connectToGRPC(cfg, "authn", cfg.authnURL, logger)
 	defer authConn.Close()
 
 	auth := authapi.NewClient(authTracer, authConn, cfg.authnTimeout)
 
-	svc := newService(auth, db, logger, nil, tlsCert, caCert, cfg, pkiClient)
+	usersTracer, usersCloser := initJaeger("users", cfg.jaegerURL, logger)
+	defer usersCloser.Close()
+
+	usersConn := connectToGRPC(cfg, "users", cfg.usersURL, logger)
+	defer usersConn.Close()
+
+	users := usersapi.NewClient(usersConn, usersTracer, cfg.usersTimeout)
+
+	// Auditing is disabled unless the audit events stream is configured.
+	var auditClient *redis.Client
+	if cfg.auditURL != "" {
+		auditClient = connectToRedis(cfg.auditURL, cfg.auditPass, cfg.auditDB, logger)
+		defer auditClient.Close()
+	}
+
+	svc := newService(auth, users, db, logger, auditClient, tlsCert, caCert, cfg, pkiClient)
 	errs := make(chan error, 2)
 
 	go startHTTPServer(svc, cfg, logger, errs)
@@ -206,6 +243,11 @@ func loadConfig() config {
 		log.Fatalf("Invalid %s value: %s", envAuthnTimeout, err.Error())
 	}
 
+	usersTimeout, err := time.ParseDuration(mainflux.Env(envUsersTimeout, defUsersTimeout))
+	if err != nil {
+		log.Fatalf("Invalid %s value: %s", envUsersTimeout, err.Error())
+	}
+
 	signRSABits, err := strconv.Atoi(mainflux.Env(envSignRSABits, defSignRSABits))
 	if err != nil {
 		log.Fatalf("Invalid %s value: %s", envSignRSABits, err.Error())
@@ -224,6 +266,11 @@ func loadConfig() config {
 		jaegerURL:    mainflux.Env(envJaegerURL, defJaegerURL),
 		authnURL:     mainflux.Env(envAuthnURL, defAuthnURL),
 		authnTimeout: authnTimeout,
+		usersURL:     mainflux.Env(envUsersURL, defUsersURL),
+		usersTimeout: usersTimeout,
+		auditURL:     mainflux.Env(envAuditURL, defAuditURL),
+		auditPass:    mainflux.Env(envAuditPass, defAuditPass),
+		auditDB:      mainflux.Env(envAuditDB, defAuditDB),
 
 		signCAKeyPath:  mainflux.Env(envSignCAKey, defSignCAKeyPath),
 		signCAPath:     mainflux.Env(envSignCAPath, defSignCAPath),
@@ -261,7 +308,7 @@ func connectToDB(dbConfig postgres.Config, logger logger.Logger) *sqlx.DB {
 	return db
 }
 
-func connectToAuth(cfg config, logger logger.Logger) *grpc.ClientConn {
+func connectToGRPC(cfg config, svcName, url string, logger logger.Logger) *grpc.ClientConn {
 	var opts []grpc.DialOption
 	if cfg.clientTLS {
 		if cfg.caCerts != "" {
@@ -277,9 +324,9 @@ func connectToAuth(cfg config, logger logger.Logger) *grpc.ClientConn {
 		logger.Info("gRPC communication is not encrypted")
 	}
 
-	conn, err := grpc.Dial(cfg.authnURL, opts...)
+	conn, err := grpc.Dial(url, opts...)
 	if err != nil {
-		logger.Error(fmt.Sprintf("Failed to connect to authn service: %s", err))
+		logger.Error(fmt.Sprintf("Failed to connect to %s service: %s", svcName, err))
 		os.Exit(1)
 	}
 
@@ -310,7 +357,7 @@ func initJaeger(svcName, url string, logger logger.Logger) (opentracing.Tracer,
 	return tracer, closer
 }
 
-func newService(auth mainflux.AuthNServiceClient, db *sqlx.DB, logger mflog.Logger, esClient *redis.Client, tlsCert tls.Certificate, x509Cert *x509.Certificate, cfg config, pkiAgent vault.Agent) certs.Service {
+func newService(auth mainflux.AuthNServiceClient, users mainflux.UsersServiceClient, db *sqlx.DB, logger mflog.Logger, auditClient *redis.Client, tlsCert tls.Certificate, x509Cert *x509.Certificate, cfg config, pkiAgent vault.Agent) certs.Service {
 	certsRepo := postgres.NewRepository(db, logger)
 
 	certsConfig := certs.Config{
@@ -342,7 +389,11 @@ func newService(auth mainflux.AuthNServiceClient, db *sqlx.DB, logger mflog.Logg
 
 	sdk := mfsdk.NewSDK(config)
 
-	svc := certs.New(auth, certsRepo, sdk, certsConfig, pkiAgent)
+	svc := certs.New(auth, users, certsRepo, sdk, certsConfig, pkiAgent)
+	if auditClient != nil {
+		rec := audit.NewRecorder("certs", audit.NewIdentifier(auth), audit.NewPublisher(auditClient), logger)
+		svc = api.AuditMiddleware(svc, rec)
+	}
 	svc = api.NewLoggingMiddleware(svc, logger)
 	svc = api.MetricsMiddleware(
 		svc,
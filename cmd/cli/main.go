@@ -18,6 +18,8 @@ func main() {
 		ReaderURL:         "http://localhost:8905",
 		BootstrapURL:      "http://localhost:8202",
 		CertsURL:          "http://localhost:8204",
+		AuthnURL:          "http://localhost:8189",
+		AuditURL:          "http://localhost:9024",
 		ReaderPrefix:      "",
 		UsersPrefix:       "",
 		GroupsPrefix:      "",
@@ -50,6 +52,8 @@ func main() {
 	provisionCmd := cli.NewProvisionCmd()
 	bootstrapCmd := cli.NewBootstrapCmd()
 	certsCmd := cli.NewCertsCmd()
+	keysCmd := cli.NewKeysCmd()
+	auditCmd := cli.NewAuditCmd()
 
 	// Root Commands
 	rootCmd.AddCommand(versionCmd)
@@ -61,6 +65,8 @@ func main() {
 	rootCmd.AddCommand(provisionCmd)
 	rootCmd.AddCommand(bootstrapCmd)
 	rootCmd.AddCommand(certsCmd)
+	rootCmd.AddCommand(keysCmd)
+	rootCmd.AddCommand(auditCmd)
 
 	// Root Flags
 	rootCmd.PersistentFlags().StringVarP(
@@ -20,6 +20,9 @@ import (
 	"github.com/mainflux/mainflux/coap"
 	"github.com/mainflux/mainflux/coap/api"
 	logger "github.com/mainflux/mainflux/logger"
+	"github.com/mainflux/mainflux/pkg/messaging"
+	"github.com/mainflux/mainflux/pkg/messaging/nats"
+	"github.com/mainflux/mainflux/pkg/schema"
 	thingsapi "github.com/mainflux/mainflux/things/api/auth/grpc"
 	broker "github.com/nats-io/nats.go"
 	opentracing "github.com/opentracing/opentracing-go"
@@ -39,6 +42,8 @@ const (
 	defJaegerURL         = ""
 	defThingsAuthURL     = "localhost:8181"
 	defThingsAuthTimeout = "1s"
+	defSchemaCacheTTL    = "1m"
+	defSchemaErrors      = ""
 
 	envPort              = "MF_COAP_ADAPTER_PORT"
 	envNatsURL           = "MF_NATS_URL"
@@ -48,17 +53,21 @@ const (
 	envJaegerURL         = "MF_JAEGER_URL"
 	envThingsAuthURL     = "MF_THINGS_AUTH_GRPC_URL"
 	envThingsAuthTimeout = "MF_THINGS_AUTH_GRPC_TIMEOUT"
+	envSchemaCacheTTL    = "MF_SCHEMA_CACHE_TTL"
+	envSchemaErrors      = "MF_SCHEMA_ERRORS_SUBJECT"
 )
 
 type config struct {
-	port              string
-	natsURL           string
-	logLevel          string
-	clientTLS         bool
-	caCerts           string
-	jaegerURL         string
-	thingsAuthURL     string
-	thingsAuthTimeout time.Duration
+	port                string
+	natsURL             string
+	logLevel            string
+	clientTLS           bool
+	caCerts             string
+	jaegerURL           string
+	thingsAuthURL       string
+	thingsAuthTimeout   time.Duration
+	schemaCacheTTL      time.Duration
+	schemaErrorsSubject string
 }
 
 func main() {
@@ -83,7 +92,13 @@ func main() {
 	}
 	defer nc.Close()
 
-	svc := coap.New(tc, nc)
+	pub, err := nats.NewPublisher(cfg.natsURL)
+	if err != nil {
+		log.Fatalf(err.Error())
+	}
+	defer pub.Close()
+
+	svc := coap.New(tc, nc, newSchemaPublisher(pub, tc, cfg, logger))
 
 	svc = api.LoggingMiddleware(svc, logger)
 
@@ -129,15 +144,22 @@ func loadConfig() config {
 		log.Fatalf("Invalid %s value: %s", envThingsAuthTimeout, err.Error())
 	}
 
+	schemaCacheTTL, err := time.ParseDuration(mainflux.Env(envSchemaCacheTTL, defSchemaCacheTTL))
+	if err != nil {
+		log.Fatalf("Invalid %s value: %s", envSchemaCacheTTL, err.Error())
+	}
+
 	return config{
-		natsURL:           mainflux.Env(envNatsURL, defNatsURL),
-		port:              mainflux.Env(envPort, defPort),
-		logLevel:          mainflux.Env(envLogLevel, defLogLevel),
-		clientTLS:         tls,
-		caCerts:           mainflux.Env(envCACerts, defCACerts),
-		jaegerURL:         mainflux.Env(envJaegerURL, defJaegerURL),
-		thingsAuthURL:     mainflux.Env(envThingsAuthURL, defThingsAuthURL),
-		thingsAuthTimeout: authTimeout,
+		natsURL:             mainflux.Env(envNatsURL, defNatsURL),
+		port:                mainflux.Env(envPort, defPort),
+		logLevel:            mainflux.Env(envLogLevel, defLogLevel),
+		clientTLS:           tls,
+		caCerts:             mainflux.Env(envCACerts, defCACerts),
+		jaegerURL:           mainflux.Env(envJaegerURL, defJaegerURL),
+		thingsAuthURL:       mainflux.Env(envThingsAuthURL, defThingsAuthURL),
+		thingsAuthTimeout:   authTimeout,
+		schemaCacheTTL:      schemaCacheTTL,
+		schemaErrorsSubject: mainflux.Env(envSchemaErrors, defSchemaErrors),
 	}
 }
 
@@ -200,3 +222,24 @@ func startCOAPServer(cfg config, svc coap.Service, auth mainflux.ThingsServiceCl
 	l.Info(fmt.Sprintf("CoAP adapter service started, exposed port %s", cfg.port))
 	errs <- gocoap.ListenAndServe("udp", p, api.MakeCoAPHandler(svc, l))
 }
+
+func newSchemaPublisher(pub messaging.Publisher, tc mainflux.ThingsServiceClient, cfg config, logger logger.Logger) messaging.Publisher {
+	var errPub messaging.Publisher
+	if cfg.schemaErrorsSubject != "" {
+		ep, err := nats.NewPrefixedPublisher(cfg.natsURL, cfg.schemaErrorsSubject)
+		if err != nil {
+			logger.Error(fmt.Sprintf("Failed to connect to NATS: %s", err))
+			os.Exit(1)
+		}
+		errPub = ep
+	}
+
+	rejected := kitprometheus.NewCounterFrom(stdprometheus.CounterOpts{
+		Namespace: "coap_adapter",
+		Subsystem: "schema",
+		Name:      "rejected_count",
+		Help:      "Number of messages rejected by the channel schema.",
+	}, []string{})
+
+	return schema.NewPublisher(pub, schema.NewValidator(tc, cfg.schemaCacheTTL), errPub, rejected)
+}
@@ -22,7 +22,9 @@ import (
 	adapter "github.com/mainflux/mainflux/http"
 	"github.com/mainflux/mainflux/http/api"
 	"github.com/mainflux/mainflux/logger"
+	"github.com/mainflux/mainflux/pkg/messaging"
 	"github.com/mainflux/mainflux/pkg/messaging/nats"
+	"github.com/mainflux/mainflux/pkg/schema"
 	thingsapi "github.com/mainflux/mainflux/things/api/auth/grpc"
 	"github.com/opentracing/opentracing-go"
 	stdprometheus "github.com/prometheus/client_golang/prometheus"
@@ -39,6 +41,8 @@ const (
 	defJaegerURL         = ""
 	defThingsAuthURL     = "localhost:8181"
 	defThingsAuthTimeout = "1s"
+	defSchemaCacheTTL    = "1m"
+	defSchemaErrors      = ""
 
 	envLogLevel          = "MF_HTTP_ADAPTER_LOG_LEVEL"
 	envClientTLS         = "MF_HTTP_ADAPTER_CLIENT_TLS"
@@ -48,17 +52,21 @@ const (
 	envJaegerURL         = "MF_JAEGER_URL"
 	envThingsAuthURL     = "MF_THINGS_AUTH_GRPC_URL"
 	envThingsAuthTimeout = "MF_THINGS_AUTH_GRPC_TIMEOUT"
+	envSchemaCacheTTL    = "MF_SCHEMA_CACHE_TTL"
+	envSchemaErrors      = "MF_SCHEMA_ERRORS_SUBJECT"
 )
 
 type config struct {
-	natsURL           string
-	logLevel          string
-	port              string
-	clientTLS         bool
-	caCerts           string
-	jaegerURL         string
-	thingsAuthURL     string
-	thingsAuthTimeout time.Duration
+	natsURL             string
+	logLevel            string
+	port                string
+	clientTLS           bool
+	caCerts             string
+	jaegerURL           string
+	thingsAuthURL       string
+	thingsAuthTimeout   time.Duration
+	schemaCacheTTL      time.Duration
+	schemaErrorsSubject string
 }
 
 func main() {
@@ -86,7 +94,7 @@ func main() {
 	defer pub.Close()
 
 	tc := thingsapi.NewClient(conn, thingsTracer, cfg.thingsAuthTimeout)
-	svc := adapter.New(pub, tc)
+	svc := adapter.New(newSchemaPublisher(pub, tc, cfg, logger), tc)
 
 	svc = api.LoggingMiddleware(svc, logger)
 	svc = api.MetricsMiddleware(
@@ -134,15 +142,22 @@ func loadConfig() config {
 		log.Fatalf("Invalid %s value: %s", envThingsAuthTimeout, err.Error())
 	}
 
+	schemaCacheTTL, err := time.ParseDuration(mainflux.Env(envSchemaCacheTTL, defSchemaCacheTTL))
+	if err != nil {
+		log.Fatalf("Invalid %s value: %s", envSchemaCacheTTL, err.Error())
+	}
+
 	return config{
-		natsURL:           mainflux.Env(envNatsURL, defNatsURL),
-		logLevel:          mainflux.Env(envLogLevel, defLogLevel),
-		port:              mainflux.Env(envPort, defPort),
-		clientTLS:         tls,
-		caCerts:           mainflux.Env(envCACerts, defCACerts),
-		jaegerURL:         mainflux.Env(envJaegerURL, defJaegerURL),
-		thingsAuthURL:     mainflux.Env(envThingsAuthURL, defThingsAuthURL),
-		thingsAuthTimeout: authTimeout,
+		natsURL:             mainflux.Env(envNatsURL, defNatsURL),
+		logLevel:            mainflux.Env(envLogLevel, defLogLevel),
+		port:                mainflux.Env(envPort, defPort),
+		clientTLS:           tls,
+		caCerts:             mainflux.Env(envCACerts, defCACerts),
+		jaegerURL:           mainflux.Env(envJaegerURL, defJaegerURL),
+		thingsAuthURL:       mainflux.Env(envThingsAuthURL, defThingsAuthURL),
+		thingsAuthTimeout:   authTimeout,
+		schemaCacheTTL:      schemaCacheTTL,
+		schemaErrorsSubject: mainflux.Env(envSchemaErrors, defSchemaErrors),
 	}
 }
 
@@ -193,3 +208,24 @@ func connectToThings(cfg config, logger logger.Logger) *grpc.ClientConn {
 	}
 	return conn
 }
+
+func newSchemaPublisher(pub messaging.Publisher, tc mainflux.ThingsServiceClient, cfg config, logger logger.Logger) messaging.Publisher {
+	var errPub messaging.Publisher
+	if cfg.schemaErrorsSubject != "" {
+		ep, err := nats.NewPrefixedPublisher(cfg.natsURL, cfg.schemaErrorsSubject)
+		if err != nil {
+			logger.Error(fmt.Sprintf("Failed to connect to NATS: %s", err))
+			os.Exit(1)
+		}
+		errPub = ep
+	}
+
+	rejected := kitprometheus.NewCounterFrom(stdprometheus.CounterOpts{
+		Namespace: "http_adapter",
+		Subsystem: "schema",
+		Name:      "rejected_count",
+		Help:      "Number of messages rejected by the channel schema.",
+	}, []string{})
+
+	return schema.NewPublisher(pub, schema.NewValidator(tc, cfg.schemaCacheTTL), errPub, rejected)
+}
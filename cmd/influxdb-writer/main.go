@@ -15,7 +15,8 @@ import (
 	influxdata "github.com/influxdata/influxdb/client/v2"
 	"github.com/mainflux/mainflux"
 	"github.com/mainflux/mainflux/logger"
-	"github.com/mainflux/mainflux/pkg/messaging/nats"
+	"github.com/mainflux/mainflux/pkg/encryption"
+	"github.com/mainflux/mainflux/pkg/messaging/jetstream"
 	"github.com/mainflux/mainflux/pkg/transformers/senml"
 	"github.com/mainflux/mainflux/writers"
 	"github.com/mainflux/mainflux/writers/api"
@@ -51,6 +52,8 @@ const (
 
 type config struct {
 	natsURL         string
+	jetStream       bool
+	jsConfig        jetstream.Config
 	logLevel        string
 	port            string
 	dbName          string
@@ -60,6 +63,8 @@ type config struct {
 	dbPass          string
 	subjectsCfgPath string
 	contentType     string
+	encryption      encryption.Config
+	writer          writers.Config
 }
 
 func main() {
@@ -70,9 +75,9 @@ func main() {
 		log.Fatalf(err.Error())
 	}
 
-	pubSub, err := nats.NewPubSub(cfg.natsURL, "", logger)
+	pubSub, err := jetstream.Connect(cfg.natsURL, svcName, cfg.jetStream, cfg.jsConfig, logger)
 	if err != nil {
-		logger.Error(fmt.Sprintf("Failed to connect to NATS: %s", err))
+		logger.Error(err.Error())
 		os.Exit(1)
 	}
 	defer pubSub.Close()
@@ -89,9 +94,17 @@ func main() {
 	counter, latency := makeMetrics()
 	repo = api.LoggingMiddleware(repo, logger)
 	repo = api.MetricsMiddleware(repo, counter, latency)
+	if cfg.encryption.Enabled() {
+		cipher, err := encryption.NewCipher(cfg.encryption)
+		if err != nil {
+			logger.Error(fmt.Sprintf("Failed to create message cipher: %s", err))
+			os.Exit(1)
+		}
+		repo = writers.EncryptionMiddleware(repo, cipher)
+	}
 	st := senml.New(cfg.contentType)
 
-	if err := writers.Start(pubSub, repo, st, svcName, cfg.subjectsCfgPath, logger); err != nil {
+	if err := writers.Start(pubSub, repo, st, svcName, cfg.subjectsCfgPath, cfg.writer, logger); err != nil {
 		logger.Error(fmt.Sprintf("Failed to start InfluxDB writer: %s", err))
 		os.Exit(1)
 	}
@@ -110,8 +123,25 @@ func main() {
 }
 
 func loadConfigs() (config, influxdata.HTTPConfig) {
+	jetStream, jsConfig, err := jetstream.LoadConfig()
+	if err != nil {
+		log.Fatalf(err.Error())
+	}
+
+	wcfg, err := writers.LoadConfig("MF_INFLUX_WRITER", "influxdb", jsConfig.AckWait)
+	if err != nil {
+		log.Fatalf(err.Error())
+	}
+
+	encCfg, err := encryption.LoadConfig()
+	if err != nil {
+		log.Fatalf(err.Error())
+	}
+
 	cfg := config{
 		natsURL:         mainflux.Env(envNatsURL, defNatsURL),
+		jetStream:       jetStream,
+		jsConfig:        jsConfig,
 		logLevel:        mainflux.Env(envLogLevel, defLogLevel),
 		port:            mainflux.Env(envPort, defPort),
 		dbName:          mainflux.Env(envDB, defDB),
@@ -121,6 +151,8 @@ func loadConfigs() (config, influxdata.HTTPConfig) {
 		dbPass:          mainflux.Env(envDBPass, defDBPass),
 		subjectsCfgPath: mainflux.Env(envSubjectsCfgPath, defSubjectsCfgPath),
 		contentType:     mainflux.Env(envContentType, defContentType),
+		encryption:      encCfg,
+		writer:          wcfg,
 	}
 
 	clientCfg := influxdata.HTTPConfig{
@@ -18,7 +18,9 @@ import (
 
 	kitprometheus "github.com/go-kit/kit/metrics/prometheus"
 	"github.com/mainflux/mainflux"
+	authapi "github.com/mainflux/mainflux/authn/api/grpc"
 	"github.com/mainflux/mainflux/logger"
+	"github.com/mainflux/mainflux/pkg/encryption"
 	"github.com/mainflux/mainflux/readers"
 	"github.com/mainflux/mainflux/readers/api"
 	"github.com/mainflux/mainflux/readers/mongodb"
@@ -45,6 +47,8 @@ const (
 	defJaegerURL         = ""
 	defThingsAuthURL     = "localhost:8181"
 	defThingsAuthTimeout = "1s"
+	defAuthnURL          = ""
+	defAuthnTimeout      = "1s"
 
 	envLogLevel          = "MF_MONGO_READER_LOG_LEVEL"
 	envPort              = "MF_MONGO_READER_PORT"
@@ -58,6 +62,8 @@ const (
 	envJaegerURL         = "MF_JAEGER_URL"
 	envThingsAuthURL     = "MF_THINGS_AUTH_GRPC_URL"
 	envThingsAuthTimeout = "MF_THINGS_AUTH_GRPC_TIMEOUT"
+	envAuthnURL          = "MF_AUTHN_GRPC_URL"
+	envAuthnTimeout      = "MF_AUTHN_GRPC_TIMEOUT"
 )
 
 type config struct {
@@ -73,6 +79,9 @@ type config struct {
 	jaegerURL         string
 	thingsAuthURL     string
 	thingsAuthTimeout time.Duration
+	authnURL          string
+	authnTimeout      time.Duration
+	encryption        encryption.Config
 }
 
 func main() {
@@ -82,7 +91,7 @@ func main() {
 		log.Fatalf(err.Error())
 	}
 
-	conn := connectToThings(cfg, logger)
+	conn := connectToGRPC(cfg.thingsAuthURL, cfg, logger)
 	defer conn.Close()
 
 	thingsTracer, thingsCloser := initJaeger("things", cfg.jaegerURL, logger)
@@ -90,9 +99,29 @@ func main() {
 
 	tc := thingsapi.NewClient(conn, thingsTracer, cfg.thingsAuthTimeout)
 
+	// User and API keys are accepted only if authn service is configured.
+	var ac mainflux.AuthNServiceClient
+	if cfg.authnURL != "" {
+		authnConn := connectToGRPC(cfg.authnURL, cfg, logger)
+		defer authnConn.Close()
+
+		authnTracer, authnCloser := initJaeger("authn", cfg.jaegerURL, logger)
+		defer authnCloser.Close()
+
+		ac = authapi.NewClient(authnTracer, authnConn, cfg.authnTimeout)
+	}
+
 	db := connectToMongoDB(cfg.dbHost, cfg.dbPort, cfg.dbName, logger)
 
 	repo := newService(db, logger)
+	if cfg.encryption.Enabled() {
+		cipher, err := encryption.NewCipher(cfg.encryption)
+		if err != nil {
+			logger.Error(fmt.Sprintf("Failed to create message cipher: %s", err))
+			os.Exit(1)
+		}
+		repo = readers.DecryptionMiddleware(repo, cipher)
+	}
 
 	errs := make(chan error, 2)
 	go func() {
@@ -101,7 +130,7 @@ func main() {
 		errs <- fmt.Errorf("%s", <-c)
 	}()
 
-	go startHTTPServer(repo, tc, cfg, logger, errs)
+	go startHTTPServer(repo, tc, ac, cfg, logger, errs)
 
 	err = <-errs
 	logger.Error(fmt.Sprintf("MongoDB reader service terminated: %s", err))
@@ -118,6 +147,16 @@ func loadConfigs() config {
 		log.Fatalf("Invalid %s value: %s", envThingsAuthTimeout, err.Error())
 	}
 
+	authnTimeout, err := time.ParseDuration(mainflux.Env(envAuthnTimeout, defAuthnTimeout))
+	if err != nil {
+		log.Fatalf("Invalid %s value: %s", envAuthnTimeout, err.Error())
+	}
+
+	encCfg, err := encryption.LoadConfig()
+	if err != nil {
+		log.Fatalf(err.Error())
+	}
+
 	return config{
 		logLevel:          mainflux.Env(envLogLevel, defLogLevel),
 		port:              mainflux.Env(envPort, defPort),
@@ -131,6 +170,9 @@ func loadConfigs() config {
 		jaegerURL:         mainflux.Env(envJaegerURL, defJaegerURL),
 		thingsAuthURL:     mainflux.Env(envThingsAuthURL, defThingsAuthURL),
 		thingsAuthTimeout: authTimeout,
+		authnURL:          mainflux.Env(envAuthnURL, defAuthnURL),
+		authnTimeout:      authnTimeout,
+		encryption:        encCfg,
 	}
 }
 
@@ -169,7 +211,7 @@ func initJaeger(svcName, url string, logger logger.Logger) (opentracing.Tracer,
 	return tracer, closer
 }
 
-func connectToThings(cfg config, logger logger.Logger) *grpc.ClientConn {
+func connectToGRPC(url string, cfg config, logger logger.Logger) *grpc.ClientConn {
 	var opts []grpc.DialOption
 	if cfg.clientTLS {
 		if cfg.caCerts != "" {
@@ -185,9 +227,9 @@ func connectToThings(cfg config, logger logger.Logger) *grpc.ClientConn {
 		opts = append(opts, grpc.WithInsecure())
 	}
 
-	conn, err := grpc.Dial(cfg.thingsAuthURL, opts...)
+	conn, err := grpc.Dial(url, opts...)
 	if err != nil {
-		logger.Error(fmt.Sprintf("Failed to connect to things service: %s", err))
+		logger.Error(fmt.Sprintf("Failed to connect to %s: %s", url, err))
 		os.Exit(1)
 	}
 	return conn
@@ -215,14 +257,14 @@ func newService(db *mongo.Database, logger logger.Logger) readers.MessageReposit
 	return repo
 }
 
-func startHTTPServer(repo readers.MessageRepository, tc mainflux.ThingsServiceClient, cfg config, logger logger.Logger, errs chan error) {
+func startHTTPServer(repo readers.MessageRepository, tc mainflux.ThingsServiceClient, ac mainflux.AuthNServiceClient, cfg config, logger logger.Logger, errs chan error) {
 	p := fmt.Sprintf(":%s", cfg.port)
 	if cfg.serverCert != "" || cfg.serverKey != "" {
 		logger.Info(fmt.Sprintf("Mongo reader service started using https on port %s with cert %s key %s",
 			cfg.port, cfg.serverCert, cfg.serverKey))
-		errs <- http.ListenAndServeTLS(p, cfg.serverCert, cfg.serverKey, api.MakeHandler(repo, tc, "mongodb-reader"))
+		errs <- http.ListenAndServeTLS(p, cfg.serverCert, cfg.serverKey, api.MakeHandler(repo, tc, ac, "mongodb-reader"))
 		return
 	}
 	logger.Info(fmt.Sprintf("Mongo reader service started, exposed port %s", cfg.port))
-	errs <- http.ListenAndServe(p, api.MakeHandler(repo, tc, "mongodb-reader"))
+	errs <- http.ListenAndServe(p, api.MakeHandler(repo, tc, ac, "mongodb-reader"))
 }
@@ -12,6 +12,7 @@ import (
 	"syscall"
 	"time"
 
+	kitprometheus "github.com/go-kit/kit/metrics/prometheus"
 	"github.com/go-redis/redis"
 	"github.com/mainflux/mainflux"
 	mflog "github.com/mainflux/mainflux/logger"
@@ -21,11 +22,13 @@ import (
 	"github.com/mainflux/mainflux/pkg/messaging"
 	mqttpub "github.com/mainflux/mainflux/pkg/messaging/mqtt"
 	"github.com/mainflux/mainflux/pkg/messaging/nats"
+	"github.com/mainflux/mainflux/pkg/schema"
 	thingsapi "github.com/mainflux/mainflux/things/api/auth/grpc"
 	mp "github.com/mainflux/mproxy/pkg/mqtt"
 	"github.com/mainflux/mproxy/pkg/session"
 	ws "github.com/mainflux/mproxy/pkg/websocket"
 	opentracing "github.com/opentracing/opentracing-go"
+	stdprometheus "github.com/prometheus/client_golang/prometheus"
 	jconfig "github.com/uber/jaeger-client-go/config"
 	"google.golang.org/grpc"
 	"google.golang.org/grpc/credentials"
@@ -86,6 +89,11 @@ const (
 	defAuthcacheURL  = "localhost:6379"
 	defAuthCachePass = ""
 	defAuthCacheDB   = "0"
+	// Schema
+	envSchemaCacheTTL = "MF_SCHEMA_CACHE_TTL"
+	envSchemaErrors   = "MF_SCHEMA_ERRORS_SUBJECT"
+	defSchemaCacheTTL = "1m"
+	defSchemaErrors   = ""
 )
 
 type config struct {
@@ -112,6 +120,8 @@ type config struct {
 	authURL              string
 	authPass             string
 	authDB               string
+	schemaCacheTTL       time.Duration
+	schemaErrorsSubject  string
 }
 
 func main() {
@@ -166,7 +176,7 @@ func main() {
 	authClient := auth.New(ac, tc)
 
 	// Event handler for MQTT hooks
-	h := mqtt.NewHandler([]messaging.Publisher{np}, es, logger, authClient)
+	h := mqtt.NewHandler([]messaging.Publisher{newSchemaPublisher(np, tc, cfg, logger)}, es, logger, authClient)
 
 	errs := make(chan error, 2)
 
@@ -202,6 +212,11 @@ func loadConfig() config {
 		log.Fatalf("Invalid %s value: %s", envThingsAuthTimeout, err.Error())
 	}
 
+	schemaCacheTTL, err := time.ParseDuration(mainflux.Env(envSchemaCacheTTL, defSchemaCacheTTL))
+	if err != nil {
+		log.Fatalf("Invalid %s value: %s", envSchemaCacheTTL, err.Error())
+	}
+
 	return config{
 		mqttPort:             mainflux.Env(envMQTTPort, defMQTTPort),
 		mqttTargetHost:       mainflux.Env(envMQTTTargetHost, defMQTTTargetHost),
@@ -226,6 +241,8 @@ func loadConfig() config {
 		authURL:              mainflux.Env(envAuthCacheURL, defAuthcacheURL),
 		authPass:             mainflux.Env(envAuthCachePass, defAuthCachePass),
 		authDB:               mainflux.Env(envAuthCacheDB, defAuthCacheDB),
+		schemaCacheTTL:       schemaCacheTTL,
+		schemaErrorsSubject:  mainflux.Env(envSchemaErrors, defSchemaErrors),
 	}
 }
 
@@ -305,3 +322,24 @@ func proxyWS(cfg config, logger mflog.Logger, handler session.Handler, errs chan
 
 	errs <- wp.Listen(cfg.httpPort)
 }
+
+func newSchemaPublisher(pub messaging.Publisher, tc mainflux.ThingsServiceClient, cfg config, logger mflog.Logger) messaging.Publisher {
+	var errPub messaging.Publisher
+	if cfg.schemaErrorsSubject != "" {
+		ep, err := nats.NewPrefixedPublisher(cfg.natsURL, cfg.schemaErrorsSubject)
+		if err != nil {
+			logger.Error(fmt.Sprintf("Failed to connect to NATS: %s", err))
+			os.Exit(1)
+		}
+		errPub = ep
+	}
+
+	rejected := kitprometheus.NewCounterFrom(stdprometheus.CounterOpts{
+		Namespace: "mqtt_adapter",
+		Subsystem: "schema",
+		Name:      "rejected_count",
+		Help:      "Number of messages rejected by the channel schema.",
+	}, []string{})
+
+	return schema.NewPublisher(pub, schema.NewValidator(tc, cfg.schemaCacheTTL), errPub, rejected)
+}
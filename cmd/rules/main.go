@@ -0,0 +1,345 @@
+// Copyright (c) Mainflux
+// SPDX-License-Identifier: Apache-2.0
+
+package main
+
+import (
+	"fmt"
+	"io"
+	"io/ioutil"
+	"log"
+	"net/http"
+	"os"
+	"os/signal"
+	"strconv"
+	"syscall"
+	"time"
+
+	kitprometheus "github.com/go-kit/kit/metrics/prometheus"
+	"github.com/go-redis/redis"
+	"github.com/jmoiron/sqlx"
+	"github.com/mainflux/mainflux"
+	authapi "github.com/mainflux/mainflux/authn/api/grpc"
+	"github.com/mainflux/mainflux/logger"
+	"github.com/mainflux/mainflux/pkg/audit"
+	"github.com/mainflux/mainflux/pkg/messaging"
+	"github.com/mainflux/mainflux/pkg/messaging/nats"
+	mfsdk "github.com/mainflux/mainflux/pkg/sdk/go"
+	"github.com/mainflux/mainflux/pkg/transformers/senml"
+	uuidProvider "github.com/mainflux/mainflux/pkg/uuid"
+	"github.com/mainflux/mainflux/rules"
+	"github.com/mainflux/mainflux/rules/api"
+	"github.com/mainflux/mainflux/rules/postgres"
+	"github.com/mainflux/mainflux/rules/webhook"
+	opentracing "github.com/opentracing/opentracing-go"
+	stdprometheus "github.com/prometheus/client_golang/prometheus"
+	jconfig "github.com/uber/jaeger-client-go/config"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/credentials"
+)
+
+const (
+	queue = "rules"
+
+	defLogLevel       = "error"
+	defHTTPPort       = "8180"
+	defJaegerURL      = ""
+	defServerCert     = ""
+	defServerKey      = ""
+	defDBHost         = "localhost"
+	defDBPort         = "5432"
+	defDBUser         = "mainflux"
+	defDBPass         = "mainflux"
+	defDB             = "rules"
+	defDBSSLMode      = "disable"
+	defDBSSLCert      = ""
+	defDBSSLKey       = ""
+	defDBSSLRootCert  = ""
+	defClientTLS      = "false"
+	defCACerts        = ""
+	defNatsURL        = "nats://localhost:4222"
+	defBaseURL        = "http://localhost"
+	defThingsPrefix   = ""
+	defWebhookTimeout = "5s"
+	defAuthnURL       = "localhost:8181"
+	defAuthnTimeout   = "1s"
+	defAuditURL       = ""
+	defAuditPass      = ""
+	defAuditDB        = "0"
+
+	envLogLevel       = "MF_RULES_LOG_LEVEL"
+	envHTTPPort       = "MF_RULES_HTTP_PORT"
+	envJaegerURL      = "MF_JAEGER_URL"
+	envServerCert     = "MF_RULES_SERVER_CERT"
+	envServerKey      = "MF_RULES_SERVER_KEY"
+	envDBHost         = "MF_RULES_DB_HOST"
+	envDBPort         = "MF_RULES_DB_PORT"
+	envDBUser         = "MF_RULES_DB_USER"
+	envDBPass         = "MF_RULES_DB_PASS"
+	envDB             = "MF_RULES_DB"
+	envDBSSLMode      = "MF_RULES_DB_SSL_MODE"
+	envDBSSLCert      = "MF_RULES_DB_SSL_CERT"
+	envDBSSLKey       = "MF_RULES_DB_SSL_KEY"
+	envDBSSLRootCert  = "MF_RULES_DB_SSL_ROOT_CERT"
+	envClientTLS      = "MF_RULES_CLIENT_TLS"
+	envCACerts        = "MF_RULES_CA_CERTS"
+	envNatsURL        = "MF_NATS_URL"
+	envBaseURL        = "MF_SDK_BASE_URL"
+	envThingsPrefix   = "MF_SDK_THINGS_PREFIX"
+	envWebhookTimeout = "MF_RULES_WEBHOOK_TIMEOUT"
+	envAuthnURL       = "MF_AUTHN_GRPC_URL"
+	envAuthnTimeout   = "MF_AUTHN_GRPC_TIMEOUT"
+	envAuditURL       = "MF_RULES_AUDIT_URL"
+	envAuditPass      = "MF_RULES_AUDIT_PASS"
+	envAuditDB        = "MF_RULES_AUDIT_DB"
+)
+
+type config struct {
+	logLevel       string
+	httpPort       string
+	jaegerURL      string
+	serverCert     string
+	serverKey      string
+	dbConfig       postgres.Config
+	clientTLS      bool
+	caCerts        string
+	natsURL        string
+	baseURL        string
+	thingsPrefix   string
+	webhookTimeout time.Duration
+	authnURL       string
+	authnTimeout   time.Duration
+	auditURL       string
+	auditPass      string
+	auditDB        string
+}
+
+func main() {
+	cfg := loadConfig()
+
+	logger, err := logger.New(os.Stdout, cfg.logLevel)
+	if err != nil {
+		log.Fatalf(err.Error())
+	}
+
+	if err := audit.LoadTrustedProxies(); err != nil {
+		logger.Error(fmt.Sprintf("Failed to load trusted proxies: %s", err))
+		os.Exit(1)
+	}
+
+	db := connectToDB(cfg.dbConfig, logger)
+	defer db.Close()
+
+	authTracer, authCloser := initJaeger("auth", cfg.jaegerURL, logger)
+	defer authCloser.Close()
+
+	authConn := connectToAuth(cfg, logger)
+	defer authConn.Close()
+
+	auth := authapi.NewClient(authTracer, authConn, cfg.authnTimeout)
+
+	pubSub, err := nats.NewPubSub(cfg.natsURL, queue, logger)
+	if err != nil {
+		logger.Error(fmt.Sprintf("Failed to connect to NATS: %s", err))
+		os.Exit(1)
+	}
+	defer pubSub.Close()
+
+	auditClient := connectToRedis(cfg.auditURL, cfg.auditPass, cfg.auditDB, logger)
+	if auditClient != nil {
+		defer auditClient.Close()
+	}
+
+	svc := newService(pubSub, auth, auditClient, db, cfg, logger)
+
+	errs := make(chan error, 2)
+	go startHTTPServer(api.MakeHandler(svc), cfg, logger, errs)
+
+	go func() {
+		c := make(chan os.Signal, 1)
+		signal.Notify(c, syscall.SIGINT)
+		errs <- fmt.Errorf("%s", <-c)
+	}()
+
+	err = <-errs
+	logger.Error(fmt.Sprintf("Rules service terminated: %s", err))
+}
+
+func loadConfig() config {
+	tls, err := strconv.ParseBool(mainflux.Env(envClientTLS, defClientTLS))
+	if err != nil {
+		log.Fatalf("Invalid value passed for %s\n", envClientTLS)
+	}
+
+	authnTimeout, err := time.ParseDuration(mainflux.Env(envAuthnTimeout, defAuthnTimeout))
+	if err != nil {
+		log.Fatalf("Invalid %s value: %s", envAuthnTimeout, err.Error())
+	}
+
+	webhookTimeout, err := time.ParseDuration(mainflux.Env(envWebhookTimeout, defWebhookTimeout))
+	if err != nil {
+		log.Fatalf("Invalid %s value: %s", envWebhookTimeout, err.Error())
+	}
+
+	dbConfig := postgres.Config{
+		Host:        mainflux.Env(envDBHost, defDBHost),
+		Port:        mainflux.Env(envDBPort, defDBPort),
+		User:        mainflux.Env(envDBUser, defDBUser),
+		Pass:        mainflux.Env(envDBPass, defDBPass),
+		Name:        mainflux.Env(envDB, defDB),
+		SSLMode:     mainflux.Env(envDBSSLMode, defDBSSLMode),
+		SSLCert:     mainflux.Env(envDBSSLCert, defDBSSLCert),
+		SSLKey:      mainflux.Env(envDBSSLKey, defDBSSLKey),
+		SSLRootCert: mainflux.Env(envDBSSLRootCert, defDBSSLRootCert),
+	}
+
+	return config{
+		logLevel:       mainflux.Env(envLogLevel, defLogLevel),
+		httpPort:       mainflux.Env(envHTTPPort, defHTTPPort),
+		jaegerURL:      mainflux.Env(envJaegerURL, defJaegerURL),
+		serverCert:     mainflux.Env(envServerCert, defServerCert),
+		serverKey:      mainflux.Env(envServerKey, defServerKey),
+		dbConfig:       dbConfig,
+		clientTLS:      tls,
+		caCerts:        mainflux.Env(envCACerts, defCACerts),
+		natsURL:        mainflux.Env(envNatsURL, defNatsURL),
+		baseURL:        mainflux.Env(envBaseURL, defBaseURL),
+		thingsPrefix:   mainflux.Env(envThingsPrefix, defThingsPrefix),
+		webhookTimeout: webhookTimeout,
+		authnURL:       mainflux.Env(envAuthnURL, defAuthnURL),
+		authnTimeout:   authnTimeout,
+		auditURL:       mainflux.Env(envAuditURL, defAuditURL),
+		auditPass:      mainflux.Env(envAuditPass, defAuditPass),
+		auditDB:        mainflux.Env(envAuditDB, defAuditDB),
+	}
+}
+
+// connectToRedis connects to the audit events stream. Empty URL disables
+// auditing, in which case nil client is returned.
+func connectToRedis(redisURL, redisPass, redisDB string, logger logger.Logger) *redis.Client {
+	if redisURL == "" {
+		return nil
+	}
+
+	db, err := strconv.Atoi(redisDB)
+	if err != nil {
+		logger.Error(fmt.Sprintf("Failed to connect to redis: %s", err))
+		os.Exit(1)
+	}
+
+	return redis.NewClient(&redis.Options{
+		Addr:     redisURL,
+		Password: redisPass,
+		DB:       db,
+	})
+}
+
+func connectToDB(cfg postgres.Config, logger logger.Logger) *sqlx.DB {
+	db, err := postgres.Connect(cfg)
+	if err != nil {
+		logger.Error(fmt.Sprintf("Failed to connect to postgres: %s", err))
+		os.Exit(1)
+	}
+	return db
+}
+
+func initJaeger(svcName, url string, logger logger.Logger) (opentracing.Tracer, io.Closer) {
+	if url == "" {
+		return opentracing.NoopTracer{}, ioutil.NopCloser(nil)
+	}
+
+	tracer, closer, err := jconfig.Configuration{
+		ServiceName: svcName,
+		Sampler: &jconfig.SamplerConfig{
+			Type:  "const",
+			Param: 1,
+		},
+		Reporter: &jconfig.ReporterConfig{
+			LocalAgentHostPort: url,
+			LogSpans:           true,
+		},
+	}.NewTracer()
+	if err != nil {
+		logger.Error(fmt.Sprintf("Failed to init Jaeger client: %s", err))
+		os.Exit(1)
+	}
+
+	return tracer, closer
+}
+
+func connectToAuth(cfg config, logger logger.Logger) *grpc.ClientConn {
+	var opts []grpc.DialOption
+	if cfg.clientTLS {
+		if cfg.caCerts != "" {
+			tpc, err := credentials.NewClientTLSFromFile(cfg.caCerts, "")
+			if err != nil {
+				logger.Error(fmt.Sprintf("Failed to create tls credentials: %s", err))
+				os.Exit(1)
+			}
+			opts = append(opts, grpc.WithTransportCredentials(tpc))
+		}
+	} else {
+		opts = append(opts, grpc.WithInsecure())
+		logger.Info("gRPC communication is not encrypted")
+	}
+
+	conn, err := grpc.Dial(cfg.authnURL, opts...)
+	if err != nil {
+		logger.Error(fmt.Sprintf("Failed to connect to authn service: %s", err))
+		os.Exit(1)
+	}
+
+	return conn
+}
+
+func newService(ps messaging.PubSub, auth mainflux.AuthNServiceClient, auditClient *redis.Client, db *sqlx.DB, cfg config, logger logger.Logger) rules.Service {
+	ruleRepo := postgres.NewRuleRepository(db)
+	alarmRepo := postgres.NewAlarmRepository(db)
+
+	sdk := mfsdk.NewSDK(mfsdk.Config{
+		BaseURL:      cfg.baseURL,
+		ThingsPrefix: cfg.thingsPrefix,
+	})
+	notifier := webhook.New(&http.Client{Timeout: cfg.webhookTimeout})
+
+	svc := rules.New(auth, ruleRepo, alarmRepo, sdk, ps, notifier, senml.New(senml.JSON), uuidProvider.New())
+	if auditClient != nil {
+		rec := audit.NewRecorder("rules", audit.NewIdentifier(auth), audit.NewPublisher(auditClient), logger)
+		svc = api.AuditMiddleware(svc, rec)
+	}
+	svc = api.LoggingMiddleware(svc, logger)
+	svc = api.MetricsMiddleware(
+		svc,
+		kitprometheus.NewCounterFrom(stdprometheus.CounterOpts{
+			Namespace: "rules",
+			Subsystem: "api",
+			Name:      "request_count",
+			Help:      "Number of requests received.",
+		}, []string{"method"}),
+		kitprometheus.NewSummaryFrom(stdprometheus.SummaryOpts{
+			Namespace: "rules",
+			Subsystem: "api",
+			Name:      "request_latency_microseconds",
+			Help:      "Total duration of requests in microseconds.",
+		}, []string{"method"}),
+	)
+
+	if err := ps.Subscribe(nats.SubjectAllChannels, svc.Consume); err != nil {
+		logger.Error(fmt.Sprintf("Failed to subscribe to NATS: %s", err))
+		os.Exit(1)
+	}
+
+	return svc
+}
+
+func startHTTPServer(handler http.Handler, cfg config, logger logger.Logger, errs chan error) {
+	p := fmt.Sprintf(":%s", cfg.httpPort)
+	if cfg.serverCert != "" || cfg.serverKey != "" {
+		logger.Info(fmt.Sprintf("Rules service started using https on port %s with cert %s key %s",
+			cfg.httpPort, cfg.serverCert, cfg.serverKey))
+		errs <- http.ListenAndServeTLS(p, cfg.serverCert, cfg.serverKey, handler)
+		return
+	}
+	logger.Info(fmt.Sprintf("Rules service started using http on port %s", cfg.httpPort))
+	errs <- http.ListenAndServe(p, handler)
+}
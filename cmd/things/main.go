@@ -27,6 +27,7 @@ import (
 	"github.com/mainflux/mainflux"
 	authapi "github.com/mainflux/mainflux/authn/api/grpc"
 	"github.com/mainflux/mainflux/logger"
+	"github.com/mainflux/mainflux/pkg/audit"
 	uuidProvider "github.com/mainflux/mainflux/pkg/uuid"
 	"github.com/mainflux/mainflux/things"
 	"github.com/mainflux/mainflux/things/api"
@@ -36,6 +37,7 @@ import (
 	"github.com/mainflux/mainflux/things/postgres"
 	rediscache "github.com/mainflux/mainflux/things/redis"
 	localusers "github.com/mainflux/mainflux/things/users"
+	usersapi "github.com/mainflux/mainflux/users/api/grpc"
 	stdprometheus "github.com/prometheus/client_golang/prometheus"
 	jconfig "github.com/uber/jaeger-client-go/config"
 	"google.golang.org/grpc"
@@ -60,6 +62,9 @@ const (
 	defESURL           = "localhost:6379"
 	defESPass          = ""
 	defESDB            = "0"
+	defAuditURL        = ""
+	defAuditPass       = ""
+	defAuditDB         = "0"
 	defHTTPPort        = "8182"
 	defAuthHTTPPort    = "8180"
 	defAuthGRPCPort    = "8181"
@@ -70,6 +75,8 @@ const (
 	defJaegerURL       = ""
 	defAuthnURL        = "localhost:8181"
 	defAuthnTimeout    = "1s"
+	defUsersURL        = "localhost:8181"
+	defUsersTimeout    = "1s"
 
 	envLogLevel        = "MF_THINGS_LOG_LEVEL"
 	envDBHost          = "MF_THINGS_DB_HOST"
@@ -89,6 +96,9 @@ const (
 	envESURL           = "MF_THINGS_ES_URL"
 	envESPass          = "MF_THINGS_ES_PASS"
 	envESDB            = "MF_THINGS_ES_DB"
+	envAuditURL        = "MF_THINGS_AUDIT_URL"
+	envAuditPass       = "MF_THINGS_AUDIT_PASS"
+	envAuditDB         = "MF_THINGS_AUDIT_DB"
 	envHTTPPort        = "MF_THINGS_HTTP_PORT"
 	envAuthHTTPPort    = "MF_THINGS_AUTH_HTTP_PORT"
 	envAuthGRPCPort    = "MF_THINGS_AUTH_GRPC_PORT"
@@ -99,6 +109,8 @@ const (
 	envJaegerURL       = "MF_JAEGER_URL"
 	envAuthnURL        = "MF_AUTHN_GRPC_URL"
 	envAuthnTimeout    = "MF_AUTHN_GRPC_TIMEOUT"
+	envUsersURL        = "MF_USERS_GRPC_URL"
+	envUsersTimeout    = "MF_USERS_GRPC_TIMEOUT"
 )
 
 type config struct {
@@ -112,6 +124,9 @@ type config struct {
 	esURL           string
 	esPass          string
 	esDB            string
+	auditURL        string
+	auditPass       string
+	auditDB         string
 	httpPort        string
 	authHTTPPort    string
 	authGRPCPort    string
@@ -122,6 +137,8 @@ type config struct {
 	jaegerURL       string
 	authnURL        string
 	authnTimeout    time.Duration
+	usersURL        string
+	usersTimeout    time.Duration
 }
 
 func main() {
@@ -132,6 +149,11 @@ func main() {
 		log.Fatalf(err.Error())
 	}
 
+	if err := audit.LoadTrustedProxies(); err != nil {
+		logger.Error(fmt.Sprintf("Failed to load trusted proxies: %s", err))
+		os.Exit(1)
+	}
+
 	thingsTracer, thingsCloser := initJaeger("things", cfg.jaegerURL, logger)
 	defer thingsCloser.Close()
 
@@ -139,6 +161,12 @@ func main() {
 
 	esClient := connectToRedis(cfg.esURL, cfg.esPass, cfg.esDB, logger)
 
+	// Auditing is disabled unless the audit events stream is configured.
+	var auditClient *redis.Client
+	if cfg.auditURL != "" {
+		auditClient = connectToRedis(cfg.auditURL, cfg.auditPass, cfg.auditDB, logger)
+	}
+
 	db := connectToDB(cfg.dbConfig, logger)
 	defer db.Close()
 
@@ -150,13 +178,21 @@ func main() {
 		defer close()
 	}
 
+	usersTracer, usersCloser := initJaeger("users", cfg.jaegerURL, logger)
+	defer usersCloser.Close()
+
+	users, usersClose := createUsersClient(cfg, usersTracer, logger)
+	if usersClose != nil {
+		defer usersClose()
+	}
+
 	dbTracer, dbCloser := initJaeger("things_db", cfg.jaegerURL, logger)
 	defer dbCloser.Close()
 
 	cacheTracer, cacheCloser := initJaeger("things_cache", cfg.jaegerURL, logger)
 	defer cacheCloser.Close()
 
-	svc := newService(auth, dbTracer, cacheTracer, db, cacheClient, esClient, logger)
+	svc := newService(auth, users, dbTracer, cacheTracer, db, cacheClient, esClient, auditClient, logger)
 	errs := make(chan error, 2)
 
 	go startHTTPServer(thhttpapi.MakeHandler(thingsTracer, svc), cfg.httpPort, cfg, logger, errs)
@@ -184,6 +220,11 @@ func loadConfig() config {
 		log.Fatalf("Invalid %s value: %s", envAuthnTimeout, err.Error())
 	}
 
+	usersTimeout, err := time.ParseDuration(mainflux.Env(envUsersTimeout, defUsersTimeout))
+	if err != nil {
+		log.Fatalf("Invalid %s value: %s", envUsersTimeout, err.Error())
+	}
+
 	dbConfig := postgres.Config{
 		Host:        mainflux.Env(envDBHost, defDBHost),
 		Port:        mainflux.Env(envDBPort, defDBPort),
@@ -207,6 +248,9 @@ func loadConfig() config {
 		esURL:           mainflux.Env(envESURL, defESURL),
 		esPass:          mainflux.Env(envESPass, defESPass),
 		esDB:            mainflux.Env(envESDB, defESDB),
+		auditURL:        mainflux.Env(envAuditURL, defAuditURL),
+		auditPass:       mainflux.Env(envAuditPass, defAuditPass),
+		auditDB:         mainflux.Env(envAuditDB, defAuditDB),
 		httpPort:        mainflux.Env(envHTTPPort, defHTTPPort),
 		authHTTPPort:    mainflux.Env(envAuthHTTPPort, defAuthHTTPPort),
 		authGRPCPort:    mainflux.Env(envAuthGRPCPort, defAuthGRPCPort),
@@ -217,6 +261,8 @@ func loadConfig() config {
 		jaegerURL:       mainflux.Env(envJaegerURL, defJaegerURL),
 		authnURL:        mainflux.Env(envAuthnURL, defAuthnURL),
 		authnTimeout:    authnTimeout,
+		usersURL:        mainflux.Env(envUsersURL, defUsersURL),
+		usersTimeout:    usersTimeout,
 	}
 }
 
@@ -272,11 +318,20 @@ func createAuthClient(cfg config, tracer opentracing.Tracer, logger logger.Logge
 		return localusers.NewSingleUserService(cfg.singleUserEmail, cfg.singleUserToken), nil
 	}
 
-	conn := connectToAuth(cfg, logger)
+	conn := connectToGRPC(cfg, "authn", cfg.authnURL, logger)
 	return authapi.NewClient(tracer, conn, cfg.authnTimeout), conn.Close
 }
 
-func connectToAuth(cfg config, logger logger.Logger) *grpc.ClientConn {
+func createUsersClient(cfg config, tracer opentracing.Tracer, logger logger.Logger) (mainflux.UsersServiceClient, func() error) {
+	if cfg.singleUserEmail != "" && cfg.singleUserToken != "" {
+		return localusers.NewSingleUserGroups(), nil
+	}
+
+	conn := connectToGRPC(cfg, "users", cfg.usersURL, logger)
+	return usersapi.NewClient(conn, tracer, cfg.usersTimeout), conn.Close
+}
+
+func connectToGRPC(cfg config, svcName, url string, logger logger.Logger) *grpc.ClientConn {
 	var opts []grpc.DialOption
 	if cfg.clientTLS {
 		if cfg.caCerts != "" {
@@ -292,16 +347,16 @@ func connectToAuth(cfg config, logger logger.Logger) *grpc.ClientConn {
 		logger.Info("gRPC communication is not encrypted")
 	}
 
-	conn, err := grpc.Dial(cfg.authnURL, opts...)
+	conn, err := grpc.Dial(url, opts...)
 	if err != nil {
-		logger.Error(fmt.Sprintf("Failed to connect to authn service: %s", err))
+		logger.Error(fmt.Sprintf("Failed to connect to %s service: %s", svcName, err))
 		os.Exit(1)
 	}
 
 	return conn
 }
 
-func newService(auth mainflux.AuthNServiceClient, dbTracer opentracing.Tracer, cacheTracer opentracing.Tracer, db *sqlx.DB, cacheClient *redis.Client, esClient *redis.Client, logger logger.Logger) things.Service {
+func newService(auth mainflux.AuthNServiceClient, users mainflux.UsersServiceClient, dbTracer opentracing.Tracer, cacheTracer opentracing.Tracer, db *sqlx.DB, cacheClient *redis.Client, esClient *redis.Client, auditClient *redis.Client, logger logger.Logger) things.Service {
 	database := postgres.NewDatabase(db)
 
 	thingsRepo := postgres.NewThingRepository(database)
@@ -310,6 +365,9 @@ func newService(auth mainflux.AuthNServiceClient, dbTracer opentracing.Tracer, c
 	channelsRepo := postgres.NewChannelRepository(database)
 	channelsRepo = tracing.ChannelRepositoryMiddleware(dbTracer, channelsRepo)
 
+	groupsRepo := postgres.NewGroupRepository(database)
+	groupsRepo = tracing.GroupRepositoryMiddleware(dbTracer, groupsRepo)
+
 	chanCache := rediscache.NewChannelCache(cacheClient)
 	chanCache = tracing.ChannelCacheMiddleware(cacheTracer, chanCache)
 
@@ -317,8 +375,12 @@ func newService(auth mainflux.AuthNServiceClient, dbTracer opentracing.Tracer, c
 	thingCache = tracing.ThingCacheMiddleware(cacheTracer, thingCache)
 	up := uuidProvider.New()
 
-	svc := things.New(auth, thingsRepo, channelsRepo, chanCache, thingCache, up)
+	svc := things.New(auth, users, thingsRepo, channelsRepo, groupsRepo, chanCache, thingCache, up)
 	svc = rediscache.NewEventStoreMiddleware(svc, esClient)
+	if auditClient != nil {
+		rec := audit.NewRecorder("things", audit.NewIdentifier(auth), audit.NewPublisher(auditClient), logger)
+		svc = api.AuditMiddleware(svc, rec)
+	}
 	svc = api.LoggingMiddleware(svc, logger)
 	svc = api.MetricsMiddleware(
 		svc,
@@ -20,6 +20,7 @@ import (
 	"github.com/mainflux/mainflux"
 	authapi "github.com/mainflux/mainflux/authn/api/grpc"
 	"github.com/mainflux/mainflux/logger"
+	"github.com/mainflux/mainflux/pkg/audit"
 	"github.com/mainflux/mainflux/pkg/messaging"
 	"github.com/mainflux/mainflux/pkg/messaging/nats"
 	uuidProvider "github.com/mainflux/mainflux/pkg/uuid"
@@ -30,6 +31,7 @@ import (
 	twmongodb "github.com/mainflux/mainflux/twins/mongodb"
 	rediscache "github.com/mainflux/mainflux/twins/redis"
 	"github.com/mainflux/mainflux/twins/tracing"
+	usersapi "github.com/mainflux/mainflux/users/api/grpc"
 	opentracing "github.com/opentracing/opentracing-go"
 	stdprometheus "github.com/prometheus/client_golang/prometheus"
 	jconfig "github.com/uber/jaeger-client-go/config"
@@ -53,6 +55,9 @@ const (
 	defCacheURL        = "localhost:6379"
 	defCachePass       = ""
 	defCacheDB         = "0"
+	defAuditURL        = ""
+	defAuditPass       = ""
+	defAuditDB         = "0"
 	defSingleUserEmail = ""
 	defSingleUserToken = ""
 	defClientTLS       = "false"
@@ -61,6 +66,8 @@ const (
 	defNatsURL         = "nats://localhost:4222"
 	defAuthnURL        = "localhost:8181"
 	defAuthnTimeout    = "1s"
+	defUsersURL        = "localhost:8181"
+	defUsersTimeout    = "1s"
 
 	envLogLevel        = "MF_TWINS_LOG_LEVEL"
 	envHTTPPort        = "MF_TWINS_HTTP_PORT"
@@ -73,6 +80,9 @@ const (
 	envCacheURL        = "MF_TWINS_CACHE_URL"
 	envCachePass       = "MF_TWINS_CACHE_PASS"
 	envCacheDB         = "MF_TWINS_CACHE_DB"
+	envAuditURL        = "MF_TWINS_AUDIT_URL"
+	envAuditPass       = "MF_TWINS_AUDIT_PASS"
+	envAuditDB         = "MF_TWINS_AUDIT_DB"
 	envSingleUserEmail = "MF_TWINS_SINGLE_USER_EMAIL"
 	envSingleUserToken = "MF_TWINS_SINGLE_USER_TOKEN"
 	envClientTLS       = "MF_TWINS_CLIENT_TLS"
@@ -81,6 +91,8 @@ const (
 	envNatsURL         = "MF_NATS_URL"
 	envAuthnURL        = "MF_AUTHN_GRPC_URL"
 	envAuthnTimeout    = "MF_AUTHN_GRPC_TIMEOUT"
+	envUsersURL        = "MF_USERS_GRPC_URL"
+	envUsersTimeout    = "MF_USERS_GRPC_TIMEOUT"
 )
 
 type config struct {
@@ -93,6 +105,9 @@ type config struct {
 	cacheURL        string
 	cachePass       string
 	cacheDB         string
+	auditURL        string
+	auditPass       string
+	auditDB         string
 	singleUserEmail string
 	singleUserToken string
 	clientTLS       bool
@@ -102,6 +117,8 @@ type config struct {
 
 	authnURL     string
 	authnTimeout time.Duration
+	usersURL     string
+	usersTimeout time.Duration
 }
 
 func main() {
@@ -112,6 +129,11 @@ func main() {
 		log.Fatalf(err.Error())
 	}
 
+	if err := audit.LoadTrustedProxies(); err != nil {
+		logger.Error(fmt.Sprintf("Failed to load trusted proxies: %s", err))
+		os.Exit(1)
+	}
+
 	cacheClient := connectToRedis(cfg.cacheURL, cfg.cachePass, cfg.cacheDB, logger)
 	cacheTracer, cacheCloser := initJaeger("twins_cache", cfg.jaegerURL, logger)
 	defer cacheCloser.Close()
@@ -128,6 +150,13 @@ func main() {
 	defer authCloser.Close()
 	auth, _ := createAuthClient(cfg, authTracer, logger)
 
+	usersTracer, usersCloser := initJaeger("users", cfg.jaegerURL, logger)
+	defer usersCloser.Close()
+	users, usersClose := createUsersClient(cfg, usersTracer, logger)
+	if usersClose != nil {
+		defer usersClose()
+	}
+
 	pubSub, err := nats.NewPubSub(cfg.natsURL, queue, logger)
 	if err != nil {
 		logger.Error(fmt.Sprintf("Failed to connect to NATS: %s", err))
@@ -135,7 +164,13 @@ func main() {
 	}
 	defer pubSub.Close()
 
-	svc := newService(pubSub, cfg.channelID, auth, dbTracer, db, cacheTracer, cacheClient, logger)
+	// Auditing is disabled unless the audit events stream is configured.
+	var auditClient *redis.Client
+	if cfg.auditURL != "" {
+		auditClient = connectToRedis(cfg.auditURL, cfg.auditPass, cfg.auditDB, logger)
+	}
+
+	svc := newService(pubSub, cfg.channelID, auth, users, dbTracer, db, cacheTracer, cacheClient, auditClient, logger)
 
 	tracer, closer := initJaeger("twins", cfg.jaegerURL, logger)
 	defer closer.Close()
@@ -163,6 +198,11 @@ func loadConfig() config {
 		log.Fatalf("Invalid %s value: %s", envAuthnTimeout, err.Error())
 	}
 
+	usersTimeout, err := time.ParseDuration(mainflux.Env(envUsersTimeout, defUsersTimeout))
+	if err != nil {
+		log.Fatalf("Invalid %s value: %s", envUsersTimeout, err.Error())
+	}
+
 	dbCfg := twmongodb.Config{
 		Name: mainflux.Env(envDB, defDB),
 		Host: mainflux.Env(envDBHost, defDBHost),
@@ -179,6 +219,9 @@ func loadConfig() config {
 		cacheURL:        mainflux.Env(envCacheURL, defCacheURL),
 		cachePass:       mainflux.Env(envCachePass, defCachePass),
 		cacheDB:         mainflux.Env(envCacheDB, defCacheDB),
+		auditURL:        mainflux.Env(envAuditURL, defAuditURL),
+		auditPass:       mainflux.Env(envAuditPass, defAuditPass),
+		auditDB:         mainflux.Env(envAuditDB, defAuditDB),
 		singleUserEmail: mainflux.Env(envSingleUserEmail, defSingleUserEmail),
 		singleUserToken: mainflux.Env(envSingleUserToken, defSingleUserToken),
 		clientTLS:       tls,
@@ -187,6 +230,8 @@ func loadConfig() config {
 		natsURL:         mainflux.Env(envNatsURL, defNatsURL),
 		authnURL:        mainflux.Env(envAuthnURL, defAuthnURL),
 		authnTimeout:    authnTimeout,
+		usersURL:        mainflux.Env(envUsersURL, defUsersURL),
+		usersTimeout:    usersTimeout,
 	}
 }
 
@@ -219,11 +264,20 @@ func createAuthClient(cfg config, tracer opentracing.Tracer, logger logger.Logge
 		return localusers.NewSingleUserService(cfg.singleUserEmail, cfg.singleUserToken), nil
 	}
 
-	conn := connectToAuth(cfg, logger)
+	conn := connectToGRPC(cfg, "authn", cfg.authnURL, logger)
 	return authapi.NewClient(tracer, conn, cfg.authnTimeout), conn.Close
 }
 
-func connectToAuth(cfg config, logger logger.Logger) *grpc.ClientConn {
+func createUsersClient(cfg config, tracer opentracing.Tracer, logger logger.Logger) (mainflux.UsersServiceClient, func() error) {
+	if cfg.singleUserEmail != "" && cfg.singleUserToken != "" {
+		return localusers.NewSingleUserGroups(), nil
+	}
+
+	conn := connectToGRPC(cfg, "users", cfg.usersURL, logger)
+	return usersapi.NewClient(conn, tracer, cfg.usersTimeout), conn.Close
+}
+
+func connectToGRPC(cfg config, svcName, url string, logger logger.Logger) *grpc.ClientConn {
 	var opts []grpc.DialOption
 	if cfg.clientTLS {
 		if cfg.caCerts != "" {
@@ -239,9 +293,9 @@ func connectToAuth(cfg config, logger logger.Logger) *grpc.ClientConn {
 		logger.Info("gRPC communication is not encrypted")
 	}
 
-	conn, err := grpc.Dial(cfg.authnURL, opts...)
+	conn, err := grpc.Dial(url, opts...)
 	if err != nil {
-		logger.Error(fmt.Sprintf("Failed to connect to authn service: %s", err))
+		logger.Error(fmt.Sprintf("Failed to connect to %s service: %s", svcName, err))
 		os.Exit(1)
 	}
 
@@ -262,7 +316,7 @@ func connectToRedis(cacheURL, cachePass, cacheDB string, logger logger.Logger) *
 	})
 }
 
-func newService(ps messaging.PubSub, chanID string, users mainflux.AuthNServiceClient, dbTracer opentracing.Tracer, db *mongo.Database, cacheTracer opentracing.Tracer, cacheClient *redis.Client, logger logger.Logger) twins.Service {
+func newService(ps messaging.PubSub, chanID string, auth mainflux.AuthNServiceClient, users mainflux.UsersServiceClient, dbTracer opentracing.Tracer, db *mongo.Database, cacheTracer opentracing.Tracer, cacheClient *redis.Client, auditClient *redis.Client, logger logger.Logger) twins.Service {
 	twinRepo := twmongodb.NewTwinRepository(db)
 	twinRepo = tracing.TwinRepositoryMiddleware(dbTracer, twinRepo)
 
@@ -273,7 +327,11 @@ func newService(ps messaging.PubSub, chanID string, users mainflux.AuthNServiceC
 	twinCache := rediscache.NewTwinCache(cacheClient)
 	twinCache = tracing.TwinCacheMiddleware(cacheTracer, twinCache)
 
-	svc := twins.New(ps, users, twinRepo, twinCache, stateRepo, up, chanID, logger)
+	svc := twins.New(ps, auth, users, twinRepo, twinCache, stateRepo, up, chanID, logger)
+	if auditClient != nil {
+		rec := audit.NewRecorder("twins", audit.NewIdentifier(auth), audit.NewPublisher(auditClient), logger)
+		svc = api.AuditMiddleware(svc, rec)
+	}
 	svc = api.LoggingMiddleware(svc, logger)
 	svc = api.MetricsMiddleware(
 		svc,
@@ -9,10 +9,12 @@ import (
 	"io"
 	"io/ioutil"
 	"log"
+	"net"
 	"net/http"
 	"os"
 	"os/signal"
 	"strconv"
+	"strings"
 	"syscall"
 	"time"
 
@@ -20,23 +22,30 @@ import (
 	"github.com/mainflux/mainflux/users"
 	"github.com/mainflux/mainflux/users/bcrypt"
 	"github.com/mainflux/mainflux/users/emailer"
+	"github.com/mainflux/mainflux/users/oidc"
 	"github.com/mainflux/mainflux/users/tracing"
 	"google.golang.org/grpc"
 	"google.golang.org/grpc/credentials"
 
 	kitprometheus "github.com/go-kit/kit/metrics/prometheus"
+	"github.com/go-redis/redis"
 	"github.com/jmoiron/sqlx"
 	"github.com/mainflux/mainflux"
 	authapi "github.com/mainflux/mainflux/authn/api/grpc"
 	"github.com/mainflux/mainflux/logger"
+	"github.com/mainflux/mainflux/pkg/audit"
 	"github.com/mainflux/mainflux/users/api"
+	grpcapi "github.com/mainflux/mainflux/users/api/grpc"
 	"github.com/mainflux/mainflux/users/postgres"
+	rediscache "github.com/mainflux/mainflux/users/redis"
 	opentracing "github.com/opentracing/opentracing-go"
 	stdprometheus "github.com/prometheus/client_golang/prometheus"
 	jconfig "github.com/uber/jaeger-client-go/config"
 )
 
 const (
+	sep = ","
+
 	defLogLevel      = "error"
 	defDBHost        = "localhost"
 	defDBPort        = "5432"
@@ -48,6 +57,7 @@ const (
 	defDBSSLKey      = ""
 	defDBSSLRootCert = ""
 	defHTTPPort      = "8180"
+	defGRPCPort      = "8181"
 	defServerCert    = ""
 	defServerKey     = ""
 	defJaegerURL     = ""
@@ -71,6 +81,35 @@ const (
 	defAuthnURL     = "localhost:8181"
 	defAuthnTimeout = "1s"
 
+	defOIDCIssuer         = ""
+	defOIDCClientID       = ""
+	defOIDCClientSecret   = ""
+	defOIDCRedirectURL    = ""
+	defOIDCScopes         = "openid,email,profile"
+	defOIDCGroupsClaim    = "groups"
+	defOIDCMetadataClaims = "name"
+	defOIDCTimeout        = "10s"
+
+	defCacheURL           = ""
+	defCachePass          = ""
+	defCacheDB            = "0"
+	defLoginMaxAttempts   = "5"
+	defLoginMaxIPAttempts = "20"
+	defLoginDelay         = "1s"
+	defLoginLockout       = "15m"
+	defAuditURL           = ""
+	defAuditPass          = ""
+	defAuditDB            = "0"
+
+	defVerifyEmail                = "false"
+	defVerificationURL            = "http://localhost/verify-email"
+	defVerificationDuration       = "24h"
+	defVerificationResendInterval = "1m"
+
+	defInvitationSecret   = "invitations"
+	defInvitationURL      = "http://localhost/accept-invitation"
+	defInvitationDuration = "72h"
+
 	envLogLevel      = "MF_USERS_LOG_LEVEL"
 	envDBHost        = "MF_USERS_DB_HOST"
 	envDBPort        = "MF_USERS_DB_PORT"
@@ -82,6 +121,7 @@ const (
 	envDBSSLKey      = "MF_USERS_DB_SSL_KEY"
 	envDBSSLRootCert = "MF_USERS_DB_SSL_ROOT_CERT"
 	envHTTPPort      = "MF_USERS_HTTP_PORT"
+	envGRPCPort      = "MF_USERS_GRPC_PORT"
 	envServerCert    = "MF_USERS_SERVER_CERT"
 	envServerKey     = "MF_USERS_SERVER_KEY"
 	envJaegerURL     = "MF_JAEGER_URL"
@@ -105,6 +145,35 @@ const (
 	envAuthnCACerts = "MF_AUTHN_CA_CERTS"
 	envAuthnURL     = "MF_AUTHN_GRPC_URL"
 	envAuthnTimeout = "MF_AUTHN_GRPC_TIMEOUT"
+
+	envOIDCIssuer         = "MF_USERS_OIDC_ISSUER"
+	envOIDCClientID       = "MF_USERS_OIDC_CLIENT_ID"
+	envOIDCClientSecret   = "MF_USERS_OIDC_CLIENT_SECRET"
+	envOIDCRedirectURL    = "MF_USERS_OIDC_REDIRECT_URL"
+	envOIDCScopes         = "MF_USERS_OIDC_SCOPES"
+	envOIDCGroupsClaim    = "MF_USERS_OIDC_GROUPS_CLAIM"
+	envOIDCMetadataClaims = "MF_USERS_OIDC_METADATA_CLAIMS"
+	envOIDCTimeout        = "MF_USERS_OIDC_TIMEOUT"
+
+	envCacheURL           = "MF_USERS_CACHE_URL"
+	envCachePass          = "MF_USERS_CACHE_PASS"
+	envCacheDB            = "MF_USERS_CACHE_DB"
+	envLoginMaxAttempts   = "MF_USERS_LOGIN_MAX_ATTEMPTS"
+	envLoginMaxIPAttempts = "MF_USERS_LOGIN_MAX_IP_ATTEMPTS"
+	envLoginDelay         = "MF_USERS_LOGIN_DELAY"
+	envLoginLockout       = "MF_USERS_LOGIN_LOCKOUT"
+	envAuditURL           = "MF_USERS_AUDIT_URL"
+	envAuditPass          = "MF_USERS_AUDIT_PASS"
+	envAuditDB            = "MF_USERS_AUDIT_DB"
+
+	envVerifyEmail                = "MF_USERS_VERIFY_EMAIL"
+	envVerificationURL            = "MF_USERS_VERIFICATION_URL"
+	envVerificationDuration       = "MF_USERS_VERIFICATION_DURATION"
+	envVerificationResendInterval = "MF_USERS_VERIFICATION_RESEND_INTERVAL"
+
+	envInvitationSecret   = "MF_USERS_INVITATION_SECRET"
+	envInvitationURL      = "MF_USERS_INVITATION_URL"
+	envInvitationDuration = "MF_USERS_INVITATION_DURATION"
 )
 
 type config struct {
@@ -112,6 +181,7 @@ type config struct {
 	dbConfig      postgres.Config
 	emailConf     email.Config
 	httpPort      string
+	grpcPort      string
 	serverCert    string
 	serverKey     string
 	jaegerURL     string
@@ -122,6 +192,19 @@ type config struct {
 	authnTimeout  time.Duration
 	adminEmail    string
 	adminPassword string
+	oidcConf      oidc.Config
+	oidcTimeout   time.Duration
+	cacheURL      string
+	cachePass     string
+	cacheDB       string
+	auditURL      string
+	auditPass     string
+	auditDB       string
+	lockout       users.LockoutPolicy
+	verify        users.VerificationPolicy
+	verifyURL     string
+	invite        users.InvitationPolicy
+	inviteURL     string
 }
 
 func main() {
@@ -131,6 +214,11 @@ func main() {
 	if err != nil {
 		log.Fatalf(err.Error())
 	}
+
+	if err := audit.LoadTrustedProxies(); err != nil {
+		logger.Error(fmt.Sprintf("Failed to load trusted proxies: %s", err))
+		os.Exit(1)
+	}
 	db := connectToDB(cfg.dbConfig, logger)
 	defer db.Close()
 
@@ -148,10 +236,21 @@ func main() {
 	dbTracer, dbCloser := initJaeger("users_db", cfg.jaegerURL, logger)
 	defer dbCloser.Close()
 
-	svc := newService(db, dbTracer, auth, cfg, logger)
+	cacheClient := connectToRedis(cfg.cacheURL, cfg.cachePass, cfg.cacheDB, logger)
+	if cacheClient != nil {
+		defer cacheClient.Close()
+	}
+
+	auditClient := connectToRedis(cfg.auditURL, cfg.auditPass, cfg.auditDB, logger)
+	if auditClient != nil {
+		defer auditClient.Close()
+	}
+
+	svc := newService(db, cacheClient, auditClient, dbTracer, auth, cfg, logger)
 	errs := make(chan error, 2)
 
 	go startHTTPServer(tracer, svc, cfg.httpPort, cfg.serverCert, cfg.serverKey, logger, errs)
+	go startGRPCServer(tracer, svc, cfg.grpcPort, cfg.serverCert, cfg.serverKey, logger, errs)
 
 	go func() {
 		c := make(chan os.Signal)
@@ -197,11 +296,85 @@ func loadConfig() config {
 		Template:    mainflux.Env(envEmailTemplate, defEmailTemplate),
 	}
 
+	oidcTimeout, err := time.ParseDuration(mainflux.Env(envOIDCTimeout, defOIDCTimeout))
+	if err != nil {
+		log.Fatalf("Invalid %s value: %s", envOIDCTimeout, err.Error())
+	}
+
+	oidcConf := oidc.Config{
+		Issuer:         mainflux.Env(envOIDCIssuer, defOIDCIssuer),
+		ClientID:       mainflux.Env(envOIDCClientID, defOIDCClientID),
+		ClientSecret:   mainflux.Env(envOIDCClientSecret, defOIDCClientSecret),
+		RedirectURL:    mainflux.Env(envOIDCRedirectURL, defOIDCRedirectURL),
+		Scopes:         strings.Split(mainflux.Env(envOIDCScopes, defOIDCScopes), sep),
+		GroupsClaim:    mainflux.Env(envOIDCGroupsClaim, defOIDCGroupsClaim),
+		MetadataClaims: strings.Split(mainflux.Env(envOIDCMetadataClaims, defOIDCMetadataClaims), sep),
+	}
+
+	maxAttempts, err := strconv.ParseUint(mainflux.Env(envLoginMaxAttempts, defLoginMaxAttempts), 10, 64)
+	if err != nil {
+		log.Fatalf("Invalid %s value: %s", envLoginMaxAttempts, err.Error())
+	}
+
+	maxIPAttempts, err := strconv.ParseUint(mainflux.Env(envLoginMaxIPAttempts, defLoginMaxIPAttempts), 10, 64)
+	if err != nil {
+		log.Fatalf("Invalid %s value: %s", envLoginMaxIPAttempts, err.Error())
+	}
+
+	loginDelay, err := time.ParseDuration(mainflux.Env(envLoginDelay, defLoginDelay))
+	if err != nil {
+		log.Fatalf("Invalid %s value: %s", envLoginDelay, err.Error())
+	}
+
+	loginLockout, err := time.ParseDuration(mainflux.Env(envLoginLockout, defLoginLockout))
+	if err != nil {
+		log.Fatalf("Invalid %s value: %s", envLoginLockout, err.Error())
+	}
+
+	lockout := users.LockoutPolicy{
+		MaxAttempts:   maxAttempts,
+		MaxIPAttempts: maxIPAttempts,
+		Delay:         loginDelay,
+		Duration:      loginLockout,
+	}
+
+	verifyEmail, err := strconv.ParseBool(mainflux.Env(envVerifyEmail, defVerifyEmail))
+	if err != nil {
+		log.Fatalf("Invalid %s value: %s", envVerifyEmail, err.Error())
+	}
+
+	verificationDuration, err := time.ParseDuration(mainflux.Env(envVerificationDuration, defVerificationDuration))
+	if err != nil {
+		log.Fatalf("Invalid %s value: %s", envVerificationDuration, err.Error())
+	}
+
+	resendInterval, err := time.ParseDuration(mainflux.Env(envVerificationResendInterval, defVerificationResendInterval))
+	if err != nil {
+		log.Fatalf("Invalid %s value: %s", envVerificationResendInterval, err.Error())
+	}
+
+	verify := users.VerificationPolicy{
+		Enabled:        verifyEmail,
+		Duration:       verificationDuration,
+		ResendInterval: resendInterval,
+	}
+
+	invitationDuration, err := time.ParseDuration(mainflux.Env(envInvitationDuration, defInvitationDuration))
+	if err != nil {
+		log.Fatalf("Invalid %s value: %s", envInvitationDuration, err.Error())
+	}
+
+	invite := users.InvitationPolicy{
+		Secret:   mainflux.Env(envInvitationSecret, defInvitationSecret),
+		Duration: invitationDuration,
+	}
+
 	return config{
 		logLevel:      mainflux.Env(envLogLevel, defLogLevel),
 		dbConfig:      dbConfig,
 		emailConf:     emailConf,
 		httpPort:      mainflux.Env(envHTTPPort, defHTTPPort),
+		grpcPort:      mainflux.Env(envGRPCPort, defGRPCPort),
 		serverCert:    mainflux.Env(envServerCert, defServerCert),
 		serverKey:     mainflux.Env(envServerKey, defServerKey),
 		jaegerURL:     mainflux.Env(envJaegerURL, defJaegerURL),
@@ -212,6 +385,19 @@ func loadConfig() config {
 		authnTimeout:  authnTimeout,
 		adminEmail:    mainflux.Env(envAdminEmail, defAdminEmail),
 		adminPassword: mainflux.Env(envAdminPassword, defAdminPassword),
+		oidcConf:      oidcConf,
+		oidcTimeout:   oidcTimeout,
+		cacheURL:      mainflux.Env(envCacheURL, defCacheURL),
+		cachePass:     mainflux.Env(envCachePass, defCachePass),
+		cacheDB:       mainflux.Env(envCacheDB, defCacheDB),
+		auditURL:      mainflux.Env(envAuditURL, defAuditURL),
+		auditPass:     mainflux.Env(envAuditPass, defAuditPass),
+		auditDB:       mainflux.Env(envAuditDB, defAuditDB),
+		lockout:       lockout,
+		verify:        verify,
+		verifyURL:     mainflux.Env(envVerificationURL, defVerificationURL),
+		invite:        invite,
+		inviteURL:     mainflux.Env(envInvitationURL, defInvitationURL),
 	}
 
 }
@@ -248,6 +434,27 @@ func connectToDB(dbConfig postgres.Config, logger logger.Logger) *sqlx.DB {
 	return db
 }
 
+// connectToRedis connects to the store of the failed login attempts or to
+// the audit events stream. Empty URL disables the store, in which case nil
+// client is returned.
+func connectToRedis(redisURL, redisPass, redisDB string, logger logger.Logger) *redis.Client {
+	if redisURL == "" {
+		return nil
+	}
+
+	db, err := strconv.Atoi(redisDB)
+	if err != nil {
+		logger.Error(fmt.Sprintf("Failed to connect to redis: %s", err))
+		os.Exit(1)
+	}
+
+	return redis.NewClient(&redis.Options{
+		Addr:     redisURL,
+		Password: redisPass,
+		DB:       db,
+	})
+}
+
 func connectToAuthn(cfg config, tracer opentracing.Tracer, logger logger.Logger) (mainflux.AuthNServiceClient, func() error) {
 	var opts []grpc.DialOption
 	if cfg.authnTLS {
@@ -273,18 +480,48 @@ func connectToAuthn(cfg config, tracer opentracing.Tracer, logger logger.Logger)
 	return authapi.NewClient(tracer, conn, cfg.authnTimeout), conn.Close
 }
 
-func newService(db *sqlx.DB, tracer opentracing.Tracer, auth mainflux.AuthNServiceClient, c config, logger logger.Logger) users.Service {
+// connectToOIDC retrieves the configuration of the OpenID Connect provider.
+// Login using the provider is disabled if the issuer is not set.
+func connectToOIDC(c config, logger logger.Logger) users.IdentityProvider {
+	if c.oidcConf.Issuer == "" {
+		return nil
+	}
+
+	idp, err := oidc.New(c.oidcConf, &http.Client{Timeout: c.oidcTimeout})
+	if err != nil {
+		logger.Error(fmt.Sprintf("Failed to connect to OpenID Connect provider: %s", err))
+		os.Exit(1)
+	}
+	return idp
+}
+
+func newService(db *sqlx.DB, cacheClient, auditClient *redis.Client, tracer opentracing.Tracer, auth mainflux.AuthNServiceClient, c config, logger logger.Logger) users.Service {
 	database := postgres.NewDatabase(db)
 	hasher := bcrypt.New()
 	userRepo := tracing.UserRepositoryMiddleware(postgres.NewUserRepo(database), tracer)
 	groupRepo := tracing.GroupRepositoryMiddleware(postgres.NewGroupRepo(database), tracer)
+	invitationRepo := tracing.InvitationRepositoryMiddleware(postgres.NewInvitationRepo(database), tracer)
+	impersonationRepo := tracing.ImpersonationRepositoryMiddleware(postgres.NewImpersonationRepo(database), tracer)
 
-	emailer, err := emailer.New(c.resetURL, &c.emailConf)
+	emailer, err := emailer.New(c.resetURL, c.verifyURL, c.inviteURL, &c.emailConf)
 	if err != nil {
 		logger.Error(fmt.Sprintf("Failed to configure e-mailing util: %s", err.Error()))
 	}
 
-	svc := users.New(userRepo, groupRepo, hasher, auth, emailer)
+	idp := connectToOIDC(c, logger)
+
+	// Failed login attempts are kept in memory, unless they are shared by
+	// the service instances using Redis.
+	attempts := users.NewAttemptRepository()
+	if cacheClient != nil {
+		attempts = rediscache.NewAttemptRepository(cacheClient)
+	}
+
+	svc := users.New(userRepo, groupRepo, invitationRepo, impersonationRepo, hasher, auth, emailer, idp, attempts, c.lockout, c.verify, c.invite, c.adminEmail)
+	if auditClient != nil {
+		rec := audit.NewRecorder("users", audit.NewIdentifier(auth), audit.NewPublisher(auditClient), logger)
+		svc = api.AuditMiddleware(svc, rec)
+	}
 	svc = api.LoggingMiddleware(svc, logger)
 	svc = api.MetricsMiddleware(
 		svc,
@@ -300,6 +537,12 @@ func newService(db *sqlx.DB, tracer opentracing.Tracer, auth mainflux.AuthNServi
 			Name:      "request_latency_microseconds",
 			Help:      "Total duration of requests in microseconds.",
 		}, []string{"method"}),
+		kitprometheus.NewCounterFrom(stdprometheus.CounterOpts{
+			Namespace: "users",
+			Subsystem: "api",
+			Name:      "login_lockouts",
+			Help:      "Number of login lockouts and logins rejected because of them.",
+		}, []string{"event"}),
 	)
 	if err := createAdmin(svc, userRepo, groupRepo, c); err != nil {
 		logger.Error("failed to create admin user: " + err.Error())
@@ -336,3 +579,29 @@ func startHTTPServer(tracer opentracing.Tracer, svc users.Service, port string,
 		errs <- http.ListenAndServe(p, api.MakeHandler(svc, tracer))
 	}
 }
+
+func startGRPCServer(tracer opentracing.Tracer, svc users.Service, port string, certFile string, keyFile string, logger logger.Logger, errs chan error) {
+	p := fmt.Sprintf(":%s", port)
+	listener, err := net.Listen("tcp", p)
+	if err != nil {
+		logger.Error(fmt.Sprintf("Failed to listen on port %s: %s", port, err))
+		os.Exit(1)
+	}
+
+	var server *grpc.Server
+	if certFile != "" || keyFile != "" {
+		creds, err := credentials.NewServerTLSFromFile(certFile, keyFile)
+		if err != nil {
+			logger.Error(fmt.Sprintf("Failed to load users certificates: %s", err))
+			os.Exit(1)
+		}
+		logger.Info(fmt.Sprintf("Users gRPC service started using https on port %s with cert %s key %s", port, certFile, keyFile))
+		server = grpc.NewServer(grpc.Creds(creds))
+	} else {
+		logger.Info(fmt.Sprintf("Users gRPC service started using http on port %s", port))
+		server = grpc.NewServer()
+	}
+
+	mainflux.RegisterUsersServiceServer(server, grpcapi.NewServer(tracer, svc))
+	errs <- server.Serve(listener)
+}
@@ -11,12 +11,12 @@ import (
 	"fmt"
 	"sync"
 
-	"github.com/gogo/protobuf/proto"
 	"github.com/mainflux/mainflux/pkg/errors"
 	broker "github.com/nats-io/nats.go"
 
 	"github.com/mainflux/mainflux"
 	"github.com/mainflux/mainflux/pkg/messaging"
+	"github.com/mainflux/mainflux/things"
 )
 
 const chansPrefix = "channels"
@@ -46,15 +46,18 @@ var _ Service = (*adapterService)(nil)
 type adapterService struct {
 	auth      mainflux.ThingsServiceClient
 	conn      *broker.Conn
+	publisher messaging.Publisher
 	observers map[string]observers
 	obsLock   sync.Mutex
 }
 
-// New instantiates the CoAP adapter implementation.
-func New(auth mainflux.ThingsServiceClient, nc *broker.Conn) Service {
+// New instantiates the CoAP adapter implementation. Messages are published
+// using the given publisher, while observers subscribe using the connection.
+func New(auth mainflux.ThingsServiceClient, nc *broker.Conn, publisher messaging.Publisher) Service {
 	as := &adapterService{
 		auth:      auth,
 		conn:      nc,
+		publisher: publisher,
 		observers: make(map[string]observers),
 		obsLock:   sync.Mutex{},
 	}
@@ -66,6 +69,7 @@ func (svc *adapterService) Publish(ctx context.Context, key string, msg messagin
 	ar := &mainflux.AccessByKeyReq{
 		Token:  key,
 		ChanID: msg.Channel,
+		Action: things.ActionPublish,
 	}
 	thid, err := svc.auth.CanAccessByKey(ctx, ar)
 	if err != nil {
@@ -73,23 +77,14 @@ func (svc *adapterService) Publish(ctx context.Context, key string, msg messagin
 	}
 	msg.Publisher = thid.GetValue()
 
-	data, err := proto.Marshal(&msg)
-	if err != nil {
-		return err
-	}
-
-	subject := fmt.Sprintf("%s.%s", chansPrefix, msg.Channel)
-	if msg.Subtopic != "" {
-		subject = fmt.Sprintf("%s.%s", subject, msg.Subtopic)
-	}
-
-	return svc.conn.Publish(subject, data)
+	return svc.publisher.Publish(msg.Channel, msg)
 }
 
 func (svc *adapterService) Subscribe(ctx context.Context, key, chanID, subtopic string, c Client) error {
 	ar := &mainflux.AccessByKeyReq{
 		Token:  key,
 		ChanID: chanID,
+		Action: things.ActionSubscribe,
 	}
 	if _, err := svc.auth.CanAccessByKey(ctx, ar); err != nil {
 		return errors.Wrap(ErrUnauthorized, err)
@@ -117,6 +112,7 @@ func (svc *adapterService) Unsubscribe(ctx context.Context, key, chanID, subtopi
 	ar := &mainflux.AccessByKeyReq{
 		Token:  key,
 		ChanID: chanID,
+		Action: things.ActionSubscribe,
 	}
 	if _, err := svc.auth.CanAccessByKey(ctx, ar); err != nil {
 		return errors.Wrap(ErrUnauthorized, err)
@@ -20,6 +20,7 @@ import (
 	"github.com/mainflux/mainflux/coap"
 	log "github.com/mainflux/mainflux/logger"
 	"github.com/mainflux/mainflux/pkg/messaging"
+	"github.com/mainflux/mainflux/pkg/schema"
 	"github.com/plgd-dev/go-coap/v2/message"
 	"github.com/plgd-dev/go-coap/v2/message/codes"
 	"github.com/plgd-dev/go-coap/v2/mux"
@@ -116,6 +117,8 @@ func handler(w mux.ResponseWriter, m *mux.Message) {
 			return
 		case errors.Contains(err, coap.ErrUnsubscribe):
 			resp.Code = codes.InternalServerError
+		case errors.Contains(err, schema.ErrInvalidPayload):
+			resp.Code = codes.BadRequest
 		}
 	}
 }
@@ -10,6 +10,7 @@ import (
 
 	"github.com/mainflux/mainflux"
 	"github.com/mainflux/mainflux/pkg/messaging"
+	"github.com/mainflux/mainflux/things"
 )
 
 // Service specifies coap service API.
@@ -37,6 +38,7 @@ func (as *adapterService) Publish(ctx context.Context, token string, msg messagi
 	ar := &mainflux.AccessByKeyReq{
 		Token:  token,
 		ChanID: msg.Channel,
+		Action: things.ActionPublish,
 	}
 	thid, err := as.things.CanAccessByKey(ctx, ar)
 	if err != nil {
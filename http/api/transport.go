@@ -5,13 +5,10 @@ package api
 
 import (
 	"context"
-	"errors"
 	"io"
 	"io/ioutil"
 	"net/http"
-	"net/url"
 	"regexp"
-	"strings"
 	"time"
 
 	kitot "github.com/go-kit/kit/tracing/opentracing"
@@ -19,7 +16,9 @@ import (
 	"github.com/go-zoo/bone"
 	"github.com/mainflux/mainflux"
 	adapter "github.com/mainflux/mainflux/http"
+	"github.com/mainflux/mainflux/pkg/errors"
 	"github.com/mainflux/mainflux/pkg/messaging"
+	"github.com/mainflux/mainflux/pkg/schema"
 	"github.com/mainflux/mainflux/things"
 	opentracing "github.com/opentracing/opentracing-go"
 	"github.com/prometheus/client_golang/prometheus/promhttp"
@@ -29,10 +28,7 @@ import (
 
 const protocol = "http"
 
-var (
-	errMalformedData     = errors.New("malformed request data")
-	errMalformedSubtopic = errors.New("malformed subtopic")
-)
+var errMalformedData = errors.New("malformed request data")
 
 var channelPartRegExp = regexp.MustCompile(`^/channels/([\w\-]+)/messages(/[^?]*)?(\?.*)?$`)
 
@@ -63,35 +59,6 @@ func MakeHandler(svc adapter.Service, tracer opentracing.Tracer) http.Handler {
 	return r
 }
 
-func parseSubtopic(subtopic string) (string, error) {
-	if subtopic == "" {
-		return subtopic, nil
-	}
-
-	subtopic, err := url.QueryUnescape(subtopic)
-	if err != nil {
-		return "", errMalformedSubtopic
-	}
-	subtopic = strings.Replace(subtopic, "/", ".", -1)
-
-	elems := strings.Split(subtopic, ".")
-	filteredElems := []string{}
-	for _, elem := range elems {
-		if elem == "" {
-			continue
-		}
-
-		if len(elem) > 1 && (strings.Contains(elem, "*") || strings.Contains(elem, ">")) {
-			return "", errMalformedSubtopic
-		}
-
-		filteredElems = append(filteredElems, elem)
-	}
-
-	subtopic = strings.Join(filteredElems, ".")
-	return subtopic, nil
-}
-
 func decodeRequest(ctx context.Context, r *http.Request) (interface{}, error) {
 	channelParts := channelPartRegExp.FindStringSubmatch(r.RequestURI)
 	if len(channelParts) < 2 {
@@ -99,7 +66,7 @@ func decodeRequest(ctx context.Context, r *http.Request) (interface{}, error) {
 	}
 
 	chanID := bone.GetValue(r, "id")
-	subtopic, err := parseSubtopic(channelParts[2])
+	subtopic, err := messaging.ParseSubtopic(channelParts[2])
 	if err != nil {
 		return nil, err
 	}
@@ -142,11 +109,15 @@ func encodeResponse(_ context.Context, w http.ResponseWriter, response interface
 
 func encodeError(_ context.Context, err error, w http.ResponseWriter) {
 	switch err {
-	case errMalformedData, errMalformedSubtopic:
+	case errMalformedData, messaging.ErrMalformedSubtopic:
 		w.WriteHeader(http.StatusBadRequest)
 	case things.ErrUnauthorizedAccess:
 		w.WriteHeader(http.StatusForbidden)
 	default:
+		if errors.Contains(err, schema.ErrInvalidPayload) {
+			w.WriteHeader(http.StatusBadRequest)
+			return
+		}
 		if e, ok := status.FromError(err); ok {
 			switch e.Code() {
 			case codes.PermissionDenied:
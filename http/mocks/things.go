@@ -57,3 +57,11 @@ func (tc thingsClient) CanAccessByID(context.Context, *mainflux.AccessByIDReq, .
 func (tc thingsClient) Identify(ctx context.Context, req *mainflux.Token, opts ...grpc.CallOption) (*mainflux.ThingID, error) {
 	panic("not implemented")
 }
+
+func (tc thingsClient) IsChannelOwner(context.Context, *mainflux.ChannelOwnerReq, ...grpc.CallOption) (*empty.Empty, error) {
+	panic("not implemented")
+}
+
+func (tc thingsClient) ChannelSchema(context.Context, *mainflux.ChannelID, ...grpc.CallOption) (*mainflux.ChannelSchemaRes, error) {
+	panic("not implemented")
+}
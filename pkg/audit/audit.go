@@ -0,0 +1,169 @@
+// Copyright (c) Mainflux
+// SPDX-License-Identifier: Apache-2.0
+
+package audit
+
+import (
+	"context"
+	"crypto/sha256"
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/mainflux/mainflux"
+	"github.com/mainflux/mainflux/logger"
+)
+
+const (
+	identityTTL   = time.Minute
+	maxIdentities = 10000
+)
+
+// Event represents the management operation performed by the user.
+type Event struct {
+	Service      string
+	Operation    string
+	Actor        string
+	Impersonator string
+	KeyID        string
+	IP           string
+	Entity       string
+	Error        string
+	Created      time.Time
+}
+
+// Publisher specifies the audit events publishing API.
+type Publisher interface {
+	// Publish publishes the event.
+	Publish(ctx context.Context, e Event) error
+}
+
+// Identity represents the user the token is issued to, together with the
+// ID of the key the token belongs to.
+type Identity struct {
+	Email        string
+	Impersonator string
+	KeyID        string
+}
+
+// IdentifyFunc identifies the user performing the operation using the token.
+type IdentifyFunc func(ctx context.Context, token string) (Identity, error)
+
+// NewIdentifier returns the function identifying the users by the authn
+// service. Identities are cached for a minute, so recording the operations
+// doesn't make an extra authn call for every request of the same user.
+func NewIdentifier(auth mainflux.AuthNServiceClient) IdentifyFunc {
+	identify := func(ctx context.Context, token string) (Identity, error) {
+		res, err := auth.Identify(ctx, &mainflux.Token{Value: token})
+		if err != nil {
+			return Identity{}, err
+		}
+
+		return Identity{
+			Email:        res.GetEmail(),
+			Impersonator: res.GetImpersonator(),
+			KeyID:        res.GetKeyId(),
+		}, nil
+	}
+
+	return CacheIdentities(identify, identityTTL)
+}
+
+type cachedIdentity struct {
+	id      Identity
+	expires time.Time
+}
+
+// CacheIdentities returns the function caching the identities returned by
+// the provided one for the given duration. Failed identifications are not
+// cached. Tokens are kept hashed, and the cache is cleared once it is full,
+// so it doesn't grow unbounded.
+func CacheIdentities(identify IdentifyFunc, ttl time.Duration) IdentifyFunc {
+	var mu sync.Mutex
+	cache := make(map[[sha256.Size]byte]cachedIdentity)
+
+	return func(ctx context.Context, token string) (Identity, error) {
+		key := sha256.Sum256([]byte(token))
+
+		mu.Lock()
+		c, ok := cache[key]
+		mu.Unlock()
+		if ok && time.Now().Before(c.expires) {
+			return c.id, nil
+		}
+
+		id, err := identify(ctx, token)
+		if err != nil {
+			return Identity{}, err
+		}
+
+		mu.Lock()
+		if len(cache) >= maxIdentities {
+			cache = make(map[[sha256.Size]byte]cachedIdentity)
+		}
+		cache[key] = cachedIdentity{id: id, expires: time.Now().Add(ttl)}
+		mu.Unlock()
+
+		return id, nil
+	}
+}
+
+// Recorder records the operations performed on a single service.
+type Recorder struct {
+	service  string
+	identify IdentifyFunc
+	pub      Publisher
+	logger   logger.Logger
+}
+
+// NewRecorder returns the recorder of the operations performed on the
+// service.
+func NewRecorder(service string, identify IdentifyFunc, pub Publisher, logger logger.Logger) Recorder {
+	return Recorder{
+		service:  service,
+		identify: identify,
+		pub:      pub,
+		logger:   logger,
+	}
+}
+
+// Event returns the event of the operation on the entity, performed by the
+// user identified by the token. The user is identified before performing
+// the operation, since the operation may revoke the token. If the token
+// can't be identified, the actor is left empty.
+func (r Recorder) Event(ctx context.Context, token, operation, entity string) Event {
+	e := Event{
+		Operation: operation,
+		Entity:    entity,
+	}
+
+	id, err := r.identify(ctx, token)
+	if err != nil {
+		return e
+	}
+	e.Actor = id.Email
+	e.Impersonator = id.Impersonator
+	e.KeyID = id.KeyID
+
+	return e
+}
+
+// Record publishes the event of the performed operation, together with the
+// operation error, if any. Failed operations of the unidentified users are
+// not recorded, since these don't change anything. Failure to publish the
+// event doesn't fail the operation, so it's only logged.
+func (r Recorder) Record(ctx context.Context, e Event, err error) {
+	if err != nil {
+		if e.Actor == "" {
+			return
+		}
+		e.Error = err.Error()
+	}
+	e.Service = r.service
+	e.IP = IP(ctx)
+	e.Created = time.Now().UTC()
+
+	if err := r.pub.Publish(ctx, e); err != nil {
+		r.logger.Warn(fmt.Sprintf("Failed to record %s operation %s: %s", e.Service, e.Operation, err))
+	}
+}
@@ -0,0 +1,239 @@
+// Copyright (c) Mainflux
+// SPDX-License-Identifier: Apache-2.0
+
+package audit_test
+
+import (
+	"context"
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/mainflux/mainflux/logger"
+	"github.com/mainflux/mainflux/pkg/audit"
+	"github.com/mainflux/mainflux/pkg/errors"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const (
+	token = "token"
+	email = "user@example.com"
+	keyID = "key"
+	ip    = "10.0.0.1"
+)
+
+var errIdentify = errors.New("failed to identify")
+
+type publisher struct {
+	mu     sync.Mutex
+	events []audit.Event
+}
+
+func (p *publisher) Publish(_ context.Context, e audit.Event) error {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	p.events = append(p.events, e)
+	return nil
+}
+
+func identify(_ context.Context, t string) (audit.Identity, error) {
+	if t != token {
+		return audit.Identity{}, errIdentify
+	}
+	return audit.Identity{Email: email, KeyID: keyID}, nil
+}
+
+func TestRecord(t *testing.T) {
+	pub := &publisher{}
+	log, _ := logger.New(ioutil.Discard, logger.Error.String())
+	rec := audit.NewRecorder("things", identify, pub, log)
+	ctx := audit.WithIP(context.Background(), ip)
+
+	cases := []struct {
+		desc     string
+		token    string
+		err      error
+		recorded bool
+		event    audit.Event
+	}{
+		{
+			desc:     "record successful operation",
+			token:    token,
+			err:      nil,
+			recorded: true,
+			event:    audit.Event{Service: "things", Operation: "create_things", Actor: email, KeyID: keyID, IP: ip, Entity: "1"},
+		},
+		{
+			desc:     "record failed operation",
+			token:    token,
+			err:      errIdentify,
+			recorded: true,
+			event:    audit.Event{Service: "things", Operation: "create_things", Actor: email, KeyID: keyID, IP: ip, Entity: "1", Error: errIdentify.Error()},
+		},
+		{
+			desc:     "record successful operation of unidentified user",
+			token:    "",
+			err:      nil,
+			recorded: true,
+			event:    audit.Event{Service: "things", Operation: "create_things", IP: ip, Entity: "1"},
+		},
+		{
+			desc:     "record failed operation of unidentified user",
+			token:    "",
+			err:      errIdentify,
+			recorded: false,
+		},
+	}
+
+	for _, tc := range cases {
+		pub.events = nil
+		e := rec.Event(ctx, tc.token, "create_things", "1")
+		rec.Record(ctx, e, tc.err)
+		if !tc.recorded {
+			assert.Empty(t, pub.events, fmt.Sprintf("%s: expected no events", tc.desc))
+			continue
+		}
+		if assert.Len(t, pub.events, 1, fmt.Sprintf("%s: expected single event", tc.desc)) {
+			got := pub.events[0]
+			assert.False(t, got.Created.IsZero(), fmt.Sprintf("%s: expected creation time", tc.desc))
+			got.Created = tc.event.Created
+			assert.Equal(t, tc.event, got, fmt.Sprintf("%s: expected %v got %v", tc.desc, tc.event, got))
+		}
+	}
+}
+
+func TestCacheIdentities(t *testing.T) {
+	calls := 0
+	identify := audit.CacheIdentities(func(ctx context.Context, t string) (audit.Identity, error) {
+		calls++
+		return identify(ctx, t)
+	}, time.Minute)
+
+	cases := []struct {
+		desc  string
+		token string
+		calls int
+		err   error
+	}{
+		{
+			desc:  "identify token",
+			token: token,
+			calls: 1,
+			err:   nil,
+		},
+		{
+			desc:  "identify cached token",
+			token: token,
+			calls: 1,
+			err:   nil,
+		},
+		{
+			desc:  "identify invalid token",
+			token: "invalid",
+			calls: 2,
+			err:   errIdentify,
+		},
+		{
+			desc:  "identify invalid token again",
+			token: "invalid",
+			calls: 3,
+			err:   errIdentify,
+		},
+	}
+
+	for _, tc := range cases {
+		id, err := identify(context.Background(), tc.token)
+		assert.True(t, errors.Contains(err, tc.err), fmt.Sprintf("%s: expected %s got %s", tc.desc, tc.err, err))
+		if tc.err == nil {
+			assert.Equal(t, email, id.Email, fmt.Sprintf("%s: expected %s got %s", tc.desc, email, id.Email))
+		}
+		assert.Equal(t, tc.calls, calls, fmt.Sprintf("%s: expected %d calls got %d", tc.desc, tc.calls, calls))
+	}
+}
+
+func TestClientIP(t *testing.T) {
+	err := audit.SetTrustedProxies([]string{"127.0.0.1", "172.16.0.0/12"})
+	require.Nil(t, err, fmt.Sprintf("unexpected error: %s", err))
+	defer audit.SetTrustedProxies(nil)
+
+	cases := []struct {
+		desc   string
+		header string
+		remote string
+		ip     string
+	}{
+		{
+			desc:   "address set by trusted proxy",
+			header: ip,
+			remote: "127.0.0.1:1234",
+			ip:     ip,
+		},
+		{
+			desc:   "address set by proxy in trusted range",
+			header: ip,
+			remote: "172.18.0.5:1234",
+			ip:     ip,
+		},
+		{
+			desc:   "address set by untrusted client",
+			header: ip,
+			remote: "192.168.1.10:1234",
+			ip:     "192.168.1.10",
+		},
+		{
+			desc:   "remote address",
+			remote: "127.0.0.1:1234",
+			ip:     "127.0.0.1",
+		},
+		{
+			desc:   "remote address without port",
+			remote: "127.0.0.1",
+			ip:     "127.0.0.1",
+		},
+	}
+
+	for _, tc := range cases {
+		r := &http.Request{Header: http.Header{}, RemoteAddr: tc.remote}
+		if tc.header != "" {
+			r.Header.Set("X-Real-IP", tc.header)
+		}
+		got := audit.IP(audit.PopulateIP(context.Background(), r))
+		assert.Equal(t, tc.ip, got, fmt.Sprintf("%s: expected %s got %s", tc.desc, tc.ip, got))
+	}
+}
+
+func TestSetTrustedProxies(t *testing.T) {
+	defer audit.SetTrustedProxies(nil)
+
+	cases := []struct {
+		desc    string
+		proxies []string
+		err     bool
+	}{
+		{
+			desc:    "set trusted proxy addresses and ranges",
+			proxies: []string{"127.0.0.1", "::1", "10.0.0.0/8", ""},
+			err:     false,
+		},
+		{
+			desc:    "set invalid trusted proxy address",
+			proxies: []string{"proxy"},
+			err:     true,
+		},
+		{
+			desc:    "set invalid trusted proxy range",
+			proxies: []string{"10.0.0.0/33"},
+			err:     true,
+		},
+	}
+
+	for _, tc := range cases {
+		err := audit.SetTrustedProxies(tc.proxies)
+		assert.Equal(t, tc.err, err != nil, fmt.Sprintf("%s: expected error %t got %s", tc.desc, tc.err, err))
+	}
+}
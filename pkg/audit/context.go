@@ -0,0 +1,112 @@
+// Copyright (c) Mainflux
+// SPDX-License-Identifier: Apache-2.0
+
+package audit
+
+import (
+	"context"
+	"fmt"
+	"net"
+	"net/http"
+	"strings"
+
+	"github.com/mainflux/mainflux"
+)
+
+const (
+	defTrustedProxies = ""
+	envTrustedProxies = "MF_AUDIT_TRUSTED_PROXIES"
+)
+
+type ipKey struct{}
+
+// trustedProxies contains the networks of the reverse proxies allowed to
+// set the X-Real-IP header. It's set once on the service start.
+var trustedProxies []*net.IPNet
+
+// SetTrustedProxies sets the addresses or CIDR ranges of the reverse proxies
+// whose X-Real-IP header is honored. The header sent by any other client is
+// ignored, so the recorded address can't be forged.
+func SetTrustedProxies(proxies []string) error {
+	var nets []*net.IPNet
+	for _, p := range proxies {
+		p = strings.TrimSpace(p)
+		if p == "" {
+			continue
+		}
+		if !strings.Contains(p, "/") {
+			ip := net.ParseIP(p)
+			if ip == nil {
+				return fmt.Errorf("invalid trusted proxy address %s", p)
+			}
+			bits := 8 * net.IPv6len
+			if ip.To4() != nil {
+				bits = 8 * net.IPv4len
+			}
+			p = fmt.Sprintf("%s/%d", p, bits)
+		}
+		_, n, err := net.ParseCIDR(p)
+		if err != nil {
+			return fmt.Errorf("invalid trusted proxy range %s: %s", p, err)
+		}
+		nets = append(nets, n)
+	}
+	trustedProxies = nets
+
+	return nil
+}
+
+// LoadTrustedProxies sets the trusted proxies from the comma separated list
+// of addresses and CIDR ranges in the MF_AUDIT_TRUSTED_PROXIES environment
+// variable. No proxy is trusted if the variable is not set.
+func LoadTrustedProxies() error {
+	return SetTrustedProxies(strings.Split(mainflux.Env(envTrustedProxies, defTrustedProxies), ","))
+}
+
+// WithIP returns the context carrying the address of the client performing
+// the operation.
+func WithIP(ctx context.Context, ip string) context.Context {
+	return context.WithValue(ctx, ipKey{}, ip)
+}
+
+// IP returns the address of the client carried by the context.
+func IP(ctx context.Context) string {
+	ip, _ := ctx.Value(ipKey{}).(string)
+	return ip
+}
+
+// PopulateIP adds the address of the client sending the request to the
+// context. It's meant to be used as the HTTP server before function.
+func PopulateIP(ctx context.Context, r *http.Request) context.Context {
+	return WithIP(ctx, ClientIP(r))
+}
+
+// ClientIP returns the address of the client sending the request. The
+// address is taken from the X-Real-IP header only if the request is sent
+// by one of the trusted proxies.
+func ClientIP(r *http.Request) string {
+	remote := r.RemoteAddr
+	if host, _, err := net.SplitHostPort(r.RemoteAddr); err == nil {
+		remote = host
+	}
+
+	if ip := r.Header.Get("X-Real-IP"); ip != "" && trusted(remote) {
+		return ip
+	}
+
+	return remote
+}
+
+func trusted(addr string) bool {
+	ip := net.ParseIP(addr)
+	if ip == nil {
+		return false
+	}
+	for _, n := range trustedProxies {
+		if n.Contains(ip) {
+			return true
+		}
+	}
+
+	return false
+}
@@ -0,0 +1,6 @@
+// Copyright (c) Mainflux
+// SPDX-License-Identifier: Apache-2.0
+
+// Package audit contains the recorder of the management operations performed
+// on the services, which publishes the audit events to the audit service.
+package audit
@@ -0,0 +1,54 @@
+// Copyright (c) Mainflux
+// SPDX-License-Identifier: Apache-2.0
+
+package audit
+
+import (
+	"context"
+	"strconv"
+
+	"github.com/go-redis/redis"
+)
+
+const (
+	// Stream is the Redis stream the audit events are published to.
+	Stream    = "mainflux.audit"
+	streamLen = 100000
+)
+
+var _ Publisher = (*redisPublisher)(nil)
+
+type redisPublisher struct {
+	client *redis.Client
+}
+
+// NewPublisher returns the publisher which adds the audit events to the
+// Redis stream.
+func NewPublisher(client *redis.Client) Publisher {
+	return redisPublisher{client: client}
+}
+
+func (rp redisPublisher) Publish(_ context.Context, e Event) error {
+	record := &redis.XAddArgs{
+		Stream:       Stream,
+		MaxLenApprox: streamLen,
+		Values:       e.Encode(),
+	}
+
+	return rp.client.XAdd(record).Err()
+}
+
+// Encode returns the stream values of the event.
+func (e Event) Encode() map[string]interface{} {
+	return map[string]interface{}{
+		"service":      e.Service,
+		"operation":    e.Operation,
+		"actor":        e.Actor,
+		"impersonator": e.Impersonator,
+		"key_id":       e.KeyID,
+		"ip":           e.IP,
+		"entity":       e.Entity,
+		"error":        e.Error,
+		"created":      strconv.FormatInt(e.Created.UnixNano(), 10),
+	}
+}
@@ -12,7 +12,7 @@ import (
 
 // Client represents Auth cache.
 type Client interface {
-	Authorize(chanID, thingID string) error
+	Authorize(chanID, thingID, action string) error
 	Identify(thingKey string) (string, error)
 }
 
@@ -51,14 +51,15 @@ func (c client) Identify(thingKey string) (string, error) {
 	return thingID, nil
 }
 
-func (c client) Authorize(chanID, thingID string) error {
-	if c.redisClient.SIsMember(chanPrefix+":"+chanID, thingID).Val() {
+func (c client) Authorize(chanID, thingID, action string) error {
+	if c.redisClient.SIsMember(chanPrefix+":"+chanID, thingID+":"+action).Val() {
 		return nil
 	}
 
 	ar := &mainflux.AccessByIDReq{
 		ThingID: thingID,
 		ChanID:  chanID,
+		Action:  action,
 	}
 	_, err := c.thingsClient.CanAccessByID(context.TODO(), ar)
 	return err
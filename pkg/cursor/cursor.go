@@ -0,0 +1,49 @@
+// Copyright (c) Mainflux
+// SPDX-License-Identifier: Apache-2.0
+
+// Package cursor provides opaque cursor tokens used for keyset pagination.
+// A cursor encodes the sort key of the last item of a page, so the next page
+// can be retrieved by seeking past that key instead of skipping rows.
+package cursor
+
+import (
+	"encoding/base64"
+	"encoding/json"
+
+	"github.com/mainflux/mainflux/pkg/errors"
+)
+
+// ErrMalformedCursor indicates that the cursor token cannot be decoded.
+var ErrMalformedCursor = errors.New("malformed cursor")
+
+// Encode returns an opaque cursor token built from the provided sort key
+// values.
+func Encode(keys ...string) string {
+	data, err := json.Marshal(keys)
+	if err != nil {
+		return ""
+	}
+
+	return base64.RawURLEncoding.EncodeToString(data)
+}
+
+// Decode returns the sort key values encoded in the cursor token. If the
+// number of encoded values differs from the expected one, an error is
+// returned.
+func Decode(c string, n int) ([]string, error) {
+	data, err := base64.RawURLEncoding.DecodeString(c)
+	if err != nil {
+		return nil, errors.Wrap(ErrMalformedCursor, err)
+	}
+
+	var keys []string
+	if err := json.Unmarshal(data, &keys); err != nil {
+		return nil, errors.Wrap(ErrMalformedCursor, err)
+	}
+
+	if len(keys) != n {
+		return nil, ErrMalformedCursor
+	}
+
+	return keys, nil
+}
@@ -0,0 +1,65 @@
+// Copyright (c) Mainflux
+// SPDX-License-Identifier: Apache-2.0
+
+package cursor_test
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/mainflux/mainflux/pkg/cursor"
+	"github.com/mainflux/mainflux/pkg/errors"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestDecode(t *testing.T) {
+	cases := []struct {
+		desc   string
+		cursor string
+		n      int
+		keys   []string
+		err    error
+	}{
+		{
+			desc:   "decode valid single key cursor",
+			cursor: cursor.Encode("id"),
+			n:      1,
+			keys:   []string{"id"},
+			err:    nil,
+		},
+		{
+			desc:   "decode valid multiple keys cursor",
+			cursor: cursor.Encode("1596211200.5", "id"),
+			n:      2,
+			keys:   []string{"1596211200.5", "id"},
+			err:    nil,
+		},
+		{
+			desc:   "decode cursor with unexpected number of keys",
+			cursor: cursor.Encode("id"),
+			n:      2,
+			keys:   nil,
+			err:    cursor.ErrMalformedCursor,
+		},
+		{
+			desc:   "decode cursor with invalid encoding",
+			cursor: "!invalid!",
+			n:      1,
+			keys:   nil,
+			err:    cursor.ErrMalformedCursor,
+		},
+		{
+			desc:   "decode cursor with invalid content",
+			cursor: "aW52YWxpZA",
+			n:      1,
+			keys:   nil,
+			err:    cursor.ErrMalformedCursor,
+		},
+	}
+
+	for _, tc := range cases {
+		keys, err := cursor.Decode(tc.cursor, tc.n)
+		assert.True(t, errors.Contains(err, tc.err), fmt.Sprintf("%s: expected %s got %s\n", tc.desc, tc.err, err))
+		assert.Equal(t, tc.keys, keys, fmt.Sprintf("%s: expected %v got %v\n", tc.desc, tc.keys, keys))
+	}
+}
@@ -0,0 +1,45 @@
+// Copyright (c) Mainflux
+// SPDX-License-Identifier: Apache-2.0
+
+package encryption
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/mainflux/mainflux"
+)
+
+const (
+	defKeyFile    = ""
+	defVaultHost  = ""
+	defVaultToken = ""
+	defVaultPath  = "transit"
+	defVaultKey   = ""
+	defRotation   = "24h"
+
+	envKeyFile    = "MF_ENCRYPTION_KEY_FILE"
+	envVaultHost  = "MF_ENCRYPTION_VAULT_HOST"
+	envVaultToken = "MF_ENCRYPTION_VAULT_TOKEN"
+	envVaultPath  = "MF_ENCRYPTION_VAULT_TRANSIT_PATH"
+	envVaultKey   = "MF_ENCRYPTION_VAULT_KEY"
+	envRotation   = "MF_ENCRYPTION_KEY_ROTATION"
+)
+
+// LoadConfig reads the master key and the data key rotation settings from
+// the MF_ENCRYPTION_* environment variables.
+func LoadConfig() (Config, error) {
+	rotation, err := time.ParseDuration(mainflux.Env(envRotation, defRotation))
+	if err != nil {
+		return Config{}, fmt.Errorf("invalid %s value: %s", envRotation, err)
+	}
+
+	return Config{
+		KeyFile:    mainflux.Env(envKeyFile, defKeyFile),
+		VaultHost:  mainflux.Env(envVaultHost, defVaultHost),
+		VaultToken: mainflux.Env(envVaultToken, defVaultToken),
+		VaultPath:  mainflux.Env(envVaultPath, defVaultPath),
+		VaultKey:   mainflux.Env(envVaultKey, defVaultKey),
+		Rotation:   rotation,
+	}, nil
+}
@@ -0,0 +1,74 @@
+// Copyright (c) Mainflux
+// SPDX-License-Identifier: Apache-2.0
+
+package encryption_test
+
+import (
+	"fmt"
+	"os"
+	"testing"
+	"time"
+
+	"github.com/mainflux/mainflux/pkg/encryption"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestLoadConfig(t *testing.T) {
+	cases := []struct {
+		desc string
+		env  map[string]string
+		cfg  encryption.Config
+		err  bool
+	}{
+		{
+			desc: "load default config",
+			env:  map[string]string{},
+			cfg: encryption.Config{
+				VaultPath: "transit",
+				Rotation:  24 * time.Hour,
+			},
+			err: false,
+		},
+		{
+			desc: "load config from environment",
+			env: map[string]string{
+				"MF_ENCRYPTION_KEY_FILE":           "/keys/master",
+				"MF_ENCRYPTION_VAULT_HOST":         "http://vault:8200",
+				"MF_ENCRYPTION_VAULT_TOKEN":        "token",
+				"MF_ENCRYPTION_VAULT_TRANSIT_PATH": "keys",
+				"MF_ENCRYPTION_VAULT_KEY":          "messages",
+				"MF_ENCRYPTION_KEY_ROTATION":       "1h",
+			},
+			cfg: encryption.Config{
+				KeyFile:    "/keys/master",
+				VaultHost:  "http://vault:8200",
+				VaultToken: "token",
+				VaultPath:  "keys",
+				VaultKey:   "messages",
+				Rotation:   time.Hour,
+			},
+			err: false,
+		},
+		{
+			desc: "load config with invalid rotation",
+			env:  map[string]string{"MF_ENCRYPTION_KEY_ROTATION": "daily"},
+			err:  true,
+		},
+	}
+
+	for _, tc := range cases {
+		for k, v := range tc.env {
+			os.Setenv(k, v)
+		}
+		cfg, err := encryption.LoadConfig()
+		for k := range tc.env {
+			os.Unsetenv(k)
+		}
+
+		assert.Equal(t, tc.err, err != nil, fmt.Sprintf("%s: expected error %t got %s\n", tc.desc, tc.err, err))
+		if tc.err {
+			continue
+		}
+		assert.Equal(t, tc.cfg, cfg, fmt.Sprintf("%s: expected config %v got %v\n", tc.desc, tc.cfg, cfg))
+	}
+}
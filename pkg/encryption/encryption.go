@@ -0,0 +1,266 @@
+// Copyright (c) Mainflux
+// SPDX-License-Identifier: Apache-2.0
+
+// Package encryption provides envelope encryption of the message values
+// stored by the writers. Values are encrypted using the per-channel data
+// keys, which are wrapped by the master key and stored alongside the values,
+// so the writers and the readers only have to share the master key.
+package encryption
+
+import (
+	"crypto/aes"
+	"crypto/cipher"
+	"crypto/rand"
+	"encoding/base64"
+	"io"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/mainflux/mainflux/pkg/errors"
+)
+
+const (
+	prefix   = "enc:v1:"
+	sep      = ":"
+	keySize  = 32
+	maxCache = 10000
+)
+
+var (
+	// ErrMalformedCiphertext indicates that the encrypted value can not be
+	// parsed.
+	ErrMalformedCiphertext = errors.New("malformed ciphertext")
+
+	// ErrDecryption indicates that the value can not be decrypted.
+	ErrDecryption = errors.New("failed to decrypt value")
+
+	// ErrEncryption indicates that the value can not be encrypted.
+	ErrEncryption = errors.New("failed to encrypt value")
+)
+
+// KeyWrapper encrypts the data keys using the master key.
+type KeyWrapper interface {
+	// Wrap encrypts the data key using the current master key.
+	Wrap(key []byte) (string, error)
+
+	// Unwrap decrypts the data key wrapped using any of the master keys
+	// that have not been retired.
+	Unwrap(wrapped string) ([]byte, error)
+}
+
+// Cipher encrypts and decrypts the values of the channel messages.
+type Cipher interface {
+	// Encrypt encrypts the value using the current data key of the channel.
+	Encrypt(chanID, value string) (string, error)
+
+	// Decrypt decrypts the value encrypted for the same channel. Values
+	// that are not encrypted are returned unchanged.
+	Decrypt(chanID, value string) (string, error)
+}
+
+// Config contains the master key and the data key rotation settings. Key
+// file takes precedence over the Vault transit key.
+type Config struct {
+	KeyFile    string
+	VaultHost  string
+	VaultToken string
+	VaultPath  string
+	VaultKey   string
+	Rotation   time.Duration
+}
+
+// Enabled returns true if the master key is configured.
+func (cfg Config) Enabled() bool {
+	return cfg.KeyFile != "" || cfg.VaultKey != ""
+}
+
+// NewCipher returns the cipher using the master key specified by the config.
+func NewCipher(cfg Config) (Cipher, error) {
+	if cfg.KeyFile != "" {
+		kw, err := NewFileKeyWrapper(cfg.KeyFile)
+		if err != nil {
+			return nil, err
+		}
+		return New(kw, cfg.Rotation), nil
+	}
+
+	kw, err := NewVaultKeyWrapper(cfg.VaultToken, cfg.VaultHost, cfg.VaultPath, cfg.VaultKey)
+	if err != nil {
+		return nil, err
+	}
+	return New(kw, cfg.Rotation), nil
+}
+
+type dataKey struct {
+	key     []byte
+	wrapped string
+	created time.Time
+}
+
+var _ Cipher = (*envelope)(nil)
+
+type envelope struct {
+	mu       sync.Mutex
+	wrapper  KeyWrapper
+	rotation time.Duration
+	keys     map[string]dataKey
+	cache    map[string][]byte
+}
+
+// New returns the cipher which encrypts the values using AES-256-GCM and the
+// per-channel data keys wrapped by the key wrapper. New data key is generated
+// for the channel once the current one is older than the rotation period.
+// Data keys are never rotated if the rotation period is not positive.
+func New(wrapper KeyWrapper, rotation time.Duration) Cipher {
+	return &envelope{
+		wrapper:  wrapper,
+		rotation: rotation,
+		keys:     make(map[string]dataKey),
+		cache:    make(map[string][]byte),
+	}
+}
+
+func (e *envelope) Encrypt(chanID, value string) (string, error) {
+	dk, err := e.dataKey(chanID)
+	if err != nil {
+		return "", errors.Wrap(ErrEncryption, err)
+	}
+
+	sealed, err := seal(dk.key, []byte(value), []byte(chanID))
+	if err != nil {
+		return "", errors.Wrap(ErrEncryption, err)
+	}
+
+	return prefix + dk.wrapped + sep + base64.StdEncoding.EncodeToString(sealed), nil
+}
+
+func (e *envelope) Decrypt(chanID, value string) (string, error) {
+	if !strings.HasPrefix(value, prefix) {
+		return value, nil
+	}
+
+	// Wrapped key may contain the separator, unlike the base64 encoded
+	// ciphertext following it.
+	rest := strings.TrimPrefix(value, prefix)
+	i := strings.LastIndex(rest, sep)
+	if i <= 0 {
+		return "", ErrMalformedCiphertext
+	}
+
+	sealed, err := base64.StdEncoding.DecodeString(rest[i+1:])
+	if err != nil {
+		return "", errors.Wrap(ErrMalformedCiphertext, err)
+	}
+
+	key, err := e.unwrap(rest[:i])
+	if err != nil {
+		return "", errors.Wrap(ErrDecryption, err)
+	}
+
+	plain, err := open(key, sealed, []byte(chanID))
+	if err != nil {
+		return "", errors.Wrap(ErrDecryption, err)
+	}
+
+	return string(plain), nil
+}
+
+func (e *envelope) dataKey(chanID string) (dataKey, error) {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+
+	dk, ok := e.keys[chanID]
+	if ok && (e.rotation <= 0 || time.Since(dk.created) < e.rotation) {
+		return dk, nil
+	}
+
+	key := make([]byte, keySize)
+	if _, err := io.ReadFull(rand.Reader, key); err != nil {
+		return dataKey{}, err
+	}
+
+	wrapped, err := e.wrapper.Wrap(key)
+	if err != nil {
+		return dataKey{}, err
+	}
+
+	dk = dataKey{
+		key:     key,
+		wrapped: wrapped,
+		created: time.Now(),
+	}
+	e.keys[chanID] = dk
+	e.store(wrapped, key)
+
+	return dk, nil
+}
+
+func (e *envelope) unwrap(wrapped string) ([]byte, error) {
+	e.mu.Lock()
+	key, ok := e.cache[wrapped]
+	e.mu.Unlock()
+	if ok {
+		return key, nil
+	}
+
+	key, err := e.wrapper.Unwrap(wrapped)
+	if err != nil {
+		return nil, err
+	}
+
+	e.mu.Lock()
+	e.store(wrapped, key)
+	e.mu.Unlock()
+
+	return key, nil
+}
+
+// store caches the unwrapped data key. Cache is cleared once it is full, so
+// it doesn't grow unbounded as the data keys are rotated.
+func (e *envelope) store(wrapped string, key []byte) {
+	if len(e.cache) >= maxCache {
+		e.cache = make(map[string][]byte)
+	}
+	e.cache[wrapped] = key
+}
+
+// seal encrypts the plaintext using AES-GCM and prepends the random nonce
+// to the ciphertext.
+func seal(key, plaintext, data []byte) ([]byte, error) {
+	gcm, err := newGCM(key)
+	if err != nil {
+		return nil, err
+	}
+
+	nonce := make([]byte, gcm.NonceSize())
+	if _, err := io.ReadFull(rand.Reader, nonce); err != nil {
+		return nil, err
+	}
+
+	return gcm.Seal(nonce, nonce, plaintext, data), nil
+}
+
+// open decrypts the ciphertext created by seal.
+func open(key, sealed, data []byte) ([]byte, error) {
+	gcm, err := newGCM(key)
+	if err != nil {
+		return nil, err
+	}
+
+	if len(sealed) < gcm.NonceSize() {
+		return nil, ErrMalformedCiphertext
+	}
+	nonce, ciphertext := sealed[:gcm.NonceSize()], sealed[gcm.NonceSize():]
+
+	return gcm.Open(nil, nonce, ciphertext, data)
+}
+
+func newGCM(key []byte) (cipher.AEAD, error) {
+	block, err := aes.NewCipher(key)
+	if err != nil {
+		return nil, err
+	}
+
+	return cipher.NewGCM(block)
+}
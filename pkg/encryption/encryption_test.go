@@ -0,0 +1,205 @@
+// Copyright (c) Mainflux
+// SPDX-License-Identifier: Apache-2.0
+
+package encryption_test
+
+import (
+	"encoding/base64"
+	"fmt"
+	"io/ioutil"
+	"os"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/mainflux/mainflux/pkg/encryption"
+	"github.com/mainflux/mainflux/pkg/errors"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const (
+	chanID = "50e6b371-60ff-45cf-bb52-8200e7cde536"
+	value  = "22.5 degrees"
+)
+
+func writeKeyFile(t *testing.T, ids ...string) string {
+	f, err := ioutil.TempFile("", "master-keys")
+	require.Nil(t, err, fmt.Sprintf("unexpected error: %s", err))
+	defer f.Close()
+
+	fmt.Fprintln(f, "# master keys")
+	for i, id := range ids {
+		key := base64.StdEncoding.EncodeToString([]byte(strings.Repeat(fmt.Sprint(i), 32)))
+		fmt.Fprintf(f, "%s:%s\n", id, key)
+	}
+
+	return f.Name()
+}
+
+func newCipher(t *testing.T, rotation time.Duration, ids ...string) encryption.Cipher {
+	path := writeKeyFile(t, ids...)
+	defer os.Remove(path)
+
+	kw, err := encryption.NewFileKeyWrapper(path)
+	require.Nil(t, err, fmt.Sprintf("unexpected error: %s", err))
+
+	return encryption.New(kw, rotation)
+}
+
+func TestEncryptDecrypt(t *testing.T) {
+	c := newCipher(t, 0, "k1")
+
+	enc, err := c.Encrypt(chanID, value)
+	require.Nil(t, err, fmt.Sprintf("unexpected error: %s", err))
+	assert.NotContains(t, enc, value, "expected value to be encrypted")
+
+	cases := []struct {
+		desc   string
+		chanID string
+		value  string
+		res    string
+		err    error
+	}{
+		{
+			desc:   "decrypt encrypted value",
+			chanID: chanID,
+			value:  enc,
+			res:    value,
+			err:    nil,
+		},
+		{
+			desc:   "decrypt value that is not encrypted",
+			chanID: chanID,
+			value:  value,
+			res:    value,
+			err:    nil,
+		},
+		{
+			desc:   "decrypt value encrypted for other channel",
+			chanID: "other",
+			value:  enc,
+			res:    "",
+			err:    encryption.ErrDecryption,
+		},
+		{
+			desc:   "decrypt tampered value",
+			chanID: chanID,
+			value:  enc[:len(enc)-4] + "AAA=",
+			res:    "",
+			err:    encryption.ErrDecryption,
+		},
+		{
+			desc:   "decrypt malformed value",
+			chanID: chanID,
+			value:  "enc:v1:malformed",
+			res:    "",
+			err:    encryption.ErrMalformedCiphertext,
+		},
+	}
+
+	for _, tc := range cases {
+		res, err := c.Decrypt(tc.chanID, tc.value)
+		assert.True(t, errors.Contains(err, tc.err), fmt.Sprintf("%s: expected %s got %s\n", tc.desc, tc.err, err))
+		assert.Equal(t, tc.res, res, fmt.Sprintf("%s: expected %s got %s\n", tc.desc, tc.res, res))
+	}
+}
+
+func TestDataKeyRotation(t *testing.T) {
+	c := newCipher(t, time.Millisecond, "k1")
+
+	first, err := c.Encrypt(chanID, value)
+	require.Nil(t, err, fmt.Sprintf("unexpected error: %s", err))
+	time.Sleep(2 * time.Millisecond)
+	second, err := c.Encrypt(chanID, value)
+	require.Nil(t, err, fmt.Sprintf("unexpected error: %s", err))
+
+	key := func(v string) string { return v[:strings.LastIndex(v, ":")] }
+	assert.NotEqual(t, key(first), key(second), "expected data key to be rotated")
+
+	for _, enc := range []string{first, second} {
+		res, err := c.Decrypt(chanID, enc)
+		assert.Nil(t, err, fmt.Sprintf("decrypt value: unexpected error: %s", err))
+		assert.Equal(t, value, res, fmt.Sprintf("decrypt value: expected %s got %s", value, res))
+	}
+}
+
+func TestMasterKeyRotation(t *testing.T) {
+	enc, err := newCipher(t, 0, "k1").Encrypt(chanID, value)
+	require.Nil(t, err, fmt.Sprintf("unexpected error: %s", err))
+
+	cases := []struct {
+		desc string
+		ids  []string
+		res  string
+		err  error
+	}{
+		{
+			desc: "decrypt using rotated master keys",
+			ids:  []string{"k1", "k2"},
+			res:  value,
+			err:  nil,
+		},
+		{
+			desc: "decrypt using retired master key",
+			ids:  []string{"k2"},
+			res:  "",
+			err:  encryption.ErrUnknownKey,
+		},
+	}
+
+	for _, tc := range cases {
+		res, err := newCipher(t, 0, tc.ids...).Decrypt(chanID, enc)
+		assert.True(t, errors.Contains(err, tc.err), fmt.Sprintf("%s: expected %s got %s\n", tc.desc, tc.err, err))
+		assert.Equal(t, tc.res, res, fmt.Sprintf("%s: expected %s got %s\n", tc.desc, tc.res, res))
+	}
+}
+
+func TestNewFileKeyWrapper(t *testing.T) {
+	valid := base64.StdEncoding.EncodeToString([]byte(strings.Repeat("k", 32)))
+	short := base64.StdEncoding.EncodeToString([]byte("short"))
+
+	cases := []struct {
+		desc    string
+		content string
+		err     error
+	}{
+		{
+			desc:    "load valid keys",
+			content: fmt.Sprintf("# comment\n\nk1:%s\nk2:%s\n", valid, valid),
+			err:     nil,
+		},
+		{
+			desc:    "load empty file",
+			content: "# no keys\n",
+			err:     encryption.ErrMalformedKeyFile,
+		},
+		{
+			desc:    "load key without ID",
+			content: valid,
+			err:     encryption.ErrMalformedKeyFile,
+		},
+		{
+			desc:    "load key of invalid size",
+			content: fmt.Sprintf("k1:%s", short),
+			err:     encryption.ErrMalformedKeyFile,
+		},
+		{
+			desc:    "load duplicated key ID",
+			content: fmt.Sprintf("k1:%s\nk1:%s", valid, valid),
+			err:     encryption.ErrMalformedKeyFile,
+		},
+	}
+
+	for _, tc := range cases {
+		f, err := ioutil.TempFile("", "master-keys")
+		require.Nil(t, err, fmt.Sprintf("unexpected error: %s", err))
+		_, err = f.WriteString(tc.content)
+		require.Nil(t, err, fmt.Sprintf("unexpected error: %s", err))
+		f.Close()
+
+		_, err = encryption.NewFileKeyWrapper(f.Name())
+		os.Remove(f.Name())
+		assert.True(t, errors.Contains(err, tc.err), fmt.Sprintf("%s: expected %s got %s\n", tc.desc, tc.err, err))
+	}
+}
@@ -0,0 +1,114 @@
+// Copyright (c) Mainflux
+// SPDX-License-Identifier: Apache-2.0
+
+package encryption
+
+import (
+	"bufio"
+	"encoding/base64"
+	"os"
+	"strings"
+
+	"github.com/mainflux/mainflux/pkg/errors"
+)
+
+var (
+	// ErrMalformedKeyFile indicates that the master key file can not be
+	// parsed.
+	ErrMalformedKeyFile = errors.New("malformed master key file")
+
+	// ErrUnknownKey indicates that the data key is wrapped using the master
+	// key which is not available.
+	ErrUnknownKey = errors.New("unknown master key")
+)
+
+var _ KeyWrapper = (*fileKeyWrapper)(nil)
+
+type fileKeyWrapper struct {
+	current string
+	keys    map[string][]byte
+}
+
+// NewFileKeyWrapper returns the key wrapper using the master keys read from
+// the file on the provided path. Each line of the file contains the key ID and
+// the base64 encoded 32 bytes long key separated by colon, while empty lines
+// and lines starting with # are ignored. The last key in the file is used for
+// wrapping and all of them are used for unwrapping, so the master key is
+// rotated by appending the new key to the file and restarting the services.
+func NewFileKeyWrapper(path string) (KeyWrapper, error) {
+	f, err := os.Open(path)
+	if err != nil {
+		return nil, err
+	}
+	defer f.Close()
+
+	kw := fileKeyWrapper{
+		keys: make(map[string][]byte),
+	}
+
+	scanner := bufio.NewScanner(f)
+	for scanner.Scan() {
+		line := strings.TrimSpace(scanner.Text())
+		if line == "" || strings.HasPrefix(line, "#") {
+			continue
+		}
+
+		i := strings.LastIndex(line, sep)
+		if i <= 0 {
+			return nil, ErrMalformedKeyFile
+		}
+		id := line[:i]
+		if _, ok := kw.keys[id]; ok {
+			return nil, ErrMalformedKeyFile
+		}
+
+		key, err := base64.StdEncoding.DecodeString(line[i+1:])
+		if err != nil {
+			return nil, errors.Wrap(ErrMalformedKeyFile, err)
+		}
+		if len(key) != keySize {
+			return nil, ErrMalformedKeyFile
+		}
+
+		kw.keys[id] = key
+		kw.current = id
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, err
+	}
+
+	if kw.current == "" {
+		return nil, ErrMalformedKeyFile
+	}
+
+	return kw, nil
+}
+
+func (kw fileKeyWrapper) Wrap(key []byte) (string, error) {
+	sealed, err := seal(kw.keys[kw.current], key, []byte(kw.current))
+	if err != nil {
+		return "", err
+	}
+
+	return kw.current + sep + base64.StdEncoding.EncodeToString(sealed), nil
+}
+
+func (kw fileKeyWrapper) Unwrap(wrapped string) ([]byte, error) {
+	i := strings.LastIndex(wrapped, sep)
+	if i <= 0 {
+		return nil, ErrMalformedCiphertext
+	}
+	id := wrapped[:i]
+
+	master, ok := kw.keys[id]
+	if !ok {
+		return nil, ErrUnknownKey
+	}
+
+	sealed, err := base64.StdEncoding.DecodeString(wrapped[i+1:])
+	if err != nil {
+		return nil, errors.Wrap(ErrMalformedCiphertext, err)
+	}
+
+	return open(master, sealed, []byte(id))
+}
@@ -0,0 +1,127 @@
+// Copyright (c) Mainflux
+// SPDX-License-Identifier: Apache-2.0
+
+package encryption
+
+import (
+	"encoding/base64"
+	"net/http"
+
+	"github.com/hashicorp/vault/api"
+	"github.com/mainflux/mainflux/pkg/errors"
+)
+
+const (
+	encrypt = "encrypt"
+	decrypt = "decrypt"
+	apiVer  = "v1"
+)
+
+var (
+	errFailedVaultWrap   = errors.New("failed to wrap data key using vault")
+	errFailedVaultUnwrap = errors.New("failed to unwrap data key using vault")
+)
+
+var _ KeyWrapper = (*vaultKeyWrapper)(nil)
+
+type vaultKeyWrapper struct {
+	client     *api.Client
+	encryptURL string
+	decryptURL string
+}
+
+type encryptReq struct {
+	Plaintext string `json:"plaintext"`
+}
+
+type decryptReq struct {
+	Ciphertext string `json:"ciphertext"`
+}
+
+// NewVaultKeyWrapper returns the key wrapper using the named key of the Vault
+// transit secrets engine mounted on the provided path as the master key. The
+// master key is rotated in Vault, and data keys wrapped using its previous
+// versions are unwrapped as long as those versions are not trimmed.
+func NewVaultKeyWrapper(token, host, path, key string) (KeyWrapper, error) {
+	conf := &api.Config{
+		Address: host,
+	}
+
+	client, err := api.NewClient(conf)
+	if err != nil {
+		return nil, err
+	}
+	client.SetToken(token)
+
+	return vaultKeyWrapper{
+		client:     client,
+		encryptURL: "/" + apiVer + "/" + path + "/" + encrypt + "/" + key,
+		decryptURL: "/" + apiVer + "/" + path + "/" + decrypt + "/" + key,
+	}, nil
+}
+
+func (kw vaultKeyWrapper) Wrap(key []byte) (string, error) {
+	req := encryptReq{
+		Plaintext: base64.StdEncoding.EncodeToString(key),
+	}
+
+	data, err := kw.send(kw.encryptURL, req)
+	if err != nil {
+		return "", errors.Wrap(errFailedVaultWrap, err)
+	}
+
+	wrapped, ok := data["ciphertext"].(string)
+	if !ok {
+		return "", errFailedVaultWrap
+	}
+
+	return wrapped, nil
+}
+
+func (kw vaultKeyWrapper) Unwrap(wrapped string) ([]byte, error) {
+	req := decryptReq{
+		Ciphertext: wrapped,
+	}
+
+	data, err := kw.send(kw.decryptURL, req)
+	if err != nil {
+		return nil, errors.Wrap(errFailedVaultUnwrap, err)
+	}
+
+	plaintext, ok := data["plaintext"].(string)
+	if !ok {
+		return nil, errFailedVaultUnwrap
+	}
+
+	key, err := base64.StdEncoding.DecodeString(plaintext)
+	if err != nil {
+		return nil, errors.Wrap(errFailedVaultUnwrap, err)
+	}
+
+	return key, nil
+}
+
+func (kw vaultKeyWrapper) send(url string, body interface{}) (map[string]interface{}, error) {
+	r := kw.client.NewRequest(http.MethodPost, url)
+	if err := r.SetJSONBody(body); err != nil {
+		return nil, err
+	}
+
+	resp, err := kw.client.RawRequest(r)
+	if resp != nil {
+		defer resp.Body.Close()
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	s, err := api.ParseSecret(resp.Body)
+	if err != nil {
+		return nil, err
+	}
+	if s == nil || s.Data == nil {
+		return nil, errors.New(resp.Status)
+	}
+
+	return s.Data, nil
+}
@@ -0,0 +1,69 @@
+// Copyright (c) Mainflux
+// SPDX-License-Identifier: Apache-2.0
+
+package encryption_test
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/mainflux/mainflux/pkg/encryption"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const vaultPrefix = "vault:v1:"
+
+// newTransitServer mocks the Vault transit secrets engine. Plaintext is
+// "encrypted" by prefixing it, which is enough to verify the protocol.
+func newTransitServer() *httptest.Server {
+	return httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		var req map[string]string
+		if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+			w.WriteHeader(http.StatusBadRequest)
+			return
+		}
+
+		data := map[string]string{}
+		switch r.URL.Path {
+		case "/v1/transit/encrypt/mainflux":
+			data["ciphertext"] = vaultPrefix + req["plaintext"]
+		case "/v1/transit/decrypt/mainflux":
+			if !strings.HasPrefix(req["ciphertext"], vaultPrefix) {
+				w.WriteHeader(http.StatusBadRequest)
+				return
+			}
+			data["plaintext"] = strings.TrimPrefix(req["ciphertext"], vaultPrefix)
+		default:
+			w.WriteHeader(http.StatusNotFound)
+			return
+		}
+
+		json.NewEncoder(w).Encode(map[string]interface{}{"data": data})
+	}))
+}
+
+func TestVaultKeyWrapper(t *testing.T) {
+	ts := newTransitServer()
+	defer ts.Close()
+
+	kw, err := encryption.NewVaultKeyWrapper("token", ts.URL, "transit", "mainflux")
+	require.Nil(t, err, fmt.Sprintf("unexpected error: %s", err))
+	c := encryption.New(kw, 0)
+
+	enc, err := c.Encrypt(chanID, value)
+	require.Nil(t, err, fmt.Sprintf("unexpected error: %s", err))
+	assert.True(t, strings.Contains(enc, vaultPrefix), "expected data key to be wrapped by vault")
+
+	// New cipher doesn't have the data key cached, so it's unwrapped by Vault.
+	res, err := encryption.New(kw, 0).Decrypt(chanID, enc)
+	assert.Nil(t, err, fmt.Sprintf("decrypt value: unexpected error: %s", err))
+	assert.Equal(t, value, res, fmt.Sprintf("decrypt value: expected %s got %s", value, res))
+
+	_, err = encryption.New(kw, 0).Decrypt(chanID, "enc:v1:invalid:AAAA")
+	assert.NotNil(t, err, "decrypt value wrapped by unknown key: expected error")
+}
@@ -0,0 +1,123 @@
+// Copyright (c) Mainflux
+// SPDX-License-Identifier: Apache-2.0
+
+package jetstream
+
+import (
+	"encoding/json"
+	"fmt"
+	"time"
+
+	"github.com/mainflux/mainflux/pkg/errors"
+	broker "github.com/nats-io/nats.go"
+)
+
+const (
+	apiPrefix            = "$JS.API"
+	apiStreamInfo        = apiPrefix + ".STREAM.INFO.%s"
+	apiStreamCreate      = apiPrefix + ".STREAM.CREATE.%s"
+	apiStreamUpdate      = apiPrefix + ".STREAM.UPDATE.%s"
+	apiConsumerInfo      = apiPrefix + ".CONSUMER.INFO.%s.%s"
+	apiConsumerCreate    = apiPrefix + ".CONSUMER.CREATE.%s"
+	apiDurableCreate     = apiPrefix + ".CONSUMER.DURABLE.CREATE.%s.%s"
+	codeNotFound         = 404
+	retentionLimits      = "limits"
+	storageFile          = "file"
+	deliverNew           = "new"
+	ackExplicit          = "explicit"
+	replayInstant        = "instant"
+	requestTimeout       = 5 * time.Second
+	ackResponse          = "+ACK"
+	nakResponse          = "-NAK"
+	terminateResponse    = "+TERM"
+	deliverSubjectPrefix = "mainflux.deliver"
+)
+
+var (
+	errAPI         = errors.New("JetStream API request failed")
+	errNotFound    = errors.New("JetStream entity not found")
+	errPublishAck  = errors.New("message is not acknowledged by JetStream")
+	errEmptyStream = errors.New("empty stream name")
+)
+
+type apiError struct {
+	Code        int    `json:"code"`
+	Description string `json:"description"`
+}
+
+type apiResponse struct {
+	Error *apiError `json:"error,omitempty"`
+}
+
+type streamConfig struct {
+	Name     string        `json:"name"`
+	Subjects []string      `json:"subjects"`
+	Storage  string        `json:"storage"`
+	Retain   string        `json:"retention"`
+	MaxAge   time.Duration `json:"max_age"`
+	MaxMsgs  int64         `json:"max_msgs"`
+	MaxBytes int64         `json:"max_bytes"`
+}
+
+type consumerConfig struct {
+	Durable        string        `json:"durable_name,omitempty"`
+	DeliverSubject string        `json:"deliver_subject"`
+	DeliverGroup   string        `json:"deliver_group,omitempty"`
+	DeliverPolicy  string        `json:"deliver_policy"`
+	AckPolicy      string        `json:"ack_policy"`
+	AckWait        time.Duration `json:"ack_wait,omitempty"`
+	MaxDeliver     int           `json:"max_deliver,omitempty"`
+	FilterSubject  string        `json:"filter_subject,omitempty"`
+	ReplayPolicy   string        `json:"replay_policy"`
+}
+
+type createConsumerReq struct {
+	Stream string         `json:"stream_name"`
+	Config consumerConfig `json:"config"`
+}
+
+type consumerInfo struct {
+	Config consumerConfig `json:"config"`
+}
+
+type pubAck struct {
+	Error    *apiError `json:"error,omitempty"`
+	Stream   string    `json:"stream"`
+	Sequence uint64    `json:"seq"`
+}
+
+// request sends the JetStream API request and decodes its response into res.
+func request(conn *broker.Conn, subject string, req interface{}, res interface{}) error {
+	var data []byte
+	if req != nil {
+		var err error
+		if data, err = json.Marshal(req); err != nil {
+			return errors.Wrap(errAPI, err)
+		}
+	}
+
+	msg, err := conn.Request(subject, data, requestTimeout)
+	if err != nil {
+		return errors.Wrap(errAPI, err)
+	}
+
+	var r apiResponse
+	if err := json.Unmarshal(msg.Data, &r); err != nil {
+		return errors.Wrap(errAPI, err)
+	}
+	if r.Error != nil {
+		if r.Error.Code == codeNotFound {
+			return errNotFound
+		}
+		return errors.Wrap(errAPI, fmt.Errorf("%d: %s", r.Error.Code, r.Error.Description))
+	}
+
+	if res == nil {
+		return nil
+	}
+	if err := json.Unmarshal(msg.Data, res); err != nil {
+		return errors.Wrap(errAPI, err)
+	}
+
+	return nil
+}
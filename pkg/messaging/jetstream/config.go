@@ -0,0 +1,87 @@
+// Copyright (c) Mainflux
+// SPDX-License-Identifier: Apache-2.0
+
+package jetstream
+
+import (
+	"fmt"
+	"strconv"
+	"time"
+
+	"github.com/mainflux/mainflux"
+	log "github.com/mainflux/mainflux/logger"
+	"github.com/mainflux/mainflux/pkg/messaging/nats"
+)
+
+const (
+	defJetStream           = "false"
+	defJetStreamName       = "mainflux"
+	defJetStreamMaxAge     = "24h"
+	defJetStreamMaxBytes   = "0"
+	defJetStreamAckWait    = "30s"
+	defJetStreamMaxDeliver = "0"
+
+	envJetStream           = "MF_NATS_JETSTREAM"
+	envJetStreamName       = "MF_NATS_JETSTREAM_STREAM"
+	envJetStreamMaxAge     = "MF_NATS_JETSTREAM_MAX_AGE"
+	envJetStreamMaxBytes   = "MF_NATS_JETSTREAM_MAX_BYTES"
+	envJetStreamAckWait    = "MF_NATS_JETSTREAM_ACK_WAIT"
+	envJetStreamMaxDeliver = "MF_NATS_JETSTREAM_MAX_DELIVER"
+)
+
+// LoadConfig reads the JetStream settings from the MF_NATS_JETSTREAM*
+// environment variables. It returns whether JetStream is enabled and
+// the stream and consumers configuration.
+func LoadConfig() (bool, Config, error) {
+	enabled, err := strconv.ParseBool(mainflux.Env(envJetStream, defJetStream))
+	if err != nil {
+		return false, Config{}, fmt.Errorf("invalid %s value: %s", envJetStream, err)
+	}
+
+	maxAge, err := time.ParseDuration(mainflux.Env(envJetStreamMaxAge, defJetStreamMaxAge))
+	if err != nil {
+		return false, Config{}, fmt.Errorf("invalid %s value: %s", envJetStreamMaxAge, err)
+	}
+
+	maxBytes, err := strconv.ParseInt(mainflux.Env(envJetStreamMaxBytes, defJetStreamMaxBytes), 10, 64)
+	if err != nil {
+		return false, Config{}, fmt.Errorf("invalid %s value: %s", envJetStreamMaxBytes, err)
+	}
+
+	ackWait, err := time.ParseDuration(mainflux.Env(envJetStreamAckWait, defJetStreamAckWait))
+	if err != nil {
+		return false, Config{}, fmt.Errorf("invalid %s value: %s", envJetStreamAckWait, err)
+	}
+
+	maxDeliver, err := strconv.Atoi(mainflux.Env(envJetStreamMaxDeliver, defJetStreamMaxDeliver))
+	if err != nil {
+		return false, Config{}, fmt.Errorf("invalid %s value: %s", envJetStreamMaxDeliver, err)
+	}
+
+	return enabled, Config{
+		Stream:     mainflux.Env(envJetStreamName, defJetStreamName),
+		MaxAge:     maxAge,
+		MaxBytes:   maxBytes,
+		AckWait:    ackWait,
+		MaxDeliver: maxDeliver,
+	}, nil
+}
+
+// Connect returns the JetStream PubSub consuming the stream through the
+// durable consumers of the given queue if JetStream is enabled, and the
+// core NATS PubSub otherwise.
+func Connect(url, queue string, enabled bool, cfg Config, logger log.Logger) (PubSub, error) {
+	if enabled {
+		pubSub, err := NewPubSub(url, queue, cfg, logger)
+		if err != nil {
+			return nil, fmt.Errorf("failed to connect to NATS JetStream: %s", err)
+		}
+		return pubSub, nil
+	}
+
+	pubSub, err := nats.NewPubSub(url, "", logger)
+	if err != nil {
+		return nil, fmt.Errorf("failed to connect to NATS: %s", err)
+	}
+	return pubSub, nil
+}
@@ -0,0 +1,83 @@
+// Copyright (c) Mainflux
+// SPDX-License-Identifier: Apache-2.0
+
+package jetstream_test
+
+import (
+	"fmt"
+	"os"
+	"testing"
+	"time"
+
+	"github.com/mainflux/mainflux/pkg/messaging/jetstream"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestLoadConfig(t *testing.T) {
+	cases := []struct {
+		desc    string
+		env     map[string]string
+		enabled bool
+		cfg     jetstream.Config
+		err     bool
+	}{
+		{
+			desc:    "load default config",
+			env:     map[string]string{},
+			enabled: false,
+			cfg: jetstream.Config{
+				Stream:  "mainflux",
+				MaxAge:  24 * time.Hour,
+				AckWait: 30 * time.Second,
+			},
+			err: false,
+		},
+		{
+			desc: "load config from environment",
+			env: map[string]string{
+				"MF_NATS_JETSTREAM":             "true",
+				"MF_NATS_JETSTREAM_STREAM":      "messages",
+				"MF_NATS_JETSTREAM_MAX_AGE":     "1h",
+				"MF_NATS_JETSTREAM_MAX_BYTES":   "1024",
+				"MF_NATS_JETSTREAM_ACK_WAIT":    "10s",
+				"MF_NATS_JETSTREAM_MAX_DELIVER": "5",
+			},
+			enabled: true,
+			cfg: jetstream.Config{
+				Stream:     "messages",
+				MaxAge:     time.Hour,
+				MaxBytes:   1024,
+				AckWait:    10 * time.Second,
+				MaxDeliver: 5,
+			},
+			err: false,
+		},
+		{
+			desc: "load config with invalid max age",
+			env:  map[string]string{"MF_NATS_JETSTREAM_MAX_AGE": "day"},
+			err:  true,
+		},
+		{
+			desc: "load config with invalid max deliver",
+			env:  map[string]string{"MF_NATS_JETSTREAM_MAX_DELIVER": "many"},
+			err:  true,
+		},
+	}
+
+	for _, tc := range cases {
+		for k, v := range tc.env {
+			os.Setenv(k, v)
+		}
+		enabled, cfg, err := jetstream.LoadConfig()
+		for k := range tc.env {
+			os.Unsetenv(k)
+		}
+
+		assert.Equal(t, tc.err, err != nil, fmt.Sprintf("%s: expected error %t got %s\n", tc.desc, tc.err, err))
+		if tc.err {
+			continue
+		}
+		assert.Equal(t, tc.enabled, enabled, fmt.Sprintf("%s: expected enabled %t got %t\n", tc.desc, tc.enabled, enabled))
+		assert.Equal(t, tc.cfg, cfg, fmt.Sprintf("%s: expected config %v got %v\n", tc.desc, tc.cfg, cfg))
+	}
+}
@@ -0,0 +1,12 @@
+// Copyright (c) Mainflux
+// SPDX-License-Identifier: Apache-2.0
+
+// Package jetstream holds the implementation of the Publisher and PubSub
+// interfaces backed by NATS JetStream. Unlike the core NATS implementation,
+// messages published to the channels are persisted in a stream and delivered
+// to the subscribers through consumers that require an acknowledgement, so
+// messages that are not handled successfully, or that are published while
+// the subscriber is down, are delivered again. JetStream API is accessed
+// through the plain NATS request-reply, so the implementation requires NATS
+// server with JetStream enabled, but no additional client dependencies.
+package jetstream
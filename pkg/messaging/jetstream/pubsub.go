@@ -0,0 +1,286 @@
+// Copyright (c) Mainflux
+// SPDX-License-Identifier: Apache-2.0
+
+package jetstream
+
+import (
+	"encoding/json"
+	"fmt"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/gogo/protobuf/proto"
+	log "github.com/mainflux/mainflux/logger"
+	"github.com/mainflux/mainflux/pkg/errors"
+	"github.com/mainflux/mainflux/pkg/messaging"
+	broker "github.com/nats-io/nats.go"
+)
+
+const chansPrefix = "channels"
+
+// SubjectAllChannels represents subject to subscribe for all the channels.
+const SubjectAllChannels = "channels.>"
+
+var (
+	errAlreadySubscribed = errors.New("already subscribed to topic")
+	errNotSubscribed     = errors.New("not subscribed")
+	errEmptyTopic        = errors.New("empty topic")
+)
+
+// durableReplacer maps the topic to the valid part of the durable consumer
+// name, which must not contain tokens separators and wildcards.
+var durableReplacer = strings.NewReplacer(".", "_", "*", "any", ">", "all")
+
+var _ messaging.PubSub = (*pubsub)(nil)
+
+// Config represents the stream and consumers configuration.
+type Config struct {
+	// Stream is the name of the stream messages are persisted in.
+	Stream string
+
+	// MaxAge is the maximum age of the persisted messages. Zero value
+	// means that messages are not removed due to their age.
+	MaxAge time.Duration
+
+	// MaxMsgs is the maximum number of the persisted messages. Zero value
+	// means that the number of messages is not limited.
+	MaxMsgs int64
+
+	// MaxBytes is the maximum size of the stream in bytes. Zero value
+	// means that the stream size is not limited.
+	MaxBytes int64
+
+	// AckWait is the time the server waits for the message to be
+	// acknowledged before delivering it again.
+	AckWait time.Duration
+
+	// MaxDeliver is the maximum number of delivery attempts of a single
+	// message. Zero value means that the message is delivered until
+	// it is acknowledged.
+	MaxDeliver int
+}
+
+// PubSub wraps messaging PubSub exposing
+// Close() method for NATS connection.
+type PubSub interface {
+	messaging.PubSub
+	Close()
+}
+
+type pubsub struct {
+	conn          *broker.Conn
+	cfg           Config
+	logger        log.Logger
+	mu            sync.Mutex
+	queue         string
+	subscriptions map[string]*broker.Subscription
+}
+
+// NewPubSub returns JetStream message publisher/subscriber. The stream
+// which persists all of the messages published to the channels is created
+// if it doesn't exist, or updated to match the provided configuration.
+// Parameter queue specifies the name of the durable consumer used by the
+// Subscribe method, so the subscriber continues consuming where it stopped
+// after the restart, and the messages are load balanced between all of the
+// subscribers using the same queue. If the queue is empty, the consumer is
+// removed by the server as soon as the subscriber unsubscribes.
+func NewPubSub(url, queue string, cfg Config, logger log.Logger) (PubSub, error) {
+	if cfg.Stream == "" {
+		return nil, errEmptyStream
+	}
+
+	conn, err := broker.Connect(url)
+	if err != nil {
+		return nil, err
+	}
+
+	ret := &pubsub{
+		conn:          conn,
+		cfg:           cfg,
+		queue:         queue,
+		logger:        logger,
+		subscriptions: make(map[string]*broker.Subscription),
+	}
+	if err := ret.createStream(); err != nil {
+		conn.Close()
+		return nil, err
+	}
+
+	return ret, nil
+}
+
+func (ps *pubsub) Publish(topic string, msg messaging.Message) error {
+	data, err := proto.Marshal(&msg)
+	if err != nil {
+		return err
+	}
+
+	subject := fmt.Sprintf("%s.%s", chansPrefix, topic)
+	if msg.Subtopic != "" {
+		subject = fmt.Sprintf("%s.%s", subject, msg.Subtopic)
+	}
+
+	res, err := ps.conn.Request(subject, data, requestTimeout)
+	if err != nil {
+		return errors.Wrap(errPublishAck, err)
+	}
+
+	var ack pubAck
+	if err := json.Unmarshal(res.Data, &ack); err != nil {
+		return errors.Wrap(errPublishAck, err)
+	}
+	if ack.Error != nil {
+		return errors.Wrap(errPublishAck, errors.New(ack.Error.Description))
+	}
+
+	return nil
+}
+
+func (ps *pubsub) Subscribe(topic string, handler messaging.MessageHandler) error {
+	if topic == "" {
+		return errEmptyTopic
+	}
+	ps.mu.Lock()
+	defer ps.mu.Unlock()
+	if _, ok := ps.subscriptions[topic]; ok {
+		return errAlreadySubscribed
+	}
+
+	deliver, err := ps.createConsumer(topic)
+	if err != nil {
+		return err
+	}
+
+	nh := ps.natsHandler(handler)
+	if ps.queue != "" {
+		sub, err := ps.conn.QueueSubscribe(deliver, ps.queue, nh)
+		if err != nil {
+			return err
+		}
+		ps.subscriptions[topic] = sub
+		return nil
+	}
+	sub, err := ps.conn.Subscribe(deliver, nh)
+	if err != nil {
+		return err
+	}
+	ps.subscriptions[topic] = sub
+	return nil
+}
+
+func (ps *pubsub) Unsubscribe(topic string) error {
+	if topic == "" {
+		return errEmptyTopic
+	}
+	ps.mu.Lock()
+	defer ps.mu.Unlock()
+
+	sub, ok := ps.subscriptions[topic]
+	if !ok {
+		return errNotSubscribed
+	}
+
+	if err := sub.Unsubscribe(); err != nil {
+		return err
+	}
+
+	delete(ps.subscriptions, topic)
+	return nil
+}
+
+func (ps *pubsub) Close() {
+	ps.conn.Close()
+}
+
+func (ps *pubsub) createStream() error {
+	cfg := streamConfig{
+		Name:     ps.cfg.Stream,
+		Subjects: []string{SubjectAllChannels},
+		Storage:  storageFile,
+		Retain:   retentionLimits,
+		MaxAge:   ps.cfg.MaxAge,
+		MaxMsgs:  ps.cfg.MaxMsgs,
+		MaxBytes: ps.cfg.MaxBytes,
+	}
+
+	err := request(ps.conn, fmt.Sprintf(apiStreamInfo, cfg.Name), nil, nil)
+	switch err {
+	case nil:
+		return request(ps.conn, fmt.Sprintf(apiStreamUpdate, cfg.Name), cfg, nil)
+	case errNotFound:
+		return request(ps.conn, fmt.Sprintf(apiStreamCreate, cfg.Name), cfg, nil)
+	default:
+		return err
+	}
+}
+
+// createConsumer creates the consumer which delivers the messages published
+// to the topic, and returns its deliver subject. If the durable consumer
+// already exists, the messages are delivered where it stopped.
+func (ps *pubsub) createConsumer(topic string) (string, error) {
+	cfg := consumerConfig{
+		DeliverSubject: broker.NewInbox(),
+		DeliverPolicy:  deliverNew,
+		AckPolicy:      ackExplicit,
+		AckWait:        ps.cfg.AckWait,
+		MaxDeliver:     ps.cfg.MaxDeliver,
+		FilterSubject:  topic,
+		ReplayPolicy:   replayInstant,
+	}
+
+	if ps.queue == "" {
+		req := createConsumerReq{Stream: ps.cfg.Stream, Config: cfg}
+		if err := request(ps.conn, fmt.Sprintf(apiConsumerCreate, ps.cfg.Stream), req, nil); err != nil {
+			return "", err
+		}
+		return cfg.DeliverSubject, nil
+	}
+
+	durable := fmt.Sprintf("%s_%s", ps.queue, durableReplacer.Replace(topic))
+	var info consumerInfo
+	err := request(ps.conn, fmt.Sprintf(apiConsumerInfo, ps.cfg.Stream, durable), nil, &info)
+	switch err {
+	case nil:
+		return info.Config.DeliverSubject, nil
+	case errNotFound:
+	default:
+		return "", err
+	}
+
+	cfg.Durable = durable
+	cfg.DeliverSubject = fmt.Sprintf("%s.%s.%s", deliverSubjectPrefix, ps.cfg.Stream, durable)
+	cfg.DeliverGroup = ps.queue
+	req := createConsumerReq{Stream: ps.cfg.Stream, Config: cfg}
+	if err := request(ps.conn, fmt.Sprintf(apiDurableCreate, ps.cfg.Stream, durable), req, nil); err != nil {
+		return "", err
+	}
+
+	return cfg.DeliverSubject, nil
+}
+
+// natsHandler acknowledges the successfully handled messages and requests
+// the redelivery of the messages the handler failed to handle. Messages
+// that can not be unmarshaled are never delivered again.
+func (ps *pubsub) natsHandler(h messaging.MessageHandler) broker.MsgHandler {
+	return func(m *broker.Msg) {
+		var msg messaging.Message
+		if err := proto.Unmarshal(m.Data, &msg); err != nil {
+			ps.logger.Warn(fmt.Sprintf("Failed to unmarshal received message: %s", err))
+			ps.respond(m, terminateResponse)
+			return
+		}
+		if err := h(msg); err != nil {
+			ps.logger.Warn(fmt.Sprintf("Failed to handle Mainflux message: %s", err))
+			ps.respond(m, nakResponse)
+			return
+		}
+		ps.respond(m, ackResponse)
+	}
+}
+
+func (ps *pubsub) respond(m *broker.Msg, res string) {
+	if err := m.Respond([]byte(res)); err != nil {
+		ps.logger.Warn(fmt.Sprintf("Failed to acknowledge message: %s", err))
+	}
+}
@@ -0,0 +1,163 @@
+// Copyright (c) Mainflux
+// SPDX-License-Identifier: Apache-2.0
+
+package jetstream_test
+
+import (
+	"errors"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/mainflux/mainflux/pkg/messaging"
+	"github.com/mainflux/mainflux/pkg/messaging/jetstream"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const (
+	topic       = "topic"
+	chansPrefix = "channels"
+	channel     = "9b7b1b3f-b1b0-46a8-a717-b8213f9eda3b"
+	subtopic    = "engine"
+	queue       = "writer"
+	timeout     = 5 * time.Second
+)
+
+var (
+	msgChan = make(chan messaging.Message)
+	data    = []byte("payload")
+)
+
+func TestPubsub(t *testing.T) {
+	err := pubsub.Subscribe(fmt.Sprintf("%s.%s", chansPrefix, topic), handler)
+	require.Nil(t, err, fmt.Sprintf("got unexpected error: %s", err))
+	err = pubsub.Subscribe(fmt.Sprintf("%s.%s.%s", chansPrefix, topic, subtopic), handler)
+	require.Nil(t, err, fmt.Sprintf("got unexpected error: %s", err))
+	defer pubsub.Unsubscribe(fmt.Sprintf("%s.%s", chansPrefix, topic))
+	defer pubsub.Unsubscribe(fmt.Sprintf("%s.%s.%s", chansPrefix, topic, subtopic))
+
+	cases := []struct {
+		desc     string
+		channel  string
+		subtopic string
+		payload  []byte
+	}{
+		{
+			desc:    "publish message with nil payload",
+			payload: nil,
+		},
+		{
+			desc:    "publish message with string payload",
+			payload: data,
+		},
+		{
+			desc:    "publish message with channel",
+			payload: data,
+			channel: channel,
+		},
+		{
+			desc:     "publish message with subtopic",
+			payload:  data,
+			subtopic: subtopic,
+		},
+		{
+			desc:     "publish message with channel and subtopic",
+			payload:  data,
+			channel:  channel,
+			subtopic: subtopic,
+		},
+	}
+
+	for _, tc := range cases {
+		expectedMsg := messaging.Message{
+			Channel:  tc.channel,
+			Subtopic: tc.subtopic,
+			Payload:  tc.payload,
+		}
+
+		err = pubsub.Publish(topic, expectedMsg)
+		require.Nil(t, err, fmt.Sprintf("%s: got unexpected error: %s", tc.desc, err))
+
+		receivedMsg := <-msgChan
+		assert.Equal(t, expectedMsg, receivedMsg, fmt.Sprintf("%s: expected %+v got %+v\n", tc.desc, expectedMsg, receivedMsg))
+	}
+}
+
+func TestRedelivery(t *testing.T) {
+	subject := fmt.Sprintf("%s.%s.redelivery", chansPrefix, topic)
+	attempts := make(chan int)
+	count := 0
+	err := pubsub.Subscribe(subject, func(msg messaging.Message) error {
+		count++
+		attempts <- count
+		if count < 3 {
+			return errors.New("handler failed")
+		}
+		return nil
+	})
+	require.Nil(t, err, fmt.Sprintf("got unexpected error: %s", err))
+	defer pubsub.Unsubscribe(subject)
+
+	err = pubsub.Publish(topic, messaging.Message{Channel: channel, Subtopic: "redelivery", Payload: data})
+	require.Nil(t, err, fmt.Sprintf("got unexpected error: %s", err))
+
+	for i := 1; i <= 3; i++ {
+		select {
+		case n := <-attempts:
+			assert.Equal(t, i, n, fmt.Sprintf("expected delivery attempt %d got %d", i, n))
+		case <-time.After(timeout):
+			assert.Fail(t, fmt.Sprintf("expected delivery attempt %d", i))
+		}
+	}
+
+	select {
+	case n := <-attempts:
+		assert.Fail(t, fmt.Sprintf("unexpected delivery attempt %d of acknowledged message", n))
+	case <-time.After(2 * cfg.AckWait):
+	}
+}
+
+func TestDurableConsumer(t *testing.T) {
+	subject := fmt.Sprintf("%s.%s.durable", chansPrefix, topic)
+	msg := messaging.Message{Channel: channel, Subtopic: "durable", Payload: data}
+
+	ps, err := jetstream.NewPubSub(address, queue, cfg, testLog)
+	require.Nil(t, err, fmt.Sprintf("got unexpected error: %s", err))
+	err = ps.Subscribe(subject, handler)
+	require.Nil(t, err, fmt.Sprintf("got unexpected error: %s", err))
+
+	err = pubsub.Publish(topic, msg)
+	require.Nil(t, err, fmt.Sprintf("got unexpected error: %s", err))
+	receivedMsg := <-msgChan
+	assert.Equal(t, msg, receivedMsg, fmt.Sprintf("expected %+v got %+v\n", msg, receivedMsg))
+
+	// Messages published while the subscriber is down are delivered
+	// once it subscribes again using the same queue.
+	ps.Close()
+	err = pubsub.Publish(topic, msg)
+	require.Nil(t, err, fmt.Sprintf("got unexpected error: %s", err))
+
+	ps, err = jetstream.NewPubSub(address, queue, cfg, testLog)
+	require.Nil(t, err, fmt.Sprintf("got unexpected error: %s", err))
+	defer ps.Close()
+	err = ps.Subscribe(subject, handler)
+	require.Nil(t, err, fmt.Sprintf("got unexpected error: %s", err))
+
+	select {
+	case receivedMsg := <-msgChan:
+		assert.Equal(t, msg, receivedMsg, fmt.Sprintf("expected %+v got %+v\n", msg, receivedMsg))
+	case <-time.After(timeout):
+		assert.Fail(t, "expected message published while subscriber was down")
+	}
+}
+
+func TestNewPubSub(t *testing.T) {
+	_, err := jetstream.NewPubSub(address, queue, jetstream.Config{}, testLog)
+	assert.NotNil(t, err, "expected error creating pubsub without stream name")
+}
+
+func handler(msg messaging.Message) error {
+	msgChan <- msg
+	return nil
+}
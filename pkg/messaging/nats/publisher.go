@@ -14,7 +14,8 @@ import (
 var _ messaging.Publisher = (*publisher)(nil)
 
 type publisher struct {
-	conn *broker.Conn
+	conn   *broker.Conn
+	prefix string
 }
 
 // Publisher wraps messaging Publisher exposing
@@ -26,12 +27,20 @@ type Publisher interface {
 
 // NewPublisher returns NATS message Publisher.
 func NewPublisher(url string) (Publisher, error) {
+	return NewPrefixedPublisher(url, chansPrefix)
+}
+
+// NewPrefixedPublisher returns NATS message Publisher that publishes the
+// messages to the subjects starting with the given prefix instead of the
+// channels one.
+func NewPrefixedPublisher(url, prefix string) (Publisher, error) {
 	conn, err := broker.Connect(url)
 	if err != nil {
 		return nil, err
 	}
 	ret := &publisher{
-		conn: conn,
+		conn:   conn,
+		prefix: prefix,
 	}
 	return ret, nil
 }
@@ -42,7 +51,7 @@ func (pub *publisher) Publish(topic string, msg messaging.Message) error {
 		return err
 	}
 
-	subject := fmt.Sprintf("%s.%s", chansPrefix, topic)
+	subject := fmt.Sprintf("%s.%s", pub.prefix, topic)
 	if msg.Subtopic != "" {
 		subject = fmt.Sprintf("%s.%s", subject, msg.Subtopic)
 	}
@@ -0,0 +1,46 @@
+// Copyright (c) Mainflux
+// SPDX-License-Identifier: Apache-2.0
+
+package messaging
+
+import (
+	"errors"
+	"net/url"
+	"strings"
+)
+
+// ErrMalformedSubtopic indicates that the subtopic can not be parsed.
+var ErrMalformedSubtopic = errors.New("malformed subtopic")
+
+// ParseSubtopic converts URL encoded, slash separated subtopic (e.g. taken
+// from the request path) to the dot separated form used by the message
+// broker. Empty subtopic parts are dropped. Wildcards "*" and ">" are
+// allowed only as whole subtopic parts.
+func ParseSubtopic(subtopic string) (string, error) {
+	if subtopic == "" {
+		return subtopic, nil
+	}
+
+	subtopic, err := url.QueryUnescape(subtopic)
+	if err != nil {
+		return "", ErrMalformedSubtopic
+	}
+	subtopic = strings.Replace(subtopic, "/", ".", -1)
+
+	elems := strings.Split(subtopic, ".")
+	filteredElems := []string{}
+	for _, elem := range elems {
+		if elem == "" {
+			continue
+		}
+
+		if len(elem) > 1 && (strings.Contains(elem, "*") || strings.Contains(elem, ">")) {
+			return "", ErrMalformedSubtopic
+		}
+
+		filteredElems = append(filteredElems, elem)
+	}
+
+	subtopic = strings.Join(filteredElems, ".")
+	return subtopic, nil
+}
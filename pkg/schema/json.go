@@ -0,0 +1,295 @@
+// Copyright (c) Mainflux
+// SPDX-License-Identifier: Apache-2.0
+
+package schema
+
+import (
+	"encoding/json"
+	"fmt"
+	"math"
+	"reflect"
+	"regexp"
+	"unicode/utf8"
+
+	"github.com/mainflux/mainflux/pkg/errors"
+	"github.com/mainflux/mainflux/pkg/messaging"
+)
+
+var _ Schema = (*jsonSchema)(nil)
+
+var jsonTypes = map[string]bool{
+	"object":  true,
+	"array":   true,
+	"string":  true,
+	"number":  true,
+	"integer": true,
+	"boolean": true,
+	"null":    true,
+}
+
+// rawJSONSchema is the supported subset of the JSON Schema keywords. The
+// keywords not listed here are ignored.
+type rawJSONSchema struct {
+	Type                 json.RawMessage            `json:"type"`
+	Properties           map[string]json.RawMessage `json:"properties"`
+	Required             []string                   `json:"required"`
+	AdditionalProperties *bool                      `json:"additionalProperties"`
+	Items                json.RawMessage            `json:"items"`
+	Enum                 []interface{}              `json:"enum"`
+	Minimum              *float64                   `json:"minimum"`
+	Maximum              *float64                   `json:"maximum"`
+	ExclusiveMinimum     *float64                   `json:"exclusiveMinimum"`
+	ExclusiveMaximum     *float64                   `json:"exclusiveMaximum"`
+	MinLength            *int                       `json:"minLength"`
+	MaxLength            *int                       `json:"maxLength"`
+	Pattern              string                     `json:"pattern"`
+	MinItems             *int                       `json:"minItems"`
+	MaxItems             *int                       `json:"maxItems"`
+}
+
+type jsonSchema struct {
+	types      []string
+	properties map[string]*jsonSchema
+	required   []string
+	additional bool
+	items      *jsonSchema
+	enum       []interface{}
+	minimum    *float64
+	maximum    *float64
+	exclMin    *float64
+	exclMax    *float64
+	minLength  *int
+	maxLength  *int
+	pattern    *regexp.Regexp
+	minItems   *int
+	maxItems   *int
+}
+
+func parseJSON(data json.RawMessage) (*jsonSchema, error) {
+	var raw rawJSONSchema
+	if err := json.Unmarshal(data, &raw); err != nil {
+		return nil, errors.Wrap(ErrMalformedSchema, err)
+	}
+
+	js := &jsonSchema{
+		required:   raw.Required,
+		additional: raw.AdditionalProperties == nil || *raw.AdditionalProperties,
+		enum:       raw.Enum,
+		minimum:    raw.Minimum,
+		maximum:    raw.Maximum,
+		exclMin:    raw.ExclusiveMinimum,
+		exclMax:    raw.ExclusiveMaximum,
+		minLength:  raw.MinLength,
+		maxLength:  raw.MaxLength,
+		minItems:   raw.MinItems,
+		maxItems:   raw.MaxItems,
+	}
+
+	types, err := parseTypes(raw.Type)
+	if err != nil {
+		return nil, err
+	}
+	js.types = types
+
+	if raw.Pattern != "" {
+		js.pattern, err = regexp.Compile(raw.Pattern)
+		if err != nil {
+			return nil, errors.Wrap(ErrMalformedSchema, err)
+		}
+	}
+
+	if len(raw.Properties) > 0 {
+		js.properties = make(map[string]*jsonSchema, len(raw.Properties))
+		for name, p := range raw.Properties {
+			if js.properties[name], err = parseJSON(p); err != nil {
+				return nil, err
+			}
+		}
+	}
+
+	if raw.Items != nil {
+		if js.items, err = parseJSON(raw.Items); err != nil {
+			return nil, err
+		}
+	}
+
+	return js, nil
+}
+
+// parseTypes parses the "type" keyword, which is either a single type name
+// or a list of type names.
+func parseTypes(data json.RawMessage) ([]string, error) {
+	if data == nil {
+		return nil, nil
+	}
+
+	var types []string
+	var t string
+	if err := json.Unmarshal(data, &t); err == nil {
+		types = []string{t}
+	} else if err := json.Unmarshal(data, &types); err != nil {
+		return nil, errors.Wrap(ErrMalformedSchema, err)
+	}
+
+	for _, t := range types {
+		if !jsonTypes[t] {
+			return nil, errors.Wrap(ErrMalformedSchema, fmt.Errorf("unknown type %q", t))
+		}
+	}
+
+	return types, nil
+}
+
+func (js *jsonSchema) Validate(msg messaging.Message) error {
+	var v interface{}
+	if err := json.Unmarshal(msg.Payload, &v); err != nil {
+		return errors.Wrap(ErrInvalidPayload, err)
+	}
+
+	return js.validate("$", v)
+}
+
+func (js *jsonSchema) validate(path string, v interface{}) error {
+	if len(js.types) > 0 && !js.matchesType(v) {
+		return invalid(path, "expected type %v", js.types)
+	}
+
+	if len(js.enum) > 0 && !js.inEnum(v) {
+		return invalid(path, "value is not one of the allowed values")
+	}
+
+	switch val := v.(type) {
+	case float64:
+		return js.validateNumber(path, val)
+	case string:
+		return js.validateString(path, val)
+	case []interface{}:
+		return js.validateArray(path, val)
+	case map[string]interface{}:
+		return js.validateObject(path, val)
+	}
+
+	return nil
+}
+
+func (js *jsonSchema) matchesType(v interface{}) bool {
+	for _, t := range js.types {
+		switch val := v.(type) {
+		case nil:
+			if t == "null" {
+				return true
+			}
+		case bool:
+			if t == "boolean" {
+				return true
+			}
+		case float64:
+			if t == "number" || (t == "integer" && val == math.Trunc(val)) {
+				return true
+			}
+		case string:
+			if t == "string" {
+				return true
+			}
+		case []interface{}:
+			if t == "array" {
+				return true
+			}
+		case map[string]interface{}:
+			if t == "object" {
+				return true
+			}
+		}
+	}
+
+	return false
+}
+
+func (js *jsonSchema) inEnum(v interface{}) bool {
+	for _, e := range js.enum {
+		if reflect.DeepEqual(e, v) {
+			return true
+		}
+	}
+
+	return false
+}
+
+func (js *jsonSchema) validateNumber(path string, v float64) error {
+	if js.minimum != nil && v < *js.minimum {
+		return invalid(path, "value %v is less than %v", v, *js.minimum)
+	}
+	if js.maximum != nil && v > *js.maximum {
+		return invalid(path, "value %v is greater than %v", v, *js.maximum)
+	}
+	if js.exclMin != nil && v <= *js.exclMin {
+		return invalid(path, "value %v is not greater than %v", v, *js.exclMin)
+	}
+	if js.exclMax != nil && v >= *js.exclMax {
+		return invalid(path, "value %v is not less than %v", v, *js.exclMax)
+	}
+
+	return nil
+}
+
+func (js *jsonSchema) validateString(path, v string) error {
+	n := utf8.RuneCountInString(v)
+	if js.minLength != nil && n < *js.minLength {
+		return invalid(path, "string is shorter than %d", *js.minLength)
+	}
+	if js.maxLength != nil && n > *js.maxLength {
+		return invalid(path, "string is longer than %d", *js.maxLength)
+	}
+	if js.pattern != nil && !js.pattern.MatchString(v) {
+		return invalid(path, "string does not match pattern %s", js.pattern)
+	}
+
+	return nil
+}
+
+func (js *jsonSchema) validateArray(path string, v []interface{}) error {
+	if js.minItems != nil && len(v) < *js.minItems {
+		return invalid(path, "array has less than %d items", *js.minItems)
+	}
+	if js.maxItems != nil && len(v) > *js.maxItems {
+		return invalid(path, "array has more than %d items", *js.maxItems)
+	}
+	if js.items == nil {
+		return nil
+	}
+
+	for i, item := range v {
+		if err := js.items.validate(fmt.Sprintf("%s[%d]", path, i), item); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+func (js *jsonSchema) validateObject(path string, v map[string]interface{}) error {
+	for _, name := range js.required {
+		if _, ok := v[name]; !ok {
+			return invalid(path, "missing required property %q", name)
+		}
+	}
+
+	for name, val := range v {
+		p, ok := js.properties[name]
+		if !ok {
+			if !js.additional {
+				return invalid(path, "property %q is not allowed", name)
+			}
+			continue
+		}
+		if err := p.validate(fmt.Sprintf("%s.%s", path, name), val); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+func invalid(path, format string, args ...interface{}) error {
+	return errors.Wrap(ErrInvalidPayload, fmt.Errorf("%s: %s", path, fmt.Sprintf(format, args...)))
+}
@@ -0,0 +1,70 @@
+// Copyright (c) Mainflux
+// SPDX-License-Identifier: Apache-2.0
+
+package mocks
+
+import (
+	"context"
+	"sync"
+
+	"github.com/golang/protobuf/ptypes/empty"
+	"github.com/mainflux/mainflux"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+var _ mainflux.ThingsServiceClient = (*thingsClient)(nil)
+
+// ThingsClient is the things service client mock serving channel schemas.
+type ThingsClient interface {
+	mainflux.ThingsServiceClient
+
+	// SetSchema attaches the JSON encoded schema to the channel.
+	SetSchema(chanID, schema string)
+}
+
+type thingsClient struct {
+	mu      sync.Mutex
+	schemas map[string]string
+}
+
+// NewThingsClient returns mock implementation of things service client
+// serving the given schemas keyed by the channel ID.
+func NewThingsClient(schemas map[string]string) ThingsClient {
+	return &thingsClient{schemas: schemas}
+}
+
+func (tc *thingsClient) SetSchema(chanID, schema string) {
+	tc.mu.Lock()
+	defer tc.mu.Unlock()
+
+	tc.schemas[chanID] = schema
+}
+
+func (tc *thingsClient) ChannelSchema(_ context.Context, req *mainflux.ChannelID, _ ...grpc.CallOption) (*mainflux.ChannelSchemaRes, error) {
+	tc.mu.Lock()
+	defer tc.mu.Unlock()
+
+	if req.GetValue() == "" {
+		return nil, status.Error(codes.InvalidArgument, "received invalid can access request")
+	}
+
+	return &mainflux.ChannelSchemaRes{Value: []byte(tc.schemas[req.GetValue()])}, nil
+}
+
+func (tc *thingsClient) CanAccessByKey(context.Context, *mainflux.AccessByKeyReq, ...grpc.CallOption) (*mainflux.ThingID, error) {
+	panic("not implemented")
+}
+
+func (tc *thingsClient) CanAccessByID(context.Context, *mainflux.AccessByIDReq, ...grpc.CallOption) (*empty.Empty, error) {
+	panic("not implemented")
+}
+
+func (tc *thingsClient) Identify(context.Context, *mainflux.Token, ...grpc.CallOption) (*mainflux.ThingID, error) {
+	panic("not implemented")
+}
+
+func (tc *thingsClient) IsChannelOwner(context.Context, *mainflux.ChannelOwnerReq, ...grpc.CallOption) (*empty.Empty, error) {
+	panic("not implemented")
+}
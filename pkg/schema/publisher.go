@@ -0,0 +1,52 @@
+// Copyright (c) Mainflux
+// SPDX-License-Identifier: Apache-2.0
+
+package schema
+
+import (
+	"context"
+
+	"github.com/go-kit/kit/metrics"
+	"github.com/mainflux/mainflux/pkg/errors"
+	"github.com/mainflux/mainflux/pkg/messaging"
+)
+
+var _ messaging.Publisher = (*publisher)(nil)
+
+type publisher struct {
+	pub       messaging.Publisher
+	validator Validator
+	errors    messaging.Publisher
+	rejected  metrics.Counter
+}
+
+// NewPublisher returns the publisher that validates the messages before
+// publishing them. Rejected messages are counted and, if the errors
+// publisher is not nil, published using it instead.
+func NewPublisher(pub messaging.Publisher, validator Validator, errors messaging.Publisher, rejected metrics.Counter) messaging.Publisher {
+	return &publisher{
+		pub:       pub,
+		validator: validator,
+		errors:    errors,
+		rejected:  rejected,
+	}
+}
+
+func (p *publisher) Publish(topic string, msg messaging.Message) error {
+	err := p.validator.Validate(context.Background(), msg)
+	if err == nil {
+		return p.pub.Publish(topic, msg)
+	}
+	if !errors.Contains(err, ErrInvalidPayload) {
+		return err
+	}
+
+	p.rejected.Add(1)
+	if p.errors != nil {
+		if e := p.errors.Publish(topic, msg); e != nil {
+			return errors.Wrap(err, e)
+		}
+	}
+
+	return err
+}
@@ -0,0 +1,144 @@
+// Copyright (c) Mainflux
+// SPDX-License-Identifier: Apache-2.0
+
+package schema_test
+
+import (
+	"context"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/go-kit/kit/metrics"
+	"github.com/mainflux/mainflux/pkg/errors"
+	"github.com/mainflux/mainflux/pkg/messaging"
+	"github.com/mainflux/mainflux/pkg/schema"
+	"github.com/mainflux/mainflux/pkg/schema/mocks"
+	"github.com/stretchr/testify/assert"
+)
+
+const (
+	chanID      = "1"
+	plainChanID = "2"
+)
+
+type counter struct {
+	value float64
+}
+
+func (c *counter) With(...string) metrics.Counter {
+	return c
+}
+
+func (c *counter) Add(delta float64) {
+	c.value += delta
+}
+
+type recorder struct {
+	topics []string
+}
+
+func (r *recorder) Publish(topic string, msg messaging.Message) error {
+	r.topics = append(r.topics, topic)
+	return nil
+}
+
+func TestValidator(t *testing.T) {
+	things := mocks.NewThingsClient(map[string]string{chanID: jsonSchema})
+	validator := schema.NewValidator(things, time.Minute)
+
+	valid := messaging.Message{Channel: chanID, Payload: []byte(`{"temperature": 21.5}`)}
+	invalid := messaging.Message{Channel: chanID, Payload: []byte(`{"temperature": "warm"}`)}
+
+	cases := map[string]struct {
+		msg messaging.Message
+		err error
+	}{
+		"validate valid message": {
+			msg: valid,
+			err: nil,
+		},
+		"validate invalid message": {
+			msg: invalid,
+			err: schema.ErrInvalidPayload,
+		},
+		"validate message published to channel without schema": {
+			msg: messaging.Message{Channel: plainChanID, Payload: []byte(`junk`)},
+			err: nil,
+		},
+	}
+
+	for desc, tc := range cases {
+		err := validator.Validate(context.Background(), tc.msg)
+		assert.True(t, errors.Contains(err, tc.err), fmt.Sprintf("%s: expected %s got %s\n", desc, tc.err, err))
+	}
+
+	// Schema changes are picked up only once the cached schema expires.
+	things.SetSchema(chanID, "")
+	err := validator.Validate(context.Background(), invalid)
+	assert.True(t, errors.Contains(err, schema.ErrInvalidPayload), fmt.Sprintf("validate invalid message using cached schema: expected %s got %s\n", schema.ErrInvalidPayload, err))
+
+	validator = schema.NewValidator(things, 0)
+	err = validator.Validate(context.Background(), invalid)
+	assert.Nil(t, err, fmt.Sprintf("validate message after schema removal: unexpected error: %s", err))
+}
+
+func TestPublish(t *testing.T) {
+	things := mocks.NewThingsClient(map[string]string{chanID: jsonSchema})
+	validator := schema.NewValidator(things, time.Minute)
+
+	valid := messaging.Message{Channel: chanID, Payload: []byte(`{"temperature": 21.5}`)}
+	invalid := messaging.Message{Channel: chanID, Payload: []byte(`{"temperature": "warm"}`)}
+
+	cases := map[string]struct {
+		routeErrors bool
+		msg         messaging.Message
+		published   int
+		routed      int
+		rejected    float64
+		err         error
+	}{
+		"publish valid message": {
+			routeErrors: true,
+			msg:         valid,
+			published:   1,
+			routed:      0,
+			rejected:    0,
+			err:         nil,
+		},
+		"publish invalid message": {
+			routeErrors: false,
+			msg:         invalid,
+			published:   0,
+			routed:      0,
+			rejected:    1,
+			err:         schema.ErrInvalidPayload,
+		},
+		"publish invalid message routing errors": {
+			routeErrors: true,
+			msg:         invalid,
+			published:   0,
+			routed:      1,
+			rejected:    1,
+			err:         schema.ErrInvalidPayload,
+		},
+	}
+
+	for desc, tc := range cases {
+		pub := &recorder{}
+		errPub := &recorder{}
+		rejected := &counter{}
+
+		var routed messaging.Publisher
+		if tc.routeErrors {
+			routed = errPub
+		}
+		p := schema.NewPublisher(pub, validator, routed, rejected)
+
+		err := p.Publish(tc.msg.Channel, tc.msg)
+		assert.True(t, errors.Contains(err, tc.err), fmt.Sprintf("%s: expected %s got %s\n", desc, tc.err, err))
+		assert.Equal(t, tc.published, len(pub.topics), fmt.Sprintf("%s: expected %d published messages got %d\n", desc, tc.published, len(pub.topics)))
+		assert.Equal(t, tc.routed, len(errPub.topics), fmt.Sprintf("%s: expected %d routed messages got %d\n", desc, tc.routed, len(errPub.topics)))
+		assert.Equal(t, tc.rejected, rejected.value, fmt.Sprintf("%s: expected %v rejected messages got %v\n", desc, tc.rejected, rejected.value))
+	}
+}
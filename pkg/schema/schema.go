@@ -0,0 +1,78 @@
+// Copyright (c) Mainflux
+// SPDX-License-Identifier: Apache-2.0
+
+// Package schema provides validation of the messages against the schemas
+// attached to the channels they are published to. The schema is stored in
+// the channel metadata and describes either JSON or SenML payloads.
+package schema
+
+import (
+	"encoding/json"
+
+	"github.com/mainflux/mainflux/pkg/errors"
+	"github.com/mainflux/mainflux/pkg/messaging"
+)
+
+// Key is the channel metadata key under which the channel schema is stored.
+const Key = "schema"
+
+var (
+	// ErrMalformedSchema indicates that the schema definition is invalid.
+	ErrMalformedSchema = errors.New("malformed schema")
+
+	// ErrInvalidPayload indicates that the message payload does not match
+	// the schema of the channel it is published to.
+	ErrInvalidPayload = errors.New("payload does not match channel schema")
+)
+
+// Schema validates the messages published to the channel.
+type Schema interface {
+	// Validate returns ErrInvalidPayload if the message payload does not
+	// satisfy the schema.
+	Validate(msg messaging.Message) error
+}
+
+// definition is the JSON representation of the channel schema. Exactly one
+// of the payload formats has to be defined.
+type definition struct {
+	JSON  json.RawMessage `json:"json,omitempty"`
+	SenML *senmlSchema    `json:"senml,omitempty"`
+}
+
+// Parse parses the JSON encoded schema definition. The definition is an
+// object holding either the "json" key with the JSON Schema of the payload,
+// or the "senml" key with the rules the SenML records have to follow.
+func Parse(data []byte) (Schema, error) {
+	var def definition
+	if err := json.Unmarshal(data, &def); err != nil {
+		return nil, errors.Wrap(ErrMalformedSchema, err)
+	}
+
+	switch {
+	case def.JSON != nil && def.SenML == nil:
+		return parseJSON(def.JSON)
+	case def.SenML != nil && def.JSON == nil:
+		if err := def.SenML.compile(); err != nil {
+			return nil, err
+		}
+		return def.SenML, nil
+	default:
+		return nil, ErrMalformedSchema
+	}
+}
+
+// FromMetadata returns the schema stored in the channel metadata, or nil if
+// the channel has no schema.
+func FromMetadata(metadata map[string]interface{}) (Schema, error) {
+	s, ok := metadata[Key]
+	if !ok {
+		return nil, nil
+	}
+
+	data, err := json.Marshal(s)
+	if err != nil {
+		return nil, errors.Wrap(ErrMalformedSchema, err)
+	}
+
+	return Parse(data)
+}
@@ -0,0 +1,239 @@
+// Copyright (c) Mainflux
+// SPDX-License-Identifier: Apache-2.0
+
+package schema_test
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/mainflux/mainflux/pkg/errors"
+	"github.com/mainflux/mainflux/pkg/messaging"
+	"github.com/mainflux/mainflux/pkg/schema"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const (
+	jsonSchema = `{"json": {
+		"type": "object",
+		"required": ["temperature"],
+		"additionalProperties": false,
+		"properties": {
+			"temperature": {"type": "number", "minimum": -40, "maximum": 85},
+			"status": {"enum": ["ok", "fault"]},
+			"serial": {"type": "string", "pattern": "^[A-Z]{2}[0-9]+$", "maxLength": 8},
+			"count": {"type": "integer", "exclusiveMinimum": 0},
+			"tags": {"type": "array", "maxItems": 2, "items": {"type": "string", "minLength": 1}}
+		}
+	}}`
+	senmlSchema = `{"senml": {
+		"records": [
+			{"name": "dev:temperature", "unit": "Cel", "min": -40, "max": 85},
+			{"name": "dev:label"}
+		]
+	}}`
+)
+
+func TestParse(t *testing.T) {
+	cases := map[string]struct {
+		schema string
+		err    error
+	}{
+		"parse JSON schema": {
+			schema: jsonSchema,
+			err:    nil,
+		},
+		"parse SenML schema": {
+			schema: senmlSchema,
+			err:    nil,
+		},
+		"parse SenML schema without records": {
+			schema: `{"senml": {"content_type": "application/senml+cbor"}}`,
+			err:    nil,
+		},
+		"parse invalid JSON": {
+			schema: `{"json":`,
+			err:    schema.ErrMalformedSchema,
+		},
+		"parse schema without payload format": {
+			schema: `{}`,
+			err:    schema.ErrMalformedSchema,
+		},
+		"parse schema with both payload formats": {
+			schema: `{"json": {}, "senml": {}}`,
+			err:    schema.ErrMalformedSchema,
+		},
+		"parse JSON schema with unknown type": {
+			schema: `{"json": {"type": "decimal"}}`,
+			err:    schema.ErrMalformedSchema,
+		},
+		"parse JSON schema with invalid pattern": {
+			schema: `{"json": {"properties": {"name": {"pattern": "["}}}}`,
+			err:    schema.ErrMalformedSchema,
+		},
+		"parse SenML schema with unsupported content type": {
+			schema: `{"senml": {"content_type": "application/json"}}`,
+			err:    schema.ErrMalformedSchema,
+		},
+		"parse SenML schema with unnamed record": {
+			schema: `{"senml": {"records": [{"unit": "Cel"}]}}`,
+			err:    schema.ErrMalformedSchema,
+		},
+		"parse SenML schema with invalid range": {
+			schema: `{"senml": {"records": [{"name": "temperature", "min": 10, "max": 0}]}}`,
+			err:    schema.ErrMalformedSchema,
+		},
+	}
+
+	for desc, tc := range cases {
+		_, err := schema.Parse([]byte(tc.schema))
+		assert.True(t, errors.Contains(err, tc.err), fmt.Sprintf("%s: expected %s got %s\n", desc, tc.err, err))
+	}
+}
+
+func TestFromMetadata(t *testing.T) {
+	cases := map[string]struct {
+		metadata map[string]interface{}
+		found    bool
+		err      error
+	}{
+		"retrieve schema from metadata": {
+			metadata: map[string]interface{}{
+				schema.Key: map[string]interface{}{
+					"json": map[string]interface{}{"type": "object"},
+				},
+			},
+			found: true,
+			err:   nil,
+		},
+		"retrieve schema from metadata without schema": {
+			metadata: map[string]interface{}{"location": "lab"},
+			found:    false,
+			err:      nil,
+		},
+		"retrieve malformed schema from metadata": {
+			metadata: map[string]interface{}{schema.Key: "object"},
+			found:    false,
+			err:      schema.ErrMalformedSchema,
+		},
+	}
+
+	for desc, tc := range cases {
+		s, err := schema.FromMetadata(tc.metadata)
+		assert.True(t, errors.Contains(err, tc.err), fmt.Sprintf("%s: expected %s got %s\n", desc, tc.err, err))
+		assert.Equal(t, tc.found, s != nil, fmt.Sprintf("%s: expected schema found %t\n", desc, tc.found))
+	}
+}
+
+func TestValidateJSON(t *testing.T) {
+	s, err := schema.Parse([]byte(jsonSchema))
+	require.Nil(t, err, fmt.Sprintf("unexpected error: %s", err))
+
+	cases := map[string]struct {
+		payload string
+		err     error
+	}{
+		"validate valid payload": {
+			payload: `{"temperature": 21.5, "status": "ok", "serial": "AB123", "count": 3, "tags": ["a", "b"]}`,
+			err:     nil,
+		},
+		"validate payload that is not JSON": {
+			payload: `temperature=21.5`,
+			err:     schema.ErrInvalidPayload,
+		},
+		"validate payload of wrong type": {
+			payload: `[21.5]`,
+			err:     schema.ErrInvalidPayload,
+		},
+		"validate payload without required property": {
+			payload: `{"status": "ok"}`,
+			err:     schema.ErrInvalidPayload,
+		},
+		"validate payload with additional property": {
+			payload: `{"temperature": 21.5, "humidity": 40}`,
+			err:     schema.ErrInvalidPayload,
+		},
+		"validate payload with value below minimum": {
+			payload: `{"temperature": -41}`,
+			err:     schema.ErrInvalidPayload,
+		},
+		"validate payload with value above maximum": {
+			payload: `{"temperature": 86}`,
+			err:     schema.ErrInvalidPayload,
+		},
+		"validate payload with value not in enum": {
+			payload: `{"temperature": 21.5, "status": "unknown"}`,
+			err:     schema.ErrInvalidPayload,
+		},
+		"validate payload with string not matching pattern": {
+			payload: `{"temperature": 21.5, "serial": "ab123"}`,
+			err:     schema.ErrInvalidPayload,
+		},
+		"validate payload with too long string": {
+			payload: `{"temperature": 21.5, "serial": "AB1234567"}`,
+			err:     schema.ErrInvalidPayload,
+		},
+		"validate payload with non-integer value": {
+			payload: `{"temperature": 21.5, "count": 1.5}`,
+			err:     schema.ErrInvalidPayload,
+		},
+		"validate payload with value not above exclusive minimum": {
+			payload: `{"temperature": 21.5, "count": 0}`,
+			err:     schema.ErrInvalidPayload,
+		},
+		"validate payload with too many items": {
+			payload: `{"temperature": 21.5, "tags": ["a", "b", "c"]}`,
+			err:     schema.ErrInvalidPayload,
+		},
+		"validate payload with invalid item": {
+			payload: `{"temperature": 21.5, "tags": [""]}`,
+			err:     schema.ErrInvalidPayload,
+		},
+	}
+
+	for desc, tc := range cases {
+		err := s.Validate(messaging.Message{Payload: []byte(tc.payload)})
+		assert.True(t, errors.Contains(err, tc.err), fmt.Sprintf("%s: expected %s got %s\n", desc, tc.err, err))
+	}
+}
+
+func TestValidateSenML(t *testing.T) {
+	s, err := schema.Parse([]byte(senmlSchema))
+	require.Nil(t, err, fmt.Sprintf("unexpected error: %s", err))
+
+	cases := map[string]struct {
+		payload string
+		err     error
+	}{
+		"validate valid payload": {
+			payload: `[{"bn": "dev:", "n": "temperature", "u": "Cel", "v": 21.5}, {"n": "label", "vs": "kitchen"}]`,
+			err:     nil,
+		},
+		"validate payload that is not SenML": {
+			payload: `{"temperature": 21.5}`,
+			err:     schema.ErrInvalidPayload,
+		},
+		"validate payload with unknown record": {
+			payload: `[{"bn": "dev:", "n": "humidity", "u": "%RH", "v": 40}]`,
+			err:     schema.ErrInvalidPayload,
+		},
+		"validate payload with wrong unit": {
+			payload: `[{"bn": "dev:", "n": "temperature", "u": "K", "v": 294.65}]`,
+			err:     schema.ErrInvalidPayload,
+		},
+		"validate payload with value out of range": {
+			payload: `[{"bn": "dev:", "n": "temperature", "u": "Cel", "v": 90}]`,
+			err:     schema.ErrInvalidPayload,
+		},
+		"validate payload without numeric value": {
+			payload: `[{"bn": "dev:", "n": "temperature", "u": "Cel", "vs": "warm"}]`,
+			err:     schema.ErrInvalidPayload,
+		},
+	}
+
+	for desc, tc := range cases {
+		err := s.Validate(messaging.Message{Payload: []byte(tc.payload)})
+		assert.True(t, errors.Contains(err, tc.err), fmt.Sprintf("%s: expected %s got %s\n", desc, tc.err, err))
+	}
+}
@@ -0,0 +1,93 @@
+// Copyright (c) Mainflux
+// SPDX-License-Identifier: Apache-2.0
+
+package schema
+
+import (
+	"fmt"
+
+	"github.com/mainflux/mainflux/pkg/errors"
+	"github.com/mainflux/mainflux/pkg/messaging"
+	"github.com/mainflux/mainflux/pkg/transformers"
+	"github.com/mainflux/mainflux/pkg/transformers/senml"
+)
+
+var _ Schema = (*senmlSchema)(nil)
+
+// senmlSchema restricts the records of the SenML payload. If no records are
+// listed, any record is allowed and the payload only has to be decodable.
+type senmlSchema struct {
+	ContentType string        `json:"content_type,omitempty"`
+	Records     []senmlRecord `json:"records,omitempty"`
+
+	transformer transformers.Transformer
+	records     map[string]senmlRecord
+}
+
+// senmlRecord describes the record with the given resolved name. If the unit
+// is set, the record has to carry the same unit. If the min or max bound is
+// set, the record has to carry the numeric value within the bounds.
+type senmlRecord struct {
+	Name string   `json:"name"`
+	Unit string   `json:"unit,omitempty"`
+	Min  *float64 `json:"min,omitempty"`
+	Max  *float64 `json:"max,omitempty"`
+}
+
+func (ss *senmlSchema) compile() error {
+	switch ss.ContentType {
+	case "":
+		ss.ContentType = senml.JSON
+	case senml.JSON, senml.CBOR:
+	default:
+		return errors.Wrap(ErrMalformedSchema, fmt.Errorf("unsupported content type %q", ss.ContentType))
+	}
+	ss.transformer = senml.New(ss.ContentType)
+
+	ss.records = make(map[string]senmlRecord, len(ss.Records))
+	for _, r := range ss.Records {
+		if r.Name == "" {
+			return errors.Wrap(ErrMalformedSchema, fmt.Errorf("record name is required"))
+		}
+		if r.Min != nil && r.Max != nil && *r.Min > *r.Max {
+			return errors.Wrap(ErrMalformedSchema, fmt.Errorf("record %q min is greater than max", r.Name))
+		}
+		ss.records[r.Name] = r
+	}
+
+	return nil
+}
+
+func (ss *senmlSchema) Validate(msg messaging.Message) error {
+	res, err := ss.transformer.Transform(msg)
+	if err != nil {
+		return errors.Wrap(ErrInvalidPayload, err)
+	}
+	if len(ss.records) == 0 {
+		return nil
+	}
+
+	for _, m := range res.([]senml.Message) {
+		r, ok := ss.records[m.Name]
+		if !ok {
+			return invalid(m.Name, "record is not allowed")
+		}
+		if r.Unit != "" && m.Unit != r.Unit {
+			return invalid(m.Name, "expected unit %q got %q", r.Unit, m.Unit)
+		}
+		if r.Min == nil && r.Max == nil {
+			continue
+		}
+		if m.Value == nil {
+			return invalid(m.Name, "numeric value is required")
+		}
+		if r.Min != nil && *m.Value < *r.Min {
+			return invalid(m.Name, "value %v is less than %v", *m.Value, *r.Min)
+		}
+		if r.Max != nil && *m.Value > *r.Max {
+			return invalid(m.Name, "value %v is greater than %v", *m.Value, *r.Max)
+		}
+	}
+
+	return nil
+}
@@ -0,0 +1,91 @@
+// Copyright (c) Mainflux
+// SPDX-License-Identifier: Apache-2.0
+
+package schema
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	"github.com/mainflux/mainflux"
+	"github.com/mainflux/mainflux/pkg/messaging"
+)
+
+// Validator validates the messages against the schemas of the channels they
+// are published to.
+type Validator interface {
+	// Validate returns ErrInvalidPayload if the message does not satisfy
+	// the schema of its channel. Messages published to the channels
+	// without the schema are always valid.
+	Validate(ctx context.Context, msg messaging.Message) error
+}
+
+type entry struct {
+	schema  Schema
+	expires time.Time
+}
+
+type validator struct {
+	mu      sync.Mutex
+	things  mainflux.ThingsServiceClient
+	ttl     time.Duration
+	schemas map[string]entry
+}
+
+// NewValidator returns the validator fetching the channel schemas from the
+// things service. Fetched schemas are cached for the given duration.
+func NewValidator(things mainflux.ThingsServiceClient, ttl time.Duration) Validator {
+	return &validator{
+		things:  things,
+		ttl:     ttl,
+		schemas: make(map[string]entry),
+	}
+}
+
+func (v *validator) Validate(ctx context.Context, msg messaging.Message) error {
+	s, err := v.schema(ctx, msg.Channel)
+	if err != nil {
+		return err
+	}
+	if s == nil {
+		return nil
+	}
+
+	return s.Validate(msg)
+}
+
+func (v *validator) schema(ctx context.Context, chanID string) (Schema, error) {
+	v.mu.Lock()
+	e, ok := v.schemas[chanID]
+	v.mu.Unlock()
+	if ok && time.Now().Before(e.expires) {
+		return e.schema, nil
+	}
+
+	res, err := v.things.ChannelSchema(ctx, &mainflux.ChannelID{Value: chanID})
+	if err != nil {
+		return nil, err
+	}
+
+	var s Schema
+	if len(res.GetValue()) > 0 {
+		if s, err = Parse(res.GetValue()); err != nil {
+			return nil, err
+		}
+	}
+
+	v.mu.Lock()
+	defer v.mu.Unlock()
+	for id, e := range v.schemas {
+		if time.Now().After(e.expires) {
+			delete(v.schemas, id)
+		}
+	}
+	v.schemas[chanID] = entry{
+		schema:  s,
+		expires: time.Now().Add(v.ttl),
+	}
+
+	return s, nil
+}
@@ -0,0 +1,112 @@
+// Copyright (c) Mainflux
+// SPDX-License-Identifier: Apache-2.0
+
+package sdk
+
+import (
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"net/url"
+	"strconv"
+	"time"
+
+	"github.com/mainflux/mainflux/pkg/errors"
+)
+
+const eventsEndpoint = "events"
+
+// Event represents audit event of the management operation.
+type Event struct {
+	ID           string    `json:"id"`
+	Service      string    `json:"service"`
+	Operation    string    `json:"operation"`
+	Actor        string    `json:"actor,omitempty"`
+	Impersonator string    `json:"impersonator,omitempty"`
+	KeyID        string    `json:"key_id,omitempty"`
+	IP           string    `json:"ip,omitempty"`
+	Entity       string    `json:"entity,omitempty"`
+	Error        string    `json:"error,omitempty"`
+	Created      time.Time `json:"created"`
+}
+
+// EventsQuery contains parameters used to page and filter audit events.
+// Empty fields are not sent. From and To bound event time in seconds,
+// inclusive and exclusive respectively.
+type EventsQuery struct {
+	Offset    uint64
+	Limit     uint64
+	Service   string
+	Operation string
+	Actor     string
+	Entity    string
+	From      float64
+	To        float64
+}
+
+func (eq EventsQuery) values() url.Values {
+	query := url.Values{}
+	if eq.Offset > 0 {
+		query.Set("offset", strconv.FormatUint(eq.Offset, 10))
+	}
+	if eq.Limit > 0 {
+		query.Set("limit", strconv.FormatUint(eq.Limit, 10))
+	}
+
+	strs := map[string]string{
+		"service":   eq.Service,
+		"operation": eq.Operation,
+		"actor":     eq.Actor,
+		"entity":    eq.Entity,
+	}
+	for k, v := range strs {
+		if v != "" {
+			query.Set(k, v)
+		}
+	}
+
+	if eq.From != 0 {
+		query.Set("from", strconv.FormatFloat(eq.From, 'f', -1, 64))
+	}
+	if eq.To != 0 {
+		query.Set("to", strconv.FormatFloat(eq.To, 'f', -1, 64))
+	}
+
+	return query
+}
+
+func (sdk mfSDK) Events(token string, eq EventsQuery) (EventsPage, error) {
+	endpoint := eventsEndpoint
+	if query := eq.values(); len(query) > 0 {
+		endpoint = fmt.Sprintf("%s?%s", endpoint, query.Encode())
+	}
+	url := createURL(sdk.auditURL, "", endpoint)
+
+	req, err := http.NewRequest(http.MethodGet, url, nil)
+	if err != nil {
+		return EventsPage{}, err
+	}
+
+	resp, err := sdk.sendRequest(req, token, string(CTJSON))
+	if err != nil {
+		return EventsPage{}, err
+	}
+	defer resp.Body.Close()
+
+	body, err := ioutil.ReadAll(resp.Body)
+	if err != nil {
+		return EventsPage{}, err
+	}
+
+	if resp.StatusCode != http.StatusOK {
+		return EventsPage{}, errors.Wrap(ErrFailedFetch, errors.New(resp.Status))
+	}
+
+	var ep EventsPage
+	if err := json.Unmarshal(body, &ep); err != nil {
+		return EventsPage{}, err
+	}
+
+	return ep, nil
+}
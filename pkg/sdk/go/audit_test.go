@@ -0,0 +1,103 @@
+// Copyright (c) Mainflux
+// SPDX-License-Identifier: Apache-2.0
+
+package sdk_test
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/mainflux/mainflux/audit"
+	auditapi "github.com/mainflux/mainflux/audit/api"
+	auditmocks "github.com/mainflux/mainflux/audit/mocks"
+	sdk "github.com/mainflux/mainflux/pkg/sdk/go"
+	"github.com/mainflux/mainflux/pkg/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const auditEmail = "audit@example.com"
+
+func newAuditServer(svc audit.Service) *httptest.Server {
+	mux := auditapi.MakeHandler(svc)
+	return httptest.NewServer(mux)
+}
+
+func TestEvents(t *testing.T) {
+	auth := auditmocks.NewAuthNServiceClient(map[string]string{auditEmail: auditEmail}, "")
+	svc := audit.New(auth, auditmocks.NewEventRepository(), uuid.NewMock())
+	ts := newAuditServer(svc)
+	defer ts.Close()
+	mainfluxSDK := sdk.NewSDK(sdk.Config{AuditURL: ts.URL})
+
+	start := time.Now()
+	ops := []string{"create_thing", "update_thing", "remove_thing"}
+	for i, op := range ops {
+		e := audit.Event{
+			Service:   "things",
+			Operation: op,
+			Actor:     auditEmail,
+			Entity:    "thing",
+			Created:   start.Add(time.Duration(i) * time.Second),
+		}
+		err := svc.Record(context.Background(), e)
+		require.Nil(t, err, fmt.Sprintf("unexpected error: %s", err))
+	}
+	// Float seconds lose the sub-microsecond precision, so the range starts
+	// between the events instead of exactly at one of them.
+	from := float64(start.Add(time.Second/2).UnixNano()) / 1e9
+
+	cases := []struct {
+		desc  string
+		token string
+		query sdk.EventsQuery
+		size  int
+		err   error
+	}{
+		{
+			desc:  "get a list of events",
+			token: auditEmail,
+			query: sdk.EventsQuery{Limit: 10},
+			size:  len(ops),
+			err:   nil,
+		},
+		{
+			desc:  "get a list of events of the operation",
+			token: auditEmail,
+			query: sdk.EventsQuery{Limit: 10, Service: "things", Operation: "remove_thing"},
+			size:  1,
+			err:   nil,
+		},
+		{
+			desc:  "get a list of events in the time range",
+			token: auditEmail,
+			query: sdk.EventsQuery{Limit: 10, From: from},
+			size:  2,
+			err:   nil,
+		},
+		{
+			desc:  "get a list of events with invalid limit",
+			token: auditEmail,
+			query: sdk.EventsQuery{Limit: 1000},
+			size:  0,
+			err:   createError(sdk.ErrFailedFetch, http.StatusBadRequest),
+		},
+		{
+			desc:  "get a list of events with wrong token",
+			token: "wrong",
+			query: sdk.EventsQuery{Limit: 10},
+			size:  0,
+			err:   createError(sdk.ErrFailedFetch, http.StatusForbidden),
+		},
+	}
+
+	for _, tc := range cases {
+		page, err := mainfluxSDK.Events(tc.token, tc.query)
+		assert.Equal(t, tc.err, err, fmt.Sprintf("%s: expected error %s, got %s", tc.desc, tc.err, err))
+		assert.Equal(t, tc.size, len(page.Events), fmt.Sprintf("%s: expected %d events, got %d", tc.desc, tc.size, len(page.Events)))
+	}
+}
@@ -80,6 +80,15 @@ func (sdk mfSDK) CreateChannels(chs []Channel, token string) ([]Channel, error)
 
 func (sdk mfSDK) Channels(token string, offset, limit uint64, name string) (ChannelsPage, error) {
 	endpoint := fmt.Sprintf("%s?offset=%d&limit=%d&name=%s", channelsEndpoint, offset, limit, name)
+	return sdk.channels(token, endpoint)
+}
+
+func (sdk mfSDK) ChannelsAfter(token, cursor string, limit uint64, name string) (ChannelsPage, error) {
+	endpoint := fmt.Sprintf("%s?cursor=%s&limit=%d&name=%s", channelsEndpoint, cursor, limit, name)
+	return sdk.channels(token, endpoint)
+}
+
+func (sdk mfSDK) channels(token, endpoint string) (ChannelsPage, error) {
 	url := createURL(sdk.baseURL, sdk.thingsPrefix, endpoint)
 
 	req, err := http.NewRequest(http.MethodGet, url, nil)
@@ -200,6 +209,11 @@ func (sdk mfSDK) UpdateChannel(c Channel, token string) error {
 	return nil
 }
 
+func (sdk mfSDK) ShareChannel(id, group, token string) error {
+	endpoint := fmt.Sprintf("%s/%s/share", channelsEndpoint, id)
+	return sdk.share(endpoint, group, token)
+}
+
 func (sdk mfSDK) DeleteChannel(id, token string) error {
 	endpoint := fmt.Sprintf("%s/%s", channelsEndpoint, id)
 	url := createURL(sdk.baseURL, sdk.thingsPrefix, endpoint)
@@ -0,0 +1,133 @@
+// Copyright (c) Mainflux
+// SPDX-License-Identifier: Apache-2.0
+
+package sdk
+
+import (
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"net/url"
+	"strconv"
+	"time"
+
+	"github.com/mainflux/mainflux/pkg/errors"
+)
+
+const keysEndpoint = "keys"
+
+// Key represents API key issued by the user.
+type Key struct {
+	ID         string     `json:"id,omitempty"`
+	IssuerID   string     `json:"issuer_id,omitempty"`
+	Subject    string     `json:"subject,omitempty"`
+	Type       uint32     `json:"type,omitempty"`
+	IssuedAt   time.Time  `json:"issued_at,omitempty"`
+	ExpiresAt  *time.Time `json:"expires_at,omitempty"`
+	LastUsedAt *time.Time `json:"last_used_at,omitempty"`
+	Scopes     []Scope    `json:"scopes,omitempty"`
+}
+
+// Scope restricts the API key to the action on the resources.
+type Scope struct {
+	Resource string   `json:"resource"`
+	Action   string   `json:"action"`
+	IDs      []string `json:"ids,omitempty"`
+}
+
+// KeysQuery contains the parameters used for listing the keys. Type and
+// status (active or expired) filter the keys only if set.
+type KeysQuery struct {
+	Offset uint64
+	Limit  uint64
+	Type   *uint32
+	Status string
+}
+
+func (kq KeysQuery) values() url.Values {
+	query := url.Values{}
+	if kq.Offset > 0 {
+		query.Set("offset", strconv.FormatUint(kq.Offset, 10))
+	}
+	if kq.Limit > 0 {
+		query.Set("limit", strconv.FormatUint(kq.Limit, 10))
+	}
+	if kq.Type != nil {
+		query.Set("type", strconv.FormatUint(uint64(*kq.Type), 10))
+	}
+	if kq.Status != "" {
+		query.Set("status", kq.Status)
+	}
+
+	return query
+}
+
+func (sdk mfSDK) Keys(token string, kq KeysQuery) (KeysPage, error) {
+	endpoint := keysEndpoint
+	if query := kq.values(); len(query) > 0 {
+		endpoint = fmt.Sprintf("%s?%s", endpoint, query.Encode())
+	}
+	url := createURL(sdk.authnURL, "", endpoint)
+
+	req, err := http.NewRequest(http.MethodGet, url, nil)
+	if err != nil {
+		return KeysPage{}, err
+	}
+
+	resp, err := sdk.sendRequest(req, token, string(CTJSON))
+	if err != nil {
+		return KeysPage{}, err
+	}
+	defer resp.Body.Close()
+
+	body, err := ioutil.ReadAll(resp.Body)
+	if err != nil {
+		return KeysPage{}, err
+	}
+
+	if resp.StatusCode != http.StatusOK {
+		return KeysPage{}, errors.Wrap(ErrFailedFetch, errors.New(resp.Status))
+	}
+
+	var kp KeysPage
+	if err := json.Unmarshal(body, &kp); err != nil {
+		return KeysPage{}, err
+	}
+
+	return kp, nil
+}
+
+func (sdk mfSDK) RevokeKey(id, token string) error {
+	endpoint := fmt.Sprintf("%s/%s", keysEndpoint, id)
+	return sdk.revokeKeys(endpoint, token)
+}
+
+func (sdk mfSDK) RevokeKeys(subject, token string) error {
+	endpoint := keysEndpoint
+	if subject != "" {
+		endpoint = fmt.Sprintf("%s?subject=%s", endpoint, url.QueryEscape(subject))
+	}
+	return sdk.revokeKeys(endpoint, token)
+}
+
+func (sdk mfSDK) revokeKeys(endpoint, token string) error {
+	url := createURL(sdk.authnURL, "", endpoint)
+
+	req, err := http.NewRequest(http.MethodDelete, url, nil)
+	if err != nil {
+		return err
+	}
+
+	resp, err := sdk.sendRequest(req, token, string(CTJSON))
+	if err != nil {
+		return err
+	}
+	resp.Body.Close()
+
+	if resp.StatusCode != http.StatusNoContent {
+		return errors.Wrap(ErrFailedRemoval, errors.New(resp.Status))
+	}
+
+	return nil
+}
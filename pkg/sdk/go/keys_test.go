@@ -0,0 +1,156 @@
+// Copyright (c) Mainflux
+// SPDX-License-Identifier: Apache-2.0
+
+package sdk_test
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/mainflux/mainflux/authn"
+	authnapi "github.com/mainflux/mainflux/authn/api/http"
+	"github.com/mainflux/mainflux/authn/jwt"
+	authnmocks "github.com/mainflux/mainflux/authn/mocks"
+	sdk "github.com/mainflux/mainflux/pkg/sdk/go"
+	"github.com/mainflux/mainflux/pkg/uuid"
+	"github.com/opentracing/opentracing-go/mocktracer"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const keysEmail = "keys@example.com"
+
+func newAuthnService() authn.Service {
+	return authn.New(authnmocks.NewKeyRepository(), authnmocks.NewRevocationRepository(), authnmocks.NewRefreshRepository(), uuid.NewMock(), jwt.New("secret"), "")
+}
+
+func newAuthnServer(svc authn.Service) *httptest.Server {
+	mux := authnapi.MakeHandler(svc, mocktracer.New())
+	return httptest.NewServer(mux)
+}
+
+func issueKeys(t *testing.T, svc authn.Service, subjects ...string) string {
+	_, token, err := svc.Issue(context.Background(), "", authn.Key{Type: authn.UserKey, IssuedAt: time.Now(), IssuerID: keysEmail, Subject: keysEmail})
+	require.Nil(t, err, fmt.Sprintf("unexpected error: %s", err))
+	for _, sub := range subjects {
+		_, _, err := svc.Issue(context.Background(), token, authn.Key{Type: authn.APIKey, IssuedAt: time.Now(), Subject: sub})
+		require.Nil(t, err, fmt.Sprintf("unexpected error: %s", err))
+	}
+
+	return token
+}
+
+func TestKeys(t *testing.T) {
+	svc := newAuthnService()
+	ts := newAuthnServer(svc)
+	defer ts.Close()
+	mainfluxSDK := sdk.NewSDK(sdk.Config{AuthnURL: ts.URL})
+
+	token := issueKeys(t, svc, keysEmail, keysEmail, keysEmail)
+	apiKey := authn.APIKey
+
+	cases := []struct {
+		desc  string
+		token string
+		query sdk.KeysQuery
+		size  int
+		err   error
+	}{
+		{
+			desc:  "get a list of keys",
+			token: token,
+			query: sdk.KeysQuery{Limit: 10},
+			size:  3,
+			err:   nil,
+		},
+		{
+			desc:  "get a subset of active API keys",
+			token: token,
+			query: sdk.KeysQuery{Offset: 1, Limit: 10, Type: &apiKey, Status: authn.ActiveKeys},
+			size:  2,
+			err:   nil,
+		},
+		{
+			desc:  "get a list of keys with invalid status",
+			token: token,
+			query: sdk.KeysQuery{Limit: 10, Status: "invalid"},
+			size:  0,
+			err:   createError(sdk.ErrFailedFetch, http.StatusBadRequest),
+		},
+		{
+			desc:  "get a list of keys with wrong token",
+			token: "wrong",
+			query: sdk.KeysQuery{Limit: 10},
+			size:  0,
+			err:   createError(sdk.ErrFailedFetch, http.StatusForbidden),
+		},
+	}
+
+	for _, tc := range cases {
+		page, err := mainfluxSDK.Keys(tc.token, tc.query)
+		assert.Equal(t, tc.err, err, fmt.Sprintf("%s: expected error %s, got %s", tc.desc, tc.err, err))
+		assert.Equal(t, tc.size, len(page.Keys), fmt.Sprintf("%s: expected %d keys, got %d", tc.desc, tc.size, len(page.Keys)))
+	}
+}
+
+func TestRevokeKeys(t *testing.T) {
+	svc := newAuthnService()
+	ts := newAuthnServer(svc)
+	defer ts.Close()
+	mainfluxSDK := sdk.NewSDK(sdk.Config{AuthnURL: ts.URL})
+
+	other := "ci@example.com"
+	token := issueKeys(t, svc, keysEmail, keysEmail, other)
+	page, err := mainfluxSDK.Keys(token, sdk.KeysQuery{Limit: 10})
+	require.Nil(t, err, fmt.Sprintf("unexpected error: %s", err))
+	id := page.Keys[0].ID
+
+	cases := []struct {
+		desc  string
+		token string
+		fn    func(token string) error
+		total uint64
+		err   error
+	}{
+		{
+			desc:  "revoke keys with wrong token",
+			token: "wrong",
+			fn:    func(token string) error { return mainfluxSDK.RevokeKeys("", token) },
+			total: 3,
+			err:   createError(sdk.ErrFailedRemoval, http.StatusForbidden),
+		},
+		{
+			desc:  "revoke key by id",
+			token: token,
+			fn:    func(token string) error { return mainfluxSDK.RevokeKey(id, token) },
+			total: 2,
+			err:   nil,
+		},
+		{
+			desc:  "revoke keys of the subject",
+			token: token,
+			fn:    func(token string) error { return mainfluxSDK.RevokeKeys(other, token) },
+			total: 1,
+			err:   nil,
+		},
+		{
+			desc:  "revoke all keys",
+			token: token,
+			fn:    func(token string) error { return mainfluxSDK.RevokeKeys("", token) },
+			total: 0,
+			err:   nil,
+		},
+	}
+
+	for _, tc := range cases {
+		err := tc.fn(tc.token)
+		assert.Equal(t, tc.err, err, fmt.Sprintf("%s: expected error %s, got %s", tc.desc, tc.err, err))
+		page, err := mainfluxSDK.Keys(token, sdk.KeysQuery{Limit: 10})
+		assert.Nil(t, err, fmt.Sprintf("%s: unexpected error: %s", tc.desc, err))
+		assert.Equal(t, tc.total, page.Total, fmt.Sprintf("%s: expected %d keys, got %d", tc.desc, tc.total, page.Total))
+	}
+}
@@ -41,15 +41,19 @@ func (sdk mfSDK) SendMessage(chanName, msg, token string) error {
 	return nil
 }
 
-func (sdk mfSDK) ReadMessages(chanName, token string) (MessagesPage, error) {
+func (sdk mfSDK) ReadMessages(chanName, token string, mq MessagesQuery) (MessagesPage, error) {
 	chanNameParts := strings.SplitN(chanName, ".", 2)
 	chanID := chanNameParts[0]
-	subtopicPart := ""
+
+	query := mq.values()
 	if len(chanNameParts) == 2 {
-		subtopicPart = fmt.Sprintf("?subtopic=%s", strings.Replace(chanNameParts[1], ".", "/", -1))
+		query.Set("subtopic", strings.Replace(chanNameParts[1], ".", "/", -1))
 	}
 
-	endpoint := fmt.Sprintf("channels/%s/messages%s", chanID, subtopicPart)
+	endpoint := fmt.Sprintf("channels/%s/messages", chanID)
+	if len(query) > 0 {
+		endpoint = fmt.Sprintf("%s?%s", endpoint, query.Encode())
+	}
 	url := createURL(sdk.readerURL, "", endpoint)
 
 	req, err := http.NewRequest(http.MethodGet, url, nil)
@@ -79,6 +83,46 @@ func (sdk mfSDK) ReadMessages(chanName, token string) (MessagesPage, error) {
 	return mp, nil
 }
 
+func (sdk mfSDK) AggregateMessages(chanName, token string, aq AggregationQuery) ([]Aggregate, error) {
+	chanNameParts := strings.SplitN(chanName, ".", 2)
+	chanID := chanNameParts[0]
+
+	query := aq.values()
+	if len(chanNameParts) == 2 {
+		query.Set("subtopic", strings.Replace(chanNameParts[1], ".", "/", -1))
+	}
+
+	endpoint := fmt.Sprintf("channels/%s/messages/aggregate?%s", chanID, query.Encode())
+	url := createURL(sdk.readerURL, "", endpoint)
+
+	req, err := http.NewRequest(http.MethodGet, url, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	resp, err := sdk.sendRequest(req, token, string(CTJSON))
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+
+	body, err := ioutil.ReadAll(resp.Body)
+	if err != nil {
+		return nil, err
+	}
+
+	if resp.StatusCode != http.StatusOK {
+		return nil, errors.Wrap(ErrFailedRead, errors.New(resp.Status))
+	}
+
+	var ar aggregatesRes
+	if err := json.Unmarshal(body, &ar); err != nil {
+		return nil, err
+	}
+
+	return ar.Aggregates, nil
+}
+
 func (sdk mfSDK) SetContentType(ct ContentType) error {
 	if ct != CTJSON && ct != CTJSONSenML && ct != CTBinary {
 		return ErrInvalidContentType
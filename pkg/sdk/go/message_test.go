@@ -14,8 +14,13 @@ import (
 	"github.com/mainflux/mainflux/http/api"
 	"github.com/mainflux/mainflux/http/mocks"
 	sdk "github.com/mainflux/mainflux/pkg/sdk/go"
+	"github.com/mainflux/mainflux/pkg/transformers/senml"
+	"github.com/mainflux/mainflux/readers"
+	rapi "github.com/mainflux/mainflux/readers/api"
+	rmocks "github.com/mainflux/mainflux/readers/mocks"
 	"github.com/opentracing/opentracing-go/mocktracer"
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
 )
 
 func newMessageService(cc mainflux.ThingsServiceClient) adapter.Service {
@@ -28,6 +33,12 @@ func newMessageServer(svc adapter.Service) *httptest.Server {
 	return httptest.NewServer(mux)
 }
 
+func newReaderServer(repo readers.MessageRepository) *httptest.Server {
+	tc := rmocks.NewThingsService(map[string]string{"auth_token": "1"}, map[string]string{})
+	mux := rapi.MakeHandler(repo, tc, nil, "reader")
+	return httptest.NewServer(mux)
+}
+
 func TestSendMessage(t *testing.T) {
 	chanID := "1"
 	atoken := "auth_token"
@@ -139,3 +150,133 @@ func TestSetContentType(t *testing.T) {
 		assert.Equal(t, tc.err, err, fmt.Sprintf("%s: expected error %s, got %s", tc.desc, tc.err, err))
 	}
 }
+
+func TestReadMessages(t *testing.T) {
+	chanID := "1"
+	atoken := "auth_token"
+	msgs := []senml.Message{}
+	for i := 0; i < 20; i++ {
+		msgs = append(msgs, senml.Message{Channel: chanID, Publisher: "1", Protocol: "http", Time: float64(i)})
+	}
+	repo := rmocks.NewMessageRepository(map[string][]senml.Message{chanID: msgs})
+	ts := newReaderServer(repo)
+	defer ts.Close()
+	sdkConf := sdk.Config{
+		ReaderURL:       ts.URL,
+		MsgContentType:  contentType,
+		TLSVerification: false,
+	}
+
+	mainfluxSDK := sdk.NewSDK(sdkConf)
+
+	first, err := mainfluxSDK.ReadMessages(chanID, atoken, sdk.MessagesQuery{Limit: 5})
+	require.Nil(t, err, fmt.Sprintf("unexpected error: %s", err))
+	require.NotEmpty(t, first.NextCursor, "expected next cursor in the first page")
+
+	v := 5.0
+	cases := map[string]struct {
+		chanID string
+		auth   string
+		query  sdk.MessagesQuery
+		msgs   []senml.Message
+		err    error
+	}{
+		"read messages": {
+			chanID: chanID,
+			auth:   atoken,
+			query:  sdk.MessagesQuery{Offset: 5, Limit: 5},
+			msgs:   msgs[5:10],
+			err:    nil,
+		},
+		"read messages following the cursor": {
+			chanID: chanID,
+			auth:   atoken,
+			query:  sdk.MessagesQuery{Cursor: first.NextCursor, Limit: 5},
+			msgs:   msgs[5:10],
+			err:    nil,
+		},
+		"read messages with value comparator and time range": {
+			chanID: chanID,
+			auth:   atoken,
+			query:  sdk.MessagesQuery{Limit: 5, Value: &v, Comparator: "ge", From: 1, To: 100},
+			msgs:   msgs[0:5],
+			err:    nil,
+		},
+		"read messages with invalid comparator": {
+			chanID: chanID,
+			auth:   atoken,
+			query:  sdk.MessagesQuery{Limit: 5, Value: &v, Comparator: "wrong"},
+			msgs:   nil,
+			err:    createError(sdk.ErrFailedRead, http.StatusBadRequest),
+		},
+		"read messages without authorization token": {
+			chanID: chanID,
+			auth:   "",
+			query:  sdk.MessagesQuery{Limit: 5},
+			msgs:   nil,
+			err:    createError(sdk.ErrFailedRead, http.StatusForbidden),
+		},
+	}
+	for desc, tc := range cases {
+		page, err := mainfluxSDK.ReadMessages(tc.chanID, tc.auth, tc.query)
+		assert.Equal(t, tc.err, err, fmt.Sprintf("%s: expected error %s, got %s", desc, tc.err, err))
+		assert.Equal(t, tc.msgs, page.Messages, fmt.Sprintf("%s: expected messages %v, got %v", desc, tc.msgs, page.Messages))
+	}
+}
+
+func TestAggregateMessages(t *testing.T) {
+	chanID := "1"
+	atoken := "auth_token"
+	msgs := []senml.Message{}
+	for i := 0; i < 10; i++ {
+		v := float64(i)
+		msgs = append(msgs, senml.Message{Channel: chanID, Name: "temp", Publisher: "1", Value: &v, Time: float64(i)})
+	}
+	repo := rmocks.NewMessageRepository(map[string][]senml.Message{chanID: msgs})
+	ts := newReaderServer(repo)
+	defer ts.Close()
+	sdkConf := sdk.Config{
+		ReaderURL:       ts.URL,
+		MsgContentType:  contentType,
+		TLSVerification: false,
+	}
+
+	mainfluxSDK := sdk.NewSDK(sdkConf)
+
+	cases := map[string]struct {
+		auth  string
+		query sdk.AggregationQuery
+		aggs  []sdk.Aggregate
+		err   error
+	}{
+		"aggregate messages": {
+			auth:  atoken,
+			query: sdk.AggregationQuery{Function: "sum", Interval: "5s", From: 0, To: 10},
+			aggs:  []sdk.Aggregate{{Time: 0, Value: 10}, {Time: 5, Value: 35}},
+			err:   nil,
+		},
+		"aggregate messages grouped by name": {
+			auth:  atoken,
+			query: sdk.AggregationQuery{Function: "max", Interval: "1m", From: 0, To: 10, GroupBy: []string{"name"}},
+			aggs:  []sdk.Aggregate{{Time: 0, Name: "temp", Value: 9}},
+			err:   nil,
+		},
+		"aggregate messages with invalid function": {
+			auth:  atoken,
+			query: sdk.AggregationQuery{Function: "wrong", Interval: "1m", From: 0, To: 10},
+			aggs:  nil,
+			err:   createError(sdk.ErrFailedRead, http.StatusBadRequest),
+		},
+		"aggregate messages without authorization token": {
+			auth:  "",
+			query: sdk.AggregationQuery{Function: "sum", Interval: "1m", From: 0, To: 10},
+			aggs:  nil,
+			err:   createError(sdk.ErrFailedRead, http.StatusForbidden),
+		},
+	}
+	for desc, tc := range cases {
+		aggs, err := mainfluxSDK.AggregateMessages(chanID, tc.auth, tc.query)
+		assert.Equal(t, tc.err, err, fmt.Sprintf("%s: expected error %s, got %s", desc, tc.err, err))
+		assert.Equal(t, tc.aggs, aggs, fmt.Sprintf("%s: expected aggregates %v, got %v", desc, tc.aggs, aggs))
+	}
+}
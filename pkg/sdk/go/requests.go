@@ -3,14 +3,119 @@
 
 package sdk
 
+import (
+	"net/url"
+	"strconv"
+	"strings"
+)
+
 // UserPasswordReq contains old and new passwords
 type UserPasswordReq struct {
 	OldPassword string `json:"old_password,omitempty"`
 	Password    string `json:"password,omitempty"`
 }
 
-// ConnectionIDs contains ID lists of things and channels to be connected
+type refreshReq struct {
+	RefreshToken string `json:"refresh_token"`
+}
+
+// ConnectionIDs contains ID lists of things and channels to be connected,
+// as well as actions connected things are allowed to perform. If no actions
+// are specified, things are allowed to both publish and subscribe.
 type ConnectionIDs struct {
 	ChannelIDs []string `json:"channel_ids"`
 	ThingIDs   []string `json:"thing_ids"`
+	Actions    []string `json:"actions,omitempty"`
+}
+
+// MessagesQuery contains parameters used to page and filter messages read
+// from a channel. Empty fields are not sent. If Cursor is set, Offset is
+// ignored. Value is compared to the message value using Comparator, which is
+// one of "eq", "lt", "le", "gt" and "ge". From and To bound message time in
+// seconds, inclusive and exclusive respectively.
+type MessagesQuery struct {
+	Offset     uint64
+	Limit      uint64
+	Cursor     string
+	Publisher  string
+	Protocol   string
+	Name       string
+	Value      *float64
+	Comparator string
+	From       float64
+	To         float64
+}
+
+func (mq MessagesQuery) values() url.Values {
+	query := url.Values{}
+	if mq.Offset > 0 {
+		query.Set("offset", strconv.FormatUint(mq.Offset, 10))
+	}
+	if mq.Limit > 0 {
+		query.Set("limit", strconv.FormatUint(mq.Limit, 10))
+	}
+
+	strs := map[string]string{
+		"cursor":     mq.Cursor,
+		"publisher":  mq.Publisher,
+		"protocol":   mq.Protocol,
+		"name":       mq.Name,
+		"comparator": mq.Comparator,
+	}
+	for k, v := range strs {
+		if v != "" {
+			query.Set(k, v)
+		}
+	}
+
+	if mq.Value != nil {
+		query.Set("v", strconv.FormatFloat(*mq.Value, 'f', -1, 64))
+	}
+	if mq.From != 0 {
+		query.Set("from", strconv.FormatFloat(mq.From, 'f', -1, 64))
+	}
+	if mq.To != 0 {
+		query.Set("to", strconv.FormatFloat(mq.To, 'f', -1, 64))
+	}
+
+	return query
+}
+
+// AggregationQuery contains parameters used to aggregate numeric values of
+// messages read from a channel. Function is one of "min", "max", "avg",
+// "sum", "count", "first" and "last". Interval is the bucket duration, such
+// as "1m" or "1h". Messages can be grouped by "name" and "publisher".
+type AggregationQuery struct {
+	Function  string
+	Interval  string
+	From      float64
+	To        float64
+	GroupBy   []string
+	Publisher string
+	Protocol  string
+	Name      string
+}
+
+func (aq AggregationQuery) values() url.Values {
+	query := url.Values{}
+	query.Set("function", aq.Function)
+	query.Set("interval", aq.Interval)
+	query.Set("from", strconv.FormatFloat(aq.From, 'f', -1, 64))
+	query.Set("to", strconv.FormatFloat(aq.To, 'f', -1, 64))
+	if len(aq.GroupBy) > 0 {
+		query.Set("group_by", strings.Join(aq.GroupBy, ","))
+	}
+
+	strs := map[string]string{
+		"publisher": aq.Publisher,
+		"protocol":  aq.Protocol,
+		"name":      aq.Name,
+	}
+	for k, v := range strs {
+		if v != "" {
+			query.Set(k, v)
+		}
+	}
+
+	return query
 }
@@ -6,7 +6,8 @@ package sdk
 import "github.com/mainflux/mainflux/pkg/transformers/senml"
 
 type tokenRes struct {
-	Token string `json:"token,omitempty"`
+	Token        string `json:"token,omitempty"`
+	RefreshToken string `json:"refresh_token,omitempty"`
 }
 
 type createThingsRes struct {
@@ -18,9 +19,10 @@ type createChannelsRes struct {
 }
 
 type pageRes struct {
-	Total  uint64 `json:"total"`
-	Offset uint64 `json:"offset"`
-	Limit  uint64 `json:"limit"`
+	Total      uint64 `json:"total"`
+	Offset     uint64 `json:"offset"`
+	Limit      uint64 `json:"limit"`
+	NextCursor string `json:"next_cursor,omitempty"`
 }
 
 // ThingsPage contains list of things in a page with proper metadata.
@@ -41,6 +43,31 @@ type MessagesPage struct {
 	pageRes
 }
 
+// Aggregate contains aggregated value of messages in a single time bucket.
+// Name and Publisher are set if messages are grouped by them.
+type Aggregate struct {
+	Time      float64 `json:"time"`
+	Name      string  `json:"name,omitempty"`
+	Publisher string  `json:"publisher,omitempty"`
+	Value     float64 `json:"value"`
+}
+
+type aggregatesRes struct {
+	Aggregates []Aggregate `json:"aggregates"`
+}
+
+// KeysPage contains list of keys in a page with proper metadata.
+type KeysPage struct {
+	Keys []Key `json:"keys"`
+	pageRes
+}
+
+// EventsPage contains list of audit events in a page with proper metadata.
+type EventsPage struct {
+	Events []Event `json:"events"`
+	pageRes
+}
+
 type GroupsPage struct {
 	Groups []Group `json:"groups"`
 	pageRes
@@ -21,6 +21,14 @@ const (
 	CTBinary ContentType = "application/octet-stream"
 )
 
+const (
+	// ActionPublish allows connected thing to publish messages to the channel.
+	ActionPublish = "publish"
+
+	// ActionSubscribe allows connected thing to receive messages from the channel.
+	ActionSubscribe = "subscribe"
+)
+
 const minPassLen = 8
 
 var (
@@ -55,6 +63,9 @@ var (
 	// was passed.
 	ErrInvalidContentType = errors.New("Unknown Content Type")
 
+	// ErrFailedRefresh indicates that refreshing of the access token failed.
+	ErrFailedRefresh = errors.New("failed to refresh token")
+
 	// ErrFetchVersion indicates that fetching of version failed.
 	ErrFetchVersion = errors.New("failed to fetch version")
 
@@ -99,17 +110,19 @@ type Group struct {
 
 // Thing represents mainflux thing.
 type Thing struct {
-	ID       string                 `json:"id,omitempty"`
-	Name     string                 `json:"name,omitempty"`
-	Key      string                 `json:"key,omitempty"`
-	Metadata map[string]interface{} `json:"metadata,omitempty"`
+	ID        string                 `json:"id,omitempty"`
+	Name      string                 `json:"name,omitempty"`
+	Key       string                 `json:"key,omitempty"`
+	UserGroup string                 `json:"user_group,omitempty"`
+	Metadata  map[string]interface{} `json:"metadata,omitempty"`
 }
 
 // Channel represents mainflux channel.
 type Channel struct {
-	ID       string                 `json:"id,omitempty"`
-	Name     string                 `json:"name,omitempty"`
-	Metadata map[string]interface{} `json:"metadata,omitempty"`
+	ID        string                 `json:"id,omitempty"`
+	Name      string                 `json:"name,omitempty"`
+	UserGroup string                 `json:"user_group,omitempty"`
+	Metadata  map[string]interface{} `json:"metadata,omitempty"`
 }
 
 // SDK contains Mainflux API.
@@ -120,7 +133,9 @@ type SDK interface {
 	// User returns user object.
 	User(token string) (User, error)
 
-	// CreateToken receives credentials and returns user token.
+	// CreateToken receives credentials and returns user token. Once the
+	// token expires, it is refreshed by the SDK, so it can be used for as
+	// long as the refresh token issued alongside it remains valid.
 	CreateToken(user User) (string, error)
 
 	// UpdateUser updates existing user.
@@ -138,6 +153,11 @@ type SDK interface {
 	// Things returns page of things.
 	Things(token string, offset, limit uint64, name string) (ThingsPage, error)
 
+	// ThingsAfter returns page of things following the given cursor. An empty
+	// cursor returns the first page; the cursor for the next page is returned
+	// in the page's NextCursor.
+	ThingsAfter(token, cursor string, limit uint64, name string) (ThingsPage, error)
+
 	// ThingsByChannel returns page of things that are connected or not connected
 	// to specified channel.
 	ThingsByChannel(token, chanID string, offset, limit uint64, connected bool) (ThingsPage, error)
@@ -151,6 +171,10 @@ type SDK interface {
 	// DeleteThing removes existing thing.
 	DeleteThing(id, token string) error
 
+	// ShareThing shares the thing with the users group, or unshares it if
+	// the group is empty.
+	ShareThing(id, group, token string) error
+
 	// CreateGroup creates new group and returns its id.
 	CreateGroup(group Group, token string) (string, error)
 
@@ -193,6 +217,10 @@ type SDK interface {
 	// Channels returns page of channels.
 	Channels(token string, offset, limit uint64, name string) (ChannelsPage, error)
 
+	// ChannelsAfter returns page of channels following the given cursor. An
+	// empty cursor returns the first page.
+	ChannelsAfter(token, cursor string, limit uint64, name string) (ChannelsPage, error)
+
 	// ChannelsByThing returns page of channels that are connected or not connected
 	// to specified thing.
 	ChannelsByThing(token, thingID string, offset, limit uint64, connected bool) (ChannelsPage, error)
@@ -206,11 +234,18 @@ type SDK interface {
 	// DeleteChannel removes existing channel.
 	DeleteChannel(id, token string) error
 
+	// ShareChannel shares the channel with the users group, or unshares it
+	// if the group is empty.
+	ShareChannel(id, group, token string) error
+
 	// SendMessage send message to specified channel.
 	SendMessage(chanID, msg, token string) error
 
-	// ReadMessages read messages of specified channel.
-	ReadMessages(chanID, token string) (MessagesPage, error)
+	// ReadMessages read messages of specified channel matching the query.
+	ReadMessages(chanID, token string, query MessagesQuery) (MessagesPage, error)
+
+	// AggregateMessages returns aggregated values of specified channel messages.
+	AggregateMessages(chanID, token string, query AggregationQuery) ([]Aggregate, error)
 
 	// SetContentType sets message content type.
 	SetContentType(ct ContentType) error
@@ -247,6 +282,19 @@ type SDK interface {
 
 	// RevokeCert revokes certificate with certID for thing with thingID
 	RevokeCert(thingID, certID, token string) error
+
+	// Keys returns page of API keys issued by the user.
+	Keys(token string, query KeysQuery) (KeysPage, error)
+
+	// RevokeKey revokes API key specified by id.
+	RevokeKey(id, token string) error
+
+	// RevokeKeys revokes all of the API keys issued by the user. If subject
+	// is not empty, only the keys issued for the subject are revoked.
+	RevokeKeys(subject, token string) error
+
+	// Events returns page of audit events matching the query.
+	Events(token string, query EventsQuery) (EventsPage, error)
 }
 
 type mfSDK struct {
@@ -254,6 +302,8 @@ type mfSDK struct {
 	readerURL         string
 	bootstrapURL      string
 	certsURL          string
+	authnURL          string
+	auditURL          string
 	readerPrefix      string
 	usersPrefix       string
 	groupsPrefix      string
@@ -264,6 +314,7 @@ type mfSDK struct {
 	bootstrapPrefix   string
 	msgContentType    ContentType
 	client            *http.Client
+	sessions          *sessions
 }
 
 // Config contains sdk configuration parameters.
@@ -272,6 +323,8 @@ type Config struct {
 	ReaderURL         string
 	BootstrapURL      string
 	CertsURL          string
+	AuthnURL          string
+	AuditURL          string
 	ReaderPrefix      string
 	UsersPrefix       string
 	GroupsPrefix      string
@@ -289,6 +342,8 @@ func NewSDK(conf Config) SDK {
 		readerURL:         conf.ReaderURL,
 		bootstrapURL:      conf.BootstrapURL,
 		certsURL:          conf.CertsURL,
+		authnURL:          conf.AuthnURL,
+		auditURL:          conf.AuditURL,
 		readerPrefix:      conf.ReaderPrefix,
 		usersPrefix:       conf.UsersPrefix,
 		groupsPrefix:      conf.GroupsPrefix,
@@ -303,10 +358,16 @@ func NewSDK(conf Config) SDK {
 				},
 			},
 		},
+		sessions: newSessions(),
 	}
 }
 
 func (sdk mfSDK) sendRequest(req *http.Request, token, contentType string) (*http.Response, error) {
+	s := sdk.sessions.retrieve(token)
+	if s != nil {
+		token = s.token()
+	}
+
 	if token != "" {
 		req.Header.Set("Authorization", token)
 	}
@@ -315,7 +376,37 @@ func (sdk mfSDK) sendRequest(req *http.Request, token, contentType string) (*htt
 		req.Header.Add("Content-Type", contentType)
 	}
 
-	return sdk.client.Do(req)
+	resp, err := sdk.client.Do(req)
+	if err != nil || s == nil {
+		return resp, err
+	}
+	if resp.StatusCode != http.StatusUnauthorized && resp.StatusCode != http.StatusForbidden {
+		return resp, nil
+	}
+	if req.Body != nil && req.GetBody == nil {
+		return resp, nil
+	}
+
+	// Access token may have expired, so the request is repeated once
+	// using the refreshed token. If refreshing fails, the original
+	// response is returned.
+	access, err := sdk.refreshSession(s, token)
+	if err != nil {
+		return resp, nil
+	}
+
+	retry := req.Clone(req.Context())
+	if req.GetBody != nil {
+		body, err := req.GetBody()
+		if err != nil {
+			return resp, nil
+		}
+		retry.Body = body
+	}
+	retry.Header.Set("Authorization", access)
+	resp.Body.Close()
+
+	return sdk.client.Do(retry)
 }
 
 func createURL(baseURL, prefix, endpoint string) string {
@@ -0,0 +1,112 @@
+// Copyright (c) Mainflux
+// SPDX-License-Identifier: Apache-2.0
+
+package sdk
+
+import (
+	"bytes"
+	"encoding/json"
+	"io/ioutil"
+	"net/http"
+	"sync"
+
+	"github.com/mainflux/mainflux/pkg/errors"
+)
+
+const refreshEndpoint = "tokens/refresh"
+
+// session holds the current pair of tokens obtained using the same login.
+type session struct {
+	mu      sync.Mutex
+	access  string
+	refresh string
+}
+
+// sessions keeps track of the tokens created using the SDK, so that the
+// expired access tokens can be refreshed without involving the caller.
+// Sessions are looked up by any of the access tokens issued within them,
+// so the caller can keep using the token returned on login.
+type sessions struct {
+	mu     sync.Mutex
+	tokens map[string]*session
+}
+
+func newSessions() *sessions {
+	return &sessions{
+		tokens: make(map[string]*session),
+	}
+}
+
+func (ss *sessions) save(access, refresh string) {
+	ss.mu.Lock()
+	defer ss.mu.Unlock()
+
+	ss.tokens[access] = &session{access: access, refresh: refresh}
+}
+
+func (ss *sessions) retrieve(access string) *session {
+	ss.mu.Lock()
+	defer ss.mu.Unlock()
+
+	return ss.tokens[access]
+}
+
+func (ss *sessions) alias(access string, s *session) {
+	ss.mu.Lock()
+	defer ss.mu.Unlock()
+
+	ss.tokens[access] = s
+}
+
+func (s *session) token() string {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	return s.access
+}
+
+// refreshSession exchanges the refresh token of the session for a new pair
+// of tokens, unless the session has already been refreshed since the
+// provided access token was used. Refresh tokens are single-use, so the
+// concurrent requests must not refresh the same session more than once.
+func (sdk mfSDK) refreshSession(s *session, used string) (string, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if s.access != used {
+		return s.access, nil
+	}
+
+	data, err := json.Marshal(refreshReq{RefreshToken: s.refresh})
+	if err != nil {
+		return "", err
+	}
+
+	url := createURL(sdk.baseURL, sdk.usersPrefix, refreshEndpoint)
+
+	resp, err := sdk.client.Post(url, string(CTJSON), bytes.NewReader(data))
+	if err != nil {
+		return "", err
+	}
+	defer resp.Body.Close()
+
+	body, err := ioutil.ReadAll(resp.Body)
+	if err != nil {
+		return "", err
+	}
+
+	if resp.StatusCode != http.StatusCreated {
+		return "", errors.Wrap(ErrFailedRefresh, errors.New(resp.Status))
+	}
+
+	var tr tokenRes
+	if err := json.Unmarshal(body, &tr); err != nil {
+		return "", err
+	}
+
+	s.access = tr.Token
+	s.refresh = tr.RefreshToken
+	sdk.sessions.alias(tr.Token, s)
+
+	return tr.Token, nil
+}
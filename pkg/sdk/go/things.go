@@ -7,6 +7,7 @@ import (
 	"bytes"
 	"encoding/json"
 	"fmt"
+	"io"
 	"io/ioutil"
 	"net/http"
 	"strings"
@@ -83,6 +84,15 @@ func (sdk mfSDK) CreateThings(things []Thing, token string) ([]Thing, error) {
 
 func (sdk mfSDK) Things(token string, offset, limit uint64, name string) (ThingsPage, error) {
 	endpoint := fmt.Sprintf("%s?offset=%d&limit=%d&name=%s", thingsEndpoint, offset, limit, name)
+	return sdk.things(token, endpoint)
+}
+
+func (sdk mfSDK) ThingsAfter(token, cursor string, limit uint64, name string) (ThingsPage, error) {
+	endpoint := fmt.Sprintf("%s?cursor=%s&limit=%d&name=%s", thingsEndpoint, cursor, limit, name)
+	return sdk.things(token, endpoint)
+}
+
+func (sdk mfSDK) things(token, endpoint string) (ThingsPage, error) {
 	url := createURL(sdk.baseURL, sdk.thingsPrefix, endpoint)
 
 	req, err := http.NewRequest(http.MethodGet, url, nil)
@@ -224,6 +234,43 @@ func (sdk mfSDK) DeleteThing(id, token string) error {
 	return nil
 }
 
+func (sdk mfSDK) ShareThing(id, group, token string) error {
+	endpoint := fmt.Sprintf("%s/%s/share", thingsEndpoint, id)
+	return sdk.share(endpoint, group, token)
+}
+
+func (sdk mfSDK) share(endpoint, group, token string) error {
+	url := createURL(sdk.baseURL, sdk.thingsPrefix, endpoint)
+
+	method, status := http.MethodPut, http.StatusOK
+	var body io.Reader
+	if group == "" {
+		method, status = http.MethodDelete, http.StatusNoContent
+	} else {
+		data, err := json.Marshal(map[string]string{"user_group": group})
+		if err != nil {
+			return err
+		}
+		body = bytes.NewReader(data)
+	}
+
+	req, err := http.NewRequest(method, url, body)
+	if err != nil {
+		return err
+	}
+
+	resp, err := sdk.sendRequest(req, token, string(CTJSON))
+	if err != nil {
+		return err
+	}
+
+	if resp.StatusCode != status {
+		return errors.Wrap(ErrFailedUpdate, errors.New(resp.Status))
+	}
+
+	return nil
+}
+
 func (sdk mfSDK) Connect(connIDs ConnectionIDs, token string) error {
 	data, err := json.Marshal(connIDs)
 	if err != nil {
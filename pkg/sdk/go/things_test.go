@@ -41,15 +41,20 @@ var (
 )
 
 func newThingsService(tokens map[string]string) things.Service {
+	return newSharedThingsService(tokens, map[string]map[string]string{})
+}
+
+func newSharedThingsService(tokens map[string]string, roles map[string]map[string]string) things.Service {
 	auth := mocks.NewAuthService(tokens)
 	conns := make(chan mocks.Connection)
 	thingsRepo := mocks.NewThingRepository(conns)
 	channelsRepo := mocks.NewChannelRepository(thingsRepo, conns)
+	groupsRepo := mocks.NewGroupRepository(thingsRepo, channelsRepo)
 	chanCache := mocks.NewChannelCache()
 	thingCache := mocks.NewThingCache()
 	uuidProvider := uuid.NewMock()
 
-	return things.New(auth, thingsRepo, channelsRepo, chanCache, thingCache, uuidProvider)
+	return things.New(auth, mocks.NewUsersService(roles), thingsRepo, channelsRepo, groupsRepo, chanCache, thingCache, uuidProvider)
 }
 
 func newThingsServer(svc things.Service) *httptest.Server {
@@ -341,6 +346,81 @@ func TestThings(t *testing.T) {
 	}
 }
 
+func TestThingsAfter(t *testing.T) {
+	svc := newThingsService(map[string]string{token: email})
+	ts := newThingsServer(svc)
+	defer ts.Close()
+	sdkConf := sdk.Config{
+		BaseURL:           ts.URL,
+		UsersPrefix:       "",
+		GroupsPrefix:      "",
+		ThingsPrefix:      "",
+		HTTPAdapterPrefix: "",
+		MsgContentType:    contentType,
+		TLSVerification:   false,
+	}
+	var things []sdk.Thing
+
+	mainfluxSDK := sdk.NewSDK(sdkConf)
+	for i := 1; i < 21; i++ {
+		th := sdk.Thing{ID: strconv.Itoa(i), Name: "test_device", Metadata: metadata}
+		mainfluxSDK.CreateThing(th, token)
+		th.Key = fmt.Sprintf("%s%012d", keyPrefix, 2*i)
+		things = append(things, th)
+	}
+
+	first, err := mainfluxSDK.ThingsAfter(token, "", 5, "")
+	require.Nil(t, err, fmt.Sprintf("unexpected error: %s", err))
+	require.NotEmpty(t, first.NextCursor, "expected next cursor in the first page")
+
+	cases := []struct {
+		desc     string
+		token    string
+		cursor   string
+		limit    uint64
+		err      error
+		response []sdk.Thing
+	}{
+		{
+			desc:     "get a list of things without cursor",
+			token:    token,
+			cursor:   "",
+			limit:    5,
+			err:      nil,
+			response: things[0:5],
+		},
+		{
+			desc:     "get a list of things after cursor",
+			token:    token,
+			cursor:   first.NextCursor,
+			limit:    5,
+			err:      nil,
+			response: things[5:10],
+		},
+		{
+			desc:     "get a list of things with invalid cursor",
+			token:    token,
+			cursor:   wrongValue,
+			limit:    5,
+			err:      createError(sdk.ErrFailedFetch, http.StatusBadRequest),
+			response: nil,
+		},
+		{
+			desc:     "get a list of things after cursor with invalid token",
+			token:    wrongValue,
+			cursor:   first.NextCursor,
+			limit:    5,
+			err:      createError(sdk.ErrFailedFetch, http.StatusUnauthorized),
+			response: nil,
+		},
+	}
+	for _, tc := range cases {
+		page, err := mainfluxSDK.ThingsAfter(tc.token, tc.cursor, tc.limit, "")
+		assert.Equal(t, tc.err, err, fmt.Sprintf("%s: expected error %s, got %s", tc.desc, tc.err, err))
+		assert.ElementsMatch(t, tc.response, page.Things, fmt.Sprintf("%s: expected response things %s, got %s", tc.desc, tc.response, page.Things))
+	}
+}
+
 func TestThingsByChannel(t *testing.T) {
 	svc := newThingsService(map[string]string{token: email})
 	ts := newThingsServer(svc)
@@ -639,6 +719,63 @@ func TestDeleteThing(t *testing.T) {
 	}
 }
 
+func TestShareThing(t *testing.T) {
+	const userGroup = "user-group"
+	svc := newSharedThingsService(map[string]string{token: email}, map[string]map[string]string{email: {userGroup: "editor"}})
+	ts := newThingsServer(svc)
+	defer ts.Close()
+	sdkConf := sdk.Config{
+		BaseURL:           ts.URL,
+		UsersPrefix:       "",
+		GroupsPrefix:      "",
+		ThingsPrefix:      "",
+		HTTPAdapterPrefix: "",
+		MsgContentType:    contentType,
+		TLSVerification:   false,
+	}
+
+	mainfluxSDK := sdk.NewSDK(sdkConf)
+	id, err := mainfluxSDK.CreateThing(thing, token)
+	require.Nil(t, err, fmt.Sprintf("unexpected error: %s", err))
+
+	cases := []struct {
+		desc  string
+		group string
+		token string
+		err   error
+	}{
+		{
+			desc:  "share thing",
+			group: userGroup,
+			token: token,
+			err:   nil,
+		},
+		{
+			desc:  "share thing with group the user can't write into",
+			group: wrongValue,
+			token: token,
+			err:   createError(sdk.ErrFailedUpdate, http.StatusUnauthorized),
+		},
+		{
+			desc:  "share thing with invalid token",
+			group: userGroup,
+			token: wrongValue,
+			err:   createError(sdk.ErrFailedUpdate, http.StatusUnauthorized),
+		},
+		{
+			desc:  "unshare thing",
+			group: emptyValue,
+			token: token,
+			err:   nil,
+		},
+	}
+
+	for _, tc := range cases {
+		err := mainfluxSDK.ShareThing(id, tc.group, tc.token)
+		assert.Equal(t, tc.err, err, fmt.Sprintf("%s: expected error %s, got %s", tc.desc, tc.err, err))
+	}
+}
+
 func TestConnectThing(t *testing.T) {
 	svc := newThingsService(map[string]string{
 		token:      email,
@@ -841,8 +978,8 @@ func TestConnect(t *testing.T) {
 
 	for _, tc := range cases {
 		connIDs := sdk.ConnectionIDs{
-			[]string{tc.thingID},
-			[]string{tc.chanID},
+			ChannelIDs: []string{tc.chanID},
+			ThingIDs:   []string{tc.thingID},
 		}
 
 		err := mainfluxSDK.Connect(connIDs, tc.token)
@@ -99,6 +99,9 @@ func (sdk mfSDK) CreateToken(user User) (string, error) {
 	if err := json.Unmarshal(body, &tr); err != nil {
 		return "", err
 	}
+	if tr.RefreshToken != "" {
+		sdk.sessions.save(tr.Token, tr.RefreshToken)
+	}
 
 	return tr.Token, nil
 }
@@ -17,6 +17,7 @@ import (
 	"github.com/mainflux/mainflux/users/mocks"
 	"github.com/opentracing/opentracing-go/mocktracer"
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
 )
 
 const (
@@ -30,7 +31,7 @@ func newUserService() users.Service {
 	auth := mocks.NewAuthService(map[string]string{"user@example.com": "user@example.com"})
 	emailer := mocks.NewEmailer()
 
-	return users.New(usersRepo, groupsRepo, hasher, auth, emailer)
+	return users.New(usersRepo, groupsRepo, mocks.NewInvitationRepository(), mocks.NewImpersonationRepository(), hasher, auth, emailer, nil, users.NewAttemptRepository(), users.LockoutPolicy{}, users.VerificationPolicy{}, users.InvitationPolicy{}, "")
 }
 
 func newUserServer(svc users.Service) *httptest.Server {
@@ -153,3 +154,46 @@ func TestCreateToken(t *testing.T) {
 		assert.Equal(t, tc.token, token, fmt.Sprintf("%s: expected response: %s, got:  %s", tc.desc, token, tc.token))
 	}
 }
+
+func TestRefreshToken(t *testing.T) {
+	svc := newUserService()
+	ts := newUserServer(svc)
+	defer ts.Close()
+	sdkConf := sdk.Config{
+		BaseURL:           ts.URL,
+		UsersPrefix:       "",
+		GroupsPrefix:      "",
+		ThingsPrefix:      "",
+		HTTPAdapterPrefix: "",
+		MsgContentType:    contentType,
+		TLSVerification:   false,
+	}
+
+	mainfluxSDK := sdk.NewSDK(sdkConf)
+	user := sdk.User{Email: "user@example.com", Password: "password"}
+	mainfluxSDK.CreateUser(user)
+	token, err := mainfluxSDK.CreateToken(user)
+	require.Nil(t, err, fmt.Sprintf("unexpected error: %s", err))
+
+	// Revoked access token is rejected in the same way as the expired one.
+	err = svc.Logout(context.Background(), token)
+	require.Nil(t, err, fmt.Sprintf("unexpected error: %s", err))
+
+	_, err = mainfluxSDK.User(token)
+	assert.Nil(t, err, fmt.Sprintf("fetching user with refreshed token: unexpected error %s", err))
+
+	err = svc.Logout(context.Background(), token)
+	require.Nil(t, err, fmt.Sprintf("unexpected error: %s", err))
+
+	err = mainfluxSDK.UpdateUser(sdk.User{Metadata: map[string]interface{}{"name": "test"}}, token)
+	assert.Nil(t, err, fmt.Sprintf("updating user with refreshed token: unexpected error %s", err))
+
+	err = svc.Logout(context.Background(), token)
+	require.Nil(t, err, fmt.Sprintf("unexpected error: %s", err))
+
+	// Tokens that are not created using the SDK are not refreshed.
+	otherSDK := sdk.NewSDK(sdkConf)
+	_, err = otherSDK.User(token)
+	expected := createError(sdk.ErrFailedFetch, http.StatusForbidden)
+	assert.Equal(t, expected, err, fmt.Sprintf("fetching user with revoked token: expected %s got %s", expected, err))
+}
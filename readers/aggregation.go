@@ -0,0 +1,217 @@
+// Copyright (c) Mainflux
+// SPDX-License-Identifier: Apache-2.0
+
+package readers
+
+import (
+	"errors"
+	"math"
+	"sort"
+	"time"
+
+	"github.com/mainflux/mainflux/pkg/transformers/senml"
+)
+
+// Aggregation functions supported by the message repositories.
+const (
+	AggregationMin   = "min"
+	AggregationMax   = "max"
+	AggregationAvg   = "avg"
+	AggregationSum   = "sum"
+	AggregationCount = "count"
+	AggregationFirst = "first"
+	AggregationLast  = "last"
+)
+
+// Fields messages can be grouped by during aggregation.
+const (
+	GroupByName      = "name"
+	GroupByPublisher = "publisher"
+)
+
+var (
+	// ErrUnsupportedAggregation indicates that the aggregation function is
+	// not supported.
+	ErrUnsupportedAggregation = errors.New("unsupported aggregation function")
+
+	// ErrUnboundedAggregation indicates that the aggregation query lacks the
+	// time range required by the repository.
+	ErrUnboundedAggregation = errors.New("aggregation requires from and to time range")
+
+	// ErrAggregationLimit indicates that the aggregation query matches more
+	// messages than the repository is willing to aggregate.
+	ErrAggregationLimit = errors.New("too many messages to aggregate")
+)
+
+// AggregationQuery specifies how message values are aggregated. Numeric
+// values of messages matching Query are split into time buckets of the
+// given Interval, grouped by fields listed in GroupBy and reduced using
+// Function. Query has the same format as the one used by ReadAll.
+type AggregationQuery struct {
+	Function string
+	Interval time.Duration
+	GroupBy  []string
+	Query    map[string]string
+}
+
+// GroupedBy returns true if messages are grouped by the given field.
+func (aq AggregationQuery) GroupedBy(field string) bool {
+	for _, f := range aq.GroupBy {
+		if f == field {
+			return true
+		}
+	}
+
+	return false
+}
+
+// Aggregate represents aggregated value of a single bucket. Time is the
+// bucket start in seconds. Name and Publisher are set only if messages are
+// grouped by them.
+type Aggregate struct {
+	Time      float64
+	Name      string
+	Publisher string
+	Value     float64
+}
+
+// Bucket returns the start of the bucket of given interval containing the
+// time t, where t is expressed in seconds.
+func Bucket(t float64, interval time.Duration) float64 {
+	i := interval.Seconds()
+	return math.Floor(t/i) * i
+}
+
+// SortAggregates sorts aggregates by time, name and publisher.
+func SortAggregates(aggs []Aggregate) {
+	sort.SliceStable(aggs, func(i, j int) bool {
+		if aggs[i].Time != aggs[j].Time {
+			return aggs[i].Time < aggs[j].Time
+		}
+		if aggs[i].Name != aggs[j].Name {
+			return aggs[i].Name < aggs[j].Name
+		}
+		return aggs[i].Publisher < aggs[j].Publisher
+	})
+}
+
+type accumulator struct {
+	min, max, sum float64
+	count         uint64
+	firstT, lastT float64
+	first, last   float64
+}
+
+func (acc *accumulator) add(t, v float64) {
+	if acc.count == 0 || v < acc.min {
+		acc.min = v
+	}
+	if acc.count == 0 || v > acc.max {
+		acc.max = v
+	}
+	if acc.count == 0 || t < acc.firstT {
+		acc.firstT, acc.first = t, v
+	}
+	if acc.count == 0 || t >= acc.lastT {
+		acc.lastT, acc.last = t, v
+	}
+	acc.sum += v
+	acc.count++
+}
+
+func (acc accumulator) value(function string) float64 {
+	switch function {
+	case AggregationMin:
+		return acc.min
+	case AggregationMax:
+		return acc.max
+	case AggregationAvg:
+		return acc.sum / float64(acc.count)
+	case AggregationSum:
+		return acc.sum
+	case AggregationCount:
+		return float64(acc.count)
+	case AggregationFirst:
+		return acc.first
+	default:
+		return acc.last
+	}
+}
+
+// Aggregator folds messages into the running per-bucket aggregates, so the
+// repositories lacking native aggregation support don't have to keep the
+// messages in memory.
+type Aggregator struct {
+	aq               AggregationQuery
+	groupByName      bool
+	groupByPublisher bool
+	accs             map[Aggregate]*accumulator
+}
+
+// NewAggregator returns the aggregator for the given query.
+func NewAggregator(aq AggregationQuery) (*Aggregator, error) {
+	switch aq.Function {
+	case AggregationMin, AggregationMax, AggregationAvg, AggregationSum,
+		AggregationCount, AggregationFirst, AggregationLast:
+	default:
+		return nil, ErrUnsupportedAggregation
+	}
+
+	return &Aggregator{
+		aq:               aq,
+		groupByName:      aq.GroupedBy(GroupByName),
+		groupByPublisher: aq.GroupedBy(GroupByPublisher),
+		accs:             map[Aggregate]*accumulator{},
+	}, nil
+}
+
+// Add folds the message into its bucket. Messages without numeric value are
+// skipped.
+func (a *Aggregator) Add(msg senml.Message) {
+	if msg.Value == nil {
+		return
+	}
+
+	key := Aggregate{Time: Bucket(msg.Time, a.aq.Interval)}
+	if a.groupByName {
+		key.Name = msg.Name
+	}
+	if a.groupByPublisher {
+		key.Publisher = msg.Publisher
+	}
+
+	acc, ok := a.accs[key]
+	if !ok {
+		acc = &accumulator{}
+		a.accs[key] = acc
+	}
+	acc.add(msg.Time, *msg.Value)
+}
+
+// Aggregates returns the sorted aggregates of the added messages.
+func (a *Aggregator) Aggregates() []Aggregate {
+	aggs := []Aggregate{}
+	for key, acc := range a.accs {
+		key.Value = acc.value(a.aq.Function)
+		aggs = append(aggs, key)
+	}
+	SortAggregates(aggs)
+
+	return aggs
+}
+
+// AggregateMessages computes aggregates of the given messages in memory. It
+// is meant for repositories lacking native aggregation support. Messages
+// without numeric value are skipped. The returned aggregates are sorted.
+func AggregateMessages(msgs []senml.Message, aq AggregationQuery) ([]Aggregate, error) {
+	a, err := NewAggregator(aq)
+	if err != nil {
+		return nil, err
+	}
+
+	for _, msg := range msgs {
+		a.Add(msg)
+	}
+
+	return a.Aggregates(), nil
+}
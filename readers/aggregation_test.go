@@ -0,0 +1,154 @@
+// Copyright (c) Mainflux
+// SPDX-License-Identifier: Apache-2.0
+
+package readers_test
+
+import (
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/mainflux/mainflux/pkg/transformers/senml"
+	"github.com/mainflux/mainflux/readers"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestAggregateMessages(t *testing.T) {
+	names := []string{"a", "b"}
+	msgs := []senml.Message{}
+	// Messages are intentionally out of order.
+	for i := 9; i >= 0; i-- {
+		val := float64(i)
+		msgs = append(msgs, senml.Message{Name: names[i%2], Publisher: "1", Value: &val, Time: float64(120 + i)})
+	}
+	msgs = append(msgs, senml.Message{Name: "a", StringValue: &names[0], Time: 121})
+
+	cases := []struct {
+		desc string
+		aq   readers.AggregationQuery
+		aggs []readers.Aggregate
+		err  error
+	}{
+		{
+			desc: "aggregate minimum",
+			aq:   readers.AggregationQuery{Function: readers.AggregationMin, Interval: time.Minute},
+			aggs: []readers.Aggregate{{Time: 120, Value: 0}},
+		},
+		{
+			desc: "aggregate maximum",
+			aq:   readers.AggregationQuery{Function: readers.AggregationMax, Interval: time.Minute},
+			aggs: []readers.Aggregate{{Time: 120, Value: 9}},
+		},
+		{
+			desc: "aggregate average",
+			aq:   readers.AggregationQuery{Function: readers.AggregationAvg, Interval: time.Minute},
+			aggs: []readers.Aggregate{{Time: 120, Value: 4.5}},
+		},
+		{
+			desc: "aggregate sum",
+			aq:   readers.AggregationQuery{Function: readers.AggregationSum, Interval: time.Minute},
+			aggs: []readers.Aggregate{{Time: 120, Value: 45}},
+		},
+		{
+			desc: "aggregate count",
+			aq:   readers.AggregationQuery{Function: readers.AggregationCount, Interval: time.Minute},
+			aggs: []readers.Aggregate{{Time: 120, Value: 10}},
+		},
+		{
+			desc: "aggregate first",
+			aq:   readers.AggregationQuery{Function: readers.AggregationFirst, Interval: time.Minute},
+			aggs: []readers.Aggregate{{Time: 120, Value: 0}},
+		},
+		{
+			desc: "aggregate last",
+			aq:   readers.AggregationQuery{Function: readers.AggregationLast, Interval: time.Minute},
+			aggs: []readers.Aggregate{{Time: 120, Value: 9}},
+		},
+		{
+			desc: "aggregate grouped by name and publisher",
+			aq:   readers.AggregationQuery{Function: readers.AggregationSum, Interval: time.Minute, GroupBy: []string{readers.GroupByName, readers.GroupByPublisher}},
+			aggs: []readers.Aggregate{
+				{Time: 120, Name: "a", Publisher: "1", Value: 20},
+				{Time: 120, Name: "b", Publisher: "1", Value: 25},
+			},
+		},
+		{
+			desc: "aggregate in smaller buckets",
+			aq:   readers.AggregationQuery{Function: readers.AggregationCount, Interval: 5 * time.Second},
+			aggs: []readers.Aggregate{
+				{Time: 120, Value: 5},
+				{Time: 125, Value: 5},
+			},
+		},
+		{
+			desc: "aggregate using unsupported function",
+			aq:   readers.AggregationQuery{Function: "median", Interval: time.Minute},
+			err:  readers.ErrUnsupportedAggregation,
+		},
+	}
+
+	for _, tc := range cases {
+		aggs, err := readers.AggregateMessages(msgs, tc.aq)
+		assert.Equal(t, tc.err, err, fmt.Sprintf("%s: expected %s got %s", tc.desc, tc.err, err))
+		assert.Equal(t, tc.aggs, aggs, fmt.Sprintf("%s: expected %v got %v", tc.desc, tc.aggs, aggs))
+	}
+}
+
+func TestAggregator(t *testing.T) {
+	_, err := readers.NewAggregator(readers.AggregationQuery{Function: "median", Interval: time.Minute})
+	assert.Equal(t, readers.ErrUnsupportedAggregation, err, fmt.Sprintf("create aggregator using unsupported function: expected %s got %s", readers.ErrUnsupportedAggregation, err))
+
+	agg, err := readers.NewAggregator(readers.AggregationQuery{Function: readers.AggregationAvg, Interval: time.Minute})
+	assert.Nil(t, err, fmt.Sprintf("create aggregator: expected no error got %s", err))
+
+	cases := []struct {
+		desc string
+		val  float64
+		time float64
+		aggs []readers.Aggregate
+	}{
+		{
+			desc: "add first message",
+			val:  2,
+			time: 120,
+			aggs: []readers.Aggregate{{Time: 120, Value: 2}},
+		},
+		{
+			desc: "add message to the same bucket",
+			val:  4,
+			time: 150,
+			aggs: []readers.Aggregate{{Time: 120, Value: 3}},
+		},
+		{
+			desc: "add message to the earlier bucket",
+			val:  1,
+			time: 60,
+			aggs: []readers.Aggregate{{Time: 60, Value: 1}, {Time: 120, Value: 3}},
+		},
+	}
+
+	for _, tc := range cases {
+		val := tc.val
+		agg.Add(senml.Message{Value: &val, Time: tc.time})
+		aggs := agg.Aggregates()
+		assert.Equal(t, tc.aggs, aggs, fmt.Sprintf("%s: expected %v got %v", tc.desc, tc.aggs, aggs))
+	}
+}
+
+func TestBucket(t *testing.T) {
+	cases := []struct {
+		desc     string
+		time     float64
+		interval time.Duration
+		bucket   float64
+	}{
+		{desc: "bucket of time at interval start", time: 120, interval: time.Minute, bucket: 120},
+		{desc: "bucket of time within interval", time: 179.5, interval: time.Minute, bucket: 120},
+		{desc: "bucket of time within sub-second interval", time: 1.75, interval: 500 * time.Millisecond, bucket: 1.5},
+	}
+
+	for _, tc := range cases {
+		bucket := readers.Bucket(tc.time, tc.interval)
+		assert.Equal(t, tc.bucket, bucket, fmt.Sprintf("%s: expected %f got %f", tc.desc, tc.bucket, bucket))
+	}
+}
@@ -18,16 +18,54 @@ func listMessagesEndpoint(svc readers.MessageRepository) endpoint.Endpoint {
 			return nil, err
 		}
 
-		page, err := svc.ReadAll(req.chanID, req.offset, req.limit, req.query)
+		page, err := svc.ReadAll(req.chanID, req.offset, req.limit, req.cursor, req.query)
 		if err != nil {
 			return nil, err
 		}
 
 		return pageRes{
-			Total:    page.Total,
-			Offset:   page.Offset,
-			Limit:    page.Limit,
-			Messages: page.Messages,
+			Total:      page.Total,
+			Offset:     page.Offset,
+			Limit:      page.Limit,
+			NextCursor: page.NextCursor,
+			Messages:   page.Messages,
 		}, nil
 	}
 }
+
+func aggregateEndpoint(svc readers.MessageRepository) endpoint.Endpoint {
+	return func(_ context.Context, request interface{}) (interface{}, error) {
+		req := request.(aggregateReq)
+
+		if err := req.validate(); err != nil {
+			return nil, err
+		}
+
+		aq := readers.AggregationQuery{
+			Function: req.function,
+			Interval: req.interval,
+			GroupBy:  req.groupBy,
+			Query:    req.query,
+		}
+		aggs, err := svc.Aggregate(req.chanID, aq)
+		if err != nil {
+			return nil, err
+		}
+
+		res := aggregatesRes{
+			Function:   req.function,
+			Interval:   req.interval.String(),
+			Aggregates: []aggregateRes{},
+		}
+		for _, a := range aggs {
+			res.Aggregates = append(res.Aggregates, aggregateRes{
+				Time:      a.Time,
+				Name:      a.Name,
+				Publisher: a.Publisher,
+				Value:     a.Value,
+			})
+		}
+
+		return res, nil
+	}
+}
@@ -15,6 +15,7 @@ import (
 	"github.com/mainflux/mainflux/readers/api"
 	"github.com/mainflux/mainflux/readers/mocks"
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
 )
 
 const (
@@ -24,6 +25,10 @@ const (
 	numOfMessages = 42
 	chanID        = "1"
 	valueFields   = 5
+	email         = "user@example.com"
+	userToken     = "user"
+	scopedToken   = "scoped"
+	otherToken    = "other"
 )
 
 var (
@@ -66,11 +71,27 @@ func newService() readers.MessageRepository {
 	})
 }
 
-func newServer(repo readers.MessageRepository, tc mainflux.ThingsServiceClient) *httptest.Server {
-	mux := api.MakeHandler(repo, tc, svcName)
+func newServer(repo readers.MessageRepository, tc mainflux.ThingsServiceClient, ac mainflux.AuthNServiceClient) *httptest.Server {
+	mux := api.MakeHandler(repo, tc, ac, svcName)
 	return httptest.NewServer(mux)
 }
 
+func newThingsService() mainflux.ThingsServiceClient {
+	return mocks.NewThingsService(map[string]string{token: chanID}, map[string]string{chanID: email})
+}
+
+func newAuthService() mainflux.AuthNServiceClient {
+	return mocks.NewAuthService(map[string]mainflux.UserIdentity{
+		userToken: {Id: email, Email: email},
+		scopedToken: {Id: email, Email: email, Scopes: []*mainflux.Scope{
+			{Resource: mainflux.MessagesResource, Action: mainflux.ReadAction, Ids: []string{chanID}},
+		}},
+		otherToken: {Id: email, Email: email, Scopes: []*mainflux.Scope{
+			{Resource: mainflux.ChannelsResource, Action: mainflux.ReadAction},
+		}},
+	})
+}
+
 type testRequest struct {
 	client *http.Client
 	method string
@@ -92,10 +113,13 @@ func (tr testRequest) make() (*http.Response, error) {
 
 func TestReadAll(t *testing.T) {
 	svc := newService()
-	tc := mocks.NewThingsService()
-	ts := newServer(svc, tc)
+	tc := newThingsService()
+	ts := newServer(svc, tc, newAuthService())
 	defer ts.Close()
 
+	page, err := svc.ReadAll(chanID, 0, 10, "", nil)
+	require.Nil(t, err, fmt.Sprintf("unexpected error: %s", err))
+
 	cases := map[string]struct {
 		url    string
 		token  string
@@ -141,6 +165,26 @@ func TestReadAll(t *testing.T) {
 			token:  invalid,
 			status: http.StatusForbidden,
 		},
+		"read page with user token": {
+			url:    fmt.Sprintf("%s/channels/%s/messages?offset=0&limit=10", ts.URL, chanID),
+			token:  userToken,
+			status: http.StatusOK,
+		},
+		"read page with scoped API token": {
+			url:    fmt.Sprintf("%s/channels/%s/messages?offset=0&limit=10", ts.URL, chanID),
+			token:  scopedToken,
+			status: http.StatusOK,
+		},
+		"read page with API token out of scope": {
+			url:    fmt.Sprintf("%s/channels/%s/messages?offset=0&limit=10", ts.URL, chanID),
+			token:  otherToken,
+			status: http.StatusForbidden,
+		},
+		"read page of the channel that is not owned": {
+			url:    fmt.Sprintf("%s/channels/%s/messages?offset=0&limit=10", ts.URL, "2"),
+			token:  userToken,
+			status: http.StatusForbidden,
+		},
 		"read page with multiple offset": {
 			url:    fmt.Sprintf("%s/channels/%s/messages?offset=0&offset=1&limit=10", ts.URL, chanID),
 			token:  token,
@@ -161,6 +205,56 @@ func TestReadAll(t *testing.T) {
 			token:  token,
 			status: http.StatusOK,
 		},
+		"read page following the cursor": {
+			url:    fmt.Sprintf("%s/channels/%s/messages?limit=10&cursor=%s", ts.URL, chanID, page.NextCursor),
+			token:  token,
+			status: http.StatusOK,
+		},
+		"read page with invalid cursor": {
+			url:    fmt.Sprintf("%s/channels/%s/messages?limit=10&cursor=%s", ts.URL, chanID, invalid),
+			token:  token,
+			status: http.StatusBadRequest,
+		},
+		"read page with multiple cursors": {
+			url:    fmt.Sprintf("%s/channels/%s/messages?limit=10&cursor=%s&cursor=%s", ts.URL, chanID, page.NextCursor, page.NextCursor),
+			token:  token,
+			status: http.StatusBadRequest,
+		},
+		"read page within time range": {
+			url:    fmt.Sprintf("%s/channels/%s/messages?limit=10&from=1600000000&to=1600000100.5", ts.URL, chanID),
+			token:  token,
+			status: http.StatusOK,
+		},
+		"read page with invalid from": {
+			url:    fmt.Sprintf("%s/channels/%s/messages?limit=10&from=%s", ts.URL, chanID, invalid),
+			token:  token,
+			status: http.StatusBadRequest,
+		},
+		"read page with invalid to": {
+			url:    fmt.Sprintf("%s/channels/%s/messages?limit=10&to=%s", ts.URL, chanID, invalid),
+			token:  token,
+			status: http.StatusBadRequest,
+		},
+		"read page with value comparator": {
+			url:    fmt.Sprintf("%s/channels/%s/messages?limit=10&v=5&comparator=%s", ts.URL, chanID, readers.GreaterThanEqualKey),
+			token:  token,
+			status: http.StatusOK,
+		},
+		"read page with invalid value": {
+			url:    fmt.Sprintf("%s/channels/%s/messages?limit=10&v=%s", ts.URL, chanID, invalid),
+			token:  token,
+			status: http.StatusBadRequest,
+		},
+		"read page with invalid comparator": {
+			url:    fmt.Sprintf("%s/channels/%s/messages?limit=10&v=5&comparator=%s", ts.URL, chanID, invalid),
+			token:  token,
+			status: http.StatusBadRequest,
+		},
+		"read page with comparator without value": {
+			url:    fmt.Sprintf("%s/channels/%s/messages?limit=10&comparator=%s", ts.URL, chanID, readers.LowerThanKey),
+			token:  token,
+			status: http.StatusBadRequest,
+		},
 		"read page with default limit": {
 			url:    fmt.Sprintf("%s/channels/%s/messages?offset=0", ts.URL, chanID),
 			token:  token,
@@ -180,3 +274,89 @@ func TestReadAll(t *testing.T) {
 		assert.Equal(t, tc.status, res.StatusCode, fmt.Sprintf("%s: expected %d got %d", desc, tc.status, res.StatusCode))
 	}
 }
+
+func TestAggregate(t *testing.T) {
+	svc := newService()
+	tc := newThingsService()
+	ts := newServer(svc, tc, newAuthService())
+	defer ts.Close()
+
+	cases := map[string]struct {
+		url    string
+		token  string
+		status int
+	}{
+		"aggregate messages": {
+			url:    fmt.Sprintf("%s/channels/%s/messages/aggregate?function=avg&interval=1m&from=0&to=3600", ts.URL, chanID),
+			token:  token,
+			status: http.StatusOK,
+		},
+		"aggregate messages grouped by name and publisher": {
+			url:    fmt.Sprintf("%s/channels/%s/messages/aggregate?function=count&interval=1h&from=0&to=3600&group_by=name,publisher", ts.URL, chanID),
+			token:  token,
+			status: http.StatusOK,
+		},
+		"aggregate messages grouped by repeated fields": {
+			url:    fmt.Sprintf("%s/channels/%s/messages/aggregate?function=count&interval=1h&from=0&to=3600&group_by=name&group_by=publisher", ts.URL, chanID),
+			token:  token,
+			status: http.StatusOK,
+		},
+		"aggregate messages grouped by invalid field": {
+			url:    fmt.Sprintf("%s/channels/%s/messages/aggregate?function=count&interval=1h&from=0&to=3600&group_by=%s", ts.URL, chanID, invalid),
+			token:  token,
+			status: http.StatusBadRequest,
+		},
+		"aggregate messages with invalid function": {
+			url:    fmt.Sprintf("%s/channels/%s/messages/aggregate?function=%s&interval=1m&from=0&to=3600", ts.URL, chanID, invalid),
+			token:  token,
+			status: http.StatusBadRequest,
+		},
+		"aggregate messages with multiple functions": {
+			url:    fmt.Sprintf("%s/channels/%s/messages/aggregate?function=min&function=max&interval=1m&from=0&to=3600", ts.URL, chanID),
+			token:  token,
+			status: http.StatusBadRequest,
+		},
+		"aggregate messages with invalid interval": {
+			url:    fmt.Sprintf("%s/channels/%s/messages/aggregate?function=min&interval=%s&from=0&to=3600", ts.URL, chanID, invalid),
+			token:  token,
+			status: http.StatusBadRequest,
+		},
+		"aggregate messages with too short interval": {
+			url:    fmt.Sprintf("%s/channels/%s/messages/aggregate?function=min&interval=1ms&from=0&to=3600", ts.URL, chanID),
+			token:  token,
+			status: http.StatusBadRequest,
+		},
+		"aggregate messages with too many buckets": {
+			url:    fmt.Sprintf("%s/channels/%s/messages/aggregate?function=min&interval=1s&from=0&to=100000", ts.URL, chanID),
+			token:  token,
+			status: http.StatusBadRequest,
+		},
+		"aggregate messages without time range": {
+			url:    fmt.Sprintf("%s/channels/%s/messages/aggregate?function=min&interval=1m", ts.URL, chanID),
+			token:  token,
+			status: http.StatusBadRequest,
+		},
+		"aggregate messages with inverted time range": {
+			url:    fmt.Sprintf("%s/channels/%s/messages/aggregate?function=min&interval=1m&from=3600&to=0", ts.URL, chanID),
+			token:  token,
+			status: http.StatusBadRequest,
+		},
+		"aggregate messages with invalid token": {
+			url:    fmt.Sprintf("%s/channels/%s/messages/aggregate?function=min&interval=1m&from=0&to=3600", ts.URL, chanID),
+			token:  invalid,
+			status: http.StatusForbidden,
+		},
+	}
+
+	for desc, tc := range cases {
+		req := testRequest{
+			client: ts.Client(),
+			method: http.MethodGet,
+			url:    tc.url,
+			token:  tc.token,
+		}
+		res, err := req.make()
+		assert.Nil(t, err, fmt.Sprintf("%s: unexpected error %s", desc, err))
+		assert.Equal(t, tc.status, res.StatusCode, fmt.Sprintf("%s: expected %d got %d", desc, tc.status, res.StatusCode))
+	}
+}
@@ -28,7 +28,7 @@ func LoggingMiddleware(svc readers.MessageRepository, logger logger.Logger) read
 	}
 }
 
-func (lm *loggingMiddleware) ReadAll(chanID string, offset, limit uint64, query map[string]string) (page readers.MessagesPage, err error) {
+func (lm *loggingMiddleware) ReadAll(chanID string, offset, limit uint64, cursor string, query map[string]string) (page readers.MessagesPage, err error) {
 	defer func(begin time.Time) {
 		message := fmt.Sprintf("Method read_all for channel %s with offset %d and limit %d took %s to complete", chanID, offset, limit, time.Since(begin))
 		if err != nil {
@@ -38,5 +38,18 @@ func (lm *loggingMiddleware) ReadAll(chanID string, offset, limit uint64, query
 		lm.logger.Info(fmt.Sprintf("%s without errors.", message))
 	}(time.Now())
 
-	return lm.svc.ReadAll(chanID, offset, limit, query)
+	return lm.svc.ReadAll(chanID, offset, limit, cursor, query)
+}
+
+func (lm *loggingMiddleware) Aggregate(chanID string, aq readers.AggregationQuery) (aggs []readers.Aggregate, err error) {
+	defer func(begin time.Time) {
+		message := fmt.Sprintf("Method aggregate for channel %s with function %s and interval %s took %s to complete", chanID, aq.Function, aq.Interval, time.Since(begin))
+		if err != nil {
+			lm.logger.Warn(fmt.Sprintf("%s with error: %s.", message, err))
+			return
+		}
+		lm.logger.Info(fmt.Sprintf("%s without errors.", message))
+	}(time.Now())
+
+	return lm.svc.Aggregate(chanID, aq)
 }
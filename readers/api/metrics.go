@@ -30,11 +30,20 @@ func MetricsMiddleware(svc readers.MessageRepository, counter metrics.Counter, l
 	}
 }
 
-func (mm *metricsMiddleware) ReadAll(chanID string, offset, limit uint64, query map[string]string) (readers.MessagesPage, error) {
+func (mm *metricsMiddleware) ReadAll(chanID string, offset, limit uint64, cursor string, query map[string]string) (readers.MessagesPage, error) {
 	defer func(begin time.Time) {
 		mm.counter.With("method", "read_all").Add(1)
 		mm.latency.With("method", "read_all").Observe(time.Since(begin).Seconds())
 	}(time.Now())
 
-	return mm.svc.ReadAll(chanID, offset, limit, query)
+	return mm.svc.ReadAll(chanID, offset, limit, cursor, query)
+}
+
+func (mm *metricsMiddleware) Aggregate(chanID string, aq readers.AggregationQuery) ([]readers.Aggregate, error) {
+	defer func(begin time.Time) {
+		mm.counter.With("method", "aggregate").Add(1)
+		mm.latency.With("method", "aggregate").Observe(time.Since(begin).Seconds())
+	}(time.Now())
+
+	return mm.svc.Aggregate(chanID, aq)
 }
@@ -3,6 +3,19 @@
 
 package api
 
+import (
+	"strconv"
+	"time"
+
+	"github.com/mainflux/mainflux/pkg/errors"
+	"github.com/mainflux/mainflux/readers"
+)
+
+const (
+	minInterval = time.Second
+	maxBuckets  = 10000
+)
+
 type apiReq interface {
 	validate() error
 }
@@ -11,6 +24,7 @@ type listMessagesReq struct {
 	chanID string
 	offset uint64
 	limit  uint64
+	cursor string
 	query  map[string]string
 }
 
@@ -19,5 +33,92 @@ func (req listMessagesReq) validate() error {
 		return errInvalidRequest
 	}
 
+	if req.cursor != "" {
+		if _, err := readers.DecodeCursor(req.cursor); err != nil {
+			return errors.Wrap(errInvalidRequest, err)
+		}
+	}
+
+	return validateQuery(req.query)
+}
+
+type aggregateReq struct {
+	chanID   string
+	function string
+	interval time.Duration
+	groupBy  []string
+	query    map[string]string
+}
+
+func (req aggregateReq) validate() error {
+	switch req.function {
+	case readers.AggregationMin,
+		readers.AggregationMax,
+		readers.AggregationAvg,
+		readers.AggregationSum,
+		readers.AggregationCount,
+		readers.AggregationFirst,
+		readers.AggregationLast:
+	default:
+		return errInvalidRequest
+	}
+
+	if req.interval < minInterval {
+		return errInvalidRequest
+	}
+
+	for _, field := range req.groupBy {
+		if field != readers.GroupByName && field != readers.GroupByPublisher {
+			return errInvalidRequest
+		}
+	}
+
+	if err := validateQuery(req.query); err != nil {
+		return err
+	}
+
+	// Aggregation has to be bounded in time to limit the number of buckets.
+	from, ok := req.query["from"]
+	if !ok {
+		return errInvalidRequest
+	}
+	to, ok := req.query["to"]
+	if !ok {
+		return errInvalidRequest
+	}
+	f, _ := strconv.ParseFloat(from, 64)
+	t, _ := strconv.ParseFloat(to, 64)
+	if f >= t || (t-f)/req.interval.Seconds() > maxBuckets {
+		return errInvalidRequest
+	}
+
+	return nil
+}
+
+func validateQuery(query map[string]string) error {
+	for _, name := range []string{"v", "from", "to"} {
+		if val, ok := query[name]; ok {
+			if _, err := strconv.ParseFloat(val, 64); err != nil {
+				return errors.Wrap(errInvalidRequest, err)
+			}
+		}
+	}
+
+	if c, ok := query["comparator"]; ok {
+		if _, ok := query["v"]; !ok {
+			return errInvalidRequest
+		}
+
+		switch c {
+		case readers.EqualKey,
+			readers.LowerThanKey,
+			readers.LowerThanEqualKey,
+			readers.GreaterThanKey,
+			readers.GreaterThanEqualKey:
+		default:
+			return errInvalidRequest
+		}
+	}
+
 	return nil
 }
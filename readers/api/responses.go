@@ -13,10 +13,11 @@ import (
 var _ mainflux.Response = (*pageRes)(nil)
 
 type pageRes struct {
-	Total    uint64          `json:"total"`
-	Offset   uint64          `json:"offset"`
-	Limit    uint64          `json:"limit"`
-	Messages []senml.Message `json:"messages"`
+	Total      uint64          `json:"total"`
+	Offset     uint64          `json:"offset"`
+	Limit      uint64          `json:"limit"`
+	NextCursor string          `json:"next_cursor,omitempty"`
+	Messages   []senml.Message `json:"messages"`
 }
 
 func (res pageRes) Headers() map[string]string {
@@ -31,6 +32,33 @@ func (res pageRes) Empty() bool {
 	return false
 }
 
+var _ mainflux.Response = (*aggregatesRes)(nil)
+
+type aggregateRes struct {
+	Time      float64 `json:"time"`
+	Name      string  `json:"name,omitempty"`
+	Publisher string  `json:"publisher,omitempty"`
+	Value     float64 `json:"value"`
+}
+
+type aggregatesRes struct {
+	Function   string         `json:"function"`
+	Interval   string         `json:"interval"`
+	Aggregates []aggregateRes `json:"aggregates"`
+}
+
+func (res aggregatesRes) Headers() map[string]string {
+	return map[string]string{}
+}
+
+func (res aggregatesRes) Code() int {
+	return http.StatusOK
+}
+
+func (res aggregatesRes) Empty() bool {
+	return false
+}
+
 type errorRes struct {
 	Err string `json:"error"`
 }
@@ -8,13 +8,16 @@ import (
 	"encoding/json"
 	"net/http"
 	"strconv"
+	"strings"
 	"time"
 
 	kithttp "github.com/go-kit/kit/transport/http"
 	"github.com/go-zoo/bone"
 	"github.com/mainflux/mainflux"
+	mfcursor "github.com/mainflux/mainflux/pkg/cursor"
 	"github.com/mainflux/mainflux/pkg/errors"
 	"github.com/mainflux/mainflux/readers"
+	"github.com/mainflux/mainflux/things"
 	"github.com/prometheus/client_golang/prometheus/promhttp"
 	"google.golang.org/grpc/codes"
 	"google.golang.org/grpc/status"
@@ -30,12 +33,16 @@ var (
 	errInvalidRequest     = errors.New("received invalid request")
 	errUnauthorizedAccess = errors.New("missing or invalid credentials provided")
 	auth                  mainflux.ThingsServiceClient
-	queryFields           = []string{"subtopic", "publisher", "protocol", "name", "value", "v", "vs", "vb", "vd"}
+	authn                 mainflux.AuthNServiceClient
+	queryFields           = []string{"subtopic", "publisher", "protocol", "name", "value", "v", "vs", "vb", "vd", "comparator", "from", "to"}
 )
 
-// MakeHandler returns a HTTP handler for API endpoints.
-func MakeHandler(svc readers.MessageRepository, tc mainflux.ThingsServiceClient, svcName string) http.Handler {
+// MakeHandler returns a HTTP handler for API endpoints. Messages can be read
+// using thing keys. If AuthN client is provided, user and API keys allowed to
+// read messages of the owned channel are accepted as well.
+func MakeHandler(svc readers.MessageRepository, tc mainflux.ThingsServiceClient, ac mainflux.AuthNServiceClient, svcName string) http.Handler {
 	auth = tc
+	authn = ac
 
 	opts := []kithttp.ServerOption{
 		kithttp.ServerErrorEncoder(encodeError),
@@ -49,6 +56,13 @@ func MakeHandler(svc readers.MessageRepository, tc mainflux.ThingsServiceClient,
 		opts...,
 	))
 
+	mux.Get("/channels/:chanID/messages/aggregate", kithttp.NewServer(
+		aggregateEndpoint(svc),
+		decodeAggregate,
+		encodeResponse,
+		opts...,
+	))
+
 	mux.GetFunc("/version", mainflux.Version(svcName))
 	mux.Handle("/metrics", promhttp.Handler())
 
@@ -75,18 +89,57 @@ func decodeList(_ context.Context, r *http.Request) (interface{}, error) {
 		return nil, err
 	}
 
-	query := map[string]string{}
-	for _, name := range queryFields {
-		if value := bone.GetQuery(r, name); len(value) == 1 {
-			query[name] = value[0]
-		}
+	cursor, err := readString(r, "cursor")
+	if err != nil {
+		return nil, err
 	}
 
 	req := listMessagesReq{
 		chanID: chanID,
 		offset: offset,
 		limit:  limit,
-		query:  query,
+		cursor: cursor,
+		query:  readQueryFields(r),
+	}
+
+	return req, nil
+}
+
+func decodeAggregate(_ context.Context, r *http.Request) (interface{}, error) {
+	chanID := bone.GetValue(r, "chanID")
+	if chanID == "" {
+		return nil, errInvalidRequest
+	}
+
+	if err := authorize(r, chanID); err != nil {
+		return nil, err
+	}
+
+	function, err := readString(r, "function")
+	if err != nil {
+		return nil, err
+	}
+
+	i, err := readString(r, "interval")
+	if err != nil {
+		return nil, err
+	}
+	interval, err := time.ParseDuration(i)
+	if err != nil {
+		return nil, errors.Wrap(errInvalidRequest, err)
+	}
+
+	groupBy := []string{}
+	for _, val := range bone.GetQuery(r, "group_by") {
+		groupBy = append(groupBy, strings.Split(val, ",")...)
+	}
+
+	req := aggregateReq{
+		chanID:   chanID,
+		function: function,
+		interval: interval,
+		groupBy:  groupBy,
+		query:    readQueryFields(r),
 	}
 
 	return req, nil
@@ -113,7 +166,11 @@ func encodeResponse(_ context.Context, w http.ResponseWriter, response interface
 func encodeError(_ context.Context, err error, w http.ResponseWriter) {
 	switch {
 	case errors.Contains(err, nil):
-	case errors.Contains(err, errInvalidRequest):
+	case errors.Contains(err, errInvalidRequest),
+		errors.Contains(err, mfcursor.ErrMalformedCursor),
+		errors.Contains(err, readers.ErrUnsupportedAggregation),
+		errors.Contains(err, readers.ErrUnboundedAggregation),
+		errors.Contains(err, readers.ErrAggregationLimit):
 		w.WriteHeader(http.StatusBadRequest)
 	case errors.Contains(err, errUnauthorizedAccess):
 		w.WriteHeader(http.StatusForbidden)
@@ -138,13 +195,43 @@ func authorize(r *http.Request, chanID string) error {
 	ctx, cancel := context.WithTimeout(context.Background(), time.Second)
 	defer cancel()
 
-	_, err := auth.CanAccessByKey(ctx, &mainflux.AccessByKeyReq{Token: token, ChanID: chanID})
+	ar := &mainflux.AccessByKeyReq{
+		Token:  token,
+		ChanID: chanID,
+		Action: things.ActionSubscribe,
+	}
+	_, err := auth.CanAccessByKey(ctx, ar)
 	if err != nil {
 		e, ok := status.FromError(err)
-		if ok && e.Code() == codes.PermissionDenied {
+		if !ok || (e.Code() != codes.PermissionDenied && e.Code() != codes.NotFound) {
+			return err
+		}
+		if authn == nil {
 			return errUnauthorizedAccess
 		}
-		return err
+		return authorizeUser(ctx, token, chanID)
+	}
+
+	return nil
+}
+
+// authorizeUser checks whether the user key is allowed to read messages of
+// the channel owned by the user.
+func authorizeUser(ctx context.Context, token, chanID string) error {
+	res, err := authn.Identify(ctx, &mainflux.Token{Value: token})
+	if err != nil {
+		return errUnauthorizedAccess
+	}
+	if !res.Allows(mainflux.MessagesResource, mainflux.ReadAction, chanID) {
+		return errUnauthorizedAccess
+	}
+
+	req := &mainflux.ChannelOwnerReq{
+		Owner:  res.GetEmail(),
+		ChanID: chanID,
+	}
+	if _, err := auth.IsChannelOwner(ctx, req); err != nil {
+		return errUnauthorizedAccess
 	}
 
 	return nil
@@ -167,3 +254,27 @@ func getQuery(req *http.Request, name string, fallback uint64) (uint64, error) {
 
 	return uint64(val), nil
 }
+
+func readString(req *http.Request, name string) (string, error) {
+	vals := bone.GetQuery(req, name)
+	if len(vals) > 1 {
+		return "", errInvalidRequest
+	}
+
+	if len(vals) == 0 {
+		return "", nil
+	}
+
+	return vals[0], nil
+}
+
+func readQueryFields(req *http.Request) map[string]string {
+	query := map[string]string{}
+	for _, name := range queryFields {
+		if value := bone.GetQuery(req, name); len(value) == 1 {
+			query[name] = value[0]
+		}
+	}
+
+	return query
+}
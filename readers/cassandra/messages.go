@@ -5,13 +5,25 @@ package cassandra
 
 import (
 	"fmt"
+	"strconv"
 
 	"github.com/gocql/gocql"
+	mfcursor "github.com/mainflux/mainflux/pkg/cursor"
 	"github.com/mainflux/mainflux/pkg/errors"
 	"github.com/mainflux/mainflux/pkg/transformers/senml"
 	"github.com/mainflux/mainflux/readers"
 )
 
+const (
+	// cursorTime is the name of the condition used to select messages not
+	// newer than the cursor.
+	cursorTime = "cursor_time"
+
+	// maxAggregated is the maximum number of messages a single aggregation
+	// query is allowed to scan.
+	maxAggregated = 1000000
+)
+
 var errReadMessages = errors.New("failed to read messages from cassandra database")
 
 var _ readers.MessageRepository = (*cassandraRepository)(nil)
@@ -27,24 +39,42 @@ func New(session *gocql.Session) readers.MessageRepository {
 	}
 }
 
-func (cr cassandraRepository) ReadAll(chanID string, offset, limit uint64, query map[string]string) (readers.MessagesPage, error) {
-	names := []string{}
-	vals := []interface{}{chanID}
-	for name, val := range query {
-		names = append(names, name)
-		vals = append(vals, val)
+func (cr cassandraRepository) ReadAll(chanID string, offset, limit uint64, cursor string, query map[string]string) (readers.MessagesPage, error) {
+	names, vals, err := queryConditions(chanID, query)
+	if err != nil {
+		return readers.MessagesPage{}, errors.Wrap(errReadMessages, err)
 	}
-	vals = append(vals, offset+limit)
+	comparator := readers.ParseValueComparator(query)
+	countVals := vals
+	countCQL := buildCountQuery(chanID, names, comparator)
+
+	c := readers.Cursor{}
+	skip := offset
+	if cursor != "" {
+		if c, err = readers.DecodeCursor(cursor); err != nil {
+			return readers.MessagesPage{}, err
+		}
 
-	selectCQL := buildSelectQuery(chanID, offset, limit, names)
-	countCQL := buildCountQuery(chanID, names)
+		t, err := strconv.ParseFloat(c.Time, 64)
+		if err != nil {
+			return readers.MessagesPage{}, errors.Wrap(mfcursor.ErrMalformedCursor, err)
+		}
+
+		names = append(names, cursorTime)
+		vals = append(vals, t)
+		skip = c.Skip
+		offset = 0
+	}
+	vals = append(vals, skip+limit)
+
+	selectCQL := buildSelectQuery(chanID, skip, limit, names, comparator)
 
 	iter := cr.session.Query(selectCQL, vals...).Iter()
 	defer iter.Close()
 	scanner := iter.Scanner()
 
 	// skip first OFFSET rows
-	for i := uint64(0); i < offset; i++ {
+	for i := uint64(0); i < skip; i++ {
 		if !scanner.Next() {
 			break
 		}
@@ -67,21 +97,70 @@ func (cr cassandraRepository) ReadAll(chanID string, offset, limit uint64, query
 		page.Messages = append(page.Messages, msg)
 	}
 
-	if err := cr.session.Query(countCQL, vals[:len(vals)-1]...).Scan(&page.Total); err != nil {
+	if n := len(page.Messages); n > 0 {
+		last := strconv.FormatFloat(page.Messages[n-1].Time, 'f', -1, 64)
+		page.NextCursor = readers.NextCursor(c, page.Messages, limit, last)
+	}
+
+	if err := cr.session.Query(countCQL, countVals...).Scan(&page.Total); err != nil {
 		return readers.MessagesPage{}, errors.Wrap(errReadMessages, err)
 	}
 
 	return page, nil
 }
 
-func buildSelectQuery(chanID string, offset, limit uint64, names []string) string {
-	var condCQL string
-	cql := `SELECT channel, subtopic, publisher, protocol, name, unit,
-	        value, string_value, bool_value, data_value, sum, time,
-			update_time FROM messages WHERE channel = ? %s LIMIT ?
-			ALLOW FILTERING`
+// Aggregate computes aggregates in memory, since Cassandra lacks support
+// for grouping by arbitrary time buckets. Since the messages are scanned
+// using filtering, the query has to be bounded by both from and to time, and
+// it may not match more than maxAggregated messages.
+func (cr cassandraRepository) Aggregate(chanID string, aq readers.AggregationQuery) ([]readers.Aggregate, error) {
+	if aq.Query["from"] == "" || aq.Query["to"] == "" {
+		return nil, readers.ErrUnboundedAggregation
+	}
 
-	for _, name := range names {
+	agg, err := readers.NewAggregator(aq)
+	if err != nil {
+		return nil, err
+	}
+
+	names, vals, err := queryConditions(chanID, aq.Query)
+	if err != nil {
+		return nil, errors.Wrap(errReadMessages, err)
+	}
+	vals = append(vals, maxAggregated+1)
+
+	cql := fmt.Sprintf(`SELECT name, publisher, value, time FROM messages
+			WHERE channel = ? %s LIMIT ? ALLOW FILTERING`, buildCondition(names, readers.ParseValueComparator(aq.Query)))
+
+	iter := cr.session.Query(cql, vals...).Iter()
+	defer iter.Close()
+	scanner := iter.Scanner()
+
+	n := 0
+	for scanner.Next() {
+		if n++; n > maxAggregated {
+			return nil, readers.ErrAggregationLimit
+		}
+
+		var msg senml.Message
+		if err := scanner.Scan(&msg.Name, &msg.Publisher, &msg.Value, &msg.Time); err != nil {
+			return nil, errors.Wrap(errReadMessages, err)
+		}
+		agg.Add(msg)
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, errors.Wrap(errReadMessages, err)
+	}
+
+	return agg.Aggregates(), nil
+}
+
+// queryConditions returns names of query conditions and the values bound to
+// them, starting with the channel.
+func queryConditions(chanID string, query map[string]string) ([]string, []interface{}, error) {
+	names := []string{}
+	vals := []interface{}{chanID}
+	for name, val := range query {
 		switch name {
 		case
 			"channel",
@@ -89,17 +168,38 @@ func buildSelectQuery(chanID string, offset, limit uint64, names []string) strin
 			"publisher",
 			"name",
 			"protocol":
-			condCQL = fmt.Sprintf(`%s AND %s = ?`, condCQL, name)
+			names = append(names, name)
+			vals = append(vals, val)
+		case "v", "from", "to":
+			fVal, err := strconv.ParseFloat(val, 64)
+			if err != nil {
+				return nil, nil, err
+			}
+			names = append(names, name)
+			vals = append(vals, fVal)
 		}
 	}
 
-	return fmt.Sprintf(cql, condCQL)
+	return names, vals, nil
 }
 
-func buildCountQuery(chanID string, names []string) string {
-	var condCQL string
+func buildSelectQuery(chanID string, offset, limit uint64, names []string, comparator string) string {
+	cql := `SELECT channel, subtopic, publisher, protocol, name, unit,
+	        value, string_value, bool_value, data_value, sum, time,
+			update_time FROM messages WHERE channel = ? %s LIMIT ?
+			ALLOW FILTERING`
+
+	return fmt.Sprintf(cql, buildCondition(names, comparator))
+}
+
+func buildCountQuery(chanID string, names []string, comparator string) string {
 	cql := `SELECT COUNT(*) FROM messages WHERE channel = ? %s ALLOW FILTERING`
 
+	return fmt.Sprintf(cql, buildCondition(names, comparator))
+}
+
+func buildCondition(names []string, comparator string) string {
+	var condCQL string
 	for _, name := range names {
 		switch name {
 		case
@@ -109,8 +209,16 @@ func buildCountQuery(chanID string, names []string) string {
 			"name",
 			"protocol":
 			condCQL = fmt.Sprintf(`%s AND %s = ?`, condCQL, name)
+		case "v":
+			condCQL = fmt.Sprintf(`%s AND value %s ?`, condCQL, comparator)
+		case "from":
+			condCQL = fmt.Sprintf(`%s AND time >= ?`, condCQL)
+		case "to":
+			condCQL = fmt.Sprintf(`%s AND time < ?`, condCQL)
+		case cursorTime:
+			condCQL = fmt.Sprintf(`%s AND time <= ?`, condCQL)
 		}
 	}
 
-	return fmt.Sprintf(cql, condCQL)
+	return condCQL
 }
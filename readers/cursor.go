@@ -0,0 +1,59 @@
+// Copyright (c) Mainflux
+// SPDX-License-Identifier: Apache-2.0
+
+package readers
+
+import (
+	"strconv"
+
+	"github.com/mainflux/mainflux/pkg/cursor"
+	"github.com/mainflux/mainflux/pkg/errors"
+	"github.com/mainflux/mainflux/pkg/transformers/senml"
+)
+
+// Cursor points past the last message of a page. Since messages are read in
+// descending time order, the following page starts with messages that are not
+// newer than Time, skipping the first Skip of them which were already read.
+// Time is stored in the format native to the message repository.
+type Cursor struct {
+	Time string
+	Skip uint64
+}
+
+// DecodeCursor decodes the opaque cursor token.
+func DecodeCursor(c string) (Cursor, error) {
+	keys, err := cursor.Decode(c, 2)
+	if err != nil {
+		return Cursor{}, err
+	}
+
+	skip, err := strconv.ParseUint(keys[1], 10, 64)
+	if err != nil {
+		return Cursor{}, errors.Wrap(cursor.ErrMalformedCursor, err)
+	}
+
+	return Cursor{Time: keys[0], Skip: skip}, nil
+}
+
+// NextCursor returns the opaque token of the cursor pointing past the page
+// read using the previous cursor. The last argument is the time of the last
+// message in the repository native format. If the page is not full, there
+// are no more messages to read and an empty token is returned.
+func NextCursor(prev Cursor, msgs []senml.Message, limit uint64, last string) string {
+	n := len(msgs)
+	if n == 0 || uint64(n) < limit {
+		return ""
+	}
+
+	// Count messages sharing the time of the last one, as they have to be
+	// skipped when reading the following page.
+	skip := uint64(0)
+	for i := n - 1; i >= 0 && msgs[i].Time == msgs[n-1].Time; i-- {
+		skip++
+	}
+	if skip == uint64(n) && prev.Time == last {
+		skip += prev.Skip
+	}
+
+	return cursor.Encode(last, strconv.FormatUint(skip, 10))
+}
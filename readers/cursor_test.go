@@ -0,0 +1,100 @@
+// Copyright (c) Mainflux
+// SPDX-License-Identifier: Apache-2.0
+
+package readers_test
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/mainflux/mainflux/pkg/cursor"
+	"github.com/mainflux/mainflux/pkg/errors"
+	"github.com/mainflux/mainflux/pkg/transformers/senml"
+	"github.com/mainflux/mainflux/readers"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestNextCursor(t *testing.T) {
+	msgs := []senml.Message{{Time: 3}, {Time: 2}, {Time: 2}}
+	ties := []senml.Message{{Time: 2}, {Time: 2}, {Time: 2}}
+
+	cases := []struct {
+		desc  string
+		prev  readers.Cursor
+		msgs  []senml.Message
+		limit uint64
+		last  string
+		next  readers.Cursor
+		empty bool
+	}{
+		{
+			desc:  "next cursor of full page",
+			msgs:  msgs,
+			limit: 3,
+			last:  "2",
+			next:  readers.Cursor{Time: "2", Skip: 2},
+		},
+		{
+			desc:  "next cursor of page with the same time as the previous cursor",
+			prev:  readers.Cursor{Time: "2", Skip: 4},
+			msgs:  ties,
+			limit: 3,
+			last:  "2",
+			next:  readers.Cursor{Time: "2", Skip: 7},
+		},
+		{
+			desc:  "next cursor of partial page",
+			msgs:  msgs,
+			limit: 10,
+			last:  "2",
+			empty: true,
+		},
+		{
+			desc:  "next cursor of empty page",
+			msgs:  []senml.Message{},
+			limit: 10,
+			empty: true,
+		},
+	}
+
+	for _, tc := range cases {
+		next := readers.NextCursor(tc.prev, tc.msgs, tc.limit, tc.last)
+		if tc.empty {
+			assert.Empty(t, next, fmt.Sprintf("%s: expected empty cursor got %s\n", tc.desc, next))
+			continue
+		}
+
+		c, err := readers.DecodeCursor(next)
+		assert.Nil(t, err, fmt.Sprintf("%s: unexpected error %s\n", tc.desc, err))
+		assert.Equal(t, tc.next, c, fmt.Sprintf("%s: expected %v got %v\n", tc.desc, tc.next, c))
+	}
+}
+
+func TestDecodeCursor(t *testing.T) {
+	cases := []struct {
+		desc   string
+		cursor string
+		err    error
+	}{
+		{
+			desc:   "decode valid cursor",
+			cursor: cursor.Encode("1596211200.5", "1"),
+			err:    nil,
+		},
+		{
+			desc:   "decode cursor with invalid skip",
+			cursor: cursor.Encode("1596211200.5", "invalid"),
+			err:    cursor.ErrMalformedCursor,
+		},
+		{
+			desc:   "decode cursor with missing skip",
+			cursor: cursor.Encode("1596211200.5"),
+			err:    cursor.ErrMalformedCursor,
+		},
+	}
+
+	for _, tc := range cases {
+		_, err := readers.DecodeCursor(tc.cursor)
+		assert.True(t, errors.Contains(err, tc.err), fmt.Sprintf("%s: expected %s got %s\n", tc.desc, tc.err, err))
+	}
+}
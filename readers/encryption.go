@@ -0,0 +1,68 @@
+// Copyright (c) Mainflux
+// SPDX-License-Identifier: Apache-2.0
+
+package readers
+
+import (
+	"github.com/mainflux/mainflux/pkg/encryption"
+	"github.com/mainflux/mainflux/pkg/transformers/senml"
+)
+
+var _ MessageRepository = (*decryptionMiddleware)(nil)
+
+type decryptionMiddleware struct {
+	cipher encryption.Cipher
+	repo   MessageRepository
+}
+
+// DecryptionMiddleware decrypts string and data values of the messages
+// encrypted by the writers. Values stored before the encryption was enabled
+// and the values that fail to decrypt, such as plaintext values starting
+// with the encryption prefix, are returned unchanged. Since the encrypted values differ from the
+// plaintext, messages can't be filtered by them.
+func DecryptionMiddleware(repo MessageRepository, cipher encryption.Cipher) MessageRepository {
+	return decryptionMiddleware{
+		cipher: cipher,
+		repo:   repo,
+	}
+}
+
+func (dm decryptionMiddleware) ReadAll(chanID string, offset, limit uint64, cursor string, query map[string]string) (MessagesPage, error) {
+	page, err := dm.repo.ReadAll(chanID, offset, limit, cursor, query)
+	if err != nil {
+		return MessagesPage{}, err
+	}
+
+	// Decrypted messages are copied, so the messages returned by the
+	// repository are left intact.
+	msgs := make([]senml.Message, len(page.Messages))
+	for i, msg := range page.Messages {
+		if msg.StringValue != nil {
+			v := dm.decrypt(chanID, *msg.StringValue)
+			msg.StringValue = &v
+		}
+		if msg.DataValue != nil {
+			v := dm.decrypt(chanID, *msg.DataValue)
+			msg.DataValue = &v
+		}
+		msgs[i] = msg
+	}
+	page.Messages = msgs
+
+	return page, nil
+}
+
+// decrypt returns the value unchanged if it can't be decrypted, so a single
+// value which only looks like the encrypted one doesn't make the whole page
+// unreadable.
+func (dm decryptionMiddleware) decrypt(chanID, value string) string {
+	v, err := dm.cipher.Decrypt(chanID, value)
+	if err != nil {
+		return value
+	}
+	return v
+}
+
+func (dm decryptionMiddleware) Aggregate(chanID string, aq AggregationQuery) ([]Aggregate, error) {
+	return dm.repo.Aggregate(chanID, aq)
+}
@@ -0,0 +1,117 @@
+// Copyright (c) Mainflux
+// SPDX-License-Identifier: Apache-2.0
+
+package readers_test
+
+import (
+	"encoding/base64"
+	"fmt"
+	"testing"
+
+	"github.com/mainflux/mainflux/pkg/encryption"
+	"github.com/mainflux/mainflux/pkg/errors"
+	"github.com/mainflux/mainflux/pkg/transformers/senml"
+	"github.com/mainflux/mainflux/readers"
+	"github.com/mainflux/mainflux/readers/mocks"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const chanID = "1"
+
+var _ encryption.KeyWrapper = (*keyWrapper)(nil)
+
+// keyWrapper stores the data keys as plaintext, which is enough to verify
+// the decryption of the message values.
+type keyWrapper struct{}
+
+func (kw keyWrapper) Wrap(key []byte) (string, error) {
+	return base64.StdEncoding.EncodeToString(key), nil
+}
+
+func (kw keyWrapper) Unwrap(wrapped string) ([]byte, error) {
+	return base64.StdEncoding.DecodeString(wrapped)
+}
+
+func TestDecryptionMiddleware(t *testing.T) {
+	cipher := encryption.New(keyWrapper{}, 0)
+
+	sv := "on"
+	dv := "base64 payload"
+	esv, err := cipher.Encrypt(chanID, sv)
+	require.Nil(t, err, fmt.Sprintf("unexpected error: %s", err))
+	edv, err := cipher.Encrypt(chanID, dv)
+	require.Nil(t, err, fmt.Sprintf("unexpected error: %s", err))
+	other, err := cipher.Encrypt("other", sv)
+	require.Nil(t, err, fmt.Sprintf("unexpected error: %s", err))
+	plain := "stored before encryption"
+	prefixed := "enc:v1:written by device"
+
+	repo := mocks.NewMessageRepository(map[string][]senml.Message{
+		chanID: {
+			{Channel: chanID, StringValue: &esv},
+			{Channel: chanID, DataValue: &edv},
+			{Channel: chanID, StringValue: &plain},
+			{Channel: chanID, StringValue: &other},
+			{Channel: chanID, StringValue: &prefixed},
+		},
+	})
+	svc := readers.DecryptionMiddleware(repo, cipher)
+
+	cases := []struct {
+		desc   string
+		offset uint64
+		limit  uint64
+		values []string
+		err    error
+	}{
+		{
+			desc:   "read encrypted and plaintext values",
+			offset: 0,
+			limit:  3,
+			values: []string{sv, dv, plain},
+			err:    nil,
+		},
+		{
+			desc:   "read value encrypted for other channel",
+			offset: 3,
+			limit:  1,
+			values: []string{other},
+			err:    nil,
+		},
+		{
+			desc:   "read plaintext value with encryption prefix",
+			offset: 4,
+			limit:  1,
+			values: []string{prefixed},
+			err:    nil,
+		},
+		{
+			desc:   "read all values",
+			offset: 0,
+			limit:  5,
+			values: []string{sv, dv, plain, other, prefixed},
+			err:    nil,
+		},
+	}
+
+	for _, tc := range cases {
+		page, err := svc.ReadAll(chanID, tc.offset, tc.limit, "", nil)
+		assert.True(t, errors.Contains(err, tc.err), fmt.Sprintf("%s: expected %s got %s\n", tc.desc, tc.err, err))
+
+		var values []string
+		for _, msg := range page.Messages {
+			switch {
+			case msg.StringValue != nil:
+				values = append(values, *msg.StringValue)
+			case msg.DataValue != nil:
+				values = append(values, *msg.DataValue)
+			}
+		}
+		assert.Equal(t, tc.values, values, fmt.Sprintf("%s: expected %v got %v\n", tc.desc, tc.values, values))
+	}
+
+	page, err := repo.ReadAll(chanID, 0, 1, "", nil)
+	require.Nil(t, err, fmt.Sprintf("unexpected error: %s", err))
+	assert.Equal(t, esv, *page.Messages[0].StringValue, "expected repository messages to be left intact")
+}
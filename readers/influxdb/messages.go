@@ -8,6 +8,7 @@ import (
 	"strings"
 	"time"
 
+	mfcursor "github.com/mainflux/mainflux/pkg/cursor"
 	"github.com/mainflux/mainflux/pkg/errors"
 	"github.com/mainflux/mainflux/readers"
 
@@ -34,9 +35,30 @@ func New(client influxdata.Client, database string) readers.MessageRepository {
 	}
 }
 
-func (repo *influxRepository) ReadAll(chanID string, offset, limit uint64, query map[string]string) (readers.MessagesPage, error) {
-	condition := fmtCondition(chanID, query)
-	cmd := fmt.Sprintf(`SELECT * FROM messages WHERE %s ORDER BY time DESC LIMIT %d OFFSET %d`, condition, limit, offset)
+func (repo *influxRepository) ReadAll(chanID string, offset, limit uint64, cursor string, query map[string]string) (readers.MessagesPage, error) {
+	condition, err := fmtCondition(chanID, query)
+	if err != nil {
+		return readers.MessagesPage{}, errors.Wrap(errReadMessages, err)
+	}
+	cond := condition
+	skip := offset
+
+	c := readers.Cursor{}
+	if cursor != "" {
+		if c, err = readers.DecodeCursor(cursor); err != nil {
+			return readers.MessagesPage{}, err
+		}
+
+		if _, err := time.Parse(time.RFC3339Nano, c.Time); err != nil {
+			return readers.MessagesPage{}, errors.Wrap(mfcursor.ErrMalformedCursor, err)
+		}
+
+		cond = fmt.Sprintf(`%s AND time <= '%s'`, condition, c.Time)
+		skip = c.Skip
+		offset = 0
+	}
+
+	cmd := fmt.Sprintf(`SELECT * FROM messages WHERE %s ORDER BY time DESC LIMIT %d OFFSET %d`, cond, limit, skip)
 	q := influxdata.Query{
 		Command:  cmd,
 		Database: repo.database,
@@ -66,12 +88,109 @@ func (repo *influxRepository) ReadAll(chanID string, offset, limit uint64, query
 		return readers.MessagesPage{}, errors.Wrap(errReadMessages, err)
 	}
 
-	return readers.MessagesPage{
+	page := readers.MessagesPage{
 		Total:    total,
 		Offset:   offset,
 		Limit:    limit,
 		Messages: ret,
-	}, nil
+	}
+
+	if n := len(result.Values); n > 0 {
+		// Cursor keeps the time as returned by InfluxDB to avoid precision
+		// loss of converting it to float seconds and back.
+		if last, ok := timeValue(result.Columns, result.Values[n-1]); ok {
+			page.NextCursor = readers.NextCursor(c, ret, limit, last)
+		}
+	}
+
+	return page, nil
+}
+
+func (repo *influxRepository) Aggregate(chanID string, aq readers.AggregationQuery) ([]readers.Aggregate, error) {
+	fn, ok := aggregations[aq.Function]
+	if !ok {
+		return nil, readers.ErrUnsupportedAggregation
+	}
+
+	condition, err := fmtCondition(chanID, aq.Query)
+	if err != nil {
+		return nil, errors.Wrap(errReadMessages, err)
+	}
+
+	groups := fmt.Sprintf(`time(%dms)`, aq.Interval.Milliseconds())
+	for _, field := range []string{readers.GroupByName, readers.GroupByPublisher} {
+		if aq.GroupedBy(field) {
+			groups = fmt.Sprintf(`%s, "%s"`, groups, field)
+		}
+	}
+
+	cmd := fmt.Sprintf(`SELECT %s("value") AS "value" FROM messages WHERE %s GROUP BY %s fill(none)`, fn, condition, groups)
+	q := influxdata.Query{
+		Command:  cmd,
+		Database: repo.database,
+	}
+
+	resp, err := repo.client.Query(q)
+	if err != nil {
+		return nil, errors.Wrap(errReadMessages, err)
+	}
+	if resp.Error() != nil {
+		return nil, errors.Wrap(errReadMessages, resp.Error())
+	}
+
+	aggs := []readers.Aggregate{}
+	if len(resp.Results) < 1 {
+		return aggs, nil
+	}
+
+	// Each series holds buckets of a single group.
+	for _, series := range resp.Results[0].Series {
+		for _, row := range series.Values {
+			a := readers.Aggregate{
+				Name:      series.Tags[readers.GroupByName],
+				Publisher: series.Tags[readers.GroupByPublisher],
+			}
+			for i, col := range series.Columns {
+				switch col {
+				case "time":
+					t, err := time.Parse(time.RFC3339Nano, fmt.Sprint(row[i]))
+					if err != nil {
+						return nil, errors.Wrap(errReadMessages, err)
+					}
+					a.Time = float64(t.UnixNano()) / float64(1e9)
+				case "value":
+					if num, ok := row[i].(json.Number); ok {
+						a.Value, _ = num.Float64()
+					}
+				}
+			}
+			aggs = append(aggs, a)
+		}
+	}
+	readers.SortAggregates(aggs)
+
+	return aggs, nil
+}
+
+var aggregations = map[string]string{
+	readers.AggregationMin:   "MIN",
+	readers.AggregationMax:   "MAX",
+	readers.AggregationAvg:   "MEAN",
+	readers.AggregationSum:   "SUM",
+	readers.AggregationCount: "COUNT",
+	readers.AggregationFirst: "FIRST",
+	readers.AggregationLast:  "LAST",
+}
+
+func timeValue(names []string, fields []interface{}) (string, bool) {
+	for i, name := range names {
+		if name == "time" {
+			t, ok := fields[i].(string)
+			return t, ok
+		}
+	}
+
+	return "", false
 }
 
 func (repo *influxRepository) count(condition string) (uint64, error) {
@@ -116,7 +235,7 @@ func (repo *influxRepository) count(condition string) (uint64, error) {
 	return strconv.ParseUint(count.String(), 10, 64)
 }
 
-func fmtCondition(chanID string, query map[string]string) string {
+func fmtCondition(chanID string, query map[string]string) (string, error) {
 	condition := fmt.Sprintf(`channel='%s'`, chanID)
 	for name, value := range query {
 		switch name {
@@ -131,9 +250,27 @@ func fmtCondition(chanID string, query map[string]string) string {
 			"protocol":
 			condition = fmt.Sprintf(`%s AND "%s"='%s'`, condition, name,
 				strings.Replace(value, "\"", "\\\"", -1))
+		case "v":
+			v, err := strconv.ParseFloat(value, 64)
+			if err != nil {
+				return "", err
+			}
+			comparator := readers.ParseValueComparator(query)
+			condition = fmt.Sprintf(`%s AND value %s %s`, condition, comparator, strconv.FormatFloat(v, 'f', -1, 64))
+		case "from", "to":
+			t, err := strconv.ParseFloat(value, 64)
+			if err != nil {
+				return "", err
+			}
+			op := ">="
+			if name == "to" {
+				op = "<"
+			}
+			// InfluxDB compares integer time in nanoseconds.
+			condition = fmt.Sprintf(`%s AND time %s %d`, condition, op, int64(t*1e9))
 		}
 	}
-	return condition
+	return condition, nil
 }
 
 // ParseMessage and parseValues are util methods. Since InfluxDB client returns
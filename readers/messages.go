@@ -9,21 +9,61 @@ import (
 	"github.com/mainflux/mainflux/pkg/transformers/senml"
 )
 
+const (
+	// EqualKey represents the equality comparator.
+	EqualKey = "eq"
+	// LowerThanKey represents the lower-than comparator.
+	LowerThanKey = "lt"
+	// LowerThanEqualKey represents the lower-than-or-equal comparator.
+	LowerThanEqualKey = "le"
+	// GreaterThanKey represents the greater-than comparator.
+	GreaterThanKey = "gt"
+	// GreaterThanEqualKey represents the greater-than-or-equal comparator.
+	GreaterThanEqualKey = "ge"
+)
+
 // ErrNotFound indicates that requested entity doesn't exist.
 var ErrNotFound = errors.New("entity not found")
 
 // MessageRepository specifies message reader API.
 type MessageRepository interface {
 	// ReadAll skips given number of messages for given channel and returns next
-	// limited number of messages.
-	ReadAll(chanID string, offset, limit uint64, query map[string]string) (MessagesPage, error)
+	// limited number of messages. If cursor is provided, messages following
+	// the cursor are returned and the offset is ignored. Besides exact-match
+	// fields, the query may contain "from" and "to" (inclusive and exclusive
+	// time bounds in seconds), and "v" compared to the message value using
+	// the optional "comparator".
+	ReadAll(chanID string, offset, limit uint64, cursor string, query map[string]string) (MessagesPage, error)
+
+	// Aggregate returns values of the given channel messages aggregated
+	// according to the aggregation query.
+	Aggregate(chanID string, aq AggregationQuery) ([]Aggregate, error)
 }
 
 // MessagesPage contains page related metadata as well as list of messages that
-// belong to this page.
+// belong to this page. NextCursor is set if there may be more messages
+// following the page.
 type MessagesPage struct {
-	Total    uint64
-	Offset   uint64
-	Limit    uint64
-	Messages []senml.Message
+	Total      uint64
+	Offset     uint64
+	Limit      uint64
+	NextCursor string
+	Messages   []senml.Message
+}
+
+// ParseValueComparator returns the SQL comparison operator matching the query
+// comparator. Equality is used if the comparator is missing or unknown.
+func ParseValueComparator(query map[string]string) string {
+	switch query["comparator"] {
+	case LowerThanKey:
+		return "<"
+	case LowerThanEqualKey:
+		return "<="
+	case GreaterThanKey:
+		return ">"
+	case GreaterThanEqualKey:
+		return ">="
+	default:
+		return "="
+	}
 }
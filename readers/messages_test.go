@@ -0,0 +1,32 @@
+// Copyright (c) Mainflux
+// SPDX-License-Identifier: Apache-2.0
+
+package readers_test
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/mainflux/mainflux/readers"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestParseValueComparator(t *testing.T) {
+	cases := map[string]struct {
+		query    map[string]string
+		operator string
+	}{
+		"parse missing comparator":          {query: map[string]string{"v": "1"}, operator: "="},
+		"parse equal comparator":            {query: map[string]string{"comparator": readers.EqualKey}, operator: "="},
+		"parse lower than comparator":       {query: map[string]string{"comparator": readers.LowerThanKey}, operator: "<"},
+		"parse lower than equal comparator": {query: map[string]string{"comparator": readers.LowerThanEqualKey}, operator: "<="},
+		"parse greater than comparator":     {query: map[string]string{"comparator": readers.GreaterThanKey}, operator: ">"},
+		"parse greater than equal":          {query: map[string]string{"comparator": readers.GreaterThanEqualKey}, operator: ">="},
+		"parse unknown comparator":          {query: map[string]string{"comparator": "unknown"}, operator: "="},
+	}
+
+	for desc, tc := range cases {
+		operator := readers.ParseValueComparator(tc.query)
+		assert.Equal(t, tc.operator, operator, fmt.Sprintf("%s: expected %s got %s", desc, tc.operator, operator))
+	}
+}
@@ -0,0 +1,62 @@
+// Copyright (c) Mainflux
+// SPDX-License-Identifier: Apache-2.0
+
+package mocks
+
+import (
+	"context"
+
+	"github.com/golang/protobuf/ptypes/empty"
+	"github.com/mainflux/mainflux"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+var _ mainflux.AuthNServiceClient = (*authServiceMock)(nil)
+
+type authServiceMock struct {
+	users map[string]mainflux.UserIdentity
+}
+
+// NewAuthService creates mock of authn service. Users map contains
+// identities of the users identified by the provided tokens.
+func NewAuthService(users map[string]mainflux.UserIdentity) mainflux.AuthNServiceClient {
+	return authServiceMock{users}
+}
+
+func (svc authServiceMock) Identify(ctx context.Context, in *mainflux.Token, opts ...grpc.CallOption) (*mainflux.UserIdentity, error) {
+	if id, ok := svc.users[in.GetValue()]; ok {
+		return &id, nil
+	}
+
+	return nil, status.Error(codes.Unauthenticated, "unauthorized access")
+}
+
+func (svc authServiceMock) Issue(ctx context.Context, in *mainflux.IssueReq, opts ...grpc.CallOption) (*mainflux.Token, error) {
+	panic("not implemented")
+}
+
+func (svc authServiceMock) RevokeToken(ctx context.Context, in *mainflux.Token, opts ...grpc.CallOption) (*empty.Empty, error) {
+	panic("not implemented")
+}
+
+func (svc authServiceMock) RevokeSessions(ctx context.Context, in *mainflux.Token, opts ...grpc.CallOption) (*empty.Empty, error) {
+	panic("not implemented")
+}
+
+func (svc authServiceMock) Refresh(ctx context.Context, in *mainflux.Token, opts ...grpc.CallOption) (*mainflux.Tokens, error) {
+	panic("not implemented")
+}
+
+func (svc authServiceMock) VerifyChallenge(ctx context.Context, in *mainflux.Token, opts ...grpc.CallOption) (*mainflux.UserIdentity, error) {
+	panic("not implemented")
+}
+
+func (svc authServiceMock) Impersonate(ctx context.Context, in *mainflux.ImpersonateReq, opts ...grpc.CallOption) (*mainflux.Token, error) {
+	panic("not implemented")
+}
+
+func (svc authServiceMock) SetDisabled(ctx context.Context, in *mainflux.DisableReq, opts ...grpc.CallOption) (*empty.Empty, error) {
+	panic("not implemented")
+}
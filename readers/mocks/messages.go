@@ -4,8 +4,11 @@
 package mocks
 
 import (
+	"strconv"
 	"sync"
 
+	mfcursor "github.com/mainflux/mainflux/pkg/cursor"
+	"github.com/mainflux/mainflux/pkg/errors"
 	"github.com/mainflux/mainflux/pkg/transformers/senml"
 	"github.com/mainflux/mainflux/readers"
 )
@@ -25,10 +28,21 @@ func NewMessageRepository(messages map[string][]senml.Message) readers.MessageRe
 	}
 }
 
-func (repo *messageRepositoryMock) ReadAll(chanID string, offset, limit uint64, query map[string]string) (readers.MessagesPage, error) {
+func (repo *messageRepositoryMock) ReadAll(chanID string, offset, limit uint64, cursor string, query map[string]string) (readers.MessagesPage, error) {
 	repo.mutex.Lock()
 	defer repo.mutex.Unlock()
 
+	// Mock cursor holds the index of the message following the page.
+	if cursor != "" {
+		c, err := readers.DecodeCursor(cursor)
+		if err != nil {
+			return readers.MessagesPage{}, err
+		}
+		if offset, err = strconv.ParseUint(c.Time, 10, 64); err != nil {
+			return readers.MessagesPage{}, errors.Wrap(mfcursor.ErrMalformedCursor, err)
+		}
+	}
+
 	end := offset + limit
 
 	numOfMessages := uint64(len(repo.messages[chanID]))
@@ -44,10 +58,33 @@ func (repo *messageRepositoryMock) ReadAll(chanID string, offset, limit uint64,
 		end = numOfMessages
 	}
 
-	return readers.MessagesPage{
+	page := readers.MessagesPage{
 		Total:    numOfMessages,
 		Limit:    limit,
 		Offset:   offset,
 		Messages: repo.messages[chanID][offset:end],
-	}, nil
+	}
+
+	if end-offset == limit {
+		page.NextCursor = mfcursor.Encode(strconv.FormatUint(end, 10), "0")
+	}
+
+	return page, nil
+}
+
+func (repo *messageRepositoryMock) Aggregate(chanID string, aq readers.AggregationQuery) ([]readers.Aggregate, error) {
+	repo.mutex.Lock()
+	defer repo.mutex.Unlock()
+
+	from, _ := strconv.ParseFloat(aq.Query["from"], 64)
+	to, _ := strconv.ParseFloat(aq.Query["to"], 64)
+
+	msgs := []senml.Message{}
+	for _, msg := range repo.messages[chanID] {
+		if msg.Time >= from && msg.Time < to {
+			msgs = append(msgs, msg)
+		}
+	}
+
+	return readers.AggregateMessages(msgs, aq)
 }
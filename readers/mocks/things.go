@@ -14,15 +14,26 @@ import (
 	"google.golang.org/grpc/status"
 )
 
-var errUnauthorized = status.Error(codes.PermissionDenied, "missing or invalid credentials provided")
+var (
+	errUnauthorized = status.Error(codes.PermissionDenied, "missing or invalid credentials provided")
+	errNotFound     = status.Error(codes.NotFound, "entity does not exist")
+)
 
 var _ mainflux.ThingsServiceClient = (*thingsServiceMock)(nil)
 
-type thingsServiceMock struct{}
+type thingsServiceMock struct {
+	things   map[string]string
+	channels map[string]string
+}
 
-// NewThingsService returns mock implementation of things service
-func NewThingsService() mainflux.ThingsServiceClient {
-	return thingsServiceMock{}
+// NewThingsService returns mock implementation of things service. Things
+// map contains channels the thing keys are connected to, while channels
+// map contains owners of the channels.
+func NewThingsService(things, channels map[string]string) mainflux.ThingsServiceClient {
+	return thingsServiceMock{
+		things:   things,
+		channels: channels,
+	}
 }
 
 func (svc thingsServiceMock) CanAccessByKey(ctx context.Context, in *mainflux.AccessByKeyReq, opts ...grpc.CallOption) (*mainflux.ThingID, error) {
@@ -35,6 +46,14 @@ func (svc thingsServiceMock) CanAccessByKey(ctx context.Context, in *mainflux.Ac
 		return nil, errUnauthorized
 	}
 
+	chanID, ok := svc.things[token]
+	if !ok {
+		return nil, errNotFound
+	}
+	if chanID != in.GetChanID() {
+		return nil, errUnauthorized
+	}
+
 	return &mainflux.ThingID{Value: token}, nil
 }
 
@@ -45,3 +64,15 @@ func (svc thingsServiceMock) CanAccessByID(context.Context, *mainflux.AccessByID
 func (svc thingsServiceMock) Identify(context.Context, *mainflux.Token, ...grpc.CallOption) (*mainflux.ThingID, error) {
 	panic("not implemented")
 }
+
+func (svc thingsServiceMock) IsChannelOwner(ctx context.Context, in *mainflux.ChannelOwnerReq, opts ...grpc.CallOption) (*empty.Empty, error) {
+	if owner, ok := svc.channels[in.GetChanID()]; ok && owner == in.GetOwner() {
+		return &empty.Empty{}, nil
+	}
+
+	return nil, errNotFound
+}
+
+func (svc thingsServiceMock) ChannelSchema(context.Context, *mainflux.ChannelID, ...grpc.CallOption) (*mainflux.ChannelSchemaRes, error) {
+	panic("not implemented")
+}
@@ -5,7 +5,9 @@ package mongodb
 
 import (
 	"context"
+	"strconv"
 
+	mfcursor "github.com/mainflux/mainflux/pkg/cursor"
 	"github.com/mainflux/mainflux/pkg/errors"
 	"github.com/mainflux/mainflux/pkg/transformers/senml"
 	"github.com/mainflux/mainflux/readers"
@@ -48,14 +50,43 @@ func New(db *mongo.Database) readers.MessageRepository {
 	}
 }
 
-func (repo mongoRepository) ReadAll(chanID string, offset, limit uint64, query map[string]string) (readers.MessagesPage, error) {
+func (repo mongoRepository) ReadAll(chanID string, offset, limit uint64, pageCursor string, query map[string]string) (readers.MessagesPage, error) {
 	col := repo.db.Collection(collection)
-	sortMap := map[string]interface{}{
-		"time": -1,
+	sortMap := bson.D{
+		bson.E{Key: "time", Value: -1},
+		bson.E{Key: "_id", Value: -1},
 	}
 
-	filter := fmtCondition(chanID, query)
-	cursor, err := col.Find(context.Background(), filter, options.Find().SetSort(sortMap).SetLimit(int64(limit)).SetSkip(int64(offset)))
+	filter, err := fmtCondition(chanID, query)
+	if err != nil {
+		return readers.MessagesPage{}, errors.Wrap(errReadMessages, err)
+	}
+	pageFilter := filter
+	skip := offset
+
+	c := readers.Cursor{}
+	if pageCursor != "" {
+		if c, err = readers.DecodeCursor(pageCursor); err != nil {
+			return readers.MessagesPage{}, err
+		}
+
+		t, err := strconv.ParseFloat(c.Time, 64)
+		if err != nil {
+			return readers.MessagesPage{}, errors.Wrap(mfcursor.ErrMalformedCursor, err)
+		}
+
+		// Wrap the filter since it may already restrict the time.
+		pageFilter = &bson.D{
+			bson.E{Key: "$and", Value: bson.A{
+				*filter,
+				bson.D{bson.E{Key: "time", Value: bson.M{"$lte": t}}},
+			}},
+		}
+		skip = c.Skip
+		offset = 0
+	}
+
+	cursor, err := col.Find(context.Background(), pageFilter, options.Find().SetSort(sortMap).SetLimit(int64(limit)).SetSkip(int64(skip)))
 	if err != nil {
 		return readers.MessagesPage{}, errors.Wrap(errReadMessages, err)
 	}
@@ -102,21 +133,110 @@ func (repo mongoRepository) ReadAll(chanID string, offset, limit uint64, query m
 		return readers.MessagesPage{}, nil
 	}
 
-	return readers.MessagesPage{
+	page := readers.MessagesPage{
 		Total:    uint64(total),
 		Offset:   offset,
 		Limit:    limit,
 		Messages: messages,
-	}, nil
+	}
+
+	if n := len(messages); n > 0 {
+		last := strconv.FormatFloat(messages[n-1].Time, 'f', -1, 64)
+		page.NextCursor = readers.NextCursor(c, messages, limit, last)
+	}
+
+	return page, nil
+}
+
+func (repo mongoRepository) Aggregate(chanID string, aq readers.AggregationQuery) ([]readers.Aggregate, error) {
+	acc, ok := accumulators[aq.Function]
+	if !ok {
+		return nil, readers.ErrUnsupportedAggregation
+	}
+
+	filter, err := fmtCondition(chanID, aq.Query)
+	if err != nil {
+		return nil, errors.Wrap(errReadMessages, err)
+	}
+	match := append(*filter, bson.E{Key: "value", Value: bson.M{"$exists": true}})
+
+	interval := aq.Interval.Seconds()
+	id := bson.D{
+		bson.E{Key: "bucket", Value: bson.M{"$subtract": bson.A{"$time", bson.M{"$mod": bson.A{"$time", interval}}}}},
+	}
+	for _, field := range []string{readers.GroupByName, readers.GroupByPublisher} {
+		if aq.GroupedBy(field) {
+			id = append(id, bson.E{Key: field, Value: "$" + field})
+		}
+	}
+
+	pipeline := mongo.Pipeline{
+		bson.D{bson.E{Key: "$match", Value: match}},
+		// Sort by time for first and last accumulators.
+		bson.D{bson.E{Key: "$sort", Value: bson.D{bson.E{Key: "time", Value: 1}}}},
+		bson.D{bson.E{Key: "$group", Value: bson.D{
+			bson.E{Key: "_id", Value: id},
+			bson.E{Key: "value", Value: acc},
+		}}},
+		bson.D{bson.E{Key: "$sort", Value: bson.D{
+			bson.E{Key: "_id.bucket", Value: 1},
+			bson.E{Key: "_id.name", Value: 1},
+			bson.E{Key: "_id.publisher", Value: 1},
+		}}},
+	}
+
+	col := repo.db.Collection(collection)
+	cursor, err := col.Aggregate(context.Background(), pipeline)
+	if err != nil {
+		return nil, errors.Wrap(errReadMessages, err)
+	}
+	defer cursor.Close(context.Background())
+
+	aggs := []readers.Aggregate{}
+	for cursor.Next(context.Background()) {
+		var a aggregate
+		if err := cursor.Decode(&a); err != nil {
+			return nil, errors.Wrap(errReadMessages, err)
+		}
+
+		aggs = append(aggs, readers.Aggregate{
+			Time:      a.ID.Bucket,
+			Name:      a.ID.Name,
+			Publisher: a.ID.Publisher,
+			Value:     a.Value,
+		})
+	}
+
+	return aggs, nil
 }
 
-func fmtCondition(chanID string, query map[string]string) *bson.D {
+var accumulators = map[string]bson.M{
+	readers.AggregationMin:   {"$min": "$value"},
+	readers.AggregationMax:   {"$max": "$value"},
+	readers.AggregationAvg:   {"$avg": "$value"},
+	readers.AggregationSum:   {"$sum": "$value"},
+	readers.AggregationCount: {"$sum": 1},
+	readers.AggregationFirst: {"$first": "$value"},
+	readers.AggregationLast:  {"$last": "$value"},
+}
+
+type aggregate struct {
+	ID struct {
+		Bucket    float64 `bson:"bucket"`
+		Name      string  `bson:"name,omitempty"`
+		Publisher string  `bson:"publisher,omitempty"`
+	} `bson:"_id"`
+	Value float64 `bson:"value"`
+}
+
+func fmtCondition(chanID string, query map[string]string) (*bson.D, error) {
 	filter := bson.D{
 		bson.E{
 			Key:   "channel",
 			Value: chanID,
 		},
 	}
+	timeRange := bson.M{}
 	for name, value := range query {
 		switch name {
 		case
@@ -126,8 +246,42 @@ func fmtCondition(chanID string, query map[string]string) *bson.D {
 			"name",
 			"protocol":
 			filter = append(filter, bson.E{Key: name, Value: value})
+		case "v":
+			v, err := strconv.ParseFloat(value, 64)
+			if err != nil {
+				return nil, err
+			}
+			filter = append(filter, bson.E{Key: "value", Value: bson.M{valueComparator(query): v}})
+		case "from", "to":
+			t, err := strconv.ParseFloat(value, 64)
+			if err != nil {
+				return nil, err
+			}
+			op := "$gte"
+			if name == "to" {
+				op = "$lt"
+			}
+			timeRange[op] = t
 		}
 	}
+	if len(timeRange) > 0 {
+		filter = append(filter, bson.E{Key: "time", Value: timeRange})
+	}
 
-	return &filter
+	return &filter, nil
+}
+
+func valueComparator(query map[string]string) string {
+	switch query["comparator"] {
+	case readers.LowerThanKey:
+		return "$lt"
+	case readers.LowerThanEqualKey:
+		return "$lte"
+	case readers.GreaterThanKey:
+		return "$gt"
+	case readers.GreaterThanEqualKey:
+		return "$gte"
+	default:
+		return "$eq"
+	}
 }
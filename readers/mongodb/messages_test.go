@@ -7,6 +7,7 @@ import (
 	"context"
 	"fmt"
 	"os"
+	"strconv"
 	"testing"
 	"time"
 
@@ -88,6 +89,10 @@ func TestReadAll(t *testing.T) {
 
 	reader := mreaders.New(db)
 
+	// Time range bounds lay between message times to avoid rounding issues.
+	from := fmt.Sprintf("%f", messages[19].Time-0.5)
+	to := fmt.Sprintf("%f", messages[9].Time-0.5)
+
 	cases := map[string]struct {
 		chanID string
 		offset uint64
@@ -152,12 +157,138 @@ func TestReadAll(t *testing.T) {
 				Messages: subtopicMsgs,
 			},
 		},
+		"read message with value comparator": {
+			chanID: chanID,
+			offset: 0,
+			limit:  msgsNum,
+			query:  map[string]string{"v": "6", "comparator": readers.LowerThanKey},
+			page: readers.MessagesPage{
+				Total:    uint64(len(subtopicMsgs)),
+				Offset:   0,
+				Limit:    msgsNum,
+				Messages: subtopicMsgs,
+			},
+		},
+		"read message with value and unmatched comparator": {
+			chanID: chanID,
+			offset: 0,
+			limit:  msgsNum,
+			query:  map[string]string{"v": "5", "comparator": readers.GreaterThanKey},
+			page: readers.MessagesPage{
+				Total:    0,
+				Offset:   0,
+				Limit:    msgsNum,
+				Messages: []senml.Message{},
+			},
+		},
+		"read message within time range": {
+			chanID: chanID,
+			offset: 0,
+			limit:  msgsNum,
+			query:  map[string]string{"from": from, "to": to},
+			page: readers.MessagesPage{
+				Total:    10,
+				Offset:   0,
+				Limit:    msgsNum,
+				Messages: messages[10:20],
+			},
+		},
 	}
 
 	for desc, tc := range cases {
-		result, err := reader.ReadAll(tc.chanID, tc.offset, tc.limit, tc.query)
+		result, err := reader.ReadAll(tc.chanID, tc.offset, tc.limit, "", tc.query)
 		assert.Nil(t, err, fmt.Sprintf("%s: expected no error got %s", desc, err))
 		assert.ElementsMatch(t, tc.page.Messages, result.Messages, fmt.Sprintf("%s: expected %v got %v", desc, tc.page.Messages, result.Messages))
 		assert.Equal(t, tc.page.Total, result.Total, fmt.Sprintf("%s: expected %v got %v", desc, tc.page.Total, result.Total))
 	}
 }
+
+func TestAggregate(t *testing.T) {
+	client, err := mongo.Connect(context.Background(), options.Client().ApplyURI(addr))
+	require.Nil(t, err, fmt.Sprintf("Creating new MongoDB client expected to succeed: %s.\n", err))
+
+	db := client.Database(testDB)
+	writer := mwriters.New(db)
+	aggChanID := "aggregate"
+
+	// Align messages to a minute so that all of them fall into a single bucket.
+	start := time.Now().Add(-time.Hour).Truncate(time.Minute).Unix()
+	names := []string{"a", "b"}
+	messages := []senml.Message{}
+	for i := 0; i < 10; i++ {
+		val := float64(i)
+		messages = append(messages, senml.Message{
+			Channel:   aggChanID,
+			Publisher: "1",
+			Protocol:  "mqtt",
+			Name:      names[i%2],
+			Value:     &val,
+			Time:      float64(start + int64(i)),
+		})
+	}
+
+	err = writer.Save(messages...)
+	require.Nil(t, err, fmt.Sprintf("expected no error got %s\n", err))
+
+	reader := mreaders.New(db)
+
+	query := map[string]string{
+		"from": strconv.FormatInt(start, 10),
+		"to":   strconv.FormatInt(start+60, 10),
+	}
+	bucket := float64(start)
+
+	cases := map[string]struct {
+		aq   readers.AggregationQuery
+		aggs []readers.Aggregate
+	}{
+		"aggregate minimum": {
+			aq:   readers.AggregationQuery{Function: readers.AggregationMin, Interval: time.Minute, Query: query},
+			aggs: []readers.Aggregate{{Time: bucket, Value: 0}},
+		},
+		"aggregate maximum": {
+			aq:   readers.AggregationQuery{Function: readers.AggregationMax, Interval: time.Minute, Query: query},
+			aggs: []readers.Aggregate{{Time: bucket, Value: 9}},
+		},
+		"aggregate average": {
+			aq:   readers.AggregationQuery{Function: readers.AggregationAvg, Interval: time.Minute, Query: query},
+			aggs: []readers.Aggregate{{Time: bucket, Value: 4.5}},
+		},
+		"aggregate sum": {
+			aq:   readers.AggregationQuery{Function: readers.AggregationSum, Interval: time.Minute, Query: query},
+			aggs: []readers.Aggregate{{Time: bucket, Value: 45}},
+		},
+		"aggregate count": {
+			aq:   readers.AggregationQuery{Function: readers.AggregationCount, Interval: time.Minute, Query: query},
+			aggs: []readers.Aggregate{{Time: bucket, Value: 10}},
+		},
+		"aggregate first": {
+			aq:   readers.AggregationQuery{Function: readers.AggregationFirst, Interval: time.Minute, Query: query},
+			aggs: []readers.Aggregate{{Time: bucket, Value: 0}},
+		},
+		"aggregate last": {
+			aq:   readers.AggregationQuery{Function: readers.AggregationLast, Interval: time.Minute, Query: query},
+			aggs: []readers.Aggregate{{Time: bucket, Value: 9}},
+		},
+		"aggregate sum grouped by name": {
+			aq: readers.AggregationQuery{Function: readers.AggregationSum, Interval: time.Minute, GroupBy: []string{readers.GroupByName}, Query: query},
+			aggs: []readers.Aggregate{
+				{Time: bucket, Name: "a", Value: 20},
+				{Time: bucket, Name: "b", Value: 25},
+			},
+		},
+		"aggregate sum in smaller buckets": {
+			aq: readers.AggregationQuery{Function: readers.AggregationSum, Interval: 5 * time.Second, Query: query},
+			aggs: []readers.Aggregate{
+				{Time: bucket, Value: 10},
+				{Time: bucket + 5, Value: 35},
+			},
+		},
+	}
+
+	for desc, tc := range cases {
+		aggs, err := reader.Aggregate(aggChanID, tc.aq)
+		assert.Nil(t, err, fmt.Sprintf("%s: expected no error got %s", desc, err))
+		assert.Equal(t, tc.aggs, aggs, fmt.Sprintf("%s: expected %v got %v", desc, tc.aggs, aggs))
+	}
+}
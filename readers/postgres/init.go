@@ -70,6 +70,15 @@ func migrateDB(db *sqlx.DB) error {
 					"DROP TABLE messages",
 				},
 			},
+			{
+				Id: "messages_2",
+				Up: []string{
+					`CREATE INDEX IF NOT EXISTS messages_channel_time_idx ON messages (channel, time DESC, id)`,
+				},
+				Down: []string{
+					"DROP INDEX IF EXISTS messages_channel_time_idx",
+				},
+			},
 		},
 	}
 
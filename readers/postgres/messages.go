@@ -5,8 +5,11 @@ package postgres
 
 import (
 	"fmt"
+	"strconv"
+	"strings"
 
 	"github.com/jmoiron/sqlx" // required for DB access
+	mfcursor "github.com/mainflux/mainflux/pkg/cursor"
 	"github.com/mainflux/mainflux/pkg/errors"
 	"github.com/mainflux/mainflux/pkg/transformers/senml"
 	"github.com/mainflux/mainflux/readers"
@@ -29,21 +32,38 @@ func New(db *sqlx.DB) readers.MessageRepository {
 	}
 }
 
-func (tr postgresRepository) ReadAll(chanID string, offset, limit uint64, query map[string]string) (readers.MessagesPage, error) {
-	q := fmt.Sprintf(`SELECT * FROM messages
-    WHERE %s ORDER BY time DESC
-    LIMIT :limit OFFSET :offset;`, fmtCondition(chanID, query))
+func (tr postgresRepository) ReadAll(chanID string, offset, limit uint64, cursor string, query map[string]string) (readers.MessagesPage, error) {
+	condition := fmtCondition(chanID, query)
 
-	params := map[string]interface{}{
-		"channel":   chanID,
-		"limit":     limit,
-		"offset":    offset,
-		"subtopic":  query["subtopic"],
-		"publisher": query["publisher"],
-		"name":      query["name"],
-		"protocol":  query["protocol"],
+	params, err := queryParams(chanID, query)
+	if err != nil {
+		return readers.MessagesPage{}, errors.Wrap(errReadMessages, err)
+	}
+	params["limit"] = limit
+	params["offset"] = offset
+
+	c := readers.Cursor{}
+	cond := condition
+	if cursor != "" {
+		if c, err = readers.DecodeCursor(cursor); err != nil {
+			return readers.MessagesPage{}, err
+		}
+
+		t, err := strconv.ParseFloat(c.Time, 64)
+		if err != nil {
+			return readers.MessagesPage{}, errors.Wrap(mfcursor.ErrMalformedCursor, err)
+		}
+
+		cond = fmt.Sprintf(`%s AND time <= :cursor_time`, condition)
+		params["cursor_time"] = t
+		params["offset"] = c.Skip
+		offset = 0
 	}
 
+	q := fmt.Sprintf(`SELECT * FROM messages
+    WHERE %s ORDER BY time DESC, id
+    LIMIT :limit OFFSET :offset;`, cond)
+
 	rows, err := tr.db.NamedQuery(q, params)
 	if err != nil {
 		return readers.MessagesPage{}, errors.Wrap(errReadMessages, err)
@@ -65,21 +85,109 @@ func (tr postgresRepository) ReadAll(chanID string, offset, limit uint64, query
 		page.Messages = append(page.Messages, msg)
 	}
 
-	q = `SELECT COUNT(*) FROM messages WHERE channel = $1;`
-	qParams := []interface{}{chanID}
+	if n := len(page.Messages); n > 0 {
+		last := strconv.FormatFloat(page.Messages[n-1].Time, 'f', -1, 64)
+		page.NextCursor = readers.NextCursor(c, page.Messages, limit, last)
+	}
 
-	if query["subtopic"] != "" {
-		q = `SELECT COUNT(*) FROM messages WHERE channel = $1 AND subtopic = $2;`
-		qParams = append(qParams, query["subtopic"])
+	q = fmt.Sprintf(`SELECT COUNT(*) FROM messages WHERE %s;`, condition)
+	stmt, err := tr.db.PrepareNamed(q)
+	if err != nil {
+		return readers.MessagesPage{}, errors.Wrap(errReadMessages, err)
 	}
+	defer stmt.Close()
 
-	if err := tr.db.QueryRow(q, qParams...).Scan(&page.Total); err != nil {
+	if err := stmt.QueryRow(params).Scan(&page.Total); err != nil {
 		return readers.MessagesPage{}, errors.Wrap(errReadMessages, err)
 	}
 
 	return page, nil
 }
 
+func (tr postgresRepository) Aggregate(chanID string, aq readers.AggregationQuery) ([]readers.Aggregate, error) {
+	fn, ok := aggregations[aq.Function]
+	if !ok {
+		return nil, readers.ErrUnsupportedAggregation
+	}
+
+	params, err := queryParams(chanID, aq.Query)
+	if err != nil {
+		return nil, errors.Wrap(errReadMessages, err)
+	}
+	params["interval"] = aq.Interval.Seconds()
+
+	// Time is stored in seconds and the interval is arbitrary, so buckets
+	// are computed arithmetically instead of using date_trunc.
+	cols := []string{`floor(time / :interval) * :interval AS bucket`}
+	groups := []string{`bucket`}
+	for _, field := range []string{readers.GroupByName, readers.GroupByPublisher} {
+		if aq.GroupedBy(field) {
+			cols = append(cols, field)
+			groups = append(groups, field)
+		}
+	}
+	cols = append(cols, fmt.Sprintf(`%s AS value`, fn))
+
+	q := fmt.Sprintf(`SELECT %s FROM messages
+    WHERE %s AND value IS NOT NULL
+    GROUP BY %s ORDER BY %s;`,
+		strings.Join(cols, ", "), fmtCondition(chanID, aq.Query), strings.Join(groups, ", "), strings.Join(groups, ", "))
+
+	rows, err := tr.db.NamedQuery(q, params)
+	if err != nil {
+		return nil, errors.Wrap(errReadMessages, err)
+	}
+	defer rows.Close()
+
+	aggs := []readers.Aggregate{}
+	for rows.Next() {
+		dba := dbAggregate{}
+		if err := rows.StructScan(&dba); err != nil {
+			return nil, errors.Wrap(errReadMessages, err)
+		}
+
+		aggs = append(aggs, readers.Aggregate{
+			Time:      dba.Bucket,
+			Name:      dba.Name,
+			Publisher: dba.Publisher,
+			Value:     dba.Value,
+		})
+	}
+
+	return aggs, nil
+}
+
+var aggregations = map[string]string{
+	readers.AggregationMin:   `MIN(value)`,
+	readers.AggregationMax:   `MAX(value)`,
+	readers.AggregationAvg:   `AVG(value)`,
+	readers.AggregationSum:   `SUM(value)`,
+	readers.AggregationCount: `COUNT(value)`,
+	readers.AggregationFirst: `(ARRAY_AGG(value ORDER BY time))[1]`,
+	readers.AggregationLast:  `(ARRAY_AGG(value ORDER BY time DESC))[1]`,
+}
+
+func queryParams(chanID string, query map[string]string) (map[string]interface{}, error) {
+	params := map[string]interface{}{
+		"channel":   chanID,
+		"subtopic":  query["subtopic"],
+		"publisher": query["publisher"],
+		"name":      query["name"],
+		"protocol":  query["protocol"],
+	}
+	for _, name := range []string{"v", "from", "to"} {
+		if val, ok := query[name]; ok {
+			fVal, err := strconv.ParseFloat(val, 64)
+			if err != nil {
+				return nil, err
+			}
+			params[name] = fVal
+		}
+	}
+
+	return params, nil
+}
+
 func fmtCondition(chanID string, query map[string]string) string {
 	condition := `channel = :channel`
 	for name := range query {
@@ -90,11 +198,25 @@ func fmtCondition(chanID string, query map[string]string) string {
 			"name",
 			"protocol":
 			condition = fmt.Sprintf(`%s AND %s = :%s`, condition, name, name)
+		case "v":
+			comparator := readers.ParseValueComparator(query)
+			condition = fmt.Sprintf(`%s AND value %s :v`, condition, comparator)
+		case "from":
+			condition = fmt.Sprintf(`%s AND time >= :from`, condition)
+		case "to":
+			condition = fmt.Sprintf(`%s AND time < :to`, condition)
 		}
 	}
 	return condition
 }
 
+type dbAggregate struct {
+	Bucket    float64 `db:"bucket"`
+	Name      string  `db:"name"`
+	Publisher string  `db:"publisher"`
+	Value     float64 `db:"value"`
+}
+
 type dbMessage struct {
 	ID          string   `db:"id"`
 	Channel     string   `db:"channel"`
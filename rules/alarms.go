@@ -0,0 +1,48 @@
+// Copyright (c) Mainflux
+// SPDX-License-Identifier: Apache-2.0
+
+package rules
+
+import (
+	"context"
+	"time"
+
+	"github.com/mainflux/mainflux/pkg/transformers/senml"
+)
+
+// Alarm represents the event of the fired rule. Records contain all the
+// records that made the rule fire.
+type Alarm struct {
+	ID      string          `json:"id"`
+	RuleID  string          `json:"rule_id"`
+	Owner   string          `json:"-"`
+	Channel string          `json:"channel"`
+	Records []senml.Message `json:"records"`
+	Created time.Time       `json:"created"`
+}
+
+// AlarmsPage contains page related metadata as well as a list of alarms
+// that belong to this page.
+type AlarmsPage struct {
+	Total  uint64
+	Offset uint64
+	Limit  uint64
+	Alarms []Alarm
+}
+
+// AlarmRepository specifies an alarm persistence API.
+type AlarmRepository interface {
+	// Save persists the alarm.
+	Save(ctx context.Context, alarm Alarm) (string, error)
+
+	// RetrieveAll retrieves the subset of alarms owned by the specified
+	// user. If rule ID is not empty, only alarms raised by that rule are
+	// retrieved. Alarms are sorted from the newest to the oldest.
+	RetrieveAll(ctx context.Context, owner, ruleID string, offset, limit uint64) (AlarmsPage, error)
+}
+
+// Notifier specifies an API for delivering alarms to webhooks.
+type Notifier interface {
+	// Notify sends the alarm to the webhook with the given URL.
+	Notify(url string, alarm Alarm) error
+}
@@ -0,0 +1,65 @@
+// Copyright (c) Mainflux
+// SPDX-License-Identifier: Apache-2.0
+
+package api
+
+import (
+	"context"
+
+	"github.com/mainflux/mainflux/pkg/audit"
+	"github.com/mainflux/mainflux/pkg/messaging"
+	"github.com/mainflux/mainflux/rules"
+)
+
+var _ rules.Service = (*auditMiddleware)(nil)
+
+type auditMiddleware struct {
+	rec audit.Recorder
+	svc rules.Service
+}
+
+// AuditMiddleware records the operations changing the rules. Read-only
+// operations and the evaluation of the consumed messages are not recorded.
+func AuditMiddleware(svc rules.Service, rec audit.Recorder) rules.Service {
+	return &auditMiddleware{rec, svc}
+}
+
+func (am *auditMiddleware) CreateRule(ctx context.Context, token string, rule rules.Rule) (saved rules.Rule, err error) {
+	e := am.rec.Event(ctx, token, "create_rule", "")
+	defer func() {
+		e.Entity = saved.ID
+		am.rec.Record(ctx, e, err)
+	}()
+
+	return am.svc.CreateRule(ctx, token, rule)
+}
+
+func (am *auditMiddleware) ViewRule(ctx context.Context, token, id string) (rules.Rule, error) {
+	return am.svc.ViewRule(ctx, token, id)
+}
+
+func (am *auditMiddleware) UpdateRule(ctx context.Context, token string, rule rules.Rule) (err error) {
+	e := am.rec.Event(ctx, token, "update_rule", rule.ID)
+	defer func() { am.rec.Record(ctx, e, err) }()
+
+	return am.svc.UpdateRule(ctx, token, rule)
+}
+
+func (am *auditMiddleware) ListRules(ctx context.Context, token string, offset, limit uint64) (rules.RulesPage, error) {
+	return am.svc.ListRules(ctx, token, offset, limit)
+}
+
+func (am *auditMiddleware) RemoveRule(ctx context.Context, token, id string) (err error) {
+	e := am.rec.Event(ctx, token, "remove_rule", id)
+	defer func() { am.rec.Record(ctx, e, err) }()
+
+	return am.svc.RemoveRule(ctx, token, id)
+}
+
+func (am *auditMiddleware) ListAlarms(ctx context.Context, token, ruleID string, offset, limit uint64) (rules.AlarmsPage, error) {
+	return am.svc.ListAlarms(ctx, token, ruleID, offset, limit)
+}
+
+func (am *auditMiddleware) Consume(msg messaging.Message) error {
+	return am.svc.Consume(msg)
+}
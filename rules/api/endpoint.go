@@ -0,0 +1,153 @@
+// Copyright (c) Mainflux
+// SPDX-License-Identifier: Apache-2.0
+
+package api
+
+import (
+	"context"
+
+	"github.com/go-kit/kit/endpoint"
+	"github.com/mainflux/mainflux/rules"
+)
+
+func createRuleEndpoint(svc rules.Service) endpoint.Endpoint {
+	return func(ctx context.Context, request interface{}) (interface{}, error) {
+		req := request.(ruleReq)
+		if err := req.validate(); err != nil {
+			return nil, err
+		}
+
+		rule, err := req.rule()
+		if err != nil {
+			return nil, err
+		}
+
+		saved, err := svc.CreateRule(ctx, req.token, rule)
+		if err != nil {
+			return nil, err
+		}
+
+		return ruleRes{id: saved.ID, created: true}, nil
+	}
+}
+
+func viewRuleEndpoint(svc rules.Service) endpoint.Endpoint {
+	return func(ctx context.Context, request interface{}) (interface{}, error) {
+		req := request.(viewRuleReq)
+		if err := req.validate(); err != nil {
+			return nil, err
+		}
+
+		rule, err := svc.ViewRule(ctx, req.token, req.id)
+		if err != nil {
+			return nil, err
+		}
+
+		return toRuleRes(rule), nil
+	}
+}
+
+func updateRuleEndpoint(svc rules.Service) endpoint.Endpoint {
+	return func(ctx context.Context, request interface{}) (interface{}, error) {
+		req := request.(ruleReq)
+		if err := req.validate(); err != nil {
+			return nil, err
+		}
+
+		rule, err := req.rule()
+		if err != nil {
+			return nil, err
+		}
+
+		if err := svc.UpdateRule(ctx, req.token, rule); err != nil {
+			return nil, err
+		}
+
+		return ruleRes{id: req.id, created: false}, nil
+	}
+}
+
+func listRulesEndpoint(svc rules.Service) endpoint.Endpoint {
+	return func(ctx context.Context, request interface{}) (interface{}, error) {
+		req := request.(listReq)
+		if err := req.validate(); err != nil {
+			return nil, err
+		}
+
+		page, err := svc.ListRules(ctx, req.token, req.offset, req.limit)
+		if err != nil {
+			return nil, err
+		}
+
+		res := rulesPageRes{
+			pageRes: pageRes{
+				Total:  page.Total,
+				Offset: page.Offset,
+				Limit:  page.Limit,
+			},
+			Rules: []viewRuleRes{},
+		}
+		for _, rule := range page.Rules {
+			res.Rules = append(res.Rules, toRuleRes(rule))
+		}
+
+		return res, nil
+	}
+}
+
+func removeRuleEndpoint(svc rules.Service) endpoint.Endpoint {
+	return func(ctx context.Context, request interface{}) (interface{}, error) {
+		req := request.(viewRuleReq)
+		if err := req.validate(); err != nil {
+			return nil, err
+		}
+
+		if err := svc.RemoveRule(ctx, req.token, req.id); err != nil {
+			return nil, err
+		}
+
+		return removeRes{}, nil
+	}
+}
+
+func listAlarmsEndpoint(svc rules.Service) endpoint.Endpoint {
+	return func(ctx context.Context, request interface{}) (interface{}, error) {
+		req := request.(listReq)
+		if err := req.validate(); err != nil {
+			return nil, err
+		}
+
+		page, err := svc.ListAlarms(ctx, req.token, req.ruleID, req.offset, req.limit)
+		if err != nil {
+			return nil, err
+		}
+
+		res := alarmsPageRes{
+			pageRes: pageRes{
+				Total:  page.Total,
+				Offset: page.Offset,
+				Limit:  page.Limit,
+			},
+			Alarms: page.Alarms,
+		}
+
+		return res, nil
+	}
+}
+
+func toRuleRes(rule rules.Rule) viewRuleRes {
+	res := viewRuleRes{
+		ID:         rule.ID,
+		Channel:    rule.Channel,
+		Name:       rule.Name,
+		Conditions: rule.Conditions,
+		Window:     windowRes{Count: rule.Window.Count},
+		Actions:    rule.Actions,
+		Created:    rule.Created,
+	}
+	if rule.Window.Duration > 0 {
+		res.Window.Duration = rule.Window.Duration.String()
+	}
+
+	return res
+}
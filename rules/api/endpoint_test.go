@@ -0,0 +1,547 @@
+// Copyright (c) Mainflux
+// SPDX-License-Identifier: Apache-2.0
+
+package api_test
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/mainflux/mainflux/pkg/messaging"
+	mfsdk "github.com/mainflux/mainflux/pkg/sdk/go"
+	"github.com/mainflux/mainflux/pkg/transformers/senml"
+	"github.com/mainflux/mainflux/pkg/uuid"
+	"github.com/mainflux/mainflux/rules"
+	"github.com/mainflux/mainflux/rules/api"
+	"github.com/mainflux/mainflux/rules/mocks"
+	"github.com/mainflux/mainflux/things"
+	thingsapi "github.com/mainflux/mainflux/things/api/things/http"
+	thmocks "github.com/mainflux/mainflux/things/mocks"
+	"github.com/opentracing/opentracing-go/mocktracer"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const (
+	token       = "token"
+	wrongValue  = "wrong-value"
+	email       = "user@example.com"
+	contentType = "application/json"
+)
+
+type testRequest struct {
+	client      *http.Client
+	method      string
+	url         string
+	contentType string
+	token       string
+	body        io.Reader
+}
+
+func (tr testRequest) make() (*http.Response, error) {
+	req, err := http.NewRequest(tr.method, tr.url, tr.body)
+	if err != nil {
+		return nil, err
+	}
+	if tr.token != "" {
+		req.Header.Set("Authorization", tr.token)
+	}
+	if tr.contentType != "" {
+		req.Header.Set("Content-Type", tr.contentType)
+	}
+	return tr.client.Do(req)
+}
+
+func newService(t *testing.T) (rules.Service, string) {
+	tokens := map[string]string{token: email}
+
+	conns := make(chan thmocks.Connection)
+	thingsRepo := thmocks.NewThingRepository(conns)
+	channelsRepo := thmocks.NewChannelRepository(thingsRepo, conns)
+	groupsRepo := thmocks.NewGroupRepository(thingsRepo, channelsRepo)
+	ths := things.New(thmocks.NewAuthService(tokens), thmocks.NewUsersService(map[string]map[string]string{}), thingsRepo, channelsRepo, groupsRepo, thmocks.NewChannelCache(), thmocks.NewThingCache(), uuid.NewMock())
+
+	server := httptest.NewServer(thingsapi.MakeHandler(mocktracer.New(), ths))
+	t.Cleanup(server.Close)
+
+	chs, err := ths.CreateChannels(context.Background(), token, things.Channel{Name: "src"})
+	require.Nil(t, err, fmt.Sprintf("unexpected error: %s", err))
+
+	sdk := mfsdk.NewSDK(mfsdk.Config{BaseURL: server.URL})
+	svc := rules.New(
+		mocks.NewAuthNServiceClient(tokens),
+		mocks.NewRuleRepository(),
+		mocks.NewAlarmRepository(),
+		sdk,
+		mocks.NewPublisher(make(chan messaging.Message, 1)),
+		mocks.NewNotifier(make(chan rules.Alarm, 1)),
+		senml.New(senml.JSON),
+		uuid.NewMock(),
+	)
+
+	return svc, chs[0].ID
+}
+
+func newServer(svc rules.Service) *httptest.Server {
+	return httptest.NewServer(api.MakeHandler(svc))
+}
+
+func toJSON(data interface{}) string {
+	jsonData, _ := json.Marshal(data)
+	return string(jsonData)
+}
+
+func ruleBody(channel, duration string) string {
+	return toJSON(map[string]interface{}{
+		"channel": channel,
+		"name":    "overheating",
+		"conditions": []map[string]interface{}{
+			{"field": "value", "operator": "gt", "threshold": 30},
+		},
+		"window":  map[string]interface{}{"count": 3, "duration": duration},
+		"actions": []map[string]interface{}{{"type": "alarm"}},
+	})
+}
+
+func TestCreateRule(t *testing.T) {
+	svc, chID := newService(t)
+	ts := newServer(svc)
+	defer ts.Close()
+
+	cases := []struct {
+		desc        string
+		body        string
+		contentType string
+		token       string
+		status      int
+		location    string
+	}{
+		{
+			desc:        "create valid rule",
+			body:        ruleBody(chID, "1m"),
+			contentType: contentType,
+			token:       token,
+			status:      http.StatusCreated,
+			location:    fmt.Sprintf("/rules/%s%012d", uuid.Prefix, 1),
+		},
+		{
+			desc:        "create rule with invalid token",
+			body:        ruleBody(chID, "1m"),
+			contentType: contentType,
+			token:       wrongValue,
+			status:      http.StatusForbidden,
+		},
+		{
+			desc:        "create rule with empty token",
+			body:        ruleBody(chID, "1m"),
+			contentType: contentType,
+			token:       "",
+			status:      http.StatusForbidden,
+		},
+		{
+			desc:        "create rule for non-existing channel",
+			body:        ruleBody(wrongValue, "1m"),
+			contentType: contentType,
+			token:       token,
+			status:      http.StatusNotFound,
+		},
+		{
+			desc:        "create rule with invalid window duration",
+			body:        ruleBody(chID, "one minute"),
+			contentType: contentType,
+			token:       token,
+			status:      http.StatusBadRequest,
+		},
+		{
+			desc:        "create rule without channel",
+			body:        ruleBody("", "1m"),
+			contentType: contentType,
+			token:       token,
+			status:      http.StatusBadRequest,
+		},
+		{
+			desc:        "create rule with invalid JSON",
+			body:        "{",
+			contentType: contentType,
+			token:       token,
+			status:      http.StatusBadRequest,
+		},
+		{
+			desc:        "create rule with invalid content type",
+			body:        ruleBody(chID, "1m"),
+			contentType: "text/plain",
+			token:       token,
+			status:      http.StatusUnsupportedMediaType,
+		},
+	}
+
+	for _, tc := range cases {
+		req := testRequest{
+			client:      ts.Client(),
+			method:      http.MethodPost,
+			url:         fmt.Sprintf("%s/rules", ts.URL),
+			contentType: tc.contentType,
+			token:       tc.token,
+			body:        strings.NewReader(tc.body),
+		}
+		res, err := req.make()
+		assert.Nil(t, err, fmt.Sprintf("%s: unexpected error %s", tc.desc, err))
+		assert.Equal(t, tc.status, res.StatusCode, fmt.Sprintf("%s: expected status code %d got %d", tc.desc, tc.status, res.StatusCode))
+		location := res.Header.Get("Location")
+		assert.Equal(t, tc.location, location, fmt.Sprintf("%s: expected location %s got %s", tc.desc, tc.location, location))
+	}
+}
+
+func TestViewRule(t *testing.T) {
+	svc, chID := newService(t)
+	ts := newServer(svc)
+	defer ts.Close()
+
+	rule := rules.Rule{
+		Channel:    chID,
+		Conditions: []rules.Condition{{Field: rules.FieldValue, Operator: rules.OperatorGreaterThan, Threshold: 30}},
+		Actions:    []rules.Action{{Type: rules.ActionAlarm}},
+	}
+	saved, err := svc.CreateRule(context.Background(), token, rule)
+	require.Nil(t, err, fmt.Sprintf("unexpected error: %s", err))
+
+	cases := []struct {
+		desc   string
+		id     string
+		token  string
+		status int
+	}{
+		{
+			desc:   "view existing rule",
+			id:     saved.ID,
+			token:  token,
+			status: http.StatusOK,
+		},
+		{
+			desc:   "view non-existing rule",
+			id:     wrongValue,
+			token:  token,
+			status: http.StatusNotFound,
+		},
+		{
+			desc:   "view rule with invalid token",
+			id:     saved.ID,
+			token:  wrongValue,
+			status: http.StatusForbidden,
+		},
+	}
+
+	for _, tc := range cases {
+		req := testRequest{
+			client: ts.Client(),
+			method: http.MethodGet,
+			url:    fmt.Sprintf("%s/rules/%s", ts.URL, tc.id),
+			token:  tc.token,
+		}
+		res, err := req.make()
+		assert.Nil(t, err, fmt.Sprintf("%s: unexpected error %s", tc.desc, err))
+		assert.Equal(t, tc.status, res.StatusCode, fmt.Sprintf("%s: expected status code %d got %d", tc.desc, tc.status, res.StatusCode))
+	}
+}
+
+func TestUpdateRule(t *testing.T) {
+	svc, chID := newService(t)
+	ts := newServer(svc)
+	defer ts.Close()
+
+	rule := rules.Rule{
+		Channel:    chID,
+		Conditions: []rules.Condition{{Field: rules.FieldValue, Operator: rules.OperatorGreaterThan, Threshold: 30}},
+		Actions:    []rules.Action{{Type: rules.ActionAlarm}},
+	}
+	saved, err := svc.CreateRule(context.Background(), token, rule)
+	require.Nil(t, err, fmt.Sprintf("unexpected error: %s", err))
+
+	cases := []struct {
+		desc   string
+		id     string
+		body   string
+		token  string
+		status int
+	}{
+		{
+			desc:   "update existing rule",
+			id:     saved.ID,
+			body:   ruleBody(chID, "5m"),
+			token:  token,
+			status: http.StatusOK,
+		},
+		{
+			desc:   "update non-existing rule",
+			id:     wrongValue,
+			body:   ruleBody(chID, "5m"),
+			token:  token,
+			status: http.StatusNotFound,
+		},
+		{
+			desc:   "update rule with invalid token",
+			id:     saved.ID,
+			body:   ruleBody(chID, "5m"),
+			token:  wrongValue,
+			status: http.StatusForbidden,
+		},
+		{
+			desc:   "update rule with negative window duration",
+			id:     saved.ID,
+			body:   ruleBody(chID, "-5m"),
+			token:  token,
+			status: http.StatusBadRequest,
+		},
+	}
+
+	for _, tc := range cases {
+		req := testRequest{
+			client:      ts.Client(),
+			method:      http.MethodPut,
+			url:         fmt.Sprintf("%s/rules/%s", ts.URL, tc.id),
+			contentType: contentType,
+			token:       tc.token,
+			body:        strings.NewReader(tc.body),
+		}
+		res, err := req.make()
+		assert.Nil(t, err, fmt.Sprintf("%s: unexpected error %s", tc.desc, err))
+		assert.Equal(t, tc.status, res.StatusCode, fmt.Sprintf("%s: expected status code %d got %d", tc.desc, tc.status, res.StatusCode))
+	}
+
+	req := testRequest{
+		client: ts.Client(),
+		method: http.MethodGet,
+		url:    fmt.Sprintf("%s/rules/%s", ts.URL, saved.ID),
+		token:  token,
+	}
+	res, err := req.make()
+	require.Nil(t, err, fmt.Sprintf("unexpected error %s", err))
+	body, err := ioutil.ReadAll(res.Body)
+	require.Nil(t, err, fmt.Sprintf("unexpected error %s", err))
+	assert.Contains(t, string(body), `"duration":"5m0s"`, fmt.Sprintf("expected updated window got %s", body))
+}
+
+func TestListRules(t *testing.T) {
+	svc, chID := newService(t)
+	ts := newServer(svc)
+	defer ts.Close()
+
+	rule := rules.Rule{
+		Channel:    chID,
+		Conditions: []rules.Condition{{Field: rules.FieldValue, Operator: rules.OperatorGreaterThan, Threshold: 30}},
+		Actions:    []rules.Action{{Type: rules.ActionAlarm}},
+	}
+	n := 5
+	for i := 0; i < n; i++ {
+		_, err := svc.CreateRule(context.Background(), token, rule)
+		require.Nil(t, err, fmt.Sprintf("unexpected error: %s", err))
+	}
+
+	cases := []struct {
+		desc   string
+		url    string
+		token  string
+		status int
+		size   int
+	}{
+		{
+			desc:   "list rules",
+			url:    fmt.Sprintf("%s/rules", ts.URL),
+			token:  token,
+			status: http.StatusOK,
+			size:   n,
+		},
+		{
+			desc:   "list rules with offset and limit",
+			url:    fmt.Sprintf("%s/rules?offset=1&limit=2", ts.URL),
+			token:  token,
+			status: http.StatusOK,
+			size:   2,
+		},
+		{
+			desc:   "list rules with limit exceeding maximum",
+			url:    fmt.Sprintf("%s/rules?limit=1000", ts.URL),
+			token:  token,
+			status: http.StatusBadRequest,
+		},
+		{
+			desc:   "list rules with invalid offset",
+			url:    fmt.Sprintf("%s/rules?offset=e", ts.URL),
+			token:  token,
+			status: http.StatusBadRequest,
+		},
+		{
+			desc:   "list rules with invalid token",
+			url:    fmt.Sprintf("%s/rules", ts.URL),
+			token:  wrongValue,
+			status: http.StatusForbidden,
+		},
+	}
+
+	for _, tc := range cases {
+		req := testRequest{
+			client: ts.Client(),
+			method: http.MethodGet,
+			url:    tc.url,
+			token:  tc.token,
+		}
+		res, err := req.make()
+		assert.Nil(t, err, fmt.Sprintf("%s: unexpected error %s", tc.desc, err))
+		assert.Equal(t, tc.status, res.StatusCode, fmt.Sprintf("%s: expected status code %d got %d", tc.desc, tc.status, res.StatusCode))
+		if tc.status != http.StatusOK {
+			continue
+		}
+
+		var page struct {
+			Rules []interface{} `json:"rules"`
+		}
+		err = json.NewDecoder(res.Body).Decode(&page)
+		assert.Nil(t, err, fmt.Sprintf("%s: unexpected error %s", tc.desc, err))
+		assert.Equal(t, tc.size, len(page.Rules), fmt.Sprintf("%s: expected %d rules got %d", tc.desc, tc.size, len(page.Rules)))
+	}
+}
+
+func TestRemoveRule(t *testing.T) {
+	svc, chID := newService(t)
+	ts := newServer(svc)
+	defer ts.Close()
+
+	rule := rules.Rule{
+		Channel:    chID,
+		Conditions: []rules.Condition{{Field: rules.FieldValue, Operator: rules.OperatorGreaterThan, Threshold: 30}},
+		Actions:    []rules.Action{{Type: rules.ActionAlarm}},
+	}
+	saved, err := svc.CreateRule(context.Background(), token, rule)
+	require.Nil(t, err, fmt.Sprintf("unexpected error: %s", err))
+
+	cases := []struct {
+		desc   string
+		id     string
+		token  string
+		status int
+	}{
+		{
+			desc:   "remove rule with invalid token",
+			id:     saved.ID,
+			token:  wrongValue,
+			status: http.StatusForbidden,
+		},
+		{
+			desc:   "remove existing rule",
+			id:     saved.ID,
+			token:  token,
+			status: http.StatusNoContent,
+		},
+		{
+			desc:   "remove removed rule",
+			id:     saved.ID,
+			token:  token,
+			status: http.StatusNoContent,
+		},
+	}
+
+	for _, tc := range cases {
+		req := testRequest{
+			client: ts.Client(),
+			method: http.MethodDelete,
+			url:    fmt.Sprintf("%s/rules/%s", ts.URL, tc.id),
+			token:  tc.token,
+		}
+		res, err := req.make()
+		assert.Nil(t, err, fmt.Sprintf("%s: unexpected error %s", tc.desc, err))
+		assert.Equal(t, tc.status, res.StatusCode, fmt.Sprintf("%s: expected status code %d got %d", tc.desc, tc.status, res.StatusCode))
+	}
+}
+
+func TestListAlarms(t *testing.T) {
+	svc, chID := newService(t)
+	ts := newServer(svc)
+	defer ts.Close()
+
+	rule := rules.Rule{
+		Channel:    chID,
+		Conditions: []rules.Condition{{Field: rules.FieldValue, Operator: rules.OperatorGreaterThan, Threshold: 30}},
+		Actions:    []rules.Action{{Type: rules.ActionAlarm}},
+	}
+	saved, err := svc.CreateRule(context.Background(), token, rule)
+	require.Nil(t, err, fmt.Sprintf("unexpected error: %s", err))
+
+	msg := messaging.Message{
+		Channel:  chID,
+		Protocol: "http",
+		Payload:  []byte(`[{"n":"temperature","v":35},{"n":"temperature","v":36}]`),
+	}
+	err = svc.Consume(msg)
+	require.Nil(t, err, fmt.Sprintf("unexpected error: %s", err))
+
+	cases := []struct {
+		desc   string
+		url    string
+		token  string
+		status int
+		size   int
+	}{
+		{
+			desc:   "list alarms",
+			url:    fmt.Sprintf("%s/alarms", ts.URL),
+			token:  token,
+			status: http.StatusOK,
+			size:   2,
+		},
+		{
+			desc:   "list alarms by rule",
+			url:    fmt.Sprintf("%s/alarms?rule=%s", ts.URL, saved.ID),
+			token:  token,
+			status: http.StatusOK,
+			size:   2,
+		},
+		{
+			desc:   "list alarms by non-existing rule",
+			url:    fmt.Sprintf("%s/alarms?rule=%s", ts.URL, wrongValue),
+			token:  token,
+			status: http.StatusOK,
+			size:   0,
+		},
+		{
+			desc:   "list alarms with invalid limit",
+			url:    fmt.Sprintf("%s/alarms?limit=0", ts.URL),
+			token:  token,
+			status: http.StatusBadRequest,
+		},
+		{
+			desc:   "list alarms with invalid token",
+			url:    fmt.Sprintf("%s/alarms", ts.URL),
+			token:  wrongValue,
+			status: http.StatusForbidden,
+		},
+	}
+
+	for _, tc := range cases {
+		req := testRequest{
+			client: ts.Client(),
+			method: http.MethodGet,
+			url:    tc.url,
+			token:  tc.token,
+		}
+		res, err := req.make()
+		assert.Nil(t, err, fmt.Sprintf("%s: unexpected error %s", tc.desc, err))
+		assert.Equal(t, tc.status, res.StatusCode, fmt.Sprintf("%s: expected status code %d got %d", tc.desc, tc.status, res.StatusCode))
+		if tc.status != http.StatusOK {
+			continue
+		}
+
+		var page struct {
+			Alarms []rules.Alarm `json:"alarms"`
+		}
+		err = json.NewDecoder(res.Body).Decode(&page)
+		assert.Nil(t, err, fmt.Sprintf("%s: unexpected error %s", tc.desc, err))
+		assert.Equal(t, tc.size, len(page.Alarms), fmt.Sprintf("%s: expected %d alarms got %d", tc.desc, tc.size, len(page.Alarms)))
+	}
+}
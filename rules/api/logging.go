@@ -0,0 +1,119 @@
+// Copyright (c) Mainflux
+// SPDX-License-Identifier: Apache-2.0
+
+// +build !test
+
+package api
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	log "github.com/mainflux/mainflux/logger"
+	"github.com/mainflux/mainflux/pkg/messaging"
+	"github.com/mainflux/mainflux/rules"
+)
+
+var _ rules.Service = (*loggingMiddleware)(nil)
+
+type loggingMiddleware struct {
+	logger log.Logger
+	svc    rules.Service
+}
+
+// LoggingMiddleware adds logging facilities to the core service.
+func LoggingMiddleware(svc rules.Service, logger log.Logger) rules.Service {
+	return &loggingMiddleware{logger, svc}
+}
+
+func (lm *loggingMiddleware) CreateRule(ctx context.Context, token string, rule rules.Rule) (saved rules.Rule, err error) {
+	defer func(begin time.Time) {
+		message := fmt.Sprintf("Method create_rule for token %s and channel %s took %s to complete", token, rule.Channel, time.Since(begin))
+		if err != nil {
+			lm.logger.Warn(fmt.Sprintf("%s with error: %s.", message, err))
+			return
+		}
+		lm.logger.Info(fmt.Sprintf("%s without errors.", message))
+	}(time.Now())
+
+	return lm.svc.CreateRule(ctx, token, rule)
+}
+
+func (lm *loggingMiddleware) ViewRule(ctx context.Context, token, id string) (rule rules.Rule, err error) {
+	defer func(begin time.Time) {
+		message := fmt.Sprintf("Method view_rule for token %s and rule %s took %s to complete", token, id, time.Since(begin))
+		if err != nil {
+			lm.logger.Warn(fmt.Sprintf("%s with error: %s.", message, err))
+			return
+		}
+		lm.logger.Info(fmt.Sprintf("%s without errors.", message))
+	}(time.Now())
+
+	return lm.svc.ViewRule(ctx, token, id)
+}
+
+func (lm *loggingMiddleware) UpdateRule(ctx context.Context, token string, rule rules.Rule) (err error) {
+	defer func(begin time.Time) {
+		message := fmt.Sprintf("Method update_rule for token %s and rule %s took %s to complete", token, rule.ID, time.Since(begin))
+		if err != nil {
+			lm.logger.Warn(fmt.Sprintf("%s with error: %s.", message, err))
+			return
+		}
+		lm.logger.Info(fmt.Sprintf("%s without errors.", message))
+	}(time.Now())
+
+	return lm.svc.UpdateRule(ctx, token, rule)
+}
+
+func (lm *loggingMiddleware) ListRules(ctx context.Context, token string, offset, limit uint64) (page rules.RulesPage, err error) {
+	defer func(begin time.Time) {
+		message := fmt.Sprintf("Method list_rules for token %s took %s to complete", token, time.Since(begin))
+		if err != nil {
+			lm.logger.Warn(fmt.Sprintf("%s with error: %s.", message, err))
+			return
+		}
+		lm.logger.Info(fmt.Sprintf("%s without errors.", message))
+	}(time.Now())
+
+	return lm.svc.ListRules(ctx, token, offset, limit)
+}
+
+func (lm *loggingMiddleware) RemoveRule(ctx context.Context, token, id string) (err error) {
+	defer func(begin time.Time) {
+		message := fmt.Sprintf("Method remove_rule for token %s and rule %s took %s to complete", token, id, time.Since(begin))
+		if err != nil {
+			lm.logger.Warn(fmt.Sprintf("%s with error: %s.", message, err))
+			return
+		}
+		lm.logger.Info(fmt.Sprintf("%s without errors.", message))
+	}(time.Now())
+
+	return lm.svc.RemoveRule(ctx, token, id)
+}
+
+func (lm *loggingMiddleware) ListAlarms(ctx context.Context, token, ruleID string, offset, limit uint64) (page rules.AlarmsPage, err error) {
+	defer func(begin time.Time) {
+		message := fmt.Sprintf("Method list_alarms for token %s took %s to complete", token, time.Since(begin))
+		if err != nil {
+			lm.logger.Warn(fmt.Sprintf("%s with error: %s.", message, err))
+			return
+		}
+		lm.logger.Info(fmt.Sprintf("%s without errors.", message))
+	}(time.Now())
+
+	return lm.svc.ListAlarms(ctx, token, ruleID, offset, limit)
+}
+
+func (lm *loggingMiddleware) Consume(msg messaging.Message) (err error) {
+	defer func(begin time.Time) {
+		message := fmt.Sprintf("Method consume for channel %s took %s to complete", msg.Channel, time.Since(begin))
+		if err != nil {
+			lm.logger.Warn(fmt.Sprintf("%s with error: %s.", message, err))
+			return
+		}
+		lm.logger.Info(fmt.Sprintf("%s without errors.", message))
+	}(time.Now())
+
+	return lm.svc.Consume(msg)
+}
@@ -0,0 +1,107 @@
+// Copyright (c) Mainflux
+// SPDX-License-Identifier: Apache-2.0
+
+package api
+
+import (
+	"time"
+
+	"github.com/mainflux/mainflux/pkg/errors"
+	"github.com/mainflux/mainflux/rules"
+)
+
+const maxNameSize = 1024
+
+type apiReq interface {
+	validate() error
+}
+
+type windowReq struct {
+	Count    uint64 `json:"count"`
+	Duration string `json:"duration"`
+}
+
+type ruleReq struct {
+	token      string
+	id         string
+	Channel    string            `json:"channel"`
+	Name       string            `json:"name"`
+	Conditions []rules.Condition `json:"conditions"`
+	Window     windowReq         `json:"window"`
+	Actions    []rules.Action    `json:"actions"`
+}
+
+func (req ruleReq) validate() error {
+	if req.token == "" {
+		return rules.ErrUnauthorizedAccess
+	}
+
+	if len(req.Name) > maxNameSize {
+		return rules.ErrMalformedEntity
+	}
+
+	rule, err := req.rule()
+	if err != nil {
+		return err
+	}
+
+	return rule.Validate()
+}
+
+func (req ruleReq) rule() (rules.Rule, error) {
+	var d time.Duration
+	if req.Window.Duration != "" {
+		var err error
+		if d, err = time.ParseDuration(req.Window.Duration); err != nil {
+			return rules.Rule{}, errors.Wrap(rules.ErrMalformedEntity, err)
+		}
+	}
+
+	return rules.Rule{
+		ID:         req.id,
+		Channel:    req.Channel,
+		Name:       req.Name,
+		Conditions: req.Conditions,
+		Window: rules.Window{
+			Count:    req.Window.Count,
+			Duration: d,
+		},
+		Actions: req.Actions,
+	}, nil
+}
+
+type viewRuleReq struct {
+	token string
+	id    string
+}
+
+func (req viewRuleReq) validate() error {
+	if req.token == "" {
+		return rules.ErrUnauthorizedAccess
+	}
+
+	if req.id == "" {
+		return rules.ErrMalformedEntity
+	}
+
+	return nil
+}
+
+type listReq struct {
+	token  string
+	ruleID string
+	offset uint64
+	limit  uint64
+}
+
+func (req listReq) validate() error {
+	if req.token == "" {
+		return rules.ErrUnauthorizedAccess
+	}
+
+	if req.limit == 0 || req.limit > maxLimit {
+		return rules.ErrMalformedEntity
+	}
+
+	return nil
+}
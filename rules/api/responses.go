@@ -0,0 +1,133 @@
+// Copyright (c) Mainflux
+// SPDX-License-Identifier: Apache-2.0
+
+package api
+
+import (
+	"fmt"
+	"net/http"
+	"time"
+
+	"github.com/mainflux/mainflux"
+	"github.com/mainflux/mainflux/rules"
+)
+
+var (
+	_ mainflux.Response = (*ruleRes)(nil)
+	_ mainflux.Response = (*viewRuleRes)(nil)
+	_ mainflux.Response = (*rulesPageRes)(nil)
+	_ mainflux.Response = (*alarmsPageRes)(nil)
+	_ mainflux.Response = (*removeRes)(nil)
+)
+
+type ruleRes struct {
+	id      string
+	created bool
+}
+
+func (res ruleRes) Code() int {
+	if res.created {
+		return http.StatusCreated
+	}
+
+	return http.StatusOK
+}
+
+func (res ruleRes) Headers() map[string]string {
+	if res.created {
+		return map[string]string{
+			"Location": fmt.Sprintf("/rules/%s", res.id),
+		}
+	}
+
+	return map[string]string{}
+}
+
+func (res ruleRes) Empty() bool {
+	return true
+}
+
+type windowRes struct {
+	Count    uint64 `json:"count,omitempty"`
+	Duration string `json:"duration,omitempty"`
+}
+
+type viewRuleRes struct {
+	ID         string            `json:"id"`
+	Channel    string            `json:"channel"`
+	Name       string            `json:"name,omitempty"`
+	Conditions []rules.Condition `json:"conditions"`
+	Window     windowRes         `json:"window"`
+	Actions    []rules.Action    `json:"actions"`
+	Created    time.Time         `json:"created"`
+}
+
+func (res viewRuleRes) Code() int {
+	return http.StatusOK
+}
+
+func (res viewRuleRes) Headers() map[string]string {
+	return map[string]string{}
+}
+
+func (res viewRuleRes) Empty() bool {
+	return false
+}
+
+type pageRes struct {
+	Total  uint64 `json:"total"`
+	Offset uint64 `json:"offset"`
+	Limit  uint64 `json:"limit"`
+}
+
+type rulesPageRes struct {
+	pageRes
+	Rules []viewRuleRes `json:"rules"`
+}
+
+func (res rulesPageRes) Code() int {
+	return http.StatusOK
+}
+
+func (res rulesPageRes) Headers() map[string]string {
+	return map[string]string{}
+}
+
+func (res rulesPageRes) Empty() bool {
+	return false
+}
+
+type alarmsPageRes struct {
+	pageRes
+	Alarms []rules.Alarm `json:"alarms"`
+}
+
+func (res alarmsPageRes) Code() int {
+	return http.StatusOK
+}
+
+func (res alarmsPageRes) Headers() map[string]string {
+	return map[string]string{}
+}
+
+func (res alarmsPageRes) Empty() bool {
+	return false
+}
+
+type removeRes struct{}
+
+func (res removeRes) Code() int {
+	return http.StatusNoContent
+}
+
+func (res removeRes) Headers() map[string]string {
+	return map[string]string{}
+}
+
+func (res removeRes) Empty() bool {
+	return true
+}
+
+type errorRes struct {
+	Err string `json:"error"`
+}
@@ -0,0 +1,208 @@
+// Copyright (c) Mainflux
+// SPDX-License-Identifier: Apache-2.0
+
+package api
+
+import (
+	"context"
+	"encoding/json"
+	"io"
+	"net/http"
+	"strconv"
+	"strings"
+
+	kithttp "github.com/go-kit/kit/transport/http"
+	"github.com/go-zoo/bone"
+	"github.com/mainflux/mainflux"
+	"github.com/mainflux/mainflux/pkg/audit"
+	"github.com/mainflux/mainflux/pkg/errors"
+	"github.com/mainflux/mainflux/rules"
+	"github.com/prometheus/client_golang/prometheus/promhttp"
+)
+
+const (
+	contentType  = "application/json"
+	offsetKey    = "offset"
+	limitKey     = "limit"
+	ruleKey      = "rule"
+	maxLimit     = 100
+	defaultLimit = 10
+)
+
+var (
+	errUnsupportedContentType = errors.New("unsupported content type")
+	errInvalidQueryParams     = errors.New("invalid query params")
+)
+
+// MakeHandler returns a HTTP handler for API endpoints.
+func MakeHandler(svc rules.Service) http.Handler {
+	opts := []kithttp.ServerOption{
+		kithttp.ServerErrorEncoder(encodeError),
+		kithttp.ServerBefore(audit.PopulateIP),
+	}
+
+	r := bone.New()
+
+	r.Post("/rules", kithttp.NewServer(
+		createRuleEndpoint(svc),
+		decodeRuleRequest,
+		encodeResponse,
+		opts...,
+	))
+
+	r.Get("/rules", kithttp.NewServer(
+		listRulesEndpoint(svc),
+		decodeListRequest,
+		encodeResponse,
+		opts...,
+	))
+
+	r.Get("/rules/:id", kithttp.NewServer(
+		viewRuleEndpoint(svc),
+		decodeViewRequest,
+		encodeResponse,
+		opts...,
+	))
+
+	r.Put("/rules/:id", kithttp.NewServer(
+		updateRuleEndpoint(svc),
+		decodeRuleRequest,
+		encodeResponse,
+		opts...,
+	))
+
+	r.Delete("/rules/:id", kithttp.NewServer(
+		removeRuleEndpoint(svc),
+		decodeViewRequest,
+		encodeResponse,
+		opts...,
+	))
+
+	r.Get("/alarms", kithttp.NewServer(
+		listAlarmsEndpoint(svc),
+		decodeListRequest,
+		encodeResponse,
+		opts...,
+	))
+
+	r.GetFunc("/version", mainflux.Version("rules"))
+	r.Handle("/metrics", promhttp.Handler())
+
+	return r
+}
+
+func decodeRuleRequest(_ context.Context, r *http.Request) (interface{}, error) {
+	if !strings.Contains(r.Header.Get("Content-Type"), contentType) {
+		return nil, errUnsupportedContentType
+	}
+
+	req := ruleReq{
+		token: r.Header.Get("Authorization"),
+		id:    bone.GetValue(r, "id"),
+	}
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		return nil, errors.Wrap(rules.ErrMalformedEntity, err)
+	}
+
+	return req, nil
+}
+
+func decodeViewRequest(_ context.Context, r *http.Request) (interface{}, error) {
+	req := viewRuleReq{
+		token: r.Header.Get("Authorization"),
+		id:    bone.GetValue(r, "id"),
+	}
+
+	return req, nil
+}
+
+func decodeListRequest(_ context.Context, r *http.Request) (interface{}, error) {
+	offset, err := readUintQuery(r, offsetKey, 0)
+	if err != nil {
+		return nil, err
+	}
+
+	limit, err := readUintQuery(r, limitKey, defaultLimit)
+	if err != nil {
+		return nil, err
+	}
+
+	req := listReq{
+		token:  r.Header.Get("Authorization"),
+		ruleID: r.URL.Query().Get(ruleKey),
+		offset: offset,
+		limit:  limit,
+	}
+
+	return req, nil
+}
+
+func readUintQuery(r *http.Request, key string, def uint64) (uint64, error) {
+	vals := bone.GetQuery(r, key)
+	if len(vals) > 1 {
+		return 0, errInvalidQueryParams
+	}
+
+	if len(vals) == 0 {
+		return def, nil
+	}
+
+	val, err := strconv.ParseUint(vals[0], 10, 64)
+	if err != nil {
+		return 0, errors.Wrap(errInvalidQueryParams, err)
+	}
+
+	return val, nil
+}
+
+func encodeResponse(_ context.Context, w http.ResponseWriter, response interface{}) error {
+	w.Header().Set("Content-Type", contentType)
+
+	if ar, ok := response.(mainflux.Response); ok {
+		for k, v := range ar.Headers() {
+			w.Header().Set(k, v)
+		}
+
+		w.WriteHeader(ar.Code())
+
+		if ar.Empty() {
+			return nil
+		}
+	}
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+func encodeError(_ context.Context, err error, w http.ResponseWriter) {
+	switch errorVal := err.(type) {
+	case errors.Error:
+		w.Header().Set("Content-Type", contentType)
+		switch {
+		case errors.Contains(errorVal, errUnsupportedContentType):
+			w.WriteHeader(http.StatusUnsupportedMediaType)
+		case errors.Contains(errorVal, errInvalidQueryParams):
+			w.WriteHeader(http.StatusBadRequest)
+		case errors.Contains(errorVal, rules.ErrMalformedEntity):
+			w.WriteHeader(http.StatusBadRequest)
+		case errors.Contains(errorVal, rules.ErrNotFound):
+			w.WriteHeader(http.StatusNotFound)
+		case errors.Contains(errorVal, rules.ErrUnauthorizedAccess):
+			w.WriteHeader(http.StatusForbidden)
+		case errors.Contains(errorVal, rules.ErrConflict):
+			w.WriteHeader(http.StatusConflict)
+		case errors.Contains(errorVal, io.EOF):
+			w.WriteHeader(http.StatusBadRequest)
+		case errors.Contains(errorVal, io.ErrUnexpectedEOF):
+			w.WriteHeader(http.StatusBadRequest)
+		default:
+			w.WriteHeader(http.StatusInternalServerError)
+		}
+		if errorVal.Msg() != "" {
+			if err := json.NewEncoder(w).Encode(errorRes{Err: errorVal.Msg()}); err != nil {
+				w.WriteHeader(http.StatusInternalServerError)
+			}
+		}
+	default:
+		w.WriteHeader(http.StatusInternalServerError)
+	}
+}
@@ -0,0 +1,10 @@
+// Copyright (c) Mainflux
+// SPDX-License-Identifier: Apache-2.0
+
+// Package rules contains the domain concept definitions needed to support
+// Mainflux rules engine service functionality. Rule is a user defined set of
+// conditions evaluated against SenML records received on a channel. Once the
+// conditions are met the required number of times within the rule window,
+// rule actions are fired: records are republished to another channel, sent
+// to a webhook or stored as an alarm.
+package rules
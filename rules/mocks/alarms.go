@@ -0,0 +1,55 @@
+// Copyright (c) Mainflux
+// SPDX-License-Identifier: Apache-2.0
+
+package mocks
+
+import (
+	"context"
+	"sort"
+	"sync"
+
+	"github.com/mainflux/mainflux/rules"
+)
+
+var _ rules.AlarmRepository = (*alarmRepositoryMock)(nil)
+
+type alarmRepositoryMock struct {
+	mu     sync.Mutex
+	alarms []rules.Alarm
+}
+
+// NewAlarmRepository creates in-memory alarm repository.
+func NewAlarmRepository() rules.AlarmRepository {
+	return &alarmRepositoryMock{}
+}
+
+func (arm *alarmRepositoryMock) Save(_ context.Context, alarm rules.Alarm) (string, error) {
+	arm.mu.Lock()
+	defer arm.mu.Unlock()
+
+	arm.alarms = append(arm.alarms, alarm)
+	return alarm.ID, nil
+}
+
+func (arm *alarmRepositoryMock) RetrieveAll(_ context.Context, owner, ruleID string, offset, limit uint64) (rules.AlarmsPage, error) {
+	arm.mu.Lock()
+	defer arm.mu.Unlock()
+
+	items := []rules.Alarm{}
+	for _, alarm := range arm.alarms {
+		if alarm.Owner == owner && (ruleID == "" || alarm.RuleID == ruleID) {
+			items = append(items, alarm)
+		}
+	}
+	sort.SliceStable(items, func(i, j int) bool {
+		return items[i].Created.After(items[j].Created)
+	})
+
+	total := uint64(len(items))
+	return rules.AlarmsPage{
+		Total:  total,
+		Offset: offset,
+		Limit:  limit,
+		Alarms: items[min(offset, total):min(offset+limit, total)],
+	}, nil
+}
@@ -0,0 +1,59 @@
+// Copyright (c) Mainflux
+// SPDX-License-Identifier: Apache-2.0
+
+package mocks
+
+import (
+	"context"
+
+	"github.com/golang/protobuf/ptypes/empty"
+	"github.com/mainflux/mainflux"
+	"github.com/mainflux/mainflux/rules"
+	"google.golang.org/grpc"
+)
+
+var _ mainflux.AuthNServiceClient = (*authNServiceClient)(nil)
+
+type authNServiceClient struct {
+	users map[string]string
+}
+
+// NewAuthNServiceClient creates mock of authn service.
+func NewAuthNServiceClient(users map[string]string) mainflux.AuthNServiceClient {
+	return &authNServiceClient{users}
+}
+
+func (svc authNServiceClient) Identify(ctx context.Context, in *mainflux.Token, opts ...grpc.CallOption) (*mainflux.UserIdentity, error) {
+	if id, ok := svc.users[in.Value]; ok {
+		return &mainflux.UserIdentity{Id: id, Email: id}, nil
+	}
+	return nil, rules.ErrUnauthorizedAccess
+}
+
+func (svc authNServiceClient) Issue(ctx context.Context, in *mainflux.IssueReq, opts ...grpc.CallOption) (*mainflux.Token, error) {
+	return new(mainflux.Token), nil
+}
+
+func (svc authNServiceClient) RevokeToken(ctx context.Context, in *mainflux.Token, opts ...grpc.CallOption) (*empty.Empty, error) {
+	panic("not implemented")
+}
+
+func (svc authNServiceClient) RevokeSessions(ctx context.Context, in *mainflux.Token, opts ...grpc.CallOption) (*empty.Empty, error) {
+	panic("not implemented")
+}
+
+func (svc authNServiceClient) Refresh(ctx context.Context, in *mainflux.Token, opts ...grpc.CallOption) (*mainflux.Tokens, error) {
+	panic("not implemented")
+}
+
+func (svc authNServiceClient) VerifyChallenge(ctx context.Context, in *mainflux.Token, opts ...grpc.CallOption) (*mainflux.UserIdentity, error) {
+	panic("not implemented")
+}
+
+func (svc authNServiceClient) Impersonate(ctx context.Context, in *mainflux.ImpersonateReq, opts ...grpc.CallOption) (*mainflux.Token, error) {
+	panic("not implemented")
+}
+
+func (svc authNServiceClient) SetDisabled(ctx context.Context, in *mainflux.DisableReq, opts ...grpc.CallOption) (*empty.Empty, error) {
+	panic("not implemented")
+}
@@ -0,0 +1,53 @@
+// Copyright (c) Mainflux
+// SPDX-License-Identifier: Apache-2.0
+
+package mocks
+
+import (
+	"github.com/mainflux/mainflux/pkg/errors"
+	"github.com/mainflux/mainflux/pkg/messaging"
+	"github.com/mainflux/mainflux/rules"
+)
+
+// FailingURL is the webhook URL the mock notifier fails to notify.
+const FailingURL = "http://example.com/failing"
+
+var errNotify = errors.New("failed to notify webhook")
+
+var (
+	_ messaging.Publisher = (*publisher)(nil)
+	_ rules.Notifier      = (*notifier)(nil)
+)
+
+type publisher struct {
+	msgs chan<- messaging.Message
+}
+
+// NewPublisher returns mock message publisher that sends published messages
+// to the given channel.
+func NewPublisher(msgs chan<- messaging.Message) messaging.Publisher {
+	return &publisher{msgs: msgs}
+}
+
+func (pub publisher) Publish(_ string, msg messaging.Message) error {
+	pub.msgs <- msg
+	return nil
+}
+
+type notifier struct {
+	alarms chan<- rules.Alarm
+}
+
+// NewNotifier returns mock webhook notifier that sends alarms to the given
+// channel. Notifying FailingURL always fails.
+func NewNotifier(alarms chan<- rules.Alarm) rules.Notifier {
+	return &notifier{alarms: alarms}
+}
+
+func (n notifier) Notify(url string, alarm rules.Alarm) error {
+	if url == FailingURL {
+		return errNotify
+	}
+	n.alarms <- alarm
+	return nil
+}
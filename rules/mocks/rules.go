@@ -0,0 +1,120 @@
+// Copyright (c) Mainflux
+// SPDX-License-Identifier: Apache-2.0
+
+package mocks
+
+import (
+	"context"
+	"sort"
+	"sync"
+
+	"github.com/mainflux/mainflux/rules"
+)
+
+var _ rules.RuleRepository = (*ruleRepositoryMock)(nil)
+
+type ruleRepositoryMock struct {
+	mu    sync.Mutex
+	rules map[string]rules.Rule
+}
+
+// NewRuleRepository creates in-memory rule repository.
+func NewRuleRepository() rules.RuleRepository {
+	return &ruleRepositoryMock{
+		rules: make(map[string]rules.Rule),
+	}
+}
+
+func (rrm *ruleRepositoryMock) Save(_ context.Context, rule rules.Rule) (string, error) {
+	rrm.mu.Lock()
+	defer rrm.mu.Unlock()
+
+	if _, ok := rrm.rules[rule.ID]; ok {
+		return "", rules.ErrConflict
+	}
+
+	rrm.rules[rule.ID] = rule
+	return rule.ID, nil
+}
+
+func (rrm *ruleRepositoryMock) Update(_ context.Context, rule rules.Rule) error {
+	rrm.mu.Lock()
+	defer rrm.mu.Unlock()
+
+	old, ok := rrm.rules[rule.ID]
+	if !ok || old.Owner != rule.Owner {
+		return rules.ErrNotFound
+	}
+
+	rule.Created = old.Created
+	rrm.rules[rule.ID] = rule
+	return nil
+}
+
+func (rrm *ruleRepositoryMock) RetrieveByID(_ context.Context, owner, id string) (rules.Rule, error) {
+	rrm.mu.Lock()
+	defer rrm.mu.Unlock()
+
+	rule, ok := rrm.rules[id]
+	if !ok || rule.Owner != owner {
+		return rules.Rule{}, rules.ErrNotFound
+	}
+
+	return rule, nil
+}
+
+func (rrm *ruleRepositoryMock) RetrieveAll(_ context.Context, owner string, offset, limit uint64) (rules.RulesPage, error) {
+	rrm.mu.Lock()
+	defer rrm.mu.Unlock()
+
+	items := []rules.Rule{}
+	for _, rule := range rrm.rules {
+		if rule.Owner == owner {
+			items = append(items, rule)
+		}
+	}
+	sort.SliceStable(items, func(i, j int) bool {
+		return items[i].ID < items[j].ID
+	})
+
+	total := uint64(len(items))
+	return rules.RulesPage{
+		Total:  total,
+		Offset: offset,
+		Limit:  limit,
+		Rules:  items[min(offset, total):min(offset+limit, total)],
+	}, nil
+}
+
+func (rrm *ruleRepositoryMock) RetrieveByChannel(_ context.Context, channel string) ([]rules.Rule, error) {
+	rrm.mu.Lock()
+	defer rrm.mu.Unlock()
+
+	items := []rules.Rule{}
+	for _, rule := range rrm.rules {
+		if rule.Channel == channel {
+			items = append(items, rule)
+		}
+	}
+
+	return items, nil
+}
+
+func (rrm *ruleRepositoryMock) Remove(_ context.Context, owner, id string) error {
+	rrm.mu.Lock()
+	defer rrm.mu.Unlock()
+
+	if rule, ok := rrm.rules[id]; ok && rule.Owner == owner {
+		delete(rrm.rules, id)
+	}
+
+	return nil
+}
+
+func min(a, b uint64) uint64 {
+	if a < b {
+		return a
+	}
+
+	return b
+}
@@ -0,0 +1,131 @@
+// Copyright (c) Mainflux
+// SPDX-License-Identifier: Apache-2.0
+
+package postgres
+
+import (
+	"context"
+	"encoding/json"
+	"time"
+
+	"github.com/jmoiron/sqlx"
+	"github.com/lib/pq"
+	"github.com/mainflux/mainflux/pkg/errors"
+	"github.com/mainflux/mainflux/pkg/transformers/senml"
+	"github.com/mainflux/mainflux/rules"
+)
+
+var (
+	errSaveAlarm     = errors.New("failed to save alarm to database")
+	errRetrieveAlarm = errors.New("failed to retrieve alarms from database")
+)
+
+var _ rules.AlarmRepository = (*alarmRepository)(nil)
+
+type alarmRepository struct {
+	db *sqlx.DB
+}
+
+// NewAlarmRepository instantiates a PostgreSQL implementation of alarm
+// repository.
+func NewAlarmRepository(db *sqlx.DB) rules.AlarmRepository {
+	return &alarmRepository{db: db}
+}
+
+func (ar alarmRepository) Save(ctx context.Context, alarm rules.Alarm) (string, error) {
+	q := `INSERT INTO alarms (id, rule_id, owner, channel, records, created)
+		  VALUES (:id, :rule_id, :owner, :channel, :records, :created)`
+
+	recs, err := json.Marshal(alarm.Records)
+	if err != nil {
+		return "", errors.Wrap(errSaveAlarm, err)
+	}
+
+	dba := dbAlarm{
+		ID:      alarm.ID,
+		RuleID:  alarm.RuleID,
+		Owner:   alarm.Owner,
+		Channel: alarm.Channel,
+		Records: recs,
+		Created: alarm.Created,
+	}
+	if _, err := ar.db.NamedExecContext(ctx, q, dba); err != nil {
+		return "", errors.Wrap(errSaveAlarm, err)
+	}
+
+	return alarm.ID, nil
+}
+
+func (ar alarmRepository) RetrieveAll(ctx context.Context, owner, ruleID string, offset, limit uint64) (rules.AlarmsPage, error) {
+	cond := `owner = :owner`
+	if ruleID != "" {
+		cond = `owner = :owner AND rule_id = :rule_id`
+	}
+	params := map[string]interface{}{
+		"owner":   owner,
+		"rule_id": ruleID,
+		"limit":   limit,
+		"offset":  offset,
+	}
+
+	q := `SELECT id, rule_id, owner, channel, records, created FROM alarms
+		  WHERE ` + cond + ` ORDER BY created DESC LIMIT :limit OFFSET :offset`
+	rows, err := ar.db.NamedQueryContext(ctx, q, params)
+	if err != nil {
+		if pqErr, ok := err.(*pq.Error); ok && errInvalid == pqErr.Code.Name() {
+			return rules.AlarmsPage{Offset: offset, Limit: limit, Alarms: []rules.Alarm{}}, nil
+		}
+		return rules.AlarmsPage{}, errors.Wrap(errRetrieveAlarm, err)
+	}
+	defer rows.Close()
+
+	items := []rules.Alarm{}
+	for rows.Next() {
+		dba := dbAlarm{}
+		if err := rows.StructScan(&dba); err != nil {
+			return rules.AlarmsPage{}, errors.Wrap(errRetrieveAlarm, err)
+		}
+
+		var recs []senml.Message
+		if err := json.Unmarshal(dba.Records, &recs); err != nil {
+			return rules.AlarmsPage{}, errors.Wrap(errRetrieveAlarm, err)
+		}
+
+		items = append(items, rules.Alarm{
+			ID:      dba.ID,
+			RuleID:  dba.RuleID,
+			Owner:   dba.Owner,
+			Channel: dba.Channel,
+			Records: recs,
+			Created: dba.Created,
+		})
+	}
+
+	cq := `SELECT COUNT(*) FROM alarms WHERE ` + cond
+	stmt, err := ar.db.PrepareNamedContext(ctx, cq)
+	if err != nil {
+		return rules.AlarmsPage{}, errors.Wrap(errRetrieveAlarm, err)
+	}
+	defer stmt.Close()
+
+	var total uint64
+	if err := stmt.GetContext(ctx, &total, params); err != nil {
+		return rules.AlarmsPage{}, errors.Wrap(errRetrieveAlarm, err)
+	}
+
+	return rules.AlarmsPage{
+		Total:  total,
+		Offset: offset,
+		Limit:  limit,
+		Alarms: items,
+	}, nil
+}
+
+type dbAlarm struct {
+	ID      string    `db:"id"`
+	RuleID  string    `db:"rule_id"`
+	Owner   string    `db:"owner"`
+	Channel string    `db:"channel"`
+	Records []byte    `db:"records"`
+	Created time.Time `db:"created"`
+}
@@ -0,0 +1,109 @@
+// Copyright (c) Mainflux
+// SPDX-License-Identifier: Apache-2.0
+
+package postgres_test
+
+import (
+	"context"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/mainflux/mainflux/pkg/transformers/senml"
+	uuidProvider "github.com/mainflux/mainflux/pkg/uuid"
+	"github.com/mainflux/mainflux/rules"
+	"github.com/mainflux/mainflux/rules/postgres"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestAlarmSaveRetrieveAll(t *testing.T) {
+	repo := postgres.NewAlarmRepository(db)
+
+	owner := "alarms@example.com"
+	ruleIDs := []string{}
+	for i := 0; i < 2; i++ {
+		id, err := uuidProvider.New().ID()
+		require.Nil(t, err, fmt.Sprintf("got unexpected error: %s", err))
+		ruleIDs = append(ruleIDs, id)
+	}
+
+	v := 42.0
+	n := uint64(10)
+	start := time.Now().UTC().Truncate(time.Millisecond)
+	for i := uint64(0); i < n; i++ {
+		id, err := uuidProvider.New().ID()
+		require.Nil(t, err, fmt.Sprintf("got unexpected error: %s", err))
+
+		alarm := rules.Alarm{
+			ID:      id,
+			RuleID:  ruleIDs[i%2],
+			Owner:   owner,
+			Channel: "1",
+			Records: []senml.Message{{Name: "temperature", Value: &v}},
+			Created: start.Add(time.Duration(i) * time.Second),
+		}
+		_, err = repo.Save(context.Background(), alarm)
+		require.Nil(t, err, fmt.Sprintf("got unexpected error: %s", err))
+	}
+
+	cases := map[string]struct {
+		owner  string
+		ruleID string
+		offset uint64
+		limit  uint64
+		size   uint64
+		total  uint64
+	}{
+		"retrieve all alarms": {
+			owner:  owner,
+			offset: 0,
+			limit:  n,
+			size:   n,
+			total:  n,
+		},
+		"retrieve subset of alarms": {
+			owner:  owner,
+			offset: 2,
+			limit:  5,
+			size:   5,
+			total:  n,
+		},
+		"retrieve alarms by rule": {
+			owner:  owner,
+			ruleID: ruleIDs[0],
+			offset: 0,
+			limit:  n,
+			size:   n / 2,
+			total:  n / 2,
+		},
+		"retrieve alarms by invalid rule ID": {
+			owner:  owner,
+			ruleID: wrongValue,
+			offset: 0,
+			limit:  n,
+			size:   0,
+			total:  0,
+		},
+		"retrieve alarms of user without alarms": {
+			owner:  wrongValue,
+			offset: 0,
+			limit:  n,
+			size:   0,
+			total:  0,
+		},
+	}
+
+	for desc, tc := range cases {
+		page, err := repo.RetrieveAll(context.Background(), tc.owner, tc.ruleID, tc.offset, tc.limit)
+		assert.Nil(t, err, fmt.Sprintf("%s: expected no error got %s\n", desc, err))
+		size := uint64(len(page.Alarms))
+		assert.Equal(t, tc.size, size, fmt.Sprintf("%s: expected size %d got %d\n", desc, tc.size, size))
+		assert.Equal(t, tc.total, page.Total, fmt.Sprintf("%s: expected total %d got %d\n", desc, tc.total, page.Total))
+	}
+
+	page, err := repo.RetrieveAll(context.Background(), owner, "", 0, 1)
+	require.Nil(t, err, fmt.Sprintf("got unexpected error: %s", err))
+	latest := start.Add(time.Duration(n-1) * time.Second)
+	assert.True(t, latest.Equal(page.Alarms[0].Created), fmt.Sprintf("expected the latest alarm created at %s got %s\n", latest, page.Alarms[0].Created))
+}
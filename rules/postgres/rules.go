@@ -0,0 +1,242 @@
+// Copyright (c) Mainflux
+// SPDX-License-Identifier: Apache-2.0
+
+package postgres
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+	"time"
+
+	"github.com/jmoiron/sqlx"
+	"github.com/lib/pq"
+	"github.com/mainflux/mainflux/pkg/errors"
+	"github.com/mainflux/mainflux/rules"
+)
+
+const (
+	errDuplicate  = "unique_violation"
+	errInvalid    = "invalid_text_representation"
+	errTruncation = "string_data_right_truncation"
+)
+
+var (
+	errSaveDB    = errors.New("failed to save rule to database")
+	errUpdateDB  = errors.New("failed to update rule in database")
+	errRetrieve  = errors.New("failed to retrieve rule from database")
+	errRemoveDB  = errors.New("failed to remove rule from database")
+	errMarshal   = errors.New("failed to marshal rule into json")
+	errUnmarshal = errors.New("failed to unmarshal json to rule")
+)
+
+var _ rules.RuleRepository = (*ruleRepository)(nil)
+
+type ruleRepository struct {
+	db *sqlx.DB
+}
+
+// NewRuleRepository instantiates a PostgreSQL implementation of rule
+// repository.
+func NewRuleRepository(db *sqlx.DB) rules.RuleRepository {
+	return &ruleRepository{db: db}
+}
+
+func (rr ruleRepository) Save(ctx context.Context, rule rules.Rule) (string, error) {
+	q := `INSERT INTO rules (id, owner, channel, name, conditions, window_count, window_duration, actions, created)
+		  VALUES (:id, :owner, :channel, :name, :conditions, :window_count, :window_duration, :actions, :created)`
+
+	dbr, err := toDBRule(rule)
+	if err != nil {
+		return "", errors.Wrap(errSaveDB, err)
+	}
+
+	if _, err := rr.db.NamedExecContext(ctx, q, dbr); err != nil {
+		if pqErr, ok := err.(*pq.Error); ok {
+			switch pqErr.Code.Name() {
+			case errInvalid, errTruncation:
+				return "", errors.Wrap(rules.ErrMalformedEntity, err)
+			case errDuplicate:
+				return "", errors.Wrap(rules.ErrConflict, err)
+			}
+		}
+		return "", errors.Wrap(errSaveDB, err)
+	}
+
+	return rule.ID, nil
+}
+
+func (rr ruleRepository) Update(ctx context.Context, rule rules.Rule) error {
+	q := `UPDATE rules SET channel = :channel, name = :name, conditions = :conditions, window_count = :window_count,
+		  window_duration = :window_duration, actions = :actions WHERE owner = :owner AND id = :id`
+
+	dbr, err := toDBRule(rule)
+	if err != nil {
+		return errors.Wrap(errUpdateDB, err)
+	}
+
+	res, err := rr.db.NamedExecContext(ctx, q, dbr)
+	if err != nil {
+		if pqErr, ok := err.(*pq.Error); ok {
+			switch pqErr.Code.Name() {
+			case errInvalid, errTruncation:
+				return errors.Wrap(rules.ErrMalformedEntity, err)
+			}
+		}
+		return errors.Wrap(errUpdateDB, err)
+	}
+
+	cnt, err := res.RowsAffected()
+	if err != nil {
+		return errors.Wrap(errUpdateDB, err)
+	}
+	if cnt == 0 {
+		return rules.ErrNotFound
+	}
+
+	return nil
+}
+
+func (rr ruleRepository) RetrieveByID(ctx context.Context, owner, id string) (rules.Rule, error) {
+	q := `SELECT id, owner, channel, name, conditions, window_count, window_duration, actions, created
+		  FROM rules WHERE owner = $1 AND id = $2`
+
+	dbr := dbRule{}
+	if err := rr.db.QueryRowxContext(ctx, q, owner, id).StructScan(&dbr); err != nil {
+		pqErr, ok := err.(*pq.Error)
+		if err == sql.ErrNoRows || ok && errInvalid == pqErr.Code.Name() {
+			return rules.Rule{}, errors.Wrap(rules.ErrNotFound, err)
+		}
+		return rules.Rule{}, errors.Wrap(errRetrieve, err)
+	}
+
+	return toRule(dbr)
+}
+
+func (rr ruleRepository) RetrieveAll(ctx context.Context, owner string, offset, limit uint64) (rules.RulesPage, error) {
+	q := `SELECT id, owner, channel, name, conditions, window_count, window_duration, actions, created
+		  FROM rules WHERE owner = $1 ORDER BY id LIMIT $2 OFFSET $3`
+
+	items, err := rr.retrieve(ctx, q, owner, limit, offset)
+	if err != nil {
+		return rules.RulesPage{}, err
+	}
+
+	var total uint64
+	if err := rr.db.GetContext(ctx, &total, `SELECT COUNT(*) FROM rules WHERE owner = $1`, owner); err != nil {
+		return rules.RulesPage{}, errors.Wrap(errRetrieve, err)
+	}
+
+	return rules.RulesPage{
+		Total:  total,
+		Offset: offset,
+		Limit:  limit,
+		Rules:  items,
+	}, nil
+}
+
+func (rr ruleRepository) RetrieveByChannel(ctx context.Context, channel string) ([]rules.Rule, error) {
+	q := `SELECT id, owner, channel, name, conditions, window_count, window_duration, actions, created
+		  FROM rules WHERE channel = $1`
+
+	return rr.retrieve(ctx, q, channel)
+}
+
+func (rr ruleRepository) Remove(ctx context.Context, owner, id string) error {
+	q := `DELETE FROM rules WHERE owner = $1 AND id = $2`
+
+	if _, err := rr.db.ExecContext(ctx, q, owner, id); err != nil {
+		if pqErr, ok := err.(*pq.Error); ok && errInvalid == pqErr.Code.Name() {
+			return nil
+		}
+		return errors.Wrap(errRemoveDB, err)
+	}
+
+	return nil
+}
+
+func (rr ruleRepository) retrieve(ctx context.Context, q string, args ...interface{}) ([]rules.Rule, error) {
+	rows, err := rr.db.QueryxContext(ctx, q, args...)
+	if err != nil {
+		return nil, errors.Wrap(errRetrieve, err)
+	}
+	defer rows.Close()
+
+	items := []rules.Rule{}
+	for rows.Next() {
+		dbr := dbRule{}
+		if err := rows.StructScan(&dbr); err != nil {
+			return nil, errors.Wrap(errRetrieve, err)
+		}
+
+		rule, err := toRule(dbr)
+		if err != nil {
+			return nil, err
+		}
+		items = append(items, rule)
+	}
+
+	return items, nil
+}
+
+type dbRule struct {
+	ID             string    `db:"id"`
+	Owner          string    `db:"owner"`
+	Channel        string    `db:"channel"`
+	Name           string    `db:"name"`
+	Conditions     []byte    `db:"conditions"`
+	WindowCount    uint64    `db:"window_count"`
+	WindowDuration int64     `db:"window_duration"`
+	Actions        []byte    `db:"actions"`
+	Created        time.Time `db:"created"`
+}
+
+func toDBRule(rule rules.Rule) (dbRule, error) {
+	conds, err := json.Marshal(rule.Conditions)
+	if err != nil {
+		return dbRule{}, errors.Wrap(errMarshal, err)
+	}
+
+	actions, err := json.Marshal(rule.Actions)
+	if err != nil {
+		return dbRule{}, errors.Wrap(errMarshal, err)
+	}
+
+	return dbRule{
+		ID:             rule.ID,
+		Owner:          rule.Owner,
+		Channel:        rule.Channel,
+		Name:           rule.Name,
+		Conditions:     conds,
+		WindowCount:    rule.Window.Count,
+		WindowDuration: int64(rule.Window.Duration),
+		Actions:        actions,
+		Created:        rule.Created,
+	}, nil
+}
+
+func toRule(dbr dbRule) (rules.Rule, error) {
+	var conds []rules.Condition
+	if err := json.Unmarshal(dbr.Conditions, &conds); err != nil {
+		return rules.Rule{}, errors.Wrap(errUnmarshal, err)
+	}
+
+	var actions []rules.Action
+	if err := json.Unmarshal(dbr.Actions, &actions); err != nil {
+		return rules.Rule{}, errors.Wrap(errUnmarshal, err)
+	}
+
+	return rules.Rule{
+		ID:         dbr.ID,
+		Owner:      dbr.Owner,
+		Channel:    dbr.Channel,
+		Name:       dbr.Name,
+		Conditions: conds,
+		Window: rules.Window{
+			Count:    dbr.WindowCount,
+			Duration: time.Duration(dbr.WindowDuration),
+		},
+		Actions: actions,
+		Created: dbr.Created,
+	}, nil
+}
@@ -0,0 +1,239 @@
+// Copyright (c) Mainflux
+// SPDX-License-Identifier: Apache-2.0
+
+package postgres_test
+
+import (
+	"context"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/mainflux/mainflux/pkg/errors"
+	uuidProvider "github.com/mainflux/mainflux/pkg/uuid"
+	"github.com/mainflux/mainflux/rules"
+	"github.com/mainflux/mainflux/rules/postgres"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const wrongValue = "wrong-value"
+
+func newRule(t *testing.T, owner, channel string) rules.Rule {
+	id, err := uuidProvider.New().ID()
+	require.Nil(t, err, fmt.Sprintf("got unexpected error: %s", err))
+
+	return rules.Rule{
+		ID:      id,
+		Owner:   owner,
+		Channel: channel,
+		Name:    "rule",
+		Conditions: []rules.Condition{
+			{Field: rules.FieldValue, Operator: rules.OperatorGreaterThan, Threshold: 30},
+		},
+		Window:  rules.Window{Count: 3, Duration: time.Minute},
+		Actions: []rules.Action{{Type: rules.ActionAlarm}},
+		Created: time.Now().UTC().Truncate(time.Millisecond),
+	}
+}
+
+func TestRuleSave(t *testing.T) {
+	repo := postgres.NewRuleRepository(db)
+
+	rule := newRule(t, "rule-save@example.com", "1")
+	invalid := rule
+	invalid.ID = wrongValue
+
+	cases := []struct {
+		desc string
+		rule rules.Rule
+		err  error
+	}{
+		{
+			desc: "save new rule",
+			rule: rule,
+			err:  nil,
+		},
+		{
+			desc: "save rule that already exists",
+			rule: rule,
+			err:  rules.ErrConflict,
+		},
+		{
+			desc: "save rule with invalid ID",
+			rule: invalid,
+			err:  rules.ErrMalformedEntity,
+		},
+	}
+
+	for _, tc := range cases {
+		_, err := repo.Save(context.Background(), tc.rule)
+		assert.True(t, errors.Contains(err, tc.err), fmt.Sprintf("%s: expected %s got %s\n", tc.desc, tc.err, err))
+	}
+}
+
+func TestRuleRetrieveByID(t *testing.T) {
+	repo := postgres.NewRuleRepository(db)
+
+	owner := "rule-retrieve@example.com"
+	rule := newRule(t, owner, "1")
+	_, err := repo.Save(context.Background(), rule)
+	require.Nil(t, err, fmt.Sprintf("got unexpected error: %s", err))
+
+	cases := []struct {
+		desc  string
+		owner string
+		id    string
+		err   error
+	}{
+		{
+			desc:  "retrieve existing rule",
+			owner: owner,
+			id:    rule.ID,
+			err:   nil,
+		},
+		{
+			desc:  "retrieve rule with wrong owner",
+			owner: wrongValue,
+			id:    rule.ID,
+			err:   rules.ErrNotFound,
+		},
+		{
+			desc:  "retrieve rule with invalid ID",
+			owner: owner,
+			id:    wrongValue,
+			err:   rules.ErrNotFound,
+		},
+	}
+
+	for _, tc := range cases {
+		res, err := repo.RetrieveByID(context.Background(), tc.owner, tc.id)
+		assert.True(t, errors.Contains(err, tc.err), fmt.Sprintf("%s: expected %s got %s\n", tc.desc, tc.err, err))
+		if err == nil {
+			assert.Equal(t, rule.Conditions, res.Conditions, fmt.Sprintf("%s: expected %v got %v\n", tc.desc, rule.Conditions, res.Conditions))
+			assert.Equal(t, rule.Window, res.Window, fmt.Sprintf("%s: expected %v got %v\n", tc.desc, rule.Window, res.Window))
+			assert.Equal(t, rule.Actions, res.Actions, fmt.Sprintf("%s: expected %v got %v\n", tc.desc, rule.Actions, res.Actions))
+		}
+	}
+}
+
+func TestRuleUpdate(t *testing.T) {
+	repo := postgres.NewRuleRepository(db)
+
+	owner := "rule-update@example.com"
+	rule := newRule(t, owner, "1")
+	_, err := repo.Save(context.Background(), rule)
+	require.Nil(t, err, fmt.Sprintf("got unexpected error: %s", err))
+
+	updated := rule
+	updated.Name = "updated"
+	updated.Window = rules.Window{Count: 1}
+	wrongOwner := updated
+	wrongOwner.Owner = wrongValue
+	nonexistent := newRule(t, owner, "1")
+
+	cases := []struct {
+		desc string
+		rule rules.Rule
+		err  error
+	}{
+		{
+			desc: "update existing rule",
+			rule: updated,
+			err:  nil,
+		},
+		{
+			desc: "update rule with wrong owner",
+			rule: wrongOwner,
+			err:  rules.ErrNotFound,
+		},
+		{
+			desc: "update non-existing rule",
+			rule: nonexistent,
+			err:  rules.ErrNotFound,
+		},
+	}
+
+	for _, tc := range cases {
+		err := repo.Update(context.Background(), tc.rule)
+		assert.True(t, errors.Contains(err, tc.err), fmt.Sprintf("%s: expected %s got %s\n", tc.desc, tc.err, err))
+	}
+
+	res, err := repo.RetrieveByID(context.Background(), owner, rule.ID)
+	require.Nil(t, err, fmt.Sprintf("got unexpected error: %s", err))
+	assert.Equal(t, updated.Name, res.Name, fmt.Sprintf("expected name %s got %s\n", updated.Name, res.Name))
+	assert.Equal(t, updated.Window, res.Window, fmt.Sprintf("expected window %v got %v\n", updated.Window, res.Window))
+}
+
+func TestRuleRetrieveAll(t *testing.T) {
+	repo := postgres.NewRuleRepository(db)
+
+	owner := "rule-retrieve-all@example.com"
+	channel := "retrieve-all-channel"
+	n := uint64(10)
+	for i := uint64(0); i < n; i++ {
+		_, err := repo.Save(context.Background(), newRule(t, owner, channel))
+		require.Nil(t, err, fmt.Sprintf("got unexpected error: %s", err))
+	}
+
+	cases := map[string]struct {
+		owner  string
+		offset uint64
+		limit  uint64
+		size   uint64
+		total  uint64
+	}{
+		"retrieve all rules": {
+			owner:  owner,
+			offset: 0,
+			limit:  n,
+			size:   n,
+			total:  n,
+		},
+		"retrieve subset of rules": {
+			owner:  owner,
+			offset: n / 2,
+			limit:  n,
+			size:   n / 2,
+			total:  n,
+		},
+		"retrieve rules of user without rules": {
+			owner:  wrongValue,
+			offset: 0,
+			limit:  n,
+			size:   0,
+			total:  0,
+		},
+	}
+
+	for desc, tc := range cases {
+		page, err := repo.RetrieveAll(context.Background(), tc.owner, tc.offset, tc.limit)
+		assert.Nil(t, err, fmt.Sprintf("%s: expected no error got %s\n", desc, err))
+		size := uint64(len(page.Rules))
+		assert.Equal(t, tc.size, size, fmt.Sprintf("%s: expected size %d got %d\n", desc, tc.size, size))
+		assert.Equal(t, tc.total, page.Total, fmt.Sprintf("%s: expected total %d got %d\n", desc, tc.total, page.Total))
+	}
+
+	rs, err := repo.RetrieveByChannel(context.Background(), channel)
+	assert.Nil(t, err, fmt.Sprintf("retrieve by channel: expected no error got %s\n", err))
+	assert.Equal(t, int(n), len(rs), fmt.Sprintf("retrieve by channel: expected %d rules got %d\n", n, len(rs)))
+}
+
+func TestRuleRemove(t *testing.T) {
+	repo := postgres.NewRuleRepository(db)
+
+	owner := "rule-remove@example.com"
+	rule := newRule(t, owner, "1")
+	_, err := repo.Save(context.Background(), rule)
+	require.Nil(t, err, fmt.Sprintf("got unexpected error: %s", err))
+
+	// show that the removal works the same for both existing and non-existing
+	// (removed) rule
+	for i := 0; i < 2; i++ {
+		err := repo.Remove(context.Background(), owner, rule.ID)
+		require.Nil(t, err, fmt.Sprintf("#%d: failed to remove rule due to: %s", i, err))
+
+		_, err = repo.RetrieveByID(context.Background(), owner, rule.ID)
+		require.True(t, errors.Contains(err, rules.ErrNotFound), fmt.Sprintf("#%d: expected %s got %s", i, rules.ErrNotFound, err))
+	}
+}
@@ -0,0 +1,229 @@
+// Copyright (c) Mainflux
+// SPDX-License-Identifier: Apache-2.0
+
+package rules
+
+import (
+	"context"
+	"net/url"
+	"time"
+
+	"github.com/mainflux/mainflux/pkg/transformers/senml"
+)
+
+// Record fields rule conditions can be applied to.
+const (
+	FieldName      = "name"
+	FieldValue     = "value"
+	FieldUnit      = "unit"
+	FieldPublisher = "publisher"
+)
+
+// Operators used to compare record fields with the condition.
+const (
+	OperatorEqual            = "eq"
+	OperatorNotEqual         = "ne"
+	OperatorLowerThan        = "lt"
+	OperatorLowerThanEqual   = "le"
+	OperatorGreaterThan      = "gt"
+	OperatorGreaterThanEqual = "ge"
+)
+
+// Types of actions fired by the rule.
+const (
+	ActionPublish = "publish"
+	ActionWebhook = "webhook"
+	ActionAlarm   = "alarm"
+)
+
+// Condition represents a single check applied to the SenML record. Value
+// field is compared against Threshold, while name, unit and publisher fields
+// are compared against Value and support only equality operators.
+type Condition struct {
+	Field     string  `json:"field"`
+	Operator  string  `json:"operator"`
+	Threshold float64 `json:"threshold,omitempty"`
+	Value     string  `json:"value,omitempty"`
+}
+
+// Window specifies how many matching records are needed for the rule to
+// fire. If Duration is set, all of the Count records must fall into the
+// time span of the given Duration. Zero Count is treated as one.
+type Window struct {
+	Count    uint64        `json:"count,omitempty"`
+	Duration time.Duration `json:"duration,omitempty"`
+}
+
+// Action represents the reaction on the fired rule. Channel is used by the
+// publish action, and URL by the webhook action.
+type Action struct {
+	Type    string `json:"type"`
+	Channel string `json:"channel,omitempty"`
+	URL     string `json:"url,omitempty"`
+}
+
+// Rule represents a set of conditions applied to the records received on
+// the channel. The rule matches a record if all of its conditions are met.
+type Rule struct {
+	ID         string
+	Owner      string
+	Channel    string
+	Name       string
+	Conditions []Condition
+	Window     Window
+	Actions    []Action
+	Created    time.Time
+}
+
+// RulesPage contains page related metadata as well as a list of rules that
+// belong to this page.
+type RulesPage struct {
+	Total  uint64
+	Offset uint64
+	Limit  uint64
+	Rules  []Rule
+}
+
+// RuleRepository specifies a rule persistence API.
+type RuleRepository interface {
+	// Save persists the rule.
+	Save(ctx context.Context, rule Rule) (string, error)
+
+	// Update performs an update to the existing rule. A non-nil error is
+	// returned to indicate operation failure.
+	Update(ctx context.Context, rule Rule) error
+
+	// RetrieveByID retrieves the rule having the provided identifier, that
+	// is owned by the specified user.
+	RetrieveByID(ctx context.Context, owner, id string) (Rule, error)
+
+	// RetrieveAll retrieves the subset of rules owned by the specified user.
+	RetrieveAll(ctx context.Context, owner string, offset, limit uint64) (RulesPage, error)
+
+	// RetrieveByChannel retrieves all the rules, regardless of the owner,
+	// applied to the channel with the given identifier.
+	RetrieveByChannel(ctx context.Context, channel string) ([]Rule, error)
+
+	// Remove removes the rule having the provided identifier, that is owned
+	// by the specified user.
+	Remove(ctx context.Context, owner, id string) error
+}
+
+// Validate returns an error if the rule is not valid.
+func (r Rule) Validate() error {
+	if r.Channel == "" || len(r.Conditions) == 0 || len(r.Actions) == 0 || r.Window.Duration < 0 {
+		return ErrMalformedEntity
+	}
+
+	for _, c := range r.Conditions {
+		if err := c.validate(); err != nil {
+			return err
+		}
+	}
+
+	for _, a := range r.Actions {
+		if err := a.validate(r.Channel); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// Match returns true if the record satisfies all of the rule conditions.
+func (r Rule) Match(rec senml.Message) bool {
+	for _, c := range r.Conditions {
+		if !c.match(rec) {
+			return false
+		}
+	}
+
+	return true
+}
+
+func (c Condition) validate() error {
+	switch c.Field {
+	case FieldValue:
+		switch c.Operator {
+		case OperatorEqual, OperatorNotEqual, OperatorLowerThan, OperatorLowerThanEqual,
+			OperatorGreaterThan, OperatorGreaterThanEqual:
+			return nil
+		}
+	case FieldName, FieldUnit, FieldPublisher:
+		switch c.Operator {
+		case OperatorEqual, OperatorNotEqual:
+			return nil
+		}
+	}
+
+	return ErrMalformedEntity
+}
+
+func (c Condition) match(rec senml.Message) bool {
+	switch c.Field {
+	case FieldValue:
+		if rec.Value == nil {
+			return false
+		}
+		return compare(*rec.Value, c.Threshold, c.Operator)
+	case FieldName:
+		return equal(rec.Name, c.Value, c.Operator)
+	case FieldUnit:
+		return equal(rec.Unit, c.Value, c.Operator)
+	case FieldPublisher:
+		return equal(rec.Publisher, c.Value, c.Operator)
+	default:
+		return false
+	}
+}
+
+func (a Action) validate(channel string) error {
+	switch a.Type {
+	case ActionPublish:
+		// Republishing to the source channel would make the rule fire
+		// on its own output.
+		if a.Channel == "" || a.Channel == channel {
+			return ErrMalformedEntity
+		}
+	case ActionWebhook:
+		u, err := url.Parse(a.URL)
+		if err != nil || (u.Scheme != "http" && u.Scheme != "https") || u.Host == "" {
+			return ErrMalformedEntity
+		}
+	case ActionAlarm:
+	default:
+		return ErrMalformedEntity
+	}
+
+	return nil
+}
+
+func compare(v, threshold float64, op string) bool {
+	switch op {
+	case OperatorEqual:
+		return v == threshold
+	case OperatorNotEqual:
+		return v != threshold
+	case OperatorLowerThan:
+		return v < threshold
+	case OperatorLowerThanEqual:
+		return v <= threshold
+	case OperatorGreaterThan:
+		return v > threshold
+	case OperatorGreaterThanEqual:
+		return v >= threshold
+	default:
+		return false
+	}
+}
+
+func equal(v, expected, op string) bool {
+	switch op {
+	case OperatorEqual:
+		return v == expected
+	case OperatorNotEqual:
+		return v != expected
+	default:
+		return false
+	}
+}
@@ -0,0 +1,304 @@
+// Copyright (c) Mainflux
+// SPDX-License-Identifier: Apache-2.0
+
+package rules
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/mainflux/mainflux"
+	"github.com/mainflux/mainflux/pkg/errors"
+	"github.com/mainflux/mainflux/pkg/messaging"
+	mfsdk "github.com/mainflux/mainflux/pkg/sdk/go"
+	"github.com/mainflux/mainflux/pkg/transformers"
+	"github.com/mainflux/mainflux/pkg/transformers/senml"
+)
+
+// Protocol is set on messages republished by the rules engine. Such messages
+// are not evaluated again to prevent rules from firing each other endlessly.
+const Protocol = "rules"
+
+var (
+	// ErrNotFound indicates a non-existent entity request.
+	ErrNotFound = errors.New("non-existent entity")
+
+	// ErrMalformedEntity indicates malformed entity specification.
+	ErrMalformedEntity = errors.New("malformed entity specification")
+
+	// ErrUnauthorizedAccess indicates missing or invalid credentials provided
+	// when accessing a protected resource.
+	ErrUnauthorizedAccess = errors.New("missing or invalid credentials provided")
+
+	// ErrConflict indicates that entity already exists.
+	ErrConflict = errors.New("entity already exists")
+
+	errTransform = errors.New("failed to transform message")
+	errFire      = errors.New("failed to fire rule action")
+)
+
+var _ Service = (*rulesService)(nil)
+
+// Service specifies an API that must be fulfilled by the domain service
+// implementation, and all of its decorators (e.g. logging & metrics).
+type Service interface {
+	// CreateRule adds a rule to the user identified by the provided token.
+	CreateRule(ctx context.Context, token string, rule Rule) (Rule, error)
+
+	// ViewRule retrieves data about the rule identified by the provided ID,
+	// that belongs to the user identified by the provided token.
+	ViewRule(ctx context.Context, token, id string) (Rule, error)
+
+	// UpdateRule updates the rule identified by the provided ID, that
+	// belongs to the user identified by the provided token.
+	UpdateRule(ctx context.Context, token string, rule Rule) error
+
+	// ListRules retrieves data about a subset of rules that belong to the
+	// user identified by the provided token.
+	ListRules(ctx context.Context, token string, offset, limit uint64) (RulesPage, error)
+
+	// RemoveRule removes the rule identified by the provided ID, that
+	// belongs to the user identified by the provided token.
+	RemoveRule(ctx context.Context, token, id string) error
+
+	// ListAlarms retrieves a subset of alarms raised by the rules that
+	// belong to the user identified by the provided token. If rule ID is
+	// not empty, only alarms raised by that rule are retrieved.
+	ListAlarms(ctx context.Context, token, ruleID string, offset, limit uint64) (AlarmsPage, error)
+
+	// Consume evaluates the records of the received message against the
+	// rules applied to the message channel and fires the actions of the
+	// rules whose window is filled. A rule failing to fire does not stop
+	// the evaluation of the remaining records and rules, and the errors
+	// of all failed rules are returned once the message is processed.
+	Consume(msg messaging.Message) error
+}
+
+type rulesService struct {
+	auth        mainflux.AuthNServiceClient
+	rules       RuleRepository
+	alarms      AlarmRepository
+	sdk         mfsdk.SDK
+	publisher   messaging.Publisher
+	notifier    Notifier
+	transformer transformers.Transformer
+	idp         mainflux.UUIDProvider
+	windows     *windows
+}
+
+// New instantiates the rules service implementation.
+func New(auth mainflux.AuthNServiceClient, rules RuleRepository, alarms AlarmRepository, sdk mfsdk.SDK, publisher messaging.Publisher, notifier Notifier, transformer transformers.Transformer, idp mainflux.UUIDProvider) Service {
+	return &rulesService{
+		auth:        auth,
+		rules:       rules,
+		alarms:      alarms,
+		sdk:         sdk,
+		publisher:   publisher,
+		notifier:    notifier,
+		transformer: transformer,
+		idp:         idp,
+		windows:     newWindows(),
+	}
+}
+
+func (rs *rulesService) CreateRule(ctx context.Context, token string, rule Rule) (Rule, error) {
+	owner, err := rs.identify(ctx, token)
+	if err != nil {
+		return Rule{}, err
+	}
+
+	if err := rs.checkChannels(token, rule); err != nil {
+		return Rule{}, err
+	}
+
+	rule.ID, err = rs.idp.ID()
+	if err != nil {
+		return Rule{}, err
+	}
+	rule.Owner = owner
+	rule.Created = time.Now()
+
+	if _, err := rs.rules.Save(ctx, rule); err != nil {
+		return Rule{}, err
+	}
+
+	return rule, nil
+}
+
+func (rs *rulesService) ViewRule(ctx context.Context, token, id string) (Rule, error) {
+	owner, err := rs.identify(ctx, token)
+	if err != nil {
+		return Rule{}, err
+	}
+
+	return rs.rules.RetrieveByID(ctx, owner, id)
+}
+
+func (rs *rulesService) UpdateRule(ctx context.Context, token string, rule Rule) error {
+	owner, err := rs.identify(ctx, token)
+	if err != nil {
+		return err
+	}
+
+	if err := rs.checkChannels(token, rule); err != nil {
+		return err
+	}
+
+	rule.Owner = owner
+	if err := rs.rules.Update(ctx, rule); err != nil {
+		return err
+	}
+	rs.windows.reset(rule.ID)
+
+	return nil
+}
+
+func (rs *rulesService) ListRules(ctx context.Context, token string, offset, limit uint64) (RulesPage, error) {
+	owner, err := rs.identify(ctx, token)
+	if err != nil {
+		return RulesPage{}, err
+	}
+
+	return rs.rules.RetrieveAll(ctx, owner, offset, limit)
+}
+
+func (rs *rulesService) RemoveRule(ctx context.Context, token, id string) error {
+	owner, err := rs.identify(ctx, token)
+	if err != nil {
+		return err
+	}
+
+	if err := rs.rules.Remove(ctx, owner, id); err != nil {
+		return err
+	}
+	rs.windows.reset(id)
+
+	return nil
+}
+
+func (rs *rulesService) ListAlarms(ctx context.Context, token, ruleID string, offset, limit uint64) (AlarmsPage, error) {
+	owner, err := rs.identify(ctx, token)
+	if err != nil {
+		return AlarmsPage{}, err
+	}
+
+	return rs.alarms.RetrieveAll(ctx, owner, ruleID, offset, limit)
+}
+
+func (rs *rulesService) Consume(msg messaging.Message) error {
+	if msg.Protocol == Protocol {
+		return nil
+	}
+
+	ctx := context.Background()
+	rules, err := rs.rules.RetrieveByChannel(ctx, msg.Channel)
+	if err != nil {
+		return err
+	}
+	if len(rules) == 0 {
+		return nil
+	}
+
+	t, err := rs.transformer.Transform(msg)
+	if err != nil {
+		return errors.Wrap(errTransform, err)
+	}
+	recs, ok := t.([]senml.Message)
+	if !ok {
+		return errTransform
+	}
+
+	var failed []string
+	for _, rule := range rules {
+		for _, rec := range recs {
+			if !rule.Match(rec) {
+				continue
+			}
+			fired := rs.windows.add(rule, rec)
+			if fired == nil {
+				continue
+			}
+			if err := rs.fire(ctx, rule, msg, fired); err != nil {
+				failed = append(failed, fmt.Sprintf("rule %s: %s", rule.ID, err))
+			}
+		}
+	}
+
+	if len(failed) > 0 {
+		return errors.Wrap(errFire, errors.New(strings.Join(failed, "; ")))
+	}
+
+	return nil
+}
+
+// fire executes all of the rule actions. Failure of a single action does
+// not prevent the remaining actions from being executed.
+func (rs *rulesService) fire(ctx context.Context, rule Rule, msg messaging.Message, recs []senml.Message) error {
+	id, err := rs.idp.ID()
+	if err != nil {
+		return err
+	}
+	alarm := Alarm{
+		ID:      id,
+		RuleID:  rule.ID,
+		Owner:   rule.Owner,
+		Channel: rule.Channel,
+		Records: recs,
+		Created: time.Now(),
+	}
+
+	var ret error
+	for _, action := range rule.Actions {
+		var err error
+		switch action.Type {
+		case ActionPublish:
+			m := msg
+			m.Channel = action.Channel
+			m.Protocol = Protocol
+			err = rs.publisher.Publish(m.Channel, m)
+		case ActionWebhook:
+			err = rs.notifier.Notify(action.URL, alarm)
+		case ActionAlarm:
+			_, err = rs.alarms.Save(ctx, alarm)
+		}
+		if err != nil {
+			ret = err
+		}
+	}
+
+	return ret
+}
+
+// checkChannels verifies that the source channel and all of the channels
+// the rule republishes to belong to the user identified by the token.
+func (rs *rulesService) checkChannels(token string, rule Rule) error {
+	if err := rule.Validate(); err != nil {
+		return err
+	}
+
+	channels := []string{rule.Channel}
+	for _, a := range rule.Actions {
+		if a.Type == ActionPublish {
+			channels = append(channels, a.Channel)
+		}
+	}
+
+	for _, id := range channels {
+		if _, err := rs.sdk.Channel(id, token); err != nil {
+			return errors.Wrap(ErrNotFound, err)
+		}
+	}
+
+	return nil
+}
+
+func (rs *rulesService) identify(ctx context.Context, token string) (string, error) {
+	res, err := rs.auth.Identify(ctx, &mainflux.Token{Value: token})
+	if err != nil {
+		return "", errors.Wrap(ErrUnauthorizedAccess, err)
+	}
+
+	return res.GetEmail(), nil
+}
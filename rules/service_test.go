@@ -0,0 +1,527 @@
+// Copyright (c) Mainflux
+// SPDX-License-Identifier: Apache-2.0
+
+package rules_test
+
+import (
+	"context"
+	"fmt"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/mainflux/mainflux/pkg/errors"
+	"github.com/mainflux/mainflux/pkg/messaging"
+	mfsdk "github.com/mainflux/mainflux/pkg/sdk/go"
+	"github.com/mainflux/mainflux/pkg/transformers/senml"
+	"github.com/mainflux/mainflux/pkg/uuid"
+	"github.com/mainflux/mainflux/rules"
+	"github.com/mainflux/mainflux/rules/mocks"
+	"github.com/mainflux/mainflux/things"
+	httpapi "github.com/mainflux/mainflux/things/api/things/http"
+	thmocks "github.com/mainflux/mainflux/things/mocks"
+	"github.com/opentracing/opentracing-go/mocktracer"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const (
+	token      = "token"
+	otherToken = "other-token"
+	wrongValue = "wrong-value"
+	email      = "user@example.com"
+	otherEmail = "other@example.com"
+	queueSize  = 10
+)
+
+type env struct {
+	svc    rules.Service
+	msgs   chan messaging.Message
+	alarms chan rules.Alarm
+	chs    []string
+	other  string
+}
+
+func newEnv(t *testing.T) env {
+	tokens := map[string]string{token: email, otherToken: otherEmail}
+	auth := mocks.NewAuthNServiceClient(tokens)
+
+	ths := newThingsService(tokens)
+	server := httptest.NewServer(httpapi.MakeHandler(mocktracer.New(), ths))
+	t.Cleanup(server.Close)
+
+	chs, err := ths.CreateChannels(context.Background(), token, things.Channel{Name: "src"}, things.Channel{Name: "dst"})
+	require.Nil(t, err, fmt.Sprintf("unexpected error: %s", err))
+	other, err := ths.CreateChannels(context.Background(), otherToken, things.Channel{Name: "other"})
+	require.Nil(t, err, fmt.Sprintf("unexpected error: %s", err))
+
+	sdk := mfsdk.NewSDK(mfsdk.Config{BaseURL: server.URL})
+	msgs := make(chan messaging.Message, queueSize)
+	alarms := make(chan rules.Alarm, queueSize)
+	svc := rules.New(
+		auth,
+		mocks.NewRuleRepository(),
+		mocks.NewAlarmRepository(),
+		sdk,
+		mocks.NewPublisher(msgs),
+		mocks.NewNotifier(alarms),
+		senml.New(senml.JSON),
+		uuid.NewMock(),
+	)
+
+	return env{
+		svc:    svc,
+		msgs:   msgs,
+		alarms: alarms,
+		chs:    []string{chs[0].ID, chs[1].ID},
+		other:  other[0].ID,
+	}
+}
+
+func newThingsService(tokens map[string]string) things.Service {
+	auth := thmocks.NewAuthService(tokens)
+	conns := make(chan thmocks.Connection)
+	thingsRepo := thmocks.NewThingRepository(conns)
+	channelsRepo := thmocks.NewChannelRepository(thingsRepo, conns)
+	groupsRepo := thmocks.NewGroupRepository(thingsRepo, channelsRepo)
+
+	return things.New(auth, thmocks.NewUsersService(map[string]map[string]string{}), thingsRepo, channelsRepo, groupsRepo, thmocks.NewChannelCache(), thmocks.NewThingCache(), uuid.NewMock())
+}
+
+func newRule(channel string) rules.Rule {
+	return rules.Rule{
+		Channel: channel,
+		Name:    "overheating",
+		Conditions: []rules.Condition{
+			{Field: rules.FieldName, Operator: rules.OperatorEqual, Value: "temperature"},
+			{Field: rules.FieldValue, Operator: rules.OperatorGreaterThan, Threshold: 30},
+		},
+		Actions: []rules.Action{{Type: rules.ActionAlarm}},
+	}
+}
+
+func newMessage(channel string, value float64) messaging.Message {
+	return messaging.Message{
+		Channel:   channel,
+		Publisher: "publisher",
+		Protocol:  "http",
+		Payload:   []byte(fmt.Sprintf(`[{"n":"temperature","u":"Cel","t":%d,"v":%g}]`, time.Now().Unix(), value)),
+		Created:   time.Now().UnixNano(),
+	}
+}
+
+func TestCreateRule(t *testing.T) {
+	e := newEnv(t)
+
+	publish := newRule(e.chs[0])
+	publish.Actions = []rules.Action{{Type: rules.ActionPublish, Channel: e.chs[1]}}
+	foreignTarget := newRule(e.chs[0])
+	foreignTarget.Actions = []rules.Action{{Type: rules.ActionPublish, Channel: e.other}}
+	noConditions := newRule(e.chs[0])
+	noConditions.Conditions = nil
+	invalidOperator := newRule(e.chs[0])
+	invalidOperator.Conditions = []rules.Condition{{Field: rules.FieldUnit, Operator: rules.OperatorGreaterThan, Value: "Cel"}}
+	invalidWebhook := newRule(e.chs[0])
+	invalidWebhook.Actions = []rules.Action{{Type: rules.ActionWebhook, URL: "ftp://example.com"}}
+	selfPublish := newRule(e.chs[0])
+	selfPublish.Actions = []rules.Action{{Type: rules.ActionPublish, Channel: e.chs[0]}}
+
+	cases := []struct {
+		desc  string
+		token string
+		rule  rules.Rule
+		err   error
+	}{
+		{
+			desc:  "create valid rule",
+			token: token,
+			rule:  newRule(e.chs[0]),
+			err:   nil,
+		},
+		{
+			desc:  "create rule republishing to owned channel",
+			token: token,
+			rule:  publish,
+			err:   nil,
+		},
+		{
+			desc:  "create rule with invalid token",
+			token: wrongValue,
+			rule:  newRule(e.chs[0]),
+			err:   rules.ErrUnauthorizedAccess,
+		},
+		{
+			desc:  "create rule for foreign channel",
+			token: token,
+			rule:  newRule(e.other),
+			err:   rules.ErrNotFound,
+		},
+		{
+			desc:  "create rule republishing to foreign channel",
+			token: token,
+			rule:  foreignTarget,
+			err:   rules.ErrNotFound,
+		},
+		{
+			desc:  "create rule without conditions",
+			token: token,
+			rule:  noConditions,
+			err:   rules.ErrMalformedEntity,
+		},
+		{
+			desc:  "create rule with invalid operator",
+			token: token,
+			rule:  invalidOperator,
+			err:   rules.ErrMalformedEntity,
+		},
+		{
+			desc:  "create rule with invalid webhook URL",
+			token: token,
+			rule:  invalidWebhook,
+			err:   rules.ErrMalformedEntity,
+		},
+		{
+			desc:  "create rule republishing to source channel",
+			token: token,
+			rule:  selfPublish,
+			err:   rules.ErrMalformedEntity,
+		},
+	}
+
+	for _, tc := range cases {
+		_, err := e.svc.CreateRule(context.Background(), tc.token, tc.rule)
+		assert.True(t, errors.Contains(err, tc.err), fmt.Sprintf("%s: expected %s got %s\n", tc.desc, tc.err, err))
+	}
+}
+
+func TestViewRule(t *testing.T) {
+	e := newEnv(t)
+
+	saved, err := e.svc.CreateRule(context.Background(), token, newRule(e.chs[0]))
+	require.Nil(t, err, fmt.Sprintf("unexpected error: %s", err))
+
+	cases := []struct {
+		desc  string
+		token string
+		id    string
+		err   error
+	}{
+		{
+			desc:  "view existing rule",
+			token: token,
+			id:    saved.ID,
+			err:   nil,
+		},
+		{
+			desc:  "view rule with invalid token",
+			token: wrongValue,
+			id:    saved.ID,
+			err:   rules.ErrUnauthorizedAccess,
+		},
+		{
+			desc:  "view rule of another user",
+			token: otherToken,
+			id:    saved.ID,
+			err:   rules.ErrNotFound,
+		},
+		{
+			desc:  "view non-existing rule",
+			token: token,
+			id:    wrongValue,
+			err:   rules.ErrNotFound,
+		},
+	}
+
+	for _, tc := range cases {
+		rule, err := e.svc.ViewRule(context.Background(), tc.token, tc.id)
+		assert.True(t, errors.Contains(err, tc.err), fmt.Sprintf("%s: expected %s got %s\n", tc.desc, tc.err, err))
+		if err == nil {
+			assert.Equal(t, saved, rule, fmt.Sprintf("%s: expected %v got %v\n", tc.desc, saved, rule))
+		}
+	}
+}
+
+func TestUpdateRule(t *testing.T) {
+	e := newEnv(t)
+
+	saved, err := e.svc.CreateRule(context.Background(), token, newRule(e.chs[0]))
+	require.Nil(t, err, fmt.Sprintf("unexpected error: %s", err))
+
+	updated := saved
+	updated.Name = "updated"
+	updated.Window = rules.Window{Count: 3, Duration: time.Minute}
+	malformed := saved
+	malformed.Actions = nil
+	nonexistent := newRule(e.chs[0])
+	nonexistent.ID = wrongValue
+
+	cases := []struct {
+		desc  string
+		token string
+		rule  rules.Rule
+		err   error
+	}{
+		{
+			desc:  "update existing rule",
+			token: token,
+			rule:  updated,
+			err:   nil,
+		},
+		{
+			desc:  "update rule with invalid token",
+			token: wrongValue,
+			rule:  updated,
+			err:   rules.ErrUnauthorizedAccess,
+		},
+		{
+			desc:  "update rule with no actions",
+			token: token,
+			rule:  malformed,
+			err:   rules.ErrMalformedEntity,
+		},
+		{
+			desc:  "update non-existing rule",
+			token: token,
+			rule:  nonexistent,
+			err:   rules.ErrNotFound,
+		},
+	}
+
+	for _, tc := range cases {
+		err := e.svc.UpdateRule(context.Background(), tc.token, tc.rule)
+		assert.True(t, errors.Contains(err, tc.err), fmt.Sprintf("%s: expected %s got %s\n", tc.desc, tc.err, err))
+	}
+
+	rule, err := e.svc.ViewRule(context.Background(), token, saved.ID)
+	require.Nil(t, err, fmt.Sprintf("unexpected error: %s", err))
+	assert.Equal(t, updated, rule, fmt.Sprintf("expected %v got %v\n", updated, rule))
+}
+
+func TestListRules(t *testing.T) {
+	e := newEnv(t)
+
+	n := uint64(10)
+	for i := uint64(0); i < n; i++ {
+		_, err := e.svc.CreateRule(context.Background(), token, newRule(e.chs[0]))
+		require.Nil(t, err, fmt.Sprintf("unexpected error: %s", err))
+	}
+
+	cases := []struct {
+		desc   string
+		token  string
+		offset uint64
+		limit  uint64
+		size   uint64
+		err    error
+	}{
+		{
+			desc:   "list all rules",
+			token:  token,
+			offset: 0,
+			limit:  n,
+			size:   n,
+			err:    nil,
+		},
+		{
+			desc:   "list subset of rules",
+			token:  token,
+			offset: n - 3,
+			limit:  n,
+			size:   3,
+			err:    nil,
+		},
+		{
+			desc:   "list rules of another user",
+			token:  otherToken,
+			offset: 0,
+			limit:  n,
+			size:   0,
+			err:    nil,
+		},
+		{
+			desc:   "list rules with invalid token",
+			token:  wrongValue,
+			offset: 0,
+			limit:  n,
+			size:   0,
+			err:    rules.ErrUnauthorizedAccess,
+		},
+	}
+
+	for _, tc := range cases {
+		page, err := e.svc.ListRules(context.Background(), tc.token, tc.offset, tc.limit)
+		size := uint64(len(page.Rules))
+		assert.Equal(t, tc.size, size, fmt.Sprintf("%s: expected %d got %d\n", tc.desc, tc.size, size))
+		assert.True(t, errors.Contains(err, tc.err), fmt.Sprintf("%s: expected %s got %s\n", tc.desc, tc.err, err))
+	}
+}
+
+func TestRemoveRule(t *testing.T) {
+	e := newEnv(t)
+
+	saved, err := e.svc.CreateRule(context.Background(), token, newRule(e.chs[0]))
+	require.Nil(t, err, fmt.Sprintf("unexpected error: %s", err))
+
+	cases := []struct {
+		desc  string
+		token string
+		id    string
+		err   error
+	}{
+		{
+			desc:  "remove rule with invalid token",
+			token: wrongValue,
+			id:    saved.ID,
+			err:   rules.ErrUnauthorizedAccess,
+		},
+		{
+			desc:  "remove existing rule",
+			token: token,
+			id:    saved.ID,
+			err:   nil,
+		},
+		{
+			desc:  "remove removed rule",
+			token: token,
+			id:    saved.ID,
+			err:   nil,
+		},
+	}
+
+	for _, tc := range cases {
+		err := e.svc.RemoveRule(context.Background(), tc.token, tc.id)
+		assert.True(t, errors.Contains(err, tc.err), fmt.Sprintf("%s: expected %s got %s\n", tc.desc, tc.err, err))
+	}
+
+	_, err = e.svc.ViewRule(context.Background(), token, saved.ID)
+	assert.True(t, errors.Contains(err, rules.ErrNotFound), fmt.Sprintf("expected %s got %s\n", rules.ErrNotFound, err))
+}
+
+func TestConsume(t *testing.T) {
+	e := newEnv(t)
+
+	rule := newRule(e.chs[0])
+	rule.Window = rules.Window{Count: 2, Duration: time.Minute}
+	rule.Actions = []rules.Action{
+		{Type: rules.ActionPublish, Channel: e.chs[1]},
+		{Type: rules.ActionWebhook, URL: "http://example.com/hook"},
+		{Type: rules.ActionAlarm},
+	}
+	saved, err := e.svc.CreateRule(context.Background(), token, rule)
+	require.Nil(t, err, fmt.Sprintf("unexpected error: %s", err))
+
+	republished := newMessage(e.chs[0], 40)
+	republished.Protocol = rules.Protocol
+
+	cases := []struct {
+		desc  string
+		msg   messaging.Message
+		fired bool
+	}{
+		{
+			desc:  "consume message matching the rule",
+			msg:   newMessage(e.chs[0], 35),
+			fired: false,
+		},
+		{
+			desc:  "consume message not matching the rule",
+			msg:   newMessage(e.chs[0], 20),
+			fired: false,
+		},
+		{
+			desc:  "consume message republished by the rules engine",
+			msg:   republished,
+			fired: false,
+		},
+		{
+			desc:  "consume message on channel without rules",
+			msg:   newMessage(e.chs[1], 40),
+			fired: false,
+		},
+		{
+			desc:  "consume message filling the rule window",
+			msg:   newMessage(e.chs[0], 36),
+			fired: true,
+		},
+		{
+			desc:  "consume message after the rule window is reset",
+			msg:   newMessage(e.chs[0], 37),
+			fired: false,
+		},
+	}
+
+	for _, tc := range cases {
+		err := e.svc.Consume(tc.msg)
+		assert.Nil(t, err, fmt.Sprintf("%s: expected no error got %s\n", tc.desc, err))
+
+		if !tc.fired {
+			assert.Equal(t, 0, len(e.msgs), fmt.Sprintf("%s: expected no published messages\n", tc.desc))
+			assert.Equal(t, 0, len(e.alarms), fmt.Sprintf("%s: expected no webhook notifications\n", tc.desc))
+			continue
+		}
+
+		msg := <-e.msgs
+		assert.Equal(t, e.chs[1], msg.Channel, fmt.Sprintf("%s: expected channel %s got %s\n", tc.desc, e.chs[1], msg.Channel))
+		assert.Equal(t, rules.Protocol, msg.Protocol, fmt.Sprintf("%s: expected protocol %s got %s\n", tc.desc, rules.Protocol, msg.Protocol))
+		assert.Equal(t, tc.msg.Payload, msg.Payload, fmt.Sprintf("%s: expected republished payload\n", tc.desc))
+
+		alarm := <-e.alarms
+		assert.Equal(t, saved.ID, alarm.RuleID, fmt.Sprintf("%s: expected rule %s got %s\n", tc.desc, saved.ID, alarm.RuleID))
+		assert.Equal(t, 2, len(alarm.Records), fmt.Sprintf("%s: expected 2 records got %d\n", tc.desc, len(alarm.Records)))
+	}
+
+	page, err := e.svc.ListAlarms(context.Background(), token, saved.ID, 0, 10)
+	require.Nil(t, err, fmt.Sprintf("unexpected error: %s", err))
+	assert.Equal(t, uint64(1), page.Total, fmt.Sprintf("expected 1 alarm got %d\n", page.Total))
+
+	page, err = e.svc.ListAlarms(context.Background(), otherToken, "", 0, 10)
+	require.Nil(t, err, fmt.Sprintf("unexpected error: %s", err))
+	assert.Equal(t, uint64(0), page.Total, fmt.Sprintf("expected no alarms of another user got %d\n", page.Total))
+
+	_, err = e.svc.ListAlarms(context.Background(), wrongValue, "", 0, 10)
+	assert.True(t, errors.Contains(err, rules.ErrUnauthorizedAccess), fmt.Sprintf("expected %s got %s\n", rules.ErrUnauthorizedAccess, err))
+}
+
+func TestConsumeFailingRule(t *testing.T) {
+	e := newEnv(t)
+
+	failing := newRule(e.chs[0])
+	failing.Actions = []rules.Action{{Type: rules.ActionWebhook, URL: mocks.FailingURL}}
+	_, err := e.svc.CreateRule(context.Background(), token, failing)
+	require.Nil(t, err, fmt.Sprintf("unexpected error: %s", err))
+
+	rule := newRule(e.chs[0])
+	saved, err := e.svc.CreateRule(context.Background(), token, rule)
+	require.Nil(t, err, fmt.Sprintf("unexpected error: %s", err))
+
+	err = e.svc.Consume(newMessage(e.chs[0], 40))
+	assert.NotNil(t, err, "expected error of the failing rule\n")
+
+	page, err := e.svc.ListAlarms(context.Background(), token, saved.ID, 0, 10)
+	require.Nil(t, err, fmt.Sprintf("unexpected error: %s", err))
+	assert.Equal(t, uint64(1), page.Total, fmt.Sprintf("failing rule should not prevent other rules from firing: got %d alarms\n", page.Total))
+}
+
+func TestConsumeWindowDuration(t *testing.T) {
+	e := newEnv(t)
+
+	rule := newRule(e.chs[0])
+	rule.Window = rules.Window{Count: 2, Duration: time.Minute}
+	_, err := e.svc.CreateRule(context.Background(), token, rule)
+	require.Nil(t, err, fmt.Sprintf("unexpected error: %s", err))
+
+	now := time.Now().Unix()
+	stale := newMessage(e.chs[0], 35)
+	stale.Payload = []byte(fmt.Sprintf(`[{"n":"temperature","t":%d,"v":35}]`, now-120))
+	fresh := newMessage(e.chs[0], 35)
+	fresh.Payload = []byte(fmt.Sprintf(`[{"n":"temperature","t":%d,"v":35}]`, now))
+
+	for _, msg := range []messaging.Message{stale, fresh} {
+		err := e.svc.Consume(msg)
+		assert.Nil(t, err, fmt.Sprintf("expected no error got %s\n", err))
+	}
+
+	page, err := e.svc.ListAlarms(context.Background(), token, "", 0, 10)
+	require.Nil(t, err, fmt.Sprintf("unexpected error: %s", err))
+	assert.Equal(t, uint64(0), page.Total, fmt.Sprintf("records outside of the window should not fire the rule: got %d alarms\n", page.Total))
+}
@@ -0,0 +1,5 @@
+// Copyright (c) Mainflux
+// SPDX-License-Identifier: Apache-2.0
+
+// Package webhook contains the HTTP implementation of the rules notifier.
+package webhook
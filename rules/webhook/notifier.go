@@ -0,0 +1,48 @@
+// Copyright (c) Mainflux
+// SPDX-License-Identifier: Apache-2.0
+
+package webhook
+
+import (
+	"bytes"
+	"encoding/json"
+	"net/http"
+
+	"github.com/mainflux/mainflux/pkg/errors"
+	"github.com/mainflux/mainflux/rules"
+)
+
+const contentType = "application/json"
+
+var errNotify = errors.New("failed to notify webhook")
+
+var _ rules.Notifier = (*notifier)(nil)
+
+type notifier struct {
+	client *http.Client
+}
+
+// New returns a notifier that sends alarms to webhooks as JSON encoded
+// HTTP POST requests.
+func New(client *http.Client) rules.Notifier {
+	return &notifier{client: client}
+}
+
+func (n *notifier) Notify(url string, alarm rules.Alarm) error {
+	body, err := json.Marshal(alarm)
+	if err != nil {
+		return err
+	}
+
+	resp, err := n.client.Post(url, contentType, bytes.NewReader(body))
+	if err != nil {
+		return errors.Wrap(errNotify, err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode < http.StatusOK || resp.StatusCode >= http.StatusMultipleChoices {
+		return errors.Wrap(errNotify, errors.New(resp.Status))
+	}
+
+	return nil
+}
@@ -0,0 +1,80 @@
+// Copyright (c) Mainflux
+// SPDX-License-Identifier: Apache-2.0
+
+package webhook_test
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/mainflux/mainflux/pkg/transformers/senml"
+	"github.com/mainflux/mainflux/rules"
+	"github.com/mainflux/mainflux/rules/webhook"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestNotify(t *testing.T) {
+	received := make(chan rules.Alarm, 1)
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		var alarm rules.Alarm
+		if err := json.NewDecoder(r.Body).Decode(&alarm); err != nil {
+			w.WriteHeader(http.StatusBadRequest)
+			return
+		}
+		received <- alarm
+		w.WriteHeader(http.StatusNoContent)
+	}))
+	defer server.Close()
+
+	failing := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusInternalServerError)
+	}))
+	defer failing.Close()
+
+	closed := httptest.NewServer(http.NotFoundHandler())
+	closed.Close()
+
+	notifier := webhook.New(http.DefaultClient)
+
+	v := 42.0
+	alarm := rules.Alarm{
+		ID:      "1",
+		RuleID:  "2",
+		Channel: "3",
+		Records: []senml.Message{{Name: "temperature", Value: &v}},
+	}
+
+	cases := []struct {
+		desc string
+		url  string
+		err  bool
+	}{
+		{
+			desc: "notify webhook",
+			url:  server.URL,
+			err:  false,
+		},
+		{
+			desc: "notify webhook responding with error",
+			url:  failing.URL,
+			err:  true,
+		},
+		{
+			desc: "notify unreachable webhook",
+			url:  closed.URL,
+			err:  true,
+		},
+	}
+
+	for _, tc := range cases {
+		err := notifier.Notify(tc.url, alarm)
+		assert.Equal(t, tc.err, err != nil, fmt.Sprintf("%s: expected error %t got %s", tc.desc, tc.err, err))
+	}
+
+	res := <-received
+	assert.Equal(t, alarm.RuleID, res.RuleID, fmt.Sprintf("expected rule %s got %s", alarm.RuleID, res.RuleID))
+	assert.Equal(t, alarm.Records, res.Records, fmt.Sprintf("expected records %v got %v", alarm.Records, res.Records))
+}
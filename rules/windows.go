@@ -0,0 +1,62 @@
+// Copyright (c) Mainflux
+// SPDX-License-Identifier: Apache-2.0
+
+package rules
+
+import (
+	"sync"
+
+	"github.com/mainflux/mainflux/pkg/transformers/senml"
+)
+
+// windows keeps track of the records matched by the rules that are still
+// waiting for their window to be filled.
+type windows struct {
+	mu      sync.Mutex
+	records map[string][]senml.Message
+}
+
+func newWindows() *windows {
+	return &windows{
+		records: make(map[string][]senml.Message),
+	}
+}
+
+// add adds the matched record to the rule window. If the window is filled,
+// it is cleared and the records that filled it are returned. Otherwise, nil
+// is returned.
+func (w *windows) add(rule Rule, rec senml.Message) []senml.Message {
+	count := rule.Window.Count
+	if count <= 1 {
+		return []senml.Message{rec}
+	}
+
+	w.mu.Lock()
+	defer w.mu.Unlock()
+
+	recs := append(w.records[rule.ID], rec)
+	if rule.Window.Duration > 0 {
+		start := rec.Time - rule.Window.Duration.Seconds()
+		i := 0
+		for i < len(recs) && recs[i].Time <= start {
+			i++
+		}
+		recs = recs[i:]
+	}
+
+	if uint64(len(recs)) < count {
+		w.records[rule.ID] = recs
+		return nil
+	}
+
+	delete(w.records, rule.ID)
+	return recs
+}
+
+// reset discards the records collected for the rule.
+func (w *windows) reset(id string) {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+
+	delete(w.records, id)
+}
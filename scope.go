@@ -0,0 +1,48 @@
+// Copyright (c) Mainflux
+// SPDX-License-Identifier: Apache-2.0
+
+package mainflux
+
+// Resources that the scope of an identity can refer to.
+const (
+	ThingsResource   = "things"
+	ChannelsResource = "channels"
+	GroupsResource   = "groups"
+	MessagesResource = "messages"
+	TwinsResource    = "twins"
+	ConfigsResource  = "configs"
+)
+
+// Actions that the scope of an identity can allow.
+const (
+	ReadAction  = "read"
+	WriteAction = "write"
+)
+
+// Allows checks whether the identity is allowed to perform the action on
+// the resource identified by the provided ID. Identities without scopes
+// are not restricted. Empty ID stands for operations that are not bound
+// to a single resource (e.g. listing or creation), which are allowed only
+// by scopes that are not limited to a set of resource IDs.
+func (m *UserIdentity) Allows(resource, action, id string) bool {
+	scopes := m.GetScopes()
+	if len(scopes) == 0 {
+		return true
+	}
+
+	for _, s := range scopes {
+		if s.GetResource() != resource || s.GetAction() != action {
+			continue
+		}
+		if len(s.GetIds()) == 0 {
+			return true
+		}
+		for _, sid := range s.GetIds() {
+			if id != "" && sid == id {
+				return true
+			}
+		}
+	}
+
+	return false
+}
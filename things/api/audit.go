@@ -0,0 +1,245 @@
+// Copyright (c) Mainflux
+// SPDX-License-Identifier: Apache-2.0
+
+package api
+
+import (
+	"context"
+	"strings"
+
+	"github.com/mainflux/mainflux/pkg/audit"
+	"github.com/mainflux/mainflux/things"
+)
+
+const entitySeparator = ","
+
+var _ things.Service = (*auditMiddleware)(nil)
+
+type auditMiddleware struct {
+	rec audit.Recorder
+	svc things.Service
+}
+
+// AuditMiddleware records the operations changing things, channels, groups
+// and the connections between them. Read-only operations and the access
+// checks of the protocol adapters are not recorded.
+func AuditMiddleware(svc things.Service, rec audit.Recorder) things.Service {
+	return &auditMiddleware{rec, svc}
+}
+
+func (am *auditMiddleware) CreateThings(ctx context.Context, token string, ths ...things.Thing) (saved []things.Thing, err error) {
+	e := am.rec.Event(ctx, token, "create_things", "")
+	defer func() {
+		ids := []string{}
+		for _, th := range saved {
+			ids = append(ids, th.ID)
+		}
+		e.Entity = strings.Join(ids, entitySeparator)
+		am.rec.Record(ctx, e, err)
+	}()
+
+	return am.svc.CreateThings(ctx, token, ths...)
+}
+
+func (am *auditMiddleware) UpdateThing(ctx context.Context, token string, thing things.Thing) (err error) {
+	e := am.rec.Event(ctx, token, "update_thing", thing.ID)
+	defer func() { am.rec.Record(ctx, e, err) }()
+
+	return am.svc.UpdateThing(ctx, token, thing)
+}
+
+func (am *auditMiddleware) UpdateKey(ctx context.Context, token, id, key string) (err error) {
+	e := am.rec.Event(ctx, token, "update_key", id)
+	defer func() { am.rec.Record(ctx, e, err) }()
+
+	return am.svc.UpdateKey(ctx, token, id, key)
+}
+
+func (am *auditMiddleware) ShareThing(ctx context.Context, token, id, group string) (err error) {
+	e := am.rec.Event(ctx, token, "share_thing", id)
+	defer func() { am.rec.Record(ctx, e, err) }()
+
+	return am.svc.ShareThing(ctx, token, id, group)
+}
+
+func (am *auditMiddleware) ViewThing(ctx context.Context, token, id string) (things.Thing, error) {
+	return am.svc.ViewThing(ctx, token, id)
+}
+
+func (am *auditMiddleware) ListThings(ctx context.Context, token string, offset, limit uint64, cursor, name string, metadata things.Metadata) (things.Page, error) {
+	return am.svc.ListThings(ctx, token, offset, limit, cursor, name, metadata)
+}
+
+func (am *auditMiddleware) ListThingsByChannel(ctx context.Context, token, id string, offset, limit uint64, connected bool) (things.Page, error) {
+	return am.svc.ListThingsByChannel(ctx, token, id, offset, limit, connected)
+}
+
+func (am *auditMiddleware) RemoveThing(ctx context.Context, token, id string) (err error) {
+	e := am.rec.Event(ctx, token, "remove_thing", id)
+	defer func() { am.rec.Record(ctx, e, err) }()
+
+	return am.svc.RemoveThing(ctx, token, id)
+}
+
+func (am *auditMiddleware) CreateChannels(ctx context.Context, token string, channels ...things.Channel) (saved []things.Channel, err error) {
+	e := am.rec.Event(ctx, token, "create_channels", "")
+	defer func() {
+		ids := []string{}
+		for _, ch := range saved {
+			ids = append(ids, ch.ID)
+		}
+		e.Entity = strings.Join(ids, entitySeparator)
+		am.rec.Record(ctx, e, err)
+	}()
+
+	return am.svc.CreateChannels(ctx, token, channels...)
+}
+
+func (am *auditMiddleware) UpdateChannel(ctx context.Context, token string, channel things.Channel) (err error) {
+	e := am.rec.Event(ctx, token, "update_channel", channel.ID)
+	defer func() { am.rec.Record(ctx, e, err) }()
+
+	return am.svc.UpdateChannel(ctx, token, channel)
+}
+
+func (am *auditMiddleware) ShareChannel(ctx context.Context, token, id, group string) (err error) {
+	e := am.rec.Event(ctx, token, "share_channel", id)
+	defer func() { am.rec.Record(ctx, e, err) }()
+
+	return am.svc.ShareChannel(ctx, token, id, group)
+}
+
+func (am *auditMiddleware) ViewChannel(ctx context.Context, token, id string) (things.Channel, error) {
+	return am.svc.ViewChannel(ctx, token, id)
+}
+
+func (am *auditMiddleware) ListChannels(ctx context.Context, token string, offset, limit uint64, cursor, name string, metadata things.Metadata) (things.ChannelsPage, error) {
+	return am.svc.ListChannels(ctx, token, offset, limit, cursor, name, metadata)
+}
+
+func (am *auditMiddleware) ListChannelsByThing(ctx context.Context, token, id string, offset, limit uint64, connected bool) (things.ChannelsPage, error) {
+	return am.svc.ListChannelsByThing(ctx, token, id, offset, limit, connected)
+}
+
+func (am *auditMiddleware) RemoveChannel(ctx context.Context, token, id string) (err error) {
+	e := am.rec.Event(ctx, token, "remove_channel", id)
+	defer func() { am.rec.Record(ctx, e, err) }()
+
+	return am.svc.RemoveChannel(ctx, token, id)
+}
+
+func (am *auditMiddleware) Connect(ctx context.Context, token string, chIDs, thIDs, actions []string) (err error) {
+	e := am.rec.Event(ctx, token, "connect", strings.Join(chIDs, entitySeparator))
+	defer func() { am.rec.Record(ctx, e, err) }()
+
+	return am.svc.Connect(ctx, token, chIDs, thIDs, actions)
+}
+
+func (am *auditMiddleware) Disconnect(ctx context.Context, token, chanID, thingID string) (err error) {
+	e := am.rec.Event(ctx, token, "disconnect", chanID)
+	defer func() { am.rec.Record(ctx, e, err) }()
+
+	return am.svc.Disconnect(ctx, token, chanID, thingID)
+}
+
+func (am *auditMiddleware) CanAccessByKey(ctx context.Context, id, key, action string) (string, error) {
+	return am.svc.CanAccessByKey(ctx, id, key, action)
+}
+
+func (am *auditMiddleware) CanAccessByID(ctx context.Context, chanID, thingID, action string) error {
+	return am.svc.CanAccessByID(ctx, chanID, thingID, action)
+}
+
+func (am *auditMiddleware) Identify(ctx context.Context, key string) (string, error) {
+	return am.svc.Identify(ctx, key)
+}
+
+func (am *auditMiddleware) IsChannelOwner(ctx context.Context, owner, chanID string) error {
+	return am.svc.IsChannelOwner(ctx, owner, chanID)
+}
+
+func (am *auditMiddleware) ChannelSchema(ctx context.Context, chanID string) ([]byte, error) {
+	return am.svc.ChannelSchema(ctx, chanID)
+}
+
+func (am *auditMiddleware) CreateGroup(ctx context.Context, token string, group things.Group) (saved things.Group, err error) {
+	e := am.rec.Event(ctx, token, "create_group", "")
+	defer func() {
+		e.Entity = saved.ID
+		am.rec.Record(ctx, e, err)
+	}()
+
+	return am.svc.CreateGroup(ctx, token, group)
+}
+
+func (am *auditMiddleware) UpdateGroup(ctx context.Context, token string, group things.Group) (err error) {
+	e := am.rec.Event(ctx, token, "update_group", group.ID)
+	defer func() { am.rec.Record(ctx, e, err) }()
+
+	return am.svc.UpdateGroup(ctx, token, group)
+}
+
+func (am *auditMiddleware) ViewGroup(ctx context.Context, token, id string) (things.Group, error) {
+	return am.svc.ViewGroup(ctx, token, id)
+}
+
+func (am *auditMiddleware) ListGroups(ctx context.Context, token, parentID string, offset, limit uint64, metadata things.Metadata) (things.GroupsPage, error) {
+	return am.svc.ListGroups(ctx, token, parentID, offset, limit, metadata)
+}
+
+func (am *auditMiddleware) ListThingsByGroup(ctx context.Context, token, groupID string, offset, limit uint64) (things.Page, error) {
+	return am.svc.ListThingsByGroup(ctx, token, groupID, offset, limit)
+}
+
+func (am *auditMiddleware) ListChannelsByGroup(ctx context.Context, token, groupID string, offset, limit uint64) (things.ChannelsPage, error) {
+	return am.svc.ListChannelsByGroup(ctx, token, groupID, offset, limit)
+}
+
+func (am *auditMiddleware) RemoveGroup(ctx context.Context, token, id string) (err error) {
+	e := am.rec.Event(ctx, token, "remove_group", id)
+	defer func() { am.rec.Record(ctx, e, err) }()
+
+	return am.svc.RemoveGroup(ctx, token, id)
+}
+
+func (am *auditMiddleware) AssignThings(ctx context.Context, token, groupID string, thIDs ...string) (err error) {
+	e := am.rec.Event(ctx, token, "assign_things", groupID)
+	defer func() { am.rec.Record(ctx, e, err) }()
+
+	return am.svc.AssignThings(ctx, token, groupID, thIDs...)
+}
+
+func (am *auditMiddleware) UnassignThings(ctx context.Context, token, groupID string, thIDs ...string) (err error) {
+	e := am.rec.Event(ctx, token, "unassign_things", groupID)
+	defer func() { am.rec.Record(ctx, e, err) }()
+
+	return am.svc.UnassignThings(ctx, token, groupID, thIDs...)
+}
+
+func (am *auditMiddleware) AssignChannels(ctx context.Context, token, groupID string, chIDs ...string) (err error) {
+	e := am.rec.Event(ctx, token, "assign_channels", groupID)
+	defer func() { am.rec.Record(ctx, e, err) }()
+
+	return am.svc.AssignChannels(ctx, token, groupID, chIDs...)
+}
+
+func (am *auditMiddleware) UnassignChannels(ctx context.Context, token, groupID string, chIDs ...string) (err error) {
+	e := am.rec.Event(ctx, token, "unassign_channels", groupID)
+	defer func() { am.rec.Record(ctx, e, err) }()
+
+	return am.svc.UnassignChannels(ctx, token, groupID, chIDs...)
+}
+
+func (am *auditMiddleware) ConnectGroup(ctx context.Context, token, groupID string, chIDs, actions []string) (err error) {
+	e := am.rec.Event(ctx, token, "connect_group", groupID)
+	defer func() { am.rec.Record(ctx, e, err) }()
+
+	return am.svc.ConnectGroup(ctx, token, groupID, chIDs, actions)
+}
+
+func (am *auditMiddleware) DisconnectGroup(ctx context.Context, token, groupID, chanID string) (err error) {
+	e := am.rec.Event(ctx, token, "disconnect_group", groupID)
+	defer func() { am.rec.Record(ctx, e, err) }()
+
+	return am.svc.DisconnectGroup(ctx, token, groupID, chanID)
+}
@@ -22,6 +22,8 @@ type grpcClient struct {
 	timeout        time.Duration
 	canAccessByKey endpoint.Endpoint
 	canAccessByID  endpoint.Endpoint
+	isChannelOwner endpoint.Endpoint
+	channelSchema  endpoint.Endpoint
 	identify       endpoint.Endpoint
 }
 
@@ -47,6 +49,22 @@ func NewClient(conn *grpc.ClientConn, tracer opentracing.Tracer, timeout time.Du
 			decodeEmptyResponse,
 			empty.Empty{},
 		).Endpoint()),
+		isChannelOwner: kitot.TraceClient(tracer, "is_channel_owner")(kitgrpc.NewClient(
+			conn,
+			svcName,
+			"IsChannelOwner",
+			encodeIsChannelOwnerRequest,
+			decodeEmptyResponse,
+			empty.Empty{},
+		).Endpoint()),
+		channelSchema: kitot.TraceClient(tracer, "channel_schema")(kitgrpc.NewClient(
+			conn,
+			svcName,
+			"ChannelSchema",
+			encodeChannelSchemaRequest,
+			decodeChannelSchemaResponse,
+			mainflux.ChannelSchemaRes{},
+		).Endpoint()),
 		identify: kitot.TraceClient(tracer, "identify")(kitgrpc.NewClient(
 			conn,
 			svcName,
@@ -65,6 +83,7 @@ func (client grpcClient) CanAccessByKey(ctx context.Context, req *mainflux.Acces
 	ar := AccessByKeyReq{
 		thingKey: req.GetToken(),
 		chanID:   req.GetChanID(),
+		action:   req.GetAction(),
 	}
 	res, err := client.canAccessByKey(ctx, ar)
 	if err != nil {
@@ -76,7 +95,7 @@ func (client grpcClient) CanAccessByKey(ctx context.Context, req *mainflux.Acces
 }
 
 func (client grpcClient) CanAccessByID(ctx context.Context, req *mainflux.AccessByIDReq, _ ...grpc.CallOption) (*empty.Empty, error) {
-	ar := accessByIDReq{thingID: req.GetThingID(), chanID: req.GetChanID()}
+	ar := accessByIDReq{thingID: req.GetThingID(), chanID: req.GetChanID(), action: req.GetAction()}
 	res, err := client.canAccessByID(ctx, ar)
 	if err != nil {
 		return nil, err
@@ -86,6 +105,32 @@ func (client grpcClient) CanAccessByID(ctx context.Context, req *mainflux.Access
 	return &empty.Empty{}, er.err
 }
 
+func (client grpcClient) IsChannelOwner(ctx context.Context, req *mainflux.ChannelOwnerReq, _ ...grpc.CallOption) (*empty.Empty, error) {
+	ctx, cancel := context.WithTimeout(ctx, client.timeout)
+	defer cancel()
+
+	res, err := client.isChannelOwner(ctx, channelOwnerReq{owner: req.GetOwner(), chanID: req.GetChanID()})
+	if err != nil {
+		return nil, err
+	}
+
+	er := res.(emptyRes)
+	return &empty.Empty{}, er.err
+}
+
+func (client grpcClient) ChannelSchema(ctx context.Context, req *mainflux.ChannelID, _ ...grpc.CallOption) (*mainflux.ChannelSchemaRes, error) {
+	ctx, cancel := context.WithTimeout(ctx, client.timeout)
+	defer cancel()
+
+	res, err := client.channelSchema(ctx, channelSchemaReq{chanID: req.GetValue()})
+	if err != nil {
+		return nil, err
+	}
+
+	sr := res.(channelSchemaRes)
+	return &mainflux.ChannelSchemaRes{Value: sr.schema}, sr.err
+}
+
 func (client grpcClient) Identify(ctx context.Context, req *mainflux.Token, _ ...grpc.CallOption) (*mainflux.ThingID, error) {
 	ctx, cancel := context.WithTimeout(ctx, client.timeout)
 	defer cancel()
@@ -101,12 +146,22 @@ func (client grpcClient) Identify(ctx context.Context, req *mainflux.Token, _ ..
 
 func encodeCanAccessByKeyRequest(_ context.Context, grpcReq interface{}) (interface{}, error) {
 	req := grpcReq.(AccessByKeyReq)
-	return &mainflux.AccessByKeyReq{Token: req.thingKey, ChanID: req.chanID}, nil
+	return &mainflux.AccessByKeyReq{Token: req.thingKey, ChanID: req.chanID, Action: req.action}, nil
 }
 
 func encodeCanAccessByIDRequest(_ context.Context, grpcReq interface{}) (interface{}, error) {
 	req := grpcReq.(accessByIDReq)
-	return &mainflux.AccessByIDReq{ThingID: req.thingID, ChanID: req.chanID}, nil
+	return &mainflux.AccessByIDReq{ThingID: req.thingID, ChanID: req.chanID, Action: req.action}, nil
+}
+
+func encodeIsChannelOwnerRequest(_ context.Context, grpcReq interface{}) (interface{}, error) {
+	req := grpcReq.(channelOwnerReq)
+	return &mainflux.ChannelOwnerReq{Owner: req.owner, ChanID: req.chanID}, nil
+}
+
+func encodeChannelSchemaRequest(_ context.Context, grpcReq interface{}) (interface{}, error) {
+	req := grpcReq.(channelSchemaReq)
+	return &mainflux.ChannelID{Value: req.chanID}, nil
 }
 
 func encodeIdentifyRequest(_ context.Context, grpcReq interface{}) (interface{}, error) {
@@ -119,6 +174,11 @@ func decodeIdentityResponse(_ context.Context, grpcRes interface{}) (interface{}
 	return identityRes{id: res.GetValue(), err: nil}, nil
 }
 
+func decodeChannelSchemaResponse(_ context.Context, grpcRes interface{}) (interface{}, error) {
+	res := grpcRes.(*mainflux.ChannelSchemaRes)
+	return channelSchemaRes{schema: res.GetValue(), err: nil}, nil
+}
+
 func decodeEmptyResponse(_ context.Context, _ interface{}) (interface{}, error) {
 	return emptyRes{}, nil
 }
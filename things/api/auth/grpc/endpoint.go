@@ -17,7 +17,7 @@ func canAccessEndpoint(svc things.Service) endpoint.Endpoint {
 			return nil, err
 		}
 
-		id, err := svc.CanAccessByKey(ctx, req.chanID, req.thingKey)
+		id, err := svc.CanAccessByKey(ctx, req.chanID, req.thingKey, req.action)
 		if err != nil {
 			return identityRes{err: err}, err
 		}
@@ -32,11 +32,38 @@ func canAccessByIDEndpoint(svc things.Service) endpoint.Endpoint {
 			return nil, err
 		}
 
-		err := svc.CanAccessByID(ctx, req.chanID, req.thingID)
+		err := svc.CanAccessByID(ctx, req.chanID, req.thingID, req.action)
 		return emptyRes{err: err}, err
 	}
 }
 
+func isChannelOwnerEndpoint(svc things.Service) endpoint.Endpoint {
+	return func(ctx context.Context, request interface{}) (interface{}, error) {
+		req := request.(channelOwnerReq)
+		if err := req.validate(); err != nil {
+			return nil, err
+		}
+
+		err := svc.IsChannelOwner(ctx, req.owner, req.chanID)
+		return emptyRes{err: err}, err
+	}
+}
+
+func channelSchemaEndpoint(svc things.Service) endpoint.Endpoint {
+	return func(ctx context.Context, request interface{}) (interface{}, error) {
+		req := request.(channelSchemaReq)
+		if err := req.validate(); err != nil {
+			return nil, err
+		}
+
+		s, err := svc.ChannelSchema(ctx, req.chanID)
+		if err != nil {
+			return channelSchemaRes{err: err}, err
+		}
+		return channelSchemaRes{schema: s, err: nil}, nil
+	}
+}
+
 func identifyEndpoint(svc things.Service) endpoint.Endpoint {
 	return func(ctx context.Context, request interface{}) (interface{}, error) {
 		req := request.(identifyReq)
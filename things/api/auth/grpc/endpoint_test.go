@@ -12,6 +12,7 @@ import (
 	"github.com/opentracing/opentracing-go/mocktracer"
 
 	"github.com/mainflux/mainflux"
+	"github.com/mainflux/mainflux/pkg/schema"
 	"github.com/mainflux/mainflux/things"
 	grpcapi "github.com/mainflux/mainflux/things/api/auth/grpc"
 	"github.com/stretchr/testify/assert"
@@ -37,7 +38,7 @@ func TestCanAccessByKey(t *testing.T) {
 	chs, err := svc.CreateChannels(context.Background(), token, channel)
 	require.Nil(t, err, fmt.Sprintf("unexpected error: %s\n", err))
 	ch := chs[0]
-	err = svc.Connect(context.Background(), token, []string{ch.ID}, []string{th1.ID})
+	err = svc.Connect(context.Background(), token, []string{ch.ID}, []string{th1.ID}, []string{things.ActionPublish})
 	require.Nil(t, err, fmt.Sprintf("unexpected error: %s\n", err))
 
 	usersAddr := fmt.Sprintf("localhost:%d", port)
@@ -50,37 +51,62 @@ func TestCanAccessByKey(t *testing.T) {
 	cases := map[string]struct {
 		key     string
 		chanID  string
+		action  string
 		thingID string
 		code    codes.Code
 	}{
 		"check if connected thing can access existing channel": {
 			key:     th1.Key,
 			chanID:  ch.ID,
+			action:  things.ActionPublish,
 			thingID: th1.ID,
 			code:    codes.OK,
 		},
+		"check if connected thing can perform forbidden action": {
+			key:     th1.Key,
+			chanID:  ch.ID,
+			action:  things.ActionSubscribe,
+			thingID: wrongID,
+			code:    codes.PermissionDenied,
+		},
+		"check if connected thing can perform unknown action": {
+			key:     th1.Key,
+			chanID:  ch.ID,
+			action:  wrong,
+			thingID: wrongID,
+			code:    codes.InvalidArgument,
+		},
+		"check if connected thing can access channel without action": {
+			key:     th1.Key,
+			chanID:  ch.ID,
+			thingID: wrongID,
+			code:    codes.InvalidArgument,
+		},
 		"check if unconnected thing can access existing channel": {
 			key:     th2.Key,
 			chanID:  ch.ID,
+			action:  things.ActionPublish,
 			thingID: wrongID,
 			code:    codes.PermissionDenied,
 		},
 		"check if thing with wrong access key can access existing channel": {
 			key:     wrong,
 			chanID:  ch.ID,
+			action:  things.ActionPublish,
 			thingID: wrongID,
 			code:    codes.NotFound,
 		},
 		"check if connected thing can access non-existent channel": {
 			key:     th1.Key,
 			chanID:  wrongID,
+			action:  things.ActionPublish,
 			thingID: wrongID,
 			code:    codes.InvalidArgument,
 		},
 	}
 
 	for desc, tc := range cases {
-		id, err := cli.CanAccessByKey(ctx, &mainflux.AccessByKeyReq{Token: tc.key, ChanID: tc.chanID})
+		id, err := cli.CanAccessByKey(ctx, &mainflux.AccessByKeyReq{Token: tc.key, ChanID: tc.chanID, Action: tc.action})
 		e, ok := status.FromError(err)
 		assert.True(t, ok, "OK expected to be true")
 		assert.Equal(t, tc.thingID, id.GetValue(), fmt.Sprintf("%s: expected %s got %s", desc, tc.thingID, id.GetValue()))
@@ -99,7 +125,7 @@ func TestCanAccessByID(t *testing.T) {
 	chs, err := svc.CreateChannels(context.Background(), token, channel)
 	require.Nil(t, err, fmt.Sprintf("unexpected error: %s\n", err))
 	ch := chs[0]
-	svc.Connect(context.Background(), token, []string{ch.ID}, []string{th2.ID})
+	svc.Connect(context.Background(), token, []string{ch.ID}, []string{th2.ID}, nil)
 
 	usersAddr := fmt.Sprintf("localhost:%d", port)
 	conn, err := grpc.Dial(usersAddr, grpc.WithInsecure())
@@ -111,37 +137,49 @@ func TestCanAccessByID(t *testing.T) {
 	cases := map[string]struct {
 		chanID  string
 		thingID string
+		action  string
 		code    codes.Code
 	}{
 		"check if connected thing can access existing channel": {
 			chanID:  ch.ID,
 			thingID: th2.ID,
+			action:  things.ActionSubscribe,
 			code:    codes.OK,
 		},
+		"check if connected thing can perform unknown action": {
+			chanID:  ch.ID,
+			thingID: th2.ID,
+			action:  wrong,
+			code:    codes.InvalidArgument,
+		},
 		"check if unconnected thing can access existing channel": {
 			chanID:  ch.ID,
 			thingID: th1.ID,
+			action:  things.ActionSubscribe,
 			code:    codes.PermissionDenied,
 		},
 		"check if connected thing can access non-existent channel": {
 			chanID:  wrongID,
 			thingID: th2.ID,
+			action:  things.ActionSubscribe,
 			code:    codes.InvalidArgument,
 		},
 		"check if thing with empty ID can access existing channel": {
 			chanID:  ch.ID,
 			thingID: "",
+			action:  things.ActionSubscribe,
 			code:    codes.InvalidArgument,
 		},
 		"check if connected thing can access channel with empty ID": {
 			chanID:  "",
 			thingID: th2.ID,
+			action:  things.ActionSubscribe,
 			code:    codes.InvalidArgument,
 		},
 	}
 
 	for desc, tc := range cases {
-		_, err := cli.CanAccessByID(ctx, &mainflux.AccessByIDReq{ThingID: tc.thingID, ChanID: tc.chanID})
+		_, err := cli.CanAccessByID(ctx, &mainflux.AccessByIDReq{ThingID: tc.thingID, ChanID: tc.chanID, Action: tc.action})
 		e, ok := status.FromError(err)
 		assert.True(t, ok, "OK expected to be true")
 		assert.Equal(t, tc.code, e.Code(), fmt.Sprintf("%s: expected %s got %s", desc, tc.code, e.Code()))
@@ -185,3 +223,56 @@ func TestIdentify(t *testing.T) {
 		assert.Equal(t, tc.code, e.Code(), fmt.Sprintf("%s: expected %s got %s", desc, tc.code, e.Code()))
 	}
 }
+
+func TestChannelSchema(t *testing.T) {
+	validated := things.Channel{
+		Name: "validated",
+		Metadata: map[string]interface{}{
+			schema.Key: map[string]interface{}{"json": map[string]interface{}{"type": "object"}},
+		},
+	}
+	chs, err := svc.CreateChannels(context.Background(), token, channel, validated)
+	require.Nil(t, err, fmt.Sprintf("unexpected error: %s\n", err))
+
+	usersAddr := fmt.Sprintf("localhost:%d", port)
+	conn, err := grpc.Dial(usersAddr, grpc.WithInsecure())
+	require.Nil(t, err, fmt.Sprintf("unexpected error: %s\n", err))
+	cli := grpcapi.NewClient(conn, mocktracer.New(), time.Second)
+	ctx, cancel := context.WithTimeout(context.Background(), time.Second)
+	defer cancel()
+
+	cases := map[string]struct {
+		chanID string
+		schema string
+		code   codes.Code
+	}{
+		"retrieve schema of channel without schema": {
+			chanID: chs[0].ID,
+			schema: "",
+			code:   codes.OK,
+		},
+		"retrieve schema of channel with schema": {
+			chanID: chs[1].ID,
+			schema: `{"json":{"type":"object"}}`,
+			code:   codes.OK,
+		},
+		"retrieve schema of non-existing channel": {
+			chanID: wrong,
+			schema: "",
+			code:   codes.NotFound,
+		},
+		"retrieve schema with empty channel ID": {
+			chanID: wrongID,
+			schema: "",
+			code:   codes.InvalidArgument,
+		},
+	}
+
+	for desc, tc := range cases {
+		res, err := cli.ChannelSchema(ctx, &mainflux.ChannelID{Value: tc.chanID})
+		e, ok := status.FromError(err)
+		assert.True(t, ok, "OK expected to be true")
+		assert.Equal(t, tc.schema, string(res.GetValue()), fmt.Sprintf("%s: expected %s got %s", desc, tc.schema, res.GetValue()))
+		assert.Equal(t, tc.code, e.Code(), fmt.Sprintf("%s: expected %s got %s", desc, tc.code, e.Code()))
+	}
+}
@@ -8,23 +8,50 @@ import "github.com/mainflux/mainflux/things"
 type AccessByKeyReq struct {
 	thingKey string
 	chanID   string
+	action   string
 }
 
 func (req AccessByKeyReq) validate() error {
-	if req.chanID == "" || req.thingKey == "" {
+	if req.chanID == "" || req.thingKey == "" || req.action == "" {
 		return things.ErrMalformedEntity
 	}
 
-	return nil
+	return things.ValidateActions([]string{req.action})
 }
 
 type accessByIDReq struct {
 	thingID string
 	chanID  string
+	action  string
 }
 
 func (req accessByIDReq) validate() error {
-	if req.thingID == "" || req.chanID == "" {
+	if req.thingID == "" || req.chanID == "" || req.action == "" {
+		return things.ErrMalformedEntity
+	}
+
+	return things.ValidateActions([]string{req.action})
+}
+
+type channelOwnerReq struct {
+	owner  string
+	chanID string
+}
+
+func (req channelOwnerReq) validate() error {
+	if req.owner == "" || req.chanID == "" {
+		return things.ErrMalformedEntity
+	}
+
+	return nil
+}
+
+type channelSchemaReq struct {
+	chanID string
+}
+
+func (req channelSchemaReq) validate() error {
+	if req.chanID == "" {
 		return things.ErrMalformedEntity
 	}
 
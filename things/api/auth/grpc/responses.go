@@ -8,6 +8,11 @@ type identityRes struct {
 	err error
 }
 
+type channelSchemaRes struct {
+	schema []byte
+	err    error
+}
+
 type emptyRes struct {
 	err error
 }
@@ -21,6 +21,8 @@ var _ mainflux.ThingsServiceServer = (*grpcServer)(nil)
 type grpcServer struct {
 	canAccessByKey kitgrpc.Handler
 	canAccessByID  kitgrpc.Handler
+	isChannelOwner kitgrpc.Handler
+	channelSchema  kitgrpc.Handler
 	identify       kitgrpc.Handler
 }
 
@@ -37,6 +39,16 @@ func NewServer(tracer opentracing.Tracer, svc things.Service) mainflux.ThingsSer
 			decodeCanAccessByIDRequest,
 			encodeEmptyResponse,
 		),
+		isChannelOwner: kitgrpc.NewServer(
+			kitot.TraceServer(tracer, "is_channel_owner")(isChannelOwnerEndpoint(svc)),
+			decodeIsChannelOwnerRequest,
+			encodeEmptyResponse,
+		),
+		channelSchema: kitgrpc.NewServer(
+			kitot.TraceServer(tracer, "channel_schema")(channelSchemaEndpoint(svc)),
+			decodeChannelSchemaRequest,
+			encodeChannelSchemaResponse,
+		),
 		identify: kitgrpc.NewServer(
 			kitot.TraceServer(tracer, "identify")(identifyEndpoint(svc)),
 			decodeIdentifyRequest,
@@ -63,6 +75,24 @@ func (gs *grpcServer) CanAccessByID(ctx context.Context, req *mainflux.AccessByI
 	return res.(*empty.Empty), nil
 }
 
+func (gs *grpcServer) IsChannelOwner(ctx context.Context, req *mainflux.ChannelOwnerReq) (*empty.Empty, error) {
+	_, res, err := gs.isChannelOwner.ServeGRPC(ctx, req)
+	if err != nil {
+		return nil, encodeError(err)
+	}
+
+	return res.(*empty.Empty), nil
+}
+
+func (gs *grpcServer) ChannelSchema(ctx context.Context, req *mainflux.ChannelID) (*mainflux.ChannelSchemaRes, error) {
+	_, res, err := gs.channelSchema.ServeGRPC(ctx, req)
+	if err != nil {
+		return nil, encodeError(err)
+	}
+
+	return res.(*mainflux.ChannelSchemaRes), nil
+}
+
 func (gs *grpcServer) Identify(ctx context.Context, req *mainflux.Token) (*mainflux.ThingID, error) {
 	_, res, err := gs.identify.ServeGRPC(ctx, req)
 	if err != nil {
@@ -74,12 +104,22 @@ func (gs *grpcServer) Identify(ctx context.Context, req *mainflux.Token) (*mainf
 
 func decodeCanAccessByKeyRequest(_ context.Context, grpcReq interface{}) (interface{}, error) {
 	req := grpcReq.(*mainflux.AccessByKeyReq)
-	return AccessByKeyReq{thingKey: req.GetToken(), chanID: req.GetChanID()}, nil
+	return AccessByKeyReq{thingKey: req.GetToken(), chanID: req.GetChanID(), action: req.GetAction()}, nil
 }
 
 func decodeCanAccessByIDRequest(_ context.Context, grpcReq interface{}) (interface{}, error) {
 	req := grpcReq.(*mainflux.AccessByIDReq)
-	return accessByIDReq{thingID: req.GetThingID(), chanID: req.GetChanID()}, nil
+	return accessByIDReq{thingID: req.GetThingID(), chanID: req.GetChanID(), action: req.GetAction()}, nil
+}
+
+func decodeIsChannelOwnerRequest(_ context.Context, grpcReq interface{}) (interface{}, error) {
+	req := grpcReq.(*mainflux.ChannelOwnerReq)
+	return channelOwnerReq{owner: req.GetOwner(), chanID: req.GetChanID()}, nil
+}
+
+func decodeChannelSchemaRequest(_ context.Context, grpcReq interface{}) (interface{}, error) {
+	req := grpcReq.(*mainflux.ChannelID)
+	return channelSchemaReq{chanID: req.GetValue()}, nil
 }
 
 func decodeIdentifyRequest(_ context.Context, grpcReq interface{}) (interface{}, error) {
@@ -92,6 +132,11 @@ func encodeIdentityResponse(_ context.Context, grpcRes interface{}) (interface{}
 	return &mainflux.ThingID{Value: res.id}, encodeError(res.err)
 }
 
+func encodeChannelSchemaResponse(_ context.Context, grpcRes interface{}) (interface{}, error) {
+	res := grpcRes.(channelSchemaRes)
+	return &mainflux.ChannelSchemaRes{Value: res.schema}, encodeError(res.err)
+}
+
 func encodeEmptyResponse(_ context.Context, grpcRes interface{}) (interface{}, error) {
 	res := grpcRes.(emptyRes)
 	return &empty.Empty{}, encodeError(res.err)
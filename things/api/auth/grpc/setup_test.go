@@ -46,9 +46,10 @@ func newService(tokens map[string]string) things.Service {
 	conns := make(chan mocks.Connection)
 	thingsRepo := mocks.NewThingRepository(conns)
 	channelsRepo := mocks.NewChannelRepository(thingsRepo, conns)
+	groupsRepo := mocks.NewGroupRepository(thingsRepo, channelsRepo)
 	chanCache := mocks.NewChannelCache()
 	thingCache := mocks.NewThingCache()
 	uuidProvider := uuid.NewMock()
 
-	return things.New(auth, thingsRepo, channelsRepo, chanCache, thingCache, uuidProvider)
+	return things.New(auth, mocks.NewUsersService(map[string]map[string]string{}), thingsRepo, channelsRepo, groupsRepo, chanCache, thingCache, uuidProvider)
 }
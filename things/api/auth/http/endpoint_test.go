@@ -71,11 +71,12 @@ func newService(tokens map[string]string) things.Service {
 	conns := make(chan mocks.Connection)
 	thingsRepo := mocks.NewThingRepository(conns)
 	channelsRepo := mocks.NewChannelRepository(thingsRepo, conns)
+	groupsRepo := mocks.NewGroupRepository(thingsRepo, channelsRepo)
 	chanCache := mocks.NewChannelCache()
 	thingCache := mocks.NewThingCache()
 	uuidProvider := uuid.NewMock()
 
-	return things.New(auth, thingsRepo, channelsRepo, chanCache, thingCache, uuidProvider)
+	return things.New(auth, mocks.NewUsersService(map[string]map[string]string{}), thingsRepo, channelsRepo, groupsRepo, chanCache, thingCache, uuidProvider)
 }
 
 func newServer(svc things.Service) *httptest.Server {
@@ -156,12 +157,20 @@ func TestCanAccessByKey(t *testing.T) {
 	require.Nil(t, err, fmt.Sprintf("failed to create channel: %s", err))
 	ch := chs[0]
 
-	err = svc.Connect(context.Background(), token, []string{ch.ID}, []string{th.ID})
+	err = svc.Connect(context.Background(), token, []string{ch.ID}, []string{th.ID}, nil)
 	require.Nil(t, err, fmt.Sprintf("failed to connect thing and channel: %s", err))
 
 	data := toJSON(canAccessByKeyReq{
+		Token:  th.Key,
+		Action: things.ActionPublish,
+	})
+	noActionData := toJSON(canAccessByKeyReq{
 		Token: th.Key,
 	})
+	invalidActionData := toJSON(canAccessByKeyReq{
+		Token:  th.Key,
+		Action: wrong,
+	})
 
 	cases := map[string]struct {
 		contentType string
@@ -181,6 +190,18 @@ func TestCanAccessByKey(t *testing.T) {
 			req:         data,
 			status:      http.StatusForbidden,
 		},
+		"check access without action": {
+			contentType: contentType,
+			chanID:      ch.ID,
+			req:         noActionData,
+			status:      http.StatusBadRequest,
+		},
+		"check access with invalid action": {
+			contentType: contentType,
+			chanID:      ch.ID,
+			req:         invalidActionData,
+			status:      http.StatusBadRequest,
+		},
 		"check access with invalid content type": {
 			contentType: wrong,
 			chanID:      ch.ID,
@@ -234,11 +255,19 @@ func TestCanAccessByID(t *testing.T) {
 	require.Nil(t, err, fmt.Sprintf("failed to create channel: %s", err))
 	ch := chs[0]
 
-	err = svc.Connect(context.Background(), token, []string{ch.ID}, []string{th.ID})
+	err = svc.Connect(context.Background(), token, []string{ch.ID}, []string{th.ID}, nil)
 	require.Nil(t, err, fmt.Sprintf("failed to connect thing and channel: %s", err))
 
 	data := toJSON(canAccessByIDReq{
 		ThingID: th.ID,
+		Action:  things.ActionPublish,
+	})
+	noActionData := toJSON(canAccessByIDReq{
+		ThingID: th.ID,
+	})
+	invalidActionData := toJSON(canAccessByIDReq{
+		ThingID: th.ID,
+		Action:  wrong,
 	})
 
 	cases := map[string]struct {
@@ -259,6 +288,18 @@ func TestCanAccessByID(t *testing.T) {
 			req:         data,
 			status:      http.StatusForbidden,
 		},
+		"check access without action": {
+			contentType: contentType,
+			chanID:      ch.ID,
+			req:         noActionData,
+			status:      http.StatusBadRequest,
+		},
+		"check access with invalid action": {
+			contentType: contentType,
+			chanID:      ch.ID,
+			req:         invalidActionData,
+			status:      http.StatusBadRequest,
+		},
 		"check access with invalid content type": {
 			contentType: wrong,
 			chanID:      ch.ID,
@@ -304,9 +345,11 @@ type identifyReq struct {
 }
 
 type canAccessByKeyReq struct {
-	Token string `json:"token"`
+	Token  string `json:"token"`
+	Action string `json:"action,omitempty"`
 }
 
 type canAccessByIDReq struct {
 	ThingID string `json:"thing_id"`
+	Action  string `json:"action,omitempty"`
 }
@@ -121,6 +121,8 @@ func encodeError(_ context.Context, err error, w http.ResponseWriter) {
 	switch err {
 	case things.ErrUnauthorizedAccess:
 		w.WriteHeader(http.StatusUnauthorized)
+	case things.ErrMalformedEntity:
+		w.WriteHeader(http.StatusBadRequest)
 	case things.ErrNotFound:
 		w.WriteHeader(http.StatusNotFound)
 	case things.ErrEntityConnected:
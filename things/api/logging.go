@@ -65,6 +65,19 @@ func (lm *loggingMiddleware) UpdateKey(ctx context.Context, token, id, key strin
 	return lm.svc.UpdateKey(ctx, token, id, key)
 }
 
+func (lm *loggingMiddleware) ShareThing(ctx context.Context, token, id, group string) (err error) {
+	defer func(begin time.Time) {
+		message := fmt.Sprintf("Method share_thing for token %s, thing %s and group %s took %s to complete", token, id, group, time.Since(begin))
+		if err != nil {
+			lm.logger.Warn(fmt.Sprintf("%s with error: %s.", message, err))
+			return
+		}
+		lm.logger.Info(fmt.Sprintf("%s without errors.", message))
+	}(time.Now())
+
+	return lm.svc.ShareThing(ctx, token, id, group)
+}
+
 func (lm *loggingMiddleware) ViewThing(ctx context.Context, token, id string) (thing things.Thing, err error) {
 	defer func(begin time.Time) {
 		message := fmt.Sprintf("Method view_thing for token %s and thing %s took %s to complete", token, id, time.Since(begin))
@@ -78,7 +91,7 @@ func (lm *loggingMiddleware) ViewThing(ctx context.Context, token, id string) (t
 	return lm.svc.ViewThing(ctx, token, id)
 }
 
-func (lm *loggingMiddleware) ListThings(ctx context.Context, token string, offset, limit uint64, name string, metadata things.Metadata) (_ things.Page, err error) {
+func (lm *loggingMiddleware) ListThings(ctx context.Context, token string, offset, limit uint64, cursor, name string, metadata things.Metadata) (_ things.Page, err error) {
 	defer func(begin time.Time) {
 		nlog := ""
 		if name != "" {
@@ -92,7 +105,7 @@ func (lm *loggingMiddleware) ListThings(ctx context.Context, token string, offse
 		lm.logger.Info(fmt.Sprintf("%s without errors.", message))
 	}(time.Now())
 
-	return lm.svc.ListThings(ctx, token, offset, limit, name, metadata)
+	return lm.svc.ListThings(ctx, token, offset, limit, cursor, name, metadata)
 }
 
 func (lm *loggingMiddleware) ListThingsByChannel(ctx context.Context, token, id string, offset, limit uint64, connected bool) (_ things.Page, err error) {
@@ -146,6 +159,19 @@ func (lm *loggingMiddleware) UpdateChannel(ctx context.Context, token string, ch
 	return lm.svc.UpdateChannel(ctx, token, channel)
 }
 
+func (lm *loggingMiddleware) ShareChannel(ctx context.Context, token, id, group string) (err error) {
+	defer func(begin time.Time) {
+		message := fmt.Sprintf("Method share_channel for token %s, channel %s and group %s took %s to complete", token, id, group, time.Since(begin))
+		if err != nil {
+			lm.logger.Warn(fmt.Sprintf("%s with error: %s.", message, err))
+			return
+		}
+		lm.logger.Info(fmt.Sprintf("%s without errors.", message))
+	}(time.Now())
+
+	return lm.svc.ShareChannel(ctx, token, id, group)
+}
+
 func (lm *loggingMiddleware) ViewChannel(ctx context.Context, token, id string) (channel things.Channel, err error) {
 	defer func(begin time.Time) {
 		message := fmt.Sprintf("Method view_channel for token %s and channel %s took %s to complete", token, id, time.Since(begin))
@@ -159,7 +185,7 @@ func (lm *loggingMiddleware) ViewChannel(ctx context.Context, token, id string)
 	return lm.svc.ViewChannel(ctx, token, id)
 }
 
-func (lm *loggingMiddleware) ListChannels(ctx context.Context, token string, offset, limit uint64, name string, metadata things.Metadata) (_ things.ChannelsPage, err error) {
+func (lm *loggingMiddleware) ListChannels(ctx context.Context, token string, offset, limit uint64, cursor, name string, metadata things.Metadata) (_ things.ChannelsPage, err error) {
 	defer func(begin time.Time) {
 		nlog := ""
 		if name != "" {
@@ -173,7 +199,7 @@ func (lm *loggingMiddleware) ListChannels(ctx context.Context, token string, off
 		lm.logger.Info(fmt.Sprintf("%s without errors.", message))
 	}(time.Now())
 
-	return lm.svc.ListChannels(ctx, token, offset, limit, name, metadata)
+	return lm.svc.ListChannels(ctx, token, offset, limit, cursor, name, metadata)
 }
 
 func (lm *loggingMiddleware) ListChannelsByThing(ctx context.Context, token, id string, offset, limit uint64, connected bool) (_ things.ChannelsPage, err error) {
@@ -201,9 +227,9 @@ func (lm *loggingMiddleware) RemoveChannel(ctx context.Context, token, id string
 	return lm.svc.RemoveChannel(ctx, token, id)
 }
 
-func (lm *loggingMiddleware) Connect(ctx context.Context, token string, chIDs, thIDs []string) (err error) {
+func (lm *loggingMiddleware) Connect(ctx context.Context, token string, chIDs, thIDs, actions []string) (err error) {
 	defer func(begin time.Time) {
-		message := fmt.Sprintf("Method connect for token %s, channels %s and things %s took %s to complete", token, chIDs, thIDs, time.Since(begin))
+		message := fmt.Sprintf("Method connect for token %s, channels %s, things %s and actions %s took %s to complete", token, chIDs, thIDs, actions, time.Since(begin))
 		if err != nil {
 			lm.logger.Warn(fmt.Sprintf("%s with error: %s.", message, err))
 			return
@@ -211,7 +237,7 @@ func (lm *loggingMiddleware) Connect(ctx context.Context, token string, chIDs, t
 		lm.logger.Info(fmt.Sprintf("%s without errors.", message))
 	}(time.Now())
 
-	return lm.svc.Connect(ctx, token, chIDs, thIDs)
+	return lm.svc.Connect(ctx, token, chIDs, thIDs, actions)
 }
 
 func (lm *loggingMiddleware) Disconnect(ctx context.Context, token, chanID, thingID string) (err error) {
@@ -227,9 +253,9 @@ func (lm *loggingMiddleware) Disconnect(ctx context.Context, token, chanID, thin
 	return lm.svc.Disconnect(ctx, token, chanID, thingID)
 }
 
-func (lm *loggingMiddleware) CanAccessByKey(ctx context.Context, id, key string) (thing string, err error) {
+func (lm *loggingMiddleware) CanAccessByKey(ctx context.Context, id, key, action string) (thing string, err error) {
 	defer func(begin time.Time) {
-		message := fmt.Sprintf("Method can_access for channel %s and thing %s took %s to complete", id, thing, time.Since(begin))
+		message := fmt.Sprintf("Method can_access for channel %s, thing %s and action %s took %s to complete", id, thing, action, time.Since(begin))
 		if err != nil {
 			lm.logger.Warn(fmt.Sprintf("%s with error: %s.", message, err))
 			return
@@ -237,12 +263,12 @@ func (lm *loggingMiddleware) CanAccessByKey(ctx context.Context, id, key string)
 		lm.logger.Info(fmt.Sprintf("%s without errors.", message))
 	}(time.Now())
 
-	return lm.svc.CanAccessByKey(ctx, id, key)
+	return lm.svc.CanAccessByKey(ctx, id, key, action)
 }
 
-func (lm *loggingMiddleware) CanAccessByID(ctx context.Context, chanID, thingID string) (err error) {
+func (lm *loggingMiddleware) CanAccessByID(ctx context.Context, chanID, thingID, action string) (err error) {
 	defer func(begin time.Time) {
-		message := fmt.Sprintf("Method can_access_by_id for channel %s and thing %s took %s to complete", chanID, thingID, time.Since(begin))
+		message := fmt.Sprintf("Method can_access_by_id for channel %s, thing %s and action %s took %s to complete", chanID, thingID, action, time.Since(begin))
 		if err != nil {
 			lm.logger.Warn(fmt.Sprintf("%s with error: %s.", message, err))
 			return
@@ -250,7 +276,7 @@ func (lm *loggingMiddleware) CanAccessByID(ctx context.Context, chanID, thingID
 		lm.logger.Info(fmt.Sprintf("%s without errors.", message))
 	}(time.Now())
 
-	return lm.svc.CanAccessByID(ctx, chanID, thingID)
+	return lm.svc.CanAccessByID(ctx, chanID, thingID, action)
 }
 func (lm *loggingMiddleware) Identify(ctx context.Context, key string) (id string, err error) {
 	defer func(begin time.Time) {
@@ -264,3 +290,198 @@ func (lm *loggingMiddleware) Identify(ctx context.Context, key string) (id strin
 
 	return lm.svc.Identify(ctx, key)
 }
+
+func (lm *loggingMiddleware) IsChannelOwner(ctx context.Context, owner, chanID string) (err error) {
+	defer func(begin time.Time) {
+		message := fmt.Sprintf("Method is_channel_owner for channel %s and owner %s took %s to complete", chanID, owner, time.Since(begin))
+		if err != nil {
+			lm.logger.Warn(fmt.Sprintf("%s with error: %s.", message, err))
+			return
+		}
+		lm.logger.Info(fmt.Sprintf("%s without errors.", message))
+	}(time.Now())
+
+	return lm.svc.IsChannelOwner(ctx, owner, chanID)
+}
+
+func (lm *loggingMiddleware) ChannelSchema(ctx context.Context, chanID string) (s []byte, err error) {
+	defer func(begin time.Time) {
+		message := fmt.Sprintf("Method channel_schema for channel %s took %s to complete", chanID, time.Since(begin))
+		if err != nil {
+			lm.logger.Warn(fmt.Sprintf("%s with error: %s.", message, err))
+			return
+		}
+		lm.logger.Info(fmt.Sprintf("%s without errors.", message))
+	}(time.Now())
+
+	return lm.svc.ChannelSchema(ctx, chanID)
+}
+
+func (lm *loggingMiddleware) CreateGroup(ctx context.Context, token string, group things.Group) (saved things.Group, err error) {
+	defer func(begin time.Time) {
+		message := fmt.Sprintf("Method create_group for token %s and group %s took %s to complete", token, saved.ID, time.Since(begin))
+		if err != nil {
+			lm.logger.Warn(fmt.Sprintf("%s with error: %s.", message, err))
+			return
+		}
+		lm.logger.Info(fmt.Sprintf("%s without errors.", message))
+	}(time.Now())
+
+	return lm.svc.CreateGroup(ctx, token, group)
+}
+
+func (lm *loggingMiddleware) UpdateGroup(ctx context.Context, token string, group things.Group) (err error) {
+	defer func(begin time.Time) {
+		message := fmt.Sprintf("Method update_group for token %s and group %s took %s to complete", token, group.ID, time.Since(begin))
+		if err != nil {
+			lm.logger.Warn(fmt.Sprintf("%s with error: %s.", message, err))
+			return
+		}
+		lm.logger.Info(fmt.Sprintf("%s without errors.", message))
+	}(time.Now())
+
+	return lm.svc.UpdateGroup(ctx, token, group)
+}
+
+func (lm *loggingMiddleware) ViewGroup(ctx context.Context, token, id string) (group things.Group, err error) {
+	defer func(begin time.Time) {
+		message := fmt.Sprintf("Method view_group for token %s and group %s took %s to complete", token, id, time.Since(begin))
+		if err != nil {
+			lm.logger.Warn(fmt.Sprintf("%s with error: %s.", message, err))
+			return
+		}
+		lm.logger.Info(fmt.Sprintf("%s without errors.", message))
+	}(time.Now())
+
+	return lm.svc.ViewGroup(ctx, token, id)
+}
+
+func (lm *loggingMiddleware) ListGroups(ctx context.Context, token, parentID string, offset, limit uint64, metadata things.Metadata) (_ things.GroupsPage, err error) {
+	defer func(begin time.Time) {
+		message := fmt.Sprintf("Method list_groups for token %s and parent %s took %s to complete", token, parentID, time.Since(begin))
+		if err != nil {
+			lm.logger.Warn(fmt.Sprintf("%s with error: %s.", message, err))
+			return
+		}
+		lm.logger.Info(fmt.Sprintf("%s without errors.", message))
+	}(time.Now())
+
+	return lm.svc.ListGroups(ctx, token, parentID, offset, limit, metadata)
+}
+
+func (lm *loggingMiddleware) ListThingsByGroup(ctx context.Context, token, groupID string, offset, limit uint64) (_ things.Page, err error) {
+	defer func(begin time.Time) {
+		message := fmt.Sprintf("Method list_things_by_group for token %s and group %s took %s to complete", token, groupID, time.Since(begin))
+		if err != nil {
+			lm.logger.Warn(fmt.Sprintf("%s with error: %s.", message, err))
+			return
+		}
+		lm.logger.Info(fmt.Sprintf("%s without errors.", message))
+	}(time.Now())
+
+	return lm.svc.ListThingsByGroup(ctx, token, groupID, offset, limit)
+}
+
+func (lm *loggingMiddleware) ListChannelsByGroup(ctx context.Context, token, groupID string, offset, limit uint64) (_ things.ChannelsPage, err error) {
+	defer func(begin time.Time) {
+		message := fmt.Sprintf("Method list_channels_by_group for token %s and group %s took %s to complete", token, groupID, time.Since(begin))
+		if err != nil {
+			lm.logger.Warn(fmt.Sprintf("%s with error: %s.", message, err))
+			return
+		}
+		lm.logger.Info(fmt.Sprintf("%s without errors.", message))
+	}(time.Now())
+
+	return lm.svc.ListChannelsByGroup(ctx, token, groupID, offset, limit)
+}
+
+func (lm *loggingMiddleware) RemoveGroup(ctx context.Context, token, id string) (err error) {
+	defer func(begin time.Time) {
+		message := fmt.Sprintf("Method remove_group for token %s and group %s took %s to complete", token, id, time.Since(begin))
+		if err != nil {
+			lm.logger.Warn(fmt.Sprintf("%s with error: %s.", message, err))
+			return
+		}
+		lm.logger.Info(fmt.Sprintf("%s without errors.", message))
+	}(time.Now())
+
+	return lm.svc.RemoveGroup(ctx, token, id)
+}
+
+func (lm *loggingMiddleware) AssignThings(ctx context.Context, token, groupID string, thIDs ...string) (err error) {
+	defer func(begin time.Time) {
+		message := fmt.Sprintf("Method assign_things for token %s, group %s and things %s took %s to complete", token, groupID, thIDs, time.Since(begin))
+		if err != nil {
+			lm.logger.Warn(fmt.Sprintf("%s with error: %s.", message, err))
+			return
+		}
+		lm.logger.Info(fmt.Sprintf("%s without errors.", message))
+	}(time.Now())
+
+	return lm.svc.AssignThings(ctx, token, groupID, thIDs...)
+}
+
+func (lm *loggingMiddleware) UnassignThings(ctx context.Context, token, groupID string, thIDs ...string) (err error) {
+	defer func(begin time.Time) {
+		message := fmt.Sprintf("Method unassign_things for token %s, group %s and things %s took %s to complete", token, groupID, thIDs, time.Since(begin))
+		if err != nil {
+			lm.logger.Warn(fmt.Sprintf("%s with error: %s.", message, err))
+			return
+		}
+		lm.logger.Info(fmt.Sprintf("%s without errors.", message))
+	}(time.Now())
+
+	return lm.svc.UnassignThings(ctx, token, groupID, thIDs...)
+}
+
+func (lm *loggingMiddleware) AssignChannels(ctx context.Context, token, groupID string, chIDs ...string) (err error) {
+	defer func(begin time.Time) {
+		message := fmt.Sprintf("Method assign_channels for token %s, group %s and channels %s took %s to complete", token, groupID, chIDs, time.Since(begin))
+		if err != nil {
+			lm.logger.Warn(fmt.Sprintf("%s with error: %s.", message, err))
+			return
+		}
+		lm.logger.Info(fmt.Sprintf("%s without errors.", message))
+	}(time.Now())
+
+	return lm.svc.AssignChannels(ctx, token, groupID, chIDs...)
+}
+
+func (lm *loggingMiddleware) UnassignChannels(ctx context.Context, token, groupID string, chIDs ...string) (err error) {
+	defer func(begin time.Time) {
+		message := fmt.Sprintf("Method unassign_channels for token %s, group %s and channels %s took %s to complete", token, groupID, chIDs, time.Since(begin))
+		if err != nil {
+			lm.logger.Warn(fmt.Sprintf("%s with error: %s.", message, err))
+			return
+		}
+		lm.logger.Info(fmt.Sprintf("%s without errors.", message))
+	}(time.Now())
+
+	return lm.svc.UnassignChannels(ctx, token, groupID, chIDs...)
+}
+
+func (lm *loggingMiddleware) ConnectGroup(ctx context.Context, token, groupID string, chIDs, actions []string) (err error) {
+	defer func(begin time.Time) {
+		message := fmt.Sprintf("Method connect_group for token %s, group %s, channels %s and actions %s took %s to complete", token, groupID, chIDs, actions, time.Since(begin))
+		if err != nil {
+			lm.logger.Warn(fmt.Sprintf("%s with error: %s.", message, err))
+			return
+		}
+		lm.logger.Info(fmt.Sprintf("%s without errors.", message))
+	}(time.Now())
+
+	return lm.svc.ConnectGroup(ctx, token, groupID, chIDs, actions)
+}
+
+func (lm *loggingMiddleware) DisconnectGroup(ctx context.Context, token, groupID, chanID string) (err error) {
+	defer func(begin time.Time) {
+		message := fmt.Sprintf("Method disconnect_group for token %s, group %s and channel %s took %s to complete", token, groupID, chanID, time.Since(begin))
+		if err != nil {
+			lm.logger.Warn(fmt.Sprintf("%s with error: %s.", message, err))
+			return
+		}
+		lm.logger.Info(fmt.Sprintf("%s without errors.", message))
+	}(time.Now())
+
+	return lm.svc.DisconnectGroup(ctx, token, groupID, chanID)
+}
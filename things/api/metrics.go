@@ -58,6 +58,15 @@ func (ms *metricsMiddleware) UpdateKey(ctx context.Context, token, id, key strin
 	return ms.svc.UpdateKey(ctx, token, id, key)
 }
 
+func (ms *metricsMiddleware) ShareThing(ctx context.Context, token, id, group string) error {
+	defer func(begin time.Time) {
+		ms.counter.With("method", "share_thing").Add(1)
+		ms.latency.With("method", "share_thing").Observe(time.Since(begin).Seconds())
+	}(time.Now())
+
+	return ms.svc.ShareThing(ctx, token, id, group)
+}
+
 func (ms *metricsMiddleware) ViewThing(ctx context.Context, token, id string) (things.Thing, error) {
 	defer func(begin time.Time) {
 		ms.counter.With("method", "view_thing").Add(1)
@@ -67,13 +76,13 @@ func (ms *metricsMiddleware) ViewThing(ctx context.Context, token, id string) (t
 	return ms.svc.ViewThing(ctx, token, id)
 }
 
-func (ms *metricsMiddleware) ListThings(ctx context.Context, token string, offset, limit uint64, name string, metadata things.Metadata) (things.Page, error) {
+func (ms *metricsMiddleware) ListThings(ctx context.Context, token string, offset, limit uint64, cursor, name string, metadata things.Metadata) (things.Page, error) {
 	defer func(begin time.Time) {
 		ms.counter.With("method", "list_things").Add(1)
 		ms.latency.With("method", "list_things").Observe(time.Since(begin).Seconds())
 	}(time.Now())
 
-	return ms.svc.ListThings(ctx, token, offset, limit, name, metadata)
+	return ms.svc.ListThings(ctx, token, offset, limit, cursor, name, metadata)
 }
 
 func (ms *metricsMiddleware) ListThingsByChannel(ctx context.Context, token, id string, offset, limit uint64, connected bool) (things.Page, error) {
@@ -112,6 +121,15 @@ func (ms *metricsMiddleware) UpdateChannel(ctx context.Context, token string, ch
 	return ms.svc.UpdateChannel(ctx, token, channel)
 }
 
+func (ms *metricsMiddleware) ShareChannel(ctx context.Context, token, id, group string) error {
+	defer func(begin time.Time) {
+		ms.counter.With("method", "share_channel").Add(1)
+		ms.latency.With("method", "share_channel").Observe(time.Since(begin).Seconds())
+	}(time.Now())
+
+	return ms.svc.ShareChannel(ctx, token, id, group)
+}
+
 func (ms *metricsMiddleware) ViewChannel(ctx context.Context, token, id string) (things.Channel, error) {
 	defer func(begin time.Time) {
 		ms.counter.With("method", "view_channel").Add(1)
@@ -121,13 +139,13 @@ func (ms *metricsMiddleware) ViewChannel(ctx context.Context, token, id string)
 	return ms.svc.ViewChannel(ctx, token, id)
 }
 
-func (ms *metricsMiddleware) ListChannels(ctx context.Context, token string, offset, limit uint64, name string, metadata things.Metadata) (things.ChannelsPage, error) {
+func (ms *metricsMiddleware) ListChannels(ctx context.Context, token string, offset, limit uint64, cursor, name string, metadata things.Metadata) (things.ChannelsPage, error) {
 	defer func(begin time.Time) {
 		ms.counter.With("method", "list_channels").Add(1)
 		ms.latency.With("method", "list_channels").Observe(time.Since(begin).Seconds())
 	}(time.Now())
 
-	return ms.svc.ListChannels(ctx, token, offset, limit, name, metadata)
+	return ms.svc.ListChannels(ctx, token, offset, limit, cursor, name, metadata)
 }
 
 func (ms *metricsMiddleware) ListChannelsByThing(ctx context.Context, token, id string, offset, limit uint64, connected bool) (things.ChannelsPage, error) {
@@ -148,13 +166,13 @@ func (ms *metricsMiddleware) RemoveChannel(ctx context.Context, token, id string
 	return ms.svc.RemoveChannel(ctx, token, id)
 }
 
-func (ms *metricsMiddleware) Connect(ctx context.Context, token string, chIDs, thIDs []string) error {
+func (ms *metricsMiddleware) Connect(ctx context.Context, token string, chIDs, thIDs, actions []string) error {
 	defer func(begin time.Time) {
 		ms.counter.With("method", "connect").Add(1)
 		ms.latency.With("method", "connect").Observe(time.Since(begin).Seconds())
 	}(time.Now())
 
-	return ms.svc.Connect(ctx, token, chIDs, thIDs)
+	return ms.svc.Connect(ctx, token, chIDs, thIDs, actions)
 }
 
 func (ms *metricsMiddleware) Disconnect(ctx context.Context, token, chanID, thingID string) error {
@@ -166,22 +184,22 @@ func (ms *metricsMiddleware) Disconnect(ctx context.Context, token, chanID, thin
 	return ms.svc.Disconnect(ctx, token, chanID, thingID)
 }
 
-func (ms *metricsMiddleware) CanAccessByKey(ctx context.Context, id, key string) (string, error) {
+func (ms *metricsMiddleware) CanAccessByKey(ctx context.Context, id, key, action string) (string, error) {
 	defer func(begin time.Time) {
 		ms.counter.With("method", "can_access_by_key").Add(1)
 		ms.latency.With("method", "can_access_by_key").Observe(time.Since(begin).Seconds())
 	}(time.Now())
 
-	return ms.svc.CanAccessByKey(ctx, id, key)
+	return ms.svc.CanAccessByKey(ctx, id, key, action)
 }
 
-func (ms *metricsMiddleware) CanAccessByID(ctx context.Context, chanID, thingID string) error {
+func (ms *metricsMiddleware) CanAccessByID(ctx context.Context, chanID, thingID, action string) error {
 	defer func(begin time.Time) {
 		ms.counter.With("method", "can_access_by_id").Add(1)
 		ms.latency.With("method", "can_access_by_id").Observe(time.Since(begin).Seconds())
 	}(time.Now())
 
-	return ms.svc.CanAccessByID(ctx, chanID, thingID)
+	return ms.svc.CanAccessByID(ctx, chanID, thingID, action)
 }
 
 func (ms *metricsMiddleware) Identify(ctx context.Context, key string) (string, error) {
@@ -192,3 +210,138 @@ func (ms *metricsMiddleware) Identify(ctx context.Context, key string) (string,
 
 	return ms.svc.Identify(ctx, key)
 }
+
+func (ms *metricsMiddleware) IsChannelOwner(ctx context.Context, owner, chanID string) error {
+	defer func(begin time.Time) {
+		ms.counter.With("method", "is_channel_owner").Add(1)
+		ms.latency.With("method", "is_channel_owner").Observe(time.Since(begin).Seconds())
+	}(time.Now())
+
+	return ms.svc.IsChannelOwner(ctx, owner, chanID)
+}
+
+func (ms *metricsMiddleware) ChannelSchema(ctx context.Context, chanID string) ([]byte, error) {
+	defer func(begin time.Time) {
+		ms.counter.With("method", "channel_schema").Add(1)
+		ms.latency.With("method", "channel_schema").Observe(time.Since(begin).Seconds())
+	}(time.Now())
+
+	return ms.svc.ChannelSchema(ctx, chanID)
+}
+
+func (ms *metricsMiddleware) CreateGroup(ctx context.Context, token string, group things.Group) (things.Group, error) {
+	defer func(begin time.Time) {
+		ms.counter.With("method", "create_group").Add(1)
+		ms.latency.With("method", "create_group").Observe(time.Since(begin).Seconds())
+	}(time.Now())
+
+	return ms.svc.CreateGroup(ctx, token, group)
+}
+
+func (ms *metricsMiddleware) UpdateGroup(ctx context.Context, token string, group things.Group) error {
+	defer func(begin time.Time) {
+		ms.counter.With("method", "update_group").Add(1)
+		ms.latency.With("method", "update_group").Observe(time.Since(begin).Seconds())
+	}(time.Now())
+
+	return ms.svc.UpdateGroup(ctx, token, group)
+}
+
+func (ms *metricsMiddleware) ViewGroup(ctx context.Context, token, id string) (things.Group, error) {
+	defer func(begin time.Time) {
+		ms.counter.With("method", "view_group").Add(1)
+		ms.latency.With("method", "view_group").Observe(time.Since(begin).Seconds())
+	}(time.Now())
+
+	return ms.svc.ViewGroup(ctx, token, id)
+}
+
+func (ms *metricsMiddleware) ListGroups(ctx context.Context, token, parentID string, offset, limit uint64, metadata things.Metadata) (things.GroupsPage, error) {
+	defer func(begin time.Time) {
+		ms.counter.With("method", "list_groups").Add(1)
+		ms.latency.With("method", "list_groups").Observe(time.Since(begin).Seconds())
+	}(time.Now())
+
+	return ms.svc.ListGroups(ctx, token, parentID, offset, limit, metadata)
+}
+
+func (ms *metricsMiddleware) ListThingsByGroup(ctx context.Context, token, groupID string, offset, limit uint64) (things.Page, error) {
+	defer func(begin time.Time) {
+		ms.counter.With("method", "list_things_by_group").Add(1)
+		ms.latency.With("method", "list_things_by_group").Observe(time.Since(begin).Seconds())
+	}(time.Now())
+
+	return ms.svc.ListThingsByGroup(ctx, token, groupID, offset, limit)
+}
+
+func (ms *metricsMiddleware) ListChannelsByGroup(ctx context.Context, token, groupID string, offset, limit uint64) (things.ChannelsPage, error) {
+	defer func(begin time.Time) {
+		ms.counter.With("method", "list_channels_by_group").Add(1)
+		ms.latency.With("method", "list_channels_by_group").Observe(time.Since(begin).Seconds())
+	}(time.Now())
+
+	return ms.svc.ListChannelsByGroup(ctx, token, groupID, offset, limit)
+}
+
+func (ms *metricsMiddleware) RemoveGroup(ctx context.Context, token, id string) error {
+	defer func(begin time.Time) {
+		ms.counter.With("method", "remove_group").Add(1)
+		ms.latency.With("method", "remove_group").Observe(time.Since(begin).Seconds())
+	}(time.Now())
+
+	return ms.svc.RemoveGroup(ctx, token, id)
+}
+
+func (ms *metricsMiddleware) AssignThings(ctx context.Context, token, groupID string, thIDs ...string) error {
+	defer func(begin time.Time) {
+		ms.counter.With("method", "assign_things").Add(1)
+		ms.latency.With("method", "assign_things").Observe(time.Since(begin).Seconds())
+	}(time.Now())
+
+	return ms.svc.AssignThings(ctx, token, groupID, thIDs...)
+}
+
+func (ms *metricsMiddleware) UnassignThings(ctx context.Context, token, groupID string, thIDs ...string) error {
+	defer func(begin time.Time) {
+		ms.counter.With("method", "unassign_things").Add(1)
+		ms.latency.With("method", "unassign_things").Observe(time.Since(begin).Seconds())
+	}(time.Now())
+
+	return ms.svc.UnassignThings(ctx, token, groupID, thIDs...)
+}
+
+func (ms *metricsMiddleware) AssignChannels(ctx context.Context, token, groupID string, chIDs ...string) error {
+	defer func(begin time.Time) {
+		ms.counter.With("method", "assign_channels").Add(1)
+		ms.latency.With("method", "assign_channels").Observe(time.Since(begin).Seconds())
+	}(time.Now())
+
+	return ms.svc.AssignChannels(ctx, token, groupID, chIDs...)
+}
+
+func (ms *metricsMiddleware) UnassignChannels(ctx context.Context, token, groupID string, chIDs ...string) error {
+	defer func(begin time.Time) {
+		ms.counter.With("method", "unassign_channels").Add(1)
+		ms.latency.With("method", "unassign_channels").Observe(time.Since(begin).Seconds())
+	}(time.Now())
+
+	return ms.svc.UnassignChannels(ctx, token, groupID, chIDs...)
+}
+
+func (ms *metricsMiddleware) ConnectGroup(ctx context.Context, token, groupID string, chIDs, actions []string) error {
+	defer func(begin time.Time) {
+		ms.counter.With("method", "connect_group").Add(1)
+		ms.latency.With("method", "connect_group").Observe(time.Since(begin).Seconds())
+	}(time.Now())
+
+	return ms.svc.ConnectGroup(ctx, token, groupID, chIDs, actions)
+}
+
+func (ms *metricsMiddleware) DisconnectGroup(ctx context.Context, token, groupID, chanID string) error {
+	defer func(begin time.Time) {
+		ms.counter.With("method", "disconnect_group").Add(1)
+		ms.latency.With("method", "disconnect_group").Observe(time.Since(begin).Seconds())
+	}(time.Now())
+
+	return ms.svc.DisconnectGroup(ctx, token, groupID, chanID)
+}
@@ -19,9 +19,10 @@ func createThingEndpoint(svc things.Service) endpoint.Endpoint {
 		}
 
 		th := things.Thing{
-			Key:      req.Key,
-			Name:     req.Name,
-			Metadata: req.Metadata,
+			Key:       req.Key,
+			Name:      req.Name,
+			UserGroup: req.UserGroup,
+			Metadata:  req.Metadata,
 		}
 		saved, err := svc.CreateThings(ctx, req.token, th)
 		if err != nil {
@@ -48,9 +49,10 @@ func createThingsEndpoint(svc things.Service) endpoint.Endpoint {
 		ths := []things.Thing{}
 		for _, tReq := range req.Things {
 			th := things.Thing{
-				Name:     tReq.Name,
-				Key:      tReq.Key,
-				Metadata: tReq.Metadata,
+				Name:      tReq.Name,
+				UserGroup: tReq.UserGroup,
+				Key:       tReq.Key,
+				Metadata:  tReq.Metadata,
 			}
 			ths = append(ths, th)
 		}
@@ -67,10 +69,11 @@ func createThingsEndpoint(svc things.Service) endpoint.Endpoint {
 
 		for _, th := range saved {
 			tRes := thingRes{
-				ID:       th.ID,
-				Name:     th.Name,
-				Key:      th.Key,
-				Metadata: th.Metadata,
+				ID:        th.ID,
+				Name:      th.Name,
+				UserGroup: th.UserGroup,
+				Key:       th.Key,
+				Metadata:  th.Metadata,
 			}
 			res.Things = append(res.Things, tRes)
 		}
@@ -102,6 +105,39 @@ func updateThingEndpoint(svc things.Service) endpoint.Endpoint {
 	}
 }
 
+func shareThingEndpoint(svc things.Service) endpoint.Endpoint {
+	return func(ctx context.Context, request interface{}) (interface{}, error) {
+		req := request.(shareReq)
+
+		if err := req.validate(); err != nil {
+			return nil, err
+		}
+
+		if err := svc.ShareThing(ctx, req.token, req.id, req.UserGroup); err != nil {
+			return nil, err
+		}
+
+		res := thingRes{ID: req.id, created: false}
+		return res, nil
+	}
+}
+
+func unshareThingEndpoint(svc things.Service) endpoint.Endpoint {
+	return func(ctx context.Context, request interface{}) (interface{}, error) {
+		req := request.(viewResourceReq)
+
+		if err := req.validate(); err != nil {
+			return nil, err
+		}
+
+		if err := svc.ShareThing(ctx, req.token, req.id, ""); err != nil {
+			return nil, err
+		}
+
+		return removeRes{}, nil
+	}
+}
+
 func updateKeyEndpoint(svc things.Service) endpoint.Endpoint {
 	return func(ctx context.Context, request interface{}) (interface{}, error) {
 		req := request.(updateKeyReq)
@@ -133,11 +169,12 @@ func viewThingEndpoint(svc things.Service) endpoint.Endpoint {
 		}
 
 		res := viewThingRes{
-			ID:       thing.ID,
-			Owner:    thing.Owner,
-			Name:     thing.Name,
-			Key:      thing.Key,
-			Metadata: thing.Metadata,
+			ID:        thing.ID,
+			Owner:     thing.Owner,
+			Name:      thing.Name,
+			UserGroup: thing.UserGroup,
+			Key:       thing.Key,
+			Metadata:  thing.Metadata,
 		}
 		return res, nil
 	}
@@ -151,26 +188,28 @@ func listThingsEndpoint(svc things.Service) endpoint.Endpoint {
 			return nil, err
 		}
 
-		page, err := svc.ListThings(ctx, req.token, req.offset, req.limit, req.name, req.metadata)
+		page, err := svc.ListThings(ctx, req.token, req.offset, req.limit, req.cursor, req.name, req.metadata)
 		if err != nil {
 			return nil, err
 		}
 
 		res := thingsPageRes{
 			pageRes: pageRes{
-				Total:  page.Total,
-				Offset: page.Offset,
-				Limit:  page.Limit,
+				Total:      page.Total,
+				Offset:     page.Offset,
+				Limit:      page.Limit,
+				NextCursor: page.NextCursor,
 			},
 			Things: []viewThingRes{},
 		}
 		for _, thing := range page.Things {
 			view := viewThingRes{
-				ID:       thing.ID,
-				Owner:    thing.Owner,
-				Name:     thing.Name,
-				Key:      thing.Key,
-				Metadata: thing.Metadata,
+				ID:        thing.ID,
+				Owner:     thing.Owner,
+				Name:      thing.Name,
+				UserGroup: thing.UserGroup,
+				Key:       thing.Key,
+				Metadata:  thing.Metadata,
 			}
 			res.Things = append(res.Things, view)
 		}
@@ -202,11 +241,12 @@ func listThingsByChannelEndpoint(svc things.Service) endpoint.Endpoint {
 		}
 		for _, thing := range page.Things {
 			view := viewThingRes{
-				ID:       thing.ID,
-				Owner:    thing.Owner,
-				Key:      thing.Key,
-				Name:     thing.Name,
-				Metadata: thing.Metadata,
+				ID:        thing.ID,
+				Owner:     thing.Owner,
+				Key:       thing.Key,
+				Name:      thing.Name,
+				UserGroup: thing.UserGroup,
+				Metadata:  thing.Metadata,
 			}
 			res.Things = append(res.Things, view)
 		}
@@ -244,7 +284,7 @@ func createChannelEndpoint(svc things.Service) endpoint.Endpoint {
 			return nil, err
 		}
 
-		ch := things.Channel{Name: req.Name, Metadata: req.Metadata}
+		ch := things.Channel{Name: req.Name, UserGroup: req.UserGroup, Metadata: req.Metadata}
 		saved, err := svc.CreateChannels(ctx, req.token, ch)
 		if err != nil {
 			return nil, err
@@ -269,8 +309,9 @@ func createChannelsEndpoint(svc things.Service) endpoint.Endpoint {
 		chs := []things.Channel{}
 		for _, cReq := range req.Channels {
 			ch := things.Channel{
-				Metadata: cReq.Metadata,
-				Name:     cReq.Name,
+				Metadata:  cReq.Metadata,
+				Name:      cReq.Name,
+				UserGroup: cReq.UserGroup,
 			}
 			chs = append(chs, ch)
 		}
@@ -287,9 +328,10 @@ func createChannelsEndpoint(svc things.Service) endpoint.Endpoint {
 
 		for _, ch := range saved {
 			cRes := channelRes{
-				ID:       ch.ID,
-				Name:     ch.Name,
-				Metadata: ch.Metadata,
+				ID:        ch.ID,
+				Name:      ch.Name,
+				UserGroup: ch.UserGroup,
+				Metadata:  ch.Metadata,
 			}
 			res.Channels = append(res.Channels, cRes)
 		}
@@ -323,6 +365,42 @@ func updateChannelEndpoint(svc things.Service) endpoint.Endpoint {
 	}
 }
 
+func shareChannelEndpoint(svc things.Service) endpoint.Endpoint {
+	return func(ctx context.Context, request interface{}) (interface{}, error) {
+		req := request.(shareReq)
+
+		if err := req.validate(); err != nil {
+			return nil, err
+		}
+
+		if err := svc.ShareChannel(ctx, req.token, req.id, req.UserGroup); err != nil {
+			return nil, err
+		}
+
+		res := channelRes{
+			ID:      req.id,
+			created: false,
+		}
+		return res, nil
+	}
+}
+
+func unshareChannelEndpoint(svc things.Service) endpoint.Endpoint {
+	return func(ctx context.Context, request interface{}) (interface{}, error) {
+		req := request.(viewResourceReq)
+
+		if err := req.validate(); err != nil {
+			return nil, err
+		}
+
+		if err := svc.ShareChannel(ctx, req.token, req.id, ""); err != nil {
+			return nil, err
+		}
+
+		return removeRes{}, nil
+	}
+}
+
 func viewChannelEndpoint(svc things.Service) endpoint.Endpoint {
 	return func(ctx context.Context, request interface{}) (interface{}, error) {
 		req := request.(viewResourceReq)
@@ -337,10 +415,11 @@ func viewChannelEndpoint(svc things.Service) endpoint.Endpoint {
 		}
 
 		res := viewChannelRes{
-			ID:       channel.ID,
-			Owner:    channel.Owner,
-			Name:     channel.Name,
-			Metadata: channel.Metadata,
+			ID:        channel.ID,
+			Owner:     channel.Owner,
+			Name:      channel.Name,
+			UserGroup: channel.UserGroup,
+			Metadata:  channel.Metadata,
 		}
 
 		return res, nil
@@ -355,26 +434,28 @@ func listChannelsEndpoint(svc things.Service) endpoint.Endpoint {
 			return nil, err
 		}
 
-		page, err := svc.ListChannels(ctx, req.token, req.offset, req.limit, req.name, req.metadata)
+		page, err := svc.ListChannels(ctx, req.token, req.offset, req.limit, req.cursor, req.name, req.metadata)
 		if err != nil {
 			return nil, err
 		}
 
 		res := channelsPageRes{
 			pageRes: pageRes{
-				Total:  page.Total,
-				Offset: page.Offset,
-				Limit:  page.Limit,
+				Total:      page.Total,
+				Offset:     page.Offset,
+				Limit:      page.Limit,
+				NextCursor: page.NextCursor,
 			},
 			Channels: []viewChannelRes{},
 		}
 		// Cast channels
 		for _, channel := range page.Channels {
 			view := viewChannelRes{
-				ID:       channel.ID,
-				Owner:    channel.Owner,
-				Name:     channel.Name,
-				Metadata: channel.Metadata,
+				ID:        channel.ID,
+				Owner:     channel.Owner,
+				Name:      channel.Name,
+				UserGroup: channel.UserGroup,
+				Metadata:  channel.Metadata,
 			}
 
 			res.Channels = append(res.Channels, view)
@@ -407,10 +488,11 @@ func listChannelsByThingEndpoint(svc things.Service) endpoint.Endpoint {
 		}
 		for _, channel := range page.Channels {
 			view := viewChannelRes{
-				ID:       channel.ID,
-				Owner:    channel.Owner,
-				Name:     channel.Name,
-				Metadata: channel.Metadata,
+				ID:        channel.ID,
+				Owner:     channel.Owner,
+				Name:      channel.Name,
+				UserGroup: channel.UserGroup,
+				Metadata:  channel.Metadata,
 			}
 			res.Channels = append(res.Channels, view)
 		}
@@ -446,7 +528,7 @@ func connectEndpoint(svc things.Service) endpoint.Endpoint {
 			return nil, err
 		}
 
-		if err := svc.Connect(ctx, cr.token, []string{cr.chanID}, []string{cr.thingID}); err != nil {
+		if err := svc.Connect(ctx, cr.token, []string{cr.chanID}, []string{cr.thingID}, nil); err != nil {
 			return nil, err
 		}
 
@@ -462,7 +544,7 @@ func createConnectionsEndpoint(svc things.Service) endpoint.Endpoint {
 			return nil, err
 		}
 
-		if err := svc.Connect(ctx, cr.token, cr.ChannelIDs, cr.ThingIDs); err != nil {
+		if err := svc.Connect(ctx, cr.token, cr.ChannelIDs, cr.ThingIDs, cr.Actions); err != nil {
 			return nil, err
 		}
 
@@ -485,3 +567,315 @@ func disconnectEndpoint(svc things.Service) endpoint.Endpoint {
 		return disconnectionRes{}, nil
 	}
 }
+
+func createGroupEndpoint(svc things.Service) endpoint.Endpoint {
+	return func(ctx context.Context, request interface{}) (interface{}, error) {
+		req := request.(createGroupReq)
+
+		if err := req.validate(); err != nil {
+			return nil, err
+		}
+
+		group := things.Group{
+			ParentID:    req.ParentID,
+			Name:        req.Name,
+			Description: req.Description,
+			Metadata:    req.Metadata,
+		}
+		saved, err := svc.CreateGroup(ctx, req.token, group)
+		if err != nil {
+			return nil, err
+		}
+
+		res := groupRes{
+			ID:      saved.ID,
+			created: true,
+		}
+		return res, nil
+	}
+}
+
+func updateGroupEndpoint(svc things.Service) endpoint.Endpoint {
+	return func(ctx context.Context, request interface{}) (interface{}, error) {
+		req := request.(updateGroupReq)
+
+		if err := req.validate(); err != nil {
+			return nil, err
+		}
+
+		group := things.Group{
+			ID:          req.id,
+			Name:        req.Name,
+			Description: req.Description,
+			Metadata:    req.Metadata,
+		}
+
+		if err := svc.UpdateGroup(ctx, req.token, group); err != nil {
+			return nil, err
+		}
+
+		res := groupRes{ID: req.id, created: false}
+		return res, nil
+	}
+}
+
+func viewGroupEndpoint(svc things.Service) endpoint.Endpoint {
+	return func(ctx context.Context, request interface{}) (interface{}, error) {
+		req := request.(viewResourceReq)
+
+		if err := req.validate(); err != nil {
+			return nil, err
+		}
+
+		group, err := svc.ViewGroup(ctx, req.token, req.id)
+		if err != nil {
+			return nil, err
+		}
+
+		return toViewGroupRes(group), nil
+	}
+}
+
+func listGroupsEndpoint(svc things.Service) endpoint.Endpoint {
+	return func(ctx context.Context, request interface{}) (interface{}, error) {
+		req := request.(listResourcesReq)
+
+		if err := req.validate(); err != nil {
+			return nil, err
+		}
+
+		page, err := svc.ListGroups(ctx, req.token, "", req.offset, req.limit, req.metadata)
+		if err != nil {
+			return nil, err
+		}
+
+		return toGroupsPageRes(page), nil
+	}
+}
+
+func listChildrenEndpoint(svc things.Service) endpoint.Endpoint {
+	return func(ctx context.Context, request interface{}) (interface{}, error) {
+		req := request.(listByGroupReq)
+
+		if err := req.validate(); err != nil {
+			return nil, err
+		}
+
+		page, err := svc.ListGroups(ctx, req.token, req.id, req.offset, req.limit, req.metadata)
+		if err != nil {
+			return nil, err
+		}
+
+		return toGroupsPageRes(page), nil
+	}
+}
+
+func listThingsByGroupEndpoint(svc things.Service) endpoint.Endpoint {
+	return func(ctx context.Context, request interface{}) (interface{}, error) {
+		req := request.(listByGroupReq)
+
+		if err := req.validate(); err != nil {
+			return nil, err
+		}
+
+		page, err := svc.ListThingsByGroup(ctx, req.token, req.id, req.offset, req.limit)
+		if err != nil {
+			return nil, err
+		}
+
+		res := thingsPageRes{
+			pageRes: pageRes{
+				Total:  page.Total,
+				Offset: page.Offset,
+				Limit:  page.Limit,
+			},
+			Things: []viewThingRes{},
+		}
+		for _, thing := range page.Things {
+			view := viewThingRes{
+				ID:       thing.ID,
+				Owner:    thing.Owner,
+				Key:      thing.Key,
+				Name:     thing.Name,
+				Metadata: thing.Metadata,
+			}
+			res.Things = append(res.Things, view)
+		}
+
+		return res, nil
+	}
+}
+
+func listChannelsByGroupEndpoint(svc things.Service) endpoint.Endpoint {
+	return func(ctx context.Context, request interface{}) (interface{}, error) {
+		req := request.(listByGroupReq)
+
+		if err := req.validate(); err != nil {
+			return nil, err
+		}
+
+		page, err := svc.ListChannelsByGroup(ctx, req.token, req.id, req.offset, req.limit)
+		if err != nil {
+			return nil, err
+		}
+
+		res := channelsPageRes{
+			pageRes: pageRes{
+				Total:  page.Total,
+				Offset: page.Offset,
+				Limit:  page.Limit,
+			},
+			Channels: []viewChannelRes{},
+		}
+		for _, channel := range page.Channels {
+			view := viewChannelRes{
+				ID:       channel.ID,
+				Owner:    channel.Owner,
+				Name:     channel.Name,
+				Metadata: channel.Metadata,
+			}
+			res.Channels = append(res.Channels, view)
+		}
+
+		return res, nil
+	}
+}
+
+func removeGroupEndpoint(svc things.Service) endpoint.Endpoint {
+	return func(ctx context.Context, request interface{}) (interface{}, error) {
+		req := request.(viewResourceReq)
+
+		if err := req.validate(); err != nil {
+			return nil, err
+		}
+
+		if err := svc.RemoveGroup(ctx, req.token, req.id); err != nil {
+			return nil, err
+		}
+
+		return removeRes{}, nil
+	}
+}
+
+func assignThingsEndpoint(svc things.Service) endpoint.Endpoint {
+	return func(ctx context.Context, request interface{}) (interface{}, error) {
+		req := request.(groupThingsReq)
+
+		if err := req.validate(); err != nil {
+			return nil, err
+		}
+
+		if err := svc.AssignThings(ctx, req.token, req.id, req.ThingIDs...); err != nil {
+			return nil, err
+		}
+
+		return assignRes{}, nil
+	}
+}
+
+func unassignThingsEndpoint(svc things.Service) endpoint.Endpoint {
+	return func(ctx context.Context, request interface{}) (interface{}, error) {
+		req := request.(groupThingsReq)
+
+		if err := req.validate(); err != nil {
+			return nil, err
+		}
+
+		if err := svc.UnassignThings(ctx, req.token, req.id, req.ThingIDs...); err != nil {
+			return nil, err
+		}
+
+		return unassignRes{}, nil
+	}
+}
+
+func assignChannelsEndpoint(svc things.Service) endpoint.Endpoint {
+	return func(ctx context.Context, request interface{}) (interface{}, error) {
+		req := request.(groupChannelsReq)
+
+		if err := req.validate(); err != nil {
+			return nil, err
+		}
+
+		if err := svc.AssignChannels(ctx, req.token, req.id, req.ChannelIDs...); err != nil {
+			return nil, err
+		}
+
+		return assignRes{}, nil
+	}
+}
+
+func unassignChannelsEndpoint(svc things.Service) endpoint.Endpoint {
+	return func(ctx context.Context, request interface{}) (interface{}, error) {
+		req := request.(groupChannelsReq)
+
+		if err := req.validate(); err != nil {
+			return nil, err
+		}
+
+		if err := svc.UnassignChannels(ctx, req.token, req.id, req.ChannelIDs...); err != nil {
+			return nil, err
+		}
+
+		return unassignRes{}, nil
+	}
+}
+
+func connectGroupEndpoint(svc things.Service) endpoint.Endpoint {
+	return func(ctx context.Context, request interface{}) (interface{}, error) {
+		req := request.(connectGroupReq)
+
+		if err := req.validate(); err != nil {
+			return nil, err
+		}
+
+		if err := svc.ConnectGroup(ctx, req.token, req.id, req.ChannelIDs, req.Actions); err != nil {
+			return nil, err
+		}
+
+		return createConnectionsRes{}, nil
+	}
+}
+
+func disconnectGroupEndpoint(svc things.Service) endpoint.Endpoint {
+	return func(ctx context.Context, request interface{}) (interface{}, error) {
+		req := request.(disconnectGroupReq)
+
+		if err := req.validate(); err != nil {
+			return nil, err
+		}
+
+		if err := svc.DisconnectGroup(ctx, req.token, req.groupID, req.chanID); err != nil {
+			return nil, err
+		}
+
+		return disconnectionRes{}, nil
+	}
+}
+
+func toViewGroupRes(group things.Group) viewGroupRes {
+	return viewGroupRes{
+		ID:          group.ID,
+		Owner:       group.Owner,
+		ParentID:    group.ParentID,
+		Name:        group.Name,
+		Description: group.Description,
+		Metadata:    group.Metadata,
+	}
+}
+
+func toGroupsPageRes(page things.GroupsPage) groupsPageRes {
+	res := groupsPageRes{
+		pageRes: pageRes{
+			Total:  page.Total,
+			Offset: page.Offset,
+			Limit:  page.Limit,
+		},
+		Groups: []viewGroupRes{},
+	}
+	for _, group := range page.Groups {
+		res.Groups = append(res.Groups, toViewGroupRes(group))
+	}
+
+	return res
+}
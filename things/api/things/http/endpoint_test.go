@@ -43,6 +43,10 @@ var (
 		Name:     "test",
 		Metadata: map[string]interface{}{"test": "data"},
 	}
+	group = things.Group{
+		Name:     "test_group",
+		Metadata: map[string]interface{}{"test": "data"},
+	}
 	invalidName = strings.Repeat("m", maxNameSize+1)
 	notFoundRes = toJSON(errorRes{things.ErrNotFound.Error()})
 	unauthRes   = toJSON(errorRes{things.ErrUnauthorizedAccess.Error()})
@@ -72,15 +76,20 @@ func (tr testRequest) make() (*http.Response, error) {
 }
 
 func newService(tokens map[string]string) things.Service {
+	return newSharedService(tokens, map[string]map[string]string{})
+}
+
+func newSharedService(tokens map[string]string, roles map[string]map[string]string) things.Service {
 	auth := mocks.NewAuthService(tokens)
 	conns := make(chan mocks.Connection)
 	thingsRepo := mocks.NewThingRepository(conns)
 	channelsRepo := mocks.NewChannelRepository(thingsRepo, conns)
+	groupsRepo := mocks.NewGroupRepository(thingsRepo, channelsRepo)
 	chanCache := mocks.NewChannelCache()
 	thingCache := mocks.NewThingCache()
 	uuidProvider := uuid.NewMock()
 
-	return things.New(auth, thingsRepo, channelsRepo, chanCache, thingCache, uuidProvider)
+	return things.New(auth, mocks.NewUsersService(roles), thingsRepo, channelsRepo, groupsRepo, chanCache, thingCache, uuidProvider)
 }
 
 func newServer(svc things.Service) *httptest.Server {
@@ -553,6 +562,113 @@ func TestUpdateKey(t *testing.T) {
 	}
 }
 
+func TestShareThing(t *testing.T) {
+	const userGroup = "user-group"
+	svc := newSharedService(map[string]string{token: email}, map[string]map[string]string{email: {userGroup: "editor"}})
+	ts := newServer(svc)
+	defer ts.Close()
+
+	ths, err := svc.CreateThings(context.Background(), token, thing)
+	require.Nil(t, err, fmt.Sprintf("unexpected error: %s\n", err))
+	th := ths[0]
+
+	data := toJSON(map[string]string{"user_group": userGroup})
+	otherData := toJSON(map[string]string{"user_group": wrongValue})
+
+	cases := []struct {
+		desc        string
+		method      string
+		req         string
+		id          string
+		contentType string
+		auth        string
+		status      int
+	}{
+		{
+			desc:        "share existing thing",
+			method:      http.MethodPut,
+			req:         data,
+			id:          th.ID,
+			contentType: contentType,
+			auth:        token,
+			status:      http.StatusOK,
+		},
+		{
+			desc:        "share thing with group the user can't write into",
+			method:      http.MethodPut,
+			req:         otherData,
+			id:          th.ID,
+			contentType: contentType,
+			auth:        token,
+			status:      http.StatusUnauthorized,
+		},
+		{
+			desc:        "share thing without group",
+			method:      http.MethodPut,
+			req:         "{}",
+			id:          th.ID,
+			contentType: contentType,
+			auth:        token,
+			status:      http.StatusBadRequest,
+		},
+		{
+			desc:        "share non-existent thing",
+			method:      http.MethodPut,
+			req:         data,
+			id:          strconv.FormatUint(wrongID, 10),
+			contentType: contentType,
+			auth:        token,
+			status:      http.StatusNotFound,
+		},
+		{
+			desc:        "share thing with invalid user token",
+			method:      http.MethodPut,
+			req:         data,
+			id:          th.ID,
+			contentType: contentType,
+			auth:        wrongValue,
+			status:      http.StatusUnauthorized,
+		},
+		{
+			desc:        "share thing without content type",
+			method:      http.MethodPut,
+			req:         data,
+			id:          th.ID,
+			contentType: "",
+			auth:        token,
+			status:      http.StatusUnsupportedMediaType,
+		},
+		{
+			desc:   "unshare existing thing",
+			method: http.MethodDelete,
+			id:     th.ID,
+			auth:   token,
+			status: http.StatusNoContent,
+		},
+		{
+			desc:   "unshare thing with invalid user token",
+			method: http.MethodDelete,
+			id:     th.ID,
+			auth:   wrongValue,
+			status: http.StatusUnauthorized,
+		},
+	}
+
+	for _, tc := range cases {
+		req := testRequest{
+			client:      ts.Client(),
+			method:      tc.method,
+			url:         fmt.Sprintf("%s/things/%s/share", ts.URL, tc.id),
+			contentType: tc.contentType,
+			token:       tc.auth,
+			body:        strings.NewReader(tc.req),
+		}
+		res, err := req.make()
+		assert.Nil(t, err, fmt.Sprintf("%s: unexpected error %s", tc.desc, err))
+		assert.Equal(t, tc.status, res.StatusCode, fmt.Sprintf("%s: expected status code %d got %d", tc.desc, tc.status, res.StatusCode))
+	}
+}
+
 func TestViewThing(t *testing.T) {
 	svc := newService(map[string]string{token: email})
 	ts := newServer(svc)
@@ -648,6 +764,9 @@ func TestListThings(t *testing.T) {
 		})
 	}
 
+	first, err := svc.ListThings(context.Background(), token, 0, 5, "", "", nil)
+	require.Nil(t, err, fmt.Sprintf("unexpected error: %s", err))
+
 	thingURL := fmt.Sprintf("%s/things", ts.URL)
 	cases := []struct {
 		desc   string
@@ -663,6 +782,20 @@ func TestListThings(t *testing.T) {
 			url:    fmt.Sprintf("%s?offset=%d&limit=%d", thingURL, 0, 5),
 			res:    data[0:5],
 		},
+		{
+			desc:   "get a list of things following the cursor",
+			auth:   token,
+			status: http.StatusOK,
+			url:    fmt.Sprintf("%s?limit=%d&cursor=%s", thingURL, 5, first.NextCursor),
+			res:    data[5:10],
+		},
+		{
+			desc:   "get a list of things with invalid cursor",
+			auth:   token,
+			status: http.StatusBadRequest,
+			url:    fmt.Sprintf("%s?limit=%d&cursor=%s", thingURL, 5, wrongValue),
+			res:    nil,
+		},
 		{
 			desc:   "get a list of things with invalid token",
 			auth:   wrongValue,
@@ -793,7 +926,7 @@ func TestListThingsByChannel(t *testing.T) {
 		ths, err := svc.CreateThings(context.Background(), token, thing)
 		require.Nil(t, err, fmt.Sprintf("unexpected error: %s", err))
 		th := ths[0]
-		err = svc.Connect(context.Background(), token, []string{ch.ID}, []string{th.ID})
+		err = svc.Connect(context.Background(), token, []string{ch.ID}, []string{th.ID}, nil)
 		require.Nil(t, err, fmt.Sprintf("unexpected error: %s", err))
 
 		data = append(data, thingRes{
@@ -1319,7 +1452,7 @@ func TestViewChannel(t *testing.T) {
 	ths, err := svc.CreateThings(context.Background(), token, thing)
 	require.Nil(t, err, fmt.Sprintf("unexpected error: %s\n", err))
 	th := ths[0]
-	svc.Connect(context.Background(), token, []string{sch.ID}, []string{th.ID})
+	svc.Connect(context.Background(), token, []string{sch.ID}, []string{th.ID}, nil)
 
 	data := toJSON(channelRes{
 		ID:       sch.ID,
@@ -1401,7 +1534,7 @@ func TestListChannels(t *testing.T) {
 		ths, err := svc.CreateThings(context.Background(), token, thing)
 		require.Nil(t, err, fmt.Sprintf("unexpected error: %s", err))
 		th := ths[0]
-		svc.Connect(context.Background(), token, []string{ch.ID}, []string{th.ID})
+		svc.Connect(context.Background(), token, []string{ch.ID}, []string{th.ID}, nil)
 
 		channels = append(channels, channelRes{
 			ID:       ch.ID,
@@ -1555,7 +1688,7 @@ func TestListChannelsByThing(t *testing.T) {
 		chs, err := svc.CreateChannels(context.Background(), token, channel)
 		require.Nil(t, err, fmt.Sprintf("unexpected error: %s", err))
 		ch := chs[0]
-		err = svc.Connect(context.Background(), token, []string{ch.ID}, []string{th.ID})
+		err = svc.Connect(context.Background(), token, []string{ch.ID}, []string{th.ID}, nil)
 		require.Nil(t, err, fmt.Sprintf("unexpected error: %s", err))
 
 		channels = append(channels, channelRes{
@@ -1877,6 +2010,7 @@ func TestCreateConnections(t *testing.T) {
 		desc        string
 		channelIDs  []string
 		thingIDs    []string
+		actions     []string
 		auth        string
 		contentType string
 		body        string
@@ -1890,6 +2024,24 @@ func TestCreateConnections(t *testing.T) {
 			contentType: contentType,
 			status:      http.StatusOK,
 		},
+		{
+			desc:        "connect existing things to existing channels with publish action",
+			channelIDs:  chIDs1,
+			thingIDs:    thIDs,
+			actions:     []string{things.ActionPublish},
+			auth:        token,
+			contentType: contentType,
+			status:      http.StatusOK,
+		},
+		{
+			desc:        "connect existing things to existing channels with invalid action",
+			channelIDs:  chIDs1,
+			thingIDs:    thIDs,
+			actions:     []string{"invalid"},
+			auth:        token,
+			contentType: contentType,
+			status:      http.StatusBadRequest,
+		},
 		{
 			desc:        "connect existing things to non-existent channels",
 			channelIDs:  []string{strconv.FormatUint(wrongID, 10)},
@@ -2007,9 +2159,11 @@ func TestCreateConnections(t *testing.T) {
 		data := struct {
 			ChannelIDs []string `json:"channel_ids"`
 			ThingIDs   []string `json:"thing_ids"`
+			Actions    []string `json:"actions,omitempty"`
 		}{
 			tc.channelIDs,
 			tc.thingIDs,
+			tc.actions,
 		}
 		body := toJSON(data)
 
@@ -2046,7 +2200,7 @@ func TestDisconnnect(t *testing.T) {
 	th1 := ths[0]
 	chs, _ := svc.CreateChannels(context.Background(), token, channel)
 	ch1 := chs[0]
-	svc.Connect(context.Background(), token, []string{ch1.ID}, []string{th1.ID})
+	svc.Connect(context.Background(), token, []string{ch1.ID}, []string{th1.ID}, nil)
 	chs, _ = svc.CreateChannels(context.Background(), otherToken, channel)
 	ch2 := chs[0]
 
@@ -2135,6 +2289,399 @@ func TestDisconnnect(t *testing.T) {
 	}
 }
 
+func TestCreateGroup(t *testing.T) {
+	svc := newService(map[string]string{token: email})
+	ts := newServer(svc)
+	defer ts.Close()
+
+	parent, err := svc.CreateGroup(context.Background(), token, group)
+	require.Nil(t, err, fmt.Sprintf("unexpected error: %s\n", err))
+
+	data := toJSON(group)
+	childData := toJSON(groupReq{Name: "child", ParentID: parent.ID})
+	invalidData := toJSON(groupReq{Name: invalidName})
+
+	cases := []struct {
+		desc        string
+		req         string
+		contentType string
+		auth        string
+		status      int
+		location    string
+	}{
+		{
+			desc:        "create valid group",
+			req:         data,
+			contentType: contentType,
+			auth:        token,
+			status:      http.StatusCreated,
+			location:    fmt.Sprintf("/groups/%s%012d", uuid.Prefix, 2),
+		},
+		{
+			desc:        "create valid child group",
+			req:         childData,
+			contentType: contentType,
+			auth:        token,
+			status:      http.StatusCreated,
+			location:    fmt.Sprintf("/groups/%s%012d", uuid.Prefix, 3),
+		},
+		{
+			desc:        "create group with invalid name",
+			req:         invalidData,
+			contentType: contentType,
+			auth:        token,
+			status:      http.StatusBadRequest,
+			location:    "",
+		},
+		{
+			desc:        "create group with invalid auth token",
+			req:         data,
+			contentType: contentType,
+			auth:        wrongValue,
+			status:      http.StatusUnauthorized,
+			location:    "",
+		},
+		{
+			desc:        "create group with invalid request format",
+			req:         "{",
+			contentType: contentType,
+			auth:        token,
+			status:      http.StatusBadRequest,
+			location:    "",
+		},
+		{
+			desc:        "create group without content type",
+			req:         data,
+			contentType: "",
+			auth:        token,
+			status:      http.StatusUnsupportedMediaType,
+			location:    "",
+		},
+	}
+
+	for _, tc := range cases {
+		req := testRequest{
+			client:      ts.Client(),
+			method:      http.MethodPost,
+			url:         fmt.Sprintf("%s/groups", ts.URL),
+			contentType: tc.contentType,
+			token:       tc.auth,
+			body:        strings.NewReader(tc.req),
+		}
+		res, err := req.make()
+		assert.Nil(t, err, fmt.Sprintf("%s: unexpected error %s", tc.desc, err))
+		assert.Equal(t, tc.status, res.StatusCode, fmt.Sprintf("%s: expected status code %d got %d", tc.desc, tc.status, res.StatusCode))
+
+		location := res.Header.Get("Location")
+		assert.Equal(t, tc.location, location, fmt.Sprintf("%s: expected location %s got %s", tc.desc, tc.location, location))
+	}
+}
+
+func TestAssignThings(t *testing.T) {
+	svc := newService(map[string]string{token: email})
+	ts := newServer(svc)
+	defer ts.Close()
+
+	ths, err := svc.CreateThings(context.Background(), token, thing)
+	require.Nil(t, err, fmt.Sprintf("unexpected error: %s\n", err))
+	th := ths[0]
+	gr, err := svc.CreateGroup(context.Background(), token, group)
+	require.Nil(t, err, fmt.Sprintf("unexpected error: %s\n", err))
+
+	data := toJSON(groupThingsReq{ThingIDs: []string{th.ID}})
+
+	cases := []struct {
+		desc        string
+		groupID     string
+		req         string
+		contentType string
+		auth        string
+		status      int
+	}{
+		{
+			desc:        "assign existing thing to existing group",
+			groupID:     gr.ID,
+			req:         data,
+			contentType: contentType,
+			auth:        token,
+			status:      http.StatusOK,
+		},
+		{
+			desc:        "assign already assigned thing to existing group",
+			groupID:     gr.ID,
+			req:         data,
+			contentType: contentType,
+			auth:        token,
+			status:      http.StatusConflict,
+		},
+		{
+			desc:        "assign existing thing to non-existent group",
+			groupID:     wrongValue,
+			req:         data,
+			contentType: contentType,
+			auth:        token,
+			status:      http.StatusNotFound,
+		},
+		{
+			desc:        "assign non-existent thing to existing group",
+			groupID:     gr.ID,
+			req:         toJSON(groupThingsReq{ThingIDs: []string{wrongValue}}),
+			contentType: contentType,
+			auth:        token,
+			status:      http.StatusNotFound,
+		},
+		{
+			desc:        "assign without things",
+			groupID:     gr.ID,
+			req:         "{}",
+			contentType: contentType,
+			auth:        token,
+			status:      http.StatusBadRequest,
+		},
+		{
+			desc:        "assign thing with invalid auth token",
+			groupID:     gr.ID,
+			req:         data,
+			contentType: contentType,
+			auth:        wrongValue,
+			status:      http.StatusUnauthorized,
+		},
+		{
+			desc:        "assign thing without content type",
+			groupID:     gr.ID,
+			req:         data,
+			contentType: "",
+			auth:        token,
+			status:      http.StatusUnsupportedMediaType,
+		},
+	}
+
+	for _, tc := range cases {
+		req := testRequest{
+			client:      ts.Client(),
+			method:      http.MethodPut,
+			url:         fmt.Sprintf("%s/groups/%s/things", ts.URL, tc.groupID),
+			contentType: tc.contentType,
+			token:       tc.auth,
+			body:        strings.NewReader(tc.req),
+		}
+		res, err := req.make()
+		assert.Nil(t, err, fmt.Sprintf("%s: unexpected error %s", tc.desc, err))
+		assert.Equal(t, tc.status, res.StatusCode, fmt.Sprintf("%s: expected status code %d got %d", tc.desc, tc.status, res.StatusCode))
+	}
+}
+
+func TestListThingsByGroup(t *testing.T) {
+	svc := newService(map[string]string{token: email})
+	ts := newServer(svc)
+	defer ts.Close()
+
+	ths, err := svc.CreateThings(context.Background(), token, thing, thing, thing)
+	require.Nil(t, err, fmt.Sprintf("unexpected error: %s\n", err))
+	gr, err := svc.CreateGroup(context.Background(), token, group)
+	require.Nil(t, err, fmt.Sprintf("unexpected error: %s\n", err))
+	err = svc.AssignThings(context.Background(), token, gr.ID, ths[0].ID, ths[1].ID)
+	require.Nil(t, err, fmt.Sprintf("unexpected error: %s\n", err))
+
+	cases := []struct {
+		desc   string
+		auth   string
+		url    string
+		status int
+		size   int
+	}{
+		{
+			desc:   "get a list of things assigned to group",
+			auth:   token,
+			url:    fmt.Sprintf("%s/groups/%s/things?offset=%d&limit=%d", ts.URL, gr.ID, 0, 10),
+			status: http.StatusOK,
+			size:   2,
+		},
+		{
+			desc:   "get a list of things assigned to group with offset",
+			auth:   token,
+			url:    fmt.Sprintf("%s/groups/%s/things?offset=%d&limit=%d", ts.URL, gr.ID, 1, 10),
+			status: http.StatusOK,
+			size:   1,
+		},
+		{
+			desc:   "get a list of things assigned to group with invalid limit",
+			auth:   token,
+			url:    fmt.Sprintf("%s/groups/%s/things?offset=%d&limit=%d", ts.URL, gr.ID, 0, 110),
+			status: http.StatusBadRequest,
+			size:   0,
+		},
+		{
+			desc:   "get a list of things assigned to group with invalid auth token",
+			auth:   wrongValue,
+			url:    fmt.Sprintf("%s/groups/%s/things", ts.URL, gr.ID),
+			status: http.StatusUnauthorized,
+			size:   0,
+		},
+	}
+
+	for _, tc := range cases {
+		req := testRequest{
+			client: ts.Client(),
+			method: http.MethodGet,
+			url:    tc.url,
+			token:  tc.auth,
+		}
+		res, err := req.make()
+		assert.Nil(t, err, fmt.Sprintf("%s: unexpected error %s", tc.desc, err))
+		var data thingsPageRes
+		json.NewDecoder(res.Body).Decode(&data)
+		assert.Equal(t, tc.status, res.StatusCode, fmt.Sprintf("%s: expected status code %d got %d", tc.desc, tc.status, res.StatusCode))
+		assert.Equal(t, tc.size, len(data.Things), fmt.Sprintf("%s: expected %d things got %d", tc.desc, tc.size, len(data.Things)))
+	}
+}
+
+func TestConnectGroup(t *testing.T) {
+	svc := newService(map[string]string{token: email})
+	ts := newServer(svc)
+	defer ts.Close()
+
+	chs, err := svc.CreateChannels(context.Background(), token, channel)
+	require.Nil(t, err, fmt.Sprintf("unexpected error: %s\n", err))
+	ch := chs[0]
+	gr, err := svc.CreateGroup(context.Background(), token, group)
+	require.Nil(t, err, fmt.Sprintf("unexpected error: %s\n", err))
+
+	cases := []struct {
+		desc        string
+		groupID     string
+		channelIDs  []string
+		actions     []string
+		contentType string
+		auth        string
+		status      int
+	}{
+		{
+			desc:        "connect existing group to existing channel",
+			groupID:     gr.ID,
+			channelIDs:  []string{ch.ID},
+			actions:     []string{things.ActionPublish},
+			contentType: contentType,
+			auth:        token,
+			status:      http.StatusOK,
+		},
+		{
+			desc:        "connect connected group to existing channel",
+			groupID:     gr.ID,
+			channelIDs:  []string{ch.ID},
+			contentType: contentType,
+			auth:        token,
+			status:      http.StatusConflict,
+		},
+		{
+			desc:        "connect existing group to non-existent channel",
+			groupID:     gr.ID,
+			channelIDs:  []string{wrongValue},
+			contentType: contentType,
+			auth:        token,
+			status:      http.StatusNotFound,
+		},
+		{
+			desc:        "connect group with invalid action",
+			groupID:     gr.ID,
+			channelIDs:  []string{ch.ID},
+			actions:     []string{wrongValue},
+			contentType: contentType,
+			auth:        token,
+			status:      http.StatusBadRequest,
+		},
+		{
+			desc:        "connect group without channels",
+			groupID:     gr.ID,
+			contentType: contentType,
+			auth:        token,
+			status:      http.StatusBadRequest,
+		},
+		{
+			desc:        "connect group with invalid auth token",
+			groupID:     gr.ID,
+			channelIDs:  []string{ch.ID},
+			contentType: contentType,
+			auth:        wrongValue,
+			status:      http.StatusUnauthorized,
+		},
+	}
+
+	for _, tc := range cases {
+		data := toJSON(connectGroupReq{
+			ChannelIDs: tc.channelIDs,
+			Actions:    tc.actions,
+		})
+		req := testRequest{
+			client:      ts.Client(),
+			method:      http.MethodPut,
+			url:         fmt.Sprintf("%s/groups/%s/connections", ts.URL, tc.groupID),
+			contentType: tc.contentType,
+			token:       tc.auth,
+			body:        strings.NewReader(data),
+		}
+		res, err := req.make()
+		assert.Nil(t, err, fmt.Sprintf("%s: unexpected error %s", tc.desc, err))
+		assert.Equal(t, tc.status, res.StatusCode, fmt.Sprintf("%s: expected status code %d got %d", tc.desc, tc.status, res.StatusCode))
+	}
+}
+
+func TestDisconnectGroup(t *testing.T) {
+	svc := newService(map[string]string{token: email})
+	ts := newServer(svc)
+	defer ts.Close()
+
+	chs, err := svc.CreateChannels(context.Background(), token, channel)
+	require.Nil(t, err, fmt.Sprintf("unexpected error: %s\n", err))
+	ch := chs[0]
+	gr, err := svc.CreateGroup(context.Background(), token, group)
+	require.Nil(t, err, fmt.Sprintf("unexpected error: %s\n", err))
+	err = svc.ConnectGroup(context.Background(), token, gr.ID, []string{ch.ID}, nil)
+	require.Nil(t, err, fmt.Sprintf("unexpected error: %s\n", err))
+
+	cases := []struct {
+		desc    string
+		groupID string
+		chanID  string
+		auth    string
+		status  int
+	}{
+		{
+			desc:    "disconnect group with invalid auth token",
+			groupID: gr.ID,
+			chanID:  ch.ID,
+			auth:    wrongValue,
+			status:  http.StatusUnauthorized,
+		},
+		{
+			desc:    "disconnect connected group",
+			groupID: gr.ID,
+			chanID:  ch.ID,
+			auth:    token,
+			status:  http.StatusNoContent,
+		},
+		{
+			desc:    "disconnect disconnected group",
+			groupID: gr.ID,
+			chanID:  ch.ID,
+			auth:    token,
+			status:  http.StatusNotFound,
+		},
+	}
+
+	for _, tc := range cases {
+		req := testRequest{
+			client: ts.Client(),
+			method: http.MethodDelete,
+			url:    fmt.Sprintf("%s/groups/%s/connections/%s", ts.URL, tc.groupID, tc.chanID),
+			token:  tc.auth,
+		}
+		res, err := req.make()
+		assert.Nil(t, err, fmt.Sprintf("%s: unexpected error %s", tc.desc, err))
+		assert.Equal(t, tc.status, res.StatusCode, fmt.Sprintf("%s: expected status code %d got %d", tc.desc, tc.status, res.StatusCode))
+	}
+}
+
 type thingRes struct {
 	ID       string                 `json:"id"`
 	Name     string                 `json:"name,omitempty"`
@@ -2154,10 +2701,11 @@ type channelRes struct {
 }
 
 type thingsPageRes struct {
-	Things []thingRes `json:"things"`
-	Total  uint64     `json:"total"`
-	Offset uint64     `json:"offset"`
-	Limit  uint64     `json:"limit"`
+	Things     []thingRes `json:"things"`
+	Total      uint64     `json:"total"`
+	Offset     uint64     `json:"offset"`
+	Limit      uint64     `json:"limit"`
+	NextCursor string     `json:"next_cursor"`
 }
 
 type channelsPageRes struct {
@@ -2167,6 +2715,20 @@ type channelsPageRes struct {
 	Limit    uint64       `json:"limit"`
 }
 
+type groupReq struct {
+	ParentID string `json:"parent_id,omitempty"`
+	Name     string `json:"name,omitempty"`
+}
+
+type groupThingsReq struct {
+	ThingIDs []string `json:"thing_ids,omitempty"`
+}
+
+type connectGroupReq struct {
+	ChannelIDs []string `json:"channel_ids,omitempty"`
+	Actions    []string `json:"actions,omitempty"`
+}
+
 type errorRes struct {
 	Err string `json:"error"`
 }
@@ -15,10 +15,11 @@ type apiReq interface {
 }
 
 type createThingReq struct {
-	token    string
-	Name     string                 `json:"name,omitempty"`
-	Key      string                 `json:"key,omitempty"`
-	Metadata map[string]interface{} `json:"metadata,omitempty"`
+	token     string
+	Name      string                 `json:"name,omitempty"`
+	Key       string                 `json:"key,omitempty"`
+	UserGroup string                 `json:"user_group,omitempty"`
+	Metadata  map[string]interface{} `json:"metadata,omitempty"`
 }
 
 func (req createThingReq) validate() error {
@@ -97,10 +98,29 @@ func (req updateKeyReq) validate() error {
 	return nil
 }
 
+type shareReq struct {
+	token     string
+	id        string
+	UserGroup string `json:"user_group"`
+}
+
+func (req shareReq) validate() error {
+	if req.token == "" {
+		return things.ErrUnauthorizedAccess
+	}
+
+	if req.id == "" || req.UserGroup == "" {
+		return things.ErrMalformedEntity
+	}
+
+	return nil
+}
+
 type createChannelReq struct {
-	token    string
-	Name     string                 `json:"name,omitempty"`
-	Metadata map[string]interface{} `json:"metadata,omitempty"`
+	token     string
+	Name      string                 `json:"name,omitempty"`
+	UserGroup string                 `json:"user_group,omitempty"`
+	Metadata  map[string]interface{} `json:"metadata,omitempty"`
 }
 
 func (req createChannelReq) validate() error {
@@ -182,6 +202,7 @@ type listResourcesReq struct {
 	token    string
 	offset   uint64
 	limit    uint64
+	cursor   string
 	name     string
 	metadata map[string]interface{}
 }
@@ -248,6 +269,7 @@ type createConnectionsReq struct {
 	token      string
 	ChannelIDs []string `json:"channel_ids,omitempty"`
 	ThingIDs   []string `json:"thing_ids,omitempty"`
+	Actions    []string `json:"actions,omitempty"`
 }
 
 func (req createConnectionsReq) validate() error {
@@ -270,5 +292,164 @@ func (req createConnectionsReq) validate() error {
 		}
 	}
 
+	return things.ValidateActions(req.Actions)
+}
+
+type createGroupReq struct {
+	token       string
+	ParentID    string                 `json:"parent_id,omitempty"`
+	Name        string                 `json:"name,omitempty"`
+	Description string                 `json:"description,omitempty"`
+	Metadata    map[string]interface{} `json:"metadata,omitempty"`
+}
+
+func (req createGroupReq) validate() error {
+	if req.token == "" {
+		return things.ErrUnauthorizedAccess
+	}
+
+	if len(req.Name) > maxNameSize || len(req.Description) > maxNameSize {
+		return things.ErrMalformedEntity
+	}
+
+	return nil
+}
+
+type updateGroupReq struct {
+	token       string
+	id          string
+	Name        string                 `json:"name,omitempty"`
+	Description string                 `json:"description,omitempty"`
+	Metadata    map[string]interface{} `json:"metadata,omitempty"`
+}
+
+func (req updateGroupReq) validate() error {
+	if req.token == "" {
+		return things.ErrUnauthorizedAccess
+	}
+
+	if req.id == "" {
+		return things.ErrMalformedEntity
+	}
+
+	if len(req.Name) > maxNameSize || len(req.Description) > maxNameSize {
+		return things.ErrMalformedEntity
+	}
+
+	return nil
+}
+
+type listByGroupReq struct {
+	token    string
+	id       string
+	offset   uint64
+	limit    uint64
+	metadata map[string]interface{}
+}
+
+func (req listByGroupReq) validate() error {
+	if req.token == "" {
+		return things.ErrUnauthorizedAccess
+	}
+
+	if req.id == "" {
+		return things.ErrMalformedEntity
+	}
+
+	if req.limit == 0 || req.limit > maxLimitSize {
+		return things.ErrMalformedEntity
+	}
+
+	return nil
+}
+
+type groupThingsReq struct {
+	token    string
+	id       string
+	ThingIDs []string `json:"thing_ids,omitempty"`
+}
+
+func (req groupThingsReq) validate() error {
+	if req.token == "" {
+		return things.ErrUnauthorizedAccess
+	}
+
+	if req.id == "" || len(req.ThingIDs) == 0 {
+		return things.ErrMalformedEntity
+	}
+
+	for _, thingID := range req.ThingIDs {
+		if thingID == "" {
+			return things.ErrMalformedEntity
+		}
+	}
+
+	return nil
+}
+
+type groupChannelsReq struct {
+	token      string
+	id         string
+	ChannelIDs []string `json:"channel_ids,omitempty"`
+}
+
+func (req groupChannelsReq) validate() error {
+	if req.token == "" {
+		return things.ErrUnauthorizedAccess
+	}
+
+	if req.id == "" || len(req.ChannelIDs) == 0 {
+		return things.ErrMalformedEntity
+	}
+
+	for _, chID := range req.ChannelIDs {
+		if chID == "" {
+			return things.ErrMalformedEntity
+		}
+	}
+
+	return nil
+}
+
+type connectGroupReq struct {
+	token      string
+	id         string
+	ChannelIDs []string `json:"channel_ids,omitempty"`
+	Actions    []string `json:"actions,omitempty"`
+}
+
+func (req connectGroupReq) validate() error {
+	if req.token == "" {
+		return things.ErrUnauthorizedAccess
+	}
+
+	if req.id == "" || len(req.ChannelIDs) == 0 {
+		return things.ErrMalformedEntity
+	}
+
+	for _, chID := range req.ChannelIDs {
+		if chID == "" {
+			return things.ErrMalformedEntity
+		}
+	}
+
+	return things.ValidateActions(req.Actions)
+}
+
+type disconnectGroupReq struct {
+	token   string
+	groupID string
+	chanID  string
+}
+
+func (req disconnectGroupReq) validate() error {
+	if req.token == "" {
+		return things.ErrUnauthorizedAccess
+	}
+
+	if req.groupID == "" || req.chanID == "" {
+		return things.ErrMalformedEntity
+	}
+
 	return nil
 }
@@ -20,6 +20,11 @@ var (
 	_ mainflux.Response = (*channelsPageRes)(nil)
 	_ mainflux.Response = (*connectionRes)(nil)
 	_ mainflux.Response = (*disconnectionRes)(nil)
+	_ mainflux.Response = (*groupRes)(nil)
+	_ mainflux.Response = (*viewGroupRes)(nil)
+	_ mainflux.Response = (*groupsPageRes)(nil)
+	_ mainflux.Response = (*assignRes)(nil)
+	_ mainflux.Response = (*unassignRes)(nil)
 )
 
 type removeRes struct{}
@@ -37,11 +42,12 @@ func (res removeRes) Empty() bool {
 }
 
 type thingRes struct {
-	ID       string                 `json:"id"`
-	Name     string                 `json:"name,omitempty"`
-	Key      string                 `json:"key"`
-	Metadata map[string]interface{} `json:"metadata,omitempty"`
-	created  bool
+	ID        string                 `json:"id"`
+	Name      string                 `json:"name,omitempty"`
+	UserGroup string                 `json:"user_group,omitempty"`
+	Key       string                 `json:"key"`
+	Metadata  map[string]interface{} `json:"metadata,omitempty"`
+	created   bool
 }
 
 func (res thingRes) Code() int {
@@ -89,11 +95,12 @@ func (res thingsRes) Empty() bool {
 }
 
 type viewThingRes struct {
-	ID       string                 `json:"id"`
-	Owner    string                 `json:"-"`
-	Name     string                 `json:"name,omitempty"`
-	Key      string                 `json:"key"`
-	Metadata map[string]interface{} `json:"metadata,omitempty"`
+	ID        string                 `json:"id"`
+	Owner     string                 `json:"-"`
+	Name      string                 `json:"name,omitempty"`
+	UserGroup string                 `json:"user_group,omitempty"`
+	Key       string                 `json:"key"`
+	Metadata  map[string]interface{} `json:"metadata,omitempty"`
 }
 
 func (res viewThingRes) Code() int {
@@ -126,10 +133,11 @@ func (res thingsPageRes) Empty() bool {
 }
 
 type channelRes struct {
-	ID       string                 `json:"id"`
-	Name     string                 `json:"name,omitempty"`
-	Metadata map[string]interface{} `json:"metadata,omitempty"`
-	created  bool
+	ID        string                 `json:"id"`
+	Name      string                 `json:"name,omitempty"`
+	UserGroup string                 `json:"user_group,omitempty"`
+	Metadata  map[string]interface{} `json:"metadata,omitempty"`
+	created   bool
 }
 
 func (res channelRes) Code() int {
@@ -177,11 +185,12 @@ func (res channelsRes) Empty() bool {
 }
 
 type viewChannelRes struct {
-	ID       string                 `json:"id"`
-	Owner    string                 `json:"-"`
-	Name     string                 `json:"name,omitempty"`
-	Things   []viewThingRes         `json:"connected,omitempty"`
-	Metadata map[string]interface{} `json:"metadata,omitempty"`
+	ID        string                 `json:"id"`
+	Owner     string                 `json:"-"`
+	Name      string                 `json:"name,omitempty"`
+	UserGroup string                 `json:"user_group,omitempty"`
+	Things    []viewThingRes         `json:"connected,omitempty"`
+	Metadata  map[string]interface{} `json:"metadata,omitempty"`
 }
 
 func (res viewChannelRes) Code() int {
@@ -257,10 +266,104 @@ func (res disconnectionRes) Empty() bool {
 	return true
 }
 
+type groupRes struct {
+	ID      string `json:"id"`
+	created bool
+}
+
+func (res groupRes) Code() int {
+	if res.created {
+		return http.StatusCreated
+	}
+
+	return http.StatusOK
+}
+
+func (res groupRes) Headers() map[string]string {
+	if res.created {
+		return map[string]string{
+			"Location": fmt.Sprintf("/groups/%s", res.ID),
+		}
+	}
+
+	return map[string]string{}
+}
+
+func (res groupRes) Empty() bool {
+	return true
+}
+
+type viewGroupRes struct {
+	ID          string                 `json:"id"`
+	Owner       string                 `json:"-"`
+	ParentID    string                 `json:"parent_id,omitempty"`
+	Name        string                 `json:"name,omitempty"`
+	Description string                 `json:"description,omitempty"`
+	Metadata    map[string]interface{} `json:"metadata,omitempty"`
+}
+
+func (res viewGroupRes) Code() int {
+	return http.StatusOK
+}
+
+func (res viewGroupRes) Headers() map[string]string {
+	return map[string]string{}
+}
+
+func (res viewGroupRes) Empty() bool {
+	return false
+}
+
+type groupsPageRes struct {
+	pageRes
+	Groups []viewGroupRes `json:"groups"`
+}
+
+func (res groupsPageRes) Code() int {
+	return http.StatusOK
+}
+
+func (res groupsPageRes) Headers() map[string]string {
+	return map[string]string{}
+}
+
+func (res groupsPageRes) Empty() bool {
+	return false
+}
+
+type assignRes struct{}
+
+func (res assignRes) Code() int {
+	return http.StatusOK
+}
+
+func (res assignRes) Headers() map[string]string {
+	return map[string]string{}
+}
+
+func (res assignRes) Empty() bool {
+	return true
+}
+
+type unassignRes struct{}
+
+func (res unassignRes) Code() int {
+	return http.StatusNoContent
+}
+
+func (res unassignRes) Headers() map[string]string {
+	return map[string]string{}
+}
+
+func (res unassignRes) Empty() bool {
+	return true
+}
+
 type pageRes struct {
-	Total  uint64 `json:"total"`
-	Offset uint64 `json:"offset"`
-	Limit  uint64 `json:"limit"`
+	Total      uint64 `json:"total"`
+	Offset     uint64 `json:"offset"`
+	Limit      uint64 `json:"limit"`
+	NextCursor string `json:"next_cursor,omitempty"`
 }
 
 type errorRes struct {
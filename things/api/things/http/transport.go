@@ -15,6 +15,7 @@ import (
 	kithttp "github.com/go-kit/kit/transport/http"
 	"github.com/go-zoo/bone"
 	"github.com/mainflux/mainflux"
+	"github.com/mainflux/mainflux/pkg/audit"
 	"github.com/mainflux/mainflux/pkg/errors"
 	"github.com/mainflux/mainflux/things"
 	opentracing "github.com/opentracing/opentracing-go"
@@ -27,6 +28,7 @@ const (
 	limitKey    = "limit"
 	nameKey     = "name"
 	metadataKey = "metadata"
+	cursorKey   = "cursor"
 	connKey     = "connected"
 
 	defOffset = 0
@@ -42,6 +44,7 @@ var (
 func MakeHandler(tracer opentracing.Tracer, svc things.Service) http.Handler {
 	opts := []kithttp.ServerOption{
 		kithttp.ServerErrorEncoder(encodeError),
+		kithttp.ServerBefore(audit.PopulateIP),
 	}
 
 	r := bone.New()
@@ -67,6 +70,20 @@ func MakeHandler(tracer opentracing.Tracer, svc things.Service) http.Handler {
 		opts...,
 	))
 
+	r.Put("/things/:id/share", kithttp.NewServer(
+		kitot.TraceServer(tracer, "share_thing")(shareThingEndpoint(svc)),
+		decodeShare,
+		encodeResponse,
+		opts...,
+	))
+
+	r.Delete("/things/:id/share", kithttp.NewServer(
+		kitot.TraceServer(tracer, "unshare_thing")(unshareThingEndpoint(svc)),
+		decodeView,
+		encodeResponse,
+		opts...,
+	))
+
 	r.Put("/things/:id", kithttp.NewServer(
 		kitot.TraceServer(tracer, "update_thing")(updateThingEndpoint(svc)),
 		decodeThingUpdate,
@@ -116,6 +133,20 @@ func MakeHandler(tracer opentracing.Tracer, svc things.Service) http.Handler {
 		opts...,
 	))
 
+	r.Put("/channels/:id/share", kithttp.NewServer(
+		kitot.TraceServer(tracer, "share_channel")(shareChannelEndpoint(svc)),
+		decodeShare,
+		encodeResponse,
+		opts...,
+	))
+
+	r.Delete("/channels/:id/share", kithttp.NewServer(
+		kitot.TraceServer(tracer, "unshare_channel")(unshareChannelEndpoint(svc)),
+		decodeView,
+		encodeResponse,
+		opts...,
+	))
+
 	r.Put("/channels/:id", kithttp.NewServer(
 		kitot.TraceServer(tracer, "update_channel")(updateChannelEndpoint(svc)),
 		decodeChannelUpdate,
@@ -172,6 +203,104 @@ func MakeHandler(tracer opentracing.Tracer, svc things.Service) http.Handler {
 		opts...,
 	))
 
+	r.Post("/groups", kithttp.NewServer(
+		kitot.TraceServer(tracer, "create_group")(createGroupEndpoint(svc)),
+		decodeGroupCreation,
+		encodeResponse,
+		opts...,
+	))
+
+	r.Put("/groups/:id", kithttp.NewServer(
+		kitot.TraceServer(tracer, "update_group")(updateGroupEndpoint(svc)),
+		decodeGroupUpdate,
+		encodeResponse,
+		opts...,
+	))
+
+	r.Delete("/groups/:id", kithttp.NewServer(
+		kitot.TraceServer(tracer, "remove_group")(removeGroupEndpoint(svc)),
+		decodeView,
+		encodeResponse,
+		opts...,
+	))
+
+	r.Get("/groups/:id", kithttp.NewServer(
+		kitot.TraceServer(tracer, "view_group")(viewGroupEndpoint(svc)),
+		decodeView,
+		encodeResponse,
+		opts...,
+	))
+
+	r.Get("/groups", kithttp.NewServer(
+		kitot.TraceServer(tracer, "list_groups")(listGroupsEndpoint(svc)),
+		decodeList,
+		encodeResponse,
+		opts...,
+	))
+
+	r.Get("/groups/:id/groups", kithttp.NewServer(
+		kitot.TraceServer(tracer, "list_children")(listChildrenEndpoint(svc)),
+		decodeListByGroup,
+		encodeResponse,
+		opts...,
+	))
+
+	r.Get("/groups/:id/things", kithttp.NewServer(
+		kitot.TraceServer(tracer, "list_things_by_group")(listThingsByGroupEndpoint(svc)),
+		decodeListByGroup,
+		encodeResponse,
+		opts...,
+	))
+
+	r.Put("/groups/:id/things", kithttp.NewServer(
+		kitot.TraceServer(tracer, "assign_things")(assignThingsEndpoint(svc)),
+		decodeGroupThings,
+		encodeResponse,
+		opts...,
+	))
+
+	r.Delete("/groups/:id/things", kithttp.NewServer(
+		kitot.TraceServer(tracer, "unassign_things")(unassignThingsEndpoint(svc)),
+		decodeGroupThings,
+		encodeResponse,
+		opts...,
+	))
+
+	r.Get("/groups/:id/channels", kithttp.NewServer(
+		kitot.TraceServer(tracer, "list_channels_by_group")(listChannelsByGroupEndpoint(svc)),
+		decodeListByGroup,
+		encodeResponse,
+		opts...,
+	))
+
+	r.Put("/groups/:id/channels", kithttp.NewServer(
+		kitot.TraceServer(tracer, "assign_channels")(assignChannelsEndpoint(svc)),
+		decodeGroupChannels,
+		encodeResponse,
+		opts...,
+	))
+
+	r.Delete("/groups/:id/channels", kithttp.NewServer(
+		kitot.TraceServer(tracer, "unassign_channels")(unassignChannelsEndpoint(svc)),
+		decodeGroupChannels,
+		encodeResponse,
+		opts...,
+	))
+
+	r.Put("/groups/:id/connections", kithttp.NewServer(
+		kitot.TraceServer(tracer, "connect_group")(connectGroupEndpoint(svc)),
+		decodeGroupConnection,
+		encodeResponse,
+		opts...,
+	))
+
+	r.Delete("/groups/:groupId/connections/:chanId", kithttp.NewServer(
+		kitot.TraceServer(tracer, "disconnect_group")(disconnectGroupEndpoint(svc)),
+		decodeGroupDisconnection,
+		encodeResponse,
+		opts...,
+	))
+
 	r.GetFunc("/version", mainflux.Version("things"))
 	r.Handle("/metrics", promhttp.Handler())
 
@@ -236,6 +365,22 @@ func decodeKeyUpdate(_ context.Context, r *http.Request) (interface{}, error) {
 	return req, nil
 }
 
+func decodeShare(_ context.Context, r *http.Request) (interface{}, error) {
+	if !strings.Contains(r.Header.Get("Content-Type"), contentType) {
+		return nil, errUnsupportedContentType
+	}
+
+	req := shareReq{
+		token: r.Header.Get("Authorization"),
+		id:    bone.GetValue(r, "id"),
+	}
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		return nil, errors.Wrap(things.ErrMalformedEntity, err)
+	}
+
+	return req, nil
+}
+
 func decodeChannelCreation(_ context.Context, r *http.Request) (interface{}, error) {
 	if !strings.Contains(r.Header.Get("Content-Type"), contentType) {
 		return nil, errUnsupportedContentType
@@ -309,10 +454,16 @@ func decodeList(_ context.Context, r *http.Request) (interface{}, error) {
 		return nil, err
 	}
 
+	c, err := readStringQuery(r, cursorKey)
+	if err != nil {
+		return nil, err
+	}
+
 	req := listResourcesReq{
 		token:    r.Header.Get("Authorization"),
 		offset:   o,
 		limit:    l,
+		cursor:   c,
 		name:     n,
 		metadata: m,
 	}
@@ -370,6 +521,120 @@ func decodeCreateConnections(_ context.Context, r *http.Request) (interface{}, e
 	return req, nil
 }
 
+func decodeGroupCreation(_ context.Context, r *http.Request) (interface{}, error) {
+	if !strings.Contains(r.Header.Get("Content-Type"), contentType) {
+		return nil, errUnsupportedContentType
+	}
+
+	req := createGroupReq{token: r.Header.Get("Authorization")}
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		return nil, errors.Wrap(things.ErrMalformedEntity, err)
+	}
+
+	return req, nil
+}
+
+func decodeGroupUpdate(_ context.Context, r *http.Request) (interface{}, error) {
+	if !strings.Contains(r.Header.Get("Content-Type"), contentType) {
+		return nil, errUnsupportedContentType
+	}
+
+	req := updateGroupReq{
+		token: r.Header.Get("Authorization"),
+		id:    bone.GetValue(r, "id"),
+	}
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		return nil, errors.Wrap(things.ErrMalformedEntity, err)
+	}
+
+	return req, nil
+}
+
+func decodeListByGroup(_ context.Context, r *http.Request) (interface{}, error) {
+	o, err := readUintQuery(r, offsetKey, defOffset)
+	if err != nil {
+		return nil, err
+	}
+
+	l, err := readUintQuery(r, limitKey, defLimit)
+	if err != nil {
+		return nil, err
+	}
+
+	m, err := readMetadataQuery(r, metadataKey)
+	if err != nil {
+		return nil, err
+	}
+
+	req := listByGroupReq{
+		token:    r.Header.Get("Authorization"),
+		id:       bone.GetValue(r, "id"),
+		offset:   o,
+		limit:    l,
+		metadata: m,
+	}
+
+	return req, nil
+}
+
+func decodeGroupThings(_ context.Context, r *http.Request) (interface{}, error) {
+	if !strings.Contains(r.Header.Get("Content-Type"), contentType) {
+		return nil, errUnsupportedContentType
+	}
+
+	req := groupThingsReq{
+		token: r.Header.Get("Authorization"),
+		id:    bone.GetValue(r, "id"),
+	}
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		return nil, errors.Wrap(things.ErrMalformedEntity, err)
+	}
+
+	return req, nil
+}
+
+func decodeGroupChannels(_ context.Context, r *http.Request) (interface{}, error) {
+	if !strings.Contains(r.Header.Get("Content-Type"), contentType) {
+		return nil, errUnsupportedContentType
+	}
+
+	req := groupChannelsReq{
+		token: r.Header.Get("Authorization"),
+		id:    bone.GetValue(r, "id"),
+	}
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		return nil, errors.Wrap(things.ErrMalformedEntity, err)
+	}
+
+	return req, nil
+}
+
+func decodeGroupConnection(_ context.Context, r *http.Request) (interface{}, error) {
+	if !strings.Contains(r.Header.Get("Content-Type"), contentType) {
+		return nil, errUnsupportedContentType
+	}
+
+	req := connectGroupReq{
+		token: r.Header.Get("Authorization"),
+		id:    bone.GetValue(r, "id"),
+	}
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		return nil, errors.Wrap(things.ErrMalformedEntity, err)
+	}
+
+	return req, nil
+}
+
+func decodeGroupDisconnection(_ context.Context, r *http.Request) (interface{}, error) {
+	req := disconnectGroupReq{
+		token:   r.Header.Get("Authorization"),
+		groupID: bone.GetValue(r, "groupId"),
+		chanID:  bone.GetValue(r, "chanId"),
+	}
+
+	return req, nil
+}
+
 func encodeResponse(_ context.Context, w http.ResponseWriter, response interface{}) error {
 	w.Header().Set("Content-Type", contentType)
 
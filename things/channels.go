@@ -7,13 +7,26 @@ import (
 	"context"
 )
 
+const (
+	// ActionPublish allows a connected thing to publish messages to the channel.
+	ActionPublish = "publish"
+
+	// ActionSubscribe allows a connected thing to receive messages from the channel.
+	ActionSubscribe = "subscribe"
+)
+
+// DefaultActions are granted to the connection when no actions are specified.
+var DefaultActions = []string{ActionPublish, ActionSubscribe}
+
 // Channel represents a Mainflux "communication group". This group contains the
-// things that can exchange messages between eachother.
+// things that can exchange messages between eachother. The channel may be
+// shared with the members of the users group.
 type Channel struct {
-	ID       string
-	Owner    string
-	Name     string
-	Metadata map[string]interface{}
+	ID        string
+	Owner     string
+	UserGroup string
+	Name      string
+	Metadata  map[string]interface{}
 }
 
 // ChannelsPage contains page related metadata as well as list of channels that
@@ -30,50 +43,60 @@ type ChannelRepository interface {
 	// error response.
 	Save(ctx context.Context, chs ...Channel) ([]Channel, error)
 
-	// Update performs an update to the existing channel. A non-nil error is
-	// returned to indicate operation failure.
-	Update(ctx context.Context, c Channel) error
+	// Update performs an update to the existing accessible channel. A
+	// non-nil error is returned to indicate operation failure.
+	Update(ctx context.Context, acc Access, c Channel) error
 
-	// RetrieveByID retrieves the channel having the provided identifier, that is owned
-	// by the specified user.
-	RetrieveByID(ctx context.Context, owner, id string) (Channel, error)
+	// Share shares the channel owned by the owner with the users group, or
+	// unshares it if the group is empty. A non-nil error is returned to
+	// indicate operation failure.
+	Share(ctx context.Context, owner, id, group string) error
 
-	// RetrieveAll retrieves the subset of channels owned by the specified user.
-	RetrieveAll(ctx context.Context, owner string, offset, limit uint64, name string, m Metadata) (ChannelsPage, error)
+	// RetrieveByID retrieves the accessible channel having the provided
+	// identifier.
+	RetrieveByID(ctx context.Context, acc Access, id string) (Channel, error)
 
-	// RetrieveByThing retrieves the subset of channels owned by the specified
-	// user and have specified thing connected or not connected to them.
-	RetrieveByThing(ctx context.Context, owner, thing string, offset, limit uint64, connected bool) (ChannelsPage, error)
+	// RetrieveAll retrieves the subset of accessible channels. If cursor is
+	// provided, channels following the cursor are retrieved and the offset
+	// is ignored.
+	RetrieveAll(ctx context.Context, acc Access, offset, limit uint64, cursor, name string, m Metadata) (ChannelsPage, error)
 
-	// Remove removes the channel having the provided identifier, that is owned
-	// by the specified user.
-	Remove(ctx context.Context, owner, id string) error
+	// RetrieveByThing retrieves the subset of accessible channels that have
+	// specified thing connected or not connected to them.
+	RetrieveByThing(ctx context.Context, acc Access, thing string, offset, limit uint64, connected bool) (ChannelsPage, error)
 
-	// Connect adds things to the channel's list of connected things.
-	Connect(ctx context.Context, owner string, chIDs, thIDs []string) error
+	// Remove removes the accessible channel having the provided identifier.
+	Remove(ctx context.Context, acc Access, id string) error
 
-	// Disconnect removes thing from the channel's list of connected
+	// Connect adds accessible things to the accessible channel's list of
+	// connected things. Each connection is allowed to perform only the
+	// specified actions, which replace the actions of the already connected
 	// things.
-	Disconnect(ctx context.Context, owner, chanID, thingID string) error
+	Connect(ctx context.Context, acc Access, chIDs, thIDs, actions []string) error
+
+	// Disconnect removes accessible thing from the accessible channel's list
+	// of connected things.
+	Disconnect(ctx context.Context, acc Access, chanID, thingID string) error
 
 	// HasThing determines whether the thing with the provided access key, is
-	// "connected" to the specified channel. If that's the case, it returns
-	// thing's ID.
-	HasThing(ctx context.Context, chanID, key string) (string, error)
+	// "connected" to the specified channel and allowed to perform the given
+	// action. If that's the case, it returns thing's ID.
+	HasThing(ctx context.Context, chanID, key, action string) (string, error)
 
 	// HasThingByID determines whether the thing with the provided ID, is
-	// "connected" to the specified channel. If that's the case, then
-	// returned error will be nil.
-	HasThingByID(ctx context.Context, chanID, thingID string) error
+	// "connected" to the specified channel and allowed to perform the given
+	// action. If that's the case, then returned error will be nil.
+	HasThingByID(ctx context.Context, chanID, thingID, action string) error
 }
 
 // ChannelCache contains channel-thing connection caching interface.
 type ChannelCache interface {
-	// Connect channel thing connection.
-	Connect(context.Context, string, string) error
+	// Connect channel thing connection for the given actions.
+	Connect(context.Context, string, string, []string) error
 
-	// HasThing checks if thing is connected to channel.
-	HasThing(context.Context, string, string) bool
+	// HasThing checks if thing is connected to channel and allowed to
+	// perform the given action.
+	HasThing(context.Context, string, string, string) bool
 
 	// Disconnects thing from channel.
 	Disconnect(context.Context, string, string) error
@@ -81,3 +104,14 @@ type ChannelCache interface {
 	// Removes channel from cache.
 	Remove(context.Context, string) error
 }
+
+// ValidateActions checks that every provided connection action is known.
+func ValidateActions(actions []string) error {
+	for _, a := range actions {
+		if a != ActionPublish && a != ActionSubscribe {
+			return ErrMalformedEntity
+		}
+	}
+
+	return nil
+}
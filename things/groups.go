@@ -0,0 +1,83 @@
+// Copyright (c) Mainflux
+// SPDX-License-Identifier: Apache-2.0
+
+package things
+
+import (
+	"context"
+)
+
+// Group represents a Mainflux group of things and channels. Groups can be
+// nested, in which case ParentID holds the identifier of the parent group.
+type Group struct {
+	ID          string
+	Owner       string
+	ParentID    string
+	Name        string
+	Description string
+	Metadata    Metadata
+}
+
+// GroupsPage contains page related metadata as well as list of groups that
+// belong to this page.
+type GroupsPage struct {
+	PageMetadata
+	Groups []Group
+}
+
+// GroupRepository specifies a group persistence API.
+type GroupRepository interface {
+	// Save persists the group. Successful operation is indicated by non-nil
+	// error response.
+	Save(ctx context.Context, g Group) (Group, error)
+
+	// Update performs an update to the existing group. A non-nil error is
+	// returned to indicate operation failure.
+	Update(ctx context.Context, g Group) error
+
+	// RetrieveByID retrieves the group having the provided identifier, that is owned
+	// by the specified user.
+	RetrieveByID(ctx context.Context, owner, id string) (Group, error)
+
+	// RetrieveAll retrieves the subset of groups owned by the specified user.
+	// If parent ID is provided, only descendants of the parent group are retrieved.
+	RetrieveAll(ctx context.Context, owner, parentID string, offset, limit uint64, m Metadata) (GroupsPage, error)
+
+	// RetrieveThings retrieves the subset of things assigned to the group.
+	RetrieveThings(ctx context.Context, owner, groupID string, offset, limit uint64) (Page, error)
+
+	// RetrieveChannels retrieves the subset of channels assigned to the group.
+	RetrieveChannels(ctx context.Context, owner, groupID string, offset, limit uint64) (ChannelsPage, error)
+
+	// RetrieveConnections retrieves IDs of channels connected to the group
+	// or to any of its descendants.
+	RetrieveConnections(ctx context.Context, owner, groupID string) ([]string, error)
+
+	// Remove removes the group having the provided identifier, that is owned
+	// by the specified user, together with its descendants. Connections
+	// established through removed groups are removed as well.
+	Remove(ctx context.Context, owner, id string) error
+
+	// AssignThings adds things to the group and connects them to every
+	// channel the group is connected to.
+	AssignThings(ctx context.Context, owner, groupID string, thIDs ...string) error
+
+	// UnassignThings removes things from the group and disconnects them from
+	// every channel the group is connected to.
+	UnassignThings(ctx context.Context, owner, groupID string, thIDs ...string) error
+
+	// AssignChannels adds channels to the group.
+	AssignChannels(ctx context.Context, owner, groupID string, chIDs ...string) error
+
+	// UnassignChannels removes channels from the group.
+	UnassignChannels(ctx context.Context, owner, groupID string, chIDs ...string) error
+
+	// Connect connects the group to the channels. Every thing assigned to
+	// the group, now or later, is connected to the channels and allowed to
+	// perform only the specified actions.
+	Connect(ctx context.Context, owner, groupID string, chIDs, actions []string) error
+
+	// Disconnect disconnects the group from the channel, disconnecting all
+	// of the group's things from it.
+	Disconnect(ctx context.Context, owner, groupID, chanID string) error
+}
@@ -6,6 +6,7 @@ package mocks
 import (
 	"context"
 
+	"github.com/golang/protobuf/ptypes/empty"
 	"github.com/mainflux/mainflux"
 	"github.com/mainflux/mainflux/users"
 	"google.golang.org/grpc"
@@ -14,17 +15,32 @@ import (
 var _ mainflux.AuthNServiceClient = (*authServiceMock)(nil)
 
 type authServiceMock struct {
-	users map[string]string
+	users  map[string]string
+	scopes map[string][]*mainflux.Scope
+	admin  string
 }
 
 // NewAuthService creates mock of users service.
 func NewAuthService(users map[string]string) mainflux.AuthNServiceClient {
-	return &authServiceMock{users}
+	return NewScopedAuthService(users, map[string][]*mainflux.Scope{})
+}
+
+// NewScopedAuthService creates mock of users service which identifies the
+// tokens present in the scopes map as API keys restricted to the scopes.
+func NewScopedAuthService(users map[string]string, scopes map[string][]*mainflux.Scope) mainflux.AuthNServiceClient {
+	return &authServiceMock{users: users, scopes: scopes}
+}
+
+// NewAdminAuthService creates mock of users service which identifies the
+// user with the given email as the admin.
+func NewAdminAuthService(users map[string]string, admin string) mainflux.AuthNServiceClient {
+	return &authServiceMock{users: users, scopes: map[string][]*mainflux.Scope{}, admin: admin}
 }
 
 func (svc authServiceMock) Identify(ctx context.Context, in *mainflux.Token, opts ...grpc.CallOption) (*mainflux.UserIdentity, error) {
 	if id, ok := svc.users[in.Value]; ok {
-		return &mainflux.UserIdentity{Id: id, Email: id}, nil
+		admin := svc.admin != "" && id == svc.admin
+		return &mainflux.UserIdentity{Id: id, Email: id, Admin: admin, Scopes: svc.scopes[in.Value]}, nil
 	}
 	return nil, users.ErrUnauthorizedAccess
 }
@@ -38,3 +54,27 @@ func (svc authServiceMock) Issue(ctx context.Context, in *mainflux.IssueReq, opt
 	}
 	return nil, users.ErrUnauthorizedAccess
 }
+
+func (svc authServiceMock) RevokeToken(ctx context.Context, in *mainflux.Token, opts ...grpc.CallOption) (*empty.Empty, error) {
+	panic("not implemented")
+}
+
+func (svc authServiceMock) RevokeSessions(ctx context.Context, in *mainflux.Token, opts ...grpc.CallOption) (*empty.Empty, error) {
+	panic("not implemented")
+}
+
+func (svc authServiceMock) Refresh(ctx context.Context, in *mainflux.Token, opts ...grpc.CallOption) (*mainflux.Tokens, error) {
+	panic("not implemented")
+}
+
+func (svc authServiceMock) VerifyChallenge(ctx context.Context, in *mainflux.Token, opts ...grpc.CallOption) (*mainflux.UserIdentity, error) {
+	panic("not implemented")
+}
+
+func (svc authServiceMock) Impersonate(ctx context.Context, in *mainflux.ImpersonateReq, opts ...grpc.CallOption) (*mainflux.Token, error) {
+	panic("not implemented")
+}
+
+func (svc authServiceMock) SetDisabled(ctx context.Context, in *mainflux.DisableReq, opts ...grpc.CallOption) (*empty.Empty, error) {
+	panic("not implemented")
+}
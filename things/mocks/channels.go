@@ -5,12 +5,12 @@ package mocks
 
 import (
 	"context"
-	"fmt"
 	"sort"
 	"strconv"
-	"strings"
 	"sync"
 
+	mfcursor "github.com/mainflux/mainflux/pkg/cursor"
+	"github.com/mainflux/mainflux/pkg/errors"
 	"github.com/mainflux/mainflux/things"
 )
 
@@ -30,6 +30,7 @@ type channelRepositoryMock struct {
 	channels map[string]things.Channel
 	tconns   chan Connection                      // used for syncronization with thing repo
 	cconns   map[string]map[string]things.Channel // used to track connections
+	actions  map[string][]string                  // used to track connection actions
 	things   things.ThingRepository
 }
 
@@ -39,6 +40,7 @@ func NewChannelRepository(repo things.ThingRepository, tconns chan Connection) t
 		channels: make(map[string]things.Channel),
 		tconns:   tconns,
 		cconns:   make(map[string]map[string]things.Channel),
+		actions:  make(map[string][]string),
 		things:   repo,
 	}
 }
@@ -56,29 +58,46 @@ func (crm *channelRepositoryMock) Save(_ context.Context, channels ...things.Cha
 	return channels, nil
 }
 
-func (crm *channelRepositoryMock) Update(_ context.Context, channel things.Channel) error {
+func (crm *channelRepositoryMock) Update(_ context.Context, acc things.Access, channel things.Channel) error {
 	crm.mu.Lock()
 	defer crm.mu.Unlock()
 
-	dbKey := key(channel.Owner, channel.ID)
-
-	if _, ok := crm.channels[dbKey]; !ok {
+	dbKey, ok := crm.find(acc, channel.ID)
+	if !ok {
 		return things.ErrNotFound
 	}
 
+	channel.Owner = crm.channels[dbKey].Owner
+	channel.UserGroup = crm.channels[dbKey].UserGroup
 	crm.channels[dbKey] = channel
 	return nil
 }
 
-func (crm *channelRepositoryMock) RetrieveByID(_ context.Context, owner, id string) (things.Channel, error) {
-	if c, ok := crm.channels[key(owner, id)]; ok {
-		return c, nil
+func (crm *channelRepositoryMock) Share(_ context.Context, owner, id, group string) error {
+	crm.mu.Lock()
+	defer crm.mu.Unlock()
+
+	dbKey, ok := crm.find(things.Access{Owner: owner}, id)
+	if !ok {
+		return things.ErrNotFound
+	}
+
+	ch := crm.channels[dbKey]
+	ch.UserGroup = group
+	crm.channels[dbKey] = ch
+
+	return nil
+}
+
+func (crm *channelRepositoryMock) RetrieveByID(_ context.Context, acc things.Access, id string) (things.Channel, error) {
+	if dbKey, ok := crm.find(acc, id); ok {
+		return crm.channels[dbKey], nil
 	}
 
 	return things.Channel{}, things.ErrNotFound
 }
 
-func (crm *channelRepositoryMock) RetrieveAll(_ context.Context, owner string, offset, limit uint64, name string, metadata things.Metadata) (things.ChannelsPage, error) {
+func (crm *channelRepositoryMock) RetrieveAll(_ context.Context, acc things.Access, offset, limit uint64, cursor, name string, metadata things.Metadata) (things.ChannelsPage, error) {
 	channels := make([]things.Channel, 0)
 
 	if offset < 0 || limit <= 0 {
@@ -86,14 +105,22 @@ func (crm *channelRepositoryMock) RetrieveAll(_ context.Context, owner string, o
 	}
 
 	first := uint64(offset) + 1
+	if cursor != "" {
+		keys, err := mfcursor.Decode(cursor, 1)
+		if err != nil {
+			return things.ChannelsPage{}, errors.Wrap(things.ErrMalformedEntity, err)
+		}
+		after, err := strconv.ParseUint(keys[0], 10, 64)
+		if err != nil {
+			return things.ChannelsPage{}, errors.Wrap(things.ErrMalformedEntity, err)
+		}
+		first = after + 1
+	}
 	last := first + uint64(limit)
 
-	// This obscure way to examine map keys is enforced by the key structure
-	// itself (see mocks/commons.go).
-	prefix := fmt.Sprintf("%s-", owner)
-	for k, v := range crm.channels {
+	for _, v := range crm.channels {
 		id, _ := strconv.ParseUint(v.ID, 10, 64)
-		if strings.HasPrefix(k, prefix) && id >= first && id < last {
+		if canAccess(acc, v.Owner, v.UserGroup) && id >= first && id < last {
 			channels = append(channels, v)
 		}
 	}
@@ -111,10 +138,14 @@ func (crm *channelRepositoryMock) RetrieveAll(_ context.Context, owner string, o
 		},
 	}
 
+	if l := len(channels); l > 0 && uint64(l) == limit {
+		page.NextCursor = mfcursor.Encode(strconv.FormatUint(last-1, 10))
+	}
+
 	return page, nil
 }
 
-func (crm *channelRepositoryMock) RetrieveByThing(_ context.Context, owner, thingID string, offset, limit uint64, connected bool) (things.ChannelsPage, error) {
+func (crm *channelRepositoryMock) RetrieveByThing(_ context.Context, _ things.Access, thingID string, offset, limit uint64, connected bool) (things.ChannelsPage, error) {
 	channels := make([]things.Channel, 0)
 
 	if offset < 0 || limit <= 0 {
@@ -168,11 +199,16 @@ func (crm *channelRepositoryMock) RetrieveByThing(_ context.Context, owner, thin
 	return page, nil
 }
 
-func (crm *channelRepositoryMock) Remove(_ context.Context, owner, id string) error {
-	delete(crm.channels, key(owner, id))
+func (crm *channelRepositoryMock) Remove(_ context.Context, acc things.Access, id string) error {
+	dbKey, ok := crm.find(acc, id)
+	if !ok {
+		return nil
+	}
+
+	delete(crm.channels, dbKey)
 	// delete channel from any thing list
 	for thk := range crm.cconns {
-		delete(crm.cconns[thk], key(owner, id))
+		delete(crm.cconns[thk], dbKey)
 	}
 	crm.tconns <- Connection{
 		chanID:    id,
@@ -181,15 +217,15 @@ func (crm *channelRepositoryMock) Remove(_ context.Context, owner, id string) er
 	return nil
 }
 
-func (crm *channelRepositoryMock) Connect(_ context.Context, owner string, chIDs, thIDs []string) error {
+func (crm *channelRepositoryMock) Connect(_ context.Context, acc things.Access, chIDs, thIDs, actions []string) error {
 	for _, chID := range chIDs {
-		ch, err := crm.RetrieveByID(context.Background(), owner, chID)
+		ch, err := crm.RetrieveByID(context.Background(), acc, chID)
 		if err != nil {
 			return err
 		}
 
 		for _, thID := range thIDs {
-			th, err := crm.things.RetrieveByID(context.Background(), owner, thID)
+			th, err := crm.things.RetrieveByID(context.Background(), acc, thID)
 			if err != nil {
 				return err
 			}
@@ -203,13 +239,14 @@ func (crm *channelRepositoryMock) Connect(_ context.Context, owner string, chIDs
 				crm.cconns[thID] = make(map[string]things.Channel)
 			}
 			crm.cconns[thID][chID] = ch
+			crm.actions[key(chID, thID)] = actions
 		}
 	}
 
 	return nil
 }
 
-func (crm *channelRepositoryMock) Disconnect(_ context.Context, owner, chanID, thingID string) error {
+func (crm *channelRepositoryMock) Disconnect(_ context.Context, acc things.Access, chanID, thingID string) error {
 	if _, ok := crm.cconns[thingID]; !ok {
 		return things.ErrNotFound
 	}
@@ -220,32 +257,44 @@ func (crm *channelRepositoryMock) Disconnect(_ context.Context, owner, chanID, t
 
 	crm.tconns <- Connection{
 		chanID:    chanID,
-		thing:     things.Thing{ID: thingID, Owner: owner},
+		thing:     things.Thing{ID: thingID, Owner: acc.Owner},
 		connected: false,
 	}
 	delete(crm.cconns[thingID], chanID)
+	delete(crm.actions, key(chanID, thingID))
 	return nil
 }
 
-func (crm *channelRepositoryMock) HasThing(_ context.Context, chanID, token string) (string, error) {
+// find returns the key of the channel with the given ID, if the channel is
+// accessible.
+func (crm *channelRepositoryMock) find(acc things.Access, id string) (string, bool) {
+	for k, ch := range crm.channels {
+		if ch.ID == id && canAccess(acc, ch.Owner, ch.UserGroup) {
+			return k, true
+		}
+	}
+
+	return "", false
+}
+
+func (crm *channelRepositoryMock) HasThing(_ context.Context, chanID, token, action string) (string, error) {
 	tid, err := crm.things.RetrieveByKey(context.Background(), token)
 	if err != nil {
 		return "", err
 	}
 
-	chans, ok := crm.cconns[tid]
-	if !ok {
-		return "", things.ErrEntityConnected
-	}
-
-	if _, ok := chans[chanID]; !ok {
-		return "", things.ErrEntityConnected
+	if err := crm.hasThing(chanID, tid, action); err != nil {
+		return "", err
 	}
 
 	return tid, nil
 }
 
-func (crm *channelRepositoryMock) HasThingByID(_ context.Context, chanID, thingID string) error {
+func (crm *channelRepositoryMock) HasThingByID(_ context.Context, chanID, thingID, action string) error {
+	return crm.hasThing(chanID, thingID, action)
+}
+
+func (crm *channelRepositoryMock) hasThing(chanID, thingID, action string) error {
 	chans, ok := crm.cconns[thingID]
 	if !ok {
 		return things.ErrEntityConnected
@@ -255,41 +304,48 @@ func (crm *channelRepositoryMock) HasThingByID(_ context.Context, chanID, thingI
 		return things.ErrEntityConnected
 	}
 
+	if !contains(crm.actions[key(chanID, thingID)], action) {
+		return things.ErrEntityConnected
+	}
+
 	return nil
 }
 
 type channelCacheMock struct {
 	mu       sync.Mutex
-	channels map[string]string
+	channels map[string]map[string][]string
 }
 
 // NewChannelCache returns mock cache instance.
 func NewChannelCache() things.ChannelCache {
 	return &channelCacheMock{
-		channels: make(map[string]string),
+		channels: make(map[string]map[string][]string),
 	}
 }
 
-func (ccm *channelCacheMock) Connect(_ context.Context, chanID, thingID string) error {
+func (ccm *channelCacheMock) Connect(_ context.Context, chanID, thingID string, actions []string) error {
 	ccm.mu.Lock()
 	defer ccm.mu.Unlock()
 
-	ccm.channels[chanID] = thingID
+	if _, ok := ccm.channels[chanID]; !ok {
+		ccm.channels[chanID] = make(map[string][]string)
+	}
+	ccm.channels[chanID][thingID] = append(ccm.channels[chanID][thingID], actions...)
 	return nil
 }
 
-func (ccm *channelCacheMock) HasThing(_ context.Context, chanID, thingID string) bool {
+func (ccm *channelCacheMock) HasThing(_ context.Context, chanID, thingID, action string) bool {
 	ccm.mu.Lock()
 	defer ccm.mu.Unlock()
 
-	return ccm.channels[chanID] == thingID
+	return contains(ccm.channels[chanID][thingID], action)
 }
 
 func (ccm *channelCacheMock) Disconnect(_ context.Context, chanID, thingID string) error {
 	ccm.mu.Lock()
 	defer ccm.mu.Unlock()
 
-	delete(ccm.channels, chanID)
+	delete(ccm.channels[chanID], thingID)
 	return nil
 }
 
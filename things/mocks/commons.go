@@ -3,7 +3,11 @@
 
 package mocks
 
-import "fmt"
+import (
+	"fmt"
+
+	"github.com/mainflux/mainflux/things"
+)
 
 // Since mocks will store data in map, and they need to resemble the real
 // identifiers as much as possible, a key will be created as combination of
@@ -12,3 +16,19 @@ import "fmt"
 func key(owner string, id string) string {
 	return fmt.Sprintf("%s-%s", owner, id)
 }
+
+func contains(actions []string, action string) bool {
+	for _, a := range actions {
+		if a == action {
+			return true
+		}
+	}
+
+	return false
+}
+
+// canAccess checks whether the entity with the given owner, shared with the
+// given users group, is accessible.
+func canAccess(acc things.Access, owner, group string) bool {
+	return acc.All || owner == acc.Owner || acc.Shares(group)
+}
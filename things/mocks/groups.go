@@ -0,0 +1,398 @@
+// Copyright (c) Mainflux
+// SPDX-License-Identifier: Apache-2.0
+
+package mocks
+
+import (
+	"context"
+	"sort"
+	"sync"
+
+	"github.com/mainflux/mainflux/things"
+)
+
+var _ things.GroupRepository = (*groupRepositoryMock)(nil)
+
+type groupRepositoryMock struct {
+	mu       sync.Mutex
+	groups   map[string]things.Group
+	tmembers map[string]map[string]bool     // used to track assigned things
+	cmembers map[string]map[string]bool     // used to track assigned channels
+	gconns   map[string]map[string][]string // used to track group connections
+	things   things.ThingRepository
+	channels things.ChannelRepository
+}
+
+// NewGroupRepository creates in-memory group repository.
+func NewGroupRepository(thingsRepo things.ThingRepository, channelsRepo things.ChannelRepository) things.GroupRepository {
+	return &groupRepositoryMock{
+		groups:   make(map[string]things.Group),
+		tmembers: make(map[string]map[string]bool),
+		cmembers: make(map[string]map[string]bool),
+		gconns:   make(map[string]map[string][]string),
+		things:   thingsRepo,
+		channels: channelsRepo,
+	}
+}
+
+func (grm *groupRepositoryMock) Save(_ context.Context, g things.Group) (things.Group, error) {
+	grm.mu.Lock()
+	defer grm.mu.Unlock()
+
+	if _, ok := grm.groups[key(g.Owner, g.ID)]; ok {
+		return things.Group{}, things.ErrConflict
+	}
+
+	if g.ParentID != "" {
+		if _, ok := grm.groups[key(g.Owner, g.ParentID)]; !ok {
+			return things.Group{}, things.ErrNotFound
+		}
+	}
+
+	grm.groups[key(g.Owner, g.ID)] = g
+	return g, nil
+}
+
+func (grm *groupRepositoryMock) Update(_ context.Context, g things.Group) error {
+	grm.mu.Lock()
+	defer grm.mu.Unlock()
+
+	dbKey := key(g.Owner, g.ID)
+
+	old, ok := grm.groups[dbKey]
+	if !ok {
+		return things.ErrNotFound
+	}
+
+	g.ParentID = old.ParentID
+	grm.groups[dbKey] = g
+	return nil
+}
+
+func (grm *groupRepositoryMock) RetrieveByID(_ context.Context, owner, id string) (things.Group, error) {
+	grm.mu.Lock()
+	defer grm.mu.Unlock()
+
+	if g, ok := grm.groups[key(owner, id)]; ok {
+		return g, nil
+	}
+
+	return things.Group{}, things.ErrNotFound
+}
+
+func (grm *groupRepositoryMock) RetrieveAll(_ context.Context, owner, parentID string, offset, limit uint64, _ things.Metadata) (things.GroupsPage, error) {
+	grm.mu.Lock()
+	defer grm.mu.Unlock()
+
+	groups := []things.Group{}
+	switch parentID {
+	case "":
+		for _, g := range grm.groups {
+			if g.Owner == owner {
+				groups = append(groups, g)
+			}
+		}
+	default:
+		for _, id := range grm.subtree(owner, parentID) {
+			if id != parentID {
+				groups = append(groups, grm.groups[key(owner, id)])
+			}
+		}
+	}
+
+	sort.SliceStable(groups, func(i, j int) bool {
+		return groups[i].ID < groups[j].ID
+	})
+
+	total := uint64(len(groups))
+	groups = groups[min(offset, total):min(offset+limit, total)]
+
+	page := things.GroupsPage{
+		Groups: groups,
+		PageMetadata: things.PageMetadata{
+			Total:  total,
+			Offset: offset,
+			Limit:  limit,
+		},
+	}
+
+	return page, nil
+}
+
+func (grm *groupRepositoryMock) RetrieveThings(ctx context.Context, owner, groupID string, offset, limit uint64) (things.Page, error) {
+	grm.mu.Lock()
+	defer grm.mu.Unlock()
+
+	ths := []things.Thing{}
+	for _, id := range sortedIDs(grm.tmembers[key(owner, groupID)]) {
+		th, err := grm.things.RetrieveByID(ctx, things.Access{Owner: owner}, id)
+		if err != nil {
+			return things.Page{}, err
+		}
+		ths = append(ths, th)
+	}
+
+	total := uint64(len(ths))
+	page := things.Page{
+		Things: ths[min(offset, total):min(offset+limit, total)],
+		PageMetadata: things.PageMetadata{
+			Total:  total,
+			Offset: offset,
+			Limit:  limit,
+		},
+	}
+
+	return page, nil
+}
+
+func (grm *groupRepositoryMock) RetrieveChannels(ctx context.Context, owner, groupID string, offset, limit uint64) (things.ChannelsPage, error) {
+	grm.mu.Lock()
+	defer grm.mu.Unlock()
+
+	chs := []things.Channel{}
+	for _, id := range sortedIDs(grm.cmembers[key(owner, groupID)]) {
+		ch, err := grm.channels.RetrieveByID(ctx, things.Access{Owner: owner}, id)
+		if err != nil {
+			return things.ChannelsPage{}, err
+		}
+		chs = append(chs, ch)
+	}
+
+	total := uint64(len(chs))
+	page := things.ChannelsPage{
+		Channels: chs[min(offset, total):min(offset+limit, total)],
+		PageMetadata: things.PageMetadata{
+			Total:  total,
+			Offset: offset,
+			Limit:  limit,
+		},
+	}
+
+	return page, nil
+}
+
+func (grm *groupRepositoryMock) RetrieveConnections(_ context.Context, owner, groupID string) ([]string, error) {
+	grm.mu.Lock()
+	defer grm.mu.Unlock()
+
+	chIDs := []string{}
+	for _, id := range grm.subtree(owner, groupID) {
+		for chID := range grm.gconns[key(owner, id)] {
+			chIDs = append(chIDs, chID)
+		}
+	}
+
+	return chIDs, nil
+}
+
+func (grm *groupRepositoryMock) Remove(ctx context.Context, owner, id string) error {
+	grm.mu.Lock()
+	defer grm.mu.Unlock()
+
+	for _, gID := range grm.subtree(owner, id) {
+		gKey := key(owner, gID)
+		for chID := range grm.gconns[gKey] {
+			for thID := range grm.tmembers[gKey] {
+				grm.channels.Disconnect(ctx, things.Access{Owner: owner}, chID, thID)
+			}
+		}
+
+		delete(grm.groups, gKey)
+		delete(grm.tmembers, gKey)
+		delete(grm.cmembers, gKey)
+		delete(grm.gconns, gKey)
+	}
+
+	return nil
+}
+
+func (grm *groupRepositoryMock) AssignThings(ctx context.Context, owner, groupID string, thIDs ...string) error {
+	grm.mu.Lock()
+	defer grm.mu.Unlock()
+
+	gKey := key(owner, groupID)
+	if _, ok := grm.groups[gKey]; !ok {
+		return things.ErrNotFound
+	}
+
+	for _, thID := range thIDs {
+		if _, err := grm.things.RetrieveByID(ctx, things.Access{Owner: owner}, thID); err != nil {
+			return err
+		}
+		if grm.tmembers[gKey][thID] {
+			return things.ErrConflict
+		}
+	}
+
+	if _, ok := grm.tmembers[gKey]; !ok {
+		grm.tmembers[gKey] = make(map[string]bool)
+	}
+	for _, thID := range thIDs {
+		grm.tmembers[gKey][thID] = true
+	}
+
+	for chID, actions := range grm.gconns[gKey] {
+		if err := grm.channels.Connect(ctx, things.Access{Owner: owner}, []string{chID}, thIDs, actions); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+func (grm *groupRepositoryMock) UnassignThings(ctx context.Context, owner, groupID string, thIDs ...string) error {
+	grm.mu.Lock()
+	defer grm.mu.Unlock()
+
+	gKey := key(owner, groupID)
+	for _, thID := range thIDs {
+		if !grm.tmembers[gKey][thID] {
+			return things.ErrNotFound
+		}
+	}
+
+	for _, thID := range thIDs {
+		for chID := range grm.gconns[gKey] {
+			grm.channels.Disconnect(ctx, things.Access{Owner: owner}, chID, thID)
+		}
+		delete(grm.tmembers[gKey], thID)
+	}
+
+	return nil
+}
+
+func (grm *groupRepositoryMock) AssignChannels(ctx context.Context, owner, groupID string, chIDs ...string) error {
+	grm.mu.Lock()
+	defer grm.mu.Unlock()
+
+	gKey := key(owner, groupID)
+	if _, ok := grm.groups[gKey]; !ok {
+		return things.ErrNotFound
+	}
+
+	for _, chID := range chIDs {
+		if _, err := grm.channels.RetrieveByID(ctx, things.Access{Owner: owner}, chID); err != nil {
+			return err
+		}
+		if grm.cmembers[gKey][chID] {
+			return things.ErrConflict
+		}
+	}
+
+	if _, ok := grm.cmembers[gKey]; !ok {
+		grm.cmembers[gKey] = make(map[string]bool)
+	}
+	for _, chID := range chIDs {
+		grm.cmembers[gKey][chID] = true
+	}
+
+	return nil
+}
+
+func (grm *groupRepositoryMock) UnassignChannels(_ context.Context, owner, groupID string, chIDs ...string) error {
+	grm.mu.Lock()
+	defer grm.mu.Unlock()
+
+	gKey := key(owner, groupID)
+	for _, chID := range chIDs {
+		if !grm.cmembers[gKey][chID] {
+			return things.ErrNotFound
+		}
+	}
+
+	for _, chID := range chIDs {
+		delete(grm.cmembers[gKey], chID)
+	}
+
+	return nil
+}
+
+func (grm *groupRepositoryMock) Connect(ctx context.Context, owner, groupID string, chIDs, actions []string) error {
+	grm.mu.Lock()
+	defer grm.mu.Unlock()
+
+	gKey := key(owner, groupID)
+	if _, ok := grm.groups[gKey]; !ok {
+		return things.ErrNotFound
+	}
+
+	for _, chID := range chIDs {
+		if _, err := grm.channels.RetrieveByID(ctx, things.Access{Owner: owner}, chID); err != nil {
+			return err
+		}
+		if _, ok := grm.gconns[gKey][chID]; ok {
+			return things.ErrConflict
+		}
+	}
+
+	if _, ok := grm.gconns[gKey]; !ok {
+		grm.gconns[gKey] = make(map[string][]string)
+	}
+
+	thIDs := sortedIDs(grm.tmembers[gKey])
+	for _, chID := range chIDs {
+		grm.gconns[gKey][chID] = actions
+		if len(thIDs) == 0 {
+			continue
+		}
+		if err := grm.channels.Connect(ctx, things.Access{Owner: owner}, []string{chID}, thIDs, actions); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+func (grm *groupRepositoryMock) Disconnect(ctx context.Context, owner, groupID, chanID string) error {
+	grm.mu.Lock()
+	defer grm.mu.Unlock()
+
+	gKey := key(owner, groupID)
+	if _, ok := grm.gconns[gKey][chanID]; !ok {
+		return things.ErrNotFound
+	}
+
+	for thID := range grm.tmembers[gKey] {
+		grm.channels.Disconnect(ctx, things.Access{Owner: owner}, chanID, thID)
+	}
+	delete(grm.gconns[gKey], chanID)
+
+	return nil
+}
+
+// subtree returns IDs of the group and all of its descendants, starting
+// with the group itself.
+func (grm *groupRepositoryMock) subtree(owner, id string) []string {
+	if _, ok := grm.groups[key(owner, id)]; !ok {
+		return []string{}
+	}
+
+	ids := []string{id}
+	for i := 0; i < len(ids); i++ {
+		for _, g := range grm.groups {
+			if g.Owner == owner && g.ParentID == ids[i] {
+				ids = append(ids, g.ID)
+			}
+		}
+	}
+
+	return ids
+}
+
+func sortedIDs(set map[string]bool) []string {
+	ids := []string{}
+	for id := range set {
+		ids = append(ids, id)
+	}
+	sort.Strings(ids)
+
+	return ids
+}
+
+func min(a, b uint64) uint64 {
+	if a < b {
+		return a
+	}
+
+	return b
+}
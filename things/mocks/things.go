@@ -5,12 +5,12 @@ package mocks
 
 import (
 	"context"
-	"fmt"
 	"sort"
 	"strconv"
-	"strings"
 	"sync"
 
+	mfcursor "github.com/mainflux/mainflux/pkg/cursor"
+	"github.com/mainflux/mainflux/pkg/errors"
 	"github.com/mainflux/mainflux/things"
 )
 
@@ -63,22 +63,39 @@ func (trm *thingRepositoryMock) Save(_ context.Context, ths ...things.Thing) ([]
 	return ths, nil
 }
 
-func (trm *thingRepositoryMock) Update(_ context.Context, thing things.Thing) error {
+func (trm *thingRepositoryMock) Update(_ context.Context, acc things.Access, thing things.Thing) error {
 	trm.mu.Lock()
 	defer trm.mu.Unlock()
 
-	dbKey := key(thing.Owner, thing.ID)
-
-	if _, ok := trm.things[dbKey]; !ok {
+	dbKey, ok := trm.find(acc, thing.ID)
+	if !ok {
 		return things.ErrNotFound
 	}
 
+	thing.Owner = trm.things[dbKey].Owner
+	thing.UserGroup = trm.things[dbKey].UserGroup
 	trm.things[dbKey] = thing
 
 	return nil
 }
 
-func (trm *thingRepositoryMock) UpdateKey(_ context.Context, owner, id, val string) error {
+func (trm *thingRepositoryMock) Share(_ context.Context, owner, id, group string) error {
+	trm.mu.Lock()
+	defer trm.mu.Unlock()
+
+	dbKey, ok := trm.find(things.Access{Owner: owner}, id)
+	if !ok {
+		return things.ErrNotFound
+	}
+
+	th := trm.things[dbKey]
+	th.UserGroup = group
+	trm.things[dbKey] = th
+
+	return nil
+}
+
+func (trm *thingRepositoryMock) UpdateKey(_ context.Context, acc things.Access, id, val string) error {
 	trm.mu.Lock()
 	defer trm.mu.Unlock()
 
@@ -88,31 +105,30 @@ func (trm *thingRepositoryMock) UpdateKey(_ context.Context, owner, id, val stri
 		}
 	}
 
-	dbKey := key(owner, id)
-
-	th, ok := trm.things[dbKey]
+	dbKey, ok := trm.find(acc, id)
 	if !ok {
 		return things.ErrNotFound
 	}
 
+	th := trm.things[dbKey]
 	th.Key = val
 	trm.things[dbKey] = th
 
 	return nil
 }
 
-func (trm *thingRepositoryMock) RetrieveByID(_ context.Context, owner, id string) (things.Thing, error) {
+func (trm *thingRepositoryMock) RetrieveByID(_ context.Context, acc things.Access, id string) (things.Thing, error) {
 	trm.mu.Lock()
 	defer trm.mu.Unlock()
 
-	if c, ok := trm.things[key(owner, id)]; ok {
-		return c, nil
+	if dbKey, ok := trm.find(acc, id); ok {
+		return trm.things[dbKey], nil
 	}
 
 	return things.Thing{}, things.ErrNotFound
 }
 
-func (trm *thingRepositoryMock) RetrieveAll(_ context.Context, owner string, offset, limit uint64, name string, metadata things.Metadata) (things.Page, error) {
+func (trm *thingRepositoryMock) RetrieveAll(_ context.Context, acc things.Access, offset, limit uint64, cursor, name string, metadata things.Metadata) (things.Page, error) {
 	trm.mu.Lock()
 	defer trm.mu.Unlock()
 
@@ -123,14 +139,22 @@ func (trm *thingRepositoryMock) RetrieveAll(_ context.Context, owner string, off
 	}
 
 	first := uint64(offset) + 1
+	if cursor != "" {
+		keys, err := mfcursor.Decode(cursor, 1)
+		if err != nil {
+			return things.Page{}, errors.Wrap(things.ErrMalformedEntity, err)
+		}
+		after, err := strconv.ParseUint(keys[0], 10, 64)
+		if err != nil {
+			return things.Page{}, errors.Wrap(things.ErrMalformedEntity, err)
+		}
+		first = after + 1
+	}
 	last := first + uint64(limit)
 
-	// This obscure way to examine map keys is enforced by the key structure
-	// itself (see mocks/commons.go).
-	prefix := fmt.Sprintf("%s-", owner)
-	for k, v := range trm.things {
+	for _, v := range trm.things {
 		id, _ := strconv.ParseUint(v.ID, 10, 64)
-		if strings.HasPrefix(k, prefix) && id >= first && id < last {
+		if canAccess(acc, v.Owner, v.UserGroup) && id >= first && id < last {
 			items = append(items, v)
 		}
 	}
@@ -148,10 +172,14 @@ func (trm *thingRepositoryMock) RetrieveAll(_ context.Context, owner string, off
 		},
 	}
 
+	if l := len(items); l > 0 && uint64(l) == limit {
+		page.NextCursor = mfcursor.Encode(strconv.FormatUint(last-1, 10))
+	}
+
 	return page, nil
 }
 
-func (trm *thingRepositoryMock) RetrieveByChannel(_ context.Context, owner, chanID string, offset, limit uint64, connected bool) (things.Page, error) {
+func (trm *thingRepositoryMock) RetrieveByChannel(_ context.Context, _ things.Access, chanID string, offset, limit uint64, connected bool) (things.Page, error) {
 	trm.mu.Lock()
 	defer trm.mu.Unlock()
 
@@ -208,10 +236,12 @@ func (trm *thingRepositoryMock) RetrieveByChannel(_ context.Context, owner, chan
 	return page, nil
 }
 
-func (trm *thingRepositoryMock) Remove(_ context.Context, owner, id string) error {
+func (trm *thingRepositoryMock) Remove(_ context.Context, acc things.Access, id string) error {
 	trm.mu.Lock()
 	defer trm.mu.Unlock()
-	delete(trm.things, key(owner, id))
+	if dbKey, ok := trm.find(acc, id); ok {
+		delete(trm.things, dbKey)
+	}
 	return nil
 }
 
@@ -228,6 +258,18 @@ func (trm *thingRepositoryMock) RetrieveByKey(_ context.Context, key string) (st
 	return "", things.ErrNotFound
 }
 
+// find returns the key of the thing with the given ID, if the thing is
+// accessible.
+func (trm *thingRepositoryMock) find(acc things.Access, id string) (string, bool) {
+	for k, th := range trm.things {
+		if th.ID == id && canAccess(acc, th.Owner, th.UserGroup) {
+			return k, true
+		}
+	}
+
+	return "", false
+}
+
 func (trm *thingRepositoryMock) connect(conn Connection) {
 	trm.mu.Lock()
 	defer trm.mu.Unlock()
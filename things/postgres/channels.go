@@ -5,7 +5,6 @@ package postgres
 
 import (
 	"context"
-	"database/sql"
 	"database/sql/driver"
 	"encoding/json"
 	"fmt"
@@ -13,6 +12,7 @@ import (
 
 	"github.com/gofrs/uuid"
 	"github.com/lib/pq"
+	mfcursor "github.com/mainflux/mainflux/pkg/cursor"
 	"github.com/mainflux/mainflux/pkg/errors"
 	"github.com/mainflux/mainflux/things"
 )
@@ -23,12 +23,6 @@ type channelRepository struct {
 	db Database
 }
 
-type dbConnection struct {
-	Channel string `db:"channel"`
-	Thing   string `db:"thing"`
-	Owner   string `db:"owner"`
-}
-
 // NewChannelRepository instantiates a PostgreSQL implementation of channel
 // repository.
 func NewChannelRepository(db Database) things.ChannelRepository {
@@ -43,8 +37,8 @@ func (cr channelRepository) Save(ctx context.Context, channels ...things.Channel
 		return nil, errors.Wrap(things.ErrCreateEntity, err)
 	}
 
-	q := `INSERT INTO channels (id, owner, name, metadata)
-		  VALUES (:id, :owner, :name, :metadata);`
+	q := `INSERT INTO channels (id, owner, user_group, name, metadata)
+		  VALUES (:id, :owner, :user_group, :name, :metadata);`
 
 	for _, channel := range channels {
 		dbch := toDBChannel(channel)
@@ -72,12 +66,18 @@ func (cr channelRepository) Save(ctx context.Context, channels ...things.Channel
 	return channels, nil
 }
 
-func (cr channelRepository) Update(ctx context.Context, channel things.Channel) error {
-	q := `UPDATE channels SET name = :name, metadata = :metadata WHERE owner = :owner AND id = :id;`
+func (cr channelRepository) Update(ctx context.Context, acc things.Access, channel things.Channel) error {
+	q := fmt.Sprintf(`UPDATE channels SET name = :name, metadata = :metadata
+	      WHERE id = :id AND %s;`, getAccessQuery(""))
 
 	dbch := toDBChannel(channel)
+	params := accessParams(acc, map[string]interface{}{
+		"id":       dbch.ID,
+		"name":     dbch.Name,
+		"metadata": dbch.Metadata,
+	})
 
-	res, err := cr.db.NamedExecContext(ctx, q, dbch)
+	res, err := cr.db.NamedExecContext(ctx, q, params)
 	if err != nil {
 		pqErr, ok := err.(*pq.Error)
 		if ok {
@@ -102,41 +102,100 @@ func (cr channelRepository) Update(ctx context.Context, channel things.Channel)
 	return nil
 }
 
-func (cr channelRepository) RetrieveByID(ctx context.Context, owner, id string) (things.Channel, error) {
-	q := `SELECT name, metadata FROM channels WHERE id = $1 AND owner = $2;`
+func (cr channelRepository) Share(ctx context.Context, owner, id, group string) error {
+	q := `UPDATE channels SET user_group = :user_group WHERE id = :id AND owner = :owner;`
 
-	dbch := dbChannel{
-		ID:    id,
-		Owner: owner,
+	params := map[string]interface{}{
+		"id":         id,
+		"owner":      owner,
+		"user_group": group,
 	}
-	if err := cr.db.QueryRowxContext(ctx, q, id, owner).StructScan(&dbch); err != nil {
+
+	res, err := cr.db.NamedExecContext(ctx, q, params)
+	if err != nil {
 		pqErr, ok := err.(*pq.Error)
-		if err == sql.ErrNoRows || ok && errInvalid == pqErr.Code.Name() {
-			return things.Channel{}, things.ErrNotFound
+		if ok {
+			switch pqErr.Code.Name() {
+			case errInvalid, errTruncation:
+				return errors.Wrap(things.ErrMalformedEntity, err)
+			}
 		}
+
+		return errors.Wrap(things.ErrUpdateEntity, err)
+	}
+
+	cnt, err := res.RowsAffected()
+	if err != nil {
+		return errors.Wrap(things.ErrUpdateEntity, err)
+	}
+
+	if cnt == 0 {
+		return things.ErrNotFound
+	}
+
+	return nil
+}
+
+func (cr channelRepository) RetrieveByID(ctx context.Context, acc things.Access, id string) (things.Channel, error) {
+	// Verify if UUID format is valid to avoid internal Postgres error
+	if _, err := uuid.FromString(id); err != nil {
+		return things.Channel{}, things.ErrNotFound
+	}
+
+	q := fmt.Sprintf(`SELECT id, owner, user_group, name, metadata FROM channels
+	      WHERE id = :id AND %s;`, getAccessQuery(""))
+
+	params := accessParams(acc, map[string]interface{}{
+		"id": id,
+	})
+
+	rows, err := cr.db.NamedQueryContext(ctx, q, params)
+	if err != nil {
+		return things.Channel{}, errors.Wrap(things.ErrSelectEntity, err)
+	}
+	defer rows.Close()
+
+	if !rows.Next() {
+		if err := rows.Err(); err != nil {
+			return things.Channel{}, errors.Wrap(things.ErrSelectEntity, err)
+		}
+		return things.Channel{}, things.ErrNotFound
+	}
+
+	dbch := dbChannel{}
+	if err := rows.StructScan(&dbch); err != nil {
 		return things.Channel{}, errors.Wrap(things.ErrSelectEntity, err)
 	}
 
 	return toChannel(dbch), nil
 }
 
-func (cr channelRepository) RetrieveAll(ctx context.Context, owner string, offset, limit uint64, name string, metadata things.Metadata) (things.ChannelsPage, error) {
+func (cr channelRepository) RetrieveAll(ctx context.Context, acc things.Access, offset, limit uint64, cursor, name string, metadata things.Metadata) (things.ChannelsPage, error) {
 	nq, name := getNameQuery(name)
 	m, mq, err := getMetadataQuery(metadata)
 	if err != nil {
 		return things.ChannelsPage{}, errors.Wrap(things.ErrSelectEntity, err)
 	}
 
-	q := fmt.Sprintf(`SELECT id, name, metadata FROM channels
-	      WHERE owner = :owner %s%s ORDER BY id LIMIT :limit OFFSET :offset;`, mq, nq)
+	cq, after, err := getCursorQuery(cursor)
+	if err != nil {
+		return things.ChannelsPage{}, errors.Wrap(things.ErrMalformedEntity, err)
+	}
+	if cq != "" {
+		offset = 0
+	}
 
-	params := map[string]interface{}{
-		"owner":    owner,
+	aq := getAccessQuery("")
+	q := fmt.Sprintf(`SELECT id, owner, user_group, name, metadata FROM channels
+	      WHERE %s%s%s%s ORDER BY id LIMIT :limit OFFSET :offset;`, aq, mq, nq, cq)
+
+	params := accessParams(acc, map[string]interface{}{
 		"limit":    limit,
 		"offset":   offset,
 		"name":     name,
 		"metadata": m,
-	}
+		"cursor":   after,
+	})
 	rows, err := cr.db.NamedQueryContext(ctx, q, params)
 	if err != nil {
 		return things.ChannelsPage{}, errors.Wrap(things.ErrSelectEntity, err)
@@ -145,7 +204,7 @@ func (cr channelRepository) RetrieveAll(ctx context.Context, owner string, offse
 
 	items := []things.Channel{}
 	for rows.Next() {
-		dbch := dbChannel{Owner: owner}
+		dbch := dbChannel{}
 		if err := rows.StructScan(&dbch); err != nil {
 			return things.ChannelsPage{}, errors.Wrap(things.ErrSelectEntity, err)
 		}
@@ -154,9 +213,9 @@ func (cr channelRepository) RetrieveAll(ctx context.Context, owner string, offse
 		items = append(items, ch)
 	}
 
-	cq := fmt.Sprintf(`SELECT COUNT(*) FROM channels WHERE owner = :owner %s%s;`, nq, mq)
+	tq := fmt.Sprintf(`SELECT COUNT(*) FROM channels WHERE %s%s%s;`, aq, nq, mq)
 
-	total, err := total(ctx, cr.db, cq, params)
+	total, err := total(ctx, cr.db, tq, params)
 	if err != nil {
 		return things.ChannelsPage{}, errors.Wrap(things.ErrSelectEntity, err)
 	}
@@ -170,58 +229,56 @@ func (cr channelRepository) RetrieveAll(ctx context.Context, owner string, offse
 		},
 	}
 
+	if l := len(items); l > 0 && uint64(l) == limit {
+		page.NextCursor = mfcursor.Encode(items[l-1].ID)
+	}
+
 	return page, nil
 }
 
-func (cr channelRepository) RetrieveByThing(ctx context.Context, owner, thing string, offset, limit uint64, connected bool) (things.ChannelsPage, error) {
+func (cr channelRepository) RetrieveByThing(ctx context.Context, acc things.Access, thing string, offset, limit uint64, connected bool) (things.ChannelsPage, error) {
 	// Verify if UUID format is valid to avoid internal Postgres error
 	if _, err := uuid.FromString(thing); err != nil {
 		return things.ChannelsPage{}, things.ErrNotFound
 	}
 
+	aq := getAccessQuery("ch.")
 	var q, qc string
 	switch connected {
 	case true:
-		q = `SELECT id, name, metadata FROM channels ch
+		q = fmt.Sprintf(`SELECT id, owner, user_group, name, metadata FROM channels ch
 		        INNER JOIN connections conn
 		        ON ch.id = conn.channel_id
-		        WHERE ch.owner = :owner AND conn.thing_id = :thing
+		        WHERE %s AND conn.thing_id = :thing
 		        ORDER BY ch.id
 		        LIMIT :limit
-		        OFFSET :offset;`
+		        OFFSET :offset;`, aq)
 
-		qc = `SELECT COUNT(*)
+		qc = fmt.Sprintf(`SELECT COUNT(*)
 		        FROM channels ch
 		        INNER JOIN connections conn
 		        ON ch.id = conn.channel_id
-		        WHERE ch.owner = $1 AND conn.thing_id = $2`
+		        WHERE %s AND conn.thing_id = :thing;`, aq)
 	default:
-		q = `SELECT id, name, metadata
+		q = fmt.Sprintf(`SELECT id, owner, user_group, name, metadata
 		        FROM channels ch
-		        WHERE ch.owner = :owner AND ch.id NOT IN
-		        (SELECT id FROM channels ch
-		          INNER JOIN connections conn
-		          ON ch.id = conn.channel_id
-		          WHERE ch.owner = :owner AND conn.thing_id = :thing)
+		        WHERE %s AND ch.id NOT IN
+		        (SELECT channel_id FROM connections WHERE thing_id = :thing)
 		        ORDER BY ch.id
 		        LIMIT :limit
-		        OFFSET :offset;`
+		        OFFSET :offset;`, aq)
 
-		qc = `SELECT COUNT(*)
+		qc = fmt.Sprintf(`SELECT COUNT(*)
 		        FROM channels ch
-		        WHERE ch.owner = $1 AND ch.id NOT IN
-		        (SELECT id FROM channels ch
-		          INNER JOIN connections conn
-		          ON ch.id = conn.channel_id
-		          WHERE ch.owner = $1 AND conn.thing_id = $2);`
+		        WHERE %s AND ch.id NOT IN
+		        (SELECT channel_id FROM connections WHERE thing_id = :thing);`, aq)
 	}
 
-	params := map[string]interface{}{
-		"owner":  owner,
+	params := accessParams(acc, map[string]interface{}{
 		"thing":  thing,
 		"limit":  limit,
 		"offset": offset,
-	}
+	})
 
 	rows, err := cr.db.NamedQueryContext(ctx, q, params)
 	if err != nil {
@@ -231,7 +288,7 @@ func (cr channelRepository) RetrieveByThing(ctx context.Context, owner, thing st
 
 	items := []things.Channel{}
 	for rows.Next() {
-		dbch := dbChannel{Owner: owner}
+		dbch := dbChannel{}
 		if err := rows.StructScan(&dbch); err != nil {
 			return things.ChannelsPage{}, errors.Wrap(things.ErrSelectEntity, err)
 		}
@@ -240,8 +297,8 @@ func (cr channelRepository) RetrieveByThing(ctx context.Context, owner, thing st
 		items = append(items, ch)
 	}
 
-	var total uint64
-	if err := cr.db.GetContext(ctx, &total, qc, owner, thing); err != nil {
+	total, err := total(ctx, cr.db, qc, params)
+	if err != nil {
 		return things.ChannelsPage{}, errors.Wrap(things.ErrSelectEntity, err)
 	}
 
@@ -255,48 +312,64 @@ func (cr channelRepository) RetrieveByThing(ctx context.Context, owner, thing st
 	}, nil
 }
 
-func (cr channelRepository) Remove(ctx context.Context, owner, id string) error {
-	dbch := dbChannel{
-		ID:    id,
-		Owner: owner,
-	}
-	q := `DELETE FROM channels WHERE id = :id AND owner = :owner`
-	cr.db.NamedExecContext(ctx, q, dbch)
+func (cr channelRepository) Remove(ctx context.Context, acc things.Access, id string) error {
+	q := fmt.Sprintf(`DELETE FROM channels WHERE id = :id AND %s`, getAccessQuery(""))
+
+	params := accessParams(acc, map[string]interface{}{
+		"id": id,
+	})
+	cr.db.NamedExecContext(ctx, q, params)
 	return nil
 }
 
-func (cr channelRepository) Connect(ctx context.Context, owner string, chIDs, thIDs []string) error {
+func (cr channelRepository) Connect(ctx context.Context, acc things.Access, chIDs, thIDs, actions []string) error {
 	tx, err := cr.db.BeginTxx(ctx, nil)
 	if err != nil {
 		return errors.Wrap(things.ErrConnect, err)
 	}
 
-	q := `INSERT INTO connections (channel_id, channel_owner, thing_id, thing_owner)
-	      VALUES (:channel, :owner, :thing, :owner);`
+	// Connection keeps the owners of both the channel and the thing, which
+	// differ when either of them is shared with the user through a group.
+	// Connecting already connected pair replaces the actions of the thing.
+	q := fmt.Sprintf(`INSERT INTO connections (channel_id, channel_owner, thing_id, thing_owner, actions)
+	      SELECT ch.id, ch.owner, th.id, th.owner, CAST(:actions AS VARCHAR(16)[])
+	      FROM channels ch, things th
+	      WHERE ch.id = :channel AND %s AND th.id = :thing AND %s
+	      ON CONFLICT (channel_id, channel_owner, thing_id, thing_owner)
+	      DO UPDATE SET actions = EXCLUDED.actions;`, getAccessQuery("ch."), getAccessQuery("th."))
 
 	for _, chID := range chIDs {
 		for _, thID := range thIDs {
-			dbco := dbConnection{
-				Channel: chID,
-				Thing:   thID,
-				Owner:   owner,
-			}
+			params := accessParams(acc, map[string]interface{}{
+				"channel": chID,
+				"thing":   thID,
+				"actions": pq.StringArray(actions),
+			})
 
-			_, err := tx.NamedExecContext(ctx, q, dbco)
+			res, err := tx.NamedExecContext(ctx, q, params)
 			if err != nil {
 				tx.Rollback()
 				pqErr, ok := err.(*pq.Error)
 				if ok {
 					switch pqErr.Code.Name() {
-					case errFK:
+					case errFK, errInvalid:
 						return things.ErrNotFound
-					case errDuplicate:
-						return things.ErrConflict
 					}
 				}
 
 				return errors.Wrap(things.ErrConnect, err)
 			}
+
+			cnt, err := res.RowsAffected()
+			if err != nil {
+				tx.Rollback()
+				return errors.Wrap(things.ErrConnect, err)
+			}
+
+			if cnt == 0 {
+				tx.Rollback()
+				return things.ErrNotFound
+			}
 		}
 	}
 
@@ -307,18 +380,19 @@ func (cr channelRepository) Connect(ctx context.Context, owner string, chIDs, th
 	return nil
 }
 
-func (cr channelRepository) Disconnect(ctx context.Context, owner, chanID, thingID string) error {
-	q := `DELETE FROM connections
-	      WHERE channel_id = :channel AND channel_owner = :owner
-	      AND thing_id = :thing AND thing_owner = :owner`
+func (cr channelRepository) Disconnect(ctx context.Context, acc things.Access, chanID, thingID string) error {
+	q := fmt.Sprintf(`DELETE FROM connections conn
+	      USING channels ch, things th
+	      WHERE conn.channel_id = :channel AND conn.thing_id = :thing
+	      AND ch.id = conn.channel_id AND ch.owner = conn.channel_owner AND %s
+	      AND th.id = conn.thing_id AND th.owner = conn.thing_owner AND %s`, getAccessQuery("ch."), getAccessQuery("th."))
 
-	conn := dbConnection{
-		Channel: chanID,
-		Thing:   thingID,
-		Owner:   owner,
-	}
+	params := accessParams(acc, map[string]interface{}{
+		"channel": chanID,
+		"thing":   thingID,
+	})
 
-	res, err := cr.db.NamedExecContext(ctx, q, conn)
+	res, err := cr.db.NamedExecContext(ctx, q, params)
 	if err != nil {
 		return errors.Wrap(things.ErrDisconnect, err)
 	}
@@ -335,28 +409,28 @@ func (cr channelRepository) Disconnect(ctx context.Context, owner, chanID, thing
 	return nil
 }
 
-func (cr channelRepository) HasThing(ctx context.Context, chanID, thingKey string) (string, error) {
+func (cr channelRepository) HasThing(ctx context.Context, chanID, thingKey, action string) (string, error) {
 	var thingID string
 	q := `SELECT id FROM things WHERE key = $1`
 	if err := cr.db.QueryRowxContext(ctx, q, thingKey).Scan(&thingID); err != nil {
 		return "", errors.Wrap(things.ErrEntityConnected, err)
 	}
 
-	if err := cr.hasThing(ctx, chanID, thingID); err != nil {
+	if err := cr.hasThing(ctx, chanID, thingID, action); err != nil {
 		return "", err
 	}
 
 	return thingID, nil
 }
 
-func (cr channelRepository) HasThingByID(ctx context.Context, chanID, thingID string) error {
-	return cr.hasThing(ctx, chanID, thingID)
+func (cr channelRepository) HasThingByID(ctx context.Context, chanID, thingID, action string) error {
+	return cr.hasThing(ctx, chanID, thingID, action)
 }
 
-func (cr channelRepository) hasThing(ctx context.Context, chanID, thingID string) error {
-	q := `SELECT EXISTS (SELECT 1 FROM connections WHERE channel_id = $1 AND thing_id = $2);`
+func (cr channelRepository) hasThing(ctx context.Context, chanID, thingID, action string) error {
+	q := `SELECT EXISTS (SELECT 1 FROM connections WHERE channel_id = $1 AND thing_id = $2 AND $3 = ANY(actions));`
 	exists := false
-	if err := cr.db.QueryRowxContext(ctx, q, chanID, thingID).Scan(&exists); err != nil {
+	if err := cr.db.QueryRowxContext(ctx, q, chanID, thingID, action).Scan(&exists); err != nil {
 		return errors.Wrap(things.ErrEntityConnected, err)
 	}
 
@@ -404,28 +478,52 @@ func (m dbMetadata) Value() (driver.Value, error) {
 }
 
 type dbChannel struct {
-	ID       string     `db:"id"`
-	Owner    string     `db:"owner"`
-	Name     string     `db:"name"`
-	Metadata dbMetadata `db:"metadata"`
+	ID        string     `db:"id"`
+	Owner     string     `db:"owner"`
+	UserGroup string     `db:"user_group"`
+	Name      string     `db:"name"`
+	Metadata  dbMetadata `db:"metadata"`
 }
 
 func toDBChannel(ch things.Channel) dbChannel {
 	return dbChannel{
-		ID:       ch.ID,
-		Owner:    ch.Owner,
-		Name:     ch.Name,
-		Metadata: ch.Metadata,
+		ID:        ch.ID,
+		Owner:     ch.Owner,
+		UserGroup: ch.UserGroup,
+		Name:      ch.Name,
+		Metadata:  ch.Metadata,
 	}
 }
 
 func toChannel(ch dbChannel) things.Channel {
 	return things.Channel{
-		ID:       ch.ID,
-		Owner:    ch.Owner,
-		Name:     ch.Name,
-		Metadata: ch.Metadata,
+		ID:        ch.ID,
+		Owner:     ch.Owner,
+		UserGroup: ch.UserGroup,
+		Name:      ch.Name,
+		Metadata:  ch.Metadata,
+	}
+}
+
+// getAccessQuery returns the condition that selects the entities owned by
+// the user or shared with one of the user's groups, or any entity if the
+// access is granted to all of them. The prefix is the alias of the table the
+// entities are selected from.
+func getAccessQuery(prefix string) string {
+	return fmt.Sprintf(`(:all OR %[1]sowner = :owner OR %[1]suser_group = ANY(:user_groups))`, prefix)
+}
+
+// accessParams adds the access query parameters to the query parameters.
+func accessParams(acc things.Access, params map[string]interface{}) map[string]interface{} {
+	groups := acc.UserGroups
+	if groups == nil {
+		groups = []string{}
 	}
+	params["all"] = acc.All
+	params["owner"] = acc.Owner
+	params["user_groups"] = pq.StringArray(groups)
+
+	return params
 }
 
 func getNameQuery(name string) (string, string) {
@@ -438,6 +536,26 @@ func getNameQuery(name string) (string, string) {
 	return nq, name
 }
 
+// getCursorQuery returns the keyset condition that selects entities
+// following the one the cursor points to, together with its ID.
+func getCursorQuery(c string) (string, string, error) {
+	if c == "" {
+		return "", "", nil
+	}
+
+	keys, err := mfcursor.Decode(c, 1)
+	if err != nil {
+		return "", "", err
+	}
+
+	// Verify if UUID format is valid to avoid internal Postgres error
+	if _, err := uuid.FromString(keys[0]); err != nil {
+		return "", "", errors.Wrap(mfcursor.ErrMalformedCursor, err)
+	}
+
+	return ` AND id > :cursor`, keys[0], nil
+}
+
 func getMetadataQuery(m things.Metadata) ([]byte, string, error) {
 	mq := ""
 	mb := []byte("{}")
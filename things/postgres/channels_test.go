@@ -136,11 +136,72 @@ func TestChannelUpdate(t *testing.T) {
 	}
 
 	for _, tc := range cases {
-		err := chanRepo.Update(context.Background(), tc.channel)
+		err := chanRepo.Update(context.Background(), things.Access{Owner: tc.channel.Owner}, tc.channel)
 		assert.True(t, errors.Contains(err, tc.err), fmt.Sprintf("%s: expected %s got %s\n", tc.desc, tc.err, err))
 	}
 }
 
+func TestChannelShare(t *testing.T) {
+	email := "channel-share@example.com"
+	userGroup := "channel-share-group"
+	dbMiddleware := postgres.NewDatabase(db)
+	chanRepo := postgres.NewChannelRepository(dbMiddleware)
+
+	id, err := uuidProvider.New().ID()
+	require.Nil(t, err, fmt.Sprintf("got unexpected error: %s", err))
+	ch := things.Channel{
+		ID:    id,
+		Owner: email,
+	}
+	chs, err := chanRepo.Save(context.Background(), ch)
+	require.Nil(t, err, fmt.Sprintf("unexpected error: %s\n", err))
+	ch.ID = chs[0].ID
+
+	nonexistentChanID, err := uuidProvider.New().ID()
+	require.Nil(t, err, fmt.Sprintf("got unexpected error: %s", err))
+
+	cases := []struct {
+		desc  string
+		owner string
+		id    string
+		group string
+		err   error
+	}{
+		{
+			desc:  "share existing channel",
+			owner: email,
+			id:    ch.ID,
+			group: userGroup,
+			err:   nil,
+		},
+		{
+			desc:  "share existing channel with non-owner",
+			owner: wrongValue,
+			id:    ch.ID,
+			group: userGroup,
+			err:   things.ErrNotFound,
+		},
+		{
+			desc:  "share non-existing channel",
+			owner: email,
+			id:    nonexistentChanID,
+			group: userGroup,
+			err:   things.ErrNotFound,
+		},
+	}
+
+	for _, tc := range cases {
+		err := chanRepo.Share(context.Background(), tc.owner, tc.id, tc.group)
+		assert.True(t, errors.Contains(err, tc.err), fmt.Sprintf("%s: expected %s got %s\n", tc.desc, tc.err, err))
+	}
+
+	err = chanRepo.Update(context.Background(), things.Access{Owner: email}, things.Channel{ID: ch.ID, Name: "renamed"})
+	require.Nil(t, err, fmt.Sprintf("unexpected error: %s\n", err))
+	saved, err := chanRepo.RetrieveByID(context.Background(), things.Access{Owner: email}, ch.ID)
+	require.Nil(t, err, fmt.Sprintf("unexpected error: %s\n", err))
+	assert.Equal(t, userGroup, saved.UserGroup, fmt.Sprintf("update channel: expected group %s got %s\n", userGroup, saved.UserGroup))
+}
+
 func TestSingleChannelRetrieval(t *testing.T) {
 	email := "channel-single-retrieval@example.com"
 	dbMiddleware := postgres.NewDatabase(db)
@@ -167,7 +228,7 @@ func TestSingleChannelRetrieval(t *testing.T) {
 	}
 	chs, _ := chanRepo.Save(context.Background(), ch)
 	ch.ID = chs[0].ID
-	chanRepo.Connect(context.Background(), email, []string{ch.ID}, []string{th.ID})
+	chanRepo.Connect(context.Background(), things.Access{Owner: email}, []string{ch.ID}, []string{th.ID}, things.DefaultActions)
 
 	nonexistentChanID, err := uuidProvider.New().ID()
 	require.Nil(t, err, fmt.Sprintf("got unexpected error: %s", err))
@@ -200,7 +261,7 @@ func TestSingleChannelRetrieval(t *testing.T) {
 	}
 
 	for desc, tc := range cases {
-		_, err := chanRepo.RetrieveByID(context.Background(), tc.owner, tc.ID)
+		_, err := chanRepo.RetrieveByID(context.Background(), things.Access{Owner: tc.owner}, tc.ID)
 		assert.True(t, errors.Contains(err, tc.err), fmt.Sprintf("%s: expected %s got %s\n", desc, tc.err, err))
 	}
 }
@@ -323,7 +384,7 @@ func TestMultiChannelRetrieval(t *testing.T) {
 	}
 
 	for desc, tc := range cases {
-		page, err := chanRepo.RetrieveAll(context.Background(), tc.owner, tc.offset, tc.limit, tc.name, tc.metadata)
+		page, err := chanRepo.RetrieveAll(context.Background(), things.Access{Owner: tc.owner}, tc.offset, tc.limit, "", tc.name, tc.metadata)
 		size := uint64(len(page.Channels))
 		assert.Equal(t, tc.size, size, fmt.Sprintf("%s: expected size %d got %d\n", desc, tc.size, size))
 		assert.Equal(t, tc.total, page.Total, fmt.Sprintf("%s: expected total %d got %d\n", desc, tc.total, page.Total))
@@ -365,7 +426,7 @@ func TestRetrieveByThing(t *testing.T) {
 			break
 		}
 
-		err = chanRepo.Connect(context.Background(), email, []string{cid}, []string{thid})
+		err = chanRepo.Connect(context.Background(), things.Access{Owner: email}, []string{cid}, []string{thid}, things.DefaultActions)
 		require.Nil(t, err, fmt.Sprintf("unexpected error: %s", err))
 	}
 
@@ -433,7 +494,7 @@ func TestRetrieveByThing(t *testing.T) {
 	}
 
 	for desc, tc := range cases {
-		page, err := chanRepo.RetrieveByThing(context.Background(), tc.owner, tc.thing, tc.offset, tc.limit, tc.connected)
+		page, err := chanRepo.RetrieveByThing(context.Background(), things.Access{Owner: tc.owner}, tc.thing, tc.offset, tc.limit, tc.connected)
 		size := uint64(len(page.Channels))
 		assert.Equal(t, tc.size, size, fmt.Sprintf("%s: expected size %d got %d\n", desc, tc.size, size))
 		assert.True(t, errors.Contains(err, tc.err), fmt.Sprintf("%s: expected no error got %d\n", desc, err))
@@ -457,10 +518,10 @@ func TestChannelRemoval(t *testing.T) {
 	// show that the removal works the same for both existing and non-existing
 	// (removed) channel
 	for i := 0; i < 2; i++ {
-		err := chanRepo.Remove(context.Background(), email, chid)
+		err := chanRepo.Remove(context.Background(), things.Access{Owner: email}, chid)
 		require.Nil(t, err, fmt.Sprintf("#%d: failed to remove channel due to: %s", i, err))
 
-		_, err = chanRepo.RetrieveByID(context.Background(), email, chid)
+		_, err = chanRepo.RetrieveByID(context.Background(), things.Access{Owner: email}, chid)
 		assert.True(t, errors.Contains(err, things.ErrNotFound), fmt.Sprintf("#%d: expected %s got %s", i, things.ErrNotFound, err))
 	}
 }
@@ -521,7 +582,7 @@ func TestConnect(t *testing.T) {
 			owner: email,
 			chid:  chid,
 			thid:  thid,
-			err:   things.ErrConflict,
+			err:   nil,
 		},
 		{
 			desc:  "connect with non-existing user",
@@ -547,9 +608,16 @@ func TestConnect(t *testing.T) {
 	}
 
 	for _, tc := range cases {
-		err := chanRepo.Connect(context.Background(), tc.owner, []string{tc.chid}, []string{tc.thid})
+		err := chanRepo.Connect(context.Background(), things.Access{Owner: tc.owner}, []string{tc.chid}, []string{tc.thid}, things.DefaultActions)
 		assert.True(t, errors.Contains(err, tc.err), fmt.Sprintf("%s: expected %s got %s\n", tc.desc, tc.err, err))
 	}
+
+	err = chanRepo.Connect(context.Background(), things.Access{Owner: email}, []string{chid}, []string{thid}, []string{things.ActionPublish})
+	require.Nil(t, err, fmt.Sprintf("reconnect with publish action: unexpected error: %s\n", err))
+	err = chanRepo.HasThingByID(context.Background(), chid, thid, things.ActionPublish)
+	assert.Nil(t, err, fmt.Sprintf("publish after reconnecting: unexpected error: %s\n", err))
+	err = chanRepo.HasThingByID(context.Background(), chid, thid, things.ActionSubscribe)
+	assert.True(t, errors.Contains(err, things.ErrNotFound), fmt.Sprintf("subscribe after reconnecting: expected %s got %s\n", things.ErrNotFound, err))
 }
 
 func TestDisconnect(t *testing.T) {
@@ -580,7 +648,7 @@ func TestDisconnect(t *testing.T) {
 	})
 	require.Nil(t, err, fmt.Sprintf("unexpected error: %s\n", err))
 	chid = chs[0].ID
-	chanRepo.Connect(context.Background(), email, []string{chid}, []string{thid})
+	chanRepo.Connect(context.Background(), things.Access{Owner: email}, []string{chid}, []string{thid}, things.DefaultActions)
 
 	nonexistentThingID, err := uuidProvider.New().ID()
 	require.Nil(t, err, fmt.Sprintf("got unexpected error: %s", err))
@@ -633,7 +701,7 @@ func TestDisconnect(t *testing.T) {
 	}
 
 	for _, tc := range cases {
-		err := chanRepo.Disconnect(context.Background(), tc.owner, tc.chid, tc.thid)
+		err := chanRepo.Disconnect(context.Background(), things.Access{Owner: tc.owner}, tc.chid, tc.thid)
 		assert.True(t, errors.Contains(err, tc.err), fmt.Sprintf("%s: expected %s got %s\n", tc.desc, tc.err, err))
 	}
 }
@@ -666,7 +734,7 @@ func TestHasThing(t *testing.T) {
 	})
 	require.Nil(t, err, fmt.Sprintf("unexpected error: %s\n", err))
 	chid = chs[0].ID
-	chanRepo.Connect(context.Background(), email, []string{chid}, []string{thid})
+	chanRepo.Connect(context.Background(), things.Access{Owner: email}, []string{chid}, []string{thid}, []string{things.ActionPublish})
 
 	nonexistentChanID, err := uuidProvider.New().ID()
 	require.Nil(t, err, fmt.Sprintf("got unexpected error: %s", err))
@@ -674,27 +742,37 @@ func TestHasThing(t *testing.T) {
 	cases := map[string]struct {
 		chid      string
 		key       string
+		action    string
 		hasAccess bool
 	}{
 		"access check for thing that has access": {
 			chid:      chid,
 			key:       th.Key,
+			action:    things.ActionPublish,
 			hasAccess: true,
 		},
+		"access check for thing without permission to perform action": {
+			chid:      chid,
+			key:       th.Key,
+			action:    things.ActionSubscribe,
+			hasAccess: false,
+		},
 		"access check for thing without access": {
 			chid:      chid,
 			key:       wrongValue,
+			action:    things.ActionPublish,
 			hasAccess: false,
 		},
 		"access check for non-existing channel": {
 			chid:      nonexistentChanID,
 			key:       th.Key,
+			action:    things.ActionPublish,
 			hasAccess: false,
 		},
 	}
 
 	for desc, tc := range cases {
-		_, err := chanRepo.HasThing(context.Background(), tc.chid, tc.key)
+		_, err := chanRepo.HasThing(context.Background(), tc.chid, tc.key, tc.action)
 		hasAccess := err == nil
 		assert.Equal(t, tc.hasAccess, hasAccess, fmt.Sprintf("%s: expected %t got %t\n", desc, tc.hasAccess, hasAccess))
 	}
@@ -741,7 +819,7 @@ func TestHasThingByID(t *testing.T) {
 	})
 	require.Nil(t, err, fmt.Sprintf("unexpected error: %s\n", err))
 	chid = chs[0].ID
-	chanRepo.Connect(context.Background(), email, []string{chid}, []string{thid})
+	chanRepo.Connect(context.Background(), things.Access{Owner: email}, []string{chid}, []string{thid}, []string{things.ActionPublish})
 
 	nonexistentChanID, err := uuidProvider.New().ID()
 	require.Nil(t, err, fmt.Sprintf("got unexpected error: %s", err))
@@ -749,32 +827,43 @@ func TestHasThingByID(t *testing.T) {
 	cases := map[string]struct {
 		chid      string
 		thid      string
+		action    string
 		hasAccess bool
 	}{
 		"access check for thing that has access": {
 			chid:      chid,
 			thid:      thid,
+			action:    things.ActionPublish,
 			hasAccess: true,
 		},
+		"access check for thing without permission to perform action": {
+			chid:      chid,
+			thid:      thid,
+			action:    things.ActionSubscribe,
+			hasAccess: false,
+		},
 		"access check for thing without access": {
 			chid:      chid,
 			thid:      disconnectedThingID,
+			action:    things.ActionPublish,
 			hasAccess: false,
 		},
 		"access check for non-existing channel": {
 			chid:      nonexistentChanID,
 			thid:      thid,
+			action:    things.ActionPublish,
 			hasAccess: false,
 		},
 		"access check for non-existing thing": {
 			chid:      chid,
 			thid:      wrongValue,
+			action:    things.ActionPublish,
 			hasAccess: false,
 		},
 	}
 
 	for desc, tc := range cases {
-		err := chanRepo.HasThingByID(context.Background(), tc.chid, tc.thid)
+		err := chanRepo.HasThingByID(context.Background(), tc.chid, tc.thid, tc.action)
 		hasAccess := err == nil
 		assert.Equal(t, tc.hasAccess, hasAccess, fmt.Sprintf("%s: expected %t got %t\n", desc, tc.hasAccess, hasAccess))
 	}
@@ -0,0 +1,533 @@
+// Copyright (c) Mainflux
+// SPDX-License-Identifier: Apache-2.0
+
+package postgres
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+
+	"github.com/gofrs/uuid"
+	"github.com/lib/pq"
+	"github.com/mainflux/mainflux/pkg/errors"
+	"github.com/mainflux/mainflux/things"
+)
+
+// subtreeQuery selects identifiers of the group and all of its descendants.
+const subtreeQuery = `WITH RECURSIVE subtree AS (
+	SELECT id, owner FROM groups WHERE id = :group AND owner = :owner
+	UNION ALL
+	SELECT g.id, g.owner FROM groups g
+	INNER JOIN subtree s ON g.parent_id = s.id AND g.owner = s.owner
+)`
+
+var _ things.GroupRepository = (*groupRepository)(nil)
+
+type groupRepository struct {
+	db Database
+}
+
+// NewGroupRepository instantiates a PostgreSQL implementation of group
+// repository.
+func NewGroupRepository(db Database) things.GroupRepository {
+	return &groupRepository{
+		db: db,
+	}
+}
+
+func (gr groupRepository) Save(ctx context.Context, g things.Group) (things.Group, error) {
+	q := `INSERT INTO groups (id, owner, parent_id, name, description, metadata)
+	      VALUES (:id, :owner, :parent_id, :name, :description, :metadata);`
+
+	if _, err := gr.db.NamedExecContext(ctx, q, toDBGroup(g)); err != nil {
+		pqErr, ok := err.(*pq.Error)
+		if ok {
+			switch pqErr.Code.Name() {
+			case errInvalid, errTruncation:
+				return things.Group{}, errors.Wrap(things.ErrMalformedEntity, err)
+			case errFK:
+				return things.Group{}, errors.Wrap(things.ErrNotFound, err)
+			case errDuplicate:
+				return things.Group{}, errors.Wrap(things.ErrConflict, err)
+			}
+		}
+
+		return things.Group{}, errors.Wrap(things.ErrCreateEntity, err)
+	}
+
+	return g, nil
+}
+
+func (gr groupRepository) Update(ctx context.Context, g things.Group) error {
+	q := `UPDATE groups SET name = :name, description = :description, metadata = :metadata
+	      WHERE owner = :owner AND id = :id;`
+
+	res, err := gr.db.NamedExecContext(ctx, q, toDBGroup(g))
+	if err != nil {
+		pqErr, ok := err.(*pq.Error)
+		if ok {
+			switch pqErr.Code.Name() {
+			case errInvalid, errTruncation:
+				return errors.Wrap(things.ErrMalformedEntity, err)
+			}
+		}
+
+		return errors.Wrap(things.ErrUpdateEntity, err)
+	}
+
+	cnt, err := res.RowsAffected()
+	if err != nil {
+		return errors.Wrap(things.ErrUpdateEntity, err)
+	}
+
+	if cnt == 0 {
+		return things.ErrNotFound
+	}
+
+	return nil
+}
+
+func (gr groupRepository) RetrieveByID(ctx context.Context, owner, id string) (things.Group, error) {
+	q := `SELECT parent_id, name, description, metadata FROM groups WHERE id = $1 AND owner = $2;`
+
+	dbg := dbGroup{
+		ID:    id,
+		Owner: owner,
+	}
+	if err := gr.db.QueryRowxContext(ctx, q, id, owner).StructScan(&dbg); err != nil {
+		pqErr, ok := err.(*pq.Error)
+		if err == sql.ErrNoRows || ok && errInvalid == pqErr.Code.Name() {
+			return things.Group{}, things.ErrNotFound
+		}
+		return things.Group{}, errors.Wrap(things.ErrSelectEntity, err)
+	}
+
+	return toGroup(dbg), nil
+}
+
+func (gr groupRepository) RetrieveAll(ctx context.Context, owner, parentID string, offset, limit uint64, gm things.Metadata) (things.GroupsPage, error) {
+	m, mq, err := getMetadataQuery(gm)
+	if err != nil {
+		return things.GroupsPage{}, errors.Wrap(things.ErrSelectEntity, err)
+	}
+
+	q := fmt.Sprintf(`SELECT id, parent_id, name, description, metadata FROM groups
+	      WHERE owner = :owner %s ORDER BY id LIMIT :limit OFFSET :offset;`, mq)
+	cq := fmt.Sprintf(`SELECT COUNT(*) FROM groups WHERE owner = :owner %s;`, mq)
+
+	if parentID != "" {
+		// Verify if UUID format is valid to avoid internal Postgres error
+		if _, err := uuid.FromString(parentID); err != nil {
+			return things.GroupsPage{}, things.ErrNotFound
+		}
+
+		dq := `WITH RECURSIVE descendants AS (
+		        SELECT id, owner, parent_id, name, description, metadata
+		        FROM groups WHERE parent_id = :parent AND owner = :owner
+		        UNION ALL
+		        SELECT g.id, g.owner, g.parent_id, g.name, g.description, g.metadata
+		        FROM groups g
+		        INNER JOIN descendants d ON g.parent_id = d.id AND g.owner = d.owner
+		      )`
+		q = fmt.Sprintf(`%s SELECT id, parent_id, name, description, metadata FROM descendants
+		      WHERE owner = :owner %s ORDER BY id LIMIT :limit OFFSET :offset;`, dq, mq)
+		cq = fmt.Sprintf(`%s SELECT COUNT(*) FROM descendants WHERE owner = :owner %s;`, dq, mq)
+	}
+
+	params := map[string]interface{}{
+		"owner":    owner,
+		"parent":   parentID,
+		"limit":    limit,
+		"offset":   offset,
+		"metadata": m,
+	}
+	rows, err := gr.db.NamedQueryContext(ctx, q, params)
+	if err != nil {
+		return things.GroupsPage{}, errors.Wrap(things.ErrSelectEntity, err)
+	}
+	defer rows.Close()
+
+	items := []things.Group{}
+	for rows.Next() {
+		dbg := dbGroup{Owner: owner}
+		if err := rows.StructScan(&dbg); err != nil {
+			return things.GroupsPage{}, errors.Wrap(things.ErrSelectEntity, err)
+		}
+
+		items = append(items, toGroup(dbg))
+	}
+
+	total, err := total(ctx, gr.db, cq, params)
+	if err != nil {
+		return things.GroupsPage{}, errors.Wrap(things.ErrSelectEntity, err)
+	}
+
+	page := things.GroupsPage{
+		Groups: items,
+		PageMetadata: things.PageMetadata{
+			Total:  total,
+			Offset: offset,
+			Limit:  limit,
+		},
+	}
+
+	return page, nil
+}
+
+func (gr groupRepository) RetrieveThings(ctx context.Context, owner, groupID string, offset, limit uint64) (things.Page, error) {
+	// Verify if UUID format is valid to avoid internal Postgres error
+	if _, err := uuid.FromString(groupID); err != nil {
+		return things.Page{}, things.ErrNotFound
+	}
+
+	q := `SELECT id, name, key, metadata
+	      FROM things th
+	      INNER JOIN group_things gt
+	      ON th.id = gt.thing_id AND th.owner = gt.owner
+	      WHERE gt.owner = :owner AND gt.group_id = :group
+	      ORDER BY th.id
+	      LIMIT :limit
+	      OFFSET :offset;`
+
+	cq := `SELECT COUNT(*) FROM group_things WHERE owner = :owner AND group_id = :group;`
+
+	params := map[string]interface{}{
+		"owner":  owner,
+		"group":  groupID,
+		"limit":  limit,
+		"offset": offset,
+	}
+
+	rows, err := gr.db.NamedQueryContext(ctx, q, params)
+	if err != nil {
+		return things.Page{}, errors.Wrap(things.ErrSelectEntity, err)
+	}
+	defer rows.Close()
+
+	var items []things.Thing
+	for rows.Next() {
+		dbth := dbThing{Owner: owner}
+		if err := rows.StructScan(&dbth); err != nil {
+			return things.Page{}, errors.Wrap(things.ErrSelectEntity, err)
+		}
+
+		th, err := toThing(dbth)
+		if err != nil {
+			return things.Page{}, errors.Wrap(things.ErrViewEntity, err)
+		}
+
+		items = append(items, th)
+	}
+
+	total, err := total(ctx, gr.db, cq, params)
+	if err != nil {
+		return things.Page{}, errors.Wrap(things.ErrSelectEntity, err)
+	}
+
+	return things.Page{
+		Things: items,
+		PageMetadata: things.PageMetadata{
+			Total:  total,
+			Offset: offset,
+			Limit:  limit,
+		},
+	}, nil
+}
+
+func (gr groupRepository) RetrieveChannels(ctx context.Context, owner, groupID string, offset, limit uint64) (things.ChannelsPage, error) {
+	// Verify if UUID format is valid to avoid internal Postgres error
+	if _, err := uuid.FromString(groupID); err != nil {
+		return things.ChannelsPage{}, things.ErrNotFound
+	}
+
+	q := `SELECT id, name, metadata
+	      FROM channels ch
+	      INNER JOIN group_channels gc
+	      ON ch.id = gc.channel_id AND ch.owner = gc.owner
+	      WHERE gc.owner = :owner AND gc.group_id = :group
+	      ORDER BY ch.id
+	      LIMIT :limit
+	      OFFSET :offset;`
+
+	cq := `SELECT COUNT(*) FROM group_channels WHERE owner = :owner AND group_id = :group;`
+
+	params := map[string]interface{}{
+		"owner":  owner,
+		"group":  groupID,
+		"limit":  limit,
+		"offset": offset,
+	}
+
+	rows, err := gr.db.NamedQueryContext(ctx, q, params)
+	if err != nil {
+		return things.ChannelsPage{}, errors.Wrap(things.ErrSelectEntity, err)
+	}
+	defer rows.Close()
+
+	items := []things.Channel{}
+	for rows.Next() {
+		dbch := dbChannel{Owner: owner}
+		if err := rows.StructScan(&dbch); err != nil {
+			return things.ChannelsPage{}, errors.Wrap(things.ErrSelectEntity, err)
+		}
+
+		items = append(items, toChannel(dbch))
+	}
+
+	total, err := total(ctx, gr.db, cq, params)
+	if err != nil {
+		return things.ChannelsPage{}, errors.Wrap(things.ErrSelectEntity, err)
+	}
+
+	return things.ChannelsPage{
+		Channels: items,
+		PageMetadata: things.PageMetadata{
+			Total:  total,
+			Offset: offset,
+			Limit:  limit,
+		},
+	}, nil
+}
+
+func (gr groupRepository) RetrieveConnections(ctx context.Context, owner, groupID string) ([]string, error) {
+	// Verify if UUID format is valid to avoid internal Postgres error
+	if _, err := uuid.FromString(groupID); err != nil {
+		return []string{}, nil
+	}
+
+	q := fmt.Sprintf(`%s SELECT DISTINCT channel_id FROM group_connections
+	      WHERE owner = :owner AND group_id IN (SELECT id FROM subtree);`, subtreeQuery)
+
+	params := map[string]interface{}{
+		"owner": owner,
+		"group": groupID,
+	}
+
+	rows, err := gr.db.NamedQueryContext(ctx, q, params)
+	if err != nil {
+		return []string{}, errors.Wrap(things.ErrSelectEntity, err)
+	}
+	defer rows.Close()
+
+	chIDs := []string{}
+	for rows.Next() {
+		var chID string
+		if err := rows.Scan(&chID); err != nil {
+			return []string{}, errors.Wrap(things.ErrSelectEntity, err)
+		}
+		chIDs = append(chIDs, chID)
+	}
+
+	return chIDs, nil
+}
+
+func (gr groupRepository) Remove(ctx context.Context, owner, id string) error {
+	tx, err := gr.db.BeginTxx(ctx, nil)
+	if err != nil {
+		return errors.Wrap(things.ErrRemoveEntity, err)
+	}
+
+	// Connections established through the group and its descendants have
+	// to be removed explicitly, since they are not bound to the group.
+	cq := fmt.Sprintf(`%s DELETE FROM connections conn
+	      USING group_connections gc, group_things gt
+	      WHERE gc.group_id IN (SELECT id FROM subtree) AND gc.owner = :owner
+	      AND gt.group_id = gc.group_id AND gt.owner = gc.owner
+	      AND conn.channel_id = gc.channel_id AND conn.channel_owner = gc.owner
+	      AND conn.thing_id = gt.thing_id AND conn.thing_owner = gt.owner;`, subtreeQuery)
+	q := `DELETE FROM groups WHERE id = :group AND owner = :owner;`
+
+	dbr := dbGroupRelation{
+		Group: id,
+		Owner: owner,
+	}
+	for _, query := range []string{cq, q} {
+		if _, err := tx.NamedExecContext(ctx, query, dbr); err != nil {
+			tx.Rollback()
+			return errors.Wrap(things.ErrRemoveEntity, err)
+		}
+	}
+
+	if err := tx.Commit(); err != nil {
+		return errors.Wrap(things.ErrRemoveEntity, err)
+	}
+
+	return nil
+}
+
+func (gr groupRepository) AssignThings(ctx context.Context, owner, groupID string, thIDs ...string) error {
+	q := `INSERT INTO group_things (group_id, thing_id, owner) VALUES (:group, :member, :owner);`
+	cq := `INSERT INTO connections (channel_id, channel_owner, thing_id, thing_owner, actions)
+	       SELECT channel_id, owner, CAST(:member AS UUID), owner, actions FROM group_connections
+	       WHERE group_id = :group AND owner = :owner
+	       ON CONFLICT DO NOTHING;`
+
+	return gr.assign(ctx, owner, groupID, thIDs, nil, q, cq)
+}
+
+func (gr groupRepository) UnassignThings(ctx context.Context, owner, groupID string, thIDs ...string) error {
+	cq := `DELETE FROM connections
+	       WHERE thing_id = :member AND thing_owner = :owner AND channel_id IN
+	       (SELECT channel_id FROM group_connections WHERE group_id = :group AND owner = :owner);`
+	q := `DELETE FROM group_things WHERE group_id = :group AND thing_id = :member AND owner = :owner;`
+
+	return gr.unassign(ctx, owner, groupID, thIDs, cq, q)
+}
+
+func (gr groupRepository) AssignChannels(ctx context.Context, owner, groupID string, chIDs ...string) error {
+	q := `INSERT INTO group_channels (group_id, channel_id, owner) VALUES (:group, :member, :owner);`
+
+	return gr.assign(ctx, owner, groupID, chIDs, nil, q)
+}
+
+func (gr groupRepository) UnassignChannels(ctx context.Context, owner, groupID string, chIDs ...string) error {
+	q := `DELETE FROM group_channels WHERE group_id = :group AND channel_id = :member AND owner = :owner;`
+
+	return gr.unassign(ctx, owner, groupID, chIDs, q)
+}
+
+func (gr groupRepository) Connect(ctx context.Context, owner, groupID string, chIDs, actions []string) error {
+	q := `INSERT INTO group_connections (group_id, channel_id, owner, actions)
+	      VALUES (:group, :member, :owner, :actions);`
+	cq := `INSERT INTO connections (channel_id, channel_owner, thing_id, thing_owner, actions)
+	       SELECT CAST(:member AS UUID), owner, thing_id, owner, CAST(:actions AS VARCHAR(16)[])
+	       FROM group_things WHERE group_id = :group AND owner = :owner
+	       ON CONFLICT DO NOTHING;`
+
+	return gr.assign(ctx, owner, groupID, chIDs, actions, q, cq)
+}
+
+func (gr groupRepository) Disconnect(ctx context.Context, owner, groupID, chanID string) error {
+	cq := `DELETE FROM connections
+	       WHERE channel_id = :member AND channel_owner = :owner AND thing_id IN
+	       (SELECT thing_id FROM group_things WHERE group_id = :group AND owner = :owner);`
+	q := `DELETE FROM group_connections WHERE group_id = :group AND channel_id = :member AND owner = :owner;`
+
+	return gr.unassign(ctx, owner, groupID, []string{chanID}, cq, q)
+}
+
+// assign executes the given queries for every member in a single transaction.
+func (gr groupRepository) assign(ctx context.Context, owner, groupID string, members, actions []string, queries ...string) error {
+	tx, err := gr.db.BeginTxx(ctx, nil)
+	if err != nil {
+		return errors.Wrap(things.ErrConnect, err)
+	}
+
+	for _, member := range members {
+		dbr := dbGroupRelation{
+			Group:   groupID,
+			Member:  member,
+			Owner:   owner,
+			Actions: actions,
+		}
+
+		for _, q := range queries {
+			if _, err := tx.NamedExecContext(ctx, q, dbr); err != nil {
+				tx.Rollback()
+				pqErr, ok := err.(*pq.Error)
+				if ok {
+					switch pqErr.Code.Name() {
+					case errFK, errInvalid:
+						return errors.Wrap(things.ErrNotFound, err)
+					case errDuplicate:
+						return errors.Wrap(things.ErrConflict, err)
+					}
+				}
+
+				return errors.Wrap(things.ErrConnect, err)
+			}
+		}
+	}
+
+	if err = tx.Commit(); err != nil {
+		return errors.Wrap(things.ErrConnect, err)
+	}
+
+	return nil
+}
+
+// unassign executes the given queries for every member in a single
+// transaction. The last query is expected to remove the group relation and
+// ErrNotFound is returned if no relation is removed.
+func (gr groupRepository) unassign(ctx context.Context, owner, groupID string, members []string, queries ...string) error {
+	tx, err := gr.db.BeginTxx(ctx, nil)
+	if err != nil {
+		return errors.Wrap(things.ErrDisconnect, err)
+	}
+
+	for _, member := range members {
+		dbr := dbGroupRelation{
+			Group:  groupID,
+			Member: member,
+			Owner:  owner,
+		}
+
+		var res sql.Result
+		for _, q := range queries {
+			if res, err = tx.NamedExecContext(ctx, q, dbr); err != nil {
+				tx.Rollback()
+				pqErr, ok := err.(*pq.Error)
+				if ok && pqErr.Code.Name() == errInvalid {
+					return errors.Wrap(things.ErrNotFound, err)
+				}
+				return errors.Wrap(things.ErrDisconnect, err)
+			}
+		}
+
+		cnt, err := res.RowsAffected()
+		if err != nil {
+			tx.Rollback()
+			return errors.Wrap(things.ErrDisconnect, err)
+		}
+
+		if cnt == 0 {
+			tx.Rollback()
+			return things.ErrNotFound
+		}
+	}
+
+	if err = tx.Commit(); err != nil {
+		return errors.Wrap(things.ErrDisconnect, err)
+	}
+
+	return nil
+}
+
+type dbGroup struct {
+	ID          string         `db:"id"`
+	Owner       string         `db:"owner"`
+	ParentID    sql.NullString `db:"parent_id"`
+	Name        string         `db:"name"`
+	Description string         `db:"description"`
+	Metadata    dbMetadata     `db:"metadata"`
+}
+
+type dbGroupRelation struct {
+	Group   string         `db:"group"`
+	Member  string         `db:"member"`
+	Owner   string         `db:"owner"`
+	Actions pq.StringArray `db:"actions"`
+}
+
+func toDBGroup(g things.Group) dbGroup {
+	return dbGroup{
+		ID:          g.ID,
+		Owner:       g.Owner,
+		ParentID:    sql.NullString{String: g.ParentID, Valid: g.ParentID != ""},
+		Name:        g.Name,
+		Description: g.Description,
+		Metadata:    dbMetadata(g.Metadata),
+	}
+}
+
+func toGroup(g dbGroup) things.Group {
+	return things.Group{
+		ID:          g.ID,
+		Owner:       g.Owner,
+		ParentID:    g.ParentID.String,
+		Name:        g.Name,
+		Description: g.Description,
+		Metadata:    things.Metadata(g.Metadata),
+	}
+}
@@ -0,0 +1,277 @@
+// Copyright (c) Mainflux
+// SPDX-License-Identifier: Apache-2.0
+
+package postgres_test
+
+import (
+	"context"
+	"fmt"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/mainflux/mainflux/pkg/errors"
+	uuidProvider "github.com/mainflux/mainflux/pkg/uuid"
+	"github.com/mainflux/mainflux/things"
+	"github.com/mainflux/mainflux/things/postgres"
+)
+
+func TestGroupSave(t *testing.T) {
+	dbMiddleware := postgres.NewDatabase(db)
+	groupRepo := postgres.NewGroupRepository(dbMiddleware)
+
+	email := "group-save@example.com"
+
+	id, err := uuidProvider.New().ID()
+	require.Nil(t, err, fmt.Sprintf("got unexpected error: %s", err))
+	childID, err := uuidProvider.New().ID()
+	require.Nil(t, err, fmt.Sprintf("got unexpected error: %s", err))
+	nonexistentID, err := uuidProvider.New().ID()
+	require.Nil(t, err, fmt.Sprintf("got unexpected error: %s", err))
+	nonexistentParentID, err := uuidProvider.New().ID()
+	require.Nil(t, err, fmt.Sprintf("got unexpected error: %s", err))
+
+	cases := []struct {
+		desc  string
+		group things.Group
+		err   error
+	}{
+		{
+			desc:  "create new group",
+			group: things.Group{ID: id, Owner: email, Name: "parent"},
+			err:   nil,
+		},
+		{
+			desc:  "create group that already exists",
+			group: things.Group{ID: id, Owner: email, Name: "parent"},
+			err:   things.ErrConflict,
+		},
+		{
+			desc:  "create child group",
+			group: things.Group{ID: childID, Owner: email, ParentID: id, Name: "child"},
+			err:   nil,
+		},
+		{
+			desc:  "create group with non-existing parent",
+			group: things.Group{ID: nonexistentID, Owner: email, ParentID: nonexistentParentID, Name: "orphan"},
+			err:   things.ErrNotFound,
+		},
+		{
+			desc:  "create group with invalid ID",
+			group: things.Group{ID: "invalid", Owner: email},
+			err:   things.ErrMalformedEntity,
+		},
+		{
+			desc:  "create group with invalid name",
+			group: things.Group{ID: nonexistentID, Owner: email, Name: invalidName},
+			err:   things.ErrMalformedEntity,
+		},
+	}
+
+	for _, tc := range cases {
+		_, err := groupRepo.Save(context.Background(), tc.group)
+		assert.True(t, errors.Contains(err, tc.err), fmt.Sprintf("%s: expected %s got %s\n", tc.desc, tc.err, err))
+	}
+}
+
+func TestGroupRetrieveAll(t *testing.T) {
+	dbMiddleware := postgres.NewDatabase(db)
+	groupRepo := postgres.NewGroupRepository(dbMiddleware)
+
+	email := "group-retrieve-all@example.com"
+
+	parentID := ""
+	rootID := ""
+	n := uint64(5)
+	for i := uint64(0); i < n; i++ {
+		id, err := uuidProvider.New().ID()
+		require.Nil(t, err, fmt.Sprintf("got unexpected error: %s", err))
+		_, err = groupRepo.Save(context.Background(), things.Group{ID: id, Owner: email, ParentID: parentID})
+		require.Nil(t, err, fmt.Sprintf("unexpected error: %s\n", err))
+		if rootID == "" {
+			rootID = id
+		}
+		parentID = id
+	}
+
+	cases := map[string]struct {
+		parentID string
+		offset   uint64
+		limit    uint64
+		size     uint64
+	}{
+		"retrieve all groups": {
+			offset: 0,
+			limit:  n,
+			size:   n,
+		},
+		"retrieve all descendants of the root group": {
+			parentID: rootID,
+			offset:   0,
+			limit:    n,
+			size:     n - 1,
+		},
+		"retrieve descendants of the leaf group": {
+			parentID: parentID,
+			offset:   0,
+			limit:    n,
+			size:     0,
+		},
+		"retrieve descendants of group with invalid ID": {
+			parentID: wrongValue,
+			offset:   0,
+			limit:    n,
+			size:     0,
+		},
+	}
+
+	for desc, tc := range cases {
+		page, err := groupRepo.RetrieveAll(context.Background(), email, tc.parentID, tc.offset, tc.limit, nil)
+		size := uint64(len(page.Groups))
+		assert.Equal(t, tc.size, size, fmt.Sprintf("%s: expected size %d got %d\n", desc, tc.size, size))
+		assert.Nil(t, err, fmt.Sprintf("%s: expected no error got %d\n", desc, err))
+	}
+}
+
+func TestGroupAssignThings(t *testing.T) {
+	email := "group-assign@example.com"
+	dbMiddleware := postgres.NewDatabase(db)
+	thingRepo := postgres.NewThingRepository(dbMiddleware)
+	chanRepo := postgres.NewChannelRepository(dbMiddleware)
+	groupRepo := postgres.NewGroupRepository(dbMiddleware)
+
+	thid, err := uuidProvider.New().ID()
+	require.Nil(t, err, fmt.Sprintf("got unexpected error: %s", err))
+	thkey, err := uuidProvider.New().ID()
+	require.Nil(t, err, fmt.Sprintf("got unexpected error: %s", err))
+	_, err = thingRepo.Save(context.Background(), things.Thing{ID: thid, Owner: email, Key: thkey})
+	require.Nil(t, err, fmt.Sprintf("unexpected error: %s\n", err))
+
+	chid, err := uuidProvider.New().ID()
+	require.Nil(t, err, fmt.Sprintf("got unexpected error: %s", err))
+	_, err = chanRepo.Save(context.Background(), things.Channel{ID: chid, Owner: email})
+	require.Nil(t, err, fmt.Sprintf("unexpected error: %s\n", err))
+
+	grid, err := uuidProvider.New().ID()
+	require.Nil(t, err, fmt.Sprintf("got unexpected error: %s", err))
+	_, err = groupRepo.Save(context.Background(), things.Group{ID: grid, Owner: email})
+	require.Nil(t, err, fmt.Sprintf("unexpected error: %s\n", err))
+
+	err = groupRepo.Connect(context.Background(), email, grid, []string{chid}, []string{things.ActionPublish})
+	require.Nil(t, err, fmt.Sprintf("unexpected error: %s\n", err))
+
+	nonexistentID, err := uuidProvider.New().ID()
+	require.Nil(t, err, fmt.Sprintf("got unexpected error: %s", err))
+
+	cases := []struct {
+		desc  string
+		owner string
+		grid  string
+		thid  string
+		err   error
+	}{
+		{
+			desc:  "assign existing thing to existing group",
+			owner: email,
+			grid:  grid,
+			thid:  thid,
+			err:   nil,
+		},
+		{
+			desc:  "assign assigned thing",
+			owner: email,
+			grid:  grid,
+			thid:  thid,
+			err:   things.ErrConflict,
+		},
+		{
+			desc:  "assign thing to non-existing group",
+			owner: email,
+			grid:  nonexistentID,
+			thid:  thid,
+			err:   things.ErrNotFound,
+		},
+		{
+			desc:  "assign non-existing thing",
+			owner: email,
+			grid:  grid,
+			thid:  nonexistentID,
+			err:   things.ErrNotFound,
+		},
+		{
+			desc:  "assign thing with non-existing user",
+			owner: wrongValue,
+			grid:  grid,
+			thid:  thid,
+			err:   things.ErrNotFound,
+		},
+	}
+
+	for _, tc := range cases {
+		err := groupRepo.AssignThings(context.Background(), tc.owner, tc.grid, tc.thid)
+		assert.True(t, errors.Contains(err, tc.err), fmt.Sprintf("%s: expected %s got %s\n", tc.desc, tc.err, err))
+	}
+
+	err = chanRepo.HasThingByID(context.Background(), chid, thid, things.ActionPublish)
+	assert.Nil(t, err, fmt.Sprintf("assigned thing should be connected to group channel: %s", err))
+	err = chanRepo.HasThingByID(context.Background(), chid, thid, things.ActionSubscribe)
+	assert.True(t, errors.Contains(err, things.ErrNotFound), fmt.Sprintf("assigned thing should be limited to group actions: got %s", err))
+
+	err = groupRepo.UnassignThings(context.Background(), email, grid, thid)
+	assert.Nil(t, err, fmt.Sprintf("unexpected error: %s\n", err))
+	err = chanRepo.HasThingByID(context.Background(), chid, thid, things.ActionPublish)
+	assert.True(t, errors.Contains(err, things.ErrNotFound), fmt.Sprintf("unassigned thing should be disconnected from group channel: got %s", err))
+}
+
+func TestGroupRemoval(t *testing.T) {
+	email := "group-removal@example.com"
+	dbMiddleware := postgres.NewDatabase(db)
+	thingRepo := postgres.NewThingRepository(dbMiddleware)
+	chanRepo := postgres.NewChannelRepository(dbMiddleware)
+	groupRepo := postgres.NewGroupRepository(dbMiddleware)
+
+	thid, err := uuidProvider.New().ID()
+	require.Nil(t, err, fmt.Sprintf("got unexpected error: %s", err))
+	thkey, err := uuidProvider.New().ID()
+	require.Nil(t, err, fmt.Sprintf("got unexpected error: %s", err))
+	_, err = thingRepo.Save(context.Background(), things.Thing{ID: thid, Owner: email, Key: thkey})
+	require.Nil(t, err, fmt.Sprintf("unexpected error: %s\n", err))
+
+	chid, err := uuidProvider.New().ID()
+	require.Nil(t, err, fmt.Sprintf("got unexpected error: %s", err))
+	_, err = chanRepo.Save(context.Background(), things.Channel{ID: chid, Owner: email})
+	require.Nil(t, err, fmt.Sprintf("unexpected error: %s\n", err))
+
+	parentID, err := uuidProvider.New().ID()
+	require.Nil(t, err, fmt.Sprintf("got unexpected error: %s", err))
+	_, err = groupRepo.Save(context.Background(), things.Group{ID: parentID, Owner: email})
+	require.Nil(t, err, fmt.Sprintf("unexpected error: %s\n", err))
+
+	childID, err := uuidProvider.New().ID()
+	require.Nil(t, err, fmt.Sprintf("got unexpected error: %s", err))
+	_, err = groupRepo.Save(context.Background(), things.Group{ID: childID, Owner: email, ParentID: parentID})
+	require.Nil(t, err, fmt.Sprintf("unexpected error: %s\n", err))
+
+	err = groupRepo.AssignThings(context.Background(), email, childID, thid)
+	require.Nil(t, err, fmt.Sprintf("unexpected error: %s\n", err))
+	err = groupRepo.Connect(context.Background(), email, childID, []string{chid}, things.DefaultActions)
+	require.Nil(t, err, fmt.Sprintf("unexpected error: %s\n", err))
+
+	conns, err := groupRepo.RetrieveConnections(context.Background(), email, parentID)
+	require.Nil(t, err, fmt.Sprintf("unexpected error: %s\n", err))
+	assert.Equal(t, []string{chid}, conns, fmt.Sprintf("expected connections %v got %v\n", []string{chid}, conns))
+
+	// show that the removal works the same for both existing and non-existing
+	// (removed) group
+	for i := 0; i < 2; i++ {
+		err := groupRepo.Remove(context.Background(), email, parentID)
+		require.Nil(t, err, fmt.Sprintf("#%d: failed to remove group due to: %s", i, err))
+
+		_, err = groupRepo.RetrieveByID(context.Background(), email, childID)
+		require.True(t, errors.Contains(err, things.ErrNotFound), fmt.Sprintf("#%d: expected %s got %s", i, things.ErrNotFound, err))
+	}
+
+	err = chanRepo.HasThingByID(context.Background(), chid, thid, things.ActionPublish)
+	assert.True(t, errors.Contains(err, things.ErrNotFound), fmt.Sprintf("expected %s got %s", things.ErrNotFound, err))
+}
@@ -93,6 +93,79 @@ func migrateDB(db *sqlx.DB) error {
 					 metadata TYPE JSONB using metadata::text::jsonb`,
 				},
 			},
+			{
+				Id: "things_4",
+				Up: []string{
+					`ALTER TABLE IF EXISTS connections ADD COLUMN IF NOT EXISTS
+					 actions VARCHAR(16)[] NOT NULL DEFAULT '{publish,subscribe}'`,
+				},
+				Down: []string{
+					"ALTER TABLE connections DROP COLUMN actions",
+				},
+			},
+			{
+				Id: "things_5",
+				Up: []string{
+					`CREATE TABLE IF NOT EXISTS groups (
+						id          UUID,
+						owner       VARCHAR(254),
+						parent_id   UUID,
+						name        VARCHAR(1024),
+						description VARCHAR(1024),
+						metadata    JSONB,
+						FOREIGN KEY (parent_id, owner) REFERENCES groups (id, owner) ON DELETE CASCADE ON UPDATE CASCADE,
+						PRIMARY KEY (id, owner)
+					)`,
+					`CREATE TABLE IF NOT EXISTS group_things (
+						group_id UUID,
+						thing_id UUID,
+						owner    VARCHAR(254),
+						FOREIGN KEY (group_id, owner) REFERENCES groups (id, owner) ON DELETE CASCADE ON UPDATE CASCADE,
+						FOREIGN KEY (thing_id, owner) REFERENCES things (id, owner) ON DELETE CASCADE ON UPDATE CASCADE,
+						PRIMARY KEY (group_id, thing_id, owner)
+					)`,
+					`CREATE TABLE IF NOT EXISTS group_channels (
+						group_id   UUID,
+						channel_id UUID,
+						owner      VARCHAR(254),
+						FOREIGN KEY (group_id, owner) REFERENCES groups (id, owner) ON DELETE CASCADE ON UPDATE CASCADE,
+						FOREIGN KEY (channel_id, owner) REFERENCES channels (id, owner) ON DELETE CASCADE ON UPDATE CASCADE,
+						PRIMARY KEY (group_id, channel_id, owner)
+					)`,
+					`CREATE TABLE IF NOT EXISTS group_connections (
+						group_id   UUID,
+						channel_id UUID,
+						owner      VARCHAR(254),
+						actions    VARCHAR(16)[] NOT NULL,
+						FOREIGN KEY (group_id, owner) REFERENCES groups (id, owner) ON DELETE CASCADE ON UPDATE CASCADE,
+						FOREIGN KEY (channel_id, owner) REFERENCES channels (id, owner) ON DELETE CASCADE ON UPDATE CASCADE,
+						PRIMARY KEY (group_id, channel_id, owner)
+					)`,
+				},
+				Down: []string{
+					"DROP TABLE group_connections",
+					"DROP TABLE group_channels",
+					"DROP TABLE group_things",
+					"DROP TABLE groups",
+				},
+			},
+			{
+				Id: "things_6",
+				Up: []string{
+					`ALTER TABLE IF EXISTS things ADD COLUMN IF NOT EXISTS
+					 user_group VARCHAR(36) NOT NULL DEFAULT ''`,
+					`ALTER TABLE IF EXISTS channels ADD COLUMN IF NOT EXISTS
+					 user_group VARCHAR(36) NOT NULL DEFAULT ''`,
+					`CREATE INDEX IF NOT EXISTS things_user_group_idx ON things (user_group)`,
+					`CREATE INDEX IF NOT EXISTS channels_user_group_idx ON channels (user_group)`,
+				},
+				Down: []string{
+					"DROP INDEX channels_user_group_idx",
+					"DROP INDEX things_user_group_idx",
+					"ALTER TABLE channels DROP COLUMN user_group",
+					"ALTER TABLE things DROP COLUMN user_group",
+				},
+			},
 		},
 	}
 
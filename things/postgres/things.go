@@ -11,6 +11,7 @@ import (
 
 	"github.com/gofrs/uuid"
 	"github.com/lib/pq" // required for DB access
+	mfcursor "github.com/mainflux/mainflux/pkg/cursor"
 	"github.com/mainflux/mainflux/pkg/errors"
 	"github.com/mainflux/mainflux/things"
 )
@@ -42,8 +43,8 @@ func (tr thingRepository) Save(ctx context.Context, ths ...things.Thing) ([]thin
 		return []things.Thing{}, errors.Wrap(things.ErrCreateEntity, err)
 	}
 
-	q := `INSERT INTO things (id, owner, name, key, metadata)
-		  VALUES (:id, :owner, :name, :key, :metadata);`
+	q := `INSERT INTO things (id, owner, user_group, name, key, metadata)
+		  VALUES (:id, :owner, :user_group, :name, :key, :metadata);`
 
 	for _, thing := range ths {
 		dbth, err := toDBThing(thing)
@@ -74,15 +75,22 @@ func (tr thingRepository) Save(ctx context.Context, ths ...things.Thing) ([]thin
 	return ths, nil
 }
 
-func (tr thingRepository) Update(ctx context.Context, t things.Thing) error {
-	q := `UPDATE things SET name = :name, metadata = :metadata WHERE owner = :owner AND id = :id;`
+func (tr thingRepository) Update(ctx context.Context, acc things.Access, t things.Thing) error {
+	q := fmt.Sprintf(`UPDATE things SET name = :name, metadata = :metadata
+	      WHERE id = :id AND %s;`, getAccessQuery(""))
 
 	dbth, err := toDBThing(t)
 	if err != nil {
 		return errors.Wrap(things.ErrUpdateEntity, err)
 	}
 
-	res, errdb := tr.db.NamedExecContext(ctx, q, dbth)
+	params := accessParams(acc, map[string]interface{}{
+		"id":       dbth.ID,
+		"name":     dbth.Name,
+		"metadata": dbth.Metadata,
+	})
+
+	res, errdb := tr.db.NamedExecContext(ctx, q, params)
 	if errdb != nil {
 		pqErr, ok := errdb.(*pq.Error)
 		if ok {
@@ -107,16 +115,15 @@ func (tr thingRepository) Update(ctx context.Context, t things.Thing) error {
 	return nil
 }
 
-func (tr thingRepository) UpdateKey(ctx context.Context, owner, id, key string) error {
-	q := `UPDATE things SET key = :key WHERE owner = :owner AND id = :id;`
+func (tr thingRepository) UpdateKey(ctx context.Context, acc things.Access, id, key string) error {
+	q := fmt.Sprintf(`UPDATE things SET key = :key WHERE id = :id AND %s;`, getAccessQuery(""))
 
-	dbth := dbThing{
-		ID:    id,
-		Owner: owner,
-		Key:   key,
-	}
+	params := accessParams(acc, map[string]interface{}{
+		"id":  id,
+		"key": key,
+	})
 
-	res, err := tr.db.NamedExecContext(ctx, q, dbth)
+	res, err := tr.db.NamedExecContext(ctx, q, params)
 	if err != nil {
 		pqErr, ok := err.(*pq.Error)
 		if ok {
@@ -143,19 +150,68 @@ func (tr thingRepository) UpdateKey(ctx context.Context, owner, id, key string)
 	return nil
 }
 
-func (tr thingRepository) RetrieveByID(ctx context.Context, owner, id string) (things.Thing, error) {
-	q := `SELECT name, key, metadata FROM things WHERE id = $1 AND owner = $2;`
+func (tr thingRepository) Share(ctx context.Context, owner, id, group string) error {
+	q := `UPDATE things SET user_group = :user_group WHERE id = :id AND owner = :owner;`
 
-	dbth := dbThing{
-		ID:    id,
-		Owner: owner,
+	params := map[string]interface{}{
+		"id":         id,
+		"owner":      owner,
+		"user_group": group,
 	}
 
-	if err := tr.db.QueryRowxContext(ctx, q, id, owner).StructScan(&dbth); err != nil {
+	res, err := tr.db.NamedExecContext(ctx, q, params)
+	if err != nil {
 		pqErr, ok := err.(*pq.Error)
-		if err == sql.ErrNoRows || ok && errInvalid == pqErr.Code.Name() {
-			return things.Thing{}, errors.Wrap(things.ErrNotFound, err)
+		if ok {
+			switch pqErr.Code.Name() {
+			case errInvalid, errTruncation:
+				return errors.Wrap(things.ErrMalformedEntity, err)
+			}
 		}
+
+		return errors.Wrap(things.ErrUpdateEntity, err)
+	}
+
+	cnt, err := res.RowsAffected()
+	if err != nil {
+		return errors.Wrap(things.ErrUpdateEntity, err)
+	}
+
+	if cnt == 0 {
+		return things.ErrNotFound
+	}
+
+	return nil
+}
+
+func (tr thingRepository) RetrieveByID(ctx context.Context, acc things.Access, id string) (things.Thing, error) {
+	// Verify if UUID format is valid to avoid internal Postgres error
+	if _, err := uuid.FromString(id); err != nil {
+		return things.Thing{}, errors.Wrap(things.ErrNotFound, err)
+	}
+
+	q := fmt.Sprintf(`SELECT id, owner, user_group, name, key, metadata FROM things
+	      WHERE id = :id AND %s;`, getAccessQuery(""))
+
+	params := accessParams(acc, map[string]interface{}{
+		"id": id,
+	})
+
+	rows, err := tr.db.NamedQueryContext(ctx, q, params)
+	if err != nil {
+		return things.Thing{}, errors.Wrap(things.ErrSelectEntity, err)
+	}
+	defer rows.Close()
+
+	if !rows.Next() {
+		if err := rows.Err(); err != nil {
+			return things.Thing{}, errors.Wrap(things.ErrSelectEntity, err)
+		}
+		return things.Thing{}, errors.Wrap(things.ErrNotFound, sql.ErrNoRows)
+	}
+
+	dbth := dbThing{}
+	if err := rows.StructScan(&dbth); err != nil {
 		return things.Thing{}, errors.Wrap(things.ErrSelectEntity, err)
 	}
 
@@ -176,23 +232,32 @@ func (tr thingRepository) RetrieveByKey(ctx context.Context, key string) (string
 	return id, nil
 }
 
-func (tr thingRepository) RetrieveAll(ctx context.Context, owner string, offset, limit uint64, name string, tm things.Metadata) (things.Page, error) {
+func (tr thingRepository) RetrieveAll(ctx context.Context, acc things.Access, offset, limit uint64, cursor, name string, tm things.Metadata) (things.Page, error) {
 	nq, name := getNameQuery(name)
 	m, mq, err := getMetadataQuery(tm)
 	if err != nil {
 		return things.Page{}, errors.Wrap(things.ErrSelectEntity, err)
 	}
 
-	q := fmt.Sprintf(`SELECT id, name, key, metadata FROM things
-		  WHERE owner = :owner %s%s ORDER BY id LIMIT :limit OFFSET :offset;`, mq, nq)
+	cq, after, err := getCursorQuery(cursor)
+	if err != nil {
+		return things.Page{}, errors.Wrap(things.ErrMalformedEntity, err)
+	}
+	if cq != "" {
+		offset = 0
+	}
+
+	aq := getAccessQuery("")
+	q := fmt.Sprintf(`SELECT id, owner, user_group, name, key, metadata FROM things
+		  WHERE %s%s%s%s ORDER BY id LIMIT :limit OFFSET :offset;`, aq, mq, nq, cq)
 
-	params := map[string]interface{}{
-		"owner":    owner,
+	params := accessParams(acc, map[string]interface{}{
 		"limit":    limit,
 		"offset":   offset,
 		"name":     name,
 		"metadata": m,
-	}
+		"cursor":   after,
+	})
 
 	rows, err := tr.db.NamedQueryContext(ctx, q, params)
 	if err != nil {
@@ -202,7 +267,7 @@ func (tr thingRepository) RetrieveAll(ctx context.Context, owner string, offset,
 
 	var items []things.Thing
 	for rows.Next() {
-		dbth := dbThing{Owner: owner}
+		dbth := dbThing{}
 		if err := rows.StructScan(&dbth); err != nil {
 			return things.Page{}, errors.Wrap(things.ErrSelectEntity, err)
 		}
@@ -215,9 +280,9 @@ func (tr thingRepository) RetrieveAll(ctx context.Context, owner string, offset,
 		items = append(items, th)
 	}
 
-	cq := fmt.Sprintf(`SELECT COUNT(*) FROM things WHERE owner = :owner %s%s;`, nq, mq)
+	tq := fmt.Sprintf(`SELECT COUNT(*) FROM things WHERE %s%s%s;`, aq, nq, mq)
 
-	total, err := total(ctx, tr.db, cq, params)
+	total, err := total(ctx, tr.db, tq, params)
 	if err != nil {
 		return things.Page{}, errors.Wrap(things.ErrSelectEntity, err)
 	}
@@ -231,59 +296,57 @@ func (tr thingRepository) RetrieveAll(ctx context.Context, owner string, offset,
 		},
 	}
 
+	if l := len(items); l > 0 && uint64(l) == limit {
+		page.NextCursor = mfcursor.Encode(items[l-1].ID)
+	}
+
 	return page, nil
 }
 
-func (tr thingRepository) RetrieveByChannel(ctx context.Context, owner, channel string, offset, limit uint64, connected bool) (things.Page, error) {
+func (tr thingRepository) RetrieveByChannel(ctx context.Context, acc things.Access, channel string, offset, limit uint64, connected bool) (things.Page, error) {
 	// Verify if UUID format is valid to avoid internal Postgres error
 	if _, err := uuid.FromString(channel); err != nil {
 		return things.Page{}, things.ErrNotFound
 	}
 
+	aq := getAccessQuery("th.")
 	var q, qc string
 	switch connected {
 	case true:
-		q = `SELECT id, name, key, metadata
+		q = fmt.Sprintf(`SELECT id, owner, user_group, name, key, metadata
 		        FROM things th
 		        INNER JOIN connections conn
 		        ON th.id = conn.thing_id
-		        WHERE th.owner = :owner AND conn.channel_id = :channel
+		        WHERE %s AND conn.channel_id = :channel
 		        ORDER BY th.id
 		        LIMIT :limit
-		        OFFSET :offset;`
+		        OFFSET :offset;`, aq)
 
-		qc = `SELECT COUNT(*)
+		qc = fmt.Sprintf(`SELECT COUNT(*)
 		        FROM things th
 		        INNER JOIN connections conn
 		        ON th.id = conn.thing_id
-		        WHERE th.owner = $1 AND conn.channel_id = $2;`
+		        WHERE %s AND conn.channel_id = :channel;`, aq)
 	default:
-		q = `SELECT id, name, key, metadata
+		q = fmt.Sprintf(`SELECT id, owner, user_group, name, key, metadata
 		        FROM things th
-		        WHERE th.owner = :owner AND th.id NOT IN
-		        (SELECT id FROM things th
-		          INNER JOIN connections conn
-		          ON th.id = conn.thing_id
-		          WHERE th.owner = :owner AND conn.channel_id = :channel)
+		        WHERE %s AND th.id NOT IN
+		        (SELECT thing_id FROM connections WHERE channel_id = :channel)
 		        ORDER BY th.id
 		        LIMIT :limit
-		        OFFSET :offset;`
+		        OFFSET :offset;`, aq)
 
-		qc = `SELECT COUNT(*)
+		qc = fmt.Sprintf(`SELECT COUNT(*)
 		        FROM things th
-		        WHERE th.owner = $1 AND th.id NOT IN
-		        (SELECT id FROM things th
-		          INNER JOIN connections conn
-		          ON th.id = conn.thing_id
-		          WHERE th.owner = $1 AND conn.channel_id = $2);`
+		        WHERE %s AND th.id NOT IN
+		        (SELECT thing_id FROM connections WHERE channel_id = :channel);`, aq)
 	}
 
-	params := map[string]interface{}{
-		"owner":   owner,
+	params := accessParams(acc, map[string]interface{}{
 		"channel": channel,
 		"limit":   limit,
 		"offset":  offset,
-	}
+	})
 
 	rows, err := tr.db.NamedQueryContext(ctx, q, params)
 	if err != nil {
@@ -293,7 +356,7 @@ func (tr thingRepository) RetrieveByChannel(ctx context.Context, owner, channel
 
 	var items []things.Thing
 	for rows.Next() {
-		dbth := dbThing{Owner: owner}
+		dbth := dbThing{}
 		if err := rows.StructScan(&dbth); err != nil {
 			return things.Page{}, errors.Wrap(things.ErrSelectEntity, err)
 		}
@@ -306,8 +369,8 @@ func (tr thingRepository) RetrieveByChannel(ctx context.Context, owner, channel
 		items = append(items, th)
 	}
 
-	var total uint64
-	if err := tr.db.GetContext(ctx, &total, qc, owner, channel); err != nil {
+	total, err := total(ctx, tr.db, qc, params)
+	if err != nil {
 		return things.Page{}, errors.Wrap(things.ErrSelectEntity, err)
 	}
 
@@ -321,24 +384,26 @@ func (tr thingRepository) RetrieveByChannel(ctx context.Context, owner, channel
 	}, nil
 }
 
-func (tr thingRepository) Remove(ctx context.Context, owner, id string) error {
-	dbth := dbThing{
-		ID:    id,
-		Owner: owner,
-	}
-	q := `DELETE FROM things WHERE id = :id AND owner = :owner;`
-	if _, err := tr.db.NamedExecContext(ctx, q, dbth); err != nil {
+func (tr thingRepository) Remove(ctx context.Context, acc things.Access, id string) error {
+	q := fmt.Sprintf(`DELETE FROM things WHERE id = :id AND %s;`, getAccessQuery(""))
+
+	params := accessParams(acc, map[string]interface{}{
+		"id": id,
+	})
+
+	if _, err := tr.db.NamedExecContext(ctx, q, params); err != nil {
 		return errors.Wrap(things.ErrRemoveEntity, err)
 	}
 	return nil
 }
 
 type dbThing struct {
-	ID       string `db:"id"`
-	Owner    string `db:"owner"`
-	Name     string `db:"name"`
-	Key      string `db:"key"`
-	Metadata []byte `db:"metadata"`
+	ID        string `db:"id"`
+	Owner     string `db:"owner"`
+	UserGroup string `db:"user_group"`
+	Name      string `db:"name"`
+	Key       string `db:"key"`
+	Metadata  []byte `db:"metadata"`
 }
 
 func toDBThing(th things.Thing) (dbThing, error) {
@@ -352,11 +417,12 @@ func toDBThing(th things.Thing) (dbThing, error) {
 	}
 
 	return dbThing{
-		ID:       th.ID,
-		Owner:    th.Owner,
-		Name:     th.Name,
-		Key:      th.Key,
-		Metadata: data,
+		ID:        th.ID,
+		Owner:     th.Owner,
+		UserGroup: th.UserGroup,
+		Name:      th.Name,
+		Key:       th.Key,
+		Metadata:  data,
 	}, nil
 }
 
@@ -367,10 +433,11 @@ func toThing(dbth dbThing) (things.Thing, error) {
 	}
 
 	return things.Thing{
-		ID:       dbth.ID,
-		Owner:    dbth.Owner,
-		Name:     dbth.Name,
-		Key:      dbth.Key,
-		Metadata: metadata,
+		ID:        dbth.ID,
+		Owner:     dbth.Owner,
+		UserGroup: dbth.UserGroup,
+		Name:      dbth.Name,
+		Key:       dbth.Key,
+		Metadata:  metadata,
 	}, nil
 }
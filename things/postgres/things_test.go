@@ -179,7 +179,7 @@ func TestThingUpdate(t *testing.T) {
 	}
 
 	for _, tc := range cases {
-		err := thingRepo.Update(context.Background(), tc.thing)
+		err := thingRepo.Update(context.Background(), things.Access{Owner: tc.thing.Owner}, tc.thing)
 		assert.True(t, errors.Contains(err, tc.err), fmt.Sprintf("%s: expected %s got %s\n", tc.desc, tc.err, err))
 	}
 }
@@ -264,13 +264,14 @@ func TestUpdateKey(t *testing.T) {
 	}
 
 	for _, tc := range cases {
-		err := thingRepo.UpdateKey(context.Background(), tc.owner, tc.id, tc.key)
+		err := thingRepo.UpdateKey(context.Background(), things.Access{Owner: tc.owner}, tc.id, tc.key)
 		assert.True(t, errors.Contains(err, tc.err), fmt.Sprintf("%s: expected %s got %s\n", tc.desc, tc.err, err))
 	}
 }
 
-func TestSingleThingRetrieval(t *testing.T) {
-	email := "thing-single-retrieval@example.com"
+func TestThingShare(t *testing.T) {
+	email := "thing-share@example.com"
+	userGroup := "thing-share-group"
 	dbMiddleware := postgres.NewDatabase(db)
 	thingRepo := postgres.NewThingRepository(dbMiddleware)
 
@@ -283,7 +284,6 @@ func TestSingleThingRetrieval(t *testing.T) {
 		Owner: email,
 		Key:   key,
 	}
-
 	ths, err := thingRepo.Save(context.Background(), th)
 	require.Nil(t, err, fmt.Sprintf("unexpected error: %s\n", err))
 	th.ID = ths[0].ID
@@ -291,35 +291,120 @@ func TestSingleThingRetrieval(t *testing.T) {
 	nonexistentThingID, err := uuidProvider.New().ID()
 	require.Nil(t, err, fmt.Sprintf("got unexpected error: %s", err))
 
-	cases := map[string]struct {
+	cases := []struct {
+		desc  string
 		owner string
-		ID    string
+		id    string
+		group string
 		err   error
 	}{
-		"retrieve thing with existing user": {
-			owner: th.Owner,
-			ID:    th.ID,
+		{
+			desc:  "share existing thing",
+			owner: email,
+			id:    th.ID,
+			group: userGroup,
 			err:   nil,
 		},
-		"retrieve non-existing thing with existing user": {
-			owner: th.Owner,
-			ID:    nonexistentThingID,
+		{
+			desc:  "share existing thing with non-owner",
+			owner: wrongValue,
+			id:    th.ID,
+			group: userGroup,
 			err:   things.ErrNotFound,
 		},
-		"retrieve thing with non-existing owner": {
-			owner: wrongValue,
-			ID:    th.ID,
+		{
+			desc:  "share non-existing thing",
+			owner: email,
+			id:    nonexistentThingID,
+			group: userGroup,
 			err:   things.ErrNotFound,
 		},
+		{
+			desc:  "unshare existing thing",
+			owner: email,
+			id:    th.ID,
+			group: "",
+			err:   nil,
+		},
+	}
+
+	for _, tc := range cases {
+		err := thingRepo.Share(context.Background(), tc.owner, tc.id, tc.group)
+		assert.True(t, errors.Contains(err, tc.err), fmt.Sprintf("%s: expected %s got %s\n", tc.desc, tc.err, err))
+	}
+
+	err = thingRepo.Share(context.Background(), email, th.ID, userGroup)
+	require.Nil(t, err, fmt.Sprintf("unexpected error: %s\n", err))
+	err = thingRepo.Update(context.Background(), things.Access{Owner: email}, things.Thing{ID: th.ID, Name: "shared"})
+	require.Nil(t, err, fmt.Sprintf("unexpected error: %s\n", err))
+	saved, err := thingRepo.RetrieveByID(context.Background(), things.Access{Owner: email}, th.ID)
+	require.Nil(t, err, fmt.Sprintf("unexpected error: %s\n", err))
+	assert.Equal(t, userGroup, saved.UserGroup, fmt.Sprintf("update thing: expected group %s got %s\n", userGroup, saved.UserGroup))
+}
+
+func TestSingleThingRetrieval(t *testing.T) {
+	email := "thing-single-retrieval@example.com"
+	userGroup := "thing-single-retrieval-group"
+	dbMiddleware := postgres.NewDatabase(db)
+	thingRepo := postgres.NewThingRepository(dbMiddleware)
+
+	id, err := uuidProvider.New().ID()
+	require.Nil(t, err, fmt.Sprintf("got unexpected error: %s", err))
+	key, err := uuidProvider.New().ID()
+	require.Nil(t, err, fmt.Sprintf("got unexpected error: %s", err))
+	th := things.Thing{
+		ID:        id,
+		Owner:     email,
+		UserGroup: userGroup,
+		Key:       key,
+	}
+
+	ths, err := thingRepo.Save(context.Background(), th)
+	require.Nil(t, err, fmt.Sprintf("unexpected error: %s\n", err))
+	th.ID = ths[0].ID
+
+	nonexistentThingID, err := uuidProvider.New().ID()
+	require.Nil(t, err, fmt.Sprintf("got unexpected error: %s", err))
+
+	cases := map[string]struct {
+		acc things.Access
+		ID  string
+		err error
+	}{
+		"retrieve thing with existing user": {
+			acc: things.Access{Owner: th.Owner},
+			ID:  th.ID,
+			err: nil,
+		},
+		"retrieve non-existing thing with existing user": {
+			acc: things.Access{Owner: th.Owner},
+			ID:  nonexistentThingID,
+			err: things.ErrNotFound,
+		},
+		"retrieve thing with non-existing owner": {
+			acc: things.Access{Owner: wrongValue},
+			ID:  th.ID,
+			err: things.ErrNotFound,
+		},
+		"retrieve thing shared with the user group": {
+			acc: things.Access{Owner: wrongValue, UserGroups: []string{userGroup}},
+			ID:  th.ID,
+			err: nil,
+		},
+		"retrieve thing shared with other user group": {
+			acc: things.Access{Owner: wrongValue, UserGroups: []string{wrongValue}},
+			ID:  th.ID,
+			err: things.ErrNotFound,
+		},
 		"retrieve thing with malformed ID": {
-			owner: th.Owner,
-			ID:    wrongValue,
-			err:   things.ErrNotFound,
+			acc: things.Access{Owner: th.Owner},
+			ID:  wrongValue,
+			err: things.ErrNotFound,
 		},
 	}
 
 	for desc, tc := range cases {
-		_, err := thingRepo.RetrieveByID(context.Background(), tc.owner, tc.ID)
+		_, err := thingRepo.RetrieveByID(context.Background(), tc.acc, tc.ID)
 		assert.True(t, errors.Contains(err, tc.err), fmt.Sprintf("%s: expected %s got %s\n", desc, tc.err, err))
 	}
 }
@@ -417,10 +502,14 @@ func TestMultiThingRetrieval(t *testing.T) {
 		require.Nil(t, err, fmt.Sprintf("unexpected error: %s\n", err))
 	}
 
+	first, err := thingRepo.RetrieveAll(context.Background(), things.Access{Owner: email}, 0, n/2, "", "", nil)
+	require.Nil(t, err, fmt.Sprintf("unexpected error: %s\n", err))
+
 	cases := map[string]struct {
 		owner    string
 		offset   uint64
 		limit    uint64
+		cursor   string
 		name     string
 		size     uint64
 		total    uint64
@@ -440,6 +529,14 @@ func TestMultiThingRetrieval(t *testing.T) {
 			size:   n / 2,
 			total:  n,
 		},
+		"retrieve things following the cursor": {
+			owner:  email,
+			offset: n,
+			limit:  n,
+			cursor: first.NextCursor,
+			size:   n / 2,
+			total:  n,
+		},
 		"retrieve things with non-existing owner": {
 			owner:  wrongValue,
 			offset: 0,
@@ -491,7 +588,7 @@ func TestMultiThingRetrieval(t *testing.T) {
 	}
 
 	for desc, tc := range cases {
-		page, err := thingRepo.RetrieveAll(context.Background(), tc.owner, tc.offset, tc.limit, tc.name, tc.metadata)
+		page, err := thingRepo.RetrieveAll(context.Background(), things.Access{Owner: tc.owner}, tc.offset, tc.limit, tc.cursor, tc.name, tc.metadata)
 		size := uint64(len(page.Things))
 		assert.Equal(t, tc.size, size, fmt.Sprintf("%s: expected size %d got %d\n", desc, tc.size, size))
 		assert.Equal(t, tc.total, page.Total, fmt.Sprintf("%s: expected total %d got %d\n", desc, tc.total, page.Total))
@@ -538,7 +635,7 @@ func TestMultiThingRetrievalByChannel(t *testing.T) {
 			break
 		}
 
-		err = channelRepo.Connect(context.Background(), email, []string{cid}, []string{thid})
+		err = channelRepo.Connect(context.Background(), things.Access{Owner: email}, []string{cid}, []string{thid}, things.DefaultActions)
 		require.Nil(t, err, fmt.Sprintf("unexpected error: %s", err))
 	}
 
@@ -606,7 +703,7 @@ func TestMultiThingRetrievalByChannel(t *testing.T) {
 	}
 
 	for desc, tc := range cases {
-		page, err := thingRepo.RetrieveByChannel(context.Background(), tc.owner, tc.channel, tc.offset, tc.limit, tc.connected)
+		page, err := thingRepo.RetrieveByChannel(context.Background(), things.Access{Owner: tc.owner}, tc.channel, tc.offset, tc.limit, tc.connected)
 		size := uint64(len(page.Things))
 		assert.Equal(t, tc.size, size, fmt.Sprintf("%s: expected size %d got %d\n", desc, tc.size, size))
 		assert.True(t, errors.Contains(err, tc.err), fmt.Sprintf("%s: expected no error got %d\n", desc, err))
@@ -634,10 +731,10 @@ func TestThingRemoval(t *testing.T) {
 	// show that the removal works the same for both existing and non-existing
 	// (removed) thing
 	for i := 0; i < 2; i++ {
-		err := thingRepo.Remove(context.Background(), email, thing.ID)
+		err := thingRepo.Remove(context.Background(), things.Access{Owner: email}, thing.ID)
 		require.Nil(t, err, fmt.Sprintf("#%d: failed to remove thing due to: %s", i, err))
 
-		_, err = thingRepo.RetrieveByID(context.Background(), email, thing.ID)
+		_, err = thingRepo.RetrieveByID(context.Background(), things.Access{Owner: email}, thing.ID)
 		require.True(t, errors.Contains(err, things.ErrNotFound), fmt.Sprintf("#%d: expected %s got %s", i, things.ErrNotFound, err))
 	}
 }
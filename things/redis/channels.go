@@ -25,37 +25,68 @@ func NewChannelCache(client *redis.Client) things.ChannelCache {
 	return channelCache{client: client}
 }
 
-func (cc channelCache) Connect(_ context.Context, chanID, thingID string) error {
-	cid, tid := kv(chanID, thingID)
-	if err := cc.client.SAdd(cid, tid).Err(); err != nil {
+func (cc channelCache) Connect(_ context.Context, chanID, thingID string, actions []string) error {
+	if len(actions) == 0 {
+		return nil
+	}
+
+	cid := channelKey(chanID)
+	if err := cc.client.SAdd(cid, members(thingID, actions)...).Err(); err != nil {
 		return errors.Wrap(things.ErrConnect, err)
 	}
 	return nil
 }
 
-func (cc channelCache) HasThing(_ context.Context, chanID, thingID string) bool {
-	cid, tid := kv(chanID, thingID)
-	return cc.client.SIsMember(cid, tid).Val()
+func (cc channelCache) HasThing(_ context.Context, chanID, thingID, action string) bool {
+	cid := channelKey(chanID)
+	return cc.client.SIsMember(cid, member(thingID, action)).Val()
 }
 
+// Disconnect removes all of the cached actions of the thing, regardless of
+// the actions it was connected with.
 func (cc channelCache) Disconnect(_ context.Context, chanID, thingID string) error {
-	cid, tid := kv(chanID, thingID)
-	if err := cc.client.SRem(cid, tid).Err(); err != nil {
+	cid := channelKey(chanID)
+
+	ms := []interface{}{}
+	iter := cc.client.SScan(cid, 0, member(thingID, "*"), 0).Iterator()
+	for iter.Next() {
+		ms = append(ms, iter.Val())
+	}
+	if err := iter.Err(); err != nil {
+		return errors.Wrap(things.ErrDisconnect, err)
+	}
+	if len(ms) == 0 {
+		return nil
+	}
+
+	if err := cc.client.SRem(cid, ms...).Err(); err != nil {
 		return errors.Wrap(things.ErrDisconnect, err)
 	}
 	return nil
 }
 
 func (cc channelCache) Remove(_ context.Context, chanID string) error {
-	cid, _ := kv(chanID, "0")
+	cid := channelKey(chanID)
 	if err := cc.client.Del(cid).Err(); err != nil {
 		return errors.Wrap(things.ErrRemoveEntity, err)
 	}
 	return nil
 }
 
-// Generates key-value pair
-func kv(chanID, thingID string) (string, string) {
-	cid := fmt.Sprintf("%s:%s", chanPrefix, chanID)
-	return cid, thingID
+func channelKey(chanID string) string {
+	return fmt.Sprintf("%s:%s", chanPrefix, chanID)
+}
+
+// Channel set members are composed of the thing ID and the action that
+// the thing is allowed to perform on the channel.
+func member(thingID, action string) string {
+	return fmt.Sprintf("%s:%s", thingID, action)
+}
+
+func members(thingID string, actions []string) []interface{} {
+	ms := []interface{}{}
+	for _, action := range actions {
+		ms = append(ms, member(thingID, action))
+	}
+	return ms
 }
@@ -1,6 +1,9 @@
 package redis
 
-import "encoding/json"
+import (
+	"encoding/json"
+	"strings"
+)
 
 const (
 	thingPrefix     = "thing."
@@ -14,6 +17,18 @@ const (
 	channelCreate = channelPrefix + "create"
 	channelUpdate = channelPrefix + "update"
 	channelRemove = channelPrefix + "remove"
+
+	groupPrefix     = "group."
+	groupCreate     = groupPrefix + "create"
+	groupUpdate     = groupPrefix + "update"
+	groupRemove     = groupPrefix + "remove"
+	groupAssign     = groupPrefix + "assign"
+	groupUnassign   = groupPrefix + "unassign"
+	groupConnect    = groupPrefix + "connect"
+	groupDisconnect = groupPrefix + "disconnect"
+
+	memberThing   = "thing"
+	memberChannel = "channel"
 )
 
 type event interface {
@@ -29,6 +44,12 @@ var (
 	_ event = (*removeChannelEvent)(nil)
 	_ event = (*connectThingEvent)(nil)
 	_ event = (*disconnectThingEvent)(nil)
+	_ event = (*createGroupEvent)(nil)
+	_ event = (*updateGroupEvent)(nil)
+	_ event = (*removeGroupEvent)(nil)
+	_ event = (*assignGroupEvent)(nil)
+	_ event = (*connectGroupEvent)(nil)
+	_ event = (*disconnectGroupEvent)(nil)
 )
 
 type createThingEvent struct {
@@ -172,12 +193,14 @@ func (rce removeChannelEvent) Encode() map[string]interface{} {
 type connectThingEvent struct {
 	chanID  string
 	thingID string
+	actions []string
 }
 
 func (cte connectThingEvent) Encode() map[string]interface{} {
 	return map[string]interface{}{
 		"chan_id":   cte.chanID,
 		"thing_id":  cte.thingID,
+		"actions":   strings.Join(cte.actions, ","),
 		"operation": thingConnect,
 	}
 }
@@ -194,3 +217,126 @@ func (dte disconnectThingEvent) Encode() map[string]interface{} {
 		"operation": thingDisconnect,
 	}
 }
+
+type createGroupEvent struct {
+	id       string
+	owner    string
+	parentID string
+	name     string
+	metadata map[string]interface{}
+}
+
+func (cge createGroupEvent) Encode() map[string]interface{} {
+	val := map[string]interface{}{
+		"id":        cge.id,
+		"owner":     cge.owner,
+		"operation": groupCreate,
+	}
+
+	if cge.parentID != "" {
+		val["parent_id"] = cge.parentID
+	}
+
+	if cge.name != "" {
+		val["name"] = cge.name
+	}
+
+	if cge.metadata != nil {
+		metadata, err := json.Marshal(cge.metadata)
+		if err != nil {
+			return val
+		}
+
+		val["metadata"] = string(metadata)
+	}
+
+	return val
+}
+
+type updateGroupEvent struct {
+	id       string
+	name     string
+	metadata map[string]interface{}
+}
+
+func (uge updateGroupEvent) Encode() map[string]interface{} {
+	val := map[string]interface{}{
+		"id":        uge.id,
+		"operation": groupUpdate,
+	}
+
+	if uge.name != "" {
+		val["name"] = uge.name
+	}
+
+	if uge.metadata != nil {
+		metadata, err := json.Marshal(uge.metadata)
+		if err != nil {
+			return val
+		}
+
+		val["metadata"] = string(metadata)
+	}
+
+	return val
+}
+
+type removeGroupEvent struct {
+	id string
+}
+
+func (rge removeGroupEvent) Encode() map[string]interface{} {
+	return map[string]interface{}{
+		"id":        rge.id,
+		"operation": groupRemove,
+	}
+}
+
+type assignGroupEvent struct {
+	groupID    string
+	memberType string
+	memberIDs  []string
+	unassign   bool
+}
+
+func (age assignGroupEvent) Encode() map[string]interface{} {
+	operation := groupAssign
+	if age.unassign {
+		operation = groupUnassign
+	}
+
+	return map[string]interface{}{
+		"group_id":    age.groupID,
+		"member_type": age.memberType,
+		"member_ids":  strings.Join(age.memberIDs, ","),
+		"operation":   operation,
+	}
+}
+
+type connectGroupEvent struct {
+	groupID string
+	chanIDs []string
+	actions []string
+}
+
+func (cge connectGroupEvent) Encode() map[string]interface{} {
+	return map[string]interface{}{
+		"group_id":  cge.groupID,
+		"chan_ids":  strings.Join(cge.chanIDs, ","),
+		"actions":   strings.Join(cge.actions, ","),
+		"operation": groupConnect,
+	}
+}
+
+type disconnectGroupEvent struct {
+	groupID string
+	chanID  string
+}
+
+func (dge disconnectGroupEvent) Encode() map[string]interface{} {
+	return map[string]interface{}{
+		"group_id":  dge.groupID,
+		"chan_id":   dge.chanID,
+		"operation": groupDisconnect,
+	}
+}
@@ -82,12 +82,16 @@ func (es eventStore) UpdateKey(ctx context.Context, token, id, key string) error
 	return es.svc.UpdateKey(ctx, token, id, key)
 }
 
+func (es eventStore) ShareThing(ctx context.Context, token, id, group string) error {
+	return es.svc.ShareThing(ctx, token, id, group)
+}
+
 func (es eventStore) ViewThing(ctx context.Context, token, id string) (things.Thing, error) {
 	return es.svc.ViewThing(ctx, token, id)
 }
 
-func (es eventStore) ListThings(ctx context.Context, token string, offset, limit uint64, name string, metadata things.Metadata) (things.Page, error) {
-	return es.svc.ListThings(ctx, token, offset, limit, name, metadata)
+func (es eventStore) ListThings(ctx context.Context, token string, offset, limit uint64, cursor, name string, metadata things.Metadata) (things.Page, error) {
+	return es.svc.ListThings(ctx, token, offset, limit, cursor, name, metadata)
 }
 
 func (es eventStore) ListThingsByChannel(ctx context.Context, token, id string, offset, limit uint64, connected bool) (things.Page, error) {
@@ -156,12 +160,16 @@ func (es eventStore) UpdateChannel(ctx context.Context, token string, channel th
 	return nil
 }
 
+func (es eventStore) ShareChannel(ctx context.Context, token, id, group string) error {
+	return es.svc.ShareChannel(ctx, token, id, group)
+}
+
 func (es eventStore) ViewChannel(ctx context.Context, token, id string) (things.Channel, error) {
 	return es.svc.ViewChannel(ctx, token, id)
 }
 
-func (es eventStore) ListChannels(ctx context.Context, token string, offset, limit uint64, name string, metadata things.Metadata) (things.ChannelsPage, error) {
-	return es.svc.ListChannels(ctx, token, offset, limit, name, metadata)
+func (es eventStore) ListChannels(ctx context.Context, token string, offset, limit uint64, cursor, name string, metadata things.Metadata) (things.ChannelsPage, error) {
+	return es.svc.ListChannels(ctx, token, offset, limit, cursor, name, metadata)
 }
 
 func (es eventStore) ListChannelsByThing(ctx context.Context, token, id string, offset, limit uint64, connected bool) (things.ChannelsPage, error) {
@@ -186,16 +194,21 @@ func (es eventStore) RemoveChannel(ctx context.Context, token, id string) error
 	return nil
 }
 
-func (es eventStore) Connect(ctx context.Context, token string, chIDs, thIDs []string) error {
-	if err := es.svc.Connect(ctx, token, chIDs, thIDs); err != nil {
+func (es eventStore) Connect(ctx context.Context, token string, chIDs, thIDs, actions []string) error {
+	if err := es.svc.Connect(ctx, token, chIDs, thIDs, actions); err != nil {
 		return err
 	}
 
+	if len(actions) == 0 {
+		actions = things.DefaultActions
+	}
+
 	for _, chID := range chIDs {
 		for _, thID := range thIDs {
 			event := connectThingEvent{
 				chanID:  chID,
 				thingID: thID,
+				actions: actions,
 			}
 			record := &redis.XAddArgs{
 				Stream:       streamID,
@@ -228,14 +241,185 @@ func (es eventStore) Disconnect(ctx context.Context, token, chanID, thingID stri
 	return nil
 }
 
-func (es eventStore) CanAccessByKey(ctx context.Context, chanID string, key string) (string, error) {
-	return es.svc.CanAccessByKey(ctx, chanID, key)
+func (es eventStore) CanAccessByKey(ctx context.Context, chanID, key, action string) (string, error) {
+	return es.svc.CanAccessByKey(ctx, chanID, key, action)
 }
 
-func (es eventStore) CanAccessByID(ctx context.Context, chanID string, thingID string) error {
-	return es.svc.CanAccessByID(ctx, chanID, thingID)
+func (es eventStore) CanAccessByID(ctx context.Context, chanID, thingID, action string) error {
+	return es.svc.CanAccessByID(ctx, chanID, thingID, action)
 }
 
 func (es eventStore) Identify(ctx context.Context, key string) (string, error) {
 	return es.svc.Identify(ctx, key)
 }
+
+func (es eventStore) IsChannelOwner(ctx context.Context, owner, chanID string) error {
+	return es.svc.IsChannelOwner(ctx, owner, chanID)
+}
+
+func (es eventStore) ChannelSchema(ctx context.Context, chanID string) ([]byte, error) {
+	return es.svc.ChannelSchema(ctx, chanID)
+}
+
+func (es eventStore) CreateGroup(ctx context.Context, token string, group things.Group) (things.Group, error) {
+	sg, err := es.svc.CreateGroup(ctx, token, group)
+	if err != nil {
+		return sg, err
+	}
+
+	event := createGroupEvent{
+		id:       sg.ID,
+		owner:    sg.Owner,
+		parentID: sg.ParentID,
+		name:     sg.Name,
+		metadata: sg.Metadata,
+	}
+	es.add(event)
+
+	return sg, nil
+}
+
+func (es eventStore) UpdateGroup(ctx context.Context, token string, group things.Group) error {
+	if err := es.svc.UpdateGroup(ctx, token, group); err != nil {
+		return err
+	}
+
+	event := updateGroupEvent{
+		id:       group.ID,
+		name:     group.Name,
+		metadata: group.Metadata,
+	}
+	es.add(event)
+
+	return nil
+}
+
+func (es eventStore) ViewGroup(ctx context.Context, token, id string) (things.Group, error) {
+	return es.svc.ViewGroup(ctx, token, id)
+}
+
+func (es eventStore) ListGroups(ctx context.Context, token, parentID string, offset, limit uint64, metadata things.Metadata) (things.GroupsPage, error) {
+	return es.svc.ListGroups(ctx, token, parentID, offset, limit, metadata)
+}
+
+func (es eventStore) ListThingsByGroup(ctx context.Context, token, groupID string, offset, limit uint64) (things.Page, error) {
+	return es.svc.ListThingsByGroup(ctx, token, groupID, offset, limit)
+}
+
+func (es eventStore) ListChannelsByGroup(ctx context.Context, token, groupID string, offset, limit uint64) (things.ChannelsPage, error) {
+	return es.svc.ListChannelsByGroup(ctx, token, groupID, offset, limit)
+}
+
+func (es eventStore) RemoveGroup(ctx context.Context, token, id string) error {
+	if err := es.svc.RemoveGroup(ctx, token, id); err != nil {
+		return err
+	}
+
+	es.add(removeGroupEvent{id: id})
+
+	return nil
+}
+
+func (es eventStore) AssignThings(ctx context.Context, token, groupID string, thIDs ...string) error {
+	if err := es.svc.AssignThings(ctx, token, groupID, thIDs...); err != nil {
+		return err
+	}
+
+	event := assignGroupEvent{
+		groupID:    groupID,
+		memberType: memberThing,
+		memberIDs:  thIDs,
+	}
+	es.add(event)
+
+	return nil
+}
+
+func (es eventStore) UnassignThings(ctx context.Context, token, groupID string, thIDs ...string) error {
+	if err := es.svc.UnassignThings(ctx, token, groupID, thIDs...); err != nil {
+		return err
+	}
+
+	event := assignGroupEvent{
+		groupID:    groupID,
+		memberType: memberThing,
+		memberIDs:  thIDs,
+		unassign:   true,
+	}
+	es.add(event)
+
+	return nil
+}
+
+func (es eventStore) AssignChannels(ctx context.Context, token, groupID string, chIDs ...string) error {
+	if err := es.svc.AssignChannels(ctx, token, groupID, chIDs...); err != nil {
+		return err
+	}
+
+	event := assignGroupEvent{
+		groupID:    groupID,
+		memberType: memberChannel,
+		memberIDs:  chIDs,
+	}
+	es.add(event)
+
+	return nil
+}
+
+func (es eventStore) UnassignChannels(ctx context.Context, token, groupID string, chIDs ...string) error {
+	if err := es.svc.UnassignChannels(ctx, token, groupID, chIDs...); err != nil {
+		return err
+	}
+
+	event := assignGroupEvent{
+		groupID:    groupID,
+		memberType: memberChannel,
+		memberIDs:  chIDs,
+		unassign:   true,
+	}
+	es.add(event)
+
+	return nil
+}
+
+func (es eventStore) ConnectGroup(ctx context.Context, token, groupID string, chIDs, actions []string) error {
+	if err := es.svc.ConnectGroup(ctx, token, groupID, chIDs, actions); err != nil {
+		return err
+	}
+
+	if len(actions) == 0 {
+		actions = things.DefaultActions
+	}
+
+	event := connectGroupEvent{
+		groupID: groupID,
+		chanIDs: chIDs,
+		actions: actions,
+	}
+	es.add(event)
+
+	return nil
+}
+
+func (es eventStore) DisconnectGroup(ctx context.Context, token, groupID, chanID string) error {
+	if err := es.svc.DisconnectGroup(ctx, token, groupID, chanID); err != nil {
+		return err
+	}
+
+	event := disconnectGroupEvent{
+		groupID: groupID,
+		chanID:  chanID,
+	}
+	es.add(event)
+
+	return nil
+}
+
+func (es eventStore) add(e event) {
+	record := &redis.XAddArgs{
+		Stream:       streamID,
+		MaxLenApprox: streamLen,
+		Values:       e.Encode(),
+	}
+	es.client.XAdd(record).Err()
+}
@@ -36,6 +36,9 @@ const (
 	channelCreate = channelPrefix + "create"
 	channelUpdate = channelPrefix + "update"
 	channelRemove = channelPrefix + "remove"
+
+	groupPrefix  = "group."
+	groupConnect = groupPrefix + "connect"
 )
 
 func newService(tokens map[string]string) things.Service {
@@ -43,11 +46,12 @@ func newService(tokens map[string]string) things.Service {
 	conns := make(chan mocks.Connection)
 	thingsRepo := mocks.NewThingRepository(conns)
 	channelsRepo := mocks.NewChannelRepository(thingsRepo, conns)
+	groupsRepo := mocks.NewGroupRepository(thingsRepo, channelsRepo)
 	chanCache := mocks.NewChannelCache()
 	thingCache := mocks.NewThingCache()
 	uuidProvider := uuid.NewMock()
 
-	return things.New(auth, thingsRepo, channelsRepo, chanCache, thingCache, uuidProvider)
+	return things.New(auth, mocks.NewUsersService(map[string]map[string]string{}), thingsRepo, channelsRepo, groupsRepo, chanCache, thingCache, uuidProvider)
 }
 
 func TestCreateThings(t *testing.T) {
@@ -193,8 +197,8 @@ func TestListThings(t *testing.T) {
 	require.Nil(t, err, fmt.Sprintf("unexpected error %s", err))
 
 	essvc := redis.NewEventStoreMiddleware(svc, redisClient)
-	esths, eserr := essvc.ListThings(context.Background(), token, 0, 10, "", nil)
-	ths, err := svc.ListThings(context.Background(), token, 0, 10, "", nil)
+	esths, eserr := essvc.ListThings(context.Background(), token, 0, 10, "", "", nil)
+	ths, err := svc.ListThings(context.Background(), token, 0, 10, "", "", nil)
 	assert.Equal(t, ths, esths, fmt.Sprintf("event sourcing changed service behaviour: expected %v got %v", ths, esths))
 	assert.Equal(t, err, eserr, fmt.Sprintf("event sourcing changed service behaviour: expected %v got %v", err, eserr))
 }
@@ -210,7 +214,7 @@ func TestListThingsByChannel(t *testing.T) {
 	schs, err := svc.CreateChannels(context.Background(), token, things.Channel{Name: "a"})
 	require.Nil(t, err, fmt.Sprintf("unexpected error %s", err))
 	sch := schs[0]
-	err = svc.Connect(context.Background(), token, []string{sch.ID}, []string{sth.ID})
+	err = svc.Connect(context.Background(), token, []string{sch.ID}, []string{sth.ID}, nil)
 	require.Nil(t, err, fmt.Sprintf("unexpected error %s", err))
 
 	essvc := redis.NewEventStoreMiddleware(svc, redisClient)
@@ -429,8 +433,8 @@ func TestListChannels(t *testing.T) {
 	require.Nil(t, err, fmt.Sprintf("unexpected error %s", err))
 
 	essvc := redis.NewEventStoreMiddleware(svc, redisClient)
-	eschs, eserr := essvc.ListChannels(context.Background(), token, 0, 10, "", nil)
-	chs, err := svc.ListChannels(context.Background(), token, 0, 10, "", nil)
+	eschs, eserr := essvc.ListChannels(context.Background(), token, 0, 10, "", "", nil)
+	chs, err := svc.ListChannels(context.Background(), token, 0, 10, "", "", nil)
 	assert.Equal(t, chs, eschs, fmt.Sprintf("event sourcing changed service behaviour: expected %v got %v", chs, eschs))
 	assert.Equal(t, err, eserr, fmt.Sprintf("event sourcing changed service behaviour: expected %v got %v", err, eserr))
 }
@@ -446,7 +450,7 @@ func TestListChannelsByThing(t *testing.T) {
 	schs, err := svc.CreateChannels(context.Background(), token, things.Channel{Name: "a"})
 	require.Nil(t, err, fmt.Sprintf("unexpected error %s", err))
 	sch := schs[0]
-	err = svc.Connect(context.Background(), token, []string{sch.ID}, []string{sth.ID})
+	err = svc.Connect(context.Background(), token, []string{sch.ID}, []string{sth.ID}, nil)
 	require.Nil(t, err, fmt.Sprintf("unexpected error %s", err))
 
 	essvc := redis.NewEventStoreMiddleware(svc, redisClient)
@@ -546,6 +550,7 @@ func TestConnectEvent(t *testing.T) {
 			event: map[string]interface{}{
 				"chan_id":   sch.ID,
 				"thing_id":  sth.ID,
+				"actions":   "publish,subscribe",
 				"operation": thingConnect,
 			},
 		},
@@ -561,7 +566,7 @@ func TestConnectEvent(t *testing.T) {
 
 	lastID := "0"
 	for _, tc := range cases {
-		err := svc.Connect(context.Background(), tc.key, []string{tc.chanID}, []string{tc.thingID})
+		err := svc.Connect(context.Background(), tc.key, []string{tc.chanID}, []string{tc.thingID}, nil)
 		assert.Equal(t, tc.err, err, fmt.Sprintf("%s: expected %s got %s\n", tc.desc, tc.err, err))
 
 		streams := redisClient.XRead(&r.XReadArgs{
@@ -592,7 +597,7 @@ func TestDisconnectEvent(t *testing.T) {
 	schs, err := svc.CreateChannels(context.Background(), token, things.Channel{Name: "a"})
 	require.Nil(t, err, fmt.Sprintf("unexpected error %s", err))
 	sch := schs[0]
-	err = svc.Connect(context.Background(), token, []string{sch.ID}, []string{sth.ID})
+	err = svc.Connect(context.Background(), token, []string{sch.ID}, []string{sth.ID}, nil)
 	require.Nil(t, err, fmt.Sprintf("unexpected error %s", err))
 
 	svc = redis.NewEventStoreMiddleware(svc, redisClient)
@@ -648,3 +653,69 @@ func TestDisconnectEvent(t *testing.T) {
 		assert.Equal(t, tc.event, event, fmt.Sprintf("%s: expected %v got %v\n", tc.desc, tc.event, event))
 	}
 }
+
+func TestConnectGroupEvent(t *testing.T) {
+	redisClient.FlushAll().Err()
+
+	svc := newService(map[string]string{token: email})
+	// Create group and channel that will be connected.
+	sgr, err := svc.CreateGroup(context.Background(), token, things.Group{Name: "a"})
+	require.Nil(t, err, fmt.Sprintf("unexpected error %s", err))
+	schs, err := svc.CreateChannels(context.Background(), token, things.Channel{Name: "a"})
+	require.Nil(t, err, fmt.Sprintf("unexpected error %s", err))
+	sch := schs[0]
+
+	svc = redis.NewEventStoreMiddleware(svc, redisClient)
+
+	cases := []struct {
+		desc    string
+		groupID string
+		chanID  string
+		key     string
+		err     error
+		event   map[string]interface{}
+	}{
+		{
+			desc:    "connect existing group to existing channel",
+			groupID: sgr.ID,
+			chanID:  sch.ID,
+			key:     token,
+			err:     nil,
+			event: map[string]interface{}{
+				"group_id":  sgr.ID,
+				"chan_ids":  sch.ID,
+				"actions":   "publish,subscribe",
+				"operation": groupConnect,
+			},
+		},
+		{
+			desc:    "connect non-existent group to channel",
+			groupID: strconv.FormatUint(math.MaxUint64, 10),
+			chanID:  sch.ID,
+			key:     token,
+			err:     things.ErrNotFound,
+			event:   nil,
+		},
+	}
+
+	lastID := "0"
+	for _, tc := range cases {
+		err := svc.ConnectGroup(context.Background(), tc.key, tc.groupID, []string{tc.chanID}, nil)
+		assert.True(t, errors.Contains(err, tc.err), fmt.Sprintf("%s: expected %s got %s\n", tc.desc, tc.err, err))
+
+		streams := redisClient.XRead(&r.XReadArgs{
+			Streams: []string{streamID, lastID},
+			Count:   1,
+			Block:   time.Second,
+		}).Val()
+
+		var event map[string]interface{}
+		if len(streams) > 0 && len(streams[0].Messages) > 0 {
+			msg := streams[0].Messages[0]
+			event = msg.Values
+			lastID = msg.ID
+		}
+
+		assert.Equal(t, tc.event, event, fmt.Sprintf("%s: expected %v got %v\n", tc.desc, tc.event, event))
+	}
+}
@@ -5,8 +5,10 @@ package things
 
 import (
 	"context"
+	"encoding/json"
 
 	"github.com/mainflux/mainflux/pkg/errors"
+	"github.com/mainflux/mainflux/pkg/schema"
 
 	"github.com/mainflux/mainflux"
 )
@@ -52,13 +54,19 @@ type Service interface {
 	// returned to indicate operation failure.
 	UpdateKey(ctx context.Context, token, id, key string) error
 
+	// ShareThing shares the thing identified by the provided ID with the
+	// users group, or unshares it if the group is empty. Only the owner of
+	// the thing is allowed to share it.
+	ShareThing(ctx context.Context, token, id, group string) error
+
 	// ViewThing retrieves data about the thing identified with the provided
 	// ID, that belongs to the user identified by the provided key.
 	ViewThing(ctx context.Context, token, id string) (Thing, error)
 
 	// ListThings retrieves data about subset of things that belongs to the
-	// user identified by the provided key.
-	ListThings(ctx context.Context, token string, offset, limit uint64, name string, metadata Metadata) (Page, error)
+	// user identified by the provided key. If cursor is provided, the page
+	// following the cursor is retrieved instead of the one at the offset.
+	ListThings(ctx context.Context, token string, offset, limit uint64, cursor, name string, metadata Metadata) (Page, error)
 
 	// ListThingsByChannel retrieves data about subset of things that are
 	// connected or not connected to specified channel and belong to the user identified by
@@ -76,13 +84,19 @@ type Service interface {
 	// belongs to the user identified by the provided key.
 	UpdateChannel(ctx context.Context, token string, channel Channel) error
 
+	// ShareChannel shares the channel identified by the provided ID with the
+	// users group, or unshares it if the group is empty. Only the owner of
+	// the channel is allowed to share it.
+	ShareChannel(ctx context.Context, token, id, group string) error
+
 	// ViewChannel retrieves data about the channel identified by the provided
 	// ID, that belongs to the user identified by the provided key.
 	ViewChannel(ctx context.Context, token, id string) (Channel, error)
 
 	// ListChannels retrieves data about subset of channels that belongs to the
-	// user identified by the provided key.
-	ListChannels(ctx context.Context, token string, offset, limit uint64, name string, m Metadata) (ChannelsPage, error)
+	// user identified by the provided key. If cursor is provided, the page
+	// following the cursor is retrieved instead of the one at the offset.
+	ListChannels(ctx context.Context, token string, offset, limit uint64, cursor, name string, m Metadata) (ChannelsPage, error)
 
 	// ListChannelsByThing retrieves data about subset of channels that have
 	// specified thing connected or not connected to them and belong to the user identified by
@@ -93,50 +107,125 @@ type Service interface {
 	// belongs to the user identified by the provided key.
 	RemoveChannel(ctx context.Context, token, id string) error
 
-	// Connect adds things to the channel's list of connected things.
-	Connect(ctx context.Context, token string, chIDs, thIDs []string) error
+	// Connect adds things to the channel's list of connected things. Connected
+	// things are allowed to perform only the given actions on the channel. If
+	// no actions are provided, DefaultActions are granted. Connecting already
+	// connected thing replaces its actions.
+	Connect(ctx context.Context, token string, chIDs, thIDs, actions []string) error
 
 	// Disconnect removes thing from the channel's list of connected
 	// things.
 	Disconnect(ctx context.Context, token, chanID, thingID string) error
 
 	// CanAccessByKey determines whether the channel can be accessed using the
-	// provided key and returns thing's id if access is allowed.
-	CanAccessByKey(ctx context.Context, chanID, key string) (string, error)
+	// provided key in order to perform the given action and returns thing's id
+	// if access is allowed.
+	CanAccessByKey(ctx context.Context, chanID, key, action string) (string, error)
 
 	// CanAccessByID determines whether the channel can be accessed by
-	// the given thing and returns error if it cannot.
-	CanAccessByID(ctx context.Context, chanID, thingID string) error
+	// the given thing in order to perform the given action and returns error
+	// if it cannot.
+	CanAccessByID(ctx context.Context, chanID, thingID, action string) error
 
 	// Identify returns thing ID for given thing key.
 	Identify(ctx context.Context, key string) (string, error)
+
+	// IsChannelOwner determines whether the channel identified by the
+	// provided ID belongs to the given owner and returns error if it does
+	// not.
+	IsChannelOwner(ctx context.Context, owner, chanID string) error
+
+	// ChannelSchema returns the JSON encoded schema of the channel
+	// identified by the provided ID, or nil if the channel has no schema.
+	ChannelSchema(ctx context.Context, chanID string) ([]byte, error)
+
+	// CreateGroup adds a group to the user identified by the provided key.
+	CreateGroup(ctx context.Context, token string, group Group) (Group, error)
+
+	// UpdateGroup updates the group identified by the provided ID, that
+	// belongs to the user identified by the provided key.
+	UpdateGroup(ctx context.Context, token string, group Group) error
+
+	// ViewGroup retrieves data about the group identified by the provided
+	// ID, that belongs to the user identified by the provided key.
+	ViewGroup(ctx context.Context, token, id string) (Group, error)
+
+	// ListGroups retrieves data about subset of groups that belongs to the
+	// user identified by the provided key. If parent ID is provided, only
+	// descendants of the parent group are retrieved.
+	ListGroups(ctx context.Context, token, parentID string, offset, limit uint64, m Metadata) (GroupsPage, error)
+
+	// ListThingsByGroup retrieves data about subset of things that are
+	// assigned to the specified group and belong to the user identified by
+	// the provided key.
+	ListThingsByGroup(ctx context.Context, token, groupID string, offset, limit uint64) (Page, error)
+
+	// ListChannelsByGroup retrieves data about subset of channels that are
+	// assigned to the specified group and belong to the user identified by
+	// the provided key.
+	ListChannelsByGroup(ctx context.Context, token, groupID string, offset, limit uint64) (ChannelsPage, error)
+
+	// RemoveGroup removes the group identified by the provided ID, that
+	// belongs to the user identified by the provided key, as well as all of
+	// its descendants.
+	RemoveGroup(ctx context.Context, token, id string) error
+
+	// AssignThings adds things to the group. Assigned things are connected
+	// to every channel the group is connected to.
+	AssignThings(ctx context.Context, token, groupID string, thIDs ...string) error
+
+	// UnassignThings removes things from the group. Unassigned things are
+	// disconnected from every channel the group is connected to.
+	UnassignThings(ctx context.Context, token, groupID string, thIDs ...string) error
+
+	// AssignChannels adds channels to the group.
+	AssignChannels(ctx context.Context, token, groupID string, chIDs ...string) error
+
+	// UnassignChannels removes channels from the group.
+	UnassignChannels(ctx context.Context, token, groupID string, chIDs ...string) error
+
+	// ConnectGroup connects all things assigned to the group to the
+	// channels. Connected things are allowed to perform only the given
+	// actions on the channels. If no actions are provided, DefaultActions
+	// are granted.
+	ConnectGroup(ctx context.Context, token, groupID string, chIDs, actions []string) error
+
+	// DisconnectGroup disconnects all things assigned to the group from the
+	// channel.
+	DisconnectGroup(ctx context.Context, token, groupID, chanID string) error
 }
 
-// PageMetadata contains page metadata that helps navigation.
+// PageMetadata contains page metadata that helps navigation. NextCursor
+// is set if there may be more items following the page.
 type PageMetadata struct {
-	Total  uint64
-	Offset uint64
-	Limit  uint64
-	Name   string
+	Total      uint64
+	Offset     uint64
+	Limit      uint64
+	Name       string
+	NextCursor string
 }
 
 var _ Service = (*thingsService)(nil)
 
 type thingsService struct {
 	auth         mainflux.AuthNServiceClient
+	users        mainflux.UsersServiceClient
 	things       ThingRepository
 	channels     ChannelRepository
+	groups       GroupRepository
 	channelCache ChannelCache
 	thingCache   ThingCache
 	uuidProvider mainflux.UUIDProvider
 }
 
 // New instantiates the things service implementation.
-func New(auth mainflux.AuthNServiceClient, things ThingRepository, channels ChannelRepository, ccache ChannelCache, tcache ThingCache, up mainflux.UUIDProvider) Service {
+func New(auth mainflux.AuthNServiceClient, users mainflux.UsersServiceClient, things ThingRepository, channels ChannelRepository, groups GroupRepository, ccache ChannelCache, tcache ThingCache, up mainflux.UUIDProvider) Service {
 	return &thingsService{
 		auth:         auth,
+		users:        users,
 		things:       things,
 		channels:     channels,
+		groups:       groups,
 		channelCache: ccache,
 		thingCache:   tcache,
 		uuidProvider: up,
@@ -144,18 +233,22 @@ func New(auth mainflux.AuthNServiceClient, things ThingRepository, channels Chan
 }
 
 func (ts *thingsService) CreateThings(ctx context.Context, token string, things ...Thing) ([]Thing, error) {
-	res, err := ts.auth.Identify(ctx, &mainflux.Token{Value: token})
+	acc, err := ts.access(ctx, token, mainflux.ThingsResource, mainflux.WriteAction)
 	if err != nil {
-		return []Thing{}, errors.Wrap(ErrUnauthorizedAccess, err)
+		return []Thing{}, err
 	}
 
 	for i := range things {
+		if !canShare(acc, things[i].UserGroup) {
+			return []Thing{}, ErrUnauthorizedAccess
+		}
+
 		things[i].ID, err = ts.uuidProvider.ID()
 		if err != nil {
 			return []Thing{}, errors.Wrap(ErrCreateUUID, err)
 		}
 
-		things[i].Owner = res.GetEmail()
+		things[i].Owner = acc.Owner
 
 		if things[i].Key == "" {
 			things[i].Key, err = ts.uuidProvider.ID()
@@ -169,163 +262,229 @@ func (ts *thingsService) CreateThings(ctx context.Context, token string, things
 }
 
 func (ts *thingsService) UpdateThing(ctx context.Context, token string, thing Thing) error {
-	res, err := ts.auth.Identify(ctx, &mainflux.Token{Value: token})
+	acc, err := ts.access(ctx, token, mainflux.ThingsResource, mainflux.WriteAction, thing.ID)
 	if err != nil {
-		return errors.Wrap(ErrUnauthorizedAccess, err)
+		return err
 	}
 
-	thing.Owner = res.GetEmail()
+	return ts.things.Update(ctx, acc, thing)
+}
+
+func (ts *thingsService) ShareThing(ctx context.Context, token, id, group string) error {
+	acc, err := ts.access(ctx, token, mainflux.ThingsResource, mainflux.WriteAction, id)
+	if err != nil {
+		return err
+	}
 
-	return ts.things.Update(ctx, thing)
+	if !canShare(acc, group) {
+		return ErrUnauthorizedAccess
+	}
+
+	return ts.things.Share(ctx, acc.Owner, id, group)
 }
 
 func (ts *thingsService) UpdateKey(ctx context.Context, token, id, key string) error {
-	res, err := ts.auth.Identify(ctx, &mainflux.Token{Value: token})
+	acc, err := ts.access(ctx, token, mainflux.ThingsResource, mainflux.WriteAction, id)
 	if err != nil {
-		return errors.Wrap(ErrUnauthorizedAccess, err)
+		return err
 	}
 
-	owner := res.GetEmail()
-
-	return ts.things.UpdateKey(ctx, owner, id, key)
+	return ts.things.UpdateKey(ctx, acc, id, key)
 }
 
 func (ts *thingsService) ViewThing(ctx context.Context, token, id string) (Thing, error) {
-	res, err := ts.auth.Identify(ctx, &mainflux.Token{Value: token})
+	acc, err := ts.access(ctx, token, mainflux.ThingsResource, mainflux.ReadAction, id)
 	if err != nil {
-		return Thing{}, errors.Wrap(ErrUnauthorizedAccess, err)
+		return Thing{}, err
 	}
 
-	return ts.things.RetrieveByID(ctx, res.GetEmail(), id)
+	return ts.things.RetrieveByID(ctx, acc, id)
 }
 
-func (ts *thingsService) ListThings(ctx context.Context, token string, offset, limit uint64, name string, metadata Metadata) (Page, error) {
-	res, err := ts.auth.Identify(ctx, &mainflux.Token{Value: token})
+func (ts *thingsService) ListThings(ctx context.Context, token string, offset, limit uint64, cursor, name string, metadata Metadata) (Page, error) {
+	acc, err := ts.access(ctx, token, mainflux.ThingsResource, mainflux.ReadAction)
 	if err != nil {
-		return Page{}, errors.Wrap(ErrUnauthorizedAccess, err)
+		return Page{}, err
 	}
 
-	return ts.things.RetrieveAll(ctx, res.GetEmail(), offset, limit, name, metadata)
+	return ts.things.RetrieveAll(ctx, acc, offset, limit, cursor, name, metadata)
 }
 
 func (ts *thingsService) ListThingsByChannel(ctx context.Context, token, channel string, offset, limit uint64, connected bool) (Page, error) {
-	res, err := ts.auth.Identify(ctx, &mainflux.Token{Value: token})
+	acc, err := ts.access(ctx, token, mainflux.ChannelsResource, mainflux.ReadAction, channel)
 	if err != nil {
-		return Page{}, errors.Wrap(ErrUnauthorizedAccess, err)
+		return Page{}, err
 	}
 
-	return ts.things.RetrieveByChannel(ctx, res.GetEmail(), channel, offset, limit, connected)
+	return ts.things.RetrieveByChannel(ctx, acc, channel, offset, limit, connected)
 }
 
 func (ts *thingsService) RemoveThing(ctx context.Context, token, id string) error {
-	res, err := ts.auth.Identify(ctx, &mainflux.Token{Value: token})
+	acc, err := ts.access(ctx, token, mainflux.ThingsResource, mainflux.WriteAction, id)
 	if err != nil {
-		return errors.Wrap(ErrUnauthorizedAccess, err)
+		return err
 	}
 
 	if err := ts.thingCache.Remove(ctx, id); err != nil {
 		return err
 	}
-	return ts.things.Remove(ctx, res.GetEmail(), id)
+	return ts.things.Remove(ctx, acc, id)
 }
 
 func (ts *thingsService) CreateChannels(ctx context.Context, token string, channels ...Channel) ([]Channel, error) {
-	res, err := ts.auth.Identify(ctx, &mainflux.Token{Value: token})
+	acc, err := ts.access(ctx, token, mainflux.ChannelsResource, mainflux.WriteAction)
 	if err != nil {
-		return []Channel{}, errors.Wrap(ErrUnauthorizedAccess, err)
+		return []Channel{}, err
 	}
 
 	for i := range channels {
+		if !canShare(acc, channels[i].UserGroup) {
+			return []Channel{}, ErrUnauthorizedAccess
+		}
+
+		if _, err := schema.FromMetadata(channels[i].Metadata); err != nil {
+			return []Channel{}, errors.Wrap(ErrMalformedEntity, err)
+		}
+
 		channels[i].ID, err = ts.uuidProvider.ID()
 		if err != nil {
 			return []Channel{}, errors.Wrap(ErrCreateUUID, err)
 		}
 
-		channels[i].Owner = res.GetEmail()
+		channels[i].Owner = acc.Owner
 	}
 
 	return ts.channels.Save(ctx, channels...)
 }
 
 func (ts *thingsService) UpdateChannel(ctx context.Context, token string, channel Channel) error {
-	res, err := ts.auth.Identify(ctx, &mainflux.Token{Value: token})
+	acc, err := ts.access(ctx, token, mainflux.ChannelsResource, mainflux.WriteAction, channel.ID)
 	if err != nil {
-		return errors.Wrap(ErrUnauthorizedAccess, err)
+		return err
+	}
+
+	if _, err := schema.FromMetadata(channel.Metadata); err != nil {
+		return errors.Wrap(ErrMalformedEntity, err)
 	}
 
-	channel.Owner = res.GetEmail()
-	return ts.channels.Update(ctx, channel)
+	return ts.channels.Update(ctx, acc, channel)
+}
+
+func (ts *thingsService) ShareChannel(ctx context.Context, token, id, group string) error {
+	acc, err := ts.access(ctx, token, mainflux.ChannelsResource, mainflux.WriteAction, id)
+	if err != nil {
+		return err
+	}
+
+	if !canShare(acc, group) {
+		return ErrUnauthorizedAccess
+	}
+
+	return ts.channels.Share(ctx, acc.Owner, id, group)
 }
 
 func (ts *thingsService) ViewChannel(ctx context.Context, token, id string) (Channel, error) {
-	res, err := ts.auth.Identify(ctx, &mainflux.Token{Value: token})
+	acc, err := ts.access(ctx, token, mainflux.ChannelsResource, mainflux.ReadAction, id)
 	if err != nil {
-		return Channel{}, errors.Wrap(ErrUnauthorizedAccess, err)
+		return Channel{}, err
 	}
 
-	return ts.channels.RetrieveByID(ctx, res.GetEmail(), id)
+	return ts.channels.RetrieveByID(ctx, acc, id)
 }
 
-func (ts *thingsService) ListChannels(ctx context.Context, token string, offset, limit uint64, name string, m Metadata) (ChannelsPage, error) {
-	res, err := ts.auth.Identify(ctx, &mainflux.Token{Value: token})
+func (ts *thingsService) ListChannels(ctx context.Context, token string, offset, limit uint64, cursor, name string, m Metadata) (ChannelsPage, error) {
+	acc, err := ts.access(ctx, token, mainflux.ChannelsResource, mainflux.ReadAction)
 	if err != nil {
-		return ChannelsPage{}, errors.Wrap(ErrUnauthorizedAccess, err)
+		return ChannelsPage{}, err
 	}
 
-	return ts.channels.RetrieveAll(ctx, res.GetEmail(), offset, limit, name, m)
+	return ts.channels.RetrieveAll(ctx, acc, offset, limit, cursor, name, m)
 }
 
 func (ts *thingsService) ListChannelsByThing(ctx context.Context, token, thing string, offset, limit uint64, connected bool) (ChannelsPage, error) {
-	res, err := ts.auth.Identify(ctx, &mainflux.Token{Value: token})
+	acc, err := ts.access(ctx, token, mainflux.ThingsResource, mainflux.ReadAction, thing)
 	if err != nil {
-		return ChannelsPage{}, errors.Wrap(ErrUnauthorizedAccess, err)
+		return ChannelsPage{}, err
 	}
 
-	return ts.channels.RetrieveByThing(ctx, res.GetEmail(), thing, offset, limit, connected)
+	return ts.channels.RetrieveByThing(ctx, acc, thing, offset, limit, connected)
 }
 
 func (ts *thingsService) RemoveChannel(ctx context.Context, token, id string) error {
-	res, err := ts.auth.Identify(ctx, &mainflux.Token{Value: token})
+	acc, err := ts.access(ctx, token, mainflux.ChannelsResource, mainflux.WriteAction, id)
 	if err != nil {
-		return errors.Wrap(ErrUnauthorizedAccess, err)
+		return err
 	}
 
 	if err := ts.channelCache.Remove(ctx, id); err != nil {
 		return err
 	}
 
-	return ts.channels.Remove(ctx, res.GetEmail(), id)
+	return ts.channels.Remove(ctx, acc, id)
 }
 
-func (ts *thingsService) Connect(ctx context.Context, token string, chIDs, thIDs []string) error {
-	res, err := ts.auth.Identify(ctx, &mainflux.Token{Value: token})
+func (ts *thingsService) Connect(ctx context.Context, token string, chIDs, thIDs, actions []string) error {
+	res, err := ts.identify(ctx, token, mainflux.ChannelsResource, mainflux.WriteAction, chIDs...)
+	if err != nil {
+		return err
+	}
+	if !allows(res, mainflux.ThingsResource, mainflux.WriteAction, thIDs...) {
+		return ErrUnauthorizedAccess
+	}
+
+	acc, err := ts.userAccess(ctx, res.GetEmail(), mainflux.WriteAction)
 	if err != nil {
-		return errors.Wrap(ErrUnauthorizedAccess, err)
+		return err
+	}
+
+	if len(actions) == 0 {
+		actions = DefaultActions
 	}
 
-	return ts.channels.Connect(ctx, res.GetEmail(), chIDs, thIDs)
+	if err := ts.channels.Connect(ctx, acc, chIDs, thIDs, actions); err != nil {
+		return err
+	}
+
+	// Cached actions of the reconnected things may no longer be allowed.
+	for _, chID := range chIDs {
+		for _, thID := range thIDs {
+			if err := ts.channelCache.Disconnect(ctx, chID, thID); err != nil {
+				return err
+			}
+		}
+	}
+
+	return nil
 }
 
 func (ts *thingsService) Disconnect(ctx context.Context, token, chanID, thingID string) error {
-	res, err := ts.auth.Identify(ctx, &mainflux.Token{Value: token})
+	res, err := ts.identify(ctx, token, mainflux.ChannelsResource, mainflux.WriteAction, chanID)
 	if err != nil {
-		return errors.Wrap(ErrUnauthorizedAccess, err)
+		return err
+	}
+	if !allows(res, mainflux.ThingsResource, mainflux.WriteAction, thingID) {
+		return ErrUnauthorizedAccess
+	}
+
+	acc, err := ts.userAccess(ctx, res.GetEmail(), mainflux.WriteAction)
+	if err != nil {
+		return err
 	}
 
 	if err := ts.channelCache.Disconnect(ctx, chanID, thingID); err != nil {
 		return err
 	}
 
-	return ts.channels.Disconnect(ctx, res.GetEmail(), chanID, thingID)
+	return ts.channels.Disconnect(ctx, acc, chanID, thingID)
 }
 
-func (ts *thingsService) CanAccessByKey(ctx context.Context, chanID, thingKey string) (string, error) {
-	thingID, err := ts.hasThing(ctx, chanID, thingKey)
+func (ts *thingsService) CanAccessByKey(ctx context.Context, chanID, thingKey, action string) (string, error) {
+	thingID, err := ts.hasThing(ctx, chanID, thingKey, action)
 	if err == nil {
 		return thingID, nil
 	}
 
-	thingID, err = ts.channels.HasThing(ctx, chanID, thingKey)
+	thingID, err = ts.channels.HasThing(ctx, chanID, thingKey, action)
 	if err != nil {
 		return "", err
 	}
@@ -333,22 +492,22 @@ func (ts *thingsService) CanAccessByKey(ctx context.Context, chanID, thingKey st
 	if err := ts.thingCache.Save(ctx, thingKey, thingID); err != nil {
 		return "", err
 	}
-	if err := ts.channelCache.Connect(ctx, chanID, thingID); err != nil {
+	if err := ts.channelCache.Connect(ctx, chanID, thingID, []string{action}); err != nil {
 		return "", err
 	}
 	return thingID, nil
 }
 
-func (ts *thingsService) CanAccessByID(ctx context.Context, chanID, thingID string) error {
-	if connected := ts.channelCache.HasThing(ctx, chanID, thingID); connected {
+func (ts *thingsService) CanAccessByID(ctx context.Context, chanID, thingID, action string) error {
+	if connected := ts.channelCache.HasThing(ctx, chanID, thingID, action); connected {
 		return nil
 	}
 
-	if err := ts.channels.HasThingByID(ctx, chanID, thingID); err != nil {
+	if err := ts.channels.HasThingByID(ctx, chanID, thingID, action); err != nil {
 		return err
 	}
 
-	if err := ts.channelCache.Connect(ctx, chanID, thingID); err != nil {
+	if err := ts.channelCache.Connect(ctx, chanID, thingID, []string{action}); err != nil {
 		return err
 	}
 	return nil
@@ -371,13 +530,297 @@ func (ts *thingsService) Identify(ctx context.Context, key string) (string, erro
 	return id, nil
 }
 
-func (ts *thingsService) hasThing(ctx context.Context, chanID, thingKey string) (string, error) {
+func (ts *thingsService) IsChannelOwner(ctx context.Context, owner, chanID string) error {
+	acc, err := ts.userAccess(ctx, owner, mainflux.ReadAction)
+	if err != nil {
+		return err
+	}
+
+	if _, err := ts.channels.RetrieveByID(ctx, acc, chanID); err != nil {
+		return err
+	}
+
+	return nil
+}
+
+func (ts *thingsService) ChannelSchema(ctx context.Context, chanID string) ([]byte, error) {
+	ch, err := ts.channels.RetrieveByID(ctx, Access{All: true}, chanID)
+	if err != nil {
+		return nil, err
+	}
+
+	s, ok := ch.Metadata[schema.Key]
+	if !ok {
+		return nil, nil
+	}
+
+	return json.Marshal(s)
+}
+
+func (ts *thingsService) CreateGroup(ctx context.Context, token string, group Group) (Group, error) {
+	res, err := ts.identify(ctx, token, mainflux.GroupsResource, mainflux.WriteAction)
+	if err != nil {
+		return Group{}, err
+	}
+
+	group.ID, err = ts.uuidProvider.ID()
+	if err != nil {
+		return Group{}, errors.Wrap(ErrCreateUUID, err)
+	}
+
+	group.Owner = res.GetEmail()
+
+	return ts.groups.Save(ctx, group)
+}
+
+func (ts *thingsService) UpdateGroup(ctx context.Context, token string, group Group) error {
+	res, err := ts.identify(ctx, token, mainflux.GroupsResource, mainflux.WriteAction, group.ID)
+	if err != nil {
+		return err
+	}
+
+	group.Owner = res.GetEmail()
+
+	return ts.groups.Update(ctx, group)
+}
+
+func (ts *thingsService) ViewGroup(ctx context.Context, token, id string) (Group, error) {
+	res, err := ts.identify(ctx, token, mainflux.GroupsResource, mainflux.ReadAction, id)
+	if err != nil {
+		return Group{}, err
+	}
+
+	return ts.groups.RetrieveByID(ctx, res.GetEmail(), id)
+}
+
+func (ts *thingsService) ListGroups(ctx context.Context, token, parentID string, offset, limit uint64, m Metadata) (GroupsPage, error) {
+	res, err := ts.identify(ctx, token, mainflux.GroupsResource, mainflux.ReadAction)
+	if err != nil {
+		return GroupsPage{}, err
+	}
+
+	return ts.groups.RetrieveAll(ctx, res.GetEmail(), parentID, offset, limit, m)
+}
+
+func (ts *thingsService) ListThingsByGroup(ctx context.Context, token, groupID string, offset, limit uint64) (Page, error) {
+	res, err := ts.identify(ctx, token, mainflux.GroupsResource, mainflux.ReadAction, groupID)
+	if err != nil {
+		return Page{}, err
+	}
+
+	return ts.groups.RetrieveThings(ctx, res.GetEmail(), groupID, offset, limit)
+}
+
+func (ts *thingsService) ListChannelsByGroup(ctx context.Context, token, groupID string, offset, limit uint64) (ChannelsPage, error) {
+	res, err := ts.identify(ctx, token, mainflux.GroupsResource, mainflux.ReadAction, groupID)
+	if err != nil {
+		return ChannelsPage{}, err
+	}
+
+	return ts.groups.RetrieveChannels(ctx, res.GetEmail(), groupID, offset, limit)
+}
+
+func (ts *thingsService) RemoveGroup(ctx context.Context, token, id string) error {
+	res, err := ts.identify(ctx, token, mainflux.GroupsResource, mainflux.WriteAction, id)
+	if err != nil {
+		return err
+	}
+
+	owner := res.GetEmail()
+	chIDs, err := ts.groups.RetrieveConnections(ctx, owner, id)
+	if err != nil {
+		return err
+	}
+
+	// Connections established through the group are removed along with it,
+	// so cached connections of affected channels are no longer valid.
+	for _, chID := range chIDs {
+		if err := ts.channelCache.Remove(ctx, chID); err != nil {
+			return err
+		}
+	}
+
+	return ts.groups.Remove(ctx, owner, id)
+}
+
+func (ts *thingsService) AssignThings(ctx context.Context, token, groupID string, thIDs ...string) error {
+	res, err := ts.identify(ctx, token, mainflux.GroupsResource, mainflux.WriteAction, groupID)
+	if err != nil {
+		return err
+	}
+	if !allows(res, mainflux.ThingsResource, mainflux.WriteAction, thIDs...) {
+		return ErrUnauthorizedAccess
+	}
+
+	return ts.groups.AssignThings(ctx, res.GetEmail(), groupID, thIDs...)
+}
+
+func (ts *thingsService) UnassignThings(ctx context.Context, token, groupID string, thIDs ...string) error {
+	res, err := ts.identify(ctx, token, mainflux.GroupsResource, mainflux.WriteAction, groupID)
+	if err != nil {
+		return err
+	}
+	if !allows(res, mainflux.ThingsResource, mainflux.WriteAction, thIDs...) {
+		return ErrUnauthorizedAccess
+	}
+
+	owner := res.GetEmail()
+	chIDs, err := ts.groups.RetrieveConnections(ctx, owner, groupID)
+	if err != nil {
+		return err
+	}
+
+	for _, chID := range chIDs {
+		for _, thID := range thIDs {
+			if err := ts.channelCache.Disconnect(ctx, chID, thID); err != nil {
+				return err
+			}
+		}
+	}
+
+	return ts.groups.UnassignThings(ctx, owner, groupID, thIDs...)
+}
+
+func (ts *thingsService) AssignChannels(ctx context.Context, token, groupID string, chIDs ...string) error {
+	res, err := ts.identify(ctx, token, mainflux.GroupsResource, mainflux.WriteAction, groupID)
+	if err != nil {
+		return err
+	}
+	if !allows(res, mainflux.ChannelsResource, mainflux.WriteAction, chIDs...) {
+		return ErrUnauthorizedAccess
+	}
+
+	return ts.groups.AssignChannels(ctx, res.GetEmail(), groupID, chIDs...)
+}
+
+func (ts *thingsService) UnassignChannels(ctx context.Context, token, groupID string, chIDs ...string) error {
+	res, err := ts.identify(ctx, token, mainflux.GroupsResource, mainflux.WriteAction, groupID)
+	if err != nil {
+		return err
+	}
+	if !allows(res, mainflux.ChannelsResource, mainflux.WriteAction, chIDs...) {
+		return ErrUnauthorizedAccess
+	}
+
+	return ts.groups.UnassignChannels(ctx, res.GetEmail(), groupID, chIDs...)
+}
+
+func (ts *thingsService) ConnectGroup(ctx context.Context, token, groupID string, chIDs, actions []string) error {
+	res, err := ts.identify(ctx, token, mainflux.GroupsResource, mainflux.WriteAction, groupID)
+	if err != nil {
+		return err
+	}
+	if !allows(res, mainflux.ChannelsResource, mainflux.WriteAction, chIDs...) {
+		return ErrUnauthorizedAccess
+	}
+
+	if len(actions) == 0 {
+		actions = DefaultActions
+	}
+
+	return ts.groups.Connect(ctx, res.GetEmail(), groupID, chIDs, actions)
+}
+
+func (ts *thingsService) DisconnectGroup(ctx context.Context, token, groupID, chanID string) error {
+	res, err := ts.identify(ctx, token, mainflux.GroupsResource, mainflux.WriteAction, groupID)
+	if err != nil {
+		return err
+	}
+	if !allows(res, mainflux.ChannelsResource, mainflux.WriteAction, chanID) {
+		return ErrUnauthorizedAccess
+	}
+
+	if err := ts.channelCache.Remove(ctx, chanID); err != nil {
+		return err
+	}
+
+	return ts.groups.Disconnect(ctx, res.GetEmail(), groupID, chanID)
+}
+
+// identify identifies the user by the provided token and checks whether the
+// identity is allowed to perform the action on the resources with the
+// provided IDs.
+func (ts *thingsService) identify(ctx context.Context, token, resource, action string, ids ...string) (*mainflux.UserIdentity, error) {
+	res, err := ts.auth.Identify(ctx, &mainflux.Token{Value: token})
+	if err != nil {
+		return nil, errors.Wrap(ErrUnauthorizedAccess, err)
+	}
+
+	if !allows(res, resource, action, ids...) {
+		return nil, ErrUnauthorizedAccess
+	}
+
+	return res, nil
+}
+
+// access identifies the user by the provided token, checks whether the
+// identity is allowed to perform the action on the resources with the
+// provided IDs and returns the things and channels the user can access. The
+// admin can read the things and channels of all users, while writes remain
+// limited to the admin's own.
+func (ts *thingsService) access(ctx context.Context, token, resource, action string, ids ...string) (Access, error) {
+	res, err := ts.identify(ctx, token, resource, action, ids...)
+	if err != nil {
+		return Access{}, err
+	}
+
+	acc, err := ts.userAccess(ctx, res.GetEmail(), action)
+	if err != nil {
+		return Access{}, err
+	}
+	acc.All = res.GetAdmin() && action == mainflux.ReadAction
+
+	return acc, nil
+}
+
+// userAccess returns the things and channels the user can access in order to
+// perform the action. Besides the owned ones, these are the ones shared with
+// the users groups in which the user is a viewer for reading, or an editor
+// for writing.
+func (ts *thingsService) userAccess(ctx context.Context, email, action string) (Access, error) {
+	role := viewerRole
+	if action == mainflux.WriteAction {
+		role = editorRole
+	}
+
+	res, err := ts.users.Authorize(ctx, &mainflux.AuthorizeReq{Email: email, Role: role})
+	if err != nil {
+		return Access{}, errors.Wrap(ErrUnauthorizedAccess, err)
+	}
+
+	return Access{Owner: email, UserGroups: res.GetGroups()}, nil
+}
+
+// canShare checks whether the thing or the channel can be shared with the
+// users group, which is the case if the user can write into the group.
+func canShare(acc Access, group string) bool {
+	return group == "" || acc.Shares(group)
+}
+
+// allows checks whether the identity is allowed to perform the action on all
+// of the resources with the provided IDs. If no IDs are provided, action is
+// not bound to a single resource.
+func allows(res *mainflux.UserIdentity, resource, action string, ids ...string) bool {
+	if len(ids) == 0 {
+		return res.Allows(resource, action, "")
+	}
+
+	for _, id := range ids {
+		if !res.Allows(resource, action, id) {
+			return false
+		}
+	}
+
+	return true
+}
+
+func (ts *thingsService) hasThing(ctx context.Context, chanID, thingKey, action string) (string, error) {
 	thingID, err := ts.thingCache.ID(ctx, thingKey)
 	if err != nil {
 		return "", err
 	}
 
-	if connected := ts.channelCache.HasThing(ctx, chanID, thingID); !connected {
+	if connected := ts.channelCache.HasThing(ctx, chanID, thingID, action); !connected {
 		return "", ErrEntityConnected
 	}
 	return thingID, nil
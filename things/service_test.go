@@ -9,7 +9,9 @@ import (
 	"testing"
 	"time"
 
+	"github.com/mainflux/mainflux"
 	"github.com/mainflux/mainflux/pkg/errors"
+	"github.com/mainflux/mainflux/pkg/schema"
 	"github.com/mainflux/mainflux/pkg/uuid"
 	"github.com/mainflux/mainflux/things"
 	"github.com/mainflux/mainflux/things/mocks"
@@ -27,18 +29,44 @@ const (
 var (
 	thing   = things.Thing{Name: "test"}
 	channel = things.Channel{Name: "test"}
+	group   = things.Group{Name: "test"}
+
+	validSchema = map[string]interface{}{
+		"senml": map[string]interface{}{
+			"records": []interface{}{map[string]interface{}{"name": "temperature", "unit": "Cel"}},
+		},
+	}
+	malformedSchema = map[string]interface{}{"senml": map[string]interface{}{"content_type": "text/plain"}}
 )
 
 func newService(tokens map[string]string) things.Service {
+	return newScopedService(tokens, map[string][]*mainflux.Scope{})
+}
+
+func newScopedService(tokens map[string]string, scopes map[string][]*mainflux.Scope) things.Service {
+	auth := mocks.NewScopedAuthService(tokens, scopes)
+	users := mocks.NewUsersService(map[string]map[string]string{})
+
+	return newServiceWith(auth, users)
+}
+
+func newSharedService(tokens map[string]string, roles map[string]map[string]string) things.Service {
 	auth := mocks.NewAuthService(tokens)
+	users := mocks.NewUsersService(roles)
+
+	return newServiceWith(auth, users)
+}
+
+func newServiceWith(auth mainflux.AuthNServiceClient, users mainflux.UsersServiceClient) things.Service {
 	conns := make(chan mocks.Connection)
 	thingsRepo := mocks.NewThingRepository(conns)
 	channelsRepo := mocks.NewChannelRepository(thingsRepo, conns)
+	groupsRepo := mocks.NewGroupRepository(thingsRepo, channelsRepo)
 	chanCache := mocks.NewChannelCache()
 	thingCache := mocks.NewThingCache()
 	uuidProvider := uuid.NewMock()
 
-	return things.New(auth, thingsRepo, channelsRepo, chanCache, thingCache, uuidProvider)
+	return things.New(auth, users, thingsRepo, channelsRepo, groupsRepo, chanCache, thingCache, uuidProvider)
 }
 
 func TestCreateThings(t *testing.T) {
@@ -199,10 +227,14 @@ func TestListThings(t *testing.T) {
 		require.Nil(t, err, fmt.Sprintf("unexpected error: %s\n", err))
 	}
 
+	first, err := svc.ListThings(context.Background(), token, 0, n/2, "", "", nil)
+	require.Nil(t, err, fmt.Sprintf("unexpected error: %s\n", err))
+
 	cases := map[string]struct {
 		token    string
 		offset   uint64
 		limit    uint64
+		cursor   string
 		name     string
 		size     uint64
 		metadata map[string]interface{}
@@ -250,6 +282,22 @@ func TestListThings(t *testing.T) {
 			size:   0,
 			err:    things.ErrUnauthorizedAccess,
 		},
+		"list with cursor": {
+			token:  token,
+			offset: n,
+			limit:  n,
+			cursor: first.NextCursor,
+			size:   n / 2,
+			err:    nil,
+		},
+		"list with invalid cursor": {
+			token:  token,
+			offset: 0,
+			limit:  n,
+			cursor: wrongValue,
+			size:   0,
+			err:    things.ErrMalformedEntity,
+		},
 		"list with metadata": {
 			token:    token,
 			offset:   0,
@@ -261,7 +309,7 @@ func TestListThings(t *testing.T) {
 	}
 
 	for desc, tc := range cases {
-		page, err := svc.ListThings(context.Background(), tc.token, tc.offset, tc.limit, tc.name, tc.metadata)
+		page, err := svc.ListThings(context.Background(), tc.token, tc.offset, tc.limit, tc.cursor, tc.name, tc.metadata)
 		size := uint64(len(page.Things))
 		assert.Equal(t, tc.size, size, fmt.Sprintf("%s: expected %d got %d\n", desc, tc.size, size))
 		assert.True(t, errors.Contains(err, tc.err), fmt.Sprintf("%s: expected %s got %s\n", desc, tc.err, err))
@@ -288,7 +336,7 @@ func TestListThingsByChannel(t *testing.T) {
 			break
 		}
 
-		err = svc.Connect(context.Background(), token, []string{ch.ID}, []string{th.ID})
+		err = svc.Connect(context.Background(), token, []string{ch.ID}, []string{th.ID}, nil)
 		require.Nil(t, err, fmt.Sprintf("unexpected error: %s\n", err))
 	}
 
@@ -451,6 +499,18 @@ func TestCreateChannels(t *testing.T) {
 			token:    wrongValue,
 			err:      things.ErrUnauthorizedAccess,
 		},
+		{
+			desc:     "create channel with schema",
+			channels: []things.Channel{{Name: "f", Metadata: map[string]interface{}{schema.Key: validSchema}}},
+			token:    token,
+			err:      nil,
+		},
+		{
+			desc:     "create channel with malformed schema",
+			channels: []things.Channel{{Name: "g", Metadata: map[string]interface{}{schema.Key: malformedSchema}}},
+			token:    token,
+			err:      things.ErrMalformedEntity,
+		},
 	}
 
 	for _, cc := range cases {
@@ -465,6 +525,8 @@ func TestUpdateChannel(t *testing.T) {
 	require.Nil(t, err, fmt.Sprintf("unexpected error: %s\n", err))
 	ch := chs[0]
 	other := things.Channel{ID: wrongID}
+	malformed := ch
+	malformed.Metadata = map[string]interface{}{schema.Key: malformedSchema}
 
 	cases := []struct {
 		desc    string
@@ -490,6 +552,12 @@ func TestUpdateChannel(t *testing.T) {
 			token:   token,
 			err:     things.ErrNotFound,
 		},
+		{
+			desc:    "update channel with malformed schema",
+			channel: malformed,
+			token:   token,
+			err:     things.ErrMalformedEntity,
+		},
 	}
 
 	for _, tc := range cases {
@@ -547,10 +615,15 @@ func TestListChannels(t *testing.T) {
 	for i := uint64(0); i < n; i++ {
 		svc.CreateChannels(context.Background(), token, channel)
 	}
+
+	first, err := svc.ListChannels(context.Background(), token, 0, n/2, "", "", nil)
+	require.Nil(t, err, fmt.Sprintf("unexpected error: %s\n", err))
+
 	cases := map[string]struct {
 		token    string
 		offset   uint64
 		limit    uint64
+		cursor   string
 		size     uint64
 		name     string
 		err      error
@@ -614,6 +687,22 @@ func TestListChannels(t *testing.T) {
 			name:   "wrong",
 			err:    nil,
 		},
+		"list with cursor": {
+			token:  token,
+			offset: n,
+			limit:  n,
+			cursor: first.NextCursor,
+			size:   n / 2,
+			err:    nil,
+		},
+		"list with invalid cursor": {
+			token:  token,
+			offset: 0,
+			limit:  n,
+			cursor: wrongValue,
+			size:   0,
+			err:    things.ErrMalformedEntity,
+		},
 		"list all channels with metadata": {
 			token:    token,
 			offset:   0,
@@ -625,7 +714,7 @@ func TestListChannels(t *testing.T) {
 	}
 
 	for desc, tc := range cases {
-		page, err := svc.ListChannels(context.Background(), tc.token, tc.offset, tc.limit, tc.name, tc.metadata)
+		page, err := svc.ListChannels(context.Background(), tc.token, tc.offset, tc.limit, tc.cursor, tc.name, tc.metadata)
 		size := uint64(len(page.Channels))
 		assert.Equal(t, tc.size, size, fmt.Sprintf("%s: expected %d got %d\n", desc, tc.size, size))
 		assert.True(t, errors.Contains(err, tc.err), fmt.Sprintf("%s: expected %s got %s\n", desc, tc.err, err))
@@ -652,7 +741,7 @@ func TestListChannelsByThing(t *testing.T) {
 			break
 		}
 
-		err = svc.Connect(context.Background(), token, []string{sch.ID}, []string{th.ID})
+		err = svc.Connect(context.Background(), token, []string{sch.ID}, []string{th.ID}, nil)
 		require.Nil(t, err, fmt.Sprintf("unexpected error: %s\n", err))
 	}
 
@@ -842,7 +931,7 @@ func TestConnect(t *testing.T) {
 	}
 
 	for _, tc := range cases {
-		err := svc.Connect(context.Background(), tc.token, []string{tc.chanID}, []string{tc.thingID})
+		err := svc.Connect(context.Background(), tc.token, []string{tc.chanID}, []string{tc.thingID}, nil)
 		assert.True(t, errors.Contains(err, tc.err), fmt.Sprintf("%s: expected %s got %s\n", tc.desc, tc.err, err))
 	}
 }
@@ -856,7 +945,7 @@ func TestDisconnect(t *testing.T) {
 	chs, err := svc.CreateChannels(context.Background(), token, channel)
 	require.Nil(t, err, fmt.Sprintf("unexpected error: %s\n", err))
 	ch := chs[0]
-	err = svc.Connect(context.Background(), token, []string{ch.ID}, []string{th.ID})
+	err = svc.Connect(context.Background(), token, []string{ch.ID}, []string{th.ID}, nil)
 	require.Nil(t, err, fmt.Sprintf("unexpected error: %s\n", err))
 
 	cases := []struct {
@@ -913,87 +1002,148 @@ func TestDisconnect(t *testing.T) {
 func TestCanAccessByKey(t *testing.T) {
 	svc := newService(map[string]string{token: email})
 
-	ths, err := svc.CreateThings(context.Background(), token, thing)
+	ths, err := svc.CreateThings(context.Background(), token, thing, thing)
 	require.Nil(t, err, fmt.Sprintf("unexpected error: %s\n", err))
 	chs, err := svc.CreateChannels(context.Background(), token, channel, channel)
 	require.Nil(t, err, fmt.Sprintf("unexpected error: %s\n", err))
-	err = svc.Connect(context.Background(), token, []string{chs[0].ID}, []string{ths[0].ID})
+	err = svc.Connect(context.Background(), token, []string{chs[0].ID}, []string{ths[0].ID}, nil)
+	require.Nil(t, err, fmt.Sprintf("unexpected error: %s\n", err))
+	err = svc.Connect(context.Background(), token, []string{chs[0].ID}, []string{ths[1].ID}, []string{things.ActionPublish})
 	require.Nil(t, err, fmt.Sprintf("unexpected error: %s\n", err))
 
 	cases := map[string]struct {
 		token   string
 		channel string
+		action  string
 		err     error
 	}{
 		"allowed access": {
 			token:   ths[0].Key,
 			channel: chs[0].ID,
+			action:  things.ActionSubscribe,
+			err:     nil,
+		},
+		"allowed access to publish-only connection": {
+			token:   ths[1].Key,
+			channel: chs[0].ID,
+			action:  things.ActionPublish,
 			err:     nil,
 		},
+		"subscribe over publish-only connection": {
+			token:   ths[1].Key,
+			channel: chs[0].ID,
+			action:  things.ActionSubscribe,
+			err:     things.ErrEntityConnected,
+		},
 		"non-existing thing": {
 			token:   wrongValue,
 			channel: chs[0].ID,
+			action:  things.ActionPublish,
 			err:     things.ErrNotFound,
 		},
 		"non-existing chan": {
 			token:   ths[0].Key,
 			channel: wrongValue,
+			action:  things.ActionPublish,
 			err:     things.ErrEntityConnected,
 		},
 		"non-connected channel": {
 			token:   ths[0].Key,
 			channel: chs[1].ID,
+			action:  things.ActionPublish,
 			err:     things.ErrEntityConnected,
 		},
 	}
 
 	for desc, tc := range cases {
-		_, err := svc.CanAccessByKey(context.Background(), tc.channel, tc.token)
+		_, err := svc.CanAccessByKey(context.Background(), tc.channel, tc.token, tc.action)
 		assert.True(t, errors.Contains(err, tc.err), fmt.Sprintf("%s: expected '%s' got '%s'\n", desc, tc.err, err))
 	}
 }
 
+func TestReconnect(t *testing.T) {
+	svc := newService(map[string]string{token: email})
+
+	ths, err := svc.CreateThings(context.Background(), token, thing)
+	require.Nil(t, err, fmt.Sprintf("unexpected error: %s\n", err))
+	chs, err := svc.CreateChannels(context.Background(), token, channel)
+	require.Nil(t, err, fmt.Sprintf("unexpected error: %s\n", err))
+	th, ch := ths[0], chs[0]
+
+	err = svc.Connect(context.Background(), token, []string{ch.ID}, []string{th.ID}, nil)
+	require.Nil(t, err, fmt.Sprintf("unexpected error: %s\n", err))
+	err = svc.CanAccessByID(context.Background(), ch.ID, th.ID, things.ActionSubscribe)
+	require.Nil(t, err, fmt.Sprintf("unexpected error: %s\n", err))
+
+	err = svc.Connect(context.Background(), token, []string{ch.ID}, []string{th.ID}, []string{things.ActionPublish})
+	assert.Nil(t, err, fmt.Sprintf("reconnect with publish action: unexpected error: %s\n", err))
+
+	err = svc.CanAccessByID(context.Background(), ch.ID, th.ID, things.ActionPublish)
+	assert.Nil(t, err, fmt.Sprintf("publish after reconnecting: unexpected error: %s\n", err))
+	err = svc.CanAccessByID(context.Background(), ch.ID, th.ID, things.ActionSubscribe)
+	assert.True(t, errors.Contains(err, things.ErrEntityConnected), fmt.Sprintf("subscribe after reconnecting: expected %s got %s\n", things.ErrEntityConnected, err))
+}
+
 func TestCanAccessByID(t *testing.T) {
 	svc := newService(map[string]string{token: email})
 
-	ths, err := svc.CreateThings(context.Background(), token, thing, thing)
+	ths, err := svc.CreateThings(context.Background(), token, thing, thing, thing)
 	require.Nil(t, err, fmt.Sprintf("unexpected error: %s\n", err))
 	th := ths[0]
 	chs, err := svc.CreateChannels(context.Background(), token, channel)
 	require.Nil(t, err, fmt.Sprintf("unexpected error: %s\n", err))
 	ch := chs[0]
-	err = svc.Connect(context.Background(), token, []string{ch.ID}, []string{th.ID})
+	err = svc.Connect(context.Background(), token, []string{ch.ID}, []string{th.ID}, nil)
+	require.Nil(t, err, fmt.Sprintf("unexpected error: %s\n", err))
+	err = svc.Connect(context.Background(), token, []string{ch.ID}, []string{ths[2].ID}, []string{things.ActionSubscribe})
 	require.Nil(t, err, fmt.Sprintf("unexpected error: %s\n", err))
 
 	cases := map[string]struct {
 		thingID string
 		channel string
+		action  string
 		err     error
 	}{
 		"allowed access": {
 			thingID: th.ID,
 			channel: ch.ID,
+			action:  things.ActionPublish,
+			err:     nil,
+		},
+		"allowed access to subscribe-only connection": {
+			thingID: ths[2].ID,
+			channel: ch.ID,
+			action:  things.ActionSubscribe,
 			err:     nil,
 		},
+		"publish over subscribe-only connection": {
+			thingID: ths[2].ID,
+			channel: ch.ID,
+			action:  things.ActionPublish,
+			err:     things.ErrEntityConnected,
+		},
 		"access to non-existing thing": {
 			thingID: wrongValue,
 			channel: ch.ID,
+			action:  things.ActionPublish,
 			err:     things.ErrEntityConnected,
 		},
 		"access to non-existing channel": {
 			thingID: th.ID,
 			channel: wrongID,
+			action:  things.ActionPublish,
 			err:     things.ErrEntityConnected,
 		},
 		"access to not-connected thing": {
 			thingID: ths[1].ID,
 			channel: ch.ID,
+			action:  things.ActionPublish,
 			err:     things.ErrEntityConnected,
 		},
 	}
 
 	for desc, tc := range cases {
-		err := svc.CanAccessByID(context.Background(), tc.channel, tc.thingID)
+		err := svc.CanAccessByID(context.Background(), tc.channel, tc.thingID, tc.action)
 		assert.True(t, errors.Contains(err, tc.err), fmt.Sprintf("%s: expected %s got %s\n", desc, tc.err, err))
 	}
 }
@@ -1028,3 +1178,916 @@ func TestIdentify(t *testing.T) {
 		assert.True(t, errors.Contains(err, tc.err), fmt.Sprintf("%s: expected %s got %s\n", desc, tc.err, err))
 	}
 }
+
+func TestCreateGroup(t *testing.T) {
+	svc := newService(map[string]string{token: email})
+
+	parent, err := svc.CreateGroup(context.Background(), token, group)
+	require.Nil(t, err, fmt.Sprintf("unexpected error: %s\n", err))
+
+	cases := []struct {
+		desc  string
+		group things.Group
+		token string
+		err   error
+	}{
+		{
+			desc:  "create new group",
+			group: group,
+			token: token,
+			err:   nil,
+		},
+		{
+			desc:  "create new child group",
+			group: things.Group{Name: "child", ParentID: parent.ID},
+			token: token,
+			err:   nil,
+		},
+		{
+			desc:  "create child group of non-existing group",
+			group: things.Group{Name: "child", ParentID: wrongValue},
+			token: token,
+			err:   things.ErrNotFound,
+		},
+		{
+			desc:  "create new group with wrong credentials",
+			group: group,
+			token: wrongValue,
+			err:   things.ErrUnauthorizedAccess,
+		},
+	}
+
+	for _, tc := range cases {
+		_, err := svc.CreateGroup(context.Background(), tc.token, tc.group)
+		assert.True(t, errors.Contains(err, tc.err), fmt.Sprintf("%s: expected %s got %s\n", tc.desc, tc.err, err))
+	}
+}
+
+func TestListGroups(t *testing.T) {
+	svc := newService(map[string]string{token: email})
+
+	parent, err := svc.CreateGroup(context.Background(), token, group)
+	require.Nil(t, err, fmt.Sprintf("unexpected error: %s\n", err))
+	child, err := svc.CreateGroup(context.Background(), token, things.Group{Name: "child", ParentID: parent.ID})
+	require.Nil(t, err, fmt.Sprintf("unexpected error: %s\n", err))
+	_, err = svc.CreateGroup(context.Background(), token, things.Group{Name: "grandchild", ParentID: child.ID})
+	require.Nil(t, err, fmt.Sprintf("unexpected error: %s\n", err))
+
+	cases := []struct {
+		desc     string
+		token    string
+		parentID string
+		offset   uint64
+		limit    uint64
+		size     uint64
+		err      error
+	}{
+		{
+			desc:   "list all groups",
+			token:  token,
+			offset: 0,
+			limit:  10,
+			size:   3,
+			err:    nil,
+		},
+		{
+			desc:   "list last group",
+			token:  token,
+			offset: 2,
+			limit:  10,
+			size:   1,
+			err:    nil,
+		},
+		{
+			desc:     "list descendants of the group",
+			token:    token,
+			parentID: parent.ID,
+			offset:   0,
+			limit:    10,
+			size:     2,
+			err:      nil,
+		},
+		{
+			desc:     "list descendants of the non-existing group",
+			token:    token,
+			parentID: wrongValue,
+			offset:   0,
+			limit:    10,
+			size:     0,
+			err:      nil,
+		},
+		{
+			desc:   "list groups with wrong credentials",
+			token:  wrongValue,
+			offset: 0,
+			limit:  10,
+			size:   0,
+			err:    things.ErrUnauthorizedAccess,
+		},
+	}
+
+	for _, tc := range cases {
+		page, err := svc.ListGroups(context.Background(), tc.token, tc.parentID, tc.offset, tc.limit, nil)
+		size := uint64(len(page.Groups))
+		assert.Equal(t, tc.size, size, fmt.Sprintf("%s: expected %d got %d\n", tc.desc, tc.size, size))
+		assert.True(t, errors.Contains(err, tc.err), fmt.Sprintf("%s: expected %s got %s\n", tc.desc, tc.err, err))
+	}
+}
+
+func TestAssignThings(t *testing.T) {
+	svc := newService(map[string]string{token: email})
+
+	ths, err := svc.CreateThings(context.Background(), token, thing, thing)
+	require.Nil(t, err, fmt.Sprintf("unexpected error: %s\n", err))
+	chs, err := svc.CreateChannels(context.Background(), token, channel)
+	require.Nil(t, err, fmt.Sprintf("unexpected error: %s\n", err))
+	ch := chs[0]
+	gr, err := svc.CreateGroup(context.Background(), token, group)
+	require.Nil(t, err, fmt.Sprintf("unexpected error: %s\n", err))
+	err = svc.ConnectGroup(context.Background(), token, gr.ID, []string{ch.ID}, []string{things.ActionPublish})
+	require.Nil(t, err, fmt.Sprintf("unexpected error: %s\n", err))
+
+	cases := []struct {
+		desc    string
+		token   string
+		groupID string
+		thingID string
+		err     error
+	}{
+		{
+			desc:    "assign thing to group",
+			token:   token,
+			groupID: gr.ID,
+			thingID: ths[0].ID,
+			err:     nil,
+		},
+		{
+			desc:    "assign already assigned thing to group",
+			token:   token,
+			groupID: gr.ID,
+			thingID: ths[0].ID,
+			err:     things.ErrConflict,
+		},
+		{
+			desc:    "assign thing to non-existing group",
+			token:   token,
+			groupID: wrongValue,
+			thingID: ths[1].ID,
+			err:     things.ErrNotFound,
+		},
+		{
+			desc:    "assign non-existing thing to group",
+			token:   token,
+			groupID: gr.ID,
+			thingID: wrongValue,
+			err:     things.ErrNotFound,
+		},
+		{
+			desc:    "assign thing to group with wrong credentials",
+			token:   wrongValue,
+			groupID: gr.ID,
+			thingID: ths[1].ID,
+			err:     things.ErrUnauthorizedAccess,
+		},
+	}
+
+	for _, tc := range cases {
+		err := svc.AssignThings(context.Background(), tc.token, tc.groupID, tc.thingID)
+		assert.True(t, errors.Contains(err, tc.err), fmt.Sprintf("%s: expected %s got %s\n", tc.desc, tc.err, err))
+	}
+
+	err = svc.CanAccessByID(context.Background(), ch.ID, ths[0].ID, things.ActionPublish)
+	assert.Nil(t, err, fmt.Sprintf("assigned thing should be connected to group channel: %s\n", err))
+	err = svc.CanAccessByID(context.Background(), ch.ID, ths[0].ID, things.ActionSubscribe)
+	assert.True(t, errors.Contains(err, things.ErrEntityConnected), fmt.Sprintf("assigned thing should not be allowed to subscribe: got %s\n", err))
+	err = svc.CanAccessByID(context.Background(), ch.ID, ths[1].ID, things.ActionPublish)
+	assert.True(t, errors.Contains(err, things.ErrEntityConnected), fmt.Sprintf("unassigned thing should not be connected: got %s\n", err))
+
+	page, err := svc.ListThingsByGroup(context.Background(), token, gr.ID, 0, 10)
+	require.Nil(t, err, fmt.Sprintf("unexpected error: %s\n", err))
+	assert.Equal(t, 1, len(page.Things), fmt.Sprintf("expected 1 thing in group got %d\n", len(page.Things)))
+}
+
+func TestUnassignThings(t *testing.T) {
+	svc := newService(map[string]string{token: email})
+
+	ths, err := svc.CreateThings(context.Background(), token, thing)
+	require.Nil(t, err, fmt.Sprintf("unexpected error: %s\n", err))
+	th := ths[0]
+	chs, err := svc.CreateChannels(context.Background(), token, channel)
+	require.Nil(t, err, fmt.Sprintf("unexpected error: %s\n", err))
+	ch := chs[0]
+	gr, err := svc.CreateGroup(context.Background(), token, group)
+	require.Nil(t, err, fmt.Sprintf("unexpected error: %s\n", err))
+	err = svc.AssignThings(context.Background(), token, gr.ID, th.ID)
+	require.Nil(t, err, fmt.Sprintf("unexpected error: %s\n", err))
+	err = svc.ConnectGroup(context.Background(), token, gr.ID, []string{ch.ID}, nil)
+	require.Nil(t, err, fmt.Sprintf("unexpected error: %s\n", err))
+	// Populate channel cache.
+	err = svc.CanAccessByID(context.Background(), ch.ID, th.ID, things.ActionPublish)
+	require.Nil(t, err, fmt.Sprintf("unexpected error: %s\n", err))
+
+	cases := []struct {
+		desc    string
+		token   string
+		groupID string
+		thingID string
+		err     error
+	}{
+		{
+			desc:    "unassign thing from group with wrong credentials",
+			token:   wrongValue,
+			groupID: gr.ID,
+			thingID: th.ID,
+			err:     things.ErrUnauthorizedAccess,
+		},
+		{
+			desc:    "unassign thing from group",
+			token:   token,
+			groupID: gr.ID,
+			thingID: th.ID,
+			err:     nil,
+		},
+		{
+			desc:    "unassign unassigned thing from group",
+			token:   token,
+			groupID: gr.ID,
+			thingID: th.ID,
+			err:     things.ErrNotFound,
+		},
+	}
+
+	for _, tc := range cases {
+		err := svc.UnassignThings(context.Background(), tc.token, tc.groupID, tc.thingID)
+		assert.True(t, errors.Contains(err, tc.err), fmt.Sprintf("%s: expected %s got %s\n", tc.desc, tc.err, err))
+	}
+
+	err = svc.CanAccessByID(context.Background(), ch.ID, th.ID, things.ActionPublish)
+	assert.True(t, errors.Contains(err, things.ErrEntityConnected), fmt.Sprintf("unassigned thing should be disconnected: got %s\n", err))
+}
+
+func TestConnectGroup(t *testing.T) {
+	svc := newService(map[string]string{token: email})
+
+	ths, err := svc.CreateThings(context.Background(), token, thing)
+	require.Nil(t, err, fmt.Sprintf("unexpected error: %s\n", err))
+	th := ths[0]
+	chs, err := svc.CreateChannels(context.Background(), token, channel)
+	require.Nil(t, err, fmt.Sprintf("unexpected error: %s\n", err))
+	ch := chs[0]
+	gr, err := svc.CreateGroup(context.Background(), token, group)
+	require.Nil(t, err, fmt.Sprintf("unexpected error: %s\n", err))
+	err = svc.AssignThings(context.Background(), token, gr.ID, th.ID)
+	require.Nil(t, err, fmt.Sprintf("unexpected error: %s\n", err))
+
+	cases := []struct {
+		desc    string
+		token   string
+		groupID string
+		chanID  string
+		err     error
+	}{
+		{
+			desc:    "connect group to channel",
+			token:   token,
+			groupID: gr.ID,
+			chanID:  ch.ID,
+			err:     nil,
+		},
+		{
+			desc:    "connect already connected group to channel",
+			token:   token,
+			groupID: gr.ID,
+			chanID:  ch.ID,
+			err:     things.ErrConflict,
+		},
+		{
+			desc:    "connect non-existing group to channel",
+			token:   token,
+			groupID: wrongValue,
+			chanID:  ch.ID,
+			err:     things.ErrNotFound,
+		},
+		{
+			desc:    "connect group to non-existing channel",
+			token:   token,
+			groupID: gr.ID,
+			chanID:  wrongValue,
+			err:     things.ErrNotFound,
+		},
+		{
+			desc:    "connect group to channel with wrong credentials",
+			token:   wrongValue,
+			groupID: gr.ID,
+			chanID:  ch.ID,
+			err:     things.ErrUnauthorizedAccess,
+		},
+	}
+
+	for _, tc := range cases {
+		err := svc.ConnectGroup(context.Background(), tc.token, tc.groupID, []string{tc.chanID}, nil)
+		assert.True(t, errors.Contains(err, tc.err), fmt.Sprintf("%s: expected %s got %s\n", tc.desc, tc.err, err))
+	}
+
+	for _, action := range things.DefaultActions {
+		err := svc.CanAccessByID(context.Background(), ch.ID, th.ID, action)
+		assert.Nil(t, err, fmt.Sprintf("group thing should be allowed to %s: %s\n", action, err))
+	}
+}
+
+func TestDisconnectGroup(t *testing.T) {
+	svc := newService(map[string]string{token: email})
+
+	ths, err := svc.CreateThings(context.Background(), token, thing)
+	require.Nil(t, err, fmt.Sprintf("unexpected error: %s\n", err))
+	th := ths[0]
+	chs, err := svc.CreateChannels(context.Background(), token, channel)
+	require.Nil(t, err, fmt.Sprintf("unexpected error: %s\n", err))
+	ch := chs[0]
+	gr, err := svc.CreateGroup(context.Background(), token, group)
+	require.Nil(t, err, fmt.Sprintf("unexpected error: %s\n", err))
+	err = svc.AssignThings(context.Background(), token, gr.ID, th.ID)
+	require.Nil(t, err, fmt.Sprintf("unexpected error: %s\n", err))
+	err = svc.ConnectGroup(context.Background(), token, gr.ID, []string{ch.ID}, nil)
+	require.Nil(t, err, fmt.Sprintf("unexpected error: %s\n", err))
+	// Populate channel cache.
+	err = svc.CanAccessByID(context.Background(), ch.ID, th.ID, things.ActionPublish)
+	require.Nil(t, err, fmt.Sprintf("unexpected error: %s\n", err))
+
+	cases := []struct {
+		desc    string
+		token   string
+		groupID string
+		chanID  string
+		err     error
+	}{
+		{
+			desc:    "disconnect group with wrong credentials",
+			token:   wrongValue,
+			groupID: gr.ID,
+			chanID:  ch.ID,
+			err:     things.ErrUnauthorizedAccess,
+		},
+		{
+			desc:    "disconnect connected group",
+			token:   token,
+			groupID: gr.ID,
+			chanID:  ch.ID,
+			err:     nil,
+		},
+		{
+			desc:    "disconnect disconnected group",
+			token:   token,
+			groupID: gr.ID,
+			chanID:  ch.ID,
+			err:     things.ErrNotFound,
+		},
+	}
+
+	for _, tc := range cases {
+		err := svc.DisconnectGroup(context.Background(), tc.token, tc.groupID, tc.chanID)
+		assert.True(t, errors.Contains(err, tc.err), fmt.Sprintf("%s: expected %s got %s\n", tc.desc, tc.err, err))
+	}
+
+	err = svc.CanAccessByID(context.Background(), ch.ID, th.ID, things.ActionPublish)
+	assert.True(t, errors.Contains(err, things.ErrEntityConnected), fmt.Sprintf("group thing should be disconnected: got %s\n", err))
+}
+
+func TestRemoveGroup(t *testing.T) {
+	svc := newService(map[string]string{token: email})
+
+	ths, err := svc.CreateThings(context.Background(), token, thing)
+	require.Nil(t, err, fmt.Sprintf("unexpected error: %s\n", err))
+	th := ths[0]
+	chs, err := svc.CreateChannels(context.Background(), token, channel)
+	require.Nil(t, err, fmt.Sprintf("unexpected error: %s\n", err))
+	ch := chs[0]
+	parent, err := svc.CreateGroup(context.Background(), token, group)
+	require.Nil(t, err, fmt.Sprintf("unexpected error: %s\n", err))
+	child, err := svc.CreateGroup(context.Background(), token, things.Group{Name: "child", ParentID: parent.ID})
+	require.Nil(t, err, fmt.Sprintf("unexpected error: %s\n", err))
+	err = svc.AssignThings(context.Background(), token, child.ID, th.ID)
+	require.Nil(t, err, fmt.Sprintf("unexpected error: %s\n", err))
+	err = svc.ConnectGroup(context.Background(), token, child.ID, []string{ch.ID}, nil)
+	require.Nil(t, err, fmt.Sprintf("unexpected error: %s\n", err))
+	// Populate channel cache.
+	err = svc.CanAccessByID(context.Background(), ch.ID, th.ID, things.ActionPublish)
+	require.Nil(t, err, fmt.Sprintf("unexpected error: %s\n", err))
+
+	cases := []struct {
+		desc  string
+		token string
+		id    string
+		err   error
+	}{
+		{
+			desc:  "remove group with wrong credentials",
+			token: wrongValue,
+			id:    parent.ID,
+			err:   things.ErrUnauthorizedAccess,
+		},
+		{
+			desc:  "remove existing group",
+			token: token,
+			id:    parent.ID,
+			err:   nil,
+		},
+		{
+			desc:  "remove removed group",
+			token: token,
+			id:    parent.ID,
+			err:   nil,
+		},
+	}
+
+	for _, tc := range cases {
+		err := svc.RemoveGroup(context.Background(), tc.token, tc.id)
+		assert.True(t, errors.Contains(err, tc.err), fmt.Sprintf("%s: expected %s got %s\n", tc.desc, tc.err, err))
+	}
+
+	_, err = svc.ViewGroup(context.Background(), token, child.ID)
+	assert.True(t, errors.Contains(err, things.ErrNotFound), fmt.Sprintf("child group should be removed: got %s\n", err))
+	err = svc.CanAccessByID(context.Background(), ch.ID, th.ID, things.ActionPublish)
+	assert.True(t, errors.Contains(err, things.ErrEntityConnected), fmt.Sprintf("group thing should be disconnected: got %s\n", err))
+}
+
+func TestScopedAccess(t *testing.T) {
+	const (
+		thingsReadToken  = "things-read"
+		thingWriteToken  = "thing-write"
+		channelOnlyToken = "channel-only"
+		connectToken     = "connect"
+	)
+	tokens := map[string]string{
+		token:            email,
+		thingsReadToken:  email,
+		thingWriteToken:  email,
+		channelOnlyToken: email,
+		connectToken:     email,
+	}
+	scopes := map[string][]*mainflux.Scope{}
+	svc := newScopedService(tokens, scopes)
+
+	ths, err := svc.CreateThings(context.Background(), token, thing, thing)
+	require.Nil(t, err, fmt.Sprintf("unexpected error: %s\n", err))
+	th, other := ths[0], ths[1]
+	chs, err := svc.CreateChannels(context.Background(), token, channel)
+	require.Nil(t, err, fmt.Sprintf("unexpected error: %s\n", err))
+	ch := chs[0]
+
+	scopes[thingsReadToken] = []*mainflux.Scope{
+		{Resource: mainflux.ThingsResource, Action: mainflux.ReadAction},
+	}
+	scopes[thingWriteToken] = []*mainflux.Scope{
+		{Resource: mainflux.ThingsResource, Action: mainflux.WriteAction, Ids: []string{th.ID}},
+	}
+	scopes[channelOnlyToken] = []*mainflux.Scope{
+		{Resource: mainflux.ChannelsResource, Action: mainflux.WriteAction, Ids: []string{ch.ID}},
+	}
+	scopes[connectToken] = []*mainflux.Scope{
+		{Resource: mainflux.ChannelsResource, Action: mainflux.WriteAction, Ids: []string{ch.ID}},
+		{Resource: mainflux.ThingsResource, Action: mainflux.WriteAction, Ids: []string{th.ID}},
+	}
+
+	cases := []struct {
+		desc string
+		op   func() error
+		err  error
+	}{
+		{
+			desc: "view thing with read scope",
+			op: func() error {
+				_, err := svc.ViewThing(context.Background(), thingsReadToken, th.ID)
+				return err
+			},
+			err: nil,
+		},
+		{
+			desc: "list things with read scope",
+			op: func() error {
+				_, err := svc.ListThings(context.Background(), thingsReadToken, 0, 10, "", "", nil)
+				return err
+			},
+			err: nil,
+		},
+		{
+			desc: "view channel with things read scope",
+			op: func() error {
+				_, err := svc.ViewChannel(context.Background(), thingsReadToken, ch.ID)
+				return err
+			},
+			err: things.ErrUnauthorizedAccess,
+		},
+		{
+			desc: "update thing with read scope",
+			op: func() error {
+				return svc.UpdateThing(context.Background(), thingsReadToken, th)
+			},
+			err: things.ErrUnauthorizedAccess,
+		},
+		{
+			desc: "update thing with write scope limited to the thing",
+			op: func() error {
+				return svc.UpdateThing(context.Background(), thingWriteToken, th)
+			},
+			err: nil,
+		},
+		{
+			desc: "update other thing with write scope limited to the thing",
+			op: func() error {
+				return svc.UpdateThing(context.Background(), thingWriteToken, other)
+			},
+			err: things.ErrUnauthorizedAccess,
+		},
+		{
+			desc: "create things with write scope limited to the thing",
+			op: func() error {
+				_, err := svc.CreateThings(context.Background(), thingWriteToken, thing)
+				return err
+			},
+			err: things.ErrUnauthorizedAccess,
+		},
+		{
+			desc: "connect thing with channels write scope only",
+			op: func() error {
+				return svc.Connect(context.Background(), channelOnlyToken, []string{ch.ID}, []string{th.ID}, nil)
+			},
+			err: things.ErrUnauthorizedAccess,
+		},
+		{
+			desc: "connect thing with channels and things write scope",
+			op: func() error {
+				return svc.Connect(context.Background(), connectToken, []string{ch.ID}, []string{th.ID}, nil)
+			},
+			err: nil,
+		},
+		{
+			desc: "connect other thing with channels and things write scope",
+			op: func() error {
+				return svc.Connect(context.Background(), connectToken, []string{ch.ID}, []string{other.ID}, nil)
+			},
+			err: things.ErrUnauthorizedAccess,
+		},
+	}
+
+	for _, tc := range cases {
+		err := tc.op()
+		assert.True(t, errors.Contains(err, tc.err), fmt.Sprintf("%s: expected %s got %s\n", tc.desc, tc.err, err))
+	}
+}
+
+func TestSharedAccess(t *testing.T) {
+	const (
+		userGroup    = "user-group"
+		otherGroup   = "other-group"
+		viewerToken  = "viewer"
+		editorToken  = "editor"
+		viewerEmail  = "viewer@example.com"
+		editorEmail  = "editor@example.com"
+		outsiderMail = "outsider@example.com"
+	)
+	tokens := map[string]string{
+		token:        email,
+		viewerToken:  viewerEmail,
+		editorToken:  editorEmail,
+		outsiderMail: outsiderMail,
+	}
+	roles := map[string]map[string]string{
+		email:       {userGroup: "admin"},
+		viewerEmail: {userGroup: "viewer"},
+		editorEmail: {userGroup: "editor", otherGroup: "viewer"},
+	}
+	svc := newSharedService(tokens, roles)
+
+	shared := things.Thing{Name: "shared", UserGroup: userGroup}
+	ths, err := svc.CreateThings(context.Background(), token, shared)
+	require.Nil(t, err, fmt.Sprintf("unexpected error: %s\n", err))
+	th := ths[0]
+	chs, err := svc.CreateChannels(context.Background(), token, things.Channel{Name: "shared", UserGroup: userGroup})
+	require.Nil(t, err, fmt.Sprintf("unexpected error: %s\n", err))
+	ch := chs[0]
+
+	cases := []struct {
+		desc string
+		op   func() error
+		err  error
+	}{
+		{
+			desc: "view shared thing as viewer",
+			op: func() error {
+				_, err := svc.ViewThing(context.Background(), viewerToken, th.ID)
+				return err
+			},
+			err: nil,
+		},
+		{
+			desc: "view shared channel as viewer",
+			op: func() error {
+				_, err := svc.ViewChannel(context.Background(), viewerToken, ch.ID)
+				return err
+			},
+			err: nil,
+		},
+		{
+			desc: "list shared things as viewer",
+			op: func() error {
+				page, err := svc.ListThings(context.Background(), viewerToken, 0, 10, "", "", nil)
+				if err == nil && len(page.Things) != 1 {
+					return fmt.Errorf("expected 1 thing got %d", len(page.Things))
+				}
+				return err
+			},
+			err: nil,
+		},
+		{
+			desc: "view shared thing as outsider",
+			op: func() error {
+				_, err := svc.ViewThing(context.Background(), outsiderMail, th.ID)
+				return err
+			},
+			err: things.ErrNotFound,
+		},
+		{
+			desc: "update shared thing as viewer",
+			op: func() error {
+				return svc.UpdateThing(context.Background(), viewerToken, th)
+			},
+			err: things.ErrNotFound,
+		},
+		{
+			desc: "update shared thing as editor",
+			op: func() error {
+				return svc.UpdateThing(context.Background(), editorToken, th)
+			},
+			err: nil,
+		},
+		{
+			desc: "connect shared thing and channel as editor",
+			op: func() error {
+				return svc.Connect(context.Background(), editorToken, []string{ch.ID}, []string{th.ID}, nil)
+			},
+			err: nil,
+		},
+		{
+			desc: "share thing with group the user can only view",
+			op: func() error {
+				_, err := svc.CreateThings(context.Background(), editorToken, things.Thing{Name: "other", UserGroup: otherGroup})
+				return err
+			},
+			err: things.ErrUnauthorizedAccess,
+		},
+		{
+			desc: "share channel with group the user is not member of",
+			op: func() error {
+				_, err := svc.CreateChannels(context.Background(), outsiderMail, things.Channel{Name: "other", UserGroup: userGroup})
+				return err
+			},
+			err: things.ErrUnauthorizedAccess,
+		},
+		{
+			desc: "update shared thing without group",
+			op: func() error {
+				if err := svc.UpdateThing(context.Background(), editorToken, things.Thing{ID: th.ID, Name: "renamed"}); err != nil {
+					return err
+				}
+				_, err := svc.ViewThing(context.Background(), viewerToken, th.ID)
+				return err
+			},
+			err: nil,
+		},
+		{
+			desc: "unshare shared thing as editor",
+			op: func() error {
+				return svc.ShareThing(context.Background(), editorToken, th.ID, "")
+			},
+			err: things.ErrNotFound,
+		},
+		{
+			desc: "share channel with group the owner can't write into",
+			op: func() error {
+				return svc.ShareChannel(context.Background(), token, ch.ID, otherGroup)
+			},
+			err: things.ErrUnauthorizedAccess,
+		},
+		{
+			desc: "unshare channel as owner",
+			op: func() error {
+				if err := svc.ShareChannel(context.Background(), token, ch.ID, ""); err != nil {
+					return err
+				}
+				_, err := svc.ViewChannel(context.Background(), viewerToken, ch.ID)
+				return err
+			},
+			err: things.ErrNotFound,
+		},
+		{
+			desc: "share channel as owner",
+			op: func() error {
+				if err := svc.ShareChannel(context.Background(), token, ch.ID, userGroup); err != nil {
+					return err
+				}
+				_, err := svc.ViewChannel(context.Background(), viewerToken, ch.ID)
+				return err
+			},
+			err: nil,
+		},
+		{
+			desc: "remove shared thing as viewer",
+			op: func() error {
+				if err := svc.RemoveThing(context.Background(), viewerToken, th.ID); err != nil {
+					return err
+				}
+				_, err := svc.ViewThing(context.Background(), token, th.ID)
+				return err
+			},
+			err: nil,
+		},
+	}
+
+	for _, tc := range cases {
+		err := tc.op()
+		assert.True(t, errors.Contains(err, tc.err), fmt.Sprintf("%s: expected %s got %s\n", tc.desc, tc.err, err))
+	}
+}
+
+func TestIsChannelOwner(t *testing.T) {
+	svc := newService(map[string]string{token: email})
+	chs, err := svc.CreateChannels(context.Background(), token, channel)
+	require.Nil(t, err, fmt.Sprintf("unexpected error: %s\n", err))
+	ch := chs[0]
+
+	cases := []struct {
+		desc   string
+		owner  string
+		chanID string
+		err    error
+	}{
+		{
+			desc:   "check owner of the channel",
+			owner:  email,
+			chanID: ch.ID,
+			err:    nil,
+		},
+		{
+			desc:   "check other user",
+			owner:  "other@example.com",
+			chanID: ch.ID,
+			err:    things.ErrNotFound,
+		},
+		{
+			desc:   "check non-existing channel",
+			owner:  email,
+			chanID: wrongValue,
+			err:    things.ErrNotFound,
+		},
+	}
+
+	for _, tc := range cases {
+		err := svc.IsChannelOwner(context.Background(), tc.owner, tc.chanID)
+		assert.True(t, errors.Contains(err, tc.err), fmt.Sprintf("%s: expected %s got %s\n", tc.desc, tc.err, err))
+	}
+}
+
+func TestChannelSchema(t *testing.T) {
+	svc := newService(map[string]string{token: email})
+	chs, err := svc.CreateChannels(context.Background(), token,
+		things.Channel{Name: "plain"},
+		things.Channel{Name: "validated", Metadata: map[string]interface{}{schema.Key: validSchema}},
+	)
+	require.Nil(t, err, fmt.Sprintf("unexpected error: %s\n", err))
+
+	cases := []struct {
+		desc   string
+		chanID string
+		found  bool
+		err    error
+	}{
+		{
+			desc:   "retrieve schema of channel without schema",
+			chanID: chs[0].ID,
+			found:  false,
+			err:    nil,
+		},
+		{
+			desc:   "retrieve schema of channel with schema",
+			chanID: chs[1].ID,
+			found:  true,
+			err:    nil,
+		},
+		{
+			desc:   "retrieve schema of non-existing channel",
+			chanID: wrongValue,
+			found:  false,
+			err:    things.ErrNotFound,
+		},
+	}
+
+	for _, tc := range cases {
+		data, err := svc.ChannelSchema(context.Background(), tc.chanID)
+		assert.True(t, errors.Contains(err, tc.err), fmt.Sprintf("%s: expected %s got %s\n", tc.desc, tc.err, err))
+		if !tc.found {
+			assert.Empty(t, data, fmt.Sprintf("%s: expected no schema got %s\n", tc.desc, data))
+			continue
+		}
+		_, err = schema.Parse(data)
+		assert.Nil(t, err, fmt.Sprintf("%s: unexpected error parsing schema: %s\n", tc.desc, err))
+	}
+}
+
+func TestAdminAccess(t *testing.T) {
+	const (
+		adminToken = "admin"
+		adminEmail = "admin@example.com"
+	)
+	auth := mocks.NewAdminAuthService(map[string]string{token: email, adminToken: adminEmail}, adminEmail)
+	svc := newServiceWith(auth, mocks.NewUsersService(map[string]map[string]string{}))
+
+	ths, err := svc.CreateThings(context.Background(), token, thing)
+	require.Nil(t, err, fmt.Sprintf("unexpected error: %s\n", err))
+	th := ths[0]
+	chs, err := svc.CreateChannels(context.Background(), token, channel)
+	require.Nil(t, err, fmt.Sprintf("unexpected error: %s\n", err))
+	ch := chs[0]
+	err = svc.Connect(context.Background(), token, []string{ch.ID}, []string{th.ID}, nil)
+	require.Nil(t, err, fmt.Sprintf("unexpected error: %s\n", err))
+
+	cases := []struct {
+		desc string
+		op   func() error
+		err  error
+	}{
+		{
+			desc: "view thing of other user as admin",
+			op: func() error {
+				_, err := svc.ViewThing(context.Background(), adminToken, th.ID)
+				return err
+			},
+			err: nil,
+		},
+		{
+			desc: "view channel of other user as admin",
+			op: func() error {
+				_, err := svc.ViewChannel(context.Background(), adminToken, ch.ID)
+				return err
+			},
+			err: nil,
+		},
+		{
+			desc: "list things of all users as admin",
+			op: func() error {
+				page, err := svc.ListThings(context.Background(), adminToken, 0, 10, "", "", nil)
+				if err == nil && len(page.Things) != 1 {
+					return fmt.Errorf("expected 1 thing got %d", len(page.Things))
+				}
+				return err
+			},
+			err: nil,
+		},
+		{
+			desc: "list channels of all users as admin",
+			op: func() error {
+				page, err := svc.ListChannels(context.Background(), adminToken, 0, 10, "", "", nil)
+				if err == nil && len(page.Channels) != 1 {
+					return fmt.Errorf("expected 1 channel got %d", len(page.Channels))
+				}
+				return err
+			},
+			err: nil,
+		},
+		{
+			desc: "update thing of other user as admin",
+			op: func() error {
+				return svc.UpdateThing(context.Background(), adminToken, th)
+			},
+			err: things.ErrNotFound,
+		},
+		{
+			desc: "remove channel of other user as admin",
+			op: func() error {
+				if err := svc.RemoveChannel(context.Background(), adminToken, ch.ID); err != nil {
+					return err
+				}
+				_, err := svc.ViewChannel(context.Background(), token, ch.ID)
+				return err
+			},
+			err: nil,
+		},
+		{
+			desc: "view thing of admin as other user",
+			op: func() error {
+				ths, err := svc.CreateThings(context.Background(), adminToken, thing)
+				if err != nil {
+					return err
+				}
+				_, err = svc.ViewThing(context.Background(), token, ths[0].ID)
+				return err
+			},
+			err: things.ErrNotFound,
+		},
+	}
+
+	for _, tc := range cases {
+		err := tc.op()
+		assert.True(t, errors.Contains(err, tc.err), fmt.Sprintf("%s: expected %s got %s\n", tc.desc, tc.err, err))
+	}
+}
@@ -34,14 +34,45 @@ var (
 // describing of particular thing or channel.
 type Metadata map[string]interface{}
 
+// Users group roles required to read and to write the things and channels
+// shared with the group.
+const (
+	viewerRole = "viewer"
+	editorRole = "editor"
+)
+
+// Access specifies the things and channels the user is allowed to access.
+// These are the ones owned by the user, together with the ones shared with
+// the listed users groups. If All is set, all of the things and channels
+// are accessible, which is the case when the platform admin reads them.
+type Access struct {
+	Owner      string
+	UserGroups []string
+	All        bool
+}
+
+// Shares checks whether the users group is one of the groups the access is
+// shared with.
+func (acc Access) Shares(group string) bool {
+	for _, g := range acc.UserGroups {
+		if g == group {
+			return true
+		}
+	}
+
+	return false
+}
+
 // Thing represents a Mainflux thing. Each thing is owned by one user, and
-// it is assigned with the unique identifier and (temporary) access key.
+// it is assigned with the unique identifier and (temporary) access key. The
+// thing may be shared with the members of the users group.
 type Thing struct {
-	ID       string
-	Owner    string
-	Name     string
-	Key      string
-	Metadata Metadata
+	ID        string
+	Owner     string
+	UserGroup string
+	Name      string
+	Key       string
+	Metadata  Metadata
 }
 
 // Page contains page related metadata as well as list of things that
@@ -58,31 +89,37 @@ type ThingRepository interface {
 	// error response.
 	Save(ctx context.Context, ths ...Thing) ([]Thing, error)
 
-	// Update performs an update to the existing thing. A non-nil error is
-	// returned to indicate operation failure.
-	Update(ctx context.Context, t Thing) error
+	// Update performs an update to the existing accessible thing. A non-nil
+	// error is returned to indicate operation failure.
+	Update(ctx context.Context, acc Access, t Thing) error
+
+	// UpdateKey updates key value of the existing accessible thing. A non-nil
+	// error is returned to indicate operation failure.
+	UpdateKey(ctx context.Context, acc Access, id, key string) error
 
-	// UpdateKey updates key value of the existing thing. A non-nil error is
-	// returned to indicate operation failure.
-	UpdateKey(ctx context.Context, owner, id, key string) error
+	// Share shares the thing owned by the owner with the users group, or
+	// unshares it if the group is empty. A non-nil error is returned to
+	// indicate operation failure.
+	Share(ctx context.Context, owner, id, group string) error
 
-	// RetrieveByID retrieves the thing having the provided identifier, that is owned
-	// by the specified user.
-	RetrieveByID(ctx context.Context, owner, id string) (Thing, error)
+	// RetrieveByID retrieves the accessible thing having the provided
+	// identifier.
+	RetrieveByID(ctx context.Context, acc Access, id string) (Thing, error)
 
 	// RetrieveByKey returns thing ID for given thing key.
 	RetrieveByKey(ctx context.Context, key string) (string, error)
 
-	// RetrieveAll retrieves the subset of things owned by the specified user.
-	RetrieveAll(ctx context.Context, owner string, offset, limit uint64, name string, m Metadata) (Page, error)
+	// RetrieveAll retrieves the subset of accessible things. If cursor is
+	// provided, things following the cursor are retrieved and the offset is
+	// ignored.
+	RetrieveAll(ctx context.Context, acc Access, offset, limit uint64, cursor, name string, m Metadata) (Page, error)
 
-	// RetrieveByChannel retrieves the subset of things owned by the specified
-	// user and connected or not connected to specified channel.
-	RetrieveByChannel(ctx context.Context, owner, channel string, offset, limit uint64, connected bool) (Page, error)
+	// RetrieveByChannel retrieves the subset of accessible things connected
+	// or not connected to specified channel.
+	RetrieveByChannel(ctx context.Context, acc Access, channel string, offset, limit uint64, connected bool) (Page, error)
 
-	// Remove removes the thing having the provided identifier, that is owned
-	// by the specified user.
-	Remove(ctx context.Context, owner, id string) error
+	// Remove removes the accessible thing having the provided identifier.
+	Remove(ctx context.Context, acc Access, id string) error
 }
 
 // ThingCache contains thing caching interface.
@@ -14,6 +14,7 @@ const (
 	saveChannelOp             = "save_channel"
 	saveChannelsOp            = "save_channels"
 	updateChannelOp           = "update_channel"
+	shareChannelOp            = "share_channel"
 	retrieveChannelByIDOp     = "retrieve_channel_by_id"
 	retrieveAllChannelsOp     = "retrieve_all_channels"
 	retrieveChannelsByThingOp = "retrieve_channels_by_thing"
@@ -51,76 +52,84 @@ func (crm channelRepositoryMiddleware) Save(ctx context.Context, channels ...thi
 	return crm.repo.Save(ctx, channels...)
 }
 
-func (crm channelRepositoryMiddleware) Update(ctx context.Context, ch things.Channel) error {
+func (crm channelRepositoryMiddleware) Update(ctx context.Context, acc things.Access, ch things.Channel) error {
 	span := createSpan(ctx, crm.tracer, updateChannelOp)
 	defer span.Finish()
 	ctx = opentracing.ContextWithSpan(ctx, span)
 
-	return crm.repo.Update(ctx, ch)
+	return crm.repo.Update(ctx, acc, ch)
 }
 
-func (crm channelRepositoryMiddleware) RetrieveByID(ctx context.Context, owner, id string) (things.Channel, error) {
+func (crm channelRepositoryMiddleware) Share(ctx context.Context, owner, id, group string) error {
+	span := createSpan(ctx, crm.tracer, shareChannelOp)
+	defer span.Finish()
+	ctx = opentracing.ContextWithSpan(ctx, span)
+
+	return crm.repo.Share(ctx, owner, id, group)
+}
+
+func (crm channelRepositoryMiddleware) RetrieveByID(ctx context.Context, acc things.Access, id string) (things.Channel, error) {
 	span := createSpan(ctx, crm.tracer, retrieveChannelByIDOp)
 	defer span.Finish()
 	ctx = opentracing.ContextWithSpan(ctx, span)
 
-	return crm.repo.RetrieveByID(ctx, owner, id)
+	return crm.repo.RetrieveByID(ctx, acc, id)
 }
 
-func (crm channelRepositoryMiddleware) RetrieveAll(ctx context.Context, owner string, offset, limit uint64, name string, metadata things.Metadata) (things.ChannelsPage, error) {
+func (crm channelRepositoryMiddleware) RetrieveAll(ctx context.Context, acc things.Access, offset, limit uint64, cursor, name string, metadata things.Metadata) (things.ChannelsPage, error) {
 	span := createSpan(ctx, crm.tracer, retrieveAllChannelsOp)
 	defer span.Finish()
 	ctx = opentracing.ContextWithSpan(ctx, span)
 
-	return crm.repo.RetrieveAll(ctx, owner, offset, limit, name, metadata)
+	return crm.repo.RetrieveAll(ctx, acc, offset, limit, cursor, name, metadata)
 }
 
-func (crm channelRepositoryMiddleware) RetrieveByThing(ctx context.Context, owner, thing string, offset, limit uint64, connected bool) (things.ChannelsPage, error) {
+func (crm channelRepositoryMiddleware) RetrieveByThing(ctx context.Context, acc things.Access, thing string, offset, limit uint64, connected bool) (things.ChannelsPage, error) {
 	span := createSpan(ctx, crm.tracer, retrieveChannelsByThingOp)
 	defer span.Finish()
 	ctx = opentracing.ContextWithSpan(ctx, span)
 
-	return crm.repo.RetrieveByThing(ctx, owner, thing, offset, limit, connected)
+	return crm.repo.RetrieveByThing(ctx, acc, thing, offset, limit, connected)
 }
 
-func (crm channelRepositoryMiddleware) Remove(ctx context.Context, owner, id string) error {
+func (crm channelRepositoryMiddleware) Remove(ctx context.Context, acc things.Access, id string) error {
 	span := createSpan(ctx, crm.tracer, removeChannelOp)
 	defer span.Finish()
 	ctx = opentracing.ContextWithSpan(ctx, span)
 
-	return crm.repo.Remove(ctx, owner, id)
+	return crm.repo.Remove(ctx, acc, id)
 }
 
-func (crm channelRepositoryMiddleware) Connect(ctx context.Context, owner string, chIDs, thIDs []string) error {
+func (crm channelRepositoryMiddleware) Connect(ctx context.Context, acc things.Access, chIDs, thIDs, actions []string) error {
 	span := createSpan(ctx, crm.tracer, connectOp)
 	defer span.Finish()
 	ctx = opentracing.ContextWithSpan(ctx, span)
 
-	return crm.repo.Connect(ctx, owner, chIDs, thIDs)
+	return crm.repo.Connect(ctx, acc, chIDs, thIDs, actions)
 }
 
-func (crm channelRepositoryMiddleware) Disconnect(ctx context.Context, owner, chanID, thingID string) error {
+func (crm channelRepositoryMiddleware) Disconnect(ctx context.Context, acc things.Access, chanID, thingID string) error {
 	span := createSpan(ctx, crm.tracer, disconnectOp)
 	defer span.Finish()
 	ctx = opentracing.ContextWithSpan(ctx, span)
 
-	return crm.repo.Disconnect(ctx, owner, chanID, thingID)
+	return crm.repo.Disconnect(ctx, acc, chanID, thingID)
 }
 
-func (crm channelRepositoryMiddleware) HasThing(ctx context.Context, chanID, key string) (string, error) {
+func (crm channelRepositoryMiddleware) HasThing(ctx context.Context, chanID, key, action string) (string, error) {
 	span := createSpan(ctx, crm.tracer, hasThingOp)
 	defer span.Finish()
 	ctx = opentracing.ContextWithSpan(ctx, span)
 
-	return crm.repo.HasThing(ctx, chanID, key)
+	return crm.repo.HasThing(ctx, chanID, key, action)
 }
 
-func (crm channelRepositoryMiddleware) HasThingByID(ctx context.Context, chanID, thingID string) error {
+func (crm channelRepositoryMiddleware) HasThingByID(ctx context.Context, chanID, thingID, action string) error {
 	span := createSpan(ctx, crm.tracer, hasThingByIDOp)
 	defer span.Finish()
 	ctx = opentracing.ContextWithSpan(ctx, span)
 
-	return crm.repo.HasThingByID(ctx, chanID, thingID)
+	return crm.repo.HasThingByID(ctx, chanID, thingID, action)
 }
 
 type channelCacheMiddleware struct {
@@ -137,20 +146,20 @@ func ChannelCacheMiddleware(tracer opentracing.Tracer, cache things.ChannelCache
 	}
 }
 
-func (ccm channelCacheMiddleware) Connect(ctx context.Context, chanID, thingID string) error {
+func (ccm channelCacheMiddleware) Connect(ctx context.Context, chanID, thingID string, actions []string) error {
 	span := createSpan(ctx, ccm.tracer, connectOp)
 	defer span.Finish()
 	ctx = opentracing.ContextWithSpan(ctx, span)
 
-	return ccm.cache.Connect(ctx, chanID, thingID)
+	return ccm.cache.Connect(ctx, chanID, thingID, actions)
 }
 
-func (ccm channelCacheMiddleware) HasThing(ctx context.Context, chanID, thingID string) bool {
+func (ccm channelCacheMiddleware) HasThing(ctx context.Context, chanID, thingID, action string) bool {
 	span := createSpan(ctx, ccm.tracer, hasThingOp)
 	defer span.Finish()
 	ctx = opentracing.ContextWithSpan(ctx, span)
 
-	return ccm.cache.HasThing(ctx, chanID, thingID)
+	return ccm.cache.HasThing(ctx, chanID, thingID, action)
 }
 
 func (ccm channelCacheMiddleware) Disconnect(ctx context.Context, chanID, thingID string) error {
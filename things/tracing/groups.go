@@ -0,0 +1,156 @@
+// Copyright (c) Mainflux
+// SPDX-License-Identifier: Apache-2.0
+
+package tracing
+
+import (
+	"context"
+
+	"github.com/mainflux/mainflux/things"
+	opentracing "github.com/opentracing/opentracing-go"
+)
+
+const (
+	saveGroupOp                = "save_group"
+	updateGroupOp              = "update_group"
+	retrieveGroupByIDOp        = "retrieve_group_by_id"
+	retrieveAllGroupsOp        = "retrieve_all_groups"
+	retrieveThingsByGroupOp    = "retrieve_things_by_group"
+	retrieveChannelsByGroupOp  = "retrieve_channels_by_group"
+	retrieveGroupConnectionsOp = "retrieve_group_connections"
+	removeGroupOp              = "remove_group"
+	assignThingsOp             = "assign_things"
+	unassignThingsOp           = "unassign_things"
+	assignChannelsOp           = "assign_channels"
+	unassignChannelsOp         = "unassign_channels"
+	connectGroupOp             = "connect_group"
+	disconnectGroupOp          = "disconnect_group"
+)
+
+var _ things.GroupRepository = (*groupRepositoryMiddleware)(nil)
+
+type groupRepositoryMiddleware struct {
+	tracer opentracing.Tracer
+	repo   things.GroupRepository
+}
+
+// GroupRepositoryMiddleware tracks request and their latency, and adds spans
+// to context.
+func GroupRepositoryMiddleware(tracer opentracing.Tracer, repo things.GroupRepository) things.GroupRepository {
+	return groupRepositoryMiddleware{
+		tracer: tracer,
+		repo:   repo,
+	}
+}
+
+func (grm groupRepositoryMiddleware) Save(ctx context.Context, g things.Group) (things.Group, error) {
+	span := createSpan(ctx, grm.tracer, saveGroupOp)
+	defer span.Finish()
+	ctx = opentracing.ContextWithSpan(ctx, span)
+
+	return grm.repo.Save(ctx, g)
+}
+
+func (grm groupRepositoryMiddleware) Update(ctx context.Context, g things.Group) error {
+	span := createSpan(ctx, grm.tracer, updateGroupOp)
+	defer span.Finish()
+	ctx = opentracing.ContextWithSpan(ctx, span)
+
+	return grm.repo.Update(ctx, g)
+}
+
+func (grm groupRepositoryMiddleware) RetrieveByID(ctx context.Context, owner, id string) (things.Group, error) {
+	span := createSpan(ctx, grm.tracer, retrieveGroupByIDOp)
+	defer span.Finish()
+	ctx = opentracing.ContextWithSpan(ctx, span)
+
+	return grm.repo.RetrieveByID(ctx, owner, id)
+}
+
+func (grm groupRepositoryMiddleware) RetrieveAll(ctx context.Context, owner, parentID string, offset, limit uint64, m things.Metadata) (things.GroupsPage, error) {
+	span := createSpan(ctx, grm.tracer, retrieveAllGroupsOp)
+	defer span.Finish()
+	ctx = opentracing.ContextWithSpan(ctx, span)
+
+	return grm.repo.RetrieveAll(ctx, owner, parentID, offset, limit, m)
+}
+
+func (grm groupRepositoryMiddleware) RetrieveThings(ctx context.Context, owner, groupID string, offset, limit uint64) (things.Page, error) {
+	span := createSpan(ctx, grm.tracer, retrieveThingsByGroupOp)
+	defer span.Finish()
+	ctx = opentracing.ContextWithSpan(ctx, span)
+
+	return grm.repo.RetrieveThings(ctx, owner, groupID, offset, limit)
+}
+
+func (grm groupRepositoryMiddleware) RetrieveChannels(ctx context.Context, owner, groupID string, offset, limit uint64) (things.ChannelsPage, error) {
+	span := createSpan(ctx, grm.tracer, retrieveChannelsByGroupOp)
+	defer span.Finish()
+	ctx = opentracing.ContextWithSpan(ctx, span)
+
+	return grm.repo.RetrieveChannels(ctx, owner, groupID, offset, limit)
+}
+
+func (grm groupRepositoryMiddleware) RetrieveConnections(ctx context.Context, owner, groupID string) ([]string, error) {
+	span := createSpan(ctx, grm.tracer, retrieveGroupConnectionsOp)
+	defer span.Finish()
+	ctx = opentracing.ContextWithSpan(ctx, span)
+
+	return grm.repo.RetrieveConnections(ctx, owner, groupID)
+}
+
+func (grm groupRepositoryMiddleware) Remove(ctx context.Context, owner, id string) error {
+	span := createSpan(ctx, grm.tracer, removeGroupOp)
+	defer span.Finish()
+	ctx = opentracing.ContextWithSpan(ctx, span)
+
+	return grm.repo.Remove(ctx, owner, id)
+}
+
+func (grm groupRepositoryMiddleware) AssignThings(ctx context.Context, owner, groupID string, thIDs ...string) error {
+	span := createSpan(ctx, grm.tracer, assignThingsOp)
+	defer span.Finish()
+	ctx = opentracing.ContextWithSpan(ctx, span)
+
+	return grm.repo.AssignThings(ctx, owner, groupID, thIDs...)
+}
+
+func (grm groupRepositoryMiddleware) UnassignThings(ctx context.Context, owner, groupID string, thIDs ...string) error {
+	span := createSpan(ctx, grm.tracer, unassignThingsOp)
+	defer span.Finish()
+	ctx = opentracing.ContextWithSpan(ctx, span)
+
+	return grm.repo.UnassignThings(ctx, owner, groupID, thIDs...)
+}
+
+func (grm groupRepositoryMiddleware) AssignChannels(ctx context.Context, owner, groupID string, chIDs ...string) error {
+	span := createSpan(ctx, grm.tracer, assignChannelsOp)
+	defer span.Finish()
+	ctx = opentracing.ContextWithSpan(ctx, span)
+
+	return grm.repo.AssignChannels(ctx, owner, groupID, chIDs...)
+}
+
+func (grm groupRepositoryMiddleware) UnassignChannels(ctx context.Context, owner, groupID string, chIDs ...string) error {
+	span := createSpan(ctx, grm.tracer, unassignChannelsOp)
+	defer span.Finish()
+	ctx = opentracing.ContextWithSpan(ctx, span)
+
+	return grm.repo.UnassignChannels(ctx, owner, groupID, chIDs...)
+}
+
+func (grm groupRepositoryMiddleware) Connect(ctx context.Context, owner, groupID string, chIDs, actions []string) error {
+	span := createSpan(ctx, grm.tracer, connectGroupOp)
+	defer span.Finish()
+	ctx = opentracing.ContextWithSpan(ctx, span)
+
+	return grm.repo.Connect(ctx, owner, groupID, chIDs, actions)
+}
+
+func (grm groupRepositoryMiddleware) Disconnect(ctx context.Context, owner, groupID, chanID string) error {
+	span := createSpan(ctx, grm.tracer, disconnectGroupOp)
+	defer span.Finish()
+	ctx = opentracing.ContextWithSpan(ctx, span)
+
+	return grm.repo.Disconnect(ctx, owner, groupID, chanID)
+}
@@ -15,6 +15,7 @@ const (
 	saveThingsOp              = "save_things"
 	updateThingOp             = "update_thing"
 	updateThingKeyOp          = "update_thing_by_key"
+	shareThingOp              = "share_thing"
 	retrieveThingByIDOp       = "retrieve_thing_by_id"
 	retrieveThingByKeyOp      = "retrieve_thing_by_key"
 	retrieveAllThingsOp       = "retrieve_all_things"
@@ -50,28 +51,36 @@ func (trm thingRepositoryMiddleware) Save(ctx context.Context, ths ...things.Thi
 	return trm.repo.Save(ctx, ths...)
 }
 
-func (trm thingRepositoryMiddleware) Update(ctx context.Context, th things.Thing) error {
+func (trm thingRepositoryMiddleware) Update(ctx context.Context, acc things.Access, th things.Thing) error {
 	span := createSpan(ctx, trm.tracer, updateThingOp)
 	defer span.Finish()
 	ctx = opentracing.ContextWithSpan(ctx, span)
 
-	return trm.repo.Update(ctx, th)
+	return trm.repo.Update(ctx, acc, th)
 }
 
-func (trm thingRepositoryMiddleware) UpdateKey(ctx context.Context, owner, id, key string) error {
+func (trm thingRepositoryMiddleware) Share(ctx context.Context, owner, id, group string) error {
+	span := createSpan(ctx, trm.tracer, shareThingOp)
+	defer span.Finish()
+	ctx = opentracing.ContextWithSpan(ctx, span)
+
+	return trm.repo.Share(ctx, owner, id, group)
+}
+
+func (trm thingRepositoryMiddleware) UpdateKey(ctx context.Context, acc things.Access, id, key string) error {
 	span := createSpan(ctx, trm.tracer, updateThingKeyOp)
 	defer span.Finish()
 	ctx = opentracing.ContextWithSpan(ctx, span)
 
-	return trm.repo.UpdateKey(ctx, owner, id, key)
+	return trm.repo.UpdateKey(ctx, acc, id, key)
 }
 
-func (trm thingRepositoryMiddleware) RetrieveByID(ctx context.Context, owner, id string) (things.Thing, error) {
+func (trm thingRepositoryMiddleware) RetrieveByID(ctx context.Context, acc things.Access, id string) (things.Thing, error) {
 	span := createSpan(ctx, trm.tracer, retrieveThingByIDOp)
 	defer span.Finish()
 	ctx = opentracing.ContextWithSpan(ctx, span)
 
-	return trm.repo.RetrieveByID(ctx, owner, id)
+	return trm.repo.RetrieveByID(ctx, acc, id)
 }
 
 func (trm thingRepositoryMiddleware) RetrieveByKey(ctx context.Context, key string) (string, error) {
@@ -82,28 +91,28 @@ func (trm thingRepositoryMiddleware) RetrieveByKey(ctx context.Context, key stri
 	return trm.repo.RetrieveByKey(ctx, key)
 }
 
-func (trm thingRepositoryMiddleware) RetrieveAll(ctx context.Context, owner string, offset, limit uint64, name string, metadata things.Metadata) (things.Page, error) {
+func (trm thingRepositoryMiddleware) RetrieveAll(ctx context.Context, acc things.Access, offset, limit uint64, cursor, name string, metadata things.Metadata) (things.Page, error) {
 	span := createSpan(ctx, trm.tracer, retrieveAllThingsOp)
 	defer span.Finish()
 	ctx = opentracing.ContextWithSpan(ctx, span)
 
-	return trm.repo.RetrieveAll(ctx, owner, offset, limit, name, metadata)
+	return trm.repo.RetrieveAll(ctx, acc, offset, limit, cursor, name, metadata)
 }
 
-func (trm thingRepositoryMiddleware) RetrieveByChannel(ctx context.Context, owner, channel string, offset, limit uint64, connected bool) (things.Page, error) {
+func (trm thingRepositoryMiddleware) RetrieveByChannel(ctx context.Context, acc things.Access, channel string, offset, limit uint64, connected bool) (things.Page, error) {
 	span := createSpan(ctx, trm.tracer, retrieveThingsByChannelOp)
 	defer span.Finish()
 	ctx = opentracing.ContextWithSpan(ctx, span)
 
-	return trm.repo.RetrieveByChannel(ctx, owner, channel, offset, limit, connected)
+	return trm.repo.RetrieveByChannel(ctx, acc, channel, offset, limit, connected)
 }
 
-func (trm thingRepositoryMiddleware) Remove(ctx context.Context, owner, id string) error {
+func (trm thingRepositoryMiddleware) Remove(ctx context.Context, acc things.Access, id string) error {
 	span := createSpan(ctx, trm.tracer, removeThingOp)
 	defer span.Finish()
 	ctx = opentracing.ContextWithSpan(ctx, span)
 
-	return trm.repo.Remove(ctx, owner, id)
+	return trm.repo.Remove(ctx, acc, id)
 }
 
 type thingCacheMiddleware struct {
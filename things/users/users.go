@@ -11,6 +11,7 @@ import (
 
 	"github.com/mainflux/mainflux/things"
 
+	"github.com/golang/protobuf/ptypes/empty"
 	"github.com/mainflux/mainflux"
 	"google.golang.org/grpc"
 )
@@ -51,3 +52,43 @@ func (repo singleUserRepo) Identify(ctx context.Context, token *mainflux.Token,
 
 	return &mainflux.UserIdentity{Id: repo.email, Email: repo.email}, nil
 }
+
+// The single user token is static, so it can not be revoked.
+func (repo singleUserRepo) RevokeToken(ctx context.Context, token *mainflux.Token, opts ...grpc.CallOption) (*empty.Empty, error) {
+	return nil, things.ErrUnauthorizedAccess
+}
+
+func (repo singleUserRepo) RevokeSessions(ctx context.Context, token *mainflux.Token, opts ...grpc.CallOption) (*empty.Empty, error) {
+	return nil, things.ErrUnauthorizedAccess
+}
+
+func (repo singleUserRepo) Refresh(ctx context.Context, token *mainflux.Token, opts ...grpc.CallOption) (*mainflux.Tokens, error) {
+	return nil, things.ErrUnauthorizedAccess
+}
+
+func (repo singleUserRepo) VerifyChallenge(ctx context.Context, token *mainflux.Token, opts ...grpc.CallOption) (*mainflux.UserIdentity, error) {
+	return nil, things.ErrUnauthorizedAccess
+}
+
+// There are no other users to impersonate or disable.
+func (repo singleUserRepo) Impersonate(ctx context.Context, req *mainflux.ImpersonateReq, opts ...grpc.CallOption) (*mainflux.Token, error) {
+	return nil, things.ErrUnauthorizedAccess
+}
+
+func (repo singleUserRepo) SetDisabled(ctx context.Context, req *mainflux.DisableReq, opts ...grpc.CallOption) (*empty.Empty, error) {
+	return nil, things.ErrUnauthorizedAccess
+}
+
+var _ mainflux.UsersServiceClient = (*singleUserGroups)(nil)
+
+type singleUserGroups struct{}
+
+// NewSingleUserGroups creates users groups service for constrained
+// environments, in which the single user is not a member of any group.
+func NewSingleUserGroups() mainflux.UsersServiceClient {
+	return singleUserGroups{}
+}
+
+func (groups singleUserGroups) Authorize(ctx context.Context, req *mainflux.AuthorizeReq, opts ...grpc.CallOption) (*mainflux.Authorization, error) {
+	return &mainflux.Authorization{Groups: []string{}}, nil
+}
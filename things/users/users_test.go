@@ -72,3 +72,11 @@ func TestIssue(t *testing.T) {
 		assert.Equal(t, tc.err, err, fmt.Sprintf("%s: expected %s, got %s", desc, tc.err, err))
 	}
 }
+
+func TestAuthorize(t *testing.T) {
+	svc := users.NewSingleUserGroups()
+
+	res, err := svc.Authorize(context.Background(), &mainflux.AuthorizeReq{Email: email, Role: "viewer"})
+	assert.Nil(t, err, fmt.Sprintf("authorize: unexpected error %s", err))
+	assert.Empty(t, res.GetGroups(), fmt.Sprintf("authorize: expected no groups, got %v", res.GetGroups()))
+}
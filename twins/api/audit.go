@@ -0,0 +1,66 @@
+// Copyright (c) Mainflux
+// SPDX-License-Identifier: Apache-2.0
+
+package api
+
+import (
+	"context"
+
+	"github.com/mainflux/mainflux/pkg/audit"
+	"github.com/mainflux/mainflux/pkg/messaging"
+	"github.com/mainflux/mainflux/twins"
+)
+
+var _ twins.Service = (*auditMiddleware)(nil)
+
+type auditMiddleware struct {
+	rec audit.Recorder
+	svc twins.Service
+}
+
+// AuditMiddleware records the operations changing the twins. Read-only
+// operations and the states saved from the received messages are not
+// recorded.
+func AuditMiddleware(svc twins.Service, rec audit.Recorder) twins.Service {
+	return &auditMiddleware{rec, svc}
+}
+
+func (am *auditMiddleware) AddTwin(ctx context.Context, token string, twin twins.Twin, def twins.Definition) (tw twins.Twin, err error) {
+	e := am.rec.Event(ctx, token, "add_twin", "")
+	defer func() {
+		e.Entity = tw.ID
+		am.rec.Record(ctx, e, err)
+	}()
+
+	return am.svc.AddTwin(ctx, token, twin, def)
+}
+
+func (am *auditMiddleware) UpdateTwin(ctx context.Context, token string, twin twins.Twin, def twins.Definition) (err error) {
+	e := am.rec.Event(ctx, token, "update_twin", twin.ID)
+	defer func() { am.rec.Record(ctx, e, err) }()
+
+	return am.svc.UpdateTwin(ctx, token, twin, def)
+}
+
+func (am *auditMiddleware) ViewTwin(ctx context.Context, token, twinID string) (twins.Twin, error) {
+	return am.svc.ViewTwin(ctx, token, twinID)
+}
+
+func (am *auditMiddleware) ListTwins(ctx context.Context, token string, offset uint64, limit uint64, name string, metadata twins.Metadata) (twins.Page, error) {
+	return am.svc.ListTwins(ctx, token, offset, limit, name, metadata)
+}
+
+func (am *auditMiddleware) SaveStates(msg *messaging.Message) error {
+	return am.svc.SaveStates(msg)
+}
+
+func (am *auditMiddleware) ListStates(ctx context.Context, token string, offset uint64, limit uint64, twinID string) (twins.StatesPage, error) {
+	return am.svc.ListStates(ctx, token, offset, limit, twinID)
+}
+
+func (am *auditMiddleware) RemoveTwin(ctx context.Context, token, twinID string) (err error) {
+	e := am.rec.Event(ctx, token, "remove_twin", twinID)
+	defer func() { am.rec.Record(ctx, e, err) }()
+
+	return am.svc.RemoveTwin(ctx, token, twinID)
+}
@@ -19,8 +19,9 @@ func addTwinEndpoint(svc twins.Service) endpoint.Endpoint {
 		}
 
 		twin := twins.Twin{
-			Name:     req.Name,
-			Metadata: req.Metadata,
+			Name:      req.Name,
+			UserGroup: req.UserGroup,
+			Metadata:  req.Metadata,
 		}
 		saved, err := svc.AddTwin(ctx, req.token, twin, req.Definition)
 		if err != nil {
@@ -44,9 +45,10 @@ func updateTwinEndpoint(svc twins.Service) endpoint.Endpoint {
 		}
 
 		twin := twins.Twin{
-			ID:       req.id,
-			Name:     req.Name,
-			Metadata: req.Metadata,
+			ID:        req.id,
+			Name:      req.Name,
+			UserGroup: req.UserGroup,
+			Metadata:  req.Metadata,
 		}
 
 		if err := svc.UpdateTwin(ctx, req.token, twin, req.Definition); err != nil {
@@ -73,6 +75,7 @@ func viewTwinEndpoint(svc twins.Service) endpoint.Endpoint {
 
 		res := viewTwinRes{
 			Owner:       twin.Owner,
+			UserGroup:   twin.UserGroup,
 			ID:          twin.ID,
 			Name:        twin.Name,
 			Created:     twin.Created,
@@ -109,6 +112,7 @@ func listTwinsEndpoint(svc twins.Service) endpoint.Endpoint {
 		for _, twin := range page.Twins {
 			view := viewTwinRes{
 				Owner:       twin.Owner,
+				UserGroup:   twin.UserGroup,
 				ID:          twin.ID,
 				Name:        twin.Name,
 				Created:     twin.Created,
@@ -19,6 +19,7 @@ type apiReq interface {
 type addTwinReq struct {
 	token      string
 	Name       string                 `json:"name,omitempty"`
+	UserGroup  string                 `json:"user_group,omitempty"`
 	Definition twins.Definition       `json:"definition,omitempty"`
 	Metadata   map[string]interface{} `json:"metadata,omitempty"`
 }
@@ -39,6 +40,7 @@ type updateTwinReq struct {
 	token      string
 	id         string
 	Name       string                 `json:"name,omitempty"`
+	UserGroup  string                 `json:"user_group,omitempty"`
 	Definition twins.Definition       `json:"definition,omitempty"`
 	Metadata   map[string]interface{} `json:"metadata,omitempty"`
 }
@@ -50,6 +50,7 @@ func (res twinRes) Empty() bool {
 
 type viewTwinRes struct {
 	Owner       string                 `json:"owner,omitempty"`
+	UserGroup   string                 `json:"user_group,omitempty"`
 	ID          string                 `json:"id"`
 	Name        string                 `json:"name,omitempty"`
 	Revision    int                    `json:"revision"`
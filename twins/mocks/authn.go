@@ -6,6 +6,7 @@ package mocks
 import (
 	"context"
 
+	"github.com/golang/protobuf/ptypes/empty"
 	"github.com/mainflux/mainflux"
 	"github.com/mainflux/mainflux/users"
 	"google.golang.org/grpc"
@@ -14,17 +15,32 @@ import (
 var _ mainflux.AuthNServiceClient = (*authNServiceClient)(nil)
 
 type authNServiceClient struct {
-	users map[string]string
+	users  map[string]string
+	scopes map[string][]*mainflux.Scope
+	admin  string
 }
 
 // NewAuthNServiceClient creates mock of auth service.
 func NewAuthNServiceClient(users map[string]string) mainflux.AuthNServiceClient {
-	return &authNServiceClient{users}
+	return NewScopedAuthNServiceClient(users, map[string][]*mainflux.Scope{})
+}
+
+// NewScopedAuthNServiceClient creates mock of auth service which identifies
+// the tokens present in the scopes map as API keys restricted to the scopes.
+func NewScopedAuthNServiceClient(users map[string]string, scopes map[string][]*mainflux.Scope) mainflux.AuthNServiceClient {
+	return &authNServiceClient{users: users, scopes: scopes}
+}
+
+// NewAdminAuthNServiceClient creates mock of auth service which identifies
+// the user with the given email as the admin.
+func NewAdminAuthNServiceClient(users map[string]string, admin string) mainflux.AuthNServiceClient {
+	return &authNServiceClient{users: users, scopes: map[string][]*mainflux.Scope{}, admin: admin}
 }
 
 func (svc authNServiceClient) Identify(ctx context.Context, in *mainflux.Token, opts ...grpc.CallOption) (*mainflux.UserIdentity, error) {
 	if id, ok := svc.users[in.Value]; ok {
-		return &mainflux.UserIdentity{Id: id, Email: id}, nil
+		admin := svc.admin != "" && id == svc.admin
+		return &mainflux.UserIdentity{Id: id, Email: id, Admin: admin, Scopes: svc.scopes[in.Value]}, nil
 	}
 	return nil, users.ErrUnauthorizedAccess
 }
@@ -32,3 +48,27 @@ func (svc authNServiceClient) Identify(ctx context.Context, in *mainflux.Token,
 func (svc *authNServiceClient) Issue(ctx context.Context, in *mainflux.IssueReq, opts ...grpc.CallOption) (*mainflux.Token, error) {
 	return new(mainflux.Token), nil
 }
+
+func (svc authNServiceClient) RevokeToken(ctx context.Context, in *mainflux.Token, opts ...grpc.CallOption) (*empty.Empty, error) {
+	panic("not implemented")
+}
+
+func (svc authNServiceClient) RevokeSessions(ctx context.Context, in *mainflux.Token, opts ...grpc.CallOption) (*empty.Empty, error) {
+	panic("not implemented")
+}
+
+func (svc authNServiceClient) Refresh(ctx context.Context, in *mainflux.Token, opts ...grpc.CallOption) (*mainflux.Tokens, error) {
+	panic("not implemented")
+}
+
+func (svc authNServiceClient) VerifyChallenge(ctx context.Context, in *mainflux.Token, opts ...grpc.CallOption) (*mainflux.UserIdentity, error) {
+	panic("not implemented")
+}
+
+func (svc authNServiceClient) Impersonate(ctx context.Context, in *mainflux.ImpersonateReq, opts ...grpc.CallOption) (*mainflux.Token, error) {
+	panic("not implemented")
+}
+
+func (svc authNServiceClient) SetDisabled(ctx context.Context, in *mainflux.DisableReq, opts ...grpc.CallOption) (*empty.Empty, error) {
+	panic("not implemented")
+}
@@ -5,6 +5,7 @@ import (
 	"strconv"
 	"time"
 
+	"github.com/mainflux/mainflux"
 	"github.com/mainflux/mainflux/pkg/messaging"
 	"github.com/mainflux/mainflux/pkg/uuid"
 	"github.com/mainflux/mainflux/twins"
@@ -17,14 +18,35 @@ var id = 0
 
 // NewService use mock dependencies to create real twins service
 func NewService(tokens map[string]string) twins.Service {
-	auth := NewAuthNServiceClient(tokens)
+	return NewScopedService(tokens, map[string][]*mainflux.Scope{})
+}
+
+// NewScopedService use mock dependencies to create real twins service whose
+// auth mock restricts the tokens present in the scopes map to the scopes
+func NewScopedService(tokens map[string]string, scopes map[string][]*mainflux.Scope) twins.Service {
+	return newService(NewScopedAuthNServiceClient(tokens, scopes), NewUsersService(nil))
+}
+
+// NewAdminService use mock dependencies to create real twins service whose
+// auth mock identifies the user with the given email as the admin.
+func NewAdminService(tokens map[string]string, admin string) twins.Service {
+	return newService(NewAdminAuthNServiceClient(tokens, admin), NewUsersService(nil))
+}
+
+// NewSharingService use mock dependencies to create real twins service whose
+// users mock assigns the users to the users groups with the given roles.
+func NewSharingService(tokens map[string]string, roles map[string]map[string]string) twins.Service {
+	return newService(NewAuthNServiceClient(tokens), NewUsersService(roles))
+}
+
+func newService(auth mainflux.AuthNServiceClient, users mainflux.UsersServiceClient) twins.Service {
 	twinsRepo := NewTwinRepository()
 	twinCache := NewTwinCache()
 	statesRepo := NewStateRepository()
 	uuidProvider := uuid.NewMock()
 	subs := map[string]string{"chanID": "chanID"}
 	broker := NewBroker(subs)
-	return twins.New(broker, auth, twinsRepo, twinCache, statesRepo, uuidProvider, "chanID", nil)
+	return twins.New(broker, auth, users, twinsRepo, twinCache, statesRepo, uuidProvider, "chanID", nil)
 }
 
 // CreateDefinition creates twin definition
@@ -7,7 +7,6 @@ import (
 	"context"
 	"sort"
 	"strconv"
-	"strings"
 	"sync"
 
 	"github.com/mainflux/mainflux/pkg/uuid"
@@ -84,7 +83,7 @@ func (trm *twinRepositoryMock) RetrieveByAttribute(ctx context.Context, channel,
 	return ids, nil
 }
 
-func (trm *twinRepositoryMock) RetrieveAll(_ context.Context, owner string, offset uint64, limit uint64, name string, metadata twins.Metadata) (twins.Page, error) {
+func (trm *twinRepositoryMock) RetrieveAll(_ context.Context, acc twins.Access, offset uint64, limit uint64, name string, metadata twins.Metadata) (twins.Page, error) {
 	trm.mu.Lock()
 	defer trm.mu.Unlock()
 
@@ -94,14 +93,14 @@ func (trm *twinRepositoryMock) RetrieveAll(_ context.Context, owner string, offs
 		return twins.Page{}, nil
 	}
 
-	for k, v := range trm.twins {
+	for _, v := range trm.twins {
 		if (uint64)(len(items)) >= limit {
 			break
 		}
 		if len(name) > 0 && v.Name != name {
 			continue
 		}
-		if !strings.HasPrefix(k, owner) {
+		if !acc.Allows(v) {
 			continue
 		}
 		suffix := string(v.ID[len(uuid.Prefix):])
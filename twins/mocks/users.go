@@ -0,0 +1,48 @@
+// Copyright (c) Mainflux
+// SPDX-License-Identifier: Apache-2.0
+
+package mocks
+
+import (
+	"context"
+	"sort"
+
+	"github.com/mainflux/mainflux"
+	"github.com/mainflux/mainflux/users"
+	"google.golang.org/grpc"
+)
+
+var _ mainflux.UsersServiceClient = (*usersServiceMock)(nil)
+
+var roleRanks = map[string]int{
+	users.ViewerRole: 1,
+	users.EditorRole: 2,
+	users.AdminRole:  3,
+}
+
+type usersServiceMock struct {
+	roles map[string]map[string]string
+}
+
+// NewUsersService creates mock of users service. Roles map the user email to
+// the roles the user has in the users groups, keyed by the group ID.
+func NewUsersService(roles map[string]map[string]string) mainflux.UsersServiceClient {
+	return &usersServiceMock{roles}
+}
+
+func (svc usersServiceMock) Authorize(ctx context.Context, in *mainflux.AuthorizeReq, opts ...grpc.CallOption) (*mainflux.Authorization, error) {
+	rank, ok := roleRanks[in.GetRole()]
+	if !ok {
+		return nil, users.ErrMalformedEntity
+	}
+
+	groups := []string{}
+	for group, role := range svc.roles[in.GetEmail()] {
+		if roleRanks[role] >= rank {
+			groups = append(groups, group)
+		}
+	}
+	sort.Strings(groups)
+
+	return &mainflux.Authorization{Groups: groups}, nil
+}
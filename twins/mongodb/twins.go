@@ -132,7 +132,7 @@ func (tr *twinRepository) RetrieveByAttribute(ctx context.Context, channel, subt
 	return ids, nil
 }
 
-func (tr *twinRepository) RetrieveAll(ctx context.Context, owner string, offset uint64, limit uint64, name string, metadata twins.Metadata) (twins.Page, error) {
+func (tr *twinRepository) RetrieveAll(ctx context.Context, acc twins.Access, offset uint64, limit uint64, name string, metadata twins.Metadata) (twins.Page, error) {
 	coll := tr.db.Collection(twinsCollection)
 
 	findOptions := options.Find()
@@ -141,8 +141,15 @@ func (tr *twinRepository) RetrieveAll(ctx context.Context, owner string, offset
 
 	filter := bson.M{}
 
-	if owner != "" {
-		filter["owner"] = owner
+	if !acc.All {
+		groups := acc.UserGroups
+		if groups == nil {
+			groups = []string{}
+		}
+		filter["$or"] = []interface{}{
+			bson.M{"owner": acc.Owner},
+			bson.M{"usergroup": bson.M{"$in": groups}},
+		}
 	}
 	if name != "" {
 		filter["name"] = name
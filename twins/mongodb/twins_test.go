@@ -273,8 +273,14 @@ func TestTwinsRetrieveAll(t *testing.T) {
 		twinRepo.Save(context.Background(), tw)
 	}
 
+	group := "twin-multi-retrieval-group"
+	twid, err := uuidProvider.New().ID()
+	require.Nil(t, err, fmt.Sprintf("got unexpected error: %s", err))
+	_, err = twinRepo.Save(context.Background(), twins.Twin{Owner: "other@example.com", UserGroup: group, ID: twid})
+	require.Nil(t, err, fmt.Sprintf("got unexpected error: %s", err))
+
 	cases := map[string]struct {
-		owner    string
+		acc      twins.Access
 		limit    uint64
 		offset   uint64
 		name     string
@@ -283,27 +289,42 @@ func TestTwinsRetrieveAll(t *testing.T) {
 		metadata twins.Metadata
 	}{
 		"retrieve all twins with existing owner": {
-			owner:  email,
+			acc:    twins.Access{Owner: email},
 			offset: 0,
 			limit:  n,
 			size:   n,
 			total:  n,
 		},
 		"retrieve subset of twins with existing owner": {
-			owner:  email,
+			acc:    twins.Access{Owner: email},
 			offset: 0,
 			limit:  n / 2,
 			size:   n / 2,
 			total:  n,
 		},
 		"retrieve twins with non-existing owner": {
-			owner:  wrongValue,
+			acc:    twins.Access{Owner: wrongValue},
 			offset: 0,
 			limit:  n,
 			size:   0,
 			total:  0,
 		},
+		"retrieve twins shared with users group": {
+			acc:    twins.Access{Owner: wrongValue, UserGroups: []string{group}},
+			offset: 0,
+			limit:  n,
+			size:   1,
+			total:  1,
+		},
+		"retrieve owned and shared twins": {
+			acc:    twins.Access{Owner: email, UserGroups: []string{group}},
+			offset: 0,
+			limit:  n + 1,
+			size:   n + 1,
+			total:  n + 1,
+		},
 		"retrieve twins with existing name": {
+			acc:    twins.Access{All: true},
 			offset: 0,
 			limit:  1,
 			name:   name,
@@ -311,6 +332,7 @@ func TestTwinsRetrieveAll(t *testing.T) {
 			total:  2,
 		},
 		"retrieve twins with non-existing name": {
+			acc:    twins.Access{All: true},
 			offset: 0,
 			limit:  n,
 			name:   "wrong",
@@ -318,6 +340,7 @@ func TestTwinsRetrieveAll(t *testing.T) {
 			total:  0,
 		},
 		"retrieve twins with metadata": {
+			acc:      twins.Access{All: true},
 			offset:   0,
 			limit:    n,
 			size:     n,
@@ -325,6 +348,7 @@ func TestTwinsRetrieveAll(t *testing.T) {
 			metadata: metadata,
 		},
 		"retrieve twins with wrong metadata": {
+			acc:      twins.Access{All: true},
 			offset:   0,
 			limit:    n,
 			size:     0,
@@ -334,7 +358,7 @@ func TestTwinsRetrieveAll(t *testing.T) {
 	}
 
 	for desc, tc := range cases {
-		page, err := twinRepo.RetrieveAll(context.Background(), tc.owner, tc.offset, tc.limit, tc.name, tc.metadata)
+		page, err := twinRepo.RetrieveAll(context.Background(), tc.acc, tc.offset, tc.limit, tc.name, tc.metadata)
 		size := uint64(len(page.Twins))
 		assert.Equal(t, tc.size, size, fmt.Sprintf("%s: expected %d got %d\n", desc, tc.size, size))
 		assert.Equal(t, tc.total, page.Total, fmt.Sprintf("%s: expected %d got %d\n", desc, tc.total, page.Total))
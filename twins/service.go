@@ -55,7 +55,8 @@ type Service interface {
 	RemoveTwin(ctx context.Context, token, twinID string) (err error)
 
 	// ListTwins retrieves data about subset of twins that belongs to the
-	// user identified by the provided key.
+	// user identified by the provided key, or are shared with the users
+	// groups of the user. The admin retrieves the twins of all users.
 	ListTwins(ctx context.Context, token string, offset uint64, limit uint64, name string, metadata Metadata) (Page, error)
 
 	// ListStates retrieves data about subset of states that belongs to the
@@ -91,6 +92,7 @@ var crudOp = map[string]string{
 type twinsService struct {
 	publisher    messaging.Publisher
 	auth         mainflux.AuthNServiceClient
+	users        mainflux.UsersServiceClient
 	twins        TwinRepository
 	states       StateRepository
 	uuidProvider mainflux.UUIDProvider
@@ -102,10 +104,11 @@ type twinsService struct {
 var _ Service = (*twinsService)(nil)
 
 // New instantiates the twins service implementation.
-func New(publisher messaging.Publisher, auth mainflux.AuthNServiceClient, twins TwinRepository, tcache TwinCache, sr StateRepository, idp mainflux.UUIDProvider, chann string, logger logger.Logger) Service {
+func New(publisher messaging.Publisher, auth mainflux.AuthNServiceClient, users mainflux.UsersServiceClient, twins TwinRepository, tcache TwinCache, sr StateRepository, idp mainflux.UUIDProvider, chann string, logger logger.Logger) Service {
 	return &twinsService{
 		publisher:    publisher,
 		auth:         auth,
+		users:        users,
 		twins:        twins,
 		twinCache:    tcache,
 		states:       sr,
@@ -120,9 +123,19 @@ func (ts *twinsService) AddTwin(ctx context.Context, token string, twin Twin, de
 	var b []byte
 	defer ts.publish(&id, &err, crudOp["createSucc"], crudOp["createFail"], &b)
 
-	res, err := ts.auth.Identify(ctx, &mainflux.Token{Value: token})
+	res, err := ts.identify(ctx, token, mainflux.WriteAction, "")
 	if err != nil {
-		return Twin{}, ErrUnauthorizedAccess
+		return Twin{}, err
+	}
+
+	if twin.UserGroup != "" {
+		acc, err := ts.access(ctx, res, mainflux.WriteAction)
+		if err != nil {
+			return Twin{}, err
+		}
+		if !acc.Shares(twin.UserGroup) {
+			return Twin{}, ErrUnauthorizedAccess
+		}
 	}
 
 	twin.ID, err = ts.uuidProvider.ID()
@@ -163,15 +176,23 @@ func (ts *twinsService) UpdateTwin(ctx context.Context, token string, twin Twin,
 	var id string
 	defer ts.publish(&id, &err, crudOp["updateSucc"], crudOp["updateFail"], &b)
 
-	_, err = ts.auth.Identify(ctx, &mainflux.Token{Value: token})
+	res, err := ts.identify(ctx, token, mainflux.WriteAction, twin.ID)
 	if err != nil {
-		return ErrUnauthorizedAccess
+		return err
+	}
+
+	acc, err := ts.access(ctx, res, mainflux.WriteAction)
+	if err != nil {
+		return err
 	}
 
 	tw, err := ts.twins.RetrieveByID(ctx, twin.ID)
 	if err != nil {
 		return err
 	}
+	if !acc.Allows(tw) {
+		return ErrNotFound
+	}
 
 	revision := false
 
@@ -180,6 +201,14 @@ func (ts *twinsService) UpdateTwin(ctx context.Context, token string, twin Twin,
 		tw.Name = twin.Name
 	}
 
+	if twin.UserGroup != "" {
+		if !acc.Shares(twin.UserGroup) {
+			return ErrUnauthorizedAccess
+		}
+		revision = true
+		tw.UserGroup = twin.UserGroup
+	}
+
 	if len(def.Attributes) > 0 {
 		revision = true
 		def.Created = time.Now()
@@ -213,15 +242,23 @@ func (ts *twinsService) ViewTwin(ctx context.Context, token, twinID string) (tw
 	var b []byte
 	defer ts.publish(&twinID, &err, crudOp["getSucc"], crudOp["getFail"], &b)
 
-	_, err = ts.auth.Identify(ctx, &mainflux.Token{Value: token})
+	res, err := ts.identify(ctx, token, mainflux.ReadAction, twinID)
+	if err != nil {
+		return Twin{}, err
+	}
+
+	acc, err := ts.access(ctx, res, mainflux.ReadAction)
 	if err != nil {
-		return Twin{}, ErrUnauthorizedAccess
+		return Twin{}, err
 	}
 
 	twin, err := ts.twins.RetrieveByID(ctx, twinID)
 	if err != nil {
 		return Twin{}, err
 	}
+	if !acc.Allows(twin) {
+		return Twin{}, ErrNotFound
+	}
 
 	b, err = json.Marshal(twin)
 
@@ -232,9 +269,19 @@ func (ts *twinsService) RemoveTwin(ctx context.Context, token, twinID string) (e
 	var b []byte
 	defer ts.publish(&twinID, &err, crudOp["removeSucc"], crudOp["removeFail"], &b)
 
-	_, err = ts.auth.Identify(ctx, &mainflux.Token{Value: token})
+	res, err := ts.identify(ctx, token, mainflux.WriteAction, twinID)
 	if err != nil {
-		return ErrUnauthorizedAccess
+		return err
+	}
+
+	acc, err := ts.access(ctx, res, mainflux.WriteAction)
+	if err != nil {
+		return err
+	}
+
+	// Removal of the non-existent twin is left to the repository.
+	if tw, err := ts.twins.RetrieveByID(ctx, twinID); err == nil && !acc.Allows(tw) {
+		return ErrNotFound
 	}
 
 	if err := ts.twins.Remove(ctx, twinID); err != nil {
@@ -245,23 +292,73 @@ func (ts *twinsService) RemoveTwin(ctx context.Context, token, twinID string) (e
 }
 
 func (ts *twinsService) ListTwins(ctx context.Context, token string, offset uint64, limit uint64, name string, metadata Metadata) (Page, error) {
-	res, err := ts.auth.Identify(ctx, &mainflux.Token{Value: token})
+	res, err := ts.identify(ctx, token, mainflux.ReadAction, "")
 	if err != nil {
-		return Page{}, ErrUnauthorizedAccess
+		return Page{}, err
 	}
 
-	return ts.twins.RetrieveAll(ctx, res.GetEmail(), offset, limit, name, metadata)
+	acc, err := ts.access(ctx, res, mainflux.ReadAction)
+	if err != nil {
+		return Page{}, err
+	}
+
+	return ts.twins.RetrieveAll(ctx, acc, offset, limit, name, metadata)
 }
 
 func (ts *twinsService) ListStates(ctx context.Context, token string, offset uint64, limit uint64, twinID string) (StatesPage, error) {
-	_, err := ts.auth.Identify(ctx, &mainflux.Token{Value: token})
+	res, err := ts.identify(ctx, token, mainflux.ReadAction, twinID)
+	if err != nil {
+		return StatesPage{}, err
+	}
+
+	acc, err := ts.access(ctx, res, mainflux.ReadAction)
 	if err != nil {
-		return StatesPage{}, ErrUnauthorizedAccess
+		return StatesPage{}, err
+	}
+
+	if tw, err := ts.twins.RetrieveByID(ctx, twinID); err == nil && !acc.Allows(tw) {
+		return StatesPage{}, ErrNotFound
 	}
 
 	return ts.states.RetrieveAll(ctx, offset, limit, twinID)
 }
 
+// access returns the twins the identified user can access in order to
+// perform the action. Besides the owned ones, these are the ones shared with
+// the users groups in which the user is a viewer for reading, or an editor
+// for writing. The admin reads the twins of all users.
+func (ts *twinsService) access(ctx context.Context, id *mainflux.UserIdentity, action string) (Access, error) {
+	if id.GetAdmin() && action == mainflux.ReadAction {
+		return Access{Owner: id.GetEmail(), All: true}, nil
+	}
+
+	role := viewerRole
+	if action == mainflux.WriteAction {
+		role = editorRole
+	}
+
+	res, err := ts.users.Authorize(ctx, &mainflux.AuthorizeReq{Email: id.GetEmail(), Role: role})
+	if err != nil {
+		return Access{}, errors.Wrap(ErrUnauthorizedAccess, err)
+	}
+
+	return Access{Owner: id.GetEmail(), UserGroups: res.GetGroups()}, nil
+}
+
+// identify identifies the user by the provided token and checks whether the
+// identity is allowed to perform the action on the twin with the provided ID.
+func (ts *twinsService) identify(ctx context.Context, token, action, id string) (*mainflux.UserIdentity, error) {
+	res, err := ts.auth.Identify(ctx, &mainflux.Token{Value: token})
+	if err != nil {
+		return nil, ErrUnauthorizedAccess
+	}
+	if !res.Allows(mainflux.TwinsResource, action, id) {
+		return nil, ErrUnauthorizedAccess
+	}
+
+	return res, nil
+}
+
 func (ts *twinsService) SaveStates(msg *messaging.Message) error {
 	var ids []string
 
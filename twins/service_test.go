@@ -8,6 +8,8 @@ import (
 	"fmt"
 	"testing"
 
+	"github.com/mainflux/mainflux"
+	"github.com/mainflux/mainflux/pkg/errors"
 	"github.com/mainflux/mainflux/twins"
 	"github.com/mainflux/mainflux/twins/mocks"
 	"github.com/mainflux/senml"
@@ -20,7 +22,12 @@ const (
 	wrongID    = ""
 	token      = "token"
 	wrongToken = "wrong-token"
+	readToken  = "read-token"
 	email      = "user@example.com"
+	adminToken = "admin-token"
+	adminEmail = "admin@example.com"
+	otherToken = "other-token"
+	otherEmail = "other@example.com"
 	natsURL    = "nats://localhost:4222"
 	numRecs    = 100
 )
@@ -106,11 +113,17 @@ func TestUpdateTwin(t *testing.T) {
 }
 
 func TestViewTwin(t *testing.T) {
-	svc := mocks.NewService(map[string]string{token: email})
+	scopes := map[string][]*mainflux.Scope{}
+	svc := mocks.NewScopedService(map[string]string{token: email, readToken: email}, scopes)
 	twin := twins.Twin{}
 	def := twins.Definition{}
 	saved, err := svc.AddTwin(context.Background(), token, twin, def)
 	require.Nil(t, err, fmt.Sprintf("unexpected error: %s\n", err))
+	other, err := svc.AddTwin(context.Background(), token, twin, def)
+	require.Nil(t, err, fmt.Sprintf("unexpected error: %s\n", err))
+	scopes[readToken] = []*mainflux.Scope{
+		{Resource: mainflux.TwinsResource, Action: mainflux.ReadAction, Ids: []string{saved.ID}},
+	}
 
 	cases := map[string]struct {
 		id    string
@@ -132,6 +145,16 @@ func TestViewTwin(t *testing.T) {
 			token: token,
 			err:   twins.ErrNotFound,
 		},
+		"view twin with scoped key": {
+			id:    saved.ID,
+			token: readToken,
+			err:   nil,
+		},
+		"view twin out of key scope": {
+			id:    other.ID,
+			token: readToken,
+			err:   twins.ErrUnauthorizedAccess,
+		},
 	}
 
 	for desc, tc := range cases {
@@ -141,7 +164,7 @@ func TestViewTwin(t *testing.T) {
 }
 
 func TestListTwins(t *testing.T) {
-	svc := mocks.NewService(map[string]string{token: email})
+	svc := mocks.NewAdminService(map[string]string{token: email, adminToken: adminEmail, otherToken: otherEmail}, adminEmail)
 	twin := twins.Twin{Name: twinName, Owner: email}
 	def := twins.Definition{}
 	m := make(map[string]interface{})
@@ -188,6 +211,20 @@ func TestListTwins(t *testing.T) {
 			offset: n,
 			err:    twins.ErrUnauthorizedAccess,
 		},
+		"list twins of all users as admin": {
+			token:  adminToken,
+			offset: 0,
+			limit:  n,
+			size:   n,
+			err:    nil,
+		},
+		"list twins of other user": {
+			token:  otherToken,
+			offset: 0,
+			limit:  n,
+			size:   0,
+			err:    nil,
+		},
 	}
 
 	for desc, tc := range cases {
@@ -243,6 +280,140 @@ func TestRemoveTwin(t *testing.T) {
 	}
 }
 
+func TestSharedAccess(t *testing.T) {
+	const (
+		userGroup     = "user-group"
+		otherGroup    = "other-group"
+		viewerToken   = "viewer-token"
+		editorToken   = "editor-token"
+		viewerEmail   = "viewer@example.com"
+		editorEmail   = "editor@example.com"
+		outsiderToken = "outsider-token"
+		outsiderEmail = "outsider@example.com"
+	)
+	tokens := map[string]string{
+		token:         email,
+		viewerToken:   viewerEmail,
+		editorToken:   editorEmail,
+		outsiderToken: outsiderEmail,
+	}
+	roles := map[string]map[string]string{
+		email:       {userGroup: "admin"},
+		viewerEmail: {userGroup: "viewer"},
+		editorEmail: {userGroup: "editor", otherGroup: "viewer"},
+	}
+	svc := mocks.NewSharingService(tokens, roles)
+
+	tw, err := svc.AddTwin(context.Background(), token, twins.Twin{Name: twinName, UserGroup: userGroup}, twins.Definition{})
+	require.Nil(t, err, fmt.Sprintf("unexpected error: %s", err))
+
+	cases := []struct {
+		desc string
+		op   func() error
+		err  error
+	}{
+		{
+			desc: "view shared twin as viewer",
+			op: func() error {
+				_, err := svc.ViewTwin(context.Background(), viewerToken, tw.ID)
+				return err
+			},
+			err: nil,
+		},
+		{
+			desc: "list shared twins as viewer",
+			op: func() error {
+				page, err := svc.ListTwins(context.Background(), viewerToken, 0, 10, "", nil)
+				if err == nil && len(page.Twins) != 1 {
+					return fmt.Errorf("expected 1 twin got %d", len(page.Twins))
+				}
+				return err
+			},
+			err: nil,
+		},
+		{
+			desc: "list states of shared twin as viewer",
+			op: func() error {
+				_, err := svc.ListStates(context.Background(), viewerToken, 0, 10, tw.ID)
+				return err
+			},
+			err: nil,
+		},
+		{
+			desc: "view shared twin as outsider",
+			op: func() error {
+				_, err := svc.ViewTwin(context.Background(), outsiderToken, tw.ID)
+				return err
+			},
+			err: twins.ErrNotFound,
+		},
+		{
+			desc: "list states of shared twin as outsider",
+			op: func() error {
+				_, err := svc.ListStates(context.Background(), outsiderToken, 0, 10, tw.ID)
+				return err
+			},
+			err: twins.ErrNotFound,
+		},
+		{
+			desc: "update shared twin as viewer",
+			op: func() error {
+				return svc.UpdateTwin(context.Background(), viewerToken, twins.Twin{ID: tw.ID, Name: "viewer"}, twins.Definition{})
+			},
+			err: twins.ErrNotFound,
+		},
+		{
+			desc: "update shared twin as editor",
+			op: func() error {
+				return svc.UpdateTwin(context.Background(), editorToken, twins.Twin{ID: tw.ID, Name: "editor"}, twins.Definition{})
+			},
+			err: nil,
+		},
+		{
+			desc: "share twin with group the user can only view",
+			op: func() error {
+				_, err := svc.AddTwin(context.Background(), editorToken, twins.Twin{UserGroup: otherGroup}, twins.Definition{})
+				return err
+			},
+			err: twins.ErrUnauthorizedAccess,
+		},
+		{
+			desc: "share twin with group the user is not member of",
+			op: func() error {
+				_, err := svc.AddTwin(context.Background(), outsiderToken, twins.Twin{UserGroup: userGroup}, twins.Definition{})
+				return err
+			},
+			err: twins.ErrUnauthorizedAccess,
+		},
+		{
+			desc: "reshare twin with group the user can only view",
+			op: func() error {
+				return svc.UpdateTwin(context.Background(), editorToken, twins.Twin{ID: tw.ID, UserGroup: otherGroup}, twins.Definition{})
+			},
+			err: twins.ErrUnauthorizedAccess,
+		},
+		{
+			desc: "remove shared twin as viewer",
+			op: func() error {
+				return svc.RemoveTwin(context.Background(), viewerToken, tw.ID)
+			},
+			err: twins.ErrNotFound,
+		},
+		{
+			desc: "remove shared twin as editor",
+			op: func() error {
+				return svc.RemoveTwin(context.Background(), editorToken, tw.ID)
+			},
+			err: nil,
+		},
+	}
+
+	for _, tc := range cases {
+		err := tc.op()
+		assert.True(t, errors.Contains(err, tc.err), fmt.Sprintf("%s: expected %s got %s\n", tc.desc, tc.err, err))
+	}
+}
+
 func TestSaveStates(t *testing.T) {
 	svc := mocks.NewService(map[string]string{token: email})
 
@@ -59,12 +59,12 @@ func (trm twinRepositoryMiddleware) RetrieveByID(ctx context.Context, twinID str
 	return trm.repo.RetrieveByID(ctx, twinID)
 }
 
-func (trm twinRepositoryMiddleware) RetrieveAll(ctx context.Context, owner string, offset, limit uint64, name string, metadata twins.Metadata) (twins.Page, error) {
+func (trm twinRepositoryMiddleware) RetrieveAll(ctx context.Context, acc twins.Access, offset, limit uint64, name string, metadata twins.Metadata) (twins.Page, error) {
 	span := createSpan(ctx, trm.tracer, retrieveAllTwinsOp)
 	defer span.Finish()
 	ctx = opentracing.ContextWithSpan(ctx, span)
 
-	return trm.repo.RetrieveAll(ctx, owner, offset, limit, name, metadata)
+	return trm.repo.RetrieveAll(ctx, acc, offset, limit, name, metadata)
 }
 
 func (trm twinRepositoryMiddleware) RetrieveByAttribute(ctx context.Context, channel, subtopic string) ([]string, error) {
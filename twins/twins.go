@@ -27,10 +27,46 @@ type Definition struct {
 	Delta      int64       `json:"delta"`
 }
 
+// Users group roles required to read and to write the twins shared with
+// the group.
+const (
+	viewerRole = "viewer"
+	editorRole = "editor"
+)
+
+// Access specifies the twins the user is allowed to access. These are the
+// ones owned by the user, together with the ones shared with the listed
+// users groups. If All is set, all of the twins are accessible, which is the
+// case when the platform admin reads them.
+type Access struct {
+	Owner      string
+	UserGroups []string
+	All        bool
+}
+
+// Shares checks whether the users group is one of the groups the access is
+// shared with.
+func (acc Access) Shares(group string) bool {
+	for _, g := range acc.UserGroups {
+		if g == group {
+			return true
+		}
+	}
+
+	return false
+}
+
+// Allows checks whether the twin is accessible.
+func (acc Access) Allows(tw Twin) bool {
+	return acc.All || tw.Owner == acc.Owner || acc.Shares(tw.UserGroup)
+}
+
 // Twin is a Mainflux data system representation. Each twin is owned
-// by a single user, and is assigned with the unique identifier.
+// by a single user, and is assigned with the unique identifier. The twin
+// may be shared with the members of the users group.
 type Twin struct {
 	Owner       string
+	UserGroup   string
 	ID          string
 	Name        string
 	Created     time.Time
@@ -70,8 +106,9 @@ type TwinRepository interface {
 	// the attribute with given channel and subtopic
 	RetrieveByAttribute(ctx context.Context, channel, subtopic string) ([]string, error)
 
-	// RetrieveAll retrieves the subset of twins owned by the specified user.
-	RetrieveAll(ctx context.Context, owner string, offset, limit uint64, name string, metadata Metadata) (Page, error)
+	// RetrieveAll retrieves the subset of twins accessible using the
+	// provided access.
+	RetrieveAll(ctx context.Context, acc Access, offset, limit uint64, name string, metadata Metadata) (Page, error)
 
 	// Remove removes the twin having the provided identifier.
 	Remove(ctx context.Context, twinID string) error
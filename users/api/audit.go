@@ -0,0 +1,286 @@
+// Copyright (c) Mainflux
+// SPDX-License-Identifier: Apache-2.0
+
+package api
+
+import (
+	"context"
+
+	"github.com/mainflux/mainflux/pkg/audit"
+	"github.com/mainflux/mainflux/users"
+)
+
+var _ users.Service = (*auditMiddleware)(nil)
+
+type auditMiddleware struct {
+	rec audit.Recorder
+	svc users.Service
+}
+
+// AuditMiddleware records the operations changing the users, groups and
+// credentials. Read-only operations are not recorded.
+func AuditMiddleware(svc users.Service, rec audit.Recorder) users.Service {
+	return &auditMiddleware{rec, svc}
+}
+
+func (am *auditMiddleware) Register(ctx context.Context, user users.User) (uid string, err error) {
+	e := audit.Event{Operation: "register", Actor: user.Email}
+	defer func() {
+		e.Entity = uid
+		am.rec.Record(ctx, e, err)
+	}()
+
+	return am.svc.Register(ctx, user)
+}
+
+func (am *auditMiddleware) VerifyEmail(ctx context.Context, token string) (err error) {
+	e := am.rec.Event(ctx, token, "verify_email", "")
+	defer func() { am.rec.Record(ctx, e, err) }()
+
+	return am.svc.VerifyEmail(ctx, token)
+}
+
+func (am *auditMiddleware) ResendVerification(ctx context.Context, email string) error {
+	return am.svc.ResendVerification(ctx, email)
+}
+
+func (am *auditMiddleware) Login(ctx context.Context, user users.User, ip string) (token, refreshToken string, err error) {
+	e := audit.Event{Operation: "login", Actor: user.Email}
+	defer func() {
+		if err == nil {
+			e = am.rec.Event(ctx, token, e.Operation, "")
+		}
+		am.rec.Record(ctx, e, err)
+	}()
+
+	return am.svc.Login(ctx, user, ip)
+}
+
+func (am *auditMiddleware) LoginTOTP(ctx context.Context, challenge, code string) (token, refreshToken string, err error) {
+	// The user is known only once the challenge is verified, so only the
+	// successful logins are recorded.
+	defer func() {
+		if err == nil {
+			am.rec.Record(ctx, am.rec.Event(ctx, token, "login_totp", ""), nil)
+		}
+	}()
+
+	return am.svc.LoginTOTP(ctx, challenge, code)
+}
+
+func (am *auditMiddleware) Refresh(ctx context.Context, refreshToken string) (string, string, error) {
+	return am.svc.Refresh(ctx, refreshToken)
+}
+
+func (am *auditMiddleware) OIDCAuthURL(ctx context.Context, state string) (string, error) {
+	return am.svc.OIDCAuthURL(ctx, state)
+}
+
+func (am *auditMiddleware) OIDCLogin(ctx context.Context, code, state string) (token, refreshToken string, err error) {
+	defer func() {
+		if err == nil {
+			am.rec.Record(ctx, am.rec.Event(ctx, token, "oidc_login", ""), nil)
+		}
+	}()
+
+	return am.svc.OIDCLogin(ctx, code, state)
+}
+
+func (am *auditMiddleware) Logout(ctx context.Context, token string) (err error) {
+	e := am.rec.Event(ctx, token, "logout", "")
+	defer func() { am.rec.Record(ctx, e, err) }()
+
+	return am.svc.Logout(ctx, token)
+}
+
+func (am *auditMiddleware) EnrollTOTP(ctx context.Context, token string) (enr users.TOTPEnrollment, err error) {
+	e := am.rec.Event(ctx, token, "enroll_totp", "")
+	defer func() { am.rec.Record(ctx, e, err) }()
+
+	return am.svc.EnrollTOTP(ctx, token)
+}
+
+func (am *auditMiddleware) ConfirmTOTP(ctx context.Context, token, code string) (codes []string, err error) {
+	e := am.rec.Event(ctx, token, "confirm_totp", "")
+	defer func() { am.rec.Record(ctx, e, err) }()
+
+	return am.svc.ConfirmTOTP(ctx, token, code)
+}
+
+func (am *auditMiddleware) DisableTOTP(ctx context.Context, token, code string) (err error) {
+	e := am.rec.Event(ctx, token, "disable_totp", "")
+	defer func() { am.rec.Record(ctx, e, err) }()
+
+	return am.svc.DisableTOTP(ctx, token, code)
+}
+
+func (am *auditMiddleware) ResetTOTP(ctx context.Context, token, id string) (err error) {
+	e := am.rec.Event(ctx, token, "reset_totp", id)
+	defer func() { am.rec.Record(ctx, e, err) }()
+
+	return am.svc.ResetTOTP(ctx, token, id)
+}
+
+func (am *auditMiddleware) Unlock(ctx context.Context, token, id string) (err error) {
+	e := am.rec.Event(ctx, token, "unlock", id)
+	defer func() { am.rec.Record(ctx, e, err) }()
+
+	return am.svc.Unlock(ctx, token, id)
+}
+
+func (am *auditMiddleware) DisableUser(ctx context.Context, token, id string) (err error) {
+	e := am.rec.Event(ctx, token, "disable_user", id)
+	defer func() { am.rec.Record(ctx, e, err) }()
+
+	return am.svc.DisableUser(ctx, token, id)
+}
+
+func (am *auditMiddleware) EnableUser(ctx context.Context, token, id string) (err error) {
+	e := am.rec.Event(ctx, token, "enable_user", id)
+	defer func() { am.rec.Record(ctx, e, err) }()
+
+	return am.svc.EnableUser(ctx, token, id)
+}
+
+func (am *auditMiddleware) Impersonate(ctx context.Context, token, id, reason string) (t string, err error) {
+	e := am.rec.Event(ctx, token, "impersonate", id)
+	defer func() { am.rec.Record(ctx, e, err) }()
+
+	return am.svc.Impersonate(ctx, token, id, reason)
+}
+
+func (am *auditMiddleware) ListImpersonations(ctx context.Context, token, userID string, offset, limit uint64) (users.ImpersonationPage, error) {
+	return am.svc.ListImpersonations(ctx, token, userID, offset, limit)
+}
+
+func (am *auditMiddleware) ViewUser(ctx context.Context, token, id string) (users.User, error) {
+	return am.svc.ViewUser(ctx, token, id)
+}
+
+func (am *auditMiddleware) ViewProfile(ctx context.Context, token string) (users.User, error) {
+	return am.svc.ViewProfile(ctx, token)
+}
+
+func (am *auditMiddleware) ListUsers(ctx context.Context, token string, offset, limit uint64, cursor, email string, um users.Metadata) (users.UserPage, error) {
+	return am.svc.ListUsers(ctx, token, offset, limit, cursor, email, um)
+}
+
+func (am *auditMiddleware) UpdateUser(ctx context.Context, token string, u users.User) (err error) {
+	e := am.rec.Event(ctx, token, "update_user", u.ID)
+	defer func() { am.rec.Record(ctx, e, err) }()
+
+	return am.svc.UpdateUser(ctx, token, u)
+}
+
+func (am *auditMiddleware) GenerateResetToken(ctx context.Context, email, host string) (err error) {
+	e := audit.Event{Operation: "generate_reset_token", Actor: email}
+	defer func() { am.rec.Record(ctx, e, err) }()
+
+	return am.svc.GenerateResetToken(ctx, email, host)
+}
+
+func (am *auditMiddleware) ChangePassword(ctx context.Context, authToken, password, oldPassword string) (err error) {
+	e := am.rec.Event(ctx, authToken, "change_password", "")
+	defer func() { am.rec.Record(ctx, e, err) }()
+
+	return am.svc.ChangePassword(ctx, authToken, password, oldPassword)
+}
+
+func (am *auditMiddleware) ResetPassword(ctx context.Context, resetToken, password string) (err error) {
+	e := am.rec.Event(ctx, resetToken, "reset_password", "")
+	defer func() { am.rec.Record(ctx, e, err) }()
+
+	return am.svc.ResetPassword(ctx, resetToken, password)
+}
+
+func (am *auditMiddleware) SendPasswordReset(ctx context.Context, host, email, token string) error {
+	return am.svc.SendPasswordReset(ctx, host, email, token)
+}
+
+func (am *auditMiddleware) CreateGroup(ctx context.Context, token string, group users.Group) (g users.Group, err error) {
+	e := am.rec.Event(ctx, token, "create_group", "")
+	defer func() {
+		e.Entity = g.ID
+		am.rec.Record(ctx, e, err)
+	}()
+
+	return am.svc.CreateGroup(ctx, token, group)
+}
+
+func (am *auditMiddleware) UpdateGroup(ctx context.Context, token string, group users.Group) (err error) {
+	e := am.rec.Event(ctx, token, "update_group", group.ID)
+	defer func() { am.rec.Record(ctx, e, err) }()
+
+	return am.svc.UpdateGroup(ctx, token, group)
+}
+
+func (am *auditMiddleware) ViewGroup(ctx context.Context, token, id string) (users.Group, error) {
+	return am.svc.ViewGroup(ctx, token, id)
+}
+
+func (am *auditMiddleware) ListGroups(ctx context.Context, token, parentID string, offset, limit uint64, um users.Metadata) (users.GroupPage, error) {
+	return am.svc.ListGroups(ctx, token, parentID, offset, limit, um)
+}
+
+func (am *auditMiddleware) ListMembers(ctx context.Context, token, groupID string, offset, limit uint64, um users.Metadata) (users.UserPage, error) {
+	return am.svc.ListMembers(ctx, token, groupID, offset, limit, um)
+}
+
+func (am *auditMiddleware) ListMemberships(ctx context.Context, token, groupID string, offset, limit uint64, um users.Metadata) (users.GroupPage, error) {
+	return am.svc.ListMemberships(ctx, token, groupID, offset, limit, um)
+}
+
+func (am *auditMiddleware) RemoveGroup(ctx context.Context, token, id string) (err error) {
+	e := am.rec.Event(ctx, token, "remove_group", id)
+	defer func() { am.rec.Record(ctx, e, err) }()
+
+	return am.svc.RemoveGroup(ctx, token, id)
+}
+
+func (am *auditMiddleware) Assign(ctx context.Context, token, userID, groupID, role string) (err error) {
+	e := am.rec.Event(ctx, token, "assign", groupID)
+	defer func() { am.rec.Record(ctx, e, err) }()
+
+	return am.svc.Assign(ctx, token, userID, groupID, role)
+}
+
+func (am *auditMiddleware) Unassign(ctx context.Context, token, userID, groupID string) (err error) {
+	e := am.rec.Event(ctx, token, "unassign", groupID)
+	defer func() { am.rec.Record(ctx, e, err) }()
+
+	return am.svc.Unassign(ctx, token, userID, groupID)
+}
+
+func (am *auditMiddleware) Invite(ctx context.Context, token, groupID, email, role string) (inv users.Invitation, err error) {
+	e := am.rec.Event(ctx, token, "invite", groupID)
+	defer func() { am.rec.Record(ctx, e, err) }()
+
+	return am.svc.Invite(ctx, token, groupID, email, role)
+}
+
+func (am *auditMiddleware) ListInvitations(ctx context.Context, token, groupID string, offset, limit uint64) (users.InvitationPage, error) {
+	return am.svc.ListInvitations(ctx, token, groupID, offset, limit)
+}
+
+func (am *auditMiddleware) RevokeInvitation(ctx context.Context, token, id string) (err error) {
+	e := am.rec.Event(ctx, token, "revoke_invitation", id)
+	defer func() { am.rec.Record(ctx, e, err) }()
+
+	return am.svc.RevokeInvitation(ctx, token, id)
+}
+
+func (am *auditMiddleware) AcceptInvitation(ctx context.Context, invToken, password string) (id string, err error) {
+	// Invitation token doesn't identify the user, so only the accepted
+	// invitations are recorded, with the ID of the invited user.
+	defer func() {
+		if err == nil {
+			am.rec.Record(ctx, audit.Event{Operation: "accept_invitation", Entity: id}, nil)
+		}
+	}()
+
+	return am.svc.AcceptInvitation(ctx, invToken, password)
+}
+
+func (am *auditMiddleware) Authorize(ctx context.Context, email, role string) ([]string, error) {
+	return am.svc.Authorize(ctx, email, role)
+}
@@ -5,11 +5,15 @@ package api
 
 import (
 	"context"
+	"crypto/rand"
+	"encoding/hex"
 
 	"github.com/go-kit/kit/endpoint"
 	"github.com/mainflux/mainflux/users"
 )
 
+const stateSize = 16
+
 func registrationEndpoint(svc users.Service) endpoint.Endpoint {
 	return func(ctx context.Context, request interface{}) (interface{}, error) {
 		req := request.(userReq)
@@ -89,6 +93,7 @@ func viewUserEndpoint(svc users.Service) endpoint.Endpoint {
 			ID:       u.ID,
 			Email:    u.Email,
 			Metadata: u.Metadata,
+			Status:   u.Status,
 		}, nil
 	}
 }
@@ -108,6 +113,7 @@ func viewProfileEndpoint(svc users.Service) endpoint.Endpoint {
 			ID:       u.ID,
 			Email:    u.Email,
 			Metadata: u.Metadata,
+			Status:   u.Status,
 		}, nil
 	}
 }
@@ -118,7 +124,7 @@ func listUsersEndpoint(svc users.Service) endpoint.Endpoint {
 		if err := req.validate(); err != nil {
 			return users.UserPage{}, err
 		}
-		up, err := svc.ListUsers(ctx, req.token, req.offset, req.limit, req.email, req.metadata)
+		up, err := svc.ListUsers(ctx, req.token, req.offset, req.limit, req.cursor, req.email, req.metadata)
 		if err != nil {
 			return users.UserPage{}, err
 		}
@@ -157,18 +163,285 @@ func passwordChangeEndpoint(svc users.Service) endpoint.Endpoint {
 	}
 }
 
+func verifyEmailEndpoint(svc users.Service) endpoint.Endpoint {
+	return func(ctx context.Context, request interface{}) (interface{}, error) {
+		req := request.(verifyEmailReq)
+		if err := req.validate(); err != nil {
+			return nil, err
+		}
+		if err := svc.VerifyEmail(ctx, req.Token); err != nil {
+			return nil, err
+		}
+
+		return verifyEmailRes{}, nil
+	}
+}
+
+func resendVerificationEndpoint(svc users.Service) endpoint.Endpoint {
+	return func(ctx context.Context, request interface{}) (interface{}, error) {
+		req := request.(resendVerificationReq)
+		if err := req.validate(); err != nil {
+			return nil, err
+		}
+		if err := svc.ResendVerification(ctx, req.Email); err != nil {
+			return nil, err
+		}
+
+		return resendVerificationRes{}, nil
+	}
+}
+
 func loginEndpoint(svc users.Service) endpoint.Endpoint {
 	return func(ctx context.Context, request interface{}) (interface{}, error) {
-		req := request.(userReq)
+		req := request.(loginReq)
 		if err := req.validate(); err != nil {
 			return nil, err
 		}
-		token, err := svc.Login(ctx, req.user)
+		access, refresh, err := svc.Login(ctx, req.user, req.ip)
 		if err != nil {
 			return nil, err
 		}
+		// Only the challenge token is issued if the user has two-factor
+		// authentication enabled.
+		if refresh == "" {
+			return tokenRes{ChallengeToken: access}, nil
+		}
 
-		return tokenRes{token}, nil
+		return tokenRes{Token: access, RefreshToken: refresh}, nil
+	}
+}
+
+func loginTOTPEndpoint(svc users.Service) endpoint.Endpoint {
+	return func(ctx context.Context, request interface{}) (interface{}, error) {
+		req := request.(loginTOTPReq)
+		if err := req.validate(); err != nil {
+			return nil, err
+		}
+		access, refresh, err := svc.LoginTOTP(ctx, req.ChallengeToken, req.Code)
+		if err != nil {
+			return nil, err
+		}
+
+		return tokenRes{Token: access, RefreshToken: refresh}, nil
+	}
+}
+
+func enrollTOTPEndpoint(svc users.Service) endpoint.Endpoint {
+	return func(ctx context.Context, request interface{}) (interface{}, error) {
+		req := request.(viewUserReq)
+		if err := req.validate(); err != nil {
+			return nil, err
+		}
+		e, err := svc.EnrollTOTP(ctx, req.token)
+		if err != nil {
+			return nil, err
+		}
+
+		return enrollTOTPRes{Secret: e.Secret, URI: e.URI}, nil
+	}
+}
+
+func confirmTOTPEndpoint(svc users.Service) endpoint.Endpoint {
+	return func(ctx context.Context, request interface{}) (interface{}, error) {
+		req := request.(totpReq)
+		if err := req.validate(); err != nil {
+			return nil, err
+		}
+		codes, err := svc.ConfirmTOTP(ctx, req.token, req.Code)
+		if err != nil {
+			return nil, err
+		}
+
+		return confirmTOTPRes{RecoveryCodes: codes}, nil
+	}
+}
+
+func disableTOTPEndpoint(svc users.Service) endpoint.Endpoint {
+	return func(ctx context.Context, request interface{}) (interface{}, error) {
+		req := request.(totpReq)
+		if err := req.validate(); err != nil {
+			return nil, err
+		}
+		if err := svc.DisableTOTP(ctx, req.token, req.Code); err != nil {
+			return nil, err
+		}
+
+		return disableTOTPRes{}, nil
+	}
+}
+
+func resetTOTPEndpoint(svc users.Service) endpoint.Endpoint {
+	return func(ctx context.Context, request interface{}) (interface{}, error) {
+		req := request.(viewUserReq)
+		if err := req.validate(); err != nil {
+			return nil, err
+		}
+		if err := svc.ResetTOTP(ctx, req.token, req.userID); err != nil {
+			return nil, err
+		}
+
+		return disableTOTPRes{}, nil
+	}
+}
+
+func disableUserEndpoint(svc users.Service) endpoint.Endpoint {
+	return func(ctx context.Context, request interface{}) (interface{}, error) {
+		req := request.(viewUserReq)
+		if err := req.validate(); err != nil {
+			return nil, err
+		}
+		if err := svc.DisableUser(ctx, req.token, req.userID); err != nil {
+			return nil, err
+		}
+
+		return userStatusRes{}, nil
+	}
+}
+
+func enableUserEndpoint(svc users.Service) endpoint.Endpoint {
+	return func(ctx context.Context, request interface{}) (interface{}, error) {
+		req := request.(viewUserReq)
+		if err := req.validate(); err != nil {
+			return nil, err
+		}
+		if err := svc.EnableUser(ctx, req.token, req.userID); err != nil {
+			return nil, err
+		}
+
+		return userStatusRes{}, nil
+	}
+}
+
+func impersonateEndpoint(svc users.Service) endpoint.Endpoint {
+	return func(ctx context.Context, request interface{}) (interface{}, error) {
+		req := request.(impersonateReq)
+		if err := req.validate(); err != nil {
+			return nil, err
+		}
+
+		token, err := svc.Impersonate(ctx, req.token, req.userID, req.Reason)
+		if err != nil {
+			return nil, err
+		}
+
+		return tokenRes{Token: token}, nil
+	}
+}
+
+func listImpersonationsEndpoint(svc users.Service) endpoint.Endpoint {
+	return func(ctx context.Context, request interface{}) (interface{}, error) {
+		req := request.(listImpersonationsReq)
+		if err := req.validate(); err != nil {
+			return nil, err
+		}
+
+		ip, err := svc.ListImpersonations(ctx, req.token, req.userID, req.offset, req.limit)
+		if err != nil {
+			return nil, err
+		}
+
+		res := impersonationPageRes{
+			pageRes: pageRes{
+				Total:  ip.Total,
+				Offset: ip.Offset,
+				Limit:  ip.Limit,
+			},
+			Impersonations: []impersonationRes{},
+		}
+		for _, imp := range ip.Impersonations {
+			res.Impersonations = append(res.Impersonations, impersonationRes{
+				ID:      imp.ID,
+				AdminID: imp.AdminID,
+				UserID:  imp.UserID,
+				Reason:  imp.Reason,
+				Created: imp.Created,
+			})
+		}
+
+		return res, nil
+	}
+}
+
+func unlockEndpoint(svc users.Service) endpoint.Endpoint {
+	return func(ctx context.Context, request interface{}) (interface{}, error) {
+		req := request.(viewUserReq)
+		if err := req.validate(); err != nil {
+			return nil, err
+		}
+		if err := svc.Unlock(ctx, req.token, req.userID); err != nil {
+			return nil, err
+		}
+
+		return unlockRes{}, nil
+	}
+}
+
+func refreshEndpoint(svc users.Service) endpoint.Endpoint {
+	return func(ctx context.Context, request interface{}) (interface{}, error) {
+		req := request.(refreshReq)
+		if err := req.validate(); err != nil {
+			return nil, err
+		}
+		access, refresh, err := svc.Refresh(ctx, req.RefreshToken)
+		if err != nil {
+			return nil, err
+		}
+
+		return tokenRes{Token: access, RefreshToken: refresh}, nil
+	}
+}
+
+func oidcRedirectEndpoint(svc users.Service) endpoint.Endpoint {
+	return func(ctx context.Context, request interface{}) (interface{}, error) {
+		state, err := newState()
+		if err != nil {
+			return nil, err
+		}
+		url, err := svc.OIDCAuthURL(ctx, state)
+		if err != nil {
+			return nil, err
+		}
+
+		return oidcRedirectRes{url: url, state: state}, nil
+	}
+}
+
+func oidcCallbackEndpoint(svc users.Service) endpoint.Endpoint {
+	return func(ctx context.Context, request interface{}) (interface{}, error) {
+		req := request.(oidcCallbackReq)
+		if err := req.validate(); err != nil {
+			return nil, err
+		}
+		access, refresh, err := svc.OIDCLogin(ctx, req.code, req.state)
+		if err != nil {
+			return nil, err
+		}
+
+		return tokenRes{Token: access, RefreshToken: refresh}, nil
+	}
+}
+
+// newState generates random state used to protect the OpenID Connect
+// callback from forgery.
+func newState() (string, error) {
+	b := make([]byte, stateSize)
+	if _, err := rand.Read(b); err != nil {
+		return "", err
+	}
+	return hex.EncodeToString(b), nil
+}
+
+func logoutEndpoint(svc users.Service) endpoint.Endpoint {
+	return func(ctx context.Context, request interface{}) (interface{}, error) {
+		req := request.(logoutReq)
+		if err := req.validate(); err != nil {
+			return nil, err
+		}
+		if err := svc.Logout(ctx, req.token); err != nil {
+			return nil, err
+		}
+
+		return logoutRes{}, nil
 	}
 }
 
@@ -203,11 +476,11 @@ func createGroupEndpoint(svc users.Service) endpoint.Endpoint {
 
 func assignUserToGroup(svc users.Service) endpoint.Endpoint {
 	return func(ctx context.Context, request interface{}) (interface{}, error) {
-		req := request.(userGroupReq)
+		req := request.(assignReq)
 		if err := req.validate(); err != nil {
 			return nil, err
 		}
-		if err := svc.Assign(ctx, req.token, req.userID, req.groupID); err != nil {
+		if err := svc.Assign(ctx, req.token, req.userID, req.groupID, req.role); err != nil {
 			return nil, err
 		}
 		return assignUserToGroupRes{}, nil
@@ -351,9 +624,10 @@ func buildGroupsResponse(gp users.GroupPage) groupPageRes {
 func buildUsersResponse(up users.UserPage) userPageRes {
 	res := userPageRes{
 		pageRes: pageRes{
-			Total:  up.Total,
-			Offset: up.Offset,
-			Limit:  up.Limit,
+			Total:      up.Total,
+			Offset:     up.Offset,
+			Limit:      up.Limit,
+			NextCursor: up.NextCursor,
 		},
 		Users: []viewUserRes{},
 	}
@@ -362,8 +636,98 @@ func buildUsersResponse(up users.UserPage) userPageRes {
 			ID:       user.ID,
 			Email:    user.Email,
 			Metadata: user.Metadata,
+			Status:   user.Status,
 		}
 		res.Users = append(res.Users, view)
 	}
 	return res
 }
+
+func inviteEndpoint(svc users.Service) endpoint.Endpoint {
+	return func(ctx context.Context, request interface{}) (interface{}, error) {
+		req := request.(inviteReq)
+		if err := req.validate(); err != nil {
+			return nil, err
+		}
+
+		inv, err := svc.Invite(ctx, req.token, req.groupID, req.Email, req.Role)
+		if err != nil {
+			return nil, err
+		}
+
+		res := toInvitationRes(inv)
+		res.created = true
+		return res, nil
+	}
+}
+
+func listInvitationsEndpoint(svc users.Service) endpoint.Endpoint {
+	return func(ctx context.Context, request interface{}) (interface{}, error) {
+		req := request.(listInvitationsReq)
+		if err := req.validate(); err != nil {
+			return nil, err
+		}
+
+		ip, err := svc.ListInvitations(ctx, req.token, req.groupID, req.offset, req.limit)
+		if err != nil {
+			return nil, err
+		}
+
+		res := invitationPageRes{
+			pageRes: pageRes{
+				Total:  ip.Total,
+				Offset: ip.Offset,
+				Limit:  ip.Limit,
+			},
+			Invitations: []invitationRes{},
+		}
+		for _, inv := range ip.Invitations {
+			res.Invitations = append(res.Invitations, toInvitationRes(inv))
+		}
+
+		return res, nil
+	}
+}
+
+func revokeInvitationEndpoint(svc users.Service) endpoint.Endpoint {
+	return func(ctx context.Context, request interface{}) (interface{}, error) {
+		req := request.(invitationReq)
+		if err := req.validate(); err != nil {
+			return nil, err
+		}
+
+		if err := svc.RevokeInvitation(ctx, req.token, req.id); err != nil {
+			return nil, err
+		}
+
+		return revokeInvitationRes{}, nil
+	}
+}
+
+func acceptInvitationEndpoint(svc users.Service) endpoint.Endpoint {
+	return func(ctx context.Context, request interface{}) (interface{}, error) {
+		req := request.(acceptInvitationReq)
+		if err := req.validate(); err != nil {
+			return nil, err
+		}
+
+		id, err := svc.AcceptInvitation(ctx, req.Token, req.Password)
+		if err != nil {
+			return nil, err
+		}
+
+		return acceptInvitationRes{ID: id}, nil
+	}
+}
+
+func toInvitationRes(inv users.Invitation) invitationRes {
+	return invitationRes{
+		ID:        inv.ID,
+		GroupID:   inv.GroupID,
+		Email:     inv.Email,
+		Role:      inv.Role,
+		InvitedBy: inv.InvitedBy,
+		Created:   inv.Created,
+		Expires:   inv.Expires,
+	}
+}
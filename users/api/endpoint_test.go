@@ -13,12 +13,15 @@ import (
 	"net/http/httptest"
 	"strings"
 	"testing"
+	"time"
 
 	"github.com/mainflux/mainflux"
+	"github.com/mainflux/mainflux/authn"
 	"github.com/mainflux/mainflux/users"
 	"github.com/mainflux/mainflux/users/api"
 	"github.com/mainflux/mainflux/users/bcrypt"
 	"github.com/mainflux/mainflux/users/mocks"
+	"github.com/mainflux/mainflux/users/totp"
 	"github.com/opentracing/opentracing-go/mocktracer"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
@@ -27,10 +30,16 @@ import (
 const (
 	contentType  = "application/json"
 	invalidEmail = "userexample.com"
+	oidcEmail    = "oidc@example.com"
+	oidcCode     = "oidc-code"
+	invitee      = "invitee@example.com"
 )
 
 var (
 	user           = users.User{Email: "user@example.com", Password: "password"}
+	admin          = users.User{Email: "admin@example.com", Password: "password"}
+	lockout        = users.LockoutPolicy{MaxAttempts: 3, MaxIPAttempts: 5, Duration: time.Minute}
+	invitation     = users.InvitationPolicy{Secret: "secret", Duration: time.Hour}
 	notFoundRes    = toJSON(errorRes{users.ErrUserNotFound.Error()})
 	unauthRes      = toJSON(errorRes{users.ErrUnauthorizedAccess.Error()})
 	malformedRes   = toJSON(errorRes{users.ErrMalformedEntity.Error()})
@@ -65,123 +74,789 @@ func (tr testRequest) make() (*http.Response, error) {
 }
 
 func newService() users.Service {
+	svc, _ := newVerificationService(users.VerificationPolicy{})
+	return svc
+}
+
+func newVerificationService(policy users.VerificationPolicy) (users.Service, *mocks.Emailer) {
+	return newPolicyService(policy, invitation)
+}
+
+func newPolicyService(vp users.VerificationPolicy, ip users.InvitationPolicy) (users.Service, *mocks.Emailer) {
 	usersRepo := mocks.NewUserRepository()
 	groupRepo := mocks.NewGroupRepository()
 	hasher := bcrypt.New()
-	auth := mocks.NewAuthService(map[string]string{user.Email: user.Email})
+	auth := mocks.NewAdminAuthService(map[string]string{user.Email: user.Email, admin.Email: admin.Email, oidcEmail: oidcEmail, invitee: invitee}, admin.Email)
 	email := mocks.NewEmailer()
+	idp := mocks.NewIdentityProvider(map[string]users.ExternalIdentity{oidcCode: {Email: oidcEmail}})
+
+	return users.New(usersRepo, groupRepo, mocks.NewInvitationRepository(), mocks.NewImpersonationRepository(), hasher, auth, email, idp, users.NewAttemptRepository(), lockout, vp, ip, admin.Email), email
+}
+
+func newServer(svc users.Service) *httptest.Server {
+	mux := api.MakeHandler(svc, mocktracer.New())
+	return httptest.NewServer(mux)
+}
+
+type tokenRes struct {
+	Token          string `json:"token,omitempty"`
+	RefreshToken   string `json:"refresh_token,omitempty"`
+	ChallengeToken string `json:"challenge_token,omitempty"`
+}
+
+func toJSON(data interface{}) string {
+	jsonData, _ := json.Marshal(data)
+	return string(jsonData)
+}
+
+func TestRegister(t *testing.T) {
+	svc := newService()
+	ts := newServer(svc)
+	defer ts.Close()
+	client := ts.Client()
+
+	data := toJSON(user)
+	invalidData := toJSON(users.User{Email: invalidEmail, Password: "password"})
+	invalidFieldData := fmt.Sprintf(`{"email": "%s", "pass": "%s"}`, user.Email, user.Password)
+
+	cases := []struct {
+		desc        string
+		req         string
+		contentType string
+		status      int
+	}{
+		{"register new user", data, contentType, http.StatusCreated},
+		{"register existing user", data, contentType, http.StatusConflict},
+		{"register user with invalid email address", invalidData, contentType, http.StatusBadRequest},
+		{"register user with invalid request format", "{", contentType, http.StatusBadRequest},
+		{"register user with empty JSON request", "{}", contentType, http.StatusBadRequest},
+		{"register user with empty request", "", contentType, http.StatusBadRequest},
+		{"register user with invalid field name", invalidFieldData, contentType, http.StatusBadRequest},
+		{"register user with missing content type", data, "", http.StatusUnsupportedMediaType},
+	}
+
+	for _, tc := range cases {
+		req := testRequest{
+			client:      client,
+			method:      http.MethodPost,
+			url:         fmt.Sprintf("%s/users", ts.URL),
+			contentType: tc.contentType,
+			body:        strings.NewReader(tc.req),
+		}
+		res, err := req.make()
+		assert.Nil(t, err, fmt.Sprintf("%s: unexpected error %s", tc.desc, err))
+		assert.Equal(t, tc.status, res.StatusCode, fmt.Sprintf("%s: expected status code %d got %d", tc.desc, tc.status, res.StatusCode))
+	}
+}
+
+func TestLogin(t *testing.T) {
+	svc := newService()
+	ts := newServer(svc)
+	defer ts.Close()
+	client := ts.Client()
+	auth := mocks.NewAuthService(map[string]string{user.Email: user.Email})
+	tkn, _ := auth.Issue(context.Background(), &mainflux.IssueReq{Id: user.ID, Email: user.Email, Type: 0})
+	token := tkn.GetValue()
+	rtkn, _ := auth.Issue(context.Background(), &mainflux.IssueReq{Id: user.ID, Email: user.Email, Type: authn.RefreshKey})
+	tokenData := toJSON(tokenRes{Token: token, RefreshToken: rtkn.GetValue()})
+	data := toJSON(user)
+	invalidEmailData := toJSON(users.User{
+		Email:    invalidEmail,
+		Password: "password",
+	})
+	invalidData := toJSON(users.User{
+		Email:    "user@example.com",
+		Password: "invalid_password",
+	})
+	nonexistentData := toJSON(users.User{
+		Email:    "non-existentuser@example.com",
+		Password: "password",
+	})
+	_, err := svc.Register(context.Background(), user)
+	require.Nil(t, err, fmt.Sprintf("register user got unexpected error: %s", err))
+
+	cases := []struct {
+		desc        string
+		req         string
+		contentType string
+		status      int
+		res         string
+	}{
+		{"login with valid credentials", data, contentType, http.StatusCreated, tokenData},
+		{"login with invalid credentials", invalidData, contentType, http.StatusForbidden, unauthRes},
+		{"login with invalid email address", invalidEmailData, contentType, http.StatusBadRequest, malformedRes},
+		{"login non-existent user", nonexistentData, contentType, http.StatusForbidden, unauthRes},
+		{"login with invalid request format", "{", contentType, http.StatusBadRequest, malformedRes},
+		{"login with empty JSON request", "{}", contentType, http.StatusBadRequest, malformedRes},
+		{"login with empty request", "", contentType, http.StatusBadRequest, malformedRes},
+		{"login with missing content type", data, "", http.StatusUnsupportedMediaType, unsupportedRes},
+	}
+
+	for _, tc := range cases {
+		req := testRequest{
+			client:      client,
+			method:      http.MethodPost,
+			url:         fmt.Sprintf("%s/tokens", ts.URL),
+			contentType: tc.contentType,
+			body:        strings.NewReader(tc.req),
+		}
+		res, err := req.make()
+		assert.Nil(t, err, fmt.Sprintf("%s: unexpected error %s", tc.desc, err))
+		body, err := ioutil.ReadAll(res.Body)
+		assert.Nil(t, err, fmt.Sprintf("%s: unexpected error %s", tc.desc, err))
+		token := strings.Trim(string(body), "\n")
+
+		assert.Equal(t, tc.status, res.StatusCode, fmt.Sprintf("%s: expected status code %d got %d", tc.desc, tc.status, res.StatusCode))
+		assert.Equal(t, tc.res, token, fmt.Sprintf("%s: expected body %s got %s", tc.desc, tc.res, token))
+	}
+}
+
+func TestLogout(t *testing.T) {
+	svc := newService()
+	ts := newServer(svc)
+	defer ts.Close()
+	client := ts.Client()
+	_, err := svc.Register(context.Background(), user)
+	require.Nil(t, err, fmt.Sprintf("register user got unexpected error: %s", err))
+	token, _, err := svc.Login(context.Background(), user, "")
+	require.Nil(t, err, fmt.Sprintf("login got unexpected error: %s", err))
+
+	cases := []struct {
+		desc   string
+		token  string
+		status int
+	}{
+		{"logout with valid token", token, http.StatusNoContent},
+		{"logout with revoked token", token, http.StatusForbidden},
+		{"logout with invalid token", "invalid", http.StatusForbidden},
+		{"logout without token", "", http.StatusForbidden},
+	}
+
+	for _, tc := range cases {
+		req := testRequest{
+			client: client,
+			method: http.MethodDelete,
+			url:    fmt.Sprintf("%s/tokens", ts.URL),
+			token:  tc.token,
+		}
+		res, err := req.make()
+		assert.Nil(t, err, fmt.Sprintf("%s: unexpected error %s", tc.desc, err))
+		assert.Equal(t, tc.status, res.StatusCode, fmt.Sprintf("%s: expected status code %d got %d", tc.desc, tc.status, res.StatusCode))
+	}
+}
+
+func TestRefresh(t *testing.T) {
+	svc := newService()
+	ts := newServer(svc)
+	defer ts.Close()
+	client := ts.Client()
+	_, err := svc.Register(context.Background(), user)
+	require.Nil(t, err, fmt.Sprintf("register user got unexpected error: %s", err))
+	_, refreshToken, err := svc.Login(context.Background(), user, "")
+	require.Nil(t, err, fmt.Sprintf("login got unexpected error: %s", err))
+
+	data := toJSON(map[string]string{"refresh_token": refreshToken})
+
+	cases := []struct {
+		desc        string
+		req         string
+		contentType string
+		status      int
+	}{
+		{"refresh with valid refresh token", data, contentType, http.StatusCreated},
+		{"refresh with used refresh token", data, contentType, http.StatusForbidden},
+		{"refresh with invalid refresh token", toJSON(map[string]string{"refresh_token": "invalid"}), contentType, http.StatusForbidden},
+		{"refresh with access token", toJSON(map[string]string{"refresh_token": user.Email}), contentType, http.StatusForbidden},
+		{"refresh with empty JSON request", "{}", contentType, http.StatusBadRequest},
+		{"refresh with invalid request format", "{", contentType, http.StatusBadRequest},
+		{"refresh with missing content type", data, "", http.StatusUnsupportedMediaType},
+	}
+
+	for _, tc := range cases {
+		req := testRequest{
+			client:      client,
+			method:      http.MethodPost,
+			url:         fmt.Sprintf("%s/tokens/refresh", ts.URL),
+			contentType: tc.contentType,
+			body:        strings.NewReader(tc.req),
+		}
+		res, err := req.make()
+		assert.Nil(t, err, fmt.Sprintf("%s: unexpected error %s", tc.desc, err))
+		assert.Equal(t, tc.status, res.StatusCode, fmt.Sprintf("%s: expected status code %d got %d", tc.desc, tc.status, res.StatusCode))
+		if tc.status != http.StatusCreated {
+			continue
+		}
+		var tr tokenRes
+		err = json.NewDecoder(res.Body).Decode(&tr)
+		assert.Nil(t, err, fmt.Sprintf("%s: unexpected error %s", tc.desc, err))
+		assert.NotEmpty(t, tr.Token, fmt.Sprintf("%s: expected access token", tc.desc))
+		assert.NotEqual(t, refreshToken, tr.RefreshToken, fmt.Sprintf("%s: expected new refresh token", tc.desc))
+	}
+}
+
+func TestOIDCLogin(t *testing.T) {
+	svc := newService()
+	ts := newServer(svc)
+	defer ts.Close()
+	// Redirects are not followed, since the provider doesn't exist.
+	client := ts.Client()
+	client.CheckRedirect = func(*http.Request, []*http.Request) error {
+		return http.ErrUseLastResponse
+	}
+
+	res, err := client.Get(fmt.Sprintf("%s/oidc/login", ts.URL))
+	require.Nil(t, err, fmt.Sprintf("unexpected error %s", err))
+	assert.Equal(t, http.StatusFound, res.StatusCode, fmt.Sprintf("redirect to provider: expected status code %d got %d", http.StatusFound, res.StatusCode))
+	var state string
+	for _, c := range res.Cookies() {
+		if c.Name == "oidc_state" {
+			state = c.Value
+		}
+	}
+	require.NotEmpty(t, state, "redirect to provider expected to set state cookie")
+	assert.Equal(t, fmt.Sprintf("https://idp.example.com/authorize?state=%s", state), res.Header.Get("Location"), "redirect to provider: unexpected location")
+
+	cases := []struct {
+		desc   string
+		code   string
+		state  string
+		cookie string
+		status int
+	}{
+		{"login with valid code", oidcCode, state, state, http.StatusCreated},
+		{"login with invalid code", "invalid", state, state, http.StatusForbidden},
+		{"login with state not matching cookie", oidcCode, state, "other", http.StatusForbidden},
+		{"login without state cookie", oidcCode, state, "", http.StatusForbidden},
+		{"login without code", "", state, state, http.StatusBadRequest},
+		{"login without state", oidcCode, "", state, http.StatusBadRequest},
+	}
+
+	for _, tc := range cases {
+		req, err := http.NewRequest(http.MethodGet, fmt.Sprintf("%s/oidc/callback?code=%s&state=%s", ts.URL, tc.code, tc.state), nil)
+		require.Nil(t, err, fmt.Sprintf("%s: unexpected error %s", tc.desc, err))
+		if tc.cookie != "" {
+			req.AddCookie(&http.Cookie{Name: "oidc_state", Value: tc.cookie})
+		}
+		res, err := client.Do(req)
+		assert.Nil(t, err, fmt.Sprintf("%s: unexpected error %s", tc.desc, err))
+		assert.Equal(t, tc.status, res.StatusCode, fmt.Sprintf("%s: expected status code %d got %d", tc.desc, tc.status, res.StatusCode))
+	}
+}
+
+// enableTOTP enables two-factor authentication for the user identified by
+// the token and returns the TOTP secret and recovery codes.
+func enableTOTP(t *testing.T, svc users.Service, token string) (string, []string) {
+	e, err := svc.EnrollTOTP(context.Background(), token)
+	require.Nil(t, err, fmt.Sprintf("enrolling TOTP got unexpected error: %s", err))
+	code, err := totp.Generate(e.Secret, time.Now())
+	require.Nil(t, err, fmt.Sprintf("generating TOTP code got unexpected error: %s", err))
+	codes, err := svc.ConfirmTOTP(context.Background(), token, code)
+	require.Nil(t, err, fmt.Sprintf("confirming TOTP got unexpected error: %s", err))
+
+	return e.Secret, codes
+}
+
+func TestEnrollTOTP(t *testing.T) {
+	svc := newService()
+	ts := newServer(svc)
+	defer ts.Close()
+	client := ts.Client()
+	_, err := svc.Register(context.Background(), user)
+	require.Nil(t, err, fmt.Sprintf("register user got unexpected error: %s", err))
+	_, err = svc.Register(context.Background(), admin)
+	require.Nil(t, err, fmt.Sprintf("register user got unexpected error: %s", err))
+	enableTOTP(t, svc, admin.Email)
+
+	cases := []struct {
+		desc   string
+		token  string
+		status int
+	}{
+		{"enroll TOTP with valid token", user.Email, http.StatusCreated},
+		{"enroll TOTP again before confirmation", user.Email, http.StatusCreated},
+		{"enroll TOTP with TOTP already enabled", admin.Email, http.StatusConflict},
+		{"enroll TOTP with invalid token", "invalid", http.StatusForbidden},
+		{"enroll TOTP without token", "", http.StatusForbidden},
+	}
+
+	for _, tc := range cases {
+		req := testRequest{
+			client: client,
+			method: http.MethodPost,
+			url:    fmt.Sprintf("%s/users/totp", ts.URL),
+			token:  tc.token,
+		}
+		res, err := req.make()
+		assert.Nil(t, err, fmt.Sprintf("%s: unexpected error %s", tc.desc, err))
+		assert.Equal(t, tc.status, res.StatusCode, fmt.Sprintf("%s: expected status code %d got %d", tc.desc, tc.status, res.StatusCode))
+		if tc.status != http.StatusCreated {
+			continue
+		}
+		var body struct {
+			Secret string `json:"secret"`
+			URI    string `json:"uri"`
+		}
+		err = json.NewDecoder(res.Body).Decode(&body)
+		assert.Nil(t, err, fmt.Sprintf("%s: unexpected error %s", tc.desc, err))
+		assert.NotEmpty(t, body.Secret, fmt.Sprintf("%s: expected secret", tc.desc))
+		assert.Contains(t, body.URI, body.Secret, fmt.Sprintf("%s: expected otpauth URI containing the secret", tc.desc))
+	}
+}
+
+func TestConfirmTOTP(t *testing.T) {
+	svc := newService()
+	ts := newServer(svc)
+	defer ts.Close()
+	client := ts.Client()
+	_, err := svc.Register(context.Background(), user)
+	require.Nil(t, err, fmt.Sprintf("register user got unexpected error: %s", err))
+	_, err = svc.Register(context.Background(), admin)
+	require.Nil(t, err, fmt.Sprintf("register user got unexpected error: %s", err))
+	e, err := svc.EnrollTOTP(context.Background(), user.Email)
+	require.Nil(t, err, fmt.Sprintf("enrolling TOTP got unexpected error: %s", err))
+	code, err := totp.Generate(e.Secret, time.Now())
+	require.Nil(t, err, fmt.Sprintf("generating TOTP code got unexpected error: %s", err))
+
+	data := toJSON(map[string]string{"code": code})
+
+	cases := []struct {
+		desc        string
+		token       string
+		req         string
+		contentType string
+		status      int
+	}{
+		{"confirm TOTP with invalid code", user.Email, toJSON(map[string]string{"code": "000000"}), contentType, http.StatusForbidden},
+		{"confirm TOTP without enrollment", admin.Email, data, contentType, http.StatusNotFound},
+		{"confirm TOTP with invalid token", "invalid", data, contentType, http.StatusForbidden},
+		{"confirm TOTP without token", "", data, contentType, http.StatusForbidden},
+		{"confirm TOTP with empty JSON request", user.Email, "{}", contentType, http.StatusBadRequest},
+		{"confirm TOTP with invalid request format", user.Email, "{", contentType, http.StatusBadRequest},
+		{"confirm TOTP with missing content type", user.Email, data, "", http.StatusUnsupportedMediaType},
+		{"confirm TOTP with valid code", user.Email, data, contentType, http.StatusOK},
+		{"confirm TOTP already enabled", user.Email, data, contentType, http.StatusConflict},
+	}
+
+	for _, tc := range cases {
+		req := testRequest{
+			client:      client,
+			method:      http.MethodPost,
+			url:         fmt.Sprintf("%s/users/totp/confirm", ts.URL),
+			contentType: tc.contentType,
+			token:       tc.token,
+			body:        strings.NewReader(tc.req),
+		}
+		res, err := req.make()
+		assert.Nil(t, err, fmt.Sprintf("%s: unexpected error %s", tc.desc, err))
+		assert.Equal(t, tc.status, res.StatusCode, fmt.Sprintf("%s: expected status code %d got %d", tc.desc, tc.status, res.StatusCode))
+		if tc.status != http.StatusOK {
+			continue
+		}
+		var body struct {
+			RecoveryCodes []string `json:"recovery_codes"`
+		}
+		err = json.NewDecoder(res.Body).Decode(&body)
+		assert.Nil(t, err, fmt.Sprintf("%s: unexpected error %s", tc.desc, err))
+		assert.Len(t, body.RecoveryCodes, 10, fmt.Sprintf("%s: expected 10 recovery codes got %d", tc.desc, len(body.RecoveryCodes)))
+	}
+}
+
+func TestLoginTOTP(t *testing.T) {
+	svc := newService()
+	ts := newServer(svc)
+	defer ts.Close()
+	client := ts.Client()
+	_, err := svc.Register(context.Background(), user)
+	require.Nil(t, err, fmt.Sprintf("register user got unexpected error: %s", err))
+	secret, recoveryCodes := enableTOTP(t, svc, user.Email)
+	code, err := totp.Generate(secret, time.Now())
+	require.Nil(t, err, fmt.Sprintf("generating TOTP code got unexpected error: %s", err))
+
+	login := func() string {
+		req := testRequest{
+			client:      client,
+			method:      http.MethodPost,
+			url:         fmt.Sprintf("%s/tokens", ts.URL),
+			contentType: contentType,
+			body:        strings.NewReader(toJSON(user)),
+		}
+		res, err := req.make()
+		require.Nil(t, err, fmt.Sprintf("login got unexpected error: %s", err))
+		require.Equal(t, http.StatusCreated, res.StatusCode, fmt.Sprintf("login: expected status code %d got %d", http.StatusCreated, res.StatusCode))
+		var tr tokenRes
+		err = json.NewDecoder(res.Body).Decode(&tr)
+		require.Nil(t, err, fmt.Sprintf("login got unexpected error: %s", err))
+		require.Empty(t, tr.Token, "login with TOTP enabled expected not to issue access token")
+		require.Empty(t, tr.RefreshToken, "login with TOTP enabled expected not to issue refresh token")
+		require.NotEmpty(t, tr.ChallengeToken, "login with TOTP enabled expected to issue challenge token")
+		return tr.ChallengeToken
+	}
+	challenge := login()
+
+	cases := []struct {
+		desc        string
+		req         string
+		contentType string
+		status      int
+	}{
+		{"login with TOTP code", toJSON(map[string]string{"challenge_token": challenge, "code": code}), contentType, http.StatusCreated},
+		{"login with used challenge token", toJSON(map[string]string{"challenge_token": challenge, "code": code}), contentType, http.StatusForbidden},
+		{"login with recovery code", toJSON(map[string]string{"challenge_token": login(), "code": recoveryCodes[0]}), contentType, http.StatusCreated},
+		{"login with invalid code", toJSON(map[string]string{"challenge_token": login(), "code": "000000"}), contentType, http.StatusForbidden},
+		{"login with invalid challenge token", toJSON(map[string]string{"challenge_token": "invalid", "code": code}), contentType, http.StatusForbidden},
+		{"login without code", toJSON(map[string]string{"challenge_token": login()}), contentType, http.StatusBadRequest},
+		{"login with empty JSON request", "{}", contentType, http.StatusBadRequest},
+		{"login with invalid request format", "{", contentType, http.StatusBadRequest},
+		{"login with missing content type", toJSON(map[string]string{"challenge_token": login(), "code": code}), "", http.StatusUnsupportedMediaType},
+	}
+
+	for _, tc := range cases {
+		req := testRequest{
+			client:      client,
+			method:      http.MethodPost,
+			url:         fmt.Sprintf("%s/tokens/totp", ts.URL),
+			contentType: tc.contentType,
+			body:        strings.NewReader(tc.req),
+		}
+		res, err := req.make()
+		assert.Nil(t, err, fmt.Sprintf("%s: unexpected error %s", tc.desc, err))
+		assert.Equal(t, tc.status, res.StatusCode, fmt.Sprintf("%s: expected status code %d got %d", tc.desc, tc.status, res.StatusCode))
+		if tc.status != http.StatusCreated {
+			continue
+		}
+		var tr tokenRes
+		err = json.NewDecoder(res.Body).Decode(&tr)
+		assert.Nil(t, err, fmt.Sprintf("%s: unexpected error %s", tc.desc, err))
+		assert.NotEmpty(t, tr.Token, fmt.Sprintf("%s: expected access token", tc.desc))
+		assert.NotEmpty(t, tr.RefreshToken, fmt.Sprintf("%s: expected refresh token", tc.desc))
+	}
+}
+
+func TestDisableTOTP(t *testing.T) {
+	svc := newService()
+	ts := newServer(svc)
+	defer ts.Close()
+	client := ts.Client()
+	_, err := svc.Register(context.Background(), user)
+	require.Nil(t, err, fmt.Sprintf("register user got unexpected error: %s", err))
+	secret, _ := enableTOTP(t, svc, user.Email)
+	code, err := totp.Generate(secret, time.Now())
+	require.Nil(t, err, fmt.Sprintf("generating TOTP code got unexpected error: %s", err))
+
+	data := toJSON(map[string]string{"code": code})
+
+	cases := []struct {
+		desc        string
+		token       string
+		req         string
+		contentType string
+		status      int
+	}{
+		{"disable TOTP with invalid code", user.Email, toJSON(map[string]string{"code": "000000"}), contentType, http.StatusForbidden},
+		{"disable TOTP with invalid token", "invalid", data, contentType, http.StatusForbidden},
+		{"disable TOTP with empty JSON request", user.Email, "{}", contentType, http.StatusBadRequest},
+		{"disable TOTP with missing content type", user.Email, data, "", http.StatusUnsupportedMediaType},
+		{"disable TOTP with valid code", user.Email, data, contentType, http.StatusNoContent},
+		{"disable disabled TOTP", user.Email, data, contentType, http.StatusNotFound},
+	}
+
+	for _, tc := range cases {
+		req := testRequest{
+			client:      client,
+			method:      http.MethodPost,
+			url:         fmt.Sprintf("%s/users/totp/disable", ts.URL),
+			contentType: tc.contentType,
+			token:       tc.token,
+			body:        strings.NewReader(tc.req),
+		}
+		res, err := req.make()
+		assert.Nil(t, err, fmt.Sprintf("%s: unexpected error %s", tc.desc, err))
+		assert.Equal(t, tc.status, res.StatusCode, fmt.Sprintf("%s: expected status code %d got %d", tc.desc, tc.status, res.StatusCode))
+	}
+}
+
+func TestResetTOTP(t *testing.T) {
+	svc := newService()
+	ts := newServer(svc)
+	defer ts.Close()
+	client := ts.Client()
+	uid, err := svc.Register(context.Background(), user)
+	require.Nil(t, err, fmt.Sprintf("register user got unexpected error: %s", err))
+	_, err = svc.Register(context.Background(), admin)
+	require.Nil(t, err, fmt.Sprintf("register user got unexpected error: %s", err))
+	enableTOTP(t, svc, user.Email)
+
+	cases := []struct {
+		desc   string
+		token  string
+		id     string
+		status int
+	}{
+		{"reset TOTP as non-admin user", user.Email, uid, http.StatusForbidden},
+		{"reset TOTP with invalid token", "invalid", uid, http.StatusForbidden},
+		{"reset TOTP without token", "", uid, http.StatusForbidden},
+		{"reset TOTP of non-existing user", admin.Email, "invalid", http.StatusNotFound},
+		{"reset TOTP as admin", admin.Email, uid, http.StatusNoContent},
+	}
+
+	for _, tc := range cases {
+		req := testRequest{
+			client: client,
+			method: http.MethodDelete,
+			url:    fmt.Sprintf("%s/users/%s/totp", ts.URL, tc.id),
+			token:  tc.token,
+		}
+		res, err := req.make()
+		assert.Nil(t, err, fmt.Sprintf("%s: unexpected error %s", tc.desc, err))
+		assert.Equal(t, tc.status, res.StatusCode, fmt.Sprintf("%s: expected status code %d got %d", tc.desc, tc.status, res.StatusCode))
+	}
+}
+
+func TestLoginLockout(t *testing.T) {
+	svc := newService()
+	ts := newServer(svc)
+	defer ts.Close()
+	client := ts.Client()
+	_, err := svc.Register(context.Background(), user)
+	require.Nil(t, err, fmt.Sprintf("register user got unexpected error: %s", err))
+
+	data := toJSON(user)
+	invalidData := toJSON(users.User{Email: user.Email, Password: "wrong_password"})
+
+	cases := []struct {
+		desc   string
+		req    string
+		status int
+	}{
+		{"login with invalid credentials", invalidData, http.StatusForbidden},
+		{"login with invalid credentials again", invalidData, http.StatusForbidden},
+		{"login with invalid credentials locking out the account", invalidData, http.StatusForbidden},
+		{"login to locked out account with valid credentials", data, http.StatusTooManyRequests},
+	}
 
-	return users.New(usersRepo, groupRepo, hasher, auth, email)
+	for _, tc := range cases {
+		req := testRequest{
+			client:      client,
+			method:      http.MethodPost,
+			url:         fmt.Sprintf("%s/tokens", ts.URL),
+			contentType: contentType,
+			body:        strings.NewReader(tc.req),
+		}
+		res, err := req.make()
+		assert.Nil(t, err, fmt.Sprintf("%s: unexpected error %s", tc.desc, err))
+		assert.Equal(t, tc.status, res.StatusCode, fmt.Sprintf("%s: expected status code %d got %d", tc.desc, tc.status, res.StatusCode))
+	}
 }
 
-func newServer(svc users.Service) *httptest.Server {
-	mux := api.MakeHandler(svc, mocktracer.New())
-	return httptest.NewServer(mux)
+func TestUnlock(t *testing.T) {
+	svc := newService()
+	ts := newServer(svc)
+	defer ts.Close()
+	client := ts.Client()
+	uid, err := svc.Register(context.Background(), user)
+	require.Nil(t, err, fmt.Sprintf("register user got unexpected error: %s", err))
+	_, err = svc.Register(context.Background(), admin)
+	require.Nil(t, err, fmt.Sprintf("register user got unexpected error: %s", err))
+	for i := uint64(0); i < lockout.MaxAttempts; i++ {
+		svc.Login(context.Background(), users.User{Email: user.Email, Password: "wrong_password"}, "")
+	}
+
+	cases := []struct {
+		desc   string
+		token  string
+		id     string
+		status int
+	}{
+		{"unlock as non-admin user", user.Email, uid, http.StatusForbidden},
+		{"unlock with invalid token", "invalid", uid, http.StatusForbidden},
+		{"unlock without token", "", uid, http.StatusForbidden},
+		{"unlock non-existing user", admin.Email, "invalid", http.StatusNotFound},
+		{"unlock as admin", admin.Email, uid, http.StatusNoContent},
+	}
+
+	for _, tc := range cases {
+		req := testRequest{
+			client: client,
+			method: http.MethodDelete,
+			url:    fmt.Sprintf("%s/users/%s/lockout", ts.URL, tc.id),
+			token:  tc.token,
+		}
+		res, err := req.make()
+		assert.Nil(t, err, fmt.Sprintf("%s: unexpected error %s", tc.desc, err))
+		assert.Equal(t, tc.status, res.StatusCode, fmt.Sprintf("%s: expected status code %d got %d", tc.desc, tc.status, res.StatusCode))
+	}
+
+	_, _, err = svc.Login(context.Background(), user, "")
+	assert.Nil(t, err, fmt.Sprintf("login after unlocking expected to succeed: %s", err))
 }
 
-func toJSON(data interface{}) string {
-	jsonData, _ := json.Marshal(data)
-	return string(jsonData)
+func TestDisableUser(t *testing.T) {
+	svc := newService()
+	ts := newServer(svc)
+	defer ts.Close()
+	client := ts.Client()
+	uid, err := svc.Register(context.Background(), user)
+	require.Nil(t, err, fmt.Sprintf("register user got unexpected error: %s", err))
+	_, err = svc.Register(context.Background(), admin)
+	require.Nil(t, err, fmt.Sprintf("register user got unexpected error: %s", err))
+
+	cases := []struct {
+		desc   string
+		method string
+		token  string
+		id     string
+		status int
+	}{
+		{"disable user as non-admin user", http.MethodPut, user.Email, uid, http.StatusForbidden},
+		{"disable user without token", http.MethodPut, "", uid, http.StatusForbidden},
+		{"disable non-existing user", http.MethodPut, admin.Email, "invalid", http.StatusNotFound},
+		{"disable user as admin", http.MethodPut, admin.Email, uid, http.StatusNoContent},
+		{"enable user as non-admin user", http.MethodDelete, user.Email, uid, http.StatusForbidden},
+		{"enable user as admin", http.MethodDelete, admin.Email, uid, http.StatusNoContent},
+	}
+
+	for _, tc := range cases {
+		req := testRequest{
+			client: client,
+			method: tc.method,
+			url:    fmt.Sprintf("%s/users/%s/disabled", ts.URL, tc.id),
+			token:  tc.token,
+		}
+		res, err := req.make()
+		assert.Nil(t, err, fmt.Sprintf("%s: unexpected error %s", tc.desc, err))
+		assert.Equal(t, tc.status, res.StatusCode, fmt.Sprintf("%s: expected status code %d got %d", tc.desc, tc.status, res.StatusCode))
+	}
 }
 
-func TestRegister(t *testing.T) {
+func TestImpersonate(t *testing.T) {
 	svc := newService()
 	ts := newServer(svc)
 	defer ts.Close()
 	client := ts.Client()
+	uid, err := svc.Register(context.Background(), user)
+	require.Nil(t, err, fmt.Sprintf("register user got unexpected error: %s", err))
+	_, err = svc.Register(context.Background(), admin)
+	require.Nil(t, err, fmt.Sprintf("register user got unexpected error: %s", err))
 
-	data := toJSON(user)
-	invalidData := toJSON(users.User{Email: invalidEmail, Password: "password"})
-	invalidFieldData := fmt.Sprintf(`{"email": "%s", "pass": "%s"}`, user.Email, user.Password)
+	data := toJSON(map[string]string{"reason": "support"})
+	emptyData := toJSON(map[string]string{"reason": ""})
 
 	cases := []struct {
 		desc        string
 		req         string
 		contentType string
+		token       string
+		id          string
 		status      int
 	}{
-		{"register new user", data, contentType, http.StatusCreated},
-		{"register existing user", data, contentType, http.StatusConflict},
-		{"register user with invalid email address", invalidData, contentType, http.StatusBadRequest},
-		{"register user with invalid request format", "{", contentType, http.StatusBadRequest},
-		{"register user with empty JSON request", "{}", contentType, http.StatusBadRequest},
-		{"register user with empty request", "", contentType, http.StatusBadRequest},
-		{"register user with invalid field name", invalidFieldData, contentType, http.StatusBadRequest},
-		{"register user with missing content type", data, "", http.StatusUnsupportedMediaType},
+		{"impersonate user as non-admin user", data, contentType, user.Email, uid, http.StatusForbidden},
+		{"impersonate user without reason", emptyData, contentType, admin.Email, uid, http.StatusBadRequest},
+		{"impersonate user with malformed JSON", "{", contentType, admin.Email, uid, http.StatusBadRequest},
+		{"impersonate user with invalid content type", data, "", admin.Email, uid, http.StatusUnsupportedMediaType},
+		{"impersonate non-existing user", data, contentType, admin.Email, "invalid", http.StatusNotFound},
+		{"impersonate user as admin", data, contentType, admin.Email, uid, http.StatusCreated},
 	}
 
 	for _, tc := range cases {
 		req := testRequest{
 			client:      client,
 			method:      http.MethodPost,
-			url:         fmt.Sprintf("%s/users", ts.URL),
+			url:         fmt.Sprintf("%s/users/%s/impersonate", ts.URL, tc.id),
 			contentType: tc.contentType,
+			token:       tc.token,
 			body:        strings.NewReader(tc.req),
 		}
 		res, err := req.make()
 		assert.Nil(t, err, fmt.Sprintf("%s: unexpected error %s", tc.desc, err))
 		assert.Equal(t, tc.status, res.StatusCode, fmt.Sprintf("%s: expected status code %d got %d", tc.desc, tc.status, res.StatusCode))
 	}
+
+	req := testRequest{
+		client: client,
+		method: http.MethodGet,
+		url:    fmt.Sprintf("%s/impersonations?user_id=%s", ts.URL, uid),
+		token:  admin.Email,
+	}
+	res, err := req.make()
+	require.Nil(t, err, fmt.Sprintf("list impersonations: unexpected error %s", err))
+	assert.Equal(t, http.StatusOK, res.StatusCode, fmt.Sprintf("list impersonations: expected status code %d got %d", http.StatusOK, res.StatusCode))
 }
 
-func TestLogin(t *testing.T) {
-	svc := newService()
+func TestVerifyEmail(t *testing.T) {
+	svc, e := newVerificationService(users.VerificationPolicy{Enabled: true, Duration: time.Hour})
 	ts := newServer(svc)
 	defer ts.Close()
 	client := ts.Client()
-	auth := mocks.NewAuthService(map[string]string{user.Email: user.Email})
-	tkn, _ := auth.Issue(context.Background(), &mainflux.IssueReq{Id: user.ID, Email: user.Email, Type: 0})
-	token := tkn.GetValue()
-	tokenData := toJSON(map[string]string{"token": token})
-	data := toJSON(user)
-	invalidEmailData := toJSON(users.User{
-		Email:    invalidEmail,
-		Password: "password",
-	})
-	invalidData := toJSON(users.User{
-		Email:    "user@example.com",
-		Password: "invalid_password",
-	})
-	nonexistentData := toJSON(users.User{
-		Email:    "non-existentuser@example.com",
-		Password: "password",
-	})
 	_, err := svc.Register(context.Background(), user)
 	require.Nil(t, err, fmt.Sprintf("register user got unexpected error: %s", err))
+	token := e.VerificationToken(user.Email)
+
+	data := toJSON(map[string]string{"token": token})
+	invalidData := toJSON(map[string]string{"token": "invalid"})
+	emptyData := toJSON(map[string]string{"token": ""})
 
 	cases := []struct {
 		desc        string
 		req         string
 		contentType string
 		status      int
-		res         string
 	}{
-		{"login with valid credentials", data, contentType, http.StatusCreated, tokenData},
-		{"login with invalid credentials", invalidData, contentType, http.StatusForbidden, unauthRes},
-		{"login with invalid email address", invalidEmailData, contentType, http.StatusBadRequest, malformedRes},
-		{"login non-existent user", nonexistentData, contentType, http.StatusForbidden, unauthRes},
-		{"login with invalid request format", "{", contentType, http.StatusBadRequest, malformedRes},
-		{"login with empty JSON request", "{}", contentType, http.StatusBadRequest, malformedRes},
-		{"login with empty request", "", contentType, http.StatusBadRequest, malformedRes},
-		{"login with missing content type", data, "", http.StatusUnsupportedMediaType, unsupportedRes},
+		{"verify email with invalid token", invalidData, contentType, http.StatusForbidden},
+		{"verify email with empty token", emptyData, contentType, http.StatusBadRequest},
+		{"verify email with malformed JSON", "{", contentType, http.StatusBadRequest},
+		{"verify email with invalid content type", data, "", http.StatusUnsupportedMediaType},
+		{"verify email with valid token", data, contentType, http.StatusNoContent},
+		{"verify email with already used token", data, contentType, http.StatusForbidden},
 	}
 
 	for _, tc := range cases {
 		req := testRequest{
 			client:      client,
 			method:      http.MethodPost,
-			url:         fmt.Sprintf("%s/tokens", ts.URL),
+			url:         fmt.Sprintf("%s/users/verify", ts.URL),
 			contentType: tc.contentType,
 			body:        strings.NewReader(tc.req),
 		}
 		res, err := req.make()
 		assert.Nil(t, err, fmt.Sprintf("%s: unexpected error %s", tc.desc, err))
-		body, err := ioutil.ReadAll(res.Body)
-		assert.Nil(t, err, fmt.Sprintf("%s: unexpected error %s", tc.desc, err))
-		token := strings.Trim(string(body), "\n")
+		assert.Equal(t, tc.status, res.StatusCode, fmt.Sprintf("%s: expected status code %d got %d", tc.desc, tc.status, res.StatusCode))
+	}
+}
+
+func TestResendVerification(t *testing.T) {
+	svc, _ := newVerificationService(users.VerificationPolicy{Enabled: true, Duration: time.Hour, ResendInterval: time.Hour})
+	ts := newServer(svc)
+	defer ts.Close()
+	client := ts.Client()
+	_, err := svc.Register(context.Background(), user)
+	require.Nil(t, err, fmt.Sprintf("register user got unexpected error: %s", err))
+	_, err = svc.Register(context.Background(), admin)
+	require.Nil(t, err, fmt.Sprintf("register user got unexpected error: %s", err))
+
+	cases := []struct {
+		desc        string
+		req         string
+		contentType string
+		status      int
+	}{
+		{"resend verification to non-existing user", toJSON(map[string]string{"email": "unknown@example.com"}), contentType, http.StatusBadRequest},
+		{"resend verification with empty email", toJSON(map[string]string{"email": ""}), contentType, http.StatusBadRequest},
+		{"resend verification with invalid content type", toJSON(map[string]string{"email": user.Email}), "", http.StatusUnsupportedMediaType},
+		{"resend verification to verified user", toJSON(map[string]string{"email": admin.Email}), contentType, http.StatusConflict},
+		{"resend verification before resend interval passes", toJSON(map[string]string{"email": user.Email}), contentType, http.StatusTooManyRequests},
+	}
 
+	for _, tc := range cases {
+		req := testRequest{
+			client:      client,
+			method:      http.MethodPost,
+			url:         fmt.Sprintf("%s/users/verify/resend", ts.URL),
+			contentType: tc.contentType,
+			body:        strings.NewReader(tc.req),
+		}
+		res, err := req.make()
+		assert.Nil(t, err, fmt.Sprintf("%s: unexpected error %s", tc.desc, err))
 		assert.Equal(t, tc.status, res.StatusCode, fmt.Sprintf("%s: expected status code %d got %d", tc.desc, tc.status, res.StatusCode))
-		assert.Equal(t, tc.res, token, fmt.Sprintf("%s: expected body %s got %s", tc.desc, tc.res, token))
 	}
 }
 
@@ -503,3 +1178,179 @@ func TestGroupCreate(t *testing.T) {
 type errorRes struct {
 	Err string `json:"error"`
 }
+
+func TestInvite(t *testing.T) {
+	svc, _ := newPolicyService(users.VerificationPolicy{}, invitation)
+	ts := newServer(svc)
+	defer ts.Close()
+	client := ts.Client()
+	_, err := svc.Register(context.Background(), user)
+	require.Nil(t, err, fmt.Sprintf("register user got unexpected error: %s", err))
+	_, err = svc.Register(context.Background(), admin)
+	require.Nil(t, err, fmt.Sprintf("register user got unexpected error: %s", err))
+	group, err := svc.CreateGroup(context.Background(), user.Email, users.Group{Name: "Mainflux"})
+	require.Nil(t, err, fmt.Sprintf("create group got unexpected error: %s", err))
+
+	data := toJSON(map[string]string{"email": invitee, "role": users.EditorRole})
+	invalidRole := toJSON(map[string]string{"email": invitee, "role": "invalid"})
+
+	cases := []struct {
+		desc        string
+		req         string
+		groupID     string
+		contentType string
+		token       string
+		status      int
+	}{
+		{"invite with invalid token", data, group.ID, contentType, "invalid", http.StatusForbidden},
+		{"invite into group not owned by the user", data, group.ID, contentType, admin.Email, http.StatusForbidden},
+		{"invite into non-existing group", data, "invalid", contentType, user.Email, http.StatusNotFound},
+		{"invite with invalid role", invalidRole, group.ID, contentType, user.Email, http.StatusBadRequest},
+		{"invite with empty JSON request", "{}", group.ID, contentType, user.Email, http.StatusBadRequest},
+		{"invite with invalid content type", data, group.ID, "", user.Email, http.StatusUnsupportedMediaType},
+		{"invite email into group", data, group.ID, contentType, user.Email, http.StatusCreated},
+		{"invite already invited email", data, group.ID, contentType, user.Email, http.StatusConflict},
+	}
+
+	for _, tc := range cases {
+		req := testRequest{
+			client:      client,
+			method:      http.MethodPost,
+			url:         fmt.Sprintf("%s/groups/%s/invitations", ts.URL, tc.groupID),
+			contentType: tc.contentType,
+			token:       tc.token,
+			body:        strings.NewReader(tc.req),
+		}
+		res, err := req.make()
+		assert.Nil(t, err, fmt.Sprintf("%s: unexpected error %s", tc.desc, err))
+		assert.Equal(t, tc.status, res.StatusCode, fmt.Sprintf("%s: expected status code %d got %d", tc.desc, tc.status, res.StatusCode))
+	}
+}
+
+func TestListInvitations(t *testing.T) {
+	svc, _ := newPolicyService(users.VerificationPolicy{}, invitation)
+	ts := newServer(svc)
+	defer ts.Close()
+	client := ts.Client()
+	_, err := svc.Register(context.Background(), user)
+	require.Nil(t, err, fmt.Sprintf("register user got unexpected error: %s", err))
+	_, err = svc.Register(context.Background(), admin)
+	require.Nil(t, err, fmt.Sprintf("register user got unexpected error: %s", err))
+	group, err := svc.CreateGroup(context.Background(), user.Email, users.Group{Name: "Mainflux"})
+	require.Nil(t, err, fmt.Sprintf("create group got unexpected error: %s", err))
+	_, err = svc.Invite(context.Background(), user.Email, group.ID, invitee, users.ViewerRole)
+	require.Nil(t, err, fmt.Sprintf("invite got unexpected error: %s", err))
+
+	cases := []struct {
+		desc   string
+		url    string
+		token  string
+		status int
+		total  uint64
+	}{
+		{"list invitations with invalid token", fmt.Sprintf("%s/groups/%s/invitations", ts.URL, group.ID), "invalid", http.StatusForbidden, 0},
+		{"list invitations into group not owned by the user", fmt.Sprintf("%s/groups/%s/invitations", ts.URL, group.ID), admin.Email, http.StatusForbidden, 0},
+		{"list invitations", fmt.Sprintf("%s/groups/%s/invitations", ts.URL, group.ID), user.Email, http.StatusOK, 1},
+	}
+
+	for _, tc := range cases {
+		req := testRequest{
+			client: client,
+			method: http.MethodGet,
+			url:    tc.url,
+			token:  tc.token,
+		}
+		res, err := req.make()
+		assert.Nil(t, err, fmt.Sprintf("%s: unexpected error %s", tc.desc, err))
+		assert.Equal(t, tc.status, res.StatusCode, fmt.Sprintf("%s: expected status code %d got %d", tc.desc, tc.status, res.StatusCode))
+		var page struct {
+			Total uint64 `json:"total"`
+		}
+		json.NewDecoder(res.Body).Decode(&page)
+		assert.Equal(t, tc.total, page.Total, fmt.Sprintf("%s: expected total %d got %d", tc.desc, tc.total, page.Total))
+	}
+}
+
+func TestRevokeInvitation(t *testing.T) {
+	svc, _ := newPolicyService(users.VerificationPolicy{}, invitation)
+	ts := newServer(svc)
+	defer ts.Close()
+	client := ts.Client()
+	_, err := svc.Register(context.Background(), user)
+	require.Nil(t, err, fmt.Sprintf("register user got unexpected error: %s", err))
+	_, err = svc.Register(context.Background(), admin)
+	require.Nil(t, err, fmt.Sprintf("register user got unexpected error: %s", err))
+	group, err := svc.CreateGroup(context.Background(), user.Email, users.Group{Name: "Mainflux"})
+	require.Nil(t, err, fmt.Sprintf("create group got unexpected error: %s", err))
+	inv, err := svc.Invite(context.Background(), user.Email, group.ID, invitee, users.ViewerRole)
+	require.Nil(t, err, fmt.Sprintf("invite got unexpected error: %s", err))
+
+	cases := []struct {
+		desc   string
+		id     string
+		token  string
+		status int
+	}{
+		{"revoke invitation with invalid token", inv.ID, "invalid", http.StatusForbidden},
+		{"revoke invitation without token", inv.ID, "", http.StatusForbidden},
+		{"revoke invitation into group not owned by the user", inv.ID, admin.Email, http.StatusForbidden},
+		{"revoke non-existing invitation", "invalid", user.Email, http.StatusNotFound},
+		{"revoke invitation", inv.ID, user.Email, http.StatusNoContent},
+	}
+
+	for _, tc := range cases {
+		req := testRequest{
+			client: client,
+			method: http.MethodDelete,
+			url:    fmt.Sprintf("%s/invitations/%s", ts.URL, tc.id),
+			token:  tc.token,
+		}
+		res, err := req.make()
+		assert.Nil(t, err, fmt.Sprintf("%s: unexpected error %s", tc.desc, err))
+		assert.Equal(t, tc.status, res.StatusCode, fmt.Sprintf("%s: expected status code %d got %d", tc.desc, tc.status, res.StatusCode))
+	}
+}
+
+func TestAcceptInvitation(t *testing.T) {
+	svc, e := newPolicyService(users.VerificationPolicy{}, invitation)
+	ts := newServer(svc)
+	defer ts.Close()
+	client := ts.Client()
+	_, err := svc.Register(context.Background(), user)
+	require.Nil(t, err, fmt.Sprintf("register user got unexpected error: %s", err))
+	group, err := svc.CreateGroup(context.Background(), user.Email, users.Group{Name: "Mainflux"})
+	require.Nil(t, err, fmt.Sprintf("create group got unexpected error: %s", err))
+	_, err = svc.Invite(context.Background(), user.Email, group.ID, invitee, users.ViewerRole)
+	require.Nil(t, err, fmt.Sprintf("invite got unexpected error: %s", err))
+
+	data := toJSON(map[string]string{"token": e.InvitationToken(invitee), "password": "password"})
+	shortPass := toJSON(map[string]string{"token": e.InvitationToken(invitee), "password": "pass"})
+	invalidData := toJSON(map[string]string{"token": "invalid", "password": "password"})
+
+	cases := []struct {
+		desc        string
+		req         string
+		contentType string
+		status      int
+	}{
+		{"accept invitation with invalid token", invalidData, contentType, http.StatusForbidden},
+		{"accept invitation with empty JSON request", "{}", contentType, http.StatusBadRequest},
+		{"accept invitation with invalid content type", data, "", http.StatusUnsupportedMediaType},
+		{"accept invitation with invalid password", shortPass, contentType, http.StatusBadRequest},
+		{"accept invitation", data, contentType, http.StatusOK},
+		{"accept already accepted invitation", data, contentType, http.StatusForbidden},
+	}
+
+	for _, tc := range cases {
+		req := testRequest{
+			client:      client,
+			method:      http.MethodPost,
+			url:         fmt.Sprintf("%s/invitations/accept", ts.URL),
+			contentType: tc.contentType,
+			body:        strings.NewReader(tc.req),
+		}
+		res, err := req.make()
+		assert.Nil(t, err, fmt.Sprintf("%s: unexpected error %s", tc.desc, err))
+		assert.Equal(t, tc.status, res.StatusCode, fmt.Sprintf("%s: expected status code %d got %d", tc.desc, tc.status, res.StatusCode))
+	}
+}
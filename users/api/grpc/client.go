@@ -0,0 +1,61 @@
+// Copyright (c) Mainflux
+// SPDX-License-Identifier: Apache-2.0
+
+package grpc
+
+import (
+	"context"
+	"time"
+
+	"github.com/go-kit/kit/endpoint"
+	kitot "github.com/go-kit/kit/tracing/opentracing"
+	kitgrpc "github.com/go-kit/kit/transport/grpc"
+	"github.com/mainflux/mainflux"
+	opentracing "github.com/opentracing/opentracing-go"
+	"google.golang.org/grpc"
+)
+
+var _ mainflux.UsersServiceClient = (*grpcClient)(nil)
+
+type grpcClient struct {
+	timeout   time.Duration
+	authorize endpoint.Endpoint
+}
+
+// NewClient returns new gRPC client instance.
+func NewClient(conn *grpc.ClientConn, tracer opentracing.Tracer, timeout time.Duration) mainflux.UsersServiceClient {
+	return &grpcClient{
+		timeout: timeout,
+		authorize: kitot.TraceClient(tracer, "authorize")(kitgrpc.NewClient(
+			conn,
+			"mainflux.UsersService",
+			"Authorize",
+			encodeAuthorizeRequest,
+			decodeAuthorizationResponse,
+			mainflux.Authorization{},
+		).Endpoint()),
+	}
+}
+
+func (client grpcClient) Authorize(ctx context.Context, req *mainflux.AuthorizeReq, _ ...grpc.CallOption) (*mainflux.Authorization, error) {
+	ctx, cancel := context.WithTimeout(ctx, client.timeout)
+	defer cancel()
+
+	res, err := client.authorize(ctx, authorizeReq{email: req.GetEmail(), role: req.GetRole()})
+	if err != nil {
+		return nil, err
+	}
+
+	ar := res.(authorizationRes)
+	return &mainflux.Authorization{Groups: ar.groups}, ar.err
+}
+
+func encodeAuthorizeRequest(_ context.Context, grpcReq interface{}) (interface{}, error) {
+	req := grpcReq.(authorizeReq)
+	return &mainflux.AuthorizeReq{Email: req.email, Role: req.role}, nil
+}
+
+func decodeAuthorizationResponse(_ context.Context, grpcRes interface{}) (interface{}, error) {
+	res := grpcRes.(*mainflux.Authorization)
+	return authorizationRes{groups: res.GetGroups(), err: nil}, nil
+}
@@ -0,0 +1,26 @@
+// Copyright (c) Mainflux
+// SPDX-License-Identifier: Apache-2.0
+
+package grpc
+
+import (
+	"context"
+
+	"github.com/go-kit/kit/endpoint"
+	"github.com/mainflux/mainflux/users"
+)
+
+func authorizeEndpoint(svc users.Service) endpoint.Endpoint {
+	return func(ctx context.Context, request interface{}) (interface{}, error) {
+		req := request.(authorizeReq)
+		if err := req.validate(); err != nil {
+			return nil, err
+		}
+
+		groups, err := svc.Authorize(ctx, req.email, req.role)
+		if err != nil {
+			return authorizationRes{err: err}, err
+		}
+		return authorizationRes{groups: groups, err: nil}, nil
+	}
+}
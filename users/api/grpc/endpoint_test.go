@@ -0,0 +1,99 @@
+// Copyright (c) Mainflux
+// SPDX-License-Identifier: Apache-2.0
+
+package grpc_test
+
+import (
+	"context"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/mainflux/mainflux"
+	"github.com/mainflux/mainflux/users"
+	grpcapi "github.com/mainflux/mainflux/users/api/grpc"
+	"github.com/opentracing/opentracing-go/mocktracer"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+func TestAuthorize(t *testing.T) {
+	for _, email := range []string{owner, member} {
+		_, err := svc.Register(context.Background(), users.User{Email: email, Password: "password"})
+		require.Nil(t, err, fmt.Sprintf("unexpected error: %s\n", err))
+	}
+	group, err := svc.CreateGroup(context.Background(), owner, users.Group{Name: "team"})
+	require.Nil(t, err, fmt.Sprintf("unexpected error: %s\n", err))
+	u, err := svc.ViewProfile(context.Background(), member)
+	require.Nil(t, err, fmt.Sprintf("unexpected error: %s\n", err))
+	err = svc.Assign(context.Background(), owner, u.ID, group.ID, users.EditorRole)
+	require.Nil(t, err, fmt.Sprintf("unexpected error: %s\n", err))
+
+	usersAddr := fmt.Sprintf("localhost:%d", port)
+	conn, err := grpc.Dial(usersAddr, grpc.WithInsecure())
+	require.Nil(t, err, fmt.Sprintf("unexpected error: %s\n", err))
+	cli := grpcapi.NewClient(conn, mocktracer.New(), time.Second)
+
+	cases := map[string]struct {
+		email  string
+		role   string
+		groups []string
+		code   codes.Code
+	}{
+		"authorize group owner as admin": {
+			email:  owner,
+			role:   users.AdminRole,
+			groups: []string{group.ID},
+			code:   codes.OK,
+		},
+		"authorize editor as viewer": {
+			email:  member,
+			role:   users.ViewerRole,
+			groups: []string{group.ID},
+			code:   codes.OK,
+		},
+		"authorize editor as editor": {
+			email:  member,
+			role:   users.EditorRole,
+			groups: []string{group.ID},
+			code:   codes.OK,
+		},
+		"authorize editor as admin": {
+			email:  member,
+			role:   users.AdminRole,
+			groups: nil,
+			code:   codes.OK,
+		},
+		"authorize with unknown role": {
+			email:  member,
+			role:   "owner",
+			groups: nil,
+			code:   codes.InvalidArgument,
+		},
+		"authorize without role": {
+			email:  member,
+			role:   "",
+			groups: nil,
+			code:   codes.InvalidArgument,
+		},
+		"authorize non-existing user": {
+			email:  "unknown@example.com",
+			role:   users.ViewerRole,
+			groups: nil,
+			code:   codes.NotFound,
+		},
+	}
+
+	for desc, tc := range cases {
+		ctx, cancel := context.WithTimeout(context.Background(), time.Second)
+		res, err := cli.Authorize(ctx, &mainflux.AuthorizeReq{Email: tc.email, Role: tc.role})
+		cancel()
+		e, ok := status.FromError(err)
+		assert.True(t, ok, "gRPC status can't be extracted from the error")
+		assert.Equal(t, tc.code, e.Code(), fmt.Sprintf("%s: expected %s got %s", desc, tc.code, e.Code()))
+		assert.Equal(t, tc.groups, res.GetGroups(), fmt.Sprintf("%s: expected %v got %v", desc, tc.groups, res.GetGroups()))
+	}
+}
@@ -0,0 +1,19 @@
+// Copyright (c) Mainflux
+// SPDX-License-Identifier: Apache-2.0
+
+package grpc
+
+import "github.com/mainflux/mainflux/users"
+
+type authorizeReq struct {
+	email string
+	role  string
+}
+
+func (req authorizeReq) validate() error {
+	if req.email == "" || req.role == "" {
+		return users.ErrMalformedEntity
+	}
+
+	return nil
+}
@@ -0,0 +1,9 @@
+// Copyright (c) Mainflux
+// SPDX-License-Identifier: Apache-2.0
+
+package grpc
+
+type authorizationRes struct {
+	groups []string
+	err    error
+}
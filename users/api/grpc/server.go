@@ -0,0 +1,66 @@
+// Copyright (c) Mainflux
+// SPDX-License-Identifier: Apache-2.0
+
+package grpc
+
+import (
+	"context"
+
+	kitot "github.com/go-kit/kit/tracing/opentracing"
+	kitgrpc "github.com/go-kit/kit/transport/grpc"
+	"github.com/mainflux/mainflux"
+	"github.com/mainflux/mainflux/pkg/errors"
+	"github.com/mainflux/mainflux/users"
+	opentracing "github.com/opentracing/opentracing-go"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+var _ mainflux.UsersServiceServer = (*grpcServer)(nil)
+
+type grpcServer struct {
+	authorize kitgrpc.Handler
+}
+
+// NewServer returns new UsersServiceServer instance.
+func NewServer(tracer opentracing.Tracer, svc users.Service) mainflux.UsersServiceServer {
+	return &grpcServer{
+		authorize: kitgrpc.NewServer(
+			kitot.TraceServer(tracer, "authorize")(authorizeEndpoint(svc)),
+			decodeAuthorizeRequest,
+			encodeAuthorizationResponse,
+		),
+	}
+}
+
+func (s *grpcServer) Authorize(ctx context.Context, req *mainflux.AuthorizeReq) (*mainflux.Authorization, error) {
+	_, res, err := s.authorize.ServeGRPC(ctx, req)
+	if err != nil {
+		return nil, encodeError(err)
+	}
+
+	return res.(*mainflux.Authorization), nil
+}
+
+func decodeAuthorizeRequest(_ context.Context, grpcReq interface{}) (interface{}, error) {
+	req := grpcReq.(*mainflux.AuthorizeReq)
+	return authorizeReq{email: req.GetEmail(), role: req.GetRole()}, nil
+}
+
+func encodeAuthorizationResponse(_ context.Context, grpcRes interface{}) (interface{}, error) {
+	res := grpcRes.(authorizationRes)
+	return &mainflux.Authorization{Groups: res.groups}, encodeError(res.err)
+}
+
+func encodeError(err error) error {
+	switch {
+	case err == nil:
+		return nil
+	case errors.Contains(err, users.ErrMalformedEntity):
+		return status.Error(codes.InvalidArgument, "received invalid authorize request")
+	case errors.Contains(err, users.ErrNotFound):
+		return status.Error(codes.NotFound, "user does not exist")
+	default:
+		return status.Error(codes.Internal, "internal server error")
+	}
+}
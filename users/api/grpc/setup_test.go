@@ -0,0 +1,51 @@
+// Copyright (c) Mainflux
+// SPDX-License-Identifier: Apache-2.0
+
+package grpc_test
+
+import (
+	"fmt"
+	"net"
+	"os"
+	"testing"
+	"time"
+
+	"github.com/mainflux/mainflux"
+	"github.com/mainflux/mainflux/users"
+	grpcapi "github.com/mainflux/mainflux/users/api/grpc"
+	"github.com/mainflux/mainflux/users/bcrypt"
+	"github.com/mainflux/mainflux/users/mocks"
+	"github.com/opentracing/opentracing-go/mocktracer"
+	"google.golang.org/grpc"
+)
+
+const (
+	port   = 8082
+	owner  = "owner@example.com"
+	member = "member@example.com"
+)
+
+var svc users.Service
+
+func TestMain(m *testing.M) {
+	startServer()
+	code := m.Run()
+	os.Exit(code)
+}
+
+func startServer() {
+	svc = newService()
+	listener, _ := net.Listen("tcp", fmt.Sprintf(":%d", port))
+	server := grpc.NewServer()
+	mainflux.RegisterUsersServiceServer(server, grpcapi.NewServer(mocktracer.New(), svc))
+	go server.Serve(listener)
+}
+
+func newService() users.Service {
+	usersRepo := mocks.NewUserRepository()
+	groupRepo := mocks.NewGroupRepository()
+	auth := mocks.NewAuthService(map[string]string{owner: owner, member: member})
+	lockout := users.LockoutPolicy{MaxAttempts: 3, MaxIPAttempts: 5, Duration: time.Minute}
+
+	return users.New(usersRepo, groupRepo, mocks.NewInvitationRepository(), mocks.NewImpersonationRepository(), bcrypt.New(), auth, mocks.NewEmailer(), nil, users.NewAttemptRepository(), lockout, users.VerificationPolicy{}, users.InvitationPolicy{}, "")
+}
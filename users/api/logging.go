@@ -9,6 +9,7 @@ import (
 	"time"
 
 	log "github.com/mainflux/mainflux/logger"
+	"github.com/mainflux/mainflux/pkg/errors"
 	"github.com/mainflux/mainflux/users"
 )
 
@@ -38,9 +39,9 @@ func (lm *loggingMiddleware) Register(ctx context.Context, user users.User) (uid
 	return lm.svc.Register(ctx, user)
 }
 
-func (lm *loggingMiddleware) Login(ctx context.Context, user users.User) (token string, err error) {
+func (lm *loggingMiddleware) VerifyEmail(ctx context.Context, token string) (err error) {
 	defer func(begin time.Time) {
-		message := fmt.Sprintf("Method login for user %s took %s to complete", user.Email, time.Since(begin))
+		message := fmt.Sprintf("Method verify_email took %s to complete", time.Since(begin))
 		if err != nil {
 			lm.logger.Warn(fmt.Sprintf("%s with error: %s.", message, err))
 			return
@@ -48,7 +49,218 @@ func (lm *loggingMiddleware) Login(ctx context.Context, user users.User) (token
 		lm.logger.Info(fmt.Sprintf("%s without errors.", message))
 	}(time.Now())
 
-	return lm.svc.Login(ctx, user)
+	return lm.svc.VerifyEmail(ctx, token)
+}
+
+func (lm *loggingMiddleware) ResendVerification(ctx context.Context, email string) (err error) {
+	defer func(begin time.Time) {
+		message := fmt.Sprintf("Method resend_verification for user %s took %s to complete", email, time.Since(begin))
+		if err != nil {
+			lm.logger.Warn(fmt.Sprintf("%s with error: %s.", message, err))
+			return
+		}
+		lm.logger.Info(fmt.Sprintf("%s without errors.", message))
+	}(time.Now())
+
+	return lm.svc.ResendVerification(ctx, email)
+}
+
+func (lm *loggingMiddleware) Login(ctx context.Context, user users.User, ip string) (token, refreshToken string, err error) {
+	defer func(begin time.Time) {
+		message := fmt.Sprintf("Method login for user %s from %s took %s to complete", user.Email, ip, time.Since(begin))
+		if errors.Contains(err, users.ErrLockout) {
+			lm.logger.Warn(fmt.Sprintf("Too many failed login attempts for user %s from %s, login locked out.", user.Email, ip))
+		}
+		if err != nil {
+			lm.logger.Warn(fmt.Sprintf("%s with error: %s.", message, err))
+			return
+		}
+		lm.logger.Info(fmt.Sprintf("%s without errors.", message))
+	}(time.Now())
+
+	return lm.svc.Login(ctx, user, ip)
+}
+
+func (lm *loggingMiddleware) LoginTOTP(ctx context.Context, challenge, code string) (token, refreshToken string, err error) {
+	defer func(begin time.Time) {
+		message := fmt.Sprintf("Method login_totp took %s to complete", time.Since(begin))
+		if err != nil {
+			lm.logger.Warn(fmt.Sprintf("%s with error: %s.", message, err))
+			return
+		}
+		lm.logger.Info(fmt.Sprintf("%s without errors.", message))
+	}(time.Now())
+
+	return lm.svc.LoginTOTP(ctx, challenge, code)
+}
+
+func (lm *loggingMiddleware) EnrollTOTP(ctx context.Context, token string) (e users.TOTPEnrollment, err error) {
+	defer func(begin time.Time) {
+		message := fmt.Sprintf("Method enroll_totp took %s to complete", time.Since(begin))
+		if err != nil {
+			lm.logger.Warn(fmt.Sprintf("%s with error: %s.", message, err))
+			return
+		}
+		lm.logger.Info(fmt.Sprintf("%s without errors.", message))
+	}(time.Now())
+
+	return lm.svc.EnrollTOTP(ctx, token)
+}
+
+func (lm *loggingMiddleware) ConfirmTOTP(ctx context.Context, token, code string) (codes []string, err error) {
+	defer func(begin time.Time) {
+		message := fmt.Sprintf("Method confirm_totp took %s to complete", time.Since(begin))
+		if err != nil {
+			lm.logger.Warn(fmt.Sprintf("%s with error: %s.", message, err))
+			return
+		}
+		lm.logger.Info(fmt.Sprintf("%s without errors.", message))
+	}(time.Now())
+
+	return lm.svc.ConfirmTOTP(ctx, token, code)
+}
+
+func (lm *loggingMiddleware) DisableTOTP(ctx context.Context, token, code string) (err error) {
+	defer func(begin time.Time) {
+		message := fmt.Sprintf("Method disable_totp took %s to complete", time.Since(begin))
+		if err != nil {
+			lm.logger.Warn(fmt.Sprintf("%s with error: %s.", message, err))
+			return
+		}
+		lm.logger.Info(fmt.Sprintf("%s without errors.", message))
+	}(time.Now())
+
+	return lm.svc.DisableTOTP(ctx, token, code)
+}
+
+func (lm *loggingMiddleware) ResetTOTP(ctx context.Context, token, id string) (err error) {
+	defer func(begin time.Time) {
+		message := fmt.Sprintf("Method reset_totp for user %s took %s to complete", id, time.Since(begin))
+		if err != nil {
+			lm.logger.Warn(fmt.Sprintf("%s with error: %s.", message, err))
+			return
+		}
+		lm.logger.Info(fmt.Sprintf("%s without errors.", message))
+	}(time.Now())
+
+	return lm.svc.ResetTOTP(ctx, token, id)
+}
+
+func (lm *loggingMiddleware) DisableUser(ctx context.Context, token, id string) (err error) {
+	defer func(begin time.Time) {
+		message := fmt.Sprintf("Method disable_user for user %s took %s to complete", id, time.Since(begin))
+		if err != nil {
+			lm.logger.Warn(fmt.Sprintf("%s with error: %s.", message, err))
+			return
+		}
+		lm.logger.Info(fmt.Sprintf("%s without errors.", message))
+	}(time.Now())
+
+	return lm.svc.DisableUser(ctx, token, id)
+}
+
+func (lm *loggingMiddleware) EnableUser(ctx context.Context, token, id string) (err error) {
+	defer func(begin time.Time) {
+		message := fmt.Sprintf("Method enable_user for user %s took %s to complete", id, time.Since(begin))
+		if err != nil {
+			lm.logger.Warn(fmt.Sprintf("%s with error: %s.", message, err))
+			return
+		}
+		lm.logger.Info(fmt.Sprintf("%s without errors.", message))
+	}(time.Now())
+
+	return lm.svc.EnableUser(ctx, token, id)
+}
+
+func (lm *loggingMiddleware) Impersonate(ctx context.Context, token, id, reason string) (t string, err error) {
+	defer func(begin time.Time) {
+		message := fmt.Sprintf("Method impersonate for user %s took %s to complete", id, time.Since(begin))
+		if err != nil {
+			lm.logger.Warn(fmt.Sprintf("%s with error: %s.", message, err))
+			return
+		}
+		lm.logger.Info(fmt.Sprintf("%s without errors.", message))
+	}(time.Now())
+
+	return lm.svc.Impersonate(ctx, token, id, reason)
+}
+
+func (lm *loggingMiddleware) ListImpersonations(ctx context.Context, token, userID string, offset, limit uint64) (ip users.ImpersonationPage, err error) {
+	defer func(begin time.Time) {
+		message := fmt.Sprintf("Method list_impersonations took %s to complete", time.Since(begin))
+		if err != nil {
+			lm.logger.Warn(fmt.Sprintf("%s with error: %s.", message, err))
+			return
+		}
+		lm.logger.Info(fmt.Sprintf("%s without errors.", message))
+	}(time.Now())
+
+	return lm.svc.ListImpersonations(ctx, token, userID, offset, limit)
+}
+
+func (lm *loggingMiddleware) Unlock(ctx context.Context, token, id string) (err error) {
+	defer func(begin time.Time) {
+		message := fmt.Sprintf("Method unlock for user %s took %s to complete", id, time.Since(begin))
+		if err != nil {
+			lm.logger.Warn(fmt.Sprintf("%s with error: %s.", message, err))
+			return
+		}
+		lm.logger.Info(fmt.Sprintf("%s without errors.", message))
+	}(time.Now())
+
+	return lm.svc.Unlock(ctx, token, id)
+}
+
+func (lm *loggingMiddleware) Refresh(ctx context.Context, refreshToken string) (token, newRefreshToken string, err error) {
+	defer func(begin time.Time) {
+		message := fmt.Sprintf("Method refresh took %s to complete", time.Since(begin))
+		if err != nil {
+			lm.logger.Warn(fmt.Sprintf("%s with error: %s.", message, err))
+			return
+		}
+		lm.logger.Info(fmt.Sprintf("%s without errors.", message))
+	}(time.Now())
+
+	return lm.svc.Refresh(ctx, refreshToken)
+}
+
+func (lm *loggingMiddleware) OIDCAuthURL(ctx context.Context, state string) (url string, err error) {
+	defer func(begin time.Time) {
+		message := fmt.Sprintf("Method oidc_auth_url took %s to complete", time.Since(begin))
+		if err != nil {
+			lm.logger.Warn(fmt.Sprintf("%s with error: %s.", message, err))
+			return
+		}
+		lm.logger.Info(fmt.Sprintf("%s without errors.", message))
+	}(time.Now())
+
+	return lm.svc.OIDCAuthURL(ctx, state)
+}
+
+func (lm *loggingMiddleware) OIDCLogin(ctx context.Context, code, state string) (token, refreshToken string, err error) {
+	defer func(begin time.Time) {
+		message := fmt.Sprintf("Method oidc_login took %s to complete", time.Since(begin))
+		if err != nil {
+			lm.logger.Warn(fmt.Sprintf("%s with error: %s.", message, err))
+			return
+		}
+		lm.logger.Info(fmt.Sprintf("%s without errors.", message))
+	}(time.Now())
+
+	return lm.svc.OIDCLogin(ctx, code, state)
+}
+
+func (lm *loggingMiddleware) Logout(ctx context.Context, token string) (err error) {
+	defer func(begin time.Time) {
+		message := fmt.Sprintf("Method logout took %s to complete", time.Since(begin))
+		if err != nil {
+			lm.logger.Warn(fmt.Sprintf("%s with error: %s.", message, err))
+			return
+		}
+		lm.logger.Info(fmt.Sprintf("%s without errors.", message))
+	}(time.Now())
+
+	return lm.svc.Logout(ctx, token)
 }
 
 func (lm *loggingMiddleware) ViewUser(ctx context.Context, token, id string) (u users.User, err error) {
@@ -77,7 +289,7 @@ func (lm *loggingMiddleware) ViewProfile(ctx context.Context, token string) (u u
 	return lm.svc.ViewProfile(ctx, token)
 }
 
-func (lm *loggingMiddleware) ListUsers(ctx context.Context, token string, offset, limit uint64, email string, um users.Metadata) (e users.UserPage, err error) {
+func (lm *loggingMiddleware) ListUsers(ctx context.Context, token string, offset, limit uint64, cursor, email string, um users.Metadata) (e users.UserPage, err error) {
 	defer func(begin time.Time) {
 		message := fmt.Sprintf("Method list_users for token %s took %s to complete", token, time.Since(begin))
 		if err != nil {
@@ -87,7 +299,7 @@ func (lm *loggingMiddleware) ListUsers(ctx context.Context, token string, offset
 		lm.logger.Info(fmt.Sprintf("%s without errors.", message))
 	}(time.Now())
 
-	return lm.svc.ListUsers(ctx, token, offset, limit, email, um)
+	return lm.svc.ListUsers(ctx, token, offset, limit, cursor, email, um)
 }
 
 func (lm *loggingMiddleware) UpdateUser(ctx context.Context, token string, u users.User) (err error) {
@@ -233,9 +445,9 @@ func (lm *loggingMiddleware) ViewGroup(ctx context.Context, token, id string) (u
 	return lm.svc.ViewGroup(ctx, token, id)
 }
 
-func (lm *loggingMiddleware) Assign(ctx context.Context, token, userID, groupID string) (err error) {
+func (lm *loggingMiddleware) Assign(ctx context.Context, token, userID, groupID, role string) (err error) {
 	defer func(begin time.Time) {
-		message := fmt.Sprintf("Method assign user %s, group %s took %s to complete", userID, groupID, time.Since(begin))
+		message := fmt.Sprintf("Method assign user %s, group %s, role %s took %s to complete", userID, groupID, role, time.Since(begin))
 		if err != nil {
 			lm.logger.Warn(fmt.Sprintf("%s with error: %s.", message, err))
 			return
@@ -243,7 +455,7 @@ func (lm *loggingMiddleware) Assign(ctx context.Context, token, userID, groupID
 		lm.logger.Info(fmt.Sprintf("%s without errors.", message))
 	}(time.Now())
 
-	return lm.svc.Assign(ctx, token, userID, groupID)
+	return lm.svc.Assign(ctx, token, userID, groupID, role)
 }
 
 func (lm *loggingMiddleware) Unassign(ctx context.Context, token, userID, groupID string) (err error) {
@@ -271,3 +483,68 @@ func (lm *loggingMiddleware) ListMemberships(ctx context.Context, token, id stri
 
 	return lm.svc.ListMemberships(ctx, token, id, offset, limit, um)
 }
+
+func (lm *loggingMiddleware) Invite(ctx context.Context, token, groupID, email, role string) (inv users.Invitation, err error) {
+	defer func(begin time.Time) {
+		message := fmt.Sprintf("Method invite for group %s, role %s took %s to complete", groupID, role, time.Since(begin))
+		if err != nil {
+			lm.logger.Warn(fmt.Sprintf("%s with error: %s.", message, err))
+			return
+		}
+		lm.logger.Info(fmt.Sprintf("%s without errors.", message))
+	}(time.Now())
+
+	return lm.svc.Invite(ctx, token, groupID, email, role)
+}
+
+func (lm *loggingMiddleware) ListInvitations(ctx context.Context, token, groupID string, offset, limit uint64) (ip users.InvitationPage, err error) {
+	defer func(begin time.Time) {
+		message := fmt.Sprintf("Method list_invitations for group %s took %s to complete", groupID, time.Since(begin))
+		if err != nil {
+			lm.logger.Warn(fmt.Sprintf("%s with error: %s.", message, err))
+			return
+		}
+		lm.logger.Info(fmt.Sprintf("%s without errors.", message))
+	}(time.Now())
+
+	return lm.svc.ListInvitations(ctx, token, groupID, offset, limit)
+}
+
+func (lm *loggingMiddleware) RevokeInvitation(ctx context.Context, token, id string) (err error) {
+	defer func(begin time.Time) {
+		message := fmt.Sprintf("Method revoke_invitation for invitation %s took %s to complete", id, time.Since(begin))
+		if err != nil {
+			lm.logger.Warn(fmt.Sprintf("%s with error: %s.", message, err))
+			return
+		}
+		lm.logger.Info(fmt.Sprintf("%s without errors.", message))
+	}(time.Now())
+
+	return lm.svc.RevokeInvitation(ctx, token, id)
+}
+
+func (lm *loggingMiddleware) AcceptInvitation(ctx context.Context, invToken, password string) (id string, err error) {
+	defer func(begin time.Time) {
+		message := fmt.Sprintf("Method accept_invitation for user %s took %s to complete", id, time.Since(begin))
+		if err != nil {
+			lm.logger.Warn(fmt.Sprintf("%s with error: %s.", message, err))
+			return
+		}
+		lm.logger.Info(fmt.Sprintf("%s without errors.", message))
+	}(time.Now())
+
+	return lm.svc.AcceptInvitation(ctx, invToken, password)
+}
+
+func (lm *loggingMiddleware) Authorize(ctx context.Context, email, role string) (groups []string, err error) {
+	defer func(begin time.Time) {
+		message := fmt.Sprintf("Method authorize for user %s, role %s took %s to complete", email, role, time.Since(begin))
+		if err != nil {
+			lm.logger.Warn(fmt.Sprintf("%s with error: %s.", message, err))
+			return
+		}
+		lm.logger.Info(fmt.Sprintf("%s without errors.", message))
+	}(time.Now())
+
+	return lm.svc.Authorize(ctx, email, role)
+}
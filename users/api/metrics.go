@@ -8,24 +8,28 @@ import (
 	"time"
 
 	"github.com/go-kit/kit/metrics"
+	"github.com/mainflux/mainflux/pkg/errors"
 	"github.com/mainflux/mainflux/users"
 )
 
 var _ users.Service = (*metricsMiddleware)(nil)
 
 type metricsMiddleware struct {
-	counter metrics.Counter
-	latency metrics.Histogram
-	svc     users.Service
+	counter  metrics.Counter
+	latency  metrics.Histogram
+	lockouts metrics.Counter
+	svc      users.Service
 }
 
 // MetricsMiddleware instruments core service by tracking request count and
-// latency.
-func MetricsMiddleware(svc users.Service, counter metrics.Counter, latency metrics.Histogram) users.Service {
+// latency, as well as the number of login lockouts and the logins rejected
+// because of them.
+func MetricsMiddleware(svc users.Service, counter metrics.Counter, latency metrics.Histogram, lockouts metrics.Counter) users.Service {
 	return &metricsMiddleware{
-		counter: counter,
-		latency: latency,
-		svc:     svc,
+		counter:  counter,
+		latency:  latency,
+		lockouts: lockouts,
+		svc:      svc,
 	}
 }
 
@@ -38,13 +42,163 @@ func (ms *metricsMiddleware) Register(ctx context.Context, user users.User) (str
 	return ms.svc.Register(ctx, user)
 }
 
-func (ms *metricsMiddleware) Login(ctx context.Context, user users.User) (string, error) {
+func (ms *metricsMiddleware) VerifyEmail(ctx context.Context, token string) error {
+	defer func(begin time.Time) {
+		ms.counter.With("method", "verify_email").Add(1)
+		ms.latency.With("method", "verify_email").Observe(time.Since(begin).Seconds())
+	}(time.Now())
+
+	return ms.svc.VerifyEmail(ctx, token)
+}
+
+func (ms *metricsMiddleware) ResendVerification(ctx context.Context, email string) error {
+	defer func(begin time.Time) {
+		ms.counter.With("method", "resend_verification").Add(1)
+		ms.latency.With("method", "resend_verification").Observe(time.Since(begin).Seconds())
+	}(time.Now())
+
+	return ms.svc.ResendVerification(ctx, email)
+}
+
+func (ms *metricsMiddleware) Login(ctx context.Context, user users.User, ip string) (token, refreshToken string, err error) {
 	defer func(begin time.Time) {
 		ms.counter.With("method", "login").Add(1)
 		ms.latency.With("method", "login").Observe(time.Since(begin).Seconds())
+		switch {
+		case errors.Contains(err, users.ErrLockout):
+			ms.lockouts.With("event", "lockout").Add(1)
+		case errors.Contains(err, users.ErrTooManyAttempts):
+			ms.lockouts.With("event", "rejected").Add(1)
+		}
 	}(time.Now())
 
-	return ms.svc.Login(ctx, user)
+	return ms.svc.Login(ctx, user, ip)
+}
+
+func (ms *metricsMiddleware) LoginTOTP(ctx context.Context, challenge, code string) (string, string, error) {
+	defer func(begin time.Time) {
+		ms.counter.With("method", "login_totp").Add(1)
+		ms.latency.With("method", "login_totp").Observe(time.Since(begin).Seconds())
+	}(time.Now())
+
+	return ms.svc.LoginTOTP(ctx, challenge, code)
+}
+
+func (ms *metricsMiddleware) EnrollTOTP(ctx context.Context, token string) (users.TOTPEnrollment, error) {
+	defer func(begin time.Time) {
+		ms.counter.With("method", "enroll_totp").Add(1)
+		ms.latency.With("method", "enroll_totp").Observe(time.Since(begin).Seconds())
+	}(time.Now())
+
+	return ms.svc.EnrollTOTP(ctx, token)
+}
+
+func (ms *metricsMiddleware) ConfirmTOTP(ctx context.Context, token, code string) ([]string, error) {
+	defer func(begin time.Time) {
+		ms.counter.With("method", "confirm_totp").Add(1)
+		ms.latency.With("method", "confirm_totp").Observe(time.Since(begin).Seconds())
+	}(time.Now())
+
+	return ms.svc.ConfirmTOTP(ctx, token, code)
+}
+
+func (ms *metricsMiddleware) DisableTOTP(ctx context.Context, token, code string) error {
+	defer func(begin time.Time) {
+		ms.counter.With("method", "disable_totp").Add(1)
+		ms.latency.With("method", "disable_totp").Observe(time.Since(begin).Seconds())
+	}(time.Now())
+
+	return ms.svc.DisableTOTP(ctx, token, code)
+}
+
+func (ms *metricsMiddleware) ResetTOTP(ctx context.Context, token, id string) error {
+	defer func(begin time.Time) {
+		ms.counter.With("method", "reset_totp").Add(1)
+		ms.latency.With("method", "reset_totp").Observe(time.Since(begin).Seconds())
+	}(time.Now())
+
+	return ms.svc.ResetTOTP(ctx, token, id)
+}
+
+func (ms *metricsMiddleware) DisableUser(ctx context.Context, token, id string) error {
+	defer func(begin time.Time) {
+		ms.counter.With("method", "disable_user").Add(1)
+		ms.latency.With("method", "disable_user").Observe(time.Since(begin).Seconds())
+	}(time.Now())
+
+	return ms.svc.DisableUser(ctx, token, id)
+}
+
+func (ms *metricsMiddleware) EnableUser(ctx context.Context, token, id string) error {
+	defer func(begin time.Time) {
+		ms.counter.With("method", "enable_user").Add(1)
+		ms.latency.With("method", "enable_user").Observe(time.Since(begin).Seconds())
+	}(time.Now())
+
+	return ms.svc.EnableUser(ctx, token, id)
+}
+
+func (ms *metricsMiddleware) Impersonate(ctx context.Context, token, id, reason string) (string, error) {
+	defer func(begin time.Time) {
+		ms.counter.With("method", "impersonate").Add(1)
+		ms.latency.With("method", "impersonate").Observe(time.Since(begin).Seconds())
+	}(time.Now())
+
+	return ms.svc.Impersonate(ctx, token, id, reason)
+}
+
+func (ms *metricsMiddleware) ListImpersonations(ctx context.Context, token, userID string, offset, limit uint64) (users.ImpersonationPage, error) {
+	defer func(begin time.Time) {
+		ms.counter.With("method", "list_impersonations").Add(1)
+		ms.latency.With("method", "list_impersonations").Observe(time.Since(begin).Seconds())
+	}(time.Now())
+
+	return ms.svc.ListImpersonations(ctx, token, userID, offset, limit)
+}
+
+func (ms *metricsMiddleware) Unlock(ctx context.Context, token, id string) error {
+	defer func(begin time.Time) {
+		ms.counter.With("method", "unlock").Add(1)
+		ms.latency.With("method", "unlock").Observe(time.Since(begin).Seconds())
+	}(time.Now())
+
+	return ms.svc.Unlock(ctx, token, id)
+}
+
+func (ms *metricsMiddleware) Refresh(ctx context.Context, refreshToken string) (string, string, error) {
+	defer func(begin time.Time) {
+		ms.counter.With("method", "refresh").Add(1)
+		ms.latency.With("method", "refresh").Observe(time.Since(begin).Seconds())
+	}(time.Now())
+
+	return ms.svc.Refresh(ctx, refreshToken)
+}
+
+func (ms *metricsMiddleware) OIDCAuthURL(ctx context.Context, state string) (string, error) {
+	defer func(begin time.Time) {
+		ms.counter.With("method", "oidc_auth_url").Add(1)
+		ms.latency.With("method", "oidc_auth_url").Observe(time.Since(begin).Seconds())
+	}(time.Now())
+
+	return ms.svc.OIDCAuthURL(ctx, state)
+}
+
+func (ms *metricsMiddleware) OIDCLogin(ctx context.Context, code, state string) (string, string, error) {
+	defer func(begin time.Time) {
+		ms.counter.With("method", "oidc_login").Add(1)
+		ms.latency.With("method", "oidc_login").Observe(time.Since(begin).Seconds())
+	}(time.Now())
+
+	return ms.svc.OIDCLogin(ctx, code, state)
+}
+
+func (ms *metricsMiddleware) Logout(ctx context.Context, token string) error {
+	defer func(begin time.Time) {
+		ms.counter.With("method", "logout").Add(1)
+		ms.latency.With("method", "logout").Observe(time.Since(begin).Seconds())
+	}(time.Now())
+
+	return ms.svc.Logout(ctx, token)
 }
 
 func (ms *metricsMiddleware) ViewUser(ctx context.Context, token, id string) (users.User, error) {
@@ -65,13 +219,13 @@ func (ms *metricsMiddleware) ViewProfile(ctx context.Context, token string) (use
 	return ms.svc.ViewProfile(ctx, token)
 }
 
-func (ms *metricsMiddleware) ListUsers(ctx context.Context, token string, offset, limit uint64, email string, um users.Metadata) (users.UserPage, error) {
+func (ms *metricsMiddleware) ListUsers(ctx context.Context, token string, offset, limit uint64, cursor, email string, um users.Metadata) (users.UserPage, error) {
 	defer func(begin time.Time) {
 		ms.counter.With("method", "list_users").Add(1)
 		ms.latency.With("method", "list_users").Observe(time.Since(begin).Seconds())
 	}(time.Now())
 
-	return ms.svc.ListUsers(ctx, token, offset, limit, email, um)
+	return ms.svc.ListUsers(ctx, token, offset, limit, cursor, email, um)
 }
 
 func (ms *metricsMiddleware) UpdateUser(ctx context.Context, token string, u users.User) (err error) {
@@ -174,13 +328,13 @@ func (ms *metricsMiddleware) ViewGroup(ctx context.Context, token, name string)
 	return ms.svc.ViewGroup(ctx, token, name)
 }
 
-func (ms *metricsMiddleware) Assign(ctx context.Context, token, userID, groupID string) error {
+func (ms *metricsMiddleware) Assign(ctx context.Context, token, userID, groupID, role string) error {
 	defer func(begin time.Time) {
 		ms.counter.With("method", "assign").Add(1)
 		ms.latency.With("method", "assign").Observe(time.Since(begin).Seconds())
 	}(time.Now())
 
-	return ms.svc.Assign(ctx, token, userID, groupID)
+	return ms.svc.Assign(ctx, token, userID, groupID, role)
 }
 
 func (ms *metricsMiddleware) Unassign(ctx context.Context, token, userID, groupID string) error {
@@ -200,3 +354,48 @@ func (ms *metricsMiddleware) ListMemberships(ctx context.Context, token, id stri
 
 	return ms.svc.ListMemberships(ctx, token, id, offset, limit, um)
 }
+
+func (ms *metricsMiddleware) Invite(ctx context.Context, token, groupID, email, role string) (users.Invitation, error) {
+	defer func(begin time.Time) {
+		ms.counter.With("method", "invite").Add(1)
+		ms.latency.With("method", "invite").Observe(time.Since(begin).Seconds())
+	}(time.Now())
+
+	return ms.svc.Invite(ctx, token, groupID, email, role)
+}
+
+func (ms *metricsMiddleware) ListInvitations(ctx context.Context, token, groupID string, offset, limit uint64) (users.InvitationPage, error) {
+	defer func(begin time.Time) {
+		ms.counter.With("method", "list_invitations").Add(1)
+		ms.latency.With("method", "list_invitations").Observe(time.Since(begin).Seconds())
+	}(time.Now())
+
+	return ms.svc.ListInvitations(ctx, token, groupID, offset, limit)
+}
+
+func (ms *metricsMiddleware) RevokeInvitation(ctx context.Context, token, id string) error {
+	defer func(begin time.Time) {
+		ms.counter.With("method", "revoke_invitation").Add(1)
+		ms.latency.With("method", "revoke_invitation").Observe(time.Since(begin).Seconds())
+	}(time.Now())
+
+	return ms.svc.RevokeInvitation(ctx, token, id)
+}
+
+func (ms *metricsMiddleware) AcceptInvitation(ctx context.Context, invToken, password string) (string, error) {
+	defer func(begin time.Time) {
+		ms.counter.With("method", "accept_invitation").Add(1)
+		ms.latency.With("method", "accept_invitation").Observe(time.Since(begin).Seconds())
+	}(time.Now())
+
+	return ms.svc.AcceptInvitation(ctx, invToken, password)
+}
+
+func (ms *metricsMiddleware) Authorize(ctx context.Context, email, role string) ([]string, error) {
+	defer func(begin time.Time) {
+		ms.counter.With("method", "authorize").Add(1)
+		ms.latency.With("method", "authorize").Observe(time.Since(begin).Seconds())
+	}(time.Now())
+
+	return ms.svc.Authorize(ctx, email, role)
+}
@@ -4,6 +4,8 @@
 package api
 
 import (
+	"strings"
+
 	"github.com/mainflux/mainflux/users"
 )
 
@@ -20,6 +22,37 @@ func (req userReq) validate() error {
 	return req.user.Validate()
 }
 
+type verifyEmailReq struct {
+	Token string `json:"token"`
+}
+
+func (req verifyEmailReq) validate() error {
+	if req.Token == "" {
+		return users.ErrMalformedEntity
+	}
+	return nil
+}
+
+type resendVerificationReq struct {
+	Email string `json:"email"`
+}
+
+func (req resendVerificationReq) validate() error {
+	if req.Email == "" {
+		return users.ErrMalformedEntity
+	}
+	return nil
+}
+
+type loginReq struct {
+	user users.User
+	ip   string
+}
+
+func (req loginReq) validate() error {
+	return req.user.Validate()
+}
+
 type viewUserReq struct {
 	token  string
 	userID string
@@ -32,10 +65,79 @@ func (req viewUserReq) validate() error {
 	return nil
 }
 
+type logoutReq struct {
+	token string
+}
+
+func (req logoutReq) validate() error {
+	if req.token == "" {
+		return users.ErrUnauthorizedAccess
+	}
+	return nil
+}
+
+type refreshReq struct {
+	RefreshToken string `json:"refresh_token"`
+}
+
+func (req refreshReq) validate() error {
+	if req.RefreshToken == "" {
+		return users.ErrMalformedEntity
+	}
+	return nil
+}
+
+type loginTOTPReq struct {
+	ChallengeToken string `json:"challenge_token"`
+	Code           string `json:"code"`
+}
+
+func (req loginTOTPReq) validate() error {
+	if req.ChallengeToken == "" || req.Code == "" {
+		return users.ErrMalformedEntity
+	}
+	return nil
+}
+
+type totpReq struct {
+	token string
+	Code  string `json:"code"`
+}
+
+func (req totpReq) validate() error {
+	if req.token == "" {
+		return users.ErrUnauthorizedAccess
+	}
+	if req.Code == "" {
+		return users.ErrMalformedEntity
+	}
+	return nil
+}
+
+type oidcCallbackReq struct {
+	code        string
+	state       string
+	cookieState string
+}
+
+// validate checks that the callback state matches the one stored in the
+// cookie on redirect, which ties the callback to the login started by the
+// same user agent.
+func (req oidcCallbackReq) validate() error {
+	if req.code == "" || req.state == "" {
+		return users.ErrMalformedEntity
+	}
+	if req.state != req.cookieState {
+		return users.ErrUnauthorizedAccess
+	}
+	return nil
+}
+
 type listUsersReq struct {
 	token    string
 	offset   uint64
 	limit    uint64
+	cursor   string
 	email    string
 	metadata users.Metadata
 }
@@ -186,6 +288,11 @@ func (req userGroupReq) validate() error {
 	return nil
 }
 
+type assignReq struct {
+	userGroupReq
+	role string
+}
+
 type groupReq struct {
 	token   string
 	groupID string
@@ -201,3 +308,94 @@ func (req groupReq) validate() error {
 	}
 	return nil
 }
+
+type inviteReq struct {
+	token   string
+	groupID string
+	Email   string `json:"email"`
+	Role    string `json:"role"`
+}
+
+func (req inviteReq) validate() error {
+	if req.token == "" {
+		return users.ErrUnauthorizedAccess
+	}
+	if req.groupID == "" || req.Email == "" || req.Role == "" {
+		return users.ErrMalformedEntity
+	}
+	return nil
+}
+
+type listInvitationsReq struct {
+	token   string
+	groupID string
+	offset  uint64
+	limit   uint64
+}
+
+func (req listInvitationsReq) validate() error {
+	if req.token == "" {
+		return users.ErrUnauthorizedAccess
+	}
+	if req.groupID == "" {
+		return users.ErrMalformedEntity
+	}
+	return nil
+}
+
+type invitationReq struct {
+	token string
+	id    string
+}
+
+func (req invitationReq) validate() error {
+	if req.token == "" {
+		return users.ErrUnauthorizedAccess
+	}
+	if req.id == "" {
+		return users.ErrMalformedEntity
+	}
+	return nil
+}
+
+type acceptInvitationReq struct {
+	Token    string `json:"token"`
+	Password string `json:"password,omitempty"`
+}
+
+func (req acceptInvitationReq) validate() error {
+	if req.Token == "" {
+		return users.ErrMalformedEntity
+	}
+	return nil
+}
+
+type impersonateReq struct {
+	token  string
+	userID string
+	Reason string `json:"reason"`
+}
+
+func (req impersonateReq) validate() error {
+	if req.token == "" {
+		return users.ErrUnauthorizedAccess
+	}
+	if req.userID == "" || strings.TrimSpace(req.Reason) == "" || len(req.Reason) > maxNameSize {
+		return users.ErrMalformedEntity
+	}
+	return nil
+}
+
+type listImpersonationsReq struct {
+	token  string
+	userID string
+	offset uint64
+	limit  uint64
+}
+
+func (req listImpersonationsReq) validate() error {
+	if req.token == "" {
+		return users.ErrUnauthorizedAccess
+	}
+	return nil
+}
@@ -6,6 +6,7 @@ package api
 import (
 	"fmt"
 	"net/http"
+	"time"
 
 	"github.com/mainflux/mainflux"
 	"github.com/mainflux/mainflux/users"
@@ -22,15 +23,30 @@ var (
 	_ mainflux.Response = (*groupDeleteRes)(nil)
 	_ mainflux.Response = (*assignUserToGroupRes)(nil)
 	_ mainflux.Response = (*removeUserFromGroupRes)(nil)
+	_ mainflux.Response = (*logoutRes)(nil)
+	_ mainflux.Response = (*oidcRedirectRes)(nil)
+	_ mainflux.Response = (*enrollTOTPRes)(nil)
+	_ mainflux.Response = (*confirmTOTPRes)(nil)
+	_ mainflux.Response = (*disableTOTPRes)(nil)
+	_ mainflux.Response = (*unlockRes)(nil)
+	_ mainflux.Response = (*verifyEmailRes)(nil)
+	_ mainflux.Response = (*resendVerificationRes)(nil)
+	_ mainflux.Response = (*invitationRes)(nil)
+	_ mainflux.Response = (*invitationPageRes)(nil)
+	_ mainflux.Response = (*revokeInvitationRes)(nil)
+	_ mainflux.Response = (*acceptInvitationRes)(nil)
+	_ mainflux.Response = (*userStatusRes)(nil)
+	_ mainflux.Response = (*impersonationPageRes)(nil)
 )
 
 // MailSent message response when link is sent
 const MailSent = "Email with reset link is sent"
 
 type pageRes struct {
-	Total  uint64 `json:"total"`
-	Offset uint64 `json:"offset"`
-	Limit  uint64 `json:"limit"`
+	Total      uint64 `json:"total"`
+	Offset     uint64 `json:"offset"`
+	Limit      uint64 `json:"limit"`
+	NextCursor string `json:"next_cursor,omitempty"`
 }
 
 type createUserRes struct {
@@ -61,7 +77,9 @@ func (res createUserRes) Empty() bool {
 }
 
 type tokenRes struct {
-	Token string `json:"token,omitempty"`
+	Token          string `json:"token,omitempty"`
+	RefreshToken   string `json:"refresh_token,omitempty"`
+	ChallengeToken string `json:"challenge_token,omitempty"`
 }
 
 func (res tokenRes) Code() int {
@@ -73,7 +91,152 @@ func (res tokenRes) Headers() map[string]string {
 }
 
 func (res tokenRes) Empty() bool {
-	return res.Token == ""
+	return res.Token == "" && res.ChallengeToken == ""
+}
+
+type oidcRedirectRes struct {
+	url   string
+	state string
+}
+
+func (res oidcRedirectRes) Code() int {
+	return http.StatusFound
+}
+
+func (res oidcRedirectRes) Headers() map[string]string {
+	cookie := http.Cookie{
+		Name:     oidcStateCookie,
+		Value:    res.state,
+		Path:     oidcPath,
+		MaxAge:   oidcStateMaxAge,
+		HttpOnly: true,
+		SameSite: http.SameSiteLaxMode,
+	}
+	return map[string]string{
+		"Location":   res.url,
+		"Set-Cookie": cookie.String(),
+	}
+}
+
+func (res oidcRedirectRes) Empty() bool {
+	return true
+}
+
+type logoutRes struct{}
+
+func (res logoutRes) Code() int {
+	return http.StatusNoContent
+}
+
+func (res logoutRes) Headers() map[string]string {
+	return map[string]string{}
+}
+
+func (res logoutRes) Empty() bool {
+	return true
+}
+
+type enrollTOTPRes struct {
+	Secret string `json:"secret"`
+	URI    string `json:"uri"`
+}
+
+func (res enrollTOTPRes) Code() int {
+	return http.StatusCreated
+}
+
+func (res enrollTOTPRes) Headers() map[string]string {
+	return map[string]string{}
+}
+
+func (res enrollTOTPRes) Empty() bool {
+	return false
+}
+
+type confirmTOTPRes struct {
+	RecoveryCodes []string `json:"recovery_codes"`
+}
+
+func (res confirmTOTPRes) Code() int {
+	return http.StatusOK
+}
+
+func (res confirmTOTPRes) Headers() map[string]string {
+	return map[string]string{}
+}
+
+func (res confirmTOTPRes) Empty() bool {
+	return false
+}
+
+type disableTOTPRes struct{}
+
+func (res disableTOTPRes) Code() int {
+	return http.StatusNoContent
+}
+
+func (res disableTOTPRes) Headers() map[string]string {
+	return map[string]string{}
+}
+
+func (res disableTOTPRes) Empty() bool {
+	return true
+}
+
+type unlockRes struct{}
+
+func (res unlockRes) Code() int {
+	return http.StatusNoContent
+}
+
+func (res unlockRes) Headers() map[string]string {
+	return map[string]string{}
+}
+
+func (res unlockRes) Empty() bool {
+	return true
+}
+
+type userStatusRes struct{}
+
+func (res userStatusRes) Code() int {
+	return http.StatusNoContent
+}
+
+func (res userStatusRes) Headers() map[string]string {
+	return map[string]string{}
+}
+
+func (res userStatusRes) Empty() bool {
+	return true
+}
+
+type verifyEmailRes struct{}
+
+func (res verifyEmailRes) Code() int {
+	return http.StatusNoContent
+}
+
+func (res verifyEmailRes) Headers() map[string]string {
+	return map[string]string{}
+}
+
+func (res verifyEmailRes) Empty() bool {
+	return true
+}
+
+type resendVerificationRes struct{}
+
+func (res resendVerificationRes) Code() int {
+	return http.StatusAccepted
+}
+
+func (res resendVerificationRes) Headers() map[string]string {
+	return map[string]string{}
+}
+
+func (res resendVerificationRes) Empty() bool {
+	return true
 }
 
 type updateUserRes struct{}
@@ -95,6 +258,7 @@ type viewUserRes struct {
 	Email    string                 `json:"email"`
 	Groups   []users.Group          `json:"groups"`
 	Metadata map[string]interface{} `json:"metadata,omitempty"`
+	Status   string                 `json:"status,omitempty"`
 }
 
 func (res viewUserRes) Code() int {
@@ -269,3 +433,102 @@ func (res removeUserFromGroupRes) Headers() map[string]string {
 func (res removeUserFromGroupRes) Empty() bool {
 	return true
 }
+
+type invitationRes struct {
+	ID        string    `json:"id"`
+	GroupID   string    `json:"group_id"`
+	Email     string    `json:"email"`
+	Role      string    `json:"role"`
+	InvitedBy string    `json:"invited_by"`
+	Created   time.Time `json:"created"`
+	Expires   time.Time `json:"expires"`
+	created   bool
+}
+
+func (res invitationRes) Code() int {
+	if res.created {
+		return http.StatusCreated
+	}
+
+	return http.StatusOK
+}
+
+func (res invitationRes) Headers() map[string]string {
+	return map[string]string{}
+}
+
+func (res invitationRes) Empty() bool {
+	return false
+}
+
+type invitationPageRes struct {
+	pageRes
+	Invitations []invitationRes `json:"invitations"`
+}
+
+func (res invitationPageRes) Code() int {
+	return http.StatusOK
+}
+
+func (res invitationPageRes) Headers() map[string]string {
+	return map[string]string{}
+}
+
+func (res invitationPageRes) Empty() bool {
+	return false
+}
+
+type revokeInvitationRes struct{}
+
+func (res revokeInvitationRes) Code() int {
+	return http.StatusNoContent
+}
+
+func (res revokeInvitationRes) Headers() map[string]string {
+	return map[string]string{}
+}
+
+func (res revokeInvitationRes) Empty() bool {
+	return true
+}
+
+type acceptInvitationRes struct {
+	ID string `json:"id"`
+}
+
+func (res acceptInvitationRes) Code() int {
+	return http.StatusOK
+}
+
+func (res acceptInvitationRes) Headers() map[string]string {
+	return map[string]string{}
+}
+
+func (res acceptInvitationRes) Empty() bool {
+	return false
+}
+
+type impersonationRes struct {
+	ID      string    `json:"id"`
+	AdminID string    `json:"admin_id"`
+	UserID  string    `json:"user_id"`
+	Reason  string    `json:"reason"`
+	Created time.Time `json:"created"`
+}
+
+type impersonationPageRes struct {
+	pageRes
+	Impersonations []impersonationRes `json:"impersonations"`
+}
+
+func (res impersonationPageRes) Code() int {
+	return http.StatusOK
+}
+
+func (res impersonationPageRes) Headers() map[string]string {
+	return map[string]string{}
+}
+
+func (res impersonationPageRes) Empty() bool {
+	return false
+}
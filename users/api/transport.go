@@ -17,6 +17,7 @@ import (
 	kithttp "github.com/go-kit/kit/transport/http"
 	"github.com/go-zoo/bone"
 	"github.com/mainflux/mainflux"
+	"github.com/mainflux/mainflux/pkg/audit"
 	"github.com/mainflux/mainflux/users"
 	opentracing "github.com/opentracing/opentracing-go"
 	"github.com/prometheus/client_golang/prometheus/promhttp"
@@ -30,6 +31,15 @@ const (
 	nameKey     = "name"
 	emailKey    = "email"
 	metadataKey = "metadata"
+	cursorKey   = "cursor"
+	codeKey     = "code"
+	stateKey    = "state"
+	roleKey     = "role"
+	userIDKey   = "user_id"
+
+	oidcPath        = "/oidc"
+	oidcStateCookie = "oidc_state"
+	oidcStateMaxAge = 600
 
 	defOffset = 0
 	defLimit  = 10
@@ -49,6 +59,7 @@ var (
 func MakeHandler(svc users.Service, tracer opentracing.Tracer) http.Handler {
 	opts := []kithttp.ServerOption{
 		kithttp.ServerErrorEncoder(encodeError),
+		kithttp.ServerBefore(audit.PopulateIP),
 	}
 
 	mux := bone.New()
@@ -60,6 +71,90 @@ func MakeHandler(svc users.Service, tracer opentracing.Tracer) http.Handler {
 		opts...,
 	))
 
+	mux.Delete("/tokens", kithttp.NewServer(
+		kitot.TraceServer(tracer, "logout")(logoutEndpoint(svc)),
+		decodeLogout,
+		encodeResponse,
+		opts...,
+	))
+
+	mux.Post("/users/verify", kithttp.NewServer(
+		kitot.TraceServer(tracer, "verify_email")(verifyEmailEndpoint(svc)),
+		decodeVerifyEmail,
+		encodeResponse,
+		opts...,
+	))
+
+	mux.Post("/users/verify/resend", kithttp.NewServer(
+		kitot.TraceServer(tracer, "resend_verification")(resendVerificationEndpoint(svc)),
+		decodeResendVerification,
+		encodeResponse,
+		opts...,
+	))
+
+	mux.Post("/users/totp", kithttp.NewServer(
+		kitot.TraceServer(tracer, "enroll_totp")(enrollTOTPEndpoint(svc)),
+		decodeViewProfile,
+		encodeResponse,
+		opts...,
+	))
+
+	mux.Post("/users/totp/confirm", kithttp.NewServer(
+		kitot.TraceServer(tracer, "confirm_totp")(confirmTOTPEndpoint(svc)),
+		decodeTOTP,
+		encodeResponse,
+		opts...,
+	))
+
+	mux.Post("/users/totp/disable", kithttp.NewServer(
+		kitot.TraceServer(tracer, "disable_totp")(disableTOTPEndpoint(svc)),
+		decodeTOTP,
+		encodeResponse,
+		opts...,
+	))
+
+	mux.Delete("/users/:userID/totp", kithttp.NewServer(
+		kitot.TraceServer(tracer, "reset_totp")(resetTOTPEndpoint(svc)),
+		decodeViewUser,
+		encodeResponse,
+		opts...,
+	))
+
+	mux.Delete("/users/:userID/lockout", kithttp.NewServer(
+		kitot.TraceServer(tracer, "unlock")(unlockEndpoint(svc)),
+		decodeViewUser,
+		encodeResponse,
+		opts...,
+	))
+
+	mux.Put("/users/:userID/disabled", kithttp.NewServer(
+		kitot.TraceServer(tracer, "disable_user")(disableUserEndpoint(svc)),
+		decodeViewUser,
+		encodeResponse,
+		opts...,
+	))
+
+	mux.Delete("/users/:userID/disabled", kithttp.NewServer(
+		kitot.TraceServer(tracer, "enable_user")(enableUserEndpoint(svc)),
+		decodeViewUser,
+		encodeResponse,
+		opts...,
+	))
+
+	mux.Post("/users/:userID/impersonate", kithttp.NewServer(
+		kitot.TraceServer(tracer, "impersonate")(impersonateEndpoint(svc)),
+		decodeImpersonate,
+		encodeResponse,
+		opts...,
+	))
+
+	mux.Get("/impersonations", kithttp.NewServer(
+		kitot.TraceServer(tracer, "list_impersonations")(listImpersonationsEndpoint(svc)),
+		decodeListImpersonations,
+		encodeResponse,
+		opts...,
+	))
+
 	mux.Get("/users/profile", kithttp.NewServer(
 		kitot.TraceServer(tracer, "view_profile")(viewProfileEndpoint(svc)),
 		decodeViewProfile,
@@ -139,7 +234,7 @@ func MakeHandler(svc users.Service, tracer opentracing.Tracer) http.Handler {
 
 	mux.Put("/groups/:groupID/users/:userID", kithttp.NewServer(
 		kitot.TraceServer(tracer, "assign_user_to_group")(assignUserToGroup(svc)),
-		decodeUserGroupRequest,
+		decodeAssignRequest,
 		encodeResponse,
 		opts...,
 	))
@@ -172,6 +267,34 @@ func MakeHandler(svc users.Service, tracer opentracing.Tracer) http.Handler {
 		opts...,
 	))
 
+	mux.Post("/groups/:groupID/invitations", kithttp.NewServer(
+		kitot.TraceServer(tracer, "invite")(inviteEndpoint(svc)),
+		decodeInvite,
+		encodeResponse,
+		opts...,
+	))
+
+	mux.Get("/groups/:groupID/invitations", kithttp.NewServer(
+		kitot.TraceServer(tracer, "list_invitations")(listInvitationsEndpoint(svc)),
+		decodeListInvitations,
+		encodeResponse,
+		opts...,
+	))
+
+	mux.Post("/invitations/accept", kithttp.NewServer(
+		kitot.TraceServer(tracer, "accept_invitation")(acceptInvitationEndpoint(svc)),
+		decodeAcceptInvitation,
+		encodeResponse,
+		opts...,
+	))
+
+	mux.Delete("/invitations/:invitationID", kithttp.NewServer(
+		kitot.TraceServer(tracer, "revoke_invitation")(revokeInvitationEndpoint(svc)),
+		decodeInvitation,
+		encodeResponse,
+		opts...,
+	))
+
 	mux.Get("/groups/:groupID", kithttp.NewServer(
 		kitot.TraceServer(tracer, "group")(viewGroupEndpoint(svc)),
 		decodeGroupRequest,
@@ -181,7 +304,35 @@ func MakeHandler(svc users.Service, tracer opentracing.Tracer) http.Handler {
 
 	mux.Post("/tokens", kithttp.NewServer(
 		kitot.TraceServer(tracer, "login")(loginEndpoint(svc)),
-		decodeCredentials,
+		decodeLogin,
+		encodeResponse,
+		opts...,
+	))
+
+	mux.Post("/tokens/refresh", kithttp.NewServer(
+		kitot.TraceServer(tracer, "refresh")(refreshEndpoint(svc)),
+		decodeRefresh,
+		encodeResponse,
+		opts...,
+	))
+
+	mux.Post("/tokens/totp", kithttp.NewServer(
+		kitot.TraceServer(tracer, "login_totp")(loginTOTPEndpoint(svc)),
+		decodeLoginTOTP,
+		encodeResponse,
+		opts...,
+	))
+
+	mux.Get(oidcPath+"/login", kithttp.NewServer(
+		kitot.TraceServer(tracer, "oidc_redirect")(oidcRedirectEndpoint(svc)),
+		decodeOIDCRedirect,
+		encodeResponse,
+		opts...,
+	))
+
+	mux.Get(oidcPath+"/callback", kithttp.NewServer(
+		kitot.TraceServer(tracer, "oidc_login")(oidcCallbackEndpoint(svc)),
+		decodeOIDCCallback,
 		encodeResponse,
 		opts...,
 	))
@@ -207,6 +358,38 @@ func decodeViewProfile(_ context.Context, r *http.Request) (interface{}, error)
 	return req, nil
 }
 
+func decodeLogout(_ context.Context, r *http.Request) (interface{}, error) {
+	req := logoutReq{
+		token: r.Header.Get("Authorization"),
+	}
+	return req, nil
+}
+
+func decodeOIDCRedirect(_ context.Context, _ *http.Request) (interface{}, error) {
+	return nil, nil
+}
+
+func decodeOIDCCallback(_ context.Context, r *http.Request) (interface{}, error) {
+	code, err := readStringQuery(r, codeKey)
+	if err != nil {
+		return nil, errors.Wrap(users.ErrMalformedEntity, err)
+	}
+	state, err := readStringQuery(r, stateKey)
+	if err != nil {
+		return nil, errors.Wrap(users.ErrMalformedEntity, err)
+	}
+
+	req := oidcCallbackReq{
+		code:  code,
+		state: state,
+	}
+	if c, err := r.Cookie(oidcStateCookie); err == nil {
+		req.cookieState = c.Value
+	}
+
+	return req, nil
+}
+
 func decodeListUsers(_ context.Context, r *http.Request) (interface{}, error) {
 	o, err := readUintQuery(r, offsetKey, defOffset)
 	if err != nil {
@@ -228,10 +411,16 @@ func decodeListUsers(_ context.Context, r *http.Request) (interface{}, error) {
 		return nil, err
 	}
 
+	c, err := readStringQuery(r, cursorKey)
+	if err != nil {
+		return nil, err
+	}
+
 	req := listUsersReq{
 		token:    r.Header.Get("Authorization"),
 		offset:   o,
 		limit:    l,
+		cursor:   c,
 		email:    e,
 		metadata: m,
 	}
@@ -261,6 +450,87 @@ func decodeCredentials(_ context.Context, r *http.Request) (interface{}, error)
 	return userReq{user}, nil
 }
 
+// decodeLogin decodes the login credentials along with the client address.
+// The address set by the reverse proxy takes precedence over the address
+// of the connection, which is the address of the proxy itself.
+func decodeLogin(_ context.Context, r *http.Request) (interface{}, error) {
+	if !strings.Contains(r.Header.Get("Content-Type"), contentType) {
+		return nil, ErrUnsupportedContentType
+	}
+
+	var user users.User
+	if err := json.NewDecoder(r.Body).Decode(&user); err != nil {
+		return nil, errors.Wrap(users.ErrMalformedEntity, err)
+	}
+
+	return loginReq{user: user, ip: audit.ClientIP(r)}, nil
+}
+
+func decodeRefresh(_ context.Context, r *http.Request) (interface{}, error) {
+	if !strings.Contains(r.Header.Get("Content-Type"), contentType) {
+		return nil, ErrUnsupportedContentType
+	}
+
+	var req refreshReq
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		return nil, errors.Wrap(users.ErrMalformedEntity, err)
+	}
+
+	return req, nil
+}
+
+func decodeVerifyEmail(_ context.Context, r *http.Request) (interface{}, error) {
+	if !strings.Contains(r.Header.Get("Content-Type"), contentType) {
+		return nil, ErrUnsupportedContentType
+	}
+
+	var req verifyEmailReq
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		return nil, errors.Wrap(users.ErrMalformedEntity, err)
+	}
+
+	return req, nil
+}
+
+func decodeResendVerification(_ context.Context, r *http.Request) (interface{}, error) {
+	if !strings.Contains(r.Header.Get("Content-Type"), contentType) {
+		return nil, ErrUnsupportedContentType
+	}
+
+	var req resendVerificationReq
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		return nil, errors.Wrap(users.ErrMalformedEntity, err)
+	}
+
+	return req, nil
+}
+
+func decodeLoginTOTP(_ context.Context, r *http.Request) (interface{}, error) {
+	if !strings.Contains(r.Header.Get("Content-Type"), contentType) {
+		return nil, ErrUnsupportedContentType
+	}
+
+	var req loginTOTPReq
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		return nil, errors.Wrap(users.ErrMalformedEntity, err)
+	}
+
+	return req, nil
+}
+
+func decodeTOTP(_ context.Context, r *http.Request) (interface{}, error) {
+	if !strings.Contains(r.Header.Get("Content-Type"), contentType) {
+		return nil, ErrUnsupportedContentType
+	}
+
+	req := totpReq{token: r.Header.Get("Authorization")}
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		return nil, errors.Wrap(users.ErrMalformedEntity, err)
+	}
+
+	return req, nil
+}
+
 func decodePasswordResetRequest(_ context.Context, r *http.Request) (interface{}, error) {
 	if !strings.Contains(r.Header.Get("Content-Type"), contentType) {
 		return nil, ErrUnsupportedContentType
@@ -391,6 +661,124 @@ func decodeUserGroupRequest(_ context.Context, r *http.Request) (interface{}, er
 	return req, nil
 }
 
+func decodeAssignRequest(_ context.Context, r *http.Request) (interface{}, error) {
+	role, err := readStringQuery(r, roleKey)
+	if err != nil {
+		return nil, err
+	}
+
+	req := assignReq{
+		userGroupReq: userGroupReq{
+			token:   r.Header.Get("Authorization"),
+			groupID: bone.GetValue(r, "groupID"),
+			userID:  bone.GetValue(r, "userID"),
+		},
+		role: role,
+	}
+	return req, nil
+}
+
+func decodeInvite(_ context.Context, r *http.Request) (interface{}, error) {
+	if !strings.Contains(r.Header.Get("Content-Type"), contentType) {
+		return nil, ErrUnsupportedContentType
+	}
+
+	req := inviteReq{
+		token:   r.Header.Get("Authorization"),
+		groupID: bone.GetValue(r, "groupID"),
+	}
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		return nil, errors.Wrap(users.ErrMalformedEntity, err)
+	}
+
+	return req, nil
+}
+
+func decodeListInvitations(_ context.Context, r *http.Request) (interface{}, error) {
+	o, err := readUintQuery(r, offsetKey, defOffset)
+	if err != nil {
+		return nil, err
+	}
+
+	l, err := readUintQuery(r, limitKey, defLimit)
+	if err != nil {
+		return nil, err
+	}
+
+	req := listInvitationsReq{
+		token:   r.Header.Get("Authorization"),
+		groupID: bone.GetValue(r, "groupID"),
+		offset:  o,
+		limit:   l,
+	}
+
+	return req, nil
+}
+
+func decodeImpersonate(_ context.Context, r *http.Request) (interface{}, error) {
+	if !strings.Contains(r.Header.Get("Content-Type"), contentType) {
+		return nil, ErrUnsupportedContentType
+	}
+
+	req := impersonateReq{
+		token:  r.Header.Get("Authorization"),
+		userID: bone.GetValue(r, "userID"),
+	}
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		return nil, errors.Wrap(users.ErrMalformedEntity, err)
+	}
+
+	return req, nil
+}
+
+func decodeListImpersonations(_ context.Context, r *http.Request) (interface{}, error) {
+	o, err := readUintQuery(r, offsetKey, defOffset)
+	if err != nil {
+		return nil, err
+	}
+
+	l, err := readUintQuery(r, limitKey, defLimit)
+	if err != nil {
+		return nil, err
+	}
+
+	u, err := readStringQuery(r, userIDKey)
+	if err != nil {
+		return nil, err
+	}
+
+	req := listImpersonationsReq{
+		token:  r.Header.Get("Authorization"),
+		userID: u,
+		offset: o,
+		limit:  l,
+	}
+
+	return req, nil
+}
+
+func decodeInvitation(_ context.Context, r *http.Request) (interface{}, error) {
+	req := invitationReq{
+		token: r.Header.Get("Authorization"),
+		id:    bone.GetValue(r, "invitationID"),
+	}
+
+	return req, nil
+}
+
+func decodeAcceptInvitation(_ context.Context, r *http.Request) (interface{}, error) {
+	if !strings.Contains(r.Header.Get("Content-Type"), contentType) {
+		return nil, ErrUnsupportedContentType
+	}
+
+	var req acceptInvitationReq
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		return nil, errors.Wrap(users.ErrMalformedEntity, err)
+	}
+
+	return req, nil
+}
+
 func encodeResponse(_ context.Context, w http.ResponseWriter, response interface{}) error {
 	if ar, ok := response.(mainflux.Response); ok {
 		for k, v := range ar.Headers() {
@@ -420,6 +808,8 @@ func encodeError(_ context.Context, err error, w http.ResponseWriter) {
 			w.WriteHeader(http.StatusConflict)
 		case errors.Contains(errorVal, users.ErrGroupConflict):
 			w.WriteHeader(http.StatusConflict)
+		case errors.Contains(errorVal, users.ErrInvitationConflict):
+			w.WriteHeader(http.StatusConflict)
 		case errors.Contains(errorVal, ErrUnsupportedContentType):
 			w.WriteHeader(http.StatusUnsupportedMediaType)
 		case errors.Contains(errorVal, ErrFailedDecode):
@@ -432,6 +822,24 @@ func encodeError(_ context.Context, err error, w http.ResponseWriter) {
 			w.WriteHeader(http.StatusBadRequest)
 		case errors.Contains(errorVal, users.ErrRecoveryToken):
 			w.WriteHeader(http.StatusNotFound)
+		case errors.Contains(errorVal, users.ErrOIDCNotConfigured):
+			w.WriteHeader(http.StatusNotFound)
+		case errors.Contains(errorVal, users.ErrTOTPEnabled):
+			w.WriteHeader(http.StatusConflict)
+		case errors.Contains(errorVal, users.ErrTOTPNotEnrolled):
+			w.WriteHeader(http.StatusNotFound)
+		case errors.Contains(errorVal, users.ErrNotFound):
+			w.WriteHeader(http.StatusNotFound)
+		case errors.Contains(errorVal, users.ErrTooManyAttempts):
+			w.WriteHeader(http.StatusTooManyRequests)
+		case errors.Contains(errorVal, users.ErrUnverified):
+			w.WriteHeader(http.StatusForbidden)
+		case errors.Contains(errorVal, users.ErrDisabled):
+			w.WriteHeader(http.StatusForbidden)
+		case errors.Contains(errorVal, users.ErrVerified):
+			w.WriteHeader(http.StatusConflict)
+		case errors.Contains(errorVal, users.ErrVerificationSent):
+			w.WriteHeader(http.StatusTooManyRequests)
 		default:
 			w.WriteHeader(http.StatusInternalServerError)
 		}
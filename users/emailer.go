@@ -6,4 +6,12 @@ package users
 // Emailer wrapper around the email
 type Emailer interface {
 	SendPasswordReset(To []string, host, token string) error
+
+	// SendVerification sends the link containing the email verification
+	// token.
+	SendVerification(To []string, token string) error
+
+	// SendInvitation sends the link containing the token of the invitation
+	// into the group.
+	SendInvitation(To []string, group, token string) error
 }
@@ -12,17 +12,31 @@ import (
 var _ users.Emailer = (*emailer)(nil)
 
 type emailer struct {
-	resetURL string
-	agent    *email.Agent
+	resetURL  string
+	verifyURL string
+	inviteURL string
+	agent     *email.Agent
 }
 
-// New creates new emailer utility
-func New(url string, c *email.Config) (users.Emailer, error) {
+// New creates new emailer utility. Verify and invite URLs are the absolute
+// URLs of the pages the users land on after clicking the email
+// verification and the group invitation links.
+func New(url, verifyURL, inviteURL string, c *email.Config) (users.Emailer, error) {
 	e, err := email.New(c)
-	return &emailer{resetURL: url, agent: e}, err
+	return &emailer{resetURL: url, verifyURL: verifyURL, inviteURL: inviteURL, agent: e}, err
 }
 
 func (e *emailer) SendPasswordReset(To []string, host string, token string) error {
 	url := fmt.Sprintf("%s%s?token=%s", host, e.resetURL, token)
 	return e.agent.Send(To, "", "Password reset", "", url, "")
 }
+
+func (e *emailer) SendVerification(To []string, token string) error {
+	url := fmt.Sprintf("%s?token=%s", e.verifyURL, token)
+	return e.agent.Send(To, "", "Email verification", "", url, "")
+}
+
+func (e *emailer) SendInvitation(To []string, group, token string) error {
+	url := fmt.Sprintf("%s?token=%s", e.inviteURL, token)
+	return e.agent.Send(To, "", fmt.Sprintf("Invitation to %s group", group), "", url, "")
+}
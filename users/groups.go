@@ -7,6 +7,13 @@ import (
 	"context"
 )
 
+// Roles of the group members.
+const (
+	ViewerRole = "viewer"
+	EditorRole = "editor"
+	AdminRole  = "admin"
+)
+
 // Group of users
 type Group struct {
 	ID          string
@@ -40,9 +47,31 @@ type GroupRepository interface {
 	// RetrieveMemberships retrieves all groups that user belongs to
 	RetrieveMemberships(ctx context.Context, userID string, offset, limit uint64, m Metadata) (GroupPage, error)
 
-	// Assign adds user to group.
-	Assign(ctx context.Context, userID, groupID string) error
+	// Assign adds user to group with the given role.
+	Assign(ctx context.Context, userID, groupID, role string) error
 
 	// Unassign removes user from group
 	Unassign(ctx context.Context, userID, groupID string) error
+
+	// RetrieveRoles retrieves the roles of the user by the IDs of the
+	// groups the user is a member of. The owner of the group is its admin.
+	RetrieveRoles(ctx context.Context, userID string) (map[string]string, error)
+}
+
+// roleRanks orders the roles, so that each role is granted everything the
+// lower ranked roles are.
+var roleRanks = map[string]int{
+	ViewerRole: 1,
+	EditorRole: 2,
+	AdminRole:  3,
+}
+
+func validRole(role string) bool {
+	_, ok := roleRanks[role]
+	return ok
+}
+
+// hasRole checks whether the role grants the required one.
+func hasRole(role, required string) bool {
+	return roleRanks[role] >= roleRanks[required]
 }
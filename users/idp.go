@@ -0,0 +1,27 @@
+// Copyright (c) Mainflux
+// SPDX-License-Identifier: Apache-2.0
+
+package users
+
+import "context"
+
+// ExternalIdentity represents the user identity asserted by an external
+// identity provider.
+type ExternalIdentity struct {
+	Email    string
+	Metadata Metadata
+	Groups   []string
+}
+
+// IdentityProvider specifies an API for authenticating users using an
+// external OpenID Connect provider.
+type IdentityProvider interface {
+	// AuthURL returns the provider URL the user is redirected to in order
+	// to log in. State is returned to the callback unchanged and is used
+	// as the nonce of the issued ID token as well.
+	AuthURL(state string) string
+
+	// Exchange exchanges the authorization code for the ID token and
+	// returns the identity it asserts, once the token is verified.
+	Exchange(ctx context.Context, code, state string) (ExternalIdentity, error)
+}
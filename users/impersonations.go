@@ -0,0 +1,36 @@
+// Copyright (c) Mainflux
+// SPDX-License-Identifier: Apache-2.0
+
+package users
+
+import (
+	"context"
+	"time"
+)
+
+// Impersonation represents the audit record of the admin acting on behalf
+// of the user, e.g. to troubleshoot the user account.
+type Impersonation struct {
+	ID      string
+	AdminID string
+	UserID  string
+	Reason  string
+	Created time.Time
+}
+
+// ImpersonationPage contains a page of impersonations.
+type ImpersonationPage struct {
+	PageMetadata
+	Impersonations []Impersonation
+}
+
+// ImpersonationRepository specifies an impersonation audit persistence API.
+type ImpersonationRepository interface {
+	// Save persists the impersonation.
+	Save(ctx context.Context, imp Impersonation) (string, error)
+
+	// RetrieveAll retrieves the impersonations, the most recent first. If
+	// user ID is not empty, only the impersonations of the user are
+	// retrieved.
+	RetrieveAll(ctx context.Context, userID string, offset, limit uint64) (ImpersonationPage, error)
+}
@@ -0,0 +1,54 @@
+// Copyright (c) Mainflux
+// SPDX-License-Identifier: Apache-2.0
+
+package users
+
+import (
+	"context"
+	"time"
+)
+
+// Invitation represents the invitation of the email into the group. Once
+// accepted, the user with the invited email is assigned to the group with
+// the invitation role.
+type Invitation struct {
+	ID        string
+	GroupID   string
+	Email     string
+	Role      string
+	InvitedBy string
+	Created   time.Time
+	Expires   time.Time
+}
+
+// InvitationPage contains a page of invitations.
+type InvitationPage struct {
+	PageMetadata
+	Invitations []Invitation
+}
+
+// InvitationPolicy specifies the signing and the validity of the group
+// invitations.
+type InvitationPolicy struct {
+	// Secret is the key the invitation tokens are signed with.
+	Secret string
+
+	// Duration is how long the invitation is valid.
+	Duration time.Duration
+}
+
+// InvitationRepository specifies an invitation persistence API.
+type InvitationRepository interface {
+	// Save persists the invitation. Only one pending invitation of the
+	// email into the group is allowed.
+	Save(ctx context.Context, inv Invitation) (string, error)
+
+	// RetrieveByID retrieves the invitation by its unique identifier.
+	RetrieveByID(ctx context.Context, id string) (Invitation, error)
+
+	// RetrieveAll retrieves the pending invitations into the group.
+	RetrieveAll(ctx context.Context, groupID string, offset, limit uint64) (InvitationPage, error)
+
+	// Remove removes the invitation.
+	Remove(ctx context.Context, id string) error
+}
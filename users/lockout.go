@@ -0,0 +1,175 @@
+// Copyright (c) Mainflux
+// SPDX-License-Identifier: Apache-2.0
+
+package users
+
+import (
+	"context"
+	"sync"
+	"time"
+)
+
+const (
+	// maxDelayShift caps the exponent of the progressive delay, preventing
+	// the delay from overflowing.
+	maxDelayShift = 32
+
+	// evictInterval is the minimal interval between the removals of the
+	// expired in-memory attempts.
+	evictInterval = time.Minute
+)
+
+// LockoutPolicy specifies how the failed login attempts are throttled.
+// Each failed attempt delays the next attempt for the account and the
+// address it came from, doubling the delay with every consecutive failure.
+// Once the maximum number of attempts is reached, the account or the
+// address is locked out for the lockout duration. Failed attempts are
+// forgotten once the lockout duration passes without new failures.
+type LockoutPolicy struct {
+	// MaxAttempts is the number of failed attempts after which the account
+	// is locked out. Zero disables the tracking of accounts.
+	MaxAttempts uint64
+
+	// MaxIPAttempts is the number of failed attempts after which the
+	// address is locked out. Zero disables the tracking of addresses.
+	MaxIPAttempts uint64
+
+	// Delay is the delay following the first failed attempt.
+	Delay time.Duration
+
+	// Duration is the lockout duration.
+	Duration time.Duration
+}
+
+// delay returns how long the key with n failed attempts stays locked.
+func (lp LockoutPolicy) delay(n, max uint64) time.Duration {
+	if n >= max {
+		return lp.Duration
+	}
+	if lp.Delay <= 0 || n == 0 {
+		return 0
+	}
+
+	shift := n - 1
+	if shift > maxDelayShift {
+		shift = maxDelayShift
+	}
+	d := lp.Delay << shift
+	if d <= 0 || d > lp.Duration {
+		return lp.Duration
+	}
+
+	return d
+}
+
+// AttemptRepository specifies failed login attempts persistence API.
+type AttemptRepository interface {
+	// Fail records the failed attempt for the key and returns the number
+	// of failed attempts recorded for it. Failed attempts expire once the
+	// ttl passes without new failures.
+	Fail(ctx context.Context, key string, ttl time.Duration) (uint64, error)
+
+	// Lock locks the key for the given duration.
+	Lock(ctx context.Context, key string, d time.Duration) error
+
+	// Locked returns the remaining duration the key is locked for, or zero
+	// if the key is not locked.
+	Locked(ctx context.Context, key string) (time.Duration, error)
+
+	// Reset removes the failed attempts and the lock of the key.
+	Reset(ctx context.Context, key string) error
+}
+
+var _ AttemptRepository = (*attemptRepository)(nil)
+
+type attempts struct {
+	count   uint64
+	expires time.Time
+}
+
+type attemptRepository struct {
+	mu       sync.Mutex
+	attempts map[string]attempts
+	locks    map[string]time.Time
+	evicted  time.Time
+}
+
+// NewAttemptRepository returns the in-memory attempt repository. Since the
+// attempts are not shared, it's suitable only for a single service instance.
+func NewAttemptRepository() AttemptRepository {
+	return &attemptRepository{
+		attempts: make(map[string]attempts),
+		locks:    make(map[string]time.Time),
+	}
+}
+
+func (ar *attemptRepository) Fail(_ context.Context, key string, ttl time.Duration) (uint64, error) {
+	ar.mu.Lock()
+	defer ar.mu.Unlock()
+
+	now := time.Now()
+	a := ar.attempts[key]
+	if now.After(a.expires) {
+		a.count = 0
+	}
+	a.count++
+	a.expires = now.Add(ttl)
+	ar.attempts[key] = a
+	ar.evict(now)
+
+	return a.count, nil
+}
+
+func (ar *attemptRepository) Lock(_ context.Context, key string, d time.Duration) error {
+	ar.mu.Lock()
+	defer ar.mu.Unlock()
+
+	ar.locks[key] = time.Now().Add(d)
+	return nil
+}
+
+func (ar *attemptRepository) Locked(_ context.Context, key string) (time.Duration, error) {
+	ar.mu.Lock()
+	defer ar.mu.Unlock()
+
+	until, ok := ar.locks[key]
+	if !ok {
+		return 0, nil
+	}
+	d := time.Until(until)
+	if d <= 0 {
+		delete(ar.locks, key)
+		return 0, nil
+	}
+
+	return d, nil
+}
+
+func (ar *attemptRepository) Reset(_ context.Context, key string) error {
+	ar.mu.Lock()
+	defer ar.mu.Unlock()
+
+	delete(ar.attempts, key)
+	delete(ar.locks, key)
+	return nil
+}
+
+// evict removes the expired attempts and locks, so that the keys that
+// are never reset don't pile up.
+func (ar *attemptRepository) evict(now time.Time) {
+	if now.Sub(ar.evicted) < evictInterval {
+		return
+	}
+	ar.evicted = now
+
+	for k, a := range ar.attempts {
+		if now.After(a.expires) {
+			delete(ar.attempts, k)
+		}
+	}
+	for k, until := range ar.locks {
+		if now.After(until) {
+			delete(ar.locks, k)
+		}
+	}
+}
@@ -5,8 +5,12 @@ package mocks
 
 import (
 	"context"
+	"fmt"
+	"sync"
 
+	"github.com/golang/protobuf/ptypes/empty"
 	"github.com/mainflux/mainflux"
+	"github.com/mainflux/mainflux/authn"
 	"github.com/mainflux/mainflux/users"
 	"google.golang.org/grpc"
 )
@@ -14,27 +18,182 @@ import (
 var _ mainflux.AuthNServiceClient = (*authNServiceMock)(nil)
 
 type authNServiceMock struct {
-	users map[string]string
+	mu      sync.Mutex
+	users   map[string]string
+	revoked map[string]bool
+	// refresh maps refresh tokens to the users they are issued to.
+	refresh map[string]string
+	used    map[string]bool
+	// challenges maps unused challenge tokens to the users they are
+	// issued to.
+	challenges map[string]string
+	// impersonators maps the impersonation tokens to the admins they are
+	// issued to.
+	impersonators map[string]string
+	disabled      map[string]bool
+	admin         string
+	counter       int
 }
 
 // NewAuthService creates mock of users service.
 func NewAuthService(users map[string]string) mainflux.AuthNServiceClient {
-	return &authNServiceMock{users}
+	return NewAdminAuthService(users, "")
 }
 
-func (svc authNServiceMock) Identify(ctx context.Context, in *mainflux.Token, opts ...grpc.CallOption) (*mainflux.UserIdentity, error) {
-	if id, ok := svc.users[in.Value]; ok {
-		return &mainflux.UserIdentity{Id: id, Email: id}, nil
+// NewAdminAuthService creates mock of users service which identifies the
+// user with the given email as the admin.
+func NewAdminAuthService(users map[string]string, admin string) mainflux.AuthNServiceClient {
+	return &authNServiceMock{
+		users:   users,
+		revoked: make(map[string]bool),
+		refresh: make(map[string]string),
+		used:    make(map[string]bool),
+		admin:   admin,
+
+		challenges:    make(map[string]string),
+		impersonators: make(map[string]string),
+		disabled:      make(map[string]bool),
+	}
+}
+
+func (svc *authNServiceMock) Identify(ctx context.Context, in *mainflux.Token, opts ...grpc.CallOption) (*mainflux.UserIdentity, error) {
+	svc.mu.Lock()
+	defer svc.mu.Unlock()
+
+	if id, ok := svc.users[in.Value]; ok && !svc.revoked[in.Value] && !svc.disabled[id] {
+		imp := svc.impersonators[in.Value]
+		admin := svc.admin != "" && id == svc.admin && imp == ""
+		return &mainflux.UserIdentity{Id: id, Email: id, Admin: admin, Impersonator: imp}, nil
 	}
 	return nil, users.ErrUnauthorizedAccess
 }
 
-func (svc authNServiceMock) Issue(ctx context.Context, in *mainflux.IssueReq, opts ...grpc.CallOption) (*mainflux.Token, error) {
+func (svc *authNServiceMock) Issue(ctx context.Context, in *mainflux.IssueReq, opts ...grpc.CallOption) (*mainflux.Token, error) {
+	svc.mu.Lock()
+	defer svc.mu.Unlock()
+
 	if id, ok := svc.users[in.GetEmail()]; ok {
 		switch in.Type {
+		case authn.RefreshKey:
+			return &mainflux.Token{Value: svc.refreshToken(id)}, nil
+		case authn.ChallengeKey:
+			svc.counter++
+			token := fmt.Sprintf("challenge-%s-%d", id, svc.counter)
+			svc.challenges[token] = id
+			return &mainflux.Token{Value: token}, nil
 		default:
+			// Mock tokens are static, so the newly issued token is
+			// valid even if it has been revoked before.
+			delete(svc.revoked, id)
 			return &mainflux.Token{Value: id}, nil
 		}
 	}
 	return nil, users.ErrUnauthorizedAccess
 }
+
+func (svc *authNServiceMock) RevokeToken(ctx context.Context, in *mainflux.Token, opts ...grpc.CallOption) (*empty.Empty, error) {
+	svc.mu.Lock()
+	defer svc.mu.Unlock()
+
+	if _, ok := svc.refresh[in.Value]; ok {
+		delete(svc.refresh, in.Value)
+		return &empty.Empty{}, nil
+	}
+	if _, ok := svc.users[in.Value]; !ok || svc.revoked[in.Value] {
+		return nil, users.ErrUnauthorizedAccess
+	}
+	svc.revoked[in.Value] = true
+	return &empty.Empty{}, nil
+}
+
+func (svc *authNServiceMock) RevokeSessions(ctx context.Context, in *mainflux.Token, opts ...grpc.CallOption) (*empty.Empty, error) {
+	svc.mu.Lock()
+	defer svc.mu.Unlock()
+
+	id, ok := svc.users[in.Value]
+	if !ok || svc.revoked[in.Value] {
+		return nil, users.ErrUnauthorizedAccess
+	}
+	for token, uid := range svc.users {
+		if uid == id {
+			svc.revoked[token] = true
+		}
+	}
+	return &empty.Empty{}, nil
+}
+
+func (svc *authNServiceMock) Refresh(ctx context.Context, in *mainflux.Token, opts ...grpc.CallOption) (*mainflux.Tokens, error) {
+	svc.mu.Lock()
+	defer svc.mu.Unlock()
+
+	id, ok := svc.refresh[in.Value]
+	if !ok {
+		return nil, users.ErrUnauthorizedAccess
+	}
+	if svc.used[in.Value] {
+		// Reuse revokes all of the refresh tokens of the user.
+		for token, uid := range svc.refresh {
+			if uid == id {
+				delete(svc.refresh, token)
+			}
+		}
+		return nil, users.ErrUnauthorizedAccess
+	}
+	svc.used[in.Value] = true
+	delete(svc.revoked, id)
+
+	return &mainflux.Tokens{Access: id, Refresh: svc.refreshToken(id)}, nil
+}
+
+func (svc *authNServiceMock) VerifyChallenge(ctx context.Context, in *mainflux.Token, opts ...grpc.CallOption) (*mainflux.UserIdentity, error) {
+	svc.mu.Lock()
+	defer svc.mu.Unlock()
+
+	id, ok := svc.challenges[in.Value]
+	if !ok {
+		return nil, users.ErrUnauthorizedAccess
+	}
+	delete(svc.challenges, in.Value)
+
+	return &mainflux.UserIdentity{Id: id, Email: id}, nil
+}
+
+func (svc *authNServiceMock) Impersonate(ctx context.Context, in *mainflux.ImpersonateReq, opts ...grpc.CallOption) (*mainflux.Token, error) {
+	svc.mu.Lock()
+	defer svc.mu.Unlock()
+
+	admin, ok := svc.users[in.GetToken()]
+	if !ok || svc.revoked[in.GetToken()] || admin != svc.admin || svc.impersonators[in.GetToken()] != "" {
+		return nil, users.ErrUnauthorizedAccess
+	}
+	if in.GetEmail() == admin {
+		return nil, users.ErrMalformedEntity
+	}
+
+	svc.counter++
+	token := fmt.Sprintf("impersonation-%s-%d", in.GetEmail(), svc.counter)
+	svc.users[token] = in.GetEmail()
+	svc.impersonators[token] = admin
+
+	return &mainflux.Token{Value: token}, nil
+}
+
+func (svc *authNServiceMock) SetDisabled(ctx context.Context, in *mainflux.DisableReq, opts ...grpc.CallOption) (*empty.Empty, error) {
+	svc.mu.Lock()
+	defer svc.mu.Unlock()
+
+	admin, ok := svc.users[in.GetToken()]
+	if !ok || svc.revoked[in.GetToken()] || admin != svc.admin || svc.impersonators[in.GetToken()] != "" {
+		return nil, users.ErrUnauthorizedAccess
+	}
+	svc.disabled[in.GetId()] = in.GetDisabled()
+
+	return &empty.Empty{}, nil
+}
+
+func (svc *authNServiceMock) refreshToken(id string) string {
+	svc.counter++
+	token := fmt.Sprintf("refresh-%s-%d", id, svc.counter)
+	svc.refresh[token] = id
+	return token
+}
@@ -4,17 +4,68 @@
 package mocks
 
 import (
+	"sync"
+
 	"github.com/mainflux/mainflux/users"
 )
 
-type emailerMock struct {
+var _ users.Emailer = (*Emailer)(nil)
+
+// Emailer is the emailer mock, which keeps the verification and invitation
+// tokens sent to the users, so that they can be used in tests.
+type Emailer struct {
+	mu          sync.Mutex
+	tokens      map[string]string
+	invitations map[string]string
 }
 
 // NewEmailer provides emailer instance for  the test
-func NewEmailer() users.Emailer {
-	return &emailerMock{}
+func NewEmailer() *Emailer {
+	return &Emailer{
+		tokens:      make(map[string]string),
+		invitations: make(map[string]string),
+	}
+}
+
+// SendPasswordReset mocks sending the password reset link.
+func (e *Emailer) SendPasswordReset([]string, string, string) error {
+	return nil
+}
+
+// SendVerification keeps the verification token sent to the addresses.
+func (e *Emailer) SendVerification(to []string, token string) error {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+
+	for _, email := range to {
+		e.tokens[email] = token
+	}
+	return nil
+}
+
+// VerificationToken returns the last verification token sent to the email.
+func (e *Emailer) VerificationToken(email string) string {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+
+	return e.tokens[email]
 }
 
-func (e *emailerMock) SendPasswordReset([]string, string, string) error {
+// SendInvitation keeps the invitation token sent to the addresses.
+func (e *Emailer) SendInvitation(to []string, _, token string) error {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+
+	for _, email := range to {
+		e.invitations[email] = token
+	}
 	return nil
 }
+
+// InvitationToken returns the last invitation token sent to the email.
+func (e *Emailer) InvitationToken(email string) string {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+
+	return e.invitations[email]
+}
@@ -20,6 +20,8 @@ type groupRepositoryMock struct {
 	groupsByUser     map[string]map[string]users.Group
 	groupsByName     map[string]users.Group
 	childrenByGroups map[string]map[string]users.Group
+	// Map of "Maps of user roles by group" where user is a key
+	roles map[string]map[string]string
 }
 
 // NewGroupRepository creates in-memory user repository
@@ -30,6 +32,7 @@ func NewGroupRepository() users.GroupRepository {
 		users:            make(map[string]map[string]users.User),
 		groupsByUser:     make(map[string]map[string]users.Group),
 		childrenByGroups: make(map[string]map[string]users.Group),
+		roles:            make(map[string]map[string]string),
 	}
 }
 
@@ -78,6 +81,7 @@ func (grm *groupRepositoryMock) Unassign(ctx context.Context, userID, groupID st
 		return users.ErrNotFound
 	}
 	delete(grm.users[groupID], userID)
+	delete(grm.roles[userID], groupID)
 	return nil
 }
 
@@ -147,7 +151,7 @@ func (grm *groupRepositoryMock) RetrieveByName(ctx context.Context, name string)
 	return val, err
 }
 
-func (grm *groupRepositoryMock) Assign(ctx context.Context, userID, groupID string) error {
+func (grm *groupRepositoryMock) Assign(ctx context.Context, userID, groupID, role string) error {
 	grm.mu.Lock()
 	defer grm.mu.Unlock()
 	if _, ok := grm.groups[groupID]; !ok {
@@ -160,8 +164,13 @@ func (grm *groupRepositoryMock) Assign(ctx context.Context, userID, groupID stri
 		grm.groupsByUser[userID] = make(map[string]users.Group)
 	}
 
+	if _, ok := grm.roles[userID]; !ok {
+		grm.roles[userID] = make(map[string]string)
+	}
+
 	grm.users[groupID][userID] = users.User{ID: userID}
 	grm.groupsByUser[userID][groupID] = users.Group{ID: groupID}
+	grm.roles[userID][groupID] = role
 	return nil
 
 }
@@ -170,11 +179,7 @@ func (grm *groupRepositoryMock) RetrieveMemberships(ctx context.Context, userID
 	grm.mu.Lock()
 	defer grm.mu.Unlock()
 	var items []users.Group
-	groups, ok := grm.groupsByUser[userID]
-	if !ok {
-		return users.GroupPage{}, users.ErrNotFound
-	}
-	for _, g := range groups {
+	for _, g := range grm.groupsByUser[userID] {
 		items = append(items, g)
 	}
 	return users.GroupPage{
@@ -203,3 +208,22 @@ func (grm *groupRepositoryMock) RetrieveAllWithAncestors(ctx context.Context, gr
 		},
 	}, nil
 }
+
+func (grm *groupRepositoryMock) RetrieveRoles(ctx context.Context, userID string) (map[string]string, error) {
+	grm.mu.Lock()
+	defer grm.mu.Unlock()
+
+	roles := make(map[string]string)
+	for groupID, role := range grm.roles[userID] {
+		if _, ok := grm.groups[groupID]; ok {
+			roles[groupID] = role
+		}
+	}
+	for _, g := range grm.groups {
+		if g.OwnerID == userID {
+			roles[g.ID] = users.AdminRole
+		}
+	}
+
+	return roles, nil
+}
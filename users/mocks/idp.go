@@ -0,0 +1,35 @@
+// Copyright (c) Mainflux
+// SPDX-License-Identifier: Apache-2.0
+
+package mocks
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/mainflux/mainflux/users"
+)
+
+var _ users.IdentityProvider = (*identityProviderMock)(nil)
+
+type identityProviderMock struct {
+	identities map[string]users.ExternalIdentity
+}
+
+// NewIdentityProvider creates mock of OpenID Connect identity provider. The
+// identities are looked up by the authorization code.
+func NewIdentityProvider(identities map[string]users.ExternalIdentity) users.IdentityProvider {
+	return &identityProviderMock{identities: identities}
+}
+
+func (idp *identityProviderMock) AuthURL(state string) string {
+	return fmt.Sprintf("https://idp.example.com/authorize?state=%s", state)
+}
+
+func (idp *identityProviderMock) Exchange(_ context.Context, code, state string) (users.ExternalIdentity, error) {
+	ext, ok := idp.identities[code]
+	if !ok || state == "" {
+		return users.ExternalIdentity{}, users.ErrUnauthorizedAccess
+	}
+	return ext, nil
+}
@@ -0,0 +1,72 @@
+// Copyright (c) Mainflux
+// SPDX-License-Identifier: Apache-2.0
+
+package mocks
+
+import (
+	"context"
+	"sort"
+	"sync"
+
+	"github.com/mainflux/mainflux/users"
+)
+
+var _ users.ImpersonationRepository = (*impersonationRepositoryMock)(nil)
+
+type impersonationRepositoryMock struct {
+	mu             sync.Mutex
+	impersonations map[string]users.Impersonation
+}
+
+// NewImpersonationRepository creates in-memory impersonation repository.
+func NewImpersonationRepository() users.ImpersonationRepository {
+	return &impersonationRepositoryMock{
+		impersonations: make(map[string]users.Impersonation),
+	}
+}
+
+func (irm *impersonationRepositoryMock) Save(_ context.Context, imp users.Impersonation) (string, error) {
+	irm.mu.Lock()
+	defer irm.mu.Unlock()
+
+	if _, ok := irm.impersonations[imp.ID]; ok {
+		return "", users.ErrConflict
+	}
+	irm.impersonations[imp.ID] = imp
+
+	return imp.ID, nil
+}
+
+func (irm *impersonationRepositoryMock) RetrieveAll(_ context.Context, userID string, offset, limit uint64) (users.ImpersonationPage, error) {
+	irm.mu.Lock()
+	defer irm.mu.Unlock()
+
+	items := []users.Impersonation{}
+	for _, imp := range irm.impersonations {
+		if userID == "" || imp.UserID == userID {
+			items = append(items, imp)
+		}
+	}
+	sort.SliceStable(items, func(i, j int) bool {
+		return items[i].Created.After(items[j].Created)
+	})
+
+	page := users.ImpersonationPage{
+		Impersonations: []users.Impersonation{},
+		PageMetadata: users.PageMetadata{
+			Total:  uint64(len(items)),
+			Offset: offset,
+			Limit:  limit,
+		},
+	}
+	if offset >= uint64(len(items)) {
+		return page, nil
+	}
+	end := offset + limit
+	if end > uint64(len(items)) {
+		end = uint64(len(items))
+	}
+	page.Impersonations = items[offset:end]
+
+	return page, nil
+}
@@ -0,0 +1,100 @@
+// Copyright (c) Mainflux
+// SPDX-License-Identifier: Apache-2.0
+
+package mocks
+
+import (
+	"context"
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/mainflux/mainflux/users"
+)
+
+var _ users.InvitationRepository = (*invitationRepositoryMock)(nil)
+
+type invitationRepositoryMock struct {
+	mu          sync.Mutex
+	invitations map[string]users.Invitation
+}
+
+// NewInvitationRepository creates in-memory invitation repository.
+func NewInvitationRepository() users.InvitationRepository {
+	return &invitationRepositoryMock{
+		invitations: make(map[string]users.Invitation),
+	}
+}
+
+func (irm *invitationRepositoryMock) Save(_ context.Context, inv users.Invitation) (string, error) {
+	irm.mu.Lock()
+	defer irm.mu.Unlock()
+
+	for id, i := range irm.invitations {
+		if i.GroupID != inv.GroupID || i.Email != inv.Email {
+			continue
+		}
+		if i.Expires.After(inv.Created) {
+			return "", users.ErrInvitationConflict
+		}
+		delete(irm.invitations, id)
+	}
+	irm.invitations[inv.ID] = inv
+
+	return inv.ID, nil
+}
+
+func (irm *invitationRepositoryMock) RetrieveByID(_ context.Context, id string) (users.Invitation, error) {
+	irm.mu.Lock()
+	defer irm.mu.Unlock()
+
+	inv, ok := irm.invitations[id]
+	if !ok {
+		return users.Invitation{}, users.ErrNotFound
+	}
+
+	return inv, nil
+}
+
+func (irm *invitationRepositoryMock) RetrieveAll(_ context.Context, groupID string, offset, limit uint64) (users.InvitationPage, error) {
+	irm.mu.Lock()
+	defer irm.mu.Unlock()
+
+	now := time.Now()
+	items := []users.Invitation{}
+	for _, inv := range irm.invitations {
+		if inv.GroupID == groupID && inv.Expires.After(now) {
+			items = append(items, inv)
+		}
+	}
+	sort.SliceStable(items, func(i, j int) bool {
+		return items[i].Created.Before(items[j].Created)
+	})
+
+	page := users.InvitationPage{
+		Invitations: []users.Invitation{},
+		PageMetadata: users.PageMetadata{
+			Total:  uint64(len(items)),
+			Offset: offset,
+			Limit:  limit,
+		},
+	}
+	if offset >= uint64(len(items)) {
+		return page, nil
+	}
+	end := offset + limit
+	if end > uint64(len(items)) {
+		end = uint64(len(items))
+	}
+	page.Invitations = items[offset:end]
+
+	return page, nil
+}
+
+func (irm *invitationRepositoryMock) Remove(_ context.Context, id string) error {
+	irm.mu.Lock()
+	defer irm.mu.Unlock()
+
+	delete(irm.invitations, id)
+	return nil
+}
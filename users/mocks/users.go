@@ -5,8 +5,11 @@ package mocks
 
 import (
 	"context"
+	"sort"
 	"sync"
 
+	mfcursor "github.com/mainflux/mainflux/pkg/cursor"
+	"github.com/mainflux/mainflux/pkg/errors"
 	"github.com/mainflux/mainflux/users"
 )
 
@@ -89,14 +92,34 @@ func (urm *userRepositoryMock) RetrieveByID(ctx context.Context, id string) (use
 	return val, nil
 }
 
-func (urm *userRepositoryMock) RetrieveAll(ctx context.Context, offset, limit uint64, email string, um users.Metadata) (users.UserPage, error) {
+func (urm *userRepositoryMock) RetrieveAll(ctx context.Context, offset, limit uint64, cursor, email string, um users.Metadata) (users.UserPage, error) {
 	urm.mu.Lock()
 	defer urm.mu.Unlock()
 
-	up := users.UserPage{}
-	i := uint64(0)
+	after := ""
+	if cursor != "" {
+		keys, err := mfcursor.Decode(cursor, 1)
+		if err != nil {
+			return users.UserPage{}, errors.Wrap(users.ErrMalformedEntity, err)
+		}
+		after = keys[0]
+		offset = 0
+	}
 
+	all := []users.User{}
 	for _, u := range urm.users {
+		all = append(all, u)
+	}
+	sort.SliceStable(all, func(i, j int) bool {
+		return all[i].Email < all[j].Email
+	})
+
+	up := users.UserPage{}
+	i := uint64(0)
+	for _, u := range all {
+		if after != "" && u.Email <= after {
+			continue
+		}
 		if i >= offset && i < (limit+offset) {
 			up.Users = append(up.Users, u)
 		}
@@ -105,7 +128,11 @@ func (urm *userRepositoryMock) RetrieveAll(ctx context.Context, offset, limit ui
 
 	up.Offset = offset
 	up.Limit = limit
-	up.Total = uint64(i)
+	up.Total = uint64(len(all))
+
+	if l := len(up.Users); l > 0 && uint64(l) == limit {
+		up.NextCursor = mfcursor.Encode(up.Users[l-1].Email)
+	}
 
 	return up, nil
 }
@@ -126,8 +153,67 @@ func (urm *userRepositoryMock) UpdatePassword(_ context.Context, token, password
 	urm.mu.Lock()
 	defer urm.mu.Unlock()
 
-	if _, ok := urm.users[token]; !ok {
+	u, ok := urm.users[token]
+	if !ok {
 		return users.ErrUserNotFound
 	}
+	u.Password = password
+	urm.users[token] = u
+	urm.usersByID[u.ID] = u
 	return nil
 }
+
+func (urm *userRepositoryMock) UpdateTOTP(_ context.Context, email string, totp users.TOTP) error {
+	urm.mu.Lock()
+	defer urm.mu.Unlock()
+
+	u, ok := urm.users[email]
+	if !ok {
+		return users.ErrNotFound
+	}
+	u.TOTP = totp
+	urm.users[email] = u
+	urm.usersByID[u.ID] = u
+	return nil
+}
+
+func (urm *userRepositoryMock) UpdateStatus(_ context.Context, email, status string) error {
+	urm.mu.Lock()
+	defer urm.mu.Unlock()
+
+	u, ok := urm.users[email]
+	if !ok {
+		return users.ErrNotFound
+	}
+	u.Status = status
+	urm.users[email] = u
+	urm.usersByID[u.ID] = u
+	return nil
+}
+
+func (urm *userRepositoryMock) UpdateVerification(_ context.Context, email string, v users.Verification) error {
+	urm.mu.Lock()
+	defer urm.mu.Unlock()
+
+	u, ok := urm.users[email]
+	if !ok {
+		return users.ErrNotFound
+	}
+	u.Verification = v
+	urm.users[email] = u
+	urm.usersByID[u.ID] = u
+	return nil
+}
+
+func (urm *userRepositoryMock) RetrieveByVerificationToken(_ context.Context, token string) (users.User, error) {
+	urm.mu.Lock()
+	defer urm.mu.Unlock()
+
+	for _, u := range urm.users {
+		if token != "" && u.Verification.Token == token {
+			return u, nil
+		}
+	}
+
+	return users.User{}, users.ErrNotFound
+}
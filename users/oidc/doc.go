@@ -0,0 +1,6 @@
+// Copyright (c) Mainflux
+// SPDX-License-Identifier: Apache-2.0
+
+// Package oidc contains the OpenID Connect identity provider implementation
+// used to log users in using the authorization code flow.
+package oidc
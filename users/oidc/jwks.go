@@ -0,0 +1,91 @@
+// Copyright (c) Mainflux
+// SPDX-License-Identifier: Apache-2.0
+
+package oidc
+
+import (
+	"crypto"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rsa"
+	"encoding/base64"
+	"math/big"
+)
+
+const useSignature = "sig"
+
+type jwk struct {
+	KeyType string `json:"kty"`
+	ID      string `json:"kid"`
+	Use     string `json:"use"`
+	N       string `json:"n"`
+	E       string `json:"e"`
+	Curve   string `json:"crv"`
+	X       string `json:"x"`
+	Y       string `json:"y"`
+}
+
+type jwks struct {
+	Keys []jwk `json:"keys"`
+}
+
+// publicKeys returns the RSA and EC signing keys of the set. Keys of other
+// types or uses are skipped.
+func (set jwks) publicKeys() map[string]crypto.PublicKey {
+	keys := make(map[string]crypto.PublicKey)
+	for _, k := range set.Keys {
+		if k.Use != "" && k.Use != useSignature {
+			continue
+		}
+		if key := k.publicKey(); key != nil {
+			keys[k.ID] = key
+		}
+	}
+	return keys
+}
+
+func (k jwk) publicKey() crypto.PublicKey {
+	switch k.KeyType {
+	case "RSA":
+		n, ok := decodeInt(k.N)
+		if !ok {
+			return nil
+		}
+		e, ok := decodeInt(k.E)
+		if !ok || !e.IsInt64() {
+			return nil
+		}
+		return &rsa.PublicKey{N: n, E: int(e.Int64())}
+	case "EC":
+		var curve elliptic.Curve
+		switch k.Curve {
+		case "P-256":
+			curve = elliptic.P256()
+		case "P-384":
+			curve = elliptic.P384()
+		case "P-521":
+			curve = elliptic.P521()
+		default:
+			return nil
+		}
+		x, ok := decodeInt(k.X)
+		if !ok {
+			return nil
+		}
+		y, ok := decodeInt(k.Y)
+		if !ok {
+			return nil
+		}
+		return &ecdsa.PublicKey{Curve: curve, X: x, Y: y}
+	default:
+		return nil
+	}
+}
+
+func decodeInt(s string) (*big.Int, bool) {
+	b, err := base64.RawURLEncoding.DecodeString(s)
+	if err != nil || len(b) == 0 {
+		return nil, false
+	}
+	return new(big.Int).SetBytes(b), true
+}
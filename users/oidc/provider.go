@@ -0,0 +1,306 @@
+// Copyright (c) Mainflux
+// SPDX-License-Identifier: Apache-2.0
+
+package oidc
+
+import (
+	"context"
+	"crypto"
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"net/url"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/dgrijalva/jwt-go"
+	"github.com/mainflux/mainflux/pkg/errors"
+	"github.com/mainflux/mainflux/users"
+)
+
+const (
+	discoveryPath = "/.well-known/openid-configuration"
+	keyIDHeader   = "kid"
+	// keysRefreshInterval limits how often the provider keys are fetched
+	// when the ID token is signed using an unknown key.
+	keysRefreshInterval = time.Minute
+)
+
+var (
+	// ErrDiscovery indicates failure to retrieve the provider configuration.
+	ErrDiscovery = errors.New("failed to discover OpenID Connect provider")
+
+	// ErrExchange indicates failure to exchange the authorization code
+	// for the ID token.
+	ErrExchange = errors.New("failed to exchange authorization code")
+
+	// ErrInvalidToken indicates that the ID token is invalid or issued
+	// to another client.
+	ErrInvalidToken = errors.New("invalid ID token")
+
+	// ErrUnverifiedEmail indicates that the provider hasn't verified the
+	// email of the user.
+	ErrUnverifiedEmail = errors.New("email is not verified")
+
+	errFetchKeys = errors.New("failed to fetch provider keys")
+)
+
+var _ users.IdentityProvider = (*provider)(nil)
+
+// Config contains the OpenID Connect client configuration.
+type Config struct {
+	// Issuer is the issuer identifier of the provider, used to retrieve
+	// the discovery document.
+	Issuer       string
+	ClientID     string
+	ClientSecret string
+	RedirectURL  string
+	Scopes       []string
+
+	// GroupsClaim is the ID token claim listing the groups of the user.
+	GroupsClaim string
+
+	// MetadataClaims are the ID token claims copied to the user metadata.
+	MetadataClaims []string
+}
+
+type endpoints struct {
+	Issuer                string `json:"issuer"`
+	AuthorizationEndpoint string `json:"authorization_endpoint"`
+	TokenEndpoint         string `json:"token_endpoint"`
+	JWKSURI               string `json:"jwks_uri"`
+}
+
+type provider struct {
+	cfg       Config
+	client    *http.Client
+	endpoints endpoints
+
+	mu      sync.Mutex
+	keys    map[string]crypto.PublicKey
+	fetched time.Time
+}
+
+// New returns OpenID Connect identity provider. Provider endpoints are
+// retrieved from the discovery document of the configured issuer.
+func New(cfg Config, client *http.Client) (users.IdentityProvider, error) {
+	p := &provider{
+		cfg:    cfg,
+		client: client,
+		keys:   make(map[string]crypto.PublicKey),
+	}
+
+	url := strings.TrimSuffix(cfg.Issuer, "/") + discoveryPath
+	if err := p.get(context.Background(), url, &p.endpoints); err != nil {
+		return nil, errors.Wrap(ErrDiscovery, err)
+	}
+	if p.endpoints.Issuer != cfg.Issuer {
+		return nil, errors.Wrap(ErrDiscovery, fmt.Errorf("issuer %s doesn't match %s", p.endpoints.Issuer, cfg.Issuer))
+	}
+
+	return p, nil
+}
+
+func (p *provider) AuthURL(state string) string {
+	u, err := url.Parse(p.endpoints.AuthorizationEndpoint)
+	if err != nil {
+		return ""
+	}
+
+	q := u.Query()
+	q.Set("response_type", "code")
+	q.Set("client_id", p.cfg.ClientID)
+	q.Set("redirect_uri", p.cfg.RedirectURL)
+	q.Set("scope", strings.Join(p.cfg.Scopes, " "))
+	q.Set("state", state)
+	q.Set("nonce", state)
+	u.RawQuery = q.Encode()
+
+	return u.String()
+}
+
+func (p *provider) Exchange(ctx context.Context, code, state string) (users.ExternalIdentity, error) {
+	form := url.Values{
+		"grant_type":   {"authorization_code"},
+		"code":         {code},
+		"redirect_uri": {p.cfg.RedirectURL},
+	}
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, p.endpoints.TokenEndpoint, strings.NewReader(form.Encode()))
+	if err != nil {
+		return users.ExternalIdentity{}, errors.Wrap(ErrExchange, err)
+	}
+	req.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+	req.SetBasicAuth(url.QueryEscape(p.cfg.ClientID), url.QueryEscape(p.cfg.ClientSecret))
+
+	resp, err := p.client.Do(req)
+	if err != nil {
+		return users.ExternalIdentity{}, errors.Wrap(ErrExchange, err)
+	}
+	defer resp.Body.Close()
+
+	body, err := ioutil.ReadAll(resp.Body)
+	if err != nil {
+		return users.ExternalIdentity{}, errors.Wrap(ErrExchange, err)
+	}
+	if resp.StatusCode != http.StatusOK {
+		return users.ExternalIdentity{}, errors.Wrap(ErrExchange, errors.New(resp.Status))
+	}
+
+	var tr struct {
+		IDToken string `json:"id_token"`
+	}
+	if err := json.Unmarshal(body, &tr); err != nil {
+		return users.ExternalIdentity{}, errors.Wrap(ErrExchange, err)
+	}
+
+	claims, err := p.verify(ctx, tr.IDToken, state)
+	if err != nil {
+		return users.ExternalIdentity{}, err
+	}
+
+	return p.identity(claims)
+}
+
+// verify checks the ID token signature, as well as its issuer, audience,
+// expiration time and nonce.
+func (p *provider) verify(ctx context.Context, token, nonce string) (jwt.MapClaims, error) {
+	claims := jwt.MapClaims{}
+	_, err := jwt.ParseWithClaims(token, claims, func(t *jwt.Token) (interface{}, error) {
+		switch t.Method.(type) {
+		case *jwt.SigningMethodRSA, *jwt.SigningMethodECDSA:
+			kid, _ := t.Header[keyIDHeader].(string)
+			return p.key(ctx, kid)
+		default:
+			return nil, ErrInvalidToken
+		}
+	})
+	if err != nil {
+		return nil, errors.Wrap(ErrInvalidToken, err)
+	}
+
+	if iss, _ := claims["iss"].(string); iss != p.endpoints.Issuer {
+		return nil, ErrInvalidToken
+	}
+	if _, ok := claims["exp"]; !ok {
+		return nil, ErrInvalidToken
+	}
+	if !audience(claims["aud"], p.cfg.ClientID) {
+		return nil, ErrInvalidToken
+	}
+	if n, _ := claims["nonce"].(string); n != nonce {
+		return nil, ErrInvalidToken
+	}
+
+	return claims, nil
+}
+
+func (p *provider) identity(claims jwt.MapClaims) (users.ExternalIdentity, error) {
+	email, _ := claims["email"].(string)
+	if email == "" {
+		return users.ExternalIdentity{}, ErrInvalidToken
+	}
+	// Unverified email could belong to anyone, so the claim is required
+	// since the email is used to match the existing users.
+	if verified, _ := claims["email_verified"].(bool); !verified {
+		return users.ExternalIdentity{}, ErrUnverifiedEmail
+	}
+
+	metadata := users.Metadata{}
+	for _, c := range p.cfg.MetadataClaims {
+		if v, ok := claims[c]; ok {
+			metadata[c] = v
+		}
+	}
+
+	var groups []string
+	switch g := claims[p.cfg.GroupsClaim].(type) {
+	case string:
+		groups = append(groups, g)
+	case []interface{}:
+		for _, v := range g {
+			if s, ok := v.(string); ok {
+				groups = append(groups, s)
+			}
+		}
+	}
+
+	return users.ExternalIdentity{
+		Email:    email,
+		Metadata: metadata,
+		Groups:   groups,
+	}, nil
+}
+
+// key returns the provider key identified by the provided ID. Provider
+// keys are fetched again if the key is not known, to support rotation of
+// the provider keys.
+func (p *provider) key(ctx context.Context, id string) (crypto.PublicKey, error) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	if k, ok := p.lookup(id); ok {
+		return k, nil
+	}
+	if !p.fetched.IsZero() && time.Since(p.fetched) < keysRefreshInterval {
+		return nil, ErrInvalidToken
+	}
+
+	var set jwks
+	if err := p.get(ctx, p.endpoints.JWKSURI, &set); err != nil {
+		return nil, errors.Wrap(errFetchKeys, err)
+	}
+	p.keys = set.publicKeys()
+	p.fetched = time.Now()
+
+	if k, ok := p.lookup(id); ok {
+		return k, nil
+	}
+	return nil, ErrInvalidToken
+}
+
+// lookup finds the key by its ID. Tokens without key ID are accepted only
+// if the provider has a single key.
+func (p *provider) lookup(id string) (crypto.PublicKey, bool) {
+	if id == "" && len(p.keys) == 1 {
+		for _, k := range p.keys {
+			return k, true
+		}
+	}
+	k, ok := p.keys[id]
+	return k, ok
+}
+
+func (p *provider) get(ctx context.Context, url string, v interface{}) error {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, url, nil)
+	if err != nil {
+		return err
+	}
+
+	resp, err := p.client.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return errors.New(resp.Status)
+	}
+
+	return json.NewDecoder(resp.Body).Decode(v)
+}
+
+func audience(aud interface{}, clientID string) bool {
+	switch a := aud.(type) {
+	case string:
+		return a == clientID
+	case []interface{}:
+		for _, v := range a {
+			if s, ok := v.(string); ok && s == clientID {
+				return true
+			}
+		}
+	}
+	return false
+}
@@ -0,0 +1,282 @@
+// Copyright (c) Mainflux
+// SPDX-License-Identifier: Apache-2.0
+
+package oidc_test
+
+import (
+	"context"
+	"crypto/rand"
+	"crypto/rsa"
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	"math/big"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"testing"
+	"time"
+
+	"github.com/dgrijalva/jwt-go"
+	"github.com/mainflux/mainflux/pkg/errors"
+	"github.com/mainflux/mainflux/users"
+	"github.com/mainflux/mainflux/users/oidc"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const (
+	clientID     = "mainflux"
+	clientSecret = "secret"
+	redirectURL  = "http://localhost/oidc/callback"
+	keyID        = "key"
+	state        = "state"
+	email        = "user@example.com"
+)
+
+// issuer is a stub OpenID Connect provider which issues the ID token with
+// the claims registered for the authorization code.
+type issuer struct {
+	server *httptest.Server
+	key    *rsa.PrivateKey
+	claims map[string]jwt.MapClaims
+	keys   map[string]*rsa.PrivateKey
+}
+
+func newIssuer(t *testing.T) *issuer {
+	key, err := rsa.GenerateKey(rand.Reader, 2048)
+	require.Nil(t, err, fmt.Sprintf("generating key expected to succeed: %s", err))
+
+	iss := &issuer{
+		key:    key,
+		claims: make(map[string]jwt.MapClaims),
+		keys:   make(map[string]*rsa.PrivateKey),
+	}
+
+	mux := http.NewServeMux()
+	mux.HandleFunc("/.well-known/openid-configuration", func(w http.ResponseWriter, r *http.Request) {
+		json.NewEncoder(w).Encode(map[string]string{
+			"issuer":                 iss.server.URL,
+			"authorization_endpoint": iss.server.URL + "/authorize",
+			"token_endpoint":         iss.server.URL + "/token",
+			"jwks_uri":               iss.server.URL + "/keys",
+		})
+	})
+	mux.HandleFunc("/keys", func(w http.ResponseWriter, r *http.Request) {
+		json.NewEncoder(w).Encode(map[string]interface{}{
+			"keys": []map[string]string{
+				{
+					"kty": "RSA",
+					"kid": keyID,
+					"use": "sig",
+					"alg": "RS256",
+					"n":   base64.RawURLEncoding.EncodeToString(key.N.Bytes()),
+					"e":   base64.RawURLEncoding.EncodeToString(big.NewInt(int64(key.E)).Bytes()),
+				},
+			},
+		})
+	})
+	mux.HandleFunc("/token", func(w http.ResponseWriter, r *http.Request) {
+		id, secret, ok := r.BasicAuth()
+		if !ok || id != clientID || secret != clientSecret || r.FormValue("redirect_uri") != redirectURL {
+			w.WriteHeader(http.StatusUnauthorized)
+			return
+		}
+		code := r.FormValue("code")
+		claims, ok := iss.claims[code]
+		if !ok {
+			w.WriteHeader(http.StatusBadRequest)
+			return
+		}
+		signingKey := iss.key
+		if k, ok := iss.keys[code]; ok {
+			signingKey = k
+		}
+		token := jwt.NewWithClaims(jwt.SigningMethodRS256, claims)
+		token.Header["kid"] = keyID
+		idToken, err := token.SignedString(signingKey)
+		if err != nil {
+			w.WriteHeader(http.StatusInternalServerError)
+			return
+		}
+		json.NewEncoder(w).Encode(map[string]string{"id_token": idToken})
+	})
+	iss.server = httptest.NewServer(mux)
+
+	return iss
+}
+
+func (iss *issuer) validClaims() jwt.MapClaims {
+	return jwt.MapClaims{
+		"iss":            iss.server.URL,
+		"sub":            "subject",
+		"aud":            []string{clientID, "other"},
+		"exp":            time.Now().Add(time.Minute).Unix(),
+		"iat":            time.Now().Unix(),
+		"nonce":          state,
+		"email":          email,
+		"email_verified": true,
+		"name":           "Test User",
+		"groups":         []string{"admins", "devices"},
+	}
+}
+
+func (iss *issuer) config() oidc.Config {
+	return oidc.Config{
+		Issuer:         iss.server.URL,
+		ClientID:       clientID,
+		ClientSecret:   clientSecret,
+		RedirectURL:    redirectURL,
+		Scopes:         []string{"openid", "email"},
+		GroupsClaim:    "groups",
+		MetadataClaims: []string{"name", "missing"},
+	}
+}
+
+func TestNew(t *testing.T) {
+	iss := newIssuer(t)
+	defer iss.server.Close()
+
+	cfg := iss.config()
+	_, err := oidc.New(cfg, iss.server.Client())
+	assert.Nil(t, err, fmt.Sprintf("creating provider expected to succeed: %s", err))
+
+	cfg.Issuer = iss.server.URL + "/other"
+	_, err = oidc.New(cfg, iss.server.Client())
+	assert.True(t, errors.Contains(err, oidc.ErrDiscovery), fmt.Sprintf("creating provider with unknown issuer: expected %s got %s", oidc.ErrDiscovery, err))
+}
+
+func TestAuthURL(t *testing.T) {
+	iss := newIssuer(t)
+	defer iss.server.Close()
+
+	idp, err := oidc.New(iss.config(), iss.server.Client())
+	require.Nil(t, err, fmt.Sprintf("creating provider expected to succeed: %s", err))
+
+	u, err := url.Parse(idp.AuthURL(state))
+	require.Nil(t, err, fmt.Sprintf("parsing auth URL expected to succeed: %s", err))
+	assert.Equal(t, iss.server.URL+"/authorize", fmt.Sprintf("%s://%s%s", u.Scheme, u.Host, u.Path), "auth URL expected to point to the authorization endpoint")
+
+	expected := url.Values{
+		"response_type": {"code"},
+		"client_id":     {clientID},
+		"redirect_uri":  {redirectURL},
+		"scope":         {"openid email"},
+		"state":         {state},
+		"nonce":         {state},
+	}
+	assert.Equal(t, expected, u.Query(), fmt.Sprintf("auth URL query: expected %v got %v", expected, u.Query()))
+}
+
+func TestExchange(t *testing.T) {
+	iss := newIssuer(t)
+	defer iss.server.Close()
+
+	iss.claims["valid"] = iss.validClaims()
+
+	iss.claims["expired"] = iss.validClaims()
+	iss.claims["expired"]["exp"] = time.Now().Add(-time.Minute).Unix()
+
+	iss.claims["no-expiration"] = iss.validClaims()
+	delete(iss.claims["no-expiration"], "exp")
+
+	iss.claims["other-issuer"] = iss.validClaims()
+	iss.claims["other-issuer"]["iss"] = "https://other.example.com"
+
+	iss.claims["other-audience"] = iss.validClaims()
+	iss.claims["other-audience"]["aud"] = "other"
+
+	iss.claims["other-nonce"] = iss.validClaims()
+	iss.claims["other-nonce"]["nonce"] = "other"
+
+	iss.claims["no-email"] = iss.validClaims()
+	delete(iss.claims["no-email"], "email")
+
+	iss.claims["unverified-email"] = iss.validClaims()
+	iss.claims["unverified-email"]["email_verified"] = false
+
+	iss.claims["no-email-verified"] = iss.validClaims()
+	delete(iss.claims["no-email-verified"], "email_verified")
+
+	iss.claims["unknown-key"] = iss.validClaims()
+	otherKey, err := rsa.GenerateKey(rand.Reader, 2048)
+	require.Nil(t, err, fmt.Sprintf("generating key expected to succeed: %s", err))
+	iss.keys["unknown-key"] = otherKey
+
+	idp, err := oidc.New(iss.config(), iss.server.Client())
+	require.Nil(t, err, fmt.Sprintf("creating provider expected to succeed: %s", err))
+
+	cases := []struct {
+		desc     string
+		code     string
+		identity users.ExternalIdentity
+		err      error
+	}{
+		{
+			desc: "exchange valid code",
+			code: "valid",
+			identity: users.ExternalIdentity{
+				Email:    email,
+				Metadata: users.Metadata{"name": "Test User"},
+				Groups:   []string{"admins", "devices"},
+			},
+			err: nil,
+		},
+		{
+			desc: "exchange unknown code",
+			code: "unknown",
+			err:  oidc.ErrExchange,
+		},
+		{
+			desc: "exchange code for expired token",
+			code: "expired",
+			err:  oidc.ErrInvalidToken,
+		},
+		{
+			desc: "exchange code for token without expiration",
+			code: "no-expiration",
+			err:  oidc.ErrInvalidToken,
+		},
+		{
+			desc: "exchange code for token of other issuer",
+			code: "other-issuer",
+			err:  oidc.ErrInvalidToken,
+		},
+		{
+			desc: "exchange code for token issued to other client",
+			code: "other-audience",
+			err:  oidc.ErrInvalidToken,
+		},
+		{
+			desc: "exchange code for token with other nonce",
+			code: "other-nonce",
+			err:  oidc.ErrInvalidToken,
+		},
+		{
+			desc: "exchange code for token without email",
+			code: "no-email",
+			err:  oidc.ErrInvalidToken,
+		},
+		{
+			desc: "exchange code for token with unverified email",
+			code: "unverified-email",
+			err:  oidc.ErrUnverifiedEmail,
+		},
+		{
+			desc: "exchange code for token without email verification claim",
+			code: "no-email-verified",
+			err:  oidc.ErrUnverifiedEmail,
+		},
+		{
+			desc: "exchange code for token signed with unknown key",
+			code: "unknown-key",
+			err:  oidc.ErrInvalidToken,
+		},
+	}
+
+	for _, tc := range cases {
+		identity, err := idp.Exchange(context.Background(), tc.code, state)
+		assert.True(t, errors.Contains(err, tc.err), fmt.Sprintf("%s: expected %s got %s\n", tc.desc, tc.err, err))
+		assert.Equal(t, tc.identity, identity, fmt.Sprintf("%s: expected %v got %v\n", tc.desc, tc.identity, identity))
+	}
+}
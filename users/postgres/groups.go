@@ -277,13 +277,14 @@ func (gr groupRepository) RetrieveMemberships(ctx context.Context, userID string
 	return page, nil
 }
 
-func (gr groupRepository) Assign(ctx context.Context, userID, groupID string) error {
+func (gr groupRepository) Assign(ctx context.Context, userID, groupID, role string) error {
 	dbr, err := toDBGroupRelation(userID, groupID)
 	if err != nil {
 		return errors.Wrap(users.ErrAssignUserToGroup, err)
 	}
+	dbr.Role = role
 
-	qIns := `INSERT INTO group_relations (group_id, user_id) VALUES (:group_id, :user_id)`
+	qIns := `INSERT INTO group_relations (group_id, user_id, role) VALUES (:group_id, :user_id, :role)`
 	_, err = gr.db.NamedQueryContext(ctx, qIns, dbr)
 	if err != nil {
 		pqErr, ok := err.(*pq.Error)
@@ -315,6 +316,41 @@ func (gr groupRepository) Unassign(ctx context.Context, userID, groupID string)
 	return nil
 }
 
+func (gr groupRepository) RetrieveRoles(ctx context.Context, userID string) (map[string]string, error) {
+	// Owned groups are ordered last, so that the admin role of the owner
+	// takes precedence over the role the owner may be assigned with.
+	q := `SELECT group_id, role, FALSE AS owned FROM group_relations WHERE user_id = :user_id
+	      UNION ALL
+	      SELECT id AS group_id, 'admin' AS role, TRUE AS owned FROM groups WHERE owner_id = :user_id
+	      ORDER BY owned`
+
+	rows, err := gr.db.NamedQueryContext(ctx, q, map[string]interface{}{"user_id": userID})
+	if err != nil {
+		if pqErr, ok := err.(*pq.Error); ok && errInvalid == pqErr.Code.Name() {
+			return nil, errors.Wrap(users.ErrNotFound, err)
+		}
+		return nil, errors.Wrap(errSelectDb, err)
+	}
+	defer rows.Close()
+
+	roles := make(map[string]string)
+	for rows.Next() {
+		dbr := dbGroupRole{}
+		if err := rows.StructScan(&dbr); err != nil {
+			return nil, errors.Wrap(errSelectDb, err)
+		}
+		roles[dbr.Group] = dbr.Role
+	}
+
+	return roles, nil
+}
+
+type dbGroupRole struct {
+	Group string `db:"group_id"`
+	Role  string `db:"role"`
+	Owned bool   `db:"owned"`
+}
+
 type dbGroup struct {
 	ID          string        `db:"id"`
 	Name        string        `db:"name"`
@@ -406,6 +442,7 @@ func toGroup(dbu dbGroup) users.Group {
 type dbGroupRelation struct {
 	Group uuid.UUID `db:"group_id"`
 	User  uuid.UUID `db:"user_id"`
+	Role  string    `db:"role"`
 }
 
 func toDBGroupRelation(userID, groupID string) (dbGroupRelation, error) {
@@ -235,7 +235,7 @@ func TestGroupDelete(t *testing.T) {
 	g1, err := repo.Save(context.Background(), group1)
 	require.Nil(t, err, fmt.Sprintf("group save got unexpected error: %s", err))
 
-	err = repo.Assign(context.Background(), user.ID, g1.ID)
+	err = repo.Assign(context.Background(), user.ID, g1.ID, users.ViewerRole)
 	require.Nil(t, err, fmt.Sprintf("failed to assign user to a group: %s", err))
 
 	gid, err = uuid.New().ID()
@@ -341,7 +341,7 @@ func TestAssignUser(t *testing.T) {
 	}
 
 	for _, tc := range cases {
-		err := repo.Assign(context.Background(), user.ID, tc.group.ID)
+		err := repo.Assign(context.Background(), user.ID, tc.group.ID, users.ViewerRole)
 		assert.True(t, errors.Contains(err, tc.err), fmt.Sprintf("%s: expected %s got %s\n", tc.desc, tc.err, err))
 	}
 
@@ -391,7 +391,7 @@ func TestUnassignUser(t *testing.T) {
 	g1, err := repo.Save(context.Background(), group1)
 	require.Nil(t, err, fmt.Sprintf("group save got unexpected error: %s", err))
 
-	err = repo.Assign(context.Background(), user1.ID, group1.ID)
+	err = repo.Assign(context.Background(), user1.ID, group1.ID, users.ViewerRole)
 	require.Nil(t, err, fmt.Sprintf("failed to assign user: %s", err))
 
 	cases := []struct {
@@ -411,3 +411,56 @@ func TestUnassignUser(t *testing.T) {
 	}
 
 }
+
+func TestRetrieveRoles(t *testing.T) {
+	dbMiddleware := postgres.NewDatabase(db)
+	repo := postgres.NewGroupRepo(dbMiddleware)
+	userRepo := postgres.NewUserRepo(dbMiddleware)
+	group := saveInvitationGroup(t, dbMiddleware, "TestRetrieveRoles")
+
+	uid, err := uuid.New().ID()
+	require.Nil(t, err, fmt.Sprintf("user id unexpected error: %s", err))
+	_, err = userRepo.Save(context.Background(), users.User{ID: uid, Email: "TestRetrieveRoles@mainflux.com", Password: password})
+	require.Nil(t, err, fmt.Sprintf("save user got unexpected error: %s", err))
+
+	err = repo.Assign(context.Background(), uid, group.ID, users.EditorRole)
+	require.Nil(t, err, fmt.Sprintf("assign got unexpected error: %s", err))
+	err = repo.Assign(context.Background(), group.OwnerID, group.ID, users.ViewerRole)
+	require.Nil(t, err, fmt.Sprintf("assign got unexpected error: %s", err))
+
+	unknownID, err := uuid.New().ID()
+	require.Nil(t, err, fmt.Sprintf("user id unexpected error: %s", err))
+
+	cases := map[string]struct {
+		userID string
+		roles  map[string]string
+		err    error
+	}{
+		"retrieve roles of group owner": {
+			userID: group.OwnerID,
+			roles:  map[string]string{group.ID: users.AdminRole},
+			err:    nil,
+		},
+		"retrieve roles of group member": {
+			userID: uid,
+			roles:  map[string]string{group.ID: users.EditorRole},
+			err:    nil,
+		},
+		"retrieve roles of user without groups": {
+			userID: unknownID,
+			roles:  map[string]string{},
+			err:    nil,
+		},
+		"retrieve roles with malformed user ID": {
+			userID: "invalid",
+			roles:  nil,
+			err:    users.ErrNotFound,
+		},
+	}
+
+	for desc, tc := range cases {
+		roles, err := repo.RetrieveRoles(context.Background(), tc.userID)
+		assert.True(t, errors.Contains(err, tc.err), fmt.Sprintf("%s: expected %s got %s\n", desc, tc.err, err))
+		assert.Equal(t, tc.roles, roles, fmt.Sprintf("%s: expected %v got %v\n", desc, tc.roles, roles))
+	}
+}
@@ -0,0 +1,129 @@
+// Copyright (c) Mainflux
+// SPDX-License-Identifier: Apache-2.0
+
+package postgres
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/lib/pq"
+	"github.com/mainflux/mainflux/pkg/errors"
+	"github.com/mainflux/mainflux/users"
+)
+
+var (
+	errSaveImpersonation     = errors.New("failed to save impersonation to database")
+	errRetrieveImpersonation = errors.New("failed to retrieve impersonation from database")
+)
+
+var _ users.ImpersonationRepository = (*impersonationRepository)(nil)
+
+type impersonationRepository struct {
+	db Database
+}
+
+// NewImpersonationRepo instantiates a PostgreSQL implementation of
+// impersonation repository.
+func NewImpersonationRepo(db Database) users.ImpersonationRepository {
+	return &impersonationRepository{
+		db: db,
+	}
+}
+
+func (ir impersonationRepository) Save(ctx context.Context, imp users.Impersonation) (string, error) {
+	q := `INSERT INTO impersonations (id, admin_id, user_id, reason, created)
+	      VALUES (:id, :admin_id, :user_id, :reason, :created)`
+
+	if _, err := ir.db.NamedExecContext(ctx, q, toDBImpersonation(imp)); err != nil {
+		pqErr, ok := err.(*pq.Error)
+		if ok {
+			switch pqErr.Code.Name() {
+			case errInvalid, errTruncation:
+				return "", errors.Wrap(users.ErrMalformedEntity, err)
+			case errDuplicate:
+				return "", errors.Wrap(users.ErrConflict, err)
+			}
+		}
+		return "", errors.Wrap(errSaveImpersonation, err)
+	}
+
+	return imp.ID, nil
+}
+
+func (ir impersonationRepository) RetrieveAll(ctx context.Context, userID string, offset, limit uint64) (users.ImpersonationPage, error) {
+	where := ""
+	if userID != "" {
+		where = "WHERE user_id = :user_id"
+	}
+	q := fmt.Sprintf(`SELECT id, admin_id, user_id, reason, created FROM impersonations
+	      %s ORDER BY created DESC LIMIT :limit OFFSET :offset`, where)
+
+	params := map[string]interface{}{
+		"user_id": userID,
+		"limit":   limit,
+		"offset":  offset,
+	}
+
+	rows, err := ir.db.NamedQueryContext(ctx, q, params)
+	if err != nil {
+		if pqErr, ok := err.(*pq.Error); ok && errInvalid == pqErr.Code.Name() {
+			return users.ImpersonationPage{}, errors.Wrap(users.ErrMalformedEntity, err)
+		}
+		return users.ImpersonationPage{}, errors.Wrap(errRetrieveImpersonation, err)
+	}
+	defer rows.Close()
+
+	items := []users.Impersonation{}
+	for rows.Next() {
+		dbi := dbImpersonation{}
+		if err := rows.StructScan(&dbi); err != nil {
+			return users.ImpersonationPage{}, errors.Wrap(errRetrieveImpersonation, err)
+		}
+		items = append(items, toImpersonation(dbi))
+	}
+
+	tq := fmt.Sprintf(`SELECT COUNT(*) FROM impersonations %s`, where)
+	total, err := total(ctx, ir.db, tq, params)
+	if err != nil {
+		return users.ImpersonationPage{}, errors.Wrap(errRetrieveImpersonation, err)
+	}
+
+	return users.ImpersonationPage{
+		Impersonations: items,
+		PageMetadata: users.PageMetadata{
+			Total:  total,
+			Offset: offset,
+			Limit:  limit,
+		},
+	}, nil
+}
+
+type dbImpersonation struct {
+	ID      string    `db:"id"`
+	AdminID string    `db:"admin_id"`
+	UserID  string    `db:"user_id"`
+	Reason  string    `db:"reason"`
+	Created time.Time `db:"created"`
+}
+
+func toDBImpersonation(imp users.Impersonation) dbImpersonation {
+	return dbImpersonation{
+		ID:      imp.ID,
+		AdminID: imp.AdminID,
+		UserID:  imp.UserID,
+		Reason:  imp.Reason,
+		Created: imp.Created,
+	}
+}
+
+func toImpersonation(dbi dbImpersonation) users.Impersonation {
+	return users.Impersonation{
+		ID:      dbi.ID,
+		AdminID: dbi.AdminID,
+		UserID:  dbi.UserID,
+		Reason:  dbi.Reason,
+		Created: dbi.Created,
+	}
+}
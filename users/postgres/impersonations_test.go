@@ -0,0 +1,128 @@
+// Copyright (c) Mainflux
+// SPDX-License-Identifier: Apache-2.0
+
+package postgres_test
+
+import (
+	"context"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/mainflux/mainflux/pkg/errors"
+	"github.com/mainflux/mainflux/pkg/uuid"
+	"github.com/mainflux/mainflux/users"
+	"github.com/mainflux/mainflux/users/postgres"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func newImpersonation(t *testing.T, adminID, userID string) users.Impersonation {
+	id, err := uuid.New().ID()
+	require.Nil(t, err, fmt.Sprintf("impersonation id unexpected error: %s", err))
+
+	return users.Impersonation{
+		ID:      id,
+		AdminID: adminID,
+		UserID:  userID,
+		Reason:  "support",
+		Created: time.Now().UTC(),
+	}
+}
+
+func TestImpersonationSave(t *testing.T) {
+	repo := postgres.NewImpersonationRepo(postgres.NewDatabase(db))
+
+	adminID, err := uuid.New().ID()
+	require.Nil(t, err, fmt.Sprintf("admin id unexpected error: %s", err))
+	userID, err := uuid.New().ID()
+	require.Nil(t, err, fmt.Sprintf("user id unexpected error: %s", err))
+
+	imp := newImpersonation(t, adminID, userID)
+	malformed := newImpersonation(t, adminID, userID)
+	malformed.UserID = "invalid"
+
+	cases := []struct {
+		desc string
+		imp  users.Impersonation
+		err  error
+	}{
+		{
+			desc: "save new impersonation",
+			imp:  imp,
+			err:  nil,
+		},
+		{
+			desc: "save existing impersonation",
+			imp:  imp,
+			err:  users.ErrConflict,
+		},
+		{
+			desc: "save impersonation with malformed user ID",
+			imp:  malformed,
+			err:  users.ErrMalformedEntity,
+		},
+	}
+
+	for _, tc := range cases {
+		_, err := repo.Save(context.Background(), tc.imp)
+		assert.True(t, errors.Contains(err, tc.err), fmt.Sprintf("%s: expected %s got %s\n", tc.desc, tc.err, err))
+	}
+}
+
+func TestImpersonationRetrieveAll(t *testing.T) {
+	_, err := db.Exec("DELETE FROM impersonations")
+	require.Nil(t, err, fmt.Sprintf("cleanup must not fail: %s", err))
+	repo := postgres.NewImpersonationRepo(postgres.NewDatabase(db))
+
+	adminID, err := uuid.New().ID()
+	require.Nil(t, err, fmt.Sprintf("admin id unexpected error: %s", err))
+	userID, err := uuid.New().ID()
+	require.Nil(t, err, fmt.Sprintf("user id unexpected error: %s", err))
+	otherID, err := uuid.New().ID()
+	require.Nil(t, err, fmt.Sprintf("user id unexpected error: %s", err))
+
+	n := uint64(5)
+	for i := uint64(0); i < n; i++ {
+		_, err := repo.Save(context.Background(), newImpersonation(t, adminID, userID))
+		require.Nil(t, err, fmt.Sprintf("save got unexpected error: %s", err))
+	}
+	_, err = repo.Save(context.Background(), newImpersonation(t, adminID, otherID))
+	require.Nil(t, err, fmt.Sprintf("save got unexpected error: %s", err))
+
+	cases := map[string]struct {
+		userID string
+		offset uint64
+		limit  uint64
+		size   uint64
+		total  uint64
+		err    error
+	}{
+		"retrieve all impersonations": {
+			offset: 0,
+			limit:  2 * n,
+			size:   n + 1,
+			total:  n + 1,
+		},
+		"retrieve impersonations of the user": {
+			userID: userID,
+			offset: 2,
+			limit:  n,
+			size:   n - 2,
+			total:  n,
+		},
+		"retrieve impersonations with malformed user ID": {
+			userID: "invalid",
+			limit:  n,
+			err:    users.ErrMalformedEntity,
+		},
+	}
+
+	for desc, tc := range cases {
+		page, err := repo.RetrieveAll(context.Background(), tc.userID, tc.offset, tc.limit)
+		assert.True(t, errors.Contains(err, tc.err), fmt.Sprintf("%s: expected %s got %s\n", desc, tc.err, err))
+		size := uint64(len(page.Impersonations))
+		assert.Equal(t, tc.size, size, fmt.Sprintf("%s: expected size %d got %d\n", desc, tc.size, size))
+		assert.Equal(t, tc.total, page.Total, fmt.Sprintf("%s: expected total %d got %d\n", desc, tc.total, page.Total))
+	}
+}
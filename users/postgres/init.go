@@ -98,6 +98,55 @@ func migrateDB(db *sqlx.DB) error {
 					`ALTER TABLE IF EXISTS users ADD FOREIGN KEY (owner_id) REFERENCES groups(id)`,
 				},
 			},
+			{
+				Id: "users_5",
+				Up: []string{
+					`ALTER TABLE IF EXISTS users ADD COLUMN IF NOT EXISTS totp_secret VARCHAR(64) NOT NULL DEFAULT ''`,
+					`ALTER TABLE IF EXISTS users ADD COLUMN IF NOT EXISTS totp_enabled BOOLEAN NOT NULL DEFAULT FALSE`,
+					`ALTER TABLE IF EXISTS users ADD COLUMN IF NOT EXISTS recovery_codes TEXT[]`,
+				},
+			},
+			{
+				Id: "users_6",
+				Up: []string{
+					`ALTER TABLE IF EXISTS users ADD COLUMN IF NOT EXISTS status VARCHAR(16) NOT NULL DEFAULT 'active'`,
+					`ALTER TABLE IF EXISTS users ADD COLUMN IF NOT EXISTS verification_token VARCHAR(64) NOT NULL DEFAULT ''`,
+					`ALTER TABLE IF EXISTS users ADD COLUMN IF NOT EXISTS verification_sent TIMESTAMP`,
+					`CREATE INDEX IF NOT EXISTS users_verification_token_idx ON users (verification_token) WHERE verification_token <> ''`,
+				},
+			},
+			{
+				Id: "users_7",
+				Up: []string{
+					`ALTER TABLE IF EXISTS group_relations ADD COLUMN IF NOT EXISTS role VARCHAR(16) NOT NULL DEFAULT 'viewer'`,
+					`CREATE TABLE IF NOT EXISTS invitations (
+					 id         UUID NOT NULL,
+					 group_id   UUID NOT NULL,
+					 email      VARCHAR(254) NOT NULL,
+					 role       VARCHAR(16) NOT NULL,
+					 invited_by UUID NOT NULL,
+					 created    TIMESTAMP NOT NULL,
+					 expires    TIMESTAMP NOT NULL,
+					 FOREIGN KEY (group_id) REFERENCES groups (id) ON DELETE CASCADE ON UPDATE CASCADE,
+					 PRIMARY KEY (id),
+					 UNIQUE (group_id, email)
+				)`,
+				},
+			},
+			{
+				Id: "users_8",
+				Up: []string{
+					`CREATE TABLE IF NOT EXISTS impersonations (
+					 id       UUID NOT NULL,
+					 admin_id UUID NOT NULL,
+					 user_id  UUID NOT NULL,
+					 reason   TEXT NOT NULL,
+					 created  TIMESTAMP NOT NULL,
+					 PRIMARY KEY (id)
+				)`,
+					`CREATE INDEX IF NOT EXISTS impersonations_user_id_idx ON impersonations (user_id)`,
+				},
+			},
 		},
 	}
 
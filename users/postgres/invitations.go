@@ -0,0 +1,177 @@
+// Copyright (c) Mainflux
+// SPDX-License-Identifier: Apache-2.0
+
+package postgres
+
+import (
+	"context"
+	"database/sql"
+	"time"
+
+	"github.com/lib/pq"
+	"github.com/mainflux/mainflux/pkg/errors"
+	"github.com/mainflux/mainflux/users"
+)
+
+var (
+	errSaveInvitation     = errors.New("failed to save invitation to database")
+	errRetrieveInvitation = errors.New("failed to retrieve invitation from database")
+	errRemoveInvitation   = errors.New("failed to remove invitation from database")
+)
+
+var _ users.InvitationRepository = (*invitationRepository)(nil)
+
+type invitationRepository struct {
+	db Database
+}
+
+// NewInvitationRepo instantiates a PostgreSQL implementation of invitation
+// repository.
+func NewInvitationRepo(db Database) users.InvitationRepository {
+	return &invitationRepository{
+		db: db,
+	}
+}
+
+func (ir invitationRepository) Save(ctx context.Context, inv users.Invitation) (string, error) {
+	// Expired invitation of the email into the group is replaced, while
+	// the pending one is left intact.
+	q := `INSERT INTO invitations (id, group_id, email, role, invited_by, created, expires)
+	      VALUES (:id, :group_id, :email, :role, :invited_by, :created, :expires)
+	      ON CONFLICT (group_id, email) DO UPDATE SET id = EXCLUDED.id, role = EXCLUDED.role,
+	      invited_by = EXCLUDED.invited_by, created = EXCLUDED.created, expires = EXCLUDED.expires
+	      WHERE invitations.expires < EXCLUDED.created`
+
+	res, err := ir.db.NamedExecContext(ctx, q, toDBInvitation(inv))
+	if err != nil {
+		pqErr, ok := err.(*pq.Error)
+		if ok {
+			switch pqErr.Code.Name() {
+			case errInvalid, errTruncation:
+				return "", errors.Wrap(users.ErrMalformedEntity, err)
+			case errFK:
+				return "", errors.Wrap(users.ErrNotFound, err)
+			}
+		}
+		return "", errors.Wrap(errSaveInvitation, err)
+	}
+
+	cnt, err := res.RowsAffected()
+	if err != nil {
+		return "", errors.Wrap(errSaveInvitation, err)
+	}
+	if cnt != 1 {
+		return "", users.ErrInvitationConflict
+	}
+
+	return inv.ID, nil
+}
+
+func (ir invitationRepository) RetrieveByID(ctx context.Context, id string) (users.Invitation, error) {
+	q := `SELECT id, group_id, email, role, invited_by, created, expires FROM invitations WHERE id = $1`
+
+	dbi := dbInvitation{}
+	if err := ir.db.QueryRowxContext(ctx, q, id).StructScan(&dbi); err != nil {
+		if err == sql.ErrNoRows {
+			return users.Invitation{}, errors.Wrap(users.ErrNotFound, err)
+		}
+		if pqErr, ok := err.(*pq.Error); ok && errInvalid == pqErr.Code.Name() {
+			return users.Invitation{}, errors.Wrap(users.ErrNotFound, err)
+		}
+		return users.Invitation{}, errors.Wrap(errRetrieveInvitation, err)
+	}
+
+	return toInvitation(dbi), nil
+}
+
+func (ir invitationRepository) RetrieveAll(ctx context.Context, groupID string, offset, limit uint64) (users.InvitationPage, error) {
+	q := `SELECT id, group_id, email, role, invited_by, created, expires FROM invitations
+	      WHERE group_id = :group_id AND expires > :now ORDER BY created LIMIT :limit OFFSET :offset`
+
+	params := map[string]interface{}{
+		"group_id": groupID,
+		"now":      time.Now().UTC(),
+		"limit":    limit,
+		"offset":   offset,
+	}
+
+	rows, err := ir.db.NamedQueryContext(ctx, q, params)
+	if err != nil {
+		if pqErr, ok := err.(*pq.Error); ok && errInvalid == pqErr.Code.Name() {
+			return users.InvitationPage{}, errors.Wrap(users.ErrNotFound, err)
+		}
+		return users.InvitationPage{}, errors.Wrap(errRetrieveInvitation, err)
+	}
+	defer rows.Close()
+
+	items := []users.Invitation{}
+	for rows.Next() {
+		dbi := dbInvitation{}
+		if err := rows.StructScan(&dbi); err != nil {
+			return users.InvitationPage{}, errors.Wrap(errRetrieveInvitation, err)
+		}
+		items = append(items, toInvitation(dbi))
+	}
+
+	tq := `SELECT COUNT(*) FROM invitations WHERE group_id = :group_id AND expires > :now`
+	total, err := total(ctx, ir.db, tq, params)
+	if err != nil {
+		return users.InvitationPage{}, errors.Wrap(errRetrieveInvitation, err)
+	}
+
+	return users.InvitationPage{
+		Invitations: items,
+		PageMetadata: users.PageMetadata{
+			Total:  total,
+			Offset: offset,
+			Limit:  limit,
+		},
+	}, nil
+}
+
+func (ir invitationRepository) Remove(ctx context.Context, id string) error {
+	q := `DELETE FROM invitations WHERE id = :id`
+
+	if _, err := ir.db.NamedExecContext(ctx, q, dbInvitation{ID: id}); err != nil {
+		if pqErr, ok := err.(*pq.Error); ok && errInvalid == pqErr.Code.Name() {
+			return nil
+		}
+		return errors.Wrap(errRemoveInvitation, err)
+	}
+
+	return nil
+}
+
+type dbInvitation struct {
+	ID        string    `db:"id"`
+	GroupID   string    `db:"group_id"`
+	Email     string    `db:"email"`
+	Role      string    `db:"role"`
+	InvitedBy string    `db:"invited_by"`
+	Created   time.Time `db:"created"`
+	Expires   time.Time `db:"expires"`
+}
+
+func toDBInvitation(inv users.Invitation) dbInvitation {
+	return dbInvitation{
+		ID:        inv.ID,
+		GroupID:   inv.GroupID,
+		Email:     inv.Email,
+		Role:      inv.Role,
+		InvitedBy: inv.InvitedBy,
+		Created:   inv.Created,
+		Expires:   inv.Expires,
+	}
+}
+
+func toInvitation(dbi dbInvitation) users.Invitation {
+	return users.Invitation{
+		ID:        dbi.ID,
+		GroupID:   dbi.GroupID,
+		Email:     dbi.Email,
+		Role:      dbi.Role,
+		InvitedBy: dbi.InvitedBy,
+		Created:   dbi.Created,
+		Expires:   dbi.Expires,
+	}
+}
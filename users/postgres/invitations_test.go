@@ -0,0 +1,198 @@
+// Copyright (c) Mainflux
+// SPDX-License-Identifier: Apache-2.0
+
+package postgres_test
+
+import (
+	"context"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/mainflux/mainflux/pkg/errors"
+	"github.com/mainflux/mainflux/pkg/uuid"
+	"github.com/mainflux/mainflux/users"
+	"github.com/mainflux/mainflux/users/postgres"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// saveInvitationGroup saves the group invitations are made into.
+func saveInvitationGroup(t *testing.T, dbMiddleware postgres.Database, name string) users.Group {
+	userRepo := postgres.NewUserRepo(dbMiddleware)
+	groupRepo := postgres.NewGroupRepo(dbMiddleware)
+
+	uid, err := uuid.New().ID()
+	require.Nil(t, err, fmt.Sprintf("user id unexpected error: %s", err))
+	_, err = userRepo.Save(context.Background(), users.User{ID: uid, Email: fmt.Sprintf("%s@example.com", name), Password: password})
+	require.Nil(t, err, fmt.Sprintf("save user got unexpected error: %s", err))
+
+	gid, err := uuid.New().ID()
+	require.Nil(t, err, fmt.Sprintf("group id unexpected error: %s", err))
+	group, err := groupRepo.Save(context.Background(), users.Group{ID: gid, Name: name, OwnerID: uid})
+	require.Nil(t, err, fmt.Sprintf("save group got unexpected error: %s", err))
+
+	return group
+}
+
+func newInvitation(t *testing.T, group users.Group, email string, expires time.Time) users.Invitation {
+	id, err := uuid.New().ID()
+	require.Nil(t, err, fmt.Sprintf("invitation id unexpected error: %s", err))
+
+	return users.Invitation{
+		ID:        id,
+		GroupID:   group.ID,
+		Email:     email,
+		Role:      users.ViewerRole,
+		InvitedBy: group.OwnerID,
+		Created:   time.Now().UTC(),
+		Expires:   expires,
+	}
+}
+
+func TestInvitationSave(t *testing.T) {
+	dbMiddleware := postgres.NewDatabase(db)
+	repo := postgres.NewInvitationRepo(dbMiddleware)
+	group := saveInvitationGroup(t, dbMiddleware, "TestInvitationSave")
+
+	expired := newInvitation(t, group, "expired@example.com", time.Now().UTC().Add(-time.Minute))
+	_, err := repo.Save(context.Background(), expired)
+	require.Nil(t, err, fmt.Sprintf("save got unexpected error: %s", err))
+
+	wrongGroup := newInvitation(t, group, "invitee@example.com", time.Now().UTC().Add(time.Hour))
+	wrongGroup.GroupID = wrongGroup.ID
+
+	cases := []struct {
+		desc string
+		inv  users.Invitation
+		err  error
+	}{
+		{
+			desc: "save new invitation",
+			inv:  newInvitation(t, group, "invitee@example.com", time.Now().UTC().Add(time.Hour)),
+			err:  nil,
+		},
+		{
+			desc: "save invitation of already invited email",
+			inv:  newInvitation(t, group, "invitee@example.com", time.Now().UTC().Add(time.Hour)),
+			err:  users.ErrInvitationConflict,
+		},
+		{
+			desc: "save invitation replacing the expired one",
+			inv:  newInvitation(t, group, expired.Email, time.Now().UTC().Add(time.Hour)),
+			err:  nil,
+		},
+		{
+			desc: "save invitation into non-existing group",
+			inv:  wrongGroup,
+			err:  users.ErrNotFound,
+		},
+	}
+
+	for _, tc := range cases {
+		_, err := repo.Save(context.Background(), tc.inv)
+		assert.True(t, errors.Contains(err, tc.err), fmt.Sprintf("%s: expected %s got %s\n", tc.desc, tc.err, err))
+	}
+}
+
+func TestInvitationRetrieveByID(t *testing.T) {
+	dbMiddleware := postgres.NewDatabase(db)
+	repo := postgres.NewInvitationRepo(dbMiddleware)
+	group := saveInvitationGroup(t, dbMiddleware, "TestInvitationRetrieveByID")
+
+	inv := newInvitation(t, group, "invitee@example.com", time.Now().UTC().Add(time.Hour))
+	_, err := repo.Save(context.Background(), inv)
+	require.Nil(t, err, fmt.Sprintf("save got unexpected error: %s", err))
+
+	wrongID, err := uuid.New().ID()
+	require.Nil(t, err, fmt.Sprintf("invitation id unexpected error: %s", err))
+
+	cases := map[string]struct {
+		id  string
+		err error
+	}{
+		"retrieve existing invitation": {
+			id:  inv.ID,
+			err: nil,
+		},
+		"retrieve non-existing invitation": {
+			id:  wrongID,
+			err: users.ErrNotFound,
+		},
+		"retrieve invitation with malformed ID": {
+			id:  "invalid",
+			err: users.ErrNotFound,
+		},
+	}
+
+	for desc, tc := range cases {
+		saved, err := repo.RetrieveByID(context.Background(), tc.id)
+		assert.True(t, errors.Contains(err, tc.err), fmt.Sprintf("%s: expected %s got %s\n", desc, tc.err, err))
+		if tc.err == nil {
+			assert.Equal(t, inv.Email, saved.Email, fmt.Sprintf("%s: expected email %s got %s\n", desc, inv.Email, saved.Email))
+			assert.Equal(t, inv.Expires.Unix(), saved.Expires.Unix(), fmt.Sprintf("%s: expected expiration %s got %s\n", desc, inv.Expires, saved.Expires))
+		}
+	}
+}
+
+func TestInvitationRetrieveAll(t *testing.T) {
+	dbMiddleware := postgres.NewDatabase(db)
+	repo := postgres.NewInvitationRepo(dbMiddleware)
+	group := saveInvitationGroup(t, dbMiddleware, "TestInvitationRetrieveAll")
+
+	n := uint64(5)
+	for i := uint64(0); i < n; i++ {
+		inv := newInvitation(t, group, fmt.Sprintf("invitee%d@example.com", i), time.Now().UTC().Add(time.Hour))
+		_, err := repo.Save(context.Background(), inv)
+		require.Nil(t, err, fmt.Sprintf("save got unexpected error: %s", err))
+	}
+	expired := newInvitation(t, group, "expired@example.com", time.Now().UTC().Add(-time.Minute))
+	_, err := repo.Save(context.Background(), expired)
+	require.Nil(t, err, fmt.Sprintf("save got unexpected error: %s", err))
+
+	cases := map[string]struct {
+		offset uint64
+		limit  uint64
+		size   uint64
+		total  uint64
+	}{
+		"retrieve all pending invitations": {
+			offset: 0,
+			limit:  n,
+			size:   n,
+			total:  n,
+		},
+		"retrieve subset of pending invitations": {
+			offset: n - 2,
+			limit:  n,
+			size:   2,
+			total:  n,
+		},
+	}
+
+	for desc, tc := range cases {
+		page, err := repo.RetrieveAll(context.Background(), group.ID, tc.offset, tc.limit)
+		assert.Nil(t, err, fmt.Sprintf("%s: unexpected error: %s", desc, err))
+		size := uint64(len(page.Invitations))
+		assert.Equal(t, tc.size, size, fmt.Sprintf("%s: expected size %d got %d\n", desc, tc.size, size))
+		assert.Equal(t, tc.total, page.Total, fmt.Sprintf("%s: expected total %d got %d\n", desc, tc.total, page.Total))
+	}
+}
+
+func TestInvitationRemove(t *testing.T) {
+	dbMiddleware := postgres.NewDatabase(db)
+	repo := postgres.NewInvitationRepo(dbMiddleware)
+	group := saveInvitationGroup(t, dbMiddleware, "TestInvitationRemove")
+
+	inv := newInvitation(t, group, "invitee@example.com", time.Now().UTC().Add(time.Hour))
+	_, err := repo.Save(context.Background(), inv)
+	require.Nil(t, err, fmt.Sprintf("save got unexpected error: %s", err))
+
+	err = repo.Remove(context.Background(), inv.ID)
+	assert.Nil(t, err, fmt.Sprintf("remove invitation: unexpected error: %s", err))
+	err = repo.Remove(context.Background(), inv.ID)
+	assert.Nil(t, err, fmt.Sprintf("remove removed invitation: unexpected error: %s", err))
+
+	_, err = repo.RetrieveByID(context.Background(), inv.ID)
+	assert.True(t, errors.Contains(err, users.ErrNotFound), fmt.Sprintf("retrieve removed invitation: expected %s got %s\n", users.ErrNotFound, err))
+}
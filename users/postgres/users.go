@@ -9,8 +9,10 @@ import (
 	"database/sql/driver"
 	"encoding/json"
 	"fmt"
+	"time"
 
 	"github.com/lib/pq"
+	mfcursor "github.com/mainflux/mainflux/pkg/cursor"
 	"github.com/mainflux/mainflux/pkg/errors"
 	"github.com/mainflux/mainflux/users"
 )
@@ -21,6 +23,9 @@ var (
 	errUpdateUserDB     = errors.New("Update user metadata to DB failed")
 	errRetrieveDB       = errors.New("Retreiving from DB failed")
 	errUpdatePasswordDB = errors.New("Update password to DB failed")
+	errUpdateTOTPDB     = errors.New("Update TOTP settings to DB failed")
+	errUpdateStatusDB   = errors.New("Update user status to DB failed")
+	errUpdateVerifyDB   = errors.New("Update email verification to DB failed")
 	errMarshal          = errors.New("Failed to marshal metadata")
 	errUnmarshal        = errors.New("Failed to unmarshal metadata")
 )
@@ -42,7 +47,8 @@ func NewUserRepo(db Database) users.UserRepository {
 }
 
 func (ur userRepository) Save(ctx context.Context, user users.User) (string, error) {
-	q := `INSERT INTO users (email, password, id, metadata) VALUES (:email, :password, :id, :metadata) RETURNING id`
+	q := `INSERT INTO users (email, password, id, metadata, status, verification_token, verification_sent)
+	      VALUES (:email, :password, :id, :metadata, :status, :verification_token, :verification_sent) RETURNING id`
 	if user.ID == "" || user.Email == "" {
 		return "", users.ErrMalformedEntity
 	}
@@ -106,7 +112,8 @@ func (ur userRepository) UpdateUser(ctx context.Context, user users.User) error
 }
 
 func (ur userRepository) RetrieveByEmail(ctx context.Context, email string) (users.User, error) {
-	q := `SELECT id, password, metadata FROM users WHERE email = $1`
+	q := `SELECT id, password, metadata, totp_secret, totp_enabled, recovery_codes, status, verification_token, verification_sent
+	      FROM users WHERE email = $1`
 
 	dbu := dbUser{
 		Email: email,
@@ -124,7 +131,8 @@ func (ur userRepository) RetrieveByEmail(ctx context.Context, email string) (use
 }
 
 func (ur userRepository) RetrieveByID(ctx context.Context, id string) (users.User, error) {
-	q := `SELECT email, password, metadata FROM users WHERE id = $1`
+	q := `SELECT email, password, metadata, totp_secret, totp_enabled, recovery_codes, status, verification_token, verification_sent
+	      FROM users WHERE id = $1`
 
 	dbu := dbUser{
 		ID: id,
@@ -141,7 +149,7 @@ func (ur userRepository) RetrieveByID(ctx context.Context, id string) (users.Use
 	return toUser(dbu)
 }
 
-func (ur userRepository) RetrieveAll(ctx context.Context, offset, limit uint64, email string, um users.Metadata) (users.UserPage, error) {
+func (ur userRepository) RetrieveAll(ctx context.Context, offset, limit uint64, cursor, email string, um users.Metadata) (users.UserPage, error) {
 	eq, ep, err := createEmailQuery("", email)
 	if err != nil {
 		return users.UserPage{}, errors.Wrap(errRetrieveDB, err)
@@ -152,6 +160,14 @@ func (ur userRepository) RetrieveAll(ctx context.Context, offset, limit uint64,
 		return users.UserPage{}, errors.Wrap(errRetrieveDB, err)
 	}
 
+	cq, cp, err := createCursorQuery("", cursor)
+	if err != nil {
+		return users.UserPage{}, errors.Wrap(users.ErrMalformedEntity, err)
+	}
+	if cq != "" {
+		offset = 0
+	}
+
 	emq := ""
 	if eq != "" && mq == "" {
 		emq = fmt.Sprintf("WHERE %s", eq)
@@ -163,13 +179,24 @@ func (ur userRepository) RetrieveAll(ctx context.Context, offset, limit uint64,
 		emq = fmt.Sprintf("WHERE %s AND %s", eq, mq)
 	}
 
-	q := fmt.Sprintf(`SELECT id, email, metadata FROM users %s ORDER BY email LIMIT :limit OFFSET :offset;`, emq)
+	// The cursor condition narrows the page only, so it's excluded from
+	// the total count.
+	emcq := emq
+	switch {
+	case cq != "" && emq == "":
+		emcq = fmt.Sprintf("WHERE %s", cq)
+	case cq != "":
+		emcq = fmt.Sprintf("%s AND %s", emq, cq)
+	}
+
+	q := fmt.Sprintf(`SELECT id, email, metadata, status FROM users %s ORDER BY email LIMIT :limit OFFSET :offset;`, emcq)
 
 	params := map[string]interface{}{
 		"limit":    limit,
 		"offset":   offset,
 		"email":    ep,
 		"metadata": mp,
+		"cursor":   cp,
 	}
 
 	rows, err := ur.db.NamedQueryContext(ctx, q, params)
@@ -193,9 +220,9 @@ func (ur userRepository) RetrieveAll(ctx context.Context, offset, limit uint64,
 		items = append(items, user)
 	}
 
-	cq := fmt.Sprintf(`SELECT COUNT(*) FROM users %s;`, emq)
+	tq := fmt.Sprintf(`SELECT COUNT(*) FROM users %s;`, emq)
 
-	total, err := total(ctx, ur.db, cq, params)
+	total, err := total(ctx, ur.db, tq, params)
 	if err != nil {
 		return users.UserPage{}, errors.Wrap(errSelectDb, err)
 	}
@@ -209,6 +236,10 @@ func (ur userRepository) RetrieveAll(ctx context.Context, offset, limit uint64,
 		},
 	}
 
+	if l := len(items); l > 0 && uint64(l) == limit {
+		page.NextCursor = mfcursor.Encode(items[l-1].Email)
+	}
+
 	return page, nil
 }
 
@@ -227,6 +258,98 @@ func (ur userRepository) UpdatePassword(ctx context.Context, email, password str
 	return nil
 }
 
+func (ur userRepository) UpdateTOTP(ctx context.Context, email string, totp users.TOTP) error {
+	q := `UPDATE users SET totp_secret = :totp_secret, totp_enabled = :totp_enabled, recovery_codes = :recovery_codes
+	      WHERE email = :email`
+
+	db := dbUser{
+		Email:         email,
+		TOTPSecret:    totp.Secret,
+		TOTPEnabled:   totp.Enabled,
+		RecoveryCodes: totp.RecoveryCodes,
+	}
+
+	res, err := ur.db.NamedExecContext(ctx, q, db)
+	if err != nil {
+		return errors.Wrap(errUpdateTOTPDB, err)
+	}
+
+	cnt, err := res.RowsAffected()
+	if err != nil {
+		return errors.Wrap(errUpdateTOTPDB, err)
+	}
+	if cnt != 1 {
+		return users.ErrNotFound
+	}
+
+	return nil
+}
+
+func (ur userRepository) UpdateStatus(ctx context.Context, email, status string) error {
+	q := `UPDATE users SET status = :status WHERE email = :email`
+
+	db := dbUser{
+		Email:  email,
+		Status: status,
+	}
+
+	res, err := ur.db.NamedExecContext(ctx, q, db)
+	if err != nil {
+		return errors.Wrap(errUpdateStatusDB, err)
+	}
+
+	cnt, err := res.RowsAffected()
+	if err != nil {
+		return errors.Wrap(errUpdateStatusDB, err)
+	}
+	if cnt != 1 {
+		return users.ErrNotFound
+	}
+
+	return nil
+}
+
+func (ur userRepository) UpdateVerification(ctx context.Context, email string, v users.Verification) error {
+	q := `UPDATE users SET verification_token = :verification_token, verification_sent = :verification_sent
+	      WHERE email = :email`
+
+	db := dbUser{
+		Email:             email,
+		VerificationToken: v.Token,
+		VerificationSent:  toNullTime(v.Sent),
+	}
+
+	res, err := ur.db.NamedExecContext(ctx, q, db)
+	if err != nil {
+		return errors.Wrap(errUpdateVerifyDB, err)
+	}
+
+	cnt, err := res.RowsAffected()
+	if err != nil {
+		return errors.Wrap(errUpdateVerifyDB, err)
+	}
+	if cnt != 1 {
+		return users.ErrNotFound
+	}
+
+	return nil
+}
+
+func (ur userRepository) RetrieveByVerificationToken(ctx context.Context, token string) (users.User, error) {
+	q := `SELECT id, email, password, metadata, totp_secret, totp_enabled, recovery_codes, status, verification_token, verification_sent
+	      FROM users WHERE verification_token = $1 AND verification_token <> ''`
+
+	dbu := dbUser{}
+	if err := ur.db.QueryRowxContext(ctx, q, token).StructScan(&dbu); err != nil {
+		if err == sql.ErrNoRows {
+			return users.User{}, errors.Wrap(users.ErrNotFound, err)
+		}
+		return users.User{}, errors.Wrap(errRetrieveDB, err)
+	}
+
+	return toUser(dbu)
+}
+
 func (ur userRepository) RetrieveMembers(ctx context.Context, groupID string, offset, limit uint64, um users.Metadata) (users.UserPage, error) {
 	mq, mp, err := createMetadataQuery("users.", um)
 	if err != nil {
@@ -237,7 +360,7 @@ func (ur userRepository) RetrieveMembers(ctx context.Context, groupID string, of
 		mq = fmt.Sprintf(" AND %s", mq)
 	}
 
-	q := fmt.Sprintf(`SELECT u.id, u.email, u.metadata FROM users u, group_relations g
+	q := fmt.Sprintf(`SELECT u.id, u.email, u.metadata, u.status FROM users u, group_relations g
                       WHERE u.id = g.user_id AND g.group_id = :group
                       %s ORDER BY id LIMIT :limit OFFSET :offset;`, mq)
 
@@ -330,6 +453,14 @@ type dbUser struct {
 	Password string        `db:"password"`
 	Metadata []byte        `db:"metadata"`
 	Groups   []users.Group `db:"groups"`
+
+	TOTPSecret    string         `db:"totp_secret"`
+	TOTPEnabled   bool           `db:"totp_enabled"`
+	RecoveryCodes pq.StringArray `db:"recovery_codes"`
+
+	Status            string       `db:"status"`
+	VerificationToken string       `db:"verification_token"`
+	VerificationSent  sql.NullTime `db:"verification_sent"`
 }
 
 func toDBUser(u users.User) (dbUser, error) {
@@ -342,14 +473,29 @@ func toDBUser(u users.User) (dbUser, error) {
 		data = b
 	}
 
+	status := u.Status
+	if status == "" {
+		status = users.ActiveStatus
+	}
+
 	return dbUser{
-		ID:       u.ID,
-		Email:    u.Email,
-		Password: u.Password,
-		Metadata: data,
+		ID:                u.ID,
+		Email:             u.Email,
+		Password:          u.Password,
+		Metadata:          data,
+		Status:            status,
+		VerificationToken: u.Verification.Token,
+		VerificationSent:  toNullTime(u.Verification.Sent),
 	}, nil
 }
 
+func toNullTime(t time.Time) sql.NullTime {
+	if t.IsZero() {
+		return sql.NullTime{}
+	}
+	return sql.NullTime{Time: t, Valid: true}
+}
+
 func toUser(dbu dbUser) (users.User, error) {
 	var metadata map[string]interface{}
 	if dbu.Metadata != nil {
@@ -363,6 +509,16 @@ func toUser(dbu dbUser) (users.User, error) {
 		Email:    dbu.Email,
 		Password: dbu.Password,
 		Metadata: metadata,
+		TOTP: users.TOTP{
+			Secret:        dbu.TOTPSecret,
+			Enabled:       dbu.TOTPEnabled,
+			RecoveryCodes: dbu.RecoveryCodes,
+		},
+		Status: dbu.Status,
+		Verification: users.Verification{
+			Token: dbu.VerificationToken,
+			Sent:  dbu.VerificationSent.Time,
+		},
 	}, nil
 }
 
@@ -378,6 +534,21 @@ func createEmailQuery(entity string, email string) (string, string, error) {
 	return query, param, nil
 }
 
+func createCursorQuery(entity string, c string) (string, string, error) {
+	if c == "" {
+		return "", "", nil
+	}
+
+	keys, err := mfcursor.Decode(c, 1)
+	if err != nil {
+		return "", "", err
+	}
+
+	query := fmt.Sprintf("%semail > :cursor", entity)
+
+	return query, keys[0], nil
+}
+
 func createMetadataQuery(entity string, um users.Metadata) (string, []byte, error) {
 	if len(um) == 0 {
 		return "", nil, nil
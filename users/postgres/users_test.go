@@ -7,6 +7,7 @@ import (
 	"context"
 	"fmt"
 	"testing"
+	"time"
 
 	"github.com/mainflux/mainflux/pkg/errors"
 	"github.com/mainflux/mainflux/pkg/uuid"
@@ -119,7 +120,7 @@ func TestRetrieveMembers(t *testing.T) {
 	require.Nil(t, err, fmt.Sprintf("group save got unexpected error: %s", err))
 
 	for _, u := range usrs {
-		err := groupRepo.Assign(context.Background(), u.ID, g.ID)
+		err := groupRepo.Assign(context.Background(), u.ID, g.ID, users.ViewerRole)
 		require.Nil(t, err, fmt.Sprintf("group user assign got unexpected error: %s", err))
 	}
 
@@ -192,9 +193,70 @@ func TestRetrieveAll(t *testing.T) {
 	}
 
 	for desc, tc := range cases {
-		page, err := userRepo.RetrieveAll(context.Background(), tc.offset, tc.limit, tc.email, tc.metadata)
+		page, err := userRepo.RetrieveAll(context.Background(), tc.offset, tc.limit, "", tc.email, tc.metadata)
 		size := uint64(len(page.Users))
 		assert.Equal(t, tc.size, size, fmt.Sprintf("%s: expected size %d got %d\n", desc, tc.size, size))
 		assert.Nil(t, err, fmt.Sprintf("%s: expected no error got %d\n", desc, err))
 	}
 }
+
+func TestUserVerification(t *testing.T) {
+	dbMiddleware := postgres.NewDatabase(db)
+	repo := postgres.NewUserRepo(dbMiddleware)
+
+	email := "user-verification@example.com"
+	token := "verification-token"
+
+	uid, err := uuid.New().ID()
+	require.Nil(t, err, fmt.Sprintf("unexpected error: %s", err))
+
+	user := users.User{
+		ID:           uid,
+		Email:        email,
+		Password:     "pass",
+		Status:       users.PendingStatus,
+		Verification: users.Verification{Token: token, Sent: time.Now().UTC()},
+	}
+
+	_, err = repo.Save(context.Background(), user)
+	require.Nil(t, err, fmt.Sprintf("unexpected error: %s", err))
+
+	cases := map[string]struct {
+		token string
+		err   error
+	}{
+		"retrieve user by existing verification token": {
+			token: token,
+			err:   nil,
+		},
+		"retrieve user by non-existing verification token": {
+			token: "non-existing",
+			err:   users.ErrNotFound,
+		},
+		"retrieve user by empty verification token": {
+			token: "",
+			err:   users.ErrNotFound,
+		},
+	}
+
+	for desc, tc := range cases {
+		u, err := repo.RetrieveByVerificationToken(context.Background(), tc.token)
+		assert.True(t, errors.Contains(err, tc.err), fmt.Sprintf("%s: expected %s got %s\n", desc, tc.err, err))
+		if tc.err == nil {
+			assert.Equal(t, users.PendingStatus, u.Status, fmt.Sprintf("%s: expected status %s got %s\n", desc, users.PendingStatus, u.Status))
+		}
+	}
+
+	err = repo.UpdateVerification(context.Background(), email, users.Verification{})
+	assert.Nil(t, err, fmt.Sprintf("clear verification: unexpected error: %s", err))
+	err = repo.UpdateStatus(context.Background(), email, users.ActiveStatus)
+	assert.Nil(t, err, fmt.Sprintf("update status: unexpected error: %s", err))
+	err = repo.UpdateStatus(context.Background(), "non-existing@example.com", users.ActiveStatus)
+	assert.True(t, errors.Contains(err, users.ErrNotFound), fmt.Sprintf("update status of non-existing user: expected %s got %s\n", users.ErrNotFound, err))
+
+	_, err = repo.RetrieveByVerificationToken(context.Background(), token)
+	assert.True(t, errors.Contains(err, users.ErrNotFound), fmt.Sprintf("retrieve user by cleared token: expected %s got %s\n", users.ErrNotFound, err))
+	u, err := repo.RetrieveByEmail(context.Background(), email)
+	require.Nil(t, err, fmt.Sprintf("unexpected error: %s", err))
+	assert.Equal(t, users.ActiveStatus, u.Status, fmt.Sprintf("expected status %s got %s\n", users.ActiveStatus, u.Status))
+}
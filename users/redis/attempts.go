@@ -0,0 +1,91 @@
+// Copyright (c) Mainflux
+// SPDX-License-Identifier: Apache-2.0
+
+package redis
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/go-redis/redis"
+	"github.com/mainflux/mainflux/pkg/errors"
+	"github.com/mainflux/mainflux/users"
+)
+
+const (
+	attemptsPrefix = "login_attempts"
+	lockPrefix     = "login_lock"
+
+	locked = "1"
+)
+
+var (
+	errFail   = errors.New("failed to record failed login attempt")
+	errLock   = errors.New("failed to lock out login")
+	errLocked = errors.New("failed to retrieve login lockout")
+	errReset  = errors.New("failed to reset failed login attempts")
+)
+
+var _ users.AttemptRepository = (*attemptRepository)(nil)
+
+type attemptRepository struct {
+	client *redis.Client
+}
+
+// NewAttemptRepository returns Redis implementation of the attempt
+// repository, which allows the attempts to be shared by all of the
+// service instances.
+func NewAttemptRepository(client *redis.Client) users.AttemptRepository {
+	return &attemptRepository{client: client}
+}
+
+func (ar *attemptRepository) Fail(_ context.Context, key string, ttl time.Duration) (uint64, error) {
+	k := attemptsKey(key)
+
+	pipe := ar.client.TxPipeline()
+	incr := pipe.Incr(k)
+	pipe.Expire(k, ttl)
+	if _, err := pipe.Exec(); err != nil {
+		return 0, errors.Wrap(errFail, err)
+	}
+
+	return uint64(incr.Val()), nil
+}
+
+func (ar *attemptRepository) Lock(_ context.Context, key string, d time.Duration) error {
+	if err := ar.client.Set(lockKey(key), locked, d).Err(); err != nil {
+		return errors.Wrap(errLock, err)
+	}
+
+	return nil
+}
+
+func (ar *attemptRepository) Locked(_ context.Context, key string) (time.Duration, error) {
+	d, err := ar.client.PTTL(lockKey(key)).Result()
+	if err != nil {
+		return 0, errors.Wrap(errLocked, err)
+	}
+	// Negative TTL is returned for the missing keys.
+	if d < 0 {
+		return 0, nil
+	}
+
+	return d, nil
+}
+
+func (ar *attemptRepository) Reset(_ context.Context, key string) error {
+	if err := ar.client.Del(attemptsKey(key), lockKey(key)).Err(); err != nil {
+		return errors.Wrap(errReset, err)
+	}
+
+	return nil
+}
+
+func attemptsKey(key string) string {
+	return fmt.Sprintf("%s:%s", attemptsPrefix, key)
+}
+
+func lockKey(key string) string {
+	return fmt.Sprintf("%s:%s", lockPrefix, key)
+}
@@ -0,0 +1,92 @@
+// Copyright (c) Mainflux
+// SPDX-License-Identifier: Apache-2.0
+
+package redis_test
+
+import (
+	"context"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/mainflux/mainflux/users/redis"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestAttemptsFail(t *testing.T) {
+	repo := redis.NewAttemptRepository(redisClient)
+
+	cases := []struct {
+		desc  string
+		key   string
+		count uint64
+	}{
+		{
+			desc:  "record first failed attempt",
+			key:   "account:user@example.com",
+			count: 1,
+		},
+		{
+			desc:  "record second failed attempt",
+			key:   "account:user@example.com",
+			count: 2,
+		},
+		{
+			desc:  "record failed attempt for another key",
+			key:   "ip:127.0.0.1",
+			count: 1,
+		},
+	}
+
+	for _, tc := range cases {
+		count, err := repo.Fail(context.Background(), tc.key, time.Minute)
+		assert.Nil(t, err, fmt.Sprintf("%s: unexpected error: %s", tc.desc, err))
+		assert.Equal(t, tc.count, count, fmt.Sprintf("%s: expected %d got %d\n", tc.desc, tc.count, count))
+	}
+}
+
+func TestAttemptsLock(t *testing.T) {
+	repo := redis.NewAttemptRepository(redisClient)
+
+	err := repo.Lock(context.Background(), "locked", time.Minute)
+	require.Nil(t, err, fmt.Sprintf("unexpected error: %s", err))
+	err = repo.Lock(context.Background(), "reset", time.Minute)
+	require.Nil(t, err, fmt.Sprintf("unexpected error: %s", err))
+	_, err = repo.Fail(context.Background(), "reset", time.Minute)
+	require.Nil(t, err, fmt.Sprintf("unexpected error: %s", err))
+	err = repo.Reset(context.Background(), "reset")
+	require.Nil(t, err, fmt.Sprintf("unexpected error: %s", err))
+
+	cases := []struct {
+		desc   string
+		key    string
+		locked bool
+	}{
+		{
+			desc:   "check locked key",
+			key:    "locked",
+			locked: true,
+		},
+		{
+			desc:   "check reset key",
+			key:    "reset",
+			locked: false,
+		},
+		{
+			desc:   "check non-locked key",
+			key:    "unlocked",
+			locked: false,
+		},
+	}
+
+	for _, tc := range cases {
+		d, err := repo.Locked(context.Background(), tc.key)
+		assert.Nil(t, err, fmt.Sprintf("%s: unexpected error: %s", tc.desc, err))
+		assert.Equal(t, tc.locked, d > 0, fmt.Sprintf("%s: expected locked %t got remaining duration %s\n", tc.desc, tc.locked, d))
+	}
+
+	count, err := repo.Fail(context.Background(), "reset", time.Minute)
+	assert.Nil(t, err, fmt.Sprintf("unexpected error: %s", err))
+	assert.Equal(t, uint64(1), count, fmt.Sprintf("failed attempts should start over after reset, got %d", count))
+}
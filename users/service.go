@@ -5,12 +5,33 @@ package users
 
 import (
 	"context"
+	"crypto/hmac"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
 	"regexp"
+	"sort"
+	"strings"
+	"time"
 
 	"github.com/mainflux/mainflux"
 	"github.com/mainflux/mainflux/authn"
 	"github.com/mainflux/mainflux/pkg/errors"
 	uuidProvider "github.com/mainflux/mainflux/pkg/uuid"
+	"github.com/mainflux/mainflux/users/totp"
+)
+
+const (
+	totpIssuer       = "Mainflux"
+	recoveryCodesNum = 10
+	recoveryCodeSize = 5
+	verifyTokenSize  = 32
+
+	accountPrefix = "account"
+	ipPrefix      = "ip"
+
+	invitationSeparator = "."
 )
 
 var (
@@ -67,28 +88,160 @@ var (
 
 	// ErrAssignUserToGroup indicates an error in assigning user to a group.
 	ErrAssignUserToGroup = errors.New("failed assigning user to a group")
+
+	// ErrRevokeSessions indicates failure to revoke the sessions of the user
+	// after the password change.
+	ErrRevokeSessions = errors.New("failed to revoke user sessions")
+
+	// ErrOIDCNotConfigured indicates that login using the OpenID Connect
+	// provider is not configured.
+	ErrOIDCNotConfigured = errors.New("OpenID Connect login is not configured")
+
+	// ErrTOTPEnabled indicates that two-factor authentication is already
+	// enabled for the user.
+	ErrTOTPEnabled = errors.New("two-factor authentication already enabled")
+
+	// ErrTOTPNotEnrolled indicates that the user has not enrolled or
+	// enabled two-factor authentication.
+	ErrTOTPNotEnrolled = errors.New("two-factor authentication not enrolled")
+
+	// ErrTooManyAttempts indicates that the login is rejected because of
+	// too many failed login attempts for the account or from the address.
+	ErrTooManyAttempts = errors.New("too many failed login attempts")
+
+	// ErrLockout indicates that the failed login attempt caused the account
+	// or the address to be locked out.
+	ErrLockout = errors.New("account or address locked out")
+
+	// ErrUnverified indicates that the user has not verified its email yet.
+	ErrUnverified = errors.New("email not verified")
+
+	// ErrVerified indicates that the user has already verified its email.
+	ErrVerified = errors.New("email already verified")
+
+	// ErrVerificationSent indicates that the verification email is resent
+	// too soon after the previous one.
+	ErrVerificationSent = errors.New("verification email recently sent")
+
+	// ErrSendVerification indicates failure to send the verification email.
+	ErrSendVerification = errors.New("failed to send verification email")
+
+	// ErrInvitationConflict indicates that the email has already been
+	// invited into the group and the invitation is still pending.
+	ErrInvitationConflict = errors.New("invitation already sent")
+
+	// ErrSendInvitation indicates failure to send the invitation email.
+	ErrSendInvitation = errors.New("failed to send invitation email")
+
+	// ErrDisabled indicates that the user is disabled by the admin.
+	ErrDisabled = errors.New("user disabled")
+
+	errEnrollTOTP = errors.New("failed to enroll two-factor authentication")
 )
 
 // Service specifies an API that must be fullfiled by the domain service
 // implementation, and all of its decorators (e.g. logging & metrics).
 type Service interface {
 	// Register creates new user account. In case of the failed registration, a
-	// non-nil error value is returned.
+	// non-nil error value is returned. If email verification is enabled, the
+	// account is created in the pending status and the verification token
+	// is sent to the user's email.
 	Register(ctx context.Context, user User) (string, error)
 
-	// Login authenticates the user given its credentials. Successful
-	// authentication generates new access token. Failed invocations are
-	// identified by the non-nil error values in the response.
-	Login(ctx context.Context, user User) (string, error)
-
-	// ViewUser retrieves user info for a given user ID and an authorized token.
+	// VerifyEmail activates the pending user account, given the email
+	// verification token.
+	VerifyEmail(ctx context.Context, token string) error
+
+	// ResendVerification sends a new email verification token to the user
+	// with the pending status. The previous token is invalidated.
+	ResendVerification(ctx context.Context, email string) error
+
+	// Login authenticates the user given its credentials and the address
+	// the login comes from. Successful authentication generates new access
+	// and refresh tokens. If the user has two-factor authentication enabled,
+	// only the challenge token is returned instead, which is exchanged for
+	// the access and refresh tokens using LoginTOTP. Failed attempts are
+	// throttled per account and per address, according to the lockout
+	// policy. Failed invocations are identified by the non-nil error values
+	// in the response.
+	Login(ctx context.Context, user User, ip string) (string, string, error)
+
+	// LoginTOTP completes the login of the user with two-factor
+	// authentication enabled, given the challenge token and the TOTP or
+	// recovery code. Challenge token can be used only once.
+	LoginTOTP(ctx context.Context, challenge, code string) (string, string, error)
+
+	// Refresh exchanges the refresh token for a new pair of access and
+	// refresh tokens. Refresh token can be used only once.
+	Refresh(ctx context.Context, refreshToken string) (string, string, error)
+
+	// OIDCAuthURL returns the URL of the OpenID Connect provider the user
+	// is redirected to in order to log in.
+	OIDCAuthURL(ctx context.Context, state string) (string, error)
+
+	// OIDCLogin authenticates the user using the authorization code issued
+	// by the OpenID Connect provider and generates new access and refresh
+	// tokens. User account is created on the first login.
+	OIDCLogin(ctx context.Context, code, state string) (string, string, error)
+
+	// Logout revokes the provided access or refresh token.
+	Logout(ctx context.Context, token string) error
+
+	// EnrollTOTP generates a new two-factor authentication secret for the
+	// user identified by the provided token. Two-factor authentication is
+	// enabled once the secret is confirmed.
+	EnrollTOTP(ctx context.Context, token string) (TOTPEnrollment, error)
+
+	// ConfirmTOTP enables two-factor authentication for the user
+	// identified by the provided token, given the code generated using the
+	// enrolled secret. Recovery codes, which can be used once each instead
+	// of the TOTP code, are returned.
+	ConfirmTOTP(ctx context.Context, token, code string) ([]string, error)
+
+	// DisableTOTP disables two-factor authentication for the user
+	// identified by the provided token, given the TOTP or recovery code.
+	DisableTOTP(ctx context.Context, token, code string) error
+
+	// ResetTOTP disables two-factor authentication for the user identified
+	// by ID, e.g. when the user has lost the authenticator device. Only
+	// the admin is allowed to reset the two-factor authentication.
+	ResetTOTP(ctx context.Context, token, id string) error
+
+	// Unlock removes the failed login attempts and the lockout of the user
+	// identified by ID. Only the admin is allowed to unlock the accounts.
+	Unlock(ctx context.Context, token, id string) error
+
+	// DisableUser disables the user identified by ID, so that the user
+	// can't log in, and revokes all of its keys. Only the admin is allowed
+	// to disable the users.
+	DisableUser(ctx context.Context, token, id string) error
+
+	// EnableUser enables the user identified by ID disabled before. Only
+	// the admin is allowed to enable the users.
+	EnableUser(ctx context.Context, token, id string) error
+
+	// Impersonate issues the access token of the user identified by ID to
+	// the admin, e.g. to troubleshoot the user account, and records the
+	// impersonation with the given reason.
+	Impersonate(ctx context.Context, token, id, reason string) (string, error)
+
+	// ListImpersonations retrieves the impersonation records. If user ID
+	// is not empty, only the impersonations of the user are retrieved.
+	// Only the admin is allowed to list the impersonations.
+	ListImpersonations(ctx context.Context, token, userID string, offset, limit uint64) (ImpersonationPage, error)
+
+	// ViewUser retrieves user info for a given user ID. Users are allowed
+	// to view only their own account, while the admin can view any.
 	ViewUser(ctx context.Context, token, id string) (User, error)
 
 	// ViewProfile retrieves user info for a given token.
 	ViewProfile(ctx context.Context, token string) (User, error)
 
-	// ListUsers retrieves users list for a valid admin token.
-	ListUsers(ctx context.Context, token string, offset, limit uint64, email string, m Metadata) (UserPage, error)
+	// ListUsers retrieves users list for a valid admin token, while the
+	// other users retrieve only their own account. If cursor is provided,
+	// the page following the cursor is retrieved instead of the one at the
+	// offset.
+	ListUsers(ctx context.Context, token string, offset, limit uint64, cursor, email string, m Metadata) (UserPage, error)
 
 	// UpdateUser updates the user metadata.
 	UpdateUser(ctx context.Context, token string, user User) error
@@ -98,10 +251,12 @@ type Service interface {
 	GenerateResetToken(ctx context.Context, email, host string) error
 
 	// ChangePassword change users password for authenticated user.
+	// All of the user access tokens are revoked after the change.
 	ChangePassword(ctx context.Context, authToken, password, oldPassword string) error
 
 	// ResetPassword change users password in reset flow.
 	// token can be authentication token or password reset token.
+	// All of the user access tokens are revoked after the reset.
 	ResetPassword(ctx context.Context, resetToken, password string) error
 
 	//SendPasswordReset sends reset password link to email.
@@ -110,38 +265,75 @@ type Service interface {
 	// CreateGroup creates new user group.
 	CreateGroup(ctx context.Context, token string, group Group) (Group, error)
 
-	// UpdateGroup updates the group identified by the provided ID.
+	// UpdateGroup updates the group identified by the provided ID. Only the
+	// group admins are allowed to update the group.
 	UpdateGroup(ctx context.Context, token string, group Group) error
 
-	// ViewGroup retrieves data about the group identified by ID.
+	// ViewGroup retrieves data about the group identified by ID. Only the
+	// group members are allowed to view the group.
 	ViewGroup(ctx context.Context, token, id string) (Group, error)
 
-	// ListGroups retrieves groups that are children to group identified by parenID
-	// if parentID is empty all groups are listed.
+	// ListGroups retrieves groups that are children to group identified by
+	// parentID. If parentID is empty, the groups the user is member of are
+	// listed. Only the group members are allowed to list its children, while
+	// the admin lists all groups.
 	ListGroups(ctx context.Context, token, parentID string, offset, limit uint64, m Metadata) (GroupPage, error)
 
-	// Members retrieves users that are assigned to a group identified by groupID.
+	// Members retrieves users that are assigned to a group identified by
+	// groupID. Only the group members are allowed to list the members.
 	ListMembers(ctx context.Context, token, groupID string, offset, limit uint64, m Metadata) (UserPage, error)
 
-	// ListMemberships retrieves groups that user identified with userID belongs to.
+	// ListMemberships retrieves groups that user identified with userID
+	// belongs to. The users can list only their own memberships, while the
+	// admin can list anyone's.
 	ListMemberships(ctx context.Context, token, groupID string, offset, limit uint64, m Metadata) (GroupPage, error)
 
-	// RemoveGroup removes the group identified with the provided ID.
+	// RemoveGroup removes the group identified with the provided ID. Only
+	// the group admins are allowed to remove the group.
 	RemoveGroup(ctx context.Context, token, id string) error
 
-	// Assign adds user with userID into the group identified by groupID.
-	Assign(ctx context.Context, token, userID, groupID string) error
+	// Assign adds user with userID into the group identified by groupID
+	// with the given role. If the role is empty, the user is a viewer. Only
+	// the group admins are allowed to assign the users.
+	Assign(ctx context.Context, token, userID, groupID, role string) error
 
 	// Unassign removes user with userID from group identified by groupID.
+	// Only the group admins are allowed to unassign the users.
 	Unassign(ctx context.Context, token, userID, groupID string) error
+
+	// Invite invites the email into the group identified by groupID with
+	// the given role, and sends the signed invitation token to the email.
+	// Only the group admins are allowed to invite.
+	Invite(ctx context.Context, token, groupID, email, role string) (Invitation, error)
+
+	// ListInvitations retrieves the pending invitations into the group
+	// identified by groupID. Only the group admins are allowed to list them.
+	ListInvitations(ctx context.Context, token, groupID string, offset, limit uint64) (InvitationPage, error)
+
+	// RevokeInvitation removes the pending invitation identified by ID.
+	RevokeInvitation(ctx context.Context, token, id string) error
+
+	// AcceptInvitation assigns the user with the invited email to the
+	// group, given the invitation token. If the user doesn't exist, it's
+	// registered with the provided password. The provided password also
+	// replaces the password of the user pending verification. Returns the
+	// ID of the user.
+	AcceptInvitation(ctx context.Context, invToken, password string) (string, error)
+
+	// Authorize retrieves the IDs of the groups in which the user with the
+	// given email has at least the given role. Other services use it to
+	// authorize access to the resources shared with the groups.
+	Authorize(ctx context.Context, email, role string) ([]string, error)
 }
 
-// PageMetadata contains page metadata that helps navigation.
+// PageMetadata contains page metadata that helps navigation. NextCursor
+// is set if there may be more items following the page.
 type PageMetadata struct {
-	Total  uint64
-	Offset uint64
-	Limit  uint64
-	Name   string
+	Total      uint64
+	Offset     uint64
+	Limit      uint64
+	Name       string
+	NextCursor string
 }
 
 // GroupPage contains a page of groups.
@@ -150,6 +342,28 @@ type GroupPage struct {
 	Groups []Group
 }
 
+// TOTPEnrollment contains the generated two-factor authentication secret
+// and its otpauth URI, which is presented to the user as a QR code.
+type TOTPEnrollment struct {
+	Secret string
+	URI    string
+}
+
+// VerificationPolicy specifies the email verification of the registered
+// users.
+type VerificationPolicy struct {
+	// Enabled indicates whether the registered users must verify their
+	// email before logging in.
+	Enabled bool
+
+	// Duration is how long the verification token is valid.
+	Duration time.Duration
+
+	// ResendInterval is the minimal interval between two verification
+	// emails sent to the same user.
+	ResendInterval time.Duration
+}
+
 // UserPage contains a page of users.
 type UserPage struct {
 	PageMetadata
@@ -159,25 +373,136 @@ type UserPage struct {
 var _ Service = (*usersService)(nil)
 
 type usersService struct {
-	users  UserRepository
-	groups GroupRepository
-	hasher Hasher
-	email  Emailer
-	auth   mainflux.AuthNServiceClient
+	users          UserRepository
+	groups         GroupRepository
+	invitations    InvitationRepository
+	impersonations ImpersonationRepository
+	hasher         Hasher
+	email          Emailer
+	auth           mainflux.AuthNServiceClient
+	idp            IdentityProvider
+	attempts       AttemptRepository
+	lockout        LockoutPolicy
+	verify         VerificationPolicy
+	invite         InvitationPolicy
+	admin          string
 }
 
-// New instantiates the users service implementation
-func New(users UserRepository, groups GroupRepository, hasher Hasher, auth mainflux.AuthNServiceClient, m Emailer) Service {
+// New instantiates the users service implementation. Identity provider is
+// optional; if it's nil, login using OpenID Connect is disabled. Failed
+// login attempts are stored in the attempt repository and throttled
+// according to the lockout policy. Group invitations are signed and
+// expire according to the invitation policy. Admin is the email of the
+// platform admin account, and it's never required to verify its email. The
+// admin privileges are granted by the authn service, which identifies the
+// admin.
+func New(users UserRepository, groups GroupRepository, invitations InvitationRepository, impersonations ImpersonationRepository, hasher Hasher, auth mainflux.AuthNServiceClient, m Emailer, idp IdentityProvider, attempts AttemptRepository, lockout LockoutPolicy, verify VerificationPolicy, invite InvitationPolicy, admin string) Service {
 	return &usersService{
-		users:  users,
-		groups: groups,
-		hasher: hasher,
-		auth:   auth,
-		email:  m,
+		users:          users,
+		groups:         groups,
+		invitations:    invitations,
+		impersonations: impersonations,
+		hasher:         hasher,
+		auth:           auth,
+		email:          m,
+		idp:            idp,
+		attempts:       attempts,
+		lockout:        lockout,
+		verify:         verify,
+		invite:         invite,
+		admin:          admin,
 	}
 }
 
 func (svc usersService) Register(ctx context.Context, user User) (string, error) {
+	if !svc.verify.Enabled || user.Email == svc.admin {
+		user.Status = ActiveStatus
+		return svc.register(ctx, user)
+	}
+
+	token, err := verificationToken()
+	if err != nil {
+		return "", errors.Wrap(ErrCreateUser, err)
+	}
+	user.Status = PendingStatus
+	user.Verification = Verification{Token: hashToken(token), Sent: time.Now().UTC()}
+
+	uid, err := svc.register(ctx, user)
+	if err != nil {
+		return "", err
+	}
+	// Failure to send the email is reported, but the account remains
+	// created, so the verification can be resent.
+	if err := svc.email.SendVerification([]string{user.Email}, token); err != nil {
+		return "", errors.Wrap(ErrSendVerification, err)
+	}
+
+	return uid, nil
+}
+
+func (svc usersService) VerifyEmail(ctx context.Context, token string) error {
+	user, err := svc.users.RetrieveByVerificationToken(ctx, hashToken(token))
+	if err != nil {
+		return errors.Wrap(ErrUnauthorizedAccess, err)
+	}
+	if time.Since(user.Verification.Sent) > svc.verify.Duration {
+		return ErrUnauthorizedAccess
+	}
+
+	if err := svc.users.UpdateVerification(ctx, user.Email, Verification{}); err != nil {
+		return err
+	}
+
+	return svc.users.UpdateStatus(ctx, user.Email, ActiveStatus)
+}
+
+func (svc usersService) ResendVerification(ctx context.Context, email string) error {
+	user, err := svc.users.RetrieveByEmail(ctx, email)
+	if err != nil {
+		return ErrUserNotFound
+	}
+	if user.Status != PendingStatus {
+		return ErrVerified
+	}
+	if time.Since(user.Verification.Sent) < svc.verify.ResendInterval {
+		return ErrVerificationSent
+	}
+
+	token, err := verificationToken()
+	if err != nil {
+		return errors.Wrap(ErrSendVerification, err)
+	}
+	v := Verification{Token: hashToken(token), Sent: time.Now().UTC()}
+	if err := svc.users.UpdateVerification(ctx, user.Email, v); err != nil {
+		return err
+	}
+	if err := svc.email.SendVerification([]string{user.Email}, token); err != nil {
+		return errors.Wrap(ErrSendVerification, err)
+	}
+
+	return nil
+}
+
+// verificationToken generates a random email verification token.
+func verificationToken() (string, error) {
+	b := make([]byte, verifyTokenSize)
+	if _, err := rand.Read(b); err != nil {
+		return "", err
+	}
+
+	return hex.EncodeToString(b), nil
+}
+
+// hashToken hashes the verification token. Unlike the passwords, the token
+// is looked up by its hash, so it's hashed using SHA-256 instead of the
+// hasher. Random tokens are not prone to dictionary attacks.
+func hashToken(token string) string {
+	h := sha256.Sum256([]byte(token))
+	return hex.EncodeToString(h[:])
+}
+
+// register creates the user account with the status set by the caller.
+func (svc usersService) register(ctx context.Context, user User) (string, error) {
 	if err := user.Validate(); err != nil {
 		return "", err
 	}
@@ -198,19 +523,482 @@ func (svc usersService) Register(ctx context.Context, user User) (string, error)
 	return uid, nil
 }
 
-func (svc usersService) Login(ctx context.Context, user User) (string, error) {
+func (svc usersService) Login(ctx context.Context, user User, ip string) (string, string, error) {
+	keys := svc.lockoutKeys(user.Email, ip)
+	for _, k := range keys {
+		d, err := svc.attempts.Locked(ctx, k.key)
+		if err != nil {
+			return "", "", err
+		}
+		if d > 0 {
+			return "", "", ErrTooManyAttempts
+		}
+	}
+
 	dbUser, err := svc.users.RetrieveByEmail(ctx, user.Email)
 	if err != nil {
-		return "", errors.Wrap(ErrUnauthorizedAccess, err)
+		return "", "", svc.fail(ctx, keys, errors.Wrap(ErrUnauthorizedAccess, err))
 	}
 	if err := svc.hasher.Compare(user.Password, dbUser.Password); err != nil {
+		return "", "", svc.fail(ctx, keys, errors.Wrap(ErrUnauthorizedAccess, err))
+	}
+	switch dbUser.Status {
+	case PendingStatus:
+		return "", "", ErrUnverified
+	case DisabledStatus:
+		return "", "", ErrDisabled
+	}
+	// Attempts from the address are not reset, so that logging into one
+	// account doesn't allow guessing the passwords of the others.
+	if svc.lockout.MaxAttempts > 0 {
+		if err := svc.attempts.Reset(ctx, accountKey(user.Email)); err != nil {
+			return "", "", err
+		}
+	}
+
+	if dbUser.TOTP.Enabled {
+		challenge, err := svc.issue(ctx, dbUser.ID, dbUser.Email, authn.ChallengeKey)
+		if err != nil {
+			return "", "", err
+		}
+		return challenge, "", nil
+	}
+
+	access, err := svc.issue(ctx, dbUser.ID, dbUser.Email, authn.UserKey)
+	if err != nil {
+		return "", "", err
+	}
+	refresh, err := svc.issue(ctx, dbUser.ID, dbUser.Email, authn.RefreshKey)
+	if err != nil {
+		return "", "", err
+	}
+
+	return access, refresh, nil
+}
+
+func (svc usersService) LoginTOTP(ctx context.Context, challenge, code string) (string, string, error) {
+	id, err := svc.auth.VerifyChallenge(ctx, &mainflux.Token{Value: challenge})
+	if err != nil {
+		return "", "", errors.Wrap(ErrUnauthorizedAccess, err)
+	}
+
+	user, err := svc.users.RetrieveByEmail(ctx, id.GetEmail())
+	if err != nil {
+		return "", "", errors.Wrap(ErrUnauthorizedAccess, err)
+	}
+	if !user.TOTP.Enabled {
+		return "", "", ErrUnauthorizedAccess
+	}
+	if err := svc.verifyTOTP(ctx, user, code); err != nil {
+		return "", "", err
+	}
+
+	access, err := svc.issue(ctx, user.ID, user.Email, authn.UserKey)
+	if err != nil {
+		return "", "", err
+	}
+	refresh, err := svc.issue(ctx, user.ID, user.Email, authn.RefreshKey)
+	if err != nil {
+		return "", "", err
+	}
+
+	return access, refresh, nil
+}
+
+func (svc usersService) Refresh(ctx context.Context, refreshToken string) (string, string, error) {
+	tokens, err := svc.auth.Refresh(ctx, &mainflux.Token{Value: refreshToken})
+	if err != nil {
+		return "", "", errors.Wrap(ErrUnauthorizedAccess, err)
+	}
+	return tokens.GetAccess(), tokens.GetRefresh(), nil
+}
+
+func (svc usersService) OIDCAuthURL(_ context.Context, state string) (string, error) {
+	if svc.idp == nil {
+		return "", ErrOIDCNotConfigured
+	}
+	return svc.idp.AuthURL(state), nil
+}
+
+func (svc usersService) OIDCLogin(ctx context.Context, code, state string) (string, string, error) {
+	if svc.idp == nil {
+		return "", "", ErrOIDCNotConfigured
+	}
+
+	ext, err := svc.idp.Exchange(ctx, code, state)
+	if err != nil {
+		return "", "", errors.Wrap(ErrUnauthorizedAccess, err)
+	}
+
+	user, err := svc.users.RetrieveByEmail(ctx, ext.Email)
+	switch {
+	case errors.Contains(err, ErrNotFound):
+		if user, err = svc.provision(ctx, ext); err != nil {
+			return "", "", err
+		}
+	case err != nil:
+		return "", "", errors.Wrap(ErrUnauthorizedAccess, err)
+	case user.Status == DisabledStatus:
+		return "", "", ErrDisabled
+	case user.Status == PendingStatus:
+		// Email of the pending user is verified by the provider.
+		if err := svc.users.UpdateStatus(ctx, user.Email, ActiveStatus); err != nil {
+			return "", "", err
+		}
+	}
+
+	access, err := svc.issue(ctx, user.ID, user.Email, authn.UserKey)
+	if err != nil {
+		return "", "", err
+	}
+	refresh, err := svc.issue(ctx, user.ID, user.Email, authn.RefreshKey)
+	if err != nil {
+		return "", "", err
+	}
+
+	return access, refresh, nil
+}
+
+// provision creates the account of the user logged in using the identity
+// provider for the first time. The account gets a random password, which
+// the user can reset to log in using the password as well. The user is
+// assigned to the existing groups named after the provider groups.
+func (svc usersService) provision(ctx context.Context, ext ExternalIdentity) (User, error) {
+	password, err := uuidProvider.New().ID()
+	if err != nil {
+		return User{}, errors.Wrap(ErrCreateUser, err)
+	}
+
+	user := User{
+		Email:    ext.Email,
+		Password: password,
+		Metadata: ext.Metadata,
+		Status:   ActiveStatus,
+	}
+	if user.ID, err = svc.register(ctx, user); err != nil {
+		return User{}, errors.Wrap(ErrCreateUser, err)
+	}
+
+	for _, name := range ext.Groups {
+		g, err := svc.groups.RetrieveByName(ctx, name)
+		if err != nil {
+			continue
+		}
+		if err := svc.groups.Assign(ctx, user.ID, g.ID, ViewerRole); err != nil {
+			return User{}, errors.Wrap(ErrAssignUserToGroup, err)
+		}
+	}
+
+	return user, nil
+}
+
+func (svc usersService) Logout(ctx context.Context, token string) error {
+	if _, err := svc.auth.RevokeToken(ctx, &mainflux.Token{Value: token}); err != nil {
+		return errors.Wrap(ErrUnauthorizedAccess, err)
+	}
+	return nil
+}
+
+func (svc usersService) EnrollTOTP(ctx context.Context, token string) (TOTPEnrollment, error) {
+	user, err := svc.profile(ctx, token)
+	if err != nil {
+		return TOTPEnrollment{}, err
+	}
+	if user.TOTP.Enabled {
+		return TOTPEnrollment{}, ErrTOTPEnabled
+	}
+
+	secret, err := totp.NewSecret()
+	if err != nil {
+		return TOTPEnrollment{}, errors.Wrap(errEnrollTOTP, err)
+	}
+	if err := svc.users.UpdateTOTP(ctx, user.Email, TOTP{Secret: secret}); err != nil {
+		return TOTPEnrollment{}, err
+	}
+
+	return TOTPEnrollment{
+		Secret: secret,
+		URI:    totp.URI(totpIssuer, user.Email, secret),
+	}, nil
+}
+
+func (svc usersService) ConfirmTOTP(ctx context.Context, token, code string) ([]string, error) {
+	user, err := svc.profile(ctx, token)
+	if err != nil {
+		return nil, err
+	}
+	if user.TOTP.Enabled {
+		return nil, ErrTOTPEnabled
+	}
+	if user.TOTP.Secret == "" {
+		return nil, ErrTOTPNotEnrolled
+	}
+	if !totp.Validate(user.TOTP.Secret, code, time.Now()) {
+		return nil, ErrUnauthorizedAccess
+	}
+
+	codes := make([]string, recoveryCodesNum)
+	hashes := make([]string, recoveryCodesNum)
+	for i := range codes {
+		b := make([]byte, recoveryCodeSize)
+		if _, err := rand.Read(b); err != nil {
+			return nil, errors.Wrap(errEnrollTOTP, err)
+		}
+		c := hex.EncodeToString(b)
+		codes[i] = c[:recoveryCodeSize] + "-" + c[recoveryCodeSize:]
+		if hashes[i], err = svc.hasher.Hash(codes[i]); err != nil {
+			return nil, errors.Wrap(errEnrollTOTP, err)
+		}
+	}
+
+	t := TOTP{
+		Secret:        user.TOTP.Secret,
+		Enabled:       true,
+		RecoveryCodes: hashes,
+	}
+	if err := svc.users.UpdateTOTP(ctx, user.Email, t); err != nil {
+		return nil, err
+	}
+
+	return codes, nil
+}
+
+func (svc usersService) DisableTOTP(ctx context.Context, token, code string) error {
+	user, err := svc.profile(ctx, token)
+	if err != nil {
+		return err
+	}
+	if !user.TOTP.Enabled {
+		return ErrTOTPNotEnrolled
+	}
+	if err := svc.verifyTOTP(ctx, user, code); err != nil {
+		return err
+	}
+
+	return svc.users.UpdateTOTP(ctx, user.Email, TOTP{})
+}
+
+func (svc usersService) ResetTOTP(ctx context.Context, token, id string) error {
+	if _, err := svc.authorizeAdmin(ctx, token); err != nil {
+		return err
+	}
+
+	user, err := svc.users.RetrieveByID(ctx, id)
+	if err != nil {
+		return err
+	}
+
+	return svc.users.UpdateTOTP(ctx, user.Email, TOTP{})
+}
+
+func (svc usersService) Unlock(ctx context.Context, token, id string) error {
+	if _, err := svc.authorizeAdmin(ctx, token); err != nil {
+		return err
+	}
+
+	user, err := svc.users.RetrieveByID(ctx, id)
+	if err != nil {
+		return err
+	}
+
+	return svc.attempts.Reset(ctx, accountKey(user.Email))
+}
+
+func (svc usersService) DisableUser(ctx context.Context, token, id string) error {
+	if _, err := svc.authorizeAdmin(ctx, token); err != nil {
+		return err
+	}
+
+	user, err := svc.users.RetrieveByID(ctx, id)
+	if err != nil {
+		return err
+	}
+
+	// The keys are rejected by the authn service first, so the user is
+	// never left disabled while still holding the valid keys.
+	if _, err := svc.auth.SetDisabled(ctx, &mainflux.DisableReq{Token: token, Id: user.ID, Disabled: true}); err != nil {
+		return errors.Wrap(ErrUnauthorizedAccess, err)
+	}
+
+	return svc.users.UpdateStatus(ctx, user.Email, DisabledStatus)
+}
+
+func (svc usersService) EnableUser(ctx context.Context, token, id string) error {
+	if _, err := svc.authorizeAdmin(ctx, token); err != nil {
+		return err
+	}
+
+	user, err := svc.users.RetrieveByID(ctx, id)
+	if err != nil {
+		return err
+	}
+
+	if _, err := svc.auth.SetDisabled(ctx, &mainflux.DisableReq{Token: token, Id: user.ID, Disabled: false}); err != nil {
+		return errors.Wrap(ErrUnauthorizedAccess, err)
+	}
+	if user.Status != DisabledStatus {
+		return nil
+	}
+
+	return svc.users.UpdateStatus(ctx, user.Email, ActiveStatus)
+}
+
+func (svc usersService) Impersonate(ctx context.Context, token, id, reason string) (string, error) {
+	if strings.TrimSpace(reason) == "" {
+		return "", ErrMalformedEntity
+	}
+
+	admin, err := svc.authorizeAdmin(ctx, token)
+	if err != nil {
+		return "", err
+	}
+
+	user, err := svc.users.RetrieveByID(ctx, id)
+	if err != nil {
+		return "", err
+	}
+	if user.Status == DisabledStatus {
+		return "", ErrDisabled
+	}
+
+	impID, err := uuidProvider.New().ID()
+	if err != nil {
+		return "", err
+	}
+
+	key, err := svc.auth.Impersonate(ctx, &mainflux.ImpersonateReq{Token: token, Id: user.ID, Email: user.Email})
+	if err != nil {
 		return "", errors.Wrap(ErrUnauthorizedAccess, err)
 	}
-	return svc.issue(ctx, dbUser.ID, dbUser.Email, authn.UserKey)
+
+	imp := Impersonation{
+		ID:      impID,
+		AdminID: admin.GetId(),
+		UserID:  user.ID,
+		Reason:  reason,
+		Created: time.Now().UTC(),
+	}
+	if _, err := svc.impersonations.Save(ctx, imp); err != nil {
+		// The impersonation must not go unrecorded, so the key is revoked.
+		if _, rerr := svc.auth.RevokeToken(ctx, key); rerr != nil {
+			err = errors.Wrap(err, rerr)
+		}
+		return "", err
+	}
+
+	return key.GetValue(), nil
+}
+
+func (svc usersService) ListImpersonations(ctx context.Context, token, userID string, offset, limit uint64) (ImpersonationPage, error) {
+	if _, err := svc.authorizeAdmin(ctx, token); err != nil {
+		return ImpersonationPage{}, err
+	}
+
+	return svc.impersonations.RetrieveAll(ctx, userID, offset, limit)
+}
+
+type lockoutKey struct {
+	key string
+	max uint64
+}
+
+// lockoutKeys returns the keys the login attempts of the account and the
+// address are tracked by. Scopes disabled by the lockout policy, as well
+// as the unknown address, are not tracked.
+func (svc usersService) lockoutKeys(email, ip string) []lockoutKey {
+	var keys []lockoutKey
+	if svc.lockout.MaxAttempts > 0 {
+		keys = append(keys, lockoutKey{key: accountKey(email), max: svc.lockout.MaxAttempts})
+	}
+	if svc.lockout.MaxIPAttempts > 0 && ip != "" {
+		keys = append(keys, lockoutKey{key: fmt.Sprintf("%s:%s", ipPrefix, ip), max: svc.lockout.MaxIPAttempts})
+	}
+
+	return keys
+}
+
+// fail records the failed login attempt and delays or locks out the
+// further attempts. The login error is wrapped with ErrLockout if the
+// attempt caused the lockout.
+func (svc usersService) fail(ctx context.Context, keys []lockoutKey, err error) error {
+	for _, k := range keys {
+		n, e := svc.attempts.Fail(ctx, k.key, svc.lockout.Duration)
+		if e != nil {
+			return errors.Wrap(err, e)
+		}
+		d := svc.lockout.delay(n, k.max)
+		if d == 0 {
+			continue
+		}
+		if e := svc.attempts.Lock(ctx, k.key, d); e != nil {
+			return errors.Wrap(err, e)
+		}
+		if n == k.max {
+			err = errors.Wrap(ErrLockout, err)
+		}
+	}
+
+	return err
+}
+
+func accountKey(email string) string {
+	return fmt.Sprintf("%s:%s", accountPrefix, email)
+}
+
+// authorizeAdmin checks whether the user identified by the token is the
+// admin, as identified by the authn service.
+func (svc usersService) authorizeAdmin(ctx context.Context, token string) (*mainflux.UserIdentity, error) {
+	id, err := svc.identity(ctx, token)
+	if err != nil {
+		return nil, err
+	}
+	if !id.GetAdmin() {
+		return nil, ErrUnauthorizedAccess
+	}
+
+	return id, nil
+}
+
+// verifyTOTP checks the code against the TOTP secret of the user, or its
+// recovery codes. Matching recovery code is removed, so it can't be used
+// again.
+func (svc usersService) verifyTOTP(ctx context.Context, user User, code string) error {
+	if len(code) == totp.Digits {
+		if !totp.Validate(user.TOTP.Secret, code, time.Now()) {
+			return ErrUnauthorizedAccess
+		}
+		return nil
+	}
+
+	for i, hash := range user.TOTP.RecoveryCodes {
+		if err := svc.hasher.Compare(code, hash); err != nil {
+			continue
+		}
+		t := user.TOTP
+		t.RecoveryCodes = append(append([]string{}, t.RecoveryCodes[:i]...), t.RecoveryCodes[i+1:]...)
+		return svc.users.UpdateTOTP(ctx, user.Email, t)
+	}
+
+	return ErrUnauthorizedAccess
+}
+
+// profile retrieves the account of the user identified by the token.
+func (svc usersService) profile(ctx context.Context, token string) (User, error) {
+	email, err := svc.identify(ctx, token)
+	if err != nil {
+		return User{}, err
+	}
+
+	user, err := svc.users.RetrieveByEmail(ctx, email)
+	if err != nil {
+		return User{}, errors.Wrap(ErrUnauthorizedAccess, err)
+	}
+
+	return user, nil
 }
 
 func (svc usersService) ViewUser(ctx context.Context, token, id string) (User, error) {
-	_, err := svc.identify(ctx, token)
+	idt, err := svc.identity(ctx, token)
 	if err != nil {
 		return User{}, err
 	}
@@ -219,12 +1007,16 @@ func (svc usersService) ViewUser(ctx context.Context, token, id string) (User, e
 	if err != nil {
 		return User{}, errors.Wrap(ErrUnauthorizedAccess, err)
 	}
+	if !idt.GetAdmin() && dbUser.Email != idt.GetEmail() {
+		return User{}, ErrUnauthorizedAccess
+	}
 
 	return User{
 		ID:       id,
 		Email:    dbUser.Email,
 		Password: "",
 		Metadata: dbUser.Metadata,
+		Status:   dbUser.Status,
 	}, nil
 }
 
@@ -244,16 +1036,38 @@ func (svc usersService) ViewProfile(ctx context.Context, token string) (User, er
 		Email:    email,
 		Password: "",
 		Metadata: dbUser.Metadata,
+		Status:   dbUser.Status,
 	}, nil
 }
 
-func (svc usersService) ListUsers(ctx context.Context, token string, offset, limit uint64, email string, m Metadata) (UserPage, error) {
-	_, err := svc.identify(ctx, token)
+func (svc usersService) ListUsers(ctx context.Context, token string, offset, limit uint64, cursor, email string, m Metadata) (UserPage, error) {
+	id, err := svc.identity(ctx, token)
 	if err != nil {
 		return UserPage{}, err
 	}
+	if id.GetAdmin() {
+		return svc.users.RetrieveAll(ctx, offset, limit, cursor, email, m)
+	}
 
-	return svc.users.RetrieveAll(ctx, offset, limit, email, m)
+	page := UserPage{
+		PageMetadata: PageMetadata{
+			Total:  1,
+			Offset: offset,
+			Limit:  limit,
+		},
+		Users: []User{},
+	}
+	if offset > 0 || cursor != "" || limit == 0 {
+		return page, nil
+	}
+	user, err := svc.users.RetrieveByEmail(ctx, id.GetEmail())
+	if err != nil {
+		return UserPage{}, errors.Wrap(ErrUnauthorizedAccess, err)
+	}
+	user.Password = ""
+	page.Users = append(page.Users, user)
+
+	return page, nil
 }
 
 func (svc usersService) UpdateUser(ctx context.Context, token string, u User) error {
@@ -293,7 +1107,10 @@ func (svc usersService) ResetPassword(ctx context.Context, resetToken, password
 	if err != nil {
 		return err
 	}
-	return svc.users.UpdatePassword(ctx, email, password)
+	if err := svc.users.UpdatePassword(ctx, email, password); err != nil {
+		return err
+	}
+	return svc.revokeSessions(ctx, resetToken)
 }
 
 func (svc usersService) ChangePassword(ctx context.Context, authToken, password, oldPassword string) error {
@@ -301,23 +1118,22 @@ func (svc usersService) ChangePassword(ctx context.Context, authToken, password,
 	if err != nil {
 		return errors.Wrap(ErrUnauthorizedAccess, err)
 	}
-	u := User{
-		Email:    email,
-		Password: oldPassword,
-	}
-	if _, err := svc.Login(ctx, u); err != nil {
-		return ErrUnauthorizedAccess
-	}
-	u, err = svc.users.RetrieveByEmail(ctx, email)
+	u, err := svc.users.RetrieveByEmail(ctx, email)
 	if err != nil || u.Email == "" {
 		return ErrUserNotFound
 	}
+	if err := svc.hasher.Compare(oldPassword, u.Password); err != nil {
+		return ErrUnauthorizedAccess
+	}
 
 	password, err = svc.hasher.Hash(password)
 	if err != nil {
 		return err
 	}
-	return svc.users.UpdatePassword(ctx, email, password)
+	if err := svc.users.UpdatePassword(ctx, email, password); err != nil {
+		return err
+	}
+	return svc.revokeSessions(ctx, authToken)
 }
 
 func (svc usersService) SendPasswordReset(_ context.Context, host, email, token string) error {
@@ -352,62 +1168,298 @@ func (svc usersService) CreateGroup(ctx context.Context, token string, group Gro
 }
 
 func (svc usersService) ListGroups(ctx context.Context, token string, parentID string, offset, limit uint64, m Metadata) (GroupPage, error) {
-	_, err := svc.auth.Identify(ctx, &mainflux.Token{Value: token})
+	id, err := svc.identity(ctx, token)
+	if err != nil {
+		return GroupPage{}, err
+	}
+	if id.GetAdmin() {
+		return svc.groups.RetrieveAllWithAncestors(ctx, parentID, offset, limit, m)
+	}
+
+	if parentID != "" {
+		if _, _, err := svc.authorizeRole(ctx, token, parentID, ViewerRole); err != nil {
+			return GroupPage{}, err
+		}
+		return svc.groups.RetrieveAllWithAncestors(ctx, parentID, offset, limit, m)
+	}
+
+	user, err := svc.users.RetrieveByEmail(ctx, id.GetEmail())
 	if err != nil {
 		return GroupPage{}, errors.Wrap(ErrUnauthorizedAccess, err)
 	}
-	return svc.groups.RetrieveAllWithAncestors(ctx, parentID, offset, limit, m)
+	return svc.groups.RetrieveMemberships(ctx, user.ID, offset, limit, m)
 }
 
 func (svc usersService) ListMembers(ctx context.Context, token, groupID string, offset, limit uint64, m Metadata) (UserPage, error) {
-	if _, err := svc.identify(ctx, token); err != nil {
+	if _, _, err := svc.authorizeRole(ctx, token, groupID, ViewerRole); err != nil {
 		return UserPage{}, err
 	}
 	return svc.users.RetrieveMembers(ctx, groupID, offset, limit, m)
 }
 
 func (svc usersService) RemoveGroup(ctx context.Context, token, id string) error {
-	if _, err := svc.identify(ctx, token); err != nil {
+	if _, _, err := svc.authorizeRole(ctx, token, id, AdminRole); err != nil {
 		return err
 	}
 	return svc.groups.Delete(ctx, id)
 }
 
 func (svc usersService) Unassign(ctx context.Context, token, userID, groupID string) error {
-	if _, err := svc.identify(ctx, token); err != nil {
+	if _, _, err := svc.authorizeRole(ctx, token, groupID, AdminRole); err != nil {
 		return err
 	}
 	return svc.groups.Unassign(ctx, userID, groupID)
 }
 
 func (svc usersService) UpdateGroup(ctx context.Context, token string, group Group) error {
-	if _, err := svc.identify(ctx, token); err != nil {
+	if _, _, err := svc.authorizeRole(ctx, token, group.ID, AdminRole); err != nil {
 		return err
 	}
 	return svc.groups.Update(ctx, group)
 }
 
 func (svc usersService) ViewGroup(ctx context.Context, token, id string) (Group, error) {
-	if _, err := svc.identify(ctx, token); err != nil {
-		return Group{}, err
-	}
-	return svc.groups.RetrieveByID(ctx, id)
+	_, group, err := svc.authorizeRole(ctx, token, id, ViewerRole)
+	return group, err
 }
 
-func (svc usersService) Assign(ctx context.Context, token, userID, groupID string) error {
-	if _, err := svc.identify(ctx, token); err != nil {
+func (svc usersService) Assign(ctx context.Context, token, userID, groupID, role string) error {
+	if role == "" {
+		role = ViewerRole
+	}
+	if !validRole(role) {
+		return ErrMalformedEntity
+	}
+	if _, _, err := svc.authorizeRole(ctx, token, groupID, AdminRole); err != nil {
 		return err
 	}
-	return svc.groups.Assign(ctx, userID, groupID)
+	return svc.groups.Assign(ctx, userID, groupID, role)
 }
 
 func (svc usersService) ListMemberships(ctx context.Context, token, userID string, offset, limit uint64, m Metadata) (GroupPage, error) {
-	if _, err := svc.identify(ctx, token); err != nil {
+	id, err := svc.identity(ctx, token)
+	if err != nil {
 		return GroupPage{}, err
 	}
+	if !id.GetAdmin() {
+		user, err := svc.users.RetrieveByEmail(ctx, id.GetEmail())
+		if err != nil {
+			return GroupPage{}, errors.Wrap(ErrUnauthorizedAccess, err)
+		}
+		if user.ID != userID {
+			return GroupPage{}, ErrUnauthorizedAccess
+		}
+	}
 	return svc.groups.RetrieveMemberships(ctx, userID, offset, limit, m)
 }
 
+func (svc usersService) Invite(ctx context.Context, token, groupID, email, role string) (Invitation, error) {
+	if !isEmail(email) || !validRole(role) {
+		return Invitation{}, ErrMalformedEntity
+	}
+
+	user, group, err := svc.authorizeRole(ctx, token, groupID, AdminRole)
+	if err != nil {
+		return Invitation{}, err
+	}
+
+	id, err := uuidProvider.New().ID()
+	if err != nil {
+		return Invitation{}, err
+	}
+	now := time.Now().UTC()
+	inv := Invitation{
+		ID:        id,
+		GroupID:   group.ID,
+		Email:     email,
+		Role:      role,
+		InvitedBy: user.ID,
+		Created:   now,
+		Expires:   now.Add(svc.invite.Duration),
+	}
+	if _, err := svc.invitations.Save(ctx, inv); err != nil {
+		return Invitation{}, err
+	}
+
+	if err := svc.email.SendInvitation([]string{email}, group.Name, svc.signInvitation(inv)); err != nil {
+		// The invitation is removed, so that the email can be invited
+		// again.
+		if rerr := svc.invitations.Remove(ctx, inv.ID); rerr != nil {
+			err = errors.Wrap(err, rerr)
+		}
+		return Invitation{}, errors.Wrap(ErrSendInvitation, err)
+	}
+
+	return inv, nil
+}
+
+func (svc usersService) ListInvitations(ctx context.Context, token, groupID string, offset, limit uint64) (InvitationPage, error) {
+	if _, _, err := svc.authorizeRole(ctx, token, groupID, AdminRole); err != nil {
+		return InvitationPage{}, err
+	}
+
+	return svc.invitations.RetrieveAll(ctx, groupID, offset, limit)
+}
+
+func (svc usersService) RevokeInvitation(ctx context.Context, token, id string) error {
+	if _, err := svc.identify(ctx, token); err != nil {
+		return err
+	}
+
+	inv, err := svc.invitations.RetrieveByID(ctx, id)
+	if err != nil {
+		return err
+	}
+	if _, _, err := svc.authorizeRole(ctx, token, inv.GroupID, AdminRole); err != nil {
+		return err
+	}
+
+	return svc.invitations.Remove(ctx, id)
+}
+
+func (svc usersService) AcceptInvitation(ctx context.Context, invToken, password string) (string, error) {
+	inv, err := svc.verifyInvitation(ctx, invToken)
+	if err != nil {
+		return "", err
+	}
+
+	user, err := svc.users.RetrieveByEmail(ctx, inv.Email)
+	switch {
+	case errors.Contains(err, ErrNotFound):
+		// The invitation proves the ownership of the email, so the user
+		// is not required to verify it.
+		user = User{Email: inv.Email, Password: password, Status: ActiveStatus}
+		if user.ID, err = svc.register(ctx, user); err != nil {
+			return "", err
+		}
+	case err != nil:
+		return "", err
+	case user.Status == PendingStatus:
+		// The pending account could be registered by anyone who knows the
+		// email, so the password it was registered with is replaced.
+		if err := (User{Email: user.Email, Password: password}).Validate(); err != nil {
+			return "", err
+		}
+		hash, err := svc.hasher.Hash(password)
+		if err != nil {
+			return "", errors.Wrap(ErrMalformedEntity, err)
+		}
+		if err := svc.users.UpdatePassword(ctx, user.Email, hash); err != nil {
+			return "", err
+		}
+		if err := svc.users.UpdateVerification(ctx, user.Email, Verification{}); err != nil {
+			return "", err
+		}
+		if err := svc.users.UpdateStatus(ctx, user.Email, ActiveStatus); err != nil {
+			return "", err
+		}
+	}
+
+	// Users who are already members of the group keep their role.
+	if err := svc.groups.Assign(ctx, user.ID, inv.GroupID, inv.Role); err != nil && !errors.Contains(err, ErrGroupConflict) {
+		return "", errors.Wrap(ErrAssignUserToGroup, err)
+	}
+
+	if err := svc.invitations.Remove(ctx, inv.ID); err != nil {
+		return "", err
+	}
+
+	return user.ID, nil
+}
+
+func (svc usersService) Authorize(ctx context.Context, email, role string) ([]string, error) {
+	if !validRole(role) {
+		return nil, ErrMalformedEntity
+	}
+
+	user, err := svc.users.RetrieveByEmail(ctx, email)
+	if err != nil {
+		return nil, err
+	}
+
+	roles, err := svc.groups.RetrieveRoles(ctx, user.ID)
+	if err != nil {
+		return nil, err
+	}
+
+	groups := []string{}
+	for id, r := range roles {
+		if hasRole(r, role) {
+			groups = append(groups, id)
+		}
+	}
+	sort.Strings(groups)
+
+	return groups, nil
+}
+
+// authorizeRole checks whether the user identified by the token holds the
+// role in the group identified by groupID, and retrieves the user and the
+// group. The owner of the group is its admin.
+func (svc usersService) authorizeRole(ctx context.Context, token, groupID, role string) (User, Group, error) {
+	email, err := svc.identify(ctx, token)
+	if err != nil {
+		return User{}, Group{}, err
+	}
+
+	user, err := svc.users.RetrieveByEmail(ctx, email)
+	if err != nil {
+		return User{}, Group{}, errors.Wrap(ErrUnauthorizedAccess, err)
+	}
+
+	group, err := svc.groups.RetrieveByID(ctx, groupID)
+	if err != nil {
+		return User{}, Group{}, err
+	}
+
+	roles, err := svc.groups.RetrieveRoles(ctx, user.ID)
+	if err != nil {
+		return User{}, Group{}, err
+	}
+	if !hasRole(roles[groupID], role) {
+		return User{}, Group{}, ErrUnauthorizedAccess
+	}
+
+	return user, group, nil
+}
+
+// signInvitation creates the invitation token, consisting of the
+// invitation ID and its signature.
+func (svc usersService) signInvitation(inv Invitation) string {
+	return inv.ID + invitationSeparator + svc.invitationSignature(inv)
+}
+
+// invitationSignature signs the invitation ID together with the invited
+// email, group, role and expiration, so that the token can't be used once
+// any of them changes.
+func (svc usersService) invitationSignature(inv Invitation) string {
+	mac := hmac.New(sha256.New, []byte(svc.invite.Secret))
+	fmt.Fprintf(mac, "%s|%s|%s|%s|%d", inv.ID, inv.GroupID, inv.Email, inv.Role, inv.Expires.Unix())
+	return hex.EncodeToString(mac.Sum(nil))
+}
+
+// verifyInvitation retrieves the pending invitation identified by the
+// signed invitation token.
+func (svc usersService) verifyInvitation(ctx context.Context, token string) (Invitation, error) {
+	parts := strings.Split(token, invitationSeparator)
+	if len(parts) != 2 {
+		return Invitation{}, ErrUnauthorizedAccess
+	}
+
+	inv, err := svc.invitations.RetrieveByID(ctx, parts[0])
+	if err != nil {
+		return Invitation{}, errors.Wrap(ErrUnauthorizedAccess, err)
+	}
+	if !hmac.Equal([]byte(parts[1]), []byte(svc.invitationSignature(inv))) {
+		return Invitation{}, ErrUnauthorizedAccess
+	}
+	if time.Now().After(inv.Expires) {
+		return Invitation{}, ErrUnauthorizedAccess
+	}
+
+	return inv, nil
+}
+
 // Auth helpers
 func (svc usersService) issue(ctx context.Context, id, email string, keyType uint32) (string, error) {
 	key, err := svc.auth.Issue(ctx, &mainflux.IssueReq{Id: id, Email: email, Type: keyType})
@@ -417,10 +1469,28 @@ func (svc usersService) issue(ctx context.Context, id, email string, keyType uin
 	return key.GetValue(), nil
 }
 
+// revokeSessions revokes all of the access tokens of the user identified
+// by the provided token, so the old password can't be used to act on
+// behalf of the user anymore.
+func (svc usersService) revokeSessions(ctx context.Context, token string) error {
+	if _, err := svc.auth.RevokeSessions(ctx, &mainflux.Token{Value: token}); err != nil {
+		return errors.Wrap(ErrRevokeSessions, err)
+	}
+	return nil
+}
+
 func (svc usersService) identify(ctx context.Context, token string) (string, error) {
-	email, err := svc.auth.Identify(ctx, &mainflux.Token{Value: token})
+	id, err := svc.identity(ctx, token)
 	if err != nil {
-		return "", errors.Wrap(ErrUnauthorizedAccess, err)
+		return "", err
+	}
+	return id.GetEmail(), nil
+}
+
+func (svc usersService) identity(ctx context.Context, token string) (*mainflux.UserIdentity, error) {
+	id, err := svc.auth.Identify(ctx, &mainflux.Token{Value: token})
+	if err != nil {
+		return nil, errors.Wrap(ErrUnauthorizedAccess, err)
 	}
-	return email.GetEmail(), nil
+	return id, nil
 }
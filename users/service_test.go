@@ -6,7 +6,9 @@ package users_test
 import (
 	"context"
 	"fmt"
+	"strings"
 	"testing"
+	"time"
 
 	"github.com/mainflux/mainflux"
 	"github.com/mainflux/mainflux/pkg/errors"
@@ -14,6 +16,7 @@ import (
 	"github.com/mainflux/mainflux/users"
 
 	"github.com/mainflux/mainflux/users/mocks"
+	"github.com/mainflux/mainflux/users/totp"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
 )
@@ -22,19 +25,48 @@ const wrong string = "wrong-value"
 
 var (
 	user            = users.User{Email: "user@example.com", Password: "password", Metadata: map[string]interface{}{"role": "user"}}
+	admin           = users.User{Email: "admin@example.com", Password: "password"}
 	nonExistingUser = users.User{Email: "non-ex-user@example.com", Password: "password", Metadata: map[string]interface{}{"role": "user"}}
 	host            = "example.com"
 	groupName       = "Mainflux"
+	oidcCode        = "oidc-code"
+	lockout         = users.LockoutPolicy{MaxAttempts: 3, MaxIPAttempts: 5, Duration: time.Minute}
+	verification    = users.VerificationPolicy{Enabled: true, Duration: time.Hour, ResendInterval: time.Hour}
+	invitation      = users.InvitationPolicy{Secret: "secret", Duration: time.Hour}
+	invitee         = "invitee@example.com"
+	oidcIdentity    = users.ExternalIdentity{
+		Email:    "oidc@example.com",
+		Metadata: users.Metadata{"name": "OIDC User"},
+		Groups:   []string{groupName, "unknown"},
+	}
 )
 
 func newService() users.Service {
+	return newLockoutService(lockout)
+}
+
+func newLockoutService(policy users.LockoutPolicy) users.Service {
+	svc, _ := newPolicyService(policy, users.VerificationPolicy{}, invitation)
+	return svc
+}
+
+func newVerificationService(policy users.VerificationPolicy) (users.Service, *mocks.Emailer) {
+	return newPolicyService(lockout, policy, invitation)
+}
+
+func newInvitationService(policy users.InvitationPolicy) (users.Service, *mocks.Emailer) {
+	return newPolicyService(lockout, users.VerificationPolicy{}, policy)
+}
+
+func newPolicyService(lp users.LockoutPolicy, vp users.VerificationPolicy, ip users.InvitationPolicy) (users.Service, *mocks.Emailer) {
 	userRepo := mocks.NewUserRepository()
 	groupRepo := mocks.NewGroupRepository()
 	hasher := mocks.NewHasher()
-	auth := mocks.NewAuthService(map[string]string{user.Email: user.Email})
+	auth := mocks.NewAdminAuthService(map[string]string{user.Email: user.Email, admin.Email: admin.Email, oidcIdentity.Email: oidcIdentity.Email, invitee: invitee}, admin.Email)
 	e := mocks.NewEmailer()
+	idp := mocks.NewIdentityProvider(map[string]users.ExternalIdentity{oidcCode: oidcIdentity})
 
-	return users.New(userRepo, groupRepo, hasher, auth, e)
+	return users.New(userRepo, groupRepo, mocks.NewInvitationRepository(), mocks.NewImpersonationRepository(), hasher, auth, e, idp, users.NewAttemptRepository(), lp, vp, ip, admin.Email), e
 }
 
 func TestRegister(t *testing.T) {
@@ -110,7 +142,7 @@ func TestLogin(t *testing.T) {
 	}
 
 	for desc, tc := range cases {
-		_, err := svc.Login(context.Background(), tc.user)
+		_, _, err := svc.Login(context.Background(), tc.user, "")
 		assert.True(t, errors.Contains(err, tc.err), fmt.Sprintf("%s: expected %s got %s\n", desc, tc.err, err))
 	}
 }
@@ -120,7 +152,15 @@ func TestViewUser(t *testing.T) {
 	id, err := svc.Register(context.Background(), user)
 	require.Nil(t, err, fmt.Sprintf("unexpected error: %s", err))
 
-	token, err := svc.Login(context.Background(), user)
+	token, _, err := svc.Login(context.Background(), user, "")
+	require.Nil(t, err, fmt.Sprintf("unexpected error: %s", err))
+
+	_, err = svc.Register(context.Background(), admin)
+	require.Nil(t, err, fmt.Sprintf("unexpected error: %s", err))
+	adminToken, _, err := svc.Login(context.Background(), admin, "")
+	require.Nil(t, err, fmt.Sprintf("unexpected error: %s", err))
+	other := users.User{Email: "other@example.com", Password: "password"}
+	otherID, err := svc.Register(context.Background(), other)
 	require.Nil(t, err, fmt.Sprintf("unexpected error: %s", err))
 
 	u := user
@@ -150,6 +190,18 @@ func TestViewUser(t *testing.T) {
 			userID: "",
 			err:    users.ErrUnauthorizedAccess,
 		},
+		"view other user": {
+			user:   users.User{},
+			token:  token,
+			userID: otherID,
+			err:    users.ErrUnauthorizedAccess,
+		},
+		"view other user as admin": {
+			user:   other,
+			token:  adminToken,
+			userID: otherID,
+			err:    nil,
+		},
 	}
 
 	for desc, tc := range cases {
@@ -163,7 +215,7 @@ func TestViewProfile(t *testing.T) {
 	_, err := svc.Register(context.Background(), user)
 	require.Nil(t, err, fmt.Sprintf("unexpected error: %s", err))
 
-	token, err := svc.Login(context.Background(), user)
+	token, _, err := svc.Login(context.Background(), user, "")
 	require.Nil(t, err, fmt.Sprintf("unexpected error: %s", err))
 
 	u := user
@@ -196,13 +248,17 @@ func TestListUsers(t *testing.T) {
 
 	_, err := svc.Register(context.Background(), user)
 	require.Nil(t, err, fmt.Sprintf("unexpected error: %s", err))
+	_, err = svc.Register(context.Background(), admin)
+	require.Nil(t, err, fmt.Sprintf("unexpected error: %s", err))
 
-	token, err := svc.Login(context.Background(), user)
+	userToken, _, err := svc.Login(context.Background(), user, "")
+	require.Nil(t, err, fmt.Sprintf("unexpected error: %s", err))
+	token, _, err := svc.Login(context.Background(), admin, "")
 	require.Nil(t, err, fmt.Sprintf("unexpected error: %s", err))
 
 	var nUsers = uint64(10)
 
-	for i := uint64(1); i < nUsers; i++ {
+	for i := uint64(2); i < nUsers; i++ {
 		email := fmt.Sprintf("TestListUsers%d@example.com", i)
 		user := users.User{
 			Email:    email,
@@ -212,10 +268,14 @@ func TestListUsers(t *testing.T) {
 		require.Nil(t, err, fmt.Sprintf("unexpected error: %s", err))
 	}
 
+	first, err := svc.ListUsers(context.Background(), token, 0, nUsers/2, "", "", nil)
+	require.Nil(t, err, fmt.Sprintf("unexpected error: %s", err))
+
 	cases := map[string]struct {
 		token  string
 		offset uint64
 		limit  uint64
+		cursor string
 		email  string
 		size   uint64
 		err    error
@@ -230,16 +290,36 @@ func TestListUsers(t *testing.T) {
 			size:  0,
 			err:   users.ErrUnauthorizedAccess,
 		},
+		"list users as non-admin": {
+			token: userToken,
+			limit: nUsers,
+			size:  1,
+			err:   nil,
+		},
 		"list users with offset and limit": {
 			token:  token,
 			offset: 6,
 			limit:  nUsers,
 			size:   nUsers - 6,
 		},
+		"list users following the cursor": {
+			token:  token,
+			offset: nUsers,
+			limit:  nUsers,
+			cursor: first.NextCursor,
+			size:   nUsers - nUsers/2,
+		},
+		"list users with invalid cursor": {
+			token:  token,
+			limit:  nUsers,
+			cursor: "invalid",
+			size:   0,
+			err:    users.ErrMalformedEntity,
+		},
 	}
 
 	for desc, tc := range cases {
-		page, err := svc.ListUsers(context.Background(), tc.token, tc.offset, tc.limit, tc.email, nil)
+		page, err := svc.ListUsers(context.Background(), tc.token, tc.offset, tc.limit, tc.cursor, tc.email, nil)
 		size := uint64(len(page.Users))
 		assert.Equal(t, tc.size, size, fmt.Sprintf("%s: expected size %d got %d\n", desc, tc.size, size))
 		assert.True(t, errors.Contains(err, tc.err), fmt.Sprintf("%s: expected %s got %s\n", desc, tc.err, err))
@@ -252,7 +332,7 @@ func TestUpdateUser(t *testing.T) {
 	_, err := svc.Register(context.Background(), user)
 	require.Nil(t, err, fmt.Sprintf("unexpected error: %s", err))
 
-	token, err := svc.Login(context.Background(), user)
+	token, _, err := svc.Login(context.Background(), user, "")
 	require.Nil(t, err, fmt.Sprintf("unexpected error: %s", err))
 
 	user.Metadata = map[string]interface{}{"role": "test"}
@@ -299,197 +379,1780 @@ func TestGenerateResetToken(t *testing.T) {
 	}
 }
 
-func TestChangePassword(t *testing.T) {
+func TestLogout(t *testing.T) {
 	svc := newService()
 	_, err := svc.Register(context.Background(), user)
 	require.Nil(t, err, fmt.Sprintf("register user error: %s", err))
-	token, _ := svc.Login(context.Background(), user)
+	token, refreshToken, err := svc.Login(context.Background(), user, "")
+	require.Nil(t, err, fmt.Sprintf("login error: %s", err))
 
-	cases := map[string]struct {
-		token       string
-		password    string
-		oldPassword string
-		err         error
+	cases := []struct {
+		desc  string
+		token string
+		err   error
 	}{
-		"valid user change password ":                    {token, "newpassword", user.Password, nil},
-		"valid user change password with wrong password": {token, "newpassword", "wrongpassword", users.ErrUnauthorizedAccess},
-		"valid user change password invalid token":       {"", "newpassword", user.Password, users.ErrUnauthorizedAccess},
+		{
+			desc:  "logout with valid token",
+			token: token,
+			err:   nil,
+		},
+		{
+			desc:  "logout with refresh token",
+			token: refreshToken,
+			err:   nil,
+		},
+		{
+			desc:  "logout with revoked token",
+			token: token,
+			err:   users.ErrUnauthorizedAccess,
+		},
+		{
+			desc:  "logout with invalid token",
+			token: wrong,
+			err:   users.ErrUnauthorizedAccess,
+		},
 	}
 
-	for desc, tc := range cases {
-		err := svc.ChangePassword(context.Background(), tc.token, tc.password, tc.oldPassword)
-		assert.True(t, errors.Contains(err, tc.err), fmt.Sprintf("%s: expected %s got %s\n", desc, tc.err, err))
-
+	for _, tc := range cases {
+		err := svc.Logout(context.Background(), tc.token)
+		assert.True(t, errors.Contains(err, tc.err), fmt.Sprintf("%s: expected %s got %s\n", tc.desc, tc.err, err))
 	}
+
+	_, err = svc.ViewProfile(context.Background(), token)
+	assert.True(t, errors.Contains(err, users.ErrUnauthorizedAccess), fmt.Sprintf("view profile with revoked token: expected %s got %s\n", users.ErrUnauthorizedAccess, err))
 }
 
-func TestResetPassword(t *testing.T) {
+func TestRefresh(t *testing.T) {
 	svc := newService()
 	_, err := svc.Register(context.Background(), user)
-	require.Nil(t, err, fmt.Sprintf("unexpected error: %s", err))
-	auth := mocks.NewAuthService(map[string]string{user.Email: user.Email})
-	resetToken, err := auth.Issue(context.Background(), &mainflux.IssueReq{Id: user.ID, Email: user.Email, Type: 2})
-	assert.Nil(t, err, fmt.Sprintf("Generating reset token expected to succeed: %s", err))
-	cases := map[string]struct {
-		token    string
-		password string
-		err      error
+	require.Nil(t, err, fmt.Sprintf("register user error: %s", err))
+	_, refreshToken, err := svc.Login(context.Background(), user, "")
+	require.Nil(t, err, fmt.Sprintf("login error: %s", err))
+	require.NotEmpty(t, refreshToken, "login expected to return refresh token")
+
+	token, newRefreshToken, err := svc.Refresh(context.Background(), refreshToken)
+	require.Nil(t, err, fmt.Sprintf("refresh error: %s", err))
+	assert.NotEqual(t, refreshToken, newRefreshToken, "refresh expected to rotate refresh token")
+	_, err = svc.ViewProfile(context.Background(), token)
+	assert.Nil(t, err, fmt.Sprintf("view profile with refreshed token: unexpected error %s", err))
+
+	cases := []struct {
+		desc  string
+		token string
+		err   error
 	}{
-		"valid user reset password ":   {resetToken.GetValue(), user.Email, nil},
-		"invalid user reset password ": {"", "newpassword", users.ErrUnauthorizedAccess},
+		{
+			desc:  "refresh with used refresh token",
+			token: refreshToken,
+			err:   users.ErrUnauthorizedAccess,
+		},
+		{
+			desc:  "refresh with refresh token revoked due to reuse",
+			token: newRefreshToken,
+			err:   users.ErrUnauthorizedAccess,
+		},
+		{
+			desc:  "refresh with access token",
+			token: token,
+			err:   users.ErrUnauthorizedAccess,
+		},
+		{
+			desc:  "refresh with invalid token",
+			token: wrong,
+			err:   users.ErrUnauthorizedAccess,
+		},
 	}
 
-	for desc, tc := range cases {
-		err := svc.ResetPassword(context.Background(), tc.token, tc.password)
-		assert.True(t, errors.Contains(err, tc.err), fmt.Sprintf("%s: expected %s got %s\n", desc, tc.err, err))
+	for _, tc := range cases {
+		_, _, err := svc.Refresh(context.Background(), tc.token)
+		assert.True(t, errors.Contains(err, tc.err), fmt.Sprintf("%s: expected %s got %s\n", tc.desc, tc.err, err))
 	}
 }
 
-func TestSendPasswordReset(t *testing.T) {
+func TestOIDCLogin(t *testing.T) {
 	svc := newService()
 	_, err := svc.Register(context.Background(), user)
 	require.Nil(t, err, fmt.Sprintf("register user error: %s", err))
-	token, _ := svc.Login(context.Background(), user)
+	token, _, err := svc.Login(context.Background(), user, "")
+	require.Nil(t, err, fmt.Sprintf("login error: %s", err))
+	group, err := svc.CreateGroup(context.Background(), token, users.Group{Name: groupName})
+	require.Nil(t, err, fmt.Sprintf("create group error: %s", err))
 
-	cases := map[string]struct {
-		token string
-		email string
-		err   error
+	url, err := svc.OIDCAuthURL(context.Background(), "state")
+	assert.Nil(t, err, fmt.Sprintf("retrieving auth URL: unexpected error %s", err))
+	assert.Contains(t, url, "state=state", "auth URL expected to contain state")
+
+	cases := []struct {
+		desc string
+		code string
+		err  error
 	}{
-		"valid user reset password ": {token, user.Email, nil},
+		{
+			desc: "login new user using OIDC",
+			code: oidcCode,
+			err:  nil,
+		},
+		{
+			desc: "login existing user using OIDC",
+			code: oidcCode,
+			err:  nil,
+		},
+		{
+			desc: "login using invalid code",
+			code: wrong,
+			err:  users.ErrUnauthorizedAccess,
+		},
 	}
 
-	for desc, tc := range cases {
-		err := svc.SendPasswordReset(context.Background(), host, tc.email, tc.token)
-		assert.True(t, errors.Contains(err, tc.err), fmt.Sprintf("%s: expected %s got %s\n", desc, tc.err, err))
-
+	for _, tc := range cases {
+		access, refresh, err := svc.OIDCLogin(context.Background(), tc.code, "state")
+		assert.True(t, errors.Contains(err, tc.err), fmt.Sprintf("%s: expected %s got %s\n", tc.desc, tc.err, err))
+		if tc.err == nil {
+			assert.NotEmpty(t, access, fmt.Sprintf("%s: expected access token", tc.desc))
+			assert.NotEmpty(t, refresh, fmt.Sprintf("%s: expected refresh token", tc.desc))
+		}
 	}
+
+	oidcToken, _, err := svc.OIDCLogin(context.Background(), oidcCode, "state")
+	require.Nil(t, err, fmt.Sprintf("OIDC login error: %s", err))
+	u, err := svc.ViewProfile(context.Background(), oidcToken)
+	require.Nil(t, err, fmt.Sprintf("view profile error: %s", err))
+	assert.Equal(t, oidcIdentity.Metadata, u.Metadata, fmt.Sprintf("provisioned user metadata: expected %v got %v", oidcIdentity.Metadata, u.Metadata))
+
+	page, err := svc.ListMemberships(context.Background(), oidcToken, u.ID, 0, 10, nil)
+	require.Nil(t, err, fmt.Sprintf("list memberships error: %s", err))
+	require.Len(t, page.Groups, 1, "provisioned user expected to be assigned to a single existing group")
+	assert.Equal(t, group.ID, page.Groups[0].ID, fmt.Sprintf("provisioned user group: expected %s got %s", group.ID, page.Groups[0].ID))
+
+	_, _, err = svc.Login(context.Background(), users.User{Email: oidcIdentity.Email, Password: "password"}, "")
+	assert.True(t, errors.Contains(err, users.ErrUnauthorizedAccess), fmt.Sprintf("password login of provisioned user: expected %s got %s", users.ErrUnauthorizedAccess, err))
 }
 
-func TestCreateGroup(t *testing.T) {
+func TestOIDCNotConfigured(t *testing.T) {
+	auth := mocks.NewAuthService(map[string]string{user.Email: user.Email})
+	svc := users.New(mocks.NewUserRepository(), mocks.NewGroupRepository(), mocks.NewInvitationRepository(), mocks.NewImpersonationRepository(), mocks.NewHasher(), auth, mocks.NewEmailer(), nil, users.NewAttemptRepository(), lockout, users.VerificationPolicy{}, invitation, admin.Email)
+
+	_, err := svc.OIDCAuthURL(context.Background(), "state")
+	assert.True(t, errors.Contains(err, users.ErrOIDCNotConfigured), fmt.Sprintf("retrieving auth URL: expected %s got %s", users.ErrOIDCNotConfigured, err))
+	_, _, err = svc.OIDCLogin(context.Background(), oidcCode, "state")
+	assert.True(t, errors.Contains(err, users.ErrOIDCNotConfigured), fmt.Sprintf("OIDC login: expected %s got %s", users.ErrOIDCNotConfigured, err))
+}
+
+// enableTOTP enables two-factor authentication for the user identified by
+// the token and returns the TOTP secret and recovery codes.
+func enableTOTP(t *testing.T, svc users.Service, token string) (string, []string) {
+	e, err := svc.EnrollTOTP(context.Background(), token)
+	require.Nil(t, err, fmt.Sprintf("enrolling TOTP expected to succeed: %s", err))
+	code, err := totp.Generate(e.Secret, time.Now())
+	require.Nil(t, err, fmt.Sprintf("generating TOTP code expected to succeed: %s", err))
+	codes, err := svc.ConfirmTOTP(context.Background(), token, code)
+	require.Nil(t, err, fmt.Sprintf("confirming TOTP expected to succeed: %s", err))
+
+	return e.Secret, codes
+}
+
+func TestEnrollTOTP(t *testing.T) {
 	svc := newService()
 	_, err := svc.Register(context.Background(), user)
-	assert.Nil(t, err, fmt.Sprintf("registering user expected to succeed: %s", err))
+	require.Nil(t, err, fmt.Sprintf("unexpected error: %s", err))
 
-	token, err := svc.Login(context.Background(), user)
-	assert.Nil(t, err, fmt.Sprintf("authenticating user expected to succeed: %s", err))
+	e, err := svc.EnrollTOTP(context.Background(), user.Email)
+	require.Nil(t, err, fmt.Sprintf("enrolling TOTP expected to succeed: %s", err))
+	assert.NotEmpty(t, e.Secret, "enrolling TOTP expected to generate secret")
+	assert.True(t, strings.HasPrefix(e.URI, "otpauth://totp/"), fmt.Sprintf("enrolling TOTP expected to return otpauth URI got %s", e.URI))
+	assert.Contains(t, e.URI, e.Secret, "otpauth URI expected to contain the secret")
 
-	uuid, err := uuidProvider.New().ID()
-	assert.Nil(t, err, fmt.Sprintf("generating uuid expected to succeed: %s", err))
+	// Login is not affected until the enrollment is confirmed.
+	_, refresh, err := svc.Login(context.Background(), user, "")
+	assert.Nil(t, err, fmt.Sprintf("login expected to succeed: %s", err))
+	assert.NotEmpty(t, refresh, "login before confirming TOTP expected to issue refresh token")
 
-	group := users.Group{
-		ID:   uuid,
-		Name: groupName,
-	}
+	_, err = svc.EnrollTOTP(context.Background(), wrong)
+	assert.True(t, errors.Contains(err, users.ErrUnauthorizedAccess), fmt.Sprintf("enrolling TOTP with invalid token: expected %s got %s", users.ErrUnauthorizedAccess, err))
+
+	enableTOTP(t, svc, user.Email)
+	_, err = svc.EnrollTOTP(context.Background(), user.Email)
+	assert.True(t, errors.Contains(err, users.ErrTOTPEnabled), fmt.Sprintf("enrolling enabled TOTP: expected %s got %s", users.ErrTOTPEnabled, err))
+}
+
+func TestConfirmTOTP(t *testing.T) {
+	svc := newService()
+	_, err := svc.Register(context.Background(), user)
+	require.Nil(t, err, fmt.Sprintf("unexpected error: %s", err))
+	_, err = svc.Register(context.Background(), admin)
+	require.Nil(t, err, fmt.Sprintf("unexpected error: %s", err))
+
+	e, err := svc.EnrollTOTP(context.Background(), user.Email)
+	require.Nil(t, err, fmt.Sprintf("enrolling TOTP expected to succeed: %s", err))
+	code, err := totp.Generate(e.Secret, time.Now())
+	require.Nil(t, err, fmt.Sprintf("generating TOTP code expected to succeed: %s", err))
+	expired, err := totp.Generate(e.Secret, time.Now().Add(-5*totp.Period))
+	require.Nil(t, err, fmt.Sprintf("generating TOTP code expected to succeed: %s", err))
 
 	cases := []struct {
 		desc  string
-		group users.Group
+		token string
+		code  string
 		err   error
 	}{
 		{
-			desc:  "create new group",
-			group: group,
+			desc:  "confirm TOTP with invalid token",
+			token: wrong,
+			code:  code,
+			err:   users.ErrUnauthorizedAccess,
+		},
+		{
+			desc:  "confirm TOTP without enrollment",
+			token: admin.Email,
+			code:  code,
+			err:   users.ErrTOTPNotEnrolled,
+		},
+		{
+			desc:  "confirm TOTP with expired code",
+			token: user.Email,
+			code:  expired,
+			err:   users.ErrUnauthorizedAccess,
+		},
+		{
+			desc:  "confirm TOTP with invalid code",
+			token: user.Email,
+			code:  wrong,
+			err:   users.ErrUnauthorizedAccess,
+		},
+		{
+			desc:  "confirm TOTP",
+			token: user.Email,
+			code:  code,
 			err:   nil,
 		},
 		{
-			desc:  "create group with existing name",
-			group: group,
-			err:   users.ErrGroupConflict,
+			desc:  "confirm already enabled TOTP",
+			token: user.Email,
+			code:  code,
+			err:   users.ErrTOTPEnabled,
 		},
 	}
 
 	for _, tc := range cases {
-		_, err := svc.CreateGroup(context.Background(), token, tc.group)
+		codes, err := svc.ConfirmTOTP(context.Background(), tc.token, tc.code)
 		assert.True(t, errors.Contains(err, tc.err), fmt.Sprintf("%s: expected %s got %s\n", tc.desc, tc.err, err))
+		if err == nil {
+			assert.Len(t, codes, 10, fmt.Sprintf("%s: expected 10 recovery codes got %d\n", tc.desc, len(codes)))
+		}
 	}
 }
 
-func TestUpdateGroup(t *testing.T) {
+func TestLoginTOTP(t *testing.T) {
 	svc := newService()
-
 	_, err := svc.Register(context.Background(), user)
-	assert.Nil(t, err, fmt.Sprintf("registering user expected to succeed: %s", err))
-
-	token, err := svc.Login(context.Background(), user)
-	assert.Nil(t, err, fmt.Sprintf("authenticating user expected to succeed: %s", err))
-
-	group := users.Group{
-		Name: groupName,
+	require.Nil(t, err, fmt.Sprintf("unexpected error: %s", err))
+	secret, recoveryCodes := enableTOTP(t, svc, user.Email)
+	code, err := totp.Generate(secret, time.Now())
+	require.Nil(t, err, fmt.Sprintf("generating TOTP code expected to succeed: %s", err))
+
+	login := func() string {
+		challenge, refresh, err := svc.Login(context.Background(), user, "")
+		require.Nil(t, err, fmt.Sprintf("login expected to succeed: %s", err))
+		require.Empty(t, refresh, "login with TOTP enabled expected to issue only challenge token")
+		require.NotEmpty(t, challenge, "login with TOTP enabled expected to issue challenge token")
+		return challenge
 	}
-
-	saved, err := svc.CreateGroup(context.Background(), token, group)
-	assert.Nil(t, err, fmt.Sprintf("generating uuid expected to succeed: %s", err))
-
-	group.Description = "test description"
-	group.Name = "NewName"
-	group.ID = saved.ID
-	group.OwnerID = saved.OwnerID
+	used := login()
+	_, _, err = svc.LoginTOTP(context.Background(), used, code)
+	require.Nil(t, err, fmt.Sprintf("login with TOTP code expected to succeed: %s", err))
+	failed := login()
+	_, _, err = svc.LoginTOTP(context.Background(), failed, wrong)
+	require.True(t, errors.Contains(err, users.ErrUnauthorizedAccess), fmt.Sprintf("login with invalid code: expected %s got %s", users.ErrUnauthorizedAccess, err))
 
 	cases := []struct {
-		desc  string
-		group users.Group
-		err   error
+		desc      string
+		challenge string
+		code      string
+		err       error
 	}{
 		{
-			desc:  "update group",
-			group: group,
-			err:   nil,
+			desc:      "login with TOTP code",
+			challenge: login(),
+			code:      code,
+			err:       nil,
+		},
+		{
+			desc:      "login with recovery code",
+			challenge: login(),
+			code:      recoveryCodes[0],
+			err:       nil,
+		},
+		{
+			desc:      "login with used recovery code",
+			challenge: login(),
+			code:      recoveryCodes[0],
+			err:       users.ErrUnauthorizedAccess,
+		},
+		{
+			desc:      "login with invalid code",
+			challenge: login(),
+			code:      "000000",
+			err:       users.ErrUnauthorizedAccess,
+		},
+		{
+			desc:      "login with used challenge token",
+			challenge: used,
+			code:      code,
+			err:       users.ErrUnauthorizedAccess,
+		},
+		{
+			desc:      "login with challenge token of failed login",
+			challenge: failed,
+			code:      code,
+			err:       users.ErrUnauthorizedAccess,
+		},
+		{
+			desc:      "login with access token",
+			challenge: user.Email,
+			code:      code,
+			err:       users.ErrUnauthorizedAccess,
 		},
 	}
 
 	for _, tc := range cases {
-		err := svc.UpdateGroup(context.Background(), token, tc.group)
+		access, refresh, err := svc.LoginTOTP(context.Background(), tc.challenge, tc.code)
 		assert.True(t, errors.Contains(err, tc.err), fmt.Sprintf("%s: expected %s got %s\n", tc.desc, tc.err, err))
-		g, err := svc.ViewGroup(context.Background(), token, saved.ID)
-		assert.Nil(t, err, fmt.Sprintf("retrieve group failed: %s", err))
-		assert.Equal(t, tc.group.Description, g.Description, tc.desc, tc.err)
-		assert.Equal(t, tc.group.Name, g.Name, tc.desc, tc.err)
-		assert.Equal(t, tc.group.ID, g.ID, tc.desc, tc.err)
-		assert.Equal(t, tc.group.OwnerID, g.OwnerID, tc.desc, tc.err)
+		if err == nil {
+			assert.NotEmpty(t, access, fmt.Sprintf("%s: expected access token\n", tc.desc))
+			assert.NotEmpty(t, refresh, fmt.Sprintf("%s: expected refresh token\n", tc.desc))
+		}
 	}
 }
 
-func TestRemoveGroup(t *testing.T) {
+func TestDisableTOTP(t *testing.T) {
 	svc := newService()
-
 	_, err := svc.Register(context.Background(), user)
-	assert.Nil(t, err, fmt.Sprintf("registering user expected to succeed: %s", err))
+	require.Nil(t, err, fmt.Sprintf("unexpected error: %s", err))
+	_, err = svc.Register(context.Background(), admin)
+	require.Nil(t, err, fmt.Sprintf("unexpected error: %s", err))
+	secret, recoveryCodes := enableTOTP(t, svc, user.Email)
+	code, err := totp.Generate(secret, time.Now())
+	require.Nil(t, err, fmt.Sprintf("generating TOTP code expected to succeed: %s", err))
 
-	token, err := svc.Login(context.Background(), user)
-	assert.Nil(t, err, fmt.Sprintf("authenticating user expected to succeed: %s", err))
+	cases := []struct {
+		desc  string
+		token string
+		code  string
+		err   error
+	}{
+		{
+			desc:  "disable TOTP with invalid token",
+			token: wrong,
+			code:  code,
+			err:   users.ErrUnauthorizedAccess,
+		},
+		{
+			desc:  "disable TOTP with invalid code",
+			token: user.Email,
+			code:  wrong,
+			err:   users.ErrUnauthorizedAccess,
+		},
+		{
+			desc:  "disable TOTP with recovery code",
+			token: user.Email,
+			code:  recoveryCodes[0],
+			err:   nil,
+		},
+		{
+			desc:  "disable disabled TOTP",
+			token: user.Email,
+			code:  code,
+			err:   users.ErrTOTPNotEnrolled,
+		},
+		{
+			desc:  "disable TOTP of user without TOTP",
+			token: admin.Email,
+			code:  code,
+			err:   users.ErrTOTPNotEnrolled,
+		},
+	}
 
-	group := users.Group{
-		Name: groupName,
+	for _, tc := range cases {
+		err := svc.DisableTOTP(context.Background(), tc.token, tc.code)
+		assert.True(t, errors.Contains(err, tc.err), fmt.Sprintf("%s: expected %s got %s\n", tc.desc, tc.err, err))
 	}
 
-	saved, err := svc.CreateGroup(context.Background(), token, group)
-	assert.Nil(t, err, fmt.Sprintf("generating uuid expected to succeed: %s", err))
+	_, refresh, err := svc.Login(context.Background(), user, "")
+	assert.Nil(t, err, fmt.Sprintf("login expected to succeed: %s", err))
+	assert.NotEmpty(t, refresh, "login after disabling TOTP expected to issue refresh token")
+}
 
-	group.Description = "test description"
-	group.Name = "NewName"
-	group.ID = saved.ID
-	group.OwnerID = saved.OwnerID
+func TestResetTOTP(t *testing.T) {
+	svc := newService()
+	uid, err := svc.Register(context.Background(), user)
+	require.Nil(t, err, fmt.Sprintf("unexpected error: %s", err))
+	_, err = svc.Register(context.Background(), admin)
+	require.Nil(t, err, fmt.Sprintf("unexpected error: %s", err))
+	enableTOTP(t, svc, user.Email)
 
 	cases := []struct {
 		desc  string
-		group users.Group
+		token string
+		id    string
 		err   error
 	}{
 		{
-			desc:  "remove existing group",
-			group: group,
-			err:   nil,
+			desc:  "reset TOTP with invalid token",
+			token: wrong,
+			id:    uid,
+			err:   users.ErrUnauthorizedAccess,
+		},
+		{
+			desc:  "reset TOTP as non-admin user",
+			token: user.Email,
+			id:    uid,
+			err:   users.ErrUnauthorizedAccess,
+		},
+		{
+			desc:  "reset TOTP of non-existing user",
+			token: admin.Email,
+			id:    wrong,
+			err:   users.ErrNotFound,
+		},
+		{
+			desc:  "reset TOTP as admin",
+			token: admin.Email,
+			id:    uid,
+			err:   nil,
+		},
+	}
+
+	for _, tc := range cases {
+		err := svc.ResetTOTP(context.Background(), tc.token, tc.id)
+		assert.True(t, errors.Contains(err, tc.err), fmt.Sprintf("%s: expected %s got %s\n", tc.desc, tc.err, err))
+	}
+
+	_, refresh, err := svc.Login(context.Background(), user, "")
+	assert.Nil(t, err, fmt.Sprintf("login expected to succeed: %s", err))
+	assert.NotEmpty(t, refresh, "login after resetting TOTP expected to issue refresh token")
+}
+
+func TestLoginLockout(t *testing.T) {
+	svc := newService()
+	_, err := svc.Register(context.Background(), user)
+	require.Nil(t, err, fmt.Sprintf("unexpected error: %s", err))
+
+	wrongPass := users.User{Email: user.Email, Password: wrong}
+	otherUser := users.User{Email: nonExistingUser.Email, Password: wrong}
+
+	cases := []struct {
+		desc string
+		user users.User
+		ip   string
+		err  error
+	}{
+		{
+			desc: "login with wrong password",
+			user: wrongPass,
+			ip:   "10.0.0.1",
+			err:  users.ErrUnauthorizedAccess,
+		},
+		{
+			desc: "login with wrong password again",
+			user: wrongPass,
+			ip:   "10.0.0.1",
+			err:  users.ErrUnauthorizedAccess,
+		},
+		{
+			desc: "login with valid credentials resets failed attempts",
+			user: user,
+			ip:   "10.0.0.1",
+			err:  nil,
+		},
+		{
+			desc: "login with wrong password after successful login",
+			user: wrongPass,
+			ip:   "10.0.0.2",
+			err:  users.ErrUnauthorizedAccess,
+		},
+		{
+			desc: "login with wrong password from another address",
+			user: wrongPass,
+			ip:   "10.0.0.3",
+			err:  users.ErrUnauthorizedAccess,
+		},
+		{
+			desc: "login with wrong password locking out the account",
+			user: wrongPass,
+			ip:   "10.0.0.4",
+			err:  users.ErrLockout,
+		},
+		{
+			desc: "login to locked out account with valid credentials",
+			user: user,
+			ip:   "10.0.0.5",
+			err:  users.ErrTooManyAttempts,
+		},
+		{
+			desc: "login to another account from the same address",
+			user: otherUser,
+			ip:   "10.0.0.1",
+			err:  users.ErrUnauthorizedAccess,
+		},
+		{
+			desc: "login to yet another account from the same address",
+			user: users.User{Email: "other@example.com", Password: wrong},
+			ip:   "10.0.0.1",
+			err:  users.ErrUnauthorizedAccess,
+		},
+		{
+			desc: "login to another account locking out the address",
+			user: otherUser,
+			ip:   "10.0.0.1",
+			err:  users.ErrLockout,
+		},
+		{
+			desc: "login from locked out address",
+			user: users.User{Email: "another@example.com", Password: wrong},
+			ip:   "10.0.0.1",
+			err:  users.ErrTooManyAttempts,
+		},
+		{
+			desc: "login from unknown address",
+			user: otherUser,
+			ip:   "",
+			err:  users.ErrUnauthorizedAccess,
+		},
+	}
+
+	for _, tc := range cases {
+		_, _, err := svc.Login(context.Background(), tc.user, tc.ip)
+		assert.True(t, errors.Contains(err, tc.err), fmt.Sprintf("%s: expected %s got %s\n", tc.desc, tc.err, err))
+	}
+}
+
+func TestLoginDelay(t *testing.T) {
+	svc := newLockoutService(users.LockoutPolicy{MaxAttempts: 3, MaxIPAttempts: 5, Delay: time.Minute, Duration: time.Hour})
+	_, err := svc.Register(context.Background(), user)
+	require.Nil(t, err, fmt.Sprintf("unexpected error: %s", err))
+
+	_, _, err = svc.Login(context.Background(), users.User{Email: user.Email, Password: wrong}, "10.0.0.1")
+	assert.True(t, errors.Contains(err, users.ErrUnauthorizedAccess), fmt.Sprintf("login with wrong password: expected %s got %s\n", users.ErrUnauthorizedAccess, err))
+
+	_, _, err = svc.Login(context.Background(), user, "10.0.0.2")
+	assert.True(t, errors.Contains(err, users.ErrTooManyAttempts), fmt.Sprintf("login before the delay passes: expected %s got %s\n", users.ErrTooManyAttempts, err))
+}
+
+func TestUnlock(t *testing.T) {
+	svc := newService()
+	uid, err := svc.Register(context.Background(), user)
+	require.Nil(t, err, fmt.Sprintf("unexpected error: %s", err))
+	_, err = svc.Register(context.Background(), admin)
+	require.Nil(t, err, fmt.Sprintf("unexpected error: %s", err))
+	for i := uint64(0); i < lockout.MaxAttempts; i++ {
+		svc.Login(context.Background(), users.User{Email: user.Email, Password: wrong}, "")
+	}
+	_, _, err = svc.Login(context.Background(), user, "")
+	require.True(t, errors.Contains(err, users.ErrTooManyAttempts), fmt.Sprintf("login to locked out account: expected %s got %s\n", users.ErrTooManyAttempts, err))
+
+	cases := []struct {
+		desc  string
+		token string
+		id    string
+		err   error
+	}{
+		{
+			desc:  "unlock with invalid token",
+			token: wrong,
+			id:    uid,
+			err:   users.ErrUnauthorizedAccess,
+		},
+		{
+			desc:  "unlock as non-admin user",
+			token: user.Email,
+			id:    uid,
+			err:   users.ErrUnauthorizedAccess,
+		},
+		{
+			desc:  "unlock non-existing user",
+			token: admin.Email,
+			id:    wrong,
+			err:   users.ErrNotFound,
+		},
+		{
+			desc:  "unlock as admin",
+			token: admin.Email,
+			id:    uid,
+			err:   nil,
+		},
+	}
+
+	for _, tc := range cases {
+		err := svc.Unlock(context.Background(), tc.token, tc.id)
+		assert.True(t, errors.Contains(err, tc.err), fmt.Sprintf("%s: expected %s got %s\n", tc.desc, tc.err, err))
+	}
+
+	_, _, err = svc.Login(context.Background(), user, "")
+	assert.Nil(t, err, fmt.Sprintf("login after unlocking expected to succeed: %s", err))
+}
+
+func TestDisableUser(t *testing.T) {
+	svc := newService()
+	uid, err := svc.Register(context.Background(), user)
+	require.Nil(t, err, fmt.Sprintf("unexpected error: %s", err))
+	adminID, err := svc.Register(context.Background(), admin)
+	require.Nil(t, err, fmt.Sprintf("unexpected error: %s", err))
+
+	cases := []struct {
+		desc  string
+		token string
+		id    string
+		err   error
+	}{
+		{
+			desc:  "disable user with invalid token",
+			token: wrong,
+			id:    uid,
+			err:   users.ErrUnauthorizedAccess,
+		},
+		{
+			desc:  "disable user as non-admin user",
+			token: user.Email,
+			id:    adminID,
+			err:   users.ErrUnauthorizedAccess,
+		},
+		{
+			desc:  "disable non-existing user",
+			token: admin.Email,
+			id:    wrong,
+			err:   users.ErrNotFound,
+		},
+		{
+			desc:  "disable user as admin",
+			token: admin.Email,
+			id:    uid,
+			err:   nil,
+		},
+	}
+
+	for _, tc := range cases {
+		err := svc.DisableUser(context.Background(), tc.token, tc.id)
+		assert.True(t, errors.Contains(err, tc.err), fmt.Sprintf("%s: expected %s got %s\n", tc.desc, tc.err, err))
+	}
+
+	_, _, err = svc.Login(context.Background(), user, "")
+	assert.True(t, errors.Contains(err, users.ErrDisabled), fmt.Sprintf("login as disabled user: expected %s got %s\n", users.ErrDisabled, err))
+	_, err = svc.Impersonate(context.Background(), admin.Email, uid, "support")
+	assert.True(t, errors.Contains(err, users.ErrDisabled), fmt.Sprintf("impersonate disabled user: expected %s got %s\n", users.ErrDisabled, err))
+
+	err = svc.EnableUser(context.Background(), user.Email, uid)
+	assert.True(t, errors.Contains(err, users.ErrUnauthorizedAccess), fmt.Sprintf("enable user as non-admin user: expected %s got %s\n", users.ErrUnauthorizedAccess, err))
+	err = svc.EnableUser(context.Background(), admin.Email, uid)
+	assert.Nil(t, err, fmt.Sprintf("enable user as admin: unexpected error: %s", err))
+
+	_, _, err = svc.Login(context.Background(), user, "")
+	assert.Nil(t, err, fmt.Sprintf("login after enabling expected to succeed: %s", err))
+}
+
+func TestImpersonate(t *testing.T) {
+	svc := newService()
+	uid, err := svc.Register(context.Background(), user)
+	require.Nil(t, err, fmt.Sprintf("unexpected error: %s", err))
+	_, err = svc.Register(context.Background(), admin)
+	require.Nil(t, err, fmt.Sprintf("unexpected error: %s", err))
+
+	cases := []struct {
+		desc   string
+		token  string
+		id     string
+		reason string
+		err    error
+	}{
+		{
+			desc:   "impersonate user without reason",
+			token:  admin.Email,
+			id:     uid,
+			reason: " ",
+			err:    users.ErrMalformedEntity,
+		},
+		{
+			desc:   "impersonate user with invalid token",
+			token:  wrong,
+			id:     uid,
+			reason: "support",
+			err:    users.ErrUnauthorizedAccess,
+		},
+		{
+			desc:   "impersonate user as non-admin user",
+			token:  user.Email,
+			id:     uid,
+			reason: "support",
+			err:    users.ErrUnauthorizedAccess,
+		},
+		{
+			desc:   "impersonate non-existing user",
+			token:  admin.Email,
+			id:     wrong,
+			reason: "support",
+			err:    users.ErrNotFound,
+		},
+		{
+			desc:   "impersonate user as admin",
+			token:  admin.Email,
+			id:     uid,
+			reason: "support",
+			err:    nil,
+		},
+	}
+
+	for _, tc := range cases {
+		token, err := svc.Impersonate(context.Background(), tc.token, tc.id, tc.reason)
+		assert.True(t, errors.Contains(err, tc.err), fmt.Sprintf("%s: expected %s got %s\n", tc.desc, tc.err, err))
+		if tc.err != nil {
+			continue
+		}
+		u, err := svc.ViewProfile(context.Background(), token)
+		assert.Nil(t, err, fmt.Sprintf("%s: unexpected error: %s", tc.desc, err))
+		assert.Equal(t, user.Email, u.Email, fmt.Sprintf("%s: expected email %s got %s\n", tc.desc, user.Email, u.Email))
+		_, err = svc.Impersonate(context.Background(), token, uid, tc.reason)
+		assert.True(t, errors.Contains(err, users.ErrUnauthorizedAccess), fmt.Sprintf("%s: impersonate using impersonation token: expected %s got %s\n", tc.desc, users.ErrUnauthorizedAccess, err))
+	}
+}
+
+func TestListImpersonations(t *testing.T) {
+	svc := newService()
+	uid, err := svc.Register(context.Background(), user)
+	require.Nil(t, err, fmt.Sprintf("unexpected error: %s", err))
+	_, err = svc.Register(context.Background(), admin)
+	require.Nil(t, err, fmt.Sprintf("unexpected error: %s", err))
+	otherID, err := svc.Register(context.Background(), users.User{Email: invitee, Password: "password"})
+	require.Nil(t, err, fmt.Sprintf("unexpected error: %s", err))
+
+	n := uint64(5)
+	for i := uint64(0); i < n; i++ {
+		_, err := svc.Impersonate(context.Background(), admin.Email, uid, fmt.Sprintf("support %d", i))
+		require.Nil(t, err, fmt.Sprintf("unexpected error: %s", err))
+	}
+	_, err = svc.Impersonate(context.Background(), admin.Email, otherID, "support")
+	require.Nil(t, err, fmt.Sprintf("unexpected error: %s", err))
+
+	cases := []struct {
+		desc   string
+		token  string
+		userID string
+		offset uint64
+		limit  uint64
+		size   uint64
+		total  uint64
+		err    error
+	}{
+		{
+			desc:  "list impersonations as non-admin user",
+			token: user.Email,
+			limit: n,
+			err:   users.ErrUnauthorizedAccess,
+		},
+		{
+			desc:  "list all impersonations",
+			token: admin.Email,
+			limit: 2 * n,
+			size:  n + 1,
+			total: n + 1,
+		},
+		{
+			desc:   "list impersonations of the user",
+			token:  admin.Email,
+			userID: uid,
+			offset: 1,
+			limit:  n,
+			size:   n - 1,
+			total:  n,
+		},
+	}
+
+	for _, tc := range cases {
+		page, err := svc.ListImpersonations(context.Background(), tc.token, tc.userID, tc.offset, tc.limit)
+		assert.True(t, errors.Contains(err, tc.err), fmt.Sprintf("%s: expected %s got %s\n", tc.desc, tc.err, err))
+		size := uint64(len(page.Impersonations))
+		assert.Equal(t, tc.size, size, fmt.Sprintf("%s: expected size %d got %d\n", tc.desc, tc.size, size))
+		assert.Equal(t, tc.total, page.Total, fmt.Sprintf("%s: expected total %d got %d\n", tc.desc, tc.total, page.Total))
+	}
+}
+
+func TestRegisterVerification(t *testing.T) {
+	svc, e := newVerificationService(verification)
+
+	cases := []struct {
+		desc   string
+		user   users.User
+		status string
+		sent   bool
+	}{
+		{
+			desc:   "register user with verification enabled",
+			user:   user,
+			status: users.PendingStatus,
+			sent:   true,
+		},
+		{
+			desc:   "register admin with verification enabled",
+			user:   admin,
+			status: users.ActiveStatus,
+			sent:   false,
+		},
+	}
+
+	for _, tc := range cases {
+		_, err := svc.Register(context.Background(), tc.user)
+		require.Nil(t, err, fmt.Sprintf("%s: unexpected error: %s", tc.desc, err))
+		u, err := svc.ViewProfile(context.Background(), tc.user.Email)
+		require.Nil(t, err, fmt.Sprintf("%s: unexpected error: %s", tc.desc, err))
+		assert.Equal(t, tc.status, u.Status, fmt.Sprintf("%s: expected status %s got %s\n", tc.desc, tc.status, u.Status))
+		token := e.VerificationToken(tc.user.Email)
+		assert.Equal(t, tc.sent, token != "", fmt.Sprintf("%s: expected verification sent to be %t", tc.desc, tc.sent))
+	}
+}
+
+func TestVerifyEmail(t *testing.T) {
+	svc, e := newVerificationService(verification)
+	_, err := svc.Register(context.Background(), user)
+	require.Nil(t, err, fmt.Sprintf("unexpected error: %s", err))
+	token := e.VerificationToken(user.Email)
+
+	expSvc, expEmail := newVerificationService(users.VerificationPolicy{Enabled: true, Duration: time.Nanosecond})
+	_, err = expSvc.Register(context.Background(), user)
+	require.Nil(t, err, fmt.Sprintf("unexpected error: %s", err))
+
+	_, _, err = svc.Login(context.Background(), user, "")
+	assert.True(t, errors.Contains(err, users.ErrUnverified), fmt.Sprintf("login to unverified account: expected %s got %s\n", users.ErrUnverified, err))
+
+	cases := []struct {
+		desc  string
+		svc   users.Service
+		token string
+		err   error
+	}{
+		{
+			desc:  "verify email with invalid token",
+			svc:   svc,
+			token: wrong,
+			err:   users.ErrUnauthorizedAccess,
+		},
+		{
+			desc:  "verify email with empty token",
+			svc:   svc,
+			token: "",
+			err:   users.ErrUnauthorizedAccess,
+		},
+		{
+			desc:  "verify email with expired token",
+			svc:   expSvc,
+			token: expEmail.VerificationToken(user.Email),
+			err:   users.ErrUnauthorizedAccess,
+		},
+		{
+			desc:  "verify email with valid token",
+			svc:   svc,
+			token: token,
+			err:   nil,
+		},
+		{
+			desc:  "verify email with already used token",
+			svc:   svc,
+			token: token,
+			err:   users.ErrUnauthorizedAccess,
+		},
+	}
+
+	for _, tc := range cases {
+		err := tc.svc.VerifyEmail(context.Background(), tc.token)
+		assert.True(t, errors.Contains(err, tc.err), fmt.Sprintf("%s: expected %s got %s\n", tc.desc, tc.err, err))
+	}
+
+	_, _, err = svc.Login(context.Background(), user, "")
+	assert.Nil(t, err, fmt.Sprintf("login after verification expected to succeed: %s", err))
+}
+
+func TestResendVerification(t *testing.T) {
+	svc, e := newVerificationService(users.VerificationPolicy{Enabled: true, Duration: time.Hour})
+	_, err := svc.Register(context.Background(), user)
+	require.Nil(t, err, fmt.Sprintf("unexpected error: %s", err))
+	_, err = svc.Register(context.Background(), admin)
+	require.Nil(t, err, fmt.Sprintf("unexpected error: %s", err))
+	first := e.VerificationToken(user.Email)
+
+	limSvc, _ := newVerificationService(verification)
+	_, err = limSvc.Register(context.Background(), user)
+	require.Nil(t, err, fmt.Sprintf("unexpected error: %s", err))
+
+	cases := []struct {
+		desc  string
+		svc   users.Service
+		email string
+		err   error
+	}{
+		{
+			desc:  "resend verification to non-existing user",
+			svc:   svc,
+			email: wrong,
+			err:   users.ErrUserNotFound,
+		},
+		{
+			desc:  "resend verification to verified user",
+			svc:   svc,
+			email: admin.Email,
+			err:   users.ErrVerified,
+		},
+		{
+			desc:  "resend verification before resend interval passes",
+			svc:   limSvc,
+			email: user.Email,
+			err:   users.ErrVerificationSent,
+		},
+		{
+			desc:  "resend verification to pending user",
+			svc:   svc,
+			email: user.Email,
+			err:   nil,
+		},
+	}
+
+	for _, tc := range cases {
+		err := tc.svc.ResendVerification(context.Background(), tc.email)
+		assert.True(t, errors.Contains(err, tc.err), fmt.Sprintf("%s: expected %s got %s\n", tc.desc, tc.err, err))
+	}
+
+	second := e.VerificationToken(user.Email)
+	assert.NotEqual(t, first, second, "expected resent verification token to differ from the previous one")
+	err = svc.VerifyEmail(context.Background(), first)
+	assert.True(t, errors.Contains(err, users.ErrUnauthorizedAccess), fmt.Sprintf("verify email with replaced token: expected %s got %s\n", users.ErrUnauthorizedAccess, err))
+	err = svc.VerifyEmail(context.Background(), second)
+	assert.Nil(t, err, fmt.Sprintf("verify email with resent token: unexpected error: %s", err))
+}
+
+func TestChangePasswordRevokesSessions(t *testing.T) {
+	svc := newService()
+	_, err := svc.Register(context.Background(), user)
+	require.Nil(t, err, fmt.Sprintf("register user error: %s", err))
+	token, _, err := svc.Login(context.Background(), user, "")
+	require.Nil(t, err, fmt.Sprintf("login error: %s", err))
+
+	err = svc.ChangePassword(context.Background(), token, "newpassword", user.Password)
+	require.Nil(t, err, fmt.Sprintf("change password error: %s", err))
+
+	_, err = svc.ViewProfile(context.Background(), token)
+	assert.True(t, errors.Contains(err, users.ErrUnauthorizedAccess), fmt.Sprintf("view profile with token issued before password change: expected %s got %s\n", users.ErrUnauthorizedAccess, err))
+
+	token, _, err = svc.Login(context.Background(), users.User{Email: user.Email, Password: "newpassword"}, "")
+	require.Nil(t, err, fmt.Sprintf("login error: %s", err))
+	_, err = svc.ViewProfile(context.Background(), token)
+	assert.Nil(t, err, fmt.Sprintf("view profile with token issued after password change: unexpected error %s\n", err))
+}
+
+func TestChangePassword(t *testing.T) {
+	svc := newService()
+	_, err := svc.Register(context.Background(), user)
+	require.Nil(t, err, fmt.Sprintf("register user error: %s", err))
+	token, _, _ := svc.Login(context.Background(), user, "")
+
+	cases := map[string]struct {
+		token       string
+		password    string
+		oldPassword string
+		err         error
+	}{
+		"valid user change password ":                    {token, "newpassword", user.Password, nil},
+		"valid user change password with wrong password": {token, "newpassword", "wrongpassword", users.ErrUnauthorizedAccess},
+		"valid user change password invalid token":       {"", "newpassword", user.Password, users.ErrUnauthorizedAccess},
+	}
+
+	for desc, tc := range cases {
+		err := svc.ChangePassword(context.Background(), tc.token, tc.password, tc.oldPassword)
+		assert.True(t, errors.Contains(err, tc.err), fmt.Sprintf("%s: expected %s got %s\n", desc, tc.err, err))
+
+	}
+}
+
+func TestResetPassword(t *testing.T) {
+	svc := newService()
+	_, err := svc.Register(context.Background(), user)
+	require.Nil(t, err, fmt.Sprintf("unexpected error: %s", err))
+	auth := mocks.NewAuthService(map[string]string{user.Email: user.Email})
+	resetToken, err := auth.Issue(context.Background(), &mainflux.IssueReq{Id: user.ID, Email: user.Email, Type: 2})
+	assert.Nil(t, err, fmt.Sprintf("Generating reset token expected to succeed: %s", err))
+	cases := map[string]struct {
+		token    string
+		password string
+		err      error
+	}{
+		"valid user reset password ":   {resetToken.GetValue(), user.Email, nil},
+		"invalid user reset password ": {"", "newpassword", users.ErrUnauthorizedAccess},
+	}
+
+	for desc, tc := range cases {
+		err := svc.ResetPassword(context.Background(), tc.token, tc.password)
+		assert.True(t, errors.Contains(err, tc.err), fmt.Sprintf("%s: expected %s got %s\n", desc, tc.err, err))
+	}
+}
+
+func TestSendPasswordReset(t *testing.T) {
+	svc := newService()
+	_, err := svc.Register(context.Background(), user)
+	require.Nil(t, err, fmt.Sprintf("register user error: %s", err))
+	token, _, _ := svc.Login(context.Background(), user, "")
+
+	cases := map[string]struct {
+		token string
+		email string
+		err   error
+	}{
+		"valid user reset password ": {token, user.Email, nil},
+	}
+
+	for desc, tc := range cases {
+		err := svc.SendPasswordReset(context.Background(), host, tc.email, tc.token)
+		assert.True(t, errors.Contains(err, tc.err), fmt.Sprintf("%s: expected %s got %s\n", desc, tc.err, err))
+
+	}
+}
+
+func TestCreateGroup(t *testing.T) {
+	svc := newService()
+	_, err := svc.Register(context.Background(), user)
+	assert.Nil(t, err, fmt.Sprintf("registering user expected to succeed: %s", err))
+
+	token, _, err := svc.Login(context.Background(), user, "")
+	assert.Nil(t, err, fmt.Sprintf("authenticating user expected to succeed: %s", err))
+
+	uuid, err := uuidProvider.New().ID()
+	assert.Nil(t, err, fmt.Sprintf("generating uuid expected to succeed: %s", err))
+
+	group := users.Group{
+		ID:   uuid,
+		Name: groupName,
+	}
+
+	cases := []struct {
+		desc  string
+		group users.Group
+		err   error
+	}{
+		{
+			desc:  "create new group",
+			group: group,
+			err:   nil,
+		},
+		{
+			desc:  "create group with existing name",
+			group: group,
+			err:   users.ErrGroupConflict,
+		},
+	}
+
+	for _, tc := range cases {
+		_, err := svc.CreateGroup(context.Background(), token, tc.group)
+		assert.True(t, errors.Contains(err, tc.err), fmt.Sprintf("%s: expected %s got %s\n", tc.desc, tc.err, err))
+	}
+}
+
+func TestUpdateGroup(t *testing.T) {
+	svc := newService()
+
+	_, err := svc.Register(context.Background(), user)
+	assert.Nil(t, err, fmt.Sprintf("registering user expected to succeed: %s", err))
+
+	_, err = svc.Register(context.Background(), admin)
+	assert.Nil(t, err, fmt.Sprintf("registering user expected to succeed: %s", err))
+
+	token, _, err := svc.Login(context.Background(), user, "")
+	assert.Nil(t, err, fmt.Sprintf("authenticating user expected to succeed: %s", err))
+
+	group := users.Group{
+		Name: groupName,
+	}
+
+	saved, err := svc.CreateGroup(context.Background(), token, group)
+	assert.Nil(t, err, fmt.Sprintf("generating uuid expected to succeed: %s", err))
+
+	group.Description = "test description"
+	group.Name = "NewName"
+	group.ID = saved.ID
+	group.OwnerID = saved.OwnerID
+
+	cases := []struct {
+		desc  string
+		token string
+		group users.Group
+		err   error
+	}{
+		{
+			desc:  "update group as non-member",
+			token: admin.Email,
+			group: users.Group{ID: saved.ID, Name: "OtherName"},
+			err:   users.ErrUnauthorizedAccess,
+		},
+		{
+			desc:  "update group",
+			token: token,
+			group: group,
+			err:   nil,
+		},
+	}
+
+	for _, tc := range cases {
+		err := svc.UpdateGroup(context.Background(), tc.token, tc.group)
+		assert.True(t, errors.Contains(err, tc.err), fmt.Sprintf("%s: expected %s got %s\n", tc.desc, tc.err, err))
+	}
+
+	g, err := svc.ViewGroup(context.Background(), token, saved.ID)
+	assert.Nil(t, err, fmt.Sprintf("retrieve group failed: %s", err))
+	assert.Equal(t, group.Description, g.Description, "expected updated group description")
+	assert.Equal(t, group.Name, g.Name, "expected updated group name")
+	assert.Equal(t, group.ID, g.ID, "expected unchanged group ID")
+	assert.Equal(t, group.OwnerID, g.OwnerID, "expected unchanged group owner")
+}
+
+func TestRemoveGroup(t *testing.T) {
+	svc := newService()
+
+	_, err := svc.Register(context.Background(), user)
+	assert.Nil(t, err, fmt.Sprintf("registering user expected to succeed: %s", err))
+
+	token, _, err := svc.Login(context.Background(), user, "")
+	assert.Nil(t, err, fmt.Sprintf("authenticating user expected to succeed: %s", err))
+
+	group := users.Group{
+		Name: groupName,
+	}
+
+	saved, err := svc.CreateGroup(context.Background(), token, group)
+	assert.Nil(t, err, fmt.Sprintf("generating uuid expected to succeed: %s", err))
+
+	group.Description = "test description"
+	group.Name = "NewName"
+	group.ID = saved.ID
+	group.OwnerID = saved.OwnerID
+
+	_, err = svc.Register(context.Background(), admin)
+	assert.Nil(t, err, fmt.Sprintf("registering user expected to succeed: %s", err))
+
+	cases := []struct {
+		desc  string
+		token string
+		group users.Group
+		err   error
+	}{
+		{
+			desc:  "remove group as non-member",
+			token: admin.Email,
+			group: group,
+			err:   users.ErrUnauthorizedAccess,
+		},
+		{
+			desc:  "remove existing group",
+			token: token,
+			group: group,
+			err:   nil,
 		},
 		{
 			desc:  "remove non existing group",
+			token: token,
 			group: group,
 			err:   users.ErrNotFound,
 		},
 	}
 
 	for _, tc := range cases {
-		err := svc.RemoveGroup(context.Background(), token, tc.group.ID)
+		err := svc.RemoveGroup(context.Background(), tc.token, tc.group.ID)
+		assert.True(t, errors.Contains(err, tc.err), fmt.Sprintf("%s: expected %s got %s\n", tc.desc, tc.err, err))
+	}
+}
+
+// newInvitationGroup registers the user and the admin, and creates the
+// group owned by the user.
+func newInvitationGroup(t *testing.T, svc users.Service) users.Group {
+	_, err := svc.Register(context.Background(), user)
+	require.Nil(t, err, fmt.Sprintf("unexpected error: %s", err))
+	_, err = svc.Register(context.Background(), admin)
+	require.Nil(t, err, fmt.Sprintf("unexpected error: %s", err))
+	group, err := svc.CreateGroup(context.Background(), user.Email, users.Group{Name: groupName})
+	require.Nil(t, err, fmt.Sprintf("unexpected error: %s", err))
+
+	return group
+}
+
+func TestInvite(t *testing.T) {
+	svc, e := newInvitationService(invitation)
+	group := newInvitationGroup(t, svc)
+
+	cases := []struct {
+		desc    string
+		token   string
+		groupID string
+		email   string
+		role    string
+		err     error
+	}{
+		{
+			desc:    "invite with invalid token",
+			token:   wrong,
+			groupID: group.ID,
+			email:   invitee,
+			role:    users.ViewerRole,
+			err:     users.ErrUnauthorizedAccess,
+		},
+		{
+			desc:    "invite into group not owned by the user",
+			token:   admin.Email,
+			groupID: group.ID,
+			email:   invitee,
+			role:    users.ViewerRole,
+			err:     users.ErrUnauthorizedAccess,
+		},
+		{
+			desc:    "invite into non-existing group",
+			token:   user.Email,
+			groupID: wrong,
+			email:   invitee,
+			role:    users.ViewerRole,
+			err:     users.ErrNotFound,
+		},
+		{
+			desc:    "invite invalid email",
+			token:   user.Email,
+			groupID: group.ID,
+			email:   wrong,
+			role:    users.ViewerRole,
+			err:     users.ErrMalformedEntity,
+		},
+		{
+			desc:    "invite with invalid role",
+			token:   user.Email,
+			groupID: group.ID,
+			email:   invitee,
+			role:    wrong,
+			err:     users.ErrMalformedEntity,
+		},
+		{
+			desc:    "invite email into group",
+			token:   user.Email,
+			groupID: group.ID,
+			email:   invitee,
+			role:    users.EditorRole,
+			err:     nil,
+		},
+		{
+			desc:    "invite already invited email into group",
+			token:   user.Email,
+			groupID: group.ID,
+			email:   invitee,
+			role:    users.ViewerRole,
+			err:     users.ErrInvitationConflict,
+		},
+	}
+
+	for _, tc := range cases {
+		inv, err := svc.Invite(context.Background(), tc.token, tc.groupID, tc.email, tc.role)
+		assert.True(t, errors.Contains(err, tc.err), fmt.Sprintf("%s: expected %s got %s\n", tc.desc, tc.err, err))
+		if tc.err == nil {
+			assert.Equal(t, tc.role, inv.Role, fmt.Sprintf("%s: expected role %s got %s\n", tc.desc, tc.role, inv.Role))
+			assert.NotEmpty(t, e.InvitationToken(tc.email), fmt.Sprintf("%s: expected invitation to be sent", tc.desc))
+		}
+	}
+}
+
+func TestGroupAdminInvitations(t *testing.T) {
+	svc, _ := newInvitationService(invitation)
+	group := newInvitationGroup(t, svc)
+	groupAdmin, err := svc.ViewProfile(context.Background(), admin.Email)
+	require.Nil(t, err, fmt.Sprintf("unexpected error: %s", err))
+
+	_, err = svc.Invite(context.Background(), admin.Email, group.ID, invitee, users.ViewerRole)
+	assert.True(t, errors.Contains(err, users.ErrUnauthorizedAccess), fmt.Sprintf("invite as non-member: expected %s got %s\n", users.ErrUnauthorizedAccess, err))
+	err = svc.Assign(context.Background(), user.Email, groupAdmin.ID, group.ID, users.EditorRole)
+	require.Nil(t, err, fmt.Sprintf("unexpected error: %s", err))
+	_, err = svc.Invite(context.Background(), admin.Email, group.ID, invitee, users.ViewerRole)
+	assert.True(t, errors.Contains(err, users.ErrUnauthorizedAccess), fmt.Sprintf("invite as group editor: expected %s got %s\n", users.ErrUnauthorizedAccess, err))
+
+	err = svc.Assign(context.Background(), user.Email, groupAdmin.ID, group.ID, users.AdminRole)
+	require.Nil(t, err, fmt.Sprintf("unexpected error: %s", err))
+	inv, err := svc.Invite(context.Background(), admin.Email, group.ID, invitee, users.ViewerRole)
+	assert.Nil(t, err, fmt.Sprintf("invite as group admin: unexpected error: %s", err))
+	page, err := svc.ListInvitations(context.Background(), admin.Email, group.ID, 0, 10)
+	assert.Nil(t, err, fmt.Sprintf("list invitations as group admin: unexpected error: %s", err))
+	assert.Len(t, page.Invitations, 1, "expected the group admin to list the invitation")
+	err = svc.RevokeInvitation(context.Background(), admin.Email, inv.ID)
+	assert.Nil(t, err, fmt.Sprintf("revoke invitation as group admin: unexpected error: %s", err))
+}
+
+func TestListInvitations(t *testing.T) {
+	svc, _ := newInvitationService(invitation)
+	group := newInvitationGroup(t, svc)
+	n := uint64(5)
+	for i := uint64(0); i < n; i++ {
+		_, err := svc.Invite(context.Background(), user.Email, group.ID, fmt.Sprintf("invitee%d@example.com", i), users.ViewerRole)
+		require.Nil(t, err, fmt.Sprintf("unexpected error: %s", err))
+	}
+
+	cases := []struct {
+		desc   string
+		token  string
+		offset uint64
+		limit  uint64
+		size   uint64
+		err    error
+	}{
+		{
+			desc:   "list invitations with invalid token",
+			token:  wrong,
+			offset: 0,
+			limit:  n,
+			size:   0,
+			err:    users.ErrUnauthorizedAccess,
+		},
+		{
+			desc:   "list invitations into group not owned by the user",
+			token:  admin.Email,
+			offset: 0,
+			limit:  n,
+			size:   0,
+			err:    users.ErrUnauthorizedAccess,
+		},
+		{
+			desc:   "list all invitations",
+			token:  user.Email,
+			offset: 0,
+			limit:  n,
+			size:   n,
+			err:    nil,
+		},
+		{
+			desc:   "list last invitation",
+			token:  user.Email,
+			offset: n - 1,
+			limit:  n,
+			size:   1,
+			err:    nil,
+		},
+	}
+
+	for _, tc := range cases {
+		page, err := svc.ListInvitations(context.Background(), tc.token, group.ID, tc.offset, tc.limit)
+		assert.True(t, errors.Contains(err, tc.err), fmt.Sprintf("%s: expected %s got %s\n", tc.desc, tc.err, err))
+		size := uint64(len(page.Invitations))
+		assert.Equal(t, tc.size, size, fmt.Sprintf("%s: expected %d got %d\n", tc.desc, tc.size, size))
+	}
+}
+
+func TestRevokeInvitation(t *testing.T) {
+	svc, e := newInvitationService(invitation)
+	group := newInvitationGroup(t, svc)
+	inv, err := svc.Invite(context.Background(), user.Email, group.ID, invitee, users.ViewerRole)
+	require.Nil(t, err, fmt.Sprintf("unexpected error: %s", err))
+
+	cases := []struct {
+		desc  string
+		token string
+		id    string
+		err   error
+	}{
+		{
+			desc:  "revoke invitation with invalid token",
+			token: wrong,
+			id:    inv.ID,
+			err:   users.ErrUnauthorizedAccess,
+		},
+		{
+			desc:  "revoke invitation into group not owned by the user",
+			token: admin.Email,
+			id:    inv.ID,
+			err:   users.ErrUnauthorizedAccess,
+		},
+		{
+			desc:  "revoke non-existing invitation",
+			token: user.Email,
+			id:    wrong,
+			err:   users.ErrNotFound,
+		},
+		{
+			desc:  "revoke invitation",
+			token: user.Email,
+			id:    inv.ID,
+			err:   nil,
+		},
+	}
+
+	for _, tc := range cases {
+		err := svc.RevokeInvitation(context.Background(), tc.token, tc.id)
+		assert.True(t, errors.Contains(err, tc.err), fmt.Sprintf("%s: expected %s got %s\n", tc.desc, tc.err, err))
+	}
+
+	_, err = svc.AcceptInvitation(context.Background(), e.InvitationToken(invitee), user.Password)
+	assert.True(t, errors.Contains(err, users.ErrUnauthorizedAccess), fmt.Sprintf("accept revoked invitation: expected %s got %s\n", users.ErrUnauthorizedAccess, err))
+}
+
+func TestAcceptInvitation(t *testing.T) {
+	svc, e := newInvitationService(invitation)
+	group := newInvitationGroup(t, svc)
+	_, err := svc.Invite(context.Background(), user.Email, group.ID, invitee, users.EditorRole)
+	require.Nil(t, err, fmt.Sprintf("unexpected error: %s", err))
+	_, err = svc.Invite(context.Background(), user.Email, group.ID, admin.Email, users.ViewerRole)
+	require.Nil(t, err, fmt.Sprintf("unexpected error: %s", err))
+	token := e.InvitationToken(invitee)
+
+	expSvc, expEmail := newInvitationService(users.InvitationPolicy{Secret: invitation.Secret, Duration: time.Nanosecond})
+	expGroup := newInvitationGroup(t, expSvc)
+	_, err = expSvc.Invite(context.Background(), user.Email, expGroup.ID, invitee, users.ViewerRole)
+	require.Nil(t, err, fmt.Sprintf("unexpected error: %s", err))
+
+	cases := []struct {
+		desc     string
+		svc      users.Service
+		token    string
+		password string
+		err      error
+	}{
+		{
+			desc:     "accept invitation with invalid token",
+			svc:      svc,
+			token:    wrong,
+			password: user.Password,
+			err:      users.ErrUnauthorizedAccess,
+		},
+		{
+			desc:     "accept invitation with tampered signature",
+			svc:      svc,
+			token:    token + "0",
+			password: user.Password,
+			err:      users.ErrUnauthorizedAccess,
+		},
+		{
+			desc:     "accept expired invitation",
+			svc:      expSvc,
+			token:    expEmail.InvitationToken(invitee),
+			password: user.Password,
+			err:      users.ErrUnauthorizedAccess,
+		},
+		{
+			desc:     "accept invitation of new user with invalid password",
+			svc:      svc,
+			token:    token,
+			password: "",
+			err:      users.ErrMalformedEntity,
+		},
+		{
+			desc:     "accept invitation of new user",
+			svc:      svc,
+			token:    token,
+			password: user.Password,
+			err:      nil,
+		},
+		{
+			desc:     "accept already accepted invitation",
+			svc:      svc,
+			token:    token,
+			password: user.Password,
+			err:      users.ErrUnauthorizedAccess,
+		},
+		{
+			desc:     "accept invitation of existing user",
+			svc:      svc,
+			token:    e.InvitationToken(admin.Email),
+			password: "",
+			err:      nil,
+		},
+	}
+
+	for _, tc := range cases {
+		_, err := tc.svc.AcceptInvitation(context.Background(), tc.token, tc.password)
+		assert.True(t, errors.Contains(err, tc.err), fmt.Sprintf("%s: expected %s got %s\n", tc.desc, tc.err, err))
+	}
+
+	_, _, err = svc.Login(context.Background(), users.User{Email: invitee, Password: user.Password}, "")
+	assert.Nil(t, err, fmt.Sprintf("login of registered invitee expected to succeed: %s", err))
+	for _, email := range []string{invitee, admin.Email} {
+		u, err := svc.ViewProfile(context.Background(), email)
+		require.Nil(t, err, fmt.Sprintf("unexpected error: %s", err))
+		gp, err := svc.ListMemberships(context.Background(), email, u.ID, 0, 10, nil)
+		require.Nil(t, err, fmt.Sprintf("unexpected error: %s", err))
+		assert.Len(t, gp.Groups, 1, fmt.Sprintf("expected %s to be assigned to the group", email))
+	}
+	page, err := svc.ListInvitations(context.Background(), user.Email, group.ID, 0, 10)
+	require.Nil(t, err, fmt.Sprintf("unexpected error: %s", err))
+	assert.Empty(t, page.Invitations, "expected accepted invitations to be removed")
+}
+
+func TestAcceptInvitationPendingUser(t *testing.T) {
+	svc, e := newPolicyService(lockout, verification, invitation)
+	_, err := svc.Register(context.Background(), admin)
+	require.Nil(t, err, fmt.Sprintf("unexpected error: %s", err))
+	group, err := svc.CreateGroup(context.Background(), admin.Email, users.Group{Name: groupName})
+	require.Nil(t, err, fmt.Sprintf("unexpected error: %s", err))
+
+	// The invitee's email is registered by someone else before the invitation is accepted.
+	preRegistered := users.User{Email: invitee, Password: "pre-registered"}
+	_, err = svc.Register(context.Background(), preRegistered)
+	require.Nil(t, err, fmt.Sprintf("unexpected error: %s", err))
+	_, err = svc.Invite(context.Background(), admin.Email, group.ID, invitee, users.ViewerRole)
+	require.Nil(t, err, fmt.Sprintf("unexpected error: %s", err))
+
+	_, err = svc.AcceptInvitation(context.Background(), e.InvitationToken(invitee), "")
+	assert.True(t, errors.Contains(err, users.ErrMalformedEntity), fmt.Sprintf("accept invitation of pending user with invalid password: expected %s got %s\n", users.ErrMalformedEntity, err))
+	_, err = svc.AcceptInvitation(context.Background(), e.InvitationToken(invitee), user.Password)
+	require.Nil(t, err, fmt.Sprintf("unexpected error: %s", err))
+
+	_, _, err = svc.Login(context.Background(), preRegistered, "")
+	assert.True(t, errors.Contains(err, users.ErrUnauthorizedAccess), fmt.Sprintf("login with pre-registered password: expected %s got %s\n", users.ErrUnauthorizedAccess, err))
+	_, _, err = svc.Login(context.Background(), users.User{Email: invitee, Password: user.Password}, "")
+	assert.Nil(t, err, fmt.Sprintf("login with password of accepted invitation expected to succeed: %s", err))
+}
+
+func TestListMemberships(t *testing.T) {
+	svc := newService()
+	group := newInvitationGroup(t, svc)
+	_, err := svc.Register(context.Background(), users.User{Email: invitee, Password: user.Password})
+	require.Nil(t, err, fmt.Sprintf("unexpected error: %s", err))
+	owner, err := svc.ViewProfile(context.Background(), user.Email)
+	require.Nil(t, err, fmt.Sprintf("unexpected error: %s", err))
+
+	cases := []struct {
+		desc  string
+		token string
+		size  int
+		err   error
+	}{
+		{
+			desc:  "list memberships with invalid token",
+			token: wrong,
+			err:   users.ErrUnauthorizedAccess,
+		},
+		{
+			desc:  "list own memberships",
+			token: user.Email,
+			size:  1,
+			err:   nil,
+		},
+		{
+			desc:  "list memberships of other user",
+			token: invitee,
+			err:   users.ErrUnauthorizedAccess,
+		},
+		{
+			desc:  "list memberships of other user as admin",
+			token: admin.Email,
+			size:  1,
+			err:   nil,
+		},
+	}
+
+	for _, tc := range cases {
+		page, err := svc.ListMemberships(context.Background(), tc.token, owner.ID, 0, 10, nil)
+		assert.True(t, errors.Contains(err, tc.err), fmt.Sprintf("%s: expected %s got %s\n", tc.desc, tc.err, err))
+		assert.Len(t, page.Groups, tc.size, fmt.Sprintf("%s: expected %d groups got %d\n", tc.desc, tc.size, len(page.Groups)))
+	}
+
+	page, err := svc.ListGroups(context.Background(), invitee, "", 0, 10, nil)
+	require.Nil(t, err, fmt.Sprintf("unexpected error: %s", err))
+	assert.Empty(t, page.Groups, "expected non-member not to list the group")
+	_, err = svc.ListGroups(context.Background(), invitee, group.ID, 0, 10, nil)
+	assert.True(t, errors.Contains(err, users.ErrUnauthorizedAccess), fmt.Sprintf("list children of group as non-member: expected %s got %s\n", users.ErrUnauthorizedAccess, err))
+}
+
+func TestAssign(t *testing.T) {
+	svc := newService()
+	group := newInvitationGroup(t, svc)
+	member, err := svc.ViewProfile(context.Background(), admin.Email)
+	require.Nil(t, err, fmt.Sprintf("unexpected error: %s", err))
+
+	cases := []struct {
+		desc  string
+		token string
+		role  string
+		err   error
+	}{
+		{
+			desc:  "assign user with invalid token",
+			token: wrong,
+			role:  users.EditorRole,
+			err:   users.ErrUnauthorizedAccess,
+		},
+		{
+			desc:  "assign user with unknown role",
+			token: user.Email,
+			role:  "owner",
+			err:   users.ErrMalformedEntity,
+		},
+		{
+			desc:  "assign self as non-member",
+			token: admin.Email,
+			role:  users.AdminRole,
+			err:   users.ErrUnauthorizedAccess,
+		},
+		{
+			desc:  "assign user with role",
+			token: user.Email,
+			role:  users.EditorRole,
+			err:   nil,
+		},
+		{
+			desc:  "assign self as editor",
+			token: admin.Email,
+			role:  users.AdminRole,
+			err:   users.ErrUnauthorizedAccess,
+		},
+	}
+
+	for _, tc := range cases {
+		err := svc.Assign(context.Background(), tc.token, member.ID, group.ID, tc.role)
+		assert.True(t, errors.Contains(err, tc.err), fmt.Sprintf("%s: expected %s got %s\n", tc.desc, tc.err, err))
+	}
+
+	groups, err := svc.Authorize(context.Background(), admin.Email, users.EditorRole)
+	require.Nil(t, err, fmt.Sprintf("unexpected error: %s", err))
+	assert.Equal(t, []string{group.ID}, groups, "expected the user to be assigned as editor")
+}
+
+func TestUnassign(t *testing.T) {
+	svc := newService()
+	group := newInvitationGroup(t, svc)
+	owner, err := svc.ViewProfile(context.Background(), user.Email)
+	require.Nil(t, err, fmt.Sprintf("unexpected error: %s", err))
+	member, err := svc.ViewProfile(context.Background(), admin.Email)
+	require.Nil(t, err, fmt.Sprintf("unexpected error: %s", err))
+	err = svc.Assign(context.Background(), user.Email, member.ID, group.ID, users.EditorRole)
+	require.Nil(t, err, fmt.Sprintf("unexpected error: %s", err))
+
+	cases := []struct {
+		desc   string
+		token  string
+		userID string
+		err    error
+	}{
+		{
+			desc:   "unassign user with invalid token",
+			token:  wrong,
+			userID: member.ID,
+			err:    users.ErrUnauthorizedAccess,
+		},
+		{
+			desc:   "unassign owner as editor",
+			token:  admin.Email,
+			userID: owner.ID,
+			err:    users.ErrUnauthorizedAccess,
+		},
+		{
+			desc:   "unassign user",
+			token:  user.Email,
+			userID: member.ID,
+			err:    nil,
+		},
+	}
+
+	for _, tc := range cases {
+		err := svc.Unassign(context.Background(), tc.token, tc.userID, group.ID)
+		assert.True(t, errors.Contains(err, tc.err), fmt.Sprintf("%s: expected %s got %s\n", tc.desc, tc.err, err))
+	}
+
+	_, err = svc.ViewGroup(context.Background(), admin.Email, group.ID)
+	assert.True(t, errors.Contains(err, users.ErrUnauthorizedAccess), fmt.Sprintf("viewing group as unassigned user expected %s got %s\n", users.ErrUnauthorizedAccess, err))
+}
+
+func TestViewGroup(t *testing.T) {
+	svc := newService()
+	group := newInvitationGroup(t, svc)
+	_, err := svc.Register(context.Background(), users.User{Email: invitee, Password: "password"})
+	require.Nil(t, err, fmt.Sprintf("unexpected error: %s", err))
+	member, err := svc.ViewProfile(context.Background(), invitee)
+	require.Nil(t, err, fmt.Sprintf("unexpected error: %s", err))
+	err = svc.Assign(context.Background(), user.Email, member.ID, group.ID, users.ViewerRole)
+	require.Nil(t, err, fmt.Sprintf("unexpected error: %s", err))
+
+	cases := []struct {
+		desc  string
+		token string
+		err   error
+	}{
+		{
+			desc:  "view group as owner",
+			token: user.Email,
+			err:   nil,
+		},
+		{
+			desc:  "view group as viewer",
+			token: invitee,
+			err:   nil,
+		},
+		{
+			desc:  "view group as non-member",
+			token: admin.Email,
+			err:   users.ErrUnauthorizedAccess,
+		},
+	}
+
+	for _, tc := range cases {
+		g, err := svc.ViewGroup(context.Background(), tc.token, group.ID)
+		assert.True(t, errors.Contains(err, tc.err), fmt.Sprintf("%s: expected %s got %s\n", tc.desc, tc.err, err))
+		if tc.err == nil {
+			assert.Equal(t, group.ID, g.ID, fmt.Sprintf("%s: expected group %s got %s\n", tc.desc, group.ID, g.ID))
+		}
+	}
+
+	_, err = svc.ListMembers(context.Background(), admin.Email, group.ID, 0, 10, nil)
+	assert.True(t, errors.Contains(err, users.ErrUnauthorizedAccess), fmt.Sprintf("listing members as non-member expected %s got %s\n", users.ErrUnauthorizedAccess, err))
+	err = svc.Unassign(context.Background(), invitee, member.ID, group.ID)
+	assert.True(t, errors.Contains(err, users.ErrUnauthorizedAccess), fmt.Sprintf("unassigning user as viewer expected %s got %s\n", users.ErrUnauthorizedAccess, err))
+}
+
+func TestAuthorize(t *testing.T) {
+	svc := newService()
+	group := newInvitationGroup(t, svc)
+	member, err := svc.ViewProfile(context.Background(), admin.Email)
+	require.Nil(t, err, fmt.Sprintf("unexpected error: %s", err))
+	err = svc.Assign(context.Background(), user.Email, member.ID, group.ID, "")
+	require.Nil(t, err, fmt.Sprintf("unexpected error: %s", err))
+
+	cases := []struct {
+		desc   string
+		email  string
+		role   string
+		groups []string
+		err    error
+	}{
+		{
+			desc:   "authorize owner as admin",
+			email:  user.Email,
+			role:   users.AdminRole,
+			groups: []string{group.ID},
+			err:    nil,
+		},
+		{
+			desc:   "authorize viewer as viewer",
+			email:  admin.Email,
+			role:   users.ViewerRole,
+			groups: []string{group.ID},
+			err:    nil,
+		},
+		{
+			desc:   "authorize viewer as editor",
+			email:  admin.Email,
+			role:   users.EditorRole,
+			groups: []string{},
+			err:    nil,
+		},
+		{
+			desc:   "authorize with unknown role",
+			email:  admin.Email,
+			role:   "owner",
+			groups: nil,
+			err:    users.ErrMalformedEntity,
+		},
+		{
+			desc:   "authorize non-existing user",
+			email:  invitee,
+			role:   users.ViewerRole,
+			groups: nil,
+			err:    users.ErrNotFound,
+		},
+	}
+
+	for _, tc := range cases {
+		groups, err := svc.Authorize(context.Background(), tc.email, tc.role)
 		assert.True(t, errors.Contains(err, tc.err), fmt.Sprintf("%s: expected %s got %s\n", tc.desc, tc.err, err))
+		assert.Equal(t, tc.groups, groups, fmt.Sprintf("%s: expected %v got %v\n", tc.desc, tc.groups, groups))
 	}
 }
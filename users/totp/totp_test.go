@@ -0,0 +1,96 @@
+// Copyright (c) Mainflux
+// SPDX-License-Identifier: Apache-2.0
+
+package totp_test
+
+import (
+	"fmt"
+	"net/url"
+	"testing"
+	"time"
+
+	"github.com/mainflux/mainflux/pkg/errors"
+	"github.com/mainflux/mainflux/users/totp"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// secret is the base32 encoded RFC 6238 SHA1 test secret.
+const secret = "GEZDGNBVGY3TQOJQGEZDGNBVGY3TQOJQ"
+
+func TestGenerate(t *testing.T) {
+	cases := []struct {
+		desc   string
+		secret string
+		time   int64
+		code   string
+		err    error
+	}{
+		// Expected codes are the last six digits of the RFC 6238 test vectors.
+		{desc: "generate code at 59", secret: secret, time: 59, code: "287082"},
+		{desc: "generate code at 1111111109", secret: secret, time: 1111111109, code: "081804"},
+		{desc: "generate code at 1111111111", secret: secret, time: 1111111111, code: "050471"},
+		{desc: "generate code at 1234567890", secret: secret, time: 1234567890, code: "005924"},
+		{desc: "generate code at 2000000000", secret: secret, time: 2000000000, code: "279037"},
+		{desc: "generate code at 20000000000", secret: secret, time: 20000000000, code: "353130"},
+		{desc: "generate code with invalid secret", secret: "1nv@l1d", time: 59, code: "", err: totp.ErrInvalidSecret},
+		{desc: "generate code with empty secret", secret: "", time: 59, code: "", err: totp.ErrInvalidSecret},
+	}
+
+	for _, tc := range cases {
+		code, err := totp.Generate(tc.secret, time.Unix(tc.time, 0))
+		assert.True(t, errors.Contains(err, tc.err), fmt.Sprintf("%s: expected %s got %s\n", tc.desc, tc.err, err))
+		assert.Equal(t, tc.code, code, fmt.Sprintf("%s: expected %s got %s\n", tc.desc, tc.code, code))
+	}
+}
+
+func TestValidate(t *testing.T) {
+	now := time.Now()
+	code, err := totp.Generate(secret, now)
+	require.Nil(t, err, fmt.Sprintf("generating code expected to succeed: %s", err))
+
+	cases := []struct {
+		desc   string
+		secret string
+		code   string
+		time   time.Time
+		valid  bool
+	}{
+		{desc: "validate current code", secret: secret, code: code, time: now, valid: true},
+		{desc: "validate code of the previous period", secret: secret, code: code, time: now.Add(totp.Period), valid: true},
+		{desc: "validate code of the next period", secret: secret, code: code, time: now.Add(-totp.Period), valid: true},
+		{desc: "validate expired code", secret: secret, code: code, time: now.Add(3 * totp.Period), valid: false},
+		{desc: "validate code with other secret", secret: "JBSWY3DPEHPK3PXP", code: code, time: now, valid: false},
+		{desc: "validate code with invalid secret", secret: "1nv@l1d", code: code, time: now, valid: false},
+		{desc: "validate malformed code", secret: secret, code: code + "0", time: now, valid: false},
+		{desc: "validate empty code", secret: secret, code: "", time: now, valid: false},
+	}
+
+	for _, tc := range cases {
+		valid := totp.Validate(tc.secret, tc.code, tc.time)
+		assert.Equal(t, tc.valid, valid, fmt.Sprintf("%s: expected %t got %t\n", tc.desc, tc.valid, valid))
+	}
+}
+
+func TestNewSecret(t *testing.T) {
+	s1, err := totp.NewSecret()
+	require.Nil(t, err, fmt.Sprintf("generating secret expected to succeed: %s", err))
+	s2, err := totp.NewSecret()
+	require.Nil(t, err, fmt.Sprintf("generating secret expected to succeed: %s", err))
+	assert.NotEqual(t, s1, s2, "generated secrets expected to differ")
+
+	_, err = totp.Generate(s1, time.Now())
+	assert.Nil(t, err, fmt.Sprintf("generating code using new secret expected to succeed: %s", err))
+}
+
+func TestURI(t *testing.T) {
+	uri := totp.URI("Mainflux", "user@example.com", secret)
+	u, err := url.Parse(uri)
+	require.Nil(t, err, fmt.Sprintf("parsing URI expected to succeed: %s", err))
+
+	assert.Equal(t, "otpauth", u.Scheme, fmt.Sprintf("expected otpauth scheme got %s", u.Scheme))
+	assert.Equal(t, "totp", u.Host, fmt.Sprintf("expected totp type got %s", u.Host))
+	assert.Equal(t, "/Mainflux:user@example.com", u.Path, fmt.Sprintf("expected issuer and account label got %s", u.Path))
+	assert.Equal(t, secret, u.Query().Get("secret"), "URI expected to contain the secret")
+	assert.Equal(t, "Mainflux", u.Query().Get("issuer"), "URI expected to contain the issuer")
+}
@@ -22,6 +22,7 @@ const (
 	retrieveByName    = "retrieve_by_name"
 	memberships       = "memberships"
 	unassignUser      = "unassign_user"
+	retrieveRoles     = "retrieve_roles"
 )
 
 var _ users.GroupRepository = (*groupRepositoryMiddleware)(nil)
@@ -101,10 +102,18 @@ func (grm groupRepositoryMiddleware) Unassign(ctx context.Context, userID, group
 	return grm.repo.Unassign(ctx, userID, groupID)
 }
 
-func (grm groupRepositoryMiddleware) Assign(ctx context.Context, userID, groupID string) error {
+func (grm groupRepositoryMiddleware) Assign(ctx context.Context, userID, groupID, role string) error {
 	span := createSpan(ctx, grm.tracer, assignUser)
 	defer span.Finish()
 	ctx = opentracing.ContextWithSpan(ctx, span)
 
-	return grm.repo.Assign(ctx, userID, groupID)
+	return grm.repo.Assign(ctx, userID, groupID, role)
+}
+
+func (grm groupRepositoryMiddleware) RetrieveRoles(ctx context.Context, userID string) (map[string]string, error) {
+	span := createSpan(ctx, grm.tracer, retrieveRoles)
+	defer span.Finish()
+	ctx = opentracing.ContextWithSpan(ctx, span)
+
+	return grm.repo.RetrieveRoles(ctx, userID)
 }
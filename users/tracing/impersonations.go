@@ -0,0 +1,47 @@
+// Copyright (c) Mainflux
+// SPDX-License-Identifier: Apache-2.0
+
+package tracing
+
+import (
+	"context"
+
+	"github.com/mainflux/mainflux/users"
+	opentracing "github.com/opentracing/opentracing-go"
+)
+
+const (
+	saveImpersonation      = "save_impersonation"
+	retrieveImpersonations = "retrieve_all_impersonations"
+)
+
+var _ users.ImpersonationRepository = (*impersonationRepositoryMiddleware)(nil)
+
+type impersonationRepositoryMiddleware struct {
+	tracer opentracing.Tracer
+	repo   users.ImpersonationRepository
+}
+
+// ImpersonationRepositoryMiddleware tracks request and their latency, and adds spans to context.
+func ImpersonationRepositoryMiddleware(repo users.ImpersonationRepository, tracer opentracing.Tracer) users.ImpersonationRepository {
+	return impersonationRepositoryMiddleware{
+		tracer: tracer,
+		repo:   repo,
+	}
+}
+
+func (irm impersonationRepositoryMiddleware) Save(ctx context.Context, imp users.Impersonation) (string, error) {
+	span := createSpan(ctx, irm.tracer, saveImpersonation)
+	defer span.Finish()
+	ctx = opentracing.ContextWithSpan(ctx, span)
+
+	return irm.repo.Save(ctx, imp)
+}
+
+func (irm impersonationRepositoryMiddleware) RetrieveAll(ctx context.Context, userID string, offset, limit uint64) (users.ImpersonationPage, error) {
+	span := createSpan(ctx, irm.tracer, retrieveImpersonations)
+	defer span.Finish()
+	ctx = opentracing.ContextWithSpan(ctx, span)
+
+	return irm.repo.RetrieveAll(ctx, userID, offset, limit)
+}
@@ -0,0 +1,65 @@
+// Copyright (c) Mainflux
+// SPDX-License-Identifier: Apache-2.0
+
+package tracing
+
+import (
+	"context"
+
+	"github.com/mainflux/mainflux/users"
+	opentracing "github.com/opentracing/opentracing-go"
+)
+
+const (
+	saveInvitation         = "save_invitation"
+	retrieveInvitationByID = "retrieve_invitation_by_id"
+	retrieveInvitations    = "retrieve_all_invitations"
+	removeInvitation       = "remove_invitation"
+)
+
+var _ users.InvitationRepository = (*invitationRepositoryMiddleware)(nil)
+
+type invitationRepositoryMiddleware struct {
+	tracer opentracing.Tracer
+	repo   users.InvitationRepository
+}
+
+// InvitationRepositoryMiddleware tracks request and their latency, and adds spans to context.
+func InvitationRepositoryMiddleware(repo users.InvitationRepository, tracer opentracing.Tracer) users.InvitationRepository {
+	return invitationRepositoryMiddleware{
+		tracer: tracer,
+		repo:   repo,
+	}
+}
+
+func (irm invitationRepositoryMiddleware) Save(ctx context.Context, inv users.Invitation) (string, error) {
+	span := createSpan(ctx, irm.tracer, saveInvitation)
+	defer span.Finish()
+	ctx = opentracing.ContextWithSpan(ctx, span)
+
+	return irm.repo.Save(ctx, inv)
+}
+
+func (irm invitationRepositoryMiddleware) RetrieveByID(ctx context.Context, id string) (users.Invitation, error) {
+	span := createSpan(ctx, irm.tracer, retrieveInvitationByID)
+	defer span.Finish()
+	ctx = opentracing.ContextWithSpan(ctx, span)
+
+	return irm.repo.RetrieveByID(ctx, id)
+}
+
+func (irm invitationRepositoryMiddleware) RetrieveAll(ctx context.Context, groupID string, offset, limit uint64) (users.InvitationPage, error) {
+	span := createSpan(ctx, irm.tracer, retrieveInvitations)
+	defer span.Finish()
+	ctx = opentracing.ContextWithSpan(ctx, span)
+
+	return irm.repo.RetrieveAll(ctx, groupID, offset, limit)
+}
+
+func (irm invitationRepositoryMiddleware) Remove(ctx context.Context, id string) error {
+	span := createSpan(ctx, irm.tracer, removeInvitation)
+	defer span.Finish()
+	ctx = opentracing.ContextWithSpan(ctx, span)
+
+	return irm.repo.Remove(ctx, id)
+}
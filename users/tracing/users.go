@@ -16,6 +16,10 @@ const (
 	saveOp            = "save_op"
 	retrieveByEmailOp = "retrieve_by_email"
 	updatePassword    = "update_password"
+	updateTOTP        = "update_totp"
+	updateStatus      = "update_status"
+	updateVerify      = "update_verification"
+	retrieveByVerify  = "retrieve_by_verification_token"
 	members           = "members"
 )
 
@@ -75,12 +79,44 @@ func (urm userRepositoryMiddleware) UpdatePassword(ctx context.Context, email, p
 	return urm.repo.UpdatePassword(ctx, email, password)
 }
 
-func (urm userRepositoryMiddleware) RetrieveAll(ctx context.Context, offset, limit uint64, email string, um users.Metadata) (users.UserPage, error) {
+func (urm userRepositoryMiddleware) UpdateTOTP(ctx context.Context, email string, totp users.TOTP) error {
+	span := createSpan(ctx, urm.tracer, updateTOTP)
+	defer span.Finish()
+	ctx = opentracing.ContextWithSpan(ctx, span)
+
+	return urm.repo.UpdateTOTP(ctx, email, totp)
+}
+
+func (urm userRepositoryMiddleware) UpdateStatus(ctx context.Context, email, status string) error {
+	span := createSpan(ctx, urm.tracer, updateStatus)
+	defer span.Finish()
+	ctx = opentracing.ContextWithSpan(ctx, span)
+
+	return urm.repo.UpdateStatus(ctx, email, status)
+}
+
+func (urm userRepositoryMiddleware) UpdateVerification(ctx context.Context, email string, v users.Verification) error {
+	span := createSpan(ctx, urm.tracer, updateVerify)
+	defer span.Finish()
+	ctx = opentracing.ContextWithSpan(ctx, span)
+
+	return urm.repo.UpdateVerification(ctx, email, v)
+}
+
+func (urm userRepositoryMiddleware) RetrieveByVerificationToken(ctx context.Context, token string) (users.User, error) {
+	span := createSpan(ctx, urm.tracer, retrieveByVerify)
+	defer span.Finish()
+	ctx = opentracing.ContextWithSpan(ctx, span)
+
+	return urm.repo.RetrieveByVerificationToken(ctx, token)
+}
+
+func (urm userRepositoryMiddleware) RetrieveAll(ctx context.Context, offset, limit uint64, cursor, email string, um users.Metadata) (users.UserPage, error) {
 	span := createSpan(ctx, urm.tracer, members)
 	defer span.Finish()
 	ctx = opentracing.ContextWithSpan(ctx, span)
 
-	return urm.repo.RetrieveAll(ctx, offset, limit, email, um)
+	return urm.repo.RetrieveAll(ctx, offset, limit, cursor, email, um)
 }
 
 func (urm userRepositoryMiddleware) RetrieveMembers(ctx context.Context, groupID string, offset, limit uint64, um users.Metadata) (users.UserPage, error) {
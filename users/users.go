@@ -8,6 +8,7 @@ import (
 	"fmt"
 	"regexp"
 	"strings"
+	"time"
 
 	"golang.org/x/net/idna"
 )
@@ -22,6 +23,19 @@ const (
 	dotSeparator = "."
 )
 
+const (
+	// ActiveStatus represents the status of the user allowed to log in.
+	ActiveStatus = "active"
+
+	// PendingStatus represents the status of the user whose email is not
+	// verified yet.
+	PendingStatus = "pending"
+
+	// DisabledStatus represents the status of the user disabled by the
+	// admin, who is not allowed to log in.
+	DisabledStatus = "disabled"
+)
+
 var (
 	userRegexp    = regexp.MustCompile("^[a-zA-Z0-9!#$%&'*+/=?^_`{|}~.-]+$")
 	hostRegexp    = regexp.MustCompile("^[^\\s]+\\.[^\\s]+$")
@@ -42,6 +56,27 @@ type User struct {
 	Owner    *User
 	Groups   []Group
 	Metadata Metadata
+	TOTP     TOTP
+
+	// Status of the user account. Users with empty status are active.
+	Status       string
+	Verification Verification
+}
+
+// Verification contains the email verification token sent to the user with
+// the pending status, and the time it was sent. Token is stored hashed.
+type Verification struct {
+	Token string
+	Sent  time.Time
+}
+
+// TOTP contains the two-factor authentication settings of the user. Secret
+// is set on enrollment, while the two-factor authentication is enabled only
+// once the user confirms the secret. Recovery codes are stored hashed.
+type TOTP struct {
+	Secret        string
+	Enabled       bool
+	RecoveryCodes []string
 }
 
 // Validate returns an error if user representation is invalid.
@@ -72,12 +107,28 @@ type UserRepository interface {
 	// RetrieveByID retrieves user by its unique identifier ID.
 	RetrieveByID(ctx context.Context, id string) (User, error)
 
-	// RetrieveAll retrieves all users
-	RetrieveAll(ctx context.Context, offset, limit uint64, email string, m Metadata) (UserPage, error)
+	// RetrieveAll retrieves all users. If cursor is provided, users following
+	// the cursor are retrieved and the offset is ignored.
+	RetrieveAll(ctx context.Context, offset, limit uint64, cursor, email string, m Metadata) (UserPage, error)
 
 	// UpdatePassword updates password for user with given email
 	UpdatePassword(ctx context.Context, email, password string) error
 
+	// UpdateTOTP updates two-factor authentication settings for user with
+	// given email.
+	UpdateTOTP(ctx context.Context, email string, totp TOTP) error
+
+	// UpdateStatus updates status for user with given email.
+	UpdateStatus(ctx context.Context, email, status string) error
+
+	// UpdateVerification updates email verification token for user with
+	// given email.
+	UpdateVerification(ctx context.Context, email string, v Verification) error
+
+	// RetrieveByVerificationToken retrieves user by its hashed email
+	// verification token.
+	RetrieveByVerificationToken(ctx context.Context, token string) (User, error)
+
 	// RetrieveMembers retrieves all users that belong to a group
 	RetrieveMembers(ctx context.Context, groupID string, offset, limit uint64, m Metadata) (UserPage, error)
 }
@@ -0,0 +1,65 @@
+// Copyright (c) Mainflux
+// SPDX-License-Identifier: Apache-2.0
+
+package api
+
+import (
+	"context"
+
+	"github.com/mainflux/mainflux/pkg/audit"
+	"github.com/mainflux/mainflux/pkg/messaging"
+	"github.com/mainflux/mainflux/webhooks"
+)
+
+var _ webhooks.Service = (*auditMiddleware)(nil)
+
+type auditMiddleware struct {
+	rec audit.Recorder
+	svc webhooks.Service
+}
+
+// AuditMiddleware records the operations changing the webhooks. Read-only
+// operations and the deliveries of the consumed messages are not recorded.
+func AuditMiddleware(svc webhooks.Service, rec audit.Recorder) webhooks.Service {
+	return &auditMiddleware{rec, svc}
+}
+
+func (am *auditMiddleware) CreateWebhook(ctx context.Context, token string, wh webhooks.Webhook) (saved webhooks.Webhook, err error) {
+	e := am.rec.Event(ctx, token, "create_webhook", "")
+	defer func() {
+		e.Entity = saved.ID
+		am.rec.Record(ctx, e, err)
+	}()
+
+	return am.svc.CreateWebhook(ctx, token, wh)
+}
+
+func (am *auditMiddleware) ViewWebhook(ctx context.Context, token, id string) (webhooks.Webhook, error) {
+	return am.svc.ViewWebhook(ctx, token, id)
+}
+
+func (am *auditMiddleware) UpdateWebhook(ctx context.Context, token string, wh webhooks.Webhook) (err error) {
+	e := am.rec.Event(ctx, token, "update_webhook", wh.ID)
+	defer func() { am.rec.Record(ctx, e, err) }()
+
+	return am.svc.UpdateWebhook(ctx, token, wh)
+}
+
+func (am *auditMiddleware) ListWebhooks(ctx context.Context, token string, offset, limit uint64) (webhooks.WebhooksPage, error) {
+	return am.svc.ListWebhooks(ctx, token, offset, limit)
+}
+
+func (am *auditMiddleware) RemoveWebhook(ctx context.Context, token, id string) (err error) {
+	e := am.rec.Event(ctx, token, "remove_webhook", id)
+	defer func() { am.rec.Record(ctx, e, err) }()
+
+	return am.svc.RemoveWebhook(ctx, token, id)
+}
+
+func (am *auditMiddleware) ListDeliveries(ctx context.Context, token string, filter webhooks.DeliveryFilter, offset, limit uint64) (webhooks.DeliveriesPage, error) {
+	return am.svc.ListDeliveries(ctx, token, filter, offset, limit)
+}
+
+func (am *auditMiddleware) Consume(msg messaging.Message) error {
+	return am.svc.Consume(msg)
+}
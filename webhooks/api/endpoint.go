@@ -0,0 +1,129 @@
+// Copyright (c) Mainflux
+// SPDX-License-Identifier: Apache-2.0
+
+package api
+
+import (
+	"context"
+
+	"github.com/go-kit/kit/endpoint"
+	"github.com/mainflux/mainflux/webhooks"
+)
+
+func createWebhookEndpoint(svc webhooks.Service) endpoint.Endpoint {
+	return func(ctx context.Context, request interface{}) (interface{}, error) {
+		req := request.(webhookReq)
+		if err := req.validate(); err != nil {
+			return nil, err
+		}
+
+		saved, err := svc.CreateWebhook(ctx, req.token, req.webhook())
+		if err != nil {
+			return nil, err
+		}
+
+		return webhookRes{id: saved.ID, created: true}, nil
+	}
+}
+
+func viewWebhookEndpoint(svc webhooks.Service) endpoint.Endpoint {
+	return func(ctx context.Context, request interface{}) (interface{}, error) {
+		req := request.(viewWebhookReq)
+		if err := req.validate(); err != nil {
+			return nil, err
+		}
+
+		wh, err := svc.ViewWebhook(ctx, req.token, req.id)
+		if err != nil {
+			return nil, err
+		}
+
+		return toWebhookRes(wh), nil
+	}
+}
+
+func updateWebhookEndpoint(svc webhooks.Service) endpoint.Endpoint {
+	return func(ctx context.Context, request interface{}) (interface{}, error) {
+		req := request.(webhookReq)
+		if err := req.validate(); err != nil {
+			return nil, err
+		}
+
+		if err := svc.UpdateWebhook(ctx, req.token, req.webhook()); err != nil {
+			return nil, err
+		}
+
+		return webhookRes{id: req.id, created: false}, nil
+	}
+}
+
+func listWebhooksEndpoint(svc webhooks.Service) endpoint.Endpoint {
+	return func(ctx context.Context, request interface{}) (interface{}, error) {
+		req := request.(listReq)
+		if err := req.validate(); err != nil {
+			return nil, err
+		}
+
+		page, err := svc.ListWebhooks(ctx, req.token, req.offset, req.limit)
+		if err != nil {
+			return nil, err
+		}
+
+		res := webhooksPageRes{
+			pageRes: pageRes{
+				Total:  page.Total,
+				Offset: page.Offset,
+				Limit:  page.Limit,
+			},
+			Webhooks: []viewWebhookRes{},
+		}
+		for _, wh := range page.Webhooks {
+			res.Webhooks = append(res.Webhooks, toWebhookRes(wh))
+		}
+
+		return res, nil
+	}
+}
+
+func removeWebhookEndpoint(svc webhooks.Service) endpoint.Endpoint {
+	return func(ctx context.Context, request interface{}) (interface{}, error) {
+		req := request.(viewWebhookReq)
+		if err := req.validate(); err != nil {
+			return nil, err
+		}
+
+		if err := svc.RemoveWebhook(ctx, req.token, req.id); err != nil {
+			return nil, err
+		}
+
+		return removeRes{}, nil
+	}
+}
+
+func listDeliveriesEndpoint(svc webhooks.Service) endpoint.Endpoint {
+	return func(ctx context.Context, request interface{}) (interface{}, error) {
+		req := request.(listDeliveriesReq)
+		if err := req.validate(); err != nil {
+			return nil, err
+		}
+
+		page, err := svc.ListDeliveries(ctx, req.token, req.filter, req.offset, req.limit)
+		if err != nil {
+			return nil, err
+		}
+
+		res := deliveriesPageRes{
+			pageRes: pageRes{
+				Total:  page.Total,
+				Offset: page.Offset,
+				Limit:  page.Limit,
+			},
+			Deliveries: []deliveryRes{},
+		}
+		for _, d := range page.Deliveries {
+			res.Deliveries = append(res.Deliveries, toDeliveryRes(d))
+		}
+
+		return res, nil
+	}
+}
@@ -0,0 +1,587 @@
+// Copyright (c) Mainflux
+// SPDX-License-Identifier: Apache-2.0
+
+package api_test
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/mainflux/mainflux/logger"
+	"github.com/mainflux/mainflux/pkg/messaging"
+	mfsdk "github.com/mainflux/mainflux/pkg/sdk/go"
+	"github.com/mainflux/mainflux/pkg/uuid"
+	"github.com/mainflux/mainflux/things"
+	thingsapi "github.com/mainflux/mainflux/things/api/things/http"
+	thmocks "github.com/mainflux/mainflux/things/mocks"
+	"github.com/mainflux/mainflux/webhooks"
+	"github.com/mainflux/mainflux/webhooks/api"
+	"github.com/mainflux/mainflux/webhooks/mocks"
+	"github.com/opentracing/opentracing-go/mocktracer"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const (
+	token       = "token"
+	wrongValue  = "wrong-value"
+	email       = "user@example.com"
+	contentType = "application/json"
+)
+
+var testLog, _ = logger.New(ioutil.Discard, logger.Error.String())
+
+type testRequest struct {
+	client      *http.Client
+	method      string
+	url         string
+	contentType string
+	token       string
+	body        io.Reader
+}
+
+func (tr testRequest) make() (*http.Response, error) {
+	req, err := http.NewRequest(tr.method, tr.url, tr.body)
+	if err != nil {
+		return nil, err
+	}
+	if tr.token != "" {
+		req.Header.Set("Authorization", tr.token)
+	}
+	if tr.contentType != "" {
+		req.Header.Set("Content-Type", tr.contentType)
+	}
+	return tr.client.Do(req)
+}
+
+type env struct {
+	svc    webhooks.Service
+	chID   string
+	target string
+}
+
+func newEnv(t *testing.T) env {
+	tokens := map[string]string{token: email}
+
+	conns := make(chan thmocks.Connection)
+	thingsRepo := thmocks.NewThingRepository(conns)
+	channelsRepo := thmocks.NewChannelRepository(thingsRepo, conns)
+	groupsRepo := thmocks.NewGroupRepository(thingsRepo, channelsRepo)
+	ths := things.New(thmocks.NewAuthService(tokens), thmocks.NewUsersService(map[string]map[string]string{}), thingsRepo, channelsRepo, groupsRepo, thmocks.NewChannelCache(), thmocks.NewThingCache(), uuid.NewMock())
+
+	server := httptest.NewServer(thingsapi.MakeHandler(mocktracer.New(), ths))
+	t.Cleanup(server.Close)
+
+	// Target responds with 200 OK to "/ok" and with 500 to all other paths.
+	target := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/ok" {
+			w.WriteHeader(http.StatusInternalServerError)
+		}
+	}))
+	t.Cleanup(target.Close)
+
+	chs, err := ths.CreateChannels(context.Background(), token, things.Channel{Name: "src"})
+	require.Nil(t, err, fmt.Sprintf("unexpected error: %s", err))
+
+	sdk := mfsdk.NewSDK(mfsdk.Config{BaseURL: server.URL})
+	svc := webhooks.New(
+		mocks.NewAuthNServiceClient(tokens),
+		mocks.NewWebhookRepository(),
+		mocks.NewDeliveryRepository(),
+		sdk,
+		target.Client(),
+		webhooks.RetryPolicy{Attempts: 2, Backoff: time.Millisecond},
+		10,
+		uuid.NewMock(),
+		testLog,
+	)
+
+	return env{
+		svc:    svc,
+		chID:   chs[0].ID,
+		target: target.URL,
+	}
+}
+
+func newServer(svc webhooks.Service) *httptest.Server {
+	return httptest.NewServer(api.MakeHandler(svc))
+}
+
+func toJSON(data interface{}) string {
+	jsonData, _ := json.Marshal(data)
+	return string(jsonData)
+}
+
+func webhookBody(channel, url, subtopic string) string {
+	return toJSON(map[string]interface{}{
+		"channel":  channel,
+		"url":      url,
+		"subtopic": subtopic,
+		"headers":  map[string]string{"Authorization": "Bearer key"},
+	})
+}
+
+func TestCreateWebhook(t *testing.T) {
+	e := newEnv(t)
+	ts := newServer(e.svc)
+	defer ts.Close()
+
+	cases := []struct {
+		desc        string
+		body        string
+		contentType string
+		token       string
+		status      int
+		location    string
+	}{
+		{
+			desc:        "create valid webhook",
+			body:        webhookBody(e.chID, e.target, "sensors.>"),
+			contentType: contentType,
+			token:       token,
+			status:      http.StatusCreated,
+			location:    fmt.Sprintf("/webhooks/%s%012d", uuid.Prefix, 1),
+		},
+		{
+			desc:        "create webhook with invalid token",
+			body:        webhookBody(e.chID, e.target, ""),
+			contentType: contentType,
+			token:       wrongValue,
+			status:      http.StatusForbidden,
+		},
+		{
+			desc:        "create webhook for non-existing channel",
+			body:        webhookBody(wrongValue, e.target, ""),
+			contentType: contentType,
+			token:       token,
+			status:      http.StatusNotFound,
+		},
+		{
+			desc:        "create webhook with invalid URL",
+			body:        webhookBody(e.chID, "example.com", ""),
+			contentType: contentType,
+			token:       token,
+			status:      http.StatusBadRequest,
+		},
+		{
+			desc:        "create webhook with invalid subtopic filter",
+			body:        webhookBody(e.chID, e.target, "sensors..temperature"),
+			contentType: contentType,
+			token:       token,
+			status:      http.StatusBadRequest,
+		},
+		{
+			desc:        "create webhook with malformed JSON",
+			body:        "{",
+			contentType: contentType,
+			token:       token,
+			status:      http.StatusBadRequest,
+		},
+		{
+			desc:        "create webhook with invalid content type",
+			body:        webhookBody(e.chID, e.target, ""),
+			contentType: "text/plain",
+			token:       token,
+			status:      http.StatusUnsupportedMediaType,
+		},
+	}
+
+	for _, tc := range cases {
+		req := testRequest{
+			client:      ts.Client(),
+			method:      http.MethodPost,
+			url:         fmt.Sprintf("%s/webhooks", ts.URL),
+			contentType: tc.contentType,
+			token:       tc.token,
+			body:        strings.NewReader(tc.body),
+		}
+		res, err := req.make()
+		assert.Nil(t, err, fmt.Sprintf("%s: unexpected error %s", tc.desc, err))
+		assert.Equal(t, tc.status, res.StatusCode, fmt.Sprintf("%s: expected status code %d got %d", tc.desc, tc.status, res.StatusCode))
+		location := res.Header.Get("Location")
+		assert.Equal(t, tc.location, location, fmt.Sprintf("%s: expected location %s got %s", tc.desc, tc.location, location))
+	}
+}
+
+func TestViewWebhook(t *testing.T) {
+	e := newEnv(t)
+	ts := newServer(e.svc)
+	defer ts.Close()
+
+	saved, err := e.svc.CreateWebhook(context.Background(), token, webhooks.Webhook{Channel: e.chID, URL: e.target})
+	require.Nil(t, err, fmt.Sprintf("unexpected error: %s", err))
+
+	cases := []struct {
+		desc   string
+		id     string
+		token  string
+		status int
+	}{
+		{
+			desc:   "view existing webhook",
+			id:     saved.ID,
+			token:  token,
+			status: http.StatusOK,
+		},
+		{
+			desc:   "view non-existing webhook",
+			id:     wrongValue,
+			token:  token,
+			status: http.StatusNotFound,
+		},
+		{
+			desc:   "view webhook with invalid token",
+			id:     saved.ID,
+			token:  wrongValue,
+			status: http.StatusForbidden,
+		},
+	}
+
+	for _, tc := range cases {
+		req := testRequest{
+			client: ts.Client(),
+			method: http.MethodGet,
+			url:    fmt.Sprintf("%s/webhooks/%s", ts.URL, tc.id),
+			token:  tc.token,
+		}
+		res, err := req.make()
+		assert.Nil(t, err, fmt.Sprintf("%s: unexpected error %s", tc.desc, err))
+		assert.Equal(t, tc.status, res.StatusCode, fmt.Sprintf("%s: expected status code %d got %d", tc.desc, tc.status, res.StatusCode))
+		if tc.status != http.StatusOK {
+			continue
+		}
+
+		var wh struct {
+			ID     string `json:"id"`
+			Secret string `json:"secret"`
+		}
+		err = json.NewDecoder(res.Body).Decode(&wh)
+		assert.Nil(t, err, fmt.Sprintf("%s: unexpected error %s", tc.desc, err))
+		assert.Equal(t, saved.ID, wh.ID, fmt.Sprintf("%s: expected id %s got %s", tc.desc, saved.ID, wh.ID))
+		assert.Equal(t, saved.Secret, wh.Secret, fmt.Sprintf("%s: expected secret %s got %s", tc.desc, saved.Secret, wh.Secret))
+	}
+}
+
+func TestUpdateWebhook(t *testing.T) {
+	e := newEnv(t)
+	ts := newServer(e.svc)
+	defer ts.Close()
+
+	saved, err := e.svc.CreateWebhook(context.Background(), token, webhooks.Webhook{Channel: e.chID, URL: e.target})
+	require.Nil(t, err, fmt.Sprintf("unexpected error: %s", err))
+
+	cases := []struct {
+		desc        string
+		id          string
+		body        string
+		contentType string
+		token       string
+		status      int
+	}{
+		{
+			desc:        "update existing webhook",
+			id:          saved.ID,
+			body:        webhookBody(e.chID, e.target+"/ok", "sensors.*"),
+			contentType: contentType,
+			token:       token,
+			status:      http.StatusOK,
+		},
+		{
+			desc:        "update non-existing webhook",
+			id:          wrongValue,
+			body:        webhookBody(e.chID, e.target, ""),
+			contentType: contentType,
+			token:       token,
+			status:      http.StatusNotFound,
+		},
+		{
+			desc:        "update webhook with invalid URL",
+			id:          saved.ID,
+			body:        webhookBody(e.chID, "", ""),
+			contentType: contentType,
+			token:       token,
+			status:      http.StatusBadRequest,
+		},
+		{
+			desc:        "update webhook with invalid token",
+			id:          saved.ID,
+			body:        webhookBody(e.chID, e.target, ""),
+			contentType: contentType,
+			token:       wrongValue,
+			status:      http.StatusForbidden,
+		},
+		{
+			desc:        "update webhook with invalid content type",
+			id:          saved.ID,
+			body:        webhookBody(e.chID, e.target, ""),
+			contentType: "text/plain",
+			token:       token,
+			status:      http.StatusUnsupportedMediaType,
+		},
+	}
+
+	for _, tc := range cases {
+		req := testRequest{
+			client:      ts.Client(),
+			method:      http.MethodPut,
+			url:         fmt.Sprintf("%s/webhooks/%s", ts.URL, tc.id),
+			contentType: tc.contentType,
+			token:       tc.token,
+			body:        strings.NewReader(tc.body),
+		}
+		res, err := req.make()
+		assert.Nil(t, err, fmt.Sprintf("%s: unexpected error %s", tc.desc, err))
+		assert.Equal(t, tc.status, res.StatusCode, fmt.Sprintf("%s: expected status code %d got %d", tc.desc, tc.status, res.StatusCode))
+	}
+}
+
+func TestListWebhooks(t *testing.T) {
+	e := newEnv(t)
+	ts := newServer(e.svc)
+	defer ts.Close()
+
+	n := 5
+	for i := 0; i < n; i++ {
+		_, err := e.svc.CreateWebhook(context.Background(), token, webhooks.Webhook{Channel: e.chID, URL: e.target})
+		require.Nil(t, err, fmt.Sprintf("unexpected error: %s", err))
+	}
+
+	cases := []struct {
+		desc   string
+		url    string
+		token  string
+		status int
+		size   int
+	}{
+		{
+			desc:   "list webhooks",
+			url:    fmt.Sprintf("%s/webhooks", ts.URL),
+			token:  token,
+			status: http.StatusOK,
+			size:   n,
+		},
+		{
+			desc:   "list webhooks with offset and limit",
+			url:    fmt.Sprintf("%s/webhooks?offset=1&limit=2", ts.URL),
+			token:  token,
+			status: http.StatusOK,
+			size:   2,
+		},
+		{
+			desc:   "list webhooks with limit exceeding maximum",
+			url:    fmt.Sprintf("%s/webhooks?limit=1000", ts.URL),
+			token:  token,
+			status: http.StatusBadRequest,
+		},
+		{
+			desc:   "list webhooks with invalid offset",
+			url:    fmt.Sprintf("%s/webhooks?offset=e", ts.URL),
+			token:  token,
+			status: http.StatusBadRequest,
+		},
+		{
+			desc:   "list webhooks with invalid token",
+			url:    fmt.Sprintf("%s/webhooks", ts.URL),
+			token:  wrongValue,
+			status: http.StatusForbidden,
+		},
+	}
+
+	for _, tc := range cases {
+		req := testRequest{
+			client: ts.Client(),
+			method: http.MethodGet,
+			url:    tc.url,
+			token:  tc.token,
+		}
+		res, err := req.make()
+		assert.Nil(t, err, fmt.Sprintf("%s: unexpected error %s", tc.desc, err))
+		assert.Equal(t, tc.status, res.StatusCode, fmt.Sprintf("%s: expected status code %d got %d", tc.desc, tc.status, res.StatusCode))
+		if tc.status != http.StatusOK {
+			continue
+		}
+
+		var page struct {
+			Webhooks []interface{} `json:"webhooks"`
+		}
+		err = json.NewDecoder(res.Body).Decode(&page)
+		assert.Nil(t, err, fmt.Sprintf("%s: unexpected error %s", tc.desc, err))
+		assert.Equal(t, tc.size, len(page.Webhooks), fmt.Sprintf("%s: expected %d webhooks got %d", tc.desc, tc.size, len(page.Webhooks)))
+	}
+}
+
+func TestRemoveWebhook(t *testing.T) {
+	e := newEnv(t)
+	ts := newServer(e.svc)
+	defer ts.Close()
+
+	saved, err := e.svc.CreateWebhook(context.Background(), token, webhooks.Webhook{Channel: e.chID, URL: e.target})
+	require.Nil(t, err, fmt.Sprintf("unexpected error: %s", err))
+
+	cases := []struct {
+		desc   string
+		id     string
+		token  string
+		status int
+	}{
+		{
+			desc:   "remove webhook with invalid token",
+			id:     saved.ID,
+			token:  wrongValue,
+			status: http.StatusForbidden,
+		},
+		{
+			desc:   "remove existing webhook",
+			id:     saved.ID,
+			token:  token,
+			status: http.StatusNoContent,
+		},
+		{
+			desc:   "remove removed webhook",
+			id:     saved.ID,
+			token:  token,
+			status: http.StatusNoContent,
+		},
+	}
+
+	for _, tc := range cases {
+		req := testRequest{
+			client: ts.Client(),
+			method: http.MethodDelete,
+			url:    fmt.Sprintf("%s/webhooks/%s", ts.URL, tc.id),
+			token:  tc.token,
+		}
+		res, err := req.make()
+		assert.Nil(t, err, fmt.Sprintf("%s: unexpected error %s", tc.desc, err))
+		assert.Equal(t, tc.status, res.StatusCode, fmt.Sprintf("%s: expected status code %d got %d", tc.desc, tc.status, res.StatusCode))
+	}
+}
+
+func TestListDeliveries(t *testing.T) {
+	e := newEnv(t)
+	ts := newServer(e.svc)
+	defer ts.Close()
+
+	ok, err := e.svc.CreateWebhook(context.Background(), token, webhooks.Webhook{Channel: e.chID, URL: e.target + "/ok"})
+	require.Nil(t, err, fmt.Sprintf("unexpected error: %s", err))
+	_, err = e.svc.CreateWebhook(context.Background(), token, webhooks.Webhook{Channel: e.chID, URL: e.target + "/fail"})
+	require.Nil(t, err, fmt.Sprintf("unexpected error: %s", err))
+
+	msg := messaging.Message{
+		Channel:  e.chID,
+		Protocol: "http",
+		Payload:  []byte(`[{"n":"temperature","v":35}]`),
+	}
+	err = e.svc.Consume(msg)
+	require.Nil(t, err, fmt.Sprintf("unexpected error: %s", err))
+	recorded := func() bool {
+		page, err := e.svc.ListDeliveries(context.Background(), token, webhooks.DeliveryFilter{}, 0, 1)
+		return err == nil && page.Total == 2
+	}
+	require.Eventually(t, recorded, time.Second, time.Millisecond, "expected deliveries to be recorded")
+
+	cases := []struct {
+		desc   string
+		url    string
+		token  string
+		status int
+		size   int
+	}{
+		{
+			desc:   "list deliveries",
+			url:    fmt.Sprintf("%s/deliveries", ts.URL),
+			token:  token,
+			status: http.StatusOK,
+			size:   2,
+		},
+		{
+			desc:   "list deliveries to webhook",
+			url:    fmt.Sprintf("%s/deliveries?webhook=%s", ts.URL, ok.ID),
+			token:  token,
+			status: http.StatusOK,
+			size:   1,
+		},
+		{
+			desc:   "list successful deliveries",
+			url:    fmt.Sprintf("%s/deliveries?status=%s", ts.URL, webhooks.StatusDelivered),
+			token:  token,
+			status: http.StatusOK,
+			size:   1,
+		},
+		{
+			desc:   "list deliveries with invalid status",
+			url:    fmt.Sprintf("%s/deliveries?status=%s", ts.URL, wrongValue),
+			token:  token,
+			status: http.StatusBadRequest,
+		},
+		{
+			desc:   "list deliveries with invalid token",
+			url:    fmt.Sprintf("%s/deliveries", ts.URL),
+			token:  wrongValue,
+			status: http.StatusForbidden,
+		},
+		{
+			desc:   "list dead letters",
+			url:    fmt.Sprintf("%s/deadletters", ts.URL),
+			token:  token,
+			status: http.StatusOK,
+			size:   1,
+		},
+		{
+			desc:   "list dead letters of successful webhook",
+			url:    fmt.Sprintf("%s/deadletters?webhook=%s", ts.URL, ok.ID),
+			token:  token,
+			status: http.StatusOK,
+			size:   0,
+		},
+		{
+			desc:   "list dead letters with status",
+			url:    fmt.Sprintf("%s/deadletters?status=%s", ts.URL, webhooks.StatusDelivered),
+			token:  token,
+			status: http.StatusBadRequest,
+		},
+		{
+			desc:   "list dead letters with invalid limit",
+			url:    fmt.Sprintf("%s/deadletters?limit=0", ts.URL),
+			token:  token,
+			status: http.StatusBadRequest,
+		},
+	}
+
+	for _, tc := range cases {
+		req := testRequest{
+			client: ts.Client(),
+			method: http.MethodGet,
+			url:    tc.url,
+			token:  tc.token,
+		}
+		res, err := req.make()
+		assert.Nil(t, err, fmt.Sprintf("%s: unexpected error %s", tc.desc, err))
+		assert.Equal(t, tc.status, res.StatusCode, fmt.Sprintf("%s: expected status code %d got %d", tc.desc, tc.status, res.StatusCode))
+		if tc.status != http.StatusOK {
+			continue
+		}
+
+		var page struct {
+			Deliveries []struct {
+				Status   string `json:"status"`
+				Attempts uint64 `json:"attempts"`
+				Payload  []byte `json:"payload"`
+			} `json:"deliveries"`
+		}
+		err = json.NewDecoder(res.Body).Decode(&page)
+		assert.Nil(t, err, fmt.Sprintf("%s: unexpected error %s", tc.desc, err))
+		assert.Equal(t, tc.size, len(page.Deliveries), fmt.Sprintf("%s: expected %d deliveries got %d", tc.desc, tc.size, len(page.Deliveries)))
+		for _, d := range page.Deliveries {
+			assert.Equal(t, msg.Payload, d.Payload, fmt.Sprintf("%s: expected payload %s got %s", tc.desc, msg.Payload, d.Payload))
+		}
+	}
+}
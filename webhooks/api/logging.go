@@ -0,0 +1,119 @@
+// Copyright (c) Mainflux
+// SPDX-License-Identifier: Apache-2.0
+
+// +build !test
+
+package api
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	log "github.com/mainflux/mainflux/logger"
+	"github.com/mainflux/mainflux/pkg/messaging"
+	"github.com/mainflux/mainflux/webhooks"
+)
+
+var _ webhooks.Service = (*loggingMiddleware)(nil)
+
+type loggingMiddleware struct {
+	logger log.Logger
+	svc    webhooks.Service
+}
+
+// LoggingMiddleware adds logging facilities to the core service.
+func LoggingMiddleware(svc webhooks.Service, logger log.Logger) webhooks.Service {
+	return &loggingMiddleware{logger, svc}
+}
+
+func (lm *loggingMiddleware) CreateWebhook(ctx context.Context, token string, wh webhooks.Webhook) (saved webhooks.Webhook, err error) {
+	defer func(begin time.Time) {
+		message := fmt.Sprintf("Method create_webhook for token %s and channel %s took %s to complete", token, wh.Channel, time.Since(begin))
+		if err != nil {
+			lm.logger.Warn(fmt.Sprintf("%s with error: %s.", message, err))
+			return
+		}
+		lm.logger.Info(fmt.Sprintf("%s without errors.", message))
+	}(time.Now())
+
+	return lm.svc.CreateWebhook(ctx, token, wh)
+}
+
+func (lm *loggingMiddleware) ViewWebhook(ctx context.Context, token, id string) (wh webhooks.Webhook, err error) {
+	defer func(begin time.Time) {
+		message := fmt.Sprintf("Method view_webhook for token %s and webhook %s took %s to complete", token, id, time.Since(begin))
+		if err != nil {
+			lm.logger.Warn(fmt.Sprintf("%s with error: %s.", message, err))
+			return
+		}
+		lm.logger.Info(fmt.Sprintf("%s without errors.", message))
+	}(time.Now())
+
+	return lm.svc.ViewWebhook(ctx, token, id)
+}
+
+func (lm *loggingMiddleware) UpdateWebhook(ctx context.Context, token string, wh webhooks.Webhook) (err error) {
+	defer func(begin time.Time) {
+		message := fmt.Sprintf("Method update_webhook for token %s and webhook %s took %s to complete", token, wh.ID, time.Since(begin))
+		if err != nil {
+			lm.logger.Warn(fmt.Sprintf("%s with error: %s.", message, err))
+			return
+		}
+		lm.logger.Info(fmt.Sprintf("%s without errors.", message))
+	}(time.Now())
+
+	return lm.svc.UpdateWebhook(ctx, token, wh)
+}
+
+func (lm *loggingMiddleware) ListWebhooks(ctx context.Context, token string, offset, limit uint64) (page webhooks.WebhooksPage, err error) {
+	defer func(begin time.Time) {
+		message := fmt.Sprintf("Method list_webhooks for token %s took %s to complete", token, time.Since(begin))
+		if err != nil {
+			lm.logger.Warn(fmt.Sprintf("%s with error: %s.", message, err))
+			return
+		}
+		lm.logger.Info(fmt.Sprintf("%s without errors.", message))
+	}(time.Now())
+
+	return lm.svc.ListWebhooks(ctx, token, offset, limit)
+}
+
+func (lm *loggingMiddleware) RemoveWebhook(ctx context.Context, token, id string) (err error) {
+	defer func(begin time.Time) {
+		message := fmt.Sprintf("Method remove_webhook for token %s and webhook %s took %s to complete", token, id, time.Since(begin))
+		if err != nil {
+			lm.logger.Warn(fmt.Sprintf("%s with error: %s.", message, err))
+			return
+		}
+		lm.logger.Info(fmt.Sprintf("%s without errors.", message))
+	}(time.Now())
+
+	return lm.svc.RemoveWebhook(ctx, token, id)
+}
+
+func (lm *loggingMiddleware) ListDeliveries(ctx context.Context, token string, filter webhooks.DeliveryFilter, offset, limit uint64) (page webhooks.DeliveriesPage, err error) {
+	defer func(begin time.Time) {
+		message := fmt.Sprintf("Method list_deliveries for token %s and webhook %s took %s to complete", token, filter.WebhookID, time.Since(begin))
+		if err != nil {
+			lm.logger.Warn(fmt.Sprintf("%s with error: %s.", message, err))
+			return
+		}
+		lm.logger.Info(fmt.Sprintf("%s without errors.", message))
+	}(time.Now())
+
+	return lm.svc.ListDeliveries(ctx, token, filter, offset, limit)
+}
+
+func (lm *loggingMiddleware) Consume(msg messaging.Message) (err error) {
+	defer func(begin time.Time) {
+		message := fmt.Sprintf("Method consume for channel %s took %s to complete", msg.Channel, time.Since(begin))
+		if err != nil {
+			lm.logger.Warn(fmt.Sprintf("%s with error: %s.", message, err))
+			return
+		}
+		lm.logger.Info(fmt.Sprintf("%s without errors.", message))
+	}(time.Now())
+
+	return lm.svc.Consume(msg)
+}
@@ -0,0 +1,108 @@
+// Copyright (c) Mainflux
+// SPDX-License-Identifier: Apache-2.0
+
+package api
+
+import (
+	"github.com/mainflux/mainflux/webhooks"
+)
+
+const maxHeaders = 32
+
+type apiReq interface {
+	validate() error
+}
+
+type webhookReq struct {
+	token       string
+	id          string
+	Channel     string            `json:"channel"`
+	URL         string            `json:"url"`
+	Headers     map[string]string `json:"headers"`
+	ContentType string            `json:"content_type"`
+	Subtopic    string            `json:"subtopic"`
+	Secret      string            `json:"secret"`
+}
+
+func (req webhookReq) validate() error {
+	if req.token == "" {
+		return webhooks.ErrUnauthorizedAccess
+	}
+
+	if len(req.Headers) > maxHeaders {
+		return webhooks.ErrMalformedEntity
+	}
+
+	return req.webhook().Validate()
+}
+
+func (req webhookReq) webhook() webhooks.Webhook {
+	return webhooks.Webhook{
+		ID:          req.id,
+		Channel:     req.Channel,
+		URL:         req.URL,
+		Headers:     req.Headers,
+		ContentType: req.ContentType,
+		Subtopic:    req.Subtopic,
+		Secret:      req.Secret,
+	}
+}
+
+type viewWebhookReq struct {
+	token string
+	id    string
+}
+
+func (req viewWebhookReq) validate() error {
+	if req.token == "" {
+		return webhooks.ErrUnauthorizedAccess
+	}
+
+	if req.id == "" {
+		return webhooks.ErrMalformedEntity
+	}
+
+	return nil
+}
+
+type listReq struct {
+	token  string
+	offset uint64
+	limit  uint64
+}
+
+func (req listReq) validate() error {
+	if req.token == "" {
+		return webhooks.ErrUnauthorizedAccess
+	}
+
+	if req.limit == 0 || req.limit > maxLimit {
+		return webhooks.ErrMalformedEntity
+	}
+
+	return nil
+}
+
+type listDeliveriesReq struct {
+	token  string
+	filter webhooks.DeliveryFilter
+	offset uint64
+	limit  uint64
+}
+
+func (req listDeliveriesReq) validate() error {
+	if req.token == "" {
+		return webhooks.ErrUnauthorizedAccess
+	}
+
+	if req.limit == 0 || req.limit > maxLimit {
+		return webhooks.ErrMalformedEntity
+	}
+
+	switch req.filter.Status {
+	case "", webhooks.StatusDelivered, webhooks.StatusFailed:
+		return nil
+	default:
+		return webhooks.ErrMalformedEntity
+	}
+}
@@ -0,0 +1,172 @@
+// Copyright (c) Mainflux
+// SPDX-License-Identifier: Apache-2.0
+
+package api
+
+import (
+	"fmt"
+	"net/http"
+	"time"
+
+	"github.com/mainflux/mainflux"
+	"github.com/mainflux/mainflux/webhooks"
+)
+
+var (
+	_ mainflux.Response = (*webhookRes)(nil)
+	_ mainflux.Response = (*viewWebhookRes)(nil)
+	_ mainflux.Response = (*webhooksPageRes)(nil)
+	_ mainflux.Response = (*deliveriesPageRes)(nil)
+	_ mainflux.Response = (*removeRes)(nil)
+)
+
+type webhookRes struct {
+	id      string
+	created bool
+}
+
+func (res webhookRes) Code() int {
+	if res.created {
+		return http.StatusCreated
+	}
+
+	return http.StatusOK
+}
+
+func (res webhookRes) Headers() map[string]string {
+	if res.created {
+		return map[string]string{
+			"Location": fmt.Sprintf("/webhooks/%s", res.id),
+		}
+	}
+
+	return map[string]string{}
+}
+
+func (res webhookRes) Empty() bool {
+	return true
+}
+
+type viewWebhookRes struct {
+	ID          string            `json:"id"`
+	Channel     string            `json:"channel"`
+	URL         string            `json:"url"`
+	ReqHeaders  map[string]string `json:"headers,omitempty"`
+	ContentType string            `json:"content_type"`
+	Subtopic    string            `json:"subtopic,omitempty"`
+	Secret      string            `json:"secret"`
+	Created     time.Time         `json:"created"`
+}
+
+func (res viewWebhookRes) Code() int {
+	return http.StatusOK
+}
+
+func (res viewWebhookRes) Headers() map[string]string {
+	return map[string]string{}
+}
+
+func (res viewWebhookRes) Empty() bool {
+	return false
+}
+
+type pageRes struct {
+	Total  uint64 `json:"total"`
+	Offset uint64 `json:"offset"`
+	Limit  uint64 `json:"limit"`
+}
+
+type webhooksPageRes struct {
+	pageRes
+	Webhooks []viewWebhookRes `json:"webhooks"`
+}
+
+func (res webhooksPageRes) Code() int {
+	return http.StatusOK
+}
+
+func (res webhooksPageRes) Headers() map[string]string {
+	return map[string]string{}
+}
+
+func (res webhooksPageRes) Empty() bool {
+	return false
+}
+
+type deliveryRes struct {
+	ID         string    `json:"id"`
+	WebhookID  string    `json:"webhook_id"`
+	Channel    string    `json:"channel"`
+	Subtopic   string    `json:"subtopic,omitempty"`
+	Publisher  string    `json:"publisher"`
+	Payload    []byte    `json:"payload"`
+	Status     string    `json:"status"`
+	Attempts   uint64    `json:"attempts"`
+	StatusCode int       `json:"status_code,omitempty"`
+	Error      string    `json:"error,omitempty"`
+	Created    time.Time `json:"created"`
+}
+
+type deliveriesPageRes struct {
+	pageRes
+	Deliveries []deliveryRes `json:"deliveries"`
+}
+
+func (res deliveriesPageRes) Code() int {
+	return http.StatusOK
+}
+
+func (res deliveriesPageRes) Headers() map[string]string {
+	return map[string]string{}
+}
+
+func (res deliveriesPageRes) Empty() bool {
+	return false
+}
+
+type removeRes struct{}
+
+func (res removeRes) Code() int {
+	return http.StatusNoContent
+}
+
+func (res removeRes) Headers() map[string]string {
+	return map[string]string{}
+}
+
+func (res removeRes) Empty() bool {
+	return true
+}
+
+type errorRes struct {
+	Err string `json:"error"`
+}
+
+func toWebhookRes(wh webhooks.Webhook) viewWebhookRes {
+	return viewWebhookRes{
+		ID:          wh.ID,
+		Channel:     wh.Channel,
+		URL:         wh.URL,
+		ReqHeaders:  wh.Headers,
+		ContentType: wh.ContentType,
+		Subtopic:    wh.Subtopic,
+		Secret:      wh.Secret,
+		Created:     wh.Created,
+	}
+}
+
+func toDeliveryRes(d webhooks.Delivery) deliveryRes {
+	return deliveryRes{
+		ID:         d.ID,
+		WebhookID:  d.WebhookID,
+		Channel:    d.Channel,
+		Subtopic:   d.Subtopic,
+		Publisher:  d.Publisher,
+		Payload:    d.Payload,
+		Status:     d.Status,
+		Attempts:   d.Attempts,
+		StatusCode: d.StatusCode,
+		Error:      d.Error,
+		Created:    d.Created,
+	}
+}
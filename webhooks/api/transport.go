@@ -0,0 +1,257 @@
+// Copyright (c) Mainflux
+// SPDX-License-Identifier: Apache-2.0
+
+package api
+
+import (
+	"context"
+	"encoding/json"
+	"io"
+	"net/http"
+	"strconv"
+	"strings"
+
+	kithttp "github.com/go-kit/kit/transport/http"
+	"github.com/go-zoo/bone"
+	"github.com/mainflux/mainflux"
+	"github.com/mainflux/mainflux/pkg/audit"
+	"github.com/mainflux/mainflux/pkg/errors"
+	"github.com/mainflux/mainflux/webhooks"
+	"github.com/prometheus/client_golang/prometheus/promhttp"
+)
+
+const (
+	contentType  = "application/json"
+	offsetKey    = "offset"
+	limitKey     = "limit"
+	webhookKey   = "webhook"
+	statusKey    = "status"
+	maxLimit     = 100
+	defaultLimit = 10
+)
+
+var (
+	errUnsupportedContentType = errors.New("unsupported content type")
+	errInvalidQueryParams     = errors.New("invalid query params")
+)
+
+// MakeHandler returns a HTTP handler for API endpoints.
+func MakeHandler(svc webhooks.Service) http.Handler {
+	opts := []kithttp.ServerOption{
+		kithttp.ServerErrorEncoder(encodeError),
+		kithttp.ServerBefore(audit.PopulateIP),
+	}
+
+	r := bone.New()
+
+	r.Post("/webhooks", kithttp.NewServer(
+		createWebhookEndpoint(svc),
+		decodeWebhookRequest,
+		encodeResponse,
+		opts...,
+	))
+
+	r.Get("/webhooks", kithttp.NewServer(
+		listWebhooksEndpoint(svc),
+		decodeListRequest,
+		encodeResponse,
+		opts...,
+	))
+
+	r.Get("/webhooks/:id", kithttp.NewServer(
+		viewWebhookEndpoint(svc),
+		decodeViewRequest,
+		encodeResponse,
+		opts...,
+	))
+
+	r.Put("/webhooks/:id", kithttp.NewServer(
+		updateWebhookEndpoint(svc),
+		decodeWebhookRequest,
+		encodeResponse,
+		opts...,
+	))
+
+	r.Delete("/webhooks/:id", kithttp.NewServer(
+		removeWebhookEndpoint(svc),
+		decodeViewRequest,
+		encodeResponse,
+		opts...,
+	))
+
+	r.Get("/deliveries", kithttp.NewServer(
+		listDeliveriesEndpoint(svc),
+		decodeListDeliveriesRequest,
+		encodeResponse,
+		opts...,
+	))
+
+	r.Get("/deadletters", kithttp.NewServer(
+		listDeliveriesEndpoint(svc),
+		decodeListDeadLettersRequest,
+		encodeResponse,
+		opts...,
+	))
+
+	r.GetFunc("/version", mainflux.Version("webhooks"))
+	r.Handle("/metrics", promhttp.Handler())
+
+	return r
+}
+
+func decodeWebhookRequest(_ context.Context, r *http.Request) (interface{}, error) {
+	if !strings.Contains(r.Header.Get("Content-Type"), contentType) {
+		return nil, errUnsupportedContentType
+	}
+
+	req := webhookReq{
+		token: r.Header.Get("Authorization"),
+		id:    bone.GetValue(r, "id"),
+	}
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		return nil, errors.Wrap(webhooks.ErrMalformedEntity, err)
+	}
+
+	return req, nil
+}
+
+func decodeViewRequest(_ context.Context, r *http.Request) (interface{}, error) {
+	req := viewWebhookReq{
+		token: r.Header.Get("Authorization"),
+		id:    bone.GetValue(r, "id"),
+	}
+
+	return req, nil
+}
+
+func decodeListRequest(_ context.Context, r *http.Request) (interface{}, error) {
+	offset, err := readUintQuery(r, offsetKey, 0)
+	if err != nil {
+		return nil, err
+	}
+
+	limit, err := readUintQuery(r, limitKey, defaultLimit)
+	if err != nil {
+		return nil, err
+	}
+
+	req := listReq{
+		token:  r.Header.Get("Authorization"),
+		offset: offset,
+		limit:  limit,
+	}
+
+	return req, nil
+}
+
+func decodeListDeliveriesRequest(_ context.Context, r *http.Request) (interface{}, error) {
+	offset, err := readUintQuery(r, offsetKey, 0)
+	if err != nil {
+		return nil, err
+	}
+
+	limit, err := readUintQuery(r, limitKey, defaultLimit)
+	if err != nil {
+		return nil, err
+	}
+
+	req := listDeliveriesReq{
+		token: r.Header.Get("Authorization"),
+		filter: webhooks.DeliveryFilter{
+			WebhookID: r.URL.Query().Get(webhookKey),
+			Status:    r.URL.Query().Get(statusKey),
+		},
+		offset: offset,
+		limit:  limit,
+	}
+
+	return req, nil
+}
+
+// decodeListDeadLettersRequest decodes the request for deliveries that
+// failed after all of the attempts were exhausted.
+func decodeListDeadLettersRequest(ctx context.Context, r *http.Request) (interface{}, error) {
+	if _, ok := r.URL.Query()[statusKey]; ok {
+		return nil, errInvalidQueryParams
+	}
+
+	req, err := decodeListDeliveriesRequest(ctx, r)
+	if err != nil {
+		return nil, err
+	}
+
+	dlr := req.(listDeliveriesReq)
+	dlr.filter.Status = webhooks.StatusFailed
+
+	return dlr, nil
+}
+
+func readUintQuery(r *http.Request, key string, def uint64) (uint64, error) {
+	vals := bone.GetQuery(r, key)
+	if len(vals) > 1 {
+		return 0, errInvalidQueryParams
+	}
+
+	if len(vals) == 0 {
+		return def, nil
+	}
+
+	val, err := strconv.ParseUint(vals[0], 10, 64)
+	if err != nil {
+		return 0, errors.Wrap(errInvalidQueryParams, err)
+	}
+
+	return val, nil
+}
+
+func encodeResponse(_ context.Context, w http.ResponseWriter, response interface{}) error {
+	w.Header().Set("Content-Type", contentType)
+
+	if ar, ok := response.(mainflux.Response); ok {
+		for k, v := range ar.Headers() {
+			w.Header().Set(k, v)
+		}
+
+		w.WriteHeader(ar.Code())
+
+		if ar.Empty() {
+			return nil
+		}
+	}
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+func encodeError(_ context.Context, err error, w http.ResponseWriter) {
+	switch errorVal := err.(type) {
+	case errors.Error:
+		w.Header().Set("Content-Type", contentType)
+		switch {
+		case errors.Contains(errorVal, errUnsupportedContentType):
+			w.WriteHeader(http.StatusUnsupportedMediaType)
+		case errors.Contains(errorVal, errInvalidQueryParams):
+			w.WriteHeader(http.StatusBadRequest)
+		case errors.Contains(errorVal, webhooks.ErrMalformedEntity):
+			w.WriteHeader(http.StatusBadRequest)
+		case errors.Contains(errorVal, webhooks.ErrNotFound):
+			w.WriteHeader(http.StatusNotFound)
+		case errors.Contains(errorVal, webhooks.ErrUnauthorizedAccess):
+			w.WriteHeader(http.StatusForbidden)
+		case errors.Contains(errorVal, webhooks.ErrConflict):
+			w.WriteHeader(http.StatusConflict)
+		case errors.Contains(errorVal, io.EOF):
+			w.WriteHeader(http.StatusBadRequest)
+		case errors.Contains(errorVal, io.ErrUnexpectedEOF):
+			w.WriteHeader(http.StatusBadRequest)
+		default:
+			w.WriteHeader(http.StatusInternalServerError)
+		}
+		if errorVal.Msg() != "" {
+			if err := json.NewEncoder(w).Encode(errorRes{Err: errorVal.Msg()}); err != nil {
+				w.WriteHeader(http.StatusInternalServerError)
+			}
+		}
+	default:
+		w.WriteHeader(http.StatusInternalServerError)
+	}
+}
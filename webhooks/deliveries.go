@@ -0,0 +1,59 @@
+// Copyright (c) Mainflux
+// SPDX-License-Identifier: Apache-2.0
+
+package webhooks
+
+import (
+	"context"
+	"time"
+)
+
+// Delivery statuses.
+const (
+	StatusDelivered = "delivered"
+	StatusFailed    = "failed"
+)
+
+// Delivery represents the outcome of forwarding a single message to the
+// webhook. Failed deliveries represent dead letters, so the message payload
+// is kept along with the last error.
+type Delivery struct {
+	ID         string
+	WebhookID  string
+	Owner      string
+	Channel    string
+	Subtopic   string
+	Publisher  string
+	Payload    []byte
+	Status     string
+	Attempts   uint64
+	StatusCode int
+	Error      string
+	Created    time.Time
+}
+
+// DeliveriesPage contains page related metadata as well as a list of
+// deliveries that belong to this page.
+type DeliveriesPage struct {
+	Total      uint64
+	Offset     uint64
+	Limit      uint64
+	Deliveries []Delivery
+}
+
+// DeliveryFilter is used to narrow down retrieved deliveries. Empty fields
+// are not used for filtering.
+type DeliveryFilter struct {
+	WebhookID string
+	Status    string
+}
+
+// DeliveryRepository specifies a delivery persistence API.
+type DeliveryRepository interface {
+	// Save persists the delivery.
+	Save(ctx context.Context, d Delivery) (string, error)
+
+	// RetrieveAll retrieves the subset of deliveries owned by the specified
+	// user. Deliveries are sorted from the newest to the oldest.
+	RetrieveAll(ctx context.Context, owner string, filter DeliveryFilter, offset, limit uint64) (DeliveriesPage, error)
+}
@@ -0,0 +1,9 @@
+// Copyright (c) Mainflux
+// SPDX-License-Identifier: Apache-2.0
+
+// Package webhooks contains the domain concept definitions needed to support
+// Mainflux webhooks service functionality. Webhook is a user defined
+// subscription forwarding messages published to a channel to an external
+// HTTP endpoint. Every delivery is signed, retried on failure and recorded,
+// so that undelivered messages can be inspected as dead letters.
+package webhooks
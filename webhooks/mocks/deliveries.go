@@ -0,0 +1,62 @@
+// Copyright (c) Mainflux
+// SPDX-License-Identifier: Apache-2.0
+
+package mocks
+
+import (
+	"context"
+	"sort"
+	"sync"
+
+	"github.com/mainflux/mainflux/webhooks"
+)
+
+var _ webhooks.DeliveryRepository = (*deliveryRepositoryMock)(nil)
+
+type deliveryRepositoryMock struct {
+	mu         sync.Mutex
+	deliveries []webhooks.Delivery
+}
+
+// NewDeliveryRepository creates in-memory delivery repository.
+func NewDeliveryRepository() webhooks.DeliveryRepository {
+	return &deliveryRepositoryMock{}
+}
+
+func (drm *deliveryRepositoryMock) Save(_ context.Context, d webhooks.Delivery) (string, error) {
+	drm.mu.Lock()
+	defer drm.mu.Unlock()
+
+	drm.deliveries = append(drm.deliveries, d)
+	return d.ID, nil
+}
+
+func (drm *deliveryRepositoryMock) RetrieveAll(_ context.Context, owner string, filter webhooks.DeliveryFilter, offset, limit uint64) (webhooks.DeliveriesPage, error) {
+	drm.mu.Lock()
+	defer drm.mu.Unlock()
+
+	items := []webhooks.Delivery{}
+	for _, d := range drm.deliveries {
+		if d.Owner != owner {
+			continue
+		}
+		if filter.WebhookID != "" && d.WebhookID != filter.WebhookID {
+			continue
+		}
+		if filter.Status != "" && d.Status != filter.Status {
+			continue
+		}
+		items = append(items, d)
+	}
+	sort.SliceStable(items, func(i, j int) bool {
+		return items[i].Created.After(items[j].Created)
+	})
+
+	total := uint64(len(items))
+	return webhooks.DeliveriesPage{
+		Total:      total,
+		Offset:     offset,
+		Limit:      limit,
+		Deliveries: items[min(offset, total):min(offset+limit, total)],
+	}, nil
+}
@@ -0,0 +1,120 @@
+// Copyright (c) Mainflux
+// SPDX-License-Identifier: Apache-2.0
+
+package mocks
+
+import (
+	"context"
+	"sort"
+	"sync"
+
+	"github.com/mainflux/mainflux/webhooks"
+)
+
+var _ webhooks.WebhookRepository = (*webhookRepositoryMock)(nil)
+
+type webhookRepositoryMock struct {
+	mu       sync.Mutex
+	webhooks map[string]webhooks.Webhook
+}
+
+// NewWebhookRepository creates in-memory webhook repository.
+func NewWebhookRepository() webhooks.WebhookRepository {
+	return &webhookRepositoryMock{
+		webhooks: make(map[string]webhooks.Webhook),
+	}
+}
+
+func (wrm *webhookRepositoryMock) Save(_ context.Context, wh webhooks.Webhook) (string, error) {
+	wrm.mu.Lock()
+	defer wrm.mu.Unlock()
+
+	if _, ok := wrm.webhooks[wh.ID]; ok {
+		return "", webhooks.ErrConflict
+	}
+
+	wrm.webhooks[wh.ID] = wh
+	return wh.ID, nil
+}
+
+func (wrm *webhookRepositoryMock) Update(_ context.Context, wh webhooks.Webhook) error {
+	wrm.mu.Lock()
+	defer wrm.mu.Unlock()
+
+	old, ok := wrm.webhooks[wh.ID]
+	if !ok || old.Owner != wh.Owner {
+		return webhooks.ErrNotFound
+	}
+
+	wh.Created = old.Created
+	wrm.webhooks[wh.ID] = wh
+	return nil
+}
+
+func (wrm *webhookRepositoryMock) RetrieveByID(_ context.Context, owner, id string) (webhooks.Webhook, error) {
+	wrm.mu.Lock()
+	defer wrm.mu.Unlock()
+
+	wh, ok := wrm.webhooks[id]
+	if !ok || wh.Owner != owner {
+		return webhooks.Webhook{}, webhooks.ErrNotFound
+	}
+
+	return wh, nil
+}
+
+func (wrm *webhookRepositoryMock) RetrieveAll(_ context.Context, owner string, offset, limit uint64) (webhooks.WebhooksPage, error) {
+	wrm.mu.Lock()
+	defer wrm.mu.Unlock()
+
+	items := []webhooks.Webhook{}
+	for _, wh := range wrm.webhooks {
+		if wh.Owner == owner {
+			items = append(items, wh)
+		}
+	}
+	sort.SliceStable(items, func(i, j int) bool {
+		return items[i].ID < items[j].ID
+	})
+
+	total := uint64(len(items))
+	return webhooks.WebhooksPage{
+		Total:    total,
+		Offset:   offset,
+		Limit:    limit,
+		Webhooks: items[min(offset, total):min(offset+limit, total)],
+	}, nil
+}
+
+func (wrm *webhookRepositoryMock) RetrieveByChannel(_ context.Context, channel string) ([]webhooks.Webhook, error) {
+	wrm.mu.Lock()
+	defer wrm.mu.Unlock()
+
+	items := []webhooks.Webhook{}
+	for _, wh := range wrm.webhooks {
+		if wh.Channel == channel {
+			items = append(items, wh)
+		}
+	}
+
+	return items, nil
+}
+
+func (wrm *webhookRepositoryMock) Remove(_ context.Context, owner, id string) error {
+	wrm.mu.Lock()
+	defer wrm.mu.Unlock()
+
+	if wh, ok := wrm.webhooks[id]; ok && wh.Owner == owner {
+		delete(wrm.webhooks, id)
+	}
+
+	return nil
+}
+
+func min(a, b uint64) uint64 {
+	if a < b {
+		return a
+	}
+
+	return b
+}
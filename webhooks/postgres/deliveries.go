@@ -0,0 +1,150 @@
+// Copyright (c) Mainflux
+// SPDX-License-Identifier: Apache-2.0
+
+package postgres
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/jmoiron/sqlx"
+	"github.com/lib/pq"
+	"github.com/mainflux/mainflux/pkg/errors"
+	"github.com/mainflux/mainflux/webhooks"
+)
+
+var (
+	errSaveDelivery     = errors.New("failed to save delivery to database")
+	errRetrieveDelivery = errors.New("failed to retrieve deliveries from database")
+)
+
+var _ webhooks.DeliveryRepository = (*deliveryRepository)(nil)
+
+type deliveryRepository struct {
+	db *sqlx.DB
+}
+
+// NewDeliveryRepository instantiates a PostgreSQL implementation of delivery
+// repository.
+func NewDeliveryRepository(db *sqlx.DB) webhooks.DeliveryRepository {
+	return &deliveryRepository{db: db}
+}
+
+func (dr deliveryRepository) Save(ctx context.Context, d webhooks.Delivery) (string, error) {
+	q := `INSERT INTO deliveries (id, webhook_id, owner, channel, subtopic, publisher, payload, status, attempts, status_code, error, created)
+		  VALUES (:id, :webhook_id, :owner, :channel, :subtopic, :publisher, :payload, :status, :attempts, :status_code, :error, :created)`
+
+	if _, err := dr.db.NamedExecContext(ctx, q, toDBDelivery(d)); err != nil {
+		return "", errors.Wrap(errSaveDelivery, err)
+	}
+
+	return d.ID, nil
+}
+
+func (dr deliveryRepository) RetrieveAll(ctx context.Context, owner string, filter webhooks.DeliveryFilter, offset, limit uint64) (webhooks.DeliveriesPage, error) {
+	conds := []string{"owner = :owner"}
+	if filter.WebhookID != "" {
+		conds = append(conds, "webhook_id = :webhook_id")
+	}
+	if filter.Status != "" {
+		conds = append(conds, "status = :status")
+	}
+	cond := strings.Join(conds, " AND ")
+
+	params := map[string]interface{}{
+		"owner":      owner,
+		"webhook_id": filter.WebhookID,
+		"status":     filter.Status,
+		"limit":      limit,
+		"offset":     offset,
+	}
+
+	q := fmt.Sprintf(`SELECT id, webhook_id, owner, channel, subtopic, publisher, payload, status, attempts, status_code, error, created
+		  FROM deliveries WHERE %s ORDER BY created DESC LIMIT :limit OFFSET :offset`, cond)
+	rows, err := dr.db.NamedQueryContext(ctx, q, params)
+	if err != nil {
+		if pqErr, ok := err.(*pq.Error); ok && errInvalid == pqErr.Code.Name() {
+			return webhooks.DeliveriesPage{Offset: offset, Limit: limit, Deliveries: []webhooks.Delivery{}}, nil
+		}
+		return webhooks.DeliveriesPage{}, errors.Wrap(errRetrieveDelivery, err)
+	}
+	defer rows.Close()
+
+	items := []webhooks.Delivery{}
+	for rows.Next() {
+		dbd := dbDelivery{}
+		if err := rows.StructScan(&dbd); err != nil {
+			return webhooks.DeliveriesPage{}, errors.Wrap(errRetrieveDelivery, err)
+		}
+		items = append(items, toDelivery(dbd))
+	}
+
+	stmt, err := dr.db.PrepareNamedContext(ctx, fmt.Sprintf(`SELECT COUNT(*) FROM deliveries WHERE %s`, cond))
+	if err != nil {
+		return webhooks.DeliveriesPage{}, errors.Wrap(errRetrieveDelivery, err)
+	}
+	defer stmt.Close()
+
+	var total uint64
+	if err := stmt.GetContext(ctx, &total, params); err != nil {
+		return webhooks.DeliveriesPage{}, errors.Wrap(errRetrieveDelivery, err)
+	}
+
+	return webhooks.DeliveriesPage{
+		Total:      total,
+		Offset:     offset,
+		Limit:      limit,
+		Deliveries: items,
+	}, nil
+}
+
+type dbDelivery struct {
+	ID         string    `db:"id"`
+	WebhookID  string    `db:"webhook_id"`
+	Owner      string    `db:"owner"`
+	Channel    string    `db:"channel"`
+	Subtopic   string    `db:"subtopic"`
+	Publisher  string    `db:"publisher"`
+	Payload    []byte    `db:"payload"`
+	Status     string    `db:"status"`
+	Attempts   uint64    `db:"attempts"`
+	StatusCode int       `db:"status_code"`
+	Error      string    `db:"error"`
+	Created    time.Time `db:"created"`
+}
+
+func toDBDelivery(d webhooks.Delivery) dbDelivery {
+	return dbDelivery{
+		ID:         d.ID,
+		WebhookID:  d.WebhookID,
+		Owner:      d.Owner,
+		Channel:    d.Channel,
+		Subtopic:   d.Subtopic,
+		Publisher:  d.Publisher,
+		Payload:    d.Payload,
+		Status:     d.Status,
+		Attempts:   d.Attempts,
+		StatusCode: d.StatusCode,
+		Error:      d.Error,
+		Created:    d.Created,
+	}
+}
+
+func toDelivery(dbd dbDelivery) webhooks.Delivery {
+	return webhooks.Delivery{
+		ID:         dbd.ID,
+		WebhookID:  dbd.WebhookID,
+		Owner:      dbd.Owner,
+		Channel:    dbd.Channel,
+		Subtopic:   dbd.Subtopic,
+		Publisher:  dbd.Publisher,
+		Payload:    dbd.Payload,
+		Status:     dbd.Status,
+		Attempts:   dbd.Attempts,
+		StatusCode: dbd.StatusCode,
+		Error:      dbd.Error,
+		Created:    dbd.Created,
+	}
+}
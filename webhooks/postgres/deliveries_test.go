@@ -0,0 +1,131 @@
+// Copyright (c) Mainflux
+// SPDX-License-Identifier: Apache-2.0
+
+package postgres_test
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"testing"
+	"time"
+
+	uuidProvider "github.com/mainflux/mainflux/pkg/uuid"
+	"github.com/mainflux/mainflux/webhooks"
+	"github.com/mainflux/mainflux/webhooks/postgres"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestDeliverySaveRetrieveAll(t *testing.T) {
+	repo := postgres.NewDeliveryRepository(db)
+
+	owner := "deliveries@example.com"
+	whIDs := []string{}
+	for i := 0; i < 2; i++ {
+		id, err := uuidProvider.New().ID()
+		require.Nil(t, err, fmt.Sprintf("got unexpected error: %s", err))
+		whIDs = append(whIDs, id)
+	}
+
+	n := uint64(10)
+	start := time.Now().UTC().Truncate(time.Millisecond)
+	for i := uint64(0); i < n; i++ {
+		id, err := uuidProvider.New().ID()
+		require.Nil(t, err, fmt.Sprintf("got unexpected error: %s", err))
+
+		d := webhooks.Delivery{
+			ID:         id,
+			WebhookID:  whIDs[i%2],
+			Owner:      owner,
+			Channel:    "1",
+			Publisher:  "1",
+			Payload:    []byte(`[{"n":"temperature","v":42}]`),
+			Status:     webhooks.StatusDelivered,
+			Attempts:   1,
+			StatusCode: http.StatusOK,
+			Created:    start.Add(time.Duration(i) * time.Second),
+		}
+		if i%5 == 0 {
+			d.Status = webhooks.StatusFailed
+			d.StatusCode = http.StatusInternalServerError
+			d.Error = "webhook responded with unexpected status"
+		}
+		_, err = repo.Save(context.Background(), d)
+		require.Nil(t, err, fmt.Sprintf("got unexpected error: %s", err))
+	}
+
+	cases := map[string]struct {
+		owner  string
+		filter webhooks.DeliveryFilter
+		offset uint64
+		limit  uint64
+		size   uint64
+		total  uint64
+	}{
+		"retrieve all deliveries": {
+			owner:  owner,
+			offset: 0,
+			limit:  n,
+			size:   n,
+			total:  n,
+		},
+		"retrieve subset of deliveries": {
+			owner:  owner,
+			offset: 8,
+			limit:  n,
+			size:   2,
+			total:  n,
+		},
+		"retrieve deliveries to webhook": {
+			owner:  owner,
+			filter: webhooks.DeliveryFilter{WebhookID: whIDs[0]},
+			offset: 0,
+			limit:  n,
+			size:   n / 2,
+			total:  n / 2,
+		},
+		"retrieve failed deliveries": {
+			owner:  owner,
+			filter: webhooks.DeliveryFilter{Status: webhooks.StatusFailed},
+			offset: 0,
+			limit:  n,
+			size:   2,
+			total:  2,
+		},
+		"retrieve failed deliveries to webhook": {
+			owner:  owner,
+			filter: webhooks.DeliveryFilter{WebhookID: whIDs[1], Status: webhooks.StatusFailed},
+			offset: 0,
+			limit:  n,
+			size:   1,
+			total:  1,
+		},
+		"retrieve deliveries with invalid webhook ID": {
+			owner:  owner,
+			filter: webhooks.DeliveryFilter{WebhookID: wrongValue},
+			offset: 0,
+			limit:  n,
+			size:   0,
+			total:  0,
+		},
+		"retrieve deliveries with wrong owner": {
+			owner:  wrongValue,
+			offset: 0,
+			limit:  n,
+			size:   0,
+			total:  0,
+		},
+	}
+
+	for desc, tc := range cases {
+		page, err := repo.RetrieveAll(context.Background(), tc.owner, tc.filter, tc.offset, tc.limit)
+		require.Nil(t, err, fmt.Sprintf("%s: got unexpected error: %s", desc, err))
+		size := uint64(len(page.Deliveries))
+		assert.Equal(t, tc.size, size, fmt.Sprintf("%s: expected size %d got %d\n", desc, tc.size, size))
+		assert.Equal(t, tc.total, page.Total, fmt.Sprintf("%s: expected total %d got %d\n", desc, tc.total, page.Total))
+		for i := 1; i < len(page.Deliveries); i++ {
+			assert.False(t, page.Deliveries[i].Created.After(page.Deliveries[i-1].Created), fmt.Sprintf("%s: expected deliveries sorted from newest", desc))
+		}
+	}
+}
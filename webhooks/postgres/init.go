@@ -0,0 +1,92 @@
+// Copyright (c) Mainflux
+// SPDX-License-Identifier: Apache-2.0
+
+package postgres
+
+import (
+	"fmt"
+
+	"github.com/jmoiron/sqlx"
+	_ "github.com/lib/pq" // required for SQL access
+	migrate "github.com/rubenv/sql-migrate"
+)
+
+// Config defines the options that are used when connecting to a PostgreSQL instance
+type Config struct {
+	Host        string
+	Port        string
+	User        string
+	Pass        string
+	Name        string
+	SSLMode     string
+	SSLCert     string
+	SSLKey      string
+	SSLRootCert string
+}
+
+// Connect creates a connection to the PostgreSQL instance and applies any
+// unapplied database migrations. A non-nil error is returned to indicate
+// failure.
+func Connect(cfg Config) (*sqlx.DB, error) {
+	url := fmt.Sprintf("host=%s port=%s user=%s dbname=%s password=%s sslmode=%s sslcert=%s sslkey=%s sslrootcert=%s", cfg.Host, cfg.Port, cfg.User, cfg.Name, cfg.Pass, cfg.SSLMode, cfg.SSLCert, cfg.SSLKey, cfg.SSLRootCert)
+
+	db, err := sqlx.Open("postgres", url)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := migrateDB(db); err != nil {
+		return nil, err
+	}
+
+	return db, nil
+}
+
+func migrateDB(db *sqlx.DB) error {
+	migrations := &migrate.MemoryMigrationSource{
+		Migrations: []*migrate.Migration{
+			{
+				Id: "webhooks_1",
+				Up: []string{
+					`CREATE TABLE IF NOT EXISTS webhooks (
+						id           UUID,
+						owner        VARCHAR(254) NOT NULL,
+						channel      VARCHAR(254) NOT NULL,
+						url          TEXT NOT NULL,
+						headers      JSONB,
+						content_type VARCHAR(254) NOT NULL,
+						subtopic     VARCHAR(1024),
+						secret       TEXT NOT NULL,
+						created      TIMESTAMPTZ NOT NULL,
+						PRIMARY KEY (id)
+					)`,
+					`CREATE INDEX IF NOT EXISTS webhooks_channel_idx ON webhooks (channel)`,
+					`CREATE TABLE IF NOT EXISTS deliveries (
+						id          UUID,
+						webhook_id  UUID NOT NULL,
+						owner       VARCHAR(254) NOT NULL,
+						channel     VARCHAR(254) NOT NULL,
+						subtopic    VARCHAR(1024),
+						publisher   VARCHAR(254),
+						payload     BYTEA,
+						status      VARCHAR(16) NOT NULL,
+						attempts    BIGINT NOT NULL,
+						status_code INTEGER NOT NULL,
+						error       TEXT,
+						created     TIMESTAMPTZ NOT NULL,
+						PRIMARY KEY (id)
+					)`,
+					`CREATE INDEX IF NOT EXISTS deliveries_owner_created_idx ON deliveries (owner, created DESC)`,
+				},
+				Down: []string{
+					"DROP TABLE deliveries",
+					"DROP TABLE webhooks",
+				},
+			},
+		},
+	}
+
+	_, err := migrate.Exec(db.DB, "postgres", migrations, migrate.Up)
+
+	return err
+}
@@ -0,0 +1,236 @@
+// Copyright (c) Mainflux
+// SPDX-License-Identifier: Apache-2.0
+
+package postgres
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+	"time"
+
+	"github.com/jmoiron/sqlx"
+	"github.com/lib/pq"
+	"github.com/mainflux/mainflux/pkg/errors"
+	"github.com/mainflux/mainflux/webhooks"
+)
+
+const (
+	errDuplicate  = "unique_violation"
+	errInvalid    = "invalid_text_representation"
+	errTruncation = "string_data_right_truncation"
+)
+
+var (
+	errSaveDB    = errors.New("failed to save webhook to database")
+	errUpdateDB  = errors.New("failed to update webhook in database")
+	errRetrieve  = errors.New("failed to retrieve webhook from database")
+	errRemoveDB  = errors.New("failed to remove webhook from database")
+	errMarshal   = errors.New("failed to marshal webhook headers into json")
+	errUnmarshal = errors.New("failed to unmarshal json to webhook headers")
+)
+
+var _ webhooks.WebhookRepository = (*webhookRepository)(nil)
+
+type webhookRepository struct {
+	db *sqlx.DB
+}
+
+// NewWebhookRepository instantiates a PostgreSQL implementation of webhook
+// repository.
+func NewWebhookRepository(db *sqlx.DB) webhooks.WebhookRepository {
+	return &webhookRepository{db: db}
+}
+
+func (wr webhookRepository) Save(ctx context.Context, wh webhooks.Webhook) (string, error) {
+	q := `INSERT INTO webhooks (id, owner, channel, url, headers, content_type, subtopic, secret, created)
+		  VALUES (:id, :owner, :channel, :url, :headers, :content_type, :subtopic, :secret, :created)`
+
+	dbwh, err := toDBWebhook(wh)
+	if err != nil {
+		return "", errors.Wrap(errSaveDB, err)
+	}
+
+	if _, err := wr.db.NamedExecContext(ctx, q, dbwh); err != nil {
+		if pqErr, ok := err.(*pq.Error); ok {
+			switch pqErr.Code.Name() {
+			case errInvalid, errTruncation:
+				return "", errors.Wrap(webhooks.ErrMalformedEntity, err)
+			case errDuplicate:
+				return "", errors.Wrap(webhooks.ErrConflict, err)
+			}
+		}
+		return "", errors.Wrap(errSaveDB, err)
+	}
+
+	return wh.ID, nil
+}
+
+func (wr webhookRepository) Update(ctx context.Context, wh webhooks.Webhook) error {
+	q := `UPDATE webhooks SET channel = :channel, url = :url, headers = :headers, content_type = :content_type,
+		  subtopic = :subtopic, secret = :secret WHERE owner = :owner AND id = :id`
+
+	dbwh, err := toDBWebhook(wh)
+	if err != nil {
+		return errors.Wrap(errUpdateDB, err)
+	}
+
+	res, err := wr.db.NamedExecContext(ctx, q, dbwh)
+	if err != nil {
+		if pqErr, ok := err.(*pq.Error); ok {
+			switch pqErr.Code.Name() {
+			case errInvalid, errTruncation:
+				return errors.Wrap(webhooks.ErrMalformedEntity, err)
+			}
+		}
+		return errors.Wrap(errUpdateDB, err)
+	}
+
+	cnt, err := res.RowsAffected()
+	if err != nil {
+		return errors.Wrap(errUpdateDB, err)
+	}
+	if cnt == 0 {
+		return webhooks.ErrNotFound
+	}
+
+	return nil
+}
+
+func (wr webhookRepository) RetrieveByID(ctx context.Context, owner, id string) (webhooks.Webhook, error) {
+	q := `SELECT id, owner, channel, url, headers, content_type, subtopic, secret, created
+		  FROM webhooks WHERE owner = $1 AND id = $2`
+
+	dbwh := dbWebhook{}
+	if err := wr.db.QueryRowxContext(ctx, q, owner, id).StructScan(&dbwh); err != nil {
+		pqErr, ok := err.(*pq.Error)
+		if err == sql.ErrNoRows || ok && errInvalid == pqErr.Code.Name() {
+			return webhooks.Webhook{}, errors.Wrap(webhooks.ErrNotFound, err)
+		}
+		return webhooks.Webhook{}, errors.Wrap(errRetrieve, err)
+	}
+
+	return toWebhook(dbwh)
+}
+
+func (wr webhookRepository) RetrieveAll(ctx context.Context, owner string, offset, limit uint64) (webhooks.WebhooksPage, error) {
+	q := `SELECT id, owner, channel, url, headers, content_type, subtopic, secret, created
+		  FROM webhooks WHERE owner = $1 ORDER BY id LIMIT $2 OFFSET $3`
+
+	items, err := wr.retrieve(ctx, q, owner, limit, offset)
+	if err != nil {
+		return webhooks.WebhooksPage{}, err
+	}
+
+	var total uint64
+	if err := wr.db.GetContext(ctx, &total, `SELECT COUNT(*) FROM webhooks WHERE owner = $1`, owner); err != nil {
+		return webhooks.WebhooksPage{}, errors.Wrap(errRetrieve, err)
+	}
+
+	return webhooks.WebhooksPage{
+		Total:    total,
+		Offset:   offset,
+		Limit:    limit,
+		Webhooks: items,
+	}, nil
+}
+
+func (wr webhookRepository) RetrieveByChannel(ctx context.Context, channel string) ([]webhooks.Webhook, error) {
+	q := `SELECT id, owner, channel, url, headers, content_type, subtopic, secret, created
+		  FROM webhooks WHERE channel = $1`
+
+	return wr.retrieve(ctx, q, channel)
+}
+
+func (wr webhookRepository) Remove(ctx context.Context, owner, id string) error {
+	q := `DELETE FROM webhooks WHERE owner = $1 AND id = $2`
+
+	if _, err := wr.db.ExecContext(ctx, q, owner, id); err != nil {
+		if pqErr, ok := err.(*pq.Error); ok && errInvalid == pqErr.Code.Name() {
+			return nil
+		}
+		return errors.Wrap(errRemoveDB, err)
+	}
+
+	return nil
+}
+
+func (wr webhookRepository) retrieve(ctx context.Context, q string, args ...interface{}) ([]webhooks.Webhook, error) {
+	rows, err := wr.db.QueryxContext(ctx, q, args...)
+	if err != nil {
+		return nil, errors.Wrap(errRetrieve, err)
+	}
+	defer rows.Close()
+
+	items := []webhooks.Webhook{}
+	for rows.Next() {
+		dbwh := dbWebhook{}
+		if err := rows.StructScan(&dbwh); err != nil {
+			return nil, errors.Wrap(errRetrieve, err)
+		}
+
+		wh, err := toWebhook(dbwh)
+		if err != nil {
+			return nil, err
+		}
+		items = append(items, wh)
+	}
+
+	return items, nil
+}
+
+type dbWebhook struct {
+	ID          string    `db:"id"`
+	Owner       string    `db:"owner"`
+	Channel     string    `db:"channel"`
+	URL         string    `db:"url"`
+	Headers     []byte    `db:"headers"`
+	ContentType string    `db:"content_type"`
+	Subtopic    string    `db:"subtopic"`
+	Secret      string    `db:"secret"`
+	Created     time.Time `db:"created"`
+}
+
+func toDBWebhook(wh webhooks.Webhook) (dbWebhook, error) {
+	headers := []byte("{}")
+	if len(wh.Headers) > 0 {
+		var err error
+		if headers, err = json.Marshal(wh.Headers); err != nil {
+			return dbWebhook{}, errors.Wrap(errMarshal, err)
+		}
+	}
+
+	return dbWebhook{
+		ID:          wh.ID,
+		Owner:       wh.Owner,
+		Channel:     wh.Channel,
+		URL:         wh.URL,
+		Headers:     headers,
+		ContentType: wh.ContentType,
+		Subtopic:    wh.Subtopic,
+		Secret:      wh.Secret,
+		Created:     wh.Created,
+	}, nil
+}
+
+func toWebhook(dbwh dbWebhook) (webhooks.Webhook, error) {
+	var headers map[string]string
+	if err := json.Unmarshal(dbwh.Headers, &headers); err != nil {
+		return webhooks.Webhook{}, errors.Wrap(errUnmarshal, err)
+	}
+	if len(headers) == 0 {
+		headers = nil
+	}
+
+	return webhooks.Webhook{
+		ID:          dbwh.ID,
+		Owner:       dbwh.Owner,
+		Channel:     dbwh.Channel,
+		URL:         dbwh.URL,
+		Headers:     headers,
+		ContentType: dbwh.ContentType,
+		Subtopic:    dbwh.Subtopic,
+		Secret:      dbwh.Secret,
+		Created:     dbwh.Created,
+	}, nil
+}
@@ -0,0 +1,260 @@
+// Copyright (c) Mainflux
+// SPDX-License-Identifier: Apache-2.0
+
+package postgres_test
+
+import (
+	"context"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/mainflux/mainflux/pkg/errors"
+	uuidProvider "github.com/mainflux/mainflux/pkg/uuid"
+	"github.com/mainflux/mainflux/webhooks"
+	"github.com/mainflux/mainflux/webhooks/postgres"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const wrongValue = "wrong-value"
+
+func newWebhook(t *testing.T, owner, channel string) webhooks.Webhook {
+	id, err := uuidProvider.New().ID()
+	require.Nil(t, err, fmt.Sprintf("got unexpected error: %s", err))
+
+	return webhooks.Webhook{
+		ID:          id,
+		Owner:       owner,
+		Channel:     channel,
+		URL:         "https://example.com/hook",
+		Headers:     map[string]string{"Authorization": "Bearer key"},
+		ContentType: webhooks.DefaultContentType,
+		Subtopic:    "sensors.*",
+		Secret:      "secret",
+		Created:     time.Now().UTC().Truncate(time.Millisecond),
+	}
+}
+
+func TestWebhookSave(t *testing.T) {
+	repo := postgres.NewWebhookRepository(db)
+
+	wh := newWebhook(t, "webhook-save@example.com", "1")
+	invalid := wh
+	invalid.ID = wrongValue
+
+	cases := []struct {
+		desc    string
+		webhook webhooks.Webhook
+		err     error
+	}{
+		{
+			desc:    "save new webhook",
+			webhook: wh,
+			err:     nil,
+		},
+		{
+			desc:    "save webhook that already exists",
+			webhook: wh,
+			err:     webhooks.ErrConflict,
+		},
+		{
+			desc:    "save webhook with invalid ID",
+			webhook: invalid,
+			err:     webhooks.ErrMalformedEntity,
+		},
+	}
+
+	for _, tc := range cases {
+		_, err := repo.Save(context.Background(), tc.webhook)
+		assert.True(t, errors.Contains(err, tc.err), fmt.Sprintf("%s: expected %s got %s\n", tc.desc, tc.err, err))
+	}
+}
+
+func TestWebhookRetrieveByID(t *testing.T) {
+	repo := postgres.NewWebhookRepository(db)
+
+	owner := "webhook-retrieve@example.com"
+	wh := newWebhook(t, owner, "1")
+	_, err := repo.Save(context.Background(), wh)
+	require.Nil(t, err, fmt.Sprintf("got unexpected error: %s", err))
+
+	cases := []struct {
+		desc  string
+		owner string
+		id    string
+		err   error
+	}{
+		{
+			desc:  "retrieve existing webhook",
+			owner: owner,
+			id:    wh.ID,
+			err:   nil,
+		},
+		{
+			desc:  "retrieve webhook with wrong owner",
+			owner: wrongValue,
+			id:    wh.ID,
+			err:   webhooks.ErrNotFound,
+		},
+		{
+			desc:  "retrieve webhook with invalid ID",
+			owner: owner,
+			id:    wrongValue,
+			err:   webhooks.ErrNotFound,
+		},
+	}
+
+	for _, tc := range cases {
+		res, err := repo.RetrieveByID(context.Background(), tc.owner, tc.id)
+		assert.True(t, errors.Contains(err, tc.err), fmt.Sprintf("%s: expected %s got %s\n", tc.desc, tc.err, err))
+		if err == nil {
+			assert.Equal(t, wh.Headers, res.Headers, fmt.Sprintf("%s: expected %v got %v\n", tc.desc, wh.Headers, res.Headers))
+			assert.Equal(t, wh.Subtopic, res.Subtopic, fmt.Sprintf("%s: expected %s got %s\n", tc.desc, wh.Subtopic, res.Subtopic))
+		}
+	}
+}
+
+func TestWebhookUpdate(t *testing.T) {
+	repo := postgres.NewWebhookRepository(db)
+
+	owner := "webhook-update@example.com"
+	wh := newWebhook(t, owner, "1")
+	_, err := repo.Save(context.Background(), wh)
+	require.Nil(t, err, fmt.Sprintf("got unexpected error: %s", err))
+
+	updated := wh
+	updated.URL = "https://example.com/updated"
+	updated.Headers = nil
+	foreign := updated
+	foreign.Owner = wrongValue
+	nonexistent := newWebhook(t, owner, "1")
+
+	cases := []struct {
+		desc    string
+		webhook webhooks.Webhook
+		err     error
+	}{
+		{
+			desc:    "update existing webhook",
+			webhook: updated,
+			err:     nil,
+		},
+		{
+			desc:    "update webhook with wrong owner",
+			webhook: foreign,
+			err:     webhooks.ErrNotFound,
+		},
+		{
+			desc:    "update non-existing webhook",
+			webhook: nonexistent,
+			err:     webhooks.ErrNotFound,
+		},
+	}
+
+	for _, tc := range cases {
+		err := repo.Update(context.Background(), tc.webhook)
+		assert.True(t, errors.Contains(err, tc.err), fmt.Sprintf("%s: expected %s got %s\n", tc.desc, tc.err, err))
+	}
+
+	res, err := repo.RetrieveByID(context.Background(), owner, wh.ID)
+	require.Nil(t, err, fmt.Sprintf("got unexpected error: %s", err))
+	assert.Equal(t, updated.URL, res.URL, fmt.Sprintf("expected %s got %s\n", updated.URL, res.URL))
+	assert.Nil(t, res.Headers, fmt.Sprintf("expected no headers got %v\n", res.Headers))
+}
+
+func TestWebhookRetrieveAll(t *testing.T) {
+	repo := postgres.NewWebhookRepository(db)
+
+	owner := "webhook-retrieve-all@example.com"
+	n := uint64(10)
+	for i := uint64(0); i < n; i++ {
+		_, err := repo.Save(context.Background(), newWebhook(t, owner, "1"))
+		require.Nil(t, err, fmt.Sprintf("got unexpected error: %s", err))
+	}
+
+	cases := map[string]struct {
+		owner  string
+		offset uint64
+		limit  uint64
+		size   uint64
+		total  uint64
+	}{
+		"retrieve all webhooks": {
+			owner:  owner,
+			offset: 0,
+			limit:  n,
+			size:   n,
+			total:  n,
+		},
+		"retrieve subset of webhooks": {
+			owner:  owner,
+			offset: n / 2,
+			limit:  n,
+			size:   n / 2,
+			total:  n,
+		},
+		"retrieve webhooks with wrong owner": {
+			owner:  wrongValue,
+			offset: 0,
+			limit:  n,
+			size:   0,
+			total:  0,
+		},
+	}
+
+	for desc, tc := range cases {
+		page, err := repo.RetrieveAll(context.Background(), tc.owner, tc.offset, tc.limit)
+		require.Nil(t, err, fmt.Sprintf("%s: got unexpected error: %s", desc, err))
+		size := uint64(len(page.Webhooks))
+		assert.Equal(t, tc.size, size, fmt.Sprintf("%s: expected size %d got %d\n", desc, tc.size, size))
+		assert.Equal(t, tc.total, page.Total, fmt.Sprintf("%s: expected total %d got %d\n", desc, tc.total, page.Total))
+	}
+}
+
+func TestWebhookRetrieveByChannel(t *testing.T) {
+	repo := postgres.NewWebhookRepository(db)
+
+	channel := "webhook-channel"
+	for i := 0; i < 2; i++ {
+		_, err := repo.Save(context.Background(), newWebhook(t, fmt.Sprintf("webhook-channel-%d@example.com", i), channel))
+		require.Nil(t, err, fmt.Sprintf("got unexpected error: %s", err))
+	}
+
+	cases := map[string]struct {
+		channel string
+		size    int
+	}{
+		"retrieve webhooks subscribed to channel": {
+			channel: channel,
+			size:    2,
+		},
+		"retrieve webhooks subscribed to channel without webhooks": {
+			channel: wrongValue,
+			size:    0,
+		},
+	}
+
+	for desc, tc := range cases {
+		whs, err := repo.RetrieveByChannel(context.Background(), tc.channel)
+		require.Nil(t, err, fmt.Sprintf("%s: got unexpected error: %s", desc, err))
+		assert.Equal(t, tc.size, len(whs), fmt.Sprintf("%s: expected size %d got %d\n", desc, tc.size, len(whs)))
+	}
+}
+
+func TestWebhookRemove(t *testing.T) {
+	repo := postgres.NewWebhookRepository(db)
+
+	owner := "webhook-remove@example.com"
+	wh := newWebhook(t, owner, "1")
+	_, err := repo.Save(context.Background(), wh)
+	require.Nil(t, err, fmt.Sprintf("got unexpected error: %s", err))
+
+	for i := 0; i < 2; i++ {
+		err := repo.Remove(context.Background(), owner, wh.ID)
+		assert.Nil(t, err, fmt.Sprintf("#%d: got unexpected error: %s", i, err))
+	}
+
+	_, err = repo.RetrieveByID(context.Background(), owner, wh.ID)
+	assert.True(t, errors.Contains(err, webhooks.ErrNotFound), fmt.Sprintf("expected %s got %s\n", webhooks.ErrNotFound, err))
+}
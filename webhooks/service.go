@@ -0,0 +1,439 @@
+// Copyright (c) Mainflux
+// SPDX-License-Identifier: Apache-2.0
+
+package webhooks
+
+import (
+	"bytes"
+	"context"
+	"crypto/rand"
+	"encoding/hex"
+	"fmt"
+	"net/http"
+	"strconv"
+	"sync"
+	"time"
+
+	"github.com/mainflux/mainflux"
+	"github.com/mainflux/mainflux/logger"
+	"github.com/mainflux/mainflux/pkg/errors"
+	"github.com/mainflux/mainflux/pkg/messaging"
+	mfsdk "github.com/mainflux/mainflux/pkg/sdk/go"
+)
+
+// Headers describing the origin of the delivered message.
+const (
+	ChannelHeader   = "X-Mainflux-Channel"
+	SubtopicHeader  = "X-Mainflux-Subtopic"
+	PublisherHeader = "X-Mainflux-Publisher"
+)
+
+const secretSize = 32
+
+var (
+	// ErrNotFound indicates a non-existent entity request.
+	ErrNotFound = errors.New("non-existent entity")
+
+	// ErrMalformedEntity indicates malformed entity specification.
+	ErrMalformedEntity = errors.New("malformed entity specification")
+
+	// ErrUnauthorizedAccess indicates missing or invalid credentials provided
+	// when accessing a protected resource.
+	ErrUnauthorizedAccess = errors.New("missing or invalid credentials provided")
+
+	// ErrConflict indicates that entity already exists.
+	ErrConflict = errors.New("entity already exists")
+
+	errDeliver          = errors.New("failed to deliver message to webhook")
+	errQueueFull        = errors.New("webhook delivery queue is full")
+	errUnexpectedStatus = errors.New("webhook responded with unexpected status")
+	errSecret           = errors.New("failed to generate webhook secret")
+)
+
+var _ Service = (*webhooksService)(nil)
+
+// Service specifies an API that must be fulfilled by the domain service
+// implementation, and all of its decorators (e.g. logging & metrics).
+type Service interface {
+	// CreateWebhook adds a webhook to the user identified by the provided
+	// token. If the webhook secret is not set, a random one is generated.
+	CreateWebhook(ctx context.Context, token string, wh Webhook) (Webhook, error)
+
+	// ViewWebhook retrieves data about the webhook identified by the
+	// provided ID, that belongs to the user identified by the provided
+	// token.
+	ViewWebhook(ctx context.Context, token, id string) (Webhook, error)
+
+	// UpdateWebhook updates the webhook identified by the provided ID, that
+	// belongs to the user identified by the provided token. If the webhook
+	// secret is not set, the existing one is kept.
+	UpdateWebhook(ctx context.Context, token string, wh Webhook) error
+
+	// ListWebhooks retrieves data about a subset of webhooks that belong to
+	// the user identified by the provided token.
+	ListWebhooks(ctx context.Context, token string, offset, limit uint64) (WebhooksPage, error)
+
+	// RemoveWebhook removes the webhook identified by the provided ID, that
+	// belongs to the user identified by the provided token.
+	RemoveWebhook(ctx context.Context, token, id string) error
+
+	// ListDeliveries retrieves a subset of deliveries to the webhooks that
+	// belong to the user identified by the provided token.
+	ListDeliveries(ctx context.Context, token string, filter DeliveryFilter, offset, limit uint64) (DeliveriesPage, error)
+
+	// Consume queues the message for delivery to all of the webhooks
+	// subscribed to the message channel and subtopic. Deliveries are
+	// recorded once they complete. If the queue of the webhook is full, the
+	// message is recorded as a failed delivery right away.
+	Consume(msg messaging.Message) error
+}
+
+// RetryPolicy specifies how many times delivery is attempted and how long
+// to wait between the attempts. Backoff is doubled after every failed
+// attempt, up to MaxBackoff if it is set.
+type RetryPolicy struct {
+	Attempts   uint64
+	Backoff    time.Duration
+	MaxBackoff time.Duration
+}
+
+type webhooksService struct {
+	auth       mainflux.AuthNServiceClient
+	webhooks   WebhookRepository
+	deliveries DeliveryRepository
+	sdk        mfsdk.SDK
+	client     *http.Client
+	policy     RetryPolicy
+	idp        mainflux.UUIDProvider
+	logger     logger.Logger
+
+	mu        sync.Mutex
+	queueSize int
+	queues    map[string]chan job
+}
+
+// job is the message waiting for delivery to the webhook.
+type job struct {
+	wh  Webhook
+	msg messaging.Message
+}
+
+// New instantiates the webhooks service implementation. Every webhook gets
+// its own queue of the given size, so a slow or failing webhook does not
+// delay deliveries to the other ones.
+func New(auth mainflux.AuthNServiceClient, webhooks WebhookRepository, deliveries DeliveryRepository, sdk mfsdk.SDK, client *http.Client, policy RetryPolicy, queueSize int, idp mainflux.UUIDProvider, logger logger.Logger) Service {
+	return &webhooksService{
+		auth:       auth,
+		webhooks:   webhooks,
+		deliveries: deliveries,
+		sdk:        sdk,
+		client:     client,
+		policy:     policy,
+		idp:        idp,
+		logger:     logger,
+		queueSize:  queueSize,
+		queues:     make(map[string]chan job),
+	}
+}
+
+func (ws *webhooksService) CreateWebhook(ctx context.Context, token string, wh Webhook) (Webhook, error) {
+	owner, err := ws.identify(ctx, token)
+	if err != nil {
+		return Webhook{}, err
+	}
+
+	if err := ws.checkChannel(token, wh); err != nil {
+		return Webhook{}, err
+	}
+
+	if wh.ID, err = ws.idp.ID(); err != nil {
+		return Webhook{}, err
+	}
+	if wh.Secret == "" {
+		if wh.Secret, err = secret(); err != nil {
+			return Webhook{}, err
+		}
+	}
+	if wh.ContentType == "" {
+		wh.ContentType = DefaultContentType
+	}
+	wh.Owner = owner
+	wh.Created = time.Now()
+
+	if _, err := ws.webhooks.Save(ctx, wh); err != nil {
+		return Webhook{}, err
+	}
+
+	return wh, nil
+}
+
+func (ws *webhooksService) ViewWebhook(ctx context.Context, token, id string) (Webhook, error) {
+	owner, err := ws.identify(ctx, token)
+	if err != nil {
+		return Webhook{}, err
+	}
+
+	return ws.webhooks.RetrieveByID(ctx, owner, id)
+}
+
+func (ws *webhooksService) UpdateWebhook(ctx context.Context, token string, wh Webhook) error {
+	owner, err := ws.identify(ctx, token)
+	if err != nil {
+		return err
+	}
+
+	if err := ws.checkChannel(token, wh); err != nil {
+		return err
+	}
+
+	old, err := ws.webhooks.RetrieveByID(ctx, owner, wh.ID)
+	if err != nil {
+		return err
+	}
+	if wh.Secret == "" {
+		wh.Secret = old.Secret
+	}
+	if wh.ContentType == "" {
+		wh.ContentType = DefaultContentType
+	}
+	wh.Owner = owner
+
+	return ws.webhooks.Update(ctx, wh)
+}
+
+func (ws *webhooksService) ListWebhooks(ctx context.Context, token string, offset, limit uint64) (WebhooksPage, error) {
+	owner, err := ws.identify(ctx, token)
+	if err != nil {
+		return WebhooksPage{}, err
+	}
+
+	return ws.webhooks.RetrieveAll(ctx, owner, offset, limit)
+}
+
+func (ws *webhooksService) RemoveWebhook(ctx context.Context, token, id string) error {
+	owner, err := ws.identify(ctx, token)
+	if err != nil {
+		return err
+	}
+
+	return ws.webhooks.Remove(ctx, owner, id)
+}
+
+func (ws *webhooksService) ListDeliveries(ctx context.Context, token string, filter DeliveryFilter, offset, limit uint64) (DeliveriesPage, error) {
+	owner, err := ws.identify(ctx, token)
+	if err != nil {
+		return DeliveriesPage{}, err
+	}
+
+	return ws.deliveries.RetrieveAll(ctx, owner, filter, offset, limit)
+}
+
+func (ws *webhooksService) Consume(msg messaging.Message) error {
+	ctx := context.Background()
+	whs, err := ws.webhooks.RetrieveByChannel(ctx, msg.Channel)
+	if err != nil {
+		return err
+	}
+
+	var ret error
+	for _, wh := range whs {
+		if !wh.Match(msg.Subtopic) {
+			continue
+		}
+		if ws.enqueue(job{wh: wh, msg: msg}) {
+			continue
+		}
+
+		d := newDelivery(wh, msg)
+		d.Error = errQueueFull.Error()
+		if err := ws.save(ctx, d); err != nil {
+			return err
+		}
+		ret = errors.Wrap(errDeliver, errQueueFull)
+	}
+
+	return ret
+}
+
+// enqueue adds the job to the queue of its webhook, starting the queue
+// worker if needed. It returns false if the queue is full.
+func (ws *webhooksService) enqueue(j job) bool {
+	ws.mu.Lock()
+	defer ws.mu.Unlock()
+
+	q, ok := ws.queues[j.wh.ID]
+	if !ok {
+		q = make(chan job, ws.queueSize)
+		ws.queues[j.wh.ID] = q
+		go ws.work(j.wh.ID, q)
+	}
+
+	select {
+	case q <- j:
+		return true
+	default:
+		return false
+	}
+}
+
+// work delivers the queued jobs one by one, preserving the message order,
+// and stops once the queue is drained.
+func (ws *webhooksService) work(id string, q chan job) {
+	for {
+		select {
+		case j := <-q:
+			d := ws.deliver(j.wh, j.msg)
+			if err := ws.save(context.Background(), d); err != nil {
+				ws.logger.Error(fmt.Sprintf("Failed to save delivery to webhook %s: %s", id, err))
+			}
+		default:
+			ws.mu.Lock()
+			if len(q) > 0 {
+				ws.mu.Unlock()
+				continue
+			}
+			delete(ws.queues, id)
+			ws.mu.Unlock()
+			return
+		}
+	}
+}
+
+func (ws *webhooksService) save(ctx context.Context, d Delivery) error {
+	if d.ID == "" {
+		id, err := ws.idp.ID()
+		if err != nil {
+			return err
+		}
+		d.ID = id
+	}
+
+	_, err := ws.deliveries.Save(ctx, d)
+	return err
+}
+
+// deliver sends the message to the webhook, retrying according to the
+// retry policy, and returns the outcome of the delivery. The delivery ID is
+// generated upfront and sent with every attempt, so the receiver can
+// discard the duplicates.
+func (ws *webhooksService) deliver(wh Webhook, msg messaging.Message) Delivery {
+	d := newDelivery(wh, msg)
+	id, err := ws.idp.ID()
+	if err != nil {
+		d.Error = err.Error()
+		return d
+	}
+	d.ID = id
+
+	attempts := ws.policy.Attempts
+	if attempts == 0 {
+		attempts = 1
+	}
+	backoff := ws.policy.Backoff
+
+	for d.Attempts < attempts {
+		d.Attempts++
+
+		code, err := ws.send(d.ID, wh, msg)
+		d.StatusCode = code
+		if err == nil {
+			d.Status = StatusDelivered
+			d.Error = ""
+			break
+		}
+		d.Error = err.Error()
+
+		if !retriable(code) || d.Attempts == attempts {
+			break
+		}
+		time.Sleep(backoff)
+		backoff *= 2
+		if ws.policy.MaxBackoff > 0 && backoff > ws.policy.MaxBackoff {
+			backoff = ws.policy.MaxBackoff
+		}
+	}
+
+	return d
+}
+
+func (ws *webhooksService) send(id string, wh Webhook, msg messaging.Message) (int, error) {
+	req, err := http.NewRequest(http.MethodPost, wh.URL, bytes.NewReader(msg.Payload))
+	if err != nil {
+		return 0, err
+	}
+
+	for k, v := range wh.Headers {
+		req.Header.Set(k, v)
+	}
+	req.Header.Set("Content-Type", wh.ContentType)
+	ts := strconv.FormatInt(time.Now().Unix(), 10)
+	req.Header.Set(SignatureHeader, Sign(wh.Secret, ts, msg.Payload))
+	req.Header.Set(TimestampHeader, ts)
+	req.Header.Set(DeliveryHeader, id)
+	req.Header.Set(ChannelHeader, msg.Channel)
+	req.Header.Set(PublisherHeader, msg.Publisher)
+	if msg.Subtopic != "" {
+		req.Header.Set(SubtopicHeader, msg.Subtopic)
+	}
+
+	resp, err := ws.client.Do(req)
+	if err != nil {
+		return 0, err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode < http.StatusOK || resp.StatusCode >= http.StatusMultipleChoices {
+		return resp.StatusCode, errors.Wrap(errUnexpectedStatus, errors.New(resp.Status))
+	}
+
+	return resp.StatusCode, nil
+}
+
+func (ws *webhooksService) checkChannel(token string, wh Webhook) error {
+	if err := wh.Validate(); err != nil {
+		return err
+	}
+
+	if _, err := ws.sdk.Channel(wh.Channel, token); err != nil {
+		return errors.Wrap(ErrNotFound, err)
+	}
+
+	return nil
+}
+
+func (ws *webhooksService) identify(ctx context.Context, token string) (string, error) {
+	res, err := ws.auth.Identify(ctx, &mainflux.Token{Value: token})
+	if err != nil {
+		return "", errors.Wrap(ErrUnauthorizedAccess, err)
+	}
+
+	return res.GetEmail(), nil
+}
+
+func newDelivery(wh Webhook, msg messaging.Message) Delivery {
+	return Delivery{
+		WebhookID: wh.ID,
+		Owner:     wh.Owner,
+		Channel:   msg.Channel,
+		Subtopic:  msg.Subtopic,
+		Publisher: msg.Publisher,
+		Payload:   msg.Payload,
+		Status:    StatusFailed,
+		Created:   time.Now(),
+	}
+}
+
+// retriable returns true if delivery failed due to the network error or the
+// response status indicating that the request can be repeated.
+func retriable(code int) bool {
+	return code == 0 || code == http.StatusRequestTimeout || code == http.StatusTooManyRequests || code >= http.StatusInternalServerError
+}
+
+func secret() (string, error) {
+	b := make([]byte, secretSize)
+	if _, err := rand.Read(b); err != nil {
+		return "", errors.Wrap(errSecret, err)
+	}
+
+	return hex.EncodeToString(b), nil
+}
@@ -0,0 +1,686 @@
+// Copyright (c) Mainflux
+// SPDX-License-Identifier: Apache-2.0
+
+package webhooks_test
+
+import (
+	"context"
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/mainflux/mainflux/logger"
+	"github.com/mainflux/mainflux/pkg/errors"
+	"github.com/mainflux/mainflux/pkg/messaging"
+	mfsdk "github.com/mainflux/mainflux/pkg/sdk/go"
+	"github.com/mainflux/mainflux/pkg/uuid"
+	"github.com/mainflux/mainflux/things"
+	httpapi "github.com/mainflux/mainflux/things/api/things/http"
+	thmocks "github.com/mainflux/mainflux/things/mocks"
+	"github.com/mainflux/mainflux/webhooks"
+	"github.com/mainflux/mainflux/webhooks/mocks"
+	"github.com/opentracing/opentracing-go/mocktracer"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const (
+	token      = "token"
+	otherToken = "other-token"
+	wrongValue = "wrong-value"
+	email      = "user@example.com"
+	otherEmail = "other@example.com"
+	attempts   = 3
+	queueSize  = 10
+)
+
+var testLog, _ = logger.New(ioutil.Discard, logger.Error.String())
+
+// receiver is a webhook endpoint that records the received requests and
+// responds with the queued statuses, or 200 OK once the queue is empty.
+type receiver struct {
+	mu       sync.Mutex
+	statuses []int
+	requests []request
+}
+
+type request struct {
+	path   string
+	header http.Header
+	body   []byte
+}
+
+func (r *receiver) ServeHTTP(w http.ResponseWriter, req *http.Request) {
+	body, _ := ioutil.ReadAll(req.Body)
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	r.requests = append(r.requests, request{path: req.URL.Path, header: req.Header, body: body})
+	status := http.StatusOK
+	if len(r.statuses) > 0 {
+		status, r.statuses = r.statuses[0], r.statuses[1:]
+	}
+	w.WriteHeader(status)
+}
+
+func (r *receiver) respond(statuses ...int) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	r.statuses = statuses
+	r.requests = nil
+}
+
+func (r *receiver) received() []request {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	return append([]request{}, r.requests...)
+}
+
+type env struct {
+	svc   webhooks.Service
+	rcv   *receiver
+	url   string
+	chs   []string
+	other string
+}
+
+func newEnv(t *testing.T) env {
+	tokens := map[string]string{token: email, otherToken: otherEmail}
+	auth := mocks.NewAuthNServiceClient(tokens)
+
+	ths := newThingsService(tokens)
+	server := httptest.NewServer(httpapi.MakeHandler(mocktracer.New(), ths))
+	t.Cleanup(server.Close)
+
+	rcv := &receiver{}
+	target := httptest.NewServer(rcv)
+	t.Cleanup(target.Close)
+
+	chs, err := ths.CreateChannels(context.Background(), token, things.Channel{Name: "first"}, things.Channel{Name: "second"})
+	require.Nil(t, err, fmt.Sprintf("unexpected error: %s", err))
+	other, err := ths.CreateChannels(context.Background(), otherToken, things.Channel{Name: "other"})
+	require.Nil(t, err, fmt.Sprintf("unexpected error: %s", err))
+
+	sdk := mfsdk.NewSDK(mfsdk.Config{BaseURL: server.URL})
+	policy := webhooks.RetryPolicy{Attempts: attempts, Backoff: time.Millisecond, MaxBackoff: 2 * time.Millisecond}
+	svc := webhooks.New(
+		auth,
+		mocks.NewWebhookRepository(),
+		mocks.NewDeliveryRepository(),
+		sdk,
+		target.Client(),
+		policy,
+		queueSize,
+		uuid.NewMock(),
+		testLog,
+	)
+
+	return env{
+		svc:   svc,
+		rcv:   rcv,
+		url:   target.URL,
+		chs:   []string{chs[0].ID, chs[1].ID},
+		other: other[0].ID,
+	}
+}
+
+func newThingsService(tokens map[string]string) things.Service {
+	auth := thmocks.NewAuthService(tokens)
+	conns := make(chan thmocks.Connection)
+	thingsRepo := thmocks.NewThingRepository(conns)
+	channelsRepo := thmocks.NewChannelRepository(thingsRepo, conns)
+	groupsRepo := thmocks.NewGroupRepository(thingsRepo, channelsRepo)
+
+	return things.New(auth, thmocks.NewUsersService(map[string]map[string]string{}), thingsRepo, channelsRepo, groupsRepo, thmocks.NewChannelCache(), thmocks.NewThingCache(), uuid.NewMock())
+}
+
+func newWebhook(channel, url string) webhooks.Webhook {
+	return webhooks.Webhook{
+		Channel: channel,
+		URL:     url,
+		Headers: map[string]string{"Authorization": "Bearer key"},
+	}
+}
+
+// waitDeliveries waits until the given number of deliveries to the webhooks
+// of the user is recorded.
+func waitDeliveries(t *testing.T, svc webhooks.Service, filter webhooks.DeliveryFilter, total uint64) {
+	recorded := func() bool {
+		page, err := svc.ListDeliveries(context.Background(), token, filter, 0, 1)
+		return err == nil && page.Total >= total
+	}
+	require.Eventually(t, recorded, time.Second, time.Millisecond, fmt.Sprintf("expected %d deliveries to be recorded", total))
+}
+
+func newMessage(channel, subtopic string) messaging.Message {
+	return messaging.Message{
+		Channel:   channel,
+		Subtopic:  subtopic,
+		Publisher: "publisher",
+		Protocol:  "http",
+		Payload:   []byte(`[{"n":"temperature","u":"Cel","v":42}]`),
+		Created:   time.Now().UnixNano(),
+	}
+}
+
+func TestCreateWebhook(t *testing.T) {
+	e := newEnv(t)
+
+	invalidURL := newWebhook(e.chs[0], "ftp://example.com")
+	invalidSubtopic := newWebhook(e.chs[0], e.url)
+	invalidSubtopic.Subtopic = "sensors.>.temperature"
+	noChannel := newWebhook("", e.url)
+
+	cases := []struct {
+		desc    string
+		token   string
+		webhook webhooks.Webhook
+		err     error
+	}{
+		{
+			desc:    "create valid webhook",
+			token:   token,
+			webhook: newWebhook(e.chs[0], e.url),
+			err:     nil,
+		},
+		{
+			desc:    "create webhook with invalid token",
+			token:   wrongValue,
+			webhook: newWebhook(e.chs[0], e.url),
+			err:     webhooks.ErrUnauthorizedAccess,
+		},
+		{
+			desc:    "create webhook for foreign channel",
+			token:   token,
+			webhook: newWebhook(e.other, e.url),
+			err:     webhooks.ErrNotFound,
+		},
+		{
+			desc:    "create webhook without channel",
+			token:   token,
+			webhook: noChannel,
+			err:     webhooks.ErrMalformedEntity,
+		},
+		{
+			desc:    "create webhook with invalid URL",
+			token:   token,
+			webhook: invalidURL,
+			err:     webhooks.ErrMalformedEntity,
+		},
+		{
+			desc:    "create webhook with invalid subtopic filter",
+			token:   token,
+			webhook: invalidSubtopic,
+			err:     webhooks.ErrMalformedEntity,
+		},
+	}
+
+	for _, tc := range cases {
+		wh, err := e.svc.CreateWebhook(context.Background(), tc.token, tc.webhook)
+		assert.True(t, errors.Contains(err, tc.err), fmt.Sprintf("%s: expected %s got %s\n", tc.desc, tc.err, err))
+		if err == nil {
+			assert.NotEmpty(t, wh.Secret, fmt.Sprintf("%s: expected generated secret", tc.desc))
+			assert.Equal(t, webhooks.DefaultContentType, wh.ContentType, fmt.Sprintf("%s: expected %s got %s\n", tc.desc, webhooks.DefaultContentType, wh.ContentType))
+		}
+	}
+}
+
+func TestViewWebhook(t *testing.T) {
+	e := newEnv(t)
+
+	saved, err := e.svc.CreateWebhook(context.Background(), token, newWebhook(e.chs[0], e.url))
+	require.Nil(t, err, fmt.Sprintf("unexpected error: %s", err))
+
+	cases := []struct {
+		desc  string
+		token string
+		id    string
+		err   error
+	}{
+		{
+			desc:  "view existing webhook",
+			token: token,
+			id:    saved.ID,
+			err:   nil,
+		},
+		{
+			desc:  "view webhook with invalid token",
+			token: wrongValue,
+			id:    saved.ID,
+			err:   webhooks.ErrUnauthorizedAccess,
+		},
+		{
+			desc:  "view webhook of another user",
+			token: otherToken,
+			id:    saved.ID,
+			err:   webhooks.ErrNotFound,
+		},
+		{
+			desc:  "view non-existing webhook",
+			token: token,
+			id:    wrongValue,
+			err:   webhooks.ErrNotFound,
+		},
+	}
+
+	for _, tc := range cases {
+		wh, err := e.svc.ViewWebhook(context.Background(), tc.token, tc.id)
+		assert.True(t, errors.Contains(err, tc.err), fmt.Sprintf("%s: expected %s got %s\n", tc.desc, tc.err, err))
+		if err == nil {
+			assert.Equal(t, saved, wh, fmt.Sprintf("%s: expected %v got %v\n", tc.desc, saved, wh))
+		}
+	}
+}
+
+func TestUpdateWebhook(t *testing.T) {
+	e := newEnv(t)
+
+	saved, err := e.svc.CreateWebhook(context.Background(), token, newWebhook(e.chs[0], e.url))
+	require.Nil(t, err, fmt.Sprintf("unexpected error: %s", err))
+
+	updated := saved
+	updated.Channel = e.chs[1]
+	updated.Subtopic = "sensors.>"
+	updated.Secret = ""
+	foreign := saved
+	foreign.Channel = e.other
+	malformed := saved
+	malformed.URL = ""
+	nonexistent := newWebhook(e.chs[0], e.url)
+	nonexistent.ID = wrongValue
+
+	cases := []struct {
+		desc    string
+		token   string
+		webhook webhooks.Webhook
+		err     error
+	}{
+		{
+			desc:    "update existing webhook",
+			token:   token,
+			webhook: updated,
+			err:     nil,
+		},
+		{
+			desc:    "update webhook with invalid token",
+			token:   wrongValue,
+			webhook: updated,
+			err:     webhooks.ErrUnauthorizedAccess,
+		},
+		{
+			desc:    "update webhook to foreign channel",
+			token:   token,
+			webhook: foreign,
+			err:     webhooks.ErrNotFound,
+		},
+		{
+			desc:    "update webhook with malformed data",
+			token:   token,
+			webhook: malformed,
+			err:     webhooks.ErrMalformedEntity,
+		},
+		{
+			desc:    "update non-existing webhook",
+			token:   token,
+			webhook: nonexistent,
+			err:     webhooks.ErrNotFound,
+		},
+	}
+
+	for _, tc := range cases {
+		err := e.svc.UpdateWebhook(context.Background(), tc.token, tc.webhook)
+		assert.True(t, errors.Contains(err, tc.err), fmt.Sprintf("%s: expected %s got %s\n", tc.desc, tc.err, err))
+	}
+
+	wh, err := e.svc.ViewWebhook(context.Background(), token, saved.ID)
+	require.Nil(t, err, fmt.Sprintf("unexpected error: %s", err))
+	assert.Equal(t, e.chs[1], wh.Channel, fmt.Sprintf("expected channel %s got %s\n", e.chs[1], wh.Channel))
+	assert.Equal(t, saved.Secret, wh.Secret, "expected secret to be kept")
+}
+
+func TestListWebhooks(t *testing.T) {
+	e := newEnv(t)
+
+	n := uint64(10)
+	for i := uint64(0); i < n; i++ {
+		_, err := e.svc.CreateWebhook(context.Background(), token, newWebhook(e.chs[0], e.url))
+		require.Nil(t, err, fmt.Sprintf("unexpected error: %s", err))
+	}
+
+	cases := []struct {
+		desc   string
+		token  string
+		offset uint64
+		limit  uint64
+		size   uint64
+		err    error
+	}{
+		{
+			desc:   "list all webhooks",
+			token:  token,
+			offset: 0,
+			limit:  n,
+			size:   n,
+			err:    nil,
+		},
+		{
+			desc:   "list subset of webhooks",
+			token:  token,
+			offset: n / 2,
+			limit:  n,
+			size:   n / 2,
+			err:    nil,
+		},
+		{
+			desc:   "list webhooks of another user",
+			token:  otherToken,
+			offset: 0,
+			limit:  n,
+			size:   0,
+			err:    nil,
+		},
+		{
+			desc:   "list webhooks with invalid token",
+			token:  wrongValue,
+			offset: 0,
+			limit:  n,
+			size:   0,
+			err:    webhooks.ErrUnauthorizedAccess,
+		},
+	}
+
+	for _, tc := range cases {
+		page, err := e.svc.ListWebhooks(context.Background(), tc.token, tc.offset, tc.limit)
+		size := uint64(len(page.Webhooks))
+		assert.Equal(t, tc.size, size, fmt.Sprintf("%s: expected size %d got %d\n", tc.desc, tc.size, size))
+		assert.True(t, errors.Contains(err, tc.err), fmt.Sprintf("%s: expected %s got %s\n", tc.desc, tc.err, err))
+	}
+}
+
+func TestRemoveWebhook(t *testing.T) {
+	e := newEnv(t)
+
+	saved, err := e.svc.CreateWebhook(context.Background(), token, newWebhook(e.chs[0], e.url))
+	require.Nil(t, err, fmt.Sprintf("unexpected error: %s", err))
+
+	cases := []struct {
+		desc  string
+		token string
+		id    string
+		err   error
+	}{
+		{
+			desc:  "remove webhook with invalid token",
+			token: wrongValue,
+			id:    saved.ID,
+			err:   webhooks.ErrUnauthorizedAccess,
+		},
+		{
+			desc:  "remove existing webhook",
+			token: token,
+			id:    saved.ID,
+			err:   nil,
+		},
+		{
+			desc:  "remove removed webhook",
+			token: token,
+			id:    saved.ID,
+			err:   nil,
+		},
+	}
+
+	for _, tc := range cases {
+		err := e.svc.RemoveWebhook(context.Background(), tc.token, tc.id)
+		assert.True(t, errors.Contains(err, tc.err), fmt.Sprintf("%s: expected %s got %s\n", tc.desc, tc.err, err))
+	}
+
+	err = e.svc.Consume(newMessage(e.chs[0], ""))
+	assert.Nil(t, err, fmt.Sprintf("unexpected error: %s", err))
+	assert.Empty(t, e.rcv.received(), "expected no requests to removed webhook")
+}
+
+func TestConsume(t *testing.T) {
+	e := newEnv(t)
+
+	wh := newWebhook(e.chs[0], e.url+"/all")
+	wh.Secret = "secret"
+	wh.ContentType = "application/json"
+	all, err := e.svc.CreateWebhook(context.Background(), token, wh)
+	require.Nil(t, err, fmt.Sprintf("unexpected error: %s", err))
+
+	wh = newWebhook(e.chs[0], e.url+"/filtered")
+	wh.Subtopic = "sensors.*"
+	_, err = e.svc.CreateWebhook(context.Background(), token, wh)
+	require.Nil(t, err, fmt.Sprintf("unexpected error: %s", err))
+
+	cases := []struct {
+		desc     string
+		msg      messaging.Message
+		statuses []int
+		paths    []string
+		requests int
+		failed   bool
+	}{
+		{
+			desc:     "consume message without subtopic",
+			msg:      newMessage(e.chs[0], ""),
+			paths:    []string{"/all"},
+			requests: 1,
+		},
+		{
+			desc:     "consume message matching subtopic filter",
+			msg:      newMessage(e.chs[0], "sensors.temperature"),
+			paths:    []string{"/all", "/filtered"},
+			requests: 2,
+		},
+		{
+			desc:     "consume message not matching subtopic filter",
+			msg:      newMessage(e.chs[0], "sensors.temperature.raw"),
+			paths:    []string{"/all"},
+			requests: 1,
+		},
+		{
+			desc:     "consume message from channel without webhooks",
+			msg:      newMessage(e.chs[1], ""),
+			requests: 0,
+		},
+		{
+			desc:     "consume message with transient webhook failure",
+			msg:      newMessage(e.chs[0], ""),
+			statuses: []int{http.StatusInternalServerError, http.StatusServiceUnavailable},
+			paths:    []string{"/all"},
+			requests: 3,
+		},
+		{
+			desc:     "consume message with persistent webhook failure",
+			msg:      newMessage(e.chs[0], ""),
+			statuses: []int{http.StatusInternalServerError, http.StatusInternalServerError, http.StatusInternalServerError},
+			paths:    []string{"/all"},
+			requests: attempts,
+			failed:   true,
+		},
+		{
+			desc:     "consume message rejected by webhook",
+			msg:      newMessage(e.chs[0], ""),
+			statuses: []int{http.StatusBadRequest},
+			paths:    []string{"/all"},
+			requests: 1,
+			failed:   true,
+		},
+	}
+
+	total, failed := uint64(0), uint64(0)
+	for _, tc := range cases {
+		e.rcv.respond(tc.statuses...)
+		err := e.svc.Consume(tc.msg)
+		assert.Nil(t, err, fmt.Sprintf("%s: unexpected error: %s\n", tc.desc, err))
+
+		total += uint64(len(tc.paths))
+		waitDeliveries(t, e.svc, webhooks.DeliveryFilter{}, total)
+		page, err := e.svc.ListDeliveries(context.Background(), token, webhooks.DeliveryFilter{Status: webhooks.StatusFailed}, 0, 1)
+		require.Nil(t, err, fmt.Sprintf("%s: unexpected error: %s\n", tc.desc, err))
+		if tc.failed {
+			failed++
+		}
+		assert.Equal(t, failed, page.Total, fmt.Sprintf("%s: expected %d failed deliveries got %d\n", tc.desc, failed, page.Total))
+
+		reqs := e.rcv.received()
+		assert.Len(t, reqs, tc.requests, fmt.Sprintf("%s: expected %d requests got %d\n", tc.desc, tc.requests, len(reqs)))
+		for _, p := range tc.paths {
+			found := false
+			for _, r := range reqs {
+				found = found || r.path == p
+			}
+			assert.True(t, found, fmt.Sprintf("%s: expected request to %s\n", tc.desc, p))
+		}
+
+		deliveries := map[string]string{}
+		for _, r := range reqs {
+			id := r.header.Get(webhooks.DeliveryHeader)
+			assert.NotEmpty(t, id, fmt.Sprintf("%s: expected delivery header", tc.desc))
+			if prev, ok := deliveries[r.path]; ok {
+				assert.Equal(t, prev, id, fmt.Sprintf("%s: expected delivery %s got %s\n", tc.desc, prev, id))
+			}
+			deliveries[r.path] = id
+
+			assert.Equal(t, tc.msg.Payload, r.body, fmt.Sprintf("%s: expected payload %s got %s\n", tc.desc, tc.msg.Payload, r.body))
+			assert.Equal(t, "Bearer key", r.header.Get("Authorization"), fmt.Sprintf("%s: expected custom header", tc.desc))
+			assert.Equal(t, tc.msg.Channel, r.header.Get(webhooks.ChannelHeader), fmt.Sprintf("%s: expected channel header", tc.desc))
+			assert.Equal(t, tc.msg.Subtopic, r.header.Get(webhooks.SubtopicHeader), fmt.Sprintf("%s: expected subtopic header", tc.desc))
+			if r.path == "/all" {
+				assert.Equal(t, "application/json", r.header.Get("Content-Type"), fmt.Sprintf("%s: expected content type", tc.desc))
+				assert.True(t, webhooks.Verify(all.Secret, r.header.Get(webhooks.TimestampHeader), r.body, r.header.Get(webhooks.SignatureHeader)), fmt.Sprintf("%s: expected valid signature", tc.desc))
+			}
+		}
+	}
+}
+
+func TestConsumeFailingWebhook(t *testing.T) {
+	e := newEnv(t)
+
+	started := make(chan struct{}, attempts*(queueSize+2))
+	release := make(chan struct{})
+	slow := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		started <- struct{}{}
+		<-release
+		w.WriteHeader(http.StatusServiceUnavailable)
+	}))
+	t.Cleanup(slow.Close)
+	defer close(release)
+
+	_, err := e.svc.CreateWebhook(context.Background(), token, newWebhook(e.chs[0], slow.URL))
+	require.Nil(t, err, fmt.Sprintf("unexpected error: %s", err))
+	wh := newWebhook(e.chs[0], e.url)
+	wh.Subtopic = "fast"
+	fast, err := e.svc.CreateWebhook(context.Background(), token, wh)
+	require.Nil(t, err, fmt.Sprintf("unexpected error: %s", err))
+
+	err = e.svc.Consume(newMessage(e.chs[0], "fast"))
+	assert.Nil(t, err, fmt.Sprintf("consume message: unexpected error: %s", err))
+	<-started
+
+	waitDeliveries(t, e.svc, webhooks.DeliveryFilter{WebhookID: fast.ID, Status: webhooks.StatusDelivered}, 1)
+	reqs := e.rcv.received()
+	assert.Len(t, reqs, 1, fmt.Sprintf("expected delivery to webhook while other webhook is failing, got %d requests\n", len(reqs)))
+
+	// The first message is being delivered, so the queue of the failing
+	// webhook is full once another queueSize messages arrive.
+	for i := 0; i < queueSize; i++ {
+		err := e.svc.Consume(newMessage(e.chs[0], ""))
+		assert.Nil(t, err, fmt.Sprintf("consume queued message: unexpected error: %s", err))
+	}
+	err = e.svc.Consume(newMessage(e.chs[0], ""))
+	assert.NotNil(t, err, "consume message with full queue: expected error")
+
+	page, err := e.svc.ListDeliveries(context.Background(), token, webhooks.DeliveryFilter{Status: webhooks.StatusFailed}, 0, 10)
+	require.Nil(t, err, fmt.Sprintf("unexpected error: %s", err))
+	require.Len(t, page.Deliveries, 1, fmt.Sprintf("expected 1 failed delivery got %d\n", len(page.Deliveries)))
+	assert.Equal(t, uint64(0), page.Deliveries[0].Attempts, fmt.Sprintf("expected no attempts got %d\n", page.Deliveries[0].Attempts))
+}
+
+func TestListDeliveries(t *testing.T) {
+	e := newEnv(t)
+
+	wh, err := e.svc.CreateWebhook(context.Background(), token, newWebhook(e.chs[0], e.url))
+	require.Nil(t, err, fmt.Sprintf("unexpected error: %s", err))
+
+	delivered := uint64(3)
+	for i := uint64(0); i < delivered; i++ {
+		err := e.svc.Consume(newMessage(e.chs[0], ""))
+		require.Nil(t, err, fmt.Sprintf("unexpected error: %s", err))
+	}
+	waitDeliveries(t, e.svc, webhooks.DeliveryFilter{}, delivered)
+	e.rcv.respond(http.StatusInternalServerError, http.StatusInternalServerError, http.StatusInternalServerError)
+	err = e.svc.Consume(newMessage(e.chs[0], ""))
+	require.Nil(t, err, fmt.Sprintf("unexpected error: %s", err))
+	waitDeliveries(t, e.svc, webhooks.DeliveryFilter{}, delivered+1)
+
+	cases := []struct {
+		desc   string
+		token  string
+		filter webhooks.DeliveryFilter
+		size   uint64
+		err    error
+	}{
+		{
+			desc:  "list all deliveries",
+			token: token,
+			size:  delivered + 1,
+			err:   nil,
+		},
+		{
+			desc:   "list deliveries to webhook",
+			token:  token,
+			filter: webhooks.DeliveryFilter{WebhookID: wh.ID},
+			size:   delivered + 1,
+			err:    nil,
+		},
+		{
+			desc:   "list successful deliveries",
+			token:  token,
+			filter: webhooks.DeliveryFilter{Status: webhooks.StatusDelivered},
+			size:   delivered,
+			err:    nil,
+		},
+		{
+			desc:   "list failed deliveries",
+			token:  token,
+			filter: webhooks.DeliveryFilter{Status: webhooks.StatusFailed},
+			size:   1,
+			err:    nil,
+		},
+		{
+			desc:  "list deliveries of another user",
+			token: otherToken,
+			size:  0,
+			err:   nil,
+		},
+		{
+			desc:  "list deliveries with invalid token",
+			token: wrongValue,
+			size:  0,
+			err:   webhooks.ErrUnauthorizedAccess,
+		},
+	}
+
+	for _, tc := range cases {
+		page, err := e.svc.ListDeliveries(context.Background(), tc.token, tc.filter, 0, 10)
+		size := uint64(len(page.Deliveries))
+		assert.Equal(t, tc.size, size, fmt.Sprintf("%s: expected size %d got %d\n", tc.desc, tc.size, size))
+		assert.True(t, errors.Contains(err, tc.err), fmt.Sprintf("%s: expected %s got %s\n", tc.desc, tc.err, err))
+	}
+
+	page, err := e.svc.ListDeliveries(context.Background(), token, webhooks.DeliveryFilter{Status: webhooks.StatusFailed}, 0, 10)
+	require.Nil(t, err, fmt.Sprintf("unexpected error: %s", err))
+	d := page.Deliveries[0]
+	assert.Equal(t, uint64(attempts), d.Attempts, fmt.Sprintf("expected %d attempts got %d\n", attempts, d.Attempts))
+	assert.Equal(t, http.StatusInternalServerError, d.StatusCode, fmt.Sprintf("expected status code %d got %d\n", http.StatusInternalServerError, d.StatusCode))
+	assert.NotEmpty(t, d.Error, "expected delivery error")
+}
@@ -0,0 +1,58 @@
+// Copyright (c) Mainflux
+// SPDX-License-Identifier: Apache-2.0
+
+package webhooks
+
+import (
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"strconv"
+	"time"
+)
+
+// Headers used to authenticate the delivered message.
+const (
+	// SignatureHeader carries the signature of the delivered message.
+	SignatureHeader = "X-Mainflux-Signature"
+
+	// TimestampHeader carries the Unix time, in seconds, at which the
+	// request was signed.
+	TimestampHeader = "X-Mainflux-Timestamp"
+
+	// DeliveryHeader carries the ID of the delivery. It remains the same
+	// across the retries of the delivery.
+	DeliveryHeader = "X-Mainflux-Delivery"
+)
+
+// Tolerance is the maximal difference between the signature timestamp and
+// the current time accepted by Verify.
+const Tolerance = 5 * time.Minute
+
+const signaturePrefix = "sha256="
+
+// Sign returns the signature of the timestamp and the body, computed as
+// HMAC-SHA256 of the `timestamp.body` string using the webhook secret, in
+// the format set in the SignatureHeader.
+func Sign(secret, timestamp string, body []byte) string {
+	mac := hmac.New(sha256.New, []byte(secret))
+	mac.Write([]byte(timestamp + "."))
+	mac.Write(body)
+	return signaturePrefix + hex.EncodeToString(mac.Sum(nil))
+}
+
+// Verify checks if the signature matches the timestamp and the body signed
+// with the secret, and if the timestamp is within the Tolerance of the
+// current time.
+func Verify(secret, timestamp string, body []byte, signature string) bool {
+	ts, err := strconv.ParseInt(timestamp, 10, 64)
+	if err != nil {
+		return false
+	}
+	diff := time.Since(time.Unix(ts, 0))
+	if diff > Tolerance || diff < -Tolerance {
+		return false
+	}
+
+	return hmac.Equal([]byte(Sign(secret, timestamp, body)), []byte(signature))
+}
@@ -0,0 +1,127 @@
+// Copyright (c) Mainflux
+// SPDX-License-Identifier: Apache-2.0
+
+package webhooks
+
+import (
+	"context"
+	"net/url"
+	"strings"
+	"time"
+)
+
+const (
+	subtopicSeparator = "."
+	singleWildcard    = "*"
+	multiWildcard     = ">"
+)
+
+// DefaultContentType is used for webhooks without content type specified.
+const DefaultContentType = "application/senml+json"
+
+// Webhook represents a subscription forwarding the messages published to
+// the channel to the external HTTP endpoint. Subtopic filters forwarded
+// messages using NATS subject syntax, where "*" matches a single subtopic
+// part and ">" matches all of the remaining parts. Empty subtopic matches
+// all of the messages. Secret is used to sign the delivered messages.
+type Webhook struct {
+	ID          string
+	Owner       string
+	Channel     string
+	URL         string
+	Headers     map[string]string
+	ContentType string
+	Subtopic    string
+	Secret      string
+	Created     time.Time
+}
+
+// WebhooksPage contains page related metadata as well as a list of webhooks
+// that belong to this page.
+type WebhooksPage struct {
+	Total    uint64
+	Offset   uint64
+	Limit    uint64
+	Webhooks []Webhook
+}
+
+// WebhookRepository specifies a webhook persistence API.
+type WebhookRepository interface {
+	// Save persists the webhook.
+	Save(ctx context.Context, wh Webhook) (string, error)
+
+	// Update performs an update to the existing webhook. A non-nil error is
+	// returned to indicate operation failure.
+	Update(ctx context.Context, wh Webhook) error
+
+	// RetrieveByID retrieves the webhook having the provided identifier,
+	// that is owned by the specified user.
+	RetrieveByID(ctx context.Context, owner, id string) (Webhook, error)
+
+	// RetrieveAll retrieves the subset of webhooks owned by the specified
+	// user.
+	RetrieveAll(ctx context.Context, owner string, offset, limit uint64) (WebhooksPage, error)
+
+	// RetrieveByChannel retrieves all the webhooks, regardless of the
+	// owner, subscribed to the channel with the given identifier.
+	RetrieveByChannel(ctx context.Context, channel string) ([]Webhook, error)
+
+	// Remove removes the webhook having the provided identifier, that is
+	// owned by the specified user.
+	Remove(ctx context.Context, owner, id string) error
+}
+
+// Validate returns an error if the webhook is not valid.
+func (wh Webhook) Validate() error {
+	if wh.Channel == "" {
+		return ErrMalformedEntity
+	}
+
+	u, err := url.Parse(wh.URL)
+	if err != nil || (u.Scheme != "http" && u.Scheme != "https") || u.Host == "" {
+		return ErrMalformedEntity
+	}
+
+	if wh.Subtopic == "" {
+		return nil
+	}
+
+	parts := strings.Split(wh.Subtopic, subtopicSeparator)
+	for i, p := range parts {
+		if p == "" {
+			return ErrMalformedEntity
+		}
+		if p == multiWildcard && i != len(parts)-1 {
+			return ErrMalformedEntity
+		}
+	}
+
+	return nil
+}
+
+// Match returns true if the message subtopic passes the webhook filter.
+func (wh Webhook) Match(subtopic string) bool {
+	if wh.Subtopic == "" {
+		return true
+	}
+
+	filter := strings.Split(wh.Subtopic, subtopicSeparator)
+	parts := []string{}
+	if subtopic != "" {
+		parts = strings.Split(subtopic, subtopicSeparator)
+	}
+
+	for i, f := range filter {
+		if f == multiWildcard {
+			return len(parts) > i
+		}
+		if i >= len(parts) {
+			return false
+		}
+		if f != singleWildcard && f != parts[i] {
+			return false
+		}
+	}
+
+	return len(parts) == len(filter)
+}
@@ -0,0 +1,72 @@
+// Copyright (c) Mainflux
+// SPDX-License-Identifier: Apache-2.0
+
+package webhooks_test
+
+import (
+	"fmt"
+	"strconv"
+	"testing"
+	"time"
+
+	"github.com/mainflux/mainflux/webhooks"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestMatch(t *testing.T) {
+	cases := []struct {
+		desc     string
+		filter   string
+		subtopic string
+		match    bool
+	}{
+		{"empty filter matches empty subtopic", "", "", true},
+		{"empty filter matches any subtopic", "", "sensors.temperature", true},
+		{"exact filter matches subtopic", "sensors.temperature", "sensors.temperature", true},
+		{"exact filter does not match other subtopic", "sensors.temperature", "sensors.humidity", false},
+		{"exact filter does not match empty subtopic", "sensors", "", false},
+		{"single wildcard matches single part", "sensors.*", "sensors.temperature", true},
+		{"single wildcard does not match multiple parts", "sensors.*", "sensors.temperature.raw", false},
+		{"single wildcard does not match missing part", "sensors.*", "sensors", false},
+		{"multi wildcard matches multiple parts", "sensors.>", "sensors.temperature.raw", true},
+		{"multi wildcard does not match missing part", "sensors.>", "sensors", false},
+		{"multi wildcard matches any subtopic", ">", "sensors", true},
+	}
+
+	for _, tc := range cases {
+		wh := webhooks.Webhook{Subtopic: tc.filter}
+		match := wh.Match(tc.subtopic)
+		assert.Equal(t, tc.match, match, fmt.Sprintf("%s: expected %t got %t\n", tc.desc, tc.match, match))
+	}
+}
+
+func TestVerify(t *testing.T) {
+	body := []byte(`[{"n":"temperature","v":42}]`)
+	now := strconv.FormatInt(time.Now().Unix(), 10)
+	sig := webhooks.Sign("secret", now, body)
+	expired := strconv.FormatInt(time.Now().Add(-webhooks.Tolerance-time.Minute).Unix(), 10)
+	future := strconv.FormatInt(time.Now().Add(webhooks.Tolerance+time.Minute).Unix(), 10)
+
+	cases := []struct {
+		desc      string
+		secret    string
+		timestamp string
+		body      []byte
+		signature string
+		valid     bool
+	}{
+		{"verify valid signature", "secret", now, body, sig, true},
+		{"verify signature with wrong secret", "wrong", now, body, sig, false},
+		{"verify signature of modified body", "secret", now, []byte("{}"), sig, false},
+		{"verify signature with modified timestamp", "secret", "0", body, sig, false},
+		{"verify malformed signature", "secret", now, body, "malformed", false},
+		{"verify signature with malformed timestamp", "secret", "now", body, webhooks.Sign("secret", "now", body), false},
+		{"verify signature with expired timestamp", "secret", expired, body, webhooks.Sign("secret", expired, body), false},
+		{"verify signature with future timestamp", "secret", future, body, webhooks.Sign("secret", future, body), false},
+	}
+
+	for _, tc := range cases {
+		valid := webhooks.Verify(tc.secret, tc.timestamp, tc.body, tc.signature)
+		assert.Equal(t, tc.valid, valid, fmt.Sprintf("%s: expected %t got %t\n", tc.desc, tc.valid, valid))
+	}
+}
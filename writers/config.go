@@ -0,0 +1,95 @@
+// Copyright (c) Mainflux
+// SPDX-License-Identifier: Apache-2.0
+
+package writers
+
+import (
+	"fmt"
+	"strconv"
+	"time"
+
+	kitprometheus "github.com/go-kit/kit/metrics/prometheus"
+	"github.com/mainflux/mainflux"
+	stdprometheus "github.com/prometheus/client_golang/prometheus"
+)
+
+const (
+	defRetryAttempts   = "3"
+	defRetryBackoff    = "1s"
+	defRetryMaxBackoff = "30s"
+	defRetryMaxElapsed = "20s"
+	defDeadLetterFile  = ""
+
+	envRetryAttempts   = "_RETRY_ATTEMPTS"
+	envRetryBackoff    = "_RETRY_BACKOFF"
+	envRetryMaxBackoff = "_RETRY_MAX_BACKOFF"
+	envRetryMaxElapsed = "_RETRY_MAX_ELAPSED"
+	envDeadLetterFile  = "_DEAD_LETTER_FILE"
+)
+
+// LoadRetryPolicy reads the retry policy from the environment variables
+// starting with the provided prefix, e.g. MF_POSTGRES_WRITER_RETRY_ATTEMPTS
+// for the MF_POSTGRES_WRITER prefix.
+func LoadRetryPolicy(prefix string) (RetryPolicy, error) {
+	attempts, err := strconv.Atoi(mainflux.Env(prefix+envRetryAttempts, defRetryAttempts))
+	if err != nil {
+		return RetryPolicy{}, fmt.Errorf("invalid %s value: %s", prefix+envRetryAttempts, err)
+	}
+
+	backoff, err := time.ParseDuration(mainflux.Env(prefix+envRetryBackoff, defRetryBackoff))
+	if err != nil {
+		return RetryPolicy{}, fmt.Errorf("invalid %s value: %s", prefix+envRetryBackoff, err)
+	}
+
+	maxBackoff, err := time.ParseDuration(mainflux.Env(prefix+envRetryMaxBackoff, defRetryMaxBackoff))
+	if err != nil {
+		return RetryPolicy{}, fmt.Errorf("invalid %s value: %s", prefix+envRetryMaxBackoff, err)
+	}
+
+	maxElapsed, err := time.ParseDuration(mainflux.Env(prefix+envRetryMaxElapsed, defRetryMaxElapsed))
+	if err != nil {
+		return RetryPolicy{}, fmt.Errorf("invalid %s value: %s", prefix+envRetryMaxElapsed, err)
+	}
+
+	return RetryPolicy{
+		Attempts:   attempts,
+		Backoff:    backoff,
+		MaxBackoff: maxBackoff,
+		MaxElapsed: maxElapsed,
+	}, nil
+}
+
+// LoadConfig reads the retry policy and the dead letter file path from the
+// environment variables starting with the provided prefix, and registers
+// the failures counter in the provided metrics namespace. The retry time
+// has to be shorter than the JetStream ack wait, otherwise the message
+// would be delivered again while it is still being retried.
+func LoadConfig(prefix, namespace string, ackWait time.Duration) (Config, error) {
+	retry, err := LoadRetryPolicy(prefix)
+	if err != nil {
+		return Config{}, err
+	}
+	if retry.MaxElapsed <= 0 || retry.MaxElapsed >= ackWait {
+		return Config{}, fmt.Errorf("%s value must be positive and lower than the JetStream ack wait of %s", prefix+envRetryMaxElapsed, ackWait)
+	}
+
+	cfg := Config{
+		Retry: retry,
+		Failures: kitprometheus.NewCounterFrom(stdprometheus.CounterOpts{
+			Namespace: namespace,
+			Subsystem: "message_writer",
+			Name:      "failure_count",
+			Help:      "Number of failures while handling messages.",
+		}, []string{"stage"}),
+	}
+
+	if path := mainflux.Env(prefix+envDeadLetterFile, defDeadLetterFile); path != "" {
+		sink, err := NewFileSink(path)
+		if err != nil {
+			return Config{}, fmt.Errorf("failed to open dead letter file: %s", err)
+		}
+		cfg.DeadLetters = sink
+	}
+
+	return cfg, nil
+}
@@ -0,0 +1,84 @@
+// Copyright (c) Mainflux
+// SPDX-License-Identifier: Apache-2.0
+
+package writers_test
+
+import (
+	"fmt"
+	"os"
+	"testing"
+	"time"
+
+	"github.com/mainflux/mainflux/writers"
+	"github.com/stretchr/testify/assert"
+)
+
+const envPrefix = "MF_TEST_WRITER"
+
+func TestLoadRetryPolicy(t *testing.T) {
+	cases := []struct {
+		desc   string
+		env    map[string]string
+		policy writers.RetryPolicy
+		err    bool
+	}{
+		{
+			desc: "load default retry policy",
+			env:  map[string]string{},
+			policy: writers.RetryPolicy{
+				Attempts:   3,
+				Backoff:    time.Second,
+				MaxBackoff: 30 * time.Second,
+				MaxElapsed: 20 * time.Second,
+			},
+			err: false,
+		},
+		{
+			desc: "load retry policy from environment",
+			env: map[string]string{
+				envPrefix + "_RETRY_ATTEMPTS":    "5",
+				envPrefix + "_RETRY_BACKOFF":     "100ms",
+				envPrefix + "_RETRY_MAX_BACKOFF": "2s",
+				envPrefix + "_RETRY_MAX_ELAPSED": "5s",
+			},
+			policy: writers.RetryPolicy{
+				Attempts:   5,
+				Backoff:    100 * time.Millisecond,
+				MaxBackoff: 2 * time.Second,
+				MaxElapsed: 5 * time.Second,
+			},
+			err: false,
+		},
+		{
+			desc: "load retry policy with invalid attempts",
+			env:  map[string]string{envPrefix + "_RETRY_ATTEMPTS": "many"},
+			err:  true,
+		},
+		{
+			desc: "load retry policy with invalid backoff",
+			env:  map[string]string{envPrefix + "_RETRY_BACKOFF": "second"},
+			err:  true,
+		},
+		{
+			desc: "load retry policy with invalid max elapsed time",
+			env:  map[string]string{envPrefix + "_RETRY_MAX_ELAPSED": "minute"},
+			err:  true,
+		},
+	}
+
+	for _, tc := range cases {
+		for k, v := range tc.env {
+			os.Setenv(k, v)
+		}
+		policy, err := writers.LoadRetryPolicy(envPrefix)
+		for k := range tc.env {
+			os.Unsetenv(k)
+		}
+
+		assert.Equal(t, tc.err, err != nil, fmt.Sprintf("%s: expected error %t got %s\n", tc.desc, tc.err, err))
+		if tc.err {
+			continue
+		}
+		assert.Equal(t, tc.policy, policy, fmt.Sprintf("%s: expected policy %v got %v\n", tc.desc, tc.policy, policy))
+	}
+}
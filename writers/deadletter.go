@@ -0,0 +1,68 @@
+// Copyright (c) Mainflux
+// SPDX-License-Identifier: Apache-2.0
+
+package writers
+
+import (
+	"encoding/json"
+	"os"
+	"sync"
+	"time"
+
+	"github.com/mainflux/mainflux/pkg/messaging"
+)
+
+// DeadLetterSink specifies the API for storing the messages the writer
+// failed to save, so they can be inspected and replayed later.
+type DeadLetterSink interface {
+	// Save stores the message alongside the cause of the failure.
+	Save(msg messaging.Message, cause error) error
+}
+
+// DeadLetter represents the message stored in the dead letter sink.
+type DeadLetter struct {
+	Message messaging.Message `json:"message"`
+	Error   string            `json:"error"`
+	Created time.Time         `json:"created"`
+}
+
+var _ DeadLetterSink = (*fileSink)(nil)
+
+type fileSink struct {
+	mu   sync.Mutex
+	file *os.File
+}
+
+// NewFileSink returns the dead letter sink which appends the messages to the
+// file on the provided path, one JSON encoded DeadLetter per line. The file
+// is created if it doesn't exist.
+func NewFileSink(path string) (DeadLetterSink, error) {
+	f, err := os.OpenFile(path, os.O_APPEND|os.O_CREATE|os.O_WRONLY, 0644)
+	if err != nil {
+		return nil, err
+	}
+
+	return &fileSink{file: f}, nil
+}
+
+func (fs *fileSink) Save(msg messaging.Message, cause error) error {
+	dl := DeadLetter{
+		Message: msg,
+		Error:   cause.Error(),
+		Created: time.Now(),
+	}
+	data, err := json.Marshal(dl)
+	if err != nil {
+		return err
+	}
+	data = append(data, '\n')
+
+	fs.mu.Lock()
+	defer fs.mu.Unlock()
+
+	if _, err := fs.file.Write(data); err != nil {
+		return err
+	}
+
+	return fs.file.Sync()
+}
@@ -0,0 +1,49 @@
+// Copyright (c) Mainflux
+// SPDX-License-Identifier: Apache-2.0
+
+package writers
+
+import (
+	"github.com/mainflux/mainflux/pkg/encryption"
+	"github.com/mainflux/mainflux/pkg/transformers/senml"
+)
+
+var _ MessageRepository = (*encryptionMiddleware)(nil)
+
+type encryptionMiddleware struct {
+	cipher encryption.Cipher
+	repo   MessageRepository
+}
+
+// EncryptionMiddleware encrypts string and data values of the messages using
+// the data key of the message channel before the messages are saved. Other
+// message fields are stored as plaintext, so they can still be queried.
+func EncryptionMiddleware(repo MessageRepository, cipher encryption.Cipher) MessageRepository {
+	return encryptionMiddleware{
+		cipher: cipher,
+		repo:   repo,
+	}
+}
+
+func (em encryptionMiddleware) Save(msgs ...senml.Message) error {
+	encrypted := make([]senml.Message, len(msgs))
+	for i, msg := range msgs {
+		if msg.StringValue != nil {
+			v, err := em.cipher.Encrypt(msg.Channel, *msg.StringValue)
+			if err != nil {
+				return err
+			}
+			msg.StringValue = &v
+		}
+		if msg.DataValue != nil {
+			v, err := em.cipher.Encrypt(msg.Channel, *msg.DataValue)
+			if err != nil {
+				return err
+			}
+			msg.DataValue = &v
+		}
+		encrypted[i] = msg
+	}
+
+	return em.repo.Save(encrypted...)
+}
@@ -0,0 +1,63 @@
+// Copyright (c) Mainflux
+// SPDX-License-Identifier: Apache-2.0
+
+package writers_test
+
+import (
+	"encoding/base64"
+	"fmt"
+	"testing"
+
+	"github.com/mainflux/mainflux/pkg/encryption"
+	"github.com/mainflux/mainflux/pkg/transformers/senml"
+	"github.com/mainflux/mainflux/writers"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+var _ encryption.KeyWrapper = (*keyWrapper)(nil)
+
+// keyWrapper stores the data keys as plaintext, which is enough to verify
+// the encryption of the message values.
+type keyWrapper struct{}
+
+func (kw keyWrapper) Wrap(key []byte) (string, error) {
+	return base64.StdEncoding.EncodeToString(key), nil
+}
+
+func (kw keyWrapper) Unwrap(wrapped string) ([]byte, error) {
+	return base64.StdEncoding.DecodeString(wrapped)
+}
+
+func TestEncryptionMiddleware(t *testing.T) {
+	cipher := encryption.New(keyWrapper{}, 0)
+	repo := &repository{}
+	svc := writers.EncryptionMiddleware(repo, cipher)
+
+	sv := "on"
+	dv := "base64 payload"
+	v := 23.5
+	msgs := []senml.Message{
+		{Channel: chanID, Name: "state", StringValue: &sv},
+		{Channel: chanID, Name: "blob", DataValue: &dv},
+		{Channel: chanID, Name: "temperature", Value: &v},
+	}
+
+	err := svc.Save(msgs...)
+	require.Nil(t, err, fmt.Sprintf("unexpected error: %s", err))
+	require.Len(t, repo.saved, len(msgs), "expected all of the messages to be saved")
+
+	saved := repo.saved
+	assert.Equal(t, sv, *msgs[0].StringValue, "expected original message to be left intact")
+	assert.NotEqual(t, sv, *saved[0].StringValue, "expected string value to be encrypted")
+	assert.NotEqual(t, dv, *saved[1].DataValue, "expected data value to be encrypted")
+	assert.Equal(t, v, *saved[2].Value, "expected numeric value to be stored as plaintext")
+	assert.Equal(t, msgs[0].Name, saved[0].Name, "expected name to be stored as plaintext")
+
+	res, err := cipher.Decrypt(chanID, *saved[0].StringValue)
+	assert.Nil(t, err, fmt.Sprintf("decrypt string value: unexpected error: %s", err))
+	assert.Equal(t, sv, res, fmt.Sprintf("decrypt string value: expected %s got %s", sv, res))
+	res, err = cipher.Decrypt(chanID, *saved[1].DataValue)
+	assert.Nil(t, err, fmt.Sprintf("decrypt data value: unexpected error: %s", err))
+	assert.Equal(t, dv, res, fmt.Sprintf("decrypt data value: expected %s got %s", dv, res))
+}
@@ -6,8 +6,10 @@ package writers
 import (
 	"fmt"
 	"io/ioutil"
+	"time"
 
 	"github.com/BurntSushi/toml"
+	"github.com/go-kit/kit/metrics"
 	"github.com/mainflux/mainflux/logger"
 	"github.com/mainflux/mainflux/pkg/errors"
 	"github.com/mainflux/mainflux/pkg/messaging"
@@ -16,25 +18,68 @@ import (
 	"github.com/mainflux/mainflux/pkg/transformers/senml"
 )
 
+const (
+	stageTransform  = "transform"
+	stageSave       = "save"
+	stageDeadLetter = "dead_letter"
+)
+
 var (
 	errOpenConfFile      = errors.New("unable to open configuration file")
 	errParseConfFile     = errors.New("unable to parse configuration file")
 	errMessageConversion = errors.New("error conversing transformed messages")
+	errTransform         = errors.New("failed to transform message")
+	errSave              = errors.New("failed to save message")
+	errDeadLetter        = errors.New("failed to store dead letter")
 )
 
+// RetryPolicy specifies how many times saving of the message is attempted
+// and how long to wait between the attempts. Backoff is doubled after every
+// failed attempt, up to MaxBackoff if it is set. If MaxElapsed is set, the
+// attempt that would start later than MaxElapsed after the first one is
+// not made, since the subscription is blocked while the message is retried
+// and JetStream redelivers the message not acknowledged within AckWait.
+type RetryPolicy struct {
+	Attempts   int
+	Backoff    time.Duration
+	MaxBackoff time.Duration
+	MaxElapsed time.Duration
+}
+
+// Config represents the consumer error handling configuration.
+type Config struct {
+	// Retry is the policy applied when the message repository fails
+	// to save the message. Messages that can not be transformed are
+	// never retried.
+	Retry RetryPolicy
+
+	// DeadLetters stores the messages that can not be transformed or
+	// saved after all of the attempts. If it is nil, such messages are
+	// dropped and the error is returned to the subscriber.
+	DeadLetters DeadLetterSink
+
+	// Failures counts the failures labeled with the stage of message
+	// handling they occurred in (transform, save or dead_letter).
+	Failures metrics.Counter
+}
+
 type consumer struct {
 	repo        MessageRepository
 	transformer transformers.Transformer
+	cfg         Config
 	logger      logger.Logger
 }
 
 // Start method starts consuming messages received from NATS.
 // This method transforms messages to SenML format before
-// using MessageRepository to store them.
-func Start(sub messaging.Subscriber, repo MessageRepository, transformer transformers.Transformer, queue string, subjectsCfgPath string, logger logger.Logger) error {
+// using MessageRepository to store them. Saving of the messages
+// is retried according to the provided configuration, after which
+// the messages are handed over to the dead letter sink.
+func Start(sub messaging.Subscriber, repo MessageRepository, transformer transformers.Transformer, queue string, subjectsCfgPath string, cfg Config, logger logger.Logger) error {
 	c := consumer{
 		repo:        repo,
 		transformer: transformer,
+		cfg:         cfg,
 		logger:      logger,
 	}
 
@@ -54,14 +99,68 @@ func Start(sub messaging.Subscriber, repo MessageRepository, transformer transfo
 func (c *consumer) handler(msg messaging.Message) error {
 	t, err := c.transformer.Transform(msg)
 	if err != nil {
-		return err
+		c.cfg.Failures.With("stage", stageTransform).Add(1)
+		return c.deadLetter(msg, errors.Wrap(errTransform, err))
 	}
 	msgs, ok := t.([]senml.Message)
 	if !ok {
-		return errMessageConversion
+		c.cfg.Failures.With("stage", stageTransform).Add(1)
+		return c.deadLetter(msg, errMessageConversion)
+	}
+
+	if err := c.save(msgs); err != nil {
+		return c.deadLetter(msg, errors.Wrap(errSave, err))
+	}
+
+	return nil
+}
+
+func (c *consumer) save(msgs []senml.Message) error {
+	attempts := c.cfg.Retry.Attempts
+	if attempts < 1 {
+		attempts = 1
 	}
 
-	return c.repo.Save(msgs...)
+	start := time.Now()
+	backoff := c.cfg.Retry.Backoff
+	for i := 1; ; i++ {
+		err := c.repo.Save(msgs...)
+		if err == nil {
+			return nil
+		}
+		c.cfg.Failures.With("stage", stageSave).Add(1)
+		if i == attempts {
+			return err
+		}
+		if max := c.cfg.Retry.MaxElapsed; max > 0 && time.Since(start)+backoff > max {
+			c.logger.Warn(fmt.Sprintf("Failed to save message (attempt %d of %d), retry time of %s exceeded: %s", i, attempts, max, err))
+			return err
+		}
+
+		c.logger.Warn(fmt.Sprintf("Failed to save message (attempt %d of %d), retrying in %s: %s", i, attempts, backoff, err))
+		time.Sleep(backoff)
+		backoff *= 2
+		if c.cfg.Retry.MaxBackoff > 0 && backoff > c.cfg.Retry.MaxBackoff {
+			backoff = c.cfg.Retry.MaxBackoff
+		}
+	}
+}
+
+// deadLetter hands the message over to the dead letter sink. The error is
+// returned only if the message is not stored in the sink, so the subscriber
+// that supports redelivery can deliver the message again.
+func (c *consumer) deadLetter(msg messaging.Message, cause error) error {
+	if c.cfg.DeadLetters == nil {
+		return cause
+	}
+
+	if err := c.cfg.DeadLetters.Save(msg, cause); err != nil {
+		c.cfg.Failures.With("stage", stageDeadLetter).Add(1)
+		return errors.Wrap(cause, errors.Wrap(errDeadLetter, err))
+	}
+	c.logger.Warn(fmt.Sprintf("Message from channel %s stored as dead letter: %s", msg.Channel, cause))
+
+	return nil
 }
 
 type filterConfig struct {
@@ -0,0 +1,269 @@
+// Copyright (c) Mainflux
+// SPDX-License-Identifier: Apache-2.0
+
+package writers_test
+
+import (
+	"bufio"
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/go-kit/kit/metrics"
+	"github.com/mainflux/mainflux/logger"
+	"github.com/mainflux/mainflux/pkg/errors"
+	"github.com/mainflux/mainflux/pkg/messaging"
+	"github.com/mainflux/mainflux/pkg/transformers/senml"
+	"github.com/mainflux/mainflux/writers"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const (
+	chanID    = "9b7b1b3f-b1b0-46a8-a717-b8213f9eda3b"
+	payload   = `[{"bn":"base-name","n":"temperature","v":23.5}]`
+	malformed = `[{"n":"temperature","v":"not a number"`
+)
+
+var (
+	testLog, _    = logger.New(ioutil.Discard, logger.Error.String())
+	errSave       = errors.New("database unavailable")
+	errDeadLetter = errors.New("dead letter sink unavailable")
+)
+
+func TestConsumer(t *testing.T) {
+	cases := []struct {
+		desc        string
+		payload     string
+		attempts    int
+		maxElapsed  time.Duration
+		failures    int
+		deadLetters bool
+		sinkErr     error
+		saves       int
+		stored      int
+		failed      map[string]float64
+		err         bool
+	}{
+		{
+			desc:     "consume message",
+			payload:  payload,
+			attempts: 3,
+			saves:    1,
+			failed:   map[string]float64{},
+		},
+		{
+			desc:     "consume message after temporary failure",
+			payload:  payload,
+			attempts: 3,
+			failures: 2,
+			saves:    3,
+			failed:   map[string]float64{"save": 2},
+		},
+		{
+			desc:        "consume message failing all attempts with dead letters",
+			payload:     payload,
+			attempts:    3,
+			failures:    3,
+			deadLetters: true,
+			saves:       3,
+			stored:      1,
+			failed:      map[string]float64{"save": 3},
+		},
+		{
+			desc:     "consume message failing all attempts without dead letters",
+			payload:  payload,
+			attempts: 3,
+			failures: 3,
+			saves:    3,
+			failed:   map[string]float64{"save": 3},
+			err:      true,
+		},
+		{
+			desc:        "consume message failing all attempts with unavailable dead letters",
+			payload:     payload,
+			attempts:    2,
+			failures:    2,
+			deadLetters: true,
+			sinkErr:     errDeadLetter,
+			saves:       2,
+			failed:      map[string]float64{"save": 2, "dead_letter": 1},
+			err:         true,
+		},
+		{
+			desc:       "consume message exceeding retry time",
+			payload:    payload,
+			attempts:   3,
+			maxElapsed: time.Microsecond,
+			failures:   3,
+			saves:      1,
+			failed:     map[string]float64{"save": 1},
+			err:        true,
+		},
+		{
+			desc:     "consume message without retry policy",
+			payload:  payload,
+			failures: 1,
+			saves:    1,
+			failed:   map[string]float64{"save": 1},
+			err:      true,
+		},
+		{
+			desc:        "consume malformed message",
+			payload:     malformed,
+			attempts:    3,
+			deadLetters: true,
+			saves:       0,
+			stored:      1,
+			failed:      map[string]float64{"transform": 1},
+		},
+	}
+
+	for _, tc := range cases {
+		sub := newSubscriber()
+		repo := &repository{failures: tc.failures}
+		counter := newCounter()
+		cfg := writers.Config{
+			Retry: writers.RetryPolicy{
+				Attempts:   tc.attempts,
+				Backoff:    time.Millisecond,
+				MaxBackoff: 2 * time.Millisecond,
+				MaxElapsed: tc.maxElapsed,
+			},
+			Failures: counter,
+		}
+		sink := &deadLetters{err: tc.sinkErr}
+		if tc.deadLetters {
+			cfg.DeadLetters = sink
+		}
+
+		err := writers.Start(sub, repo, senml.New(senml.JSON), "writer", "", cfg, testLog)
+		require.Nil(t, err, fmt.Sprintf("%s: unexpected error: %s", tc.desc, err))
+
+		err = sub.publish(messaging.Message{Channel: chanID, Payload: []byte(tc.payload), Created: time.Now().UnixNano()})
+		assert.Equal(t, tc.err, err != nil, fmt.Sprintf("%s: expected error %t got %s", tc.desc, tc.err, err))
+		assert.Equal(t, tc.saves, repo.saves, fmt.Sprintf("%s: expected %d save attempts got %d", tc.desc, tc.saves, repo.saves))
+		assert.Equal(t, tc.stored, len(sink.msgs), fmt.Sprintf("%s: expected %d dead letters got %d", tc.desc, tc.stored, len(sink.msgs)))
+		assert.Equal(t, tc.failed, counter.values, fmt.Sprintf("%s: expected failures %v got %v", tc.desc, tc.failed, counter.values))
+	}
+}
+
+func TestFileSink(t *testing.T) {
+	dir, err := ioutil.TempDir("", "writers")
+	require.Nil(t, err, fmt.Sprintf("unexpected error: %s", err))
+	defer os.RemoveAll(dir)
+	path := filepath.Join(dir, "deadletters.jsonl")
+
+	sink, err := writers.NewFileSink(path)
+	require.Nil(t, err, fmt.Sprintf("unexpected error: %s", err))
+
+	msgs := []messaging.Message{
+		{Channel: chanID, Payload: []byte(payload)},
+		{Channel: chanID, Subtopic: "engine", Payload: []byte(malformed)},
+	}
+	for _, msg := range msgs {
+		err := sink.Save(msg, errSave)
+		assert.Nil(t, err, fmt.Sprintf("unexpected error: %s", err))
+	}
+
+	_, err = writers.NewFileSink(filepath.Join(dir, "missing", "deadletters.jsonl"))
+	assert.NotNil(t, err, "expected error creating sink in non-existent directory")
+
+	f, err := os.Open(path)
+	require.Nil(t, err, fmt.Sprintf("unexpected error: %s", err))
+	defer f.Close()
+
+	var dls []writers.DeadLetter
+	scanner := bufio.NewScanner(f)
+	for scanner.Scan() {
+		var dl writers.DeadLetter
+		err := json.Unmarshal(scanner.Bytes(), &dl)
+		require.Nil(t, err, fmt.Sprintf("unexpected error: %s", err))
+		dls = append(dls, dl)
+	}
+
+	require.Len(t, dls, len(msgs), fmt.Sprintf("expected %d dead letters got %d", len(msgs), len(dls)))
+	for i, dl := range dls {
+		assert.Equal(t, msgs[i], dl.Message, fmt.Sprintf("expected %v got %v", msgs[i], dl.Message))
+		assert.Equal(t, errSave.Error(), dl.Error, fmt.Sprintf("expected error %s got %s", errSave, dl.Error))
+	}
+}
+
+type subscriber struct {
+	handlers []messaging.MessageHandler
+}
+
+func newSubscriber() *subscriber {
+	return &subscriber{}
+}
+
+func (s *subscriber) Subscribe(topic string, handler messaging.MessageHandler) error {
+	s.handlers = append(s.handlers, handler)
+	return nil
+}
+
+func (s *subscriber) Unsubscribe(topic string) error {
+	return nil
+}
+
+func (s *subscriber) publish(msg messaging.Message) error {
+	for _, h := range s.handlers {
+		if err := h(msg); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+type repository struct {
+	failures int
+	saves    int
+	saved    []senml.Message
+}
+
+func (r *repository) Save(msgs ...senml.Message) error {
+	r.saves++
+	if r.saves <= r.failures {
+		return errSave
+	}
+	r.saved = append(r.saved, msgs...)
+	return nil
+}
+
+type deadLetters struct {
+	err  error
+	msgs []messaging.Message
+}
+
+func (dl *deadLetters) Save(msg messaging.Message, cause error) error {
+	if dl.err != nil {
+		return dl.err
+	}
+	dl.msgs = append(dl.msgs, msg)
+	return nil
+}
+
+type counter struct {
+	mu     sync.Mutex
+	values map[string]float64
+	label  string
+}
+
+func newCounter() *counter {
+	return &counter{values: map[string]float64{}}
+}
+
+func (c *counter) With(labelValues ...string) metrics.Counter {
+	return &counter{values: c.values, label: labelValues[len(labelValues)-1]}
+}
+
+func (c *counter) Add(delta float64) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.values[c.label] += delta
+}
@@ -0,0 +1,136 @@
+// Copyright (c) Mainflux
+// SPDX-License-Identifier: Apache-2.0
+
+// Package ws contains the domain concept definitions needed to support
+// Mainflux WebSocket adapter service functionality.
+package ws
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/mainflux/mainflux"
+	"github.com/mainflux/mainflux/pkg/errors"
+	"github.com/mainflux/mainflux/pkg/messaging"
+	"github.com/mainflux/mainflux/things"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+const (
+	chansPrefix    = "channels"
+	singleWildcard = "*"
+	multiWildcard  = ">"
+)
+
+var (
+	// ErrUnauthorizedAccess indicates missing or invalid thing key.
+	ErrUnauthorizedAccess = errors.New("missing or invalid credentials provided")
+
+	// ErrWildcardPublish indicates an attempt to publish to the subtopic
+	// containing wildcards.
+	ErrWildcardPublish = errors.New("publishing to wildcard subtopic is not allowed")
+
+	// ErrSubscription indicates that the subscription to the message
+	// broker failed.
+	ErrSubscription = errors.New("failed to subscribe to channel")
+
+	// ErrAuthUnavailable indicates that the thing key could not be checked
+	// due to things service failure.
+	ErrAuthUnavailable = errors.New("things service unavailable")
+)
+
+// Service specifies WebSocket adapter API.
+type Service interface {
+	// Publish publishes the message on behalf of the thing identified by
+	// the provided key.
+	Publish(ctx context.Context, key string, msg messaging.Message) error
+
+	// Subscribe passes the messages published to the channel and subtopic
+	// to the handler, if the thing identified by the provided key is
+	// allowed to read from the channel. Subtopic may contain "*" and ">"
+	// wildcards.
+	Subscribe(ctx context.Context, key, chanID, subtopic string, handler messaging.MessageHandler) (Subscription, error)
+
+	// Authorize checks that the thing identified by the provided key is
+	// allowed to perform the action on the channel.
+	Authorize(ctx context.Context, key, chanID, action string) error
+}
+
+var _ Service = (*adapterService)(nil)
+
+type adapterService struct {
+	things mainflux.ThingsServiceClient
+	pubsub PubSub
+}
+
+// New instantiates the WebSocket adapter implementation.
+func New(things mainflux.ThingsServiceClient, pubsub PubSub) Service {
+	return &adapterService{
+		things: things,
+		pubsub: pubsub,
+	}
+}
+
+func (as *adapterService) Publish(ctx context.Context, key string, msg messaging.Message) error {
+	if hasWildcard(msg.Subtopic) {
+		return ErrWildcardPublish
+	}
+
+	thid, err := as.authorize(ctx, key, msg.Channel, things.ActionPublish)
+	if err != nil {
+		return err
+	}
+	msg.Publisher = thid
+
+	return as.pubsub.Publish(msg.Channel, msg)
+}
+
+func (as *adapterService) Subscribe(ctx context.Context, key, chanID, subtopic string, handler messaging.MessageHandler) (Subscription, error) {
+	if _, err := as.authorize(ctx, key, chanID, things.ActionSubscribe); err != nil {
+		return nil, err
+	}
+
+	subject := fmt.Sprintf("%s.%s", chansPrefix, chanID)
+	if subtopic != "" {
+		subject = fmt.Sprintf("%s.%s", subject, subtopic)
+	}
+
+	sub, err := as.pubsub.Subscribe(subject, handler)
+	if err != nil {
+		return nil, errors.Wrap(ErrSubscription, err)
+	}
+
+	return sub, nil
+}
+
+func (as *adapterService) Authorize(ctx context.Context, key, chanID, action string) error {
+	_, err := as.authorize(ctx, key, chanID, action)
+	return err
+}
+
+func (as *adapterService) authorize(ctx context.Context, key, chanID, action string) (string, error) {
+	ar := &mainflux.AccessByKeyReq{
+		Token:  key,
+		ChanID: chanID,
+		Action: action,
+	}
+	thid, err := as.things.CanAccessByKey(ctx, ar)
+	if err != nil {
+		if e, ok := status.FromError(err); ok {
+			switch e.Code() {
+			case codes.PermissionDenied, codes.InvalidArgument, codes.NotFound:
+			default:
+				return "", errors.Wrap(ErrAuthUnavailable, err)
+			}
+		}
+		return "", errors.Wrap(ErrUnauthorizedAccess, err)
+	}
+
+	return thid.GetValue(), nil
+}
+
+func hasWildcard(subtopic string) bool {
+	return strings.Contains(subtopic, singleWildcard) || strings.Contains(subtopic, multiWildcard)
+}
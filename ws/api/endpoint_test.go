@@ -0,0 +1,247 @@
+// Copyright (c) Mainflux
+// SPDX-License-Identifier: Apache-2.0
+
+package api_test
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/gorilla/websocket"
+	"github.com/mainflux/mainflux/logger"
+	"github.com/mainflux/mainflux/pkg/messaging"
+	"github.com/mainflux/mainflux/things"
+	"github.com/mainflux/mainflux/ws"
+	"github.com/mainflux/mainflux/ws/api"
+	"github.com/mainflux/mainflux/ws/mocks"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const (
+	chanID       = "1"
+	thingID      = "1"
+	token        = "token"
+	pubToken     = "publisher"
+	subToken     = "subscriber"
+	invalidToken = "invalid"
+	msg          = `[{"n":"current","t":-1,"v":1.6}]`
+	readTimeout  = time.Second
+)
+
+func newHTTPServer() *httptest.Server {
+	data := map[string]string{token: thingID, pubToken: thingID, subToken: thingID}
+	actions := map[string][]string{
+		pubToken: {things.ActionPublish},
+		subToken: {things.ActionSubscribe},
+	}
+	svc := ws.New(mocks.NewThingsClient(data, actions), mocks.NewPubSub())
+	logger, _ := logger.New(&strings.Builder{}, "error")
+	return httptest.NewServer(api.MakeHandler(svc, logger))
+}
+
+func makeURL(ts *httptest.Server, chanID, subtopic, key string) string {
+	u := fmt.Sprintf("ws%s/channels/%s/messages", strings.TrimPrefix(ts.URL, "http"), chanID)
+	if subtopic != "" {
+		u = fmt.Sprintf("%s/%s", u, subtopic)
+	}
+	if key != "" {
+		u = fmt.Sprintf("%s?authorization=%s", u, key)
+	}
+	return u
+}
+
+func dial(ts *httptest.Server, chanID, subtopic, key string) (*websocket.Conn, *http.Response, error) {
+	return websocket.DefaultDialer.Dial(makeURL(ts, chanID, subtopic, key), nil)
+}
+
+func TestHandshake(t *testing.T) {
+	ts := newHTTPServer()
+	defer ts.Close()
+
+	cases := []struct {
+		desc     string
+		url      string
+		header   http.Header
+		status   int
+		upgraded bool
+	}{
+		{
+			desc:     "connect with key in query",
+			url:      makeURL(ts, chanID, "", token),
+			status:   http.StatusSwitchingProtocols,
+			upgraded: true,
+		},
+		{
+			desc:     "connect with key in header",
+			url:      makeURL(ts, chanID, "sensors/temperature", ""),
+			header:   http.Header{"Authorization": []string{token}},
+			status:   http.StatusSwitchingProtocols,
+			upgraded: true,
+		},
+		{
+			desc:     "connect with wildcard subtopic",
+			url:      makeURL(ts, chanID, "sensors/*/temperature/>", token),
+			status:   http.StatusSwitchingProtocols,
+			upgraded: true,
+		},
+		{
+			desc:     "connect with publish only key",
+			url:      makeURL(ts, chanID, "", pubToken),
+			status:   http.StatusSwitchingProtocols,
+			upgraded: true,
+		},
+		{
+			desc:     "connect with subscribe only key",
+			url:      makeURL(ts, chanID, "", subToken),
+			status:   http.StatusSwitchingProtocols,
+			upgraded: true,
+		},
+		{
+			desc:   "connect without key",
+			url:    makeURL(ts, chanID, "", ""),
+			status: http.StatusForbidden,
+		},
+		{
+			desc:   "connect with invalid key",
+			url:    makeURL(ts, chanID, "", invalidToken),
+			status: http.StatusForbidden,
+		},
+		{
+			desc:   "connect with unavailable things service",
+			url:    makeURL(ts, chanID, "", mocks.ServiceErrToken),
+			status: http.StatusServiceUnavailable,
+		},
+		{
+			desc:   "connect with malformed subtopic",
+			url:    makeURL(ts, chanID, "sensors/temp*", token),
+			status: http.StatusBadRequest,
+		},
+	}
+
+	for _, tc := range cases {
+		conn, res, err := websocket.DefaultDialer.Dial(tc.url, tc.header)
+		require.NotNil(t, res, fmt.Sprintf("%s: unexpected error %s", tc.desc, err))
+		assert.Equal(t, tc.status, res.StatusCode, fmt.Sprintf("%s: expected status code %d got %d", tc.desc, tc.status, res.StatusCode))
+		assert.Equal(t, tc.upgraded, err == nil, fmt.Sprintf("%s: expected upgraded %t got error %s", tc.desc, tc.upgraded, err))
+		if conn != nil {
+			conn.Close()
+		}
+	}
+}
+
+func TestPublishSubscribe(t *testing.T) {
+	ts := newHTTPServer()
+	defer ts.Close()
+
+	pub, _, err := dial(ts, chanID, "sensors/temperature", token)
+	require.Nil(t, err, fmt.Sprintf("unexpected error: %s", err))
+	defer pub.Close()
+
+	cases := []struct {
+		desc     string
+		subtopic string
+		received bool
+	}{
+		{
+			desc:     "subscribe to exact subtopic",
+			subtopic: "sensors/temperature",
+			received: true,
+		},
+		{
+			desc:     "subscribe to single wildcard subtopic",
+			subtopic: "sensors/*",
+			received: true,
+		},
+		{
+			desc:     "subscribe to multi wildcard subtopic",
+			subtopic: ">",
+			received: true,
+		},
+		{
+			desc:     "subscribe to other subtopic",
+			subtopic: "sensors/humidity",
+			received: false,
+		},
+		{
+			desc:     "subscribe without subtopic",
+			subtopic: "",
+			received: false,
+		},
+	}
+
+	for _, tc := range cases {
+		sub, _, err := dial(ts, chanID, tc.subtopic, token)
+		require.Nil(t, err, fmt.Sprintf("%s: unexpected error: %s", tc.desc, err))
+
+		err = pub.WriteMessage(websocket.TextMessage, []byte(msg))
+		require.Nil(t, err, fmt.Sprintf("%s: unexpected error: %s", tc.desc, err))
+
+		sub.SetReadDeadline(time.Now().Add(readTimeout))
+		_, data, err := sub.ReadMessage()
+		assert.Equal(t, tc.received, err == nil, fmt.Sprintf("%s: expected received %t got error %s", tc.desc, tc.received, err))
+		if err == nil {
+			var m messaging.Message
+			err := json.Unmarshal(data, &m)
+			assert.Nil(t, err, fmt.Sprintf("%s: unexpected error: %s", tc.desc, err))
+			assert.Equal(t, msg, string(m.Payload), fmt.Sprintf("%s: expected payload %s got %s", tc.desc, msg, m.Payload))
+			assert.Equal(t, chanID, m.Channel, fmt.Sprintf("%s: expected channel %s got %s", tc.desc, chanID, m.Channel))
+			assert.Equal(t, "sensors.temperature", m.Subtopic, fmt.Sprintf("%s: expected subtopic sensors.temperature got %s", tc.desc, m.Subtopic))
+			assert.Equal(t, thingID, m.Publisher, fmt.Sprintf("%s: expected publisher %s got %s", tc.desc, thingID, m.Publisher))
+			assert.Equal(t, "ws", m.Protocol, fmt.Sprintf("%s: expected protocol ws got %s", tc.desc, m.Protocol))
+		}
+		sub.Close()
+	}
+}
+
+func TestPublishToWildcard(t *testing.T) {
+	ts := newHTTPServer()
+	defer ts.Close()
+
+	conn, _, err := dial(ts, chanID, "sensors/*", token)
+	require.Nil(t, err, fmt.Sprintf("unexpected error: %s", err))
+	defer conn.Close()
+
+	err = conn.WriteMessage(websocket.TextMessage, []byte(msg))
+	require.Nil(t, err, fmt.Sprintf("unexpected error: %s", err))
+
+	conn.SetReadDeadline(time.Now().Add(readTimeout))
+	_, _, err = conn.ReadMessage()
+	assert.True(t, websocket.IsCloseError(err, websocket.ClosePolicyViolation), fmt.Sprintf("expected policy violation close error got %s", err))
+}
+
+func TestPublishActions(t *testing.T) {
+	ts := newHTTPServer()
+	defer ts.Close()
+
+	sub, _, err := dial(ts, chanID, "", token)
+	require.Nil(t, err, fmt.Sprintf("unexpected error: %s", err))
+	defer sub.Close()
+
+	pub, _, err := dial(ts, chanID, "", pubToken)
+	require.Nil(t, err, fmt.Sprintf("unexpected error: %s", err))
+	defer pub.Close()
+
+	err = pub.WriteMessage(websocket.TextMessage, []byte(msg))
+	require.Nil(t, err, fmt.Sprintf("unexpected error: %s", err))
+
+	sub.SetReadDeadline(time.Now().Add(readTimeout))
+	_, _, err = sub.ReadMessage()
+	assert.Nil(t, err, fmt.Sprintf("publish only thing: expected message to be received got error %s", err))
+
+	conn, _, err := dial(ts, chanID, "", subToken)
+	require.Nil(t, err, fmt.Sprintf("unexpected error: %s", err))
+	defer conn.Close()
+
+	err = conn.WriteMessage(websocket.TextMessage, []byte(msg))
+	require.Nil(t, err, fmt.Sprintf("unexpected error: %s", err))
+
+	conn.SetReadDeadline(time.Now().Add(readTimeout))
+	_, _, err = conn.ReadMessage()
+	assert.True(t, websocket.IsCloseError(err, websocket.ClosePolicyViolation), fmt.Sprintf("subscribe only thing: expected policy violation close error got %s", err))
+}
@@ -0,0 +1,212 @@
+// Copyright (c) Mainflux
+// SPDX-License-Identifier: Apache-2.0
+
+package api
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"regexp"
+	"time"
+
+	"github.com/go-zoo/bone"
+	"github.com/gorilla/websocket"
+	"github.com/mainflux/mainflux"
+	log "github.com/mainflux/mainflux/logger"
+	"github.com/mainflux/mainflux/pkg/errors"
+	"github.com/mainflux/mainflux/pkg/messaging"
+	"github.com/mainflux/mainflux/things"
+	"github.com/mainflux/mainflux/ws"
+	"github.com/prometheus/client_golang/prometheus/promhttp"
+)
+
+const (
+	protocol   = "ws"
+	authQuery  = "authorization"
+	bufferSize = 100
+)
+
+var (
+	errMalformedData = errors.New("malformed request data")
+	errClosed        = errors.New("connection closed")
+)
+
+var channelPartRegExp = regexp.MustCompile(`^/channels/([\w\-]+)/messages(/[^?]*)?(\?.*)?$`)
+
+var upgrader = websocket.Upgrader{
+	ReadBufferSize:  1024,
+	WriteBufferSize: 1024,
+	// Browser clients are authenticated using thing key, so connections
+	// are accepted regardless of the page origin.
+	CheckOrigin: func(r *http.Request) bool { return true },
+}
+
+var (
+	logger  log.Logger
+	service ws.Service
+)
+
+type connReq struct {
+	key      string
+	chanID   string
+	subtopic string
+}
+
+// MakeHandler returns a HTTP handler for API endpoints.
+func MakeHandler(svc ws.Service, l log.Logger) http.Handler {
+	logger = l
+	service = svc
+
+	r := bone.New()
+	r.GetFunc("/channels/:id/messages", handshake)
+	r.GetFunc("/channels/:id/messages/*", handshake)
+	r.GetFunc("/version", mainflux.Version(protocol))
+	r.Handle("/metrics", promhttp.Handler())
+
+	return r
+}
+
+// handshake subscribes the thing to the channel before upgrading the
+// connection, so the failed authorization is reported with plain HTTP
+// status. The thing allowed only to publish to the channel is connected
+// without the subscription. Once upgraded, messages received from the
+// broker are written to the socket, while the frames sent by the client
+// are published to the channel, checking the publish right of the thing
+// for every frame.
+func handshake(w http.ResponseWriter, r *http.Request) {
+	req, err := decodeRequest(r)
+	if err != nil {
+		encodeError(w, err)
+		return
+	}
+
+	msgs := make(chan messaging.Message, bufferSize)
+	done := make(chan struct{})
+	defer close(done)
+
+	handler := func(msg messaging.Message) error {
+		select {
+		case msgs <- msg:
+			return nil
+		case <-done:
+			return errClosed
+		}
+	}
+
+	sub, err := service.Subscribe(r.Context(), req.key, req.chanID, req.subtopic, handler)
+	switch {
+	case err == nil:
+		defer func() {
+			if err := sub.Unsubscribe(); err != nil {
+				logger.Warn(fmt.Sprintf("Failed to unsubscribe from channel %s: %s", req.chanID, err))
+			}
+		}()
+	case errors.Contains(err, ws.ErrUnauthorizedAccess):
+		// The thing may be connected to the channel for publishing only.
+		if err := service.Authorize(r.Context(), req.key, req.chanID, things.ActionPublish); err != nil {
+			encodeError(w, err)
+			return
+		}
+	default:
+		encodeError(w, err)
+		return
+	}
+
+	conn, err := upgrader.Upgrade(w, r, nil)
+	if err != nil {
+		// Upgrader responds with the appropriate HTTP error.
+		logger.Warn(fmt.Sprintf("Failed to upgrade connection to websocket: %s", err))
+		return
+	}
+	c := ws.NewClient(conn)
+
+	go func() {
+		for {
+			select {
+			case msg := <-msgs:
+				if err := c.Handle(msg); err != nil {
+					logger.Warn(fmt.Sprintf("Failed to send message to client: %s", err))
+				}
+			case <-done:
+				return
+			}
+		}
+	}()
+
+	for {
+		_, payload, err := conn.ReadMessage()
+		if err != nil {
+			if websocket.IsUnexpectedCloseError(err, websocket.CloseNormalClosure, websocket.CloseGoingAway) {
+				logger.Warn(fmt.Sprintf("Failed to read message from client: %s", err))
+			}
+			c.Close(websocket.CloseNormalClosure, "")
+			return
+		}
+
+		msg := messaging.Message{
+			Protocol: protocol,
+			Channel:  req.chanID,
+			Subtopic: req.subtopic,
+			Payload:  payload,
+			Created:  time.Now().UnixNano(),
+		}
+		if err := service.Publish(context.Background(), req.key, msg); err != nil {
+			c.Close(closeCode(err), err.Error())
+			return
+		}
+	}
+}
+
+func decodeRequest(r *http.Request) (connReq, error) {
+	channelParts := channelPartRegExp.FindStringSubmatch(r.RequestURI)
+	if len(channelParts) < 2 {
+		return connReq{}, errMalformedData
+	}
+
+	subtopic, err := messaging.ParseSubtopic(channelParts[2])
+	if err != nil {
+		return connReq{}, err
+	}
+
+	// Browsers can not set headers of the WebSocket handshake request, so
+	// the thing key can be passed using the query parameter as well.
+	key := r.Header.Get("Authorization")
+	if key == "" {
+		key = r.URL.Query().Get(authQuery)
+	}
+	if key == "" {
+		return connReq{}, ws.ErrUnauthorizedAccess
+	}
+
+	req := connReq{
+		key:      key,
+		chanID:   bone.GetValue(r, "id"),
+		subtopic: subtopic,
+	}
+
+	return req, nil
+}
+
+func closeCode(err error) int {
+	switch {
+	case errors.Contains(err, ws.ErrUnauthorizedAccess),
+		errors.Contains(err, ws.ErrWildcardPublish):
+		return websocket.ClosePolicyViolation
+	default:
+		return websocket.CloseInternalServerErr
+	}
+}
+
+func encodeError(w http.ResponseWriter, err error) {
+	switch {
+	case err == errMalformedData, err == messaging.ErrMalformedSubtopic:
+		w.WriteHeader(http.StatusBadRequest)
+	case errors.Contains(err, ws.ErrUnauthorizedAccess):
+		w.WriteHeader(http.StatusForbidden)
+	case errors.Contains(err, ws.ErrAuthUnavailable):
+		w.WriteHeader(http.StatusServiceUnavailable)
+	default:
+		w.WriteHeader(http.StatusInternalServerError)
+	}
+}
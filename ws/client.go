@@ -0,0 +1,55 @@
+// Copyright (c) Mainflux
+// SPDX-License-Identifier: Apache-2.0
+
+package ws
+
+import (
+	"encoding/json"
+	"sync"
+	"time"
+
+	"github.com/gorilla/websocket"
+	"github.com/mainflux/mainflux/pkg/messaging"
+)
+
+const writeWait = 10 * time.Second
+
+// Client wraps the WebSocket connection, serializing the writes to it.
+type Client struct {
+	conn *websocket.Conn
+	mu   sync.Mutex
+}
+
+// NewClient returns a new Client instance.
+func NewClient(conn *websocket.Conn) *Client {
+	return &Client{conn: conn}
+}
+
+// Handle sends the message to the client as a JSON encoded text frame. It
+// implements messaging.MessageHandler, so the client can be passed directly
+// to the subscription.
+func (c *Client) Handle(msg messaging.Message) error {
+	data, err := json.Marshal(msg)
+	if err != nil {
+		return err
+	}
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if err := c.conn.SetWriteDeadline(time.Now().Add(writeWait)); err != nil {
+		return err
+	}
+	return c.conn.WriteMessage(websocket.TextMessage, data)
+}
+
+// Close sends the close frame with the given code and reason and closes
+// the underlying connection.
+func (c *Client) Close(code int, reason string) error {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	msg := websocket.FormatCloseMessage(code, reason)
+	c.conn.WriteControl(websocket.CloseMessage, msg, time.Now().Add(writeWait))
+	return c.conn.Close()
+}
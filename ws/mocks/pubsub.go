@@ -0,0 +1,90 @@
+// Copyright (c) Mainflux
+// SPDX-License-Identifier: Apache-2.0
+
+package mocks
+
+import (
+	"fmt"
+	"strings"
+	"sync"
+
+	"github.com/mainflux/mainflux/pkg/messaging"
+	"github.com/mainflux/mainflux/ws"
+)
+
+const chansPrefix = "channels"
+
+var _ ws.PubSub = (*pubsub)(nil)
+
+type pubsub struct {
+	mu   sync.Mutex
+	subs map[*subscription]bool
+}
+
+type subscription struct {
+	ps      *pubsub
+	subject string
+	handler messaging.MessageHandler
+}
+
+// NewPubSub returns in-memory message broker which supports NATS subject
+// wildcards.
+func NewPubSub() ws.PubSub {
+	return &pubsub{
+		subs: make(map[*subscription]bool),
+	}
+}
+
+func (ps *pubsub) Publish(topic string, msg messaging.Message) error {
+	subject := fmt.Sprintf("%s.%s", chansPrefix, topic)
+	if msg.Subtopic != "" {
+		subject = fmt.Sprintf("%s.%s", subject, msg.Subtopic)
+	}
+
+	ps.mu.Lock()
+	handlers := []messaging.MessageHandler{}
+	for sub := range ps.subs {
+		if match(sub.subject, subject) {
+			handlers = append(handlers, sub.handler)
+		}
+	}
+	ps.mu.Unlock()
+
+	for _, h := range handlers {
+		h(msg)
+	}
+
+	return nil
+}
+
+func (ps *pubsub) Subscribe(subject string, handler messaging.MessageHandler) (ws.Subscription, error) {
+	ps.mu.Lock()
+	defer ps.mu.Unlock()
+
+	sub := &subscription{ps: ps, subject: subject, handler: handler}
+	ps.subs[sub] = true
+	return sub, nil
+}
+
+func (s *subscription) Unsubscribe() error {
+	s.ps.mu.Lock()
+	defer s.ps.mu.Unlock()
+
+	delete(s.ps.subs, s)
+	return nil
+}
+
+func match(filter, subject string) bool {
+	fs := strings.Split(filter, ".")
+	ss := strings.Split(subject, ".")
+	for i, f := range fs {
+		if f == ">" {
+			return len(ss) > i
+		}
+		if i >= len(ss) || (f != "*" && f != ss[i]) {
+			return false
+		}
+	}
+
+	return len(fs) == len(ss)
+}
@@ -0,0 +1,83 @@
+// Copyright (c) Mainflux
+// SPDX-License-Identifier: Apache-2.0
+
+package mocks
+
+import (
+	"context"
+
+	"github.com/golang/protobuf/ptypes/empty"
+	"github.com/mainflux/mainflux"
+	"github.com/mainflux/mainflux/things"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+var _ mainflux.ThingsServiceClient = (*thingsClient)(nil)
+
+// ServiceErrToken is used to simulate internal server error.
+const ServiceErrToken = "unavailable"
+
+type thingsClient struct {
+	things  map[string]string
+	actions map[string][]string
+}
+
+// NewThingsClient returns mock implementation of things service client.
+// Things whose key is not present in the actions map are allowed to
+// perform all of the actions.
+func NewThingsClient(data map[string]string, actions map[string][]string) mainflux.ThingsServiceClient {
+	return &thingsClient{things: data, actions: actions}
+}
+
+func (tc thingsClient) CanAccessByKey(ctx context.Context, req *mainflux.AccessByKeyReq, opts ...grpc.CallOption) (*mainflux.ThingID, error) {
+	key := req.GetToken()
+
+	// Since there is no appropriate way to simulate internal server error,
+	// we had to use this obscure approach. ErrorToken simulates gRPC
+	// call which returns internal server error.
+	if key == ServiceErrToken {
+		return nil, status.Error(codes.Internal, "internal server error")
+	}
+
+	if key == "" {
+		return nil, things.ErrUnauthorizedAccess
+	}
+
+	id, ok := tc.things[key]
+	if !ok {
+		return nil, status.Error(codes.PermissionDenied, "invalid credentials provided")
+	}
+
+	if actions, ok := tc.actions[key]; ok && !contains(actions, req.GetAction()) {
+		return nil, status.Error(codes.PermissionDenied, "action not allowed")
+	}
+
+	return &mainflux.ThingID{Value: id}, nil
+}
+
+func (tc thingsClient) CanAccessByID(context.Context, *mainflux.AccessByIDReq, ...grpc.CallOption) (*empty.Empty, error) {
+	panic("not implemented")
+}
+
+func (tc thingsClient) Identify(ctx context.Context, req *mainflux.Token, opts ...grpc.CallOption) (*mainflux.ThingID, error) {
+	panic("not implemented")
+}
+
+func (tc thingsClient) IsChannelOwner(context.Context, *mainflux.ChannelOwnerReq, ...grpc.CallOption) (*empty.Empty, error) {
+	panic("not implemented")
+}
+
+func (tc thingsClient) ChannelSchema(context.Context, *mainflux.ChannelID, ...grpc.CallOption) (*mainflux.ChannelSchemaRes, error) {
+	panic("not implemented")
+}
+
+func contains(actions []string, action string) bool {
+	for _, a := range actions {
+		if a == action {
+			return true
+		}
+	}
+	return false
+}
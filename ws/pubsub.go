@@ -0,0 +1,71 @@
+// Copyright (c) Mainflux
+// SPDX-License-Identifier: Apache-2.0
+
+package ws
+
+import (
+	"fmt"
+
+	"github.com/gogo/protobuf/proto"
+	log "github.com/mainflux/mainflux/logger"
+	"github.com/mainflux/mainflux/pkg/messaging"
+	broker "github.com/nats-io/nats.go"
+)
+
+// Subscription represents a single subscription to the message broker.
+type Subscription interface {
+	// Unsubscribe stops passing messages to the subscription handler.
+	Unsubscribe() error
+}
+
+// PubSub specifies the message broker API used by the adapter. Unlike
+// messaging.Subscriber, it allows any number of independent subscriptions
+// to the same subject, one for every connected WebSocket client.
+type PubSub interface {
+	messaging.Publisher
+
+	// Subscribe passes the messages published to the subject to the handler.
+	Subscribe(subject string, handler messaging.MessageHandler) (Subscription, error)
+}
+
+var _ PubSub = (*natsPubSub)(nil)
+
+type natsPubSub struct {
+	conn   *broker.Conn
+	logger log.Logger
+}
+
+// NewPubSub returns NATS implementation of the adapter message broker API.
+func NewPubSub(conn *broker.Conn, logger log.Logger) PubSub {
+	return &natsPubSub{
+		conn:   conn,
+		logger: logger,
+	}
+}
+
+func (ps *natsPubSub) Publish(topic string, msg messaging.Message) error {
+	data, err := proto.Marshal(&msg)
+	if err != nil {
+		return err
+	}
+
+	subject := fmt.Sprintf("%s.%s", chansPrefix, topic)
+	if msg.Subtopic != "" {
+		subject = fmt.Sprintf("%s.%s", subject, msg.Subtopic)
+	}
+
+	return ps.conn.Publish(subject, data)
+}
+
+func (ps *natsPubSub) Subscribe(subject string, handler messaging.MessageHandler) (Subscription, error) {
+	return ps.conn.Subscribe(subject, func(m *broker.Msg) {
+		var msg messaging.Message
+		if err := proto.Unmarshal(m.Data, &msg); err != nil {
+			ps.logger.Warn(fmt.Sprintf("Failed to unmarshal received message: %s", err))
+			return
+		}
+		if err := handler(msg); err != nil {
+			ps.logger.Warn(fmt.Sprintf("Failed to handle Mainflux message: %s", err))
+		}
+	})
+}